@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	flagutils "github.com/temirov/gix/internal/utils/flags"
+)
+
+const (
+	aliasesCommandUseNameConstant              = "aliases"
+	aliasesCommandShortDescriptionConstant     = "Configured command aliases"
+	aliasesListCommandUseNameConstant          = "list"
+	aliasesListCommandAliasConstant            = "ls"
+	aliasesListCommandShortDescriptionConstant = "List configured command aliases and their expansions"
+	aliasesListCommandLongDescriptionConstant  = "aliases list prints every alias defined in the aliases section of the configuration together with the arguments it expands to."
+	aliasesListLineTemplateConstant            = "%s\t%s\n"
+	aliasesListEmptyMessageConstant            = "no aliases configured\n"
+	aliasShortDescriptionTemplateConstant      = "Alias for %s"
+	aliasNameInvalidTemplateConstant           = "alias %q must be a single word that does not start with '-'"
+	aliasExpansionEmptyTemplateConstant        = "alias %q must expand to at least one argument"
+	aliasExpandsToAliasTemplateConstant        = "alias %q expands to alias %q; aliases cannot reference other aliases"
+	aliasConflictBuiltInTemplateConstant       = "alias %q conflicts with the built-in %q command"
+	aliasConflictCommandAliasTemplateConstant  = "alias %q conflicts with an alias of the built-in %q command"
+	aliasArgumentSeparatorConstant             = " "
+	helpCommandNameConstant                    = "help"
+	completionCommandNameConstant              = "completion"
+	helpFlagShorthandConstant                  = "h"
+)
+
+var reservedAliasCommandNames = []string{helpCommandNameConstant, completionCommandNameConstant, cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd}
+
+// AliasConflictError reports a configured alias whose name is already taken by a built-in command or one of its aliases.
+type AliasConflictError struct {
+	AliasName   string
+	CommandName string
+	BuiltIn     bool
+}
+
+func (errorDetails AliasConflictError) Error() string {
+	if errorDetails.BuiltIn {
+		return fmt.Sprintf(aliasConflictBuiltInTemplateConstant, errorDetails.AliasName, errorDetails.CommandName)
+	}
+	return fmt.Sprintf(aliasConflictCommandAliasTemplateConstant, errorDetails.AliasName, errorDetails.CommandName)
+}
+
+type aliasDefinition struct {
+	Name      string
+	Expansion []string
+}
+
+// registerConfiguredAliases adds a hidden command for every alias in the configuration. Configuration files are read
+// here, before Cobra resolves the command line, so the aliases can be dispatched; load failures are left to
+// initializeConfiguration, which reports them once the command runs.
+func (application *Application) registerConfiguredAliases(arguments []string) error {
+	definitions, definitionsError := newAliasDefinitions(application.rootCommand, application.loadConfiguredAliases(arguments))
+	if definitionsError != nil {
+		return definitionsError
+	}
+
+	for _, definition := range definitions {
+		application.rootCommand.AddCommand(application.newAliasCommand(definition))
+	}
+	application.aliasDefinitions = definitions
+	return nil
+}
+
+func (application *Application) loadConfiguredAliases(arguments []string) map[string][]string {
+	startupFlags := pflag.NewFlagSet(applicationNameConstant, pflag.ContinueOnError)
+	startupFlags.ParseErrorsAllowlist.UnknownFlags = true
+	startupFlags.SetOutput(io.Discard)
+	configurationFilePath := startupFlags.String(configFileFlagNameConstant, "", configFileFlagUsageConstant)
+	noConfiguration := startupFlags.Bool(noConfigurationFlagNameConstant, false, noConfigurationFlagUsageConstant)
+	startupFlags.BoolP(helpCommandNameConstant, helpFlagShorthandConstant, false, "")
+	if parseError := startupFlags.Parse(arguments); parseError != nil {
+		return nil
+	}
+
+	configurationFilesSkipped := application.noConfigurationRequestedByEnvironment()
+	if startupFlags.Changed(noConfigurationFlagNameConstant) {
+		configurationFilesSkipped = *noConfiguration
+	}
+	if configurationFilesSkipped && len(strings.TrimSpace(*configurationFilePath)) > 0 {
+		return nil
+	}
+	if !configurationFilesSkipped {
+		application.configurationLoader.SetSearchPaths(application.resolveConfigurationSearchPaths())
+	}
+	application.configurationLoader.SetConfigurationFilesSkipped(configurationFilesSkipped)
+
+	var startupConfiguration ApplicationConfiguration
+	if _, loadError := application.configurationLoader.LoadConfiguration(*configurationFilePath, nil, &startupConfiguration); loadError != nil {
+		return nil
+	}
+	return startupConfiguration.Aliases
+}
+
+// newAliasDefinitions validates configured aliases against the built-in commands and sorts them by name.
+func newAliasDefinitions(rootCommand *cobra.Command, aliases map[string][]string) ([]aliasDefinition, error) {
+	aliasNames := make([]string, 0, len(aliases))
+	for aliasName := range aliases {
+		aliasNames = append(aliasNames, aliasName)
+	}
+	sort.Strings(aliasNames)
+
+	definitions := make([]aliasDefinition, 0, len(aliasNames))
+	for _, aliasName := range aliasNames {
+		trimmedName := strings.TrimSpace(aliasName)
+		if len(trimmedName) == 0 || trimmedName != aliasName || strings.ContainsAny(trimmedName, " \t\n") || strings.HasPrefix(trimmedName, "-") {
+			return nil, fmt.Errorf(aliasNameInvalidTemplateConstant, aliasName)
+		}
+		expansion := aliases[aliasName]
+		if len(expansion) == 0 || len(strings.TrimSpace(expansion[0])) == 0 {
+			return nil, fmt.Errorf(aliasExpansionEmptyTemplateConstant, aliasName)
+		}
+		if conflictError := aliasConflict(rootCommand, trimmedName); conflictError != nil {
+			return nil, conflictError
+		}
+		if _, expandsToAlias := aliases[expansion[0]]; expandsToAlias {
+			return nil, fmt.Errorf(aliasExpandsToAliasTemplateConstant, aliasName, expansion[0])
+		}
+		definitions = append(definitions, aliasDefinition{Name: trimmedName, Expansion: append([]string{}, expansion...)})
+	}
+	return definitions, nil
+}
+
+func aliasConflict(rootCommand *cobra.Command, aliasName string) error {
+	for _, reservedName := range reservedAliasCommandNames {
+		if aliasName == reservedName {
+			return AliasConflictError{AliasName: aliasName, CommandName: reservedName, BuiltIn: true}
+		}
+	}
+	if rootCommand == nil {
+		return nil
+	}
+	for _, command := range rootCommand.Commands() {
+		if command.Name() == aliasName {
+			return AliasConflictError{AliasName: aliasName, CommandName: command.Name(), BuiltIn: true}
+		}
+		if command.HasAlias(aliasName) {
+			return AliasConflictError{AliasName: aliasName, CommandName: command.Name()}
+		}
+	}
+	return nil
+}
+
+// newAliasCommand builds the hidden command that re-runs gix with the alias expansion ahead of the extra arguments.
+// Flag parsing and configuration loading are left to the dispatched command.
+func (application *Application) newAliasCommand(definition aliasDefinition) *cobra.Command {
+	return &cobra.Command{
+		Use:                definition.Name,
+		Short:              fmt.Sprintf(aliasShortDescriptionTemplateConstant, formatAliasExpansion(definition.Expansion)),
+		Hidden:             true,
+		DisableFlagParsing: true,
+		SilenceUsage:       true,
+		SilenceErrors:      true,
+		PersistentPreRunE: func(command *cobra.Command, arguments []string) error {
+			return nil
+		},
+		RunE: func(command *cobra.Command, arguments []string) error {
+			expandedArguments := append(append([]string{}, definition.Expansion...), arguments...)
+			expandedArguments = flagutils.NormalizeToggleArguments(expandedArguments)
+			application.rootCommand.SetArgs(normalizeInitializationScopeArguments(expandedArguments))
+			return application.rootCommand.Execute()
+		},
+	}
+}
+
+func (application *Application) newAliasesCommand() *cobra.Command {
+	aliasesCommand := newNamespaceCommand(aliasesCommandUseNameConstant, aliasesCommandShortDescriptionConstant)
+	listCommand := &cobra.Command{
+		Use:           aliasesListCommandUseNameConstant,
+		Aliases:       []string{aliasesListCommandAliasConstant},
+		Short:         aliasesListCommandShortDescriptionConstant,
+		Long:          aliasesListCommandLongDescriptionConstant,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(command *cobra.Command, arguments []string) error {
+			writeAliasDefinitions(command.OutOrStdout(), application.aliasDefinitions)
+			return nil
+		},
+	}
+	aliasesCommand.AddCommand(listCommand)
+	return aliasesCommand
+}
+
+func writeAliasDefinitions(writer io.Writer, definitions []aliasDefinition) {
+	if len(definitions) == 0 {
+		fmt.Fprint(writer, aliasesListEmptyMessageConstant)
+		return
+	}
+	for _, definition := range definitions {
+		fmt.Fprintf(writer, aliasesListLineTemplateConstant, definition.Name, formatAliasExpansion(definition.Expansion))
+	}
+}
+
+func formatAliasExpansion(expansion []string) string {
+	formattedArguments := make([]string, 0, len(expansion))
+	for _, argument := range expansion {
+		if len(argument) == 0 || strings.ContainsAny(argument, " \t\n\"'") {
+			argument = strconv.Quote(argument)
+		}
+		formattedArguments = append(formattedArguments, argument)
+	}
+	return strings.Join(formattedArguments, aliasArgumentSeparatorConstant)
+}
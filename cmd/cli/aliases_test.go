@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func newAliasTestRootCommand(capturedArguments *[]string) *cobra.Command {
+	rootCommand := &cobra.Command{Use: "root", SilenceUsage: true, SilenceErrors: true}
+	repoCommand := &cobra.Command{Use: "repo", Aliases: []string{"r"}}
+	deleteCommand := &cobra.Command{
+		Use: "delete",
+		RunE: func(command *cobra.Command, arguments []string) error {
+			dryRun, _ := command.Flags().GetBool("dry-run")
+			*capturedArguments = append([]string{command.CommandPath()}, arguments...)
+			if dryRun {
+				*capturedArguments = append(*capturedArguments, "dry-run")
+			}
+			return nil
+		},
+	}
+	deleteCommand.Flags().Bool("dry-run", false, "")
+	repoCommand.AddCommand(deleteCommand)
+	rootCommand.AddCommand(repoCommand)
+	return rootCommand
+}
+
+func TestNewAliasDefinitions(t *testing.T) {
+	testCases := []struct {
+		name             string
+		aliases          map[string][]string
+		expectedNames    []string
+		expectedConflict *AliasConflictError
+		expectError      bool
+	}{
+		{name: "sorted_definitions", aliases: map[string][]string{"pp": {"repo", "delete"}, "dd": {"repo", "delete", "--dry-run"}}, expectedNames: []string{"dd", "pp"}},
+		{name: "built_in_command_conflict", aliases: map[string][]string{"repo": {"repo", "delete"}}, expectedConflict: &AliasConflictError{AliasName: "repo", CommandName: "repo", BuiltIn: true}},
+		{name: "built_in_alias_conflict", aliases: map[string][]string{"r": {"repo", "delete"}}, expectedConflict: &AliasConflictError{AliasName: "r", CommandName: "repo"}},
+		{name: "reserved_help_conflict", aliases: map[string][]string{"help": {"repo"}}, expectedConflict: &AliasConflictError{AliasName: "help", CommandName: "help", BuiltIn: true}},
+		{name: "empty_expansion_rejected", aliases: map[string][]string{"pp": {}}, expectError: true},
+		{name: "flag_name_rejected", aliases: map[string][]string{"-p": {"repo"}}, expectError: true},
+		{name: "alias_chain_rejected", aliases: map[string][]string{"pp": {"dd"}, "dd": {"repo"}}, expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var capturedArguments []string
+			definitions, definitionsError := newAliasDefinitions(newAliasTestRootCommand(&capturedArguments), testCase.aliases)
+			if testCase.expectedConflict != nil {
+				var conflictError AliasConflictError
+				require.True(t, errors.As(definitionsError, &conflictError))
+				require.Equal(t, *testCase.expectedConflict, conflictError)
+				return
+			}
+			if testCase.expectError {
+				require.Error(t, definitionsError)
+				return
+			}
+			require.NoError(t, definitionsError)
+			names := make([]string, 0, len(definitions))
+			for _, definition := range definitions {
+				names = append(names, definition.Name)
+			}
+			require.Equal(t, testCase.expectedNames, names)
+		})
+	}
+}
+
+func TestAliasCommandDispatch(t *testing.T) {
+	testCases := []struct {
+		name              string
+		arguments         []string
+		expectedArguments []string
+	}{
+		{name: "expansion_only", arguments: []string{"pp"}, expectedArguments: []string{"root repo delete", "dry-run"}},
+		{name: "extras_appended", arguments: []string{"pp", "/tmp/projects"}, expectedArguments: []string{"root repo delete", "/tmp/projects", "dry-run"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var capturedArguments []string
+			rootCommand := newAliasTestRootCommand(&capturedArguments)
+			application := &Application{rootCommand: rootCommand}
+			definitions, definitionsError := newAliasDefinitions(rootCommand, map[string][]string{"pp": {"repo", "delete", "--dry-run"}})
+			require.NoError(t, definitionsError)
+			for _, definition := range definitions {
+				rootCommand.AddCommand(application.newAliasCommand(definition))
+			}
+
+			rootCommand.SetArgs(testCase.arguments)
+			require.NoError(t, rootCommand.Execute())
+			require.Equal(t, testCase.expectedArguments, capturedArguments)
+		})
+	}
+}
+
+func TestWriteAliasDefinitions(t *testing.T) {
+	testCases := []struct {
+		name           string
+		definitions    []aliasDefinition
+		expectedOutput string
+	}{
+		{name: "no_aliases", expectedOutput: "no aliases configured\n"},
+		{
+			name: "expansions_listed",
+			definitions: []aliasDefinition{
+				{Name: "pp", Expansion: []string{"repo", "prs", "delete", "--dry-run"}},
+				{Name: "msg", Expansion: []string{"b", "commit", "message", "--roots", "my projects"}},
+			},
+			expectedOutput: "pp\trepo prs delete --dry-run\nmsg\tb commit message --roots \"my projects\"\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var output bytes.Buffer
+			writeAliasDefinitions(&output, testCase.definitions)
+			require.Equal(t, testCase.expectedOutput, output.String())
+		})
+	}
+}
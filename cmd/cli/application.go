@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	mapstructure "github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/cobra"
@@ -25,6 +28,8 @@ import (
 	"github.com/temirov/gix/internal/branches"
 	branchcdcmd "github.com/temirov/gix/internal/branches/cd"
 	branchrefresh "github.com/temirov/gix/internal/branches/refresh"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/migrate"
 	migratecli "github.com/temirov/gix/internal/migrate/cli"
 	"github.com/temirov/gix/internal/packages"
@@ -33,6 +38,7 @@ import (
 	"github.com/temirov/gix/internal/repos/shared"
 	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
+	rootutils "github.com/temirov/gix/internal/utils/roots"
 	"github.com/temirov/gix/internal/version"
 )
 
@@ -42,10 +48,14 @@ const (
 	applicationLongDescriptionConstant                               = "gix ships reusable helpers that integrate Git, GitHub CLI, and related tooling."
 	configFileFlagNameConstant                                       = "config"
 	configFileFlagUsageConstant                                      = "Optional path to a configuration file (YAML or JSON)."
+	noConfigurationFlagNameConstant                                  = "no-config"
+	noConfigurationFlagUsageConstant                                 = "Skip configuration file discovery and use embedded defaults, environment variables, and flags only (also GIX_NO_CONFIG=1)."
+	noConfigurationEnvironmentVariableConstant                       = "GIX_NO_CONFIG"
+	noConfigurationConflictErrorMessageConstant                      = "--config cannot be combined with --no-config"
 	logLevelFlagNameConstant                                         = "log-level"
 	logLevelFlagUsageConstant                                        = "Override the configured log level."
 	logFormatFlagNameConstant                                        = "log-format"
-	logFormatFlagUsageConstant                                       = "Override the configured log format (structured or console)."
+	logFormatFlagUsageConstant                                       = "Override the configured log format (structured, json, or console)."
 	configurationInitializationFlagNameConstant                      = "init"
 	configurationInitializationFlagUsageConstant                     = "Write the embedded default configuration to LOCAL (./config.yaml) or user ($XDG_CONFIG_HOME/gix/config.yaml, falling back to $HOME/.gix/config.yaml)."
 	configurationInitializationDefaultScopeConstant                  = "local"
@@ -65,12 +75,23 @@ const (
 	configurationInitializationDirectoryConflictTemplateConstant     = "configuration directory path %s is not a directory"
 	configurationInitializationWriteErrorTemplateConstant            = "unable to write configuration file %s: %w"
 	configurationInitializationSuccessMessageConstant                = "configuration file created"
+	configurationInitializationInteractiveFlagNameConstant           = "interactive"
+	configurationInitializationInteractiveFlagUsageConstant          = "Prompt for roots, log format, default remote, and dry-run before writing the configuration requested with --init."
+	configurationInitializationNonInteractiveMessageConstant         = "standard input is not a terminal; writing the default configuration without prompts"
 	commonConfigurationKeyConstant                                   = "common"
 	commonLogLevelConfigKeyConstant                                  = commonConfigurationKeyConstant + ".log_level"
 	commonLogFormatConfigKeyConstant                                 = commonConfigurationKeyConstant + ".log_format"
 	commonDryRunConfigKeyConstant                                    = commonConfigurationKeyConstant + ".dry_run"
 	commonAssumeYesConfigKeyConstant                                 = commonConfigurationKeyConstant + ".assume_yes"
 	commonRequireCleanConfigKeyConstant                              = commonConfigurationKeyConstant + ".require_clean"
+	commonCommandLogConfigKeyConstant                                = commonConfigurationKeyConstant + ".command_log"
+	commonRunHooksConfigKeyConstant                                  = commonConfigurationKeyConstant + ".run_hooks"
+	commonOutputCaptureLimitConfigKeyConstant                        = commonConfigurationKeyConstant + ".output_capture_limit"
+	commandLogFlagNameConstant                                       = "command-log"
+	commandLogFlagUsageConstant                                      = "Append a JSON line with redacted details for every external command to the provided file."
+	operationVariantFlagNameConstant                                 = "variant"
+	operationVariantFlagUsageConstant                                = "Use the named variant of the command's operation configuration instead of the default entry."
+	commandLogCloseErrorTemplateConstant                             = "unable to close command log: %w"
 	environmentPrefixConstant                                        = "GIX"
 	configurationNameConstant                                        = "config"
 	configurationTypeConstant                                        = "yaml"
@@ -86,6 +107,7 @@ const (
 	loggerCreationErrorTemplateConstant                              = "unable to create logger: %w"
 	loggerSyncErrorTemplateConstant                                  = "unable to flush logger: %w"
 	configurationInitializedConsoleTemplateConstant                  = "%s | log level=%s | log format=%s | config file=%s"
+	configurationInitializedNoFileConsoleTemplateConstant            = "%s | log level=%s | log format=%s"
 	rootCommandInfoMessageConstant                                   = "gix CLI executed"
 	rootCommandDebugMessageConstant                                  = "gix CLI diagnostics"
 	logFieldCommandNameConstant                                      = "command_name"
@@ -101,12 +123,15 @@ const (
 	reposRenameOperationNameConstant                                 = "repo-folders-rename"
 	reposRemotesOperationNameConstant                                = "repo-remote-update"
 	reposProtocolOperationNameConstant                               = "repo-protocol-convert"
+	reposListOperationNameConstant                                   = "repo-list"
+	reposExecOperationNameConstant                                   = "repo-exec"
 	repoReleaseOperationNameConstant                                 = "repo-release"
 	repoHistoryOperationNameConstant                                 = "repo-history-remove"
 	repoFilesReplaceOperationNameConstant                            = "repo-files-replace"
 	workflowCommandOperationNameConstant                             = "workflow"
 	branchRefreshOperationNameConstant                               = "branch-refresh"
 	branchDefaultOperationNameConstant                               = "branch-default"
+	branchDefaultOverridesOptionKeyConstant                          = "overrides"
 	branchChangeOperationNameConstant                                = "branch-cd"
 	commitMessageOperationNameConstant                               = "commit-message"
 	changelogMessageOperationNameConstant                            = "changelog-message"
@@ -133,6 +158,8 @@ const (
 	repoPackagesNamespaceShortDescriptionConstant                    = "GitHub Packages maintenance commands"
 	packagesDeleteCommandUseNameConstant                             = "delete"
 	packagesDeleteCommandAliasConstant                               = "prune"
+	packagesReportCommandUseNameConstant                             = "report"
+	packagesReportLongDescriptionConstant                            = "repo packages report lists every container package of an owner with its version count, tagged/untagged split, total size, and oldest and newest version dates, largest first. It never deletes anything."
 	repoFilesNamespaceUseNameConstant                                = "files"
 	repoFilesNamespaceAliasConstant                                  = "f"
 	repoFilesNamespaceShortDescriptionConstant                       = "Repository file commands"
@@ -143,6 +170,12 @@ const (
 	repoReleaseCommandUsageTemplateConstant                          = repoReleaseCommandUseNameConstant + " <tag>"
 	repoReleaseCommandAliasConstant                                  = "rel"
 	repoReleaseCommandLongDescriptionConstant                        = "repo release annotates the provided tag (default message 'Release <tag>') and pushes it to the configured remote. Provide the tag as the first argument before any optional repository roots or flags."
+	listCommandUseNameConstant                                       = "list"
+	listCommandAliasConstant                                         = "ls"
+	listCommandLongDescriptionConstant                               = "repo list prints each discovered repository's path, origin URL, owner/repo, current branch, and dirty flag using local Git state only."
+	execCommandUseNameConstant                                       = "exec"
+	execCommandUsageSuffixConstant                                   = " -- <command> [arguments...]"
+	execCommandLongDescriptionConstant                               = "repo exec runs the command given after -- in every discovered repository, prefixing each output line with the repository path. It honors --jobs, --fail-fast, and --dry-run, and --format json reports each repository's exit code and duration."
 	removeCommandUseNameConstant                                     = "rm"
 	removeCommandAliasConstant                                       = "purge"
 	removeCommandShortDescriptionConstant                            = "Rewrite history to delete selected paths"
@@ -177,12 +210,14 @@ const (
 	updateRemoteCanonicalLongDescriptionConstant                     = "repo remote update-to-canonical adjusts origin remotes to match canonical GitHub repositories."
 	updateProtocolLongDescriptionConstant                            = "repo remote update-protocol converts origin URLs to a desired protocol."
 	prsDeleteLongDescriptionConstant                                 = "repo prs delete removes remote and local Git branches whose pull requests are already closed."
-	packagesDeleteLongDescriptionConstant                            = "repo packages delete removes untagged container versions from GitHub Packages."
+	packagesDeleteLongDescriptionConstant                            = "repo packages delete removes untagged container versions from GitHub Packages, or the whole package with --entire-package."
 	branchDefaultNestedLongDescriptionConstant                       = "branch default promotes a branch to the repository default, auto-detecting the current default branch before retargeting workflows and safety gates."
 	branchRefreshNestedLongDescriptionConstant                       = "branch refresh synchronizes repository branches by fetching, checking out, and pulling updates."
 	versionFlagNameConstant                                          = "version"
 	versionFlagUsageConstant                                         = "Print the application version and exit"
 	versionOutputTemplateConstant                                    = "gix version: %s\n"
+	versionEventFieldNameConstant                                    = "version"
+	rootArgumentsUseSuffixConstant                                   = " " + flagutils.RootArgumentsUsage
 	versionCommandUseNameConstant                                    = "version"
 	versionCommandShortDescriptionConstant                           = "Print the gix version"
 	versionCommandLongDescriptionConstant                            = "version prints the current gix release identifier."
@@ -191,6 +226,14 @@ const (
 	operationErrorLogFieldConstant                                   = "error"
 	duplicateOperationConfigurationTemplateConstant                  = "duplicate configuration for operation %q"
 	missingOperationConfigurationTemplateConstant                    = "missing configuration for operation %q"
+	duplicateOperationVariantConfigurationTemplateConstant           = "duplicate configuration for operation %q variant %q"
+	missingOperationVariantTemplateConstant                          = "configuration for operation %q has no variant %q (available: %s)"
+	unsupportedOperationVariantTemplateConstant                      = "command %q reads no operation configuration, so variant %q cannot apply"
+	conflictingOperationVariantTemplateConstant                      = "operation %q names variant %q but its variant field is %q"
+	operationVariantSeparatorConstant                                = "@"
+	defaultOperationVariantLabelConstant                             = "(default)"
+	operationVariantListSeparatorConstant                            = ", "
+	operationVariantListEmptyConstant                                = "none"
 	missingOperationConfigurationSkippedMessageConstant              = "operation configuration missing; continuing without defaults"
 	unknownCommandNamePlaceholderConstant                            = "unknown"
 	dryRunOptionKeyConstant                                          = "dry_run"
@@ -215,6 +258,8 @@ var commandOperationRequirements = map[string][]string{
 	branchNamespaceUseNameConstant + "/" + branchChangeCommandUseNameConstant: {branchChangeOperationNameConstant},
 	repoNamespaceUseNameConstant + "/" + repoReleaseCommandUseNameConstant:    {repoReleaseOperationNameConstant},
 	repoNamespaceUseNameConstant + "/" + removeCommandUseNameConstant:         {repoHistoryOperationNameConstant},
+	repoNamespaceUseNameConstant + "/" + listCommandUseNameConstant:           {reposListOperationNameConstant},
+	repoNamespaceUseNameConstant + "/" + execCommandUseNameConstant:           {reposExecOperationNameConstant},
 	repoNamespaceUseNameConstant + "/" + repoFilesNamespaceUseNameConstant + "/" + filesReplaceCommandUseNameConstant: {repoFilesReplaceOperationNameConstant},
 	renameCommandUseNameConstant:         {reposRenameOperationNameConstant},
 	reposProtocolOperationNameConstant:   {reposProtocolOperationNameConstant},
@@ -228,16 +273,21 @@ var commandOperationRequirements = map[string][]string{
 var requiredOperationConfigurationNames = collectRequiredOperationConfigurationNames()
 
 type loggerOutputsFactory interface {
-	CreateLoggerOutputs(utils.LogLevel, utils.LogFormat) (utils.LoggerOutputs, error)
+	CreateLoggerOutputsWithOptions(utils.LogLevel, utils.LogFormat, utils.LoggingOptions) (utils.LoggerOutputs, error)
 }
 
-// DuplicateOperationConfigurationError indicates that the configuration file defines the same operation multiple times.
+// DuplicateOperationConfigurationError indicates that the configuration file defines the same operation, or the same
+// variant of an operation, multiple times.
 type DuplicateOperationConfigurationError struct {
 	OperationName string
+	Variant       string
 }
 
 // Error implements the error interface.
 func (errorDetails DuplicateOperationConfigurationError) Error() string {
+	if len(errorDetails.Variant) > 0 {
+		return fmt.Sprintf(duplicateOperationVariantConfigurationTemplateConstant, errorDetails.OperationName, errorDetails.Variant)
+	}
 	return fmt.Sprintf(duplicateOperationConfigurationTemplateConstant, errorDetails.OperationName)
 }
 
@@ -251,30 +301,91 @@ func (errorDetails MissingOperationConfigurationError) Error() string {
 	return fmt.Sprintf(missingOperationConfigurationTemplateConstant, errorDetails.OperationName)
 }
 
+// MissingOperationVariantError indicates that the variant selected with --variant is not configured for an operation
+// the command reads.
+type MissingOperationVariantError struct {
+	OperationName     string
+	Variant           string
+	AvailableVariants []string
+}
+
+// Error implements the error interface.
+func (errorDetails MissingOperationVariantError) Error() string {
+	availableVariants := operationVariantListEmptyConstant
+	if len(errorDetails.AvailableVariants) > 0 {
+		availableVariants = strings.Join(errorDetails.AvailableVariants, operationVariantListSeparatorConstant)
+	}
+	return fmt.Sprintf(missingOperationVariantTemplateConstant, errorDetails.OperationName, errorDetails.Variant, availableVariants)
+}
+
 // ApplicationConfiguration describes the persisted configuration for the CLI entrypoint.
 type ApplicationConfiguration struct {
 	Common     ApplicationCommonConfiguration      `mapstructure:"common"`
 	Operations []ApplicationOperationConfiguration `mapstructure:"operations"`
+	Aliases    map[string][]string                 `mapstructure:"aliases"`
 }
 
 // ApplicationCommonConfiguration stores logging and execution defaults shared across commands.
 type ApplicationCommonConfiguration struct {
-	LogLevel     string `mapstructure:"log_level"`
-	LogFormat    string `mapstructure:"log_format"`
-	DryRun       bool   `mapstructure:"dry_run"`
-	AssumeYes    bool   `mapstructure:"assume_yes"`
-	RequireClean bool   `mapstructure:"require_clean"`
-}
-
-// ApplicationOperationConfiguration captures reusable operation defaults from the configuration file.
+	LogLevel           string               `mapstructure:"log_level"`
+	LogFormat          string               `mapstructure:"log_format"`
+	DryRun             bool                 `mapstructure:"dry_run"`
+	AssumeYes          bool                 `mapstructure:"assume_yes"`
+	RequireClean       bool                 `mapstructure:"require_clean"`
+	CommandLog         string               `mapstructure:"command_log"`
+	RunHooks           bool                 `mapstructure:"run_hooks"`
+	OutputCaptureLimit int                  `mapstructure:"output_capture_limit"`
+	Logging            utils.LoggingOptions `mapstructure:"logging"`
+}
+
+// ApplicationOperationConfiguration captures reusable operation defaults from the configuration file. Variant names an
+// alternative set of defaults selected with --variant, either through the variant field or an "operation@variant"
+// name; the entry without a variant is the default.
 type ApplicationOperationConfiguration struct {
 	Name    string         `mapstructure:"operation"`
+	Variant string         `mapstructure:"variant"`
 	Options map[string]any `mapstructure:"with"`
 }
 
-// OperationConfigurations stores reusable operation defaults indexed by normalized operation name.
+// OperationConfigurations stores reusable operation defaults indexed by normalized operation name and variant.
 type OperationConfigurations struct {
 	entries map[string]map[string]any
+	variant string
+}
+
+// WithVariant returns the configurations with the variant selected, so lookups prefer that variant's entry and fall back
+// to the default entry of operations that do not define it.
+func (configurations OperationConfigurations) WithVariant(variant string) OperationConfigurations {
+	configurations.variant = normalizeOperationName(variant)
+	return configurations
+}
+
+// Variant returns the selected variant, or an empty string when the default entries are in use.
+func (configurations OperationConfigurations) Variant() string {
+	return configurations.variant
+}
+
+// Variants returns the sorted variant names configured for the operation. The default entry is listed as "(default)".
+func (configurations OperationConfigurations) Variants(operationName string) []string {
+	normalizedName := normalizeOperationName(operationName)
+	variants := make([]string, 0)
+	for entryKey := range configurations.entries {
+		entryName, entryVariant := splitOperationConfigurationKey(entryKey)
+		if entryName != normalizedName {
+			continue
+		}
+		if len(entryVariant) == 0 {
+			entryVariant = defaultOperationVariantLabelConstant
+		}
+		variants = append(variants, entryVariant)
+	}
+	sort.Strings(variants)
+	return variants
+}
+
+func (configurations OperationConfigurations) hasVariant(operationName string, variant string) bool {
+	_, exists := configurations.entries[operationConfigurationKey(normalizeOperationName(operationName), variant)]
+	return exists
 }
 
 // MergeDefaults ensures default operation configurations are available when not overridden.
@@ -305,30 +416,38 @@ type configurationInitializationPlan struct {
 
 func newOperationConfigurations(definitions []ApplicationOperationConfiguration) (OperationConfigurations, error) {
 	entries := make(map[string]map[string]any)
-	seenOperations := make(map[string]struct{})
 	for definitionIndex := range definitions {
-		normalizedName := normalizeOperationName(definitions[definitionIndex].Name)
+		normalizedName, variant := splitOperationConfigurationKey(normalizeOperationName(definitions[definitionIndex].Name))
 		if len(normalizedName) == 0 {
 			continue
 		}
 
-		if _, exists := seenOperations[normalizedName]; exists {
-			return OperationConfigurations{}, DuplicateOperationConfigurationError{OperationName: normalizedName}
+		declaredVariant := normalizeOperationName(definitions[definitionIndex].Variant)
+		if len(declaredVariant) > 0 {
+			if len(variant) > 0 && variant != declaredVariant {
+				return OperationConfigurations{}, fmt.Errorf(conflictingOperationVariantTemplateConstant, normalizedName, variant, declaredVariant)
+			}
+			variant = declaredVariant
+		}
+
+		entryKey := operationConfigurationKey(normalizedName, variant)
+		if _, exists := entries[entryKey]; exists {
+			return OperationConfigurations{}, DuplicateOperationConfigurationError{OperationName: normalizedName, Variant: variant}
 		}
-		seenOperations[normalizedName] = struct{}{}
 
 		options := make(map[string]any)
 		for optionKey, optionValue := range definitions[definitionIndex].Options {
 			options[optionKey] = optionValue
 		}
 
-		entries[normalizedName] = options
+		entries[entryKey] = options
 	}
 
 	return OperationConfigurations{entries: entries}, nil
 }
 
 // Lookup returns the configuration options for the provided operation name or an error if the configuration is absent.
+// When a variant is selected, the variant's entry is returned if the operation defines it.
 func (configurations OperationConfigurations) Lookup(operationName string) (map[string]any, error) {
 	normalizedName := normalizeOperationName(operationName)
 	if len(normalizedName) == 0 {
@@ -339,7 +458,10 @@ func (configurations OperationConfigurations) Lookup(operationName string) (map[
 		return nil, MissingOperationConfigurationError{OperationName: normalizedName}
 	}
 
-	options, exists := configurations.entries[normalizedName]
+	options, exists := configurations.entries[operationConfigurationKey(normalizedName, configurations.variant)]
+	if !exists {
+		options, exists = configurations.entries[normalizedName]
+	}
 	if !exists {
 		return nil, MissingOperationConfigurationError{OperationName: normalizedName}
 	}
@@ -382,6 +504,18 @@ func normalizeOperationName(raw string) string {
 	return strings.ToLower(strings.TrimSpace(raw))
 }
 
+func operationConfigurationKey(operationName string, variant string) string {
+	if len(variant) == 0 {
+		return operationName
+	}
+	return operationName + operationVariantSeparatorConstant + variant
+}
+
+func splitOperationConfigurationKey(entryKey string) (string, string) {
+	operationName, variant, _ := strings.Cut(entryKey, operationVariantSeparatorConstant)
+	return strings.TrimSpace(operationName), strings.TrimSpace(variant)
+}
+
 func loadEmbeddedOperationConfigurations() OperationConfigurations {
 	configurationData, configurationType := EmbeddedDefaultConfiguration()
 	if len(configurationData) == 0 {
@@ -414,17 +548,32 @@ type Application struct {
 	configuration                     ApplicationConfiguration
 	configurationMetadata             utils.LoadedConfiguration
 	configurationFilePath             string
+	noConfigurationFlagValue          bool
+	configurationFilesSkipped         bool
 	logLevelFlagValue                 string
 	logFormatFlagValue                string
+	commandLogFlagValue               string
+	operationVariantFlagValue         string
+	commandTranscript                 *execshell.CommandTranscript
 	commandContextAccessor            utils.CommandContextAccessor
 	operationConfigurations           OperationConfigurations
+	rawOperationConfigurations        OperationConfigurations
 	embeddedOperationConfigurations   OperationConfigurations
 	rootFlagValues                    *flagutils.RootFlagValues
 	configurationInitializationScope  string
 	configurationInitializationForced bool
+	configurationInitializationPrompt bool
+	interactiveInputDetector          func(io.Reader) bool
 	versionFlag                       bool
 	versionResolver                   func(context.Context) string
 	exitFunction                      func(int)
+	runTimeoutFlagValue               time.Duration
+	noLockFlagValue                   bool
+	degradeReadOnlyFlagValue          bool
+	runTimeoutContext                 context.Context
+	cancelRunTimeout                  context.CancelFunc
+	phaseTimer                        *utils.PhaseTimer
+	aliasDefinitions                  []aliasDefinition
 }
 
 // NewApplication assembles a fully wired CLI application instance.
@@ -436,13 +585,14 @@ func NewApplication() *Application {
 		commandContextAccessor: utils.NewCommandContextAccessor(),
 	}
 	application.versionResolver = application.resolveVersion
+	application.interactiveInputDetector = isInteractiveTerminal
 	application.exitFunction = os.Exit
 
 	application.configurationLoader = utils.NewConfigurationLoader(
 		configurationNameConstant,
 		configurationTypeConstant,
 		environmentPrefixConstant,
-		application.resolveConfigurationSearchPaths(),
+		nil,
 	)
 
 	embeddedConfigurationData, embeddedConfigurationType := EmbeddedDefaultConfiguration()
@@ -456,9 +606,21 @@ func NewApplication() *Application {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PersistentPreRunE: func(command *cobra.Command, arguments []string) error {
-			if initializationError := application.initializeConfiguration(command); initializationError != nil {
+			application.startPhaseTimer(command)
+			stopConfigurationPhase := utils.StartPhase(command.Context(), utils.PhaseConfiguration)
+			initializationError := application.initializeConfiguration(command)
+			stopConfigurationPhase()
+			if initializationError != nil {
 				return initializationError
 			}
+			if timeoutError := application.applyRunTimeout(command); timeoutError != nil {
+				return timeoutError
+			}
+			application.applyRootLockPreference(command)
+			application.applyReadOnlyDegradationPreference(command)
+			if standardInputError := rootutils.ExpandStandardInput(command); standardInputError != nil {
+				return standardInputError
+			}
 
 			versionRequested := application.versionFlag
 			if command != nil {
@@ -481,8 +643,14 @@ func NewApplication() *Application {
 
 	cobraCommand.SetContext(context.Background())
 	cobraCommand.PersistentFlags().StringVar(&application.configurationFilePath, configFileFlagNameConstant, "", configFileFlagUsageConstant)
+	cobraCommand.PersistentFlags().BoolVar(&application.noConfigurationFlagValue, noConfigurationFlagNameConstant, false, noConfigurationFlagUsageConstant)
 	cobraCommand.PersistentFlags().StringVar(&application.logLevelFlagValue, logLevelFlagNameConstant, "", logLevelFlagUsageConstant)
 	cobraCommand.PersistentFlags().StringVar(&application.logFormatFlagValue, logFormatFlagNameConstant, "", logFormatFlagUsageConstant)
+	cobraCommand.PersistentFlags().StringVar(&application.commandLogFlagValue, commandLogFlagNameConstant, "", commandLogFlagUsageConstant)
+	cobraCommand.PersistentFlags().StringVar(&application.operationVariantFlagValue, operationVariantFlagNameConstant, "", operationVariantFlagUsageConstant)
+	cobraCommand.PersistentFlags().DurationVar(&application.runTimeoutFlagValue, runTimeoutFlagNameConstant, 0, runTimeoutFlagUsageConstant)
+	cobraCommand.PersistentFlags().BoolVar(&application.noLockFlagValue, noLockFlagNameConstant, false, noLockFlagUsageConstant)
+	cobraCommand.PersistentFlags().BoolVar(&application.degradeReadOnlyFlagValue, degradeReadOnlyFlagNameConstant, false, degradeReadOnlyFlagUsageConstant)
 	cobraCommand.PersistentFlags().StringVar(
 		&application.configurationInitializationScope,
 		configurationInitializationFlagNameConstant,
@@ -506,6 +674,12 @@ func NewApplication() *Application {
 		false,
 		configurationInitializationForceFlagUsageConstant,
 	)
+	cobraCommand.PersistentFlags().BoolVar(
+		&application.configurationInitializationPrompt,
+		configurationInitializationInteractiveFlagNameConstant,
+		false,
+		configurationInitializationInteractiveFlagUsageConstant,
+	)
 
 	application.rootFlagValues = flagutils.BindRootFlags(
 		cobraCommand,
@@ -538,6 +712,8 @@ func NewApplication() *Application {
 		},
 	}
 	cobraCommand.AddCommand(versionCommand)
+	cobraCommand.AddCommand(application.newAliasesCommand())
+	cobraCommand.AddCommand(application.newConfigCommand())
 
 	auditBuilder := auditcli.CommandBuilder{
 		LoggerProvider: func() *zap.Logger {
@@ -597,6 +773,13 @@ func NewApplication() *Application {
 		ConfigurationProvider: application.packagesConfiguration,
 	}
 
+	packagesReportBuilder := packages.ReportCommandBuilder{
+		LoggerProvider: func() *zap.Logger {
+			return application.logger
+		},
+		ConfigurationProvider: application.packagesConfiguration,
+	}
+
 	releaseBuilder := releasecmd.CommandBuilder{
 		LoggerProvider: func() *zap.Logger {
 			return application.logger
@@ -610,6 +793,7 @@ func NewApplication() *Application {
 			return application.logger
 		},
 		HumanReadableLoggingProvider: application.humanReadableLoggingEnabled,
+		StructuredOutputProvider:     application.structuredOutputEnabled,
 		ConfigurationProvider:        application.reposRenameConfiguration,
 	}
 
@@ -618,6 +802,7 @@ func NewApplication() *Application {
 			return application.logger
 		},
 		HumanReadableLoggingProvider: application.humanReadableLoggingEnabled,
+		StructuredOutputProvider:     application.structuredOutputEnabled,
 		ConfigurationProvider:        application.reposRemotesConfiguration,
 	}
 
@@ -626,6 +811,7 @@ func NewApplication() *Application {
 			return application.logger
 		},
 		HumanReadableLoggingProvider: application.humanReadableLoggingEnabled,
+		StructuredOutputProvider:     application.structuredOutputEnabled,
 		ConfigurationProvider:        application.reposProtocolConfiguration,
 	}
 
@@ -645,11 +831,28 @@ func NewApplication() *Application {
 		ConfigurationProvider:        application.reposReplaceConfiguration,
 	}
 
+	listBuilder := repos.ListCommandBuilder{
+		LoggerProvider: func() *zap.Logger {
+			return application.logger
+		},
+		HumanReadableLoggingProvider: application.humanReadableLoggingEnabled,
+		ConfigurationProvider:        application.reposListConfiguration,
+	}
+
+	execBuilder := repos.ExecCommandBuilder{
+		LoggerProvider: func() *zap.Logger {
+			return application.logger
+		},
+		HumanReadableLoggingProvider: application.humanReadableLoggingEnabled,
+		ConfigurationProvider:        application.reposExecConfiguration,
+	}
+
 	workflowBuilder := workflowcmd.CommandBuilder{
 		LoggerProvider: func() *zap.Logger {
 			return application.logger
 		},
 		HumanReadableLoggingProvider: application.humanReadableLoggingEnabled,
+		StructuredOutputProvider:     application.structuredOutputEnabled,
 		ConfigurationProvider:        application.workflowCommandConfiguration,
 	}
 	workflowCommand, workflowBuildError := workflowBuilder.Build()
@@ -692,7 +895,7 @@ func NewApplication() *Application {
 
 	repoFolderCommand := newNamespaceCommand(repoFolderNamespaceUseNameConstant, repoFolderNamespaceShortDescriptionConstant, repoFolderNamespaceAliasConstant)
 	if renameNestedCommand, nestedRenameError := renameBuilder.Build(); nestedRenameError == nil {
-		configureCommandMetadata(renameNestedCommand, renameCommandUseNameConstant, renameNestedCommand.Short, renameNestedLongDescriptionConstant)
+		configureCommandMetadata(renameNestedCommand, renameCommandUseNameConstant+rootArgumentsUseSuffixConstant, renameNestedCommand.Short, renameNestedLongDescriptionConstant)
 		repoFolderCommand.AddCommand(renameNestedCommand)
 	}
 	if len(repoFolderCommand.Commands()) > 0 {
@@ -701,11 +904,11 @@ func NewApplication() *Application {
 
 	repoRemoteCommand := newNamespaceCommand(repoRemoteNamespaceUseNameConstant, repoRemoteNamespaceShortDescriptionConstant)
 	if canonicalRemoteCommand, canonicalRemoteError := remotesBuilder.Build(); canonicalRemoteError == nil {
-		configureCommandMetadata(canonicalRemoteCommand, updateRemoteCanonicalUseNameConstant, canonicalRemoteCommand.Short, updateRemoteCanonicalLongDescriptionConstant, updateRemoteCanonicalAliasConstant)
+		configureCommandMetadata(canonicalRemoteCommand, updateRemoteCanonicalUseNameConstant+rootArgumentsUseSuffixConstant, canonicalRemoteCommand.Short, updateRemoteCanonicalLongDescriptionConstant, updateRemoteCanonicalAliasConstant)
 		repoRemoteCommand.AddCommand(canonicalRemoteCommand)
 	}
 	if protocolConversionCommand, protocolConversionError := protocolBuilder.Build(); protocolConversionError == nil {
-		configureCommandMetadata(protocolConversionCommand, updateProtocolCommandUseNameConstant, protocolConversionCommand.Short, updateProtocolLongDescriptionConstant, updateProtocolAliasConstant)
+		configureCommandMetadata(protocolConversionCommand, updateProtocolCommandUseNameConstant+rootArgumentsUseSuffixConstant, protocolConversionCommand.Short, updateProtocolLongDescriptionConstant, updateProtocolAliasConstant)
 		repoRemoteCommand.AddCommand(protocolConversionCommand)
 	}
 	if len(repoRemoteCommand.Commands()) > 0 {
@@ -714,7 +917,7 @@ func NewApplication() *Application {
 
 	repoPullRequestsCommand := newNamespaceCommand(repoPullRequestsNamespaceUseNameConstant, repoPullRequestsNamespaceShortDescriptionConstant)
 	if pullRequestCleanupCommand, pullRequestCleanupError := branchCleanupBuilder.Build(); pullRequestCleanupError == nil {
-		configureCommandMetadata(pullRequestCleanupCommand, prsDeleteCommandUseNameConstant, pullRequestCleanupCommand.Short, prsDeleteLongDescriptionConstant, prsDeleteCommandAliasConstant)
+		configureCommandMetadata(pullRequestCleanupCommand, prsDeleteCommandUseNameConstant+rootArgumentsUseSuffixConstant, pullRequestCleanupCommand.Short, prsDeleteLongDescriptionConstant, prsDeleteCommandAliasConstant)
 		repoPullRequestsCommand.AddCommand(pullRequestCleanupCommand)
 	}
 	if len(repoPullRequestsCommand.Commands()) > 0 {
@@ -723,22 +926,36 @@ func NewApplication() *Application {
 
 	repoPackagesCommand := newNamespaceCommand(repoPackagesNamespaceUseNameConstant, repoPackagesNamespaceShortDescriptionConstant)
 	if packagesCleanupCommand, packagesCleanupError := packagesBuilder.Build(); packagesCleanupError == nil {
-		configureCommandMetadata(packagesCleanupCommand, packagesDeleteCommandUseNameConstant, packagesCleanupCommand.Short, packagesDeleteLongDescriptionConstant, packagesDeleteCommandAliasConstant)
+		configureCommandMetadata(packagesCleanupCommand, packagesDeleteCommandUseNameConstant+rootArgumentsUseSuffixConstant, packagesCleanupCommand.Short, packagesDeleteLongDescriptionConstant, packagesDeleteCommandAliasConstant)
 		repoPackagesCommand.AddCommand(packagesCleanupCommand)
 	}
+	if packagesReportCommand, packagesReportError := packagesReportBuilder.Build(); packagesReportError == nil {
+		configureCommandMetadata(packagesReportCommand, packagesReportCommandUseNameConstant, packagesReportCommand.Short, packagesReportLongDescriptionConstant)
+		repoPackagesCommand.AddCommand(packagesReportCommand)
+	}
 	if len(repoPackagesCommand.Commands()) > 0 {
 		repoNamespaceCommand.AddCommand(repoPackagesCommand)
 	}
 
 	repoFilesCommand := newNamespaceCommand(repoFilesNamespaceUseNameConstant, repoFilesNamespaceShortDescriptionConstant, repoFilesNamespaceAliasConstant)
 	if filesReplaceCommand, filesReplaceBuildError := replaceBuilder.Build(); filesReplaceBuildError == nil {
-		configureCommandMetadata(filesReplaceCommand, filesReplaceCommandUseNameConstant, filesReplaceCommand.Short, filesReplaceCommandLongDescriptionConstant, filesReplaceCommandAliasConstant)
+		configureCommandMetadata(filesReplaceCommand, filesReplaceCommandUseNameConstant+rootArgumentsUseSuffixConstant, filesReplaceCommand.Short, filesReplaceCommandLongDescriptionConstant, filesReplaceCommandAliasConstant)
 		repoFilesCommand.AddCommand(filesReplaceCommand)
 	}
 	if len(repoFilesCommand.Commands()) > 0 {
 		repoNamespaceCommand.AddCommand(repoFilesCommand)
 	}
 
+	if listCommand, listBuildError := listBuilder.Build(); listBuildError == nil {
+		configureCommandMetadata(listCommand, listCommandUseNameConstant+rootArgumentsUseSuffixConstant, listCommand.Short, listCommandLongDescriptionConstant, listCommandAliasConstant)
+		repoNamespaceCommand.AddCommand(listCommand)
+	}
+
+	if execCommand, execBuildError := execBuilder.Build(); execBuildError == nil {
+		configureCommandMetadata(execCommand, execCommandUseNameConstant+rootArgumentsUseSuffixConstant+execCommandUsageSuffixConstant, execCommand.Short, execCommandLongDescriptionConstant)
+		repoNamespaceCommand.AddCommand(execCommand)
+	}
+
 	if removeCommand, removeBuildError := removeBuilder.Build(); removeBuildError == nil {
 		configureCommandMetadata(removeCommand, removeCommandUseNameConstant, removeCommandShortDescriptionConstant, removeCommandLongDescriptionConstant, removeCommandAliasConstant)
 		repoNamespaceCommand.AddCommand(removeCommand)
@@ -766,7 +983,7 @@ func NewApplication() *Application {
 		branchNamespaceCommand.AddCommand(branchChangeCommand)
 	}
 	if branchRefreshNestedCommand, branchRefreshNestedError := branchRefreshBuilder.Build(); branchRefreshNestedError == nil {
-		configureCommandMetadata(branchRefreshNestedCommand, refreshCommandUseNameConstant, branchRefreshNestedCommand.Short, branchRefreshNestedLongDescriptionConstant)
+		configureCommandMetadata(branchRefreshNestedCommand, refreshCommandUseNameConstant+rootArgumentsUseSuffixConstant, branchRefreshNestedCommand.Short, branchRefreshNestedLongDescriptionConstant)
 		branchNamespaceCommand.AddCommand(branchRefreshNestedCommand)
 	}
 	if commitNamespaceCommand != nil {
@@ -787,11 +1004,19 @@ func (application *Application) Execute() error {
 	normalizedArguments = normalizeInitializationScopeArguments(normalizedArguments)
 	application.rootCommand.SetArgs(normalizedArguments)
 
-	executionError := application.rootCommand.Execute()
+	if aliasError := application.registerConfiguredAliases(normalizedArguments); aliasError != nil {
+		return renderExecutionError(aliasError)
+	}
+
+	executionError := application.finishRunTimeout(application.rootCommand.Execute())
+	application.reportPhaseTiming()
+	if closeError := application.closeCommandTranscript(); closeError != nil && executionError == nil {
+		executionError = fmt.Errorf(commandLogCloseErrorTemplateConstant, closeError)
+	}
 	if syncError := application.flushLogger(); syncError != nil {
 		return fmt.Errorf(loggerSyncErrorTemplateConstant, syncError)
 	}
-	return executionError
+	return renderExecutionError(executionError)
 }
 
 // Execute builds a fresh application instance and executes the root command hierarchy.
@@ -908,12 +1133,25 @@ func (application *Application) resolveUserConfigurationDirectoryPaths() []strin
 
 func (application *Application) initializeConfiguration(command *cobra.Command) error {
 	defaultValues := map[string]any{
-		commonLogLevelConfigKeyConstant:     string(utils.LogLevelError),
-		commonLogFormatConfigKeyConstant:    string(utils.LogFormatStructured),
-		commonDryRunConfigKeyConstant:       false,
-		commonAssumeYesConfigKeyConstant:    false,
-		commonRequireCleanConfigKeyConstant: false,
+		commonLogLevelConfigKeyConstant:           string(utils.LogLevelError),
+		commonLogFormatConfigKeyConstant:          string(utils.LogFormatStructured),
+		commonDryRunConfigKeyConstant:             false,
+		commonAssumeYesConfigKeyConstant:          false,
+		commonRequireCleanConfigKeyConstant:       false,
+		commonCommandLogConfigKeyConstant:         "",
+		commonRunHooksConfigKeyConstant:           false,
+		commonOutputCaptureLimitConfigKeyConstant: 0,
+	}
+
+	application.configurationFilesSkipped = application.noConfigurationRequested(command)
+	if application.configurationFilesSkipped {
+		if len(strings.TrimSpace(application.configurationFilePath)) > 0 {
+			return errors.New(noConfigurationConflictErrorMessageConstant)
+		}
+	} else {
+		application.configurationLoader.SetSearchPaths(application.resolveConfigurationSearchPaths())
 	}
+	application.configurationLoader.SetConfigurationFilesSkipped(application.configurationFilesSkipped)
 
 	loadedConfiguration, loadError := application.configurationLoader.LoadConfiguration(application.configurationFilePath, defaultValues, &application.configuration)
 	if loadError != nil {
@@ -926,7 +1164,12 @@ func (application *Application) initializeConfiguration(command *cobra.Command)
 	if configurationBuildError != nil {
 		return configurationBuildError
 	}
-	application.operationConfigurations = operationConfigurations
+	application.operationConfigurations = operationConfigurations.WithVariant(application.operationVariantFlagValue)
+	rawOperationConfigurations, rawConfigurationError := loadRawOperationConfigurations(loadedConfiguration.ConfigFileUsed)
+	if rawConfigurationError != nil {
+		return rawConfigurationError
+	}
+	application.rawOperationConfigurations = rawOperationConfigurations.WithVariant(application.operationVariantFlagValue)
 
 	if validationError := application.validateOperationConfigurations(command); validationError != nil {
 		return validationError
@@ -941,9 +1184,14 @@ func (application *Application) initializeConfiguration(command *cobra.Command)
 		application.configuration.Common.LogFormat = application.logFormatFlagValue
 	}
 
-	loggerOutputs, loggerCreationError := application.loggerFactory.CreateLoggerOutputs(
+	if application.persistentFlagChanged(command, commandLogFlagNameConstant) {
+		application.configuration.Common.CommandLog = application.commandLogFlagValue
+	}
+
+	loggerOutputs, loggerCreationError := application.loggerFactory.CreateLoggerOutputsWithOptions(
 		utils.LogLevel(application.configuration.Common.LogLevel),
 		utils.LogFormat(application.configuration.Common.LogFormat),
+		application.configuration.Common.Logging,
 	)
 	if loggerCreationError != nil {
 		return fmt.Errorf(loggerCreationErrorTemplateConstant, loggerCreationError)
@@ -962,6 +1210,8 @@ func (application *Application) initializeConfiguration(command *cobra.Command)
 	application.logConfigurationInitialization()
 
 	if command != nil {
+		application.reportOutdatedDefaults(command.ErrOrStderr())
+
 		updatedContext := application.commandContextAccessor.WithConfigurationFilePath(
 			command.Context(),
 			application.configurationMetadata.ConfigFileUsed,
@@ -972,6 +1222,16 @@ func (application *Application) initializeConfiguration(command *cobra.Command)
 		updatedContext = application.commandContextAccessor.WithLogLevel(updatedContext, application.configuration.Common.LogLevel)
 
 		updatedContext = application.commandContextAccessor.WithBranchContext(updatedContext, utils.BranchContext{RequireClean: true})
+		updatedContext = gitrepo.WithCommitHooks(updatedContext, application.configuration.Common.RunHooks)
+		updatedContext = execshell.WithOutputCaptureLimit(updatedContext, application.configuration.Common.OutputCaptureLimit)
+
+		transcript, transcriptError := application.openCommandTranscript()
+		if transcriptError != nil {
+			return transcriptError
+		}
+		if transcript != nil {
+			updatedContext = execshell.WithCommandObserver(updatedContext, transcript)
+		}
 
 		command.SetContext(updatedContext)
 		if rootCommand := command.Root(); rootCommand != nil {
@@ -982,6 +1242,54 @@ func (application *Application) initializeConfiguration(command *cobra.Command)
 	return nil
 }
 
+func (application *Application) openCommandTranscript() (*execshell.CommandTranscript, error) {
+	if closeError := application.closeCommandTranscript(); closeError != nil {
+		return nil, fmt.Errorf(commandLogCloseErrorTemplateConstant, closeError)
+	}
+
+	transcriptPath := strings.TrimSpace(application.configuration.Common.CommandLog)
+	if len(transcriptPath) == 0 {
+		return nil, nil
+	}
+
+	transcript, openError := execshell.OpenCommandTranscript(transcriptPath)
+	if openError != nil {
+		return nil, openError
+	}
+	application.commandTranscript = transcript
+	return transcript, nil
+}
+
+func (application *Application) closeCommandTranscript() error {
+	if application.commandTranscript == nil {
+		return nil
+	}
+	transcript := application.commandTranscript
+	application.commandTranscript = nil
+	if writeError := transcript.Err(); writeError != nil {
+		_ = transcript.Close()
+		return writeError
+	}
+	return transcript.Close()
+}
+
+func (application *Application) noConfigurationRequested(command *cobra.Command) bool {
+	if application.persistentFlagChanged(command, noConfigurationFlagNameConstant) {
+		return application.noConfigurationFlagValue
+	}
+	return application.noConfigurationRequestedByEnvironment()
+}
+
+func (application *Application) noConfigurationRequestedByEnvironment() bool {
+	environmentValue := strings.TrimSpace(os.Getenv(noConfigurationEnvironmentVariableConstant))
+	if len(environmentValue) == 0 {
+		return false
+	}
+
+	parsedValue, parseError := strconv.ParseBool(environmentValue)
+	return parseError == nil && parsedValue
+}
+
 // InitializeForCommand prepares application state for the provided command name without executing command logic.
 func (application *Application) InitializeForCommand(commandUse string) error {
 	command := &cobra.Command{Use: commandUse}
@@ -998,11 +1306,34 @@ func (application *Application) humanReadableLoggingEnabled() bool {
 	return strings.EqualFold(logFormatValue, string(utils.LogFormatConsole))
 }
 
+func (application *Application) structuredOutputEnabled() bool {
+	return utils.LogFormat(application.configuration.Common.LogFormat).Structured()
+}
+
 func (application *Application) logConfigurationInitialization() {
 	if !strings.EqualFold(strings.TrimSpace(application.configuration.Common.LogLevel), string(utils.LogLevelDebug)) {
 		return
 	}
 
+	if application.configurationFilesSkipped {
+		if application.humanReadableLoggingEnabled() {
+			application.consoleLogger.Debug(fmt.Sprintf(
+				configurationInitializedNoFileConsoleTemplateConstant,
+				configurationInitializedMessageConstant,
+				application.configuration.Common.LogLevel,
+				application.configuration.Common.LogFormat,
+			))
+			return
+		}
+
+		application.logger.Debug(
+			configurationInitializedMessageConstant,
+			zap.String(configurationLogLevelFieldConstant, application.configuration.Common.LogLevel),
+			zap.String(configurationLogFormatFieldConstant, application.configuration.Common.LogFormat),
+		)
+		return
+	}
+
 	if application.humanReadableLoggingEnabled() {
 		bannerMessage := fmt.Sprintf(
 			configurationInitializedConsoleTemplateConstant,
@@ -1118,6 +1449,13 @@ func (application *Application) resolveVersion(executionContext context.Context)
 
 func (application *Application) printVersion(executionContext context.Context) {
 	versionString := application.versionResolver(executionContext)
+	if application.structuredOutputEnabled() {
+		utils.NewStructuredEventLogger(os.Stdout).Info(
+			strings.TrimSpace(fmt.Sprintf(versionOutputTemplateConstant, versionString)),
+			zap.String(versionEventFieldNameConstant, versionString),
+		)
+		return
+	}
 	fmt.Printf(versionOutputTemplateConstant, versionString)
 }
 
@@ -1169,6 +1507,24 @@ func (application *Application) reposProtocolConfiguration() repos.ProtocolConfi
 	return configuration
 }
 
+func (application *Application) reposListConfiguration() repos.ListConfiguration {
+	configuration := repos.DefaultToolsConfiguration().List
+	application.decodeOperationConfiguration(reposListOperationNameConstant, &configuration)
+	return configuration
+}
+
+func (application *Application) reposExecConfiguration() repos.ExecConfiguration {
+	configuration := repos.DefaultToolsConfiguration().Exec
+	application.decodeOperationConfiguration(reposExecOperationNameConstant, &configuration)
+
+	options, optionsExist := application.lookupOperationOptions(reposExecOperationNameConstant)
+	if !optionsExist || !optionExists(options, dryRunOptionKeyConstant) {
+		configuration.DryRun = application.configuration.Common.DryRun
+	}
+
+	return configuration
+}
+
 func (application *Application) reposRemoveConfiguration() repos.RemoveConfiguration {
 	configuration := repos.DefaultToolsConfiguration().Remove
 	application.decodeOperationConfiguration(repoHistoryOperationNameConstant, &configuration)
@@ -1232,6 +1588,10 @@ func (application *Application) commitMessageConfiguration() commitcmd.MessageCo
 func (application *Application) branchDefaultConfiguration() migrate.CommandConfiguration {
 	configuration := migrate.DefaultCommandConfiguration()
 	application.decodeOperationConfiguration(branchDefaultOperationNameConstant, &configuration)
+	var overrides map[string]migrate.RepositoryOverride
+	if application.decodeRawOperationOption(branchDefaultOperationNameConstant, branchDefaultOverridesOptionKeyConstant, &overrides) {
+		configuration.Overrides = overrides
+	}
 	if strings.EqualFold(application.configuration.Common.LogLevel, string(utils.LogLevelDebug)) {
 		configuration.EnableDebugLogging = true
 	}
@@ -1275,6 +1635,10 @@ func optionExists(options map[string]any, optionKey string) bool {
 }
 
 func (application *Application) validateOperationConfigurations(command *cobra.Command) error {
+	if variantError := application.validateOperationVariant(command); variantError != nil {
+		return variantError
+	}
+
 	if len(application.configuration.Operations) == 0 {
 		return nil
 	}
@@ -1309,6 +1673,35 @@ func (application *Application) validateOperationConfigurations(command *cobra.C
 	return nil
 }
 
+func (application *Application) validateOperationVariant(command *cobra.Command) error {
+	variant := application.operationConfigurations.Variant()
+	if len(variant) == 0 {
+		return nil
+	}
+
+	requiredOperations := application.operationsRequiredForCommand(command)
+	if len(requiredOperations) == 0 {
+		commandName := unknownCommandNamePlaceholderConstant
+		if command != nil {
+			commandName = command.CommandPath()
+		}
+		return fmt.Errorf(unsupportedOperationVariantTemplateConstant, commandName, variant)
+	}
+
+	for _, operationName := range requiredOperations {
+		if application.operationConfigurations.hasVariant(operationName, variant) {
+			continue
+		}
+		return MissingOperationVariantError{
+			OperationName:     operationName,
+			Variant:           variant,
+			AvailableVariants: application.operationConfigurations.Variants(operationName),
+		}
+	}
+
+	return nil
+}
+
 func (application *Application) logMissingOperationConfiguration(commandName string, operationName string) {
 	if application.logger == nil {
 		return
@@ -1462,6 +1855,25 @@ func (application *Application) handleConfigurationInitialization(command *cobra
 		return true, errors.New(configurationInitializationContentUnavailableErrorConstant)
 	}
 
+	if application.configurationInitializationPrompt {
+		if application.interactiveInputDetector == nil || !application.interactiveInputDetector(command.InOrStdin()) {
+			application.logger.Info(configurationInitializationNonInteractiveMessageConstant)
+		} else {
+			if existingError := application.checkExistingConfigurationFile(initializationPlan.FilePath); existingError != nil {
+				return true, existingError
+			}
+			answers, wizardError := newConfigurationWizard(command.InOrStdin(), command.OutOrStdout(), nil).Run()
+			if wizardError != nil {
+				return true, wizardError
+			}
+			renderedContent, renderError := renderConfigurationWizardAnswers(configurationContent, answers)
+			if renderError != nil {
+				return true, renderError
+			}
+			configurationContent = renderedContent
+		}
+	}
+
 	if writeError := application.writeConfigurationFile(initializationPlan, configurationContent); writeError != nil {
 		return true, writeError
 	}
@@ -1556,25 +1968,32 @@ func (application *Application) writeConfigurationFile(initializationPlan config
 		return fmt.Errorf(configurationInitializationDirectoryErrorTemplateConstant, directoryPath, directoryStatError)
 	}
 
-	fileInfo, fileStatError := os.Stat(initializationPlan.FilePath)
+	if existingError := application.checkExistingConfigurationFile(initializationPlan.FilePath); existingError != nil {
+		return existingError
+	}
+
+	writeError := os.WriteFile(initializationPlan.FilePath, configurationContent, configurationFilePermissionConstant)
+	if writeError != nil {
+		return fmt.Errorf(configurationInitializationWriteErrorTemplateConstant, initializationPlan.FilePath, writeError)
+	}
+
+	return nil
+}
+
+func (application *Application) checkExistingConfigurationFile(filePath string) error {
+	fileInfo, fileStatError := os.Stat(filePath)
 	switch {
 	case fileStatError == nil:
 		if fileInfo.IsDir() {
-			return fmt.Errorf(configurationInitializationExistingDirectoryTemplateConstant, initializationPlan.FilePath)
+			return fmt.Errorf(configurationInitializationExistingDirectoryTemplateConstant, filePath)
 		}
 		if !application.configurationInitializationForced {
-			return fmt.Errorf(configurationInitializationExistingFileTemplateConstant, initializationPlan.FilePath)
+			return fmt.Errorf(configurationInitializationExistingFileTemplateConstant, filePath)
 		}
 	case errors.Is(fileStatError, os.ErrNotExist):
 	default:
-		return fmt.Errorf(configurationInitializationWriteErrorTemplateConstant, initializationPlan.FilePath, fileStatError)
+		return fmt.Errorf(configurationInitializationWriteErrorTemplateConstant, filePath, fileStatError)
 	}
-
-	writeError := os.WriteFile(initializationPlan.FilePath, configurationContent, configurationFilePermissionConstant)
-	if writeError != nil {
-		return fmt.Errorf(configurationInitializationWriteErrorTemplateConstant, initializationPlan.FilePath, writeError)
-	}
-
 	return nil
 }
 
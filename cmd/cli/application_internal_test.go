@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/temirov/gix/internal/migrate"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 )
 
@@ -405,3 +406,142 @@ operations:
 	require.Equal(t, []string{"."}, releaseConfiguration.RepositoryRoots)
 	require.Equal(t, "origin", releaseConfiguration.RemoteName)
 }
+
+func TestInitializeConfigurationSelectsOperationVariant(t *testing.T) {
+	const variantConfigurationContent = `operations:
+  - operation: repo-prs-purge
+    with:
+      limit: 10
+  - operation: repo-prs-purge@aggressive
+    with:
+      limit: 500
+  - operation: repo-prs-purge
+    variant: Conservative
+    with:
+      limit: 1
+`
+
+	testCases := []struct {
+		name                  string
+		configurationContent  string
+		variant               string
+		commandPath           []string
+		expectedLimit         any
+		expectedErrorSample   error
+		expectedErrorText     string
+		expectedAvailableList []string
+	}{
+		{
+			name:                 "default_entry_without_variant",
+			configurationContent: variantConfigurationContent,
+			commandPath:          []string{"r", "prs", "delete"},
+			expectedLimit:        10,
+		},
+		{
+			name:                 "variant_from_operation_name",
+			configurationContent: variantConfigurationContent,
+			variant:              "aggressive",
+			commandPath:          []string{"r", "prs", "delete"},
+			expectedLimit:        500,
+		},
+		{
+			name:                 "variant_from_variant_field",
+			configurationContent: variantConfigurationContent,
+			variant:              "CONSERVATIVE",
+			commandPath:          []string{"r", "prs", "delete"},
+			expectedLimit:        1,
+		},
+		{
+			name:                  "unknown_variant",
+			configurationContent:  variantConfigurationContent,
+			variant:               "reckless",
+			commandPath:           []string{"r", "prs", "delete"},
+			expectedErrorSample:   MissingOperationVariantError{},
+			expectedErrorText:     `configuration for operation "repo-prs-purge" has no variant "reckless" (available: (default), aggressive, conservative)`,
+			expectedAvailableList: []string{defaultOperationVariantLabelConstant, "aggressive", "conservative"},
+		},
+		{
+			name:                 "duplicate_variant",
+			configurationContent: variantConfigurationContent + "  - operation: Repo-PRs-Purge@Aggressive\n",
+			commandPath:          []string{"r", "prs", "delete"},
+			expectedErrorSample:  DuplicateOperationConfigurationError{},
+			expectedErrorText:    `duplicate configuration for operation "repo-prs-purge" variant "aggressive"`,
+		},
+		{
+			name:                 "conflicting_variant_names",
+			configurationContent: "operations:\n  - operation: repo-prs-purge@aggressive\n    variant: conservative\n",
+			commandPath:          []string{"r", "prs", "delete"},
+			expectedErrorText:    `operation "repo-prs-purge" names variant "aggressive" but its variant field is "conservative"`,
+		},
+		{
+			name:                 "variant_on_command_without_operation",
+			configurationContent: variantConfigurationContent,
+			variant:              "aggressive",
+			commandPath:          []string{"version"},
+			expectedErrorText:    `command "gix version" reads no operation configuration, so variant "aggressive" cannot apply`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(subtest *testing.T) {
+			configurationPath := filepath.Join(subtest.TempDir(), "config.yaml")
+			require.NoError(subtest, os.WriteFile(configurationPath, []byte(testCase.configurationContent), 0o600))
+
+			application := NewApplication()
+			application.configurationFilePath = configurationPath
+			application.operationVariantFlagValue = testCase.variant
+
+			command, _, findError := application.rootCommand.Find(testCase.commandPath)
+			require.NoError(subtest, findError)
+			command.SetContext(context.Background())
+
+			initializationError := application.initializeConfiguration(command)
+			if len(testCase.expectedErrorText) > 0 {
+				require.EqualError(subtest, initializationError, testCase.expectedErrorText)
+				switch testCase.expectedErrorSample.(type) {
+				case MissingOperationVariantError:
+					var variantError MissingOperationVariantError
+					require.ErrorAs(subtest, initializationError, &variantError)
+					require.Equal(subtest, testCase.expectedAvailableList, variantError.AvailableVariants)
+				case DuplicateOperationConfigurationError:
+					var duplicateError DuplicateOperationConfigurationError
+					require.ErrorAs(subtest, initializationError, &duplicateError)
+				}
+				return
+			}
+			require.NoError(subtest, initializationError)
+
+			options, lookupError := application.operationConfigurations.Lookup(branchCleanupOperationNameConstant)
+			require.NoError(subtest, lookupError)
+			require.Equal(subtest, testCase.expectedLimit, options["limit"])
+		})
+	}
+}
+
+func TestBranchDefaultConfigurationKeepsOverrideKeysVerbatim(t *testing.T) {
+	const overridesConfigurationContent = `operations:
+  - operation: branch-default
+    with:
+      overrides:
+        "Acme/Web.Site":
+          to: trunk
+        "acme/*":
+          skip: true
+`
+
+	configurationPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configurationPath, []byte(overridesConfigurationContent), 0o600))
+
+	application := NewApplication()
+	application.configurationFilePath = configurationPath
+
+	command, _, findError := application.rootCommand.Find([]string{"b", "default"})
+	require.NoError(t, findError)
+	command.SetContext(context.Background())
+	require.NoError(t, application.initializeConfiguration(command))
+
+	require.Equal(t, map[string]migrate.RepositoryOverride{
+		"Acme/Web.Site": {TargetBranch: "trunk"},
+		"acme/*":        {Skip: true},
+	}, application.branchDefaultConfiguration().Overrides)
+}
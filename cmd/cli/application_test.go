@@ -888,3 +888,41 @@ func (capture *testStderrCapture) Stop(testingInstance testing.TB) string {
 
 	return string(capturedBytes)
 }
+
+func TestApplicationNoConfigurationSkipsConfigurationFiles(t *testing.T) {
+	configurationDirectory := t.TempDir()
+	configurationPath := filepath.Join(configurationDirectory, testConfigurationFileNameConstant)
+	writeConfigurationFile(t, configurationPath, buildConfigurationContent(requiredOperationNames))
+
+	t.Setenv(testConfigurationSearchPathEnvironmentName, configurationDirectory)
+	t.Setenv("HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("GIX_NO_CONFIG", "1")
+	t.Setenv("GIX_COMMON_LOG_LEVEL", "debug")
+
+	application := cli.NewApplication()
+	stderrCapture := startTestStderrCapture(t)
+	initializationError := application.InitializeForCommand(testPackagesCommandNameConstant)
+	capturedOutput := stderrCapture.Stop(t)
+
+	require.NoError(t, initializationError)
+	require.Empty(t, application.ConfigFileUsed())
+	require.Contains(t, capturedOutput, "configuration initialized")
+	require.NotContains(t, capturedOutput, "config_file")
+}
+
+func TestApplicationNoConfigurationRejectsExplicitConfiguration(t *testing.T) {
+	configurationPath := filepath.Join(t.TempDir(), testConfigurationFileNameConstant)
+	writeConfigurationFile(t, configurationPath, buildConfigurationContent(requiredOperationNames))
+
+	originalArgs := os.Args
+	t.Cleanup(func() {
+		os.Args = originalArgs
+	})
+	os.Args = []string{configurationInitializationApplicationNameConstant, "--no-config", "--config", configurationPath}
+
+	application := cli.NewApplication()
+	executionError := application.Execute()
+	require.EqualError(t, executionError, "--config cannot be combined with --no-config")
+	require.Empty(t, application.ConfigFileUsed())
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/dependencies"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 	rootutils "github.com/temirov/gix/internal/utils/roots"
 	"github.com/temirov/gix/internal/workflow"
@@ -152,6 +153,21 @@ func (builder *MessageCommandBuilder) run(command *cobra.Command, arguments []st
 	}
 
 	logger := resolveLogger(builder.LoggerProvider)
+	effectiveConfiguration := configuration
+	effectiveConfiguration.Roots = []string{repositoryPath}
+	effectiveConfiguration.DiffSource = string(diffSource)
+	effectiveConfiguration.MaxTokens = maxTokens
+	if temperaturePointer != nil {
+		effectiveConfiguration.Temperature = *temperaturePointer
+	}
+	effectiveConfiguration.Model = modelIdentifier
+	effectiveConfiguration.BaseURL = baseURL
+	effectiveConfiguration.APIKeyEnv = apiKeyEnv
+	effectiveConfiguration.TimeoutSeconds = int(timeout / time.Second)
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), struct {
+		Configuration MessageConfiguration
+		DryRun        bool
+	}{Configuration: effectiveConfiguration, DryRun: dryRun})
 	humanReadable := false
 	if builder.HumanReadableLoggingProvider != nil {
 		humanReadable = builder.HumanReadableLoggingProvider()
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+const (
+	configNamespaceUseNameConstant                     = "config"
+	configNamespaceShortDescriptionConstant            = "Configuration file commands"
+	configDiffDefaultsUseNameConstant                  = "diff-defaults"
+	configDiffDefaultsShortDescriptionConstant         = "Show how the configuration file differs from the embedded defaults"
+	configDiffDefaultsLongDescriptionConstant          = "config diff-defaults prints a unified diff from the configuration file in use to the defaults embedded in this gix build. Nothing is modified; copy the options you want into your file."
+	configDiffDefaultsNoFileErrorConstant              = "no configuration file is in use; run gix --init to create one"
+	configDiffDefaultsReadErrorTemplateConstant        = "unable to read configuration file %s: %w"
+	configDiffDefaultsRenderErrorTemplateConstant      = "unable to render configuration diff: %w"
+	configDiffDefaultsEmbeddedLabelConstant            = "embedded defaults v%d"
+	configDiffDefaultsIdenticalMessageConstant         = "configuration file matches the embedded defaults\n"
+	configDiffDefaultsLineSeparatorConstant            = "\n"
+	configDiffDefaultsContextLinesConstant             = 3
+	defaultsVersionOutdatedHintTemplateConstant        = "your config was generated from defaults v%d; v%d %s — run gix config diff-defaults\n"
+	defaultsVersionOutdatedGenericChangeConstant       = "updates the operation defaults"
+	defaultsVersionChangeSeparatorConstant             = "; "
+	defaultsVersionUnknownConstant                     = 0
+	defaultsVersionStampPatternConstant                = `(?m)^#\s*gix defaults version:\s*(\d+)\s*$`
+	defaultsVersionStampCaptureIndexConstant           = 1
+	defaultsVersionStampMinimumSubmatchCountConstant   = 2
+	configDiffDefaultsEmbeddedVersionInvalidConstant   = "embedded default configuration has no version stamp"
+	configDiffDefaultsFilePathLabelSeparatorConstant   = " "
+	configDiffDefaultsFileVersionLabelTemplateConstant = "(defaults v%d)"
+)
+
+var defaultsVersionStampPattern = regexp.MustCompile(defaultsVersionStampPatternConstant)
+
+// defaultsVersionChanges summarizes what each version of the embedded defaults adds. Bump the stamp at the top of
+// default_config.yaml and add an entry here whenever the embedded operation defaults change.
+var defaultsVersionChanges = map[int]string{
+	2: "adds repo-exec defaults",
+}
+
+// parseDefaultsVersion returns the defaults version recorded in a configuration file, or zero when the file carries no
+// stamp, as hand-written files and files generated before stamping do.
+func parseDefaultsVersion(configurationContent []byte) int {
+	matches := defaultsVersionStampPattern.FindSubmatch(configurationContent)
+	if len(matches) < defaultsVersionStampMinimumSubmatchCountConstant {
+		return defaultsVersionUnknownConstant
+	}
+	version, parseError := strconv.Atoi(string(matches[defaultsVersionStampCaptureIndexConstant]))
+	if parseError != nil {
+		return defaultsVersionUnknownConstant
+	}
+	return version
+}
+
+// embeddedDefaultsVersion returns the version stamped on the embedded default configuration.
+func embeddedDefaultsVersion() int {
+	return parseDefaultsVersion(embeddedDefaultConfigurationContent)
+}
+
+// defaultsVersionHint returns the one-line hint for a configuration file generated from older defaults, or an empty
+// string when the file is current or carries no stamp.
+func defaultsVersionHint(fileVersion int, embeddedVersion int) string {
+	if fileVersion == defaultsVersionUnknownConstant || fileVersion >= embeddedVersion {
+		return ""
+	}
+	changes := make([]string, 0, embeddedVersion-fileVersion)
+	for version := fileVersion + 1; version <= embeddedVersion; version++ {
+		if change := strings.TrimSpace(defaultsVersionChanges[version]); len(change) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	summary := defaultsVersionOutdatedGenericChangeConstant
+	if len(changes) > 0 {
+		summary = strings.Join(changes, defaultsVersionChangeSeparatorConstant)
+	}
+	return fmt.Sprintf(defaultsVersionOutdatedHintTemplateConstant, fileVersion, embeddedVersion, summary)
+}
+
+// reportOutdatedDefaults writes the defaults version hint for the configuration file in use. Unreadable files are left
+// to the configuration loader, which already reported them.
+func (application *Application) reportOutdatedDefaults(writer io.Writer) {
+	if writer == nil || application.configurationFilesSkipped {
+		return
+	}
+	configurationFilePath := strings.TrimSpace(application.configurationMetadata.ConfigFileUsed)
+	if len(configurationFilePath) == 0 {
+		return
+	}
+	configurationContent, readError := os.ReadFile(configurationFilePath)
+	if readError != nil {
+		return
+	}
+	if hint := defaultsVersionHint(parseDefaultsVersion(configurationContent), embeddedDefaultsVersion()); len(hint) > 0 {
+		fmt.Fprint(writer, hint)
+	}
+}
+
+func (application *Application) newConfigCommand() *cobra.Command {
+	configCommand := newNamespaceCommand(configNamespaceUseNameConstant, configNamespaceShortDescriptionConstant)
+	diffDefaultsCommand := &cobra.Command{
+		Use:           configDiffDefaultsUseNameConstant,
+		Short:         configDiffDefaultsShortDescriptionConstant,
+		Long:          configDiffDefaultsLongDescriptionConstant,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(command *cobra.Command, arguments []string) error {
+			configurationFilePath := strings.TrimSpace(application.configurationMetadata.ConfigFileUsed)
+			if application.configurationFilesSkipped || len(configurationFilePath) == 0 {
+				return errors.New(configDiffDefaultsNoFileErrorConstant)
+			}
+			configurationContent, readError := os.ReadFile(configurationFilePath)
+			if readError != nil {
+				return fmt.Errorf(configDiffDefaultsReadErrorTemplateConstant, configurationFilePath, readError)
+			}
+			embeddedContent, _ := EmbeddedDefaultConfiguration()
+			return writeDefaultsDiff(command.OutOrStdout(), configurationFilePath, configurationContent, embeddedContent)
+		},
+	}
+	configCommand.AddCommand(diffDefaultsCommand)
+	configCommand.AddCommand(application.newConfigSchemaCommand())
+	return configCommand
+}
+
+// writeDefaultsDiff prints a unified diff that turns the configuration file into the embedded defaults.
+func writeDefaultsDiff(writer io.Writer, configurationFilePath string, configurationContent []byte, embeddedContent []byte) error {
+	embeddedVersion := parseDefaultsVersion(embeddedContent)
+	if embeddedVersion == defaultsVersionUnknownConstant {
+		return errors.New(configDiffDefaultsEmbeddedVersionInvalidConstant)
+	}
+
+	fromLabel := configurationFilePath
+	if fileVersion := parseDefaultsVersion(configurationContent); fileVersion != defaultsVersionUnknownConstant {
+		fromLabel += configDiffDefaultsFilePathLabelSeparatorConstant + fmt.Sprintf(configDiffDefaultsFileVersionLabelTemplateConstant, fileVersion)
+	}
+
+	renderedDiff, diffError := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitDiffLines(configurationContent),
+		B:        splitDiffLines(embeddedContent),
+		FromFile: fromLabel,
+		ToFile:   fmt.Sprintf(configDiffDefaultsEmbeddedLabelConstant, embeddedVersion),
+		Context:  configDiffDefaultsContextLinesConstant,
+	})
+	if diffError != nil {
+		return fmt.Errorf(configDiffDefaultsRenderErrorTemplateConstant, diffError)
+	}
+	if len(renderedDiff) == 0 {
+		fmt.Fprint(writer, configDiffDefaultsIdenticalMessageConstant)
+		return nil
+	}
+	fmt.Fprint(writer, renderedDiff)
+	return nil
+}
+
+// splitDiffLines splits content into newline-terminated lines. difflib.SplitLines adds a phantom empty line after a
+// trailing newline, which would show up in every hunk that reaches the end of the file.
+func splitDiffLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), configDiffDefaultsLineSeparatorConstant)
+	if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
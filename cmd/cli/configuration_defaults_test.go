@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedDefaultsCarryVersionStamp(t *testing.T) {
+	require.Greater(t, embeddedDefaultsVersion(), defaultsVersionUnknownConstant)
+
+	renderedContent, renderError := renderConfigurationWizardAnswers(embeddedDefaultConfigurationContent, configurationWizardAnswers{LogFormat: "console", Roots: []string{"/tmp/projects"}})
+	require.NoError(t, renderError)
+	require.Equal(t, embeddedDefaultsVersion(), parseDefaultsVersion(renderedContent))
+}
+
+func TestDefaultsVersionHint(t *testing.T) {
+	testCases := []struct {
+		name            string
+		fileVersion     int
+		embeddedVersion int
+		changes         map[int]string
+		expectedHint    string
+	}{
+		{name: "unstamped_file_ignored", fileVersion: 0, embeddedVersion: 5},
+		{name: "current_file_ignored", fileVersion: 5, embeddedVersion: 5},
+		{name: "newer_file_ignored", fileVersion: 6, embeddedVersion: 5},
+		{
+			name:            "changes_summarized",
+			fileVersion:     3,
+			embeddedVersion: 5,
+			changes:         map[int]string{5: "adds branch-refresh options"},
+			expectedHint:    "your config was generated from defaults v3; v5 adds branch-refresh options — run gix config diff-defaults\n",
+		},
+		{
+			name:            "generic_summary_without_notes",
+			fileVersion:     1,
+			embeddedVersion: 2,
+			expectedHint:    "your config was generated from defaults v1; v2 updates the operation defaults — run gix config diff-defaults\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			originalChanges := defaultsVersionChanges
+			defaultsVersionChanges = testCase.changes
+			t.Cleanup(func() { defaultsVersionChanges = originalChanges })
+
+			require.Equal(t, testCase.expectedHint, defaultsVersionHint(testCase.fileVersion, testCase.embeddedVersion))
+		})
+	}
+}
+
+func TestParseDefaultsVersion(t *testing.T) {
+	require.Equal(t, 3, parseDefaultsVersion([]byte("# gix defaults version: 3\ncommon:\n  log_level: error\n")))
+	require.Equal(t, defaultsVersionUnknownConstant, parseDefaultsVersion([]byte("common:\n  log_level: error\n")))
+}
+
+func TestWriteDefaultsDiff(t *testing.T) {
+	embeddedContent := []byte("# gix defaults version: 2\ncommon:\n  log_level: error\n  run_hooks: false\n")
+	testCases := []struct {
+		name           string
+		content        []byte
+		expectedOutput string
+	}{
+		{name: "identical", content: embeddedContent, expectedOutput: "configuration file matches the embedded defaults\n"},
+		{
+			name:    "missing_option",
+			content: []byte("# gix defaults version: 1\ncommon:\n  log_level: error\n"),
+			expectedOutput: "--- config.yaml (defaults v1)\n+++ embedded defaults v2\n@@ -1,3 +1,4 @@\n" +
+				"-# gix defaults version: 1\n+# gix defaults version: 2\n common:\n   log_level: error\n+  run_hooks: false\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var output bytes.Buffer
+			require.NoError(t, writeDefaultsDiff(&output, "config.yaml", testCase.content, embeddedContent))
+			require.Equal(t, testCase.expectedOutput, output.String())
+		})
+	}
+}
+
+func TestReportOutdatedDefaults(t *testing.T) {
+	configurationPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configurationPath, []byte("# gix defaults version: 0\n"), 0o600))
+
+	application := &Application{}
+	application.configurationMetadata.ConfigFileUsed = configurationPath
+
+	var output bytes.Buffer
+	application.reportOutdatedDefaults(&output)
+	require.Empty(t, output.String())
+
+	require.NoError(t, os.WriteFile(configurationPath, embeddedDefaultConfigurationContent, 0o600))
+	application.reportOutdatedDefaults(&output)
+	require.Empty(t, output.String())
+}
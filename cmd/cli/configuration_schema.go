@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	changelogcmd "github.com/temirov/gix/cmd/cli/changelog"
+	commitcmd "github.com/temirov/gix/cmd/cli/commit"
+	"github.com/temirov/gix/cmd/cli/repos"
+	releasecmd "github.com/temirov/gix/cmd/cli/repos/release"
+	workflowcmd "github.com/temirov/gix/cmd/cli/workflow"
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/branches"
+	branchcdcmd "github.com/temirov/gix/internal/branches/cd"
+	branchrefresh "github.com/temirov/gix/internal/branches/refresh"
+	"github.com/temirov/gix/internal/commitmsg"
+	"github.com/temirov/gix/internal/migrate"
+	"github.com/temirov/gix/internal/packages"
+	"github.com/temirov/gix/internal/repos/fanout"
+	"github.com/temirov/gix/internal/repos/inventory"
+	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	configSchemaUseNameConstant          = "schema"
+	configSchemaShortDescriptionConstant = "Print a JSON Schema for the configuration file"
+	configSchemaLongDescriptionConstant  = "config schema prints a JSON Schema describing the configuration file: the common block, aliases, and the with: options of every operation. Save it next to config.yaml and point yaml-language-server at it to get completion and validation in editors."
+	configSchemaRenderErrorTemplate      = "unable to render configuration schema: %w"
+	configSchemaDialectConstant          = "http://json-schema.org/draft-07/schema#"
+	configSchemaTitleConstant            = "gix configuration"
+	configSchemaIndentConstant           = "  "
+	configSchemaTagNameConstant          = "mapstructure"
+	configSchemaTagSkipConstant          = "-"
+	configSchemaTagOptionSeparator       = ","
+	configSchemaSquashOptionConstant     = "squash"
+	configSchemaPathSeparatorConstant    = "."
+	configSchemaOperationPatternTemplate = "^(%s)(@.+)?$"
+	configSchemaAlternationConstant      = "|"
+	configSchemaOperationsKeyConstant    = "operations"
+	configSchemaOperationNameKeyConstant = "operation"
+	configSchemaOperationWithKeyConstant = "with"
+	configSchemaTypeObjectConstant       = "object"
+	configSchemaTypeArrayConstant        = "array"
+	configSchemaTypeStringConstant       = "string"
+	configSchemaTypeBooleanConstant      = "boolean"
+	configSchemaTypeIntegerConstant      = "integer"
+	configSchemaTypeNumberConstant       = "number"
+)
+
+// configurationSchemaOperation pairs an operation name with the configuration struct its with: block decodes into.
+type configurationSchemaOperation struct {
+	name          string
+	configuration any
+}
+
+// configurationSchemaOperations lists every operation that reads defaults from the configuration file.
+func configurationSchemaOperations() []configurationSchemaOperation {
+	toolsConfiguration := repos.DefaultToolsConfiguration()
+	return []configurationSchemaOperation{
+		{name: auditOperationNameConstant, configuration: audit.CommandConfiguration{}},
+		{name: packagesPurgeOperationNameConstant, configuration: packages.DefaultConfiguration().Purge},
+		{name: branchCleanupOperationNameConstant, configuration: branches.CommandConfiguration{}},
+		{name: reposRemotesOperationNameConstant, configuration: toolsConfiguration.Remotes},
+		{name: reposProtocolOperationNameConstant, configuration: toolsConfiguration.Protocol},
+		{name: repoHistoryOperationNameConstant, configuration: toolsConfiguration.Remove},
+		{name: repoFilesReplaceOperationNameConstant, configuration: toolsConfiguration.Replace},
+		{name: reposListOperationNameConstant, configuration: toolsConfiguration.List},
+		{name: reposExecOperationNameConstant, configuration: toolsConfiguration.Exec},
+		{name: reposRenameOperationNameConstant, configuration: toolsConfiguration.Rename},
+		{name: repoReleaseOperationNameConstant, configuration: releasecmd.CommandConfiguration{}},
+		{name: workflowCommandOperationNameConstant, configuration: workflowcmd.CommandConfiguration{}},
+		{name: branchRefreshOperationNameConstant, configuration: branchrefresh.CommandConfiguration{}},
+		{name: branchDefaultOperationNameConstant, configuration: migrate.CommandConfiguration{}},
+		{name: branchChangeOperationNameConstant, configuration: branchcdcmd.CommandConfiguration{}},
+		{name: commitMessageOperationNameConstant, configuration: commitcmd.MessageConfiguration{}},
+		{name: changelogMessageOperationNameConstant, configuration: changelogcmd.MessageConfiguration{}},
+	}
+}
+
+// configurationSchemaEnums lists the allowed values of enum-like options, keyed by "common.<key>" or
+// "<operation>.<key>".
+func configurationSchemaEnums() map[string][]string {
+	protocols := []string{string(shared.RemoteProtocolGit), string(shared.RemoteProtocolSSH), string(shared.RemoteProtocolHTTPS)}
+	return map[string][]string{
+		"common.log_level":                                    {string(utils.LogLevelDebug), string(utils.LogLevelInfo), string(utils.LogLevelWarn), string(utils.LogLevelError)},
+		"common.log_format":                                   {string(utils.LogFormatStructured), string(utils.LogFormatConsole), string(utils.LogFormatJSON)},
+		auditOperationNameConstant + ".format":                audit.ReportFormats(),
+		auditOperationNameConstant + ".sort":                  {string(audit.ReportSortPath), string(audit.ReportSortOwner), string(audit.ReportSortActivity), string(audit.ReportSortIssues), string(audit.ReportSortScore)},
+		auditOperationNameConstant + ".fix_protocol":          protocols,
+		reposProtocolOperationNameConstant + ".from":          protocols,
+		reposProtocolOperationNameConstant + ".to":            protocols,
+		reposListOperationNameConstant + ".format":            inventory.OutputFormats(),
+		reposExecOperationNameConstant + ".format":            fanout.OutputFormats(),
+		branchDefaultOperationNameConstant + ".retain_source": {string(migrate.SourceRetentionDelete), string(migrate.SourceRetentionArchive)},
+		branchDefaultOperationNameConstant + ".phase":         {string(migrate.MigrationPhaseAnnounce), string(migrate.MigrationPhaseEnforce)},
+		commitMessageOperationNameConstant + ".diff_source":   {string(commitmsg.DiffSourceStaged), string(commitmsg.DiffSourceWorktree)},
+	}
+}
+
+func (application *Application) newConfigSchemaCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           configSchemaUseNameConstant,
+		Short:         configSchemaShortDescriptionConstant,
+		Long:          configSchemaLongDescriptionConstant,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(command *cobra.Command, arguments []string) error {
+			return writeConfigurationSchema(command.OutOrStdout())
+		},
+	}
+}
+
+// writeConfigurationSchema prints the configuration JSON Schema as indented JSON.
+func writeConfigurationSchema(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", configSchemaIndentConstant)
+	if encodeError := encoder.Encode(buildConfigurationSchema()); encodeError != nil {
+		return fmt.Errorf(configSchemaRenderErrorTemplate, encodeError)
+	}
+	return nil
+}
+
+// buildConfigurationSchema derives the configuration JSON Schema from the mapstructure tags of
+// ApplicationConfiguration and of each operation's configuration struct. Unknown keys are rejected everywhere, so
+// editors flag typos as they are typed.
+func buildConfigurationSchema() map[string]any {
+	enums := configurationSchemaEnums()
+	schema := schemaForType(reflect.TypeOf(ApplicationConfiguration{}), "", enums)
+	schema["$schema"] = configSchemaDialectConstant
+	schema["title"] = configSchemaTitleConstant
+
+	operations := configurationSchemaOperations()
+	operationNames := make([]string, 0, len(operations))
+	conditions := make([]any, 0, len(operations))
+	for _, operation := range operations {
+		operationNames = append(operationNames, regexp.QuoteMeta(operation.name))
+		conditions = append(conditions, map[string]any{
+			"if": map[string]any{
+				"required": []string{configSchemaOperationNameKeyConstant},
+				"properties": map[string]any{
+					configSchemaOperationNameKeyConstant: map[string]any{"pattern": fmt.Sprintf(configSchemaOperationPatternTemplate, regexp.QuoteMeta(operation.name))},
+				},
+			},
+			"then": map[string]any{
+				"properties": map[string]any{
+					configSchemaOperationWithKeyConstant: schemaForType(reflect.TypeOf(operation.configuration), operation.name, enums),
+				},
+			},
+		})
+	}
+
+	properties := schema["properties"].(map[string]any)
+	operationSchema := properties[configSchemaOperationsKeyConstant].(map[string]any)["items"].(map[string]any)
+	operationSchema["required"] = []string{configSchemaOperationNameKeyConstant}
+	operationSchema["allOf"] = conditions
+	operationProperties := operationSchema["properties"].(map[string]any)
+	operationProperties[configSchemaOperationNameKeyConstant].(map[string]any)["pattern"] = fmt.Sprintf(configSchemaOperationPatternTemplate, strings.Join(operationNames, configSchemaAlternationConstant))
+	return schema
+}
+
+// schemaForType describes a Go type as a JSON Schema fragment. Struct fields are named by their mapstructure tags, and
+// path names the value for enum lookups.
+func schemaForType(valueType reflect.Type, path string, enums map[string][]string) map[string]any {
+	for valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+
+	var schema map[string]any
+	switch valueType.Kind() {
+	case reflect.Struct:
+		properties := map[string]any{}
+		collectStructProperties(valueType, path, enums, properties)
+		schema = map[string]any{"type": configSchemaTypeObjectConstant, "properties": properties, "additionalProperties": false}
+	case reflect.Slice, reflect.Array:
+		schema = map[string]any{"type": configSchemaTypeArrayConstant, "items": schemaForType(valueType.Elem(), path, enums)}
+	case reflect.Map:
+		schema = map[string]any{"type": configSchemaTypeObjectConstant, "additionalProperties": schemaForType(valueType.Elem(), path, enums)}
+	case reflect.Bool:
+		schema = map[string]any{"type": configSchemaTypeBooleanConstant}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema = map[string]any{"type": configSchemaTypeIntegerConstant}
+	case reflect.Float32, reflect.Float64:
+		schema = map[string]any{"type": configSchemaTypeNumberConstant}
+	case reflect.String:
+		schema = map[string]any{"type": configSchemaTypeStringConstant}
+	default:
+		schema = map[string]any{}
+	}
+
+	if allowedValues, enumerated := enums[path]; enumerated && valueType.Kind() == reflect.String {
+		schema["enum"] = allowedValues
+	}
+	return schema
+}
+
+func collectStructProperties(structType reflect.Type, path string, enums map[string][]string, properties map[string]any) {
+	for fieldIndex := 0; fieldIndex < structType.NumField(); fieldIndex++ {
+		field := structType.Field(fieldIndex)
+		if !field.IsExported() {
+			continue
+		}
+		tagName, tagOptions, _ := strings.Cut(field.Tag.Get(configSchemaTagNameConstant), configSchemaTagOptionSeparator)
+		if tagName == configSchemaTagSkipConstant {
+			continue
+		}
+		if strings.Contains(tagOptions, configSchemaSquashOptionConstant) {
+			collectStructProperties(field.Type, path, enums, properties)
+			continue
+		}
+		if len(tagName) == 0 {
+			tagName = strings.ToLower(field.Name)
+		}
+		fieldPath := tagName
+		if len(path) > 0 {
+			fieldPath = path + configSchemaPathSeparatorConstant + tagName
+		}
+		properties[tagName] = schemaForType(field.Type, fieldPath, enums)
+	}
+}
@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConfigurationSchemaValidatesEmbeddedDefaults(t *testing.T) {
+	schema := renderedConfigurationSchema(t)
+
+	var configuration any
+	require.NoError(t, yaml.Unmarshal(embeddedDefaultConfigurationContent, &configuration))
+	require.Empty(t, validateAgainstSchema(schema, configuration, "$"))
+}
+
+func TestConfigurationSchemaRejectsInvalidConfiguration(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configuration string
+		expectedError string
+	}{
+		{
+			name:          "unknown_common_key",
+			configuration: "common:\n  log_levle: debug\n",
+			expectedError: "$.common: unknown key log_levle",
+		},
+		{
+			name:          "log_level_outside_enum",
+			configuration: "common:\n  log_level: verbose\n",
+			expectedError: "$.common.log_level: verbose is not one of [debug info warn error]",
+		},
+		{
+			name:          "unknown_operation",
+			configuration: "operations:\n  - operation: audits\n",
+			expectedError: "$.operations[0].operation: audits does not match",
+		},
+		{
+			name:          "unknown_operation_option",
+			configuration: "operations:\n  - operation: repo-prs-purge\n    with:\n      limt: 5\n",
+			expectedError: "$.operations[0].with: unknown key limt",
+		},
+		{
+			name:          "variant_operation_option",
+			configuration: "operations:\n  - operation: repo-protocol-convert@ci\n    with:\n      to: svn\n",
+			expectedError: "$.operations[0].with.to: svn is not one of [git ssh https]",
+		},
+		{
+			name:          "wrong_type",
+			configuration: "operations:\n  - operation: repo-exec\n    with:\n      jobs: many\n",
+			expectedError: "$.operations[0].with.jobs: expected integer",
+		},
+	}
+
+	schema := renderedConfigurationSchema(t)
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var configuration any
+			require.NoError(t, yaml.Unmarshal([]byte(testCase.configuration), &configuration))
+			violations := validateAgainstSchema(schema, configuration, "$")
+			require.NotEmpty(t, violations)
+			require.Contains(t, fmt.Sprint(violations), testCase.expectedError)
+		})
+	}
+}
+
+func TestConfigurationSchemaCoversEveryOperation(t *testing.T) {
+	schema := renderedConfigurationSchema(t)
+	operationSchema := schema["properties"].(map[string]any)["operations"].(map[string]any)["items"].(map[string]any)
+	require.Len(t, operationSchema["allOf"], len(configurationSchemaOperations()))
+
+	pattern := regexp.MustCompile(operationSchema["properties"].(map[string]any)["operation"].(map[string]any)["pattern"].(string))
+	for _, operation := range configurationSchemaOperations() {
+		require.True(t, pattern.MatchString(operation.name), operation.name)
+		require.True(t, pattern.MatchString(operation.name+"@ci"), operation.name)
+	}
+}
+
+func renderedConfigurationSchema(t *testing.T) map[string]any {
+	t.Helper()
+	var output bytes.Buffer
+	require.NoError(t, writeConfigurationSchema(&output))
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(output.Bytes(), &schema))
+	return schema
+}
+
+// validateAgainstSchema checks a decoded YAML document against the draft-07 subset the schema generator emits: type,
+// properties, additionalProperties, items, enum, pattern, required, allOf, and if/then.
+func validateAgainstSchema(schema map[string]any, value any, path string) []string {
+	violations := []string{}
+	if expectedType, typed := schema["type"].(string); typed && !matchesSchemaType(expectedType, value) {
+		return append(violations, fmt.Sprintf("%s: expected %s", path, expectedType))
+	}
+	if allowedValues, enumerated := schema["enum"].([]any); enumerated {
+		allowed := false
+		for _, allowedValue := range allowedValues {
+			allowed = allowed || allowedValue == value
+		}
+		if !allowed {
+			violations = append(violations, fmt.Sprintf("%s: %v is not one of %v", path, value, allowedValues))
+		}
+	}
+	if pattern, patterned := schema["pattern"].(string); patterned {
+		if text, isText := value.(string); isText && !regexp.MustCompile(pattern).MatchString(text) {
+			violations = append(violations, fmt.Sprintf("%s: %s does not match %s", path, text, pattern))
+		}
+	}
+	if object, isObject := value.(map[string]any); isObject {
+		for _, requiredKey := range asStrings(schema["required"]) {
+			if _, present := object[requiredKey]; !present {
+				violations = append(violations, fmt.Sprintf("%s: missing %s", path, requiredKey))
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPath := path + "." + key
+			if propertySchema, declared := properties[key].(map[string]any); declared {
+				violations = append(violations, validateAgainstSchema(propertySchema, object[key], childPath)...)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					violations = append(violations, fmt.Sprintf("%s: unknown key %s", path, key))
+				}
+			case map[string]any:
+				violations = append(violations, validateAgainstSchema(additional, object[key], childPath)...)
+			}
+		}
+	}
+	if items, isArray := value.([]any); isArray {
+		if itemSchema, declared := schema["items"].(map[string]any); declared {
+			for itemIndex, item := range items {
+				violations = append(violations, validateAgainstSchema(itemSchema, item, fmt.Sprintf("%s[%d]", path, itemIndex))...)
+			}
+		}
+	}
+	if conditions, combined := schema["allOf"].([]any); combined {
+		for _, condition := range conditions {
+			conditionSchema := condition.(map[string]any)
+			if ifSchema, conditional := conditionSchema["if"].(map[string]any); conditional {
+				if len(validateAgainstSchema(ifSchema, value, path)) > 0 {
+					continue
+				}
+				violations = append(violations, validateAgainstSchema(conditionSchema["then"].(map[string]any), value, path)...)
+				continue
+			}
+			violations = append(violations, validateAgainstSchema(conditionSchema, value, path)...)
+		}
+	}
+	return violations
+}
+
+func matchesSchemaType(expectedType string, value any) bool {
+	switch expectedType {
+	case "object":
+		_, matches := value.(map[string]any)
+		return matches
+	case "array":
+		_, matches := value.([]any)
+		return matches
+	case "string":
+		_, matches := value.(string)
+		return matches
+	case "boolean":
+		_, matches := value.(bool)
+		return matches
+	case "integer":
+		_, matches := value.(int)
+		return matches
+	case "number":
+		switch value.(type) {
+		case int, float64:
+			return true
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func asStrings(value any) []string {
+	values, _ := value.([]any)
+	strings := make([]string, 0, len(values))
+	for _, item := range values {
+		if text, isText := item.(string); isText {
+			strings = append(strings, text)
+		}
+	}
+	return strings
+}
@@ -0,0 +1,305 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/temirov/gix/internal/utils"
+	pathutils "github.com/temirov/gix/internal/utils/path"
+)
+
+const (
+	configurationWizardRootsPromptTemplateConstant      = "Repository roots (comma-separated, ~ expands to your home directory) [%s]: "
+	configurationWizardLogFormatPromptTemplateConstant  = "Log format (%s) [%s]: "
+	configurationWizardRemotePromptTemplateConstant     = "Default remote name [%s]: "
+	configurationWizardDryRunPromptConstant             = "Enable dry-run by default? (y/N): "
+	configurationWizardInvalidAnswerTemplateConstant    = "  %s\n"
+	configurationWizardRootMissingTemplateConstant      = "root %s (%s) does not exist or is not a directory"
+	configurationWizardLogFormatInvalidTemplateConstant = "log format %q is not supported (expected %s)"
+	configurationWizardRemoteInvalidTemplateConstant    = "remote name %q must not contain whitespace"
+	configurationWizardDryRunInvalidTemplateConstant    = "answer %q is not yes or no"
+	configurationWizardAnswerMissingTemplateConstant    = "configuration wizard ended before a valid answer: %s"
+	configurationWizardRenderErrorTemplateConstant      = "unable to render configuration wizard answers: %w"
+	configurationWizardRootSeparatorConstant            = ","
+	configurationWizardChoiceSeparatorConstant          = ", "
+	configurationWizardDefaultRootConstant              = "."
+	configurationWizardDefaultRemoteConstant            = "origin"
+	configurationWizardYesShortConstant                 = "y"
+	configurationWizardYesLongConstant                  = "yes"
+	configurationWizardNoShortConstant                  = "n"
+	configurationWizardNoLongConstant                   = "no"
+	configurationWizardCommonKeyConstant                = "common"
+	configurationWizardOperationsKeyConstant            = "operations"
+	configurationWizardWithKeyConstant                  = "with"
+	configurationWizardLogFormatKeyConstant             = "log_format"
+	configurationWizardDryRunKeyConstant                = "dry_run"
+	configurationWizardRootsKeyConstant                 = "roots"
+	configurationWizardRemoteKeyConstant                = "remote"
+	configurationWizardYAMLIndentConstant               = 2
+	configurationWizardStringTagConstant                = "!!str"
+	configurationWizardBooleanTagConstant               = "!!bool"
+	configurationWizardSequenceTagConstant              = "!!seq"
+)
+
+var configurationWizardLogFormats = []string{
+	string(utils.LogFormatConsole),
+	string(utils.LogFormatStructured),
+	string(utils.LogFormatJSON),
+}
+
+// configurationWizardAnswers captures the choices collected by the interactive initialization wizard.
+type configurationWizardAnswers struct {
+	Roots      []string
+	LogFormat  string
+	RemoteName string
+	DryRun     bool
+}
+
+// configurationWizard prompts for the settings new users most often change in the embedded configuration.
+type configurationWizard struct {
+	reader       *bufio.Reader
+	writer       io.Writer
+	homeExpander *pathutils.HomeExpander
+}
+
+func newConfigurationWizard(input io.Reader, output io.Writer, homeExpander *pathutils.HomeExpander) *configurationWizard {
+	if homeExpander == nil {
+		homeExpander = pathutils.NewHomeExpander()
+	}
+	return &configurationWizard{reader: bufio.NewReader(input), writer: output, homeExpander: homeExpander}
+}
+
+// Run asks every question in turn, re-prompting after invalid answers until the input ends.
+func (wizard *configurationWizard) Run() (configurationWizardAnswers, error) {
+	var answers configurationWizardAnswers
+
+	roots, rootsError := wizard.ask(
+		fmt.Sprintf(configurationWizardRootsPromptTemplateConstant, configurationWizardDefaultRootConstant),
+		configurationWizardDefaultRootConstant,
+		wizard.validateRoots,
+	)
+	if rootsError != nil {
+		return configurationWizardAnswers{}, rootsError
+	}
+	answers.Roots = splitConfigurationWizardRoots(roots)
+
+	logFormat, logFormatError := wizard.ask(
+		fmt.Sprintf(
+			configurationWizardLogFormatPromptTemplateConstant,
+			strings.Join(configurationWizardLogFormats, configurationWizardChoiceSeparatorConstant),
+			string(utils.LogFormatConsole),
+		),
+		string(utils.LogFormatConsole),
+		validateConfigurationWizardLogFormat,
+	)
+	if logFormatError != nil {
+		return configurationWizardAnswers{}, logFormatError
+	}
+	answers.LogFormat = strings.ToLower(logFormat)
+
+	remoteName, remoteError := wizard.ask(
+		fmt.Sprintf(configurationWizardRemotePromptTemplateConstant, configurationWizardDefaultRemoteConstant),
+		configurationWizardDefaultRemoteConstant,
+		validateConfigurationWizardRemote,
+	)
+	if remoteError != nil {
+		return configurationWizardAnswers{}, remoteError
+	}
+	answers.RemoteName = remoteName
+
+	dryRun, dryRunError := wizard.ask(configurationWizardDryRunPromptConstant, configurationWizardNoShortConstant, validateConfigurationWizardYesNo)
+	if dryRunError != nil {
+		return configurationWizardAnswers{}, dryRunError
+	}
+	answers.DryRun = isConfigurationWizardYes(dryRun)
+
+	return answers, nil
+}
+
+func (wizard *configurationWizard) ask(prompt string, defaultAnswer string, validate func(string) error) (string, error) {
+	for {
+		if _, writeError := io.WriteString(wizard.writer, prompt); writeError != nil {
+			return "", writeError
+		}
+
+		response, readError := wizard.reader.ReadString('\n')
+		if readError != nil && !errors.Is(readError, io.EOF) {
+			return "", readError
+		}
+
+		answer := strings.TrimSpace(response)
+		if len(answer) == 0 {
+			answer = defaultAnswer
+		}
+
+		validationError := validate(answer)
+		if validationError == nil {
+			return answer, nil
+		}
+		if readError != nil {
+			return "", fmt.Errorf(configurationWizardAnswerMissingTemplateConstant, validationError.Error())
+		}
+		if _, writeError := fmt.Fprintf(wizard.writer, configurationWizardInvalidAnswerTemplateConstant, validationError.Error()); writeError != nil {
+			return "", writeError
+		}
+	}
+}
+
+func (wizard *configurationWizard) validateRoots(answer string) error {
+	roots := splitConfigurationWizardRoots(answer)
+	if len(roots) == 0 {
+		roots = []string{configurationWizardDefaultRootConstant}
+	}
+	for _, root := range roots {
+		expandedRoot := wizard.homeExpander.Expand(root)
+		rootInfo, statError := os.Stat(expandedRoot)
+		if statError != nil || !rootInfo.IsDir() {
+			return fmt.Errorf(configurationWizardRootMissingTemplateConstant, root, expandedRoot)
+		}
+	}
+	return nil
+}
+
+func splitConfigurationWizardRoots(answer string) []string {
+	roots := make([]string, 0)
+	for _, candidate := range strings.Split(answer, configurationWizardRootSeparatorConstant) {
+		trimmedCandidate := strings.TrimSpace(candidate)
+		if len(trimmedCandidate) > 0 {
+			roots = append(roots, trimmedCandidate)
+		}
+	}
+	return roots
+}
+
+func validateConfigurationWizardLogFormat(answer string) error {
+	normalizedAnswer := strings.ToLower(answer)
+	for _, logFormat := range configurationWizardLogFormats {
+		if normalizedAnswer == logFormat {
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		configurationWizardLogFormatInvalidTemplateConstant,
+		answer,
+		strings.Join(configurationWizardLogFormats, configurationWizardChoiceSeparatorConstant),
+	)
+}
+
+func validateConfigurationWizardRemote(answer string) error {
+	if strings.ContainsAny(answer, " \t") {
+		return fmt.Errorf(configurationWizardRemoteInvalidTemplateConstant, answer)
+	}
+	return nil
+}
+
+func validateConfigurationWizardYesNo(answer string) error {
+	switch strings.ToLower(answer) {
+	case configurationWizardYesShortConstant, configurationWizardYesLongConstant, configurationWizardNoShortConstant, configurationWizardNoLongConstant:
+		return nil
+	default:
+		return fmt.Errorf(configurationWizardDryRunInvalidTemplateConstant, answer)
+	}
+}
+
+func isConfigurationWizardYes(answer string) bool {
+	normalizedAnswer := strings.ToLower(answer)
+	return normalizedAnswer == configurationWizardYesShortConstant || normalizedAnswer == configurationWizardYesLongConstant
+}
+
+// renderConfigurationWizardAnswers writes the wizard answers into the configuration template.
+// Every operation receives the chosen roots, and operations whose remote defaults to origin receive the chosen remote.
+func renderConfigurationWizardAnswers(configurationContent []byte, answers configurationWizardAnswers) ([]byte, error) {
+	var document yaml.Node
+	if decodeError := yaml.Unmarshal(configurationContent, &document); decodeError != nil {
+		return nil, fmt.Errorf(configurationWizardRenderErrorTemplateConstant, decodeError)
+	}
+	if len(document.Content) == 0 {
+		return nil, fmt.Errorf(configurationWizardRenderErrorTemplateConstant, errors.New(configurationInitializationContentUnavailableErrorConstant))
+	}
+	rootNode := document.Content[0]
+
+	if commonNode := configurationWizardMappingValue(rootNode, configurationWizardCommonKeyConstant); commonNode != nil {
+		setConfigurationWizardScalar(commonNode, configurationWizardLogFormatKeyConstant, configurationWizardStringTagConstant, answers.LogFormat)
+		setConfigurationWizardScalar(commonNode, configurationWizardDryRunKeyConstant, configurationWizardBooleanTagConstant, fmt.Sprintf("%t", answers.DryRun))
+	}
+
+	if operationsNode := configurationWizardMappingValue(rootNode, configurationWizardOperationsKeyConstant); operationsNode != nil {
+		for _, operationNode := range operationsNode.Content {
+			withNode := configurationWizardMappingValue(operationNode, configurationWizardWithKeyConstant)
+			if withNode == nil {
+				continue
+			}
+			if rootsNode := configurationWizardMappingValue(withNode, configurationWizardRootsKeyConstant); rootsNode != nil && len(answers.Roots) > 0 {
+				rootsNode.Kind = yaml.SequenceNode
+				rootsNode.Tag = configurationWizardSequenceTagConstant
+				rootsNode.Value = ""
+				rootsNode.Content = make([]*yaml.Node, 0, len(answers.Roots))
+				for _, root := range answers.Roots {
+					rootsNode.Content = append(rootsNode.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: configurationWizardStringTagConstant, Value: root})
+				}
+			}
+			remoteNode := configurationWizardMappingValue(withNode, configurationWizardRemoteKeyConstant)
+			if remoteNode != nil && remoteNode.Value == configurationWizardDefaultRemoteConstant && len(answers.RemoteName) > 0 {
+				remoteNode.Value = answers.RemoteName
+			}
+		}
+	}
+
+	var renderedContent bytes.Buffer
+	encoder := yaml.NewEncoder(&renderedContent)
+	encoder.SetIndent(configurationWizardYAMLIndentConstant)
+	if encodeError := encoder.Encode(&document); encodeError != nil {
+		return nil, fmt.Errorf(configurationWizardRenderErrorTemplateConstant, encodeError)
+	}
+	if closeError := encoder.Close(); closeError != nil {
+		return nil, fmt.Errorf(configurationWizardRenderErrorTemplateConstant, closeError)
+	}
+	return renderedContent.Bytes(), nil
+}
+
+func configurationWizardMappingValue(mappingNode *yaml.Node, key string) *yaml.Node {
+	if mappingNode == nil || mappingNode.Kind != yaml.MappingNode {
+		return nil
+	}
+	for contentIndex := 0; contentIndex+1 < len(mappingNode.Content); contentIndex += 2 {
+		if mappingNode.Content[contentIndex].Value == key {
+			return mappingNode.Content[contentIndex+1]
+		}
+	}
+	return nil
+}
+
+func setConfigurationWizardScalar(mappingNode *yaml.Node, key string, tag string, value string) {
+	valueNode := configurationWizardMappingValue(mappingNode, key)
+	if valueNode == nil {
+		mappingNode.Content = append(
+			mappingNode.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: configurationWizardStringTagConstant, Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value},
+		)
+		return
+	}
+	valueNode.Kind = yaml.ScalarNode
+	valueNode.Tag = tag
+	valueNode.Value = value
+}
+
+// isInteractiveTerminal reports whether the input is attached to a character device such as a terminal.
+func isInteractiveTerminal(input io.Reader) bool {
+	inputFile, isFile := input.(*os.File)
+	if !isFile {
+		return false
+	}
+	fileInfo, statError := inputFile.Stat()
+	if statError != nil {
+		return false
+	}
+	return fileInfo.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	pathutils "github.com/temirov/gix/internal/utils/path"
+)
+
+func TestConfigurationWizardRun(testInstance *testing.T) {
+	homeDirectory := testInstance.TempDir()
+	require.NoError(testInstance, os.Mkdir(filepath.Join(homeDirectory, "src"), 0o755))
+	homeExpander := pathutils.NewHomeExpanderWithProvider(func() (string, error) { return homeDirectory, nil })
+
+	testCases := []struct {
+		name            string
+		input           string
+		expectedAnswers configurationWizardAnswers
+		expectedError   string
+		expectedOutput  string
+	}{
+		{
+			name:            "defaults",
+			input:           "\n\n\n\n",
+			expectedAnswers: configurationWizardAnswers{Roots: []string{"."}, LogFormat: "console", RemoteName: "origin"},
+		},
+		{
+			name:            "custom_answers_with_tilde_roots",
+			input:           "~/src, ~\nJSON\nupstream\ny\n",
+			expectedAnswers: configurationWizardAnswers{Roots: []string{"~/src", "~"}, LogFormat: "json", RemoteName: "upstream", DryRun: true},
+		},
+		{
+			name:            "invalid_answers_are_reprompted",
+			input:           "~/missing\n~/src\nxml\nstructured\nmy remote\norigin\nmaybe\nno\n",
+			expectedAnswers: configurationWizardAnswers{Roots: []string{"~/src"}, LogFormat: "structured", RemoteName: "origin"},
+			expectedOutput:  "root ~/missing (" + filepath.Join(homeDirectory, "missing") + ") does not exist or is not a directory",
+		},
+		{
+			name:          "input_ends_on_invalid_answer",
+			input:         "~/missing",
+			expectedError: "configuration wizard ended before a valid answer: root ~/missing",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			var output bytes.Buffer
+			answers, runError := newConfigurationWizard(strings.NewReader(testCase.input), &output, homeExpander).Run()
+			if len(testCase.expectedError) > 0 {
+				require.ErrorContains(subtest, runError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, runError)
+			require.Equal(subtest, testCase.expectedAnswers, answers)
+			require.Contains(subtest, output.String(), "Enable dry-run by default? (y/N): ")
+			if len(testCase.expectedOutput) > 0 {
+				require.Contains(subtest, output.String(), testCase.expectedOutput)
+			}
+		})
+	}
+}
+
+func TestRenderConfigurationWizardAnswers(testInstance *testing.T) {
+	embeddedContent, _ := EmbeddedDefaultConfiguration()
+	renderedContent, renderError := renderConfigurationWizardAnswers(embeddedContent, configurationWizardAnswers{
+		Roots:      []string{"~/src", "~/work"},
+		LogFormat:  "json",
+		RemoteName: "upstream",
+		DryRun:     true,
+	})
+	require.NoError(testInstance, renderError)
+
+	var rendered struct {
+		Common struct {
+			LogFormat string `yaml:"log_format"`
+			DryRun    bool   `yaml:"dry_run"`
+		} `yaml:"common"`
+		Operations []struct {
+			Operation string         `yaml:"operation"`
+			With      map[string]any `yaml:"with"`
+		} `yaml:"operations"`
+	}
+	require.NoError(testInstance, yaml.Unmarshal(renderedContent, &rendered))
+	require.Equal(testInstance, "json", rendered.Common.LogFormat)
+	require.True(testInstance, rendered.Common.DryRun)
+	require.NotEmpty(testInstance, rendered.Operations)
+
+	for _, operation := range rendered.Operations {
+		if roots, hasRoots := operation.With["roots"]; hasRoots {
+			require.Equal(testInstance, []any{"~/src", "~/work"}, roots, operation.Operation)
+		}
+		if remote, hasRemote := operation.With["remote"]; hasRemote {
+			require.NotEqual(testInstance, "origin", remote, operation.Operation)
+		}
+		if operation.Operation == "repo-history-remove" {
+			require.Equal(testInstance, "", operation.With["remote"])
+		}
+		if operation.Operation == "repo-prs-purge" {
+			require.Equal(testInstance, "upstream", operation.With["remote"])
+		}
+	}
+}
+
+func TestConfigurationInitializationInteractiveMode(testInstance *testing.T) {
+	embeddedContent, _ := EmbeddedDefaultConfiguration()
+
+	testCases := []struct {
+		name             string
+		terminal         bool
+		expectedDefaults bool
+	}{
+		{name: "terminal_prompts", terminal: true},
+		{name: "non_terminal_falls_back", terminal: false, expectedDefaults: true},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			workingDirectory := subtest.TempDir()
+			originalWorkingDirectory, workingDirectoryError := os.Getwd()
+			require.NoError(subtest, workingDirectoryError)
+			require.NoError(subtest, os.Chdir(workingDirectory))
+			subtest.Cleanup(func() {
+				require.NoError(subtest, os.Chdir(originalWorkingDirectory))
+			})
+
+			originalArguments := os.Args
+			os.Args = []string{"gix", "--init", "--interactive"}
+			subtest.Cleanup(func() {
+				os.Args = originalArguments
+			})
+
+			application := NewApplication()
+			application.interactiveInputDetector = func(io.Reader) bool { return testCase.terminal }
+			var output bytes.Buffer
+			application.rootCommand.SetIn(strings.NewReader(".\nstructured\norigin\nyes\n"))
+			application.rootCommand.SetOut(&output)
+			require.NoError(subtest, application.Execute())
+
+			fileContent, readError := os.ReadFile(filepath.Join(workingDirectory, "config.yaml"))
+			require.NoError(subtest, readError)
+			if testCase.expectedDefaults {
+				require.Equal(subtest, embeddedContent, fileContent)
+				require.Empty(subtest, output.String())
+				return
+			}
+			require.Contains(subtest, string(fileContent), "log_format: structured")
+			require.Contains(subtest, string(fileContent), "dry_run: true")
+			require.Contains(subtest, output.String(), "Repository roots")
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const executableInstallHintTemplateConstant = "%s; install it from %s"
+
+var executableInstallHints = map[execshell.CommandName]string{
+	execshell.CommandGit:    "https://git-scm.com/downloads",
+	execshell.CommandGitHub: "https://cli.github.com",
+	execshell.CommandCurl:   "https://curl.se/download.html",
+}
+
+type executableInstallHintError struct {
+	message string
+	cause   error
+}
+
+func (hintError executableInstallHintError) Error() string {
+	return hintError.message
+}
+
+func (hintError executableInstallHintError) Unwrap() error {
+	return hintError.cause
+}
+
+func renderExecutionError(executionError error) error {
+	var notFoundError execshell.ExecutableNotFoundError
+	if !errors.As(executionError, &notFoundError) {
+		return executionError
+	}
+
+	installHint, hintExists := executableInstallHints[notFoundError.Command]
+	if !hintExists {
+		return executableInstallHintError{message: notFoundError.Error(), cause: executionError}
+	}
+
+	return executableInstallHintError{
+		message: fmt.Sprintf(executableInstallHintTemplateConstant, notFoundError.Error(), installHint),
+		cause:   executionError,
+	}
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+func TestRenderExecutionErrorAddsInstallHints(t *testing.T) {
+	lookupFailure := errors.New("executable file not found in $PATH")
+	unrelatedFailure := errors.New("unrelated failure")
+
+	testCases := []struct {
+		name            string
+		executionError  error
+		expectedMessage string
+		expectSame      bool
+	}{
+		{
+			name:            "github_cli_missing",
+			executionError:  fmt.Errorf("workflow operation apply-tasks failed: %w", execshell.ExecutableNotFoundError{Command: execshell.CommandGitHub, Cause: lookupFailure}),
+			expectedMessage: "the 'gh' executable was not found on PATH; install it from https://cli.github.com",
+		},
+		{
+			name:            "git_missing",
+			executionError:  execshell.ExecutableNotFoundError{Command: execshell.CommandGit, Cause: lookupFailure},
+			expectedMessage: "the 'git' executable was not found on PATH; install it from https://git-scm.com/downloads",
+		},
+		{
+			name:            "unknown_executable_without_hint",
+			executionError:  execshell.ExecutableNotFoundError{Command: execshell.CommandName("custom"), Cause: lookupFailure},
+			expectedMessage: "the 'custom' executable was not found on PATH",
+		},
+		{
+			name:           "unrelated_error_passthrough",
+			executionError: unrelatedFailure,
+			expectSame:     true,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			renderedError := renderExecutionError(testCase.executionError)
+			if testCase.expectSame {
+				require.Equal(subtest, testCase.executionError, renderedError)
+				return
+			}
+			require.EqualError(subtest, renderedError, testCase.expectedMessage)
+			require.True(subtest, execshell.IsExecutableNotFound(renderedError))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/temirov/gix/internal/utils"
+)
+
+// startPhaseTimer attaches a fresh phase timer to the command context so commands can time their phases with
+// utils.StartPhase. The total duration therefore starts when the command begins, before configuration is loaded.
+func (application *Application) startPhaseTimer(command *cobra.Command) {
+	if command == nil {
+		return
+	}
+	application.phaseTimer = utils.NewPhaseTimer()
+	parentContext := command.Context()
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	timedContext := application.commandContextAccessor.WithPhaseTimer(parentContext, application.phaseTimer)
+	command.SetContext(timedContext)
+	if rootCommand := command.Root(); rootCommand != nil {
+		rootCommand.SetContext(timedContext)
+	}
+}
+
+// reportPhaseTiming prints the timing summary line once the command finishes, whether or not it succeeded, and
+// records the same figures in the diagnostic log. Runs that never started a command, such as help, print nothing.
+func (application *Application) reportPhaseTiming() {
+	if application.phaseTimer == nil {
+		return
+	}
+	summary := application.phaseTimer.Summary()
+	application.phaseTimer = nil
+	fmt.Fprintln(application.rootCommand.ErrOrStderr(), summary.String())
+	utils.LogPhaseTimingSummary(application.logger, summary)
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	rawConfigurationReadErrorTemplateConstant   = "unable to read configuration file %s: %w"
+	rawConfigurationDecodeErrorTemplateConstant = "unable to parse configuration file %s: %w"
+)
+
+type rawConfigurationFile struct {
+	Operations []rawOperationConfiguration `yaml:"operations"`
+}
+
+type rawOperationConfiguration struct {
+	Name    string         `yaml:"operation"`
+	Variant string         `yaml:"variant"`
+	Options map[string]any `yaml:"with"`
+}
+
+// loadRawOperationConfigurations reads the operation options of the configuration file straight from its YAML. Viper
+// lowercases map keys and splits them on dots, so options keyed by user data, such as branch-default overrides keyed by
+// owner/repo, are read from here instead.
+func loadRawOperationConfigurations(configurationFilePath string) (OperationConfigurations, error) {
+	trimmedPath := strings.TrimSpace(configurationFilePath)
+	if len(trimmedPath) == 0 {
+		return OperationConfigurations{}, nil
+	}
+	configurationContent, readError := os.ReadFile(trimmedPath)
+	if readError != nil {
+		return OperationConfigurations{}, fmt.Errorf(rawConfigurationReadErrorTemplateConstant, trimmedPath, readError)
+	}
+	var configurationFile rawConfigurationFile
+	if decodeError := yaml.Unmarshal(configurationContent, &configurationFile); decodeError != nil {
+		return OperationConfigurations{}, fmt.Errorf(rawConfigurationDecodeErrorTemplateConstant, trimmedPath, decodeError)
+	}
+	definitions := make([]ApplicationOperationConfiguration, 0, len(configurationFile.Operations))
+	for _, operation := range configurationFile.Operations {
+		definitions = append(definitions, ApplicationOperationConfiguration{Name: operation.Name, Variant: operation.Variant, Options: operation.Options})
+	}
+	return newOperationConfigurations(definitions)
+}
+
+// decodeRawOperationOption decodes one option of the operation, as written in the configuration file, into target. It
+// reports whether the file sets the option.
+func (application *Application) decodeRawOperationOption(operationName string, optionKey string, target any) bool {
+	options, lookupError := application.rawOperationConfigurations.Lookup(operationName)
+	if lookupError != nil {
+		return false
+	}
+	for candidateKey, optionValue := range options {
+		if !strings.EqualFold(strings.TrimSpace(candidateKey), optionKey) {
+			continue
+		}
+		decodeError := mapstructure.WeakDecode(optionValue, target)
+		if decodeError == nil {
+			return true
+		}
+		if application.logger != nil {
+			application.logger.Warn(operationDecodeErrorMessageConstant, zap.String(operationNameLogFieldConstant, operationName), zap.Error(decodeError))
+		}
+		return false
+	}
+	return false
+}
@@ -14,14 +14,20 @@ type ToolsConfiguration struct {
 	Rename   RenameConfiguration   `mapstructure:"rename"`
 	Remove   RemoveConfiguration   `mapstructure:"remove"`
 	Replace  ReplaceConfiguration  `mapstructure:"replace"`
+	List     ListConfiguration     `mapstructure:"list"`
+	Exec     ExecConfiguration     `mapstructure:"exec"`
 }
 
 // RemotesConfiguration describes configuration values for repo-remote-update.
 type RemotesConfiguration struct {
-	DryRun          bool     `mapstructure:"dry_run"`
-	AssumeYes       bool     `mapstructure:"assume_yes"`
-	Owner           string   `mapstructure:"owner"`
-	RepositoryRoots []string `mapstructure:"roots"`
+	DryRun             bool     `mapstructure:"dry_run"`
+	AssumeYes          bool     `mapstructure:"assume_yes"`
+	Owner              string   `mapstructure:"owner"`
+	RepositoryRoots    []string `mapstructure:"roots"`
+	RenameDirectory    bool     `mapstructure:"rename_directory"`
+	RenameIncludeOwner bool     `mapstructure:"rename_include_owner"`
+	IncludePushURL     bool     `mapstructure:"include_push_url"`
+	EraseCredentials   bool     `mapstructure:"erase_stale_credentials"`
 }
 
 // ProtocolConfiguration describes configuration values for repo-protocol-convert.
@@ -40,6 +46,7 @@ type RenameConfiguration struct {
 	RequireCleanWorktree bool     `mapstructure:"require_clean"`
 	RepositoryRoots      []string `mapstructure:"roots"`
 	IncludeOwner         bool     `mapstructure:"include_owner"`
+	NamingTemplate       string   `mapstructure:"naming_template"`
 }
 
 // RemoveConfiguration describes configuration values for repo history removal.
@@ -67,6 +74,27 @@ type ReplaceConfiguration struct {
 	RequirePaths    []string `mapstructure:"paths"`
 }
 
+// ListConfiguration describes configuration values for repo-list.
+type ListConfiguration struct {
+	RepositoryRoots []string `mapstructure:"roots"`
+	Format          string   `mapstructure:"format"`
+	Owner           string   `mapstructure:"owner"`
+	ExcludePatterns []string `mapstructure:"exclude"`
+	MaxDepth        int      `mapstructure:"max_depth"`
+}
+
+// ExecConfiguration describes configuration values for repo-exec.
+type ExecConfiguration struct {
+	DryRun          bool     `mapstructure:"dry_run"`
+	RepositoryRoots []string `mapstructure:"roots"`
+	Format          string   `mapstructure:"format"`
+	Owner           string   `mapstructure:"owner"`
+	ExcludePatterns []string `mapstructure:"exclude"`
+	MaxDepth        int      `mapstructure:"max_depth"`
+	Jobs            int      `mapstructure:"jobs"`
+	FailFast        bool     `mapstructure:"fail_fast"`
+}
+
 // DefaultToolsConfiguration returns baseline configuration values for repository commands.
 func DefaultToolsConfiguration() ToolsConfiguration {
 	return ToolsConfiguration{
@@ -111,6 +139,18 @@ func DefaultToolsConfiguration() ToolsConfiguration {
 			Branch:          "",
 			RequirePaths:    nil,
 		},
+		List: ListConfiguration{
+			RepositoryRoots: nil,
+			Format:          "table",
+			Owner:           "",
+			ExcludePatterns: nil,
+			MaxDepth:        0,
+		},
+		Exec: ExecConfiguration{
+			RepositoryRoots: nil,
+			Format:          "text",
+			Jobs:            1,
+		},
 	}
 }
 
@@ -135,6 +175,7 @@ func (configuration ProtocolConfiguration) sanitize() ProtocolConfiguration {
 func (configuration RenameConfiguration) sanitize() RenameConfiguration {
 	sanitized := configuration
 	sanitized.RepositoryRoots = rootutils.SanitizeConfigured(configuration.RepositoryRoots)
+	sanitized.NamingTemplate = strings.TrimSpace(configuration.NamingTemplate)
 	return sanitized
 }
 
@@ -169,6 +210,25 @@ func (configuration ReplaceConfiguration) Sanitize() ReplaceConfiguration {
 	return configuration.sanitize()
 }
 
+// sanitize normalizes list configuration values.
+func (configuration ListConfiguration) sanitize() ListConfiguration {
+	sanitized := configuration
+	sanitized.RepositoryRoots = rootutils.SanitizeConfigured(configuration.RepositoryRoots)
+	sanitized.Format = strings.TrimSpace(configuration.Format)
+	sanitized.Owner = strings.TrimSpace(configuration.Owner)
+	sanitized.ExcludePatterns = sanitizeReplacementPatterns(configuration.ExcludePatterns)
+	return sanitized
+}
+
+func (configuration ExecConfiguration) sanitize() ExecConfiguration {
+	sanitized := configuration
+	sanitized.RepositoryRoots = rootutils.SanitizeConfigured(configuration.RepositoryRoots)
+	sanitized.Format = strings.TrimSpace(configuration.Format)
+	sanitized.Owner = strings.TrimSpace(configuration.Owner)
+	sanitized.ExcludePatterns = sanitizeReplacementPatterns(configuration.ExcludePatterns)
+	return sanitized
+}
+
 func sanitizeReplacementPatterns(patterns []string) []string {
 	sanitized := make([]string, 0, len(patterns))
 	seen := map[string]struct{}{}
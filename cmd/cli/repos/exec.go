@@ -0,0 +1,259 @@
+package repos
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/temirov/gix/internal/repos/dependencies"
+	"github.com/temirov/gix/internal/repos/fanout"
+	"github.com/temirov/gix/internal/repos/inventory"
+	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
+	flagutils "github.com/temirov/gix/internal/utils/flags"
+)
+
+const (
+	execUseConstant                   = "repo-exec"
+	execShortDescription              = "Run a command in every discovered repository"
+	execLongDescription               = "repo-exec runs the command given after -- in the working directory of each discovered repository, prefixing every output line with the repository path."
+	execFormatFlagName                = "format"
+	execFormatFlagDescription         = "Output format"
+	execOwnerFlagName                 = "owner"
+	execOwnerFlagDescription          = "Only run in repositories whose origin owner matches this value"
+	execExcludeFlagName               = "exclude"
+	execExcludeFlagDescription        = "Skip repositories whose directory name or relative path matches this glob (repeatable)"
+	execMaxDepthFlagName              = "max-depth"
+	execMaxDepthFlagDescription       = "Skip repositories nested deeper than this many directories below a root (0 disables the limit)"
+	execJobsFlagName                  = "jobs"
+	execJobsFlagDescription           = "Number of repositories to run the command in at once"
+	execFailFastFlagName              = "fail-fast"
+	execFailFastFlagDescription       = "Stop starting new repositories after the first failure"
+	execNegativeMaxDepthErrorValue    = "max-depth must not be negative"
+	execInvalidJobsErrorValue         = "jobs must be at least 1"
+	execMissingCommandErrorValue      = "a command to run must be provided after --"
+	execCommandExecutorErrorValue     = "git executor cannot run arbitrary commands"
+	execDefaultJobsConstant           = 1
+	execArgumentsDashMissingIndicator = -1
+)
+
+// ExecCommandBuilder assembles the repo-exec command.
+type ExecCommandBuilder struct {
+	LoggerProvider               LoggerProvider
+	Discoverer                   shared.RepositoryDiscoverer
+	GitExecutor                  shared.GitExecutor
+	GitManager                   shared.GitRepositoryManager
+	CommandExecutor              fanout.CommandExecutor
+	HumanReadableLoggingProvider func() bool
+	ConfigurationProvider        func() ExecConfiguration
+}
+
+// Build constructs the repo-exec command.
+func (builder *ExecCommandBuilder) Build() (*cobra.Command, error) {
+	command := &cobra.Command{
+		Use:   execUseConstant,
+		Short: execShortDescription,
+		Long:  execLongDescription,
+		Args:  cobra.ArbitraryArgs,
+		RunE:  builder.run,
+	}
+
+	command.Flags().String(execFormatFlagName, string(fanout.OutputFormatText), flagutils.FormatChoiceUsage(string(fanout.OutputFormatText), fanout.OutputFormats(), execFormatFlagDescription))
+	command.Flags().String(execOwnerFlagName, "", execOwnerFlagDescription)
+	command.Flags().StringSlice(execExcludeFlagName, nil, execExcludeFlagDescription)
+	command.Flags().Int(execMaxDepthFlagName, 0, execMaxDepthFlagDescription)
+	command.Flags().Int(execJobsFlagName, execDefaultJobsConstant, execJobsFlagDescription)
+	flagutils.AddToggleFlag(command.Flags(), nil, execFailFastFlagName, "", false, execFailFastFlagDescription)
+
+	return command, nil
+}
+
+func (builder *ExecCommandBuilder) run(command *cobra.Command, arguments []string) error {
+	rootArguments, commandArguments := splitExecArguments(command, arguments)
+	if len(commandArguments) == 0 {
+		return errors.New(execMissingCommandErrorValue)
+	}
+
+	configuration := builder.resolveConfiguration()
+
+	formatValue := configuration.Format
+	flagValue, flagChanged, flagError := flagutils.StringFlag(command, execFormatFlagName)
+	if flagError != nil && !errors.Is(flagError, flagutils.ErrFlagNotDefined) {
+		return flagError
+	}
+	if flagChanged {
+		formatValue = flagValue
+	}
+	outputFormat, formatError := fanout.ParseOutputFormat(formatValue)
+	if formatError != nil {
+		return formatError
+	}
+
+	ownerFilter := configuration.Owner
+	ownerValue, ownerChanged, ownerError := flagutils.StringFlag(command, execOwnerFlagName)
+	if ownerError != nil && !errors.Is(ownerError, flagutils.ErrFlagNotDefined) {
+		return ownerError
+	}
+	if ownerChanged {
+		ownerFilter = strings.TrimSpace(ownerValue)
+	}
+
+	excludePatterns := configuration.ExcludePatterns
+	excludeValues, excludeChanged, excludeError := flagutils.StringSliceFlag(command, execExcludeFlagName)
+	if excludeError != nil && !errors.Is(excludeError, flagutils.ErrFlagNotDefined) {
+		return excludeError
+	}
+	if excludeChanged {
+		excludePatterns = excludeValues
+	}
+
+	maxDepth := configuration.MaxDepth
+	if command.Flags().Changed(execMaxDepthFlagName) {
+		maxDepthValue, maxDepthError := command.Flags().GetInt(execMaxDepthFlagName)
+		if maxDepthError != nil {
+			return maxDepthError
+		}
+		maxDepth = maxDepthValue
+	}
+	if maxDepth < 0 {
+		return errors.New(execNegativeMaxDepthErrorValue)
+	}
+
+	jobs := configuration.Jobs
+	if command.Flags().Changed(execJobsFlagName) {
+		jobsValue, jobsError := command.Flags().GetInt(execJobsFlagName)
+		if jobsError != nil {
+			return jobsError
+		}
+		jobs = jobsValue
+	}
+	if jobs == 0 {
+		jobs = execDefaultJobsConstant
+	}
+	if jobs < execDefaultJobsConstant {
+		return errors.New(execInvalidJobsErrorValue)
+	}
+
+	failFast := configuration.FailFast
+	failFastValue, failFastChanged, failFastError := flagutils.BoolFlag(command, execFailFastFlagName)
+	if failFastError != nil && !errors.Is(failFastError, flagutils.ErrFlagNotDefined) {
+		return failFastError
+	}
+	if failFastChanged {
+		failFast = failFastValue
+	}
+
+	dryRun := configuration.DryRun
+	if executionFlags, executionFlagsAvailable := flagutils.ResolveExecutionFlags(command); executionFlagsAvailable && executionFlags.DryRunSet {
+		dryRun = executionFlags.DryRun
+	}
+
+	roots, rootsError := requireRepositoryRoots(command, rootArguments, configuration.RepositoryRoots)
+	if rootsError != nil {
+		return rootsError
+	}
+
+	logger := resolveLogger(builder.LoggerProvider)
+	effectiveConfiguration := configuration
+	effectiveConfiguration.Format = string(outputFormat)
+	effectiveConfiguration.Owner = ownerFilter
+	effectiveConfiguration.ExcludePatterns = excludePatterns
+	effectiveConfiguration.MaxDepth = maxDepth
+	effectiveConfiguration.Jobs = jobs
+	effectiveConfiguration.FailFast = failFast
+	effectiveConfiguration.DryRun = dryRun
+	effectiveConfiguration.RepositoryRoots = roots
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), effectiveConfiguration)
+
+	humanReadableLogging := false
+	if builder.HumanReadableLoggingProvider != nil {
+		humanReadableLogging = builder.HumanReadableLoggingProvider()
+	}
+	gitExecutor, executorError := dependencies.ResolveGitExecutor(builder.GitExecutor, logger, humanReadableLogging)
+	if executorError != nil {
+		return executorError
+	}
+
+	commandExecutor := builder.CommandExecutor
+	if commandExecutor == nil {
+		typedExecutor, supportsCommands := gitExecutor.(fanout.CommandExecutor)
+		if !supportsCommands {
+			return errors.New(execCommandExecutorErrorValue)
+		}
+		commandExecutor = typedExecutor
+	}
+
+	gitManager, managerError := dependencies.ResolveGitRepositoryManager(builder.GitManager, gitExecutor)
+	if managerError != nil {
+		return managerError
+	}
+
+	repositoryDiscoverer := dependencies.ResolveRepositoryDiscoverer(builder.Discoverer)
+	repositories, collectError := inventory.NewService(repositoryDiscoverer, gitManager).Collect(command.Context(), inventory.Options{
+		Roots:           roots,
+		ExcludePatterns: excludePatterns,
+		MaxDepth:        maxDepth,
+		Owner:           ownerFilter,
+	})
+	if collectError != nil {
+		return collectError
+	}
+
+	repositoryPaths := make([]string, 0, len(repositories))
+	for _, repository := range repositories {
+		repositoryPaths = append(repositoryPaths, repository.Path)
+	}
+
+	runner, runnerError := fanout.NewRunner(commandExecutor)
+	if runnerError != nil {
+		return runnerError
+	}
+
+	streamOutput := command.OutOrStdout()
+	if outputFormat == fanout.OutputFormatJSON {
+		streamOutput = command.ErrOrStderr()
+	}
+	results, runError := runner.Run(command.Context(), fanout.Options{
+		RepositoryPaths: repositoryPaths,
+		Command:         commandArguments,
+		Jobs:            jobs,
+		FailFast:        failFast,
+		DryRun:          dryRun,
+		Output:          streamOutput,
+		Errors:          command.ErrOrStderr(),
+	})
+	var partialError fanout.PartialExecutionError
+	if runError != nil && !errors.As(runError, &partialError) {
+		return runError
+	}
+
+	if outputFormat == fanout.OutputFormatJSON {
+		if writeError := fanout.WriteJSONReport(command.OutOrStdout(), commandArguments, results); writeError != nil {
+			return writeError
+		}
+	} else {
+		fanout.WriteTextSummary(command.ErrOrStderr(), results)
+	}
+
+	return runError
+}
+
+// splitExecArguments separates the root arguments from the command given after --.
+func splitExecArguments(command *cobra.Command, arguments []string) ([]string, []string) {
+	dashIndex := command.ArgsLenAtDash()
+	if dashIndex == execArgumentsDashMissingIndicator {
+		return arguments, nil
+	}
+	return arguments[:dashIndex], arguments[dashIndex:]
+}
+
+func (builder *ExecCommandBuilder) resolveConfiguration() ExecConfiguration {
+	if builder.ConfigurationProvider == nil {
+		defaults := DefaultToolsConfiguration()
+		return defaults.Exec
+	}
+
+	provided := builder.ConfigurationProvider()
+	return provided.sanitize()
+}
@@ -0,0 +1,162 @@
+package repos_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	repos "github.com/temirov/gix/cmd/cli/repos"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/repos/fanout"
+)
+
+const (
+	execConfiguredRootConstant = "/tmp/exec-config-root"
+	execCLIRootConstant        = "/tmp/exec-cli-root"
+	execRepositoryConstant     = "/tmp/exec-cli-root/example"
+	execOriginURLConstant      = "https://github.com/acme/example.git"
+	execDryRunFlagConstant     = "--dry-run"
+)
+
+type recordingCommandExecutor struct {
+	commands []execshell.ShellCommand
+	exitCode int
+}
+
+func (executor *recordingCommandExecutor) Execute(_ context.Context, command execshell.ShellCommand) (execshell.ExecutionResult, error) {
+	executor.commands = append(executor.commands, command)
+	fmt.Fprintln(command.Details.OutputWriter, "ok")
+	if executor.exitCode != 0 {
+		return execshell.ExecutionResult{ExitCode: executor.exitCode}, execshell.CommandFailedError{Command: command, Result: execshell.ExecutionResult{ExitCode: executor.exitCode}}
+	}
+	return execshell.ExecutionResult{}, nil
+}
+
+func TestExecCommandRunsCommandInRepositories(testInstance *testing.T) {
+	testCases := []struct {
+		name                 string
+		configuration        repos.ExecConfiguration
+		arguments            []string
+		exitCode             int
+		expectedRoots        []string
+		expectedCommands     int
+		expectedStdout       string
+		expectedStderr       string
+		expectedErrorMessage string
+		expectJSON           bool
+	}{
+		{
+			name:             "runs_command_in_positional_root",
+			configuration:    repos.ExecConfiguration{RepositoryRoots: []string{execConfiguredRootConstant}},
+			arguments:        []string{execCLIRootConstant, "--", "git", "status"},
+			expectedRoots:    []string{execCLIRootConstant},
+			expectedCommands: 1,
+			expectedStdout:   "[/tmp/exec-cli-root/example] ok\n",
+			expectedStderr:   "EXEC-SUMMARY: 1 succeeded, 0 failed, 0 skipped\n",
+		},
+		{
+			name:           "dry_run_prints_plan",
+			configuration:  repos.ExecConfiguration{RepositoryRoots: []string{execConfiguredRootConstant}},
+			arguments:      []string{execDryRunFlagConstant, "--", "make", "test"},
+			expectedRoots:  []string{execConfiguredRootConstant},
+			expectedStdout: "PLAN-EXEC: /tmp/exec-cli-root/example: make test\n",
+		},
+		{
+			name:             "json_format_reports_results",
+			configuration:    repos.ExecConfiguration{RepositoryRoots: []string{execConfiguredRootConstant}, Format: "json"},
+			arguments:        []string{"--", "make", "test"},
+			expectedRoots:    []string{execConfiguredRootConstant},
+			expectedCommands: 1,
+			expectedStderr:   "[/tmp/exec-cli-root/example] ok\n",
+			expectJSON:       true,
+		},
+		{
+			name:                 "failure_returns_partial_error",
+			configuration:        repos.ExecConfiguration{RepositoryRoots: []string{execConfiguredRootConstant}},
+			arguments:            []string{"--", "false"},
+			exitCode:             1,
+			expectedCommands:     1,
+			expectedErrorMessage: "1 repository command(s) failed",
+		},
+		{
+			name:                 "missing_command_errors",
+			configuration:        repos.ExecConfiguration{RepositoryRoots: []string{execConfiguredRootConstant}},
+			arguments:            []string{execCLIRootConstant},
+			expectedErrorMessage: "a command to run must be provided after --",
+		},
+		{
+			name:                 "negative_jobs_errors",
+			configuration:        repos.ExecConfiguration{RepositoryRoots: []string{execConfiguredRootConstant}},
+			arguments:            []string{"--jobs", "-1", "--", "true"},
+			expectedErrorMessage: "jobs must be at least 1",
+		},
+		{
+			name:                 "error_when_roots_missing",
+			configuration:        repos.ExecConfiguration{},
+			arguments:            []string{"--", "true"},
+			expectedErrorMessage: remotesMissingRootsMessage,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			discoverer := &fakeRepositoryDiscoverer{repositories: []string{execRepositoryConstant}}
+			commandExecutor := &recordingCommandExecutor{exitCode: testCase.exitCode}
+
+			builder := repos.ExecCommandBuilder{
+				LoggerProvider:  func() *zap.Logger { return zap.NewNop() },
+				Discoverer:      discoverer,
+				GitExecutor:     &fakeGitExecutor{},
+				GitManager:      &fakeGitRepositoryManager{remoteURL: execOriginURLConstant},
+				CommandExecutor: commandExecutor,
+				ConfigurationProvider: func() repos.ExecConfiguration {
+					return testCase.configuration
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindGlobalRemotesFlags(command)
+
+			command.SetContext(context.Background())
+			stdoutBuffer := &bytes.Buffer{}
+			stderrBuffer := &bytes.Buffer{}
+			command.SetOut(stdoutBuffer)
+			command.SetErr(stderrBuffer)
+			command.SilenceUsage = true
+			command.SilenceErrors = true
+			command.SetArgs(testCase.arguments)
+
+			executionError := command.Execute()
+			require.Len(subtest, commandExecutor.commands, testCase.expectedCommands)
+			if len(testCase.expectedErrorMessage) > 0 {
+				require.Error(subtest, executionError)
+				require.Equal(subtest, testCase.expectedErrorMessage, executionError.Error())
+				return
+			}
+
+			require.NoError(subtest, executionError)
+			require.Equal(subtest, testCase.expectedRoots, discoverer.receivedRoots)
+			for _, executed := range commandExecutor.commands {
+				require.Equal(subtest, execRepositoryConstant, executed.Details.WorkingDirectory)
+			}
+			require.Equal(subtest, testCase.expectedStderr, stderrBuffer.String())
+			if !testCase.expectJSON {
+				require.Equal(subtest, testCase.expectedStdout, stdoutBuffer.String())
+				return
+			}
+
+			var report fanout.JSONReport
+			require.NoError(subtest, json.Unmarshal(stdoutBuffer.Bytes(), &report))
+			require.Equal(subtest, []string{"make", "test"}, report.Command)
+			require.Len(subtest, report.Results, 1)
+			require.Equal(subtest, fanout.StatusSucceeded, report.Results[0].Status)
+		})
+	}
+}
@@ -23,6 +23,13 @@ func requireRepositoryRoots(command *cobra.Command, arguments []string, configur
 	return roots, nil
 }
 
+func resolveStructuredOutput(provider func() bool) bool {
+	if provider == nil {
+		return false
+	}
+	return provider()
+}
+
 func resolveLogger(provider LoggerProvider) *zap.Logger {
 	if provider == nil {
 		return zap.NewNop()
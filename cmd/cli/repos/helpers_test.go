@@ -34,11 +34,17 @@ func TestRequireRepositoryRoots(t *testing.T) {
 		expectedRoots []string
 	}{
 		{
-			name:          "positional_arguments_rejected",
+			name:          "positional_arguments_merged_with_flags",
+			arguments:     []string{"/tmp/positional", flagRoot},
+			flagArgs:      []string{"--" + flagutils.DefaultRootFlagName, flagRoot},
+			configured:    []string{configuredRoot},
+			expectedRoots: []string{flagRootExpanded, "/tmp/positional"},
+		},
+		{
+			name:          "positional_arguments_replace_configuration",
 			arguments:     []string{"/tmp/positional"},
-			configured:    nil,
-			expectError:   true,
-			expectedError: "repository roots must be provided using --roots",
+			configured:    []string{configuredRoot},
+			expectedRoots: []string{"/tmp/positional"},
 		},
 		{
 			name:          "flag_values_take_precedence",
@@ -0,0 +1,162 @@
+package repos
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/temirov/gix/internal/repos/dependencies"
+	"github.com/temirov/gix/internal/repos/inventory"
+	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
+	flagutils "github.com/temirov/gix/internal/utils/flags"
+)
+
+const (
+	listUseConstant                = "repo-list"
+	listShortDescription           = "List discovered repositories with their local state"
+	listLongDescription            = "repo-list prints each discovered repository's path, origin URL, owner/repo, current branch, and dirty flag without contacting GitHub."
+	listFormatFlagName             = "format"
+	listFormatFlagDescription      = "Output format"
+	listOwnerFlagName              = "owner"
+	listOwnerFlagDescription       = "Only list repositories whose origin owner matches this value"
+	listExcludeFlagName            = "exclude"
+	listExcludeFlagDescription     = "Skip repositories whose directory name or relative path matches this glob (repeatable)"
+	listMaxDepthFlagName           = "max-depth"
+	listMaxDepthFlagDescription    = "Skip repositories nested deeper than this many directories below a root (0 disables the limit)"
+	listNegativeMaxDepthErrorValue = "max-depth must not be negative"
+)
+
+// ListCommandBuilder assembles the repo-list command.
+type ListCommandBuilder struct {
+	LoggerProvider               LoggerProvider
+	Discoverer                   shared.RepositoryDiscoverer
+	GitExecutor                  shared.GitExecutor
+	GitManager                   shared.GitRepositoryManager
+	HumanReadableLoggingProvider func() bool
+	ConfigurationProvider        func() ListConfiguration
+}
+
+// Build constructs the repo-list command.
+func (builder *ListCommandBuilder) Build() (*cobra.Command, error) {
+	command := &cobra.Command{
+		Use:   listUseConstant,
+		Short: listShortDescription,
+		Long:  listLongDescription,
+		Args:  cobra.ArbitraryArgs,
+		RunE:  builder.run,
+	}
+
+	command.Flags().String(listFormatFlagName, string(inventory.OutputFormatTable), flagutils.FormatChoiceUsage(string(inventory.OutputFormatTable), inventory.OutputFormats(), listFormatFlagDescription))
+	command.Flags().String(listOwnerFlagName, "", listOwnerFlagDescription)
+	command.Flags().StringSlice(listExcludeFlagName, nil, listExcludeFlagDescription)
+	command.Flags().Int(listMaxDepthFlagName, 0, listMaxDepthFlagDescription)
+
+	return command, nil
+}
+
+func (builder *ListCommandBuilder) run(command *cobra.Command, arguments []string) error {
+	configuration := builder.resolveConfiguration()
+
+	formatValue := configuration.Format
+	if command != nil {
+		flagValue, flagChanged, flagError := flagutils.StringFlag(command, listFormatFlagName)
+		if flagError != nil && !errors.Is(flagError, flagutils.ErrFlagNotDefined) {
+			return flagError
+		}
+		if flagChanged {
+			formatValue = flagValue
+		}
+	}
+	outputFormat, formatError := inventory.ParseOutputFormat(formatValue)
+	if formatError != nil {
+		return formatError
+	}
+
+	ownerFilter := configuration.Owner
+	if command != nil {
+		flagValue, flagChanged, flagError := flagutils.StringFlag(command, listOwnerFlagName)
+		if flagError != nil && !errors.Is(flagError, flagutils.ErrFlagNotDefined) {
+			return flagError
+		}
+		if flagChanged {
+			ownerFilter = strings.TrimSpace(flagValue)
+		}
+	}
+
+	excludePatterns := configuration.ExcludePatterns
+	if command != nil {
+		flagValues, flagChanged, flagError := flagutils.StringSliceFlag(command, listExcludeFlagName)
+		if flagError != nil && !errors.Is(flagError, flagutils.ErrFlagNotDefined) {
+			return flagError
+		}
+		if flagChanged {
+			excludePatterns = flagValues
+		}
+	}
+
+	maxDepth := configuration.MaxDepth
+	if command != nil && command.Flags().Changed(listMaxDepthFlagName) {
+		flagValue, flagError := command.Flags().GetInt(listMaxDepthFlagName)
+		if flagError != nil {
+			return flagError
+		}
+		maxDepth = flagValue
+	}
+	if maxDepth < 0 {
+		return errors.New(listNegativeMaxDepthErrorValue)
+	}
+
+	roots, rootsError := requireRepositoryRoots(command, arguments, configuration.RepositoryRoots)
+	if rootsError != nil {
+		return rootsError
+	}
+
+	logger := resolveLogger(builder.LoggerProvider)
+	effectiveConfiguration := configuration
+	effectiveConfiguration.Format = string(outputFormat)
+	effectiveConfiguration.Owner = ownerFilter
+	effectiveConfiguration.ExcludePatterns = excludePatterns
+	effectiveConfiguration.MaxDepth = maxDepth
+	effectiveConfiguration.RepositoryRoots = roots
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), effectiveConfiguration)
+	humanReadableLogging := false
+	if builder.HumanReadableLoggingProvider != nil {
+		humanReadableLogging = builder.HumanReadableLoggingProvider()
+	}
+	gitExecutor, executorError := dependencies.ResolveGitExecutor(builder.GitExecutor, logger, humanReadableLogging)
+	if executorError != nil {
+		return executorError
+	}
+
+	gitManager, managerError := dependencies.ResolveGitRepositoryManager(builder.GitManager, gitExecutor)
+	if managerError != nil {
+		return managerError
+	}
+
+	repositoryDiscoverer := dependencies.ResolveRepositoryDiscoverer(builder.Discoverer)
+	service := inventory.NewService(repositoryDiscoverer, gitManager)
+
+	repositories, collectError := service.Collect(command.Context(), inventory.Options{
+		Roots:           roots,
+		ExcludePatterns: excludePatterns,
+		MaxDepth:        maxDepth,
+		Owner:           ownerFilter,
+	})
+	if collectError != nil {
+		return collectError
+	}
+
+	return inventory.Render(command.OutOrStdout(), repositories, outputFormat)
+}
+
+func (builder *ListCommandBuilder) resolveConfiguration() ListConfiguration {
+	if builder.ConfigurationProvider == nil {
+		defaults := DefaultToolsConfiguration()
+		return defaults.List
+	}
+
+	provided := builder.ConfigurationProvider()
+	return provided.sanitize()
+}
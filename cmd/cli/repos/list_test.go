@@ -0,0 +1,120 @@
+package repos_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	repos "github.com/temirov/gix/cmd/cli/repos"
+)
+
+const (
+	listConfiguredRootConstant   = "/tmp/list-config-root"
+	listCLIRootConstant          = "/tmp/list-cli-root"
+	listDiscoveredRepository     = "/tmp/list-cli-root/example"
+	listOriginURLConstant        = "https://github.com/acme/example.git"
+	listCurrentBranchConstant    = "main"
+	listFormatFlagConstant       = "--format"
+	listOwnerFlagConstant        = "--owner"
+	listMaxDepthFlagConstant     = "--max-depth"
+	listUnsupportedFormatMessage = "unsupported output format \"xml\" (expected table, json, or paths)"
+)
+
+func TestListCommandRendersRepositories(testInstance *testing.T) {
+	testCases := []struct {
+		name                 string
+		configuration        repos.ListConfiguration
+		arguments            []string
+		expectedRoots        []string
+		expectedOutput       string
+		expectedContains     []string
+		expectedErrorMessage string
+	}{
+		{
+			name:           "paths_format_from_flag",
+			configuration:  repos.ListConfiguration{RepositoryRoots: []string{listConfiguredRootConstant}},
+			arguments:      []string{listFormatFlagConstant, "paths", remotesRootFlagConstant, listCLIRootConstant},
+			expectedRoots:  []string{listCLIRootConstant},
+			expectedOutput: listDiscoveredRepository + "\n",
+		},
+		{
+			name:             "table_format_from_configuration",
+			configuration:    repos.ListConfiguration{RepositoryRoots: []string{listConfiguredRootConstant}, Format: "table"},
+			arguments:        []string{},
+			expectedRoots:    []string{listConfiguredRootConstant},
+			expectedContains: []string{"acme/example", listCurrentBranchConstant, listOriginURLConstant},
+		},
+		{
+			name:           "owner_flag_filters_repositories",
+			configuration:  repos.ListConfiguration{RepositoryRoots: []string{listConfiguredRootConstant}, Format: "paths"},
+			arguments:      []string{listOwnerFlagConstant, "someone-else"},
+			expectedRoots:  []string{listConfiguredRootConstant},
+			expectedOutput: "",
+		},
+		{
+			name:                 "unsupported_format_errors",
+			configuration:        repos.ListConfiguration{RepositoryRoots: []string{listConfiguredRootConstant}},
+			arguments:            []string{listFormatFlagConstant, "xml"},
+			expectedErrorMessage: listUnsupportedFormatMessage,
+		},
+		{
+			name:                 "negative_max_depth_errors",
+			configuration:        repos.ListConfiguration{RepositoryRoots: []string{listConfiguredRootConstant}},
+			arguments:            []string{listMaxDepthFlagConstant, "-1"},
+			expectedErrorMessage: "max-depth must not be negative",
+		},
+		{
+			name:                 "error_when_roots_missing",
+			configuration:        repos.ListConfiguration{},
+			arguments:            []string{},
+			expectedErrorMessage: remotesMissingRootsMessage,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			discoverer := &fakeRepositoryDiscoverer{repositories: []string{listDiscoveredRepository}}
+			manager := &fakeGitRepositoryManager{remoteURL: listOriginURLConstant, currentBranch: listCurrentBranchConstant}
+
+			builder := repos.ListCommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     discoverer,
+				GitExecutor:    &fakeGitExecutor{},
+				GitManager:     manager,
+				ConfigurationProvider: func() repos.ListConfiguration {
+					return testCase.configuration
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindGlobalRemotesFlags(command)
+
+			command.SetContext(context.Background())
+			stdoutBuffer := &bytes.Buffer{}
+			command.SetOut(stdoutBuffer)
+			command.SetErr(&bytes.Buffer{})
+			command.SetArgs(testCase.arguments)
+
+			executionError := command.Execute()
+			if len(testCase.expectedErrorMessage) > 0 {
+				require.Error(subtest, executionError)
+				require.Equal(subtest, testCase.expectedErrorMessage, executionError.Error())
+				return
+			}
+
+			require.NoError(subtest, executionError)
+			require.Equal(subtest, testCase.expectedRoots, discoverer.receivedRoots)
+			if len(testCase.expectedContains) == 0 {
+				require.Equal(subtest, testCase.expectedOutput, stdoutBuffer.String())
+			}
+			for _, expectedFragment := range testCase.expectedContains {
+				require.Contains(subtest, stdoutBuffer.String(), expectedFragment)
+			}
+		})
+	}
+}
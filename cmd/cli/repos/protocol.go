@@ -12,7 +12,9 @@ import (
 	"github.com/temirov/gix/internal/githubcli"
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/dependencies"
+	"github.com/temirov/gix/internal/repos/protocol"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 	"github.com/temirov/gix/internal/workflow"
 )
@@ -28,6 +30,12 @@ const (
 	protocolErrorMissingPair    = "specify both --from and --to"
 	protocolErrorSamePair       = "--from and --to must differ"
 	protocolErrorInvalidValue   = "invalid protocol value: %s"
+	protocolReportOnlyFlagName  = "report-only"
+	protocolReportOnlyFlagUsage = "Print how many origins use each URL form per owner instead of converting"
+	protocolFormatFlagName      = "format"
+	protocolFormatFlagUsage     = "Report output format"
+	protocolErrorReportWithPair = "--report-only cannot be combined with --from or --to"
+	protocolErrorFormatNoReport = "--format requires --report-only"
 )
 
 // ProtocolCommandBuilder assembles the repo-protocol-convert command.
@@ -39,6 +47,7 @@ type ProtocolCommandBuilder struct {
 	GitHubResolver               shared.GitHubMetadataResolver
 	PrompterFactory              PrompterFactory
 	HumanReadableLoggingProvider func() bool
+	StructuredOutputProvider     func() bool
 	ConfigurationProvider        func() ProtocolConfiguration
 	TaskRunnerFactory            func(workflow.Dependencies) TaskRunnerExecutor
 }
@@ -49,12 +58,14 @@ func (builder *ProtocolCommandBuilder) Build() (*cobra.Command, error) {
 		Use:   protocolUseConstant,
 		Short: protocolShortDescription,
 		Long:  protocolLongDescription,
-		Args:  cobra.NoArgs,
+		Args:  cobra.ArbitraryArgs,
 		RunE:  builder.run,
 	}
 
 	command.Flags().String(protocolFromFlagName, "", protocolFromFlagDescription)
 	command.Flags().String(protocolToFlagName, "", protocolToFlagDescription)
+	command.Flags().Bool(protocolReportOnlyFlagName, false, protocolReportOnlyFlagUsage)
+	command.Flags().String(protocolFormatFlagName, string(protocol.ReportFormatTable), flagutils.FormatChoiceUsage(string(protocol.ReportFormatTable), protocol.ReportFormats(), protocolFormatFlagUsage))
 
 	return command, nil
 }
@@ -92,6 +103,24 @@ func (builder *ProtocolCommandBuilder) run(command *cobra.Command, arguments []s
 		toValue, _ = command.Flags().GetString(protocolToFlagName)
 	}
 
+	reportOnly := false
+	formatValue := ""
+	formatChanged := false
+	if command != nil {
+		reportOnly, _ = command.Flags().GetBool(protocolReportOnlyFlagName)
+		formatValue, _ = command.Flags().GetString(protocolFormatFlagName)
+		formatChanged = command.Flags().Changed(protocolFormatFlagName)
+	}
+	if reportOnly {
+		if command.Flags().Changed(protocolFromFlagName) || command.Flags().Changed(protocolToFlagName) {
+			return errors.New(protocolErrorReportWithPair)
+		}
+		return builder.runReport(command, arguments, configuration, formatValue)
+	}
+	if formatChanged {
+		return errors.New(protocolErrorFormatNoReport)
+	}
+
 	if len(strings.TrimSpace(fromValue)) == 0 || len(strings.TrimSpace(toValue)) == 0 {
 		if helpError := displayCommandHelp(command); helpError != nil {
 			return helpError
@@ -119,6 +148,13 @@ func (builder *ProtocolCommandBuilder) run(command *cobra.Command, arguments []s
 	}
 
 	logger := resolveLogger(builder.LoggerProvider)
+	effectiveConfiguration := configuration
+	effectiveConfiguration.DryRun = dryRun
+	effectiveConfiguration.AssumeYes = assumeYes
+	effectiveConfiguration.FromProtocol = string(fromProtocol)
+	effectiveConfiguration.ToProtocol = string(toProtocol)
+	effectiveConfiguration.RepositoryRoots = roots
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), effectiveConfiguration)
 	humanReadableLogging := false
 	if builder.HumanReadableLoggingProvider != nil {
 		humanReadableLogging = builder.HumanReadableLoggingProvider()
@@ -173,6 +209,7 @@ func (builder *ProtocolCommandBuilder) run(command *cobra.Command, arguments []s
 		Prompter:             trackingPrompter,
 		Output:               outputWriter,
 		Errors:               errorWriter,
+		Reporter:             dependencies.ResolveOutputReporter(outputWriter, resolveStructuredOutput(builder.StructuredOutputProvider)),
 	}
 
 	taskRunner := ResolveTaskRunner(builder.TaskRunnerFactory, taskDependencies)
@@ -191,11 +228,49 @@ func (builder *ProtocolCommandBuilder) run(command *cobra.Command, arguments []s
 		Commit: workflow.TaskCommitDefinition{},
 	}
 
-	runtimeOptions := workflow.RuntimeOptions{DryRun: dryRun, AssumeYes: trackingPrompter.AssumeYes()}
+	runtimeOptions := workflow.RuntimeOptions{DryRun: dryRun, AssumeYes: trackingPrompter.AssumeYes(), LockRoots: true}
 
 	return taskRunner.Run(command.Context(), roots, []workflow.TaskDefinition{taskDefinition}, runtimeOptions)
 }
 
+// runReport prints the origin URL distribution of the discovered repositories without changing anything.
+func (builder *ProtocolCommandBuilder) runReport(command *cobra.Command, arguments []string, configuration ProtocolConfiguration, formatValue string) error {
+	reportFormat, formatError := protocol.ParseReportFormat(formatValue)
+	if formatError != nil {
+		return formatError
+	}
+
+	roots, rootsError := requireRepositoryRoots(command, arguments, configuration.RepositoryRoots)
+	if rootsError != nil {
+		return rootsError
+	}
+
+	logger := resolveLogger(builder.LoggerProvider)
+	effectiveConfiguration := configuration
+	effectiveConfiguration.RepositoryRoots = roots
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), effectiveConfiguration)
+	humanReadableLogging := false
+	if builder.HumanReadableLoggingProvider != nil {
+		humanReadableLogging = builder.HumanReadableLoggingProvider()
+	}
+	gitExecutor, executorError := dependencies.ResolveGitExecutor(builder.GitExecutor, logger, humanReadableLogging)
+	if executorError != nil {
+		return executorError
+	}
+
+	gitManager, managerError := dependencies.ResolveGitRepositoryManager(builder.GitManager, gitExecutor)
+	if managerError != nil {
+		return managerError
+	}
+
+	repositoryDiscoverer := dependencies.ResolveRepositoryDiscoverer(builder.Discoverer)
+	report, reportError := protocol.CollectDistribution(command.Context(), repositoryDiscoverer, gitManager, roots)
+	if reportError != nil {
+		return reportError
+	}
+	return protocol.RenderDistribution(command.OutOrStdout(), report, reportFormat)
+}
+
 func (builder *ProtocolCommandBuilder) resolveConfiguration() ProtocolConfiguration {
 	if builder.ConfigurationProvider == nil {
 		defaults := DefaultToolsConfiguration()
@@ -272,3 +272,73 @@ func protocolHomeRootBuilder(testingInstance testing.TB) []string {
 	expandedRoot := filepath.Join(homeDirectory, protocolHomeRootSuffixConstant)
 	return []string{expandedRoot}
 }
+
+func TestProtocolCommandReportOnly(testInstance *testing.T) {
+	testCases := []struct {
+		name                 string
+		arguments            []string
+		expectedOutput       string
+		expectedErrorMessage string
+	}{
+		{
+			name:      "report_without_protocol_pair",
+			arguments: []string{"--report-only", protocolRootFlagConstant, remotesCLIRepositoryRootConstant},
+			expectedOutput: "OWNER  HTTPS  SSH-URL  SSH-SCP  GIT  OTHER  TOTAL\n" +
+				"octo   0      0        1        0    0      1\n" +
+				"TOTAL  0      0        1        0    0      1\n",
+		},
+		{
+			name:           "report_as_json",
+			arguments:      []string{"--report-only", "--format", "json", protocolRootFlagConstant, remotesCLIRepositoryRootConstant},
+			expectedOutput: "\"ssh_scp\": 1",
+		},
+		{
+			name:                 "report_rejects_protocol_pair",
+			arguments:            []string{"--report-only", protocolFromFlagConstant, "https", protocolToFlagConstant, "ssh", protocolRootFlagConstant, remotesCLIRepositoryRootConstant},
+			expectedErrorMessage: "--report-only cannot be combined with --from or --to",
+		},
+		{
+			name:                 "format_requires_report",
+			arguments:            []string{"--format", "json", protocolFromFlagConstant, "https", protocolToFlagConstant, "ssh", protocolRootFlagConstant, remotesCLIRepositoryRootConstant},
+			expectedErrorMessage: "--format requires --report-only",
+		},
+		{
+			name:                 "report_rejects_unknown_format",
+			arguments:            []string{"--report-only", "--format", "xml", protocolRootFlagConstant, remotesCLIRepositoryRootConstant},
+			expectedErrorMessage: "unsupported report format \"xml\" (expected table or json)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := repos.ProtocolCommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{repositories: []string{remotesDiscoveredRepository}},
+				GitExecutor:    &fakeGitExecutor{},
+				GitManager:     &fakeGitRepositoryManager{remoteURL: "git@github.com:octo/tools.git"},
+				TaskRunnerFactory: func(workflow.Dependencies) repos.TaskRunnerExecutor {
+					return runner
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindGlobalProtocolFlags(command)
+			command.SetContext(context.Background())
+			stdoutBuffer := &bytes.Buffer{}
+			command.SetOut(stdoutBuffer)
+			command.SetErr(&bytes.Buffer{})
+			command.SetArgs(testCase.arguments)
+
+			executionError := command.Execute()
+			require.Empty(subtest, runner.definitions)
+			if len(testCase.expectedErrorMessage) > 0 {
+				require.EqualError(subtest, executionError, testCase.expectedErrorMessage)
+				return
+			}
+			require.NoError(subtest, executionError)
+			require.Contains(subtest, stdoutBuffer.String(), testCase.expectedOutput)
+		})
+	}
+}
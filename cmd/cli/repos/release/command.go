@@ -11,6 +11,7 @@ import (
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/dependencies"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 	rootutils "github.com/temirov/gix/internal/utils/roots"
 	"github.com/temirov/gix/internal/workflow"
@@ -95,12 +96,21 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		}
 	}
 
-	repositoryRoots, rootsError := rootutils.Resolve(command, additionalArgs, configuration.RepositoryRoots)
+	repositoryRoots, rootsError := rootutils.ResolveWithoutPositional(command, additionalArgs, configuration.RepositoryRoots)
 	if rootsError != nil {
 		return rootsError
 	}
 
 	logger := resolveLogger(builder.LoggerProvider)
+	effectiveConfiguration := configuration
+	effectiveConfiguration.Message = messageValue
+	effectiveConfiguration.RemoteName = remoteName
+	effectiveConfiguration.RepositoryRoots = repositoryRoots
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), struct {
+		Configuration CommandConfiguration
+		Tag           string
+		DryRun        bool
+	}{Configuration: effectiveConfiguration, Tag: tagName, DryRun: dryRun})
 	humanReadable := false
 	if builder.HumanReadableLoggingProvider != nil {
 		humanReadable = builder.HumanReadableLoggingProvider()
@@ -1,6 +1,7 @@
 package repos
 
 import (
+	"errors"
 	"io"
 	"os"
 	"strings"
@@ -11,16 +12,25 @@ import (
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/dependencies"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 	"github.com/temirov/gix/internal/workflow"
 )
 
 const (
-	remotesUseConstant          = "repo-remote-update"
-	remotesShortDescription     = "Update origin URLs to match canonical GitHub repositories"
-	remotesLongDescription      = "repo-remote-update adjusts origin remotes to point to canonical GitHub repositories."
-	remotesOwnerFlagName        = "owner"
-	remotesOwnerFlagDescription = "Require canonical owner to match this value"
+	remotesUseConstant           = "repo-remote-update"
+	remotesShortDescription      = "Update origin URLs to match canonical GitHub repositories"
+	remotesLongDescription       = "repo-remote-update adjusts origin remotes to point to canonical GitHub repositories."
+	remotesOwnerFlagName         = "owner"
+	remotesOwnerFlagDescription  = "Require canonical owner to match this value"
+	remotesRenameDirectoryFlag   = "rename-directory"
+	remotesRenameDirectoryUsage  = "Also rename each updated repository's directory to its canonical name"
+	remotesRenameOwnerFlag       = "rename-include-owner"
+	remotesRenameOwnerUsage      = "With --rename-directory, include the repository owner in the target directory path"
+	remotesIncludePushURLFlag    = "include-pushurl"
+	remotesIncludePushURLUsage   = "Also canonicalize a separately configured origin push URL"
+	remotesEraseCredentialsFlag  = "erase-stale-credentials"
+	remotesEraseCredentialsUsage = "When an HTTPS origin moves to another host, erase the old host's stored credentials with git credential reject"
 )
 
 // RemotesCommandBuilder assembles the repo-remote-update command.
@@ -32,6 +42,7 @@ type RemotesCommandBuilder struct {
 	GitHubResolver               shared.GitHubMetadataResolver
 	PrompterFactory              PrompterFactory
 	HumanReadableLoggingProvider func() bool
+	StructuredOutputProvider     func() bool
 	ConfigurationProvider        func() RemotesConfiguration
 	TaskRunnerFactory            func(workflow.Dependencies) TaskRunnerExecutor
 }
@@ -42,11 +53,15 @@ func (builder *RemotesCommandBuilder) Build() (*cobra.Command, error) {
 		Use:   remotesUseConstant,
 		Short: remotesShortDescription,
 		Long:  remotesLongDescription,
-		Args:  cobra.NoArgs,
+		Args:  cobra.ArbitraryArgs,
 		RunE:  builder.run,
 	}
 
 	command.Flags().String(remotesOwnerFlagName, "", remotesOwnerFlagDescription)
+	flagutils.AddToggleFlag(command.Flags(), nil, remotesRenameDirectoryFlag, "", false, remotesRenameDirectoryUsage)
+	flagutils.AddToggleFlag(command.Flags(), nil, remotesRenameOwnerFlag, "", false, remotesRenameOwnerUsage)
+	flagutils.AddToggleFlag(command.Flags(), nil, remotesIncludePushURLFlag, "", false, remotesIncludePushURLUsage)
+	flagutils.AddToggleFlag(command.Flags(), nil, remotesEraseCredentialsFlag, "", false, remotesEraseCredentialsUsage)
 
 	return command, nil
 }
@@ -80,12 +95,57 @@ func (builder *RemotesCommandBuilder) run(command *cobra.Command, arguments []st
 		ownerConstraint = strings.TrimSpace(ownerValue)
 	}
 
+	renameDirectory := configuration.RenameDirectory
+	renameIncludeOwner := configuration.RenameIncludeOwner
+	includePushURL := configuration.IncludePushURL
+	eraseCredentials := configuration.EraseCredentials
+	if command != nil {
+		renameDirectoryValue, renameDirectoryChanged, renameDirectoryError := flagutils.BoolFlag(command, remotesRenameDirectoryFlag)
+		if renameDirectoryError != nil && !errors.Is(renameDirectoryError, flagutils.ErrFlagNotDefined) {
+			return renameDirectoryError
+		}
+		if renameDirectoryChanged {
+			renameDirectory = renameDirectoryValue
+		}
+		renameOwnerValue, renameOwnerChanged, renameOwnerError := flagutils.BoolFlag(command, remotesRenameOwnerFlag)
+		if renameOwnerError != nil && !errors.Is(renameOwnerError, flagutils.ErrFlagNotDefined) {
+			return renameOwnerError
+		}
+		if renameOwnerChanged {
+			renameIncludeOwner = renameOwnerValue
+		}
+		includePushURLValue, includePushURLChanged, includePushURLError := flagutils.BoolFlag(command, remotesIncludePushURLFlag)
+		if includePushURLError != nil && !errors.Is(includePushURLError, flagutils.ErrFlagNotDefined) {
+			return includePushURLError
+		}
+		if includePushURLChanged {
+			includePushURL = includePushURLValue
+		}
+		eraseCredentialsValue, eraseCredentialsChanged, eraseCredentialsError := flagutils.BoolFlag(command, remotesEraseCredentialsFlag)
+		if eraseCredentialsError != nil && !errors.Is(eraseCredentialsError, flagutils.ErrFlagNotDefined) {
+			return eraseCredentialsError
+		}
+		if eraseCredentialsChanged {
+			eraseCredentials = eraseCredentialsValue
+		}
+	}
+
 	roots, rootsError := requireRepositoryRoots(command, arguments, configuration.RepositoryRoots)
 	if rootsError != nil {
 		return rootsError
 	}
 
 	logger := resolveLogger(builder.LoggerProvider)
+	effectiveConfiguration := configuration
+	effectiveConfiguration.DryRun = dryRun
+	effectiveConfiguration.AssumeYes = assumeYes
+	effectiveConfiguration.Owner = ownerConstraint
+	effectiveConfiguration.RenameDirectory = renameDirectory
+	effectiveConfiguration.RenameIncludeOwner = renameIncludeOwner
+	effectiveConfiguration.IncludePushURL = includePushURL
+	effectiveConfiguration.EraseCredentials = eraseCredentials
+	effectiveConfiguration.RepositoryRoots = roots
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), effectiveConfiguration)
 	humanReadableLogging := false
 	if builder.HumanReadableLoggingProvider != nil {
 		humanReadableLogging = builder.HumanReadableLoggingProvider()
@@ -140,6 +200,7 @@ func (builder *RemotesCommandBuilder) run(command *cobra.Command, arguments []st
 		Prompter:             trackingPrompter,
 		Output:               outputWriter,
 		Errors:               errorWriter,
+		Reporter:             dependencies.ResolveOutputReporter(outputWriter, resolveStructuredOutput(builder.StructuredOutputProvider)),
 	}
 
 	taskRunner := ResolveTaskRunner(builder.TaskRunnerFactory, taskDependencies)
@@ -148,6 +209,16 @@ func (builder *RemotesCommandBuilder) run(command *cobra.Command, arguments []st
 	if len(strings.TrimSpace(ownerConstraint)) > 0 {
 		actionOptions["owner"] = ownerConstraint
 	}
+	if renameDirectory {
+		actionOptions["rename_directory"] = true
+		actionOptions["include_owner"] = renameIncludeOwner
+	}
+	if includePushURL {
+		actionOptions["include_push_url"] = true
+	}
+	if eraseCredentials {
+		actionOptions["erase_stale_credentials"] = true
+	}
 
 	taskDefinition := workflow.TaskDefinition{
 		Name:        "Update canonical remote",
@@ -158,7 +229,7 @@ func (builder *RemotesCommandBuilder) run(command *cobra.Command, arguments []st
 		Commit: workflow.TaskCommitDefinition{},
 	}
 
-	runtimeOptions := workflow.RuntimeOptions{DryRun: dryRun, AssumeYes: trackingPrompter.AssumeYes()}
+	runtimeOptions := workflow.RuntimeOptions{DryRun: dryRun, AssumeYes: trackingPrompter.AssumeYes(), LockRoots: true}
 
 	return taskRunner.Run(command.Context(), roots, []workflow.TaskDefinition{taskDefinition}, runtimeOptions)
 }
@@ -21,21 +21,23 @@ import (
 )
 
 const (
-	remotesAssumeYesFlagConstant     = "--" + flagutils.AssumeYesFlagName
-	remotesDryRunFlagConstant        = "--" + flagutils.DryRunFlagName
-	remotesRootFlagConstant          = "--" + flagutils.DefaultRootFlagName
-	remotesConfiguredRootConstant    = "/tmp/remotes-config-root"
-	remotesCLIRepositoryRootConstant = "/tmp/remotes-cli-root"
-	remotesDiscoveredRepository      = "/tmp/remotes-repo"
-	remotesOriginURLConstant         = "https://github.com/origin/example.git"
-	remotesCanonicalRepository       = "canonical/example"
-	remotesMetadataDefaultBranch     = "main"
-	remotesMissingRootsMessage       = "no repository roots provided; specify --roots or configure defaults"
-	remotesRelativeRootConstant      = "relative/remotes-root"
-	remotesHomeRootSuffixConstant    = "remotes-home-root"
-	remotesOwnerFlagConstant         = "--owner"
-	remotesOwnerConstraintConstant   = "canonical"
-	remotesOwnerMismatchConstant     = "different"
+	remotesAssumeYesFlagConstant       = "--" + flagutils.AssumeYesFlagName
+	remotesDryRunFlagConstant          = "--" + flagutils.DryRunFlagName
+	remotesRootFlagConstant            = "--" + flagutils.DefaultRootFlagName
+	remotesConfiguredRootConstant      = "/tmp/remotes-config-root"
+	remotesCLIRepositoryRootConstant   = "/tmp/remotes-cli-root"
+	remotesDiscoveredRepository        = "/tmp/remotes-repo"
+	remotesOriginURLConstant           = "https://github.com/origin/example.git"
+	remotesCanonicalRepository         = "canonical/example"
+	remotesMetadataDefaultBranch       = "main"
+	remotesMissingRootsMessage         = "no repository roots provided; specify --roots or configure defaults"
+	remotesRelativeRootConstant        = "relative/remotes-root"
+	remotesHomeRootSuffixConstant      = "remotes-home-root"
+	remotesOwnerFlagConstant           = "--owner"
+	remotesRenameDirectoryFlagConstant = "--rename-directory"
+	remotesRenameOwnerFlagConstant     = "--rename-include-owner"
+	remotesOwnerConstraintConstant     = "canonical"
+	remotesOwnerMismatchConstant       = "different"
 )
 
 type recordingTaskRunner struct {
@@ -312,6 +314,88 @@ func TestRemotesCommandOwnerOptions(testInstance *testing.T) {
 	}
 }
 
+func TestRemotesCommandRenameDirectoryOptions(testInstance *testing.T) {
+	testCases := []struct {
+		name                  string
+		configuration         repos.RemotesConfiguration
+		arguments             []string
+		expectRenameDirectory bool
+		expectedIncludeOwner  bool
+	}{
+		{
+			name: "rename_disabled_by_default",
+			configuration: repos.RemotesConfiguration{
+				RepositoryRoots: []string{remotesConfiguredRootConstant},
+			},
+		},
+		{
+			name: "flag_enables_rename",
+			configuration: repos.RemotesConfiguration{
+				RepositoryRoots: []string{remotesConfiguredRootConstant},
+			},
+			arguments:             []string{remotesRenameDirectoryFlagConstant},
+			expectRenameDirectory: true,
+		},
+		{
+			name: "flag_enables_rename_with_owner",
+			configuration: repos.RemotesConfiguration{
+				RepositoryRoots: []string{remotesConfiguredRootConstant},
+			},
+			arguments:             []string{remotesRenameDirectoryFlagConstant, remotesRenameOwnerFlagConstant},
+			expectRenameDirectory: true,
+			expectedIncludeOwner:  true,
+		},
+		{
+			name: "configuration_enables_rename",
+			configuration: repos.RemotesConfiguration{
+				RenameDirectory:    true,
+				RenameIncludeOwner: true,
+				RepositoryRoots:    []string{remotesConfiguredRootConstant},
+			},
+			expectRenameDirectory: true,
+			expectedIncludeOwner:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+
+			builder := repos.RemotesCommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				GitExecutor:    &fakeGitExecutor{},
+				ConfigurationProvider: func() repos.RemotesConfiguration {
+					return testCase.configuration
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) repos.TaskRunnerExecutor {
+					return runner
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindGlobalRemotesFlags(command)
+			command.SetContext(context.Background())
+			command.SetOut(io.Discard)
+			command.SetErr(io.Discard)
+			command.SetArgs(testCase.arguments)
+
+			require.NoError(subtest, command.Execute())
+
+			require.Len(subtest, runner.definitions, 1)
+			require.Len(subtest, runner.definitions[0].Actions, 1)
+			action := runner.definitions[0].Actions[0]
+			if testCase.expectRenameDirectory {
+				require.Equal(subtest, true, action.Options["rename_directory"])
+				require.Equal(subtest, testCase.expectedIncludeOwner, action.Options["include_owner"])
+			} else {
+				require.NotContains(subtest, action.Options, "rename_directory")
+				require.NotContains(subtest, action.Options, "include_owner")
+			}
+		})
+	}
+}
+
 func bindGlobalRemotesFlags(command *cobra.Command) {
 	flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Enabled: true})
 	flagutils.BindExecutionFlags(command, flagutils.ExecutionDefaults{}, flagutils.ExecutionFlagDefinitions{
@@ -10,7 +10,9 @@ import (
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/dependencies"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
+	rootutils "github.com/temirov/gix/internal/utils/roots"
 	"github.com/temirov/gix/internal/workflow"
 )
 
@@ -121,12 +123,24 @@ func (builder *RemoveCommandBuilder) run(command *cobra.Command, arguments []str
 		}
 	}
 
-	roots, rootsError := requireRepositoryRoots(command, nil, configuration.RepositoryRoots)
+	roots, rootsError := rootutils.ResolveWithoutPositional(command, nil, configuration.RepositoryRoots)
 	if rootsError != nil {
 		return rootsError
 	}
 
 	logger := resolveLogger(builder.LoggerProvider)
+	effectiveConfiguration := configuration
+	effectiveConfiguration.DryRun = dryRun
+	effectiveConfiguration.AssumeYes = assumeYes
+	effectiveConfiguration.Remote = remoteName
+	effectiveConfiguration.Push = pushEnabled
+	effectiveConfiguration.Restore = restoreEnabled
+	effectiveConfiguration.PushMissing = pushMissing
+	effectiveConfiguration.RepositoryRoots = roots
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), struct {
+		Configuration RemoveConfiguration
+		Paths         []string
+	}{Configuration: effectiveConfiguration, Paths: arguments})
 	humanReadableLogging := false
 	if builder.HumanReadableLoggingProvider != nil {
 		humanReadableLogging = builder.HumanReadableLoggingProvider()
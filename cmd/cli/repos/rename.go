@@ -2,13 +2,17 @@ package repos
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 
 	"github.com/temirov/gix/internal/githubcli"
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/dependencies"
+	"github.com/temirov/gix/internal/repos/rename"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 	"github.com/temirov/gix/internal/workflow"
 )
@@ -21,6 +25,14 @@ const (
 	renameRequireCleanDescription = "Require clean worktrees before applying renames"
 	renameIncludeOwnerFlagName    = "owner"
 	renameIncludeOwnerDescription = "Include repository owner in the target directory path"
+	renamePlanFileFlagName        = "plan-file"
+	renamePlanFileDescription     = "Write the planned renames as YAML to this path without moving any directory"
+	renameApplyPlanFlagName       = "apply-plan"
+	renameApplyPlanDescription    = "Apply renames from a plan previously written with --plan-file"
+	renameUndoFlagName            = "undo"
+	renameUndoDescription         = "Move directories renamed from a plan file back to their original locations"
+	renamePlanFlagsConflictError  = "--plan-file cannot be combined with --apply-plan"
+	renameUndoFlagsConflictError  = "--undo cannot be combined with --plan-file or --apply-plan"
 )
 
 // RenameCommandBuilder assembles the repo-folders-rename command.
@@ -33,6 +45,7 @@ type RenameCommandBuilder struct {
 	FileSystem                   shared.FileSystem
 	PrompterFactory              PrompterFactory
 	HumanReadableLoggingProvider func() bool
+	StructuredOutputProvider     func() bool
 	ConfigurationProvider        func() RenameConfiguration
 	TaskRunnerFactory            func(workflow.Dependencies) TaskRunnerExecutor
 }
@@ -43,12 +56,15 @@ func (builder *RenameCommandBuilder) Build() (*cobra.Command, error) {
 		Use:   renameUseConstant,
 		Short: renameShortDescription,
 		Long:  renameLongDescription,
-		Args:  cobra.NoArgs,
+		Args:  cobra.ArbitraryArgs,
 		RunE:  builder.run,
 	}
 
 	flagutils.AddToggleFlag(command.Flags(), nil, renameRequireCleanFlagName, "", false, renameRequireCleanDescription)
 	flagutils.AddToggleFlag(command.Flags(), nil, renameIncludeOwnerFlagName, "", false, renameIncludeOwnerDescription)
+	command.Flags().String(renamePlanFileFlagName, "", renamePlanFileDescription)
+	command.Flags().String(renameApplyPlanFlagName, "", renameApplyPlanDescription)
+	command.Flags().String(renameUndoFlagName, "", renameUndoDescription)
 
 	return command, nil
 }
@@ -79,6 +95,7 @@ func (builder *RenameCommandBuilder) run(command *cobra.Command, arguments []str
 	}
 
 	includeOwner := configuration.IncludeOwner
+	namingTemplate := configuration.NamingTemplate
 	if command != nil {
 		includeOwnerFlagValue, includeOwnerFlagChanged, includeOwnerFlagError := flagutils.BoolFlag(command, renameIncludeOwnerFlagName)
 		if includeOwnerFlagError != nil && !errors.Is(includeOwnerFlagError, flagutils.ErrFlagNotDefined) {
@@ -86,8 +103,43 @@ func (builder *RenameCommandBuilder) run(command *cobra.Command, arguments []str
 		}
 		if includeOwnerFlagChanged {
 			includeOwner = includeOwnerFlagValue
+			namingTemplate = ""
 		}
 	}
+	if includeOwner && len(namingTemplate) == 0 {
+		namingTemplate = rename.OwnerNamingTemplateText
+	}
+	if _, namingTemplateError := rename.ParseNamingTemplate(namingTemplate); namingTemplateError != nil {
+		return namingTemplateError
+	}
+
+	planFilePath, planFileError := renamePathFlag(command, renamePlanFileFlagName)
+	if planFileError != nil {
+		return planFileError
+	}
+	applyPlanPath, applyPlanError := renamePathFlag(command, renameApplyPlanFlagName)
+	if applyPlanError != nil {
+		return applyPlanError
+	}
+	undoPlanPath, undoPlanError := renamePathFlag(command, renameUndoFlagName)
+	if undoPlanError != nil {
+		return undoPlanError
+	}
+	if len(planFilePath) > 0 && len(applyPlanPath) > 0 {
+		return errors.New(renamePlanFlagsConflictError)
+	}
+	if len(undoPlanPath) > 0 && (len(planFilePath) > 0 || len(applyPlanPath) > 0) {
+		return errors.New(renameUndoFlagsConflictError)
+	}
+	if len(undoPlanPath) > 0 {
+		return builder.undoPlan(command, undoPlanPath, dryRun, assumeYes)
+	}
+	if len(planFilePath) > 0 {
+		dryRun = true
+	}
+	if len(applyPlanPath) > 0 {
+		return builder.applyPlan(command, applyPlanPath, dryRun, assumeYes)
+	}
 
 	roots, rootsError := requireRepositoryRoots(command, arguments, configuration.RepositoryRoots)
 	if rootsError != nil {
@@ -95,6 +147,17 @@ func (builder *RenameCommandBuilder) run(command *cobra.Command, arguments []str
 	}
 
 	logger := resolveLogger(builder.LoggerProvider)
+	effectiveConfiguration := configuration
+	effectiveConfiguration.DryRun = dryRun
+	effectiveConfiguration.AssumeYes = assumeYes
+	effectiveConfiguration.RequireCleanWorktree = requireClean
+	effectiveConfiguration.IncludeOwner = includeOwner
+	effectiveConfiguration.NamingTemplate = namingTemplate
+	effectiveConfiguration.RepositoryRoots = roots
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), struct {
+		Configuration RenameConfiguration
+		PlanFile      string
+	}{Configuration: effectiveConfiguration, PlanFile: planFilePath})
 	humanReadableLogging := false
 	if builder.HumanReadableLoggingProvider != nil {
 		humanReadableLogging = builder.HumanReadableLoggingProvider()
@@ -142,6 +205,7 @@ func (builder *RenameCommandBuilder) run(command *cobra.Command, arguments []str
 		Prompter:             trackingPrompter,
 		Output:               command.OutOrStdout(),
 		Errors:               command.ErrOrStderr(),
+		Reporter:             dependencies.ResolveOutputReporter(command.OutOrStdout(), resolveStructuredOutput(builder.StructuredOutputProvider)),
 	}
 
 	taskRunner := ResolveTaskRunner(builder.TaskRunnerFactory, taskDependencies)
@@ -150,6 +214,12 @@ func (builder *RenameCommandBuilder) run(command *cobra.Command, arguments []str
 		"require_clean": requireClean,
 		"include_owner": includeOwner,
 	}
+	if len(namingTemplate) > 0 {
+		actionOptions["naming_template"] = workflow.EscapeTemplateDelimiters(namingTemplate)
+	}
+	if len(planFilePath) > 0 {
+		actionOptions["plan_file"] = planFilePath
+	}
 
 	taskDefinition := workflow.TaskDefinition{
 		Name:        "Rename repository directories",
@@ -166,11 +236,84 @@ func (builder *RenameCommandBuilder) run(command *cobra.Command, arguments []str
 		IncludeNestedRepositories:            true,
 		ProcessRepositoriesByDescendingDepth: true,
 		CaptureInitialWorktreeStatus:         requireClean,
+		LockRoots:                            true,
 	}
 
 	return taskRunner.Run(command.Context(), roots, []workflow.TaskDefinition{taskDefinition}, runtimeOptions)
 }
 
+func (builder *RenameCommandBuilder) applyPlan(command *cobra.Command, planPath string, dryRun bool, assumeYes bool) error {
+	logger := resolveLogger(builder.LoggerProvider)
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), struct {
+		ApplyPlan string
+		DryRun    bool
+		AssumeYes bool
+	}{ApplyPlan: planPath, DryRun: dryRun, AssumeYes: assumeYes})
+	executor, plan, policy, prepareError := builder.preparePlanExecutor(command, logger, planPath, assumeYes)
+	if prepareError != nil {
+		return prepareError
+	}
+	return executor.ApplyPlan(command.Context(), plan, dryRun, policy)
+}
+
+func (builder *RenameCommandBuilder) undoPlan(command *cobra.Command, planPath string, dryRun bool, assumeYes bool) error {
+	logger := resolveLogger(builder.LoggerProvider)
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), struct {
+		Undo      string
+		DryRun    bool
+		AssumeYes bool
+	}{Undo: planPath, DryRun: dryRun, AssumeYes: assumeYes})
+	executor, plan, policy, prepareError := builder.preparePlanExecutor(command, logger, planPath, assumeYes)
+	if prepareError != nil {
+		return prepareError
+	}
+	return executor.UndoPlan(command.Context(), plan, dryRun, policy)
+}
+
+func (builder *RenameCommandBuilder) preparePlanExecutor(command *cobra.Command, logger *zap.Logger, planPath string, assumeYes bool) (*rename.Executor, rename.PlanFile, shared.ConfirmationPolicy, error) {
+	humanReadableLogging := false
+	if builder.HumanReadableLoggingProvider != nil {
+		humanReadableLogging = builder.HumanReadableLoggingProvider()
+	}
+	gitExecutor, executorError := dependencies.ResolveGitExecutor(builder.GitExecutor, logger, humanReadableLogging)
+	if executorError != nil {
+		return nil, rename.PlanFile{}, shared.ConfirmationPrompt, executorError
+	}
+
+	gitManager, managerError := dependencies.ResolveGitRepositoryManager(builder.GitManager, gitExecutor)
+	if managerError != nil {
+		return nil, rename.PlanFile{}, shared.ConfirmationPrompt, managerError
+	}
+
+	fileSystem := dependencies.ResolveFileSystem(builder.FileSystem)
+	plan, planError := rename.ReadPlanFile(fileSystem, planPath)
+	if planError != nil {
+		return nil, rename.PlanFile{}, shared.ConfirmationPrompt, planError
+	}
+
+	prompter := resolvePrompter(builder.PrompterFactory, command)
+	trackingPrompter := newCascadingConfirmationPrompter(prompter, assumeYes)
+
+	executor := rename.NewExecutor(rename.Dependencies{
+		FileSystem: fileSystem,
+		GitManager: gitManager,
+		Prompter:   trackingPrompter,
+		Reporter:   dependencies.ResolveOutputReporter(command.OutOrStdout(), resolveStructuredOutput(builder.StructuredOutputProvider)),
+	})
+	return executor, plan, shared.ConfirmationPolicyFromBool(trackingPrompter.AssumeYes()), nil
+}
+
+func renamePathFlag(command *cobra.Command, flagName string) (string, error) {
+	if command == nil {
+		return "", nil
+	}
+	flagValue, _, flagError := flagutils.StringFlag(command, flagName)
+	if flagError != nil && !errors.Is(flagError, flagutils.ErrFlagNotDefined) {
+		return "", flagError
+	}
+	return strings.TrimSpace(flagValue), nil
+}
+
 func (builder *RenameCommandBuilder) resolveConfiguration() RenameConfiguration {
 	if builder.ConfigurationProvider == nil {
 		defaults := DefaultToolsConfiguration()
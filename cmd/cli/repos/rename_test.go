@@ -292,3 +292,175 @@ func bindGlobalRenameFlags(command *cobra.Command) {
 		return nil
 	}
 }
+
+func TestRenameCommandPlanFileOptions(testInstance *testing.T) {
+	testCases := []struct {
+		name                 string
+		arguments            func(string) []string
+		expectedErrorMessage string
+		expectTaskInvocation bool
+		expectedOutput       func(string) string
+	}{
+		{
+			name: "plan_file_forces_dry_run",
+			arguments: func(directory string) []string {
+				return []string{"--plan-file", filepath.Join(directory, "plan.yaml")}
+			},
+			expectTaskInvocation: true,
+		},
+		{
+			name: "plan_file_conflicts_with_apply_plan",
+			arguments: func(directory string) []string {
+				return []string{"--plan-file", filepath.Join(directory, "plan.yaml"), "--apply-plan", filepath.Join(directory, "plan.yaml")}
+			},
+			expectedErrorMessage: "--plan-file cannot be combined with --apply-plan",
+		},
+		{
+			name: "apply_plan_skips_missing_sources",
+			arguments: func(directory string) []string {
+				planPath := filepath.Join(directory, "saved.yaml")
+				planContents := "renames:\n  - source: " + filepath.Join(directory, "missing") + "\n    target: " + filepath.Join(directory, "renamed") + "\n"
+				require.NoError(testInstance, os.WriteFile(planPath, []byte(planContents), 0o644))
+				return []string{"--apply-plan", planPath}
+			},
+			expectedOutput: func(directory string) string {
+				return "APPLY-SKIP (source missing): " + filepath.Join(directory, "missing") + " → " + filepath.Join(directory, "renamed") + "\n"
+			},
+		},
+		{
+			name: "undo_conflicts_with_apply_plan",
+			arguments: func(directory string) []string {
+				return []string{"--undo", filepath.Join(directory, "plan.yaml"), "--apply-plan", filepath.Join(directory, "plan.yaml")}
+			},
+			expectedErrorMessage: "--undo cannot be combined with --plan-file or --apply-plan",
+		},
+		{
+			name: "undo_reports_missing_renamed_paths",
+			arguments: func(directory string) []string {
+				planPath := filepath.Join(directory, "saved.yaml")
+				planContents := "renames:\n  - source: " + filepath.Join(directory, "original") + "\n    target: " + filepath.Join(directory, "renamed") + "\n"
+				require.NoError(testInstance, os.WriteFile(planPath, []byte(planContents), 0o644))
+				return []string{"--undo", planPath}
+			},
+			expectedOutput: func(directory string) string {
+				return "UNDO-SKIP (renamed path missing): " + filepath.Join(directory, "renamed") + " → " + filepath.Join(directory, "original") + "\n"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			temporaryDirectory := subtest.TempDir()
+			runner := &renameRecordingTaskRunner{}
+			builder := repos.RenameCommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{repositories: []string{renameDiscoveredRepositoryPath}},
+				GitExecutor:    &fakeGitExecutor{},
+				GitManager:     &fakeGitRepositoryManager{cleanWorktree: true, cleanWorktreeSet: true},
+				PrompterFactory: func(*cobra.Command) shared.ConfirmationPrompter {
+					return &recordingPrompter{result: shared.ConfirmationResult{Confirmed: true}}
+				},
+				ConfigurationProvider: func() repos.RenameConfiguration {
+					return repos.RenameConfiguration{RepositoryRoots: []string{renameConfiguredRootConstant}}
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) repos.TaskRunnerExecutor {
+					return runner
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindGlobalRenameFlags(command)
+
+			outputBuffer := &strings.Builder{}
+			command.SetOut(outputBuffer)
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments(temporaryDirectory))
+
+			executionError := command.Execute()
+			if len(testCase.expectedErrorMessage) > 0 {
+				require.EqualError(subtest, executionError, testCase.expectedErrorMessage)
+				require.Empty(subtest, runner.definitions)
+				return
+			}
+			require.NoError(subtest, executionError)
+
+			if testCase.expectTaskInvocation {
+				require.Len(subtest, runner.definitions, 1)
+				require.Equal(subtest, filepath.Join(temporaryDirectory, "plan.yaml"), runner.definitions[0].Actions[0].Options["plan_file"])
+				require.True(subtest, runner.runtimeOptions.DryRun)
+			} else {
+				require.Empty(subtest, runner.definitions)
+			}
+			if testCase.expectedOutput != nil {
+				require.Equal(subtest, testCase.expectedOutput(temporaryDirectory), outputBuffer.String())
+			}
+		})
+	}
+}
+
+func TestRenameCommandNamingTemplateOptions(testInstance *testing.T) {
+	testCases := []struct {
+		name                   string
+		configuration          repos.RenameConfiguration
+		arguments              []string
+		expectedNamingTemplate any
+		expectedErrorMessage   string
+	}{
+		{
+			name:                   "default_layout_omits_template",
+			configuration:          repos.RenameConfiguration{RepositoryRoots: []string{renameConfiguredRootConstant}},
+			expectedNamingTemplate: nil,
+		},
+		{
+			name:                   "configured_template_is_escaped",
+			configuration:          repos.RenameConfiguration{RepositoryRoots: []string{renameConfiguredRootConstant}, NamingTemplate: "{{.Owner}}__{{.Name}}"},
+			expectedNamingTemplate: `\{{.Owner}}__\{{.Name}}`,
+		},
+		{
+			name:                   "owner_flag_is_template_shorthand",
+			configuration:          repos.RenameConfiguration{RepositoryRoots: []string{renameConfiguredRootConstant}, NamingTemplate: "{{.Owner}}__{{.Name}}"},
+			arguments:              []string{renameIncludeOwnerFlagConstant},
+			expectedNamingTemplate: `\{{.Owner}}/\{{.Name}}`,
+		},
+		{
+			name:                 "invalid_template_rejected",
+			configuration:        repos.RenameConfiguration{RepositoryRoots: []string{renameConfiguredRootConstant}, NamingTemplate: "{{.Owner"},
+			expectedErrorMessage: `invalid naming template "{{.Owner": template: naming_template:1: unclosed action`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			runner := &renameRecordingTaskRunner{}
+			builder := repos.RenameCommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{repositories: []string{renameDiscoveredRepositoryPath}},
+				GitExecutor:    &fakeGitExecutor{},
+				GitManager:     &fakeGitRepositoryManager{cleanWorktree: true, cleanWorktreeSet: true},
+				ConfigurationProvider: func() repos.RenameConfiguration {
+					return testCase.configuration
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) repos.TaskRunnerExecutor {
+					return runner
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindGlobalRenameFlags(command)
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			executionError := command.Execute()
+			if len(testCase.expectedErrorMessage) > 0 {
+				require.EqualError(subtest, executionError, testCase.expectedErrorMessage)
+				require.Empty(subtest, runner.definitions)
+				return
+			}
+			require.NoError(subtest, executionError)
+			require.Len(subtest, runner.definitions, 1)
+			require.Equal(subtest, testCase.expectedNamingTemplate, runner.definitions[0].Actions[0].Options["naming_template"])
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/dependencies"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 	"github.com/temirov/gix/internal/workflow"
 )
@@ -62,7 +63,7 @@ func (builder *ReplaceCommandBuilder) Build() (*cobra.Command, error) {
 	return command, nil
 }
 
-func (builder *ReplaceCommandBuilder) run(command *cobra.Command, _ []string) error {
+func (builder *ReplaceCommandBuilder) run(command *cobra.Command, arguments []string) error {
 	configuration := builder.resolveConfiguration()
 	executionFlags, executionFlagsAvailable := flagutils.ResolveExecutionFlags(command)
 
@@ -148,12 +149,24 @@ func (builder *ReplaceCommandBuilder) run(command *cobra.Command, _ []string) er
 		requiredPaths = sanitizeReplacementPaths(flagPaths)
 	}
 
-	roots, rootsError := requireRepositoryRoots(command, nil, configuration.RepositoryRoots)
+	roots, rootsError := requireRepositoryRoots(command, arguments, configuration.RepositoryRoots)
 	if rootsError != nil {
 		return rootsError
 	}
 
 	logger := resolveLogger(builder.LoggerProvider)
+	effectiveConfiguration := configuration
+	effectiveConfiguration.DryRun = dryRun
+	effectiveConfiguration.AssumeYes = assumeYes
+	effectiveConfiguration.Patterns = patterns
+	effectiveConfiguration.Find = findValue
+	effectiveConfiguration.Replace = replaceValue
+	effectiveConfiguration.Command = strings.Join(commandArguments, " ")
+	effectiveConfiguration.RequireClean = requireClean
+	effectiveConfiguration.Branch = branchValue
+	effectiveConfiguration.RequirePaths = requiredPaths
+	effectiveConfiguration.RepositoryRoots = roots
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), effectiveConfiguration)
 	humanReadableLogging := false
 	if builder.HumanReadableLoggingProvider != nil {
 		humanReadableLogging = builder.HumanReadableLoggingProvider()
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	noLockFlagNameConstant  = "no-lock"
+	noLockFlagUsageConstant = "Skip the lock that keeps concurrent mutating runs (folder renames, remote and protocol updates, branch default, prs delete, workflow) off the same roots"
+)
+
+// applyRootLockPreference records --no-lock in the command context, where the workflow executor reads it before
+// taking the root lock.
+func (application *Application) applyRootLockPreference(command *cobra.Command) {
+	if command == nil || !application.noLockFlagValue {
+		return
+	}
+	parentContext := command.Context()
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	unlockedContext := application.commandContextAccessor.WithRootLockDisabled(parentContext, true)
+	command.SetContext(unlockedContext)
+	if rootCommand := command.Root(); rootCommand != nil {
+		rootCommand.SetContext(unlockedContext)
+	}
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	degradeReadOnlyFlagNameConstant  = "degrade-readonly"
+	degradeReadOnlyFlagUsageConstant = "Run mutating commands as a dry run, with a warning, when the GitHub token is read-only"
+)
+
+// applyReadOnlyDegradationPreference records --degrade-readonly in the command context, where the workflow executor
+// reads it after the token access preflight.
+func (application *Application) applyReadOnlyDegradationPreference(command *cobra.Command) {
+	if command == nil || !application.degradeReadOnlyFlagValue {
+		return
+	}
+	parentContext := command.Context()
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	degradedContext := application.commandContextAccessor.WithReadOnlyDegradation(parentContext, true)
+	command.SetContext(degradedContext)
+	if rootCommand := command.Root(); rootCommand != nil {
+		rootCommand.SetContext(degradedContext)
+	}
+}
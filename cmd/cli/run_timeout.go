@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	runTimeoutFlagNameConstant              = "timeout"
+	runTimeoutFlagUsageConstant             = "Abort the run once this duration elapses (for example 30m); in-flight work is cancelled and a partial summary is printed. Zero disables the limit."
+	runTimeoutNegativeErrorTemplateConstant = "--timeout must not be negative: %s"
+	runTimeoutExceededErrorTemplateConstant = "run aborted after --timeout %s: %v"
+	runAbortedExitCodeConstant              = 1
+)
+
+type runTimeoutError struct {
+	timeout time.Duration
+	cause   error
+}
+
+func (timeoutError runTimeoutError) Error() string {
+	return fmt.Sprintf(runTimeoutExceededErrorTemplateConstant, timeoutError.timeout, timeoutError.cause)
+}
+
+func (timeoutError runTimeoutError) Unwrap() error {
+	return timeoutError.cause
+}
+
+// ProcessExitCode returns the aborted exit code so a timed-out run is distinguishable from a partial failure.
+func (timeoutError runTimeoutError) ProcessExitCode() int {
+	return runAbortedExitCodeConstant
+}
+
+func (application *Application) applyRunTimeout(command *cobra.Command) error {
+	if application.runTimeoutFlagValue < 0 {
+		return fmt.Errorf(runTimeoutNegativeErrorTemplateConstant, application.runTimeoutFlagValue)
+	}
+	if application.runTimeoutFlagValue == 0 || command == nil {
+		return nil
+	}
+
+	application.releaseRunTimeout()
+	parentContext := command.Context()
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	timeoutContext, cancel := context.WithTimeout(parentContext, application.runTimeoutFlagValue)
+	application.runTimeoutContext = timeoutContext
+	application.cancelRunTimeout = cancel
+
+	command.SetContext(timeoutContext)
+	if rootCommand := command.Root(); rootCommand != nil {
+		rootCommand.SetContext(timeoutContext)
+	}
+	return nil
+}
+
+func (application *Application) finishRunTimeout(executionError error) error {
+	defer application.releaseRunTimeout()
+	if application.runTimeoutContext == nil || !errors.Is(application.runTimeoutContext.Err(), context.DeadlineExceeded) {
+		return executionError
+	}
+	if executionError == nil {
+		executionError = context.DeadlineExceeded
+	}
+	return runTimeoutError{timeout: application.runTimeoutFlagValue, cause: executionError}
+}
+
+func (application *Application) releaseRunTimeout() {
+	if application.cancelRunTimeout != nil {
+		application.cancelRunTimeout()
+		application.cancelRunTimeout = nil
+	}
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationRunTimeout(t *testing.T) {
+	testCases := []struct {
+		name             string
+		timeout          time.Duration
+		expectDeadline   bool
+		expectApplyError bool
+	}{
+		{name: "disabled_by_default", timeout: 0},
+		{name: "deadline_applied", timeout: time.Hour, expectDeadline: true},
+		{name: "negative_rejected", timeout: -time.Second, expectApplyError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rootCommand := &cobra.Command{Use: "root"}
+			childCommand := &cobra.Command{Use: "child"}
+			rootCommand.AddCommand(childCommand)
+			childCommand.SetContext(context.Background())
+
+			application := &Application{runTimeoutFlagValue: testCase.timeout}
+			applyError := application.applyRunTimeout(childCommand)
+			defer application.releaseRunTimeout()
+			if testCase.expectApplyError {
+				require.Error(t, applyError)
+				return
+			}
+			require.NoError(t, applyError)
+
+			_, hasDeadline := childCommand.Context().Deadline()
+			require.Equal(t, testCase.expectDeadline, hasDeadline)
+			if testCase.expectDeadline {
+				require.Equal(t, childCommand.Context(), rootCommand.Context())
+			}
+		})
+	}
+}
+
+func TestApplicationFinishRunTimeout(t *testing.T) {
+	commandFailure := fmt.Errorf("workflow operation apply-tasks failed: %w", context.DeadlineExceeded)
+
+	testCases := []struct {
+		name            string
+		timeout         time.Duration
+		executionError  error
+		expectAborted   bool
+		expectedMessage string
+	}{
+		{name: "no_timeout_passthrough", executionError: commandFailure, expectedMessage: commandFailure.Error()},
+		{name: "completed_before_deadline", timeout: time.Hour},
+		{
+			name:            "deadline_exceeded_with_error",
+			timeout:         time.Nanosecond,
+			executionError:  commandFailure,
+			expectAborted:   true,
+			expectedMessage: "run aborted after --timeout 1ns: workflow operation apply-tasks failed: context deadline exceeded",
+		},
+		{
+			name:            "deadline_exceeded_without_error",
+			timeout:         time.Nanosecond,
+			expectAborted:   true,
+			expectedMessage: "run aborted after --timeout 1ns: context deadline exceeded",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			command := &cobra.Command{Use: "root"}
+			command.SetContext(context.Background())
+
+			application := &Application{runTimeoutFlagValue: testCase.timeout}
+			require.NoError(t, application.applyRunTimeout(command))
+			if testCase.timeout > 0 && testCase.timeout < time.Millisecond {
+				<-command.Context().Done()
+			}
+
+			finishedError := application.finishRunTimeout(testCase.executionError)
+			if len(testCase.expectedMessage) == 0 {
+				require.NoError(t, finishedError)
+				return
+			}
+			require.EqualError(t, finishedError, testCase.expectedMessage)
+
+			var timeoutError runTimeoutError
+			require.Equal(t, testCase.expectAborted, errors.As(finishedError, &timeoutError))
+			if testCase.expectAborted {
+				require.Equal(t, runAbortedExitCodeConstant, timeoutError.ProcessExitCode())
+				require.ErrorIs(t, finishedError, context.DeadlineExceeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/temirov/gix/internal/utils"
+	"github.com/temirov/gix/internal/workflow"
+)
+
+const (
+	lintCommandUseConstant              = "lint [configuration]"
+	lintCommandShortDescriptionConstant = "Validate a workflow configuration without touching repositories"
+	lintCommandLongDescriptionConstant  = "lint parses a workflow configuration, checks operation types, option keys, task actions, templates, and only/skip filters, and prints a numbered summary of the steps that would run. It exits non-zero when any problem is found and never inspects repositories."
+	lintCommandExampleConstant          = "gix workflow lint ./workflow.yaml"
+	lintProblemsErrorTemplateConstant   = "workflow lint found %d problem(s)"
+	lintStepLineTemplateConstant        = "%s\n"
+	lintIssueLineTemplateConstant       = "LINT-ERROR: %s\n"
+	lintSuccessLineTemplateConstant     = "LINT-OK: %s (%d step(s))\n"
+)
+
+func (builder *CommandBuilder) buildLintCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     lintCommandUseConstant,
+		Short:   lintCommandShortDescriptionConstant,
+		Long:    lintCommandLongDescriptionConstant,
+		Example: lintCommandExampleConstant,
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    builder.runLint,
+	}
+}
+
+func (builder *CommandBuilder) runLint(command *cobra.Command, arguments []string) error {
+	configurationPath := ""
+	if len(arguments) > 0 {
+		configurationPath = strings.TrimSpace(arguments[0])
+	} else if configurationPathFromContext, configurationPathAvailable := utils.NewCommandContextAccessor().ConfigurationFilePath(command.Context()); configurationPathAvailable {
+		configurationPath = strings.TrimSpace(configurationPathFromContext)
+	}
+
+	if len(configurationPath) == 0 {
+		if helpError := displayCommandHelp(command); helpError != nil {
+			return helpError
+		}
+		return errors.New(configurationPathRequiredMessageConstant)
+	}
+
+	report, lintError := workflow.LintConfigurationFile(configurationPath)
+	if lintError != nil {
+		return fmt.Errorf(loadConfigurationErrorTemplateConstant, lintError)
+	}
+
+	outputWriter := command.OutOrStdout()
+	for _, stepSummary := range report.Steps {
+		fmt.Fprintf(outputWriter, lintStepLineTemplateConstant, stepSummary.String())
+	}
+
+	if report.Valid() {
+		fmt.Fprintf(outputWriter, lintSuccessLineTemplateConstant, configurationPath, len(report.Steps))
+		return nil
+	}
+
+	errorWriter := command.ErrOrStderr()
+	for _, issue := range report.Issues {
+		fmt.Fprintf(errorWriter, lintIssueLineTemplateConstant, issue.String())
+	}
+	return fmt.Errorf(lintProblemsErrorTemplateConstant, len(report.Issues))
+}
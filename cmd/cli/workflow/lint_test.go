@@ -0,0 +1,81 @@
+package workflow_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	workflowcmd "github.com/temirov/gix/cmd/cli/workflow"
+)
+
+const (
+	workflowLintValidConfigContentConstant   = "workflow:\n  - step:\n      operation: audit-report\n      with:\n        output: ./audit.csv\n"
+	workflowLintInvalidConfigContentConstant = "workflow:\n  - step:\n      operation: audit-report\n      with:\n        outptu: ./audit.csv\n"
+)
+
+func TestWorkflowLintCommand(testInstance *testing.T) {
+	testCases := []struct {
+		name                 string
+		configurationContent string
+		expectedOutput       string
+		expectedErrorOutput  string
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "valid_configuration",
+			configurationContent: workflowLintValidConfigContentConstant,
+			expectedOutput:       "1. audit-report: write ./audit.csv",
+		},
+		{
+			name:                 "unknown_option_key",
+			configurationContent: workflowLintInvalidConfigContentConstant,
+			expectedErrorOutput:  "LINT-ERROR: step 1:",
+			expectedErrorMessage: "workflow lint found 1 problem(s)",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			configPath := filepath.Join(subtest.TempDir(), workflowConfigFileNameConstant)
+			require.NoError(subtest, os.WriteFile(configPath, []byte(testCase.configurationContent), 0o644))
+
+			discoverer := &fakeWorkflowDiscoverer{}
+			runner := &recordingTaskRunner{}
+			builder := workflowcmd.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     discoverer,
+				GitExecutor:    &fakeWorkflowGitExecutor{},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindGlobalWorkflowFlags(command)
+
+			var outputBuffer bytes.Buffer
+			var errorBuffer bytes.Buffer
+			command.SetOut(&outputBuffer)
+			command.SetErr(&errorBuffer)
+			command.SetContext(context.Background())
+			command.SetArgs([]string{"lint", configPath})
+
+			executionError := command.Execute()
+
+			require.Nil(subtest, discoverer.receivedRoots)
+			require.Equal(subtest, 0, runner.invocations)
+			if len(testCase.expectedErrorMessage) > 0 {
+				require.EqualError(subtest, executionError, testCase.expectedErrorMessage)
+				require.Contains(subtest, errorBuffer.String(), testCase.expectedErrorOutput)
+				return
+			}
+			require.NoError(subtest, executionError)
+			require.Contains(subtest, outputBuffer.String(), testCase.expectedOutput)
+			require.Contains(subtest, outputBuffer.String(), "LINT-OK: "+configPath+" (1 step(s))")
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package workflow
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/temirov/gix/internal/utils"
+	"github.com/temirov/gix/internal/workflow"
+)
+
+const (
+	planCommandUseConstant              = "plan [configuration]"
+	planCommandShortDescriptionConstant = "Show the combined dry-run plan of a workflow grouped by repository"
+	planCommandLongDescriptionConstant  = "plan runs every workflow step in dry-run mode regardless of --dry-run, captures the actions each step would take, and prints them grouped by repository in step order with totals. Steps that run external commands cannot be planned without running them and are listed as opaque. Nothing is mutated."
+	planCommandExampleConstant          = "gix workflow plan ./workflow.yaml --roots ~/Development"
+)
+
+func (builder *CommandBuilder) buildPlanCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     planCommandUseConstant,
+		Short:   planCommandShortDescriptionConstant,
+		Long:    planCommandLongDescriptionConstant,
+		Example: planCommandExampleConstant,
+		Args:    cobra.ArbitraryArgs,
+		RunE:    builder.runPlan,
+	}
+}
+
+func (builder *CommandBuilder) runPlan(command *cobra.Command, arguments []string) error {
+	planCollector := workflow.NewPlanCollector(utils.NewFlushingWriter(command.OutOrStdout()))
+	if executionError := builder.execute(command, arguments, planCollector); executionError != nil {
+		return executionError
+	}
+	planCollector.Render(command.OutOrStdout())
+	return nil
+}
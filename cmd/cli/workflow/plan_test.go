@@ -0,0 +1,102 @@
+package workflow_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	workflowcmd "github.com/temirov/gix/cmd/cli/workflow"
+	flagutils "github.com/temirov/gix/internal/utils/flags"
+	workflowpkg "github.com/temirov/gix/internal/workflow"
+)
+
+type planningTaskRunner struct {
+	dependencies   workflowpkg.Dependencies
+	runtimeOptions workflowpkg.RuntimeOptions
+	invocations    int
+}
+
+func (runner *planningTaskRunner) Run(_ context.Context, roots []string, _ []workflowpkg.TaskDefinition, options workflowpkg.RuntimeOptions) error {
+	runner.invocations++
+	runner.runtimeOptions = options
+	if observer, isObserver := runner.dependencies.Output.(workflowpkg.RepositoryObserver); isObserver {
+		observer.ObserveRepositories(roots)
+	}
+	for _, root := range roots {
+		fmt.Fprintf(runner.dependencies.Output, "TASK-PLAN: Add Notes %s branch=automation base=main\n", root)
+		fmt.Fprintf(runner.dependencies.Output, "TASK-PLAN: Add Notes file=NOTES.md action=write\n")
+	}
+	runner.dependencies.Reporter.Printf("PLAN-OK: %s → %s\n", roots[0], roots[0]+"-renamed")
+	fmt.Fprintf(runner.dependencies.Output, "TASK-SKIP: Add Notes %s no changes\n", roots[len(roots)-1])
+	return nil
+}
+
+func TestWorkflowPlanCommandRendersGroupedPlan(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		additionalArgs []string
+	}{
+		{
+			name: "forces_dry_run",
+		},
+		{
+			name:           "ignores_dry_run_disable",
+			additionalArgs: []string{workflowDryRunFlagConstant + "=no"},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			tempDirectory := subtest.TempDir()
+			configPath := filepath.Join(tempDirectory, workflowConfigFileNameConstant)
+			require.NoError(subtest, os.WriteFile(configPath, []byte(workflowApplyTasksConfigContentConstant), 0o644))
+
+			runner := &planningTaskRunner{}
+			builder := workflowcmd.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeWorkflowDiscoverer{},
+				GitExecutor:    &fakeWorkflowGitExecutor{},
+				ConfigurationProvider: func() workflowcmd.CommandConfiguration {
+					return workflowcmd.CommandConfiguration{Roots: []string{"/tmp/plan-alpha", "/tmp/plan-beta"}}
+				},
+				TaskRunnerFactory: func(dependencies workflowpkg.Dependencies) workflowcmd.TaskRunnerExecutor {
+					runner.dependencies = dependencies
+					return runner
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Enabled: true, Persistent: true})
+			bindGlobalWorkflowFlags(command)
+
+			var outputBuffer bytes.Buffer
+			command.SetOut(&outputBuffer)
+			command.SetErr(&bytes.Buffer{})
+			command.SetContext(context.Background())
+			command.SetArgs(append([]string{"plan", configPath}, testCase.additionalArgs...))
+
+			require.NoError(subtest, command.Execute())
+			require.Equal(subtest, 1, runner.invocations)
+			require.True(subtest, runner.runtimeOptions.DryRun)
+			require.Equal(subtest,
+				"TASK-SKIP: Add Notes /tmp/plan-beta no changes\n"+
+					"WORKFLOW-PLAN-REPOSITORY: /tmp/plan-alpha (3 planned action(s))\n"+
+					"  1. TASK-PLAN: Add Notes /tmp/plan-alpha branch=automation base=main\n"+
+					"  2. TASK-PLAN: Add Notes file=NOTES.md action=write\n"+
+					"  3. PLAN-OK: /tmp/plan-alpha → /tmp/plan-alpha-renamed\n"+
+					"WORKFLOW-PLAN-REPOSITORY: /tmp/plan-beta (2 planned action(s))\n"+
+					"  1. TASK-PLAN: Add Notes /tmp/plan-beta branch=automation base=main\n"+
+					"  2. TASK-PLAN: Add Notes file=NOTES.md action=write\n"+
+					"WORKFLOW-PLAN-TOTAL: 5 planned action(s) across 2 repository(ies), 0 opaque\n",
+				outputBuffer.String())
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -40,6 +41,7 @@ type CommandBuilder struct {
 	FileSystem                   shared.FileSystem
 	PrompterFactory              PrompterFactory
 	HumanReadableLoggingProvider func() bool
+	StructuredOutputProvider     func() bool
 	ConfigurationProvider        func() CommandConfiguration
 	TaskRunnerFactory            func(workflow.Dependencies) TaskRunnerExecutor
 }
@@ -51,15 +53,23 @@ func (builder *CommandBuilder) Build() (*cobra.Command, error) {
 		Short:   commandShortDescriptionConstant,
 		Long:    commandLongDescriptionConstant,
 		Example: commandExampleConstant,
+		Args:    cobra.ArbitraryArgs,
 		RunE:    builder.run,
 	}
 
 	flagutils.AddToggleFlag(command.Flags(), nil, requireCleanFlagNameConstant, "", false, requireCleanFlagDescriptionConstant)
+	command.AddCommand(builder.buildLintCommand())
+	command.AddCommand(builder.buildPlanCommand())
 
 	return command, nil
 }
 
 func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) error {
+	return builder.execute(command, arguments, nil)
+}
+
+// execute runs the workflow; a non-nil planCollector forces dry-run and receives every step's output for grouping.
+func (builder *CommandBuilder) execute(command *cobra.Command, arguments []string, planCollector *workflow.PlanCollector) error {
 	executionFlags, executionFlagsAvailable := flagutils.ResolveExecutionFlags(command)
 	contextAccessor := utils.NewCommandContextAccessor()
 
@@ -137,6 +147,7 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 	if clientError != nil {
 		return fmt.Errorf(gitHubClientErrorTemplateConstant, clientError)
 	}
+	gitHubClient.ConfigureResponseCache(githubcli.ResponseCacheConfiguration{Logger: logger})
 
 	repositoryDiscoverer := dependencies.ResolveRepositoryDiscoverer(builder.Discoverer)
 	fileSystem := dependencies.ResolveFileSystem(builder.FileSystem)
@@ -153,10 +164,17 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		Output:               utils.NewFlushingWriter(command.OutOrStdout()),
 		Errors:               utils.NewFlushingWriter(command.ErrOrStderr()),
 	}
+	if builder.StructuredOutputProvider != nil && builder.StructuredOutputProvider() {
+		workflowDependencies.Reporter = dependencies.ResolveOutputReporter(workflowDependencies.Output, true)
+	}
+	if planCollector != nil {
+		workflowDependencies.Output = planCollector
+		workflowDependencies.Reporter = planCollector
+	}
 
 	taskRunner := resolveTaskRunner(builder.TaskRunnerFactory, workflowDependencies)
 
-	roots, rootsError := rootutils.Resolve(command, remainingArguments, commandConfiguration.Roots)
+	roots, rootsError := rootutils.ResolveWithoutPositional(command, remainingArguments, commandConfiguration.Roots)
 	if rootsError != nil {
 		return rootsError
 	}
@@ -165,6 +183,9 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 	if executionFlagsAvailable && executionFlags.DryRunSet {
 		dryRun = executionFlags.DryRun
 	}
+	if planCollector != nil {
+		dryRun = true
+	}
 
 	assumeYes := commandConfiguration.AssumeYes
 	if executionFlagsAvailable && executionFlags.AssumeYesSet {
@@ -177,7 +198,21 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		IncludeNestedRepositories:            taskRuntimeOptions.IncludeNestedRepositories,
 		ProcessRepositoriesByDescendingDepth: taskRuntimeOptions.ProcessRepositoriesByDescendingDepth,
 		CaptureInitialWorktreeStatus:         taskRuntimeOptions.CaptureInitialWorktreeStatus,
-	}
+		RepositoryTimeout:                    workflowConfiguration.RepositoryTimeout,
+		WorkingBranch:                        workflowConfiguration.WorkingBranch,
+		LockRoots:                            true,
+	}
+
+	effectiveConfiguration := commandConfiguration
+	effectiveConfiguration.Roots = roots
+	effectiveConfiguration.DryRun = dryRun
+	effectiveConfiguration.AssumeYes = assumeYes
+	effectiveConfiguration.RequireClean = requireCleanDefault
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), struct {
+		Configuration     CommandConfiguration
+		WorkflowPath      string
+		RepositoryTimeout time.Duration
+	}{Configuration: effectiveConfiguration, WorkflowPath: configurationPath, RepositoryTimeout: workflowConfiguration.RepositoryTimeout})
 
 	return taskRunner.Run(command.Context(), roots, taskDefinitions, runtimeOptions)
 }
@@ -33,6 +33,21 @@ workflow:
             files:
               - path: NOTES.md
                 content: "Repository: {{ .Repository.Name }}"
+`
+	workflowStepOutputsConfigContentConstant = `
+workflow:
+  - step:
+      id: rename
+      operation: rename-directories
+  - step:
+      id: notes
+      operation: apply-tasks
+      with:
+        tasks:
+          - name: Record Path
+            files:
+              - path: PATH.md
+                content: "{{ .Steps.rename.renamed_path }}"
 `
 	workflowConfiguredRootConstant = "/tmp/workflow-config-root"
 	workflowCliRootConstant        = "/tmp/workflow-cli-root"
@@ -253,6 +268,44 @@ func TestWorkflowCommandApplyTasksDryRun(testInstance *testing.T) {
 	require.Equal(testInstance, "NOTES.md", task.Files[0].PathTemplate)
 }
 
+func TestWorkflowCommandPropagatesStepOutputReferences(testInstance *testing.T) {
+	tempDirectory := testInstance.TempDir()
+	repositoryPath := filepath.Join(tempDirectory, "sample")
+	require.NoError(testInstance, os.MkdirAll(repositoryPath, 0o755))
+
+	configPath := filepath.Join(tempDirectory, workflowConfigFileNameConstant)
+	configContent := strings.TrimSpace(workflowStepOutputsConfigContentConstant)
+	require.NoError(testInstance, os.WriteFile(configPath, []byte(configContent), 0o644))
+
+	runner := &recordingTaskRunner{}
+	builder := workflowcmd.CommandBuilder{
+		LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+		Discoverer:     &fakeWorkflowDiscoverer{repositories: []string{repositoryPath}},
+		GitExecutor:    &fakeWorkflowGitExecutor{},
+		ConfigurationProvider: func() workflowcmd.CommandConfiguration {
+			return workflowcmd.CommandConfiguration{Roots: []string{repositoryPath}, DryRun: true}
+		},
+		TaskRunnerFactory: func(workflowpkg.Dependencies) workflowcmd.TaskRunnerExecutor {
+			return runner
+		},
+	}
+
+	command, buildError := builder.Build()
+	require.NoError(testInstance, buildError)
+	bindGlobalWorkflowFlags(command)
+	command.SetOut(&bytes.Buffer{})
+	command.SetErr(&bytes.Buffer{})
+	command.SetContext(context.Background())
+	command.SetArgs([]string{configPath})
+
+	require.NoError(testInstance, command.Execute())
+	require.Len(testInstance, runner.definitions, 2)
+	require.Equal(testInstance, "rename", runner.definitions[0].StepID)
+	require.Empty(testInstance, runner.definitions[0].StepReferences)
+	require.Equal(testInstance, "notes", runner.definitions[1].StepID)
+	require.Equal(testInstance, []workflowpkg.StepOutputReference{{StepID: "rename", Output: "renamed_path"}}, runner.definitions[1].StepReferences)
+}
+
 type fakeWorkflowDiscoverer struct {
 	receivedRoots []string
 	repositories  []string
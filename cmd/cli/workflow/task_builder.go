@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/temirov/gix/internal/repos/selection"
 	workflowpkg "github.com/temirov/gix/internal/workflow"
 )
 
@@ -13,6 +14,9 @@ const (
 	taskNameRenameDirectories      = "Rename repository directories"
 	taskNamePromoteDefaultBranch   = "Promote default branch to %s"
 	taskNameGenerateAuditReport    = "Generate audit report"
+	taskNameEditRepository         = "Edit repository topics and description"
+	taskNameCreatePullRequest      = "Open pull request"
+	taskNameCommitStagedChanges    = "Commit staged changes"
 	defaultMigrationRemoteFallback = "origin"
 	defaultMigrationTargetFallback = "master"
 )
@@ -27,6 +31,38 @@ func buildWorkflowTasks(operations []workflowpkg.Operation) ([]workflowpkg.TaskD
 			continue
 		}
 
+		var stepID string
+		var stepReferences []workflowpkg.StepOutputReference
+		if identifiedOperation, isIdentified := operation.(*workflowpkg.IdentifiedOperation); isIdentified {
+			stepID = identifiedOperation.StepID()
+			stepReferences = identifiedOperation.References()
+			operation = identifiedOperation.Unwrap()
+		}
+
+		stepTimeout := workflowpkg.DefaultStepTimeout
+		if timedOperation, isTimed := operation.(*workflowpkg.TimedOperation); isTimed {
+			stepTimeout = timedOperation.Timeout()
+			operation = timedOperation.Unwrap()
+		}
+
+		var repositoryFilter selection.Matcher
+		if filteredOperation, isFiltered := operation.(*workflowpkg.FilteredOperation); isFiltered {
+			repositoryFilter = filteredOperation.Filter()
+			operation = filteredOperation.Unwrap()
+		}
+
+		var stepEnvironment map[string]string
+		if environmentOperation, hasEnvironment := operation.(*workflowpkg.EnvironmentOperation); hasEnvironment {
+			stepEnvironment = environmentOperation.Environment()
+			operation = environmentOperation.Unwrap()
+		}
+
+		// Task actions render their string options per repository, so templated steps convert like any other.
+		if templatedOperation, isTemplated := operation.(*workflowpkg.TemplatedOperation); isTemplated {
+			operation = templatedOperation.Unwrap()
+		}
+		firstDefinitionIndex := len(taskDefinitions)
+
 		switch typedOperation := operation.(type) {
 		case *workflowpkg.TaskOperation:
 			taskDefinitions = append(taskDefinitions, typedOperation.Definitions()...)
@@ -63,6 +99,12 @@ func buildWorkflowTasks(operations []workflowpkg.Operation) ([]workflowpkg.TaskD
 				"require_clean": typedOperation.RequireCleanWorktree,
 				"include_owner": typedOperation.IncludeOwner,
 			}
+			if trimmedPlanFile := strings.TrimSpace(typedOperation.PlanFilePath); len(trimmedPlanFile) > 0 {
+				options["plan_file"] = trimmedPlanFile
+			}
+			if trimmedNamingTemplate := strings.TrimSpace(typedOperation.NamingTemplate); len(trimmedNamingTemplate) > 0 {
+				options["naming_template"] = workflowpkg.EscapeTemplateDelimiters(trimmedNamingTemplate)
+			}
 			taskDefinitions = append(taskDefinitions, workflowpkg.TaskDefinition{
 				Name:        taskNameRenameDirectories,
 				EnsureClean: false,
@@ -110,6 +152,24 @@ func buildWorkflowTasks(operations []workflowpkg.Operation) ([]workflowpkg.TaskD
 			if target.DeleteSourceBranch {
 				options["delete_source_branch"] = true
 			}
+			if trimmedRetention := strings.TrimSpace(target.RetainSource); len(trimmedRetention) > 0 {
+				options["retain_source"] = trimmedRetention
+			}
+			if target.LeaveTombstone {
+				options["leave_tombstone"] = true
+			}
+			if target.UpdateDocumentation {
+				options["update_docs"] = true
+			}
+			if len(target.DocumentationPatterns) > 0 {
+				options["docs_patterns"] = append([]string(nil), target.DocumentationPatterns...)
+			}
+			if trimmedPhase := strings.TrimSpace(target.Phase); len(trimmedPhase) > 0 {
+				options["phase"] = trimmedPhase
+			}
+			if target.GracePeriod > 0 {
+				options["grace_period"] = target.GracePeriod.String()
+			}
 
 			taskDefinitions = append(taskDefinitions, workflowpkg.TaskDefinition{
 				Name:        fmt.Sprintf(taskNamePromoteDefaultBranch, trimmedTarget),
@@ -124,6 +184,12 @@ func buildWorkflowTasks(operations []workflowpkg.Operation) ([]workflowpkg.TaskD
 			if trimmedOutput := strings.TrimSpace(typedOperation.OutputPath); len(trimmedOutput) > 0 {
 				options["output"] = trimmedOutput
 			}
+			if typedOperation.FailOnNested {
+				options["fail_on_nested"] = true
+			}
+			if len(typedOperation.SortOrder) > 0 {
+				options["sort"] = string(typedOperation.SortOrder)
+			}
 			taskDefinitions = append(taskDefinitions, workflowpkg.TaskDefinition{
 				Name:        taskNameGenerateAuditReport,
 				EnsureClean: false,
@@ -132,9 +198,67 @@ func buildWorkflowTasks(operations []workflowpkg.Operation) ([]workflowpkg.TaskD
 				},
 			})
 
+		case *workflowpkg.EditRepositoryOperation:
+			options := map[string]any{}
+			if len(typedOperation.AddTopics) > 0 {
+				options["add_topics"] = append([]string(nil), typedOperation.AddTopics...)
+			}
+			if len(typedOperation.RemoveTopics) > 0 {
+				options["remove_topics"] = append([]string(nil), typedOperation.RemoveTopics...)
+			}
+			if typedOperation.UpdateDescription {
+				options["description"] = typedOperation.Description
+			}
+			taskDefinitions = append(taskDefinitions, workflowpkg.TaskDefinition{
+				Name:        taskNameEditRepository,
+				EnsureClean: false,
+				Actions: []workflowpkg.TaskActionDefinition{
+					{Type: "repo.edit", Options: options},
+				},
+			})
+
+		case *workflowpkg.CreatePullRequestOperation:
+			options := map[string]any{"title": typedOperation.Title}
+			if len(typedOperation.Body) > 0 {
+				options["body"] = typedOperation.Body
+			}
+			if len(typedOperation.Base) > 0 {
+				options["base"] = typedOperation.Base
+			}
+			if len(typedOperation.Head) > 0 {
+				options["head"] = typedOperation.Head
+			}
+			if typedOperation.Draft {
+				options["draft"] = true
+			}
+			taskDefinitions = append(taskDefinitions, workflowpkg.TaskDefinition{
+				Name:        taskNameCreatePullRequest,
+				EnsureClean: false,
+				Actions: []workflowpkg.TaskActionDefinition{
+					{Type: "repo.pull-request.create", Options: options},
+				},
+			})
+
+		case *workflowpkg.CommitOperation:
+			taskDefinitions = append(taskDefinitions, workflowpkg.TaskDefinition{
+				Name:        taskNameCommitStagedChanges,
+				EnsureClean: false,
+				Actions: []workflowpkg.TaskActionDefinition{
+					{Type: "repo.commit.staged", Options: map[string]any{"message": workflowpkg.EscapeTemplateDelimiters(typedOperation.MessageTemplate)}},
+				},
+			})
+
 		default:
 			return nil, workflowpkg.RuntimeOptions{}, fmt.Errorf("unsupported workflow operation: %s", operation.Name())
 		}
+
+		for definitionIndex := firstDefinitionIndex; definitionIndex < len(taskDefinitions); definitionIndex++ {
+			taskDefinitions[definitionIndex].Timeout = stepTimeout
+			taskDefinitions[definitionIndex].RepositoryFilter = repositoryFilter
+			taskDefinitions[definitionIndex].Environment = stepEnvironment
+			taskDefinitions[definitionIndex].StepID = stepID
+			taskDefinitions[definitionIndex].StepReferences = stepReferences
+		}
 	}
 
 	return taskDefinitions, accumulatedRuntime, nil
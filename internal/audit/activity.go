@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	gitVerifyFlagConstant         = "--verify"
+	gitQuietLongFlagConstant      = "--quiet"
+	lastActivityNoCommitsConstant = "no commits"
+)
+
+// CommitActivity records when a repository last received a commit.
+type CommitActivity struct {
+	// Inspected reports whether the activity was gathered; minimal inspections skip it.
+	Inspected bool
+	// LastCommit is the committer timestamp of HEAD, or the zero time when the repository has no commits yet.
+	LastCommit time.Time
+}
+
+// HasCommits reports whether the repository has at least one commit.
+func (activity CommitActivity) HasCommits() bool {
+	return !activity.LastCommit.IsZero()
+}
+
+// String renders the activity for reports: an RFC 3339 timestamp, "no commits" for freshly initialized repositories,
+// or an empty string when the activity was not inspected.
+func (activity CommitActivity) String() string {
+	switch {
+	case !activity.Inspected:
+		return ""
+	case !activity.HasCommits():
+		return lastActivityNoCommitsConstant
+	default:
+		return activity.LastCommit.Format(time.RFC3339)
+	}
+}
+
+func (service *Service) inspectCommitActivity(executionContext context.Context, repositoryPath string) (CommitActivity, error) {
+	logResult, logError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitLogSubcommandConstant, gitLogSingleCommitFlagConstant, gitLogCommitDateFormatFlagConstant},
+		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
+	})
+	if execshell.IsExecutableNotFound(logError) {
+		return CommitActivity{}, logError
+	}
+	if logError == nil {
+		lastCommit, parseError := time.Parse(time.RFC3339, strings.TrimSpace(logResult.StandardOutput))
+		if parseError != nil {
+			return CommitActivity{}, nil
+		}
+		return CommitActivity{Inspected: true, LastCommit: lastCommit}, nil
+	}
+
+	_, verifyError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitRevParseSubcommandConstant, gitVerifyFlagConstant, gitQuietLongFlagConstant, gitHeadReferenceConstant},
+		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
+	})
+	if execshell.IsExecutableNotFound(verifyError) {
+		return CommitActivity{}, verifyError
+	}
+	if verifyError != nil {
+		return CommitActivity{Inspected: true}, nil
+	}
+	return CommitActivity{}, nil
+}
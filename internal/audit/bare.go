@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+const (
+	bareRepositoryLocalBranchMarkerConstant = "(bare)"
+	bareRepositoryFolderSuffixConstant      = ".git"
+)
+
+// SetIncludeBareRepositories controls whether DiscoverInspections finds and reports bare repositories such as
+// --mirror clones. Bare repositories have no worktree, so dirty-state, checked-out branch, in-progress operation,
+// identity, and stale origin/HEAD checks are skipped for them; remote URL, protocol, and reachability checks still run.
+func (service *Service) SetIncludeBareRepositories(include bool) {
+	service.includeBareRepositories = include
+}
+
+// folderNameMatchesDesired reports whether the inspected folder carries the repository's name. Bare repositories may
+// keep the conventional .git suffix that git clone --mirror adds.
+func folderNameMatchesDesired(inspection RepositoryInspection) bool {
+	if len(inspection.DesiredFolderName) == 0 {
+		return false
+	}
+	folderBaseName := filepath.Base(inspection.FolderName)
+	if inspection.DesiredFolderName == folderBaseName {
+		return true
+	}
+	return inspection.IsBare && inspection.DesiredFolderName == strings.TrimSuffix(folderBaseName, bareRepositoryFolderSuffixConstant)
+}
@@ -0,0 +1,73 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const bareMirrorConfigurationConstant = "[core]\n\tbare = true\n[remote \"origin\"]\n\turl = https://github.com/octocat/sample.git\n\tmirror = true\n"
+
+func createBareRepository(testInstance *testing.T, repositoryPath string) {
+	testInstance.Helper()
+	for _, directoryName := range []string{"objects", "refs"} {
+		require.NoError(testInstance, os.MkdirAll(filepath.Join(repositoryPath, directoryName), 0o755))
+	}
+	require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "HEAD"), []byte("ref: refs/heads/main\n"), 0o644))
+	require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "config"), []byte(bareMirrorConfigurationConstant), 0o644))
+}
+
+func TestServiceDiscoverInspectionsBareRepositories(testInstance *testing.T) {
+	testCases := []struct {
+		name                string
+		includeBare         bool
+		expectedInspections int
+	}{
+		{name: "bare_repositories_included", includeBare: true, expectedInspections: 1},
+		{name: "bare_repositories_excluded", includeBare: false, expectedInspections: 0},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			rootDirectory := subtest.TempDir()
+			mirrorPath := filepath.Join(rootDirectory, "sample.git")
+			createBareRepository(subtest, mirrorPath)
+
+			service := audit.NewService(
+				stubDiscoverer{},
+				stubGitManager{remoteURL: "https://github.com/octocat/sample.git", panicOnBranchLookup: true},
+				stubGitExecutor{outputs: map[string]execshell.ExecutionResult{"log -1 --format=%cI": {StandardOutput: "2025-11-02T08:30:00-05:00\n"}}},
+				nil,
+				&bytes.Buffer{},
+				&bytes.Buffer{},
+			)
+			service.DisableCheckCategory(audit.CheckCategoryRemote)
+			service.SetIncludeBareRepositories(testCase.includeBare)
+
+			inspections, discoveryError := service.DiscoverInspections(context.Background(), []string{rootDirectory}, true, false, audit.InspectionDepthFull)
+			require.NoError(subtest, discoveryError)
+			require.Len(subtest, inspections, testCase.expectedInspections)
+			if testCase.expectedInspections == 0 {
+				return
+			}
+
+			require.True(subtest, inspections[0].IsBare)
+			require.Equal(subtest, audit.RemoteProtocolHTTPS, inspections[0].RemoteProtocol)
+			require.True(subtest, inspections[0].LastActivity.HasCommits())
+
+			reportRow := audit.NewJSONReport(inspections, service.ScoreWeights()).Repositories[0]
+			require.True(subtest, reportRow.Bare)
+			require.Equal(subtest, "(bare)", reportRow.LocalBranch)
+			require.Equal(subtest, audit.TernaryValueYes, reportRow.NameMatches)
+			require.Equal(subtest, audit.TernaryValueOffline, reportRow.InSync)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package audit
+
+// CheckCategory groups inspection checks by the resources they require.
+type CheckCategory string
+
+// Supported check categories.
+const (
+	// CheckCategoryLocal covers checks derived from the working tree and git configuration alone.
+	CheckCategoryLocal CheckCategory = "local"
+	// CheckCategoryRemote covers checks that contact GitHub or the git remote.
+	CheckCategoryRemote CheckCategory = "remote"
+)
+
+// DisableCheckCategory turns off every inspection check in the provided category.
+func (service *Service) DisableCheckCategory(category CheckCategory) {
+	if service.disabledCategories == nil {
+		service.disabledCategories = make(map[CheckCategory]struct{})
+	}
+	service.disabledCategories[category] = struct{}{}
+}
+
+// CheckCategoryEnabled reports whether checks in the provided category run during inspections.
+func (service *Service) CheckCategoryEnabled(category CheckCategory) bool {
+	_, disabled := service.disabledCategories[category]
+	return !disabled
+}
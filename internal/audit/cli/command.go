@@ -2,6 +2,9 @@ package cli
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"os/signal"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -11,8 +14,10 @@ import (
 	"github.com/temirov/gix/internal/githubcli"
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/dependencies"
+	"github.com/temirov/gix/internal/repos/shared"
 	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
+	rootutils "github.com/temirov/gix/internal/utils/roots"
 	"github.com/temirov/gix/internal/workflow"
 )
 
@@ -24,13 +29,53 @@ const (
 	flagRootDescriptionConstant      = "Repository roots to scan (repeatable; nested paths ignored)"
 	flagIncludeAllNameConstant       = "all"
 	flagIncludeAllDescription        = "Include directories without Git repositories in the audit output"
+	flagOfflineNameConstant          = "offline"
+	flagOfflineDescription           = "Skip GitHub and git remote checks and report only locally derivable facts"
+	flagFailOnNestedNameConstant     = "fail-on-nested"
+	flagFailOnNestedDescription      = "Exit with an error when a repository is nested inside another repository"
+	flagDuplicatesOnlyNameConstant   = "duplicates-only"
+	flagDuplicatesOnlyDescription    = "Print only groups of repositories cloned more than once instead of the audit report"
+	flagSortNameConstant             = "sort"
+	flagSortDescription              = "Order report rows by path, owner, activity (least recent first), issues (most first), or score (highest first)"
+	flagFormatNameConstant           = "format"
+	flagFormatDescription            = "Report format: csv rows with findings on stderr, a markdown document for issues and wikis, or json with per-category score contributions"
+	flagMinScoreNameConstant         = "min-score"
+	flagMinScoreDescription          = "Report only repositories whose health score is at least this value"
+	flagFixNameConstant              = "fix"
+	flagFixDescription               = "Apply safe reconciliations (remote URL, origin/HEAD, protocol) without prompting and list unsafe findings for manual handling"
+	flagFixProtocolNameConstant      = "fix-protocol"
+	flagFixProtocolDescription       = "Remote protocol (git, ssh, https) that --fix normalizes origin URLs to"
+	flagUnshallowNameConstant        = "unshallow"
+	flagUnshallowDescription         = "Let --fix fetch the full history of shallow clones with git fetch --unshallow"
+	unshallowRequiresFixErrorMessage = "--unshallow requires --fix"
+	flagIncludeBareNameConstant      = "include-bare"
+	flagIncludeBareDescription       = "Audit bare repositories such as --mirror clones, skipping worktree-only checks"
+	flagExcludeBareNameConstant      = "exclude-bare"
+	flagExcludeBareDescription       = "Leave bare repositories out of the audit"
+	bareFlagsConflictErrorTemplate   = "--%s and --%s cannot both be set"
+	flagWatchNameConstant            = "watch"
+	flagWatchDescription             = "Keep running after the audit and re-audit repositories whose HEAD or config changes until Ctrl-C"
+	watchConflictErrorMessage        = "--watch cannot be combined with --fix, --duplicates-only, --all, or a markdown or json --format"
 	taskNameGenerateAuditReport      = "Generate audit report"
-	missingRootsErrorMessageConstant = "no repository roots provided; specify --roots or configure defaults"
 )
 
 type commandOptions struct {
 	debugOutput       bool
 	includeAllFolders bool
+	offline           bool
+	failOnNested      bool
+	duplicatesOnly    bool
+	sortOrder         audit.ReportSortOrder
+	reportFormat      audit.ReportFormat
+	minimumScore      int
+	scoreWeights      map[string]int
+	identityRules     []audit.IdentityRule
+	fix               bool
+	fixProtocol       audit.RemoteProtocolType
+	unshallow         bool
+	includeBare       bool
+	watch             bool
+	githubHost        string
 	repositoryRoots   []string
 }
 
@@ -47,26 +92,39 @@ type CommandBuilder struct {
 	HumanReadableLoggingProvider func() bool
 	ConfigurationProvider        func() audit.CommandConfiguration
 	TaskRunnerFactory            func(workflow.Dependencies) TaskRunnerExecutor
+	WatcherFactory               func() (audit.FileWatcher, error)
 }
 
 // Build constructs the audit command.
 func (builder *CommandBuilder) Build() (*cobra.Command, error) {
 	command := &cobra.Command{
-		Use:   commandUseConstant,
+		Use:   commandUseConstant + " " + flagutils.RootArgumentsUsage,
 		Short: commandShortDescriptionConstant,
 		Long:  commandLongDescriptionConstant,
-		Args:  builder.noArgumentValidator(),
+		Args:  cobra.ArbitraryArgs,
 		RunE:  builder.run,
 	}
 
 	command.Flags().StringSlice(flagRootNameConstant, nil, flagRootDescriptionConstant)
 	command.Flags().Bool(flagIncludeAllNameConstant, false, flagIncludeAllDescription)
+	command.Flags().Bool(flagOfflineNameConstant, false, flagOfflineDescription)
+	command.Flags().Bool(flagFailOnNestedNameConstant, false, flagFailOnNestedDescription)
+	command.Flags().Bool(flagDuplicatesOnlyNameConstant, false, flagDuplicatesOnlyDescription)
+	command.Flags().String(flagSortNameConstant, "", flagSortDescription)
+	command.Flags().String(flagFormatNameConstant, "", flagutils.FormatChoiceUsage(string(audit.ReportFormatCSV), audit.ReportFormats(), flagFormatDescription))
+	command.Flags().Int(flagMinScoreNameConstant, 0, flagMinScoreDescription)
+	command.Flags().Bool(flagFixNameConstant, false, flagFixDescription)
+	command.Flags().String(flagFixProtocolNameConstant, "", flagFixProtocolDescription)
+	command.Flags().Bool(flagUnshallowNameConstant, false, flagUnshallowDescription)
+	command.Flags().Bool(flagIncludeBareNameConstant, true, flagIncludeBareDescription)
+	command.Flags().Bool(flagExcludeBareNameConstant, false, flagExcludeBareDescription)
+	command.Flags().Bool(flagWatchNameConstant, false, flagWatchDescription)
 
 	return command, nil
 }
 
 func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) error {
-	options, optionsError := builder.parseOptions(command)
+	options, optionsError := builder.parseOptions(command, arguments)
 	if optionsError != nil {
 		return optionsError
 	}
@@ -82,6 +140,11 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 	}
 
 	logger := builder.resolveLogger()
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), struct {
+		Options   commandOptions
+		DryRun    bool
+		AssumeYes bool
+	}{Options: options, DryRun: dryRun, AssumeYes: assumeYes})
 	humanReadable := false
 	if builder.HumanReadableLoggingProvider != nil {
 		humanReadable = builder.HumanReadableLoggingProvider()
@@ -119,8 +182,12 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		}
 		client = constructedClient
 	}
+	client.ConfigureResponseCache(githubcli.ResponseCacheConfiguration{Logger: logger})
 
 	repositoryDiscoverer := dependencies.ResolveRepositoryDiscoverer(builder.Discoverer)
+	if options.watch {
+		return builder.runWatch(command, options, audit.NewService(repositoryDiscoverer, repositoryManager, gitExecutor, client, command.OutOrStdout(), command.ErrOrStderr()))
+	}
 
 	taskDependencies := workflow.Dependencies{
 		Logger:               logger,
@@ -141,6 +208,48 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		"debug":       options.debugOutput,
 		"depth":       string(audit.InspectionDepthFull),
 	}
+	if options.failOnNested {
+		actionOptions["fail_on_nested"] = true
+	}
+	if options.duplicatesOnly {
+		actionOptions["duplicates_only"] = true
+	}
+	if len(options.githubHost) > 0 {
+		actionOptions["github_host"] = options.githubHost
+	}
+	if len(options.sortOrder) > 0 {
+		actionOptions["sort"] = string(options.sortOrder)
+	}
+	if options.reportFormat.Structured() {
+		actionOptions["format"] = string(options.reportFormat)
+	}
+	if options.minimumScore > 0 {
+		actionOptions["min_score"] = options.minimumScore
+	}
+	if len(options.scoreWeights) > 0 {
+		scoreWeights := make(map[string]any, len(options.scoreWeights))
+		for category, weight := range options.scoreWeights {
+			scoreWeights[category] = weight
+		}
+		actionOptions["score_weights"] = scoreWeights
+	}
+	if len(options.identityRules) > 0 {
+		identityRules := make([]any, 0, len(options.identityRules))
+		for _, rule := range options.identityRules {
+			identityRules = append(identityRules, map[string]any{"owner": rule.Owner, "email_pattern": rule.EmailPattern})
+		}
+		actionOptions["identity_rules"] = identityRules
+	}
+	if options.fix {
+		actionOptions["fix"] = true
+	}
+	if len(options.fixProtocol) > 0 {
+		actionOptions["fix_protocol"] = string(options.fixProtocol)
+	}
+	if options.unshallow {
+		actionOptions["unshallow"] = true
+	}
+	actionOptions["include_bare"] = options.includeBare
 
 	taskDefinition := workflow.TaskDefinition{
 		Name:        taskNameGenerateAuditReport,
@@ -150,12 +259,12 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		},
 	}
 
-	runtimeOptions := workflow.RuntimeOptions{DryRun: dryRun, AssumeYes: assumeYes}
+	runtimeOptions := workflow.RuntimeOptions{DryRun: dryRun, AssumeYes: assumeYes, Offline: options.offline, IncludeBareRepositories: options.includeBare}
 
 	return taskRunner.Run(command.Context(), options.repositoryRoots, []workflow.TaskDefinition{taskDefinition}, runtimeOptions)
 }
 
-func (builder *CommandBuilder) parseOptions(command *cobra.Command) (commandOptions, error) {
+func (builder *CommandBuilder) parseOptions(command *cobra.Command, arguments []string) (commandOptions, error) {
 	configuration := builder.resolveConfiguration()
 
 	debugMode := configuration.Debug
@@ -168,10 +277,9 @@ func (builder *CommandBuilder) parseOptions(command *cobra.Command) (commandOpti
 		}
 	}
 
-	repositoryRoots := append([]string{}, configuration.Roots...)
-	if command != nil && command.Flags().Changed(flagRootNameConstant) {
-		flagRoots, _ := command.Flags().GetStringSlice(flagRootNameConstant)
-		repositoryRoots = audit.CommandConfiguration{Roots: flagRoots}.Sanitize().Roots
+	repositoryRoots, rootsError := rootutils.Resolve(command, arguments, configuration.Roots)
+	if rootsError != nil {
+		return commandOptions{}, rootsError
 	}
 
 	includeAll := configuration.IncludeAll
@@ -185,16 +293,170 @@ func (builder *CommandBuilder) parseOptions(command *cobra.Command) (commandOpti
 		}
 	}
 
-	if len(repositoryRoots) == 0 {
-		if command != nil {
-			_ = command.Help()
+	offline := configuration.Offline
+	if command != nil {
+		offlineValue, offlineChanged, offlineError := flagutils.BoolFlag(command, flagOfflineNameConstant)
+		if offlineError != nil && !errors.Is(offlineError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, offlineError
+		}
+		if offlineChanged {
+			offline = offlineValue
+		}
+	}
+
+	failOnNested := configuration.FailOnNested
+	if command != nil {
+		failOnNestedValue, failOnNestedChanged, failOnNestedError := flagutils.BoolFlag(command, flagFailOnNestedNameConstant)
+		if failOnNestedError != nil && !errors.Is(failOnNestedError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, failOnNestedError
+		}
+		if failOnNestedChanged {
+			failOnNested = failOnNestedValue
+		}
+	}
+
+	duplicatesOnly := configuration.DuplicatesOnly
+	if command != nil {
+		duplicatesOnlyValue, duplicatesOnlyChanged, duplicatesOnlyError := flagutils.BoolFlag(command, flagDuplicatesOnlyNameConstant)
+		if duplicatesOnlyError != nil && !errors.Is(duplicatesOnlyError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, duplicatesOnlyError
+		}
+		if duplicatesOnlyChanged {
+			duplicatesOnly = duplicatesOnlyValue
+		}
+	}
+
+	sortValue := configuration.Sort
+	if command != nil && command.Flags().Lookup(flagSortNameConstant) != nil && command.Flags().Changed(flagSortNameConstant) {
+		sortFlagValue, sortFlagError := command.Flags().GetString(flagSortNameConstant)
+		if sortFlagError != nil {
+			return commandOptions{}, sortFlagError
+		}
+		sortValue = sortFlagValue
+	}
+	var sortOrder audit.ReportSortOrder
+	if len(strings.TrimSpace(sortValue)) > 0 {
+		parsedSortOrder, sortParseError := audit.ParseReportSortOrder(sortValue)
+		if sortParseError != nil {
+			return commandOptions{}, sortParseError
+		}
+		sortOrder = parsedSortOrder
+	}
+
+	formatValue := configuration.Format
+	if command != nil && command.Flags().Lookup(flagFormatNameConstant) != nil && command.Flags().Changed(flagFormatNameConstant) {
+		formatFlagValue, formatFlagError := command.Flags().GetString(flagFormatNameConstant)
+		if formatFlagError != nil {
+			return commandOptions{}, formatFlagError
+		}
+		formatValue = formatFlagValue
+	}
+	reportFormat, formatParseError := audit.ParseReportFormat(formatValue)
+	if formatParseError != nil {
+		return commandOptions{}, formatParseError
+	}
+
+	minimumScore := configuration.MinScore
+	if command != nil && command.Flags().Lookup(flagMinScoreNameConstant) != nil && command.Flags().Changed(flagMinScoreNameConstant) {
+		minimumScoreFlagValue, minimumScoreFlagError := command.Flags().GetInt(flagMinScoreNameConstant)
+		if minimumScoreFlagError != nil {
+			return commandOptions{}, minimumScoreFlagError
 		}
-		return commandOptions{}, errors.New(missingRootsErrorMessageConstant)
+		minimumScore = minimumScoreFlagValue
+	}
+
+	fix := configuration.Fix
+	if command != nil {
+		fixValue, fixChanged, fixError := flagutils.BoolFlag(command, flagFixNameConstant)
+		if fixError != nil && !errors.Is(fixError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, fixError
+		}
+		if fixChanged {
+			fix = fixValue
+		}
+	}
+
+	fixProtocolValue := configuration.FixProtocol
+	if command != nil && command.Flags().Lookup(flagFixProtocolNameConstant) != nil && command.Flags().Changed(flagFixProtocolNameConstant) {
+		fixProtocolFlagValue, fixProtocolFlagError := command.Flags().GetString(flagFixProtocolNameConstant)
+		if fixProtocolFlagError != nil {
+			return commandOptions{}, fixProtocolFlagError
+		}
+		fixProtocolValue = fixProtocolFlagValue
+	}
+	var fixProtocol audit.RemoteProtocolType
+	if len(strings.TrimSpace(fixProtocolValue)) > 0 {
+		parsedProtocol, protocolParseError := shared.ParseRemoteProtocol(fixProtocolValue)
+		if protocolParseError != nil {
+			return commandOptions{}, protocolParseError
+		}
+		fixProtocol = parsedProtocol
+	}
+
+	unshallow := configuration.Unshallow
+	if command != nil {
+		unshallowValue, unshallowChanged, unshallowError := flagutils.BoolFlag(command, flagUnshallowNameConstant)
+		if unshallowError != nil && !errors.Is(unshallowError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, unshallowError
+		}
+		if unshallowChanged {
+			unshallow = unshallowValue
+		}
+	}
+	if unshallow && !fix {
+		return commandOptions{}, errors.New(unshallowRequiresFixErrorMessage)
+	}
+
+	includeBare := !configuration.ExcludeBare
+	if command != nil {
+		includeBareValue, includeBareChanged, includeBareError := flagutils.BoolFlag(command, flagIncludeBareNameConstant)
+		if includeBareError != nil && !errors.Is(includeBareError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, includeBareError
+		}
+		excludeBareValue, excludeBareChanged, excludeBareError := flagutils.BoolFlag(command, flagExcludeBareNameConstant)
+		if excludeBareError != nil && !errors.Is(excludeBareError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, excludeBareError
+		}
+		if includeBareChanged && excludeBareChanged && includeBareValue == excludeBareValue {
+			return commandOptions{}, fmt.Errorf(bareFlagsConflictErrorTemplate, flagIncludeBareNameConstant, flagExcludeBareNameConstant)
+		}
+		if includeBareChanged {
+			includeBare = includeBareValue
+		}
+		if excludeBareChanged {
+			includeBare = !excludeBareValue
+		}
+	}
+
+	watch := false
+	if command != nil {
+		watchValue, _, watchError := flagutils.BoolFlag(command, flagWatchNameConstant)
+		if watchError != nil && !errors.Is(watchError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, watchError
+		}
+		watch = watchValue
+	}
+	if watch && (fix || duplicatesOnly || includeAll || reportFormat.Structured()) {
+		return commandOptions{}, errors.New(watchConflictErrorMessage)
 	}
 
 	return commandOptions{
 		repositoryRoots:   repositoryRoots,
 		includeAllFolders: includeAll,
+		offline:           offline,
+		failOnNested:      failOnNested,
+		duplicatesOnly:    duplicatesOnly,
+		sortOrder:         sortOrder,
+		reportFormat:      reportFormat,
+		minimumScore:      minimumScore,
+		scoreWeights:      configuration.ScoreWeights,
+		identityRules:     configuration.IdentityRules,
+		fix:               fix,
+		fixProtocol:       fixProtocol,
+		unshallow:         unshallow,
+		includeBare:       includeBare,
+		watch:             watch,
+		githubHost:        configuration.GitHubHost,
 		debugOutput:       debugMode,
 	}, nil
 }
@@ -217,12 +479,46 @@ func (builder *CommandBuilder) resolveConfiguration() audit.CommandConfiguration
 	return builder.ConfigurationProvider().Sanitize()
 }
 
-func (builder *CommandBuilder) noArgumentValidator() cobra.PositionalArgs {
-	return func(command *cobra.Command, arguments []string) error {
-		if len(arguments) == 0 {
-			return nil
+// runWatch audits the roots once and then keeps re-auditing changed repositories until the command is interrupted.
+func (builder *CommandBuilder) runWatch(command *cobra.Command, options commandOptions, service *audit.Service) error {
+	if len(options.scoreWeights) > 0 {
+		configuredWeights := make(map[string]any, len(options.scoreWeights))
+		for category, weight := range options.scoreWeights {
+			configuredWeights[category] = weight
+		}
+		scoreWeights, weightsError := audit.ParseScoreWeights(configuredWeights)
+		if weightsError != nil {
+			return weightsError
 		}
-		_ = command.Help()
-		return cobra.NoArgs(command, arguments)
+		service.SetScoreWeights(scoreWeights)
+	}
+	if len(options.identityRules) > 0 {
+		if rulesError := service.SetIdentityRules(options.identityRules); rulesError != nil {
+			return rulesError
+		}
+	}
+
+	watcherFactory := builder.WatcherFactory
+	if watcherFactory == nil {
+		watcherFactory = audit.NewFileSystemWatcher
+	}
+	watcher, watcherError := watcherFactory()
+	if watcherError != nil {
+		return watcherError
+	}
+
+	watchContext, stopWatching := signal.NotifyContext(command.Context(), os.Interrupt)
+	defer stopWatching()
+
+	auditOptions := audit.CommandOptions{
+		Roots:           options.repositoryRoots,
+		DebugOutput:     options.debugOutput,
+		InspectionDepth: audit.InspectionDepthFull,
+		Offline:         options.offline,
+		GitHubHost:      options.githubHost,
+		SortOrder:       options.sortOrder,
+		MinimumScore:    options.minimumScore,
+		IncludeBare:     options.includeBare,
 	}
+	return service.Watch(watchContext, auditOptions, audit.WatchOptions{Watcher: watcher})
 }
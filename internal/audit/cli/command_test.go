@@ -19,6 +19,7 @@ import (
 const (
 	rootFlagArgumentConstant       = "--" + flagutils.DefaultRootFlagName
 	includeAllFlagArgumentConstant = "--all"
+	offlineFlagArgumentConstant    = "--offline"
 )
 
 var boundRootFlagValues []*flagutils.RootFlagValues
@@ -102,6 +103,139 @@ func TestCommandFlagsOverrideConfiguration(t *testing.T) {
 	require.Equal(t, true, action.Options["include_all"])
 }
 
+func TestCommandPositionalRoots(t *testing.T) {
+	testCases := []struct {
+		name          string
+		arguments     []string
+		expectedRoots []string
+	}{
+		{
+			name:          "positional_roots_replace_configuration",
+			arguments:     []string{"/tmp/positional-one", "/tmp/positional-two"},
+			expectedRoots: []string{"/tmp/positional-one", "/tmp/positional-two"},
+		},
+		{
+			name:          "positional_roots_merge_with_flag",
+			arguments:     []string{rootFlagArgumentConstant, "/tmp/flagged", "/tmp/positional-one", "/tmp/flagged"},
+			expectedRoots: []string{"/tmp/flagged", "/tmp/positional-one"},
+		},
+		{
+			name:          "configuration_used_without_arguments",
+			arguments:     []string{},
+			expectedRoots: []string{"/tmp/configured"},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := cli.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{},
+				GitExecutor:    &stubGitExecutor{},
+				GitManager:     stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return audit.CommandConfiguration{Roots: []string{"/tmp/configured"}}
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			require.NoError(subtest, command.Execute())
+			require.Equal(subtest, testCase.expectedRoots, runner.roots)
+		})
+	}
+}
+
+func TestCommandReadsRootsFromStandardInput(t *testing.T) {
+	firstRoot := t.TempDir()
+	secondRoot := t.TempDir()
+	runner := &recordingTaskRunner{}
+	builder := cli.CommandBuilder{
+		LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+		Discoverer:     &fakeRepositoryDiscoverer{},
+		GitExecutor:    &stubGitExecutor{},
+		GitManager:     stubGitRepositoryManager{},
+		ConfigurationProvider: func() audit.CommandConfiguration {
+			return audit.CommandConfiguration{Roots: []string{"/tmp/configured"}}
+		},
+		TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+	}
+
+	command, buildError := builder.Build()
+	require.NoError(t, buildError)
+	bindRootAndExecutionFlags(command)
+
+	command.SetContext(context.Background())
+	command.SetArgs([]string{rootFlagArgumentConstant, "-"})
+	command.SetIn(strings.NewReader(firstRoot + "\n" + secondRoot + "\n"))
+
+	require.NoError(t, command.Execute())
+	require.Equal(t, []string{firstRoot, secondRoot}, runner.roots)
+}
+
+func TestCommandOfflineRuntimeOption(t *testing.T) {
+	testCases := []struct {
+		name            string
+		configuration   audit.CommandConfiguration
+		arguments       []string
+		expectedOffline bool
+	}{
+		{
+			name:            "flag_enables_offline",
+			configuration:   audit.CommandConfiguration{Roots: []string{"/tmp/audit-offline"}},
+			arguments:       []string{offlineFlagArgumentConstant},
+			expectedOffline: true,
+		},
+		{
+			name:            "configuration_enables_offline",
+			configuration:   audit.CommandConfiguration{Roots: []string{"/tmp/audit-offline"}, Offline: true},
+			arguments:       []string{},
+			expectedOffline: true,
+		},
+		{
+			name:            "flag_overrides_configuration",
+			configuration:   audit.CommandConfiguration{Roots: []string{"/tmp/audit-offline"}, Offline: true},
+			arguments:       []string{offlineFlagArgumentConstant + "=false"},
+			expectedOffline: false,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := cli.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{},
+				GitExecutor:    &stubGitExecutor{},
+				GitManager:     stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return testCase.configuration
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			require.NoError(subtest, command.Execute())
+			require.Equal(subtest, testCase.expectedOffline, runner.runtimeOptions.Offline)
+		})
+	}
+}
+
 func TestCommandDisplaysHelpWhenRootsMissing(t *testing.T) {
 	t.Helper()
 
@@ -128,11 +262,13 @@ func TestCommandDisplaysHelpWhenRootsMissing(t *testing.T) {
 }
 
 type recordingTaskRunner struct {
+	roots          []string
 	definitions    []workflow.TaskDefinition
 	runtimeOptions workflow.RuntimeOptions
 }
 
-func (runner *recordingTaskRunner) Run(_ context.Context, _ []string, definitions []workflow.TaskDefinition, options workflow.RuntimeOptions) error {
+func (runner *recordingTaskRunner) Run(_ context.Context, roots []string, definitions []workflow.TaskDefinition, options workflow.RuntimeOptions) error {
+	runner.roots = append([]string{}, roots...)
 	runner.definitions = append([]workflow.TaskDefinition{}, definitions...)
 	runner.runtimeOptions = options
 	return nil
@@ -183,3 +319,541 @@ func bindRootAndExecutionFlags(command *cobra.Command) {
 		},
 	)
 }
+
+func TestCommandFailOnNestedOption(t *testing.T) {
+	testCases := []struct {
+		name           string
+		configuration  audit.CommandConfiguration
+		arguments      []string
+		expectedOption any
+	}{
+		{
+			name:           "flag_enables_strict_nesting",
+			configuration:  audit.CommandConfiguration{Roots: []string{"/tmp/audit-nested"}},
+			arguments:      []string{"--fail-on-nested"},
+			expectedOption: true,
+		},
+		{
+			name:           "configuration_enables_strict_nesting",
+			configuration:  audit.CommandConfiguration{Roots: []string{"/tmp/audit-nested"}, FailOnNested: true},
+			arguments:      []string{},
+			expectedOption: true,
+		},
+		{
+			name:           "disabled_by_default",
+			configuration:  audit.CommandConfiguration{Roots: []string{"/tmp/audit-nested"}},
+			arguments:      []string{},
+			expectedOption: nil,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := cli.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{},
+				GitExecutor:    &stubGitExecutor{},
+				GitManager:     stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return testCase.configuration
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			require.NoError(subtest, command.Execute())
+			require.Len(subtest, runner.definitions, 1)
+			require.Equal(subtest, testCase.expectedOption, runner.definitions[0].Actions[0].Options["fail_on_nested"])
+		})
+	}
+}
+
+func TestCommandDuplicatesOnlyOption(t *testing.T) {
+	testCases := []struct {
+		name           string
+		configuration  audit.CommandConfiguration
+		arguments      []string
+		expectedOption any
+	}{
+		{
+			name:           "flag_enables_duplicates_only",
+			configuration:  audit.CommandConfiguration{Roots: []string{"/tmp/audit-duplicates"}},
+			arguments:      []string{"--duplicates-only"},
+			expectedOption: true,
+		},
+		{
+			name:           "configuration_enables_duplicates_only",
+			configuration:  audit.CommandConfiguration{Roots: []string{"/tmp/audit-duplicates"}, DuplicatesOnly: true},
+			arguments:      []string{},
+			expectedOption: true,
+		},
+		{
+			name:           "disabled_by_default",
+			configuration:  audit.CommandConfiguration{Roots: []string{"/tmp/audit-duplicates"}},
+			arguments:      []string{},
+			expectedOption: nil,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := cli.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{},
+				GitExecutor:    &stubGitExecutor{},
+				GitManager:     stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return testCase.configuration
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			require.NoError(subtest, command.Execute())
+			require.Len(subtest, runner.definitions, 1)
+			require.Equal(subtest, testCase.expectedOption, runner.definitions[0].Actions[0].Options["duplicates_only"])
+		})
+	}
+}
+
+func TestCommandSortOption(t *testing.T) {
+	testCases := []struct {
+		name           string
+		configuration  audit.CommandConfiguration
+		arguments      []string
+		expectedOption any
+		expectedError  string
+	}{
+		{
+			name:           "flag_selects_order",
+			configuration:  audit.CommandConfiguration{Roots: []string{"/tmp/audit-sort"}},
+			arguments:      []string{"--sort", "Activity"},
+			expectedOption: "activity",
+		},
+		{
+			name:           "flag_overrides_configuration",
+			configuration:  audit.CommandConfiguration{Roots: []string{"/tmp/audit-sort"}, Sort: "owner"},
+			arguments:      []string{"--sort", "issues"},
+			expectedOption: "issues",
+		},
+		{
+			name:           "configuration_selects_order",
+			configuration:  audit.CommandConfiguration{Roots: []string{"/tmp/audit-sort"}, Sort: "owner"},
+			arguments:      []string{},
+			expectedOption: "owner",
+		},
+		{
+			name:           "unset_keeps_discovery_order",
+			configuration:  audit.CommandConfiguration{Roots: []string{"/tmp/audit-sort"}},
+			arguments:      []string{},
+			expectedOption: nil,
+		},
+		{
+			name:          "unsupported_order",
+			configuration: audit.CommandConfiguration{Roots: []string{"/tmp/audit-sort"}},
+			arguments:     []string{"--sort", "stars"},
+			expectedError: `unsupported audit sort order "stars" (expected path, owner, activity, issues, or score)`,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := cli.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{},
+				GitExecutor:    &stubGitExecutor{},
+				GitManager:     stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return testCase.configuration
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			executeError := command.Execute()
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, executeError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, executeError)
+			require.Len(subtest, runner.definitions, 1)
+			require.Equal(subtest, testCase.expectedOption, runner.definitions[0].Actions[0].Options["sort"])
+		})
+	}
+}
+
+func TestCommandHealthScoreOptions(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configuration        audit.CommandConfiguration
+		arguments            []string
+		expectedMinimumScore any
+		expectedWeights      any
+		expectedFormat       any
+	}{
+		{
+			name:          "unset_omits_options",
+			configuration: audit.CommandConfiguration{Roots: []string{"/tmp/audit-score"}},
+			arguments:     []string{},
+		},
+		{
+			name:                 "flag_overrides_configuration",
+			configuration:        audit.CommandConfiguration{Roots: []string{"/tmp/audit-score"}, MinScore: 2},
+			arguments:            []string{"--min-score", "5", "--format", "json"},
+			expectedMinimumScore: 5,
+			expectedFormat:       "json",
+		},
+		{
+			name:                 "configuration_supplies_weights",
+			configuration:        audit.CommandConfiguration{Roots: []string{"/tmp/audit-score"}, MinScore: 3, ScoreWeights: map[string]int{"wrong_host": 8}},
+			arguments:            []string{},
+			expectedMinimumScore: 3,
+			expectedWeights:      map[string]any{"wrong_host": 8},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := cli.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{},
+				GitExecutor:    &stubGitExecutor{},
+				GitManager:     stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return testCase.configuration
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			require.NoError(subtest, command.Execute())
+			require.Len(subtest, runner.definitions, 1)
+			actionOptions := runner.definitions[0].Actions[0].Options
+			require.Equal(subtest, testCase.expectedMinimumScore, actionOptions["min_score"])
+			require.Equal(subtest, testCase.expectedWeights, actionOptions["score_weights"])
+			require.Equal(subtest, testCase.expectedFormat, actionOptions["format"])
+		})
+	}
+}
+
+func TestCommandFixOption(t *testing.T) {
+	testCases := []struct {
+		name              string
+		configuration     audit.CommandConfiguration
+		arguments         []string
+		expectedFix       any
+		expectedProtocol  any
+		expectedUnshallow any
+		expectedError     string
+	}{
+		{
+			name:             "flags_enable_fix_with_protocol",
+			configuration:    audit.CommandConfiguration{Roots: []string{"/tmp/audit-fix"}},
+			arguments:        []string{"--fix", "--fix-protocol", "SSH", "--yes"},
+			expectedFix:      true,
+			expectedProtocol: "ssh",
+		},
+		{
+			name:             "configuration_enables_fix",
+			configuration:    audit.CommandConfiguration{Roots: []string{"/tmp/audit-fix"}, Fix: true, FixProtocol: "https"},
+			arguments:        []string{},
+			expectedFix:      true,
+			expectedProtocol: "https",
+		},
+		{
+			name:          "flag_overrides_configuration",
+			configuration: audit.CommandConfiguration{Roots: []string{"/tmp/audit-fix"}, Fix: true},
+			arguments:     []string{"--fix=false"},
+		},
+		{
+			name:          "disabled_by_default",
+			configuration: audit.CommandConfiguration{Roots: []string{"/tmp/audit-fix"}},
+			arguments:     []string{},
+		},
+		{
+			name:          "unsupported_protocol",
+			configuration: audit.CommandConfiguration{Roots: []string{"/tmp/audit-fix"}},
+			arguments:     []string{"--fix", "--fix-protocol", "ftp"},
+			expectedError: "remote protocol invalid: ftp",
+		},
+		{
+			name:              "unshallow_with_fix",
+			configuration:     audit.CommandConfiguration{Roots: []string{"/tmp/audit-fix"}},
+			arguments:         []string{"--fix", "--unshallow"},
+			expectedFix:       true,
+			expectedUnshallow: true,
+		},
+		{
+			name:          "unshallow_requires_fix",
+			configuration: audit.CommandConfiguration{Roots: []string{"/tmp/audit-fix"}, Unshallow: true},
+			arguments:     []string{},
+			expectedError: "--unshallow requires --fix",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := cli.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{},
+				GitExecutor:    &stubGitExecutor{},
+				GitManager:     stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return testCase.configuration
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			executeError := command.Execute()
+			if len(testCase.expectedError) > 0 {
+				require.ErrorContains(subtest, executeError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, executeError)
+			require.Len(subtest, runner.definitions, 1)
+			require.Equal(subtest, testCase.expectedFix, runner.definitions[0].Actions[0].Options["fix"])
+			require.Equal(subtest, testCase.expectedProtocol, runner.definitions[0].Actions[0].Options["fix_protocol"])
+			require.Equal(subtest, testCase.expectedUnshallow, runner.definitions[0].Actions[0].Options["unshallow"])
+		})
+	}
+}
+
+func TestCommandIdentityRulesOption(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configuration audit.CommandConfiguration
+		expectedRules any
+	}{
+		{
+			name: "configuration_supplies_rules",
+			configuration: audit.CommandConfiguration{
+				Roots:         []string{"/tmp/audit-identity"},
+				IdentityRules: []audit.IdentityRule{{Owner: "myorg", EmailPattern: "*@company.com"}},
+			},
+			expectedRules: []any{map[string]any{"owner": "myorg", "email_pattern": "*@company.com"}},
+		},
+		{
+			name:          "unchecked_by_default",
+			configuration: audit.CommandConfiguration{Roots: []string{"/tmp/audit-identity"}},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := cli.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{},
+				GitExecutor:    &stubGitExecutor{},
+				GitManager:     stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return testCase.configuration
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs([]string{})
+
+			require.NoError(subtest, command.Execute())
+			require.Len(subtest, runner.definitions, 1)
+			require.Equal(subtest, testCase.expectedRules, runner.definitions[0].Actions[0].Options["identity_rules"])
+		})
+	}
+}
+
+func TestCommandBareRepositoryOption(t *testing.T) {
+	testCases := []struct {
+		name                string
+		configuration       audit.CommandConfiguration
+		arguments           []string
+		expectedIncludeBare bool
+		expectedError       string
+	}{
+		{
+			name:                "included_by_default",
+			configuration:       audit.CommandConfiguration{Roots: []string{"/tmp/audit-bare"}},
+			arguments:           []string{},
+			expectedIncludeBare: true,
+		},
+		{
+			name:                "exclude_flag_disables",
+			configuration:       audit.CommandConfiguration{Roots: []string{"/tmp/audit-bare"}},
+			arguments:           []string{"--exclude-bare"},
+			expectedIncludeBare: false,
+		},
+		{
+			name:                "configuration_excludes",
+			configuration:       audit.CommandConfiguration{Roots: []string{"/tmp/audit-bare"}, ExcludeBare: true},
+			arguments:           []string{},
+			expectedIncludeBare: false,
+		},
+		{
+			name:                "include_flag_overrides_configuration",
+			configuration:       audit.CommandConfiguration{Roots: []string{"/tmp/audit-bare"}, ExcludeBare: true},
+			arguments:           []string{"--include-bare"},
+			expectedIncludeBare: true,
+		},
+		{
+			name:          "conflicting_flags_rejected",
+			configuration: audit.CommandConfiguration{Roots: []string{"/tmp/audit-bare"}},
+			arguments:     []string{"--include-bare", "--exclude-bare"},
+			expectedError: "--include-bare and --exclude-bare cannot both be set",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := cli.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{},
+				GitExecutor:    &stubGitExecutor{},
+				GitManager:     stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return testCase.configuration
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			executionError := command.Execute()
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, executionError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, executionError)
+			require.Len(subtest, runner.definitions, 1)
+			require.Equal(subtest, testCase.expectedIncludeBare, runner.definitions[0].Actions[0].Options["include_bare"])
+			require.Equal(subtest, testCase.expectedIncludeBare, runner.runtimeOptions.IncludeBareRepositories)
+		})
+	}
+}
+
+type closedFileWatcher struct {
+	events chan string
+}
+
+func (closedFileWatcher) Add(string) error { return nil }
+
+func (watcher closedFileWatcher) Events() <-chan string { return watcher.events }
+
+func (closedFileWatcher) Errors() <-chan error { return nil }
+
+func (closedFileWatcher) Close() error { return nil }
+
+func TestCommandWatchOption(t *testing.T) {
+	testCases := []struct {
+		name           string
+		arguments      []string
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name:           "watch_audits_without_task_runner",
+			arguments:      []string{"--watch"},
+			expectedOutput: "AUDIT-WATCH-FINAL: 0 repositories\n",
+		},
+		{
+			name:          "watch_rejects_fix",
+			arguments:     []string{"--watch", "--fix"},
+			expectedError: "--watch cannot be combined with --fix, --duplicates-only, --all, or a markdown or json --format",
+		},
+		{
+			name:          "watch_rejects_structured_format",
+			arguments:     []string{"--watch", "--format", "json"},
+			expectedError: "--watch cannot be combined with --fix, --duplicates-only, --all, or a markdown or json --format",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			events := make(chan string)
+			close(events)
+			builder := cli.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				Discoverer:     &fakeRepositoryDiscoverer{},
+				GitExecutor:    &stubGitExecutor{},
+				GitManager:     stubGitRepositoryManager{},
+				ConfigurationProvider: func() audit.CommandConfiguration {
+					return audit.CommandConfiguration{Roots: []string{subtest.TempDir()}}
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+				WatcherFactory:    func() (audit.FileWatcher, error) { return closedFileWatcher{events: events}, nil },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+			outputBuffer := &strings.Builder{}
+			command.SetOut(outputBuffer)
+			command.SetErr(&strings.Builder{})
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			executeError := command.Execute()
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, executeError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, executeError)
+			require.Empty(subtest, runner.definitions)
+			require.Contains(subtest, outputBuffer.String(), testCase.expectedOutput)
+		})
+	}
+}
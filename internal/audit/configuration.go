@@ -1,6 +1,8 @@
 package audit
 
 import (
+	"strings"
+
 	pathutils "github.com/temirov/gix/internal/utils/path"
 )
 
@@ -8,17 +10,43 @@ var auditConfigurationRepositoryPathSanitizer = pathutils.NewRepositoryPathSanit
 
 // CommandConfiguration captures persistent settings for the audit command.
 type CommandConfiguration struct {
-	Roots      []string `mapstructure:"roots"`
-	Debug      bool     `mapstructure:"debug"`
-	IncludeAll bool     `mapstructure:"all"`
+	Roots          []string       `mapstructure:"roots"`
+	Debug          bool           `mapstructure:"debug"`
+	IncludeAll     bool           `mapstructure:"all"`
+	Offline        bool           `mapstructure:"offline"`
+	FailOnNested   bool           `mapstructure:"fail_on_nested"`
+	GitHubHost     string         `mapstructure:"github_host"`
+	DuplicatesOnly bool           `mapstructure:"duplicates_only"`
+	Sort           string         `mapstructure:"sort"`
+	Format         string         `mapstructure:"format"`
+	Fix            bool           `mapstructure:"fix"`
+	FixProtocol    string         `mapstructure:"fix_protocol"`
+	Unshallow      bool           `mapstructure:"unshallow"`
+	MinScore       int            `mapstructure:"min_score"`
+	ScoreWeights   map[string]int `mapstructure:"score_weights"`
+	IdentityRules  []IdentityRule `mapstructure:"identity_rules"`
+	ExcludeBare    bool           `mapstructure:"exclude_bare"`
 }
 
 // DefaultCommandConfiguration returns baseline configuration values for the audit command.
 func DefaultCommandConfiguration() CommandConfiguration {
 	return CommandConfiguration{
-		Roots:      nil,
-		Debug:      false,
-		IncludeAll: false,
+		Roots:          nil,
+		Debug:          false,
+		IncludeAll:     false,
+		Offline:        false,
+		FailOnNested:   false,
+		GitHubHost:     "",
+		DuplicatesOnly: false,
+		Sort:           "",
+		Format:         "",
+		Fix:            false,
+		FixProtocol:    "",
+		Unshallow:      false,
+		MinScore:       0,
+		ScoreWeights:   nil,
+		IdentityRules:  nil,
+		ExcludeBare:    false,
 	}
 }
 
@@ -27,6 +55,10 @@ func (configuration CommandConfiguration) Sanitize() CommandConfiguration {
 	sanitized := configuration
 
 	sanitized.Roots = auditConfigurationRepositoryPathSanitizer.Sanitize(configuration.Roots)
+	sanitized.GitHubHost = strings.ToLower(strings.TrimSpace(configuration.GitHubHost))
+	sanitized.Sort = strings.ToLower(strings.TrimSpace(configuration.Sort))
+	sanitized.Format = strings.ToLower(strings.TrimSpace(configuration.Format))
+	sanitized.FixProtocol = strings.ToLower(strings.TrimSpace(configuration.FixProtocol))
 
 	return sanitized
 }
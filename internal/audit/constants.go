@@ -20,7 +20,13 @@ const (
 	csvHeaderInSync                             = "in_sync"
 	csvHeaderRemoteProtocol                     = "remote_protocol"
 	csvHeaderOriginCanonical                    = "origin_matches_canonical"
+	csvHeaderLastActivity                       = "last_activity"
+	csvHeaderHealthScore                        = "health_score"
+	csvHeaderDeleteBranchOnMerge                = "delete_branch_on_merge"
+	csvHeaderMergeQueue                         = "merge_queue"
 	gitIsInsideWorkTreeFlagConstant             = "--is-inside-work-tree"
 	gitTrueOutputConstant                       = "true"
 	notGitHubRemoteMessageConstant              = "not a github remote"
+	nestedRepositoryFindingTemplate             = "NESTED-REPOSITORY: %s is inside %s\n"
+	nestedRepositoriesErrorTemplate             = "%w: %d nested repository pair(s)"
 )
@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const (
+	duplicateCloneGroupTemplateConstant  = "DUPLICATE-CLONE: %s is cloned %d times\n"
+	duplicateCloneMemberTemplateConstant = "  %s last-commit=%s dirty=%s\n"
+	duplicateCloneKeyTemplateConstant    = "%s/%s"
+	gitLogSubcommandConstant             = "log"
+	gitLogSingleCommitFlagConstant       = "-1"
+	gitLogCommitDateFormatFlagConstant   = "--format=%cI"
+)
+
+// DuplicateCloneMember describes one clone of a repository that is cloned more than once.
+type DuplicateCloneMember struct {
+	RepositoryPath string
+	LastCommitDate string
+	Dirty          TernaryValue
+}
+
+// DuplicateCloneGroup lists every discovered clone of the same owner/repository.
+type DuplicateCloneGroup struct {
+	OwnerRepository string
+	Members         []DuplicateCloneMember
+}
+
+type duplicateCloneCandidate struct {
+	ownerRepository string
+	repositoryPath  string
+}
+
+// DuplicateClones returns the repositories cloned more than once that were detected by the most recent DiscoverInspections call.
+func (service *Service) DuplicateClones() []DuplicateCloneGroup {
+	return service.duplicateClones
+}
+
+// ReportDuplicateClones writes each duplicate clone group to the error writer.
+func (service *Service) ReportDuplicateClones() {
+	writeDuplicateClones(service.errorWriter, service.duplicateClones)
+}
+
+func writeDuplicateClones(writer io.Writer, groups []DuplicateCloneGroup) {
+	if writer == nil {
+		return
+	}
+	for _, group := range groups {
+		fmt.Fprintf(writer, duplicateCloneGroupTemplateConstant, group.OwnerRepository, len(group.Members))
+		for _, member := range group.Members {
+			fmt.Fprintf(writer, duplicateCloneMemberTemplateConstant, member.RepositoryPath, member.LastCommitDate, member.Dirty)
+		}
+	}
+}
+
+func (service *Service) recordDuplicateCloneCandidate(repositoryPath string, originURL string) {
+	parsedRemote, parseError := gitrepo.ParseRemoteURL(originURL)
+	if parseError != nil {
+		return
+	}
+	service.duplicateCloneCandidates = append(service.duplicateCloneCandidates, duplicateCloneCandidate{
+		ownerRepository: fmt.Sprintf(duplicateCloneKeyTemplateConstant, parsedRemote.Owner, parsedRemote.Repository),
+		repositoryPath:  repositoryPath,
+	})
+}
+
+func (service *Service) detectDuplicateClones(executionContext context.Context) error {
+	groupedPaths := make(map[string][]string)
+	displayNames := make(map[string]string)
+	for _, candidate := range service.duplicateCloneCandidates {
+		groupKey := strings.ToLower(candidate.ownerRepository)
+		if _, known := displayNames[groupKey]; !known {
+			displayNames[groupKey] = candidate.ownerRepository
+		}
+		groupedPaths[groupKey] = append(groupedPaths[groupKey], candidate.repositoryPath)
+	}
+
+	groupKeys := make([]string, 0, len(groupedPaths))
+	for groupKey, repositoryPaths := range groupedPaths {
+		if len(repositoryPaths) > 1 {
+			groupKeys = append(groupKeys, groupKey)
+		}
+	}
+	sort.Strings(groupKeys)
+
+	for _, groupKey := range groupKeys {
+		repositoryPaths := groupedPaths[groupKey]
+		sort.Strings(repositoryPaths)
+		group := DuplicateCloneGroup{OwnerRepository: displayNames[groupKey]}
+		for _, repositoryPath := range repositoryPaths {
+			member, memberError := service.describeDuplicateClone(executionContext, repositoryPath)
+			if memberError != nil {
+				return memberError
+			}
+			group.Members = append(group.Members, member)
+		}
+		service.duplicateClones = append(service.duplicateClones, group)
+	}
+	return nil
+}
+
+func (service *Service) describeDuplicateClone(executionContext context.Context, repositoryPath string) (DuplicateCloneMember, error) {
+	member := DuplicateCloneMember{
+		RepositoryPath: repositoryPath,
+		LastCommitDate: string(TernaryValueNotApplicable),
+		Dirty:          TernaryValueNotApplicable,
+	}
+
+	if service.gitExecutor != nil {
+		executionResult, executionError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+			Arguments:        []string{gitLogSubcommandConstant, gitLogSingleCommitFlagConstant, gitLogCommitDateFormatFlagConstant},
+			WorkingDirectory: repositoryPath,
+			Idempotent:       true,
+		})
+		if execshell.IsExecutableNotFound(executionError) {
+			return DuplicateCloneMember{}, executionError
+		}
+		if commitDate := strings.TrimSpace(executionResult.StandardOutput); executionError == nil && len(commitDate) > 0 {
+			member.LastCommitDate = commitDate
+		}
+	}
+
+	clean, cleanError := service.gitManager.CheckCleanWorktree(executionContext, repositoryPath)
+	if execshell.IsExecutableNotFound(cleanError) {
+		return DuplicateCloneMember{}, cleanError
+	}
+	if cleanError == nil {
+		member.Dirty = TernaryValueYes
+		if clean {
+			member.Dirty = TernaryValueNo
+		}
+	}
+	return member, nil
+}
@@ -0,0 +1,226 @@
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	scoreCategoryUnknownTemplateConstant  = "unsupported audit score category %q (expected one of %s)"
+	scoreWeightNegativeTemplateConstant   = "audit score weight for %s must not be negative (got %d)"
+	scoreWeightInvalidTemplateConstant    = "audit score weight for %s must be an integer"
+	scoreCategoryListSeparatorConstant    = ", "
+	healthScoreNotApplicableValueConstant = "n/a"
+	scoreWeightIntegerBitSizeConstant     = 64
+	scoreWeightIntegerBaseConstant        = 10
+)
+
+// ScoreCategory names one kind of audit finding that contributes to a repository's health score.
+type ScoreCategory string
+
+// Supported score categories, one per finding kind the audit reports.
+const (
+	ScoreCategoryNameMismatch        ScoreCategory = "name_mismatch"
+	ScoreCategoryOutOfSync           ScoreCategory = "out_of_sync"
+	ScoreCategoryNonCanonicalOrigin  ScoreCategory = "non_canonical_origin"
+	ScoreCategoryWrongHost           ScoreCategory = "wrong_host"
+	ScoreCategoryStaleRemoteHead     ScoreCategory = "stale_remote_head"
+	ScoreCategoryPushURLMismatch     ScoreCategory = "push_url_mismatch"
+	ScoreCategoryInProgressOperation ScoreCategory = "in_progress_operation"
+	ScoreCategoryDuplicateClone      ScoreCategory = "duplicate_clone"
+	ScoreCategoryNestedRepository    ScoreCategory = "nested_repository"
+)
+
+// ScoreCategories lists every score category in report order.
+func ScoreCategories() []ScoreCategory {
+	return []ScoreCategory{
+		ScoreCategoryNameMismatch,
+		ScoreCategoryOutOfSync,
+		ScoreCategoryNonCanonicalOrigin,
+		ScoreCategoryWrongHost,
+		ScoreCategoryStaleRemoteHead,
+		ScoreCategoryPushURLMismatch,
+		ScoreCategoryInProgressOperation,
+		ScoreCategoryDuplicateClone,
+		ScoreCategoryNestedRepository,
+	}
+}
+
+// ScoreWeights maps each score category to the points one finding of that category adds to a repository's score.
+type ScoreWeights map[ScoreCategory]int
+
+// DefaultScoreWeights returns the built-in weights. Findings that block work or point pushes at the wrong place weigh
+// more than cosmetic ones.
+func DefaultScoreWeights() ScoreWeights {
+	return ScoreWeights{
+		ScoreCategoryNameMismatch:        1,
+		ScoreCategoryOutOfSync:           2,
+		ScoreCategoryNonCanonicalOrigin:  3,
+		ScoreCategoryWrongHost:           3,
+		ScoreCategoryStaleRemoteHead:     1,
+		ScoreCategoryPushURLMismatch:     3,
+		ScoreCategoryInProgressOperation: 5,
+		ScoreCategoryDuplicateClone:      2,
+		ScoreCategoryNestedRepository:    2,
+	}
+}
+
+// ParseScoreWeights overlays configured weights onto DefaultScoreWeights. Keys are score category names; values must
+// be non-negative integers, and zero drops a category from the score.
+func ParseScoreWeights(configured map[string]any) (ScoreWeights, error) {
+	weights := DefaultScoreWeights()
+	for key, rawValue := range configured {
+		category := ScoreCategory(strings.ToLower(strings.TrimSpace(key)))
+		if _, known := weights[category]; !known {
+			return nil, fmt.Errorf(scoreCategoryUnknownTemplateConstant, key, scoreCategoryList())
+		}
+		weight, weightError := scoreWeightValue(category, rawValue)
+		if weightError != nil {
+			return nil, weightError
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf(scoreWeightNegativeTemplateConstant, category, weight)
+		}
+		weights[category] = weight
+	}
+	return weights, nil
+}
+
+func scoreWeightValue(category ScoreCategory, rawValue any) (int, error) {
+	switch typed := rawValue.(type) {
+	case int:
+		return typed, nil
+	case int64:
+		return int(typed), nil
+	case float64:
+		if typed == float64(int(typed)) {
+			return int(typed), nil
+		}
+	case string:
+		parsed, parseError := strconv.ParseInt(strings.TrimSpace(typed), scoreWeightIntegerBaseConstant, scoreWeightIntegerBitSizeConstant)
+		if parseError == nil {
+			return int(parsed), nil
+		}
+	}
+	return 0, fmt.Errorf(scoreWeightInvalidTemplateConstant, category)
+}
+
+func scoreCategoryList() string {
+	names := make([]string, 0, len(ScoreCategories()))
+	for _, category := range ScoreCategories() {
+		names = append(names, string(category))
+	}
+	return strings.Join(names, scoreCategoryListSeparatorConstant)
+}
+
+// HealthScore aggregates the findings for one repository. Findings counts each category's findings, Contributions
+// holds the points each category added with the weights in effect, and Score is their sum. Scored is false for folders
+// that are not git repositories.
+type HealthScore struct {
+	Scored        bool
+	Score         int
+	Findings      map[ScoreCategory]int
+	Contributions map[ScoreCategory]int
+}
+
+// String renders the score for the CSV and markdown reports.
+func (score HealthScore) String() string {
+	if !score.Scored {
+		return healthScoreNotApplicableValueConstant
+	}
+	return strconv.Itoa(score.Score)
+}
+
+// SetScoreWeights configures the weights used for health scores; nil restores DefaultScoreWeights.
+func (service *Service) SetScoreWeights(weights ScoreWeights) {
+	service.scoreWeights = weights
+}
+
+// ScoreWeights returns the weights used for health scores.
+func (service *Service) ScoreWeights() ScoreWeights {
+	if service.scoreWeights == nil {
+		return DefaultScoreWeights()
+	}
+	return service.scoreWeights
+}
+
+// applyHealthScores scores every repository inspection from its report row and the findings collected during discovery.
+func (service *Service) applyHealthScores(inspections []RepositoryInspection) {
+	findingsByPath := make(map[string]map[ScoreCategory]int)
+	addFinding := func(path string, category ScoreCategory) {
+		if findingsByPath[path] == nil {
+			findingsByPath[path] = make(map[ScoreCategory]int)
+		}
+		findingsByPath[path][category]++
+	}
+	for _, mismatch := range service.hostMismatches {
+		addFinding(mismatch.RepositoryPath, ScoreCategoryWrongHost)
+	}
+	for _, staleHead := range service.staleRemoteHeads {
+		addFinding(staleHead.RepositoryPath, ScoreCategoryStaleRemoteHead)
+	}
+	for _, mismatch := range service.pushURLMismatches {
+		addFinding(mismatch.RepositoryPath, ScoreCategoryPushURLMismatch)
+	}
+	for _, operation := range service.inProgressOperations {
+		addFinding(operation.RepositoryPath, ScoreCategoryInProgressOperation)
+	}
+	for _, group := range service.duplicateClones {
+		for _, member := range group.Members {
+			addFinding(member.RepositoryPath, ScoreCategoryDuplicateClone)
+		}
+	}
+	for _, relationship := range service.containment.Relationships {
+		addFinding(relationship.ChildPath, ScoreCategoryNestedRepository)
+	}
+
+	weights := service.ScoreWeights()
+	for inspectionIndex := range inspections {
+		inspection := &inspections[inspectionIndex]
+		if !inspection.IsGitRepository {
+			inspection.HealthScore = HealthScore{}
+			continue
+		}
+		findings := make(map[ScoreCategory]int)
+		for category, count := range findingsByPath[inspection.Path] {
+			findings[category] = count
+		}
+		row := inspectionReportRow(*inspection)
+		if row.NameMatches == TernaryValueNo {
+			findings[ScoreCategoryNameMismatch]++
+		}
+		if row.InSync == TernaryValueNo {
+			findings[ScoreCategoryOutOfSync]++
+		}
+		if row.OriginMatchesCanonical == TernaryValueNo {
+			findings[ScoreCategoryNonCanonicalOrigin]++
+		}
+		inspection.HealthScore = scoreFindings(findings, weights)
+	}
+}
+
+func scoreFindings(findings map[ScoreCategory]int, weights ScoreWeights) HealthScore {
+	score := HealthScore{Scored: true, Findings: findings, Contributions: make(map[ScoreCategory]int, len(findings))}
+	for category, count := range findings {
+		contribution := count * weights[category]
+		score.Contributions[category] = contribution
+		score.Score += contribution
+	}
+	return score
+}
+
+// FilterInspectionsByScore keeps the repositories whose health score is at least minimumScore. A minimum of zero or
+// less keeps every inspection, including folders that are not repositories.
+func FilterInspectionsByScore(inspections []RepositoryInspection, minimumScore int) []RepositoryInspection {
+	if minimumScore <= 0 {
+		return inspections
+	}
+	filtered := make([]RepositoryInspection, 0, len(inspections))
+	for _, inspection := range inspections {
+		if inspection.HealthScore.Scored && inspection.HealthScore.Score >= minimumScore {
+			filtered = append(filtered, inspection)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,142 @@
+package audit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+)
+
+func TestParseScoreWeights(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		configured      map[string]any
+		expectedWeights map[audit.ScoreCategory]int
+		expectedError   string
+	}{
+		{
+			name:            "defaults_when_unset",
+			expectedWeights: audit.DefaultScoreWeights(),
+		},
+		{
+			name:       "overrides_merge_with_defaults",
+			configured: map[string]any{" Wrong_Host ": 10, "duplicate_clone": "0", "out_of_sync": float64(4)},
+			expectedWeights: func() map[audit.ScoreCategory]int {
+				weights := audit.DefaultScoreWeights()
+				weights[audit.ScoreCategoryWrongHost] = 10
+				weights[audit.ScoreCategoryDuplicateClone] = 0
+				weights[audit.ScoreCategoryOutOfSync] = 4
+				return weights
+			}(),
+		},
+		{
+			name:          "unknown_category",
+			configured:    map[string]any{"stars": 1},
+			expectedError: `unsupported audit score category "stars" (expected one of name_mismatch, out_of_sync, non_canonical_origin, wrong_host, stale_remote_head, push_url_mismatch, in_progress_operation, duplicate_clone, nested_repository)`,
+		},
+		{
+			name:          "negative_weight",
+			configured:    map[string]any{"wrong_host": -1},
+			expectedError: "audit score weight for wrong_host must not be negative (got -1)",
+		},
+		{
+			name:          "fractional_weight",
+			configured:    map[string]any{"wrong_host": 1.5},
+			expectedError: "audit score weight for wrong_host must be an integer",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			weights, parseError := audit.ParseScoreWeights(testCase.configured)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, parseError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, parseError)
+			require.Equal(subtest, audit.ScoreWeights(testCase.expectedWeights), weights)
+		})
+	}
+}
+
+func TestHealthScoreOrderingAndFiltering(testInstance *testing.T) {
+	inspections := []audit.RepositoryInspection{
+		{Path: "/src/alpha", FolderName: "alpha", IsGitRepository: true, HealthScore: audit.HealthScore{Scored: true, Score: 2}},
+		{Path: "/src/notes", FolderName: "notes"},
+		{Path: "/src/beta", FolderName: "beta", IsGitRepository: true, HealthScore: audit.HealthScore{Scored: true, Score: 7}},
+		{Path: "/src/gamma", FolderName: "gamma", IsGitRepository: true, HealthScore: audit.HealthScore{Scored: true}},
+	}
+
+	testCases := []struct {
+		name          string
+		minimumScore  int
+		expectedPaths []string
+	}{
+		{name: "no_minimum_keeps_all", expectedPaths: []string{"/src/beta", "/src/alpha", "/src/gamma", "/src/notes"}},
+		{name: "minimum_drops_lower_scores", minimumScore: 2, expectedPaths: []string{"/src/beta", "/src/alpha"}},
+		{name: "minimum_above_every_score", minimumScore: 8, expectedPaths: []string{}},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			sorted := append([]audit.RepositoryInspection(nil), inspections...)
+			audit.SortInspections(sorted, audit.ReportSortScore)
+			filtered := audit.FilterInspectionsByScore(sorted, testCase.minimumScore)
+
+			filteredPaths := make([]string, 0, len(filtered))
+			for _, inspection := range filtered {
+				filteredPaths = append(filteredPaths, inspection.Path)
+			}
+			require.Equal(subtest, testCase.expectedPaths, filteredPaths)
+		})
+	}
+}
+
+func TestWriteJSONReport(testInstance *testing.T) {
+	inspections := []audit.RepositoryInspection{
+		{
+			Path:                   "/src/beta",
+			FolderName:             "beta",
+			OriginOwnerRepo:        "acme/beta",
+			CanonicalOwnerRepo:     "acme/beta",
+			DesiredFolderName:      "beta",
+			InSyncStatus:           audit.TernaryValueNo,
+			OriginMatchesCanonical: audit.TernaryValueYes,
+			RemoteProtocol:         audit.RemoteProtocolHTTPS,
+			IsGitRepository:        true,
+			HealthScore: audit.HealthScore{
+				Scored:        true,
+				Score:         7,
+				Findings:      map[audit.ScoreCategory]int{audit.ScoreCategoryOutOfSync: 1, audit.ScoreCategoryInProgressOperation: 1},
+				Contributions: map[audit.ScoreCategory]int{audit.ScoreCategoryOutOfSync: 2, audit.ScoreCategoryInProgressOperation: 5},
+			},
+		},
+		{Path: "/src/notes", FolderName: "notes"},
+	}
+
+	var output bytes.Buffer
+	require.NoError(testInstance, audit.WriteJSONReport(&output, inspections, audit.DefaultScoreWeights()))
+
+	var report audit.JSONReport
+	require.NoError(testInstance, json.Unmarshal(output.Bytes(), &report))
+	require.Equal(testInstance, map[audit.ScoreCategory]int(audit.DefaultScoreWeights()), report.Weights)
+	require.Len(testInstance, report.Repositories, 2)
+
+	scoredRow := report.Repositories[0]
+	require.Equal(testInstance, "/src/beta", scoredRow.Path)
+	require.Equal(testInstance, "acme/beta", scoredRow.FinalRepository)
+	require.Equal(testInstance, audit.TernaryValueNo, scoredRow.InSync)
+	require.NotNil(testInstance, scoredRow.HealthScore)
+	require.Equal(testInstance, 7, *scoredRow.HealthScore)
+	require.Equal(testInstance, map[audit.ScoreCategory]int{audit.ScoreCategoryOutOfSync: 2, audit.ScoreCategoryInProgressOperation: 5}, scoredRow.Contributions)
+
+	unscoredRow := report.Repositories[1]
+	require.Nil(testInstance, unscoredRow.HealthScore)
+	require.Empty(testInstance, unscoredRow.Contributions)
+	require.Equal(testInstance, audit.TernaryValueNotApplicable, unscoredRow.InSync)
+}
@@ -12,7 +12,6 @@ const (
 	githubHostConstant                 = "github.com"
 	gitSuffixConstant                  = ".git"
 	repositoryOwnerSeparatorConstant   = "/"
-	refsHeadsPrefixConstant            = "refs/heads/"
 	upstreamReferenceCommandArgument   = "@{u}"
 	gitFetchSubcommandConstant         = "fetch"
 	gitQuietFlagConstant               = "-q"
@@ -22,9 +21,6 @@ const (
 	gitAbbrevRefFlagConstant           = "--abbrev-ref"
 	gitSymbolicFullNameFlagConstant    = "--symbolic-full-name"
 	gitHeadReferenceConstant           = "HEAD"
-	gitLSRemoteSubcommandConstant      = "ls-remote"
-	gitSymrefFlagConstant              = "--symref"
-	gitReferenceSeparator              = "\t"
 )
 
 var errOwnerRepoNotDetected = errors.New("owner repository not detected")
@@ -128,12 +124,3 @@ func fallbackRemoteRevisionReferences(branch string) []string {
 		fmt.Sprintf("%s/%s", shared.OriginRemoteNameConstant, branch),
 	}
 }
-
-func lsRemoteHeadArguments() []string {
-	return []string{
-		gitLSRemoteSubcommandConstant,
-		gitSymrefFlagConstant,
-		shared.OriginRemoteNameConstant,
-		gitHeadReferenceConstant,
-	}
-}
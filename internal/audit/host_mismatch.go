@@ -0,0 +1,279 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const (
+	gitSCPUserPrefixConstant         = "git@"
+	gitSCPHostSeparatorConstant      = ":"
+	sshURLPrefixConstant             = "ssh://git@"
+	httpsURLPrefixConstant           = "https://"
+	hostQualifiedRepositoryTemplate  = "%s/%s"
+	wrongHostFindingTemplateConstant = "WRONG-HOST: %s origin %s is on %s but %s exists on %s; reconcile with: git -C %s remote set-url origin %s\n"
+	hostCheckFailedTemplateConstant  = "HOST-CHECK-FAILED: %s origin %s could not be checked against %s: %v\n"
+	sshConfigFlagConstant            = "-G"
+	sshConfigHostnameKeyConstant     = "hostname"
+	sshHostnameMissingTemplate       = "ssh -G %s did not report a hostname"
+)
+
+// SSHExecutor runs ssh. When the service's git executor implements it, SSH origins are resolved with ssh -G so host
+// aliases from ~/.ssh/config are compared by the host they connect to.
+type SSHExecutor interface {
+	ExecuteSSH(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error)
+}
+
+// HostCheckFailure describes a repository whose origin could not be checked against the configured GitHub host because
+// its SSH alias or the repository lookup failed. Such repositories are neither confirmed nor reported as moved.
+type HostCheckFailure struct {
+	RepositoryPath string
+	OriginURL      string
+	ConfiguredHost string
+	Cause          error
+}
+
+// HostMismatch describes a repository whose origin points at a different host than the configured GitHub host
+// even though the repository exists on the configured host.
+type HostMismatch struct {
+	RepositoryPath   string
+	OwnerRepository  string
+	OriginURL        string
+	OriginHost       string
+	ConfiguredHost   string
+	ReconciledRemote string
+	RemoteProtocol   RemoteProtocolType
+}
+
+type remoteLocation struct {
+	prefix          string
+	host            string
+	separator       string
+	path            string
+	ownerRepository string
+}
+
+type hostMismatchCandidate struct {
+	repositoryPath string
+	originURL      string
+	location       remoteLocation
+}
+
+// SetGitHubHost configures the host that repositories are expected to live on.
+// When set, inspections verify origins on other hosts against it and report repositories that moved.
+func (service *Service) SetGitHubHost(host string) {
+	service.githubHost = strings.ToLower(strings.TrimSpace(host))
+}
+
+// HostMismatches returns the wrong-host findings detected by the most recent DiscoverInspections call.
+func (service *Service) HostMismatches() []HostMismatch {
+	return service.hostMismatches
+}
+
+// HostCheckFailures returns the origins the most recent DiscoverInspections call could not check against the configured
+// host.
+func (service *Service) HostCheckFailures() []HostCheckFailure {
+	return service.hostCheckFailures
+}
+
+// ReportHostMismatches writes each wrong-host finding and its reconciliation command to the error writer, followed by
+// the origins that could not be checked.
+func (service *Service) ReportHostMismatches() {
+	if service.errorWriter == nil {
+		return
+	}
+	for _, mismatch := range service.hostMismatches {
+		fmt.Fprintf(
+			service.errorWriter,
+			wrongHostFindingTemplateConstant,
+			mismatch.RepositoryPath,
+			mismatch.OriginURL,
+			mismatch.OriginHost,
+			mismatch.OwnerRepository,
+			mismatch.ConfiguredHost,
+			mismatch.RepositoryPath,
+			mismatch.ReconciledRemote,
+		)
+	}
+	service.reportHostCheckFailures()
+}
+
+func (service *Service) reportHostCheckFailures() {
+	for _, failure := range service.hostCheckFailures {
+		fmt.Fprintf(service.errorWriter, hostCheckFailedTemplateConstant, failure.RepositoryPath, failure.OriginURL, failure.ConfiguredHost, failure.Cause)
+	}
+}
+
+func (service *Service) recordHostMismatchCandidate(repositoryPath string, originURL string) {
+	if len(service.githubHost) == 0 {
+		return
+	}
+	location, parsed := parseRemoteLocation(originURL)
+	if !parsed || strings.EqualFold(location.host, service.githubHost) {
+		return
+	}
+	service.hostMismatchCandidates = append(service.hostMismatchCandidates, hostMismatchCandidate{
+		repositoryPath: repositoryPath,
+		originURL:      originURL,
+		location:       location,
+	})
+}
+
+func (service *Service) verifyHostMismatches(executionContext context.Context) error {
+	candidates := service.hostMismatchCandidates
+	service.hostMismatchCandidates = nil
+	if !service.CheckCategoryEnabled(CheckCategoryRemote) || service.githubClient == nil {
+		return nil
+	}
+
+	for _, candidate := range candidates {
+		if candidate.location.protocol() != RemoteProtocolHTTPS {
+			connectedHost, resolutionError := service.resolveSSHHost(executionContext, candidate.location.host)
+			if resolutionError != nil {
+				service.recordHostCheckFailure(candidate, resolutionError)
+				continue
+			}
+			if connectedHost != candidate.location.host {
+				continue
+			}
+		}
+
+		hostQualifiedRepository := fmt.Sprintf(hostQualifiedRepositoryTemplate, service.githubHost, candidate.location.ownerRepository)
+		_, metadataError := service.githubClient.ResolveRepoMetadata(executionContext, hostQualifiedRepository)
+		if execshell.IsExecutableNotFound(metadataError) {
+			return metadataError
+		}
+		if githubcli.IsNotFound(metadataError) {
+			continue
+		}
+		if metadataError != nil {
+			service.recordHostCheckFailure(candidate, metadataError)
+			continue
+		}
+		service.hostMismatches = append(service.hostMismatches, HostMismatch{
+			RepositoryPath:   candidate.repositoryPath,
+			OwnerRepository:  candidate.location.ownerRepository,
+			OriginURL:        candidate.originURL,
+			OriginHost:       candidate.location.host,
+			ConfiguredHost:   service.githubHost,
+			ReconciledRemote: candidate.location.withHost(service.githubHost).String(),
+			RemoteProtocol:   candidate.location.protocol(),
+		})
+	}
+	return nil
+}
+
+func (service *Service) recordHostCheckFailure(candidate hostMismatchCandidate, cause error) {
+	service.hostCheckFailures = append(service.hostCheckFailures, HostCheckFailure{
+		RepositoryPath: candidate.repositoryPath,
+		OriginURL:      candidate.originURL,
+		ConfiguredHost: service.githubHost,
+		Cause:          cause,
+	})
+}
+
+// resolveSSHHost returns the host an SSH remote host connects to. An alias defined in ~/.ssh/config resolves to a
+// different host; such origins are skipped because rewriting them would drop the alias's key and user settings.
+// Executors without ssh support leave the host unresolved.
+func (service *Service) resolveSSHHost(executionContext context.Context, host string) (string, error) {
+	sshExecutor, supportsSSH := service.gitExecutor.(SSHExecutor)
+	if !supportsSSH {
+		return host, nil
+	}
+	executionResult, executionError := sshExecutor.ExecuteSSH(executionContext, execshell.CommandDetails{
+		Arguments:  []string{sshConfigFlagConstant, host},
+		Idempotent: true,
+	})
+	if executionError != nil {
+		return "", executionError
+	}
+	for _, line := range strings.Split(executionResult.StandardOutput, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.EqualFold(fields[0], sshConfigHostnameKeyConstant) {
+			return strings.ToLower(fields[1]), nil
+		}
+	}
+	return "", fmt.Errorf(sshHostnameMissingTemplate, host)
+}
+
+func parseRemoteLocation(remote string) (remoteLocation, bool) {
+	trimmed := strings.TrimSpace(remote)
+	var location remoteLocation
+	switch {
+	case strings.HasPrefix(trimmed, sshURLPrefixConstant):
+		location.prefix = sshURLPrefixConstant
+		location.separator = repositoryOwnerSeparatorConstant
+	case strings.HasPrefix(trimmed, httpsURLPrefixConstant):
+		location.prefix = httpsURLPrefixConstant
+		location.separator = repositoryOwnerSeparatorConstant
+	case strings.HasPrefix(trimmed, gitSCPUserPrefixConstant):
+		location.prefix = gitSCPUserPrefixConstant
+		location.separator = gitSCPHostSeparatorConstant
+	default:
+		return remoteLocation{}, false
+	}
+
+	remainder := strings.TrimPrefix(trimmed, location.prefix)
+	separatorIndex := strings.Index(remainder, location.separator)
+	if separatorIndex <= 0 {
+		return remoteLocation{}, false
+	}
+	location.host = strings.ToLower(remainder[:separatorIndex])
+	location.path = remainder[separatorIndex+len(location.separator):]
+
+	segments := strings.Split(strings.TrimSuffix(location.path, gitSuffixConstant), repositoryOwnerSeparatorConstant)
+	if len(segments) != 2 || len(segments[0]) == 0 || len(segments[1]) == 0 {
+		return remoteLocation{}, false
+	}
+	location.ownerRepository = segments[0] + repositoryOwnerSeparatorConstant + segments[1]
+	return location, true
+}
+
+func (location remoteLocation) String() string {
+	return location.prefix + location.host + location.separator + location.path
+}
+
+func (location remoteLocation) withHost(host string) remoteLocation {
+	location.host = host
+	return location
+}
+
+func (location remoteLocation) withOwnerRepository(ownerRepository string) remoteLocation {
+	hadGitSuffix := strings.HasSuffix(location.path, gitSuffixConstant)
+	location.path = ownerRepository
+	if hadGitSuffix {
+		location.path += gitSuffixConstant
+	}
+	location.ownerRepository = ownerRepository
+	return location
+}
+
+func (location remoteLocation) withProtocol(protocol RemoteProtocolType) remoteLocation {
+	switch protocol {
+	case RemoteProtocolSSH:
+		location.prefix = sshURLPrefixConstant
+		location.separator = repositoryOwnerSeparatorConstant
+	case RemoteProtocolHTTPS:
+		location.prefix = httpsURLPrefixConstant
+		location.separator = repositoryOwnerSeparatorConstant
+	case RemoteProtocolGit:
+		location.prefix = gitSCPUserPrefixConstant
+		location.separator = gitSCPHostSeparatorConstant
+	}
+	return location
+}
+
+func (location remoteLocation) protocol() RemoteProtocolType {
+	switch location.prefix {
+	case sshURLPrefixConstant:
+		return RemoteProtocolSSH
+	case httpsURLPrefixConstant:
+		return RemoteProtocolHTTPS
+	default:
+		return RemoteProtocolGit
+	}
+}
@@ -0,0 +1,248 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	identityMismatchFindingTemplateConstant    = "IDENTITY-MISMATCH: %s user.email %s does not match %s required for owner %s; set with: %s\n"
+	identityEmailCommandTemplateConstant       = "git -C %s config user.email %s"
+	identityEmailPlaceholderTemplateConstant   = "<address matching %s>"
+	identityUnsetEmailValueConstant            = "(unset)"
+	identityEmailSourceTemplateConstant        = "%s (%s)"
+	identityRuleOwnerKeyConstant               = "owner"
+	identityRulePatternKeyConstant             = "email_pattern"
+	identityRuleOwnerMissingTemplateConstant   = "identity rule %d requires an owner"
+	identityRulePatternMissingTemplateConstant = "identity rule %d requires an email_pattern"
+	identityRulePatternInvalidTemplateConstant = "identity rule %d email_pattern %q is invalid: %w"
+	identityRuleValueInvalidTemplateConstant   = "identity rule %d %s must be a string"
+	identityRuleDuplicateTemplateConstant      = "identity rules list owner %s more than once"
+	identityWildcardPrefixConstant             = "*@"
+	identityWildcardCharactersConstant         = "*?["
+	identityEmailSeparatorConstant             = "@"
+	gitConfigSubcommandConstant                = "config"
+	gitConfigGetFlagConstant                   = "--get"
+	gitUserEmailKeyConstant                    = "user.email"
+)
+
+// IdentityEmailSource records which git configuration level supplied the email checked against an identity rule.
+type IdentityEmailSource string
+
+// Supported identity email sources.
+const (
+	// IdentityEmailSourceLocal marks an email set in the repository's own git configuration.
+	IdentityEmailSourceLocal IdentityEmailSource = "local"
+	// IdentityEmailSourceGlobal marks an email inherited from the user's global git configuration.
+	IdentityEmailSourceGlobal IdentityEmailSource = "global"
+	// IdentityEmailSourceUnset marks a repository with no user.email at either level.
+	IdentityEmailSourceUnset IdentityEmailSource = "unset"
+)
+
+// IdentityRule requires repositories of Owner to commit with a user.email matching EmailPattern, a shell-style
+// pattern such as *@company.com. Owner and pattern are compared case-insensitively.
+type IdentityRule struct {
+	Owner        string `mapstructure:"owner"`
+	EmailPattern string `mapstructure:"email_pattern"`
+}
+
+// IdentityViolation describes a repository whose effective user.email does not match the identity rule for its owner.
+type IdentityViolation struct {
+	RepositoryPath string
+	Owner          string
+	Email          string
+	Source         IdentityEmailSource
+	EmailPattern   string
+	SuggestedEmail string
+}
+
+// FixCommand returns the git command that sets a matching user.email in the repository.
+func (violation IdentityViolation) FixCommand() string {
+	suggestedEmail := violation.SuggestedEmail
+	if len(suggestedEmail) == 0 {
+		suggestedEmail = fmt.Sprintf(identityEmailPlaceholderTemplateConstant, violation.EmailPattern)
+	}
+	return fmt.Sprintf(identityEmailCommandTemplateConstant, violation.RepositoryPath, suggestedEmail)
+}
+
+// ParseIdentityRules converts configured identity_rules entries into rules. Every entry needs an owner and a valid
+// email_pattern.
+func ParseIdentityRules(entries []map[string]any) ([]IdentityRule, error) {
+	rules := make([]IdentityRule, 0, len(entries))
+	for entryIndex, entry := range entries {
+		ruleNumber := entryIndex + 1
+		owner, ownerError := identityRuleString(entry, identityRuleOwnerKeyConstant, ruleNumber)
+		if ownerError != nil {
+			return nil, ownerError
+		}
+		emailPattern, patternError := identityRuleString(entry, identityRulePatternKeyConstant, ruleNumber)
+		if patternError != nil {
+			return nil, patternError
+		}
+		rule, ruleError := IdentityRule{Owner: owner, EmailPattern: emailPattern}.validate(ruleNumber)
+		if ruleError != nil {
+			return nil, ruleError
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func identityRuleString(entry map[string]any, key string, ruleNumber int) (string, error) {
+	rawValue, exists := entry[key]
+	if !exists || rawValue == nil {
+		return "", nil
+	}
+	value, isString := rawValue.(string)
+	if !isString {
+		return "", fmt.Errorf(identityRuleValueInvalidTemplateConstant, ruleNumber, key)
+	}
+	return value, nil
+}
+
+func (rule IdentityRule) validate(ruleNumber int) (IdentityRule, error) {
+	normalized := IdentityRule{
+		Owner:        strings.ToLower(strings.TrimSpace(rule.Owner)),
+		EmailPattern: strings.ToLower(strings.TrimSpace(rule.EmailPattern)),
+	}
+	if len(normalized.Owner) == 0 {
+		return IdentityRule{}, fmt.Errorf(identityRuleOwnerMissingTemplateConstant, ruleNumber)
+	}
+	if len(normalized.EmailPattern) == 0 {
+		return IdentityRule{}, fmt.Errorf(identityRulePatternMissingTemplateConstant, ruleNumber)
+	}
+	if _, matchError := path.Match(normalized.EmailPattern, ""); matchError != nil {
+		return IdentityRule{}, fmt.Errorf(identityRulePatternInvalidTemplateConstant, ruleNumber, rule.EmailPattern, matchError)
+	}
+	return normalized, nil
+}
+
+// matches reports whether the email satisfies the rule's pattern. An empty email never matches.
+func (rule IdentityRule) matches(email string) bool {
+	normalizedEmail := strings.ToLower(strings.TrimSpace(email))
+	if len(normalizedEmail) == 0 {
+		return false
+	}
+	matched, matchError := path.Match(rule.EmailPattern, normalizedEmail)
+	return matchError == nil && matched
+}
+
+// suggestEmail proposes a matching address for patterns of the form *@domain by keeping the local part of the current
+// email. Other patterns return an empty suggestion.
+func (rule IdentityRule) suggestEmail(email string) string {
+	if !strings.HasPrefix(rule.EmailPattern, identityWildcardPrefixConstant) {
+		return ""
+	}
+	domain := strings.TrimPrefix(rule.EmailPattern, identityWildcardPrefixConstant)
+	if len(domain) == 0 || strings.ContainsAny(domain, identityWildcardCharactersConstant) {
+		return ""
+	}
+	localPart, _, found := strings.Cut(strings.TrimSpace(email), identityEmailSeparatorConstant)
+	if !found || len(localPart) == 0 {
+		return ""
+	}
+	return localPart + identityEmailSeparatorConstant + domain
+}
+
+// SetIdentityRules configures the owner identity rules checked during full inspections. Invalid rules and owners listed
+// twice are an error; an empty list turns the check off.
+func (service *Service) SetIdentityRules(rules []IdentityRule) error {
+	normalizedRules := make(map[string]IdentityRule, len(rules))
+	for ruleIndex, rule := range rules {
+		normalizedRule, ruleError := rule.validate(ruleIndex + 1)
+		if ruleError != nil {
+			return ruleError
+		}
+		if _, duplicate := normalizedRules[normalizedRule.Owner]; duplicate {
+			return fmt.Errorf(identityRuleDuplicateTemplateConstant, normalizedRule.Owner)
+		}
+		normalizedRules[normalizedRule.Owner] = normalizedRule
+	}
+	service.identityRules = normalizedRules
+	return nil
+}
+
+// IdentityViolations returns the identity findings detected by the most recent DiscoverInspections call.
+func (service *Service) IdentityViolations() []IdentityViolation {
+	return service.identityViolations
+}
+
+// ReportIdentityViolations writes each identity finding and the command that sets a matching email to the error writer.
+func (service *Service) ReportIdentityViolations() {
+	if service.errorWriter == nil {
+		return
+	}
+	for _, violation := range service.identityViolations {
+		email := identityUnsetEmailValueConstant
+		if len(violation.Email) > 0 {
+			email = fmt.Sprintf(identityEmailSourceTemplateConstant, violation.Email, violation.Source)
+		}
+		fmt.Fprintf(
+			service.errorWriter,
+			identityMismatchFindingTemplateConstant,
+			violation.RepositoryPath,
+			email,
+			violation.EmailPattern,
+			violation.Owner,
+			violation.FixCommand(),
+		)
+	}
+}
+
+// recordIdentityViolation checks the effective user.email of a repository against the rule for its owner. Repositories
+// whose owner has no rule are not checked.
+func (service *Service) recordIdentityViolation(executionContext context.Context, repositoryPath string, ownerRepository string) error {
+	if len(service.identityRules) == 0 {
+		return nil
+	}
+	owner, _, _ := strings.Cut(ownerRepository, repositoryOwnerSeparatorConstant)
+	rule, ruleExists := service.identityRules[strings.ToLower(strings.TrimSpace(owner))]
+	if !ruleExists {
+		return nil
+	}
+
+	email, source, emailError := service.resolveUserEmail(executionContext, repositoryPath)
+	if emailError != nil {
+		return emailError
+	}
+	if rule.matches(email) {
+		return nil
+	}
+	service.identityViolations = append(service.identityViolations, IdentityViolation{
+		RepositoryPath: repositoryPath,
+		Owner:          rule.Owner,
+		Email:          email,
+		Source:         source,
+		EmailPattern:   rule.EmailPattern,
+		SuggestedEmail: rule.suggestEmail(email),
+	})
+	return nil
+}
+
+// resolveUserEmail reads user.email from the repository configuration and falls back to the global configuration.
+func (service *Service) resolveUserEmail(executionContext context.Context, repositoryPath string) (string, IdentityEmailSource, error) {
+	for _, source := range []IdentityEmailSource{IdentityEmailSourceLocal, IdentityEmailSourceGlobal} {
+		executionResult, executionError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+			Arguments:        []string{gitConfigSubcommandConstant, "--" + string(source), gitConfigGetFlagConstant, gitUserEmailKeyConstant},
+			WorkingDirectory: repositoryPath,
+			Idempotent:       true,
+		})
+		if executionError != nil {
+			if execshell.IsExecutableNotFound(executionError) {
+				return "", "", executionError
+			}
+			if cancellationError := executionContext.Err(); cancellationError != nil {
+				return "", "", cancellationError
+			}
+			continue
+		}
+		if email := strings.TrimSpace(executionResult.StandardOutput); len(email) > 0 {
+			return email, source, nil
+		}
+	}
+	return "", IdentityEmailSourceUnset, nil
+}
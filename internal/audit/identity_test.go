@@ -0,0 +1,193 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const (
+	identityTestRepositoryPathConstant = "/tmp/example"
+	identityTestLocalEmailKeyConstant  = "config --local --get user.email"
+	identityTestGlobalEmailKeyConstant = "config --global --get user.email"
+)
+
+func TestParseIdentityRules(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		entries       []map[string]any
+		expectedRules []audit.IdentityRule
+		expectedError string
+	}{
+		{
+			name:          "normalizes_rules",
+			entries:       []map[string]any{{"owner": " MyOrg ", "email_pattern": "*@Company.com"}},
+			expectedRules: []audit.IdentityRule{{Owner: "myorg", EmailPattern: "*@company.com"}},
+		},
+		{
+			name:          "missing_owner",
+			entries:       []map[string]any{{"email_pattern": "*@company.com"}},
+			expectedError: "identity rule 1 requires an owner",
+		},
+		{
+			name:          "missing_pattern",
+			entries:       []map[string]any{{"owner": "myorg"}},
+			expectedError: "identity rule 1 requires an email_pattern",
+		},
+		{
+			name:          "invalid_pattern",
+			entries:       []map[string]any{{"owner": "myorg", "email_pattern": "[@company.com"}},
+			expectedError: `identity rule 1 email_pattern "[@company.com" is invalid`,
+		},
+		{
+			name:          "non_string_value",
+			entries:       []map[string]any{{"owner": 7, "email_pattern": "*@company.com"}},
+			expectedError: "identity rule 1 owner must be a string",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			rules, parseError := audit.ParseIdentityRules(testCase.entries)
+			if len(testCase.expectedError) > 0 {
+				require.ErrorContains(subtest, parseError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, parseError)
+			require.Equal(subtest, testCase.expectedRules, rules)
+		})
+	}
+}
+
+func TestServiceSetIdentityRulesRejectsDuplicateOwners(testInstance *testing.T) {
+	service := audit.NewService(stubDiscoverer{}, stubGitManager{}, stubGitExecutor{}, stubGitHubResolver{}, &bytes.Buffer{}, &bytes.Buffer{})
+	rulesError := service.SetIdentityRules([]audit.IdentityRule{
+		{Owner: "myorg", EmailPattern: "*@company.com"},
+		{Owner: "MyOrg", EmailPattern: "*@other.com"},
+	})
+	require.EqualError(testInstance, rulesError, "identity rules list owner myorg more than once")
+}
+
+func TestServiceRunReportsIdentityViolations(testInstance *testing.T) {
+	testCases := []struct {
+		name                   string
+		rules                  []audit.IdentityRule
+		emailOutputs           map[string]execshell.ExecutionResult
+		inspectionDepth        audit.InspectionDepth
+		expectedViolations     []audit.IdentityViolation
+		expectedStderr         string
+		expectedReconciliation []string
+	}{
+		{
+			name:            "global_personal_email_violates_rule",
+			rules:           []audit.IdentityRule{{Owner: "origin", EmailPattern: "*@company.com"}},
+			emailOutputs:    map[string]execshell.ExecutionResult{identityTestGlobalEmailKeyConstant: {StandardOutput: "jane@personal.example\n"}},
+			inspectionDepth: audit.InspectionDepthFull,
+			expectedViolations: []audit.IdentityViolation{
+				{
+					RepositoryPath: identityTestRepositoryPathConstant,
+					Owner:          "origin",
+					Email:          "jane@personal.example",
+					Source:         audit.IdentityEmailSourceGlobal,
+					EmailPattern:   "*@company.com",
+					SuggestedEmail: "jane@company.com",
+				},
+			},
+			expectedStderr: "IDENTITY-MISMATCH: /tmp/example user.email jane@personal.example (global) does not match *@company.com required for owner origin; set with: git -C /tmp/example config user.email jane@company.com\n",
+			expectedReconciliation: []string{
+				"MANUAL-FIX: identity-email /tmp/example: git -C /tmp/example config user.email jane@company.com",
+			},
+		},
+		{
+			name:  "local_email_overrides_global",
+			rules: []audit.IdentityRule{{Owner: "Origin", EmailPattern: "*@company.com"}},
+			emailOutputs: map[string]execshell.ExecutionResult{
+				identityTestLocalEmailKeyConstant:  {StandardOutput: "Jane@Company.com\n"},
+				identityTestGlobalEmailKeyConstant: {StandardOutput: "jane@personal.example\n"},
+			},
+			inspectionDepth: audit.InspectionDepthFull,
+		},
+		{
+			name:            "unset_email_violates_rule",
+			rules:           []audit.IdentityRule{{Owner: "origin", EmailPattern: "jane.*@company.com"}},
+			inspectionDepth: audit.InspectionDepthFull,
+			expectedViolations: []audit.IdentityViolation{
+				{
+					RepositoryPath: identityTestRepositoryPathConstant,
+					Owner:          "origin",
+					Source:         audit.IdentityEmailSourceUnset,
+					EmailPattern:   "jane.*@company.com",
+				},
+			},
+			expectedStderr: "IDENTITY-MISMATCH: /tmp/example user.email (unset) does not match jane.*@company.com required for owner origin; set with: git -C /tmp/example config user.email <address matching jane.*@company.com>\n",
+			expectedReconciliation: []string{
+				"MANUAL-FIX: identity-email /tmp/example: git -C /tmp/example config user.email <address matching jane.*@company.com>",
+			},
+		},
+		{
+			name:            "owner_without_rule_is_unchecked",
+			rules:           []audit.IdentityRule{{Owner: "myorg", EmailPattern: "*@company.com"}},
+			emailOutputs:    map[string]execshell.ExecutionResult{identityTestGlobalEmailKeyConstant: {StandardOutput: "jane@personal.example\n"}},
+			inspectionDepth: audit.InspectionDepthFull,
+		},
+		{
+			name:            "minimal_depth_skips_check",
+			rules:           []audit.IdentityRule{{Owner: "origin", EmailPattern: "*@company.com"}},
+			emailOutputs:    map[string]execshell.ExecutionResult{identityTestGlobalEmailKeyConstant: {StandardOutput: "jane@personal.example\n"}},
+			inspectionDepth: audit.InspectionDepthMinimal,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			outputs := map[string]execshell.ExecutionResult{
+				"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+				"remote get-url --push origin":    {StandardOutput: "https://github.com/origin/example.git\n"},
+			}
+			for key, result := range testCase.emailOutputs {
+				outputs[key] = result
+			}
+			errorBuffer := &bytes.Buffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{identityTestRepositoryPathConstant}},
+				stubGitManager{branchName: "main", remoteURL: "https://github.com/origin/example.git"},
+				stubGitExecutor{outputs: outputs},
+				stubGitHubResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "origin/example", DefaultBranch: "main"}},
+				&bytes.Buffer{},
+				errorBuffer,
+			)
+			require.NoError(subtest, service.SetIdentityRules(testCase.rules))
+
+			runError := service.Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{identityTestRepositoryPathConstant},
+				InspectionDepth: testCase.inspectionDepth,
+				Fix:             true,
+				DryRun:          true,
+			})
+			require.NoError(subtest, runError)
+			require.Equal(subtest, testCase.expectedViolations, service.IdentityViolations())
+
+			findingLines := []string{}
+			reconciliationLines := []string{}
+			for _, line := range strings.Split(strings.TrimSpace(errorBuffer.String()), "\n") {
+				switch {
+				case strings.HasPrefix(line, "IDENTITY-MISMATCH:"):
+					findingLines = append(findingLines, line+"\n")
+				case strings.HasPrefix(line, "MANUAL-FIX: identity-email"):
+					reconciliationLines = append(reconciliationLines, line)
+				}
+			}
+			require.Equal(subtest, testCase.expectedStderr, strings.Join(findingLines, ""))
+			require.Equal(subtest, testCase.expectedReconciliation, nilIfEmpty(reconciliationLines))
+		})
+	}
+}
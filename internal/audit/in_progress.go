@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const inProgressOperationFindingTemplateConstant = "IN-PROGRESS-OPERATION: %s has an unfinished %s; finish or abort it before running migrations or renames\n"
+
+// InProgressOperationFinding describes a repository left mid-merge, mid-rebase, or mid-cherry-pick.
+type InProgressOperationFinding struct {
+	RepositoryPath string
+	Operation      gitrepo.InProgressOperation
+}
+
+// InProgressOperations returns the unfinished git operations detected by the most recent DiscoverInspections call.
+func (service *Service) InProgressOperations() []InProgressOperationFinding {
+	return service.inProgressOperations
+}
+
+// ReportInProgressOperations writes each unfinished git operation finding to the error writer.
+func (service *Service) ReportInProgressOperations() {
+	if service.errorWriter == nil {
+		return
+	}
+	for _, finding := range service.inProgressOperations {
+		fmt.Fprintf(service.errorWriter, inProgressOperationFindingTemplateConstant, finding.RepositoryPath, finding.Operation)
+	}
+}
+
+func (service *Service) recordInProgressOperation(executionContext context.Context, repositoryPath string) {
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(service.gitExecutor)
+	if managerError != nil {
+		return
+	}
+	inProgressOperation, inProgressError := repositoryManager.InProgressOperation(executionContext, repositoryPath)
+	if inProgressError != nil || !inProgressOperation.Active() {
+		return
+	}
+	service.inProgressOperations = append(service.inProgressOperations, InProgressOperationFinding{
+		RepositoryPath: repositoryPath,
+		Operation:      inProgressOperation,
+	})
+}
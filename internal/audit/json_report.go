@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const jsonReportIndentConstant = "  "
+
+// JSONReport is the document written by the json report format. Weights records the score weights in effect so
+// consumers can recompute scores from each row's findings with weights of their own.
+type JSONReport struct {
+	Weights      map[ScoreCategory]int `json:"weights"`
+	Repositories []JSONReportRow       `json:"repositories"`
+}
+
+// JSONReportRow carries one audit row together with its health score. Findings counts each category's findings and
+// Contributions holds the points each category added; both are empty for folders that are not repositories.
+type JSONReportRow struct {
+	Path                   string                `json:"path"`
+	FolderName             string                `json:"folder_name"`
+	FinalRepository        string                `json:"final_github_repo"`
+	NameMatches            TernaryValue          `json:"name_matches"`
+	RemoteDefaultBranch    string                `json:"remote_default_branch"`
+	LocalBranch            string                `json:"local_branch"`
+	Bare                   bool                  `json:"bare"`
+	InSync                 TernaryValue          `json:"in_sync"`
+	RemoteProtocol         RemoteProtocolType    `json:"remote_protocol"`
+	OriginMatchesCanonical TernaryValue          `json:"origin_matches_canonical"`
+	LastActivity           string                `json:"last_activity"`
+	DeleteBranchOnMerge    TernaryValue          `json:"delete_branch_on_merge"`
+	MergeQueue             TernaryValue          `json:"merge_queue"`
+	HealthScore            *int                  `json:"health_score"`
+	Findings               map[ScoreCategory]int `json:"findings"`
+	Contributions          map[ScoreCategory]int `json:"score_contributions"`
+}
+
+// NewJSONReport builds the json report document for the inspections in the order given.
+func NewJSONReport(inspections []RepositoryInspection, weights ScoreWeights) JSONReport {
+	report := JSONReport{Weights: map[ScoreCategory]int(weights), Repositories: make([]JSONReportRow, 0, len(inspections))}
+	for _, inspection := range inspections {
+		row := inspectionReportRow(inspection)
+		reportRow := JSONReportRow{
+			Path:                   inspection.Path,
+			FolderName:             row.FolderName,
+			FinalRepository:        row.FinalRepository,
+			NameMatches:            row.NameMatches,
+			RemoteDefaultBranch:    row.RemoteDefaultBranch,
+			LocalBranch:            row.LocalBranch,
+			Bare:                   inspection.IsBare,
+			InSync:                 row.InSync,
+			RemoteProtocol:         row.RemoteProtocol,
+			OriginMatchesCanonical: row.OriginMatchesCanonical,
+			LastActivity:           row.LastActivity,
+			DeleteBranchOnMerge:    row.DeleteBranchOnMerge,
+			MergeQueue:             row.MergeQueue,
+			Findings:               map[ScoreCategory]int{},
+			Contributions:          map[ScoreCategory]int{},
+		}
+		if inspection.HealthScore.Scored {
+			score := inspection.HealthScore.Score
+			reportRow.HealthScore = &score
+			for category, count := range inspection.HealthScore.Findings {
+				reportRow.Findings[category] = count
+			}
+			for category, contribution := range inspection.HealthScore.Contributions {
+				reportRow.Contributions[category] = contribution
+			}
+		}
+		report.Repositories = append(report.Repositories, reportRow)
+	}
+	return report
+}
+
+// WriteJSONReport renders the inspections as an indented json report document.
+func WriteJSONReport(writer io.Writer, inspections []RepositoryInspection, weights ScoreWeights) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", jsonReportIndentConstant)
+	return encoder.Encode(NewJSONReport(inspections, weights))
+}
@@ -0,0 +1,422 @@
+package audit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/temirov/gix/internal/repos/discovery"
+)
+
+const (
+	reportFormatCSVValueConstant              = "csv"
+	reportFormatMarkdownValueConstant         = "markdown"
+	reportFormatJSONValueConstant             = "json"
+	reportFormatUnsupportedTemplateConstant   = "unsupported audit report format %q (expected csv, markdown, or json)"
+	markdownDefaultHostConstant               = "github.com"
+	markdownRepositoryLinkTemplateConstant    = "[%s](https://%s/%s)"
+	markdownTitleConstant                     = "# Repository audit"
+	markdownSummaryHeadingConstant            = "## Summary"
+	markdownDetailsHeadingConstant            = "## Details"
+	markdownCategoryHeadingTemplateConstant   = "## %s (%d)"
+	markdownAuditedCountTemplateConstant      = "Audited %d folders."
+	markdownDetailsOpenTemplateConstant       = "<details>\n<summary>%s (%d)</summary>"
+	markdownDetailsCloseConstant              = "</details>"
+	markdownCodeFenceOpenConstant             = "```shell"
+	markdownCodeFenceCloseConstant            = "```"
+	markdownCellSeparatorConstant             = " | "
+	markdownRowPrefixConstant                 = "| "
+	markdownRowSuffixConstant                 = " |"
+	markdownAlignLeftConstant                 = "---"
+	markdownAlignRightConstant                = "---:"
+	markdownPipeConstant                      = "|"
+	markdownEscapedPipeConstant               = `\|`
+	markdownNotApplicableConstant             = "n/a"
+	markdownCategoryColumnConstant            = "Category"
+	markdownFindingsColumnConstant            = "Findings"
+	markdownRepositoryColumnConstant          = "Repository"
+	markdownPathColumnConstant                = "Path"
+	markdownFolderColumnConstant              = "Folder"
+	markdownExpectedFolderColumnConstant      = "Expected folder"
+	markdownLocalBranchColumnConstant         = "Local branch"
+	markdownRemoteDefaultColumnConstant       = "Remote default branch"
+	markdownOriginColumnConstant              = "Origin"
+	markdownCanonicalColumnConstant           = "Canonical"
+	markdownOriginHostColumnConstant          = "Origin host"
+	markdownConfiguredHostColumnConstant      = "Configured host"
+	markdownLocalRemoteHeadColumnConstant     = "Local origin/HEAD"
+	markdownFetchURLColumnConstant            = "Fetch URL"
+	markdownPushURLColumnConstant             = "Push URL"
+	markdownOperationColumnConstant           = "Operation"
+	markdownClonesColumnConstant              = "Clones"
+	markdownParentColumnConstant              = "Inside"
+	markdownLastCommitColumnConstant          = "Last commit"
+	markdownDirtyColumnConstant               = "Dirty"
+	markdownNameMatchesColumnConstant         = "Name matches"
+	markdownInSyncColumnConstant              = "In sync"
+	markdownProtocolColumnConstant            = "Protocol"
+	markdownOriginCanonicalColumnConstant     = "Origin canonical"
+	markdownLastActivityColumnConstant        = "Last activity"
+	markdownDeleteOnMergeColumnConstant       = "Delete branch on merge"
+	markdownMergeQueueColumnConstant          = "Merge queue"
+	markdownHealthScoreColumnConstant         = "Health score"
+	markdownFolderMismatchCategoryConstant    = "Folder name mismatches"
+	markdownOutOfSyncCategoryConstant         = "Out of sync with the remote default branch"
+	markdownNonCanonicalCategoryConstant      = "Origin differs from the canonical repository"
+	markdownWrongHostCategoryConstant         = "Wrong host"
+	markdownStaleRemoteHeadCategoryConstant   = "Stale origin/HEAD"
+	markdownPushURLCategoryConstant           = "Push URL differs from the fetch URL"
+	markdownInProgressCategoryConstant        = "Unfinished git operations"
+	markdownDuplicateClonesCategoryConstant   = "Duplicate clones"
+	markdownNestedCategoryConstant            = "Nested repositories"
+	markdownUntrustedOwnershipCategory        = "Untrusted ownership"
+	markdownRemediationColumnConstant         = "Remediation"
+	markdownInventoryDetailsConstant          = "All audited folders"
+	markdownDuplicateMembersDetailsConstant   = "Duplicate clone members"
+	markdownCommandsDetailsConstant           = "Suggested commands"
+	markdownSetURLCommandTemplateConstant     = "git -C %s remote set-url origin %s"
+	markdownSetHeadCommandTemplateConstant    = "git -C %s remote set-head origin --auto"
+	markdownSetPushURLCommandTemplateConstant = "git -C %s remote set-url --push origin %s"
+	markdownLineTerminatorConstant            = "\n"
+	markdownNewlineReplacementConstant        = " "
+	markdownCarriageReturnConstant            = "\r"
+)
+
+// ReportFormat selects how the audit report is rendered.
+type ReportFormat string
+
+// Supported audit report formats.
+const (
+	// ReportFormatCSV writes one CSV row per audited folder and prints findings as text lines on the error stream.
+	ReportFormatCSV ReportFormat = reportFormatCSVValueConstant
+	// ReportFormatMarkdown writes a single markdown document suited to GitHub issues and wikis.
+	ReportFormatMarkdown ReportFormat = reportFormatMarkdownValueConstant
+	// ReportFormatJSON writes one JSON document with every row, its health score, and the per-category contributions.
+	ReportFormatJSON ReportFormat = reportFormatJSONValueConstant
+)
+
+// ReportFormats lists the supported audit report formats in display order.
+func ReportFormats() []string {
+	return []string{string(ReportFormatCSV), string(ReportFormatMarkdown), string(ReportFormatJSON)}
+}
+
+// Structured reports whether the format renders findings inside the document rather than as text lines on the error stream.
+func (format ReportFormat) Structured() bool {
+	return format == ReportFormatMarkdown || format == ReportFormatJSON
+}
+
+// ParseReportFormat normalizes a textual report format. Blank values select ReportFormatCSV.
+func ParseReportFormat(value string) (ReportFormat, error) {
+	normalizedValue := ReportFormat(strings.ToLower(strings.TrimSpace(value)))
+	switch normalizedValue {
+	case "":
+		return ReportFormatCSV, nil
+	case ReportFormatCSV, ReportFormatMarkdown, ReportFormatJSON:
+		return normalizedValue, nil
+	default:
+		return "", fmt.Errorf(reportFormatUnsupportedTemplateConstant, value)
+	}
+}
+
+// MarkdownReport gathers everything rendered into the markdown audit report.
+// Inspections are rendered in the order given; every finding list is ordered by repository path.
+type MarkdownReport struct {
+	GitHubHost           string
+	Inspections          []RepositoryInspection
+	HostMismatches       []HostMismatch
+	StaleRemoteHeads     []StaleRemoteHead
+	PushURLMismatches    []PushURLMismatch
+	InProgressOperations []InProgressOperationFinding
+	DuplicateClones      []DuplicateCloneGroup
+	NestedRepositories   []discovery.ContainmentRelationship
+	UntrustedOwnership   []UntrustedOwnershipFinding
+}
+
+// MarkdownReport collects the inspections and the findings of the most recent DiscoverInspections call.
+func (service *Service) MarkdownReport(inspections []RepositoryInspection) MarkdownReport {
+	return MarkdownReport{
+		GitHubHost:           service.githubHost,
+		Inspections:          inspections,
+		HostMismatches:       service.hostMismatches,
+		StaleRemoteHeads:     service.staleRemoteHeads,
+		PushURLMismatches:    service.pushURLMismatches,
+		InProgressOperations: service.inProgressOperations,
+		DuplicateClones:      service.duplicateClones,
+		NestedRepositories:   service.containment.Relationships,
+		UntrustedOwnership:   service.untrustedOwnership,
+	}
+}
+
+type markdownTable struct {
+	title   string
+	headers []string
+	rows    [][]string
+}
+
+// WriteMarkdownReport renders the report as a markdown document: a summary of finding counts per category, one table
+// per category with findings, and collapsible details holding the full inventory and the suggested fix commands.
+func WriteMarkdownReport(writer io.Writer, report MarkdownReport) error {
+	bufferedWriter := bufio.NewWriter(writer)
+	host := strings.TrimSpace(report.GitHubHost)
+	if len(host) == 0 {
+		host = markdownDefaultHostConstant
+	}
+
+	repositoryByPath := make(map[string]string, len(report.Inspections))
+	for _, inspection := range report.Inspections {
+		repositoryByPath[inspection.Path] = markdownOwnerRepository(inspection)
+	}
+	pathRepositoryLink := func(path string) string {
+		return markdownRepositoryLink(host, repositoryByPath[path])
+	}
+
+	categories := []markdownTable{
+		{title: markdownFolderMismatchCategoryConstant, headers: []string{markdownRepositoryColumnConstant, markdownFolderColumnConstant, markdownExpectedFolderColumnConstant}},
+		{title: markdownOutOfSyncCategoryConstant, headers: []string{markdownRepositoryColumnConstant, markdownFolderColumnConstant, markdownLocalBranchColumnConstant, markdownRemoteDefaultColumnConstant}},
+		{title: markdownNonCanonicalCategoryConstant, headers: []string{markdownRepositoryColumnConstant, markdownFolderColumnConstant, markdownOriginColumnConstant, markdownCanonicalColumnConstant}},
+		{title: markdownWrongHostCategoryConstant, headers: []string{markdownRepositoryColumnConstant, markdownPathColumnConstant, markdownOriginHostColumnConstant, markdownConfiguredHostColumnConstant}},
+		{title: markdownStaleRemoteHeadCategoryConstant, headers: []string{markdownRepositoryColumnConstant, markdownPathColumnConstant, markdownLocalRemoteHeadColumnConstant, markdownRemoteDefaultColumnConstant}},
+		{title: markdownPushURLCategoryConstant, headers: []string{markdownRepositoryColumnConstant, markdownPathColumnConstant, markdownFetchURLColumnConstant, markdownPushURLColumnConstant}},
+		{title: markdownInProgressCategoryConstant, headers: []string{markdownRepositoryColumnConstant, markdownPathColumnConstant, markdownOperationColumnConstant}},
+		{title: markdownDuplicateClonesCategoryConstant, headers: []string{markdownRepositoryColumnConstant, markdownClonesColumnConstant}},
+		{title: markdownNestedCategoryConstant, headers: []string{markdownPathColumnConstant, markdownParentColumnConstant}},
+		{title: markdownUntrustedOwnershipCategory, headers: []string{markdownPathColumnConstant, markdownRemediationColumnConstant}},
+	}
+
+	inventory := markdownTable{
+		title: markdownInventoryDetailsConstant,
+		headers: []string{
+			markdownFolderColumnConstant,
+			markdownRepositoryColumnConstant,
+			markdownNameMatchesColumnConstant,
+			markdownRemoteDefaultColumnConstant,
+			markdownLocalBranchColumnConstant,
+			markdownInSyncColumnConstant,
+			markdownProtocolColumnConstant,
+			markdownOriginCanonicalColumnConstant,
+			markdownLastActivityColumnConstant,
+			markdownDeleteOnMergeColumnConstant,
+			markdownMergeQueueColumnConstant,
+			markdownHealthScoreColumnConstant,
+		},
+	}
+
+	for _, inspection := range report.Inspections {
+		row := inspectionReportRow(inspection)
+		repositoryLink := markdownRepositoryLink(host, markdownOwnerRepository(inspection))
+		inventory.rows = append(inventory.rows, []string{
+			row.FolderName,
+			repositoryLink,
+			string(row.NameMatches),
+			row.RemoteDefaultBranch,
+			row.LocalBranch,
+			string(row.InSync),
+			string(row.RemoteProtocol),
+			string(row.OriginMatchesCanonical),
+			row.LastActivity,
+			string(row.DeleteBranchOnMerge),
+			string(row.MergeQueue),
+			row.HealthScore,
+		})
+		if row.NameMatches == TernaryValueNo {
+			categories[0].rows = append(categories[0].rows, []string{repositoryLink, row.FolderName, inspection.DesiredFolderName})
+		}
+		if row.InSync == TernaryValueNo {
+			categories[1].rows = append(categories[1].rows, []string{repositoryLink, row.FolderName, row.LocalBranch, row.RemoteDefaultBranch})
+		}
+		if row.OriginMatchesCanonical == TernaryValueNo {
+			categories[2].rows = append(categories[2].rows, []string{repositoryLink, row.FolderName, inspection.OriginOwnerRepo, inspection.CanonicalOwnerRepo})
+		}
+	}
+
+	commands := make([]string, 0)
+
+	hostMismatches := append([]HostMismatch(nil), report.HostMismatches...)
+	sort.SliceStable(hostMismatches, func(first int, second int) bool {
+		return hostMismatches[first].RepositoryPath < hostMismatches[second].RepositoryPath
+	})
+	for _, mismatch := range hostMismatches {
+		categories[3].rows = append(categories[3].rows, []string{markdownRepositoryLink(mismatch.ConfiguredHost, mismatch.OwnerRepository), mismatch.RepositoryPath, mismatch.OriginHost, mismatch.ConfiguredHost})
+		commands = append(commands, fmt.Sprintf(markdownSetURLCommandTemplateConstant, mismatch.RepositoryPath, mismatch.ReconciledRemote))
+	}
+
+	staleRemoteHeads := append([]StaleRemoteHead(nil), report.StaleRemoteHeads...)
+	sort.SliceStable(staleRemoteHeads, func(first int, second int) bool {
+		return staleRemoteHeads[first].RepositoryPath < staleRemoteHeads[second].RepositoryPath
+	})
+	for _, staleHead := range staleRemoteHeads {
+		categories[4].rows = append(categories[4].rows, []string{pathRepositoryLink(staleHead.RepositoryPath), staleHead.RepositoryPath, staleHead.LocalRemoteHead, staleHead.RemoteDefaultBranch})
+		commands = append(commands, fmt.Sprintf(markdownSetHeadCommandTemplateConstant, staleHead.RepositoryPath))
+	}
+
+	pushURLMismatches := append([]PushURLMismatch(nil), report.PushURLMismatches...)
+	sort.SliceStable(pushURLMismatches, func(first int, second int) bool {
+		return pushURLMismatches[first].RepositoryPath < pushURLMismatches[second].RepositoryPath
+	})
+	for _, mismatch := range pushURLMismatches {
+		categories[5].rows = append(categories[5].rows, []string{pathRepositoryLink(mismatch.RepositoryPath), mismatch.RepositoryPath, mismatch.FetchURL, mismatch.PushURL})
+		commands = append(commands, fmt.Sprintf(markdownSetPushURLCommandTemplateConstant, mismatch.RepositoryPath, mismatch.FetchURL))
+	}
+
+	inProgressOperations := append([]InProgressOperationFinding(nil), report.InProgressOperations...)
+	sort.SliceStable(inProgressOperations, func(first int, second int) bool {
+		return inProgressOperations[first].RepositoryPath < inProgressOperations[second].RepositoryPath
+	})
+	for _, finding := range inProgressOperations {
+		categories[6].rows = append(categories[6].rows, []string{pathRepositoryLink(finding.RepositoryPath), finding.RepositoryPath, string(finding.Operation)})
+	}
+
+	duplicateClones := append([]DuplicateCloneGroup(nil), report.DuplicateClones...)
+	sort.SliceStable(duplicateClones, func(first int, second int) bool {
+		return strings.ToLower(duplicateClones[first].OwnerRepository) < strings.ToLower(duplicateClones[second].OwnerRepository)
+	})
+	duplicateMembers := markdownTable{
+		title:   markdownDuplicateMembersDetailsConstant,
+		headers: []string{markdownRepositoryColumnConstant, markdownPathColumnConstant, markdownLastCommitColumnConstant, markdownDirtyColumnConstant},
+	}
+	for _, group := range duplicateClones {
+		repositoryLink := markdownRepositoryLink(host, group.OwnerRepository)
+		categories[7].rows = append(categories[7].rows, []string{repositoryLink, fmt.Sprint(len(group.Members))})
+		members := append([]DuplicateCloneMember(nil), group.Members...)
+		sort.SliceStable(members, func(first int, second int) bool {
+			return members[first].RepositoryPath < members[second].RepositoryPath
+		})
+		for _, member := range members {
+			duplicateMembers.rows = append(duplicateMembers.rows, []string{repositoryLink, member.RepositoryPath, member.LastCommitDate, string(member.Dirty)})
+		}
+	}
+
+	nestedRepositories := append([]discovery.ContainmentRelationship(nil), report.NestedRepositories...)
+	sort.SliceStable(nestedRepositories, func(first int, second int) bool {
+		return nestedRepositories[first].ChildPath < nestedRepositories[second].ChildPath
+	})
+	for _, relationship := range nestedRepositories {
+		categories[8].rows = append(categories[8].rows, []string{relationship.ChildPath, relationship.ParentPath})
+	}
+
+	untrustedOwnership := append([]UntrustedOwnershipFinding(nil), report.UntrustedOwnership...)
+	sort.SliceStable(untrustedOwnership, func(first int, second int) bool {
+		return untrustedOwnership[first].RepositoryPath < untrustedOwnership[second].RepositoryPath
+	})
+	for _, finding := range untrustedOwnership {
+		categories[9].rows = append(categories[9].rows, []string{finding.RepositoryPath, finding.Remediation()})
+		commands = append(commands, finding.Remediation())
+	}
+
+	writeMarkdownLine(bufferedWriter, markdownTitleConstant)
+	writeMarkdownLine(bufferedWriter, "")
+	writeMarkdownLine(bufferedWriter, markdownSummaryHeadingConstant)
+	writeMarkdownLine(bufferedWriter, "")
+	summaryRows := make([][]string, 0, len(categories))
+	for _, category := range categories {
+		summaryRows = append(summaryRows, []string{category.title, fmt.Sprint(len(category.rows))})
+	}
+	writeMarkdownTable(bufferedWriter, []string{markdownCategoryColumnConstant, markdownFindingsColumnConstant}, []string{markdownAlignLeftConstant, markdownAlignRightConstant}, summaryRows)
+	writeMarkdownLine(bufferedWriter, "")
+	writeMarkdownLine(bufferedWriter, fmt.Sprintf(markdownAuditedCountTemplateConstant, len(report.Inspections)))
+
+	for _, category := range categories {
+		if len(category.rows) == 0 {
+			continue
+		}
+		writeMarkdownLine(bufferedWriter, "")
+		writeMarkdownLine(bufferedWriter, fmt.Sprintf(markdownCategoryHeadingTemplateConstant, category.title, len(category.rows)))
+		writeMarkdownLine(bufferedWriter, "")
+		writeMarkdownTable(bufferedWriter, category.headers, nil, category.rows)
+	}
+
+	if len(inventory.rows) > 0 || len(duplicateMembers.rows) > 0 || len(commands) > 0 {
+		writeMarkdownLine(bufferedWriter, "")
+		writeMarkdownLine(bufferedWriter, markdownDetailsHeadingConstant)
+		for _, details := range []markdownTable{inventory, duplicateMembers} {
+			if len(details.rows) == 0 {
+				continue
+			}
+			writeMarkdownLine(bufferedWriter, "")
+			writeMarkdownLine(bufferedWriter, fmt.Sprintf(markdownDetailsOpenTemplateConstant, details.title, len(details.rows)))
+			writeMarkdownLine(bufferedWriter, "")
+			writeMarkdownTable(bufferedWriter, details.headers, nil, details.rows)
+			writeMarkdownLine(bufferedWriter, "")
+			writeMarkdownLine(bufferedWriter, markdownDetailsCloseConstant)
+		}
+		if len(commands) > 0 {
+			writeMarkdownLine(bufferedWriter, "")
+			writeMarkdownLine(bufferedWriter, fmt.Sprintf(markdownDetailsOpenTemplateConstant, markdownCommandsDetailsConstant, len(commands)))
+			writeMarkdownLine(bufferedWriter, "")
+			writeMarkdownLine(bufferedWriter, markdownCodeFenceOpenConstant)
+			for _, command := range commands {
+				writeMarkdownLine(bufferedWriter, command)
+			}
+			writeMarkdownLine(bufferedWriter, markdownCodeFenceCloseConstant)
+			writeMarkdownLine(bufferedWriter, "")
+			writeMarkdownLine(bufferedWriter, markdownDetailsCloseConstant)
+		}
+	}
+
+	return bufferedWriter.Flush()
+}
+
+func writeMarkdownTable(writer *bufio.Writer, headers []string, alignments []string, rows [][]string) {
+	escapedHeaders := make([]string, len(headers))
+	separators := make([]string, len(headers))
+	for columnIndex, header := range headers {
+		escapedHeaders[columnIndex] = escapeMarkdownCell(header)
+		separators[columnIndex] = markdownAlignLeftConstant
+		if columnIndex < len(alignments) {
+			separators[columnIndex] = alignments[columnIndex]
+		}
+	}
+	writeMarkdownRow(writer, escapedHeaders)
+	writeMarkdownRow(writer, separators)
+	for _, row := range rows {
+		escapedCells := make([]string, len(row))
+		for cellIndex, cell := range row {
+			escapedCells[cellIndex] = escapeMarkdownCell(cell)
+		}
+		writeMarkdownRow(writer, escapedCells)
+	}
+}
+
+func writeMarkdownRow(writer *bufio.Writer, cells []string) {
+	writeMarkdownLine(writer, markdownRowPrefixConstant+strings.Join(cells, markdownCellSeparatorConstant)+markdownRowSuffixConstant)
+}
+
+func writeMarkdownLine(writer *bufio.Writer, line string) {
+	writer.WriteString(line)
+	writer.WriteString(markdownLineTerminatorConstant)
+}
+
+// escapeMarkdownCell keeps a value inside one table cell: pipes are escaped and line breaks become spaces.
+func escapeMarkdownCell(value string) string {
+	escaped := strings.ReplaceAll(value, markdownPipeConstant, markdownEscapedPipeConstant)
+	escaped = strings.ReplaceAll(escaped, markdownCarriageReturnConstant, "")
+	escaped = strings.ReplaceAll(escaped, markdownLineTerminatorConstant, markdownNewlineReplacementConstant)
+	if len(strings.TrimSpace(escaped)) == 0 {
+		return markdownNotApplicableConstant
+	}
+	return escaped
+}
+
+func markdownRepositoryLink(host string, ownerRepository string) string {
+	trimmedOwnerRepository := strings.TrimSpace(ownerRepository)
+	if len(trimmedOwnerRepository) == 0 {
+		return markdownNotApplicableConstant
+	}
+	trimmedHost := strings.TrimSpace(host)
+	if len(trimmedHost) == 0 {
+		trimmedHost = markdownDefaultHostConstant
+	}
+	return fmt.Sprintf(markdownRepositoryLinkTemplateConstant, trimmedOwnerRepository, trimmedHost, trimmedOwnerRepository)
+}
+
+func markdownOwnerRepository(inspection RepositoryInspection) string {
+	if !inspection.IsGitRepository {
+		return ""
+	}
+	if len(strings.TrimSpace(inspection.CanonicalOwnerRepo)) > 0 {
+		return inspection.CanonicalOwnerRepo
+	}
+	return inspection.OriginOwnerRepo
+}
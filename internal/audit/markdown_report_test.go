@@ -0,0 +1,151 @@
+package audit_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/repos/discovery"
+)
+
+const (
+	markdownGoldenDirectoryConstant = "testdata"
+	markdownGoldenFileModeConstant  = 0o644
+)
+
+var updateGoldenFiles = flag.Bool("update", false, "rewrite golden files with the current output")
+
+func TestParseReportFormat(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		value          string
+		expectedFormat audit.ReportFormat
+		expectError    bool
+	}{
+		{name: "blank_defaults_to_csv", value: "", expectedFormat: audit.ReportFormatCSV},
+		{name: "csv", value: "csv", expectedFormat: audit.ReportFormatCSV},
+		{name: "markdown_case_insensitive", value: " Markdown ", expectedFormat: audit.ReportFormatMarkdown},
+		{name: "unsupported", value: "html", expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			reportFormat, parseError := audit.ParseReportFormat(testCase.value)
+			if testCase.expectError {
+				require.Error(subtest, parseError)
+				return
+			}
+			require.NoError(subtest, parseError)
+			require.Equal(subtest, testCase.expectedFormat, reportFormat)
+		})
+	}
+}
+
+func TestWriteMarkdownReportGolden(testInstance *testing.T) {
+	lastCommit := time.Date(2026, time.March, 14, 9, 30, 0, 0, time.UTC)
+	inspections := []audit.RepositoryInspection{
+		{
+			Path:                   "/src/alpha",
+			FolderName:             "alpha",
+			OriginOwnerRepo:        "acme/alpha",
+			CanonicalOwnerRepo:     "acme/alpha",
+			DesiredFolderName:      "alpha",
+			RemoteProtocol:         audit.RemoteProtocolSSH,
+			RemoteDefaultBranch:    "main",
+			LocalBranch:            "main",
+			InSyncStatus:           audit.TernaryValueYes,
+			OriginMatchesCanonical: audit.TernaryValueYes,
+			LastActivity:           audit.CommitActivity{Inspected: true, LastCommit: lastCommit},
+			DeleteBranchOnMerge:    audit.TernaryValueYes,
+			MergeQueue:             audit.TernaryValueNo,
+			IsGitRepository:        true,
+		},
+		{
+			Path:                   "/src/old-beta",
+			FolderName:             "old-beta",
+			OriginOwnerRepo:        "acme/old-beta",
+			CanonicalOwnerRepo:     "acme/beta",
+			DesiredFolderName:      "beta",
+			RemoteProtocol:         audit.RemoteProtocolHTTPS,
+			RemoteDefaultBranch:    "main",
+			LocalBranch:            "feature|pipes",
+			InSyncStatus:           audit.TernaryValueNo,
+			OriginMatchesCanonical: audit.TernaryValueNo,
+			LastActivity:           audit.CommitActivity{Inspected: true, LastCommit: lastCommit},
+			DeleteBranchOnMerge:    audit.TernaryValueNo,
+			MergeQueue:             audit.TernaryValueYes,
+			IsGitRepository:        true,
+		},
+		{
+			Path:       "/src/notes",
+			FolderName: "notes",
+		},
+	}
+
+	report := audit.MarkdownReport{
+		GitHubHost:  "github.example.com",
+		Inspections: inspections,
+		HostMismatches: []audit.HostMismatch{
+			{RepositoryPath: "/src/alpha", OwnerRepository: "acme/alpha", OriginHost: "github.com", ConfiguredHost: "github.example.com", ReconciledRemote: "git@github.example.com:acme/alpha.git"},
+		},
+		StaleRemoteHeads: []audit.StaleRemoteHead{
+			{RepositoryPath: "/src/old-beta", LocalRemoteHead: "master", RemoteDefaultBranch: "main"},
+		},
+		PushURLMismatches: []audit.PushURLMismatch{
+			{RepositoryPath: "/src/old-beta", FetchURL: "https://github.example.com/acme/beta.git", PushURL: "git@github.example.com:fork/beta.git"},
+		},
+		InProgressOperations: []audit.InProgressOperationFinding{
+			{RepositoryPath: "/src/old-beta", Operation: gitrepo.InProgressOperationRebase},
+		},
+		DuplicateClones: []audit.DuplicateCloneGroup{
+			{
+				OwnerRepository: "acme/alpha",
+				Members: []audit.DuplicateCloneMember{
+					{RepositoryPath: "/src/alpha", LastCommitDate: "2026-03-14", Dirty: audit.TernaryValueNo},
+					{RepositoryPath: "/backup/alpha", LastCommitDate: "2025-11-02", Dirty: audit.TernaryValueYes},
+				},
+			},
+		},
+		NestedRepositories: []discovery.ContainmentRelationship{
+			{ParentPath: "/src/alpha", ChildPath: "/src/alpha/vendor/lib"},
+		},
+		UntrustedOwnership: []audit.UntrustedOwnershipFinding{
+			{RepositoryPath: "/mnt/shared/gamma"},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		report     audit.MarkdownReport
+		goldenFile string
+	}{
+		{name: "all_categories", report: report, goldenFile: "markdown_report.golden"},
+		{name: "clean_inventory", report: audit.MarkdownReport{Inspections: inspections[:1]}, goldenFile: "markdown_report_clean.golden"},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			var output bytes.Buffer
+			require.NoError(subtest, audit.WriteMarkdownReport(&output, testCase.report))
+
+			goldenPath := filepath.Join(markdownGoldenDirectoryConstant, testCase.goldenFile)
+			if *updateGoldenFiles {
+				require.NoError(subtest, os.WriteFile(goldenPath, output.Bytes(), markdownGoldenFileModeConstant))
+			}
+			expected, readError := os.ReadFile(goldenPath)
+			require.NoError(subtest, readError)
+			require.Equal(subtest, string(expected), output.String())
+
+			var repeated bytes.Buffer
+			require.NoError(subtest, audit.WriteMarkdownReport(&repeated, testCase.report))
+			require.Equal(subtest, output.String(), repeated.String())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+const pushURLMismatchFindingTemplateConstant = "PUSH-URL-MISMATCH: %s origin fetches from %s but pushes to %s; align with: git -C %s remote set-url --push origin %s\n"
+
+// PushURLMismatch describes a clone whose origin push URL names a different owner/repository than its fetch URL.
+type PushURLMismatch struct {
+	RepositoryPath       string
+	FetchURL             string
+	PushURL              string
+	FetchOwnerRepository string
+	PushOwnerRepository  string
+}
+
+// PushURLMismatches returns the push URL findings detected by the most recent DiscoverInspections call.
+func (service *Service) PushURLMismatches() []PushURLMismatch {
+	return service.pushURLMismatches
+}
+
+// ReportPushURLMismatches writes each push URL finding and the command that aligns the push URL with the fetch URL to the error writer.
+func (service *Service) ReportPushURLMismatches() {
+	if service.errorWriter == nil {
+		return
+	}
+	for _, mismatch := range service.pushURLMismatches {
+		fmt.Fprintf(
+			service.errorWriter,
+			pushURLMismatchFindingTemplateConstant,
+			mismatch.RepositoryPath,
+			mismatch.FetchURL,
+			mismatch.PushURL,
+			mismatch.RepositoryPath,
+			mismatch.FetchURL,
+		)
+	}
+}
+
+// inspectPushURL returns the origin push URL and its owner/repository, recording a finding when the push URL points at
+// a different repository than the fetch URL. Unparseable URLs are returned without a finding.
+func (service *Service) inspectPushURL(executionContext context.Context, repositoryPath string, fetchURL string, fetchOwnerRepository string) (string, string) {
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(service.gitExecutor)
+	if managerError != nil {
+		return "", ""
+	}
+	pushURL, pushURLError := repositoryManager.GetRemotePushURL(executionContext, repositoryPath, shared.OriginRemoteNameConstant)
+	if pushURLError != nil || len(pushURL) == 0 {
+		return "", ""
+	}
+	pushOwnerRepository, ownerError := canonicalizeOwnerRepo(pushURL)
+	if ownerError != nil {
+		return pushURL, ""
+	}
+	if pushURL == fetchURL || len(fetchOwnerRepository) == 0 || strings.EqualFold(pushOwnerRepository, fetchOwnerRepository) {
+		return pushURL, pushOwnerRepository
+	}
+	service.pushURLMismatches = append(service.pushURLMismatches, PushURLMismatch{
+		RepositoryPath:       repositoryPath,
+		FetchURL:             fetchURL,
+		PushURL:              pushURL,
+		FetchOwnerRepository: fetchOwnerRepository,
+		PushOwnerRepository:  pushOwnerRepository,
+	})
+	return pushURL, pushOwnerRepository
+}
@@ -0,0 +1,386 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+const (
+	fixPlanTemplateConstant               = "FIX-PLAN: %s %s: %s\n"
+	fixAppliedTemplateConstant            = "FIX-APPLIED: %s %s: %s\n"
+	fixFailedTemplateConstant             = "FIX-FAILED: %s %s: %v\n"
+	manualFixTemplateConstant             = "MANUAL-FIX: %s %s: %s\n"
+	setRemoteURLCommandTemplateConstant   = "git -C %s remote set-url origin %s"
+	setRemoteHeadCommandTemplateConstant  = "git -C %s remote set-head origin --auto"
+	setPushURLCommandTemplateConstant     = "git -C %s remote set-url --push origin %s"
+	folderRenameDetailTemplateConstant    = "rename directory to %s"
+	inProgressDetailTemplateConstant      = "finish or abort the unfinished %s"
+	duplicateCloneDetailTemplateConstant  = "%s is also cloned at %s; remove the redundant clones"
+	duplicateClonePathSeparatorConstant   = ", "
+	reconciliationFailedTemplateConstant  = "%w: %d action(s)"
+	gitRemoteSubcommandConstant           = "remote"
+	gitRemoteSetHeadSubcommandConstant    = "set-head"
+	gitRemoteSetHeadAutomaticFlagConstant = "--auto"
+)
+
+// ErrReconciliationFailed reports that at least one safe reconciliation could not be applied.
+var ErrReconciliationFailed = errors.New("audit reconciliation failed")
+
+// ReconciliationActionType identifies a kind of change that reconciles a repository with its audit findings.
+type ReconciliationActionType string
+
+// Supported reconciliation action types.
+const (
+	// ReconciliationActionRemoteHost points origin at the configured GitHub host.
+	ReconciliationActionRemoteHost ReconciliationActionType = "remote-host"
+	// ReconciliationActionRemoteCanonical points origin at the canonical owner/repository reported by GitHub.
+	ReconciliationActionRemoteCanonical ReconciliationActionType = "remote-canonical"
+	// ReconciliationActionRemoteProtocol rewrites origin to the requested remote protocol.
+	ReconciliationActionRemoteProtocol ReconciliationActionType = "remote-protocol"
+	// ReconciliationActionRemotePushURL points the origin push URL at the same repository as the fetch URL.
+	ReconciliationActionRemotePushURL ReconciliationActionType = "remote-push-url"
+	// ReconciliationActionRemoteHeadRefresh refreshes a stale origin/HEAD.
+	ReconciliationActionRemoteHeadRefresh ReconciliationActionType = "remote-head-refresh"
+	// ReconciliationActionFolderRename moves a repository directory to its canonical name.
+	ReconciliationActionFolderRename ReconciliationActionType = "folder-rename"
+	// ReconciliationActionInProgressOperation resolves an unfinished merge, rebase, or cherry-pick.
+	ReconciliationActionInProgressOperation ReconciliationActionType = "in-progress-operation"
+	// ReconciliationActionDuplicateClone removes redundant clones of the same repository.
+	ReconciliationActionDuplicateClone ReconciliationActionType = "duplicate-clone"
+	// ReconciliationActionUnshallow fetches the full history of a shallow clone.
+	ReconciliationActionUnshallow ReconciliationActionType = "unshallow"
+	// ReconciliationActionIdentityEmail sets a user.email that matches the identity rule for the repository owner.
+	ReconciliationActionIdentityEmail ReconciliationActionType = "identity-email"
+)
+
+// ReconciliationSafety classifies whether an action may be applied without human review.
+type ReconciliationSafety string
+
+// Supported reconciliation safety classes.
+const (
+	// ReconciliationSafetySafe marks metadata-only changes that never discard data.
+	ReconciliationSafetySafe ReconciliationSafety = "safe"
+	// ReconciliationSafetyUnsafe marks changes that move, delete, or rewrite local work.
+	ReconciliationSafetyUnsafe ReconciliationSafety = "unsafe"
+	// ReconciliationSafetyOptIn marks changes that never discard data but can download a lot, so they are applied only
+	// when explicitly requested.
+	ReconciliationSafetyOptIn ReconciliationSafety = "opt-in"
+)
+
+var reconciliationActionSafety = map[ReconciliationActionType]ReconciliationSafety{
+	ReconciliationActionRemoteHost:          ReconciliationSafetyUnsafe,
+	ReconciliationActionRemoteCanonical:     ReconciliationSafetySafe,
+	ReconciliationActionRemoteProtocol:      ReconciliationSafetySafe,
+	ReconciliationActionRemotePushURL:       ReconciliationSafetySafe,
+	ReconciliationActionRemoteHeadRefresh:   ReconciliationSafetySafe,
+	ReconciliationActionFolderRename:        ReconciliationSafetyUnsafe,
+	ReconciliationActionInProgressOperation: ReconciliationSafetyUnsafe,
+	ReconciliationActionDuplicateClone:      ReconciliationSafetyUnsafe,
+	ReconciliationActionIdentityEmail:       ReconciliationSafetyUnsafe,
+	ReconciliationActionUnshallow:           ReconciliationSafetyOptIn,
+}
+
+// Safety returns the safety class of the action type. Unknown action types are unsafe.
+func (actionType ReconciliationActionType) Safety() ReconciliationSafety {
+	if safety, known := reconciliationActionSafety[actionType]; known {
+		return safety
+	}
+	return ReconciliationSafetyUnsafe
+}
+
+// ReconciliationAction describes one change that reconciles a repository with an audit finding.
+// RemoteURL is the origin fetch or push URL the action sets and is empty for actions that do not rewrite origin.
+type ReconciliationAction struct {
+	Type           ReconciliationActionType
+	RepositoryPath string
+	RemoteURL      string
+	Detail         string
+}
+
+// Safe reports whether the action may be applied without human review.
+func (action ReconciliationAction) Safe() bool {
+	return action.Type.Safety() == ReconciliationSafetySafe
+}
+
+// ReconciliationOptions controls how Reconcile applies safe actions.
+// TargetProtocol enables protocol normalization when set; DryRun prints the safe actions instead of applying them.
+// Unshallow also applies the opt-in unshallow actions, which are otherwise listed for manual handling.
+type ReconciliationOptions struct {
+	TargetProtocol RemoteProtocolType
+	DryRun         bool
+	Unshallow      bool
+}
+
+func (options ReconciliationOptions) applies(action ReconciliationAction) bool {
+	if action.Type.Safety() == ReconciliationSafetyOptIn {
+		return options.Unshallow && action.Type == ReconciliationActionUnshallow
+	}
+	return action.Safe()
+}
+
+// selectActions reports which actions Reconcile applies. Remote URL actions build on the host rewrite planned before
+// them, so once a repository's remote-host action is left for manual handling its later remote URL actions are too.
+func (options ReconciliationOptions) selectActions(actions []ReconciliationAction) []bool {
+	selected := make([]bool, len(actions))
+	heldRepositories := map[string]struct{}{}
+	for actionIndex, action := range actions {
+		if action.Type.rewritesRemoteURL() {
+			if _, held := heldRepositories[action.RepositoryPath]; held {
+				continue
+			}
+		}
+		selected[actionIndex] = options.applies(action)
+		if action.Type == ReconciliationActionRemoteHost && !selected[actionIndex] {
+			heldRepositories[action.RepositoryPath] = struct{}{}
+		}
+	}
+	return selected
+}
+
+func (actionType ReconciliationActionType) rewritesRemoteURL() bool {
+	switch actionType {
+	case ReconciliationActionRemoteHost, ReconciliationActionRemoteCanonical, ReconciliationActionRemoteProtocol, ReconciliationActionRemotePushURL:
+		return true
+	default:
+		return false
+	}
+}
+
+// PlanReconciliations derives reconciliation actions from the inspections and the findings recorded by the most recent
+// DiscoverInspections call. Remote URL actions for a repository build on each other, so applying them in order leaves
+// origin on the configured host, the canonical owner/repository, and the target protocol.
+func (service *Service) PlanReconciliations(inspections []RepositoryInspection, targetProtocol RemoteProtocolType) []ReconciliationAction {
+	inspectionsByPath := make(map[string]RepositoryInspection, len(inspections))
+	hostMismatchesByPath := make(map[string]HostMismatch, len(service.hostMismatches))
+	staleRemoteHeadsByPath := make(map[string]StaleRemoteHead, len(service.staleRemoteHeads))
+	pushURLMismatchesByPath := make(map[string]PushURLMismatch, len(service.pushURLMismatches))
+	inProgressByPath := make(map[string]InProgressOperationFinding, len(service.inProgressOperations))
+	shallowByPath := make(map[string]struct{}, len(service.shallowClones))
+	identityViolationsByPath := make(map[string]IdentityViolation, len(service.identityViolations))
+	repositoryPaths := make(map[string]struct{})
+
+	for _, inspection := range inspections {
+		if !inspection.IsGitRepository {
+			continue
+		}
+		inspectionsByPath[inspection.Path] = inspection
+		repositoryPaths[inspection.Path] = struct{}{}
+	}
+	for _, mismatch := range service.hostMismatches {
+		hostMismatchesByPath[mismatch.RepositoryPath] = mismatch
+		repositoryPaths[mismatch.RepositoryPath] = struct{}{}
+	}
+	for _, staleHead := range service.staleRemoteHeads {
+		staleRemoteHeadsByPath[staleHead.RepositoryPath] = staleHead
+		repositoryPaths[staleHead.RepositoryPath] = struct{}{}
+	}
+	for _, mismatch := range service.pushURLMismatches {
+		pushURLMismatchesByPath[mismatch.RepositoryPath] = mismatch
+		repositoryPaths[mismatch.RepositoryPath] = struct{}{}
+	}
+	for _, finding := range service.inProgressOperations {
+		inProgressByPath[finding.RepositoryPath] = finding
+		repositoryPaths[finding.RepositoryPath] = struct{}{}
+	}
+	for _, finding := range service.shallowClones {
+		shallowByPath[finding.RepositoryPath] = struct{}{}
+		repositoryPaths[finding.RepositoryPath] = struct{}{}
+	}
+	for _, violation := range service.identityViolations {
+		identityViolationsByPath[violation.RepositoryPath] = violation
+		repositoryPaths[violation.RepositoryPath] = struct{}{}
+	}
+
+	orderedPaths := make([]string, 0, len(repositoryPaths))
+	for repositoryPath := range repositoryPaths {
+		orderedPaths = append(orderedPaths, repositoryPath)
+	}
+	sort.Strings(orderedPaths)
+
+	actions := make([]ReconciliationAction, 0)
+	for _, repositoryPath := range orderedPaths {
+		inspection, inspected := inspectionsByPath[repositoryPath]
+		mismatch, mismatched := hostMismatchesByPath[repositoryPath]
+
+		pushMismatch, pushMismatched := pushURLMismatchesByPath[repositoryPath]
+
+		originURL := inspection.OriginURL
+		if mismatched {
+			originURL = mismatch.OriginURL
+		} else if pushMismatched && len(originURL) == 0 {
+			originURL = pushMismatch.FetchURL
+		}
+		if location, parsed := parseRemoteLocation(originURL); parsed {
+			if mismatched {
+				location = location.withHost(mismatch.ConfiguredHost)
+				actions = append(actions, newRemoteURLAction(ReconciliationActionRemoteHost, repositoryPath, location))
+			}
+			if inspected && inspection.OriginMatchesCanonical == TernaryValueNo && len(inspection.CanonicalOwnerRepo) > 0 {
+				location = location.withOwnerRepository(inspection.CanonicalOwnerRepo)
+				actions = append(actions, newRemoteURLAction(ReconciliationActionRemoteCanonical, repositoryPath, location))
+			}
+			if len(targetProtocol) > 0 && targetProtocol != RemoteProtocolOther && location.protocol() != targetProtocol {
+				location = location.withProtocol(targetProtocol)
+				actions = append(actions, newRemoteURLAction(ReconciliationActionRemoteProtocol, repositoryPath, location))
+			}
+			if pushMismatched {
+				pushURL := location.String()
+				actions = append(actions, ReconciliationAction{
+					Type:           ReconciliationActionRemotePushURL,
+					RepositoryPath: repositoryPath,
+					RemoteURL:      pushURL,
+					Detail:         fmt.Sprintf(setPushURLCommandTemplateConstant, repositoryPath, pushURL),
+				})
+			}
+		}
+
+		if _, stale := staleRemoteHeadsByPath[repositoryPath]; stale {
+			actions = append(actions, ReconciliationAction{
+				Type:           ReconciliationActionRemoteHeadRefresh,
+				RepositoryPath: repositoryPath,
+				Detail:         fmt.Sprintf(setRemoteHeadCommandTemplateConstant, repositoryPath),
+			})
+		}
+
+		if inspected && len(inspection.DesiredFolderName) > 0 && !folderNameMatchesDesired(inspection) {
+			actions = append(actions, ReconciliationAction{
+				Type:           ReconciliationActionFolderRename,
+				RepositoryPath: repositoryPath,
+				Detail:         fmt.Sprintf(folderRenameDetailTemplateConstant, inspection.DesiredFolderName),
+			})
+		}
+
+		if finding, inProgress := inProgressByPath[repositoryPath]; inProgress {
+			actions = append(actions, ReconciliationAction{
+				Type:           ReconciliationActionInProgressOperation,
+				RepositoryPath: repositoryPath,
+				Detail:         fmt.Sprintf(inProgressDetailTemplateConstant, finding.Operation),
+			})
+		}
+
+		if _, shallow := shallowByPath[repositoryPath]; shallow {
+			actions = append(actions, ReconciliationAction{
+				Type:           ReconciliationActionUnshallow,
+				RepositoryPath: repositoryPath,
+				Detail:         fmt.Sprintf(unshallowCommandTemplateConstant, repositoryPath),
+			})
+		}
+
+		if violation, violated := identityViolationsByPath[repositoryPath]; violated {
+			actions = append(actions, ReconciliationAction{
+				Type:           ReconciliationActionIdentityEmail,
+				RepositoryPath: repositoryPath,
+				Detail:         violation.FixCommand(),
+			})
+		}
+	}
+
+	for _, group := range service.duplicateClones {
+		if len(group.Members) < 2 {
+			continue
+		}
+		otherPaths := make([]string, 0, len(group.Members)-1)
+		for _, member := range group.Members[1:] {
+			otherPaths = append(otherPaths, member.RepositoryPath)
+		}
+		actions = append(actions, ReconciliationAction{
+			Type:           ReconciliationActionDuplicateClone,
+			RepositoryPath: group.Members[0].RepositoryPath,
+			Detail:         fmt.Sprintf(duplicateCloneDetailTemplateConstant, group.OwnerRepository, strings.Join(otherPaths, duplicateClonePathSeparatorConstant)),
+		})
+	}
+
+	return actions
+}
+
+// Reconcile applies the safe actions planned for the inspections without prompting and lists the unsafe ones for manual
+// handling. Opt-in actions are applied only when the options request them and are listed for manual handling otherwise. Results are written to the error writer so the audit report stays machine readable. Failed actions do not
+// stop the remaining ones; Reconcile returns ErrReconciliationFailed once every action has been attempted.
+func (service *Service) Reconcile(executionContext context.Context, inspections []RepositoryInspection, options ReconciliationOptions) error {
+	actions := service.PlanReconciliations(inspections, options.TargetProtocol)
+	applied := options.selectActions(actions)
+
+	failureCount := 0
+	for actionIndex, action := range actions {
+		if !applied[actionIndex] {
+			continue
+		}
+		if options.DryRun {
+			service.writeFinding(fixPlanTemplateConstant, action.Type, action.RepositoryPath, action.Detail)
+			continue
+		}
+		if applyError := service.applyReconciliation(executionContext, action); applyError != nil {
+			if execshell.IsExecutableNotFound(applyError) {
+				return applyError
+			}
+			failureCount++
+			service.writeFinding(fixFailedTemplateConstant, action.Type, action.RepositoryPath, applyError)
+			continue
+		}
+		service.writeFinding(fixAppliedTemplateConstant, action.Type, action.RepositoryPath, action.Detail)
+	}
+
+	for actionIndex, action := range actions {
+		if applied[actionIndex] {
+			continue
+		}
+		service.writeFinding(manualFixTemplateConstant, action.Type, action.RepositoryPath, action.Detail)
+	}
+
+	if failureCount > 0 {
+		return fmt.Errorf(reconciliationFailedTemplateConstant, ErrReconciliationFailed, failureCount)
+	}
+	return nil
+}
+
+func (service *Service) applyReconciliation(executionContext context.Context, action ReconciliationAction) error {
+	switch action.Type {
+	case ReconciliationActionRemoteHost, ReconciliationActionRemoteCanonical, ReconciliationActionRemoteProtocol:
+		return service.gitManager.SetRemoteURL(executionContext, action.RepositoryPath, shared.OriginRemoteNameConstant, action.RemoteURL)
+	case ReconciliationActionRemotePushURL:
+		repositoryManager, managerError := gitrepo.NewRepositoryManager(service.gitExecutor)
+		if managerError != nil {
+			return managerError
+		}
+		return repositoryManager.SetRemotePushURL(executionContext, action.RepositoryPath, shared.OriginRemoteNameConstant, action.RemoteURL)
+	case ReconciliationActionUnshallow:
+		repositoryManager, managerError := gitrepo.NewRepositoryManager(service.gitExecutor)
+		if managerError != nil {
+			return managerError
+		}
+		return repositoryManager.UnshallowFetch(executionContext, action.RepositoryPath, shared.OriginRemoteNameConstant)
+	case ReconciliationActionRemoteHeadRefresh:
+		_, executionError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+			Arguments:        []string{gitRemoteSubcommandConstant, gitRemoteSetHeadSubcommandConstant, shared.OriginRemoteNameConstant, gitRemoteSetHeadAutomaticFlagConstant},
+			WorkingDirectory: action.RepositoryPath,
+			Idempotent:       false,
+		})
+		return executionError
+	default:
+		return nil
+	}
+}
+
+func (service *Service) writeFinding(template string, arguments ...any) {
+	if service.errorWriter == nil {
+		return
+	}
+	fmt.Fprintf(service.errorWriter, template, arguments...)
+}
+
+func newRemoteURLAction(actionType ReconciliationActionType, repositoryPath string, location remoteLocation) ReconciliationAction {
+	remoteURL := location.String()
+	return ReconciliationAction{
+		Type:           actionType,
+		RepositoryPath: repositoryPath,
+		RemoteURL:      remoteURL,
+		Detail:         fmt.Sprintf(setRemoteURLCommandTemplateConstant, repositoryPath, remoteURL),
+	}
+}
@@ -0,0 +1,377 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+type recordingRemoteGitManager struct {
+	stubGitManager
+	updatedRemotes *[]string
+	updateError    error
+}
+
+func (manager recordingRemoteGitManager) SetRemoteURL(ctx context.Context, repositoryPath string, remoteName string, remoteURL string) error {
+	*manager.updatedRemotes = append(*manager.updatedRemotes, repositoryPath+" "+remoteName+" "+remoteURL)
+	return manager.updateError
+}
+
+type recordingGitExecutor struct {
+	stubGitExecutor
+	executedCommands *[]string
+}
+
+func (executor recordingGitExecutor) ExecuteGit(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	*executor.executedCommands = append(*executor.executedCommands, strings.Join(details.Arguments, " "))
+	return executor.stubGitExecutor.ExecuteGit(executionContext, details)
+}
+
+func TestReconciliationActionTypeSafety(testInstance *testing.T) {
+	testCases := []struct {
+		actionType     audit.ReconciliationActionType
+		expectedSafety audit.ReconciliationSafety
+	}{
+		{actionType: audit.ReconciliationActionRemoteHost, expectedSafety: audit.ReconciliationSafetyUnsafe},
+		{actionType: audit.ReconciliationActionRemoteCanonical, expectedSafety: audit.ReconciliationSafetySafe},
+		{actionType: audit.ReconciliationActionRemoteProtocol, expectedSafety: audit.ReconciliationSafetySafe},
+		{actionType: audit.ReconciliationActionRemotePushURL, expectedSafety: audit.ReconciliationSafetySafe},
+		{actionType: audit.ReconciliationActionRemoteHeadRefresh, expectedSafety: audit.ReconciliationSafetySafe},
+		{actionType: audit.ReconciliationActionFolderRename, expectedSafety: audit.ReconciliationSafetyUnsafe},
+		{actionType: audit.ReconciliationActionInProgressOperation, expectedSafety: audit.ReconciliationSafetyUnsafe},
+		{actionType: audit.ReconciliationActionDuplicateClone, expectedSafety: audit.ReconciliationSafetyUnsafe},
+		{actionType: audit.ReconciliationActionIdentityEmail, expectedSafety: audit.ReconciliationSafetyUnsafe},
+		{actionType: audit.ReconciliationActionUnshallow, expectedSafety: audit.ReconciliationSafetyOptIn},
+		{actionType: audit.ReconciliationActionType("branch-delete"), expectedSafety: audit.ReconciliationSafetyUnsafe},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(string(testCase.actionType), func(subtest *testing.T) {
+			require.Equal(subtest, testCase.expectedSafety, testCase.actionType.Safety())
+		})
+	}
+}
+
+func TestServiceRunFixAppliesSafeReconciliations(testInstance *testing.T) {
+	testCases := []struct {
+		name                   string
+		dryRun                 bool
+		updateError            error
+		expectedError          error
+		expectedRemoteUpdates  []string
+		expectedSetHeadCalls   int
+		expectedReconciliation []string
+	}{
+		{
+			name:   "applies_safe_actions",
+			dryRun: false,
+			expectedRemoteUpdates: []string{
+				"/tmp/old-name origin https://github.com/origin/example.git",
+				"/tmp/old-name origin ssh://git@github.com/origin/example.git",
+			},
+			expectedSetHeadCalls: 1,
+			expectedReconciliation: []string{
+				"FIX-APPLIED: remote-canonical /tmp/old-name: git -C /tmp/old-name remote set-url origin https://github.com/origin/example.git",
+				"FIX-APPLIED: remote-protocol /tmp/old-name: git -C /tmp/old-name remote set-url origin ssh://git@github.com/origin/example.git",
+				"FIX-APPLIED: remote-head-refresh /tmp/old-name: git -C /tmp/old-name remote set-head origin --auto",
+				"MANUAL-FIX: folder-rename /tmp/old-name: rename directory to example",
+				"MANUAL-FIX: in-progress-operation /tmp/old-name: finish or abort the unfinished merge",
+			},
+		},
+		{
+			name:   "dry_run_prints_plan",
+			dryRun: true,
+			expectedReconciliation: []string{
+				"FIX-PLAN: remote-canonical /tmp/old-name: git -C /tmp/old-name remote set-url origin https://github.com/origin/example.git",
+				"FIX-PLAN: remote-protocol /tmp/old-name: git -C /tmp/old-name remote set-url origin ssh://git@github.com/origin/example.git",
+				"FIX-PLAN: remote-head-refresh /tmp/old-name: git -C /tmp/old-name remote set-head origin --auto",
+				"MANUAL-FIX: folder-rename /tmp/old-name: rename directory to example",
+				"MANUAL-FIX: in-progress-operation /tmp/old-name: finish or abort the unfinished merge",
+			},
+		},
+		{
+			name:          "failures_reported_after_all_actions",
+			updateError:   errors.New("permission denied"),
+			expectedError: audit.ErrReconciliationFailed,
+			expectedRemoteUpdates: []string{
+				"/tmp/old-name origin https://github.com/origin/example.git",
+				"/tmp/old-name origin ssh://git@github.com/origin/example.git",
+			},
+			expectedSetHeadCalls: 1,
+			expectedReconciliation: []string{
+				"FIX-FAILED: remote-canonical /tmp/old-name: permission denied",
+				"FIX-FAILED: remote-protocol /tmp/old-name: permission denied",
+				"FIX-APPLIED: remote-head-refresh /tmp/old-name: git -C /tmp/old-name remote set-head origin --auto",
+				"MANUAL-FIX: folder-rename /tmp/old-name: rename directory to example",
+				"MANUAL-FIX: in-progress-operation /tmp/old-name: finish or abort the unfinished merge",
+			},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			gitDirectory := subtest.TempDir()
+			require.NoError(subtest, os.WriteFile(filepath.Join(gitDirectory, "MERGE_HEAD"), []byte("abc123\n"), 0o644))
+
+			updatedRemotes := []string{}
+			executedCommands := []string{}
+			errorBuffer := &bytes.Buffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{"/tmp/old-name"}},
+				recordingRemoteGitManager{
+					stubGitManager: stubGitManager{branchName: "feature", remoteURL: "https://github.com/origin/old-name.git"},
+					updatedRemotes: &updatedRemotes,
+					updateError:    testCase.updateError,
+				},
+				recordingGitExecutor{
+					stubGitExecutor: stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+						"rev-parse --is-inside-work-tree":               {StandardOutput: "true"},
+						"rev-parse --absolute-git-dir":                  {StandardOutput: gitDirectory + "\n"},
+						"symbolic-ref --quiet refs/remotes/origin/HEAD": {StandardOutput: "refs/remotes/origin/master\n"},
+						"log -1 --format=%cI":                           {StandardOutput: "2024-05-01T10:00:00Z\n"},
+						"remote set-head origin --auto":                 {},
+					}},
+					executedCommands: &executedCommands,
+				},
+				stubGitHubResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "origin/example", DefaultBranch: "main"}},
+				&bytes.Buffer{},
+				errorBuffer,
+			)
+
+			runError := service.Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{"/tmp/old-name"},
+				InspectionDepth: audit.InspectionDepthFull,
+				Fix:             true,
+				FixProtocol:     audit.RemoteProtocolSSH,
+				DryRun:          testCase.dryRun,
+			})
+			if testCase.expectedError != nil {
+				require.ErrorIs(subtest, runError, testCase.expectedError)
+			} else {
+				require.NoError(subtest, runError)
+			}
+
+			require.Equal(subtest, testCase.expectedRemoteUpdates, nilIfEmpty(updatedRemotes))
+			setHeadCalls := 0
+			for _, command := range executedCommands {
+				if command == "remote set-head origin --auto" {
+					setHeadCalls++
+				}
+			}
+			require.Equal(subtest, testCase.expectedSetHeadCalls, setHeadCalls)
+
+			reconciliationLines := []string{}
+			for _, line := range strings.Split(strings.TrimSpace(errorBuffer.String()), "\n") {
+				if strings.HasPrefix(line, "FIX-") || strings.HasPrefix(line, "MANUAL-FIX:") {
+					reconciliationLines = append(reconciliationLines, line)
+				}
+			}
+			require.Equal(subtest, testCase.expectedReconciliation, reconciliationLines)
+		})
+	}
+}
+
+func TestServiceRunFixUnshallowsShallowClones(testInstance *testing.T) {
+	const shallowFindingConstant = "SHALLOW-CLONE: /tmp/example is a shallow clone; history-based results such as last_activity may be incomplete; fetch full history with: git -C /tmp/example fetch --unshallow origin"
+
+	testCases := []struct {
+		name                   string
+		unshallow              bool
+		dryRun                 bool
+		expectedFetchCalls     int
+		expectedReconciliation []string
+	}{
+		{
+			name:                   "listed_for_manual_handling_by_default",
+			expectedReconciliation: []string{"MANUAL-FIX: unshallow /tmp/example: git -C /tmp/example fetch --unshallow origin"},
+		},
+		{
+			name:                   "applied_when_requested",
+			unshallow:              true,
+			expectedFetchCalls:     1,
+			expectedReconciliation: []string{"FIX-APPLIED: unshallow /tmp/example: git -C /tmp/example fetch --unshallow origin"},
+		},
+		{
+			name:                   "planned_in_dry_run",
+			unshallow:              true,
+			dryRun:                 true,
+			expectedReconciliation: []string{"FIX-PLAN: unshallow /tmp/example: git -C /tmp/example fetch --unshallow origin"},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executedCommands := []string{}
+			errorBuffer := &bytes.Buffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{"/tmp/example"}},
+				stubGitManager{branchName: "main", remoteURL: "git@github.com:origin/example.git"},
+				recordingGitExecutor{
+					stubGitExecutor: stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+						"rev-parse --is-inside-work-tree":   {StandardOutput: "true"},
+						"rev-parse --is-shallow-repository": {StandardOutput: "true\n"},
+						"log -1 --format=%cI":               {StandardOutput: "2024-05-01T10:00:00Z\n"},
+						"fetch --unshallow origin":          {},
+					}},
+					executedCommands: &executedCommands,
+				},
+				stubGitHubResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "origin/example", DefaultBranch: "main"}},
+				&bytes.Buffer{},
+				errorBuffer,
+			)
+
+			runError := service.Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{"/tmp/example"},
+				InspectionDepth: audit.InspectionDepthFull,
+				Offline:         true,
+				Fix:             true,
+				Unshallow:       testCase.unshallow,
+				DryRun:          testCase.dryRun,
+			})
+			require.NoError(subtest, runError)
+			require.Equal(subtest, []audit.ShallowCloneFinding{{RepositoryPath: "/tmp/example"}}, service.ShallowClones())
+
+			fetchCalls := 0
+			for _, command := range executedCommands {
+				if command == "fetch --unshallow origin" {
+					fetchCalls++
+				}
+			}
+			require.Equal(subtest, testCase.expectedFetchCalls, fetchCalls)
+
+			reconciliationLines := []string{}
+			for _, line := range strings.Split(strings.TrimSpace(errorBuffer.String()), "\n") {
+				if strings.HasPrefix(line, "FIX-") || strings.HasPrefix(line, "MANUAL-FIX:") {
+					reconciliationLines = append(reconciliationLines, line)
+				}
+			}
+			require.Equal(subtest, testCase.expectedReconciliation, reconciliationLines)
+			require.Contains(subtest, errorBuffer.String(), shallowFindingConstant)
+		})
+	}
+}
+
+func TestServicePlanReconciliationsChainsRemoteRewrites(testInstance *testing.T) {
+	errorBuffer := &bytes.Buffer{}
+	service := audit.NewService(
+		stubDiscoverer{repositories: []string{"/tmp/example"}},
+		stubGitManager{branchName: "main", remoteURL: "git@github.com:origin/example.git"},
+		stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+			"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+		}},
+		&hostAwareGitHubResolver{existingRepositories: map[string]struct{}{"ghe.example.com/origin/example": {}}},
+		&bytes.Buffer{},
+		errorBuffer,
+	)
+	service.SetGitHubHost("ghe.example.com")
+
+	inspections, discoveryError := service.DiscoverInspections(context.Background(), []string{"/tmp"}, false, false, audit.InspectionDepthMinimal)
+	require.NoError(testInstance, discoveryError)
+
+	actions := service.PlanReconciliations(inspections, audit.RemoteProtocolHTTPS)
+	require.Equal(testInstance, []audit.ReconciliationAction{
+		{
+			Type:           audit.ReconciliationActionRemoteHost,
+			RepositoryPath: "/tmp/example",
+			RemoteURL:      "git@ghe.example.com:origin/example.git",
+			Detail:         "git -C /tmp/example remote set-url origin git@ghe.example.com:origin/example.git",
+		},
+		{
+			Type:           audit.ReconciliationActionRemoteProtocol,
+			RepositoryPath: "/tmp/example",
+			RemoteURL:      "https://ghe.example.com/origin/example.git",
+			Detail:         "git -C /tmp/example remote set-url origin https://ghe.example.com/origin/example.git",
+		},
+	}, actions)
+}
+
+func TestServiceRunFixLeavesHostRewritesForManualHandling(testInstance *testing.T) {
+	updatedRemotes := []string{}
+	errorBuffer := &bytes.Buffer{}
+	service := audit.NewService(
+		stubDiscoverer{repositories: []string{"/tmp/example"}},
+		recordingRemoteGitManager{
+			stubGitManager: stubGitManager{branchName: "main", remoteURL: "git@github.com:origin/example.git"},
+			updatedRemotes: &updatedRemotes,
+		},
+		stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+			"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+		}},
+		&hostAwareGitHubResolver{existingRepositories: map[string]struct{}{"ghe.example.com/origin/example": {}}},
+		&bytes.Buffer{},
+		errorBuffer,
+	)
+	service.SetGitHubHost("ghe.example.com")
+
+	runError := service.Run(context.Background(), audit.CommandOptions{
+		Roots:           []string{"/tmp"},
+		InspectionDepth: audit.InspectionDepthMinimal,
+		Fix:             true,
+		FixProtocol:     audit.RemoteProtocolHTTPS,
+	})
+	require.NoError(testInstance, runError)
+	require.Empty(testInstance, updatedRemotes)
+
+	reconciliationLines := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(errorBuffer.String()), "\n") {
+		if strings.HasPrefix(line, "FIX-") || strings.HasPrefix(line, "MANUAL-FIX:") {
+			reconciliationLines = append(reconciliationLines, line)
+		}
+	}
+	require.Equal(testInstance, []string{
+		"MANUAL-FIX: remote-host /tmp/example: git -C /tmp/example remote set-url origin git@ghe.example.com:origin/example.git",
+		"MANUAL-FIX: remote-protocol /tmp/example: git -C /tmp/example remote set-url origin https://ghe.example.com/origin/example.git",
+	}, reconciliationLines)
+}
+
+func TestServicePlanReconciliationsAlignsPushURL(testInstance *testing.T) {
+	service := audit.NewService(
+		stubDiscoverer{repositories: []string{"/tmp/example"}},
+		stubGitManager{branchName: "main", remoteURL: "https://github.com/origin/example.git"},
+		stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+			"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+			"remote get-url --push origin":    {StandardOutput: "git@github.com:fork/example.git\n"},
+		}},
+		stubGitHubResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "origin/example", DefaultBranch: "main"}},
+		&bytes.Buffer{},
+		&bytes.Buffer{},
+	)
+
+	inspections, discoveryError := service.DiscoverInspections(context.Background(), []string{"/tmp"}, false, false, audit.InspectionDepthFull)
+	require.NoError(testInstance, discoveryError)
+
+	actions := service.PlanReconciliations(inspections, audit.RemoteProtocolSSH)
+	require.Equal(testInstance, []audit.ReconciliationAction{
+		{
+			Type:           audit.ReconciliationActionRemoteProtocol,
+			RepositoryPath: "/tmp/example",
+			RemoteURL:      "ssh://git@github.com/origin/example.git",
+			Detail:         "git -C /tmp/example remote set-url origin ssh://git@github.com/origin/example.git",
+		},
+		{
+			Type:           audit.ReconciliationActionRemotePushURL,
+			RepositoryPath: "/tmp/example",
+			RemoteURL:      "ssh://git@github.com/origin/example.git",
+			Detail:         "git -C /tmp/example remote set-url --push origin ssh://git@github.com/origin/example.git",
+		},
+	}, actions)
+}
+
+func nilIfEmpty(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
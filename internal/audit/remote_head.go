@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+const staleRemoteHeadFindingTemplateConstant = "STALE-REMOTE-HEAD: %s origin/HEAD points to %s (from %s) but the remote default branch is %s; refresh with: git -C %s remote set-head origin --auto\n"
+
+// StaleRemoteHead describes a clone whose locally recorded origin/HEAD disagrees with the authoritative remote default branch.
+type StaleRemoteHead struct {
+	RepositoryPath      string
+	LocalRemoteHead     string
+	LocalSource         gitrepo.RemoteHeadSource
+	RemoteDefaultBranch string
+}
+
+// StaleRemoteHeads returns the stale origin/HEAD findings detected by the most recent DiscoverInspections call.
+func (service *Service) StaleRemoteHeads() []StaleRemoteHead {
+	return service.staleRemoteHeads
+}
+
+// ReportStaleRemoteHeads writes each stale origin/HEAD finding and its refresh command to the error writer.
+func (service *Service) ReportStaleRemoteHeads() {
+	if service.errorWriter == nil {
+		return
+	}
+	for _, staleHead := range service.staleRemoteHeads {
+		fmt.Fprintf(
+			service.errorWriter,
+			staleRemoteHeadFindingTemplateConstant,
+			staleHead.RepositoryPath,
+			staleHead.LocalRemoteHead,
+			staleHead.LocalSource,
+			staleHead.RemoteDefaultBranch,
+			staleHead.RepositoryPath,
+		)
+	}
+}
+
+func (service *Service) resolveDefaultBranchFromGit(executionContext context.Context, repositoryPath string) string {
+	remoteHead, resolveError := service.resolveRemoteHead(executionContext, repositoryPath, gitrepo.RemoteHeadOptions{})
+	if resolveError != nil {
+		return ""
+	}
+	return remoteHead.Branch
+}
+
+func (service *Service) recordStaleRemoteHead(executionContext context.Context, repositoryPath string, remoteDefaultBranch string) {
+	remoteHead, resolveError := service.resolveRemoteHead(executionContext, repositoryPath, gitrepo.RemoteHeadOptions{Offline: true})
+	if resolveError != nil || !remoteHead.Source.Local() || strings.EqualFold(remoteHead.Branch, remoteDefaultBranch) {
+		return
+	}
+	service.staleRemoteHeads = append(service.staleRemoteHeads, StaleRemoteHead{
+		RepositoryPath:      repositoryPath,
+		LocalRemoteHead:     remoteHead.Branch,
+		LocalSource:         remoteHead.Source,
+		RemoteDefaultBranch: remoteDefaultBranch,
+	})
+}
+
+func (service *Service) resolveRemoteHead(executionContext context.Context, repositoryPath string, options gitrepo.RemoteHeadOptions) (gitrepo.RemoteHead, error) {
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(service.gitExecutor)
+	if managerError != nil {
+		return gitrepo.RemoteHead{}, managerError
+	}
+	return repositoryManager.ResolveRemoteHead(executionContext, repositoryPath, shared.OriginRemoteNameConstant, options)
+}
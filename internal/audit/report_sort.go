@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const reportSortOrderInvalidTemplateConstant = "unsupported audit sort order %q (expected path, owner, activity, issues, or score)"
+
+// ReportSortOrder controls the order of rows in the audit report.
+type ReportSortOrder string
+
+// Supported report sort orders.
+const (
+	// ReportSortPath orders rows by repository path.
+	ReportSortPath ReportSortOrder = "path"
+	// ReportSortOwner orders rows by owner/repository, then path.
+	ReportSortOwner ReportSortOrder = "owner"
+	// ReportSortActivity places the least recently active repositories first; repositories without commits lead.
+	ReportSortActivity ReportSortOrder = "activity"
+	// ReportSortIssues places the repositories with the most "no" findings first.
+	ReportSortIssues ReportSortOrder = "issues"
+	// ReportSortScore places the repositories with the highest health score first.
+	ReportSortScore ReportSortOrder = "score"
+)
+
+// ParseReportSortOrder normalizes a textual sort order. Blank values select ReportSortPath.
+func ParseReportSortOrder(value string) (ReportSortOrder, error) {
+	normalizedValue := ReportSortOrder(strings.ToLower(strings.TrimSpace(value)))
+	switch normalizedValue {
+	case "":
+		return ReportSortPath, nil
+	case ReportSortPath, ReportSortOwner, ReportSortActivity, ReportSortIssues, ReportSortScore:
+		return normalizedValue, nil
+	default:
+		return "", fmt.Errorf(reportSortOrderInvalidTemplateConstant, value)
+	}
+}
+
+// IssueCount reports how many of the name, sync, and canonical-origin checks answered "no" for the inspection.
+func (inspection RepositoryInspection) IssueCount() int {
+	row := inspectionReportRow(inspection)
+	issueCount := 0
+	for _, value := range []TernaryValue{row.NameMatches, row.InSync, row.OriginMatchesCanonical} {
+		if value == TernaryValueNo {
+			issueCount++
+		}
+	}
+	return issueCount
+}
+
+// SortInspections reorders inspections in place. An empty order keeps discovery order; ties always fall back to path.
+func SortInspections(inspections []RepositoryInspection, order ReportSortOrder) {
+	if len(order) == 0 {
+		return
+	}
+
+	sort.SliceStable(inspections, func(firstIndex int, secondIndex int) bool {
+		first := inspections[firstIndex]
+		second := inspections[secondIndex]
+		switch order {
+		case ReportSortOwner:
+			firstOwner := strings.ToLower(reportOwnerRepository(first))
+			secondOwner := strings.ToLower(reportOwnerRepository(second))
+			if firstOwner != secondOwner {
+				if len(firstOwner) == 0 || len(secondOwner) == 0 {
+					return len(secondOwner) == 0
+				}
+				return firstOwner < secondOwner
+			}
+		case ReportSortActivity:
+			firstRank, secondRank := activitySortRank(first), activitySortRank(second)
+			if firstRank != secondRank {
+				return firstRank < secondRank
+			}
+			if !first.LastActivity.LastCommit.Equal(second.LastActivity.LastCommit) {
+				return first.LastActivity.LastCommit.Before(second.LastActivity.LastCommit)
+			}
+		case ReportSortIssues:
+			firstIssues, secondIssues := first.IssueCount(), second.IssueCount()
+			if firstIssues != secondIssues {
+				return firstIssues > secondIssues
+			}
+		case ReportSortScore:
+			if first.HealthScore.Scored != second.HealthScore.Scored {
+				return first.HealthScore.Scored
+			}
+			if first.HealthScore.Score != second.HealthScore.Score {
+				return first.HealthScore.Score > second.HealthScore.Score
+			}
+		}
+		return first.Path < second.Path
+	})
+}
+
+func reportOwnerRepository(inspection RepositoryInspection) string {
+	if !inspection.IsGitRepository {
+		return ""
+	}
+	if len(strings.TrimSpace(inspection.CanonicalOwnerRepo)) > 0 {
+		return inspection.CanonicalOwnerRepo
+	}
+	return inspection.OriginOwnerRepo
+}
+
+func activitySortRank(inspection RepositoryInspection) int {
+	switch {
+	case !inspection.IsGitRepository || !inspection.LastActivity.Inspected:
+		return 2
+	case !inspection.LastActivity.HasCommits():
+		return 0
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,124 @@
+package audit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+)
+
+func TestSortInspections(testInstance *testing.T) {
+	olderCommit := time.Date(2023, time.January, 5, 0, 0, 0, 0, time.UTC)
+	newerCommit := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	inspections := []audit.RepositoryInspection{
+		{
+			Path:                   "/src/alpha",
+			FolderName:             "alpha",
+			CanonicalOwnerRepo:     "zeta/alpha",
+			DesiredFolderName:      "alpha",
+			InSyncStatus:           audit.TernaryValueYes,
+			OriginMatchesCanonical: audit.TernaryValueYes,
+			LastActivity:           audit.CommitActivity{Inspected: true, LastCommit: newerCommit},
+			IsGitRepository:        true,
+		},
+		{
+			Path:                   "/src/beta",
+			FolderName:             "beta",
+			OriginOwnerRepo:        "acme/renamed",
+			DesiredFolderName:      "renamed",
+			InSyncStatus:           audit.TernaryValueNo,
+			OriginMatchesCanonical: audit.TernaryValueNo,
+			LastActivity:           audit.CommitActivity{Inspected: true, LastCommit: olderCommit},
+			IsGitRepository:        true,
+		},
+		{
+			Path:            "/src/gamma",
+			FolderName:      "gamma",
+			IsGitRepository: false,
+		},
+		{
+			Path:                   "/src/delta",
+			FolderName:             "delta",
+			CanonicalOwnerRepo:     "Acme/delta",
+			DesiredFolderName:      "delta",
+			InSyncStatus:           audit.TernaryValueNotApplicable,
+			OriginMatchesCanonical: audit.TernaryValueNo,
+			LastActivity:           audit.CommitActivity{Inspected: true},
+			IsGitRepository:        true,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		order         audit.ReportSortOrder
+		expectedPaths []string
+	}{
+		{
+			name:          "discovery_order",
+			expectedPaths: []string{"/src/alpha", "/src/beta", "/src/gamma", "/src/delta"},
+		},
+		{
+			name:          "path",
+			order:         audit.ReportSortPath,
+			expectedPaths: []string{"/src/alpha", "/src/beta", "/src/delta", "/src/gamma"},
+		},
+		{
+			name:          "owner",
+			order:         audit.ReportSortOwner,
+			expectedPaths: []string{"/src/delta", "/src/beta", "/src/alpha", "/src/gamma"},
+		},
+		{
+			name:          "activity",
+			order:         audit.ReportSortActivity,
+			expectedPaths: []string{"/src/delta", "/src/beta", "/src/alpha", "/src/gamma"},
+		},
+		{
+			name:          "issues",
+			order:         audit.ReportSortIssues,
+			expectedPaths: []string{"/src/beta", "/src/delta", "/src/alpha", "/src/gamma"},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			sorted := append([]audit.RepositoryInspection(nil), inspections...)
+			audit.SortInspections(sorted, testCase.order)
+
+			sortedPaths := make([]string, 0, len(sorted))
+			for _, inspection := range sorted {
+				sortedPaths = append(sortedPaths, inspection.Path)
+			}
+			require.Equal(subtest, testCase.expectedPaths, sortedPaths)
+		})
+	}
+}
+
+func TestParseReportSortOrder(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expectedOrder audit.ReportSortOrder
+		expectedError string
+	}{
+		{name: "blank_defaults_to_path", value: " ", expectedOrder: audit.ReportSortPath},
+		{name: "case_insensitive", value: "Activity", expectedOrder: audit.ReportSortActivity},
+		{name: "issues", value: "issues", expectedOrder: audit.ReportSortIssues},
+		{name: "unsupported", value: "stars", expectedError: `unsupported audit sort order "stars" (expected path, owner, activity, issues, or score)`},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			order, parseError := audit.ParseReportSortOrder(testCase.value)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, parseError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, parseError)
+			require.Equal(subtest, testCase.expectedOrder, order)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/repos/discovery"
 	"github.com/temirov/gix/internal/repos/shared"
 )
 
@@ -26,6 +27,27 @@ type Service struct {
 	githubClient GitHubMetadataResolver
 	outputWriter io.Writer
 	errorWriter  io.Writer
+
+	disabledCategories map[CheckCategory]struct{}
+	containment        discovery.Containment
+
+	githubHost                 string
+	hostMismatchCandidates     []hostMismatchCandidate
+	hostMismatches             []HostMismatch
+	hostCheckFailures          []HostCheckFailure
+	staleRemoteHeads           []StaleRemoteHead
+	pushURLMismatches          []PushURLMismatch
+	inProgressOperations       []InProgressOperationFinding
+	shallowClones              []ShallowCloneFinding
+	untrustedOwnership         []UntrustedOwnershipFinding
+	reportedUntrustedOwnership map[string]struct{}
+	scoreWeights               ScoreWeights
+	identityRules              map[string]IdentityRule
+	identityViolations         []IdentityViolation
+	includeBareRepositories    bool
+
+	duplicateCloneCandidates []duplicateCloneCandidate
+	duplicateClones          []DuplicateCloneGroup
 }
 
 // NewService constructs a Service using the provided dependencies.
@@ -47,15 +69,77 @@ func (service *Service) Run(executionContext context.Context, options CommandOpt
 		return errors.New(missingRootsErrorMessageConstant)
 	}
 
+	if options.Offline {
+		service.DisableCheckCategory(CheckCategoryRemote)
+	}
+	if len(strings.TrimSpace(options.GitHubHost)) > 0 {
+		service.SetGitHubHost(options.GitHubHost)
+	}
+	service.SetIncludeBareRepositories(options.IncludeBare)
+
 	inspections, inspectionError := service.DiscoverInspections(executionContext, roots, options.IncludeAllFolders, options.DebugOutput, options.InspectionDepth)
 	if inspectionError != nil {
+		if executionContext.Err() == nil || len(inspections) == 0 {
+			return inspectionError
+		}
+		if reportError := service.writeInspectionReport(inspections, options); reportError != nil {
+			return reportError
+		}
 		return inspectionError
 	}
 
-	return service.writeAuditReport(inspections)
+	if options.DuplicatesOnly {
+		writeDuplicateClones(service.outputWriter, service.duplicateClones)
+		return nil
+	}
+
+	if reportError := service.writeInspectionReport(inspections, options); reportError != nil {
+		return reportError
+	}
+
+	if options.Fix {
+		if reconcileError := service.Reconcile(executionContext, inspections, ReconciliationOptions{TargetProtocol: options.FixProtocol, DryRun: options.DryRun, Unshallow: options.Unshallow}); reconcileError != nil {
+			return reconcileError
+		}
+	}
+
+	if options.ReportFormat.Structured() {
+		return service.NestedRepositoriesError(options.FailOnNested)
+	}
+	return service.ReportContainment(options.FailOnNested)
 }
 
-// DiscoverInspections collects repository inspections for the provided roots.
+// Containment returns the repository nesting detected by the most recent DiscoverInspections call.
+func (service *Service) Containment() discovery.Containment {
+	return service.containment
+}
+
+// ReportContainment writes each nested repository pair as an informational finding to the error writer.
+// When failOnNested is set and nesting was detected it returns ErrNestedRepositoriesDetected.
+func (service *Service) ReportContainment(failOnNested bool) error {
+	relationships := service.containment.Relationships
+	if service.errorWriter != nil {
+		for _, relationship := range relationships {
+			fmt.Fprintf(service.errorWriter, nestedRepositoryFindingTemplate, relationship.ChildPath, relationship.ParentPath)
+		}
+	}
+
+	return service.NestedRepositoriesError(failOnNested)
+}
+
+// NestedRepositoriesError returns ErrNestedRepositoriesDetected when failOnNested is set and nesting was detected,
+// without writing any findings.
+func (service *Service) NestedRepositoriesError(failOnNested bool) error {
+	relationships := service.containment.Relationships
+	if failOnNested && len(relationships) > 0 {
+		return fmt.Errorf(nestedRepositoriesErrorTemplate, ErrNestedRepositoriesDetected, len(relationships))
+	}
+	return nil
+}
+
+// DiscoverInspections collects repository inspections for the provided roots. When the execution context ends
+// mid-discovery it returns the inspections completed so far together with the context error, so callers can still
+// report them.
 func (service *Service) DiscoverInspections(executionContext context.Context, roots []string, includeAll bool, debug bool, inspectionDepth InspectionDepth) ([]RepositoryInspection, error) {
 	normalizedDepth := normalizeInspectionDepth(inspectionDepth)
 
@@ -68,12 +152,32 @@ func (service *Service) DiscoverInspections(executionContext context.Context, ro
 	if discoveryError != nil {
 		return nil, discoveryError
 	}
+	if service.includeBareRepositories {
+		bareRepositories, bareDiscoveryError := discovery.DiscoverBareRepositories(roots)
+		if bareDiscoveryError != nil {
+			return nil, bareDiscoveryError
+		}
+		repositories = append(repositories, bareRepositories...)
+	}
 
 	normalizedRepositories, normalizationError := normalizeRepositoryPaths(repositories)
 	if normalizationError != nil {
 		return nil, normalizationError
 	}
 
+	service.containment = discovery.DetectContainment(normalizedRepositories)
+	service.hostMismatchCandidates = nil
+	service.hostMismatches = nil
+	service.hostCheckFailures = nil
+	service.staleRemoteHeads = nil
+	service.pushURLMismatches = nil
+	service.inProgressOperations = nil
+	service.shallowClones = nil
+	service.untrustedOwnership = nil
+	service.identityViolations = nil
+	service.duplicateCloneCandidates = nil
+	service.duplicateClones = nil
+
 	if debug {
 		fmt.Fprintf(service.errorWriter, debugDiscoveredTemplate, len(repositories), strings.Join(roots, " "))
 	}
@@ -92,9 +196,13 @@ func (service *Service) DiscoverInspections(executionContext context.Context, ro
 		candidatePaths = mergeCandidatePaths(candidatePaths, expandedCandidates)
 	}
 
-	inspections := make([]RepositoryInspection, 0, len(candidatePaths))
+	localInspections := make([]RepositoryInspection, 0, len(candidatePaths))
 
 	for _, repositoryPath := range candidatePaths {
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			service.applyHealthScores(localInspections)
+			return localInspections, cancellationError
+		}
 		if includeAll && isPathWithinRepository(repositoryPath, repositoryRootSet) {
 			continue
 		}
@@ -104,29 +212,113 @@ func (service *Service) DiscoverInspections(executionContext context.Context, ro
 
 		folderName := relativeFolderName(repositoryPath, normalizedRoots)
 
-		if !service.isGitRepository(executionContext, repositoryPath) {
-			if includeAll {
-				inspections = append(inspections, buildNonRepositoryInspection(repositoryPath, folderName))
-			}
+		isBare := discovery.IsBareRepository(repositoryPath)
+		if isBare && !service.includeBareRepositories {
 			continue
 		}
+		if !isBare {
+			isRepository, repositoryCheckError := service.isGitRepository(executionContext, repositoryPath)
+			if repositoryCheckError != nil {
+				return nil, repositoryCheckError
+			}
+			if !isRepository {
+				if includeAll && !service.HasUntrustedOwnership(repositoryPath) {
+					localInspections = append(localInspections, buildNonRepositoryInspection(repositoryPath, folderName))
+				}
+				continue
+			}
+		}
 
-		inspection, inspectError := service.inspectRepository(executionContext, repositoryPath, normalizedDepth)
+		inspection, inspectError := service.inspectLocal(executionContext, repositoryPath, normalizedDepth, isBare)
 		if inspectError != nil {
+			if execshell.IsExecutableNotFound(inspectError) {
+				return nil, inspectError
+			}
+			service.recordUntrustedOwnership(repositoryPath, inspectError)
+			continue
+		}
+
+		inspection.FolderName = folderName
+		localInspections = append(localInspections, inspection)
+	}
+
+	if verificationError := service.verifyHostMismatches(executionContext); verificationError != nil {
+		return nil, verificationError
+	}
+
+	if duplicateError := service.detectDuplicateClones(executionContext); duplicateError != nil {
+		return nil, duplicateError
+	}
+
+	service.prefetchRemoteMetadata(executionContext, localInspections)
+
+	inspections := make([]RepositoryInspection, 0, len(localInspections))
+	for inspectionIndex := range localInspections {
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			service.applyHealthScores(inspections)
+			return inspections, cancellationError
+		}
+		inspection := localInspections[inspectionIndex]
+		if !inspection.IsGitRepository {
+			inspections = append(inspections, inspection)
 			continue
 		}
 
-		if inspection.IsGitRepository && len(inspection.OriginOwnerRepo) == 0 && len(inspection.CanonicalOwnerRepo) == 0 {
+		remoteError := service.completeInspection(executionContext, &inspection, normalizedDepth)
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			service.applyHealthScores(inspections)
+			return inspections, cancellationError
+		}
+		if remoteError != nil {
+			if execshell.IsExecutableNotFound(remoteError) {
+				return nil, remoteError
+			}
+			continue
+		}
+
+		if len(inspection.OriginOwnerRepo) == 0 && len(inspection.CanonicalOwnerRepo) == 0 {
 			continue
 		}
 
-		inspection.FolderName = folderName
 		inspections = append(inspections, inspection)
 	}
 
+	service.applyHealthScores(inspections)
 	return inspections, nil
 }
 
+// writeInspectionReport sorts and filters the inspections and writes them in the requested report format.
+func (service *Service) writeInspectionReport(inspections []RepositoryInspection, options CommandOptions) error {
+	SortInspections(inspections, options.SortOrder)
+	inspections = FilterInspectionsByScore(inspections, options.MinimumScore)
+
+	switch options.ReportFormat {
+	case ReportFormatMarkdown:
+		return WriteMarkdownReport(service.outputWriter, service.MarkdownReport(inspections))
+	case ReportFormatJSON:
+		return WriteJSONReport(service.outputWriter, inspections, service.ScoreWeights())
+	default:
+		return service.writeCSVAuditWithFindings(inspections)
+	}
+}
+
+// writeCSVAuditWithFindings writes the CSV report followed by the findings of the most recent discovery.
+func (service *Service) writeCSVAuditWithFindings(inspections []RepositoryInspection) error {
+	if reportError := service.writeAuditReport(inspections); reportError != nil {
+		return reportError
+	}
+
+	service.ReportHostMismatches()
+	service.ReportStaleRemoteHeads()
+	service.ReportPushURLMismatches()
+	service.ReportInProgressOperations()
+	service.ReportShallowClones()
+	service.ReportUntrustedOwnership()
+	service.ReportIdentityViolations()
+	service.ReportDuplicateClones()
+	return nil
+}
+
 func (service *Service) writeAuditReport(inspections []RepositoryInspection) error {
 	csvWriter := csv.NewWriter(service.outputWriter)
 	header := []string{
@@ -138,6 +330,10 @@ func (service *Service) writeAuditReport(inspections []RepositoryInspection) err
 		csvHeaderInSync,
 		csvHeaderRemoteProtocol,
 		csvHeaderOriginCanonical,
+		csvHeaderLastActivity,
+		csvHeaderDeleteBranchOnMerge,
+		csvHeaderMergeQueue,
+		csvHeaderHealthScore,
 	}
 	if writeError := csvWriter.Write(header); writeError != nil {
 		return writeError
@@ -197,28 +393,77 @@ func normalizeInspectionDepth(depth InspectionDepth) InspectionDepth {
 	}
 }
 
-func (service *Service) isGitRepository(executionContext context.Context, repositoryPath string) bool {
+func (service *Service) isGitRepository(executionContext context.Context, repositoryPath string) (bool, error) {
 	commandDetails := execshell.CommandDetails{
 		Arguments:        []string{gitRevParseSubcommandConstant, gitIsInsideWorkTreeFlagConstant},
 		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
 	}
 
 	executionResult, executionError := service.gitExecutor.ExecuteGit(executionContext, commandDetails)
 	if executionError != nil {
-		return false
+		if execshell.IsExecutableNotFound(executionError) {
+			return false, executionError
+		}
+		service.recordUntrustedOwnership(repositoryPath, executionError)
+		return false, nil
 	}
 
-	return strings.TrimSpace(executionResult.StandardOutput) == gitTrueOutputConstant
+	return strings.TrimSpace(executionResult.StandardOutput) == gitTrueOutputConstant, nil
+}
+
+func (service *Service) prefetchRemoteMetadata(executionContext context.Context, inspections []RepositoryInspection) {
+	if !service.CheckCategoryEnabled(CheckCategoryRemote) {
+		return
+	}
+	prefetcher, supportsPrefetch := service.githubClient.(shared.GitHubMetadataPrefetcher)
+	if !supportsPrefetch {
+		return
+	}
+
+	repositories := make([]string, 0, len(inspections))
+	for inspectionIndex := range inspections {
+		if len(inspections[inspectionIndex].OriginOwnerRepo) > 0 {
+			repositories = append(repositories, inspections[inspectionIndex].OriginOwnerRepo)
+		}
+	}
+	if len(repositories) == 0 {
+		return
+	}
+	prefetcher.PrefetchRepoMetadata(executionContext, repositories)
 }
 
-func (service *Service) inspectRepository(executionContext context.Context, repositoryPath string, inspectionDepth InspectionDepth) (RepositoryInspection, error) {
-	folderName := filepath.Base(repositoryPath)
+func (service *Service) completeInspection(executionContext context.Context, inspection *RepositoryInspection, inspectionDepth InspectionDepth) error {
+	if !service.CheckCategoryEnabled(CheckCategoryRemote) {
+		offlinePlaceholder := string(TernaryValueOffline)
+		inspection.RemoteDefaultBranch = offlinePlaceholder
+		inspection.InSyncStatus = TernaryValueOffline
+		inspection.OriginMatchesCanonical = TernaryValueOffline
+		inspection.DeleteBranchOnMerge = TernaryValueOffline
+		inspection.MergeQueue = TernaryValueOffline
+		return nil
+	}
+
+	return service.inspectRemote(executionContext, inspection, inspectionDepth)
+}
 
+func (service *Service) inspectLocal(executionContext context.Context, repositoryPath string, inspectionDepth InspectionDepth, bare bool) (RepositoryInspection, error) {
 	originURL, originError := service.gitManager.GetRemoteURL(executionContext, repositoryPath, shared.OriginRemoteNameConstant)
 	if originError != nil {
 		return RepositoryInspection{}, originError
 	}
 
+	service.recordHostMismatchCandidate(repositoryPath, originURL)
+	if !bare {
+		service.recordDuplicateCloneCandidate(repositoryPath, originURL)
+	}
+	if inspectionDepth == InspectionDepthFull && !bare {
+		service.recordInProgressOperation(executionContext, repositoryPath)
+	}
+	if inspectionDepth == InspectionDepthFull {
+		service.recordShallowClone(executionContext, repositoryPath)
+	}
+
 	if !strings.Contains(strings.ToLower(originURL), githubHostConstant) {
 		return RepositoryInspection{}, errors.New(notGitHubRemoteMessageConstant)
 	}
@@ -228,54 +473,85 @@ func (service *Service) inspectRepository(executionContext context.Context, repo
 		originOwnerRepo = ""
 	}
 
-	remoteProtocol := detectRemoteProtocol(originURL)
+	localBranch := ""
+	lastActivity := CommitActivity{}
+	originPushURL := ""
+	originPushOwnerRepo := ""
+	if inspectionDepth == InspectionDepthFull {
+		originPushURL, originPushOwnerRepo = service.inspectPushURL(executionContext, repositoryPath, originURL, originOwnerRepo)
+		if !bare {
+			if identityError := service.recordIdentityViolation(executionContext, repositoryPath, originOwnerRepo); identityError != nil {
+				return RepositoryInspection{}, identityError
+			}
+			branchName, localBranchError := service.gitManager.GetCurrentBranch(executionContext, repositoryPath)
+			if localBranchError == nil {
+				localBranch = sanitizeBranchName(branchName)
+			}
+		}
+		activity, activityError := service.inspectCommitActivity(executionContext, repositoryPath)
+		if activityError != nil {
+			return RepositoryInspection{}, activityError
+		}
+		lastActivity = activity
+	}
 
+	return RepositoryInspection{
+		Path:                   repositoryPath,
+		FolderName:             filepath.Base(repositoryPath),
+		OriginURL:              originURL,
+		OriginOwnerRepo:        originOwnerRepo,
+		OriginPushURL:          originPushURL,
+		OriginPushOwnerRepo:    originPushOwnerRepo,
+		FinalOwnerRepo:         originOwnerRepo,
+		DesiredFolderName:      finalRepositoryName(originOwnerRepo),
+		RemoteProtocol:         detectRemoteProtocol(originURL),
+		LocalBranch:            localBranch,
+		InSyncStatus:           TernaryValueNotApplicable,
+		OriginMatchesCanonical: TernaryValueNotApplicable,
+		LastActivity:           lastActivity,
+		DeleteBranchOnMerge:    TernaryValueNotApplicable,
+		MergeQueue:             TernaryValueNotApplicable,
+		IsGitRepository:        true,
+		IsBare:                 bare,
+	}, nil
+}
+
+func (service *Service) inspectRemote(executionContext context.Context, inspection *RepositoryInspection, inspectionDepth InspectionDepth) error {
 	canonicalOwnerRepo := ""
 	remoteDefaultBranch := ""
 	if service.githubClient != nil {
-		metadata, metadataError := service.githubClient.ResolveRepoMetadata(executionContext, originOwnerRepo)
+		metadata, metadataError := service.githubClient.ResolveRepoMetadata(executionContext, inspection.OriginOwnerRepo)
+		if execshell.IsExecutableNotFound(metadataError) {
+			return metadataError
+		}
 		if metadataError == nil {
 			canonicalOwnerRepo = strings.TrimSpace(metadata.NameWithOwner)
 			remoteDefaultBranch = strings.TrimSpace(metadata.DefaultBranch)
+			inspection.DeleteBranchOnMerge = ternaryFromBool(metadata.DeleteBranchOnMerge)
+			if metadata.MergeQueueKnown {
+				inspection.MergeQueue = ternaryFromBool(metadata.MergeQueueEnabled)
+			}
 		}
 	}
 
 	if len(remoteDefaultBranch) == 0 {
-		remoteDefaultBranch = service.resolveDefaultBranchFromGit(executionContext, repositoryPath)
+		remoteDefaultBranch = service.resolveDefaultBranchFromGit(executionContext, inspection.Path)
+	} else if inspectionDepth == InspectionDepthFull && !inspection.IsBare {
+		service.recordStaleRemoteHead(executionContext, inspection.Path, remoteDefaultBranch)
 	}
 
-	localBranch := ""
-	inSyncStatus := TernaryValueNotApplicable
-	if inspectionDepth == InspectionDepthFull {
-		branchName, localBranchError := service.gitManager.GetCurrentBranch(executionContext, repositoryPath)
-		if localBranchError == nil {
-			sanitizedBranch := sanitizeBranchName(branchName)
-			localBranch = sanitizedBranch
-			inSyncStatus = service.computeInSync(executionContext, repositoryPath, remoteDefaultBranch, sanitizedBranch, remoteProtocol)
-		}
-	}
-
-	finalOwnerRepo := originOwnerRepo
-	if len(strings.TrimSpace(canonicalOwnerRepo)) > 0 {
-		finalOwnerRepo = canonicalOwnerRepo
+	if inspectionDepth == InspectionDepthFull && len(inspection.LocalBranch) > 0 {
+		inspection.InSyncStatus = service.computeInSync(executionContext, inspection.Path, remoteDefaultBranch, inspection.LocalBranch, inspection.RemoteProtocol)
 	}
 
-	inspection := RepositoryInspection{
-		Path:                   repositoryPath,
-		FolderName:             folderName,
-		OriginURL:              originURL,
-		OriginOwnerRepo:        originOwnerRepo,
-		CanonicalOwnerRepo:     canonicalOwnerRepo,
-		FinalOwnerRepo:         finalOwnerRepo,
-		DesiredFolderName:      finalRepositoryName(finalOwnerRepo),
-		RemoteProtocol:         remoteProtocol,
-		RemoteDefaultBranch:    remoteDefaultBranch,
-		LocalBranch:            localBranch,
-		InSyncStatus:           inSyncStatus,
-		OriginMatchesCanonical: matchesCanonical(originOwnerRepo, canonicalOwnerRepo),
-		IsGitRepository:        true,
+	if len(canonicalOwnerRepo) > 0 {
+		inspection.FinalOwnerRepo = canonicalOwnerRepo
+		inspection.DesiredFolderName = finalRepositoryName(canonicalOwnerRepo)
 	}
-	return inspection, nil
+	inspection.CanonicalOwnerRepo = canonicalOwnerRepo
+	inspection.RemoteDefaultBranch = remoteDefaultBranch
+	inspection.OriginMatchesCanonical = matchesCanonical(inspection.OriginOwnerRepo, canonicalOwnerRepo)
+	return nil
 }
 
 func matchesCanonical(origin string, canonical string) TernaryValue {
@@ -288,6 +564,21 @@ func matchesCanonical(origin string, canonical string) TernaryValue {
 	return TernaryValueNo
 }
 
+func ternaryFromBool(value bool) TernaryValue {
+	if value {
+		return TernaryValueYes
+	}
+	return TernaryValueNo
+}
+
+// TernaryOrNotApplicable returns value, or TernaryValueNotApplicable when value was never determined.
+func TernaryOrNotApplicable(value TernaryValue) TernaryValue {
+	if len(value) == 0 {
+		return TernaryValueNotApplicable
+	}
+	return value
+}
+
 func inspectionReportRow(inspection RepositoryInspection) AuditReportRow {
 	finalRepo := inspection.CanonicalOwnerRepo
 	if len(strings.TrimSpace(finalRepo)) == 0 {
@@ -296,8 +587,7 @@ func inspectionReportRow(inspection RepositoryInspection) AuditReportRow {
 	nameMatches := TernaryValueNotApplicable
 	if inspection.IsGitRepository {
 		nameMatches = TernaryValueNo
-		folderBaseName := filepath.Base(inspection.FolderName)
-		if len(inspection.DesiredFolderName) > 0 && inspection.DesiredFolderName == folderBaseName {
+		if folderNameMatchesDesired(inspection) {
 			nameMatches = TernaryValueYes
 		}
 	}
@@ -307,6 +597,12 @@ func inspectionReportRow(inspection RepositoryInspection) AuditReportRow {
 	inSync := inspection.InSyncStatus
 	remoteProtocol := inspection.RemoteProtocol
 	originMatches := inspection.OriginMatchesCanonical
+	lastActivity := inspection.LastActivity.String()
+	deleteBranchOnMerge := TernaryOrNotApplicable(inspection.DeleteBranchOnMerge)
+	mergeQueue := TernaryOrNotApplicable(inspection.MergeQueue)
+	if inspection.IsBare {
+		localBranch = bareRepositoryLocalBranchMarkerConstant
+	}
 
 	if !inspection.IsGitRepository {
 		finalRepo = string(TernaryValueNotApplicable)
@@ -315,6 +611,9 @@ func inspectionReportRow(inspection RepositoryInspection) AuditReportRow {
 		inSync = TernaryValueNotApplicable
 		remoteProtocol = RemoteProtocolType(string(TernaryValueNotApplicable))
 		originMatches = TernaryValueNotApplicable
+		lastActivity = string(TernaryValueNotApplicable)
+		deleteBranchOnMerge = TernaryValueNotApplicable
+		mergeQueue = TernaryValueNotApplicable
 	}
 	return AuditReportRow{
 		FolderName:             inspection.FolderName,
@@ -325,6 +624,10 @@ func inspectionReportRow(inspection RepositoryInspection) AuditReportRow {
 		InSync:                 inSync,
 		RemoteProtocol:         remoteProtocol,
 		OriginMatchesCanonical: originMatches,
+		LastActivity:           lastActivity,
+		DeleteBranchOnMerge:    deleteBranchOnMerge,
+		MergeQueue:             mergeQueue,
+		HealthScore:            inspection.HealthScore.String(),
 	}
 }
 
@@ -426,6 +729,8 @@ func buildNonRepositoryInspection(path string, folderName string) RepositoryInsp
 		InSyncStatus:           TernaryValueNotApplicable,
 		RemoteProtocol:         RemoteProtocolOther,
 		OriginMatchesCanonical: TernaryValueNotApplicable,
+		DeleteBranchOnMerge:    TernaryValueNotApplicable,
+		MergeQueue:             TernaryValueNotApplicable,
 		IsGitRepository:        false,
 	}
 }
@@ -444,37 +749,6 @@ func isPathWithinRepository(path string, repositories map[string]struct{}) bool
 	return false
 }
 
-func (service *Service) resolveDefaultBranchFromGit(executionContext context.Context, repositoryPath string) string {
-	commandDetails := execshell.CommandDetails{
-		Arguments:        lsRemoteHeadArguments(),
-		WorkingDirectory: repositoryPath,
-	}
-
-	executionResult, executionError := service.gitExecutor.ExecuteGit(executionContext, commandDetails)
-	if executionError != nil {
-		return ""
-	}
-
-	lines := strings.Split(executionResult.StandardOutput, "\n")
-	for _, line := range lines {
-		if !strings.HasPrefix(line, "ref:") {
-			continue
-		}
-		components := strings.Split(line, gitReferenceSeparator)
-		if len(components) < 1 {
-			continue
-		}
-		referenceParts := strings.Fields(components[0])
-		if len(referenceParts) < 2 {
-			continue
-		}
-		reference := referenceParts[1]
-		return strings.TrimPrefix(reference, refsHeadsPrefixConstant)
-	}
-
-	return ""
-}
-
 func (service *Service) computeInSync(executionContext context.Context, repositoryPath string, remoteDefaultBranch string, localBranch string, protocol RemoteProtocolType) TernaryValue {
 	if len(remoteDefaultBranch) == 0 || len(localBranch) == 0 || !strings.EqualFold(remoteDefaultBranch, localBranch) {
 		return TernaryValueNotApplicable
@@ -487,6 +761,7 @@ func (service *Service) computeInSync(executionContext context.Context, reposito
 	fetchDetails := execshell.CommandDetails{
 		Arguments:        remoteFetchArguments(remoteDefaultBranch),
 		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
 	}
 
 	if _, fetchError := service.gitExecutor.ExecuteGit(executionContext, fetchDetails); fetchError != nil {
@@ -515,6 +790,7 @@ func (service *Service) resolveUpstreamReference(executionContext context.Contex
 	upstreamDetails := execshell.CommandDetails{
 		Arguments:        upstreamReferenceArguments(),
 		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
 	}
 
 	executionResult, executionError := service.gitExecutor.ExecuteGit(executionContext, upstreamDetails)
@@ -528,6 +804,7 @@ func (service *Service) resolveRevision(executionContext context.Context, reposi
 	commandDetails := execshell.CommandDetails{
 		Arguments:        arguments,
 		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
 	}
 	executionResult, executionError := service.gitExecutor.ExecuteGit(executionContext, commandDetails)
 	if executionError != nil {
@@ -3,17 +3,20 @@ package audit_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/temirov/gix/internal/audit"
 	"github.com/temirov/gix/internal/execshell"
 	"github.com/temirov/gix/internal/githubcli"
+	"github.com/temirov/gix/internal/gitrepo"
 )
 
 const currentDirectoryRelativePathConstant = "."
@@ -51,6 +54,7 @@ type stubGitManager struct {
 	cleanWorktree       bool
 	branchName          string
 	remoteURL           string
+	remoteURLs          map[string]string
 	panicOnBranchLookup bool
 }
 
@@ -66,6 +70,9 @@ func (manager stubGitManager) GetCurrentBranch(ctx context.Context, repositoryPa
 }
 
 func (manager stubGitManager) GetRemoteURL(ctx context.Context, repositoryPath string, remoteName string) (string, error) {
+	if remoteURL, found := manager.remoteURLs[repositoryPath]; found {
+		return remoteURL, nil
+	}
 	return manager.remoteURL, nil
 }
 
@@ -125,6 +132,7 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 			discoverer: stubDiscoverer{repositories: []string{"/tmp/example"}},
 			executorOutputs: map[string]execshell.ExecutionResult{
 				"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+				"log -1 --format=%cI":             {StandardOutput: "2026-03-01T10:00:00+01:00\n"},
 			},
 			gitManager: stubGitManager{
 				cleanWorktree: true,
@@ -133,11 +141,14 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 			},
 			githubResolver: stubGitHubResolver{
 				metadata: githubcli.RepositoryMetadata{
-					NameWithOwner: "canonical/example",
-					DefaultBranch: "main",
+					NameWithOwner:       "canonical/example",
+					DefaultBranch:       "main",
+					DeleteBranchOnMerge: true,
+					MergeQueueEnabled:   true,
+					MergeQueueKnown:     true,
 				},
 			},
-			expectedOutput: "folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical\nexample,canonical/example,yes,main,main,n/a,https,no\n",
+			expectedOutput: "folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,last_activity,delete_branch_on_merge,merge_queue,health_score\nexample,canonical/example,yes,main,main,n/a,https,no,2026-03-01T10:00:00+01:00,yes,yes,3\n",
 			expectedError:  "",
 		},
 		{
@@ -162,7 +173,7 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 					DefaultBranch: "main",
 				},
 			},
-			expectedOutput:       "folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical\nexample,canonical/example,yes,main,,n/a,https,no\n",
+			expectedOutput:       "folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,last_activity,delete_branch_on_merge,merge_queue,health_score\nexample,canonical/example,yes,main,,n/a,https,no,,no,n/a,3\n",
 			expectedError:        "",
 			panicOnUnexpectedGit: true,
 		},
@@ -176,6 +187,7 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 			discoverer: stubDiscoverer{repositories: []string{"/tmp/example"}},
 			executorOutputs: map[string]execshell.ExecutionResult{
 				"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+				"log -1 --format=%cI":             {StandardOutput: "2026-03-01T10:00:00+01:00\n"},
 			},
 			gitManager: stubGitManager{
 				cleanWorktree: true,
@@ -188,7 +200,7 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 					DefaultBranch: "main",
 				},
 			},
-			expectedOutput: "folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical\nexample,canonical/example,yes,main,main,n/a,https,no\n",
+			expectedOutput: "folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,last_activity,delete_branch_on_merge,merge_queue,health_score\nexample,canonical/example,yes,main,main,n/a,https,no,2026-03-01T10:00:00+01:00,no,n/a,3\n",
 			expectedError:  "DEBUG: discovered 1 candidate repos under: /tmp/example\nDEBUG: checking /tmp/example\n",
 		},
 		{
@@ -207,7 +219,7 @@ func TestServiceRunBehaviors(testInstance *testing.T) {
 				branchName:    "main",
 				remoteURL:     "https://github.com/origin/example.git",
 			},
-			expectedOutput: "folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical\nexample,origin/example,yes,main,,n/a,https,n/a\n",
+			expectedOutput: "folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,last_activity,delete_branch_on_merge,merge_queue,health_score\nexample,origin/example,yes,main,,n/a,https,n/a,,n/a,n/a,0\n",
 			expectedError:  "",
 		},
 	}
@@ -280,14 +292,17 @@ func TestServiceRunNormalizesRepositoryPaths(testInstance *testing.T) {
 	require.NoError(testInstance, runError)
 
 	expectedNameMatches := "no"
+	expectedHealthScore := "4"
 	if repositoryFolderName == "example" {
 		expectedNameMatches = "yes"
+		expectedHealthScore = "3"
 	}
 
 	expectedCSVOutput := fmt.Sprintf(
-		"folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical\n%s,canonical/example,%s,main,,n/a,https,no\n",
+		"folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,last_activity,delete_branch_on_merge,merge_queue,health_score\n%s,canonical/example,%s,main,,n/a,https,no,,no,n/a,%s\n",
 		repositoryFolderName,
 		expectedNameMatches,
+		expectedHealthScore,
 	)
 	expectedDebugOutput := fmt.Sprintf(
 		"DEBUG: discovered 1 candidate repos under: %s\nDEBUG: checking %s\n",
@@ -343,9 +358,9 @@ func TestServiceRunIncludesAllFolders(testInstance *testing.T) {
 	require.NoError(testInstance, runError)
 
 	expectedOutput := fmt.Sprintf(
-		"folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical\n"+
-			"%s,canonical/example,no,main,,n/a,https,no\n"+
-			"%s,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n",
+		"folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,last_activity,delete_branch_on_merge,merge_queue,health_score\n"+
+			"%s,canonical/example,no,main,,n/a,https,no,,no,n/a,4\n"+
+			"%s,n/a,n/a,n/a,n/a,n/a,n/a,n/a,n/a,n/a,n/a,n/a\n",
 		gitRepositoryFolderName,
 		nonRepositoryFolderName,
 	)
@@ -390,8 +405,791 @@ func TestServiceRunUsesRelativeFolderNames(testInstance *testing.T) {
 	require.NoError(testInstance, runError)
 
 	expectedOutput := fmt.Sprintf(
-		"folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical\n%s,canonical/git-project,yes,main,,n/a,https,no\n",
+		"folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,last_activity,delete_branch_on_merge,merge_queue,health_score\n%s,canonical/git-project,yes,main,,n/a,https,no,,no,n/a,3\n",
 		filepath.ToSlash(relativeFolderPath),
 	)
 	require.Equal(testInstance, expectedOutput, outputBuffer.String())
 }
+
+type missingExecutableGitExecutor struct {
+	invocations *int
+}
+
+func (executor missingExecutableGitExecutor) ExecuteGit(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	*executor.invocations++
+	return execshell.ExecutionResult{}, execshell.ExecutableNotFoundError{Command: execshell.CommandGit, Cause: fmt.Errorf("not found")}
+}
+
+func (executor missingExecutableGitExecutor) ExecuteGitHubCLI(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	*executor.invocations++
+	return execshell.ExecutionResult{}, execshell.ExecutableNotFoundError{Command: execshell.CommandGitHub, Cause: fmt.Errorf("not found")}
+}
+
+func TestServiceDiscoverInspectionsFailsFastOnMissingExecutable(testInstance *testing.T) {
+	repositories := []string{"/tmp/audit-missing-one", "/tmp/audit-missing-two"}
+
+	testCases := []struct {
+		name                string
+		gitExecutorBuilder  func(*int) audit.GitExecutor
+		resolver            stubGitHubResolver
+		expectedCommand     execshell.CommandName
+		expectedInvocations int
+	}{
+		{
+			name: "git_missing",
+			gitExecutorBuilder: func(invocations *int) audit.GitExecutor {
+				return missingExecutableGitExecutor{invocations: invocations}
+			},
+			expectedCommand:     execshell.CommandGit,
+			expectedInvocations: 1,
+		},
+		{
+			name: "github_cli_missing",
+			gitExecutorBuilder: func(*int) audit.GitExecutor {
+				return stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+					"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+				}}
+			},
+			resolver:        stubGitHubResolver{err: execshell.ExecutableNotFoundError{Command: execshell.CommandGitHub}},
+			expectedCommand: execshell.CommandGitHub,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			invocations := 0
+			service := audit.NewService(
+				stubDiscoverer{repositories: repositories},
+				stubGitManager{remoteURL: "https://github.com/origin/example.git", branchName: "main"},
+				testCase.gitExecutorBuilder(&invocations),
+				testCase.resolver,
+				&bytes.Buffer{},
+				&bytes.Buffer{},
+			)
+
+			inspections, discoveryError := service.DiscoverInspections(context.Background(), repositories, false, false, audit.InspectionDepthFull)
+			require.Error(subtest, discoveryError)
+			require.Nil(subtest, inspections)
+
+			var notFoundError execshell.ExecutableNotFoundError
+			require.ErrorAs(subtest, discoveryError, &notFoundError)
+			require.Equal(subtest, testCase.expectedCommand, notFoundError.Command)
+			require.Equal(subtest, testCase.expectedInvocations, invocations)
+		})
+	}
+}
+
+type deadlineGitHubResolver struct {
+	blockedRepository string
+}
+
+func (resolver deadlineGitHubResolver) ResolveRepoMetadata(ctx context.Context, repository string) (githubcli.RepositoryMetadata, error) {
+	if repository == resolver.blockedRepository {
+		<-ctx.Done()
+		return githubcli.RepositoryMetadata{}, ctx.Err()
+	}
+	return githubcli.RepositoryMetadata{NameWithOwner: repository, DefaultBranch: "main"}, nil
+}
+
+func TestServiceRunPrintsInspectionsCollectedBeforeTimeout(testInstance *testing.T) {
+	repositories := []string{"/tmp/audit-timeout/alpha", "/tmp/audit-timeout/beta", "/tmp/audit-timeout/gamma"}
+	outputBuffer := &bytes.Buffer{}
+	service := audit.NewService(
+		stubDiscoverer{repositories: repositories},
+		stubGitManager{
+			cleanWorktree: true,
+			remoteURLs: map[string]string{
+				"/tmp/audit-timeout/alpha": "https://github.com/origin/alpha.git",
+				"/tmp/audit-timeout/beta":  "https://github.com/origin/beta.git",
+				"/tmp/audit-timeout/gamma": "https://github.com/origin/gamma.git",
+			},
+			panicOnBranchLookup: true,
+		},
+		stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+			"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+		}},
+		deadlineGitHubResolver{blockedRepository: "origin/beta"},
+		outputBuffer,
+		&bytes.Buffer{},
+	)
+
+	timeoutContext, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	runError := service.Run(timeoutContext, audit.CommandOptions{
+		Roots:           []string{"/tmp/audit-timeout"},
+		InspectionDepth: audit.InspectionDepthMinimal,
+	})
+	require.ErrorIs(testInstance, runError, context.DeadlineExceeded)
+
+	reportLines := strings.Split(strings.TrimSpace(outputBuffer.String()), "\n")
+	require.Len(testInstance, reportLines, 2)
+	require.True(testInstance, strings.HasPrefix(reportLines[1], "alpha,origin/alpha,"))
+}
+
+func TestServiceDiscoverInspectionsRecordsLastActivity(testInstance *testing.T) {
+	testCases := []struct {
+		name             string
+		outputs          map[string]execshell.ExecutionResult
+		expectedActivity string
+		expectedCommits  bool
+	}{
+		{
+			name:             "committed_repository",
+			outputs:          map[string]execshell.ExecutionResult{"log -1 --format=%cI": {StandardOutput: "2025-11-02T08:30:00-05:00\n"}},
+			expectedActivity: "2025-11-02T08:30:00-05:00",
+			expectedCommits:  true,
+		},
+		{
+			name:             "repository_without_commits",
+			outputs:          map[string]execshell.ExecutionResult{},
+			expectedActivity: "no commits",
+		},
+		{
+			name:             "activity_unavailable",
+			outputs:          map[string]execshell.ExecutionResult{"rev-parse --verify --quiet HEAD": {StandardOutput: "abc123\n"}},
+			expectedActivity: "",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			outputs := map[string]execshell.ExecutionResult{"rev-parse --is-inside-work-tree": {StandardOutput: "true"}}
+			for key, value := range testCase.outputs {
+				outputs[key] = value
+			}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{"/tmp/example"}},
+				stubGitManager{branchName: "main", remoteURL: "https://github.com/origin/example.git"},
+				stubGitExecutor{outputs: outputs},
+				nil,
+				&bytes.Buffer{},
+				&bytes.Buffer{},
+			)
+			service.DisableCheckCategory(audit.CheckCategoryRemote)
+
+			inspections, discoveryError := service.DiscoverInspections(context.Background(), []string{"/tmp/example"}, false, false, audit.InspectionDepthFull)
+			require.NoError(subtest, discoveryError)
+			require.Len(subtest, inspections, 1)
+			require.Equal(subtest, testCase.expectedActivity, inspections[0].LastActivity.String())
+			require.Equal(subtest, testCase.expectedCommits, inspections[0].LastActivity.HasCommits())
+		})
+	}
+}
+
+type failingGitHubResolver struct{}
+
+func (failingGitHubResolver) ResolveRepoMetadata(context.Context, string) (githubcli.RepositoryMetadata, error) {
+	panic("GitHub metadata must not be resolved while offline")
+}
+
+func TestServiceRunOfflineSkipsRemoteChecks(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		inspectionDepth audit.InspectionDepth
+		expectedRow     string
+	}{
+		{
+			name:            "full_depth",
+			inspectionDepth: audit.InspectionDepthFull,
+			expectedRow:     "example,origin/example,yes,n/a (offline),main,n/a (offline),ssh,n/a (offline),2026-03-01T10:00:00Z,n/a (offline),n/a (offline),0\n",
+		},
+		{
+			name:            "minimal_depth",
+			inspectionDepth: audit.InspectionDepthMinimal,
+			expectedRow:     "example,origin/example,yes,n/a (offline),,n/a (offline),ssh,n/a (offline),,n/a (offline),n/a (offline),0\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			rootDirectory := subtest.TempDir()
+			repositoryPath := filepath.Join(rootDirectory, "example")
+
+			outputBuffer := &bytes.Buffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{repositoryPath}},
+				stubGitManager{branchName: "main", remoteURL: "ssh://git@github.com/origin/example.git"},
+				stubGitExecutor{
+					outputs: map[string]execshell.ExecutionResult{
+						"rev-parse --is-inside-work-tree":   {StandardOutput: "true"},
+						"rev-parse --absolute-git-dir":      {StandardOutput: filepath.Join(repositoryPath, ".git")},
+						"rev-parse --is-shallow-repository": {StandardOutput: "false\n"},
+						"log -1 --format=%cI":               {StandardOutput: "2026-03-01T10:00:00Z\n"},
+						"remote get-url --push origin":      {StandardOutput: "ssh://git@github.com/origin/example.git\n"},
+					},
+					panicOnUnexpectedCommand: true,
+				},
+				failingGitHubResolver{},
+				outputBuffer,
+				&bytes.Buffer{},
+			)
+
+			runError := service.Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{rootDirectory},
+				InspectionDepth: testCase.inspectionDepth,
+				Offline:         true,
+			})
+			require.NoError(subtest, runError)
+			require.False(subtest, service.CheckCategoryEnabled(audit.CheckCategoryRemote))
+			require.True(subtest, service.CheckCategoryEnabled(audit.CheckCategoryLocal))
+			require.Equal(
+				subtest,
+				"folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,last_activity,delete_branch_on_merge,merge_queue,health_score\n"+testCase.expectedRow,
+				outputBuffer.String(),
+			)
+		})
+	}
+}
+
+type prefetchingGitHubResolver struct {
+	events []string
+}
+
+func (resolver *prefetchingGitHubResolver) PrefetchRepoMetadata(_ context.Context, repositories []string) {
+	resolver.events = append(resolver.events, "prefetch "+strings.Join(repositories, " "))
+}
+
+func (resolver *prefetchingGitHubResolver) ResolveRepoMetadata(_ context.Context, repository string) (githubcli.RepositoryMetadata, error) {
+	resolver.events = append(resolver.events, "resolve "+repository)
+	return githubcli.RepositoryMetadata{NameWithOwner: repository, DefaultBranch: "main"}, nil
+}
+
+func TestServiceDiscoverInspectionsPrefetchesRemoteMetadata(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		offline        bool
+		expectedEvents []string
+	}{
+		{
+			name:           "online_prefetches_before_lookups",
+			expectedEvents: []string{"prefetch origin/example", "resolve origin/example"},
+		},
+		{
+			name:    "offline_skips_prefetch",
+			offline: true,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			rootDirectory := subtest.TempDir()
+			repositoryPath := filepath.Join(rootDirectory, "example")
+			resolver := &prefetchingGitHubResolver{}
+
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{repositoryPath}},
+				stubGitManager{branchName: "main", remoteURL: "https://github.com/origin/example.git"},
+				stubGitExecutor{
+					outputs: map[string]execshell.ExecutionResult{
+						"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+					},
+				},
+				resolver,
+				&bytes.Buffer{},
+				&bytes.Buffer{},
+			)
+			if testCase.offline {
+				service.DisableCheckCategory(audit.CheckCategoryRemote)
+			}
+
+			inspections, inspectionError := service.DiscoverInspections(context.Background(), []string{rootDirectory}, false, false, audit.InspectionDepthMinimal)
+			require.NoError(subtest, inspectionError)
+			require.Len(subtest, inspections, 1)
+			require.Equal(subtest, testCase.expectedEvents, resolver.events)
+		})
+	}
+}
+
+func TestServiceRunReportsNestedRepositories(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		repositories   []string
+		failOnNested   bool
+		expectedError  error
+		expectedStderr string
+	}{
+		{
+			name:           "nested_pair_reported_informationally",
+			repositories:   []string{"/tmp/outer", "/tmp/outer/inner"},
+			expectedStderr: "NESTED-REPOSITORY: /tmp/outer/inner is inside /tmp/outer\n",
+		},
+		{
+			name:           "nested_pair_fails_when_strict",
+			repositories:   []string{"/tmp/outer", "/tmp/outer/inner"},
+			failOnNested:   true,
+			expectedError:  audit.ErrNestedRepositoriesDetected,
+			expectedStderr: "NESTED-REPOSITORY: /tmp/outer/inner is inside /tmp/outer\n",
+		},
+		{
+			name:         "siblings_pass_when_strict",
+			repositories: []string{"/tmp/outer", "/tmp/other"},
+			failOnNested: true,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			errorBuffer := &bytes.Buffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: testCase.repositories},
+				stubGitManager{
+					branchName: "main",
+					remoteURLs: map[string]string{
+						"/tmp/outer":       "https://github.com/origin/outer.git",
+						"/tmp/outer/inner": "https://github.com/origin/inner.git",
+						"/tmp/other":       "https://github.com/origin/other.git",
+					},
+				},
+				stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+					"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+				}},
+				stubGitHubResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "origin/example", DefaultBranch: "main"}},
+				&bytes.Buffer{},
+				errorBuffer,
+			)
+
+			runError := service.Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{"/tmp"},
+				InspectionDepth: audit.InspectionDepthMinimal,
+				FailOnNested:    testCase.failOnNested,
+			})
+			if testCase.expectedError != nil {
+				require.ErrorIs(subtest, runError, testCase.expectedError)
+			} else {
+				require.NoError(subtest, runError)
+			}
+			require.Equal(subtest, testCase.expectedStderr, errorBuffer.String())
+			require.Len(subtest, service.Containment().Relationships, strings.Count(testCase.expectedStderr, "\n"))
+		})
+	}
+}
+
+type hostAwareGitHubResolver struct {
+	existingRepositories map[string]struct{}
+	lookupError          error
+	requests             []string
+}
+
+func (resolver *hostAwareGitHubResolver) ResolveRepoMetadata(_ context.Context, repository string) (githubcli.RepositoryMetadata, error) {
+	resolver.requests = append(resolver.requests, repository)
+	if resolver.lookupError != nil {
+		return githubcli.RepositoryMetadata{}, resolver.lookupError
+	}
+	if _, exists := resolver.existingRepositories[repository]; !exists {
+		return githubcli.RepositoryMetadata{}, githubcli.GitHubCommandError{StatusCode: 404, Message: fmt.Sprintf("repository %s not found", repository)}
+	}
+	return githubcli.RepositoryMetadata{NameWithOwner: "origin/example", DefaultBranch: "main"}, nil
+}
+
+type sshConfigGitExecutor struct {
+	stubGitExecutor
+	hostnames map[string]string
+}
+
+func (executor sshConfigGitExecutor) ExecuteSSH(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	host := details.Arguments[len(details.Arguments)-1]
+	hostname, known := executor.hostnames[host]
+	if !known {
+		return execshell.ExecutionResult{}, fmt.Errorf("ssh -G %s failed", host)
+	}
+	return execshell.ExecutionResult{StandardOutput: "user git\nhostname " + hostname + "\nport 22\n"}, nil
+}
+
+func TestServiceRunReportsHostMismatches(testInstance *testing.T) {
+	testCases := []struct {
+		name                 string
+		remoteURL            string
+		githubHost           string
+		offline              bool
+		existingRepositories []string
+		sshHostnames         map[string]string
+		lookupError          error
+		expectedStderr       string
+		expectedRemote       string
+	}{
+		{
+			name:                 "github_origin_moved_to_enterprise_https",
+			remoteURL:            "https://github.com/origin/example.git",
+			githubHost:           "ghe.example.com",
+			existingRepositories: []string{"ghe.example.com/origin/example"},
+			expectedStderr:       "WRONG-HOST: /tmp/example origin https://github.com/origin/example.git is on github.com but origin/example exists on ghe.example.com; reconcile with: git -C /tmp/example remote set-url origin https://ghe.example.com/origin/example.git\n",
+			expectedRemote:       "https://ghe.example.com/origin/example.git",
+		},
+		{
+			name:                 "enterprise_origin_moved_to_github_ssh",
+			remoteURL:            "git@ghe.example.com:origin/example.git",
+			githubHost:           "github.com",
+			existingRepositories: []string{"github.com/origin/example"},
+			expectedStderr:       "WRONG-HOST: /tmp/example origin git@ghe.example.com:origin/example.git is on ghe.example.com but origin/example exists on github.com; reconcile with: git -C /tmp/example remote set-url origin git@github.com:origin/example.git\n",
+			expectedRemote:       "git@github.com:origin/example.git",
+		},
+		{
+			name:                 "ssh_origin_checked_by_connected_host",
+			remoteURL:            "git@ghe.example.com:origin/example.git",
+			githubHost:           "github.com",
+			existingRepositories: []string{"github.com/origin/example"},
+			sshHostnames:         map[string]string{"ghe.example.com": "ghe.example.com"},
+			expectedStderr:       "WRONG-HOST: /tmp/example origin git@ghe.example.com:origin/example.git is on ghe.example.com but origin/example exists on github.com; reconcile with: git -C /tmp/example remote set-url origin git@github.com:origin/example.git\n",
+			expectedRemote:       "git@github.com:origin/example.git",
+		},
+		{
+			name:                 "ssh_alias_of_configured_host",
+			remoteURL:            "git@github-work:origin/example.git",
+			githubHost:           "github.com",
+			existingRepositories: []string{"github.com/origin/example"},
+			sshHostnames:         map[string]string{"github-work": "github.com"},
+		},
+		{
+			name:                 "ssh_alias_of_other_host",
+			remoteURL:            "git@github.com-work:origin/example.git",
+			githubHost:           "ghe.example.com",
+			existingRepositories: []string{"ghe.example.com/origin/example"},
+			sshHostnames:         map[string]string{"github.com-work": "github.com"},
+		},
+		{
+			name:                 "ssh_alias_resolution_failure",
+			remoteURL:            "git@github-work:origin/example.git",
+			githubHost:           "github.com",
+			existingRepositories: []string{"github.com/origin/example"},
+			sshHostnames:         map[string]string{},
+			expectedStderr:       "HOST-CHECK-FAILED: /tmp/example origin git@github-work:origin/example.git could not be checked against github.com: ssh -G github-work failed\n",
+		},
+		{
+			name:           "repository_lookup_failure",
+			remoteURL:      "https://github.com/origin/example.git",
+			githubHost:     "ghe.example.com",
+			lookupError:    errors.New("connection reset"),
+			expectedStderr: "HOST-CHECK-FAILED: /tmp/example origin https://github.com/origin/example.git could not be checked against ghe.example.com: connection reset\n",
+		},
+		{
+			name:       "repository_genuinely_hosted_elsewhere",
+			remoteURL:  "ssh://git@gitlab.example.com/origin/example.git",
+			githubHost: "github.com",
+		},
+		{
+			name:                 "origin_on_configured_host",
+			remoteURL:            "https://github.com/origin/example.git",
+			githubHost:           "github.com",
+			existingRepositories: []string{"github.com/origin/example"},
+		},
+		{
+			name:      "host_not_configured",
+			remoteURL: "git@ghe.example.com:origin/example.git",
+		},
+		{
+			name:                 "offline_skips_existence_check",
+			remoteURL:            "https://github.com/origin/example.git",
+			githubHost:           "ghe.example.com",
+			offline:              true,
+			existingRepositories: []string{"ghe.example.com/origin/example"},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			existingRepositories := make(map[string]struct{}, len(testCase.existingRepositories))
+			for _, repository := range testCase.existingRepositories {
+				existingRepositories[repository] = struct{}{}
+			}
+			resolver := &hostAwareGitHubResolver{existingRepositories: existingRepositories, lookupError: testCase.lookupError}
+			repositoryExecutor := stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+				"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+			}}
+			var gitExecutor audit.GitExecutor = repositoryExecutor
+			if testCase.sshHostnames != nil {
+				gitExecutor = sshConfigGitExecutor{stubGitExecutor: repositoryExecutor, hostnames: testCase.sshHostnames}
+			}
+			errorBuffer := &bytes.Buffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{"/tmp/example"}},
+				stubGitManager{branchName: "main", remoteURL: testCase.remoteURL},
+				gitExecutor,
+				resolver,
+				&bytes.Buffer{},
+				errorBuffer,
+			)
+
+			runError := service.Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{"/tmp"},
+				InspectionDepth: audit.InspectionDepthMinimal,
+				Offline:         testCase.offline,
+				GitHubHost:      testCase.githubHost,
+			})
+			require.NoError(subtest, runError)
+			require.Equal(subtest, testCase.expectedStderr, errorBuffer.String())
+
+			mismatches := service.HostMismatches()
+			if len(testCase.expectedRemote) == 0 {
+				require.Empty(subtest, mismatches)
+				return
+			}
+			require.Len(subtest, mismatches, 1)
+			require.Equal(subtest, testCase.expectedRemote, mismatches[0].ReconciledRemote)
+		})
+	}
+}
+
+func TestServiceRunReportsStaleRemoteHeads(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		inspectionDepth audit.InspectionDepth
+		gitOutputs      map[string]execshell.ExecutionResult
+		defaultBranch   string
+		expectedStderr  string
+		expectedStale   []audit.StaleRemoteHead
+	}{
+		{
+			name:            "symbolic_ref_behind_remote",
+			inspectionDepth: audit.InspectionDepthFull,
+			gitOutputs: map[string]execshell.ExecutionResult{
+				"symbolic-ref --quiet refs/remotes/origin/HEAD": {StandardOutput: "refs/remotes/origin/master\n"},
+			},
+			defaultBranch:  "main",
+			expectedStderr: "STALE-REMOTE-HEAD: /tmp/example origin/HEAD points to master (from symbolic-ref) but the remote default branch is main; refresh with: git -C /tmp/example remote set-head origin --auto\n",
+			expectedStale: []audit.StaleRemoteHead{
+				{RepositoryPath: "/tmp/example", LocalRemoteHead: "master", LocalSource: gitrepo.RemoteHeadSourceSymbolicRef, RemoteDefaultBranch: "main"},
+			},
+		},
+		{
+			name:            "symbolic_ref_matches_remote",
+			inspectionDepth: audit.InspectionDepthFull,
+			gitOutputs: map[string]execshell.ExecutionResult{
+				"symbolic-ref --quiet refs/remotes/origin/HEAD": {StandardOutput: "refs/remotes/origin/main\n"},
+			},
+			defaultBranch: "main",
+		},
+		{
+			name:            "local_data_missing_skips_network",
+			inspectionDepth: audit.InspectionDepthFull,
+			defaultBranch:   "main",
+		},
+		{
+			name:            "minimal_depth_skips_check",
+			inspectionDepth: audit.InspectionDepthMinimal,
+			gitOutputs: map[string]execshell.ExecutionResult{
+				"symbolic-ref --quiet refs/remotes/origin/HEAD": {StandardOutput: "refs/remotes/origin/master\n"},
+			},
+			defaultBranch: "main",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			gitOutputs := map[string]execshell.ExecutionResult{
+				"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+			}
+			for command, result := range testCase.gitOutputs {
+				gitOutputs[command] = result
+			}
+			errorBuffer := &bytes.Buffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{"/tmp/example"}},
+				stubGitManager{branchName: "feature", remoteURL: "https://github.com/origin/example.git"},
+				stubGitExecutor{outputs: gitOutputs},
+				stubGitHubResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "origin/example", DefaultBranch: testCase.defaultBranch}},
+				&bytes.Buffer{},
+				errorBuffer,
+			)
+
+			runError := service.Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{"/tmp/example"},
+				InspectionDepth: testCase.inspectionDepth,
+			})
+			require.NoError(subtest, runError)
+			require.Equal(subtest, testCase.expectedStderr, errorBuffer.String())
+			require.Equal(subtest, testCase.expectedStale, service.StaleRemoteHeads())
+		})
+	}
+}
+
+func TestServiceRunReportsPushURLMismatches(testInstance *testing.T) {
+	testCases := []struct {
+		name               string
+		inspectionDepth    audit.InspectionDepth
+		pushURL            string
+		expectedStderr     string
+		expectedMismatches []audit.PushURLMismatch
+	}{
+		{
+			name:            "push_url_names_other_repository",
+			inspectionDepth: audit.InspectionDepthFull,
+			pushURL:         "git@github.com:fork/example.git\n",
+			expectedStderr:  "PUSH-URL-MISMATCH: /tmp/example origin fetches from https://github.com/origin/example.git but pushes to git@github.com:fork/example.git; align with: git -C /tmp/example remote set-url --push origin https://github.com/origin/example.git\n",
+			expectedMismatches: []audit.PushURLMismatch{
+				{
+					RepositoryPath:       "/tmp/example",
+					FetchURL:             "https://github.com/origin/example.git",
+					PushURL:              "git@github.com:fork/example.git",
+					FetchOwnerRepository: "origin/example",
+					PushOwnerRepository:  "fork/example",
+				},
+			},
+		},
+		{
+			name:            "push_url_other_protocol_same_repository",
+			inspectionDepth: audit.InspectionDepthFull,
+			pushURL:         "git@github.com:Origin/Example.git\n",
+		},
+		{
+			name:            "push_url_follows_fetch_url",
+			inspectionDepth: audit.InspectionDepthFull,
+			pushURL:         "https://github.com/origin/example.git\n",
+		},
+		{
+			name:            "minimal_depth_skips_check",
+			inspectionDepth: audit.InspectionDepthMinimal,
+			pushURL:         "git@github.com:fork/example.git\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			errorBuffer := &bytes.Buffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{"/tmp/example"}},
+				stubGitManager{branchName: "main", remoteURL: "https://github.com/origin/example.git"},
+				stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+					"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+					"remote get-url --push origin":    {StandardOutput: testCase.pushURL},
+				}},
+				stubGitHubResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "origin/example", DefaultBranch: "main"}},
+				&bytes.Buffer{},
+				errorBuffer,
+			)
+
+			runError := service.Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{"/tmp/example"},
+				InspectionDepth: testCase.inspectionDepth,
+			})
+			require.NoError(subtest, runError)
+			require.Equal(subtest, testCase.expectedStderr, errorBuffer.String())
+			require.Equal(subtest, testCase.expectedMismatches, service.PushURLMismatches())
+		})
+	}
+}
+
+func TestServiceRunReportsInProgressOperations(testInstance *testing.T) {
+	testCases := []struct {
+		name             string
+		inspectionDepth  audit.InspectionDepth
+		markerName       string
+		expectedStderr   string
+		expectedFindings []audit.InProgressOperationFinding
+	}{
+		{
+			name:            "cherry_pick_in_progress",
+			inspectionDepth: audit.InspectionDepthFull,
+			markerName:      "CHERRY_PICK_HEAD",
+			expectedStderr:  "IN-PROGRESS-OPERATION: /tmp/example has an unfinished cherry-pick; finish or abort it before running migrations or renames\n",
+			expectedFindings: []audit.InProgressOperationFinding{
+				{RepositoryPath: "/tmp/example", Operation: gitrepo.InProgressOperationCherryPick},
+			},
+		},
+		{
+			name:            "no_operation",
+			inspectionDepth: audit.InspectionDepthFull,
+		},
+		{
+			name:            "minimal_depth_skips_check",
+			inspectionDepth: audit.InspectionDepthMinimal,
+			markerName:      "MERGE_HEAD",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			gitDirectory := subtest.TempDir()
+			if len(testCase.markerName) > 0 {
+				require.NoError(subtest, os.WriteFile(filepath.Join(gitDirectory, testCase.markerName), []byte("abc123\n"), 0o644))
+			}
+			errorBuffer := &bytes.Buffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{"/tmp/example"}},
+				stubGitManager{branchName: "main", remoteURL: "https://github.com/origin/example.git"},
+				stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+					"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+					"rev-parse --absolute-git-dir":    {StandardOutput: gitDirectory + "\n"},
+				}},
+				stubGitHubResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "origin/example", DefaultBranch: "main"}},
+				&bytes.Buffer{},
+				errorBuffer,
+			)
+
+			runError := service.Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{"/tmp/example"},
+				InspectionDepth: testCase.inspectionDepth,
+				Offline:         true,
+			})
+			require.NoError(subtest, runError)
+			require.Equal(subtest, testCase.expectedStderr, errorBuffer.String())
+			require.Equal(subtest, testCase.expectedFindings, service.InProgressOperations())
+		})
+	}
+}
+
+func TestServiceRunReportsDuplicateClones(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		duplicatesOnly bool
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			name:           "duplicates_reported_with_audit",
+			expectedStderr: "DUPLICATE-CLONE: Origin/Example is cloned 2 times\n  /tmp/first last-commit=2024-05-01T10:00:00Z dirty=no\n  /tmp/second last-commit=2024-05-01T10:00:00Z dirty=no\n",
+		},
+		{
+			name:           "duplicates_only",
+			duplicatesOnly: true,
+			expectedStdout: "DUPLICATE-CLONE: Origin/Example is cloned 2 times\n  /tmp/first last-commit=2024-05-01T10:00:00Z dirty=no\n  /tmp/second last-commit=2024-05-01T10:00:00Z dirty=no\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			outputBuffer := &bytes.Buffer{}
+			errorBuffer := &bytes.Buffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{"/tmp/first", "/tmp/second", "/tmp/unique"}},
+				stubGitManager{
+					cleanWorktree: true,
+					remoteURLs: map[string]string{
+						"/tmp/first":  "git@github.com:Origin/Example.git",
+						"/tmp/second": "https://github.com/origin/example",
+						"/tmp/unique": "https://github.com/origin/unique.git",
+					},
+				},
+				stubGitExecutor{outputs: map[string]execshell.ExecutionResult{
+					"rev-parse --is-inside-work-tree": {StandardOutput: "true"},
+					"log -1 --format=%cI":             {StandardOutput: "2024-05-01T10:00:00Z\n"},
+				}},
+				stubGitHubResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "origin/example", DefaultBranch: "main"}},
+				outputBuffer,
+				errorBuffer,
+			)
+
+			runError := service.Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{"/tmp"},
+				InspectionDepth: audit.InspectionDepthMinimal,
+				DuplicatesOnly:  testCase.duplicatesOnly,
+			})
+			require.NoError(subtest, runError)
+			require.Equal(subtest, testCase.expectedStderr, errorBuffer.String())
+			if testCase.duplicatesOnly {
+				require.Equal(subtest, testCase.expectedStdout, outputBuffer.String())
+			}
+			require.Len(subtest, service.DuplicateClones(), 1)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const (
+	shallowCloneFindingTemplateConstant = "SHALLOW-CLONE: %s is a shallow clone; history-based results such as last_activity may be incomplete; fetch full history with: git -C %s fetch --unshallow origin\n"
+	unshallowCommandTemplateConstant    = "git -C %s fetch --unshallow origin"
+)
+
+// ShallowCloneFinding describes a clone whose history was truncated by a --depth clone or fetch.
+type ShallowCloneFinding struct {
+	RepositoryPath string
+}
+
+// ShallowClones returns the shallow clones detected by the most recent DiscoverInspections call.
+func (service *Service) ShallowClones() []ShallowCloneFinding {
+	return service.shallowClones
+}
+
+// ReportShallowClones writes each shallow clone finding and its unshallow command to the error writer.
+func (service *Service) ReportShallowClones() {
+	if service.errorWriter == nil {
+		return
+	}
+	for _, finding := range service.shallowClones {
+		fmt.Fprintf(service.errorWriter, shallowCloneFindingTemplateConstant, finding.RepositoryPath, finding.RepositoryPath)
+	}
+}
+
+func (service *Service) recordShallowClone(executionContext context.Context, repositoryPath string) {
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(service.gitExecutor)
+	if managerError != nil {
+		return
+	}
+	shallow, shallowError := repositoryManager.IsShallow(executionContext, repositoryPath)
+	if shallowError != nil || !shallow {
+		return
+	}
+	service.shallowClones = append(service.shallowClones, ShallowCloneFinding{RepositoryPath: repositoryPath})
+}
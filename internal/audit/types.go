@@ -1,6 +1,13 @@
 package audit
 
-import "github.com/temirov/gix/internal/repos/shared"
+import (
+	"errors"
+
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+// ErrNestedRepositoriesDetected reports that the audit found repositories nested inside other repositories while strict nesting checks were enabled.
+var ErrNestedRepositoriesDetected = errors.New("nested repositories detected")
 
 // RemoteProtocolType enumerates supported git remote protocols.
 type RemoteProtocolType = shared.RemoteProtocol
@@ -21,6 +28,8 @@ const (
 	TernaryValueYes           TernaryValue = "yes"
 	TernaryValueNo            TernaryValue = "no"
 	TernaryValueNotApplicable TernaryValue = "n/a"
+	// TernaryValueOffline marks values that require network access when the audit runs offline.
+	TernaryValueOffline TernaryValue = "n/a (offline)"
 )
 
 // InspectionDepth determines how much repository state should be gathered.
@@ -38,6 +47,18 @@ type CommandOptions struct {
 	DebugOutput       bool
 	InspectionDepth   InspectionDepth
 	IncludeAllFolders bool
+	Offline           bool
+	FailOnNested      bool
+	GitHubHost        string
+	DuplicatesOnly    bool
+	SortOrder         ReportSortOrder
+	ReportFormat      ReportFormat
+	MinimumScore      int
+	IncludeBare       bool
+	Fix               bool
+	FixProtocol       RemoteProtocolType
+	Unshallow         bool
+	DryRun            bool
 }
 
 // RepositoryInspection captures gathered repository state.
@@ -46,6 +67,8 @@ type RepositoryInspection struct {
 	FolderName             string
 	OriginURL              string
 	OriginOwnerRepo        string
+	OriginPushURL          string
+	OriginPushOwnerRepo    string
 	CanonicalOwnerRepo     string
 	FinalOwnerRepo         string
 	DesiredFolderName      string
@@ -54,7 +77,13 @@ type RepositoryInspection struct {
 	LocalBranch            string
 	InSyncStatus           TernaryValue
 	OriginMatchesCanonical TernaryValue
+	LastActivity           CommitActivity
+	DeleteBranchOnMerge    TernaryValue
+	MergeQueue             TernaryValue
+	HealthScore            HealthScore
 	IsGitRepository        bool
+	// IsBare marks a repository without a worktree, such as a --mirror clone.
+	IsBare bool
 }
 
 // AuditReportRow models a single CSV audit result.
@@ -67,6 +96,10 @@ type AuditReportRow struct {
 	InSync                 TernaryValue
 	RemoteProtocol         RemoteProtocolType
 	OriginMatchesCanonical TernaryValue
+	LastActivity           string
+	DeleteBranchOnMerge    TernaryValue
+	MergeQueue             TernaryValue
+	HealthScore            string
 }
 
 // CSVRecord returns the row formatted for CSV encoding.
@@ -80,5 +113,9 @@ func (row AuditReportRow) CSVRecord() []string {
 		string(row.InSync),
 		string(row.RemoteProtocol),
 		string(row.OriginMatchesCanonical),
+		row.LastActivity,
+		string(row.DeleteBranchOnMerge),
+		string(row.MergeQueue),
+		row.HealthScore,
 	}
 }
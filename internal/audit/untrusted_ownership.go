@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	untrustedOwnershipFindingTemplateConstant = "UNTRUSTED-OWNERSHIP: %s is owned by another user and git refuses to operate on it; skipping; trust it with: %s\n"
+	safeDirectoryCommandTemplateConstant      = "git config --global --add safe.directory %s"
+)
+
+// UntrustedOwnershipFinding describes a repository owned by another user, which git refuses to operate on until the
+// path is trusted through safe.directory.
+type UntrustedOwnershipFinding struct {
+	RepositoryPath string
+}
+
+// Remediation returns the git command that trusts the repository.
+func (finding UntrustedOwnershipFinding) Remediation() string {
+	return fmt.Sprintf(safeDirectoryCommandTemplateConstant, finding.RepositoryPath)
+}
+
+// UntrustedOwnership returns the repositories with untrusted ownership detected by the most recent
+// DiscoverInspections call. They are left out of the inspections.
+func (service *Service) UntrustedOwnership() []UntrustedOwnershipFinding {
+	return service.untrustedOwnership
+}
+
+// HasUntrustedOwnership reports whether the most recent DiscoverInspections call found the repository to be owned by
+// another user.
+func (service *Service) HasUntrustedOwnership(repositoryPath string) bool {
+	for _, finding := range service.untrustedOwnership {
+		if finding.RepositoryPath == repositoryPath {
+			return true
+		}
+	}
+	return false
+}
+
+// ReportUntrustedOwnership writes each untrusted ownership finding and its safe.directory command to the error writer.
+// A repository is reported once per service even when later discoveries find it again.
+func (service *Service) ReportUntrustedOwnership() {
+	if service.errorWriter == nil {
+		return
+	}
+	if service.reportedUntrustedOwnership == nil {
+		service.reportedUntrustedOwnership = make(map[string]struct{})
+	}
+	for _, finding := range service.untrustedOwnership {
+		if _, reported := service.reportedUntrustedOwnership[finding.RepositoryPath]; reported {
+			continue
+		}
+		service.reportedUntrustedOwnership[finding.RepositoryPath] = struct{}{}
+		fmt.Fprintf(service.errorWriter, untrustedOwnershipFindingTemplateConstant, finding.RepositoryPath, finding.Remediation())
+	}
+}
+
+// recordUntrustedOwnership records the repository once when the git failure is git's dubious ownership refusal and
+// reports whether it was.
+func (service *Service) recordUntrustedOwnership(repositoryPath string, gitError error) bool {
+	if !execshell.IsDubiousOwnership(gitError) {
+		return false
+	}
+	if !service.HasUntrustedOwnership(repositoryPath) {
+		service.untrustedOwnership = append(service.untrustedOwnership, UntrustedOwnershipFinding{RepositoryPath: repositoryPath})
+	}
+	return true
+}
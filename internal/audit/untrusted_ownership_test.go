@@ -0,0 +1,72 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+func TestServiceRunReportsUntrustedOwnership(testInstance *testing.T) {
+	const (
+		trustedRepositoryConstant   = "/tmp/example"
+		untrustedRepositoryConstant = "/mnt/shared/example"
+		dubiousOwnershipStderr      = "fatal: detected dubious ownership in repository at '/mnt/shared/example'\nTo add an exception for this directory, call:\n\n\tgit config --global --add safe.directory /mnt/shared/example\n"
+	)
+
+	testCases := []struct {
+		name             string
+		failure          string
+		expectedStderr   string
+		expectedFindings []audit.UntrustedOwnershipFinding
+	}{
+		{
+			name:             "dubious_ownership_classified",
+			failure:          dubiousOwnershipStderr,
+			expectedStderr:   "UNTRUSTED-OWNERSHIP: /mnt/shared/example is owned by another user and git refuses to operate on it; skipping; trust it with: git config --global --add safe.directory /mnt/shared/example\n",
+			expectedFindings: []audit.UntrustedOwnershipFinding{{RepositoryPath: untrustedRepositoryConstant}},
+		},
+		{
+			name:    "other_failures_ignored",
+			failure: "fatal: not a git repository (or any of the parent directories): .git\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := execshelltest.NewPermissiveExecutor()
+			executor.OnGit("rev-parse", "--is-inside-work-tree").InDirectory(untrustedRepositoryConstant).FailWith(128, testCase.failure)
+			executor.OnGit("rev-parse", "--is-inside-work-tree").InDirectory(trustedRepositoryConstant).ReturnOutput("true")
+
+			outputBuffer := &bytes.Buffer{}
+			errorBuffer := &bytes.Buffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{trustedRepositoryConstant, untrustedRepositoryConstant}},
+				stubGitManager{branchName: "main", remoteURL: "https://github.com/origin/example.git"},
+				executor,
+				stubGitHubResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "origin/example", DefaultBranch: "main"}},
+				outputBuffer,
+				errorBuffer,
+			)
+
+			require.NoError(subtest, service.Run(context.Background(), audit.CommandOptions{
+				Roots:           []string{"/tmp", "/mnt/shared"},
+				InspectionDepth: audit.InspectionDepthMinimal,
+				Offline:         true,
+			}))
+			require.Equal(subtest, testCase.expectedStderr, errorBuffer.String())
+			require.Equal(subtest, testCase.expectedFindings, service.UntrustedOwnership())
+			require.NotContains(subtest, outputBuffer.String(), untrustedRepositoryConstant)
+			require.Equal(subtest, len(testCase.expectedFindings) > 0, service.HasUntrustedOwnership(untrustedRepositoryConstant))
+
+			service.ReportUntrustedOwnership()
+			require.Equal(subtest, testCase.expectedStderr, errorBuffer.String())
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// DefaultWatchDebounce is how long Watch waits after the last metadata change before re-auditing.
+	DefaultWatchDebounce = 500 * time.Millisecond
+	// DefaultWatchRepositoryLimit caps the repositories Watch subscribes to.
+	DefaultWatchRepositoryLimit = 500
+
+	watchHeadFileNameConstant        = "HEAD"
+	watchConfigFileNameConstant      = "config"
+	watchStartedTemplateConstant     = "AUDIT-WATCH: watching %d repositories; press Ctrl-C to stop\n"
+	watchLimitTemplateConstant       = "AUDIT-WATCH-LIMIT: watching the first %d of %d repositories; narrow the roots to watch the rest\n"
+	watchSubscribeFailedTemplate     = "AUDIT-WATCH-ERROR: cannot watch %s: %v\n"
+	watchErrorTemplateConstant       = "AUDIT-WATCH-ERROR: %v\n"
+	watchUpdateTemplateConstant      = "AUDIT-WATCH-UPDATE: %s repo=%s branch=%s default=%s in_sync=%s protocol=%s origin_matches_canonical=%s score=%s\n"
+	watchGoneTemplateConstant        = "AUDIT-WATCH-GONE: %s no longer audits as a GitHub repository\n"
+	watchSummaryTemplateConstant     = "AUDIT-WATCH-SUMMARY: %d repositories, %d out of sync, %d not matching canonical\n"
+	watchFinalTemplateConstant       = "AUDIT-WATCH-FINAL: %d repositories\n"
+	watchWatcherRequiredErrorMessage = "audit watch requires a file watcher"
+)
+
+// FileWatcher delivers the paths of changed files inside the directories added to it.
+type FileWatcher interface {
+	Add(directory string) error
+	Events() <-chan string
+	Errors() <-chan error
+	Close() error
+}
+
+// WatchOptions configures Service.Watch.
+type WatchOptions struct {
+	// Watcher reports changes to repository metadata; Watch closes it before returning.
+	Watcher FileWatcher
+	// Debounce collapses bursts of changes into one re-audit; zero selects DefaultWatchDebounce.
+	Debounce time.Duration
+	// MaxRepositories caps the watched repositories; zero selects DefaultWatchRepositoryLimit.
+	MaxRepositories int
+}
+
+// Watch performs an initial CSV audit, then re-audits only the repositories whose HEAD or config changed and prints a
+// compact update with a summary of every watched repository. It returns nil once the context is cancelled, after
+// printing the final state as a CSV report.
+func (service *Service) Watch(executionContext context.Context, options CommandOptions, watchOptions WatchOptions) error {
+	if watchOptions.Watcher == nil {
+		return errors.New(watchWatcherRequiredErrorMessage)
+	}
+	defer watchOptions.Watcher.Close()
+	if len(options.Roots) == 0 {
+		return errors.New(missingRootsErrorMessageConstant)
+	}
+
+	if options.Offline {
+		service.DisableCheckCategory(CheckCategoryRemote)
+	}
+	if len(strings.TrimSpace(options.GitHubHost)) > 0 {
+		service.SetGitHubHost(options.GitHubHost)
+	}
+	service.SetIncludeBareRepositories(options.IncludeBare)
+
+	inspections, inspectionError := service.DiscoverInspections(executionContext, options.Roots, false, options.DebugOutput, options.InspectionDepth)
+	if inspectionError != nil {
+		return inspectionError
+	}
+	if reportError := service.writeCSVAuditWithFindings(service.watchReport(inspections, options)); reportError != nil {
+		return reportError
+	}
+
+	tracked := make(map[string]RepositoryInspection, len(inspections))
+	for _, inspection := range inspections {
+		if inspection.IsGitRepository {
+			tracked[inspection.Path] = inspection
+		}
+	}
+	repositoriesByDirectory := service.subscribeRepositories(tracked, watchOptions)
+
+	debounce := watchOptions.Debounce
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+	pending := map[string]struct{}{}
+	watchErrors := watchOptions.Watcher.Errors()
+	var debounceTimer <-chan time.Time
+	for {
+		select {
+		case <-executionContext.Done():
+			return service.writeWatchFinalState(tracked, options)
+		case changedPath, open := <-watchOptions.Watcher.Events():
+			if !open {
+				return service.writeWatchFinalState(tracked, options)
+			}
+			repositoryPath, relevant := watchedRepository(changedPath, repositoriesByDirectory)
+			if !relevant {
+				continue
+			}
+			pending[repositoryPath] = struct{}{}
+			debounceTimer = time.After(debounce)
+		case watchError, open := <-watchErrors:
+			if !open {
+				watchErrors = nil
+				continue
+			}
+			if service.errorWriter != nil {
+				fmt.Fprintf(service.errorWriter, watchErrorTemplateConstant, watchError)
+			}
+		case <-debounceTimer:
+			debounceTimer = nil
+			changedPaths := make([]string, 0, len(pending))
+			for repositoryPath := range pending {
+				changedPaths = append(changedPaths, repositoryPath)
+			}
+			pending = map[string]struct{}{}
+			if reauditError := service.reauditRepositories(executionContext, changedPaths, tracked, options); reauditError != nil {
+				if executionContext.Err() != nil {
+					return service.writeWatchFinalState(tracked, options)
+				}
+				return reauditError
+			}
+		}
+	}
+}
+
+// subscribeRepositories watches the metadata directory of each tracked repository up to the configured cap and
+// returns the repository path for every watched directory.
+func (service *Service) subscribeRepositories(tracked map[string]RepositoryInspection, watchOptions WatchOptions) map[string]string {
+	repositoryPaths := make([]string, 0, len(tracked))
+	for repositoryPath := range tracked {
+		repositoryPaths = append(repositoryPaths, repositoryPath)
+	}
+	sort.Strings(repositoryPaths)
+
+	limit := watchOptions.MaxRepositories
+	if limit <= 0 {
+		limit = DefaultWatchRepositoryLimit
+	}
+	if len(repositoryPaths) > limit {
+		if service.errorWriter != nil {
+			fmt.Fprintf(service.errorWriter, watchLimitTemplateConstant, limit, len(repositoryPaths))
+		}
+		repositoryPaths = repositoryPaths[:limit]
+	}
+
+	repositoriesByDirectory := make(map[string]string, len(repositoryPaths))
+	for _, repositoryPath := range repositoryPaths {
+		metadataDirectory := repositoryMetadataDirectory(repositoryPath)
+		if addError := watchOptions.Watcher.Add(metadataDirectory); addError != nil {
+			if service.errorWriter != nil {
+				fmt.Fprintf(service.errorWriter, watchSubscribeFailedTemplate, repositoryPath, addError)
+			}
+			continue
+		}
+		repositoriesByDirectory[metadataDirectory] = repositoryPath
+	}
+	if service.errorWriter != nil {
+		fmt.Fprintf(service.errorWriter, watchStartedTemplateConstant, len(repositoriesByDirectory))
+	}
+	return repositoriesByDirectory
+}
+
+// reauditRepositories inspects the changed repositories again, replaces their tracked state, and prints one update
+// line per repository followed by a summary of all tracked repositories.
+func (service *Service) reauditRepositories(executionContext context.Context, changedPaths []string, tracked map[string]RepositoryInspection, options CommandOptions) error {
+	sort.Strings(changedPaths)
+	inspections, inspectionError := service.DiscoverInspections(executionContext, changedPaths, false, options.DebugOutput, options.InspectionDepth)
+	if inspectionError != nil {
+		return inspectionError
+	}
+	refreshed := make(map[string]RepositoryInspection, len(inspections))
+	for _, inspection := range inspections {
+		refreshed[inspection.Path] = inspection
+	}
+
+	updates := make([]string, 0, len(changedPaths))
+	for _, repositoryPath := range changedPaths {
+		previous, wasTracked := tracked[repositoryPath]
+		inspection, stillAudited := refreshed[repositoryPath]
+		if !stillAudited {
+			delete(tracked, repositoryPath)
+			folderName := repositoryPath
+			if wasTracked {
+				folderName = previous.FolderName
+			}
+			updates = append(updates, fmt.Sprintf(watchGoneTemplateConstant, folderName))
+			continue
+		}
+		if wasTracked {
+			inspection.FolderName = previous.FolderName
+		}
+		tracked[repositoryPath] = inspection
+		row := inspectionReportRow(inspection)
+		updates = append(updates, fmt.Sprintf(watchUpdateTemplateConstant, row.FolderName, row.FinalRepository, row.LocalBranch, row.RemoteDefaultBranch, row.InSync, row.RemoteProtocol, row.OriginMatchesCanonical, row.HealthScore))
+	}
+	if service.outputWriter == nil {
+		return nil
+	}
+	for _, update := range updates {
+		fmt.Fprint(service.outputWriter, update)
+	}
+
+	outOfSync := 0
+	notCanonical := 0
+	for _, inspection := range tracked {
+		if inspection.InSyncStatus == TernaryValueNo {
+			outOfSync++
+		}
+		if inspection.OriginMatchesCanonical == TernaryValueNo {
+			notCanonical++
+		}
+	}
+	fmt.Fprintf(service.outputWriter, watchSummaryTemplateConstant, len(tracked), outOfSync, notCanonical)
+	return nil
+}
+
+func (service *Service) writeWatchFinalState(tracked map[string]RepositoryInspection, options CommandOptions) error {
+	inspections := make([]RepositoryInspection, 0, len(tracked))
+	for _, inspection := range tracked {
+		inspections = append(inspections, inspection)
+	}
+	sort.Slice(inspections, func(left int, right int) bool {
+		return inspections[left].Path < inspections[right].Path
+	})
+	if service.outputWriter != nil {
+		fmt.Fprintf(service.outputWriter, watchFinalTemplateConstant, len(inspections))
+	}
+	return service.writeAuditReport(service.watchReport(inspections, options))
+}
+
+func (service *Service) watchReport(inspections []RepositoryInspection, options CommandOptions) []RepositoryInspection {
+	SortInspections(inspections, options.SortOrder)
+	return FilterInspectionsByScore(inspections, options.MinimumScore)
+}
+
+// repositoryMetadataDirectory returns the .git directory of a worktree repository, or the repository itself when it
+// is bare.
+func repositoryMetadataDirectory(repositoryPath string) string {
+	metadataDirectory := filepath.Join(repositoryPath, gitMetadataDirectoryNameConstant)
+	if info, statError := os.Stat(metadataDirectory); statError == nil && info.IsDir() {
+		return metadataDirectory
+	}
+	return repositoryPath
+}
+
+// watchedRepository maps a changed HEAD or config file to its repository; other files are ignored.
+func watchedRepository(changedPath string, repositoriesByDirectory map[string]string) (string, bool) {
+	switch filepath.Base(changedPath) {
+	case watchHeadFileNameConstant, watchConfigFileNameConstant:
+	default:
+		return "", false
+	}
+	repositoryPath, watched := repositoriesByDirectory[filepath.Dir(changedPath)]
+	return repositoryPath, watched
+}
+
+type fileSystemWatcher struct {
+	watcher *fsnotify.Watcher
+	events  chan string
+	closed  chan struct{}
+}
+
+// NewFileSystemWatcher returns a FileWatcher backed by the operating system's file notification API.
+func NewFileSystemWatcher() (FileWatcher, error) {
+	watcher, watcherError := fsnotify.NewWatcher()
+	if watcherError != nil {
+		return nil, watcherError
+	}
+	fileWatcher := &fileSystemWatcher{watcher: watcher, events: make(chan string), closed: make(chan struct{})}
+	go fileWatcher.forward()
+	return fileWatcher, nil
+}
+
+func (fileWatcher *fileSystemWatcher) forward() {
+	defer close(fileWatcher.events)
+	for event := range fileWatcher.watcher.Events {
+		if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) && !event.Has(fsnotify.Remove) {
+			continue
+		}
+		select {
+		case fileWatcher.events <- event.Name:
+		case <-fileWatcher.closed:
+			return
+		}
+	}
+}
+
+func (fileWatcher *fileSystemWatcher) Add(directory string) error {
+	return fileWatcher.watcher.Add(directory)
+}
+
+func (fileWatcher *fileSystemWatcher) Events() <-chan string {
+	return fileWatcher.events
+}
+
+func (fileWatcher *fileSystemWatcher) Errors() <-chan error {
+	return fileWatcher.watcher.Errors
+}
+
+func (fileWatcher *fileSystemWatcher) Close() error {
+	select {
+	case <-fileWatcher.closed:
+	default:
+		close(fileWatcher.closed)
+	}
+	return fileWatcher.watcher.Close()
+}
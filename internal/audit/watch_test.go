@@ -0,0 +1,164 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+type fakeFileWatcher struct {
+	mutex   sync.Mutex
+	added   []string
+	events  chan string
+	errors  chan error
+	ready   chan struct{}
+	expects int
+}
+
+func newFakeFileWatcher(expectedAdds int) *fakeFileWatcher {
+	return &fakeFileWatcher{events: make(chan string), errors: make(chan error), ready: make(chan struct{}), expects: expectedAdds}
+}
+
+func (watcher *fakeFileWatcher) Add(directory string) error {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+	watcher.added = append(watcher.added, directory)
+	if len(watcher.added) == watcher.expects {
+		close(watcher.ready)
+	}
+	return nil
+}
+
+func (watcher *fakeFileWatcher) Events() <-chan string { return watcher.events }
+
+func (watcher *fakeFileWatcher) Errors() <-chan error { return watcher.errors }
+
+func (watcher *fakeFileWatcher) Close() error { return nil }
+
+type synchronizedBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (buffer *synchronizedBuffer) Write(data []byte) (int, error) {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+	return buffer.buffer.Write(data)
+}
+
+func (buffer *synchronizedBuffer) String() string {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+	return buffer.buffer.String()
+}
+
+type switchingGitManager struct {
+	stubGitManager
+	mutex    sync.Mutex
+	branches map[string]string
+}
+
+func (manager *switchingGitManager) GetCurrentBranch(ctx context.Context, repositoryPath string) (string, error) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	return manager.branches[repositoryPath], nil
+}
+
+func (manager *switchingGitManager) switchBranch(repositoryPath string, branch string) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	manager.branches[repositoryPath] = branch
+}
+
+func TestServiceWatchReauditsChangedRepositories(testInstance *testing.T) {
+	const csvHeader = "folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,last_activity,delete_branch_on_merge,merge_queue,health_score\n"
+
+	testCases := []struct {
+		name           string
+		maxWatched     int
+		expectedAdds   int
+		expectedErrors string
+	}{
+		{
+			name:           "watches_every_repository",
+			expectedAdds:   2,
+			expectedErrors: "AUDIT-WATCH: watching 2 repositories; press Ctrl-C to stop\n",
+		},
+		{
+			name:         "caps_watched_repositories",
+			maxWatched:   1,
+			expectedAdds: 1,
+			expectedErrors: "AUDIT-WATCH-LIMIT: watching the first 1 of 2 repositories; narrow the roots to watch the rest\n" +
+				"AUDIT-WATCH: watching 1 repositories; press Ctrl-C to stop\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			root := subtest.TempDir()
+			alphaPath := filepath.Join(root, "alpha")
+			betaPath := filepath.Join(root, "beta")
+			for _, repositoryPath := range []string{alphaPath, betaPath} {
+				require.NoError(subtest, os.MkdirAll(filepath.Join(repositoryPath, ".git"), 0o755))
+			}
+
+			gitManager := &switchingGitManager{
+				stubGitManager: stubGitManager{cleanWorktree: true, remoteURL: "https://github.com/canonical/example.git"},
+				branches:       map[string]string{alphaPath: "main", betaPath: "main"},
+			}
+			outputBuffer := &synchronizedBuffer{}
+			errorBuffer := &synchronizedBuffer{}
+			service := audit.NewService(
+				stubDiscoverer{repositories: []string{alphaPath, betaPath}},
+				gitManager,
+				stubGitExecutor{outputs: map[string]execshell.ExecutionResult{"rev-parse --is-inside-work-tree": {StandardOutput: "true"}}},
+				stubGitHubResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "canonical/example", DefaultBranch: "main"}},
+				outputBuffer,
+				errorBuffer,
+			)
+
+			watcher := newFakeFileWatcher(testCase.expectedAdds)
+			watchContext, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			watchResult := make(chan error, 1)
+			go func() {
+				watchResult <- service.Watch(watchContext, audit.CommandOptions{Roots: []string{root}, InspectionDepth: audit.InspectionDepthFull}, audit.WatchOptions{Watcher: watcher, Debounce: 10 * time.Millisecond, MaxRepositories: testCase.maxWatched})
+			}()
+
+			<-watcher.ready
+			gitManager.switchBranch(alphaPath, "feature")
+			watcher.events <- filepath.Join(alphaPath, ".git", "index")
+			watcher.events <- filepath.Join(alphaPath, ".git", "HEAD")
+			watcher.events <- filepath.Join(alphaPath, ".git", "config")
+			require.Eventually(subtest, func() bool {
+				return strings.Contains(outputBuffer.String(), "AUDIT-WATCH-SUMMARY")
+			}, 5*time.Second, 5*time.Millisecond)
+			cancel()
+			require.NoError(subtest, <-watchResult)
+
+			require.Equal(subtest, []string{filepath.Join(alphaPath, ".git"), filepath.Join(betaPath, ".git")}[:testCase.expectedAdds], watcher.added)
+			require.Equal(subtest, csvHeader+
+				"alpha,canonical/example,no,main,main,n/a,https,yes,no commits,no,n/a,3\n"+
+				"beta,canonical/example,no,main,main,n/a,https,yes,no commits,no,n/a,3\n"+
+				"AUDIT-WATCH-UPDATE: alpha repo=canonical/example branch=feature default=main in_sync=n/a protocol=https origin_matches_canonical=yes score=3\n"+
+				"AUDIT-WATCH-SUMMARY: 2 repositories, 0 out of sync, 0 not matching canonical\n"+
+				"AUDIT-WATCH-FINAL: 2 repositories\n"+
+				csvHeader+
+				"alpha,canonical/example,no,main,feature,n/a,https,yes,no commits,no,n/a,3\n"+
+				"beta,canonical/example,no,main,main,n/a,https,yes,no commits,no,n/a,3\n", outputBuffer.String())
+			require.True(subtest, strings.HasSuffix(errorBuffer.String(), testCase.expectedErrors), errorBuffer.String())
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package branches
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	fetchSubcommandConstant                      = "fetch"
+	archiveReferenceTemplateConstant             = "refs/archive/%d/%s"
+	archivePushRefspecTemplateConstant           = "%s:%s"
+	logMessageArchivingRemoteBranchConstant      = "Archiving remote branch tip before deletion"
+	logMessageSkippingArchiveDryRunConstant      = "Skipping remote branch archive (dry run)"
+	logMessageArchiveFailedConstant              = "Remote branch archive failed; keeping branch"
+	logFieldArchiveReferenceConstant             = "archive_ref"
+	taskActionArchiveRefsParameterConstant       = "archive_refs"
+	taskActionArchivedBranchesParameterConstant  = "archived_branches"
+	archivedBranchTemplateConstant               = "BRANCHES-ARCHIVED: %s %s -> %s\n"
+	archivedBranchPlanTemplateConstant           = "PLAN-ARCHIVE: %s %s -> %s; then delete %s\n"
+	archivedBranchesTotalTemplateConstant        = "BRANCHES-ARCHIVE-TOTAL: archived=%d across %d repositories\n"
+	archivedBranchesPlannedTotalTemplateConstant = "BRANCHES-ARCHIVE-TOTAL: planned=%d across %d repositories\n"
+)
+
+// ArchivedBranch records a remote branch tip preserved under refs/archive before the branch was deleted.
+// Planned is true when the archive was only planned during a dry run.
+type ArchivedBranch struct {
+	RepositoryPath string
+	Branch         string
+	Reference      string
+	Planned        bool
+}
+
+// ArchivedBranchTally accumulates ArchivedBranch records across repositories.
+type ArchivedBranchTally = utils.Tally[ArchivedBranch]
+
+// ArchiveReference returns the date-partitioned reference that preserves a branch tip archived in the given year.
+func ArchiveReference(year int, branchName string) string {
+	return fmt.Sprintf(archiveReferenceTemplateConstant, year, branchName)
+}
+
+func (service *Service) archiveReference(branchName string) string {
+	return ArchiveReference(service.now().Year(), branchName)
+}
+
+func (service *Service) now() time.Time {
+	clock := service.clock
+	if clock == nil {
+		clock = shared.SystemClock{}
+	}
+	return clock.Now()
+}
+
+// archiveRemoteBranch fetches the remote branch tip and pushes it to its archive reference on the same remote.
+// It reports false when either step fails so the caller keeps the branch.
+func (service *Service) archiveRemoteBranch(executionContext context.Context, remoteName string, branchName string, tip string, archiveReference string, baseFields []zap.Field, options CleanupOptions) bool {
+	archiveFields := append(append([]zap.Field{}, baseFields...), zap.String(logFieldArchiveReferenceConstant, archiveReference))
+	service.logger.Info(logMessageArchivingRemoteBranchConstant, archiveFields...)
+
+	if _, fetchError := service.executor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{fetchSubcommandConstant, remoteName, branchReferencePrefixConstant + branchName},
+		WorkingDirectory: options.WorkingDirectory,
+		Idempotent:       true,
+	}); fetchError != nil {
+		service.logger.Warn(logMessageArchiveFailedConstant, append(archiveFields, zap.Error(fetchError))...)
+		return false
+	}
+
+	if _, pushError := service.executor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{pushSubcommandConstant, remoteName, fmt.Sprintf(archivePushRefspecTemplateConstant, tip, archiveReference)},
+		WorkingDirectory: options.WorkingDirectory,
+		Idempotent:       false,
+	}); pushError != nil {
+		service.logger.Warn(logMessageArchiveFailedConstant, append(archiveFields, zap.Error(pushError))...)
+		return false
+	}
+
+	options.ArchivedBranches.Add(ArchivedBranch{RepositoryPath: options.WorkingDirectory, Branch: branchName, Reference: archiveReference})
+	return true
+}
+
+func reportArchivedBranches(writer io.Writer, tally *ArchivedBranchTally) {
+	records := tally.Records()
+	if len(records) == 0 {
+		return
+	}
+
+	repositories := make(map[string]struct{})
+	planned := false
+	for _, record := range records {
+		if record.Planned {
+			fmt.Fprintf(writer, archivedBranchPlanTemplateConstant, record.RepositoryPath, record.Branch, record.Reference, record.Branch)
+			planned = true
+		} else {
+			fmt.Fprintf(writer, archivedBranchTemplateConstant, record.RepositoryPath, record.Branch, record.Reference)
+		}
+		repositories[record.RepositoryPath] = struct{}{}
+	}
+
+	totalTemplate := archivedBranchesTotalTemplateConstant
+	if planned {
+		totalTemplate = archivedBranchesPlannedTotalTemplateConstant
+	}
+	fmt.Fprintf(writer, totalTemplate, len(records), len(repositories))
+}
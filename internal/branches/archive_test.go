@@ -0,0 +1,133 @@
+package branches_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	branches "github.com/temirov/gix/internal/branches"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+)
+
+type archiveTestClock struct {
+	now time.Time
+}
+
+func (clock archiveTestClock) Now() time.Time {
+	return clock.now
+}
+
+func TestServiceCleanupArchivesRemoteBranches(testInstance *testing.T) {
+	const (
+		branchNameConstant       = "feature/archive"
+		archiveReferenceConstant = "refs/archive/2025/feature/archive"
+	)
+
+	fetchArguments := []string{"fetch", testRemoteNameConstant, "refs/heads/" + branchNameConstant}
+	archivePushArguments := []string{gitPushSubcommandConstant, testRemoteNameConstant, remoteCommitPlaceholderConstant + ":" + archiveReferenceConstant}
+	deleteRemoteArguments := []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, branchNameConstant}
+	deleteLocalArguments := []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, branchNameConstant}
+
+	testCases := []struct {
+		name                string
+		dryRun              bool
+		archivePushError    error
+		expectedRecords     []branches.ArchivedBranch
+		expectedCommandKeys []string
+	}{
+		{
+			name: "archive_precedes_deletion",
+			expectedRecords: []branches.ArchivedBranch{
+				{RepositoryPath: testWorkingDirectoryConstant, Branch: branchNameConstant, Reference: archiveReferenceConstant},
+			},
+			expectedCommandKeys: []string{
+				buildCommandKey(gitCommandLabelConstant, fetchArguments),
+				buildCommandKey(gitCommandLabelConstant, archivePushArguments),
+				buildCommandKey(gitCommandLabelConstant, deleteRemoteArguments),
+				buildCommandKey(gitCommandLabelConstant, deleteLocalArguments),
+			},
+		},
+		{
+			name:             "archive_failure_keeps_branch",
+			archivePushError: errors.New("push rejected"),
+			expectedCommandKeys: []string{
+				buildCommandKey(gitCommandLabelConstant, fetchArguments),
+				buildCommandKey(gitCommandLabelConstant, archivePushArguments),
+			},
+		},
+		{
+			name:   "dry_run_plans_archive",
+			dryRun: true,
+			expectedRecords: []branches.ArchivedBranch{
+				{RepositoryPath: testWorkingDirectoryConstant, Branch: branchNameConstant, Reference: archiveReferenceConstant, Planned: true},
+			},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(fmt.Sprintf(subtestNameTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
+			pullRequestJSON, encodingError := buildPullRequestJSON([]string{branchNameConstant})
+			require.NoError(testInstance, encodingError)
+
+			fakeExecutorInstance := execshelltest.NewExecutor()
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}, execshell.ExecutionResult{StandardOutput: buildRemoteOutput([]string{branchNameConstant})}, nil)
+			registerResponse(fakeExecutorInstance, githubCommandLabelConstant, []string{
+				githubPullRequestSubcommandConstant,
+				githubListSubcommandConstant,
+				githubStateFlagConstant,
+				githubClosedStateConstant,
+				githubJSONFlagConstant,
+				pullRequestJSONFieldNameConstant,
+				githubLimitFlagConstant,
+				strconv.Itoa(testPullRequestLimitConstant),
+			}, execshell.ExecutionResult{StandardOutput: pullRequestJSON}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitListLocalBranchesArguments, execshell.ExecutionResult{StandardOutput: fmt.Sprintf(localBranchRefLineTemplateConstant, branchNameConstant, remoteCommitPlaceholderConstant)}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitBranchDescriptionsArguments, execshell.ExecutionResult{}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, fetchArguments, execshell.ExecutionResult{}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, archivePushArguments, execshell.ExecutionResult{}, testCase.archivePushError)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, deleteRemoteArguments, execshell.ExecutionResult{}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, deleteLocalArguments, execshell.ExecutionResult{}, nil)
+
+			service, serviceError := branches.NewService(zap.NewNop(), fakeExecutorInstance, nil)
+			require.NoError(testInstance, serviceError)
+			service.WithClock(archiveTestClock{now: time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)})
+
+			archivedBranches := &branches.ArchivedBranchTally{}
+			cleanupError := service.Cleanup(context.Background(), branches.CleanupOptions{
+				RemoteName:       testRemoteNameConstant,
+				PullRequestLimit: testPullRequestLimitConstant,
+				DryRun:           testCase.dryRun,
+				WorkingDirectory: testWorkingDirectoryConstant,
+				AssumeYes:        true,
+				ArchiveRefs:      true,
+				ArchivedBranches: archivedBranches,
+			})
+			require.NoError(testInstance, cleanupError)
+			require.Equal(testInstance, testCase.expectedRecords, archivedBranches.Records())
+
+			mutatingCommandKeys := []string{}
+			for _, executed := range fakeExecutorInstance.Executed() {
+				if executed.Details.Arguments[0] == "fetch" || executed.Details.Arguments[0] == gitPushSubcommandConstant || executed.Details.Arguments[0] == gitBranchSubcommandConstant {
+					mutatingCommandKeys = append(mutatingCommandKeys, executed.Key())
+				}
+			}
+			if testCase.expectedCommandKeys == nil {
+				require.Empty(testInstance, mutatingCommandKeys)
+				return
+			}
+			require.Equal(testInstance, testCase.expectedCommandKeys, mutatingCommandKeys)
+		})
+	}
+}
+
+func TestArchiveReference(testInstance *testing.T) {
+	require.Equal(testInstance, "refs/archive/2024/feature/one", branches.ArchiveReference(2024, "feature/one"))
+}
@@ -0,0 +1,120 @@
+package branches
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/workflow"
+)
+
+const (
+	logMessageRepositoryDeletesMergedBranches    = "Repository deletes head branches on merge; remote deletions are rarely needed"
+	logMessageRepositoryUsesMergeQueue           = "Repository uses a merge queue; queue branches appear on the remote while pull requests merge"
+	logMessageSkippingRemoteBranchAutoDelete     = "Skipping remote branch deletion (GitHub deletes head branches on merge)"
+	logMessageRepositorySettingsUnavailable      = "Repository settings unavailable; auto-delete and merge queue notes skipped"
+	logFieldDeleteBranchOnMergeConstant          = "delete_branch_on_merge"
+	logFieldMergeQueueConstant                   = "merge_queue"
+	logFieldRespectAutoDeleteConstant            = "respect_auto_delete"
+	localBranchDeletionPromptTemplateConstant    = "Delete local pull request branch '%s' (remote '%s' is left to GitHub auto-delete)? [y/N] "
+	taskActionRespectAutoDeleteParameterConstant = "respect_auto_delete"
+)
+
+// leavesRemoteDeletionsToGitHub reports whether remote deletions are skipped because GitHub removes merged branches itself.
+func (options CleanupOptions) leavesRemoteDeletionsToGitHub() bool {
+	return options.RespectAutoDelete && options.DeleteBranchOnMerge
+}
+
+func (service *Service) noteRepositorySettings(options CleanupOptions) {
+	baseFields := []zap.Field{
+		zap.String(logFieldRepositoryConstant, options.Repository),
+		zap.String(logFieldWorkingDirectoryConstant, options.WorkingDirectory),
+		zap.Bool(logFieldRespectAutoDeleteConstant, options.RespectAutoDelete),
+	}
+	if options.DeleteBranchOnMerge {
+		service.logger.Info(logMessageRepositoryDeletesMergedBranches, append(baseFields, zap.Bool(logFieldDeleteBranchOnMergeConstant, true))...)
+	}
+	if options.MergeQueueEnabled {
+		service.logger.Info(logMessageRepositoryUsesMergeQueue, append(baseFields, zap.Bool(logFieldMergeQueueConstant, true))...)
+	}
+}
+
+// deleteLocalBranchOnly removes the local branch of a closed pull request and leaves its remote branch to GitHub.
+func (service *Service) deleteLocalBranchOnly(executionContext context.Context, remoteName string, branchName string, existsInRemote bool, existsLocally bool, confirmation *branchDeletionConfirmation, options CleanupOptions) branchDeletionOutcome {
+	baseFields := []zap.Field{
+		zap.String(logFieldBranchNameConstant, branchName),
+		zap.String(logFieldRemoteNameConstant, remoteName),
+		zap.String(logFieldWorkingDirectoryConstant, options.WorkingDirectory),
+	}
+
+	if existsInRemote {
+		service.logger.Info(logMessageSkippingRemoteBranchAutoDelete, baseFields...)
+	}
+	if !existsLocally {
+		service.logger.Info(logMessageSkippingMissingLocalBranch, baseFields...)
+		return branchDeletionOutcome{}
+	}
+
+	if options.DryRun {
+		service.logger.Info(logMessageSkippingLocalBranchDryRunConstant,
+			append(baseFields, zap.Bool(logFieldDryRunConstant, true))...,
+		)
+		options.closedAgeRecorder.recordPlannedDeletion(branchName)
+		return branchDeletionOutcome{planned: true}
+	}
+
+	if confirmation != nil {
+		allowed, confirmationError := confirmation.ConfirmLocal(branchName, remoteName)
+		if confirmationError != nil {
+			service.logger.Warn(logMessageDeletionPromptFailedConstant,
+				append(baseFields, zap.Error(confirmationError))...,
+			)
+			return branchDeletionOutcome{}
+		}
+		if !allowed {
+			service.logger.Info(logMessageDeletionSkippedByUserConstant, baseFields...)
+			return branchDeletionOutcome{}
+		}
+	}
+
+	return branchDeletionOutcome{localDeleted: service.deleteLocalBranch(executionContext, branchName, baseFields, options)}
+}
+
+func (confirmation *branchDeletionConfirmation) ConfirmLocal(branchName string, remoteName string) (bool, error) {
+	if confirmation == nil || confirmation.assumeYes || confirmation.confirmAll || confirmation.prompter == nil {
+		return true, nil
+	}
+
+	return confirmation.confirmPrompt(fmt.Sprintf(localBranchDeletionPromptTemplateConstant, branchName, remoteName))
+}
+
+// repositoryAutoDeleteSettings returns the delete-branch-on-merge and merge queue settings of the repository.
+// Audited inspections already carry them; otherwise the repository is looked up with the GitHub CLI, whose
+// gh repo view lookup cannot report merge queues.
+func repositoryAutoDeleteSettings(executionContext context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, repositoryName string) (bool, bool) {
+	inspection := repository.Inspection
+	switch inspection.DeleteBranchOnMerge {
+	case audit.TernaryValueYes, audit.TernaryValueNo:
+		return inspection.DeleteBranchOnMerge == audit.TernaryValueYes, inspection.MergeQueue == audit.TernaryValueYes
+	case audit.TernaryValueOffline:
+		return false, false
+	}
+	if environment.GitHubClient == nil || len(strings.TrimSpace(repositoryName)) == 0 {
+		return false, false
+	}
+
+	metadata, metadataError := environment.GitHubClient.ResolveRepoMetadata(executionContext, repositoryName)
+	if metadataError != nil {
+		if environment.Logger != nil {
+			environment.Logger.Debug(logMessageRepositorySettingsUnavailable,
+				zap.String(logFieldRepositoryConstant, repositoryName),
+				zap.Error(metadataError),
+			)
+		}
+		return false, false
+	}
+	return metadata.DeleteBranchOnMerge, metadata.MergeQueueKnown && metadata.MergeQueueEnabled
+}
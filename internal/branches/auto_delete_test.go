@@ -0,0 +1,121 @@
+package branches_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	branches "github.com/temirov/gix/internal/branches"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+)
+
+const (
+	autoDeleteRemoteBranchConstant       = "feature/merged"
+	autoDeleteLocalOnlyBranchConstant    = "feature/auto-deleted"
+	autoDeleteMissingBranchConstant      = "feature/missing"
+	autoDeleteNoteLogMessageConstant     = "Repository deletes head branches on merge; remote deletions are rarely needed"
+	mergeQueueNoteLogMessageConstant     = "Repository uses a merge queue; queue branches appear on the remote while pull requests merge"
+	skippingAutoDeleteLogMessageConstant = "Skipping remote branch deletion (GitHub deletes head branches on merge)"
+)
+
+func TestServiceCleanupRespectsAutoDelete(testInstance *testing.T) {
+	testCases := []struct {
+		name                  string
+		options               branches.CleanupOptions
+		expectedRemoteDeletes []string
+		expectedLocalDeletes  []string
+		expectedLogMessages   []string
+		unexpectedLogMessages []string
+	}{
+		{
+			name:                  "notes_only_without_flag",
+			options:               branches.CleanupOptions{DeleteBranchOnMerge: true, MergeQueueEnabled: true},
+			expectedRemoteDeletes: []string{autoDeleteRemoteBranchConstant},
+			expectedLocalDeletes:  []string{autoDeleteRemoteBranchConstant},
+			expectedLogMessages:   []string{autoDeleteNoteLogMessageConstant, mergeQueueNoteLogMessageConstant},
+			unexpectedLogMessages: []string{skippingAutoDeleteLogMessageConstant},
+		},
+		{
+			name:                  "respect_auto_delete_cleans_local_branches_only",
+			options:               branches.CleanupOptions{DeleteBranchOnMerge: true, RespectAutoDelete: true},
+			expectedRemoteDeletes: []string{},
+			expectedLocalDeletes:  []string{autoDeleteRemoteBranchConstant, autoDeleteLocalOnlyBranchConstant},
+			expectedLogMessages:   []string{autoDeleteNoteLogMessageConstant, skippingAutoDeleteLogMessageConstant, skippingMissingLogMessageConstant},
+			unexpectedLogMessages: []string{mergeQueueNoteLogMessageConstant},
+		},
+		{
+			name:                  "respect_auto_delete_ignored_without_setting",
+			options:               branches.CleanupOptions{RespectAutoDelete: true},
+			expectedRemoteDeletes: []string{autoDeleteRemoteBranchConstant},
+			expectedLocalDeletes:  []string{autoDeleteRemoteBranchConstant},
+			unexpectedLogMessages: []string{autoDeleteNoteLogMessageConstant, skippingAutoDeleteLogMessageConstant},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(fmt.Sprintf(subtestNameTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
+			pullRequestBranches := []string{autoDeleteRemoteBranchConstant, autoDeleteLocalOnlyBranchConstant, autoDeleteMissingBranchConstant}
+			pullRequestJSON, encodingError := buildPullRequestJSON(pullRequestBranches)
+			require.NoError(testInstance, encodingError)
+
+			fakeExecutorInstance := execshelltest.NewExecutor()
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}, execshell.ExecutionResult{StandardOutput: buildRemoteOutput([]string{autoDeleteRemoteBranchConstant})}, nil)
+			registerResponse(fakeExecutorInstance, githubCommandLabelConstant, []string{
+				githubPullRequestSubcommandConstant,
+				githubListSubcommandConstant,
+				githubStateFlagConstant,
+				githubClosedStateConstant,
+				githubJSONFlagConstant,
+				pullRequestJSONFieldNameConstant,
+				githubLimitFlagConstant,
+				strconv.Itoa(testPullRequestLimitConstant),
+			}, execshell.ExecutionResult{StandardOutput: pullRequestJSON}, nil)
+			localBranchListing := fmt.Sprintf(localBranchRefLineTemplateConstant, autoDeleteRemoteBranchConstant, remoteCommitPlaceholderConstant) +
+				fmt.Sprintf(localBranchRefLineTemplateConstant, autoDeleteLocalOnlyBranchConstant, remoteCommitPlaceholderConstant)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitListLocalBranchesArguments, execshell.ExecutionResult{StandardOutput: localBranchListing}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitBranchDescriptionsArguments, execshell.ExecutionResult{}, nil)
+			for _, branchName := range pullRequestBranches {
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, branchName}, execshell.ExecutionResult{}, nil)
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, branchName}, execshell.ExecutionResult{}, nil)
+			}
+
+			logCore, observedLogs := observer.New(zap.DebugLevel)
+			service, serviceError := branches.NewService(zap.New(logCore), fakeExecutorInstance, nil)
+			require.NoError(testInstance, serviceError)
+
+			options := testCase.options
+			options.RemoteName = testRemoteNameConstant
+			options.PullRequestLimit = testPullRequestLimitConstant
+			options.WorkingDirectory = testWorkingDirectoryConstant
+			options.AssumeYes = true
+			require.NoError(testInstance, service.Cleanup(context.Background(), options))
+
+			remoteDeletes := []string{}
+			localDeletes := []string{}
+			for _, executed := range fakeExecutorInstance.Executed() {
+				if len(executed.Details.Arguments) == 4 && executed.Details.Arguments[0] == gitPushSubcommandConstant {
+					remoteDeletes = append(remoteDeletes, executed.Details.Arguments[3])
+				}
+				if len(executed.Details.Arguments) == 3 && executed.Details.Arguments[0] == gitBranchSubcommandConstant && executed.Details.Arguments[1] == gitForceDeleteFlagConstant {
+					localDeletes = append(localDeletes, executed.Details.Arguments[2])
+				}
+			}
+			require.Equal(testInstance, testCase.expectedRemoteDeletes, remoteDeletes)
+			require.Equal(testInstance, testCase.expectedLocalDeletes, localDeletes)
+
+			for _, expectedMessage := range testCase.expectedLogMessages {
+				require.True(testInstance, containsLogMessage(observedLogs.All(), expectedMessage), fmt.Sprintf(expectedLogMessageTemplateConstant, expectedMessage))
+			}
+			for _, unexpectedMessage := range testCase.unexpectedLogMessages {
+				require.False(testInstance, containsLogMessage(observedLogs.All(), unexpectedMessage), fmt.Sprintf(unexpectedLogMessageTemplateConstant, unexpectedMessage))
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package branches
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	baseFlagConstant                        = "--base"
+	flagBaseNameConstant                    = "base"
+	flagBaseDescriptionConstant             = "Only consider closed pull requests targeting this base branch (repeatable; default: every base)"
+	taskActionBaseBranchesParameterConstant = "base_branches"
+	logFieldBaseBranchesConstant            = "base_branches"
+	baseFilterSummaryTemplateConstant       = "BRANCHES-BASE-FILTER: considered only pull requests targeting %s\n"
+	baseFilterSummarySeparatorConstant      = ", "
+	baseFilterListSeparatorConstant         = ","
+	baseFilterOptionTypeErrorTemplate       = "option %s must be a list of branch names, received %v"
+)
+
+// normalizeBaseBranches trims the base branch names, dropping blanks and duplicates while keeping their order.
+func normalizeBaseBranches(baseBranches []string) []string {
+	normalized := make([]string, 0, len(baseBranches))
+	seen := map[string]struct{}{}
+	for _, baseBranch := range baseBranches {
+		trimmed := strings.TrimSpace(baseBranch)
+		if len(trimmed) == 0 {
+			continue
+		}
+		if _, duplicate := seen[trimmed]; duplicate {
+			continue
+		}
+		seen[trimmed] = struct{}{}
+		normalized = append(normalized, trimmed)
+	}
+	if len(normalized) == 0 {
+		return nil
+	}
+	return normalized
+}
+
+// reportBaseFilter states the base branches the cleanup was restricted to; nothing is written without a filter.
+func reportBaseFilter(writer io.Writer, baseBranches []string) {
+	if len(baseBranches) == 0 {
+		return
+	}
+	fmt.Fprintf(writer, baseFilterSummaryTemplateConstant, strings.Join(baseBranches, baseFilterSummarySeparatorConstant))
+}
+
+// baseBranchesValue reads the base_branches action option, accepting a YAML list or a comma-separated string.
+func baseBranchesValue(value any) ([]string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return normalizeBaseBranches(typed), nil
+	case []any:
+		baseBranches := make([]string, 0, len(typed))
+		for _, entry := range typed {
+			baseBranches = append(baseBranches, stringify(entry))
+		}
+		return normalizeBaseBranches(baseBranches), nil
+	case string:
+		return normalizeBaseBranches(strings.Split(typed, baseFilterListSeparatorConstant)), nil
+	default:
+		return nil, fmt.Errorf(baseFilterOptionTypeErrorTemplate, taskActionBaseBranchesParameterConstant, value)
+	}
+}
@@ -0,0 +1,174 @@
+package branches_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	branches "github.com/temirov/gix/internal/branches"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/workflow"
+)
+
+const (
+	baseFilterFlagConstant          = "--base"
+	baseFilterMainBranchConstant    = "main"
+	baseFilterReleaseBranchConstant = "release"
+	baseFilterMainHeadConstant      = "feature/into-main"
+	baseFilterReleaseHeadConstant   = "feature/into-release"
+)
+
+func TestServiceCleanupFiltersPullRequestsByBase(testInstance *testing.T) {
+	testCases := []struct {
+		name                   string
+		baseBranches           []string
+		expectedListArguments  [][]string
+		expectedRemoteDeletion []string
+	}{
+		{
+			name:         "single_base_passes_flag",
+			baseBranches: []string{baseFilterMainBranchConstant},
+			expectedListArguments: [][]string{
+				buildBaseFilteredListArguments(baseFilterMainBranchConstant),
+			},
+			expectedRemoteDeletion: []string{baseFilterMainHeadConstant},
+		},
+		{
+			name:         "multiple_bases_list_each_base",
+			baseBranches: []string{baseFilterMainBranchConstant, " " + baseFilterReleaseBranchConstant, baseFilterMainBranchConstant},
+			expectedListArguments: [][]string{
+				buildBaseFilteredListArguments(baseFilterMainBranchConstant),
+				buildBaseFilteredListArguments(baseFilterReleaseBranchConstant),
+			},
+			expectedRemoteDeletion: []string{baseFilterMainHeadConstant, baseFilterReleaseHeadConstant},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(fmt.Sprintf(subtestNameTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
+			mainJSON, mainEncodingError := buildPullRequestJSON([]string{baseFilterMainHeadConstant})
+			require.NoError(testInstance, mainEncodingError)
+			releaseJSON, releaseEncodingError := buildPullRequestJSON([]string{baseFilterReleaseHeadConstant})
+			require.NoError(testInstance, releaseEncodingError)
+
+			fakeExecutorInstance := execshelltest.NewExecutor()
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}, execshell.ExecutionResult{StandardOutput: buildRemoteOutput([]string{baseFilterMainHeadConstant, baseFilterReleaseHeadConstant})}, nil)
+			registerResponse(fakeExecutorInstance, githubCommandLabelConstant, buildBaseFilteredListArguments(baseFilterMainBranchConstant), execshell.ExecutionResult{StandardOutput: mainJSON}, nil)
+			registerResponse(fakeExecutorInstance, githubCommandLabelConstant, buildBaseFilteredListArguments(baseFilterReleaseBranchConstant), execshell.ExecutionResult{StandardOutput: releaseJSON}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitListLocalBranchesArguments, execshell.ExecutionResult{}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitBranchDescriptionsArguments, execshell.ExecutionResult{}, nil)
+			for _, branchName := range []string{baseFilterMainHeadConstant, baseFilterReleaseHeadConstant} {
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, branchName}, execshell.ExecutionResult{}, nil)
+			}
+
+			service, serviceError := branches.NewService(zap.NewNop(), fakeExecutorInstance, nil)
+			require.NoError(testInstance, serviceError)
+
+			require.NoError(testInstance, service.Cleanup(context.Background(), branches.CleanupOptions{
+				RemoteName:       testRemoteNameConstant,
+				PullRequestLimit: testPullRequestLimitConstant,
+				WorkingDirectory: testWorkingDirectoryConstant,
+				AssumeYes:        true,
+				BaseBranches:     testCase.baseBranches,
+			}))
+
+			listArguments := [][]string{}
+			remoteDeletions := []string{}
+			for _, executed := range fakeExecutorInstance.Executed() {
+				if len(executed.Details.Arguments) > 1 && executed.Details.Arguments[0] == githubPullRequestSubcommandConstant {
+					listArguments = append(listArguments, executed.Details.Arguments)
+				}
+				if len(executed.Details.Arguments) == 4 && executed.Details.Arguments[0] == gitPushSubcommandConstant {
+					remoteDeletions = append(remoteDeletions, executed.Details.Arguments[3])
+				}
+			}
+			require.Equal(testInstance, testCase.expectedListArguments, listArguments)
+			require.Equal(testInstance, testCase.expectedRemoteDeletion, remoteDeletions)
+		})
+	}
+}
+
+func TestCommandBaseFilterOption(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configuration        branches.CommandConfiguration
+		arguments            []string
+		expectedBaseBranches any
+		expectedOutput       string
+	}{
+		{
+			name:          "no_filter_by_default",
+			configuration: branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5},
+			arguments:     []string{commandRootFlagConstant, "/tmp/base"},
+		},
+		{
+			name:                 "flags_restrict_bases",
+			configuration:        branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5, BaseBranches: []string{baseFilterReleaseBranchConstant}},
+			arguments:            []string{baseFilterFlagConstant, baseFilterMainBranchConstant, baseFilterFlagConstant, "develop", commandRootFlagConstant, "/tmp/base"},
+			expectedBaseBranches: []string{baseFilterMainBranchConstant, "develop"},
+			expectedOutput:       "BRANCHES-BASE-FILTER: considered only pull requests targeting main, develop\n",
+		},
+		{
+			name:                 "configuration_restricts_bases",
+			configuration:        branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5, BaseBranches: []string{baseFilterReleaseBranchConstant}},
+			arguments:            []string{commandRootFlagConstant, "/tmp/base"},
+			expectedBaseBranches: []string{baseFilterReleaseBranchConstant},
+			expectedOutput:       "BRANCHES-BASE-FILTER: considered only pull requests targeting release\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := branches.CommandBuilder{
+				LoggerProvider:        func() *zap.Logger { return zap.NewNop() },
+				GitExecutor:           &stubGitExecutor{},
+				GitManager:            stubGitRepositoryManager{},
+				PrompterFactory:       func(*cobra.Command) shared.ConfirmationPrompter { return stubPrompter{} },
+				ConfigurationProvider: func() branches.CommandConfiguration { return testCase.configuration },
+				TaskRunnerFactory: func(workflow.Dependencies) branches.TaskRunnerExecutor {
+					return runner
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindGlobalBranchFlags(command)
+			command.SetContext(context.Background())
+			outputBuffer := &bytes.Buffer{}
+			command.SetOut(outputBuffer)
+			command.SetArgs(testCase.arguments)
+
+			require.NoError(subtest, command.Execute())
+
+			action := runner.definitions[0].Actions[0]
+			require.Equal(subtest, testCase.expectedBaseBranches, action.Options["base_branches"])
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+		})
+	}
+}
+
+func buildBaseFilteredListArguments(baseBranch string) []string {
+	return []string{
+		githubPullRequestSubcommandConstant,
+		githubListSubcommandConstant,
+		githubStateFlagConstant,
+		githubClosedStateConstant,
+		baseFilterFlagConstant,
+		baseBranch,
+		githubJSONFlagConstant,
+		pullRequestJSONFieldNameConstant,
+		githubLimitFlagConstant,
+		strconv.Itoa(testPullRequestLimitConstant),
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/dependencies"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 	rootutils "github.com/temirov/gix/internal/utils/roots"
 	"github.com/temirov/gix/internal/workflow"
@@ -83,12 +84,19 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		}
 	}
 
-	repositoryRoots, rootsError := rootutils.Resolve(command, remainingArgs, configuration.RepositoryRoots)
+	repositoryRoots, rootsError := rootutils.ResolveWithoutPositional(command, remainingArgs, configuration.RepositoryRoots)
 	if rootsError != nil {
 		return rootsError
 	}
 
 	logger := builder.resolveLogger()
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), struct {
+		Configuration   CommandConfiguration
+		BranchName      string
+		RemoteName      string
+		DryRun          bool
+		RepositoryRoots []string
+	}{Configuration: configuration, BranchName: branchName, RemoteName: remoteName, DryRun: dryRun, RepositoryRoots: repositoryRoots})
 	humanReadableLogging := false
 	if builder.HumanReadableLoggingProvider != nil {
 		humanReadableLogging = builder.HumanReadableLoggingProvider()
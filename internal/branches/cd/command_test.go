@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/temirov/gix/internal/execshell/execshelltest"
 	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 	"github.com/temirov/gix/internal/workflow"
@@ -33,7 +34,7 @@ func TestCommandRequiresBranchArgument(t *testing.T) {
 		ConfigurationProvider: func() CommandConfiguration {
 			return CommandConfiguration{}
 		},
-		GitExecutor: &stubGitExecutor{},
+		GitExecutor: execshelltest.NewPermissiveExecutor(),
 	}
 	command, err := builder.Build()
 	require.NoError(t, err)
@@ -43,7 +44,7 @@ func TestCommandRequiresBranchArgument(t *testing.T) {
 
 func TestCommandExecutesAcrossRoots(t *testing.T) {
 	temporaryRoot := t.TempDir()
-	executor := &stubGitExecutor{}
+	executor := execshelltest.NewPermissiveExecutor()
 	runner := &recordingTaskRunner{}
 	builder := CommandBuilder{
 		LoggerProvider: func() *zap.Logger { return zap.NewNop() },
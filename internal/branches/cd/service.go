@@ -141,6 +141,7 @@ func (service *Service) Change(executionContext context.Context, options Options
 			Arguments:            fetchArguments,
 			WorkingDirectory:     trimmedRepositoryPath,
 			EnvironmentVariables: environment,
+			Idempotent:           true,
 		}); err != nil {
 			summary := summarizeCommandError(err)
 			var warningMessage string
@@ -178,6 +179,7 @@ func (service *Service) Change(executionContext context.Context, options Options
 			Arguments:            switchArguments,
 			WorkingDirectory:     trimmedRepositoryPath,
 			EnvironmentVariables: environment,
+			Idempotent:           false,
 		}); err != nil {
 			createSummary := summarizeCommandError(err)
 			if shouldTrackRemote {
@@ -193,6 +195,7 @@ func (service *Service) Change(executionContext context.Context, options Options
 			Arguments:            []string{gitPullSubcommandConstant, gitPullRebaseFlagConstant},
 			WorkingDirectory:     trimmedRepositoryPath,
 			EnvironmentVariables: environment,
+			Idempotent:           false,
 		}); err != nil {
 			warningMessage := fmt.Sprintf(pullWarningTemplateConstant, summarizeCommandError(err))
 			service.logger.Warn(
@@ -217,6 +220,7 @@ func (service *Service) trySwitch(executionContext context.Context, repositoryPa
 		Arguments:            []string{gitSwitchSubcommandConstant, branchName},
 		WorkingDirectory:     repositoryPath,
 		EnvironmentVariables: environment,
+		Idempotent:           false,
 	})
 	return err
 }
@@ -231,6 +235,7 @@ func (service *Service) enumerateRemotes(executionContext context.Context, repos
 		Arguments:            []string{gitRemoteSubcommandConstant},
 		WorkingDirectory:     repositoryPath,
 		EnvironmentVariables: environment,
+		Idempotent:           true,
 	})
 	if err != nil {
 		return remoteEnumeration{}, err
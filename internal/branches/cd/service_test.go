@@ -8,40 +8,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
 )
 
-type stubGitExecutor struct {
-	recorded  []execshell.CommandDetails
-	responses []stubGitResponse
-}
-
-type stubGitResponse struct {
-	result execshell.ExecutionResult
-	err    error
-}
-
-func (executor *stubGitExecutor) ExecuteGit(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	executor.recorded = append(executor.recorded, details)
-	if len(executor.responses) == 0 {
-		return execshell.ExecutionResult{}, nil
-	}
-
-	next := executor.responses[0]
-	executor.responses = executor.responses[1:]
-	if next.err != nil {
-		return execshell.ExecutionResult{}, next.err
-	}
-	return next.result, nil
-}
-
-func (executor *stubGitExecutor) ExecuteGitHubCLI(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return execshell.ExecutionResult{}, nil
-}
-
 func TestChangeExecutesExpectedCommands(t *testing.T) {
-	executor := &stubGitExecutor{responses: []stubGitResponse{
-		{result: execshell.ExecutionResult{StandardOutput: "origin\n"}},
-	}}
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("remote").ReturnOutput("origin\n")
 	service, err := NewService(ServiceDependencies{GitExecutor: executor})
 	require.NoError(t, err)
 
@@ -51,20 +23,18 @@ func TestChangeExecutesExpectedCommands(t *testing.T) {
 	require.Equal(t, "feature", result.BranchName)
 	require.False(t, result.BranchCreated)
 	require.Empty(t, result.Warnings)
-	require.Len(t, executor.recorded, 4)
+	require.Len(t, executor.Executed(), 4)
 
-	require.Equal(t, []string{"remote"}, executor.recorded[0].Arguments)
-	require.Equal(t, []string{"fetch", "--prune", "origin"}, executor.recorded[1].Arguments)
-	require.Equal(t, []string{"switch", "feature"}, executor.recorded[2].Arguments)
-	require.Equal(t, []string{"pull", "--rebase"}, executor.recorded[3].Arguments)
+	require.Equal(t, []string{"remote"}, executor.Executed()[0].Details.Arguments)
+	require.Equal(t, []string{"fetch", "--prune", "origin"}, executor.Executed()[1].Details.Arguments)
+	require.Equal(t, []string{"switch", "feature"}, executor.Executed()[2].Details.Arguments)
+	require.Equal(t, []string{"pull", "--rebase"}, executor.Executed()[3].Details.Arguments)
 }
 
 func TestChangeCreatesBranchWhenMissing(t *testing.T) {
-	executor := &stubGitExecutor{responses: []stubGitResponse{
-		{result: execshell.ExecutionResult{StandardOutput: "upstream\n"}},
-		{},
-		{err: commandFailedError("error: pathspec 'feature' did not match any file(s) known to git")},
-	}}
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("remote").ReturnOutput("upstream\n")
+	executor.OnGit("switch", "feature").Fail(commandFailedError("error: pathspec 'feature' did not match any file(s) known to git"))
 	service, err := NewService(ServiceDependencies{GitExecutor: executor})
 	require.NoError(t, err)
 
@@ -73,12 +43,12 @@ func TestChangeCreatesBranchWhenMissing(t *testing.T) {
 	require.True(t, result.BranchCreated)
 	require.Empty(t, result.Warnings)
 
-	require.Len(t, executor.recorded, 5)
-	require.Equal(t, []string{"switch", "-c", "feature", "--track", "upstream/feature"}, executor.recorded[3].Arguments)
+	require.Len(t, executor.Executed(), 5)
+	require.Equal(t, []string{"switch", "-c", "feature", "--track", "upstream/feature"}, executor.Executed()[3].Details.Arguments)
 }
 
 func TestChangeValidatesInputs(t *testing.T) {
-	service, err := NewService(ServiceDependencies{GitExecutor: &stubGitExecutor{}})
+	service, err := NewService(ServiceDependencies{GitExecutor: execshelltest.NewPermissiveExecutor()})
 	require.NoError(t, err)
 
 	_, changeError := service.Change(context.Background(), Options{BranchName: "main"})
@@ -89,10 +59,9 @@ func TestChangeValidatesInputs(t *testing.T) {
 }
 
 func TestChangeWarnsWhenFetchFails(t *testing.T) {
-	executor := &stubGitExecutor{responses: []stubGitResponse{
-		{result: execshell.ExecutionResult{StandardOutput: "origin\n"}},
-		{err: commandFailedError("ERROR: Repository not found.\nfatal: Could not read from remote repository.\nPlease make sure you have the correct access rights\nand the repository exists.")},
-	}}
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("remote").ReturnOutput("origin\n")
+	executor.OnGit("fetch", "--prune", "origin").Fail(commandFailedError("ERROR: Repository not found.\nfatal: Could not read from remote repository.\nPlease make sure you have the correct access rights\nand the repository exists."))
 	service, err := NewService(ServiceDependencies{GitExecutor: executor})
 	require.NoError(t, err)
 
@@ -100,17 +69,16 @@ func TestChangeWarnsWhenFetchFails(t *testing.T) {
 	require.NoError(t, changeError)
 	require.Len(t, result.Warnings, 1)
 	require.Equal(t, "WARNING: no remote counterpart for ns-rewrite repo", result.Warnings[0])
-	require.Len(t, executor.recorded, 3)
-	require.Equal(t, []string{"remote"}, executor.recorded[0].Arguments)
-	require.Equal(t, []string{"fetch", "--prune", "origin"}, executor.recorded[1].Arguments)
-	require.Equal(t, []string{"switch", "main"}, executor.recorded[2].Arguments)
+	require.Len(t, executor.Executed(), 3)
+	require.Equal(t, []string{"remote"}, executor.Executed()[0].Details.Arguments)
+	require.Equal(t, []string{"fetch", "--prune", "origin"}, executor.Executed()[1].Details.Arguments)
+	require.Equal(t, []string{"switch", "main"}, executor.Executed()[2].Details.Arguments)
 }
 
 func TestChangeWarnsWithGenericFetchError(t *testing.T) {
-	executor := &stubGitExecutor{responses: []stubGitResponse{
-		{result: execshell.ExecutionResult{StandardOutput: "origin\n"}},
-		{err: commandFailedError("fatal: unexpected network failure")},
-	}}
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("remote").ReturnOutput("origin\n")
+	executor.OnGit("fetch", "--prune", "origin").Fail(commandFailedError("fatal: unexpected network failure"))
 	service, err := NewService(ServiceDependencies{GitExecutor: executor})
 	require.NoError(t, err)
 
@@ -121,10 +89,9 @@ func TestChangeWarnsWithGenericFetchError(t *testing.T) {
 }
 
 func TestChangePreservesFetchMessageWhenGitReportsCouldNotFetch(t *testing.T) {
-	executor := &stubGitExecutor{responses: []stubGitResponse{
-		{result: execshell.ExecutionResult{StandardOutput: "origin\n"}},
-		{err: commandFailedError("fatal: Could not fetch origin")},
-	}}
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("remote").ReturnOutput("origin\n")
+	executor.OnGit("fetch", "--prune", "origin").Fail(commandFailedError("fatal: Could not fetch origin"))
 	service, err := NewService(ServiceDependencies{GitExecutor: executor})
 	require.NoError(t, err)
 
@@ -135,12 +102,9 @@ func TestChangePreservesFetchMessageWhenGitReportsCouldNotFetch(t *testing.T) {
 }
 
 func TestChangeWarnsWhenPullFails(t *testing.T) {
-	executor := &stubGitExecutor{responses: []stubGitResponse{
-		{result: execshell.ExecutionResult{StandardOutput: "origin\n"}},
-		{result: execshell.ExecutionResult{}},
-		{},
-		{err: commandFailedError("fatal: Could not read from remote repository\nPlease make sure you have the correct access rights.")},
-	}}
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("remote").ReturnOutput("origin\n")
+	executor.OnGit("pull", "--rebase").Fail(commandFailedError("fatal: Could not read from remote repository\nPlease make sure you have the correct access rights."))
 	service, err := NewService(ServiceDependencies{GitExecutor: executor})
 	require.NoError(t, err)
 
@@ -148,14 +112,13 @@ func TestChangeWarnsWhenPullFails(t *testing.T) {
 	require.NoError(t, changeError)
 	require.Len(t, result.Warnings, 1)
 	require.Equal(t, "PULL-SKIP: fatal: Could not read from remote repository", result.Warnings[0])
-	require.Len(t, executor.recorded, 4)
-	require.Equal(t, []string{"pull", "--rebase"}, executor.recorded[3].Arguments)
+	require.Len(t, executor.Executed(), 4)
+	require.Equal(t, []string{"pull", "--rebase"}, executor.Executed()[3].Details.Arguments)
 }
 
 func TestChangeFetchesAllWhenDefaultRemoteMissing(t *testing.T) {
-	executor := &stubGitExecutor{responses: []stubGitResponse{
-		{result: execshell.ExecutionResult{StandardOutput: "upstream\n"}},
-	}}
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("remote").ReturnOutput("upstream\n")
 	service, err := NewService(ServiceDependencies{GitExecutor: executor})
 	require.NoError(t, err)
 
@@ -164,15 +127,13 @@ func TestChangeFetchesAllWhenDefaultRemoteMissing(t *testing.T) {
 	require.False(t, result.BranchCreated)
 	require.Empty(t, result.Warnings)
 
-	require.Len(t, executor.recorded, 4)
-	require.Equal(t, []string{"remote"}, executor.recorded[0].Arguments)
-	require.Equal(t, []string{"fetch", "--all", "--prune"}, executor.recorded[1].Arguments)
+	require.Len(t, executor.Executed(), 4)
+	require.Equal(t, []string{"remote"}, executor.Executed()[0].Details.Arguments)
+	require.Equal(t, []string{"fetch", "--all", "--prune"}, executor.Executed()[1].Details.Arguments)
 }
 
 func TestChangeSkipsNetworkWhenNoRemotesDetected(t *testing.T) {
-	executor := &stubGitExecutor{responses: []stubGitResponse{
-		{result: execshell.ExecutionResult{}},
-	}}
+	executor := execshelltest.NewPermissiveExecutor()
 	service, err := NewService(ServiceDependencies{GitExecutor: executor})
 	require.NoError(t, err)
 
@@ -181,20 +142,21 @@ func TestChangeSkipsNetworkWhenNoRemotesDetected(t *testing.T) {
 	require.False(t, result.BranchCreated)
 	require.Empty(t, result.Warnings)
 
-	require.Len(t, executor.recorded, 2)
-	require.Equal(t, []string{"remote"}, executor.recorded[0].Arguments)
-	require.Equal(t, []string{"switch", "stable"}, executor.recorded[1].Arguments)
-	for _, recorded := range executor.recorded {
-		if len(recorded.Arguments) == 0 {
+	require.Len(t, executor.Executed(), 2)
+	require.Equal(t, []string{"remote"}, executor.Executed()[0].Details.Arguments)
+	require.Equal(t, []string{"switch", "stable"}, executor.Executed()[1].Details.Arguments)
+	for _, recordedArguments := range executor.ExecutedArguments(execshell.CommandGit) {
+		if len(recordedArguments) == 0 {
 			continue
 		}
-		require.NotEqual(t, gitFetchSubcommandConstant, recorded.Arguments[0])
-		require.NotEqual(t, gitPullSubcommandConstant, recorded.Arguments[0])
+		require.NotEqual(t, gitFetchSubcommandConstant, recordedArguments[0])
+		require.NotEqual(t, gitPullSubcommandConstant, recordedArguments[0])
 	}
 }
 
 func TestChangeFailsWhenRemoteEnumerationFails(t *testing.T) {
-	executor := &stubGitExecutor{responses: []stubGitResponse{{err: errors.New("remote list failed")}}}
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("remote").Fail(errors.New("remote list failed"))
 	service, err := NewService(ServiceDependencies{GitExecutor: executor})
 	require.NoError(t, err)
 
@@ -203,11 +165,9 @@ func TestChangeFailsWhenRemoteEnumerationFails(t *testing.T) {
 }
 
 func TestChangeDoesNotCreateBranchWhenSwitchFailsForOtherReasons(t *testing.T) {
-	executor := &stubGitExecutor{responses: []stubGitResponse{
-		{result: execshell.ExecutionResult{StandardOutput: "origin\n"}},
-		{result: execshell.ExecutionResult{}},
-		{err: commandFailedError("error: Your local changes to the following files would be overwritten by checkout:\nREADME.md")},
-	}}
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("remote").ReturnOutput("origin\n")
+	executor.OnGit("switch", "feature").Fail(commandFailedError("error: Your local changes to the following files would be overwritten by checkout:\nREADME.md"))
 	service, err := NewService(ServiceDependencies{GitExecutor: executor})
 	require.NoError(t, err)
 
@@ -219,16 +179,14 @@ func TestChangeDoesNotCreateBranchWhenSwitchFailsForOtherReasons(t *testing.T) {
 	require.Error(t, changeError)
 	require.Contains(t, changeError.Error(), "failed to switch to branch \"feature\"")
 	require.Contains(t, changeError.Error(), "Your local changes to the following files would be overwritten by checkout")
-	require.Len(t, executor.recorded, 3)
+	require.Len(t, executor.Executed(), 3)
 }
 
 func TestChangeIncludesDetailsWhenBranchCreationFails(t *testing.T) {
-	executor := &stubGitExecutor{responses: []stubGitResponse{
-		{result: execshell.ExecutionResult{StandardOutput: "origin\n"}},
-		{result: execshell.ExecutionResult{}},
-		{err: commandFailedError("error: pathspec 'feature' did not match any file(s) known to git")},
-		{err: commandFailedError("fatal: a branch named 'feature' already exists")},
-	}}
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("remote").ReturnOutput("origin\n")
+	executor.OnGit("switch", "feature").Fail(commandFailedError("error: pathspec 'feature' did not match any file(s) known to git"))
+	executor.On(execshell.CommandGit, execshelltest.ArgumentPrefix("switch", "-c", "feature")).Fail(commandFailedError("fatal: a branch named 'feature' already exists"))
 	service, err := NewService(ServiceDependencies{GitExecutor: executor})
 	require.NoError(t, err)
 
@@ -241,7 +199,7 @@ func TestChangeIncludesDetailsWhenBranchCreationFails(t *testing.T) {
 	require.Error(t, changeError)
 	require.Contains(t, changeError.Error(), "failed to create branch \"feature\" from origin")
 	require.Contains(t, changeError.Error(), "a branch named 'feature' already exists")
-	require.Len(t, executor.recorded, 4)
+	require.Len(t, executor.Executed(), 4)
 }
 
 func commandFailedError(message string) error {
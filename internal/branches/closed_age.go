@@ -0,0 +1,209 @@
+package branches
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	closedAtFieldConstant                    = "closedAt"
+	pullRequestFieldSeparatorConstant        = ","
+	taskActionClosedAgesParameterConstant    = "closed_ages"
+	closedAgeRepositoryTemplateConstant      = "BRANCHES-AGE: %s candidates=%d\n"
+	closedAgeTotalTemplateConstant           = "BRANCHES-AGE-TOTAL: candidates=%d across %d repositories\n"
+	closedAgeBucketLineTemplateConstant      = "  %-7s %-20s %d\n"
+	closedAgeBarCharacterConstant            = "#"
+	closedAgeBarWidthConstant                = 20
+	closedAgeHoursPerDayConstant             = 24
+	closedAgeWeekDaysConstant                = 7
+	closedAgeMonthDaysConstant               = 30
+	closedAgeQuarterDaysConstant             = 90
+	closedAgeWeekBucketLabelConstant         = "0-7d"
+	closedAgeMonthBucketLabelConstant        = "8-30d"
+	closedAgeQuarterBucketLabelConstant      = "31-90d"
+	closedAgeOlderBucketLabelConstant        = ">90d"
+	closedAgeUnknownBucketLabelConstant      = "unknown"
+	closedAgeUnknownBucketIndexConstant      = 4
+	closedAgeBucketCountConstant             = 5
+	closedAgeDayDurationConstant             = closedAgeHoursPerDayConstant * time.Hour
+	closedAgeWeekBoundaryDurationConstant    = closedAgeWeekDaysConstant * closedAgeDayDurationConstant
+	closedAgeMonthBoundaryDurationConstant   = closedAgeMonthDaysConstant * closedAgeDayDurationConstant
+	closedAgeQuarterBoundaryDurationConstant = closedAgeQuarterDaysConstant * closedAgeDayDurationConstant
+	closedAgeWeekBucketIndexConstant         = 0
+	closedAgeMonthBucketIndexConstant        = 1
+	closedAgeQuarterBucketIndexConstant      = 2
+	closedAgeOlderBucketIndexConstant        = 3
+	closedAgeMinimumVisibleBarLengthConstant = 1
+)
+
+var closedAgeBucketLabels = [closedAgeBucketCountConstant]string{
+	closedAgeWeekBucketLabelConstant,
+	closedAgeMonthBucketLabelConstant,
+	closedAgeQuarterBucketLabelConstant,
+	closedAgeOlderBucketLabelConstant,
+	closedAgeUnknownBucketLabelConstant,
+}
+
+// ClosedAgeBuckets counts planned deletions by how long ago their pull request closed: within 7 days, 8 to 30 days,
+// 31 to 90 days, over 90 days, and unknown when GitHub reported no close time.
+type ClosedAgeBuckets [closedAgeBucketCountConstant]int
+
+// Total returns the number of counted branches.
+func (buckets ClosedAgeBuckets) Total() int {
+	total := 0
+	for _, count := range buckets {
+		total += count
+	}
+	return total
+}
+
+// ClosedAgeHistogram accumulates the close age of every branch a dry run plans to delete, per repository.
+type ClosedAgeHistogram struct {
+	mutex        sync.Mutex
+	repositories []string
+	buckets      map[string]*ClosedAgeBuckets
+}
+
+// Add counts one planned deletion whose pull request closed at closedAt, measured against now. A zero closedAt is
+// counted as unknown.
+func (histogram *ClosedAgeHistogram) Add(repositoryPath string, closedAt time.Time, now time.Time) {
+	if histogram == nil {
+		return
+	}
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+	if histogram.buckets == nil {
+		histogram.buckets = map[string]*ClosedAgeBuckets{}
+	}
+	repositoryBuckets, exists := histogram.buckets[repositoryPath]
+	if !exists {
+		repositoryBuckets = &ClosedAgeBuckets{}
+		histogram.buckets[repositoryPath] = repositoryBuckets
+		histogram.repositories = append(histogram.repositories, repositoryPath)
+	}
+	repositoryBuckets[closedAgeBucketIndex(closedAt, now)]++
+}
+
+// Repositories lists the repositories with counted branches in the order they were first added.
+func (histogram *ClosedAgeHistogram) Repositories() []string {
+	if histogram == nil {
+		return nil
+	}
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+	return append([]string(nil), histogram.repositories...)
+}
+
+// Buckets returns the counts of one repository.
+func (histogram *ClosedAgeHistogram) Buckets(repositoryPath string) ClosedAgeBuckets {
+	if histogram == nil {
+		return ClosedAgeBuckets{}
+	}
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+	if repositoryBuckets, exists := histogram.buckets[repositoryPath]; exists {
+		return *repositoryBuckets
+	}
+	return ClosedAgeBuckets{}
+}
+
+func closedAgeBucketIndex(closedAt time.Time, now time.Time) int {
+	if closedAt.IsZero() {
+		return closedAgeUnknownBucketIndexConstant
+	}
+	age := now.Sub(closedAt)
+	switch {
+	case age <= closedAgeWeekBoundaryDurationConstant:
+		return closedAgeWeekBucketIndexConstant
+	case age <= closedAgeMonthBoundaryDurationConstant:
+		return closedAgeMonthBucketIndexConstant
+	case age <= closedAgeQuarterBoundaryDurationConstant:
+		return closedAgeQuarterBucketIndexConstant
+	default:
+		return closedAgeOlderBucketIndexConstant
+	}
+}
+
+// closedAgeRecorder counts the planned deletions of one repository, looking up each branch's close time.
+type closedAgeRecorder struct {
+	histogram      *ClosedAgeHistogram
+	repositoryPath string
+	closedAt       map[string]time.Time
+	now            time.Time
+}
+
+// newClosedAgeRecorder keeps the most recent close time of each branch, since a branch reused by several pull
+// requests only became a candidate once the last of them closed.
+func newClosedAgeRecorder(histogram *ClosedAgeHistogram, repositoryPath string, pullRequests []closedPullRequest, now time.Time) *closedAgeRecorder {
+	if histogram == nil {
+		return nil
+	}
+	closedAt := make(map[string]time.Time, len(pullRequests))
+	for _, pullRequest := range pullRequests {
+		branchName := strings.TrimSpace(pullRequest.HeadRefName)
+		if pullRequest.ClosedAt.After(closedAt[branchName]) {
+			closedAt[branchName] = pullRequest.ClosedAt
+		}
+	}
+	return &closedAgeRecorder{histogram: histogram, repositoryPath: repositoryPath, closedAt: closedAt, now: now}
+}
+
+func (recorder *closedAgeRecorder) recordPlannedDeletion(branchName string) {
+	if recorder == nil {
+		return
+	}
+	recorder.histogram.Add(recorder.repositoryPath, recorder.closedAt[branchName], recorder.now)
+}
+
+// pullRequestJSONFields lists the gh pr list fields the cleanup needs.
+func pullRequestJSONFields(includeNumbers bool, includeClosedAt bool) string {
+	fields := headRefFieldConstant
+	if includeNumbers {
+		fields = pullRequestTagFieldsConstant
+	}
+	if includeClosedAt {
+		fields += pullRequestFieldSeparatorConstant + closedAtFieldConstant
+	}
+	return fields
+}
+
+// reportClosedAges prints one histogram per repository followed by the overall histogram.
+func reportClosedAges(writer io.Writer, histogram *ClosedAgeHistogram) {
+	repositories := histogram.Repositories()
+	if len(repositories) == 0 {
+		return
+	}
+
+	overall := ClosedAgeBuckets{}
+	for _, repositoryPath := range repositories {
+		repositoryBuckets := histogram.Buckets(repositoryPath)
+		fmt.Fprintf(writer, closedAgeRepositoryTemplateConstant, repositoryPath, repositoryBuckets.Total())
+		writeClosedAgeBars(writer, repositoryBuckets)
+		for bucketIndex, count := range repositoryBuckets {
+			overall[bucketIndex] += count
+		}
+	}
+	fmt.Fprintf(writer, closedAgeTotalTemplateConstant, overall.Total(), len(repositories))
+	writeClosedAgeBars(writer, overall)
+}
+
+// writeClosedAgeBars prints a bar per bucket scaled to the largest bucket. The unknown bucket is omitted when empty.
+func writeClosedAgeBars(writer io.Writer, buckets ClosedAgeBuckets) {
+	largest := 0
+	for _, count := range buckets {
+		largest = max(largest, count)
+	}
+	for bucketIndex, count := range buckets {
+		if bucketIndex == closedAgeUnknownBucketIndexConstant && count == 0 {
+			continue
+		}
+		barLength := 0
+		if largest > 0 && count > 0 {
+			barLength = max(count*closedAgeBarWidthConstant/largest, closedAgeMinimumVisibleBarLengthConstant)
+		}
+		fmt.Fprintf(writer, closedAgeBucketLineTemplateConstant, closedAgeBucketLabels[bucketIndex], strings.Repeat(closedAgeBarCharacterConstant, barLength), count)
+	}
+}
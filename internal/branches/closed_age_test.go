@@ -0,0 +1,96 @@
+package branches_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	branches "github.com/temirov/gix/internal/branches"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+)
+
+func TestClosedAgeHistogramBuckets(testInstance *testing.T) {
+	now := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	testCases := []struct {
+		name            string
+		closedAt        []time.Time
+		expectedBuckets branches.ClosedAgeBuckets
+	}{
+		{
+			name:            "week_boundary_is_inclusive",
+			closedAt:        []time.Time{now.Add(-time.Hour), now.Add(-7 * day)},
+			expectedBuckets: branches.ClosedAgeBuckets{2, 0, 0, 0, 0},
+		},
+		{
+			name:            "month_and_quarter",
+			closedAt:        []time.Time{now.Add(-8 * day), now.Add(-30 * day), now.Add(-31 * day), now.Add(-90 * day)},
+			expectedBuckets: branches.ClosedAgeBuckets{0, 2, 2, 0, 0},
+		},
+		{
+			name:            "older_and_unknown",
+			closedAt:        []time.Time{now.Add(-91 * day), {}},
+			expectedBuckets: branches.ClosedAgeBuckets{0, 0, 0, 1, 1},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(fmt.Sprintf(subtestNameTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
+			histogram := &branches.ClosedAgeHistogram{}
+			for _, closedAt := range testCase.closedAt {
+				histogram.Add(testWorkingDirectoryConstant, closedAt, now)
+			}
+			require.Equal(testInstance, []string{testWorkingDirectoryConstant}, histogram.Repositories())
+			require.Equal(testInstance, testCase.expectedBuckets, histogram.Buckets(testWorkingDirectoryConstant))
+			require.Equal(testInstance, len(testCase.closedAt), histogram.Buckets(testWorkingDirectoryConstant).Total())
+		})
+	}
+}
+
+func TestServiceCleanupDryRunCollectsClosedAges(testInstance *testing.T) {
+	const closedAgeFieldsConstant = "headRefName,closedAt"
+
+	remoteBranches := []string{"feature/recent", "feature/stale", "feature/reused"}
+	pullRequestJSON := `[` +
+		`{"headRefName":"feature/recent","closedAt":"2025-05-30T12:00:00Z"},` +
+		`{"headRefName":"feature/stale","closedAt":"2024-12-01T00:00:00Z"},` +
+		`{"headRefName":"feature/reused","closedAt":"2024-11-01T00:00:00Z"},` +
+		`{"headRefName":"feature/reused","closedAt":"2025-05-10T00:00:00Z"}` +
+		`]`
+
+	fakeExecutorInstance := execshelltest.NewExecutor()
+	registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}, execshell.ExecutionResult{StandardOutput: buildRemoteOutput(remoteBranches)}, nil)
+	registerResponse(fakeExecutorInstance, githubCommandLabelConstant, []string{
+		githubPullRequestSubcommandConstant,
+		githubListSubcommandConstant,
+		githubStateFlagConstant,
+		githubClosedStateConstant,
+		githubJSONFlagConstant,
+		closedAgeFieldsConstant,
+		githubLimitFlagConstant,
+		strconv.Itoa(testPullRequestLimitConstant),
+	}, execshell.ExecutionResult{StandardOutput: pullRequestJSON}, nil)
+
+	service, serviceError := branches.NewService(zap.NewNop(), fakeExecutorInstance, nil)
+	require.NoError(testInstance, serviceError)
+	service.WithClock(archiveTestClock{now: time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)})
+
+	closedAges := &branches.ClosedAgeHistogram{}
+	cleanupError := service.Cleanup(context.Background(), branches.CleanupOptions{
+		RemoteName:       testRemoteNameConstant,
+		PullRequestLimit: testPullRequestLimitConstant,
+		DryRun:           true,
+		WorkingDirectory: testWorkingDirectoryConstant,
+		ClosedAges:       closedAges,
+	})
+	require.NoError(testInstance, cleanupError)
+	require.Equal(testInstance, branches.ClosedAgeBuckets{1, 1, 0, 1, 0}, closedAges.Buckets(testWorkingDirectoryConstant))
+}
@@ -3,16 +3,20 @@ package branches
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
+	"github.com/temirov/gix/internal/githubcli"
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/dependencies"
 	"github.com/temirov/gix/internal/repos/prompt"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 	rootutils "github.com/temirov/gix/internal/utils/roots"
 	"github.com/temirov/gix/internal/workflow"
@@ -25,8 +29,21 @@ const (
 	flagRemoteDescriptionConstant               = "Name of the remote containing pull request branches"
 	flagLimitNameConstant                       = "limit"
 	flagLimitDescriptionConstant                = "Maximum number of closed pull requests to examine"
+	flagDeletePullRequestTagsNameConstant       = "delete-pr-tags"
+	flagDeletePullRequestTagsDescription        = "Also delete remote and local tags matching this pattern for each closed pull request (e.g. pr-{number})"
 	invalidRemoteNameErrorMessageConstant       = "remote name must not be empty or whitespace"
 	invalidPullRequestLimitErrorMessageConstant = "limit must be greater than zero"
+	flagMaxDeletionsNameConstant                = "max-deletions"
+	flagMaxDeletionsDescriptionConstant         = "Maximum number of remote branch and tag deletions across the whole run (0 means unlimited)"
+	invalidMaxDeletionsErrorMessageConstant     = "max-deletions must not be negative"
+	flagGarbageCollectNameConstant              = "gc"
+	flagGarbageCollectDescriptionConstant       = "Run git gc --prune=now after deleting local branches and report the storage reclaimed (never runs with --dry-run)"
+	flagRespectAutoDeleteNameConstant           = "respect-auto-delete"
+	flagRespectAutoDeleteDescriptionConstant    = "Skip remote branch deletions in repositories where GitHub deletes head branches on merge; local branches are still removed"
+	flagArchiveRefsNameConstant                 = "archive-refs"
+	flagArchiveRefsDescriptionConstant          = "Push each remote branch tip to refs/archive/<year>/<branch> before deleting it; branches whose archive push fails are kept"
+	deletionCapSkippedTemplateConstant          = "%s: %s skipped: deletion cap reached\n"
+	deletionCapPlanExceededTemplateConstant     = "PLAN-EXCEEDS-CAP: %d remote deletion(s) planned beyond the cap of %d\n"
 )
 
 // RepositoryDiscoverer locates Git repositories beneath the provided roots.
@@ -73,6 +90,13 @@ func (builder *CommandBuilder) Build() (*cobra.Command, error) {
 	}
 
 	command.Flags().Int(flagLimitNameConstant, defaultPullRequestLimitConstant, flagLimitDescriptionConstant)
+	command.Flags().String(flagDeletePullRequestTagsNameConstant, "", flagDeletePullRequestTagsDescription)
+	command.Flags().Int(flagMaxDeletionsNameConstant, 0, flagMaxDeletionsDescriptionConstant)
+	flagutils.AddToggleFlag(command.Flags(), nil, flagGarbageCollectNameConstant, "", false, flagGarbageCollectDescriptionConstant)
+	flagutils.AddToggleFlag(command.Flags(), nil, flagRespectAutoDeleteNameConstant, "", false, flagRespectAutoDeleteDescriptionConstant)
+	flagutils.AddToggleFlag(command.Flags(), nil, flagArchiveRefsNameConstant, "", false, flagArchiveRefsDescriptionConstant)
+	command.Flags().StringSlice(flagBaseNameConstant, nil, flagBaseDescriptionConstant)
+	command.Flags().String(flagMergedIntoNameConstant, "", flagMergedIntoDescriptionConstant)
 	flagutils.EnsureRemoteFlag(command, defaultRemoteNameConstant, flagRemoteDescriptionConstant)
 
 	return command, nil
@@ -85,6 +109,7 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 	}
 
 	logger := builder.resolveLogger()
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), options)
 	humanReadable := false
 	if builder.HumanReadableLoggingProvider != nil {
 		humanReadable = builder.HumanReadableLoggingProvider()
@@ -113,12 +138,17 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 	fileSystem := dependencies.ResolveFileSystem(builder.FileSystem)
 	prompter := builder.resolvePrompter(command)
 
+	githubClient, githubClientError := githubcli.NewClient(gitExecutor)
+	if githubClientError != nil {
+		return githubClientError
+	}
+
 	taskDependencies := workflow.Dependencies{
 		Logger:               logger,
 		RepositoryDiscoverer: repositoryDiscoverer,
 		GitExecutor:          gitExecutor,
 		RepositoryManager:    repositoryManager,
-		GitHubClient:         nil,
+		GitHubClient:         githubClient,
 		FileSystem:           fileSystem,
 		Prompter:             prompter,
 		Output:               command.OutOrStdout(),
@@ -131,6 +161,42 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		"remote": options.CleanupOptions.RemoteName,
 		"limit":  strconv.Itoa(options.CleanupOptions.PullRequestLimit),
 	}
+	if len(options.CleanupOptions.PullRequestTagPattern) > 0 {
+		actionOptions["delete_pr_tags"] = options.CleanupOptions.PullRequestTagPattern
+	}
+	if len(options.CleanupOptions.KeepMarker) > 0 {
+		actionOptions["keep_marker"] = options.CleanupOptions.KeepMarker
+	}
+	if options.CleanupOptions.GarbageCollect {
+		actionOptions["gc"] = true
+	}
+	if options.CleanupOptions.RespectAutoDelete {
+		actionOptions[taskActionRespectAutoDeleteParameterConstant] = true
+	}
+	spaceReclaim := &SpaceReclaimTally{}
+	actionOptions["space_reclaim"] = spaceReclaim
+	archivedBranches := &ArchivedBranchTally{}
+	if options.CleanupOptions.ArchiveRefs {
+		actionOptions[taskActionArchiveRefsParameterConstant] = true
+		actionOptions[taskActionArchivedBranchesParameterConstant] = archivedBranches
+	}
+	closedAges := &ClosedAgeHistogram{}
+	if options.CleanupOptions.DryRun {
+		actionOptions[taskActionClosedAgesParameterConstant] = closedAges
+	}
+	if len(options.CleanupOptions.BaseBranches) > 0 {
+		actionOptions[taskActionBaseBranchesParameterConstant] = options.CleanupOptions.BaseBranches
+	}
+	branchCandidates := &BranchCandidateTally{}
+	if len(options.CleanupOptions.MergedInto) > 0 {
+		actionOptions[taskActionMergedIntoParameterConstant] = options.CleanupOptions.MergedInto
+		actionOptions[taskActionBranchCandidatesParameterConstant] = branchCandidates
+	}
+	var deletionBudget *DeletionBudget
+	if options.MaxDeletions > 0 {
+		deletionBudget = NewDeletionBudget(options.MaxDeletions)
+		actionOptions["deletion_budget"] = deletionBudget
+	}
 
 	taskDefinition := workflow.TaskDefinition{
 		Name:        "Cleanup pull request branches",
@@ -145,13 +211,42 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		DryRun:                 options.CleanupOptions.DryRun,
 		AssumeYes:              options.CleanupOptions.AssumeYes,
 		SkipRepositoryMetadata: true,
+		LockRoots:              true,
+	}
+	runError := taskRunner.Run(command.Context(), options.RepositoryRoots, []workflow.TaskDefinition{taskDefinition}, runtimeOptions)
+	if runError != nil {
+		return runError
 	}
-	return taskRunner.Run(command.Context(), options.RepositoryRoots, []workflow.TaskDefinition{taskDefinition}, runtimeOptions)
+
+	reportBaseFilter(command.OutOrStdout(), options.CleanupOptions.BaseBranches)
+	reportBranchCandidates(command.OutOrStdout(), branchCandidates)
+	reportSpaceReclaim(command.OutOrStdout(), spaceReclaim)
+	reportArchivedBranches(command.OutOrStdout(), archivedBranches)
+	reportClosedAges(command.OutOrStdout(), closedAges)
+	return reportDeletionCap(command.OutOrStdout(), deletionBudget, options.CleanupOptions.DryRun)
+}
+
+func reportDeletionCap(writer io.Writer, deletionBudget *DeletionBudget, dryRun bool) error {
+	skipped := deletionBudget.Skipped()
+	if len(skipped) == 0 {
+		return nil
+	}
+
+	for _, cappedDeletion := range skipped {
+		fmt.Fprintf(writer, deletionCapSkippedTemplateConstant, cappedDeletion.RepositoryPath, cappedDeletion.Reference)
+	}
+
+	if dryRun {
+		fmt.Fprintf(writer, deletionCapPlanExceededTemplateConstant, len(skipped), deletionBudget.Limit())
+		return nil
+	}
+	return DeletionCapError{SkippedCount: len(skipped), Limit: deletionBudget.Limit()}
 }
 
 type commandOptions struct {
 	CleanupOptions  CleanupOptions
 	RepositoryRoots []string
+	MaxDeletions    int
 }
 
 func (builder *CommandBuilder) parseOptions(command *cobra.Command, arguments []string) (commandOptions, error) {
@@ -205,11 +300,101 @@ func (builder *CommandBuilder) parseOptions(command *cobra.Command, arguments []
 		assumeYesValue = executionFlags.AssumeYes
 	}
 
+	tagPatternValue := configuration.PullRequestTagPattern
+	if command != nil {
+		flagTagPattern, flagTagPatternChanged, flagTagPatternError := flagutils.StringFlag(command, flagDeletePullRequestTagsNameConstant)
+		if flagTagPatternError != nil && !errors.Is(flagTagPatternError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, flagTagPatternError
+		}
+		if flagTagPatternChanged {
+			tagPatternValue = strings.TrimSpace(flagTagPattern)
+		}
+	}
+	if len(tagPatternValue) > 0 && !strings.Contains(tagPatternValue, pullRequestNumberPlaceholderConstant) {
+		if command != nil {
+			_ = command.Help()
+		}
+		return commandOptions{}, errTagPatternPlaceholder
+	}
+
+	maxDeletionsValue := configuration.MaxDeletions
+	if command != nil && command.Flags().Changed(flagMaxDeletionsNameConstant) {
+		maxDeletionsValue, _ = command.Flags().GetInt(flagMaxDeletionsNameConstant)
+	}
+	if maxDeletionsValue < 0 {
+		if command != nil {
+			_ = command.Help()
+		}
+		return commandOptions{}, errors.New(invalidMaxDeletionsErrorMessageConstant)
+	}
+
+	garbageCollectValue := configuration.GarbageCollect
+	if command != nil {
+		flagGarbageCollect, flagGarbageCollectSet, flagGarbageCollectError := flagutils.BoolFlag(command, flagGarbageCollectNameConstant)
+		if flagGarbageCollectError != nil && !errors.Is(flagGarbageCollectError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, flagGarbageCollectError
+		}
+		if flagGarbageCollectSet {
+			garbageCollectValue = flagGarbageCollect
+		}
+	}
+
+	respectAutoDeleteValue := configuration.RespectAutoDelete
+	if command != nil {
+		flagRespectAutoDelete, flagRespectAutoDeleteSet, flagRespectAutoDeleteError := flagutils.BoolFlag(command, flagRespectAutoDeleteNameConstant)
+		if flagRespectAutoDeleteError != nil && !errors.Is(flagRespectAutoDeleteError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, flagRespectAutoDeleteError
+		}
+		if flagRespectAutoDeleteSet {
+			respectAutoDeleteValue = flagRespectAutoDelete
+		}
+	}
+
+	archiveRefsValue := configuration.ArchiveRefs
+	if command != nil {
+		flagArchiveRefs, flagArchiveRefsSet, flagArchiveRefsError := flagutils.BoolFlag(command, flagArchiveRefsNameConstant)
+		if flagArchiveRefsError != nil && !errors.Is(flagArchiveRefsError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, flagArchiveRefsError
+		}
+		if flagArchiveRefsSet {
+			archiveRefsValue = flagArchiveRefs
+		}
+	}
+
+	baseBranchesValue := configuration.BaseBranches
+	if command != nil {
+		flagBaseBranches, flagBaseBranchesSet, flagBaseBranchesError := flagutils.StringSliceFlag(command, flagBaseNameConstant)
+		if flagBaseBranchesError != nil && !errors.Is(flagBaseBranchesError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, flagBaseBranchesError
+		}
+		if flagBaseBranchesSet {
+			baseBranchesValue = normalizeBaseBranches(flagBaseBranches)
+		}
+	}
+
+	mergedIntoValue := configuration.MergedInto
+	if command != nil {
+		flagMergedInto, flagMergedIntoSet, flagMergedIntoError := flagutils.StringFlag(command, flagMergedIntoNameConstant)
+		if flagMergedIntoError != nil && !errors.Is(flagMergedIntoError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, flagMergedIntoError
+		}
+		if flagMergedIntoSet {
+			mergedIntoValue = strings.TrimSpace(flagMergedInto)
+		}
+	}
+
 	cleanupOptions := CleanupOptions{
-		RemoteName:       trimmedRemoteName,
-		PullRequestLimit: limitValue,
-		DryRun:           dryRunValue,
-		AssumeYes:        assumeYesValue,
+		RemoteName:            trimmedRemoteName,
+		PullRequestLimit:      limitValue,
+		DryRun:                dryRunValue,
+		AssumeYes:             assumeYesValue,
+		PullRequestTagPattern: tagPatternValue,
+		KeepMarker:            configuration.KeepMarker,
+		GarbageCollect:        garbageCollectValue,
+		RespectAutoDelete:     respectAutoDeleteValue,
+		ArchiveRefs:           archiveRefsValue,
+		BaseBranches:          baseBranchesValue,
+		MergedInto:            mergedIntoValue,
 	}
 
 	repositoryRoots, rootsError := rootutils.Resolve(command, arguments, configuration.RepositoryRoots)
@@ -217,7 +402,7 @@ func (builder *CommandBuilder) parseOptions(command *cobra.Command, arguments []
 		return commandOptions{}, rootsError
 	}
 
-	return commandOptions{CleanupOptions: cleanupOptions, RepositoryRoots: repositoryRoots}, nil
+	return commandOptions{CleanupOptions: cleanupOptions, RepositoryRoots: repositoryRoots, MaxDeletions: maxDeletionsValue}, nil
 }
 
 func (builder *CommandBuilder) resolveLogger() *zap.Logger {
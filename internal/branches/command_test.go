@@ -1,6 +1,7 @@
 package branches_test
 
 import (
+	"bytes"
 	"context"
 	"strconv"
 	"testing"
@@ -178,6 +179,180 @@ func TestCommandFlagsOverrideConfiguration(t *testing.T) {
 	require.True(t, runner.runtimeOptions.SkipRepositoryMetadata)
 }
 
+func TestCommandDeletePullRequestTagsOption(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configuration        branches.CommandConfiguration
+		arguments            []string
+		expectedPattern      any
+		expectedErrorMessage string
+	}{
+		{
+			name:            "flag_sets_pattern",
+			configuration:   branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5},
+			arguments:       []string{"--delete-pr-tags", "pr-{number}", commandRootFlagConstant, "/tmp/tags"},
+			expectedPattern: "pr-{number}",
+		},
+		{
+			name:            "configuration_sets_pattern",
+			configuration:   branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5, PullRequestTagPattern: "ci/pr-{number}"},
+			arguments:       []string{commandRootFlagConstant, "/tmp/tags"},
+			expectedPattern: "ci/pr-{number}",
+		},
+		{
+			name:            "pattern_omitted_by_default",
+			configuration:   branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5},
+			arguments:       []string{commandRootFlagConstant, "/tmp/tags"},
+			expectedPattern: nil,
+		},
+		{
+			name:                 "pattern_requires_placeholder",
+			configuration:        branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5},
+			arguments:            []string{"--delete-pr-tags", "pr-latest", commandRootFlagConstant, "/tmp/tags"},
+			expectedErrorMessage: "pull request tag pattern must contain the {number} placeholder",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := branches.CommandBuilder{
+				LoggerProvider:        func() *zap.Logger { return zap.NewNop() },
+				Discoverer:            &fakeRepositoryDiscoverer{repositories: []string{"/tmp/tags"}},
+				GitExecutor:           &stubGitExecutor{},
+				GitManager:            stubGitRepositoryManager{},
+				PrompterFactory:       func(*cobra.Command) shared.ConfirmationPrompter { return stubPrompter{} },
+				ConfigurationProvider: func() branches.CommandConfiguration { return testCase.configuration },
+				TaskRunnerFactory: func(deps workflow.Dependencies) branches.TaskRunnerExecutor {
+					runner.dependencies = deps
+					return runner
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindGlobalBranchFlags(command)
+			command.SetContext(context.Background())
+			command.SetOut(&bytes.Buffer{})
+			command.SetArgs(testCase.arguments)
+
+			executionError := command.Execute()
+			if len(testCase.expectedErrorMessage) > 0 {
+				require.EqualError(subtest, executionError, testCase.expectedErrorMessage)
+				return
+			}
+
+			require.NoError(subtest, executionError)
+			action := runner.definitions[0].Actions[0]
+			require.Equal(subtest, testCase.expectedPattern, action.Options["delete_pr_tags"])
+		})
+	}
+}
+
+type budgetConsumingTaskRunner struct {
+	references  []string
+	definitions []workflow.TaskDefinition
+}
+
+func (runner *budgetConsumingTaskRunner) Run(_ context.Context, roots []string, definitions []workflow.TaskDefinition, _ workflow.RuntimeOptions) error {
+	runner.definitions = append([]workflow.TaskDefinition{}, definitions...)
+	deletionBudget, _ := definitions[0].Actions[0].Options["deletion_budget"].(*branches.DeletionBudget)
+	for _, reference := range runner.references {
+		deletionBudget.Reserve(roots[0], reference)
+	}
+	return nil
+}
+
+func TestCommandMaxDeletionsOption(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configuration        branches.CommandConfiguration
+		arguments            []string
+		expectBudget         bool
+		expectedOutput       string
+		expectedError        error
+		expectedErrorMessage string
+	}{
+		{
+			name:          "cap_omitted_by_default",
+			configuration: branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5},
+			arguments:     []string{commandRootFlagConstant, "/tmp/cap"},
+		},
+		{
+			name:           "flag_cap_reached_fails_run",
+			configuration:  branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5},
+			arguments:      []string{"--max-deletions", "1", commandRootFlagConstant, "/tmp/cap"},
+			expectBudget:   true,
+			expectedOutput: "/tmp/cap: feature/two skipped: deletion cap reached\n/tmp/cap: feature/three skipped: deletion cap reached\n",
+			expectedError:  branches.ErrDeletionCapReached,
+		},
+		{
+			name:           "configuration_cap_dry_run_reports_plan",
+			configuration:  branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5, MaxDeletions: 2},
+			arguments:      []string{commandDryRunFlagConstant, commandRootFlagConstant, "/tmp/cap"},
+			expectBudget:   true,
+			expectedOutput: "/tmp/cap: feature/three skipped: deletion cap reached\nPLAN-EXCEEDS-CAP: 1 remote deletion(s) planned beyond the cap of 2\n",
+		},
+		{
+			name:          "cap_not_reached",
+			configuration: branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5, MaxDeletions: 3},
+			arguments:     []string{commandRootFlagConstant, "/tmp/cap"},
+			expectBudget:  true,
+		},
+		{
+			name:                 "negative_cap_rejected",
+			configuration:        branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5},
+			arguments:            []string{"--max-deletions", "-1", commandRootFlagConstant, "/tmp/cap"},
+			expectedErrorMessage: "max-deletions must not be negative",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &budgetConsumingTaskRunner{references: []string{"feature/one", "feature/two", "feature/three"}}
+			builder := branches.CommandBuilder{
+				LoggerProvider:        func() *zap.Logger { return zap.NewNop() },
+				GitExecutor:           &stubGitExecutor{},
+				GitManager:            stubGitRepositoryManager{},
+				PrompterFactory:       func(*cobra.Command) shared.ConfirmationPrompter { return stubPrompter{} },
+				ConfigurationProvider: func() branches.CommandConfiguration { return testCase.configuration },
+				TaskRunnerFactory: func(workflow.Dependencies) branches.TaskRunnerExecutor {
+					return runner
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindGlobalBranchFlags(command)
+			command.SetContext(context.Background())
+			outputBuffer := &bytes.Buffer{}
+			command.SetOut(outputBuffer)
+			command.SetArgs(testCase.arguments)
+
+			executionError := command.Execute()
+			if len(testCase.expectedErrorMessage) > 0 {
+				require.EqualError(subtest, executionError, testCase.expectedErrorMessage)
+				return
+			}
+			if testCase.expectedError != nil {
+				require.ErrorIs(subtest, executionError, testCase.expectedError)
+				var capError branches.DeletionCapError
+				require.ErrorAs(subtest, executionError, &capError)
+				require.Equal(subtest, 3, capError.ProcessExitCode())
+			} else {
+				require.NoError(subtest, executionError)
+			}
+
+			action := runner.definitions[0].Actions[0]
+			_, budgetProvided := action.Options["deletion_budget"]
+			require.Equal(subtest, testCase.expectBudget, budgetProvided)
+			require.Contains(subtest, outputBuffer.String(), testCase.expectedOutput)
+		})
+	}
+}
+
 func TestCommandErrorsWhenRemoteInvalid(t *testing.T) {
 	builder := branches.CommandBuilder{}
 	command, buildError := builder.Build()
@@ -225,3 +400,60 @@ func bindGlobalBranchFlags(command *cobra.Command) {
 	})
 	flagutils.EnsureRemoteFlag(command, configurationRemoteNameConstant, "remote")
 }
+
+func TestCommandMergedIntoOption(t *testing.T) {
+	testCases := []struct {
+		name               string
+		configuration      branches.CommandConfiguration
+		arguments          []string
+		expectedMergedInto any
+	}{
+		{
+			name:          "omitted_by_default",
+			configuration: branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5},
+			arguments:     []string{commandRootFlagConstant, "/tmp/merged"},
+		},
+		{
+			name:               "configuration_value",
+			configuration:      branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5, MergedInto: "main"},
+			arguments:          []string{commandRootFlagConstant, "/tmp/merged"},
+			expectedMergedInto: "main",
+		},
+		{
+			name:               "flag_overrides_configuration",
+			configuration:      branches.CommandConfiguration{RemoteName: configurationRemoteNameConstant, PullRequestLimit: 5, MergedInto: "main"},
+			arguments:          []string{"--merged-into", " develop ", commandRootFlagConstant, "/tmp/merged"},
+			expectedMergedInto: "develop",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := branches.CommandBuilder{
+				LoggerProvider:        func() *zap.Logger { return zap.NewNop() },
+				GitExecutor:           &stubGitExecutor{},
+				GitManager:            stubGitRepositoryManager{},
+				PrompterFactory:       func(*cobra.Command) shared.ConfirmationPrompter { return stubPrompter{} },
+				ConfigurationProvider: func() branches.CommandConfiguration { return testCase.configuration },
+				TaskRunnerFactory: func(workflow.Dependencies) branches.TaskRunnerExecutor {
+					return runner
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindGlobalBranchFlags(command)
+			command.SetContext(context.Background())
+			command.SetOut(&bytes.Buffer{})
+			command.SetArgs(testCase.arguments)
+			require.NoError(subtest, command.Execute())
+
+			options := runner.definitions[0].Actions[0].Options
+			require.Equal(subtest, testCase.expectedMergedInto, options["merged_into"])
+			_, candidatesProvided := options["branch_candidates"]
+			require.Equal(subtest, testCase.expectedMergedInto != nil, candidatesProvided)
+		})
+	}
+}
@@ -10,11 +10,19 @@ var branchConfigurationRepositoryPathSanitizer = pathutils.NewRepositoryPathSani
 
 // CommandConfiguration captures configuration values for the branch cleanup command.
 type CommandConfiguration struct {
-	RemoteName       string   `mapstructure:"remote"`
-	PullRequestLimit int      `mapstructure:"limit"`
-	DryRun           bool     `mapstructure:"dry_run"`
-	AssumeYes        bool     `mapstructure:"assume_yes"`
-	RepositoryRoots  []string `mapstructure:"roots"`
+	RemoteName            string   `mapstructure:"remote"`
+	PullRequestLimit      int      `mapstructure:"limit"`
+	DryRun                bool     `mapstructure:"dry_run"`
+	AssumeYes             bool     `mapstructure:"assume_yes"`
+	RepositoryRoots       []string `mapstructure:"roots"`
+	PullRequestTagPattern string   `mapstructure:"delete_pr_tags"`
+	MaxDeletions          int      `mapstructure:"max_deletions"`
+	KeepMarker            string   `mapstructure:"keep_marker"`
+	GarbageCollect        bool     `mapstructure:"gc"`
+	RespectAutoDelete     bool     `mapstructure:"respect_auto_delete"`
+	ArchiveRefs           bool     `mapstructure:"archive_refs"`
+	BaseBranches          []string `mapstructure:"base_branches"`
+	MergedInto            string   `mapstructure:"merged_into"`
 }
 
 // DefaultCommandConfiguration provides baseline configuration values for branch cleanup.
@@ -25,6 +33,8 @@ func DefaultCommandConfiguration() CommandConfiguration {
 		DryRun:           false,
 		AssumeYes:        false,
 		RepositoryRoots:  nil,
+		MaxDeletions:     0,
+		KeepMarker:       DefaultKeepMarker,
 	}
 }
 
@@ -33,7 +43,11 @@ func (configuration CommandConfiguration) Sanitize() CommandConfiguration {
 	sanitized := configuration
 
 	sanitized.RemoteName = strings.TrimSpace(configuration.RemoteName)
+	sanitized.PullRequestTagPattern = strings.TrimSpace(configuration.PullRequestTagPattern)
+	sanitized.KeepMarker = strings.TrimSpace(configuration.KeepMarker)
 	sanitized.RepositoryRoots = branchConfigurationRepositoryPathSanitizer.Sanitize(configuration.RepositoryRoots)
+	sanitized.BaseBranches = normalizeBaseBranches(configuration.BaseBranches)
+	sanitized.MergedInto = strings.TrimSpace(configuration.MergedInto)
 
 	return sanitized
 }
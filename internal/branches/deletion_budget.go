@@ -0,0 +1,111 @@
+package branches
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	deletionCapReachedErrorTemplateConstant = "%v: %d remote deletion(s) skipped after reaching the cap of %d"
+	deletionCapExitCodeConstant             = 3
+)
+
+// ErrDeletionCapReached reports that a run stopped deleting remote references because the deletion cap was reached.
+var ErrDeletionCapReached = errors.New("deletion cap reached")
+
+// DeletionCapError reports that a run finished with remote deletions skipped because the deletion cap was reached.
+type DeletionCapError struct {
+	SkippedCount int
+	Limit        int
+}
+
+// Error summarizes how many deletions were skipped and the configured cap.
+func (capError DeletionCapError) Error() string {
+	return fmt.Sprintf(deletionCapReachedErrorTemplateConstant, ErrDeletionCapReached, capError.SkippedCount, capError.Limit)
+}
+
+// Unwrap exposes ErrDeletionCapReached to errors.Is.
+func (capError DeletionCapError) Unwrap() error {
+	return ErrDeletionCapReached
+}
+
+// ProcessExitCode returns a dedicated exit code so a capped run is distinguishable from failures and partial failures.
+func (capError DeletionCapError) ProcessExitCode() int {
+	return deletionCapExitCodeConstant
+}
+
+// CappedDeletion identifies a remote branch or tag that was skipped because the deletion cap was reached.
+type CappedDeletion struct {
+	RepositoryPath string
+	Reference      string
+}
+
+// DeletionBudget caps the number of remote deletions performed across every repository in a run.
+// A nil budget or a non-positive limit allows unlimited deletions.
+type DeletionBudget struct {
+	mutex   sync.Mutex
+	limit   int
+	used    int
+	skipped []CappedDeletion
+}
+
+// NewDeletionBudget constructs a budget allowing at most limit remote deletions.
+func NewDeletionBudget(limit int) *DeletionBudget {
+	return &DeletionBudget{limit: limit}
+}
+
+// Reserve claims one remote deletion, or records the reference as skipped when the cap is already reached.
+func (budget *DeletionBudget) Reserve(repositoryPath string, reference string) bool {
+	if budget == nil {
+		return true
+	}
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+	if budget.limit > 0 && budget.used >= budget.limit {
+		budget.skipped = append(budget.skipped, CappedDeletion{RepositoryPath: repositoryPath, Reference: reference})
+		return false
+	}
+	budget.used++
+	return true
+}
+
+// Release returns a claimed deletion to the budget after the deletion it was reserved for failed.
+func (budget *DeletionBudget) Release() {
+	if budget == nil {
+		return
+	}
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+	if budget.used > 0 {
+		budget.used--
+	}
+}
+
+// Limit reports the configured cap.
+func (budget *DeletionBudget) Limit() int {
+	if budget == nil {
+		return 0
+	}
+	return budget.limit
+}
+
+// Used reports the number of remote deletions claimed so far.
+func (budget *DeletionBudget) Used() int {
+	if budget == nil {
+		return 0
+	}
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+	return budget.used
+}
+
+// Skipped lists the references skipped because the cap was reached, in the order they were encountered.
+func (budget *DeletionBudget) Skipped() []CappedDeletion {
+	if budget == nil {
+		return nil
+	}
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+	return append([]CappedDeletion(nil), budget.skipped...)
+}
@@ -0,0 +1,97 @@
+package branches
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	// DefaultKeepMarker is the token that marks a branch as kept when it appears in the branch description.
+	DefaultKeepMarker = "KEEP"
+
+	configSubcommandConstant               = "config"
+	configNullTerminatedFlagConstant       = "-z"
+	configGetRegexpFlagConstant            = "--get-regexp"
+	branchDescriptionPatternConstant       = `^branch\..*\.description$`
+	branchDescriptionKeyPrefixConstant     = "branch."
+	branchDescriptionKeySuffixConstant     = ".description"
+	branchDescriptionEntrySeparator        = "\x00"
+	branchDescriptionKeyValueSeparator     = "\n"
+	configNoMatchExitCodeConstant          = 1
+	logMessageSkippingKeptBranchConstant   = "Skipping branch (marked keep)"
+	logMessageKeepMarkerUnavailableMessage = "Branch descriptions unavailable; keep markers ignored"
+	logFieldKeepMarkerConstant             = "keep_marker"
+)
+
+type branchKeepMarkerCheck struct {
+	executor         CommandExecutor
+	workingDirectory string
+	marker           string
+	descriptions     map[string]string
+	loaded           bool
+}
+
+func newBranchKeepMarkerCheck(executor CommandExecutor, workingDirectory string, marker string) *branchKeepMarkerCheck {
+	trimmedMarker := strings.TrimSpace(marker)
+	if len(trimmedMarker) == 0 {
+		trimmedMarker = DefaultKeepMarker
+	}
+	return &branchKeepMarkerCheck{executor: executor, workingDirectory: workingDirectory, marker: trimmedMarker}
+}
+
+// Keeps reports whether the branch description contains the keep marker.
+// All descriptions are read with a single git config call the first time a branch is checked.
+func (check *branchKeepMarkerCheck) Keeps(executionContext context.Context, branchName string) (bool, error) {
+	if check == nil {
+		return false, nil
+	}
+	if !check.loaded {
+		descriptions, loadError := check.loadDescriptions(executionContext)
+		if loadError != nil {
+			return false, loadError
+		}
+		check.descriptions = descriptions
+		check.loaded = true
+	}
+	description, described := check.descriptions[branchName]
+	return described && strings.Contains(description, check.marker), nil
+}
+
+func (check *branchKeepMarkerCheck) loadDescriptions(executionContext context.Context) (map[string]string, error) {
+	commandDetails := execshell.CommandDetails{
+		Arguments:          []string{configSubcommandConstant, configNullTerminatedFlagConstant, configGetRegexpFlagConstant, branchDescriptionPatternConstant},
+		WorkingDirectory:   check.workingDirectory,
+		OutputCaptureLimit: execshell.UnlimitedOutputCapture,
+		Idempotent:         true,
+	}
+
+	executionResult, executionError := check.executor.ExecuteGit(executionContext, commandDetails)
+	if executionError != nil {
+		var commandFailure execshell.CommandFailedError
+		if errors.As(executionError, &commandFailure) && commandFailure.Result.ExitCode == configNoMatchExitCodeConstant {
+			return map[string]string{}, nil
+		}
+		return nil, executionError
+	}
+
+	return parseBranchDescriptions(executionResult.StandardOutput), nil
+}
+
+func parseBranchDescriptions(commandOutput string) map[string]string {
+	descriptions := make(map[string]string)
+	for _, entry := range strings.Split(commandOutput, branchDescriptionEntrySeparator) {
+		key, value, _ := strings.Cut(entry, branchDescriptionKeyValueSeparator)
+		if !strings.HasPrefix(key, branchDescriptionKeyPrefixConstant) || !strings.HasSuffix(key, branchDescriptionKeySuffixConstant) {
+			continue
+		}
+		branchName := strings.TrimSuffix(strings.TrimPrefix(key, branchDescriptionKeyPrefixConstant), branchDescriptionKeySuffixConstant)
+		if len(branchName) == 0 {
+			continue
+		}
+		descriptions[branchName] = value
+	}
+	return descriptions
+}
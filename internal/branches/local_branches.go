@@ -0,0 +1,59 @@
+package branches
+
+import (
+	"context"
+
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const (
+	logMessageLocalBranchesUnavailableConstant = "Local branches unavailable; attempting local deletions blindly"
+	logMessageSkippingMissingLocalBranch       = "Skipping local branch deletion (not present locally)"
+)
+
+type localBranchInventory struct {
+	manager          *gitrepo.RepositoryManager
+	workingDirectory string
+	branches         map[string]gitrepo.RefEntry
+	loaded           bool
+	loadError        error
+	errorReported    bool
+}
+
+func newLocalBranchInventory(executor CommandExecutor, workingDirectory string) *localBranchInventory {
+	manager, managerError := gitrepo.NewRepositoryManager(executor)
+	return &localBranchInventory{manager: manager, workingDirectory: workingDirectory, loadError: managerError, loaded: managerError != nil}
+}
+
+// Exists reports whether the branch exists locally. The second result is false when local branches could not be listed.
+// Every local branch is read with a single for-each-ref call the first time a branch is checked.
+func (inventory *localBranchInventory) Exists(executionContext context.Context, branchName string) (bool, bool) {
+	if inventory == nil {
+		return false, false
+	}
+	if !inventory.loaded {
+		inventory.loaded = true
+		entries, listError := inventory.manager.ListRefs(executionContext, inventory.workingDirectory, gitrepo.LocalBranchRefPattern)
+		if listError != nil {
+			inventory.loadError = listError
+		} else {
+			inventory.branches = make(map[string]gitrepo.RefEntry, len(entries))
+			for _, entry := range entries {
+				inventory.branches[entry.BranchName()] = entry
+			}
+		}
+	}
+	if inventory.loadError != nil {
+		return false, false
+	}
+	_, exists := inventory.branches[branchName]
+	return exists, true
+}
+
+// ObjectName returns the commit the local branch points at, or an empty string when it is unknown.
+func (inventory *localBranchInventory) ObjectName(branchName string) string {
+	if inventory == nil || inventory.branches == nil {
+		return ""
+	}
+	return inventory.branches[branchName].ObjectName
+}
@@ -0,0 +1,249 @@
+package branches
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	mergedFlagConstant                          = "--merged"
+	remotesFlagConstant                         = "-r"
+	referenceNameFormatFlagConstant             = "--format=%(refname)"
+	remoteReferencePrefixTemplateConstant       = "refs/remotes/%s/"
+	remoteHeadBranchNameConstant                = "HEAD"
+	flagMergedIntoNameConstant                  = "merged-into"
+	flagMergedIntoDescriptionConstant           = "Also clean local and remote branches fully merged into this branch, even without a pull request; the default branch is never a candidate"
+	taskActionMergedIntoParameterConstant       = "merged_into"
+	taskActionBranchCandidatesParameterConstant = "branch_candidates"
+	logMessageListingMergedBranchesConstant     = "Listing branches merged into branch"
+	logMessageDefaultBranchUnknownConstant      = "Default branch unknown; only the merged-into branch is excluded"
+	logMessageSkippingDefaultBranchConstant     = "Skipping merged branch (default or merged-into branch)"
+	logFieldMergedIntoConstant                  = "merged_into"
+	mergedBranchesListErrorTemplateConstant     = "unable to list branches merged into %s: %w"
+	mergedBranchesParsingErrorTemplateConstant  = "unable to parse merged branch list: %w"
+	mergedIntoSourceLabelTemplateConstant       = "merged-into:%s"
+	branchCandidateSourceSeparatorConstant      = ","
+	branchCandidateTemplateConstant             = "BRANCHES-CANDIDATE: %s %s sources=%s\n"
+	branchCandidatesTotalTemplateConstant       = "BRANCHES-CANDIDATE-TOTAL: pull_request_only=%d merged_only=%d both=%d across %d repositories\n"
+)
+
+// BranchCandidateSource names why a branch was offered for deletion.
+type BranchCandidateSource string
+
+// Candidate sources recorded in the branch cleanup summary.
+const (
+	BranchCandidateSourcePullRequest BranchCandidateSource = BranchCandidateSource("pull-request")
+	BranchCandidateSourceMerged      BranchCandidateSource = BranchCandidateSource("merged")
+)
+
+// BranchCandidate records a branch offered to the deletion pipeline together with every source that selected it.
+// MergedInto names the branch the merged source compared against.
+type BranchCandidate struct {
+	RepositoryPath string
+	Branch         string
+	Sources        []BranchCandidateSource
+	MergedInto     string
+}
+
+// Labels renders the candidate sources for the summary, e.g. "pull-request,merged-into:main".
+func (candidate BranchCandidate) Labels() string {
+	labels := make([]string, 0, len(candidate.Sources))
+	for _, source := range candidate.Sources {
+		if source == BranchCandidateSourceMerged {
+			labels = append(labels, fmt.Sprintf(mergedIntoSourceLabelTemplateConstant, candidate.MergedInto))
+			continue
+		}
+		labels = append(labels, string(source))
+	}
+	return strings.Join(labels, branchCandidateSourceSeparatorConstant)
+}
+
+// BranchCandidateTally accumulates BranchCandidate records across repositories.
+type BranchCandidateTally = utils.Tally[BranchCandidate]
+
+// branchCandidate is a branch selected by closed pull requests, by ancestry of the merged-into branch, or by both.
+type branchCandidate struct {
+	name        string
+	pullRequest bool
+	merged      bool
+}
+
+func (candidate branchCandidate) sources() []BranchCandidateSource {
+	sources := make([]BranchCandidateSource, 0, 2)
+	if candidate.pullRequest {
+		sources = append(sources, BranchCandidateSourcePullRequest)
+	}
+	if candidate.merged {
+		sources = append(sources, BranchCandidateSourceMerged)
+	}
+	return sources
+}
+
+// unionBranchCandidates merges pull request branches and merged branches into one deduplicated list. Pull request
+// branches keep their order and come first; a branch selected by both sources appears once carrying both.
+func unionBranchCandidates(pullRequestBranches []string, mergedBranches []string) []branchCandidate {
+	candidates := make([]branchCandidate, 0, len(pullRequestBranches)+len(mergedBranches))
+	positions := make(map[string]int)
+	for _, branchName := range pullRequestBranches {
+		trimmed := strings.TrimSpace(branchName)
+		if len(trimmed) == 0 {
+			continue
+		}
+		if _, duplicate := positions[trimmed]; duplicate {
+			continue
+		}
+		positions[trimmed] = len(candidates)
+		candidates = append(candidates, branchCandidate{name: trimmed, pullRequest: true})
+	}
+	for _, branchName := range mergedBranches {
+		if position, known := positions[branchName]; known {
+			candidates[position].merged = true
+			continue
+		}
+		positions[branchName] = len(candidates)
+		candidates = append(candidates, branchCandidate{name: branchName, merged: true})
+	}
+	return candidates
+}
+
+// fetchMergedBranches lists local branches and remote-tracking branches of the remote whose tips are reachable from
+// the merged-into branch. The merged-into branch itself and the remote's default branch are never returned.
+func (service *Service) fetchMergedBranches(executionContext context.Context, remoteName string, mergedInto string, workingDirectory string) ([]string, error) {
+	baseFields := []zap.Field{
+		zap.String(logFieldMergedIntoConstant, mergedInto),
+		zap.String(logFieldRemoteNameConstant, remoteName),
+		zap.String(logFieldWorkingDirectoryConstant, workingDirectory),
+	}
+	service.logger.Info(logMessageListingMergedBranchesConstant, baseFields...)
+
+	localOutput, localError := service.listMergedReferences(executionContext, workingDirectory, mergedInto, false)
+	if localError != nil {
+		return nil, localError
+	}
+	remoteOutput, remoteError := service.listMergedReferences(executionContext, workingDirectory, mergedInto, true)
+	if remoteError != nil {
+		return nil, remoteError
+	}
+
+	localBranches, localParseError := parseMergedReferences(localOutput, branchReferencePrefixConstant)
+	if localParseError != nil {
+		return nil, localParseError
+	}
+	remoteBranches, remoteParseError := parseMergedReferences(remoteOutput, fmt.Sprintf(remoteReferencePrefixTemplateConstant, remoteName))
+	if remoteParseError != nil {
+		return nil, remoteParseError
+	}
+
+	excluded := map[string]struct{}{mergedInto: {}}
+	if defaultBranch := service.resolveDefaultBranch(executionContext, remoteName, workingDirectory, baseFields); len(defaultBranch) > 0 {
+		excluded[defaultBranch] = struct{}{}
+	}
+
+	seen := make(map[string]struct{})
+	mergedBranches := make([]string, 0, len(localBranches)+len(remoteBranches))
+	for _, branchName := range append(localBranches, remoteBranches...) {
+		if _, duplicate := seen[branchName]; duplicate {
+			continue
+		}
+		seen[branchName] = struct{}{}
+		if _, isExcluded := excluded[branchName]; isExcluded {
+			service.logger.Info(logMessageSkippingDefaultBranchConstant, append([]zap.Field{zap.String(logFieldBranchNameConstant, branchName)}, baseFields...)...)
+			continue
+		}
+		mergedBranches = append(mergedBranches, branchName)
+	}
+	sort.Strings(mergedBranches)
+	return mergedBranches, nil
+}
+
+func (service *Service) listMergedReferences(executionContext context.Context, workingDirectory string, mergedInto string, remote bool) (string, error) {
+	arguments := []string{branchSubcommandConstant}
+	if remote {
+		arguments = append(arguments, remotesFlagConstant)
+	}
+	arguments = append(arguments, mergedFlagConstant, mergedInto, referenceNameFormatFlagConstant)
+
+	executionResult, executionError := service.executor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:          arguments,
+		WorkingDirectory:   workingDirectory,
+		OutputCaptureLimit: execshell.UnlimitedOutputCapture,
+		Idempotent:         true,
+	})
+	if executionError != nil {
+		return "", fmt.Errorf(mergedBranchesListErrorTemplateConstant, mergedInto, executionError)
+	}
+	return executionResult.StandardOutput, nil
+}
+
+// resolveDefaultBranch returns the remote's default branch, or an empty string when it cannot be determined.
+func (service *Service) resolveDefaultBranch(executionContext context.Context, remoteName string, workingDirectory string, baseFields []zap.Field) string {
+	manager, managerError := gitrepo.NewRepositoryManager(service.executor)
+	if managerError != nil {
+		return ""
+	}
+	remoteHead, resolveError := manager.ResolveRemoteHead(executionContext, workingDirectory, remoteName, gitrepo.RemoteHeadOptions{})
+	if resolveError != nil {
+		service.logger.Warn(logMessageDefaultBranchUnknownConstant, append(baseFields, zap.Error(resolveError))...)
+		return ""
+	}
+	return remoteHead.Branch
+}
+
+// parseMergedReferences keeps the references under prefix, returning their names with the prefix removed. The
+// remote's symbolic HEAD is dropped.
+func parseMergedReferences(commandOutput string, prefix string) ([]string, error) {
+	branchNames := make([]string, 0)
+	scanner := bufio.NewScanner(strings.NewReader(commandOutput))
+	for scanner.Scan() {
+		referenceName := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(referenceName, prefix) {
+			continue
+		}
+		branchName := strings.TrimPrefix(referenceName, prefix)
+		if len(branchName) == 0 || branchName == remoteHeadBranchNameConstant {
+			continue
+		}
+		branchNames = append(branchNames, branchName)
+	}
+	if scanError := scanner.Err(); scanError != nil {
+		return nil, fmt.Errorf(mergedBranchesParsingErrorTemplateConstant, scanError)
+	}
+	return branchNames, nil
+}
+
+// reportBranchCandidates lists every branch offered for deletion with the sources that selected it, followed by a
+// per-source total; nothing is written when no candidate was recorded.
+func reportBranchCandidates(writer io.Writer, tally *BranchCandidateTally) {
+	records := tally.Records()
+	if len(records) == 0 {
+		return
+	}
+
+	repositories := make(map[string]struct{})
+	pullRequestOnly, mergedOnly, both := 0, 0, 0
+	for _, record := range records {
+		fmt.Fprintf(writer, branchCandidateTemplateConstant, record.RepositoryPath, record.Branch, record.Labels())
+		repositories[record.RepositoryPath] = struct{}{}
+		switch len(record.Sources) {
+		case 2:
+			both++
+		case 1:
+			if record.Sources[0] == BranchCandidateSourceMerged {
+				mergedOnly++
+			} else {
+				pullRequestOnly++
+			}
+		}
+	}
+	fmt.Fprintf(writer, branchCandidatesTotalTemplateConstant, pullRequestOnly, mergedOnly, both, len(repositories))
+}
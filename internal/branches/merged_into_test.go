@@ -0,0 +1,154 @@
+package branches_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	branches "github.com/temirov/gix/internal/branches"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+)
+
+func TestServiceCleanupMergedInto(testInstance *testing.T) {
+	const (
+		mergedIntoBranchConstant    = "main"
+		defaultBranchConstant       = "develop"
+		bothSourcesBranchConstant   = "feature/both"
+		pullRequestBranchConstant   = "feature/pr-only"
+		mergedLocalBranchConstant   = "feature/merged-local"
+		mergedRemoteBranchConstant  = "feature/merged-remote"
+		otherRemoteBranchConstant   = "feature/other-remote"
+		mergedLocalOutputConstant   = "refs/heads/main\nrefs/heads/develop\nrefs/heads/feature/both\nrefs/heads/feature/merged-local\n"
+		mergedRemoteOutputConstant  = "refs/remotes/origin/HEAD\nrefs/remotes/origin/main\nrefs/remotes/origin/develop\nrefs/remotes/origin/feature/both\nrefs/remotes/origin/feature/merged-remote\nrefs/remotes/upstream/feature/other-remote\n"
+		defaultBranchOutputConstant = "refs/remotes/origin/develop\n"
+	)
+
+	testCases := []struct {
+		name                  string
+		mergedInto            string
+		expectedRemoteDeletes []string
+		expectedLocalDeletes  []string
+		expectedCandidates    []branches.BranchCandidate
+	}{
+		{
+			name:                  "pull_request_mode_only",
+			expectedRemoteDeletes: []string{bothSourcesBranchConstant, pullRequestBranchConstant},
+			expectedLocalDeletes:  []string{bothSourcesBranchConstant},
+			expectedCandidates: []branches.BranchCandidate{
+				{RepositoryPath: testWorkingDirectoryConstant, Branch: bothSourcesBranchConstant, Sources: []branches.BranchCandidateSource{branches.BranchCandidateSourcePullRequest}},
+				{RepositoryPath: testWorkingDirectoryConstant, Branch: pullRequestBranchConstant, Sources: []branches.BranchCandidateSource{branches.BranchCandidateSourcePullRequest}},
+			},
+		},
+		{
+			name:                  "merged_branches_union_with_pull_requests",
+			mergedInto:            mergedIntoBranchConstant,
+			expectedRemoteDeletes: []string{bothSourcesBranchConstant, pullRequestBranchConstant, mergedRemoteBranchConstant},
+			expectedLocalDeletes:  []string{bothSourcesBranchConstant, mergedLocalBranchConstant},
+			expectedCandidates: []branches.BranchCandidate{
+				{RepositoryPath: testWorkingDirectoryConstant, Branch: bothSourcesBranchConstant, Sources: []branches.BranchCandidateSource{branches.BranchCandidateSourcePullRequest, branches.BranchCandidateSourceMerged}, MergedInto: mergedIntoBranchConstant},
+				{RepositoryPath: testWorkingDirectoryConstant, Branch: pullRequestBranchConstant, Sources: []branches.BranchCandidateSource{branches.BranchCandidateSourcePullRequest}, MergedInto: mergedIntoBranchConstant},
+				{RepositoryPath: testWorkingDirectoryConstant, Branch: mergedLocalBranchConstant, Sources: []branches.BranchCandidateSource{branches.BranchCandidateSourceMerged}, MergedInto: mergedIntoBranchConstant},
+				{RepositoryPath: testWorkingDirectoryConstant, Branch: mergedRemoteBranchConstant, Sources: []branches.BranchCandidateSource{branches.BranchCandidateSourceMerged}, MergedInto: mergedIntoBranchConstant},
+			},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(fmt.Sprintf(subtestNameTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
+			pullRequestJSON, encodingError := buildPullRequestJSON([]string{bothSourcesBranchConstant, pullRequestBranchConstant, bothSourcesBranchConstant})
+			require.NoError(testInstance, encodingError)
+
+			fakeExecutorInstance := execshelltest.NewExecutor()
+			remoteBranches := []string{mergedIntoBranchConstant, defaultBranchConstant, bothSourcesBranchConstant, pullRequestBranchConstant, mergedRemoteBranchConstant}
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}, execshell.ExecutionResult{StandardOutput: buildRemoteOutput(remoteBranches)}, nil)
+			registerResponse(fakeExecutorInstance, githubCommandLabelConstant, []string{
+				githubPullRequestSubcommandConstant,
+				githubListSubcommandConstant,
+				githubStateFlagConstant,
+				githubClosedStateConstant,
+				githubJSONFlagConstant,
+				pullRequestJSONFieldNameConstant,
+				githubLimitFlagConstant,
+				strconv.Itoa(testPullRequestLimitConstant),
+			}, execshell.ExecutionResult{StandardOutput: pullRequestJSON}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitBranchSubcommandConstant, "--merged", mergedIntoBranchConstant, "--format=%(refname)"}, execshell.ExecutionResult{StandardOutput: mergedLocalOutputConstant}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitBranchSubcommandConstant, "-r", "--merged", mergedIntoBranchConstant, "--format=%(refname)"}, execshell.ExecutionResult{StandardOutput: mergedRemoteOutputConstant}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{"symbolic-ref", "--quiet", "refs/remotes/origin/HEAD"}, execshell.ExecutionResult{StandardOutput: defaultBranchOutputConstant}, nil)
+
+			localBranchListing := ""
+			for _, branchName := range []string{mergedIntoBranchConstant, defaultBranchConstant, bothSourcesBranchConstant, mergedLocalBranchConstant} {
+				localBranchListing += fmt.Sprintf(localBranchRefLineTemplateConstant, branchName, remoteCommitPlaceholderConstant)
+			}
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitListLocalBranchesArguments, execshell.ExecutionResult{StandardOutput: localBranchListing}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitBranchDescriptionsArguments, execshell.ExecutionResult{}, nil)
+			for _, branchName := range []string{bothSourcesBranchConstant, pullRequestBranchConstant, mergedLocalBranchConstant, mergedRemoteBranchConstant, otherRemoteBranchConstant} {
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, branchName}, execshell.ExecutionResult{}, nil)
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, branchName}, execshell.ExecutionResult{}, nil)
+			}
+
+			service, serviceError := branches.NewService(zap.NewNop(), fakeExecutorInstance, nil)
+			require.NoError(testInstance, serviceError)
+
+			candidates := &branches.BranchCandidateTally{}
+			require.NoError(testInstance, service.Cleanup(context.Background(), branches.CleanupOptions{
+				RemoteName:       testRemoteNameConstant,
+				PullRequestLimit: testPullRequestLimitConstant,
+				WorkingDirectory: testWorkingDirectoryConstant,
+				AssumeYes:        true,
+				MergedInto:       testCase.mergedInto,
+				BranchCandidates: candidates,
+			}))
+
+			remoteDeletes := []string{}
+			localDeletes := []string{}
+			for _, arguments := range fakeExecutorInstance.ExecutedArguments(execshell.CommandGit) {
+				if len(arguments) == 4 && arguments[0] == gitPushSubcommandConstant {
+					remoteDeletes = append(remoteDeletes, arguments[3])
+				}
+				if len(arguments) == 3 && arguments[0] == gitBranchSubcommandConstant && arguments[1] == gitForceDeleteFlagConstant {
+					localDeletes = append(localDeletes, arguments[2])
+				}
+			}
+			require.Equal(testInstance, testCase.expectedRemoteDeletes, remoteDeletes)
+			require.Equal(testInstance, testCase.expectedLocalDeletes, localDeletes)
+			require.Equal(testInstance, testCase.expectedCandidates, candidates.Records())
+		})
+	}
+}
+
+func TestBranchCandidateLabels(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		candidate      branches.BranchCandidate
+		expectedLabels string
+	}{
+		{
+			name:           "pull_request_only",
+			candidate:      branches.BranchCandidate{Sources: []branches.BranchCandidateSource{branches.BranchCandidateSourcePullRequest}},
+			expectedLabels: "pull-request",
+		},
+		{
+			name:           "merged_only",
+			candidate:      branches.BranchCandidate{Sources: []branches.BranchCandidateSource{branches.BranchCandidateSourceMerged}, MergedInto: "main"},
+			expectedLabels: "merged-into:main",
+		},
+		{
+			name:           "both_sources",
+			candidate:      branches.BranchCandidate{Sources: []branches.BranchCandidateSource{branches.BranchCandidateSourcePullRequest, branches.BranchCandidateSourceMerged}, MergedInto: "main"},
+			expectedLabels: "pull-request,merged-into:main",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(fmt.Sprintf(subtestNameTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
+			require.Equal(testInstance, testCase.expectedLabels, testCase.candidate.Labels())
+		})
+	}
+}
@@ -12,24 +12,37 @@ import (
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/dependencies"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
 	rootutils "github.com/temirov/gix/internal/utils/roots"
 	"github.com/temirov/gix/internal/workflow"
 )
 
 const (
-	commandUseConstant                      = "branch-refresh"
-	commandShortDescriptionConstant         = "Fetch, checkout, and pull a branch"
-	commandLongDescriptionConstant          = "branch-refresh synchronizes a repository branch by fetching updates, checking out the branch, and pulling the latest changes."
-	stashFlagNameConstant                   = "stash"
-	stashFlagDescriptionConstant            = "Stash local changes before refreshing the branch"
-	commitFlagNameConstant                  = "commit"
-	commitFlagDescriptionConstant           = "Commit local changes before refreshing the branch"
-	missingBranchNameMessageConstant        = "branch name is required; supply --branch"
-	conflictingRecoveryFlagsMessageConstant = "use at most one of --stash or --commit"
-	branchFlagNameConstant                  = "branch"
-	branchFlagDescriptionConstant           = "Branch name to refresh"
-	refreshSuccessMessageTemplateConstant   = "REFRESHED: %s (%s)\n"
-	taskActionBranchRefreshType             = "branch.refresh"
+	commandUseConstant                        = "branch-refresh"
+	commandShortDescriptionConstant           = "Fetch, checkout, and pull a branch"
+	commandLongDescriptionConstant            = "branch-refresh synchronizes a repository branch by fetching updates, checking out the branch, and pulling the latest changes."
+	stashFlagNameConstant                     = "stash"
+	stashFlagDescriptionConstant              = "Stash local changes before refreshing the branch"
+	commitFlagNameConstant                    = "commit"
+	commitFlagDescriptionConstant             = "Commit local changes before refreshing the branch"
+	missingBranchNameMessageConstant          = "branch name is required; supply --branch"
+	conflictingRecoveryFlagsMessageConstant   = "use at most one of --stash or --commit"
+	branchFlagNameConstant                    = "branch"
+	branchFlagDescriptionConstant             = "Branch name to refresh"
+	fetchOnlyFlagNameConstant                 = "fetch-only"
+	fetchOnlyFlagDescriptionConstant          = "Only run git fetch --all --prune --tags in each repository, without checkout or pull"
+	conflictingFetchOnlyFlagsMessageConstant  = "--fetch-only cannot be combined with --stash or --commit"
+	jobsFlagNameConstant                      = "jobs"
+	jobsFlagDescriptionConstant               = "Number of repositories to fetch at once with --fetch-only"
+	invalidJobsMessageConstant                = "jobs must be at least 1"
+	fetchFailedTotalTemplateConstant          = "FETCH-FAILED-TOTAL: %d repositories failed to fetch\n"
+	fetchFailedRepositoryTemplateConstant     = "  %s (%s)\n"
+	fetchRetryTemplateConstant                = "retry with: %s --%s %s\n"
+	refreshSuccessMessageTemplateConstant     = "REFRESHED: %s (%s)\n"
+	refreshSummaryTemplateConstant            = "REFRESH-SUMMARY: refreshed=%d conflicts=%d failed=%d\n"
+	refreshConflictRepositoryTemplateConstant = "  %s (conflicts with upstream — manual merge required, %d conflicting file(s))\n"
+	refreshFailedRepositoryTemplateConstant   = "  %s (failed: %s)\n"
+	taskActionBranchRefreshType               = "branch.refresh"
 )
 
 // LoggerProvider yields a zap logger for command execution.
@@ -53,13 +66,15 @@ func (builder *CommandBuilder) Build() (*cobra.Command, error) {
 		Use:   commandUseConstant,
 		Short: commandShortDescriptionConstant,
 		Long:  commandLongDescriptionConstant,
-		Args:  cobra.NoArgs,
+		Args:  cobra.ArbitraryArgs,
 		RunE:  builder.run,
 	}
 
 	command.Flags().Bool(stashFlagNameConstant, false, stashFlagDescriptionConstant)
 	command.Flags().Bool(commitFlagNameConstant, false, commitFlagDescriptionConstant)
 	command.Flags().String(branchFlagNameConstant, "", branchFlagDescriptionConstant)
+	command.Flags().Bool(fetchOnlyFlagNameConstant, false, fetchOnlyFlagDescriptionConstant)
+	command.Flags().Int(jobsFlagNameConstant, DefaultFetchJobs, jobsFlagDescriptionConstant)
 
 	return command, nil
 }
@@ -67,6 +82,11 @@ func (builder *CommandBuilder) Build() (*cobra.Command, error) {
 func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) error {
 	configuration := builder.resolveConfiguration()
 
+	fetchOnly, fetchOnlyFlagError := command.Flags().GetBool(fetchOnlyFlagNameConstant)
+	if fetchOnlyFlagError != nil {
+		return fetchOnlyFlagError
+	}
+
 	branchName := strings.TrimSpace(configuration.BranchName)
 	if command != nil {
 		if branchFlagValue, flagError := command.Flags().GetString(branchFlagNameConstant); flagError == nil && command.Flags().Changed(branchFlagNameConstant) {
@@ -75,7 +95,7 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 			return flagError
 		}
 	}
-	if len(branchName) == 0 {
+	if len(branchName) == 0 && !fetchOnly {
 		if command != nil {
 			_ = command.Help()
 		}
@@ -93,6 +113,21 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 	if stashRequested && commitRequested {
 		return errors.New(conflictingRecoveryFlagsMessageConstant)
 	}
+	if fetchOnly && (stashRequested || commitRequested) {
+		return errors.New(conflictingFetchOnlyFlagsMessageConstant)
+	}
+
+	jobs := configuration.Jobs
+	if command.Flags().Changed(jobsFlagNameConstant) {
+		jobsValue, jobsError := command.Flags().GetInt(jobsFlagNameConstant)
+		if jobsError != nil {
+			return jobsError
+		}
+		jobs = jobsValue
+	}
+	if jobs < 1 {
+		return errors.New(invalidJobsMessageConstant)
+	}
 
 	repositoryRoots, rootsError := rootutils.Resolve(command, arguments, configuration.RepositoryRoots)
 	if rootsError != nil {
@@ -100,6 +135,15 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 	}
 
 	logger := builder.resolveLogger()
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), struct {
+		Configuration   CommandConfiguration
+		BranchName      string
+		FetchOnly       bool
+		Jobs            int
+		Stash           bool
+		Commit          bool
+		RepositoryRoots []string
+	}{Configuration: configuration, BranchName: branchName, FetchOnly: fetchOnly, Jobs: jobs, Stash: stashRequested, Commit: commitRequested, RepositoryRoots: repositoryRoots})
 	humanReadableLogging := false
 	if builder.HumanReadableLoggingProvider != nil {
 		humanReadableLogging = builder.HumanReadableLoggingProvider()
@@ -126,6 +170,9 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 	}
 
 	repositoryDiscoverer := dependencies.ResolveRepositoryDiscoverer(builder.Discoverer)
+	if fetchOnly {
+		return runFetchOnly(command, repositoryDiscoverer, gitExecutor, concreteManager, repositoryRoots, jobs)
+	}
 	fileSystem := dependencies.ResolveFileSystem(builder.FileSystem)
 
 	gitHubClient, clientError := githubcli.NewClient(gitExecutor)
@@ -146,12 +193,15 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 	}
 
 	taskRunner := resolveTaskRunner(builder.TaskRunnerFactory, taskDependencies)
+	runtimeOptions := workflow.RuntimeOptions{DryRun: false, AssumeYes: false}
 
+	refreshTally := &RefreshTally{}
 	actionOptions := map[string]any{
 		"branch":        branchName,
 		"stash":         stashRequested,
 		"commit":        commitRequested,
 		"require_clean": true,
+		"refresh_tally": refreshTally,
 	}
 
 	taskDefinition := workflow.TaskDefinition{
@@ -162,9 +212,75 @@ func (builder *CommandBuilder) run(command *cobra.Command, arguments []string) e
 		},
 	}
 
-	runtimeOptions := workflow.RuntimeOptions{DryRun: false, AssumeYes: false}
+	if runError := taskRunner.Run(command.Context(), repositoryRoots, []workflow.TaskDefinition{taskDefinition}, runtimeOptions); runError != nil {
+		return runError
+	}
+	return reportRefreshSummary(command, refreshTally)
+}
+
+// reportRefreshSummary prints the refresh outcome counts, listing conflicted and failed repositories, and returns a
+// PartialRefreshError when any repository did not refresh.
+func reportRefreshSummary(command *cobra.Command, refreshTally *RefreshTally) error {
+	conflicts := refreshTally.Conflicts()
+	failures := refreshTally.Failures()
+	refreshed := refreshTally.Refreshed()
+	if refreshed+len(conflicts)+len(failures) == 0 {
+		return nil
+	}
+
+	errorWriter := command.ErrOrStderr()
+	fmt.Fprintf(errorWriter, refreshSummaryTemplateConstant, refreshed, len(conflicts), len(failures))
+	for _, conflict := range conflicts {
+		fmt.Fprintf(errorWriter, refreshConflictRepositoryTemplateConstant, conflict.RepositoryPath, conflict.ConflictingFiles)
+	}
+	for _, failure := range failures {
+		fmt.Fprintf(errorWriter, refreshFailedRepositoryTemplateConstant, failure.RepositoryPath, failure.Message)
+	}
+	if len(conflicts) == 0 && len(failures) == 0 {
+		return nil
+	}
+	return PartialRefreshError{Conflicts: conflicts, Failures: failures}
+}
+
+// runFetchOnly fetches the repositories under the roots, running up to jobs fetches at once, and summarizes the
+// failures with a command that retries just those repositories.
+func runFetchOnly(command *cobra.Command, repositoryDiscoverer shared.RepositoryDiscoverer, gitExecutor shared.GitExecutor, repositoryManager shared.GitRepositoryManager, repositoryRoots []string, jobs int) error {
+	repositoryPaths, discoveryError := repositoryDiscoverer.DiscoverRepositories(repositoryRoots)
+	if discoveryError != nil {
+		return discoveryError
+	}
+
+	service, serviceError := NewService(Dependencies{GitExecutor: gitExecutor, RepositoryManager: repositoryManager})
+	if serviceError != nil {
+		return serviceError
+	}
+
+	failureTally := &FetchFailureTally{}
+	if fetchError := service.FetchAll(command.Context(), FetchAllOptions{
+		RepositoryPaths: repositoryPaths,
+		Jobs:            jobs,
+		Output:          command.OutOrStdout(),
+		Errors:          command.ErrOrStderr(),
+		FailureTally:    failureTally,
+	}); fetchError != nil {
+		return fetchError
+	}
+
+	failures := failureTally.Records()
+	if len(failures) == 0 {
+		return nil
+	}
+
+	failedPaths := make([]string, 0, len(failures))
+	errorWriter := command.ErrOrStderr()
+	fmt.Fprintf(errorWriter, fetchFailedTotalTemplateConstant, len(failures))
+	for _, failure := range failures {
+		fmt.Fprintf(errorWriter, fetchFailedRepositoryTemplateConstant, failure.RepositoryPath, failure.Reason)
+		failedPaths = append(failedPaths, failure.RepositoryPath)
+	}
+	fmt.Fprintf(errorWriter, fetchRetryTemplateConstant, command.CommandPath(), fetchOnlyFlagNameConstant, strings.Join(failedPaths, " "))
 
-	return taskRunner.Run(command.Context(), repositoryRoots, []workflow.TaskDefinition{taskDefinition}, runtimeOptions)
+	return PartialFetchError{Failures: failures}
 }
 
 func (builder *CommandBuilder) resolveConfiguration() CommandConfiguration {
@@ -1,8 +1,10 @@
 package refresh_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -11,32 +13,11 @@ import (
 
 	"github.com/temirov/gix/internal/branches/refresh"
 	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 	"github.com/temirov/gix/internal/workflow"
 )
 
-type recordingGitExecutor struct {
-	invocationErrors []error
-	recordedCommands []execshell.CommandDetails
-}
-
-func (executor *recordingGitExecutor) ExecuteGit(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	executor.recordedCommands = append(executor.recordedCommands, details)
-	if len(executor.invocationErrors) == 0 {
-		return execshell.ExecutionResult{}, nil
-	}
-	err := executor.invocationErrors[0]
-	executor.invocationErrors = executor.invocationErrors[1:]
-	if err != nil {
-		return execshell.ExecutionResult{}, err
-	}
-	return execshell.ExecutionResult{}, nil
-}
-
-func (executor *recordingGitExecutor) ExecuteGitHubCLI(_ context.Context, _ execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return execshell.ExecutionResult{}, nil
-}
-
 type constantCleanRepositoryManager struct{}
 
 type erroringRepositoryManager struct{}
@@ -108,7 +89,7 @@ func TestCommandRequiresBranchName(t *testing.T) {
 		ConfigurationProvider: func() refresh.CommandConfiguration {
 			return refresh.CommandConfiguration{}
 		},
-		GitExecutor:          &recordingGitExecutor{},
+		GitExecutor:          execshelltest.NewPermissiveExecutor(),
 		GitRepositoryManager: constantCleanRepositoryManager{},
 		TaskRunnerFactory: func(workflow.Dependencies) refresh.TaskRunnerExecutor {
 			return &recordingTaskRunner{}
@@ -122,7 +103,7 @@ func TestCommandRequiresBranchName(t *testing.T) {
 
 func TestCommandRunsSuccessfully(t *testing.T) {
 	temporaryRepository := t.TempDir()
-	executor := &recordingGitExecutor{}
+	executor := execshelltest.NewPermissiveExecutor()
 	runner := &recordingTaskRunner{}
 	builder := refresh.CommandBuilder{
 		LoggerProvider: func() *zap.Logger { return zap.NewNop() },
@@ -164,7 +145,7 @@ func TestCommandReportsDirtyWorktree(t *testing.T) {
 		ConfigurationProvider: func() refresh.CommandConfiguration {
 			return refresh.CommandConfiguration{RepositoryRoots: []string{temporaryRepository}, BranchName: "main"}
 		},
-		GitExecutor:          &recordingGitExecutor{},
+		GitExecutor:          execshelltest.NewPermissiveExecutor(),
 		GitRepositoryManager: erroringRepositoryManager{},
 		TaskRunnerFactory: func(workflow.Dependencies) refresh.TaskRunnerExecutor {
 			return failingTaskRunner{err: failure}
@@ -184,7 +165,7 @@ func TestCommandRejectsConflictingFlags(t *testing.T) {
 		ConfigurationProvider: func() refresh.CommandConfiguration {
 			return refresh.CommandConfiguration{RepositoryRoots: []string{temporaryRepository}, BranchName: "main"}
 		},
-		GitExecutor:          &recordingGitExecutor{},
+		GitExecutor:          execshelltest.NewPermissiveExecutor(),
 		GitRepositoryManager: constantCleanRepositoryManager{},
 		TaskRunnerFactory: func(workflow.Dependencies) refresh.TaskRunnerExecutor {
 			return &recordingTaskRunner{}
@@ -199,3 +180,208 @@ func TestCommandRejectsConflictingFlags(t *testing.T) {
 
 	require.Error(t, command.RunE(command, []string{}))
 }
+
+type staticRepositoryDiscoverer struct {
+	repositories []string
+}
+
+func (discoverer staticRepositoryDiscoverer) DiscoverRepositories([]string) ([]string, error) {
+	return append([]string{}, discoverer.repositories...), nil
+}
+
+func TestCommandFetchOnly(t *testing.T) {
+	repositories := []string{"/repositories/alpha", "/repositories/beta", "/repositories/gamma"}
+	testCases := []struct {
+		name             string
+		failingStderr    map[string]string
+		jobs             string
+		expectedFailures []refresh.FetchFailure
+		expectedSummary  string
+		expectedError    string
+	}{
+		{
+			name: "all_fetches_succeed",
+			jobs: "2",
+		},
+		{
+			name: "failures_summarized_for_retry",
+			failingStderr: map[string]string{
+				"/repositories/alpha": "fatal: Authentication failed for 'https://github.com/owner/alpha.git/'",
+				"/repositories/gamma": "fatal: unable to access 'https://github.com/owner/gamma.git/': Could not resolve host: github.com",
+			},
+			expectedFailures: []refresh.FetchFailure{
+				{RepositoryPath: "/repositories/alpha", Reason: refresh.FetchFailureReasonAuthentication},
+				{RepositoryPath: "/repositories/gamma", Reason: refresh.FetchFailureReasonUnreachable},
+			},
+			expectedSummary: "FETCH-FAILED-TOTAL: 2 repositories failed to fetch\n  /repositories/alpha (auth)\n  /repositories/gamma (unreachable)\nretry with: branch-refresh --fetch-only /repositories/alpha /repositories/gamma\n",
+		},
+		{
+			name:          "jobs_below_one_rejected",
+			jobs:          "0",
+			expectedError: "jobs must be at least 1",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			executor := execshelltest.NewExecutor()
+			executor.On(execshell.CommandGit, execshelltest.ArgumentPrefix("fetch"))
+			for repositoryPath, standardError := range testCase.failingStderr {
+				executor.On(execshell.CommandGit, execshelltest.ArgumentPrefix("fetch")).InDirectory(repositoryPath).FailWith(128, standardError)
+			}
+			builder := refresh.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				ConfigurationProvider: func() refresh.CommandConfiguration {
+					return refresh.CommandConfiguration{RepositoryRoots: []string{subtest.TempDir()}}
+				},
+				GitExecutor:          executor,
+				GitRepositoryManager: constantCleanRepositoryManager{},
+				Discoverer:           staticRepositoryDiscoverer{repositories: repositories},
+			}
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Enabled: true})
+			outputBuffer := &bytes.Buffer{}
+			errorBuffer := &bytes.Buffer{}
+			command.SetOut(outputBuffer)
+			command.SetErr(errorBuffer)
+			command.SetContext(context.Background())
+
+			require.NoError(subtest, command.Flags().Set("fetch-only", "true"))
+			if len(testCase.jobs) > 0 {
+				require.NoError(subtest, command.Flags().Set("jobs", testCase.jobs))
+			}
+
+			runError := command.RunE(command, []string{})
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, runError, testCase.expectedError)
+				require.Empty(subtest, executor.Executed())
+				return
+			}
+			require.Len(subtest, executor.Executed(), len(repositories))
+			require.Equal(subtest, len(repositories)-len(testCase.failingStderr), strings.Count(outputBuffer.String(), "FETCHED: "))
+			require.Equal(subtest, len(testCase.failingStderr), strings.Count(errorBuffer.String(), "FETCH-FAILED: "))
+			require.True(subtest, strings.HasSuffix(errorBuffer.String(), testCase.expectedSummary))
+			if len(testCase.expectedFailures) == 0 {
+				require.NoError(subtest, runError)
+				return
+			}
+			var partialFetchError refresh.PartialFetchError
+			require.ErrorAs(subtest, runError, &partialFetchError)
+			require.Len(subtest, partialFetchError.Failures, len(testCase.expectedFailures))
+			for failureIndex, expectedFailure := range testCase.expectedFailures {
+				require.Equal(subtest, expectedFailure.RepositoryPath, partialFetchError.Failures[failureIndex].RepositoryPath)
+				require.Equal(subtest, expectedFailure.Reason, partialFetchError.Failures[failureIndex].Reason)
+			}
+		})
+	}
+}
+
+type talliedRefreshTaskRunner struct {
+	refreshed int
+	conflicts []refresh.PullConflictError
+	failures  []refresh.RefreshFailure
+}
+
+func (runner *talliedRefreshTaskRunner) Run(_ context.Context, _ []string, definitions []workflow.TaskDefinition, _ workflow.RuntimeOptions) error {
+	refreshTally := definitions[0].Actions[0].Options["refresh_tally"].(*refresh.RefreshTally)
+	for refreshedIndex := 0; refreshedIndex < runner.refreshed; refreshedIndex++ {
+		refreshTally.AddRefreshed()
+	}
+	for _, conflict := range runner.conflicts {
+		refreshTally.AddConflict(conflict)
+	}
+	for _, failure := range runner.failures {
+		refreshTally.AddFailure(failure)
+	}
+	return nil
+}
+
+func TestCommandRefreshSummary(t *testing.T) {
+	testCases := []struct {
+		name           string
+		runner         *talliedRefreshTaskRunner
+		expectedStderr string
+		expectPartial  bool
+	}{
+		{
+			name:   "no_repositories",
+			runner: &talliedRefreshTaskRunner{},
+		},
+		{
+			name:           "all_refreshed",
+			runner:         &talliedRefreshTaskRunner{refreshed: 2},
+			expectedStderr: "REFRESH-SUMMARY: refreshed=2 conflicts=0 failed=0\n",
+		},
+		{
+			name: "conflicts_counted_apart_from_failures",
+			runner: &talliedRefreshTaskRunner{
+				refreshed: 1,
+				conflicts: []refresh.PullConflictError{{RepositoryPath: "/repositories/alpha", BranchName: "main", ConflictingFiles: 3}},
+				failures:  []refresh.RefreshFailure{{RepositoryPath: "/repositories/beta", BranchName: "main", Message: "repository worktree is not clean"}},
+			},
+			expectedStderr: "REFRESH-SUMMARY: refreshed=1 conflicts=1 failed=1\n" +
+				"  /repositories/alpha (conflicts with upstream — manual merge required, 3 conflicting file(s))\n" +
+				"  /repositories/beta (failed: repository worktree is not clean)\n",
+			expectPartial: true,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			builder := refresh.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				ConfigurationProvider: func() refresh.CommandConfiguration {
+					return refresh.CommandConfiguration{RepositoryRoots: []string{subtest.TempDir()}, BranchName: "main"}
+				},
+				GitExecutor:          execshelltest.NewPermissiveExecutor(),
+				GitRepositoryManager: constantCleanRepositoryManager{},
+				TaskRunnerFactory: func(workflow.Dependencies) refresh.TaskRunnerExecutor {
+					return testCase.runner
+				},
+			}
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Enabled: true})
+			errorBuffer := &bytes.Buffer{}
+			command.SetErr(errorBuffer)
+			command.SetContext(context.Background())
+
+			runError := command.RunE(command, []string{})
+			require.Equal(subtest, testCase.expectedStderr, errorBuffer.String())
+			if !testCase.expectPartial {
+				require.NoError(subtest, runError)
+				return
+			}
+			var partialRefreshError refresh.PartialRefreshError
+			require.ErrorAs(subtest, runError, &partialRefreshError)
+			require.Equal(subtest, testCase.runner.conflicts, partialRefreshError.Conflicts)
+			require.Equal(subtest, testCase.runner.failures, partialRefreshError.Failures)
+			require.Equal(subtest, 2, partialRefreshError.ProcessExitCode())
+		})
+	}
+}
+
+func TestCommandRejectsFetchOnlyWithRecoveryFlags(t *testing.T) {
+	builder := refresh.CommandBuilder{
+		LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+		ConfigurationProvider: func() refresh.CommandConfiguration {
+			return refresh.CommandConfiguration{RepositoryRoots: []string{t.TempDir()}}
+		},
+		GitExecutor:          execshelltest.NewPermissiveExecutor(),
+		GitRepositoryManager: constantCleanRepositoryManager{},
+		TaskRunnerFactory: func(workflow.Dependencies) refresh.TaskRunnerExecutor {
+			return &recordingTaskRunner{}
+		},
+	}
+	command, buildError := builder.Build()
+	require.NoError(t, buildError)
+	flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Enabled: true})
+
+	require.NoError(t, command.Flags().Set("fetch-only", "true"))
+	require.NoError(t, command.Flags().Set("stash", "true"))
+
+	require.Error(t, command.RunE(command, []string{}))
+}
@@ -12,11 +12,15 @@ var refreshConfigurationRepositoryPathSanitizer = pathutils.NewRepositoryPathSan
 type CommandConfiguration struct {
 	RepositoryRoots []string `mapstructure:"roots"`
 	BranchName      string   `mapstructure:"branch"`
+	Jobs            int      `mapstructure:"jobs"`
 }
 
-// DefaultCommandConfiguration returns empty defaults for the branch refresh command.
+// DefaultFetchJobs is the number of repositories fetched at once by --fetch-only unless configured otherwise.
+const DefaultFetchJobs = 4
+
+// DefaultCommandConfiguration returns the defaults for the branch refresh command.
 func DefaultCommandConfiguration() CommandConfiguration {
-	return CommandConfiguration{}
+	return CommandConfiguration{Jobs: DefaultFetchJobs}
 }
 
 // Sanitize trims textual configuration values and normalizes repository roots.
@@ -24,5 +28,8 @@ func (configuration CommandConfiguration) Sanitize() CommandConfiguration {
 	sanitized := configuration
 	sanitized.BranchName = strings.TrimSpace(configuration.BranchName)
 	sanitized.RepositoryRoots = refreshConfigurationRepositoryPathSanitizer.Sanitize(configuration.RepositoryRoots)
+	if sanitized.Jobs == 0 {
+		sanitized.Jobs = DefaultFetchJobs
+	}
 	return sanitized
 }
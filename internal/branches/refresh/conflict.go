@@ -0,0 +1,96 @@
+package refresh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	gitMergeSubcommandConstant        = "merge"
+	gitRebaseSubcommandConstant       = "rebase"
+	gitAbortFlagConstant              = "--abort"
+	pullConflictLinePrefixConstant    = "CONFLICT"
+	pullAutomaticMergeFailedMarker    = "automatic merge failed"
+	pullRebaseCouldNotApplyMarker     = "could not apply"
+	pullConflictErrorTemplateConstant = "%s conflicts with upstream — manual merge required (%d conflicting file(s))"
+	pullConflictAbortFailedTemplate   = "%s; %s --abort failed: %v"
+	pullOutputLineSeparatorConstant   = "\n"
+)
+
+// PullConflictError reports a pull that stopped on merge conflicts. The refresh aborts the merge or rebase so the
+// repository returns to its state before the pull; AbortError records a failed abort.
+type PullConflictError struct {
+	RepositoryPath   string
+	BranchName       string
+	Strategy         execshell.GitPullStrategy
+	ConflictingFiles int
+	AbortError       error
+}
+
+// Error describes the conflict and the number of conflicting files git reported.
+func (conflictError PullConflictError) Error() string {
+	message := fmt.Sprintf(pullConflictErrorTemplateConstant, conflictError.RepositoryPath, conflictError.ConflictingFiles)
+	if conflictError.AbortError != nil {
+		return fmt.Sprintf(pullConflictAbortFailedTemplate, message, conflictError.abortSubcommand(), conflictError.AbortError)
+	}
+	return message
+}
+
+// Unwrap exposes the abort failure, if any.
+func (conflictError PullConflictError) Unwrap() error {
+	return conflictError.AbortError
+}
+
+func (conflictError PullConflictError) abortSubcommand() string {
+	return abortSubcommandForStrategy(conflictError.Strategy)
+}
+
+func abortSubcommandForStrategy(strategy execshell.GitPullStrategy) string {
+	if strategy == execshell.GitPullStrategyRebase {
+		return gitRebaseSubcommandConstant
+	}
+	return gitMergeSubcommandConstant
+}
+
+// detectPullConflict reports whether a failed pull stopped on conflicts and how many files git listed as conflicting.
+func detectPullConflict(pullResult execshell.ExecutionResult, pullError error) (int, bool) {
+	var commandFailure execshell.CommandFailedError
+	if !errors.As(pullError, &commandFailure) {
+		return 0, false
+	}
+	output := strings.Join([]string{
+		pullResult.StandardOutput,
+		pullResult.StandardError,
+		commandFailure.Result.StandardOutput,
+		commandFailure.Result.StandardError,
+	}, pullOutputLineSeparatorConstant)
+
+	conflictingFiles := map[string]struct{}{}
+	conflictDetected := false
+	for _, line := range strings.Split(output, pullOutputLineSeparatorConstant) {
+		trimmedLine := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmedLine, pullConflictLinePrefixConstant) {
+			conflictingFiles[trimmedLine] = struct{}{}
+			conflictDetected = true
+			continue
+		}
+		loweredLine := strings.ToLower(trimmedLine)
+		if strings.Contains(loweredLine, pullAutomaticMergeFailedMarker) || strings.Contains(loweredLine, pullRebaseCouldNotApplyMarker) {
+			conflictDetected = true
+		}
+	}
+	return len(conflictingFiles), conflictDetected
+}
+
+// abortConflictedPull restores the repository to its state before a conflicted pull.
+func (service *Service) abortConflictedPull(executionContext context.Context, repositoryPath string, strategy execshell.GitPullStrategy) error {
+	return service.executeGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{abortSubcommandForStrategy(strategy), gitAbortFlagConstant},
+		WorkingDirectory: repositoryPath,
+		Idempotent:       false,
+	})
+}
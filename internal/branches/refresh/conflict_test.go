@@ -0,0 +1,116 @@
+package refresh
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+)
+
+const (
+	conflictTestRebaseOutputConstant = "Auto-merging a.go\nCONFLICT (content): Merge conflict in a.go\nCONFLICT (modify/delete): b.go deleted in HEAD and modified in 1a2b3c4.\n"
+	conflictTestRebaseErrorConstant  = "error: could not apply 1a2b3c4... checkpoint\nResolve all conflicts manually, mark them as resolved with \"git add/rm <conflicted_files>\", then run \"git rebase --continue\".\n"
+	conflictTestMergeOutputConstant  = "Auto-merging README.md\nCONFLICT (content): Merge conflict in README.md\nAutomatic merge failed; fix conflicts and then commit the result.\n"
+)
+
+func failingPull(standardOutput string, standardError string) execshelltest.ResponseFunc {
+	return func(details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+		result := execshell.ExecutionResult{StandardOutput: standardOutput, StandardError: standardError, ExitCode: 1}
+		return result, execshell.CommandFailedError{Command: execshell.ShellCommand{Name: execshell.CommandGit, Details: details}, Result: result}
+	}
+}
+
+func TestRefreshAbortsConflictedPull(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		commitChanges          bool
+		pullArguments          []string
+		pullResponse           execshelltest.ResponseFunc
+		abortError             error
+		expectedConflict       bool
+		expectedStrategy       execshell.GitPullStrategy
+		expectedFiles          int
+		expectedAbortArguments []string
+		expectedErrorText      string
+	}{
+		{
+			name:                   "rebase_conflict_aborts_rebase",
+			commitChanges:          true,
+			pullArguments:          []string{gitPullSubcommandConstant, gitPullRebaseFlagConstant},
+			pullResponse:           failingPull(conflictTestRebaseOutputConstant, conflictTestRebaseErrorConstant),
+			expectedConflict:       true,
+			expectedStrategy:       execshell.GitPullStrategyRebase,
+			expectedFiles:          2,
+			expectedAbortArguments: []string{gitRebaseSubcommandConstant, gitAbortFlagConstant},
+			expectedErrorText:      "/tmp/repo conflicts with upstream — manual merge required (2 conflicting file(s))",
+		},
+		{
+			name:                   "merge_conflict_aborts_merge",
+			pullArguments:          []string{gitPullSubcommandConstant, gitPullFastForwardFlagConstant},
+			pullResponse:           failingPull(conflictTestMergeOutputConstant, ""),
+			expectedConflict:       true,
+			expectedStrategy:       execshell.GitPullStrategyFastForwardOnly,
+			expectedFiles:          1,
+			expectedAbortArguments: []string{gitMergeSubcommandConstant, gitAbortFlagConstant},
+			expectedErrorText:      "/tmp/repo conflicts with upstream — manual merge required (1 conflicting file(s))",
+		},
+		{
+			name:                   "abort_failure_is_reported",
+			commitChanges:          true,
+			pullArguments:          []string{gitPullSubcommandConstant, gitPullRebaseFlagConstant},
+			pullResponse:           failingPull(conflictTestRebaseOutputConstant, conflictTestRebaseErrorConstant),
+			abortError:             errors.New("no rebase in progress"),
+			expectedConflict:       true,
+			expectedStrategy:       execshell.GitPullStrategyRebase,
+			expectedFiles:          2,
+			expectedAbortArguments: []string{gitRebaseSubcommandConstant, gitAbortFlagConstant},
+			expectedErrorText:      "/tmp/repo conflicts with upstream — manual merge required (2 conflicting file(s)); rebase --abort failed: no rebase in progress",
+		},
+		{
+			name:              "other_pull_failure_is_not_a_conflict",
+			pullArguments:     []string{gitPullSubcommandConstant, gitPullFastForwardFlagConstant},
+			pullResponse:      failingPull("", "fatal: Not possible to fast-forward, aborting.\n"),
+			expectedErrorText: "failed to pull latest changes: git command exited with code 1",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			executor := execshelltest.NewPermissiveExecutor()
+			executor.OnGit(testCase.pullArguments...).Respond(testCase.pullResponse)
+			if testCase.abortError != nil {
+				executor.OnGit(testCase.expectedAbortArguments...).Fail(testCase.abortError)
+			}
+			cleanStates := []bool{true}
+			if testCase.commitChanges {
+				cleanStates = []bool{false, true}
+			}
+			service, creationError := NewService(Dependencies{GitExecutor: executor, RepositoryManager: &stubRepositoryManager{cleanStates: cleanStates}})
+			require.NoError(subtest, creationError)
+
+			_, refreshError := service.Refresh(context.Background(), Options{RepositoryPath: "/tmp/repo", BranchName: "main", RequireClean: true, CommitChanges: testCase.commitChanges})
+			require.EqualError(subtest, refreshError, testCase.expectedErrorText)
+
+			executedArguments := executor.ExecutedArguments(execshell.CommandGit)
+			var conflictError PullConflictError
+			if !testCase.expectedConflict {
+				require.False(subtest, errors.As(refreshError, &conflictError))
+				require.Equal(subtest, testCase.pullArguments, executedArguments[len(executedArguments)-1])
+				return
+			}
+			require.ErrorAs(subtest, refreshError, &conflictError)
+			require.Equal(subtest, testCase.expectedStrategy, conflictError.Strategy)
+			require.Equal(subtest, testCase.expectedFiles, conflictError.ConflictingFiles)
+			require.Equal(subtest, "main", conflictError.BranchName)
+			if testCase.abortError != nil {
+				require.ErrorIs(subtest, refreshError, testCase.abortError)
+			}
+			require.Equal(subtest, testCase.expectedAbortArguments, executedArguments[len(executedArguments)-1])
+		})
+	}
+}
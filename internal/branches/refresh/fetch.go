@@ -0,0 +1,215 @@
+package refresh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/repos/fanout"
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	gitFetchAllFlagConstant              = "--all"
+	gitFetchTagsFlagConstant             = "--tags"
+	fetchNewBranchMarkerConstant         = "[new branch]"
+	fetchNewTagMarkerConstant            = "[new tag]"
+	fetchNewReferenceMarkerConstant      = "[new ref]"
+	fetchFailureErrorTemplateConstant    = "fetch failed for %s (%s): %s"
+	partialFetchErrorTemplateConstant    = "%d repository fetch(es) failed"
+	fetchAuthenticationFailedMarker      = "authentication failed"
+	fetchPermissionDeniedMarker          = "permission denied"
+	fetchUsernamePromptMarker            = "could not read username"
+	fetchRepositoryNotFoundMarker        = "repository not found"
+	fetchResolveHostMarker               = "could not resolve host"
+	fetchConnectionTimedOutMarker        = "connection timed out"
+	fetchConnectionRefusedMarker         = "connection refused"
+	fetchUnableToAccessMarker            = "unable to access"
+	fetchCouldNotReadFromRemoteMarker    = "could not read from remote repository"
+	fetchFailureMessageSeparatorConstant = ": "
+	fetchPlanTemplateConstant            = "FETCH-PLAN: %s\n"
+	fetchedTemplateConstant              = "FETCHED: %s duration=%s new_refs=%d\n"
+	fetchFailedTemplateConstant          = "FETCH-FAILED: %s reason=%s message=%s\n"
+	fetchDurationPrecisionConstant       = time.Millisecond
+)
+
+// FetchFailureReason classifies why a fetch-only refresh failed.
+type FetchFailureReason string
+
+// Supported fetch failure reasons.
+const (
+	FetchFailureReasonAuthentication FetchFailureReason = FetchFailureReason("auth")
+	FetchFailureReasonUnreachable    FetchFailureReason = FetchFailureReason("unreachable")
+	FetchFailureReasonOther          FetchFailureReason = FetchFailureReason("error")
+)
+
+// FetchResult captures the outcome of a fetch-only refresh.
+type FetchResult struct {
+	RepositoryPath string
+	Duration       time.Duration
+	NewReferences  int
+}
+
+// FetchFailure records a repository whose fetch-only refresh failed.
+type FetchFailure struct {
+	RepositoryPath string
+	Reason         FetchFailureReason
+	Message        string
+}
+
+// Error describes the failed fetch and its classified reason.
+func (failure FetchFailure) Error() string {
+	return fmt.Sprintf(fetchFailureErrorTemplateConstant, failure.RepositoryPath, failure.Reason, failure.Message)
+}
+
+// PartialFetchError reports that a fetch-only run completed but one or more repositories failed to fetch.
+type PartialFetchError struct {
+	utils.PartialFailure
+	Failures []FetchFailure
+}
+
+// Error summarizes how many repositories failed to fetch.
+func (partialFetchError PartialFetchError) Error() string {
+	return fmt.Sprintf(partialFetchErrorTemplateConstant, len(partialFetchError.Failures))
+}
+
+// FetchFailureTally accumulates fetch failures across every repository processed in a run.
+type FetchFailureTally = utils.Tally[FetchFailure]
+
+// FetchAllOptions describe a fetch-only run across repositories. Jobs bounds how many repositories fetch at once and
+// defaults to one.
+type FetchAllOptions struct {
+	RepositoryPaths []string
+	Jobs            int
+	DryRun          bool
+	Output          io.Writer
+	Errors          io.Writer
+	FailureTally    *FetchFailureTally
+}
+
+// FetchAll fetches every repository on the fan-out worker pool. Each fetch prints a FETCHED line to Output; each
+// FetchFailure prints a FETCH-FAILED line to Errors and is recorded in FailureTally in repository order. A dry run
+// prints a FETCH-PLAN line per repository instead. Other errors, such as a missing git executable, and failures
+// without a tally are returned once every fetch has finished.
+func (service *Service) FetchAll(executionContext context.Context, options FetchAllOptions) error {
+	if options.DryRun {
+		for _, repositoryPath := range options.RepositoryPaths {
+			writeFetchLine(nil, options.Output, fetchPlanTemplateConstant, repositoryPath)
+		}
+		return nil
+	}
+
+	var outputMutex sync.Mutex
+	fetchErrors := make([]error, len(options.RepositoryPaths))
+	fanout.ForEach(len(options.RepositoryPaths), options.Jobs, func(index int) {
+		fetchResult, fetchError := service.Fetch(executionContext, options.RepositoryPaths[index])
+		fetchErrors[index] = fetchError
+		var fetchFailure FetchFailure
+		switch {
+		case fetchError == nil:
+			writeFetchLine(&outputMutex, options.Output, fetchedTemplateConstant, fetchResult.RepositoryPath, fetchResult.Duration.Round(fetchDurationPrecisionConstant), fetchResult.NewReferences)
+		case errors.As(fetchError, &fetchFailure) && options.FailureTally != nil:
+			writeFetchLine(&outputMutex, options.Errors, fetchFailedTemplateConstant, fetchFailure.RepositoryPath, fetchFailure.Reason, fetchFailure.Message)
+		}
+	})
+
+	for _, fetchError := range fetchErrors {
+		var fetchFailure FetchFailure
+		if fetchError == nil {
+			continue
+		}
+		if !errors.As(fetchError, &fetchFailure) || options.FailureTally == nil {
+			return fetchError
+		}
+		options.FailureTally.Add(fetchFailure)
+	}
+	return nil
+}
+
+func writeFetchLine(mutex *sync.Mutex, writer io.Writer, template string, arguments ...any) {
+	if writer == nil {
+		return
+	}
+	if mutex != nil {
+		mutex.Lock()
+		defer mutex.Unlock()
+	}
+	fmt.Fprintf(writer, template, arguments...)
+}
+
+// Fetch updates every remote of the repository, pruning deleted references and fetching tags, without touching the worktree.
+// Git failures are returned as FetchFailure values carrying the classified cause.
+func (service *Service) Fetch(executionContext context.Context, repositoryPath string) (FetchResult, error) {
+	trimmedRepositoryPath := strings.TrimSpace(repositoryPath)
+	if len(trimmedRepositoryPath) == 0 {
+		return FetchResult{}, ErrRepositoryPathRequired
+	}
+
+	details := execshell.CommandDetails{
+		Arguments:            []string{gitFetchSubcommandConstant, gitFetchAllFlagConstant, gitFetchPruneFlagConstant, gitFetchTagsFlagConstant},
+		WorkingDirectory:     trimmedRepositoryPath,
+		EnvironmentVariables: map[string]string{gitTerminalPromptEnvironmentNameConstant: gitTerminalPromptEnvironmentDisableConstant},
+		Idempotent:           true,
+	}
+
+	startedAt := service.clock.Now()
+	executionResult, executionError := service.executor.ExecuteGit(executionContext, details)
+	duration := service.clock.Now().Sub(startedAt)
+	if executionError != nil {
+		if execshell.IsExecutableNotFound(executionError) {
+			return FetchResult{}, executionError
+		}
+		return FetchResult{}, newFetchFailure(trimmedRepositoryPath, executionError)
+	}
+
+	return FetchResult{
+		RepositoryPath: trimmedRepositoryPath,
+		Duration:       duration,
+		NewReferences:  countNewReferences(executionResult.StandardError) + countNewReferences(executionResult.StandardOutput),
+	}, nil
+}
+
+func countNewReferences(fetchOutput string) int {
+	newReferences := 0
+	for _, line := range strings.Split(fetchOutput, "\n") {
+		if strings.Contains(line, fetchNewBranchMarkerConstant) || strings.Contains(line, fetchNewTagMarkerConstant) || strings.Contains(line, fetchNewReferenceMarkerConstant) {
+			newReferences++
+		}
+	}
+	return newReferences
+}
+
+func newFetchFailure(repositoryPath string, executionError error) FetchFailure {
+	message := strings.TrimSpace(executionError.Error())
+	var commandFailure execshell.CommandFailedError
+	if errors.As(executionError, &commandFailure) {
+		if standardError := strings.TrimSpace(commandFailure.Result.StandardError); len(standardError) > 0 {
+			message = message + fetchFailureMessageSeparatorConstant + strings.Join(strings.Fields(standardError), " ")
+		}
+	}
+	return FetchFailure{
+		RepositoryPath: repositoryPath,
+		Reason:         classifyFetchFailure(message),
+		Message:        message,
+	}
+}
+
+func classifyFetchFailure(message string) FetchFailureReason {
+	loweredMessage := strings.ToLower(message)
+	for _, marker := range []string{fetchAuthenticationFailedMarker, fetchPermissionDeniedMarker, fetchUsernamePromptMarker, fetchRepositoryNotFoundMarker} {
+		if strings.Contains(loweredMessage, marker) {
+			return FetchFailureReasonAuthentication
+		}
+	}
+	for _, marker := range []string{fetchResolveHostMarker, fetchConnectionTimedOutMarker, fetchConnectionRefusedMarker, fetchUnableToAccessMarker, fetchCouldNotReadFromRemoteMarker} {
+		if strings.Contains(loweredMessage, marker) {
+			return FetchFailureReasonUnreachable
+		}
+	}
+	return FetchFailureReasonOther
+}
@@ -0,0 +1,183 @@
+package refresh
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+)
+
+const testFetchOutputConstant = `Fetching origin
+From github.com:owner/example
+ * [new branch]      feature    -> origin/feature
+ * [new tag]         v1.2.0     -> v1.2.0
+   1a2b3c4..5d6e7f8  main       -> origin/main
+ - [deleted]         (none)     -> origin/stale
+`
+
+// newScriptedFetchExecutor answers every git command with the result and error.
+func newScriptedFetchExecutor(result execshell.ExecutionResult, executionError error) *execshelltest.Executor {
+	executor := execshelltest.NewExecutor()
+	executor.On(execshell.CommandGit, execshelltest.AnyArguments()).Respond(func(execshell.CommandDetails) (execshell.ExecutionResult, error) {
+		return result, executionError
+	})
+	return executor
+}
+
+type steppingClock struct {
+	current time.Time
+	step    time.Duration
+}
+
+func (clock *steppingClock) Now() time.Time {
+	now := clock.current
+	clock.current = clock.current.Add(clock.step)
+	return now
+}
+
+func TestServiceFetch(t *testing.T) {
+	testCases := []struct {
+		name            string
+		executor        *execshelltest.Executor
+		expectedResult  FetchResult
+		expectedReason  FetchFailureReason
+		expectedFailure bool
+	}{
+		{
+			name:           "counts_new_references",
+			executor:       newScriptedFetchExecutor(execshell.ExecutionResult{StandardError: testFetchOutputConstant}, nil),
+			expectedResult: FetchResult{RepositoryPath: "/tmp/repo", Duration: 1500 * time.Millisecond, NewReferences: 2},
+		},
+		{
+			name: "authentication_failure",
+			executor: newScriptedFetchExecutor(execshell.ExecutionResult{}, execshell.CommandFailedError{
+				Command: execshell.ShellCommand{Name: execshell.CommandGit},
+				Result:  execshell.ExecutionResult{ExitCode: 128, StandardError: "fatal: Authentication failed for 'https://github.com/owner/example.git/'"},
+			}),
+			expectedFailure: true,
+			expectedReason:  FetchFailureReasonAuthentication,
+		},
+		{
+			name: "unreachable_remote",
+			executor: newScriptedFetchExecutor(execshell.ExecutionResult{}, execshell.CommandFailedError{
+				Command: execshell.ShellCommand{Name: execshell.CommandGit},
+				Result:  execshell.ExecutionResult{ExitCode: 128, StandardError: "fatal: unable to access 'https://github.com/owner/example.git/': Could not resolve host: github.com"},
+			}),
+			expectedFailure: true,
+			expectedReason:  FetchFailureReasonUnreachable,
+		},
+		{
+			name:            "unclassified_failure",
+			executor:        newScriptedFetchExecutor(execshell.ExecutionResult{}, errors.New("fetch failed")),
+			expectedFailure: true,
+			expectedReason:  FetchFailureReasonOther,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			service, creationError := NewService(Dependencies{
+				GitExecutor:       testCase.executor,
+				RepositoryManager: &stubRepositoryManager{},
+				Clock:             &steppingClock{current: time.Unix(0, 0), step: 1500 * time.Millisecond},
+			})
+			require.NoError(t, creationError)
+
+			result, fetchError := service.Fetch(context.Background(), "/tmp/repo")
+			require.Len(t, testCase.executor.Executed(), 1)
+			require.Equal(t, []string{gitFetchSubcommandConstant, gitFetchAllFlagConstant, gitFetchPruneFlagConstant, gitFetchTagsFlagConstant}, testCase.executor.Executed()[0].Details.Arguments)
+			require.Equal(t, gitTerminalPromptEnvironmentDisableConstant, testCase.executor.Executed()[0].Details.EnvironmentVariables[gitTerminalPromptEnvironmentNameConstant])
+
+			if !testCase.expectedFailure {
+				require.NoError(t, fetchError)
+				require.Equal(t, testCase.expectedResult, result)
+				return
+			}
+			var fetchFailure FetchFailure
+			require.ErrorAs(t, fetchError, &fetchFailure)
+			require.Equal(t, "/tmp/repo", fetchFailure.RepositoryPath)
+			require.Equal(t, testCase.expectedReason, fetchFailure.Reason)
+		})
+	}
+}
+
+func TestServiceFetchAll(t *testing.T) {
+	repositoryPaths := []string{"/repositories/alpha", "/repositories/beta", "/repositories/gamma"}
+	testCases := []struct {
+		name             string
+		dryRun           bool
+		failingPaths     []string
+		withoutTally     bool
+		expectedOutput   string
+		expectedFailures []string
+		expectedError    bool
+	}{
+		{
+			name:           "dry_run_prints_plan",
+			dryRun:         true,
+			expectedOutput: "FETCH-PLAN: /repositories/alpha\nFETCH-PLAN: /repositories/beta\nFETCH-PLAN: /repositories/gamma\n",
+		},
+		{
+			name:             "failures_tallied_in_repository_order",
+			failingPaths:     []string{"/repositories/gamma", "/repositories/alpha"},
+			expectedFailures: []string{"/repositories/alpha", "/repositories/gamma"},
+		},
+		{
+			name:          "failure_returned_without_tally",
+			failingPaths:  []string{"/repositories/beta"},
+			withoutTally:  true,
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			executor := execshelltest.NewExecutor()
+			executor.On(execshell.CommandGit, execshelltest.AnyArguments())
+			for _, failingPath := range testCase.failingPaths {
+				executor.On(execshell.CommandGit, execshelltest.AnyArguments()).InDirectory(failingPath).FailWith(128, "fatal: Authentication failed")
+			}
+			service, creationError := NewService(Dependencies{GitExecutor: executor, RepositoryManager: &stubRepositoryManager{}})
+			require.NoError(t, creationError)
+
+			var failureTally *FetchFailureTally
+			if !testCase.withoutTally {
+				failureTally = &FetchFailureTally{}
+			}
+			var output strings.Builder
+			fetchError := service.FetchAll(context.Background(), FetchAllOptions{
+				RepositoryPaths: repositoryPaths,
+				Jobs:            2,
+				DryRun:          testCase.dryRun,
+				Output:          &output,
+				FailureTally:    failureTally,
+			})
+			if testCase.expectedError {
+				var fetchFailure FetchFailure
+				require.ErrorAs(t, fetchError, &fetchFailure)
+				require.Equal(t, "/repositories/beta", fetchFailure.RepositoryPath)
+				return
+			}
+			require.NoError(t, fetchError)
+			if testCase.dryRun {
+				require.Empty(t, executor.Executed())
+				require.Equal(t, testCase.expectedOutput, output.String())
+				return
+			}
+			require.Len(t, executor.Executed(), len(repositoryPaths))
+			failedPaths := []string{}
+			for _, failure := range failureTally.Records() {
+				failedPaths = append(failedPaths, failure.RepositoryPath)
+			}
+			require.Equal(t, testCase.expectedFailures, failedPaths)
+		})
+	}
+}
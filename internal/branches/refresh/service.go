@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/shared"
 )
 
@@ -27,13 +28,9 @@ const (
 	gitFetchSubcommandConstant                  = "fetch"
 	gitFetchPruneFlagConstant                   = "--prune"
 	gitCheckoutSubcommandConstant               = "checkout"
-	gitPullSubcommandConstant                   = "pull"
-	gitPullFastForwardFlagConstant              = "--ff-only"
-	gitPullRebaseFlagConstant                   = "--rebase"
 	gitAddSubcommandConstant                    = "add"
 	gitAddAllFlagConstant                       = "--all"
-	gitCommitSubcommandConstant                 = "commit"
-	gitCommitMessageFlagConstant                = "-m"
+	automatedCommitCommandNameConstant          = "branch refresh"
 	gitStashSubcommandConstant                  = "stash"
 	gitStashPushSubcommandConstant              = "push"
 	gitStashIncludeUntrackedFlagConstant        = "--include-untracked"
@@ -60,6 +57,7 @@ var ErrWorktreeNotClean = errors.New(worktreeNotCleanMessageConstant)
 type Dependencies struct {
 	GitExecutor       shared.GitExecutor
 	RepositoryManager shared.GitRepositoryManager
+	Clock             shared.Clock
 }
 
 // Options configures a branch refresh operation.
@@ -81,6 +79,7 @@ type Result struct {
 type Service struct {
 	executor          shared.GitExecutor
 	repositoryManager shared.GitRepositoryManager
+	clock             shared.Clock
 }
 
 // NewService constructs a Service from the provided dependencies.
@@ -91,7 +90,11 @@ func NewService(dependencies Dependencies) (*Service, error) {
 	if dependencies.RepositoryManager == nil {
 		return nil, ErrRepositoryManagerNotConfigured
 	}
-	return &Service{executor: dependencies.GitExecutor, repositoryManager: dependencies.RepositoryManager}, nil
+	clock := dependencies.Clock
+	if clock == nil {
+		clock = shared.SystemClock{}
+	}
+	return &Service{executor: dependencies.GitExecutor, repositoryManager: dependencies.RepositoryManager, clock: clock}, nil
 }
 
 // Refresh synchronizes the specified branch with its remote counterpart.
@@ -140,31 +143,76 @@ func (service *Service) Refresh(executionContext context.Context, options Option
 	if fetchError := service.executeGit(executionContext, execshell.CommandDetails{
 		Arguments:        []string{gitFetchSubcommandConstant, gitFetchPruneFlagConstant},
 		WorkingDirectory: trimmedRepositoryPath,
+		Idempotent:       true,
 	}); fetchError != nil {
 		return Result{}, fmt.Errorf(gitFetchFailureTemplateConstant, fetchError)
 	}
 
-	if checkoutError := service.executeGit(executionContext, execshell.CommandDetails{
-		Arguments:        []string{gitCheckoutSubcommandConstant, trimmedBranchName},
-		WorkingDirectory: trimmedRepositoryPath,
-	}); checkoutError != nil {
-		return Result{}, fmt.Errorf(gitCheckoutFailureTemplateConstant, trimmedBranchName, checkoutError)
+	branchRef, branchRefKnown := service.lookupBranchRef(executionContext, trimmedRepositoryPath, trimmedBranchName)
+
+	if !branchRefKnown || !branchRef.Head {
+		if checkoutError := service.executeGit(executionContext, execshell.CommandDetails{
+			Arguments:        []string{gitCheckoutSubcommandConstant, trimmedBranchName},
+			WorkingDirectory: trimmedRepositoryPath,
+			Idempotent:       false,
+		}); checkoutError != nil {
+			return Result{}, fmt.Errorf(gitCheckoutFailureTemplateConstant, trimmedBranchName, checkoutError)
+		}
+	}
+
+	if branchRefKnown && len(branchRef.Upstream) > 0 && !branchRef.UpstreamGone() && branchRef.Behind() == 0 {
+		return Result{RepositoryPath: trimmedRepositoryPath, BranchName: trimmedBranchName}, nil
 	}
 
-	pullArguments := []string{gitPullSubcommandConstant, gitPullFastForwardFlagConstant}
+	pullOptions := execshell.GitPullOptions{
+		WorkingDirectory:     trimmedRepositoryPath,
+		Strategy:             execshell.GitPullStrategyFastForwardOnly,
+		EnvironmentVariables: disabledTerminalPromptEnvironment(),
+	}
 	if checkpointCommitCreated {
-		pullArguments = []string{gitPullSubcommandConstant, gitPullRebaseFlagConstant}
+		pullOptions.Strategy = execshell.GitPullStrategyRebase
 	}
-	if pullError := service.executeGit(executionContext, execshell.CommandDetails{
-		Arguments:        pullArguments,
-		WorkingDirectory: trimmedRepositoryPath,
-	}); pullError != nil {
+	if branchRefKnown && len(branchRef.UpstreamRemote) > 0 && len(branchRef.UpstreamBranchName()) > 0 {
+		pullOptions.RemoteName = branchRef.UpstreamRemote
+		pullOptions.BranchName = branchRef.UpstreamBranchName()
+	}
+	pullResult, pullError := execshell.ExecutePull(executionContext, service.executor, pullOptions)
+	if pullError != nil {
+		if conflictingFiles, conflicted := detectPullConflict(pullResult, pullError); conflicted {
+			return Result{}, PullConflictError{
+				RepositoryPath:   trimmedRepositoryPath,
+				BranchName:       trimmedBranchName,
+				Strategy:         pullOptions.Strategy,
+				ConflictingFiles: conflictingFiles,
+				AbortError:       service.abortConflictedPull(executionContext, trimmedRepositoryPath, pullOptions.Strategy),
+			}
+		}
 		return Result{}, fmt.Errorf(gitPullFailureTemplateConstant, pullError)
 	}
 
 	return Result{RepositoryPath: trimmedRepositoryPath, BranchName: trimmedBranchName}, nil
 }
 
+// lookupBranchRef reads the branch's tip, upstream, and checkout state with one for-each-ref call after the fetch.
+// It reports false when the repository manager cannot list references or the branch does not exist locally,
+// in which case the refresh falls back to an unconditional checkout and pull.
+func (service *Service) lookupBranchRef(executionContext context.Context, repositoryPath string, branchName string) (gitrepo.RefEntry, bool) {
+	refLister, supportsRefs := service.repositoryManager.(shared.GitRepositoryRefLister)
+	if !supportsRefs {
+		return gitrepo.RefEntry{}, false
+	}
+	entries, listError := refLister.ListRefs(executionContext, repositoryPath, gitrepo.LocalBranchRefPattern+"/"+branchName)
+	if listError != nil {
+		return gitrepo.RefEntry{}, false
+	}
+	for _, entry := range entries {
+		if entry.BranchName() == branchName {
+			return entry, true
+		}
+	}
+	return gitrepo.RefEntry{}, false
+}
+
 func (service *Service) executeGit(executionContext context.Context, details execshell.CommandDetails) error {
 	if details.EnvironmentVariables == nil {
 		details.EnvironmentVariables = map[string]string{}
@@ -174,10 +222,15 @@ func (service *Service) executeGit(executionContext context.Context, details exe
 	return executionError
 }
 
+func disabledTerminalPromptEnvironment() map[string]string {
+	return map[string]string{gitTerminalPromptEnvironmentNameConstant: gitTerminalPromptEnvironmentDisableConstant}
+}
+
 func (service *Service) stashLocalChanges(executionContext context.Context, repositoryPath string) error {
 	if stashError := service.executeGit(executionContext, execshell.CommandDetails{
 		Arguments:        []string{gitStashSubcommandConstant, gitStashPushSubcommandConstant, gitStashIncludeUntrackedFlagConstant},
 		WorkingDirectory: repositoryPath,
+		Idempotent:       false,
 	}); stashError != nil {
 		return fmt.Errorf(stashFailureTemplateConstant, stashError)
 	}
@@ -188,14 +241,16 @@ func (service *Service) commitLocalChanges(executionContext context.Context, rep
 	if stageError := service.executeGit(executionContext, execshell.CommandDetails{
 		Arguments:        []string{gitAddSubcommandConstant, gitAddAllFlagConstant},
 		WorkingDirectory: repositoryPath,
+		Idempotent:       false,
 	}); stageError != nil {
 		return fmt.Errorf(stageFailureTemplateConstant, stageError)
 	}
 
 	commitMessage := fmt.Sprintf(commitMessageTemplateConstant, branchName)
 	if commitError := service.executeGit(executionContext, execshell.CommandDetails{
-		Arguments:        []string{gitCommitSubcommandConstant, gitCommitMessageFlagConstant, commitMessage},
+		Arguments:        gitrepo.CommitArguments(gitrepo.AutomatedCommitOptions(executionContext, automatedCommitCommandNameConstant, commitMessage)),
 		WorkingDirectory: repositoryPath,
+		Idempotent:       false,
 	}); commitError != nil {
 		return fmt.Errorf(commitFailureTemplateConstant, commitError)
 	}
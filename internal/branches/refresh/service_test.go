@@ -9,29 +9,15 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+	"github.com/temirov/gix/internal/gitrepo"
 )
 
-type stubGitExecutor struct {
-	invocationErrors []error
-	recordedCommands []execshell.CommandDetails
-}
-
-func (executor *stubGitExecutor) ExecuteGit(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	executor.recordedCommands = append(executor.recordedCommands, details)
-	if len(executor.invocationErrors) == 0 {
-		return execshell.ExecutionResult{}, nil
-	}
-	err := executor.invocationErrors[0]
-	executor.invocationErrors = executor.invocationErrors[1:]
-	if err != nil {
-		return execshell.ExecutionResult{}, err
-	}
-	return execshell.ExecutionResult{}, nil
-}
-
-func (executor *stubGitExecutor) ExecuteGitHubCLI(_ context.Context, _ execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return execshell.ExecutionResult{}, nil
-}
+const (
+	gitPullSubcommandConstant      = "pull"
+	gitPullFastForwardFlagConstant = "--ff-only"
+	gitPullRebaseFlagConstant      = "--rebase"
+)
 
 type stubRepositoryManager struct {
 	cleanStates    []bool
@@ -79,7 +65,7 @@ func TestNewServiceValidatesDependencies(t *testing.T) {
 		},
 		{
 			name:         "MissingRepositoryManager",
-			dependencies: Dependencies{GitExecutor: &stubGitExecutor{}},
+			dependencies: Dependencies{GitExecutor: execshelltest.NewPermissiveExecutor()},
 			expectedErr:  ErrRepositoryManagerNotConfigured,
 		},
 	}
@@ -93,13 +79,13 @@ func TestNewServiceValidatesDependencies(t *testing.T) {
 		})
 	}
 
-	service, creationError := NewService(Dependencies{GitExecutor: &stubGitExecutor{}, RepositoryManager: &stubRepositoryManager{cleanStates: []bool{true}}})
+	service, creationError := NewService(Dependencies{GitExecutor: execshelltest.NewPermissiveExecutor(), RepositoryManager: &stubRepositoryManager{cleanStates: []bool{true}}})
 	require.NoError(t, creationError)
 	require.NotNil(t, service)
 }
 
 func TestRefreshValidatesInputs(t *testing.T) {
-	executor := &stubGitExecutor{}
+	executor := execshelltest.NewPermissiveExecutor()
 	service, creationError := NewService(Dependencies{GitExecutor: executor, RepositoryManager: &stubRepositoryManager{cleanStates: []bool{true}}})
 	require.NoError(t, creationError)
 
@@ -111,7 +97,7 @@ func TestRefreshValidatesInputs(t *testing.T) {
 }
 
 func TestRefreshPropagatesCleanCheckError(t *testing.T) {
-	executor := &stubGitExecutor{}
+	executor := execshelltest.NewPermissiveExecutor()
 	repositoryManager := &stubRepositoryManager{cleanStates: []bool{false}, executionError: errors.New("status failed")}
 	service, creationError := NewService(Dependencies{GitExecutor: executor, RepositoryManager: repositoryManager})
 	require.NoError(t, creationError)
@@ -121,7 +107,7 @@ func TestRefreshPropagatesCleanCheckError(t *testing.T) {
 }
 
 func TestRefreshFailsWhenWorktreeDirty(t *testing.T) {
-	executor := &stubGitExecutor{}
+	executor := execshelltest.NewPermissiveExecutor()
 	repositoryManager := &stubRepositoryManager{cleanStates: []bool{false}}
 	service, creationError := NewService(Dependencies{GitExecutor: executor, RepositoryManager: repositoryManager})
 	require.NoError(t, creationError)
@@ -131,7 +117,7 @@ func TestRefreshFailsWhenWorktreeDirty(t *testing.T) {
 }
 
 func TestRefreshExecutesGitCommandsInOrder(t *testing.T) {
-	executor := &stubGitExecutor{}
+	executor := execshelltest.NewPermissiveExecutor()
 	repositoryManager := &stubRepositoryManager{cleanStates: []bool{true}}
 	service, creationError := NewService(Dependencies{GitExecutor: executor, RepositoryManager: repositoryManager})
 	require.NoError(t, creationError)
@@ -139,13 +125,99 @@ func TestRefreshExecutesGitCommandsInOrder(t *testing.T) {
 	result, err := service.Refresh(context.Background(), Options{RepositoryPath: "/tmp/repo", BranchName: "main", RequireClean: true})
 	require.NoError(t, err)
 	require.Equal(t, Result{RepositoryPath: "/tmp/repo", BranchName: "main"}, result)
-	require.Len(t, executor.recordedCommands, 3)
-	require.Equal(t, []string{gitFetchSubcommandConstant, gitFetchPruneFlagConstant}, executor.recordedCommands[0].Arguments)
-	require.Equal(t, []string{gitCheckoutSubcommandConstant, "main"}, executor.recordedCommands[1].Arguments)
-	require.Equal(t, []string{gitPullSubcommandConstant, gitPullFastForwardFlagConstant}, executor.recordedCommands[2].Arguments)
+	require.Len(t, executor.Executed(), 3)
+	require.Equal(t, []string{gitFetchSubcommandConstant, gitFetchPruneFlagConstant}, executor.Executed()[0].Details.Arguments)
+	require.Equal(t, []string{gitCheckoutSubcommandConstant, "main"}, executor.Executed()[1].Details.Arguments)
+	require.Equal(t, []string{gitPullSubcommandConstant, gitPullFastForwardFlagConstant}, executor.Executed()[2].Details.Arguments)
+
+	for _, command := range executor.Executed() {
+		require.Equal(t, gitTerminalPromptEnvironmentDisableConstant, command.Details.EnvironmentVariables[gitTerminalPromptEnvironmentNameConstant])
+	}
+}
+
+type refListingRepositoryManager struct {
+	stubRepositoryManager
+	entries         []gitrepo.RefEntry
+	listError       error
+	listedPatterns  [][]string
+	listInvocations int
+}
+
+func (manager *refListingRepositoryManager) ListRefs(_ context.Context, _ string, patterns ...string) ([]gitrepo.RefEntry, error) {
+	manager.listInvocations++
+	manager.listedPatterns = append(manager.listedPatterns, patterns)
+	return manager.entries, manager.listError
+}
 
-	for _, commandDetails := range executor.recordedCommands {
-		require.Equal(t, gitTerminalPromptEnvironmentDisableConstant, commandDetails.EnvironmentVariables[gitTerminalPromptEnvironmentNameConstant])
+func TestRefreshDerivesBranchFactsFromRefListing(t *testing.T) {
+	testCases := []struct {
+		name             string
+		entries          []gitrepo.RefEntry
+		listError        error
+		expectedCommands [][]string
+	}{
+		{
+			name:             "CheckedOutAndInSync",
+			entries:          []gitrepo.RefEntry{{Name: "refs/heads/main", Upstream: "refs/remotes/origin/main", Head: true}},
+			expectedCommands: [][]string{{gitFetchSubcommandConstant, gitFetchPruneFlagConstant}},
+		},
+		{
+			name:    "CheckedOutAndBehind",
+			entries: []gitrepo.RefEntry{{Name: "refs/heads/main", Upstream: "refs/remotes/origin/main", UpstreamTrack: "[behind 2]", Head: true}},
+			expectedCommands: [][]string{
+				{gitFetchSubcommandConstant, gitFetchPruneFlagConstant},
+				{gitPullSubcommandConstant, gitPullFastForwardFlagConstant},
+			},
+		},
+		{
+			name: "BehindNamedUpstream",
+			entries: []gitrepo.RefEntry{{
+				Name:             "refs/heads/main",
+				Upstream:         "refs/remotes/upstream/trunk",
+				UpstreamTrack:    "[behind 1]",
+				Head:             true,
+				UpstreamRemote:   "upstream",
+				UpstreamMergeRef: "refs/heads/trunk",
+			}},
+			expectedCommands: [][]string{
+				{gitFetchSubcommandConstant, gitFetchPruneFlagConstant},
+				{gitPullSubcommandConstant, gitPullFastForwardFlagConstant, "upstream", "trunk"},
+			},
+		},
+		{
+			name:    "OtherBranchInSync",
+			entries: []gitrepo.RefEntry{{Name: "refs/heads/main", Upstream: "refs/remotes/origin/main"}},
+			expectedCommands: [][]string{
+				{gitFetchSubcommandConstant, gitFetchPruneFlagConstant},
+				{gitCheckoutSubcommandConstant, "main"},
+			},
+		},
+		{
+			name:      "ListingFailureFallsBack",
+			listError: errors.New("for-each-ref failed"),
+			expectedCommands: [][]string{
+				{gitFetchSubcommandConstant, gitFetchPruneFlagConstant},
+				{gitCheckoutSubcommandConstant, "main"},
+				{gitPullSubcommandConstant, gitPullFastForwardFlagConstant},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			executor := execshelltest.NewPermissiveExecutor()
+			repositoryManager := &refListingRepositoryManager{stubRepositoryManager: stubRepositoryManager{cleanStates: []bool{true}}, entries: testCase.entries, listError: testCase.listError}
+			service, creationError := NewService(Dependencies{GitExecutor: executor, RepositoryManager: repositoryManager})
+			require.NoError(t, creationError)
+
+			_, err := service.Refresh(context.Background(), Options{RepositoryPath: "/tmp/repo", BranchName: "main", RequireClean: true})
+			require.NoError(t, err)
+			require.Equal(t, 1, repositoryManager.listInvocations)
+			require.Equal(t, [][]string{{"refs/heads/main"}}, repositoryManager.listedPatterns)
+
+			require.Equal(t, testCase.expectedCommands, executor.ExecutedArguments(execshell.CommandGit))
+		})
 	}
 }
 
@@ -153,22 +225,22 @@ func TestRefreshSurfacesGitFailures(t *testing.T) {
 	testError := errors.New("execution failed")
 	testCases := []struct {
 		name             string
-		errors           []error
+		failingArguments []string
 		expectedFragment string
 	}{
 		{
 			name:             "FetchFailure",
-			errors:           []error{testError},
+			failingArguments: []string{gitFetchSubcommandConstant, gitFetchPruneFlagConstant},
 			expectedFragment: "failed to fetch updates",
 		},
 		{
 			name:             "CheckoutFailure",
-			errors:           []error{nil, testError},
+			failingArguments: []string{gitCheckoutSubcommandConstant, "main"},
 			expectedFragment: "failed to checkout branch",
 		},
 		{
 			name:             "PullFailure",
-			errors:           []error{nil, nil, testError},
+			failingArguments: []string{gitPullSubcommandConstant, gitPullFastForwardFlagConstant},
 			expectedFragment: "failed to pull latest changes",
 		},
 	}
@@ -176,7 +248,8 @@ func TestRefreshSurfacesGitFailures(t *testing.T) {
 	for _, testCase := range testCases {
 		testCase := testCase
 		t.Run(testCase.name, func(t *testing.T) {
-			executor := &stubGitExecutor{invocationErrors: append([]error{}, testCase.errors...)}
+			executor := execshelltest.NewPermissiveExecutor()
+			executor.OnGit(testCase.failingArguments...).Fail(testError)
 			repositoryManager := &stubRepositoryManager{cleanStates: []bool{true}}
 			service, creationError := NewService(Dependencies{GitExecutor: executor, RepositoryManager: repositoryManager})
 			require.NoError(t, creationError)
@@ -189,7 +262,7 @@ func TestRefreshSurfacesGitFailures(t *testing.T) {
 }
 
 func TestRefreshStashesDirtyWorktreeWhenRequested(t *testing.T) {
-	executor := &stubGitExecutor{}
+	executor := execshelltest.NewPermissiveExecutor()
 	repositoryManager := &stubRepositoryManager{cleanStates: []bool{false, true}}
 	service, creationError := NewService(Dependencies{GitExecutor: executor, RepositoryManager: repositoryManager})
 	require.NoError(t, creationError)
@@ -197,12 +270,12 @@ func TestRefreshStashesDirtyWorktreeWhenRequested(t *testing.T) {
 	result, err := service.Refresh(context.Background(), Options{RepositoryPath: "/tmp/repo", BranchName: "feature", RequireClean: true, StashChanges: true})
 	require.NoError(t, err)
 	require.Equal(t, Result{RepositoryPath: "/tmp/repo", BranchName: "feature"}, result)
-	require.Len(t, executor.recordedCommands, 4)
-	require.Equal(t, []string{gitStashSubcommandConstant, gitStashPushSubcommandConstant, gitStashIncludeUntrackedFlagConstant}, executor.recordedCommands[0].Arguments)
+	require.Len(t, executor.Executed(), 4)
+	require.Equal(t, []string{gitStashSubcommandConstant, gitStashPushSubcommandConstant, gitStashIncludeUntrackedFlagConstant}, executor.Executed()[0].Details.Arguments)
 }
 
 func TestRefreshCommitsDirtyWorktreeWhenRequested(t *testing.T) {
-	executor := &stubGitExecutor{}
+	executor := execshelltest.NewPermissiveExecutor()
 	repositoryManager := &stubRepositoryManager{cleanStates: []bool{false, true}}
 	service, creationError := NewService(Dependencies{GitExecutor: executor, RepositoryManager: repositoryManager})
 	require.NoError(t, creationError)
@@ -211,8 +284,8 @@ func TestRefreshCommitsDirtyWorktreeWhenRequested(t *testing.T) {
 	result, err := service.Refresh(context.Background(), Options{RepositoryPath: "/tmp/repo", BranchName: branchName, RequireClean: true, CommitChanges: true})
 	require.NoError(t, err)
 	require.Equal(t, Result{RepositoryPath: "/tmp/repo", BranchName: branchName}, result)
-	require.Len(t, executor.recordedCommands, 5)
-	require.Equal(t, []string{gitAddSubcommandConstant, gitAddAllFlagConstant}, executor.recordedCommands[0].Arguments)
-	require.Equal(t, []string{gitCommitSubcommandConstant, gitCommitMessageFlagConstant, fmt.Sprintf(commitMessageTemplateConstant, branchName)}, executor.recordedCommands[1].Arguments)
-	require.Equal(t, []string{gitPullSubcommandConstant, gitPullRebaseFlagConstant}, executor.recordedCommands[4].Arguments)
+	require.Len(t, executor.Executed(), 5)
+	require.Equal(t, []string{gitAddSubcommandConstant, gitAddAllFlagConstant}, executor.Executed()[0].Details.Arguments)
+	require.Equal(t, []string{"commit", "--no-verify", "-m", fmt.Sprintf(commitMessageTemplateConstant, branchName) + "\n\ncreated by gix branch refresh"}, executor.Executed()[1].Details.Arguments)
+	require.Equal(t, []string{gitPullSubcommandConstant, gitPullRebaseFlagConstant}, executor.Executed()[4].Details.Arguments)
 }
@@ -0,0 +1,93 @@
+package refresh
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	refreshFailureErrorTemplateConstant = "refresh failed for %s (%s): %s"
+	partialRefreshErrorTemplateConstant = "%d repository refresh(es) conflicted with upstream, %d failed"
+)
+
+// RefreshFailure records a repository whose refresh failed for a reason other than a pull conflict.
+type RefreshFailure struct {
+	RepositoryPath string
+	BranchName     string
+	Message        string
+}
+
+// Error describes the failed refresh.
+func (failure RefreshFailure) Error() string {
+	return fmt.Sprintf(refreshFailureErrorTemplateConstant, failure.RepositoryPath, failure.BranchName, failure.Message)
+}
+
+// PartialRefreshError reports that a refresh run completed but one or more repositories conflicted or failed.
+type PartialRefreshError struct {
+	utils.PartialFailure
+	Conflicts []PullConflictError
+	Failures  []RefreshFailure
+}
+
+// Error summarizes how many repositories conflicted and how many failed.
+func (partialRefreshError PartialRefreshError) Error() string {
+	return fmt.Sprintf(partialRefreshErrorTemplateConstant, len(partialRefreshError.Conflicts), len(partialRefreshError.Failures))
+}
+
+// RefreshTally accumulates refresh outcomes across every repository processed in a run. Conflicts are kept apart from
+// other failures because they need a manual merge rather than a retry.
+type RefreshTally struct {
+	refreshed atomic.Int64
+	conflicts utils.Tally[PullConflictError]
+	failures  utils.Tally[RefreshFailure]
+}
+
+// AddRefreshed counts a repository refreshed successfully.
+func (tally *RefreshTally) AddRefreshed() {
+	if tally == nil {
+		return
+	}
+	tally.refreshed.Add(1)
+}
+
+// AddConflict records a repository whose pull stopped on conflicts.
+func (tally *RefreshTally) AddConflict(conflict PullConflictError) {
+	if tally == nil {
+		return
+	}
+	tally.conflicts.Add(conflict)
+}
+
+// AddFailure records a repository whose refresh failed.
+func (tally *RefreshTally) AddFailure(failure RefreshFailure) {
+	if tally == nil {
+		return
+	}
+	tally.failures.Add(failure)
+}
+
+// Refreshed returns how many repositories refreshed successfully.
+func (tally *RefreshTally) Refreshed() int {
+	if tally == nil {
+		return 0
+	}
+	return int(tally.refreshed.Load())
+}
+
+// Conflicts returns every recorded conflict in the order it was added.
+func (tally *RefreshTally) Conflicts() []PullConflictError {
+	if tally == nil {
+		return nil
+	}
+	return tally.conflicts.Records()
+}
+
+// Failures returns every recorded failure in the order it was added.
+func (tally *RefreshTally) Failures() []RefreshFailure {
+	if tally == nil {
+		return nil
+	}
+	return tally.failures.Records()
+}
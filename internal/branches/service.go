@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -20,6 +21,9 @@ const (
 	defaultPullRequestLimitConstant              = 100
 	lsRemoteSubcommandConstant                   = "ls-remote"
 	headsFlagConstant                            = "--heads"
+	tagsFlagConstant                             = "--tags"
+	tagSubcommandConstant                        = "tag"
+	tagDeleteFlagConstant                        = "-d"
 	pushSubcommandConstant                       = "push"
 	deleteFlagConstant                           = "--delete"
 	branchSubcommandConstant                     = "branch"
@@ -30,6 +34,11 @@ const (
 	closedStateConstant                          = "closed"
 	jsonFlagConstant                             = "--json"
 	headRefFieldConstant                         = "headRefName"
+	pullRequestTagFieldsConstant                 = "headRefName,number,state"
+	openPullRequestStateConstant                 = "OPEN"
+	pullRequestNumberPlaceholderConstant         = "{number}"
+	tagReferencePrefixConstant                   = "refs/tags/"
+	peeledTagSuffixConstant                      = "^{}"
 	limitFlagConstant                            = "--limit"
 	branchReferencePrefixConstant                = "refs/heads/"
 	logMessageListingRemoteBranchesConstant      = "Listing remote branches"
@@ -37,14 +46,33 @@ const (
 	logMessageDeletingRemoteBranchConstant       = "Deleting remote branch"
 	logMessageSkippingRemoteBranchDryRunConstant = "Skipping remote branch deletion (dry run)"
 	logMessageSkippingMissingBranchConstant      = "Skipping branch (already gone)"
+	logMessageSkippingProtectedBranchConstant    = "Skipping branch (protected on GitHub)"
+	logMessageSkippingBranchDeletionCapConstant  = "Skipping branch (deletion cap reached)"
+	logMessageSkippingTagDeletionCapConstant     = "Skipping pull request tag (deletion cap reached)"
+	logMessageProtectionUnavailableConstant      = "Branch protection rules unavailable; attempting deletion"
 	logMessageDeletingLocalBranchConstant        = "Deleting local branch"
 	logMessageSkippingLocalBranchDryRunConstant  = "Skipping local branch deletion (dry run)"
 	logMessageRemoteDeletionFailedConstant       = "Remote branch deletion failed"
 	logMessageLocalDeletionFailedConstant        = "Local branch deletion failed"
 	logMessageDeletionSkippedByUserConstant      = "Skipping branch deletion (user declined)"
 	logMessageDeletionPromptFailedConstant       = "Branch deletion confirmation failed"
+	logMessageListingRemoteTagsConstant          = "Listing remote tags"
+	logMessageDeletingRemoteTagConstant          = "Deleting remote pull request tag"
+	logMessageSkippingRemoteTagDryRunConstant    = "Skipping remote pull request tag deletion (dry run)"
+	logMessageDeletingLocalTagConstant           = "Deleting local pull request tag"
+	logMessageSkippingLocalTagDryRunConstant     = "Skipping local pull request tag deletion (dry run)"
+	logMessageSkippingMissingTagConstant         = "Skipping pull request tag (not on remote)"
+	logMessageSkippingOpenPullRequestTagConstant = "Skipping pull request tag (pull request still open)"
+	logMessageSkippingUncleanedBranchTagConstant = "Skipping pull request tag (branch not deleted by this run)"
+	logMessageRemoteTagDeletionFailedConstant    = "Remote pull request tag deletion failed"
+	logMessageLocalTagDeletionFailedConstant     = "Local pull request tag deletion failed"
+	logMessageTagDeletionSkippedByUserConstant   = "Skipping pull request tag deletion (user declined)"
+	logMessageTagDeletionPromptFailedConstant    = "Pull request tag deletion confirmation failed"
 	logFieldBranchNameConstant                   = "branch"
+	logFieldTagNameConstant                      = "tag"
+	logFieldPullRequestNumberConstant            = "pull_request"
 	logFieldRemoteNameConstant                   = "remote"
+	logFieldRepositoryConstant                   = "repository"
 	logFieldDryRunConstant                       = "dry_run"
 	logFieldWorkingDirectoryConstant             = "working_directory"
 	logFieldErrorConstant                        = "error"
@@ -52,11 +80,15 @@ const (
 	remoteBranchesListErrorTemplateConstant      = "unable to list remote branches: %w"
 	pullRequestListErrorTemplateConstant         = "unable to list closed pull requests: %w"
 	remoteBranchParsingErrorTemplateConstant     = "unable to parse remote branch list: %w"
+	remoteTagsListErrorTemplateConstant          = "unable to list remote tags: %w"
+	remoteTagParsingErrorTemplateConstant        = "unable to parse remote tag list: %w"
 	pullRequestDecodingErrorTemplateConstant     = "unable to decode pull request response: %w"
 	remoteNameRequiredMessageConstant            = "remote name must be provided"
 	limitPositiveRequirementMessageConstant      = "pull request limit must be greater than zero"
 	executorNotConfiguredMessageConstant         = "command executor not configured"
+	tagPatternPlaceholderMessageConstant         = "pull request tag pattern must contain the {number} placeholder"
 	branchDeletionPromptTemplateConstant         = "Delete pull request branch '%s' from remote '%s' and the local repository? [y/N] "
+	tagDeletionPromptTemplateConstant            = "Delete pull request tag '%s' from remote '%s' and the local repository? [y/N] "
 )
 
 // CommandExecutor coordinates git and GitHub CLI invocations required for cleanup.
@@ -66,27 +98,71 @@ type CommandExecutor interface {
 }
 
 // CleanupOptions describe the behavior of the branch cleanup routine.
+// PullRequestTagPattern enables removal of tags such as "pr-{number}" created alongside pull request branches.
+// Repository names the owner/name GitHub repository whose branch protection rules are consulted before deletions.
+// DeletionBudget, when set, caps remote deletions across every repository sharing it; dry runs count planned deletions against it.
+// KeepMarker is the token that keeps a branch whose git branch description contains it; DefaultKeepMarker applies when empty.
+// SpaceReclaim, when set, receives an estimate of the storage left unreachable by local branch deletions.
+// GarbageCollect runs git gc --prune=now after local branch deletions; it never runs during dry runs.
+// DeleteBranchOnMerge and MergeQueueEnabled mirror the GitHub repository settings and are logged as an informational note.
+// RespectAutoDelete leaves remote deletions to GitHub in repositories with DeleteBranchOnMerge and removes only local branches.
+// BaseBranches, when set, restricts the cleanup to closed pull requests targeting one of these base branches.
+// ArchiveRefs pushes each remote branch tip to refs/archive/<year>/<branch> before deleting it and skips the deletion
+// when the archive push fails; ArchivedBranches, when set, receives the archived references.
+// ClosedAges, when set during a dry run, receives the pull request close age of every planned branch deletion.
+// MergedInto, when set, adds local and remote branches fully merged into that branch to the pull request candidates;
+// the remote's default branch is never added. BranchCandidates, when set, receives every branch offered for deletion
+// labeled with the sources that selected it.
 type CleanupOptions struct {
-	RemoteName       string
-	PullRequestLimit int
-	DryRun           bool
-	WorkingDirectory string
-	AssumeYes        bool
+	RemoteName            string
+	PullRequestLimit      int
+	DryRun                bool
+	WorkingDirectory      string
+	AssumeYes             bool
+	PullRequestTagPattern string
+	Repository            string
+	DeletionBudget        *DeletionBudget
+	KeepMarker            string
+	SpaceReclaim          *SpaceReclaimTally
+	GarbageCollect        bool
+	DeleteBranchOnMerge   bool
+	MergeQueueEnabled     bool
+	RespectAutoDelete     bool
+	ArchiveRefs           bool
+	ArchivedBranches      *ArchivedBranchTally
+	BaseBranches          []string
+	ClosedAges            *ClosedAgeHistogram
+	MergedInto            string
+	BranchCandidates      *BranchCandidateTally
+	// DeletedBranchCount, when set, receives the number of branches the cleanup deleted.
+	DeletedBranchCount *int
+
+	closedAgeRecorder *closedAgeRecorder
 }
 
 // Service orchestrates removal of remote and local branches tied to closed pull requests.
 type Service struct {
-	logger   *zap.Logger
-	executor CommandExecutor
-	prompter shared.ConfirmationPrompter
+	logger           *zap.Logger
+	executor         CommandExecutor
+	prompter         shared.ConfirmationPrompter
+	branchProtection shared.BranchProtectionResolver
+	clock            shared.Clock
 }
 
 var (
 	errRemoteNameRequired    = errors.New(remoteNameRequiredMessageConstant)
 	errLimitMustBePositive   = errors.New(limitPositiveRequirementMessageConstant)
 	errExecutorNotConfigured = errors.New(executorNotConfiguredMessageConstant)
+	errTagPatternPlaceholder = errors.New(tagPatternPlaceholderMessageConstant)
 )
 
+type closedPullRequest struct {
+	HeadRefName string    `json:"headRefName"`
+	Number      int       `json:"number"`
+	State       string    `json:"state"`
+	ClosedAt    time.Time `json:"closedAt"`
+}
+
 // NewService constructs a Service instance.
 func NewService(logger *zap.Logger, executor CommandExecutor, prompter shared.ConfirmationPrompter) (*Service, error) {
 	if executor == nil {
@@ -97,7 +173,19 @@ func NewService(logger *zap.Logger, executor CommandExecutor, prompter shared.Co
 		logger = zap.NewNop()
 	}
 
-	return &Service{logger: logger, executor: executor, prompter: prompter}, nil
+	return &Service{logger: logger, executor: executor, prompter: prompter, clock: shared.SystemClock{}}, nil
+}
+
+// WithBranchProtection configures the resolver used to skip branches protected on GitHub.
+func (service *Service) WithBranchProtection(resolver shared.BranchProtectionResolver) *Service {
+	service.branchProtection = resolver
+	return service
+}
+
+// WithClock configures the clock that dates archive references.
+func (service *Service) WithClock(clock shared.Clock) *Service {
+	service.clock = clock
+	return service
 }
 
 // Cleanup removes stale branches based on closed pull requests.
@@ -111,31 +199,77 @@ func (service *Service) Cleanup(executionContext context.Context, options Cleanu
 		return errLimitMustBePositive
 	}
 
+	tagPattern := strings.TrimSpace(options.PullRequestTagPattern)
+	if len(tagPattern) > 0 && !strings.Contains(tagPattern, pullRequestNumberPlaceholderConstant) {
+		return errTagPatternPlaceholder
+	}
+
 	remoteBranches, remoteBranchesError := service.fetchRemoteBranches(executionContext, trimmedRemoteName, options.WorkingDirectory)
 	if remoteBranchesError != nil {
 		return fmt.Errorf(remoteBranchesListErrorTemplateConstant, remoteBranchesError)
 	}
 
-	closedBranches, pullRequestsError := service.fetchClosedPullRequestBranches(executionContext, options.PullRequestLimit, options.WorkingDirectory)
+	collectClosedAges := options.DryRun && options.ClosedAges != nil
+	jsonFields := pullRequestJSONFields(len(tagPattern) > 0, collectClosedAges)
+	closedPullRequests, pullRequestsError := service.fetchClosedPullRequests(executionContext, options.PullRequestLimit, options.WorkingDirectory, jsonFields, normalizeBaseBranches(options.BaseBranches))
 	if pullRequestsError != nil {
 		return fmt.Errorf(pullRequestListErrorTemplateConstant, pullRequestsError)
 	}
+	if collectClosedAges {
+		options.closedAgeRecorder = newClosedAgeRecorder(options.ClosedAges, options.WorkingDirectory, closedPullRequests, service.now())
+	}
+
+	service.noteRepositorySettings(options)
+
+	closedBranches := make([]string, 0, len(closedPullRequests))
+	for pullRequestIndex := range closedPullRequests {
+		closedBranches = append(closedBranches, closedPullRequests[pullRequestIndex].HeadRefName)
+	}
+
+	var mergedBranches []string
+	if mergedInto := strings.TrimSpace(options.MergedInto); len(mergedInto) > 0 {
+		fetchedBranches, mergedBranchesError := service.fetchMergedBranches(executionContext, trimmedRemoteName, mergedInto, options.WorkingDirectory)
+		if mergedBranchesError != nil {
+			return mergedBranchesError
+		}
+		mergedBranches = fetchedBranches
+	}
+	candidates := unionBranchCandidates(closedBranches, mergedBranches)
 
 	confirmation := newBranchDeletionConfirmation(service.prompter, options.AssumeYes)
-	service.processBranches(executionContext, trimmedRemoteName, remoteBranches, closedBranches, confirmation, options)
+	protection := newBranchProtectionCheck(service.branchProtection, options.Repository)
+	keepMarker := newBranchKeepMarkerCheck(service.executor, options.WorkingDirectory, options.KeepMarker)
+	localBranches := newLocalBranchInventory(service.executor, options.WorkingDirectory)
+	deletedTips, cleanedBranches := service.processBranches(executionContext, trimmedRemoteName, remoteBranches, candidates, confirmation, protection, keepMarker, localBranches, options)
+	if options.DeletedBranchCount != nil {
+		*options.DeletedBranchCount = len(deletedTips)
+	}
+
+	if len(tagPattern) > 0 {
+		remoteTags, remoteTagsError := service.fetchRemoteTags(executionContext, trimmedRemoteName, options.WorkingDirectory)
+		if remoteTagsError != nil {
+			return fmt.Errorf(remoteTagsListErrorTemplateConstant, remoteTagsError)
+		}
+
+		service.processPullRequestTags(executionContext, trimmedRemoteName, tagPattern, remoteTags, closedPullRequests, cleanedBranches, confirmation, options)
+	}
+
+	service.reclaimSpace(executionContext, deletedTips, options)
 
 	return nil
 }
 
-func (service *Service) fetchRemoteBranches(executionContext context.Context, remoteName string, workingDirectory string) (map[string]struct{}, error) {
+func (service *Service) fetchRemoteBranches(executionContext context.Context, remoteName string, workingDirectory string) (map[string]string, error) {
 	service.logger.Info(logMessageListingRemoteBranchesConstant,
 		zap.String(logFieldRemoteNameConstant, remoteName),
 		zap.String(logFieldWorkingDirectoryConstant, workingDirectory),
 	)
 
 	commandDetails := execshell.CommandDetails{
-		Arguments:        []string{lsRemoteSubcommandConstant, headsFlagConstant, remoteName},
-		WorkingDirectory: workingDirectory,
+		Arguments:          []string{lsRemoteSubcommandConstant, headsFlagConstant, remoteName},
+		WorkingDirectory:   workingDirectory,
+		OutputCaptureLimit: execshell.UnlimitedOutputCapture,
+		Idempotent:         true,
 	}
 
 	executionResult, executionError := service.executor.ExecuteGit(executionContext, commandDetails)
@@ -151,26 +285,69 @@ func (service *Service) fetchRemoteBranches(executionContext context.Context, re
 	return branchSet, nil
 }
 
-func (service *Service) fetchClosedPullRequestBranches(executionContext context.Context, limit int, workingDirectory string) ([]string, error) {
+func (service *Service) fetchRemoteTags(executionContext context.Context, remoteName string, workingDirectory string) (map[string]struct{}, error) {
+	service.logger.Info(logMessageListingRemoteTagsConstant,
+		zap.String(logFieldRemoteNameConstant, remoteName),
+		zap.String(logFieldWorkingDirectoryConstant, workingDirectory),
+	)
+
+	commandDetails := execshell.CommandDetails{
+		Arguments:          []string{lsRemoteSubcommandConstant, tagsFlagConstant, remoteName},
+		WorkingDirectory:   workingDirectory,
+		OutputCaptureLimit: execshell.UnlimitedOutputCapture,
+		Idempotent:         true,
+	}
+
+	executionResult, executionError := service.executor.ExecuteGit(executionContext, commandDetails)
+	if executionError != nil {
+		return nil, executionError
+	}
+
+	return parseRemoteTags(executionResult.StandardOutput)
+}
+
+// fetchClosedPullRequests lists closed pull requests. With base branches, gh pr list runs once per base because it
+// accepts a single --base, and the limit applies to each base.
+func (service *Service) fetchClosedPullRequests(executionContext context.Context, limit int, workingDirectory string, jsonFields string, baseBranches []string) ([]closedPullRequest, error) {
 	service.logger.Info(logMessageListingPullRequestsConstant,
 		zap.Int(logFieldPullRequestLimitConstant, limit),
 		zap.String(logFieldWorkingDirectoryConstant, workingDirectory),
+		zap.Strings(logFieldBaseBranchesConstant, baseBranches),
 	)
 
+	if len(baseBranches) == 0 {
+		return service.listClosedPullRequests(executionContext, limit, workingDirectory, jsonFields, "")
+	}
+
+	pullRequests := make([]closedPullRequest, 0)
+	for _, baseBranch := range baseBranches {
+		basePullRequests, listError := service.listClosedPullRequests(executionContext, limit, workingDirectory, jsonFields, baseBranch)
+		if listError != nil {
+			return nil, listError
+		}
+		pullRequests = append(pullRequests, basePullRequests...)
+	}
+	return pullRequests, nil
+}
+
+func (service *Service) listClosedPullRequests(executionContext context.Context, limit int, workingDirectory string, jsonFields string, baseBranch string) ([]closedPullRequest, error) {
 	limitArgument := strconv.Itoa(limit)
 
+	arguments := []string{
+		pullRequestSubcommandConstant,
+		listSubcommandConstant,
+		stateFlagConstant,
+		closedStateConstant,
+	}
+	if len(baseBranch) > 0 {
+		arguments = append(arguments, baseFlagConstant, baseBranch)
+	}
+	arguments = append(arguments, jsonFlagConstant, jsonFields, limitFlagConstant, limitArgument)
+
 	commandDetails := execshell.CommandDetails{
-		Arguments: []string{
-			pullRequestSubcommandConstant,
-			listSubcommandConstant,
-			stateFlagConstant,
-			closedStateConstant,
-			jsonFlagConstant,
-			headRefFieldConstant,
-			limitFlagConstant,
-			limitArgument,
-		},
+		Arguments:        arguments,
 		WorkingDirectory: workingDirectory,
+		Idempotent:       true,
 	}
 
 	executionResult, executionError := service.executor.ExecuteGitHubCLI(executionContext, commandDetails)
@@ -178,55 +355,156 @@ func (service *Service) fetchClosedPullRequestBranches(executionContext context.
 		return nil, executionError
 	}
 
-	pullRequestBranches, decodingError := decodePullRequestBranches(executionResult.StandardOutput)
-	if decodingError != nil {
-		return nil, decodingError
-	}
-
-	return pullRequestBranches, nil
+	return decodeClosedPullRequests(executionResult.StandardOutput)
 }
 
-func (service *Service) processBranches(executionContext context.Context, remoteName string, remoteBranches map[string]struct{}, pullRequestBranches []string, confirmation *branchDeletionConfirmation, options CleanupOptions) {
-	processedBranches := make(map[string]struct{})
-	for branchIndex := range pullRequestBranches {
-		branchName := strings.TrimSpace(pullRequestBranches[branchIndex])
-		if len(branchName) == 0 {
+// processBranches deletes the candidate branches. It returns the tips of the deleted local branches, whose objects may
+// be reclaimed, and the names of the branches the run deleted, or plans to delete during a dry run.
+func (service *Service) processBranches(executionContext context.Context, remoteName string, remoteBranches map[string]string, candidates []branchCandidate, confirmation *branchDeletionConfirmation, protection *branchProtectionCheck, keepMarker *branchKeepMarkerCheck, localBranches *localBranchInventory, options CleanupOptions) ([]string, map[string]struct{}) {
+	deletedTips := make([]string, 0)
+	cleanedBranches := make(map[string]struct{})
+	localOnly := options.leavesRemoteDeletionsToGitHub()
+	for _, candidate := range candidates {
+		branchName := candidate.name
+		remoteTip, existsInRemote := remoteBranches[branchName]
+		existsLocally := false
+		if existsInRemote || localOnly || candidate.merged {
+			existsLocally = service.branchExistsLocally(executionContext, localBranches, branchName, remoteName, options)
+		}
+		if !existsInRemote && !existsLocally {
+			service.logger.Info(logMessageSkippingMissingBranchConstant,
+				zap.String(logFieldBranchNameConstant, branchName),
+				zap.String(logFieldRemoteNameConstant, remoteName),
+				zap.String(logFieldWorkingDirectoryConstant, options.WorkingDirectory),
+			)
 			continue
 		}
 
-		if _, alreadyProcessed := processedBranches[branchName]; alreadyProcessed {
+		if existsLocally && service.branchMarkedKeep(executionContext, keepMarker, branchName, remoteName, options) {
 			continue
 		}
-		processedBranches[branchName] = struct{}{}
-
-		if _, existsInRemote := remoteBranches[branchName]; existsInRemote {
-			service.deleteRemoteAndLocalBranch(executionContext, remoteName, branchName, confirmation, options)
+		if service.branchProtected(executionContext, protection, branchName, remoteName, options) {
 			continue
 		}
+		options.BranchCandidates.Add(BranchCandidate{RepositoryPath: options.WorkingDirectory, Branch: branchName, Sources: candidate.sources(), MergedInto: strings.TrimSpace(options.MergedInto)})
+		tip := localBranches.ObjectName(branchName)
+		var outcome branchDeletionOutcome
+		if localOnly || !existsInRemote {
+			outcome = service.deleteLocalBranchOnly(executionContext, remoteName, branchName, existsInRemote, existsLocally, confirmation, options)
+		} else {
+			outcome = service.deleteRemoteAndLocalBranch(executionContext, remoteName, branchName, remoteTip, existsLocally, confirmation, options)
+		}
+		if outcome.localDeleted {
+			deletedTips = append(deletedTips, tip)
+		}
+		if outcome.cleaned() {
+			cleanedBranches[branchName] = struct{}{}
+		}
+	}
+	return deletedTips, cleanedBranches
+}
 
-		service.logger.Info(logMessageSkippingMissingBranchConstant,
-			zap.String(logFieldBranchNameConstant, branchName),
+// branchDeletionOutcome records which copies of a candidate branch the run deleted, or plans to delete during a dry run.
+type branchDeletionOutcome struct {
+	planned       bool
+	remoteDeleted bool
+	localDeleted  bool
+}
+
+func (outcome branchDeletionOutcome) cleaned() bool {
+	return outcome.planned || outcome.remoteDeleted || outcome.localDeleted
+}
+
+// branchExistsLocally reports whether the branch exists locally, assuming it does when local branches cannot be listed.
+func (service *Service) branchExistsLocally(executionContext context.Context, localBranches *localBranchInventory, branchName string, remoteName string, options CleanupOptions) bool {
+	exists, known := localBranches.Exists(executionContext, branchName)
+	if known {
+		return exists
+	}
+	if localBranches != nil && localBranches.loadError != nil && !localBranches.errorReported {
+		localBranches.errorReported = true
+		service.logger.Warn(logMessageLocalBranchesUnavailableConstant,
 			zap.String(logFieldRemoteNameConstant, remoteName),
 			zap.String(logFieldWorkingDirectoryConstant, options.WorkingDirectory),
+			zap.Error(localBranches.loadError),
 		)
 	}
+	return true
+}
+
+func (service *Service) branchMarkedKeep(executionContext context.Context, keepMarker *branchKeepMarkerCheck, branchName string, remoteName string, options CleanupOptions) bool {
+	baseFields := []zap.Field{
+		zap.String(logFieldBranchNameConstant, branchName),
+		zap.String(logFieldRemoteNameConstant, remoteName),
+		zap.String(logFieldKeepMarkerConstant, keepMarker.marker),
+		zap.String(logFieldWorkingDirectoryConstant, options.WorkingDirectory),
+	}
+
+	kept, lookupError := keepMarker.Keeps(executionContext, branchName)
+	if lookupError != nil {
+		service.logger.Warn(logMessageKeepMarkerUnavailableMessage, append(baseFields, zap.Error(lookupError))...)
+		return false
+	}
+	if kept {
+		service.logger.Info(logMessageSkippingKeptBranchConstant, baseFields...)
+	}
+	return kept
+}
+
+func (service *Service) branchProtected(executionContext context.Context, protection *branchProtectionCheck, branchName string, remoteName string, options CleanupOptions) bool {
+	baseFields := []zap.Field{
+		zap.String(logFieldBranchNameConstant, branchName),
+		zap.String(logFieldRemoteNameConstant, remoteName),
+		zap.String(logFieldRepositoryConstant, options.Repository),
+		zap.String(logFieldWorkingDirectoryConstant, options.WorkingDirectory),
+	}
+
+	protected, lookupError := protection.Protects(executionContext, branchName)
+	if lookupError != nil {
+		service.logger.Warn(logMessageProtectionUnavailableConstant, append(baseFields, zap.Error(lookupError))...)
+		return false
+	}
+	if protected {
+		service.logger.Info(logMessageSkippingProtectedBranchConstant, baseFields...)
+	}
+	return protected
 }
 
-func (service *Service) deleteRemoteAndLocalBranch(executionContext context.Context, remoteName string, branchName string, confirmation *branchDeletionConfirmation, options CleanupOptions) {
+func (service *Service) deleteRemoteAndLocalBranch(executionContext context.Context, remoteName string, branchName string, remoteTip string, existsLocally bool, confirmation *branchDeletionConfirmation, options CleanupOptions) branchDeletionOutcome {
 	baseFields := []zap.Field{
 		zap.String(logFieldBranchNameConstant, branchName),
 		zap.String(logFieldRemoteNameConstant, remoteName),
 		zap.String(logFieldWorkingDirectoryConstant, options.WorkingDirectory),
 	}
 
+	archiveReference := ""
+	if options.ArchiveRefs {
+		archiveReference = service.archiveReference(branchName)
+	}
+
 	if options.DryRun {
+		if !options.DeletionBudget.Reserve(options.WorkingDirectory, branchName) {
+			service.logger.Info(logMessageSkippingBranchDeletionCapConstant, baseFields...)
+			return branchDeletionOutcome{}
+		}
+		if len(archiveReference) > 0 {
+			service.logger.Info(logMessageSkippingArchiveDryRunConstant,
+				append(baseFields, zap.String(logFieldArchiveReferenceConstant, archiveReference), zap.Bool(logFieldDryRunConstant, true))...,
+			)
+			options.ArchivedBranches.Add(ArchivedBranch{RepositoryPath: options.WorkingDirectory, Branch: branchName, Reference: archiveReference, Planned: true})
+		}
 		service.logger.Info(logMessageSkippingRemoteBranchDryRunConstant,
 			append(baseFields, zap.Bool(logFieldDryRunConstant, true))...,
 		)
-		service.logger.Info(logMessageSkippingLocalBranchDryRunConstant,
-			append(baseFields, zap.Bool(logFieldDryRunConstant, true))...,
-		)
-		return
+		if existsLocally {
+			service.logger.Info(logMessageSkippingLocalBranchDryRunConstant,
+				append(baseFields, zap.Bool(logFieldDryRunConstant, true))...,
+			)
+		} else {
+			service.logger.Info(logMessageSkippingMissingLocalBranch, baseFields...)
+		}
+		options.closedAgeRecorder.recordPlannedDeletion(branchName)
+		return branchDeletionOutcome{planned: true}
 	}
 
 	if confirmation != nil {
@@ -235,14 +513,24 @@ func (service *Service) deleteRemoteAndLocalBranch(executionContext context.Cont
 			service.logger.Warn(logMessageDeletionPromptFailedConstant,
 				append(baseFields, zap.Error(confirmationError))...,
 			)
-			return
+			return branchDeletionOutcome{}
 		}
 		if !allowed {
 			service.logger.Info(logMessageDeletionSkippedByUserConstant, baseFields...)
-			return
+			return branchDeletionOutcome{}
 		}
 	}
 
+	if !options.DeletionBudget.Reserve(options.WorkingDirectory, branchName) {
+		service.logger.Info(logMessageSkippingBranchDeletionCapConstant, baseFields...)
+		return branchDeletionOutcome{}
+	}
+
+	if len(archiveReference) > 0 && !service.archiveRemoteBranch(executionContext, remoteName, branchName, remoteTip, archiveReference, baseFields, options) {
+		options.DeletionBudget.Release()
+		return branchDeletionOutcome{}
+	}
+
 	service.logger.Info(logMessageDeletingRemoteBranchConstant, baseFields...)
 	pushCommandDetails := execshell.CommandDetails{
 		Arguments: []string{
@@ -252,14 +540,29 @@ func (service *Service) deleteRemoteAndLocalBranch(executionContext context.Cont
 			branchName,
 		},
 		WorkingDirectory: options.WorkingDirectory,
+		Idempotent:       false,
 	}
 
+	var outcome branchDeletionOutcome
 	if _, pushError := service.executor.ExecuteGit(executionContext, pushCommandDetails); pushError != nil {
 		service.logger.Warn(logMessageRemoteDeletionFailedConstant,
 			append(baseFields, zap.Error(pushError))...,
 		)
+		options.DeletionBudget.Release()
+	} else {
+		outcome.remoteDeleted = true
+	}
+
+	if !existsLocally {
+		service.logger.Info(logMessageSkippingMissingLocalBranch, baseFields...)
+		return outcome
 	}
 
+	outcome.localDeleted = service.deleteLocalBranch(executionContext, branchName, baseFields, options)
+	return outcome
+}
+
+func (service *Service) deleteLocalBranch(executionContext context.Context, branchName string, baseFields []zap.Field, options CleanupOptions) bool {
 	service.logger.Info(logMessageDeletingLocalBranchConstant, baseFields...)
 	deleteLocalCommand := execshell.CommandDetails{
 		Arguments: []string{
@@ -268,17 +571,133 @@ func (service *Service) deleteRemoteAndLocalBranch(executionContext context.Cont
 			branchName,
 		},
 		WorkingDirectory: options.WorkingDirectory,
+		Idempotent:       false,
 	}
 
 	if _, deleteError := service.executor.ExecuteGit(executionContext, deleteLocalCommand); deleteError != nil {
 		service.logger.Warn(logMessageLocalDeletionFailedConstant,
 			append(baseFields, zap.Error(deleteError))...,
 		)
+		return false
+	}
+	return true
+}
+
+// processPullRequestTags deletes the tags of the closed pull requests whose branches this run deleted, so tags of
+// branches kept by protection, keep markers, prompts, or failed deletions stay with their branches.
+func (service *Service) processPullRequestTags(executionContext context.Context, remoteName string, tagPattern string, remoteTags map[string]struct{}, pullRequests []closedPullRequest, cleanedBranches map[string]struct{}, confirmation *branchDeletionConfirmation, options CleanupOptions) {
+	processedNumbers := make(map[int]struct{})
+	for pullRequestIndex := range pullRequests {
+		pullRequest := pullRequests[pullRequestIndex]
+		if pullRequest.Number <= 0 {
+			continue
+		}
+
+		if _, alreadyProcessed := processedNumbers[pullRequest.Number]; alreadyProcessed {
+			continue
+		}
+		processedNumbers[pullRequest.Number] = struct{}{}
+
+		tagName := strings.ReplaceAll(tagPattern, pullRequestNumberPlaceholderConstant, strconv.Itoa(pullRequest.Number))
+		baseFields := []zap.Field{
+			zap.String(logFieldTagNameConstant, tagName),
+			zap.Int(logFieldPullRequestNumberConstant, pullRequest.Number),
+			zap.String(logFieldRemoteNameConstant, remoteName),
+			zap.String(logFieldWorkingDirectoryConstant, options.WorkingDirectory),
+		}
+
+		if strings.EqualFold(strings.TrimSpace(pullRequest.State), openPullRequestStateConstant) {
+			service.logger.Info(logMessageSkippingOpenPullRequestTagConstant, baseFields...)
+			continue
+		}
+
+		if _, branchCleaned := cleanedBranches[pullRequest.HeadRefName]; !branchCleaned {
+			service.logger.Info(logMessageSkippingUncleanedBranchTagConstant, append(baseFields, zap.String(logFieldBranchNameConstant, pullRequest.HeadRefName))...)
+			continue
+		}
+
+		if _, existsInRemote := remoteTags[tagName]; !existsInRemote {
+			service.logger.Info(logMessageSkippingMissingTagConstant, baseFields...)
+			continue
+		}
+
+		service.deleteRemoteAndLocalTag(executionContext, remoteName, tagName, baseFields, confirmation, options)
 	}
 }
 
-func parseRemoteBranches(commandOutput string) (map[string]struct{}, error) {
-	branchSet := make(map[string]struct{})
+func (service *Service) deleteRemoteAndLocalTag(executionContext context.Context, remoteName string, tagName string, baseFields []zap.Field, confirmation *branchDeletionConfirmation, options CleanupOptions) {
+	if options.DryRun {
+		if !options.DeletionBudget.Reserve(options.WorkingDirectory, tagReferencePrefixConstant+tagName) {
+			service.logger.Info(logMessageSkippingTagDeletionCapConstant, baseFields...)
+			return
+		}
+		service.logger.Info(logMessageSkippingRemoteTagDryRunConstant,
+			append(baseFields, zap.Bool(logFieldDryRunConstant, true))...,
+		)
+		service.logger.Info(logMessageSkippingLocalTagDryRunConstant,
+			append(baseFields, zap.Bool(logFieldDryRunConstant, true))...,
+		)
+		return
+	}
+
+	if confirmation != nil {
+		allowed, confirmationError := confirmation.ConfirmTag(tagName, remoteName)
+		if confirmationError != nil {
+			service.logger.Warn(logMessageTagDeletionPromptFailedConstant,
+				append(baseFields, zap.Error(confirmationError))...,
+			)
+			return
+		}
+		if !allowed {
+			service.logger.Info(logMessageTagDeletionSkippedByUserConstant, baseFields...)
+			return
+		}
+	}
+
+	if !options.DeletionBudget.Reserve(options.WorkingDirectory, tagReferencePrefixConstant+tagName) {
+		service.logger.Info(logMessageSkippingTagDeletionCapConstant, baseFields...)
+		return
+	}
+
+	service.logger.Info(logMessageDeletingRemoteTagConstant, baseFields...)
+	pushCommandDetails := execshell.CommandDetails{
+		Arguments: []string{
+			pushSubcommandConstant,
+			remoteName,
+			deleteFlagConstant,
+			tagReferencePrefixConstant + tagName,
+		},
+		WorkingDirectory: options.WorkingDirectory,
+		Idempotent:       false,
+	}
+
+	if _, pushError := service.executor.ExecuteGit(executionContext, pushCommandDetails); pushError != nil {
+		service.logger.Warn(logMessageRemoteTagDeletionFailedConstant,
+			append(baseFields, zap.Error(pushError))...,
+		)
+		options.DeletionBudget.Release()
+	}
+
+	service.logger.Info(logMessageDeletingLocalTagConstant, baseFields...)
+	deleteLocalCommand := execshell.CommandDetails{
+		Arguments: []string{
+			tagSubcommandConstant,
+			tagDeleteFlagConstant,
+			tagName,
+		},
+		WorkingDirectory: options.WorkingDirectory,
+		Idempotent:       false,
+	}
+
+	if _, deleteError := service.executor.ExecuteGit(executionContext, deleteLocalCommand); deleteError != nil {
+		service.logger.Warn(logMessageLocalTagDeletionFailedConstant,
+			append(baseFields, zap.Error(deleteError))...,
+		)
+	}
+}
+
+func parseRemoteBranches(commandOutput string) (map[string]string, error) {
+	branchSet := make(map[string]string)
 	scanner := bufio.NewScanner(strings.NewReader(commandOutput))
 	for scanner.Scan() {
 		lineText := scanner.Text()
@@ -292,7 +711,7 @@ func parseRemoteBranches(commandOutput string) (map[string]struct{}, error) {
 		if len(branchName) == 0 {
 			continue
 		}
-		branchSet[branchName] = struct{}{}
+		branchSet[branchName] = lineParts[0]
 	}
 
 	if scanError := scanner.Err(); scanError != nil {
@@ -302,26 +721,61 @@ func parseRemoteBranches(commandOutput string) (map[string]struct{}, error) {
 	return branchSet, nil
 }
 
-func decodePullRequestBranches(standardOutput string) ([]string, error) {
-	type pullRequestPayload struct {
-		HeadRefName string `json:"headRefName"`
+func parseRemoteTags(commandOutput string) (map[string]struct{}, error) {
+	tagSet := make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(commandOutput))
+	for scanner.Scan() {
+		lineParts := strings.Fields(scanner.Text())
+		if len(lineParts) < 2 {
+			continue
+		}
+		tagName := strings.TrimPrefix(lineParts[1], tagReferencePrefixConstant)
+		tagName = strings.TrimSuffix(tagName, peeledTagSuffixConstant)
+		if len(tagName) == 0 {
+			continue
+		}
+		tagSet[tagName] = struct{}{}
+	}
+
+	if scanError := scanner.Err(); scanError != nil {
+		return nil, fmt.Errorf(remoteTagParsingErrorTemplateConstant, scanError)
 	}
 
+	return tagSet, nil
+}
+
+func decodeClosedPullRequests(standardOutput string) ([]closedPullRequest, error) {
 	trimmedOutput := strings.TrimSpace(standardOutput)
 	if len(trimmedOutput) == 0 {
-		return []string{}, nil
+		return []closedPullRequest{}, nil
 	}
 
-	var payload []pullRequestPayload
+	var payload []closedPullRequest
 	if decodeError := json.Unmarshal([]byte(trimmedOutput), &payload); decodeError != nil {
 		return nil, fmt.Errorf(pullRequestDecodingErrorTemplateConstant, decodeError)
 	}
 
-	branches := make([]string, 0, len(payload))
-	for payloadIndex := range payload {
-		branches = append(branches, payload[payloadIndex].HeadRefName)
+	return payload, nil
+}
+
+type branchProtectionCheck struct {
+	resolver   shared.BranchProtectionResolver
+	repository string
+}
+
+func newBranchProtectionCheck(resolver shared.BranchProtectionResolver, repository string) *branchProtectionCheck {
+	trimmedRepository := strings.TrimSpace(repository)
+	if resolver == nil || len(trimmedRepository) == 0 {
+		return nil
+	}
+	return &branchProtectionCheck{resolver: resolver, repository: trimmedRepository}
+}
+
+func (check *branchProtectionCheck) Protects(executionContext context.Context, branchName string) (bool, error) {
+	if check == nil {
+		return false, nil
 	}
-	return branches, nil
+	return check.resolver.BranchProtected(executionContext, check.repository, branchName)
 }
 
 type branchDeletionConfirmation struct {
@@ -339,7 +793,18 @@ func (confirmation *branchDeletionConfirmation) Confirm(branchName string, remot
 		return true, nil
 	}
 
-	prompt := fmt.Sprintf(branchDeletionPromptTemplateConstant, branchName, remoteName)
+	return confirmation.confirmPrompt(fmt.Sprintf(branchDeletionPromptTemplateConstant, branchName, remoteName))
+}
+
+func (confirmation *branchDeletionConfirmation) ConfirmTag(tagName string, remoteName string) (bool, error) {
+	if confirmation == nil || confirmation.assumeYes || confirmation.confirmAll || confirmation.prompter == nil {
+		return true, nil
+	}
+
+	return confirmation.confirmPrompt(fmt.Sprintf(tagDeletionPromptTemplateConstant, tagName, remoteName))
+}
+
+func (confirmation *branchDeletionConfirmation) confirmPrompt(prompt string) (bool, error) {
 	result, promptError := confirmation.prompter.Confirm(prompt)
 	if promptError != nil {
 		return false, promptError
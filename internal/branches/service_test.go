@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -15,6 +16,7 @@ import (
 
 	branches "github.com/temirov/gix/internal/branches"
 	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
 	"github.com/temirov/gix/internal/repos/shared"
 )
 
@@ -22,16 +24,13 @@ const (
 	testRemoteNameConstant                 = "origin"
 	testWorkingDirectoryConstant           = "/tmp/worktree"
 	testPullRequestLimitConstant           = 50
-	gitCommandLabelConstant                = "git"
-	githubCommandLabelConstant             = "gh"
-	commandKeySeparatorConstant            = " "
-	commandKeyTemplateConstant             = "%s%s%s"
+	gitCommandLabelConstant                = execshell.CommandGit
+	githubCommandLabelConstant             = execshell.CommandGitHub
 	remoteBranchOutputTemplateConstant     = "%s\trefs/heads/%s\n"
 	remoteCommitPlaceholderConstant        = "1111111111111111111111111111111111111111"
 	subtestNameTemplateConstant            = "%02d_%s"
 	expectedLogMessageTemplateConstant     = "expected log message %s"
 	unexpectedLogMessageTemplateConstant   = "unexpected log message %s"
-	unexpectedCommandTemplateConstant      = "unexpected %s command: %s"
 	deletingRemoteLogMessageConstant       = "Deleting remote branch"
 	deletingLocalLogMessageConstant        = "Deleting local branch"
 	skippingMissingLogMessageConstant      = "Skipping branch (already gone)"
@@ -60,6 +59,14 @@ const (
 	remoteListErrorContainsConstant        = "unable to list remote branches"
 	pullRequestListErrorContainsConstant   = "unable to list closed pull requests"
 	pullRequestDecodeErrorContainsConstant = "unable to decode pull request response"
+	skippingKeptLogMessageConstant         = "Skipping branch (marked keep)"
+	skippingMissingLocalLogMessageConstant = "Skipping local branch deletion (not present locally)"
+	localBranchRefLineTemplateConstant     = "refs/heads/%s\x00%s\x00\x00\x002024-01-02T03:04:05Z\x00\x00\x00\n"
+)
+
+var (
+	gitBranchDescriptionsArguments = []string{"config", "-z", "--get-regexp", `^branch\..*\.description$`}
+	gitListLocalBranchesArguments  = []string{"for-each-ref", "--format=%(refname)%00%(objectname)%00%(upstream)%00%(upstream:track)%00%(committerdate:iso-strict)%00%(HEAD)%00%(upstream:remotename)%00%(upstream:remoteref)", "refs/heads"}
 )
 
 type stubBranchPrompter struct {
@@ -85,75 +92,17 @@ func (prompter *stubBranchPrompter) Confirm(prompt string) (shared.ConfirmationR
 	return result, err
 }
 
-type fakeCommandExecutor struct {
-	responses           map[string]fakeCommandResponse
-	repositoryResponses map[string]map[string]fakeCommandResponse
-	executedCommands    []executedCommandRecord
-}
-
-type fakeCommandResponse struct {
-	result execshell.ExecutionResult
-	err    error
-}
-
-type executedCommandRecord struct {
-	key              string
-	toolName         string
-	arguments        []string
-	workingDirectory string
-}
-
-func (executor *fakeCommandExecutor) ExecuteGit(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return executor.executeCommand(gitCommandLabelConstant, details)
-}
-
-func (executor *fakeCommandExecutor) ExecuteGitHubCLI(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return executor.executeCommand(githubCommandLabelConstant, details)
-}
-
-func (executor *fakeCommandExecutor) executeCommand(toolName string, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	if executor.responses == nil {
-		executor.responses = map[string]fakeCommandResponse{}
-	}
-
-	commandKey := buildCommandKey(toolName, details.Arguments)
-	executor.executedCommands = append(executor.executedCommands, executedCommandRecord{
-		key:              commandKey,
-		toolName:         toolName,
-		arguments:        append([]string{}, details.Arguments...),
-		workingDirectory: details.WorkingDirectory,
-	})
-
-	if executor.repositoryResponses != nil {
-		if repositoryResponseMap, found := executor.repositoryResponses[details.WorkingDirectory]; found {
-			if response, found := repositoryResponseMap[commandKey]; found {
-				if response.err != nil {
-					return execshell.ExecutionResult{}, response.err
-				}
-				return response.result, nil
-			}
-		}
-	}
-
-	if response, found := executor.responses[commandKey]; found {
-		if response.err != nil {
-			return execshell.ExecutionResult{}, response.err
-		}
-		return response.result, nil
-	}
-
-	return execshell.ExecutionResult{}, fmt.Errorf(unexpectedCommandTemplateConstant, toolName, commandKey)
-}
-
-func buildCommandKey(toolName string, arguments []string) string {
-	return fmt.Sprintf(commandKeyTemplateConstant, toolName, commandKeySeparatorConstant, strings.Join(arguments, commandKeySeparatorConstant))
+func buildCommandKey(toolName execshell.CommandName, arguments []string) string {
+	return execshelltest.CommandKey(toolName, arguments...)
 }
 
-func registerResponse(executor *fakeCommandExecutor, toolName string, arguments []string, result execshell.ExecutionResult, commandError error) {
-	if executor.responses == nil {
-		executor.responses = map[string]fakeCommandResponse{}
+func registerResponse(executor *execshelltest.Executor, toolName execshell.CommandName, arguments []string, result execshell.ExecutionResult, commandError error) {
+	expectation := executor.On(toolName, execshelltest.ExactArguments(arguments...))
+	if commandError != nil {
+		expectation.Fail(commandError)
+		return
 	}
-	executor.responses[buildCommandKey(toolName, arguments)] = fakeCommandResponse{result: result, err: commandError}
+	expectation.Return(result)
 }
 
 func buildRemoteOutput(branchNames []string) string {
@@ -192,9 +141,12 @@ func TestServiceCleanupScenarios(testInstance *testing.T) {
 		unexpectedLogMessages []string
 		prompter              *stubBranchPrompter
 		expectedPrompts       []string
+		branchDescriptions    string
+		localBranches         []string
 	}{
 		{
 			name:                "deletes_remote_and_local_branches",
+			localBranches:       []string{"feature/delete"},
 			remoteBranches:      []string{"feature/delete"},
 			pullRequestBranches: []string{"feature/delete"},
 			options: branches.CleanupOptions{
@@ -215,6 +167,8 @@ func TestServiceCleanupScenarios(testInstance *testing.T) {
 					githubLimitFlagConstant,
 					strconv.Itoa(testPullRequestLimitConstant),
 				}),
+				buildCommandKey(gitCommandLabelConstant, gitListLocalBranchesArguments),
+				buildCommandKey(gitCommandLabelConstant, gitBranchDescriptionsArguments),
 				buildCommandKey(gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, "feature/delete"}),
 				buildCommandKey(gitCommandLabelConstant, []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, "feature/delete"}),
 			},
@@ -249,6 +203,7 @@ func TestServiceCleanupScenarios(testInstance *testing.T) {
 		},
 		{
 			name:                "dry_run_does_not_execute_deletions",
+			localBranches:       []string{"feature/dry-run"},
 			remoteBranches:      []string{"feature/dry-run"},
 			pullRequestBranches: []string{"feature/dry-run"},
 			options: branches.CleanupOptions{
@@ -269,12 +224,15 @@ func TestServiceCleanupScenarios(testInstance *testing.T) {
 					githubLimitFlagConstant,
 					strconv.Itoa(testPullRequestLimitConstant),
 				}),
+				buildCommandKey(gitCommandLabelConstant, gitListLocalBranchesArguments),
+				buildCommandKey(gitCommandLabelConstant, gitBranchDescriptionsArguments),
 			},
 			expectedLogMessages:   []string{skippingRemoteDryRunLogMessageConstant, skippingLocalDryRunLogMessageConstant},
 			unexpectedLogMessages: []string{deletingRemoteLogMessageConstant, deletingLocalLogMessageConstant},
 		},
 		{
 			name:                "user_declines_branch_deletion",
+			localBranches:       []string{"feature/user-decline"},
 			remoteBranches:      []string{"feature/user-decline"},
 			pullRequestBranches: []string{"feature/user-decline"},
 			options: branches.CleanupOptions{
@@ -295,6 +253,8 @@ func TestServiceCleanupScenarios(testInstance *testing.T) {
 					githubLimitFlagConstant,
 					strconv.Itoa(testPullRequestLimitConstant),
 				}),
+				buildCommandKey(gitCommandLabelConstant, gitListLocalBranchesArguments),
+				buildCommandKey(gitCommandLabelConstant, gitBranchDescriptionsArguments),
 			},
 			expectedLogMessages:   []string{deletionDeclinedLogMessageConstant},
 			unexpectedLogMessages: []string{deletingRemoteLogMessageConstant, deletingLocalLogMessageConstant},
@@ -303,6 +263,7 @@ func TestServiceCleanupScenarios(testInstance *testing.T) {
 		},
 		{
 			name:                "duplicates_are_processed_once",
+			localBranches:       []string{"feature/duplicate"},
 			remoteBranches:      []string{"feature/duplicate"},
 			pullRequestBranches: []string{"feature/duplicate", "feature/duplicate"},
 			options: branches.CleanupOptions{
@@ -323,18 +284,83 @@ func TestServiceCleanupScenarios(testInstance *testing.T) {
 					githubLimitFlagConstant,
 					strconv.Itoa(testPullRequestLimitConstant),
 				}),
+				buildCommandKey(gitCommandLabelConstant, gitListLocalBranchesArguments),
+				buildCommandKey(gitCommandLabelConstant, gitBranchDescriptionsArguments),
 				buildCommandKey(gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, "feature/duplicate"}),
 				buildCommandKey(gitCommandLabelConstant, []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, "feature/duplicate"}),
 			},
 			expectedLogMessages:   []string{deletingRemoteLogMessageConstant, deletingLocalLogMessageConstant},
 			unexpectedLogMessages: []string{skippingMissingLogMessageConstant},
 		},
+		{
+			name:                "branches_marked_keep_are_skipped",
+			localBranches:       []string{"feature/kept", "feature/described"},
+			remoteBranches:      []string{"feature/kept", "feature/described"},
+			pullRequestBranches: []string{"feature/kept", "feature/described"},
+			options: branches.CleanupOptions{
+				RemoteName:       testRemoteNameConstant,
+				PullRequestLimit: testPullRequestLimitConstant,
+				DryRun:           false,
+				WorkingDirectory: testWorkingDirectoryConstant,
+				KeepMarker:       "PINNED",
+			},
+			branchDescriptions: "branch.feature/kept.description\nLong-lived integration branch\nPINNED until release\x00branch.feature/described.description\nScratch work\x00",
+			expectedCommandKeys: []string{
+				buildCommandKey(gitCommandLabelConstant, []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}),
+				buildCommandKey(githubCommandLabelConstant, []string{
+					githubPullRequestSubcommandConstant,
+					githubListSubcommandConstant,
+					githubStateFlagConstant,
+					githubClosedStateConstant,
+					githubJSONFlagConstant,
+					pullRequestJSONFieldNameConstant,
+					githubLimitFlagConstant,
+					strconv.Itoa(testPullRequestLimitConstant),
+				}),
+				buildCommandKey(gitCommandLabelConstant, gitListLocalBranchesArguments),
+				buildCommandKey(gitCommandLabelConstant, gitBranchDescriptionsArguments),
+				buildCommandKey(gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, "feature/described"}),
+				buildCommandKey(gitCommandLabelConstant, []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, "feature/described"}),
+			},
+			expectedLogMessages: []string{skippingKeptLogMessageConstant, deletingRemoteLogMessageConstant, deletingLocalLogMessageConstant},
+		},
+		{
+			name:                "remote_only_branches_skip_local_work",
+			remoteBranches:      []string{"feature/one", "feature/two", "feature/three"},
+			pullRequestBranches: []string{"feature/one", "feature/two", "feature/three"},
+			localBranches:       []string{"main"},
+			options: branches.CleanupOptions{
+				RemoteName:       testRemoteNameConstant,
+				PullRequestLimit: testPullRequestLimitConstant,
+				DryRun:           false,
+				WorkingDirectory: testWorkingDirectoryConstant,
+			},
+			expectedCommandKeys: []string{
+				buildCommandKey(gitCommandLabelConstant, []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}),
+				buildCommandKey(githubCommandLabelConstant, []string{
+					githubPullRequestSubcommandConstant,
+					githubListSubcommandConstant,
+					githubStateFlagConstant,
+					githubClosedStateConstant,
+					githubJSONFlagConstant,
+					pullRequestJSONFieldNameConstant,
+					githubLimitFlagConstant,
+					strconv.Itoa(testPullRequestLimitConstant),
+				}),
+				buildCommandKey(gitCommandLabelConstant, gitListLocalBranchesArguments),
+				buildCommandKey(gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, "feature/one"}),
+				buildCommandKey(gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, "feature/two"}),
+				buildCommandKey(gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, "feature/three"}),
+			},
+			expectedLogMessages:   []string{deletingRemoteLogMessageConstant, skippingMissingLocalLogMessageConstant},
+			unexpectedLogMessages: []string{deletingLocalLogMessageConstant},
+		},
 	}
 
 	for testCaseIndex := range testCases {
 		testCase := testCases[testCaseIndex]
 		testInstance.Run(fmt.Sprintf(subtestNameTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
-			fakeExecutorInstance := &fakeCommandExecutor{}
+			fakeExecutorInstance := execshelltest.NewExecutor()
 
 			remoteOutput := buildRemoteOutput(testCase.remoteBranches)
 			pullRequestJSON, jsonError := buildPullRequestJSON(testCase.pullRequestBranches)
@@ -354,6 +380,16 @@ func TestServiceCleanupScenarios(testInstance *testing.T) {
 				strconv.Itoa(testCase.options.PullRequestLimit),
 			}
 			registerResponse(fakeExecutorInstance, githubCommandLabelConstant, githubListArguments, execshell.ExecutionResult{StandardOutput: pullRequestJSON, ExitCode: 0}, nil)
+			var localBranchOutput strings.Builder
+			for _, localBranch := range testCase.localBranches {
+				localBranchOutput.WriteString(fmt.Sprintf(localBranchRefLineTemplateConstant, localBranch, remoteCommitPlaceholderConstant))
+			}
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitListLocalBranchesArguments, execshell.ExecutionResult{StandardOutput: localBranchOutput.String()}, nil)
+			if len(testCase.branchDescriptions) > 0 {
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitBranchDescriptionsArguments, execshell.ExecutionResult{StandardOutput: testCase.branchDescriptions}, nil)
+			} else {
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitBranchDescriptionsArguments, execshell.ExecutionResult{}, execshell.CommandFailedError{Result: execshell.ExecutionResult{ExitCode: 1}})
+			}
 
 			for branchIndex := range testCase.pullRequestBranches {
 				branchName := testCase.pullRequestBranches[branchIndex]
@@ -382,10 +418,10 @@ func TestServiceCleanupScenarios(testInstance *testing.T) {
 				require.Equal(testInstance, testCase.expectedPrompts, confirmationPrompter.prompts)
 			}
 
-			actualCommandKeys := make([]string, 0, len(fakeExecutorInstance.executedCommands))
-			for commandIndex := range fakeExecutorInstance.executedCommands {
-				actualCommandKeys = append(actualCommandKeys, fakeExecutorInstance.executedCommands[commandIndex].key)
-				require.Equal(testInstance, testCase.options.WorkingDirectory, fakeExecutorInstance.executedCommands[commandIndex].workingDirectory)
+			actualCommandKeys := []string{}
+			for _, executed := range fakeExecutorInstance.Executed() {
+				actualCommandKeys = append(actualCommandKeys, executed.Key())
+				require.Equal(testInstance, testCase.options.WorkingDirectory, executed.Details.WorkingDirectory)
 			}
 			require.Equal(testInstance, testCase.expectedCommandKeys, actualCommandKeys)
 
@@ -415,13 +451,13 @@ func containsLogMessage(entries []observer.LoggedEntry, message string) bool {
 func TestServiceCleanupFailures(testInstance *testing.T) {
 	testCases := []struct {
 		name              string
-		configureExecutor func(*fakeCommandExecutor)
+		configureExecutor func(*execshelltest.Executor)
 		options           branches.CleanupOptions
 		expectedError     string
 	}{
 		{
 			name: "remote_name_required",
-			configureExecutor: func(executor *fakeCommandExecutor) {
+			configureExecutor: func(executor *execshelltest.Executor) {
 			},
 			options: branches.CleanupOptions{
 				RemoteName:       "",
@@ -432,7 +468,7 @@ func TestServiceCleanupFailures(testInstance *testing.T) {
 		},
 		{
 			name: "limit_must_be_positive",
-			configureExecutor: func(executor *fakeCommandExecutor) {
+			configureExecutor: func(executor *execshelltest.Executor) {
 			},
 			options: branches.CleanupOptions{
 				RemoteName:       testRemoteNameConstant,
@@ -443,7 +479,7 @@ func TestServiceCleanupFailures(testInstance *testing.T) {
 		},
 		{
 			name: "remote_listing_failure",
-			configureExecutor: func(executor *fakeCommandExecutor) {
+			configureExecutor: func(executor *execshelltest.Executor) {
 				failingArguments := []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}
 				registerResponse(executor, gitCommandLabelConstant, failingArguments, execshell.ExecutionResult{}, errors.New(remoteListFailureMessageConstant))
 			},
@@ -456,7 +492,7 @@ func TestServiceCleanupFailures(testInstance *testing.T) {
 		},
 		{
 			name: "pull_request_listing_failure",
-			configureExecutor: func(executor *fakeCommandExecutor) {
+			configureExecutor: func(executor *execshelltest.Executor) {
 				gitArguments := []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}
 				registerResponse(executor, gitCommandLabelConstant, gitArguments, execshell.ExecutionResult{ExitCode: 0}, nil)
 
@@ -481,7 +517,7 @@ func TestServiceCleanupFailures(testInstance *testing.T) {
 		},
 		{
 			name: "pull_request_decoding_failure",
-			configureExecutor: func(executor *fakeCommandExecutor) {
+			configureExecutor: func(executor *execshelltest.Executor) {
 				gitArguments := []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}
 				registerResponse(executor, gitCommandLabelConstant, gitArguments, execshell.ExecutionResult{ExitCode: 0}, nil)
 
@@ -509,7 +545,7 @@ func TestServiceCleanupFailures(testInstance *testing.T) {
 	for testCaseIndex := range testCases {
 		testCase := testCases[testCaseIndex]
 		testInstance.Run(fmt.Sprintf(subtestNameTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
-			fakeExecutorInstance := &fakeCommandExecutor{}
+			fakeExecutorInstance := execshelltest.NewExecutor()
 			testCase.configureExecutor(fakeExecutorInstance)
 
 			logCore, _ := observer.New(zap.DebugLevel)
@@ -525,9 +561,361 @@ func TestServiceCleanupFailures(testInstance *testing.T) {
 	}
 }
 
+func TestServiceCleanupPullRequestTags(testInstance *testing.T) {
+	const (
+		tagPatternConstant      = "pr-{number}"
+		tagFieldsConstant       = "headRefName,number,state"
+		gitTagsFlagConstant     = "--tags"
+		gitTagSubcommand        = "tag"
+		gitTagDeleteFlag        = "-d"
+		deletingRemoteTagLog    = "Deleting remote pull request tag"
+		deletingLocalTagLog     = "Deleting local pull request tag"
+		skippingRemoteTagDryRun = "Skipping remote pull request tag deletion (dry run)"
+		skippingMissingTagLog   = "Skipping pull request tag (not on remote)"
+		skippingOpenTagLog      = "Skipping pull request tag (pull request still open)"
+		skippingUncleanedTagLog = "Skipping pull request tag (branch not deleted by this run)"
+	)
+
+	testCases := []struct {
+		name                  string
+		pullRequestJSON       string
+		remoteTagOutput       string
+		dryRun                bool
+		branchDeletionFails   bool
+		expectedTagCommands   []string
+		expectedLogMessages   []string
+		unexpectedLogMessages []string
+	}{
+		{
+			name:            "deletes_remote_and_local_tag",
+			pullRequestJSON: `[{"headRefName":"feature/tagged","number":12,"state":"MERGED"}]`,
+			remoteTagOutput: remoteCommitPlaceholderConstant + "\trefs/tags/pr-12\n" + remoteCommitPlaceholderConstant + "\trefs/tags/pr-12^{}\n",
+			expectedTagCommands: []string{
+				buildCommandKey(gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, "refs/tags/pr-12"}),
+				buildCommandKey(gitCommandLabelConstant, []string{gitTagSubcommand, gitTagDeleteFlag, "pr-12"}),
+			},
+			expectedLogMessages: []string{deletingRemoteLogMessageConstant, deletingRemoteTagLog, deletingLocalTagLog},
+		},
+		{
+			name:                  "dry_run_skips_tag_deletion",
+			pullRequestJSON:       `[{"headRefName":"feature/tagged","number":12,"state":"CLOSED"}]`,
+			remoteTagOutput:       remoteCommitPlaceholderConstant + "\trefs/tags/pr-12\n",
+			dryRun:                true,
+			expectedLogMessages:   []string{skippingRemoteTagDryRun},
+			unexpectedLogMessages: []string{deletingRemoteTagLog, deletingLocalTagLog},
+		},
+		{
+			name:                  "missing_tag_is_skipped",
+			pullRequestJSON:       `[{"headRefName":"feature/tagged","number":13,"state":"MERGED"}]`,
+			remoteTagOutput:       remoteCommitPlaceholderConstant + "\trefs/tags/pr-12\n",
+			expectedLogMessages:   []string{skippingMissingTagLog},
+			unexpectedLogMessages: []string{deletingRemoteTagLog},
+		},
+		{
+			name:                  "open_pull_request_tag_is_kept",
+			pullRequestJSON:       `[{"headRefName":"feature/tagged","number":12,"state":"OPEN"}]`,
+			remoteTagOutput:       remoteCommitPlaceholderConstant + "\trefs/tags/pr-12\n",
+			expectedLogMessages:   []string{skippingOpenTagLog},
+			unexpectedLogMessages: []string{deletingRemoteTagLog, deletingLocalTagLog},
+		},
+		{
+			name:                  "tag_of_undeleted_branch_is_kept",
+			pullRequestJSON:       `[{"headRefName":"feature/tagged","number":12,"state":"MERGED"}]`,
+			remoteTagOutput:       remoteCommitPlaceholderConstant + "\trefs/tags/pr-12\n",
+			branchDeletionFails:   true,
+			expectedLogMessages:   []string{skippingUncleanedTagLog},
+			unexpectedLogMessages: []string{deletingRemoteTagLog, deletingLocalTagLog},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(fmt.Sprintf(subtestNameTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
+			fakeExecutorInstance := execshelltest.NewExecutor()
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}, execshell.ExecutionResult{StandardOutput: buildRemoteOutput([]string{"feature/tagged"})}, nil)
+			registerResponse(fakeExecutorInstance, githubCommandLabelConstant, []string{
+				githubPullRequestSubcommandConstant,
+				githubListSubcommandConstant,
+				githubStateFlagConstant,
+				githubClosedStateConstant,
+				githubJSONFlagConstant,
+				tagFieldsConstant,
+				githubLimitFlagConstant,
+				strconv.Itoa(testPullRequestLimitConstant),
+			}, execshell.ExecutionResult{StandardOutput: testCase.pullRequestJSON}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitListRemoteSubcommandConstant, gitTagsFlagConstant, testRemoteNameConstant}, execshell.ExecutionResult{StandardOutput: testCase.remoteTagOutput}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, "feature/tagged"}, execshell.ExecutionResult{}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, "feature/tagged"}, execshell.ExecutionResult{}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, "refs/tags/pr-12"}, execshell.ExecutionResult{}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitTagSubcommand, gitTagDeleteFlag, "pr-12"}, execshell.ExecutionResult{}, nil)
+			if testCase.branchDeletionFails {
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, "feature/tagged"}, execshell.ExecutionResult{}, errors.New("push rejected"))
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, "feature/tagged"}, execshell.ExecutionResult{}, errors.New("branch checked out"))
+			}
+
+			logCore, observedLogs := observer.New(zap.DebugLevel)
+			service, serviceError := branches.NewService(zap.New(logCore), fakeExecutorInstance, &stubBranchPrompter{defaultResponse: shared.ConfirmationResult{Confirmed: true}})
+			require.NoError(testInstance, serviceError)
+
+			cleanupError := service.Cleanup(context.Background(), branches.CleanupOptions{
+				RemoteName:            testRemoteNameConstant,
+				PullRequestLimit:      testPullRequestLimitConstant,
+				DryRun:                testCase.dryRun,
+				WorkingDirectory:      testWorkingDirectoryConstant,
+				PullRequestTagPattern: tagPatternConstant,
+			})
+			require.NoError(testInstance, cleanupError)
+
+			tagCommandKeys := []string{}
+			for _, executed := range fakeExecutorInstance.Executed() {
+				executedArguments := executed.Details.Arguments
+				isTagPush := len(executedArguments) == 4 && executedArguments[0] == gitPushSubcommandConstant && strings.HasPrefix(executedArguments[3], "refs/tags/")
+				isTagDelete := len(executedArguments) > 0 && executedArguments[0] == gitTagSubcommand
+				if isTagPush || isTagDelete {
+					tagCommandKeys = append(tagCommandKeys, executed.Key())
+				}
+			}
+			if testCase.expectedTagCommands == nil {
+				require.Empty(testInstance, tagCommandKeys)
+			} else {
+				require.Equal(testInstance, testCase.expectedTagCommands, tagCommandKeys)
+			}
+
+			loggedEntries := observedLogs.All()
+			for _, expectedMessage := range testCase.expectedLogMessages {
+				require.True(testInstance, containsLogMessage(loggedEntries, expectedMessage), fmt.Sprintf(expectedLogMessageTemplateConstant, expectedMessage))
+			}
+			for _, unexpectedMessage := range testCase.unexpectedLogMessages {
+				require.False(testInstance, containsLogMessage(loggedEntries, unexpectedMessage), fmt.Sprintf(unexpectedLogMessageTemplateConstant, unexpectedMessage))
+			}
+		})
+	}
+}
+
+func TestServiceCleanupRejectsTagPatternWithoutPlaceholder(testInstance *testing.T) {
+	service, serviceError := branches.NewService(zap.NewNop(), execshelltest.NewExecutor(), nil)
+	require.NoError(testInstance, serviceError)
+
+	cleanupError := service.Cleanup(context.Background(), branches.CleanupOptions{
+		RemoteName:            testRemoteNameConstant,
+		PullRequestLimit:      testPullRequestLimitConstant,
+		PullRequestTagPattern: "pr-latest",
+	})
+	require.EqualError(testInstance, cleanupError, "pull request tag pattern must contain the {number} placeholder")
+}
+
 func TestNewServiceRequiresExecutor(testInstance *testing.T) {
 	service, serviceError := branches.NewService(zap.NewNop(), nil, nil)
 	require.Error(testInstance, serviceError)
 	require.Nil(testInstance, service)
 	require.EqualError(testInstance, serviceError, executorNotConfiguredMessageConstant)
 }
+
+type stubBranchProtectionResolver struct {
+	protectedBranches []string
+	err               error
+	repositories      []string
+}
+
+func (resolver *stubBranchProtectionResolver) BranchProtected(executionContext context.Context, repository string, branchName string) (bool, error) {
+	resolver.repositories = append(resolver.repositories, repository)
+	return slices.Contains(resolver.protectedBranches, branchName), resolver.err
+}
+
+func TestServiceCleanupRespectsBranchProtection(testInstance *testing.T) {
+	const (
+		protectedBranchConstant   = "release/1.0"
+		unprotectedBranchConstant = "feature/one"
+		repositoryConstant        = "owner/example"
+		skippingProtectedLog      = "Skipping branch (protected on GitHub)"
+		protectionUnavailableLog  = "Branch protection rules unavailable; attempting deletion"
+	)
+
+	testCases := []struct {
+		name                 string
+		resolver             *stubBranchProtectionResolver
+		repository           string
+		expectedDeletions    []string
+		expectedLogMessages  []string
+		expectedLookupsCount int
+	}{
+		{
+			name:                 "protected_branch_skipped",
+			resolver:             &stubBranchProtectionResolver{protectedBranches: []string{protectedBranchConstant}},
+			repository:           repositoryConstant,
+			expectedDeletions:    []string{unprotectedBranchConstant},
+			expectedLogMessages:  []string{skippingProtectedLog},
+			expectedLookupsCount: 2,
+		},
+		{
+			name:                 "lookup_failure_falls_back_to_deletion",
+			resolver:             &stubBranchProtectionResolver{err: errors.New("protection lookup failure")},
+			repository:           repositoryConstant,
+			expectedDeletions:    []string{protectedBranchConstant, unprotectedBranchConstant},
+			expectedLogMessages:  []string{protectionUnavailableLog},
+			expectedLookupsCount: 2,
+		},
+		{
+			name:              "unknown_repository_skips_lookup",
+			resolver:          &stubBranchProtectionResolver{protectedBranches: []string{protectedBranchConstant, unprotectedBranchConstant}},
+			expectedDeletions: []string{protectedBranchConstant, unprotectedBranchConstant},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(fmt.Sprintf(subtestNameTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
+			branchNames := []string{protectedBranchConstant, unprotectedBranchConstant}
+			pullRequestJSON, encodingError := buildPullRequestJSON(branchNames)
+			require.NoError(testInstance, encodingError)
+
+			fakeExecutorInstance := execshelltest.NewExecutor()
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}, execshell.ExecutionResult{StandardOutput: buildRemoteOutput(branchNames)}, nil)
+			registerResponse(fakeExecutorInstance, githubCommandLabelConstant, []string{
+				githubPullRequestSubcommandConstant,
+				githubListSubcommandConstant,
+				githubStateFlagConstant,
+				githubClosedStateConstant,
+				githubJSONFlagConstant,
+				pullRequestJSONFieldNameConstant,
+				githubLimitFlagConstant,
+				strconv.Itoa(testPullRequestLimitConstant),
+			}, execshell.ExecutionResult{StandardOutput: pullRequestJSON}, nil)
+			for _, branchName := range branchNames {
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, branchName}, execshell.ExecutionResult{}, nil)
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, branchName}, execshell.ExecutionResult{}, nil)
+			}
+
+			logCore, observedLogs := observer.New(zap.DebugLevel)
+			service, serviceError := branches.NewService(zap.New(logCore), fakeExecutorInstance, nil)
+			require.NoError(testInstance, serviceError)
+			service.WithBranchProtection(testCase.resolver)
+
+			cleanupError := service.Cleanup(context.Background(), branches.CleanupOptions{
+				RemoteName:       testRemoteNameConstant,
+				PullRequestLimit: testPullRequestLimitConstant,
+				WorkingDirectory: testWorkingDirectoryConstant,
+				AssumeYes:        true,
+				Repository:       testCase.repository,
+			})
+			require.NoError(testInstance, cleanupError)
+
+			deletedBranches := []string{}
+			for _, executed := range fakeExecutorInstance.Executed() {
+				if len(executed.Details.Arguments) == 4 && executed.Details.Arguments[0] == gitPushSubcommandConstant {
+					deletedBranches = append(deletedBranches, executed.Details.Arguments[3])
+				}
+			}
+			require.Equal(testInstance, testCase.expectedDeletions, deletedBranches)
+			require.Len(testInstance, testCase.resolver.repositories, testCase.expectedLookupsCount)
+
+			for _, expectedMessage := range testCase.expectedLogMessages {
+				require.True(testInstance, containsLogMessage(observedLogs.All(), expectedMessage), fmt.Sprintf(expectedLogMessageTemplateConstant, expectedMessage))
+			}
+		})
+	}
+}
+
+func TestServiceCleanupEnforcesDeletionBudgetAcrossRepositories(testInstance *testing.T) {
+	const (
+		secondWorkingDirectoryConstant = "/tmp/second"
+		skippingCapLogMessageConstant  = "Skipping branch (deletion cap reached)"
+	)
+
+	testCases := []struct {
+		name              string
+		dryRun            bool
+		limit             int
+		failingBranch     string
+		expectedPushCount int
+		expectedSkipped   []branches.CappedDeletion
+	}{
+		{
+			name:              "cap_reached_in_second_repository",
+			limit:             4,
+			expectedPushCount: 4,
+			expectedSkipped: []branches.CappedDeletion{
+				{RepositoryPath: secondWorkingDirectoryConstant, Reference: "feature/two"},
+				{RepositoryPath: secondWorkingDirectoryConstant, Reference: "feature/three"},
+			},
+		},
+		{
+			name:              "dry_run_counts_planned_deletions",
+			dryRun:            true,
+			limit:             4,
+			expectedPushCount: 0,
+			expectedSkipped: []branches.CappedDeletion{
+				{RepositoryPath: secondWorkingDirectoryConstant, Reference: "feature/two"},
+				{RepositoryPath: secondWorkingDirectoryConstant, Reference: "feature/three"},
+			},
+		},
+		{
+			name:              "cap_not_reached",
+			limit:             6,
+			expectedPushCount: 6,
+		},
+		{
+			name:              "failed_push_releases_reservation",
+			limit:             4,
+			failingBranch:     "feature/one",
+			expectedPushCount: 6,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(fmt.Sprintf(subtestNameTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
+			branchNames := []string{"feature/one", "feature/two", "feature/three"}
+			pullRequestJSON, encodingError := buildPullRequestJSON(branchNames)
+			require.NoError(testInstance, encodingError)
+
+			fakeExecutorInstance := execshelltest.NewExecutor()
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}, execshell.ExecutionResult{StandardOutput: buildRemoteOutput(branchNames)}, nil)
+			registerResponse(fakeExecutorInstance, githubCommandLabelConstant, []string{
+				githubPullRequestSubcommandConstant,
+				githubListSubcommandConstant,
+				githubStateFlagConstant,
+				githubClosedStateConstant,
+				githubJSONFlagConstant,
+				pullRequestJSONFieldNameConstant,
+				githubLimitFlagConstant,
+				strconv.Itoa(testPullRequestLimitConstant),
+			}, execshell.ExecutionResult{StandardOutput: pullRequestJSON}, nil)
+			for _, branchName := range branchNames {
+				var pushError error
+				if branchName == testCase.failingBranch {
+					pushError = errors.New("remote rejected")
+				}
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, branchName}, execshell.ExecutionResult{}, pushError)
+				registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, branchName}, execshell.ExecutionResult{}, nil)
+			}
+
+			logCore, observedLogs := observer.New(zap.DebugLevel)
+			service, serviceError := branches.NewService(zap.New(logCore), fakeExecutorInstance, nil)
+			require.NoError(testInstance, serviceError)
+
+			deletionBudget := branches.NewDeletionBudget(testCase.limit)
+			for _, workingDirectory := range []string{testWorkingDirectoryConstant, secondWorkingDirectoryConstant} {
+				cleanupError := service.Cleanup(context.Background(), branches.CleanupOptions{
+					RemoteName:       testRemoteNameConstant,
+					PullRequestLimit: testPullRequestLimitConstant,
+					DryRun:           testCase.dryRun,
+					WorkingDirectory: workingDirectory,
+					AssumeYes:        true,
+					DeletionBudget:   deletionBudget,
+				})
+				require.NoError(testInstance, cleanupError)
+			}
+
+			pushCount := 0
+			for _, executed := range fakeExecutorInstance.Executed() {
+				if executed.Name == gitCommandLabelConstant && executed.Details.Arguments[0] == gitPushSubcommandConstant {
+					pushCount++
+				}
+			}
+			require.Equal(testInstance, testCase.expectedPushCount, pushCount)
+			require.Equal(testInstance, testCase.expectedSkipped, deletionBudget.Skipped())
+			require.Equal(testInstance, len(testCase.expectedSkipped) > 0, containsLogMessage(observedLogs.All(), skippingCapLogMessageConstant))
+		})
+	}
+}
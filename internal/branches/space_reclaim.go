@@ -0,0 +1,222 @@
+package branches
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	revListSubcommandConstant                 = "rev-list"
+	objectsFlagConstant                       = "--objects"
+	diskUsageFlagConstant                     = "--disk-usage"
+	notFlagConstant                           = "--not"
+	allReferencesFlagConstant                 = "--all"
+	countObjectsSubcommandConstant            = "count-objects"
+	verboseFlagConstant                       = "-v"
+	garbageCollectSubcommandConstant          = "gc"
+	pruneNowFlagConstant                      = "--prune=now"
+	countObjectsFieldSeparatorConstant        = ":"
+	countObjectsKibibyteConstant              = 1024
+	logMessageUnreachableEstimateFailed       = "Unable to estimate unreachable objects of deleted branches"
+	logMessageGarbageCollectingConstant       = "Running git gc after local branch deletions"
+	logMessageGarbageCollectionFailedConstant = "git gc failed after local branch deletions"
+	logMessageObjectCountFailedConstant       = "Unable to measure repository object storage"
+	logMessageSpaceReclaimMeasuredConstant    = "Measured storage freed by local branch deletions"
+	unreachableObjectsParseErrorTemplate      = "unable to parse disk usage %q: %w"
+	spaceReclaimUnreachableTemplateConstant   = "BRANCHES-UNREACHABLE: %s branches=%d size=%s (%d bytes)\n"
+	spaceReclaimUnreachableUnknownTemplate    = "BRANCHES-UNREACHABLE: %s branches=%d size=unknown\n"
+	spaceReclaimGarbageCollectedTemplate      = "BRANCHES-GC: %s reclaimed=%s (%d bytes)\n"
+	spaceReclaimTotalTemplateConstant         = "BRANCHES-RECLAIM-TOTAL: unreachable=%s (%d bytes) across %d repositories\n"
+	spaceReclaimGarbageCollectedTotalTemplate = "BRANCHES-GC-TOTAL: reclaimed=%s (%d bytes) across %d repositories\n"
+	logFieldDeletedBranchCountConstant        = "deleted_branches"
+	logFieldUnreachableBytesConstant          = "unreachable_bytes"
+	logFieldReclaimedBytesConstant            = "reclaimed_bytes"
+)
+
+var countObjectsSizeFields = map[string]struct{}{"size": {}, "size-pack": {}, "size-garbage": {}}
+
+var garbageCollectionMutex sync.Mutex
+
+// SpaceReclaim records the storage freed in one repository by deleting its local branches.
+// UnreachableBytes estimates the objects only the deleted branch tips reached; UnreachableKnown is false when git could
+// not estimate them. ReclaimedBytes is the measured drop in object storage when git gc ran.
+type SpaceReclaim struct {
+	RepositoryPath   string
+	DeletedBranches  int
+	UnreachableBytes int64
+	UnreachableKnown bool
+	GarbageCollected bool
+	ReclaimedBytes   int64
+}
+
+// SpaceReclaimTally accumulates SpaceReclaim records across repositories.
+type SpaceReclaimTally = utils.Tally[SpaceReclaim]
+
+func (service *Service) reclaimSpace(executionContext context.Context, deletedTips []string, options CleanupOptions) {
+	if options.DryRun || len(deletedTips) == 0 || (options.SpaceReclaim == nil && !options.GarbageCollect) {
+		return
+	}
+
+	baseFields := []zap.Field{
+		zap.String(logFieldWorkingDirectoryConstant, options.WorkingDirectory),
+		zap.Int(logFieldDeletedBranchCountConstant, len(deletedTips)),
+	}
+
+	record := SpaceReclaim{RepositoryPath: options.WorkingDirectory, DeletedBranches: len(deletedTips)}
+	knownTips := make([]string, 0, len(deletedTips))
+	for _, tip := range deletedTips {
+		if len(tip) > 0 {
+			knownTips = append(knownTips, tip)
+		}
+	}
+	if len(knownTips) > 0 {
+		unreachableBytes, estimateError := service.estimateUnreachableBytes(executionContext, knownTips, options.WorkingDirectory)
+		if estimateError != nil {
+			service.logger.Warn(logMessageUnreachableEstimateFailed, append(baseFields, zap.Error(estimateError))...)
+		} else {
+			record.UnreachableBytes = unreachableBytes
+			record.UnreachableKnown = true
+		}
+	}
+
+	if options.GarbageCollect {
+		reclaimedBytes, collected := service.collectGarbage(executionContext, options.WorkingDirectory, baseFields)
+		record.GarbageCollected = collected
+		record.ReclaimedBytes = reclaimedBytes
+	}
+
+	service.logger.Info(logMessageSpaceReclaimMeasuredConstant,
+		append(baseFields, zap.Int64(logFieldUnreachableBytesConstant, record.UnreachableBytes), zap.Int64(logFieldReclaimedBytesConstant, record.ReclaimedBytes))...,
+	)
+	options.SpaceReclaim.Add(record)
+}
+
+// estimateUnreachableBytes sums the on-disk size of objects reachable from the deleted tips but from no remaining reference.
+func (service *Service) estimateUnreachableBytes(executionContext context.Context, deletedTips []string, workingDirectory string) (int64, error) {
+	arguments := append([]string{revListSubcommandConstant, objectsFlagConstant, diskUsageFlagConstant}, deletedTips...)
+	arguments = append(arguments, notFlagConstant, allReferencesFlagConstant)
+	executionResult, executionError := service.executor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        arguments,
+		WorkingDirectory: workingDirectory,
+		Idempotent:       true,
+	})
+	if executionError != nil {
+		return 0, executionError
+	}
+
+	trimmedOutput := strings.TrimSpace(executionResult.StandardOutput)
+	byteCount, parseError := strconv.ParseInt(trimmedOutput, 10, 64)
+	if parseError != nil {
+		return 0, fmt.Errorf(unreachableObjectsParseErrorTemplate, trimmedOutput, parseError)
+	}
+	return byteCount, nil
+}
+
+// collectGarbage runs git gc --prune=now and reports the drop in object storage. Only one repository is collected at a
+// time because gc is IO-heavy.
+func (service *Service) collectGarbage(executionContext context.Context, workingDirectory string, baseFields []zap.Field) (int64, bool) {
+	garbageCollectionMutex.Lock()
+	defer garbageCollectionMutex.Unlock()
+
+	sizeBefore, beforeError := service.objectStorageBytes(executionContext, workingDirectory)
+	if beforeError != nil {
+		service.logger.Warn(logMessageObjectCountFailedConstant, append(baseFields, zap.Error(beforeError))...)
+	}
+
+	service.logger.Info(logMessageGarbageCollectingConstant, baseFields...)
+	if _, gcError := service.executor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{garbageCollectSubcommandConstant, pruneNowFlagConstant},
+		WorkingDirectory: workingDirectory,
+		Idempotent:       false,
+	}); gcError != nil {
+		service.logger.Warn(logMessageGarbageCollectionFailedConstant, append(baseFields, zap.Error(gcError))...)
+		return 0, false
+	}
+
+	if beforeError != nil {
+		return 0, true
+	}
+	sizeAfter, afterError := service.objectStorageBytes(executionContext, workingDirectory)
+	if afterError != nil {
+		service.logger.Warn(logMessageObjectCountFailedConstant, append(baseFields, zap.Error(afterError))...)
+		return 0, true
+	}
+	if sizeAfter >= sizeBefore {
+		return 0, true
+	}
+	return sizeBefore - sizeAfter, true
+}
+
+func (service *Service) objectStorageBytes(executionContext context.Context, workingDirectory string) (int64, error) {
+	executionResult, executionError := service.executor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{countObjectsSubcommandConstant, verboseFlagConstant},
+		WorkingDirectory: workingDirectory,
+		Idempotent:       true,
+	})
+	if executionError != nil {
+		return 0, executionError
+	}
+	return parseObjectStorageBytes(executionResult.StandardOutput)
+}
+
+// parseObjectStorageBytes sums the loose, packed, and garbage sizes reported by git count-objects -v, which are in KiB.
+func parseObjectStorageBytes(commandOutput string) (int64, error) {
+	var totalKibibytes int64
+	scanner := bufio.NewScanner(strings.NewReader(commandOutput))
+	for scanner.Scan() {
+		fieldName, fieldValue, found := strings.Cut(scanner.Text(), countObjectsFieldSeparatorConstant)
+		if !found {
+			continue
+		}
+		if _, sizeField := countObjectsSizeFields[strings.TrimSpace(fieldName)]; !sizeField {
+			continue
+		}
+		kibibytes, parseError := strconv.ParseInt(strings.TrimSpace(fieldValue), 10, 64)
+		if parseError != nil {
+			return 0, parseError
+		}
+		totalKibibytes += kibibytes
+	}
+	if scanError := scanner.Err(); scanError != nil {
+		return 0, scanError
+	}
+	return totalKibibytes * countObjectsKibibyteConstant, nil
+}
+
+func reportSpaceReclaim(writer io.Writer, tally *SpaceReclaimTally) {
+	records := tally.Records()
+	if len(records) == 0 {
+		return
+	}
+
+	var unreachableTotal, reclaimedTotal int64
+	collectedRepositories := 0
+	for _, record := range records {
+		if record.UnreachableKnown {
+			fmt.Fprintf(writer, spaceReclaimUnreachableTemplateConstant, record.RepositoryPath, record.DeletedBranches, utils.FormatByteSize(record.UnreachableBytes), record.UnreachableBytes)
+			unreachableTotal += record.UnreachableBytes
+		} else {
+			fmt.Fprintf(writer, spaceReclaimUnreachableUnknownTemplate, record.RepositoryPath, record.DeletedBranches)
+		}
+		if record.GarbageCollected {
+			fmt.Fprintf(writer, spaceReclaimGarbageCollectedTemplate, record.RepositoryPath, utils.FormatByteSize(record.ReclaimedBytes), record.ReclaimedBytes)
+			reclaimedTotal += record.ReclaimedBytes
+			collectedRepositories++
+		}
+	}
+
+	fmt.Fprintf(writer, spaceReclaimTotalTemplateConstant, utils.FormatByteSize(unreachableTotal), unreachableTotal, len(records))
+	if collectedRepositories > 0 {
+		fmt.Fprintf(writer, spaceReclaimGarbageCollectedTotalTemplate, utils.FormatByteSize(reclaimedTotal), reclaimedTotal, collectedRepositories)
+	}
+}
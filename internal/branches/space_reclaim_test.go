@@ -0,0 +1,118 @@
+package branches_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	branches "github.com/temirov/gix/internal/branches"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+)
+
+func TestServiceCleanupReclaimsSpace(testInstance *testing.T) {
+	const (
+		branchNameConstant  = "feature/reclaim"
+		countBeforeConstant = "count: 10\nsize: 40\nin-pack: 120\npacks: 1\nsize-pack: 960\nprune-packable: 0\ngarbage: 0\nsize-garbage: 0\n"
+		countAfterConstant  = "count: 0\nsize: 0\nin-pack: 100\npacks: 1\nsize-pack: 700\nprune-packable: 0\ngarbage: 0\nsize-garbage: 0\n"
+	)
+
+	revListArguments := []string{"rev-list", "--objects", "--disk-usage", remoteCommitPlaceholderConstant, "--not", "--all"}
+	countObjectsArguments := []string{"count-objects", "-v"}
+	garbageCollectArguments := []string{"gc", "--prune=now"}
+
+	testCases := []struct {
+		name              string
+		dryRun            bool
+		garbageCollect    bool
+		revListError      error
+		expectedRecords   []branches.SpaceReclaim
+		expectedGCInvoked bool
+	}{
+		{
+			name: "estimates_unreachable_objects",
+			expectedRecords: []branches.SpaceReclaim{
+				{RepositoryPath: testWorkingDirectoryConstant, DeletedBranches: 1, UnreachableBytes: 4096, UnreachableKnown: true},
+			},
+		},
+		{
+			name:           "garbage_collection_reports_reclaimed_bytes",
+			garbageCollect: true,
+			expectedRecords: []branches.SpaceReclaim{
+				{RepositoryPath: testWorkingDirectoryConstant, DeletedBranches: 1, UnreachableBytes: 4096, UnreachableKnown: true, GarbageCollected: true, ReclaimedBytes: 300 * 1024},
+			},
+			expectedGCInvoked: true,
+		},
+		{
+			name:         "estimate_failure_leaves_size_unknown",
+			revListError: errors.New("unknown option --disk-usage"),
+			expectedRecords: []branches.SpaceReclaim{
+				{RepositoryPath: testWorkingDirectoryConstant, DeletedBranches: 1},
+			},
+		},
+		{
+			name:           "dry_run_never_collects_garbage",
+			dryRun:         true,
+			garbageCollect: true,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(fmt.Sprintf(subtestNameTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
+			pullRequestJSON, encodingError := buildPullRequestJSON([]string{branchNameConstant})
+			require.NoError(testInstance, encodingError)
+
+			fakeExecutorInstance := execshelltest.NewExecutor()
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitListRemoteSubcommandConstant, gitHeadsFlagConstant, testRemoteNameConstant}, execshell.ExecutionResult{StandardOutput: buildRemoteOutput([]string{branchNameConstant})}, nil)
+			registerResponse(fakeExecutorInstance, githubCommandLabelConstant, []string{
+				githubPullRequestSubcommandConstant,
+				githubListSubcommandConstant,
+				githubStateFlagConstant,
+				githubClosedStateConstant,
+				githubJSONFlagConstant,
+				pullRequestJSONFieldNameConstant,
+				githubLimitFlagConstant,
+				strconv.Itoa(testPullRequestLimitConstant),
+			}, execshell.ExecutionResult{StandardOutput: pullRequestJSON}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitListLocalBranchesArguments, execshell.ExecutionResult{StandardOutput: fmt.Sprintf(localBranchRefLineTemplateConstant, branchNameConstant, remoteCommitPlaceholderConstant)}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, gitBranchDescriptionsArguments, execshell.ExecutionResult{}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitPushSubcommandConstant, testRemoteNameConstant, gitDeleteFlagConstant, branchNameConstant}, execshell.ExecutionResult{}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, []string{gitBranchSubcommandConstant, gitForceDeleteFlagConstant, branchNameConstant}, execshell.ExecutionResult{}, nil)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, revListArguments, execshell.ExecutionResult{StandardOutput: "4096\n"}, testCase.revListError)
+			registerResponse(fakeExecutorInstance, gitCommandLabelConstant, garbageCollectArguments, execshell.ExecutionResult{}, nil)
+
+			fakeExecutorInstance.OnGit(countObjectsArguments...).ReturnOutput(countBeforeConstant).ReturnOutput(countAfterConstant)
+
+			service, serviceError := branches.NewService(zap.NewNop(), fakeExecutorInstance, nil)
+			require.NoError(testInstance, serviceError)
+
+			spaceReclaim := &branches.SpaceReclaimTally{}
+			cleanupError := service.Cleanup(context.Background(), branches.CleanupOptions{
+				RemoteName:       testRemoteNameConstant,
+				PullRequestLimit: testPullRequestLimitConstant,
+				DryRun:           testCase.dryRun,
+				WorkingDirectory: testWorkingDirectoryConstant,
+				AssumeYes:        true,
+				SpaceReclaim:     spaceReclaim,
+				GarbageCollect:   testCase.garbageCollect,
+			})
+			require.NoError(testInstance, cleanupError)
+			require.Equal(testInstance, testCase.expectedRecords, spaceReclaim.Records())
+
+			garbageCollectKey := buildCommandKey(gitCommandLabelConstant, garbageCollectArguments)
+			garbageCollectInvoked := false
+			for _, executed := range fakeExecutorInstance.Executed() {
+				if executed.Key() == garbageCollectKey {
+					garbageCollectInvoked = true
+				}
+			}
+			require.Equal(testInstance, testCase.expectedGCInvoked, garbageCollectInvoked)
+		})
+	}
+}
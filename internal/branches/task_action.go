@@ -8,17 +8,21 @@ import (
 	"strings"
 
 	"github.com/temirov/gix/internal/branches/refresh"
+	"github.com/temirov/gix/internal/execshell"
 	"github.com/temirov/gix/internal/workflow"
 )
 
 const (
-	taskActionNameBranchCleanup  = "repo.branches.cleanup"
-	taskActionNameBranchRefresh  = "branch.refresh"
-	defaultBranchCleanupLimit    = 100
-	branchCleanupRemoteError     = "branch cleanup action requires 'remote'"
-	branchCleanupLimitParseError = "branch cleanup action requires numeric 'limit': %w"
-	branchRefreshBranchError     = "branch refresh action requires 'branch'"
-	branchRefreshMessageTemplate = "REFRESHED: %s (%s)\n"
+	taskActionNameBranchCleanup   = "repo.branches.cleanup"
+	taskActionNameBranchRefresh   = "branch.refresh"
+	defaultBranchCleanupLimit     = 100
+	branchCleanupRemoteError      = "branch cleanup action requires 'remote'"
+	branchCleanupLimitParseError  = "branch cleanup action requires numeric 'limit': %w"
+	branchRefreshBranchError      = "branch refresh action requires 'branch'"
+	branchRefreshMessageTemplate  = "REFRESHED: %s (%s)\n"
+	branchRefreshConflictTemplate = "REFRESH-CONFLICT: %s (%s) conflicts with upstream — manual merge required (%d conflicting file(s))\n"
+	branchRefreshFailedTemplate   = "REFRESH-FAILED: %s (%s): %s\n"
+	branchRefreshAbortTemplate    = "REFRESH-ABORT-FAILED: %s: %v; resolve or abort the pull manually\n"
 )
 
 func init() {
@@ -51,21 +55,67 @@ func handleBranchCleanupAction(ctx context.Context, environment *workflow.Enviro
 	if serviceError != nil {
 		return serviceError
 	}
+	service.WithBranchProtection(environment.BranchProtection)
 
 	assumeYes := false
 	if environment.PromptState != nil && environment.PromptState.IsAssumeYesEnabled() {
 		assumeYes = true
 	}
 
+	deletionBudget, _ := parameters["deletion_budget"].(*DeletionBudget)
+	spaceReclaim, _ := parameters["space_reclaim"].(*SpaceReclaimTally)
+	garbageCollect, garbageCollectError := boolValue(parameters["gc"])
+	if garbageCollectError != nil {
+		return garbageCollectError
+	}
+	respectAutoDelete, respectAutoDeleteError := boolValue(parameters[taskActionRespectAutoDeleteParameterConstant])
+	if respectAutoDeleteError != nil {
+		return respectAutoDeleteError
+	}
+	archiveRefs, archiveRefsError := boolValue(parameters[taskActionArchiveRefsParameterConstant])
+	if archiveRefsError != nil {
+		return archiveRefsError
+	}
+	archivedBranches, _ := parameters[taskActionArchivedBranchesParameterConstant].(*ArchivedBranchTally)
+	closedAges, _ := parameters[taskActionClosedAgesParameterConstant].(*ClosedAgeHistogram)
+	baseBranches, baseBranchesError := baseBranchesValue(parameters[taskActionBaseBranchesParameterConstant])
+	if baseBranchesError != nil {
+		return baseBranchesError
+	}
+	branchCandidates, _ := parameters[taskActionBranchCandidatesParameterConstant].(*BranchCandidateTally)
+	repositoryName := repositoryIdentifier(repository)
+	deleteBranchOnMerge, mergeQueueEnabled := repositoryAutoDeleteSettings(ctx, environment, repository, repositoryName)
+
 	options := CleanupOptions{
-		RemoteName:       remoteString,
-		PullRequestLimit: cleanupLimit,
-		DryRun:           environment.DryRun,
-		WorkingDirectory: repository.Path,
-		AssumeYes:        assumeYes,
+		RemoteName:            remoteString,
+		PullRequestLimit:      cleanupLimit,
+		DryRun:                environment.DryRun,
+		WorkingDirectory:      repository.Path,
+		AssumeYes:             assumeYes,
+		PullRequestTagPattern: strings.TrimSpace(stringify(parameters["delete_pr_tags"])),
+		Repository:            repositoryName,
+		DeletionBudget:        deletionBudget,
+		KeepMarker:            strings.TrimSpace(stringify(parameters["keep_marker"])),
+		SpaceReclaim:          spaceReclaim,
+		GarbageCollect:        garbageCollect,
+		DeleteBranchOnMerge:   deleteBranchOnMerge,
+		MergeQueueEnabled:     mergeQueueEnabled,
+		RespectAutoDelete:     respectAutoDelete,
+		ArchiveRefs:           archiveRefs,
+		ArchivedBranches:      archivedBranches,
+		BaseBranches:          baseBranches,
+		ClosedAges:            closedAges,
+		MergedInto:            strings.TrimSpace(stringify(parameters[taskActionMergedIntoParameterConstant])),
+		BranchCandidates:      branchCandidates,
 	}
 
-	return service.Cleanup(ctx, options)
+	deletedBranchCount := 0
+	options.DeletedBranchCount = &deletedBranchCount
+	if cleanupError := service.Cleanup(ctx, options); cleanupError != nil {
+		return cleanupError
+	}
+	workflow.PublishNumberOutput(ctx, workflow.StepOutputDeletedBranchCount, int64(deletedBranchCount))
+	return nil
 }
 
 func handleBranchRefreshAction(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, parameters map[string]any) error {
@@ -73,6 +123,15 @@ func handleBranchRefreshAction(ctx context.Context, environment *workflow.Enviro
 		return nil
 	}
 
+	fetchOnly, fetchOnlyError := boolValue(parameters["fetch_only"])
+	if fetchOnlyError != nil {
+		return fetchOnlyError
+	}
+	if fetchOnly {
+		failureTally, _ := parameters["failure_tally"].(*refresh.FetchFailureTally)
+		return fetchRepository(ctx, environment, repository, failureTally)
+	}
+
 	branchName := strings.TrimSpace(stringify(parameters["branch"]))
 	if len(branchName) == 0 {
 		return errors.New(branchRefreshBranchError)
@@ -113,9 +172,11 @@ func handleBranchRefreshAction(ctx context.Context, environment *workflow.Enviro
 		StashChanges:   stashChanges,
 		CommitChanges:  commitChanges,
 	})
+	refreshTally, _ := parameters["refresh_tally"].(*refresh.RefreshTally)
 	if refreshError != nil {
-		return refreshError
+		return recordRefreshError(ctx, environment, repository, branchName, refreshTally, refreshError)
 	}
+	refreshTally.AddRefreshed()
 
 	if environment.Output != nil {
 		fmt.Fprintf(environment.Output, branchRefreshMessageTemplate, repository.Path, branchName)
@@ -124,6 +185,59 @@ func handleBranchRefreshAction(ctx context.Context, environment *workflow.Enviro
 	return nil
 }
 
+// recordRefreshError reports a failed refresh and records it in the tally so the run continues with the next
+// repository. Without a tally, or when git is missing or the run was cancelled, the error is returned unchanged.
+func recordRefreshError(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, branchName string, refreshTally *refresh.RefreshTally, refreshError error) error {
+	if refreshTally == nil || execshell.IsExecutableNotFound(refreshError) || ctx.Err() != nil {
+		return refreshError
+	}
+
+	var conflictError refresh.PullConflictError
+	if errors.As(refreshError, &conflictError) {
+		if environment.Errors != nil {
+			fmt.Fprintf(environment.Errors, branchRefreshConflictTemplate, repository.Path, branchName, conflictError.ConflictingFiles)
+			if conflictError.AbortError != nil {
+				fmt.Fprintf(environment.Errors, branchRefreshAbortTemplate, repository.Path, conflictError.AbortError)
+			}
+		}
+		refreshTally.AddConflict(conflictError)
+		return nil
+	}
+
+	if environment.Errors != nil {
+		fmt.Fprintf(environment.Errors, branchRefreshFailedTemplate, repository.Path, branchName, refreshError.Error())
+	}
+	refreshTally.AddFailure(refresh.RefreshFailure{RepositoryPath: repository.Path, BranchName: branchName, Message: refreshError.Error()})
+	return nil
+}
+
+func fetchRepository(ctx context.Context, environment *workflow.Environment, repository *workflow.RepositoryState, failureTally *refresh.FetchFailureTally) error {
+	service, serviceError := refresh.NewService(refresh.Dependencies{
+		GitExecutor:       environment.GitExecutor,
+		RepositoryManager: environment.RepositoryManager,
+	})
+	if serviceError != nil {
+		return serviceError
+	}
+
+	return service.FetchAll(ctx, refresh.FetchAllOptions{
+		RepositoryPaths: []string{repository.Path},
+		DryRun:          environment.DryRun,
+		Output:          environment.Output,
+		Errors:          environment.Errors,
+		FailureTally:    failureTally,
+	})
+}
+
+func repositoryIdentifier(repository *workflow.RepositoryState) string {
+	for _, candidate := range []string{repository.Inspection.FinalOwnerRepo, repository.Inspection.CanonicalOwnerRepo, repository.Inspection.OriginOwnerRepo} {
+		if trimmed := strings.TrimSpace(candidate); len(trimmed) > 0 {
+			return trimmed
+		}
+	}
+	return ""
+}
+
 func stringify(value any) string {
 	switch typed := value.(type) {
 	case string:
@@ -257,6 +257,7 @@ func (generator Generator) runGit(ctx context.Context, repositoryPath string, ar
 	result, execError := generator.GitExecutor.ExecuteGit(ctx, execshell.CommandDetails{
 		Arguments:        arguments,
 		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
 	})
 	if execError != nil {
 		return "", execError
@@ -179,6 +179,7 @@ func (generator Generator) runGit(ctx context.Context, repositoryPath string, ar
 		execshell.CommandDetails{
 			Arguments:        arguments,
 			WorkingDirectory: repositoryPath,
+			Idempotent:       true,
 		},
 	)
 	if execError != nil {
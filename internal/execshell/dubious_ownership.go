@@ -0,0 +1,18 @@
+package execshell
+
+import (
+	"errors"
+	"strings"
+)
+
+const dubiousOwnershipSignatureConstant = "detected dubious ownership in repository"
+
+// IsDubiousOwnership reports whether the error chain holds a git failure caused by a repository owned by another
+// user. Git refuses every command in such a repository until its path is listed in safe.directory.
+func IsDubiousOwnership(err error) bool {
+	var failedError CommandFailedError
+	if !errors.As(err, &failedError) {
+		return false
+	}
+	return strings.Contains(failedError.Result.StandardError, dubiousOwnershipSignatureConstant)
+}
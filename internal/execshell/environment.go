@@ -0,0 +1,90 @@
+package execshell
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	commandEnvironmentContextKeyNameConstant = "commandEnvironment"
+	environmentReferenceMarkerConstant       = "${"
+)
+
+type commandEnvironmentContextKey string
+
+var environmentReferencePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// WithEnvironment attaches environment variables that executors add to every command run with the context.
+// Variables already attached to the parent context are kept unless environment overrides them. Values may reference
+// variables of the gix process as ${NAME}; references stay unresolved in the context and in command records and are
+// resolved only when a command starts.
+func WithEnvironment(parentContext context.Context, environment map[string]string) context.Context {
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	if len(environment) == 0 {
+		return parentContext
+	}
+	inheritedEnvironment, _ := EnvironmentFromContext(parentContext)
+	mergedEnvironment := make(map[string]string, len(inheritedEnvironment)+len(environment))
+	for environmentKey, environmentValue := range inheritedEnvironment {
+		mergedEnvironment[environmentKey] = environmentValue
+	}
+	for environmentKey, environmentValue := range environment {
+		mergedEnvironment[environmentKey] = environmentValue
+	}
+	return context.WithValue(parentContext, commandEnvironmentContextKey(commandEnvironmentContextKeyNameConstant), mergedEnvironment)
+}
+
+// EnvironmentFromContext returns a copy of the variables attached with WithEnvironment, if any.
+func EnvironmentFromContext(executionContext context.Context) (map[string]string, bool) {
+	if executionContext == nil {
+		return nil, false
+	}
+	environment, found := executionContext.Value(commandEnvironmentContextKey(commandEnvironmentContextKeyNameConstant)).(map[string]string)
+	if !found || len(environment) == 0 {
+		return nil, false
+	}
+	return cloneEnvironment(environment), true
+}
+
+// applyContextEnvironment adds the context variables to the command; variables set by the command itself win.
+func applyContextEnvironment(executionContext context.Context, command ShellCommand) ShellCommand {
+	contextEnvironment, found := EnvironmentFromContext(executionContext)
+	if !found {
+		return command
+	}
+	for environmentKey, environmentValue := range command.Details.EnvironmentVariables {
+		contextEnvironment[environmentKey] = environmentValue
+	}
+	command.Details.EnvironmentVariables = contextEnvironment
+	return command
+}
+
+// ResolveEnvironmentReferences replaces ${NAME} references in the values with variables returned by lookup.
+// Unknown variables resolve to an empty string, matching shell expansion.
+func ResolveEnvironmentReferences(environment map[string]string, lookup func(string) (string, bool)) map[string]string {
+	resolvedEnvironment := make(map[string]string, len(environment))
+	for environmentKey, environmentValue := range environment {
+		if !strings.Contains(environmentValue, environmentReferenceMarkerConstant) {
+			resolvedEnvironment[environmentKey] = environmentValue
+			continue
+		}
+		resolvedEnvironment[environmentKey] = environmentReferencePattern.ReplaceAllStringFunc(environmentValue, func(reference string) string {
+			referencedName := environmentReferencePattern.FindStringSubmatch(reference)[1]
+			referencedValue, _ := lookup(referencedName)
+			return referencedValue
+		})
+	}
+	return resolvedEnvironment
+}
+
+func resolveCommandEnvironment(command ShellCommand) ShellCommand {
+	if len(command.Details.EnvironmentVariables) == 0 {
+		return command
+	}
+	command.Details.EnvironmentVariables = ResolveEnvironmentReferences(command.Details.EnvironmentVariables, os.LookupEnv)
+	return command
+}
@@ -0,0 +1,85 @@
+package execshell_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+type recordingCommandObserver struct {
+	records []execshell.CommandRecord
+}
+
+func (observer *recordingCommandObserver) ObserveCommand(record execshell.CommandRecord) {
+	observer.records = append(observer.records, record)
+}
+
+func TestWithEnvironmentLayersValues(testInstance *testing.T) {
+	workflowContext := execshell.WithEnvironment(context.Background(), map[string]string{"HTTPS_PROXY": "http://proxy", "GIT_SSH_COMMAND": "ssh"})
+	stepContext := execshell.WithEnvironment(workflowContext, map[string]string{"GIT_SSH_COMMAND": "ssh -i key"})
+
+	environment, found := execshell.EnvironmentFromContext(stepContext)
+	require.True(testInstance, found)
+	require.Equal(testInstance, map[string]string{"HTTPS_PROXY": "http://proxy", "GIT_SSH_COMMAND": "ssh -i key"}, environment)
+
+	_, found = execshell.EnvironmentFromContext(context.Background())
+	require.False(testInstance, found)
+}
+
+func TestResolveEnvironmentReferences(testInstance *testing.T) {
+	lookup := func(name string) (string, bool) {
+		values := map[string]string{"CI_TOKEN": "secret", "PROXY_HOST": "proxy.internal"}
+		value, found := values[name]
+		return value, found
+	}
+
+	resolved := execshell.ResolveEnvironmentReferences(map[string]string{
+		"GH_TOKEN":    "${CI_TOKEN}",
+		"HTTPS_PROXY": "http://${PROXY_HOST}:3128",
+		"MISSING":     "${NOT_SET}",
+		"PLAIN":       "$HOME stays",
+	}, lookup)
+	require.Equal(testInstance, map[string]string{
+		"GH_TOKEN":    "secret",
+		"HTTPS_PROXY": "http://proxy.internal:3128",
+		"MISSING":     "",
+		"PLAIN":       "$HOME stays",
+	}, resolved)
+}
+
+func TestShellExecutorAppliesContextEnvironment(testInstance *testing.T) {
+	testInstance.Setenv("GIX_TEST_STEP_SECRET", "resolved-secret")
+
+	runner := &recordingCommandRunner{}
+	executor, creationError := execshell.NewShellExecutor(zap.NewNop(), runner, false)
+	require.NoError(testInstance, creationError)
+
+	observer := &recordingCommandObserver{}
+	executionContext := execshell.WithCommandObserver(context.Background(), observer)
+	executionContext = execshell.WithEnvironment(executionContext, map[string]string{
+		"GIT_SSH_COMMAND": "ssh -o BatchMode=yes",
+		"API_SECRET":      "${GIX_TEST_STEP_SECRET}",
+		"GIT_TERMINAL":    "step",
+	})
+
+	_, executionError := executor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:            []string{"ls-remote", "origin"},
+		EnvironmentVariables: map[string]string{"GIT_TERMINAL": "command"},
+		Idempotent:           true,
+	})
+	require.NoError(testInstance, executionError)
+
+	require.Len(testInstance, runner.recordedCommands, 1)
+	require.Equal(testInstance, map[string]string{
+		"GIT_SSH_COMMAND": "ssh -o BatchMode=yes",
+		"API_SECRET":      "resolved-secret",
+		"GIT_TERMINAL":    "command",
+	}, runner.recordedCommands[0].Details.EnvironmentVariables)
+
+	require.Len(testInstance, observer.records, 1)
+	require.Equal(testInstance, "${GIX_TEST_STEP_SECRET}", observer.records[0].Command.Details.EnvironmentVariables["API_SECRET"])
+}
@@ -0,0 +1,8 @@
+// Package execshelltest provides a deterministic fake for the execshell command executors. It satisfies the git, gh, and
+// curl executor interfaces consumed by the services, answers commands from registered expectations, and records every
+// command it receives so tests can assert on what ran.
+//
+// Expectations match a command by name, an argument matcher, and optionally a working directory. Each expectation holds
+// a sequence of steps; successive matching commands consume the steps in order and the final step repeats. Commands
+// that match no expectation fail with ErrUnexpectedCommand.
+package execshelltest
@@ -0,0 +1,299 @@
+package execshelltest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	commandKeySeparatorConstant        = " "
+	unexpectedCommandTemplateConstant  = "%w: %s"
+	directoryQualifierTemplateConstant = "%s (in %s)"
+)
+
+// ErrUnexpectedCommand reports a command that matched no registered expectation.
+var ErrUnexpectedCommand = errors.New("execshelltest: unexpected command")
+
+// ArgumentMatcher decides whether a command's arguments satisfy an expectation.
+type ArgumentMatcher func(arguments []string) bool
+
+// ExactArguments matches commands whose arguments equal the provided list.
+func ExactArguments(arguments ...string) ArgumentMatcher {
+	expected := slices.Clone(arguments)
+	return func(actual []string) bool {
+		return slices.Equal(expected, actual)
+	}
+}
+
+// ArgumentPrefix matches commands whose arguments start with the provided list.
+func ArgumentPrefix(arguments ...string) ArgumentMatcher {
+	expected := slices.Clone(arguments)
+	return func(actual []string) bool {
+		return len(actual) >= len(expected) && slices.Equal(expected, actual[:len(expected)])
+	}
+}
+
+// AnyArguments matches every command regardless of its arguments.
+func AnyArguments() ArgumentMatcher {
+	return func([]string) bool {
+		return true
+	}
+}
+
+// ResponseFunc computes a response from the received command details, for responses that depend on the input or need a
+// side effect such as creating a file.
+type ResponseFunc func(details execshell.CommandDetails) (execshell.ExecutionResult, error)
+
+// Command records a command received by the Executor.
+type Command struct {
+	Name    execshell.CommandName
+	Details execshell.CommandDetails
+}
+
+// Key renders the command as its name followed by its space-separated arguments, for example "git fetch origin".
+func (command Command) Key() string {
+	return CommandKey(command.Name, command.Details.Arguments...)
+}
+
+// CommandKey renders a command name and arguments in the form returned by Command.Key.
+func CommandKey(name execshell.CommandName, arguments ...string) string {
+	return strings.Join(append([]string{string(name)}, arguments...), commandKeySeparatorConstant)
+}
+
+// Expectation describes the responses returned for commands that match it. Configure it with Return, Fail, FailWith,
+// or Respond; each call appends one step to the sequence.
+type Expectation struct {
+	executor         *Executor
+	name             execshell.CommandName
+	matcher          ArgumentMatcher
+	workingDirectory string
+	directoryBound   bool
+	steps            []ResponseFunc
+	calls            int
+}
+
+// InDirectory restricts the expectation to commands run in the working directory. Directory-bound expectations take
+// precedence over unbound ones.
+func (expectation *Expectation) InDirectory(workingDirectory string) *Expectation {
+	expectation.executor.mutex.Lock()
+	defer expectation.executor.mutex.Unlock()
+	expectation.workingDirectory = workingDirectory
+	expectation.directoryBound = true
+	return expectation
+}
+
+// Return appends a step that succeeds with the result.
+func (expectation *Expectation) Return(result execshell.ExecutionResult) *Expectation {
+	return expectation.Respond(func(execshell.CommandDetails) (execshell.ExecutionResult, error) {
+		return result, nil
+	})
+}
+
+// ReturnOutput appends a step that succeeds with the standard output.
+func (expectation *Expectation) ReturnOutput(standardOutput string) *Expectation {
+	return expectation.Return(execshell.ExecutionResult{StandardOutput: standardOutput})
+}
+
+// Fail appends a step that returns the error with an empty result.
+func (expectation *Expectation) Fail(commandError error) *Expectation {
+	return expectation.Respond(func(execshell.CommandDetails) (execshell.ExecutionResult, error) {
+		return execshell.ExecutionResult{}, commandError
+	})
+}
+
+// FailWith appends a step that exits with the code and standard error, reported as an execshell.CommandFailedError
+// like the real executor does.
+func (expectation *Expectation) FailWith(exitCode int, standardError string) *Expectation {
+	name := expectation.name
+	return expectation.Respond(func(details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+		result := execshell.ExecutionResult{StandardError: standardError, ExitCode: exitCode}
+		return result, execshell.CommandFailedError{
+			Command: execshell.ShellCommand{Name: name, Details: details},
+			Result:  result,
+		}
+	})
+}
+
+// Respond appends a step computed by the function.
+func (expectation *Expectation) Respond(response ResponseFunc) *Expectation {
+	expectation.executor.mutex.Lock()
+	defer expectation.executor.mutex.Unlock()
+	expectation.steps = append(expectation.steps, response)
+	return expectation
+}
+
+// Calls returns how many commands matched the expectation.
+func (expectation *Expectation) Calls() int {
+	expectation.executor.mutex.Lock()
+	defer expectation.executor.mutex.Unlock()
+	return expectation.calls
+}
+
+func (expectation *Expectation) matches(name execshell.CommandName, details execshell.CommandDetails) bool {
+	if expectation.name != name {
+		return false
+	}
+	if expectation.directoryBound && expectation.workingDirectory != details.WorkingDirectory {
+		return false
+	}
+	return expectation.matcher(details.Arguments)
+}
+
+func (expectation *Expectation) nextStep() ResponseFunc {
+	expectation.calls++
+	if len(expectation.steps) == 0 {
+		return nil
+	}
+	stepIndex := min(expectation.calls, len(expectation.steps)) - 1
+	return expectation.steps[stepIndex]
+}
+
+// Executor is a fake command executor. The zero value is ready to use and answers every command with
+// ErrUnexpectedCommand until expectations are registered. It is safe for concurrent use.
+type Executor struct {
+	mutex        sync.Mutex
+	expectations []*Expectation
+	executed     []Command
+}
+
+// NewExecutor creates an Executor without expectations.
+func NewExecutor() *Executor {
+	return &Executor{}
+}
+
+// NewPermissiveExecutor creates an Executor that answers every git, gh, and curl command with an empty successful
+// result. Expectations registered afterwards take precedence, so tests only script the commands they care about.
+func NewPermissiveExecutor() *Executor {
+	executor := NewExecutor()
+	for _, name := range []execshell.CommandName{execshell.CommandGit, execshell.CommandGitHub, execshell.CommandCurl} {
+		executor.On(name, AnyArguments())
+	}
+	return executor
+}
+
+// On registers an expectation for commands with the name whose arguments satisfy the matcher. When several
+// expectations match, directory-bound ones win and, among equals, the most recently registered wins. An expectation
+// without steps succeeds with an empty result.
+func (executor *Executor) On(name execshell.CommandName, matcher ArgumentMatcher) *Expectation {
+	if matcher == nil {
+		matcher = AnyArguments()
+	}
+	expectation := &Expectation{executor: executor, name: name, matcher: matcher}
+	executor.mutex.Lock()
+	defer executor.mutex.Unlock()
+	executor.expectations = append(executor.expectations, expectation)
+	return expectation
+}
+
+// OnGit registers an expectation for the exact git arguments.
+func (executor *Executor) OnGit(arguments ...string) *Expectation {
+	return executor.On(execshell.CommandGit, ExactArguments(arguments...))
+}
+
+// OnGitHubCLI registers an expectation for the exact gh arguments.
+func (executor *Executor) OnGitHubCLI(arguments ...string) *Expectation {
+	return executor.On(execshell.CommandGitHub, ExactArguments(arguments...))
+}
+
+// ExecuteGit answers a git command.
+func (executor *Executor) ExecuteGit(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	return executor.Execute(executionContext, execshell.CommandGit, details)
+}
+
+// ExecuteGitHubCLI answers a gh command.
+func (executor *Executor) ExecuteGitHubCLI(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	return executor.Execute(executionContext, execshell.CommandGitHub, details)
+}
+
+// ExecuteCurl answers a curl command.
+func (executor *Executor) ExecuteCurl(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	return executor.Execute(executionContext, execshell.CommandCurl, details)
+}
+
+// ExecuteSSH answers an ssh command.
+func (executor *Executor) ExecuteSSH(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	return executor.Execute(executionContext, execshell.CommandSSH, details)
+}
+
+// Execute records the command and answers it from the matching expectation.
+func (executor *Executor) Execute(_ context.Context, name execshell.CommandName, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	details.Arguments = slices.Clone(details.Arguments)
+	command := Command{Name: name, Details: details}
+
+	executor.mutex.Lock()
+	executor.executed = append(executor.executed, command)
+	expectation := executor.findExpectation(name, details)
+	var step ResponseFunc
+	if expectation != nil {
+		step = expectation.nextStep()
+	}
+	executor.mutex.Unlock()
+
+	if expectation == nil {
+		description := command.Key()
+		if len(details.WorkingDirectory) > 0 {
+			description = fmt.Sprintf(directoryQualifierTemplateConstant, description, details.WorkingDirectory)
+		}
+		return execshell.ExecutionResult{}, fmt.Errorf(unexpectedCommandTemplateConstant, ErrUnexpectedCommand, description)
+	}
+	if step == nil {
+		return execshell.ExecutionResult{}, nil
+	}
+	return step(details)
+}
+
+func (executor *Executor) findExpectation(name execshell.CommandName, details execshell.CommandDetails) *Expectation {
+	var unboundMatch *Expectation
+	for expectationIndex := len(executor.expectations) - 1; expectationIndex >= 0; expectationIndex-- {
+		expectation := executor.expectations[expectationIndex]
+		if !expectation.matches(name, details) {
+			continue
+		}
+		if expectation.directoryBound {
+			return expectation
+		}
+		if unboundMatch == nil {
+			unboundMatch = expectation
+		}
+	}
+	return unboundMatch
+}
+
+// Executed returns copies of the received commands in order.
+func (executor *Executor) Executed() []Command {
+	executor.mutex.Lock()
+	defer executor.mutex.Unlock()
+	executedCommands := slices.Clone(executor.executed)
+	for commandIndex := range executedCommands {
+		executedCommands[commandIndex].Details.Arguments = slices.Clone(executedCommands[commandIndex].Details.Arguments)
+	}
+	return executedCommands
+}
+
+// ExecutedKeys returns the keys of the received commands in order.
+func (executor *Executor) ExecutedKeys() []string {
+	executedCommands := executor.Executed()
+	keys := make([]string, 0, len(executedCommands))
+	for _, command := range executedCommands {
+		keys = append(keys, command.Key())
+	}
+	return keys
+}
+
+// ExecutedArguments returns the arguments of the received commands with the name, in order.
+func (executor *Executor) ExecutedArguments(name execshell.CommandName) [][]string {
+	executedCommands := executor.Executed()
+	arguments := make([][]string, 0, len(executedCommands))
+	for _, command := range executedCommands {
+		if command.Name == name {
+			arguments = append(arguments, command.Details.Arguments)
+		}
+	}
+	return arguments
+}
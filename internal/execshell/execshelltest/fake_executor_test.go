@@ -0,0 +1,219 @@
+package execshelltest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+)
+
+const (
+	fakeExecutorTestRepositoryConstant = "/tmp/repository"
+	fakeExecutorTestOtherConstant      = "/tmp/other"
+)
+
+type fakeExecutorCall struct {
+	name             execshell.CommandName
+	arguments        []string
+	workingDirectory string
+}
+
+type fakeExecutorOutcome struct {
+	standardOutput string
+	exitCode       int
+	errorIs        error
+	errorText      string
+}
+
+func TestExecutorAnswersFromExpectations(testInstance *testing.T) {
+	injectedError := errors.New("network down")
+
+	testCases := []struct {
+		name             string
+		configure        func(*execshelltest.Executor)
+		calls            []fakeExecutorCall
+		expectedOutcomes []fakeExecutorOutcome
+	}{
+		{
+			name: "exact_arguments",
+			configure: func(executor *execshelltest.Executor) {
+				executor.OnGit("rev-parse", "HEAD").ReturnOutput("abc\n")
+			},
+			calls: []fakeExecutorCall{
+				{name: execshell.CommandGit, arguments: []string{"rev-parse", "HEAD"}},
+				{name: execshell.CommandGit, arguments: []string{"rev-parse", "HEAD", "--short"}},
+			},
+			expectedOutcomes: []fakeExecutorOutcome{
+				{standardOutput: "abc\n"},
+				{errorIs: execshelltest.ErrUnexpectedCommand, errorText: "execshelltest: unexpected command: git rev-parse HEAD --short"},
+			},
+		},
+		{
+			name: "command_name_distinguishes",
+			configure: func(executor *execshelltest.Executor) {
+				executor.On(execshell.CommandGitHub, execshelltest.AnyArguments()).ReturnOutput("gh")
+				executor.On(execshell.CommandCurl, execshelltest.ArgumentPrefix("-s")).ReturnOutput("curl")
+			},
+			calls: []fakeExecutorCall{
+				{name: execshell.CommandGitHub, arguments: []string{"repo", "view"}},
+				{name: execshell.CommandCurl, arguments: []string{"-s", "https://example.com"}},
+				{name: execshell.CommandGit, arguments: []string{"repo", "view"}, workingDirectory: fakeExecutorTestRepositoryConstant},
+			},
+			expectedOutcomes: []fakeExecutorOutcome{
+				{standardOutput: "gh"},
+				{standardOutput: "curl"},
+				{errorIs: execshelltest.ErrUnexpectedCommand, errorText: "execshelltest: unexpected command: git repo view (in /tmp/repository)"},
+			},
+		},
+		{
+			name: "directory_bound_expectation_wins",
+			configure: func(executor *execshelltest.Executor) {
+				executor.OnGit("status").InDirectory(fakeExecutorTestRepositoryConstant).ReturnOutput("dirty")
+				executor.OnGit("status").ReturnOutput("clean")
+			},
+			calls: []fakeExecutorCall{
+				{name: execshell.CommandGit, arguments: []string{"status"}, workingDirectory: fakeExecutorTestRepositoryConstant},
+				{name: execshell.CommandGit, arguments: []string{"status"}, workingDirectory: fakeExecutorTestOtherConstant},
+			},
+			expectedOutcomes: []fakeExecutorOutcome{
+				{standardOutput: "dirty"},
+				{standardOutput: "clean"},
+			},
+		},
+		{
+			name: "latest_registration_wins",
+			configure: func(executor *execshelltest.Executor) {
+				executor.OnGit("status").ReturnOutput("first")
+				executor.OnGit("status").ReturnOutput("second")
+			},
+			calls: []fakeExecutorCall{
+				{name: execshell.CommandGit, arguments: []string{"status"}},
+			},
+			expectedOutcomes: []fakeExecutorOutcome{
+				{standardOutput: "second"},
+			},
+		},
+		{
+			name: "sequence_repeats_last_step",
+			configure: func(executor *execshelltest.Executor) {
+				executor.OnGit("fetch").Fail(injectedError).ReturnOutput("fetched")
+			},
+			calls: []fakeExecutorCall{
+				{name: execshell.CommandGit, arguments: []string{"fetch"}},
+				{name: execshell.CommandGit, arguments: []string{"fetch"}},
+				{name: execshell.CommandGit, arguments: []string{"fetch"}},
+			},
+			expectedOutcomes: []fakeExecutorOutcome{
+				{errorIs: injectedError, errorText: "network down"},
+				{standardOutput: "fetched"},
+				{standardOutput: "fetched"},
+			},
+		},
+		{
+			name: "exit_code_failure",
+			configure: func(executor *execshelltest.Executor) {
+				executor.OnGit("push").FailWith(128, "rejected")
+			},
+			calls: []fakeExecutorCall{
+				{name: execshell.CommandGit, arguments: []string{"push"}},
+			},
+			expectedOutcomes: []fakeExecutorOutcome{
+				{exitCode: 128, errorText: "git command exited with code 128"},
+			},
+		},
+		{
+			name: "expectation_without_steps_succeeds",
+			configure: func(executor *execshelltest.Executor) {
+				executor.OnGit("gc")
+			},
+			calls: []fakeExecutorCall{
+				{name: execshell.CommandGit, arguments: []string{"gc"}},
+			},
+			expectedOutcomes: []fakeExecutorOutcome{
+				{},
+			},
+		},
+		{
+			name: "response_function_sees_details",
+			configure: func(executor *execshelltest.Executor) {
+				executor.On(execshell.CommandGit, execshelltest.ArgumentPrefix("config")).Respond(func(details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+					return execshell.ExecutionResult{StandardOutput: details.WorkingDirectory}, nil
+				})
+			},
+			calls: []fakeExecutorCall{
+				{name: execshell.CommandGit, arguments: []string{"config", "user.email"}, workingDirectory: fakeExecutorTestOtherConstant},
+			},
+			expectedOutcomes: []fakeExecutorOutcome{
+				{standardOutput: fakeExecutorTestOtherConstant},
+			},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := execshelltest.NewExecutor()
+			testCase.configure(executor)
+
+			expectedKeys := make([]string, 0, len(testCase.calls))
+			for callIndex, call := range testCase.calls {
+				expectedKeys = append(expectedKeys, execshelltest.CommandKey(call.name, call.arguments...))
+				details := execshell.CommandDetails{Arguments: call.arguments, WorkingDirectory: call.workingDirectory}
+				result, executionError := executor.Execute(context.Background(), call.name, details)
+
+				expectedOutcome := testCase.expectedOutcomes[callIndex]
+				require.Equal(subtest, expectedOutcome.standardOutput, result.StandardOutput)
+				require.Equal(subtest, expectedOutcome.exitCode, result.ExitCode)
+				if len(expectedOutcome.errorText) == 0 {
+					require.NoError(subtest, executionError)
+					continue
+				}
+				require.EqualError(subtest, executionError, expectedOutcome.errorText)
+				if expectedOutcome.errorIs != nil {
+					require.ErrorIs(subtest, executionError, expectedOutcome.errorIs)
+				}
+			}
+			require.Equal(subtest, expectedKeys, executor.ExecutedKeys())
+		})
+	}
+}
+
+func TestPermissiveExecutorDefersToLaterExpectations(testInstance *testing.T) {
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("rev-parse", "--git-dir").ReturnOutput(".git\n")
+
+	scriptedResult, scriptedError := executor.ExecuteGit(context.Background(), execshell.CommandDetails{Arguments: []string{"rev-parse", "--git-dir"}})
+	require.NoError(testInstance, scriptedError)
+	require.Equal(testInstance, ".git\n", scriptedResult.StandardOutput)
+
+	for _, name := range []execshell.CommandName{execshell.CommandGit, execshell.CommandGitHub, execshell.CommandCurl} {
+		result, executionError := executor.Execute(context.Background(), name, execshell.CommandDetails{Arguments: []string{"anything"}})
+		require.NoError(testInstance, executionError)
+		require.Equal(testInstance, execshell.ExecutionResult{}, result)
+	}
+	require.Len(testInstance, executor.Executed(), 4)
+}
+
+func TestExecutorRecordsCommandDetails(testInstance *testing.T) {
+	executor := &execshelltest.Executor{}
+	expectation := executor.On(execshell.CommandGit, execshelltest.AnyArguments())
+
+	arguments := []string{"fetch", "origin"}
+	_, executionError := executor.ExecuteGit(context.Background(), execshell.CommandDetails{Arguments: arguments, WorkingDirectory: fakeExecutorTestRepositoryConstant})
+	require.NoError(testInstance, executionError)
+	_, executionError = executor.ExecuteGitHubCLI(context.Background(), execshell.CommandDetails{Arguments: []string{"pr", "list"}})
+	require.ErrorIs(testInstance, executionError, execshelltest.ErrUnexpectedCommand)
+	arguments[1] = "upstream"
+
+	executed := executor.Executed()
+	require.Len(testInstance, executed, 2)
+	require.Equal(testInstance, execshell.CommandGit, executed[0].Name)
+	require.Equal(testInstance, []string{"fetch", "origin"}, executed[0].Details.Arguments)
+	require.Equal(testInstance, fakeExecutorTestRepositoryConstant, executed[0].Details.WorkingDirectory)
+	require.Equal(testInstance, [][]string{{"fetch", "origin"}}, executor.ExecutedArguments(execshell.CommandGit))
+	require.Equal(testInstance, 1, expectation.Calls())
+}
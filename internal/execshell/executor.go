@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -15,6 +17,7 @@ const (
 	gitCommandNameStringConstant              = "git"
 	githubCLICommandNameStringConstant        = "gh"
 	curlCommandNameStringConstant             = "curl"
+	sshCommandNameStringConstant              = "ssh"
 	loggerNotConfiguredMessageConstant        = "shell executor logger not configured"
 	commandRunnerNotConfiguredMessageConstant = "shell executor command runner not configured"
 	commandNameMissingMessageConstant         = "shell command name not provided"
@@ -37,6 +40,7 @@ const (
 	CommandGit    CommandName = CommandName(gitCommandNameStringConstant)
 	CommandGitHub CommandName = CommandName(githubCLICommandNameStringConstant)
 	CommandCurl   CommandName = CommandName(curlCommandNameStringConstant)
+	CommandSSH    CommandName = CommandName(sshCommandNameStringConstant)
 )
 
 // CommandDetails describes command invocation properties.
@@ -46,6 +50,26 @@ type CommandDetails struct {
 	EnvironmentVariables   map[string]string
 	StandardInput          []byte
 	GitHubTokenRequirement githubauth.TokenRequirement
+	// OutputCaptureLimit overrides the executor's per-stream capture limit in bytes; zero keeps the executor limit
+	// and UnlimitedOutputCapture keeps everything.
+	OutputCaptureLimit int
+	// Idempotent marks commands that are safe to run again after a transient failure, such as status, ls-remote, or
+	// rev-parse. Commands that change repositories or remote state (push, commit, branch deletion) leave it false and
+	// are never retried.
+	Idempotent bool
+	// ShellWrapped runs the command through a login shell (`sh -lc` by default) so PATH customizations from the user's
+	// profile, such as asdf or nvm shims, apply. Each argument is quoted, so shell operators are not interpreted. The
+	// built-in git, gh, curl, and ssh constructors refuse it.
+	ShellWrapped bool
+	// Shell names the login shell used when ShellWrapped is set; empty selects sh.
+	Shell string
+	// OutputWriter and ErrorWriter receive standard output and standard error as the command produces them, in
+	// addition to the captured copies in ExecutionResult.
+	OutputWriter io.Writer
+	ErrorWriter  io.Writer
+	// LocalizedOutput keeps the user's locale for git and gh. By default they run with LC_ALL=C and LANG=C so their
+	// messages can be parsed reliably.
+	LocalizedOutput bool
 }
 
 // ShellCommand represents a fully qualified command invocation.
@@ -59,6 +83,8 @@ type ExecutionResult struct {
 	StandardOutput string
 	StandardError  string
 	ExitCode       int
+	// Truncated reports that stdout or stderr exceeded the capture limit and ends with a "[truncated N bytes]" marker.
+	Truncated bool
 }
 
 // CommandRunner executes shell commands.
@@ -72,6 +98,8 @@ type ShellExecutor struct {
 	logger               *zap.Logger
 	humanReadableLogging bool
 	messageFormatter     CommandMessageFormatter
+	outputCaptureLimit   int
+	retryPolicy          RetryPolicy
 }
 
 var (
@@ -114,6 +142,30 @@ func (executionError CommandExecutionError) Unwrap() error {
 	return executionError.Cause
 }
 
+// ExecutableNotFoundError indicates the requested executable could not be located on PATH.
+type ExecutableNotFoundError struct {
+	Command CommandName
+	Cause   error
+}
+
+const executableNotFoundErrorMessageTemplateConstant = "the '%s' executable was not found on PATH"
+
+// Error describes the missing executable.
+func (notFoundError ExecutableNotFoundError) Error() string {
+	return fmt.Sprintf(executableNotFoundErrorMessageTemplateConstant, notFoundError.Command)
+}
+
+// Unwrap exposes the underlying lookup error.
+func (notFoundError ExecutableNotFoundError) Unwrap() error {
+	return notFoundError.Cause
+}
+
+// IsExecutableNotFound reports whether the error chain contains an ExecutableNotFoundError.
+func IsExecutableNotFound(err error) bool {
+	var notFoundError ExecutableNotFoundError
+	return errors.As(err, &notFoundError)
+}
+
 // NewShellExecutor builds an executor for the provided runner and logger.
 func NewShellExecutor(logger *zap.Logger, commandRunner CommandRunner, humanReadableLogging bool) (*ShellExecutor, error) {
 	if logger == nil {
@@ -127,15 +179,25 @@ func NewShellExecutor(logger *zap.Logger, commandRunner CommandRunner, humanRead
 		logger:               logger,
 		humanReadableLogging: humanReadableLogging,
 		messageFormatter:     CommandMessageFormatter{},
+		outputCaptureLimit:   DefaultOutputCaptureLimit,
 	}, nil
 }
 
+// SetOutputCaptureLimit sets how many bytes of stdout and stderr are kept per command; UnlimitedOutputCapture disables truncation
+// and zero restores DefaultOutputCaptureLimit. A limit attached with WithOutputCaptureLimit replaces it, and commands may
+// still override either through CommandDetails.OutputCaptureLimit.
+func (executor *ShellExecutor) SetOutputCaptureLimit(byteLimit int) {
+	executor.outputCaptureLimit = byteLimit
+}
+
 // Execute runs the provided shell command and logs lifecycle events.
 func (executor *ShellExecutor) Execute(executionContext context.Context, command ShellCommand) (ExecutionResult, error) {
 	if len(command.Name) == 0 {
 		return ExecutionResult{}, ErrCommandNameMissing
 	}
 
+	command = applyContextEnvironment(executionContext, command)
+
 	var preparationError error
 	command, preparationError = executor.prepareCommand(command)
 	if preparationError != nil {
@@ -154,7 +216,36 @@ func (executor *ShellExecutor) Execute(executionContext context.Context, command
 		)
 	}
 
-	executionResult, runnerError := executor.commandRunner.Run(executionContext, command)
+	outputCaptureLimit := resolveOutputCaptureLimit(command.Details.OutputCaptureLimit, outputCaptureLimitFromContext(executionContext, executor.outputCaptureLimit))
+	command.Details.OutputCaptureLimit = outputCaptureLimit
+
+	var executionResult ExecutionResult
+	var runnerError error
+	for attempt := 1; ; attempt++ {
+		startedAt := time.Now()
+		executionResult, runnerError = executor.commandRunner.Run(executionContext, wrapInLoginShell(resolveCommandEnvironment(command)))
+		executionResult = enforceOutputCaptureLimit(executionResult, outputCaptureLimit)
+		notifyCommandObserver(executionContext, CommandRecord{
+			Command:       command,
+			StartedAt:     startedAt,
+			Duration:      time.Since(startedAt),
+			ExitCode:      executionResult.ExitCode,
+			StandardError: executionResult.StandardError,
+			Failure:       runnerError,
+		})
+		if runnerError != nil || executionResult.ExitCode == 0 || !executor.retryPolicy.shouldRetry(command, executionResult, attempt) {
+			break
+		}
+		executor.logger.Warn(commandRetryMessageConstant,
+			zap.String(commandNameFieldNameConstant, string(command.Name)),
+			zap.Strings(commandArgumentsFieldNameConstant, command.Details.Arguments),
+			zap.Int(attemptFieldNameConstant, attempt),
+			zap.String(standardErrorFieldNameConstant, executionResult.StandardError),
+		)
+		if waitError := waitForRetry(executionContext, executor.retryPolicy.Delay); waitError != nil {
+			break
+		}
+	}
 	if runnerError != nil {
 		if executor.humanReadableLogging {
 			executor.logger.Error(executor.messageFormatter.BuildExecutionFailureMessage(command, runnerError))
@@ -164,6 +255,10 @@ func (executor *ShellExecutor) Execute(executionContext context.Context, command
 				zap.Error(runnerError),
 			)
 		}
+		var notFoundError ExecutableNotFoundError
+		if errors.As(runnerError, &notFoundError) {
+			return ExecutionResult{}, notFoundError
+		}
 		return ExecutionResult{}, CommandExecutionError{Command: command, Cause: runnerError}
 	}
 
@@ -193,19 +288,44 @@ func (executor *ShellExecutor) Execute(executionContext context.Context, command
 
 // ExecuteGit runs the git executable with the provided details.
 func (executor *ShellExecutor) ExecuteGit(executionContext context.Context, details CommandDetails) (ExecutionResult, error) {
+	if details.ShellWrapped {
+		return ExecutionResult{}, ShellWrappingRefusedError{Command: CommandGit}
+	}
 	return executor.Execute(executionContext, ShellCommand{Name: CommandGit, Details: details})
 }
 
 // ExecuteGitHubCLI runs the GitHub CLI executable with the provided details.
 func (executor *ShellExecutor) ExecuteGitHubCLI(executionContext context.Context, details CommandDetails) (ExecutionResult, error) {
+	if details.ShellWrapped {
+		return ExecutionResult{}, ShellWrappingRefusedError{Command: CommandGitHub}
+	}
 	return executor.Execute(executionContext, ShellCommand{Name: CommandGitHub, Details: details})
 }
 
 // ExecuteCurl runs the curl executable with the provided details.
 func (executor *ShellExecutor) ExecuteCurl(executionContext context.Context, details CommandDetails) (ExecutionResult, error) {
+	if details.ShellWrapped {
+		return ExecutionResult{}, ShellWrappingRefusedError{Command: CommandCurl}
+	}
 	return executor.Execute(executionContext, ShellCommand{Name: CommandCurl, Details: details})
 }
 
+// ExecuteSSH runs the ssh executable with the provided details.
+func (executor *ShellExecutor) ExecuteSSH(executionContext context.Context, details CommandDetails) (ExecutionResult, error) {
+	if details.ShellWrapped {
+		return ExecutionResult{}, ShellWrappingRefusedError{Command: CommandSSH}
+	}
+	return executor.Execute(executionContext, ShellCommand{Name: CommandSSH, Details: details})
+}
+
+func notifyCommandObserver(executionContext context.Context, record CommandRecord) {
+	observer, observerFound := CommandObserverFromContext(executionContext)
+	if !observerFound {
+		return
+	}
+	observer.ObserveCommand(record)
+}
+
 func (executor *ShellExecutor) prepareCommand(command ShellCommand) (ShellCommand, error) {
 	if command.Name != CommandGitHub {
 		return command, nil
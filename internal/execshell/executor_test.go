@@ -449,3 +449,45 @@ func TestShellExecutorWrappersSetCommandNames(testInstance *testing.T) {
 		})
 	}
 }
+
+func TestShellExecutorSurfacesExecutableNotFound(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		runner          execshell.CommandRunner
+		commandName     execshell.CommandName
+		expectedMessage string
+	}{
+		{
+			name:            "recording_runner_not_found",
+			runner:          &recordingCommandRunner{executionError: execshell.ExecutableNotFoundError{Command: execshell.CommandGitHub, Cause: errors.New("lookup failure")}},
+			commandName:     execshell.CommandGitHub,
+			expectedMessage: "the 'gh' executable was not found on PATH",
+		},
+		{
+			name:            "os_runner_missing_executable",
+			runner:          execshell.NewOSCommandRunner(),
+			commandName:     execshell.CommandName("gix-missing-executable-for-tests"),
+			expectedMessage: "the 'gix-missing-executable-for-tests' executable was not found on PATH",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor, creationError := execshell.NewShellExecutor(zap.NewNop(), testCase.runner, false)
+			require.NoError(subtest, creationError)
+
+			command := execshell.ShellCommand{
+				Name:    testCase.commandName,
+				Details: execshell.CommandDetails{Arguments: []string{testCommandArgumentConstant}, GitHubTokenRequirement: githubauth.TokenOptional},
+			}
+			_, executionError := executor.Execute(context.Background(), command)
+			require.Error(subtest, executionError)
+
+			var notFoundError execshell.ExecutableNotFoundError
+			require.ErrorAs(subtest, executionError, &notFoundError)
+			require.Equal(subtest, testCase.commandName, notFoundError.Command)
+			require.Equal(subtest, testCase.expectedMessage, executionError.Error())
+		})
+	}
+}
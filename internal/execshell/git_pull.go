@@ -0,0 +1,86 @@
+package execshell
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	gitPullFastForwardOnlyFlag  = "--ff-only"
+	gitPullRebaseFlag           = "--rebase"
+	gitPullNoRebaseFlag         = "--no-rebase"
+	gitPullFastForwardOnlyLabel = "fast-forward only"
+)
+
+// GitPullStrategy selects how git pull integrates upstream changes.
+type GitPullStrategy string
+
+// Supported pull strategies.
+const (
+	// GitPullStrategyMerge merges upstream changes, creating a merge commit when histories diverged.
+	GitPullStrategyMerge GitPullStrategy = "merge"
+	// GitPullStrategyRebase replays local commits on top of the upstream branch.
+	GitPullStrategyRebase GitPullStrategy = "rebase"
+	// GitPullStrategyFastForwardOnly refuses to pull when the branch cannot be fast-forwarded.
+	GitPullStrategyFastForwardOnly GitPullStrategy = "ff-only"
+)
+
+var gitPullStrategyFlags = map[GitPullStrategy]string{
+	GitPullStrategyMerge:           gitPullNoRebaseFlag,
+	GitPullStrategyRebase:          gitPullRebaseFlag,
+	GitPullStrategyFastForwardOnly: gitPullFastForwardOnlyFlag,
+}
+
+// GitCommandExecutor runs git commands.
+type GitCommandExecutor interface {
+	ExecuteGit(executionContext context.Context, details CommandDetails) (ExecutionResult, error)
+}
+
+// GitPullOptions describes a git pull invocation.
+// RemoteName and BranchName are optional; without them git pulls from the configured upstream.
+// BranchName is ignored when RemoteName is empty because git requires a remote before a refspec.
+type GitPullOptions struct {
+	WorkingDirectory     string
+	RemoteName           string
+	BranchName           string
+	Strategy             GitPullStrategy
+	EnvironmentVariables map[string]string
+}
+
+// NewGitPullCommandDetails builds the command details for git pull with the strategy flag followed by the remote and branch.
+func NewGitPullCommandDetails(options GitPullOptions) CommandDetails {
+	arguments := []string{gitPullSubcommandNameConstant}
+	if strategyFlag, known := gitPullStrategyFlags[options.Strategy]; known {
+		arguments = append(arguments, strategyFlag)
+	}
+	if trimmedRemote := strings.TrimSpace(options.RemoteName); len(trimmedRemote) > 0 {
+		arguments = append(arguments, trimmedRemote)
+		if trimmedBranch := strings.TrimSpace(options.BranchName); len(trimmedBranch) > 0 {
+			arguments = append(arguments, trimmedBranch)
+		}
+	}
+	return CommandDetails{
+		Arguments:            arguments,
+		WorkingDirectory:     options.WorkingDirectory,
+		EnvironmentVariables: options.EnvironmentVariables,
+		Idempotent:           false,
+	}
+}
+
+// ExecutePull runs git pull described by the options through the executor.
+func ExecutePull(executionContext context.Context, executor GitCommandExecutor, options GitPullOptions) (ExecutionResult, error) {
+	return executor.ExecuteGit(executionContext, NewGitPullCommandDetails(options))
+}
+
+func describeGitPullStrategy(arguments []string) string {
+	switch {
+	case containsArgument(arguments, gitPullFastForwardOnlyFlag):
+		return gitPullFastForwardOnlyLabel
+	case containsArgument(arguments, gitPullRebaseFlag):
+		return string(GitPullStrategyRebase)
+	case containsArgument(arguments, gitPullNoRebaseFlag):
+		return string(GitPullStrategyMerge)
+	default:
+		return ""
+	}
+}
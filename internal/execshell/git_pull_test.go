@@ -0,0 +1,130 @@
+package execshell
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingGitCommandExecutor struct {
+	recordedDetails []CommandDetails
+}
+
+func (executor *recordingGitCommandExecutor) ExecuteGit(_ context.Context, details CommandDetails) (ExecutionResult, error) {
+	executor.recordedDetails = append(executor.recordedDetails, details)
+	return ExecutionResult{}, nil
+}
+
+func TestNewGitPullCommandDetails(t *testing.T) {
+	testCases := []struct {
+		name              string
+		options           GitPullOptions
+		expectedArguments []string
+	}{
+		{
+			name:              "fast_forward_with_remote_and_branch",
+			options:           GitPullOptions{RemoteName: "origin", BranchName: "main", Strategy: GitPullStrategyFastForwardOnly},
+			expectedArguments: []string{"pull", "--ff-only", "origin", "main"},
+		},
+		{
+			name:              "rebase_from_upstream",
+			options:           GitPullOptions{Strategy: GitPullStrategyRebase},
+			expectedArguments: []string{"pull", "--rebase"},
+		},
+		{
+			name:              "merge_from_remote",
+			options:           GitPullOptions{RemoteName: " upstream ", Strategy: GitPullStrategyMerge},
+			expectedArguments: []string{"pull", "--no-rebase", "upstream"},
+		},
+		{
+			name:              "branch_without_remote_ignored",
+			options:           GitPullOptions{BranchName: "main"},
+			expectedArguments: []string{"pull"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(subtest *testing.T) {
+			details := NewGitPullCommandDetails(testCase.options)
+			require.Equal(subtest, testCase.expectedArguments, details.Arguments)
+		})
+	}
+}
+
+func TestExecutePullPassesDetailsToExecutor(t *testing.T) {
+	executor := &recordingGitCommandExecutor{}
+	_, pullError := ExecutePull(context.Background(), executor, GitPullOptions{
+		WorkingDirectory:     "/workspace/repo",
+		RemoteName:           "origin",
+		BranchName:           "main",
+		Strategy:             GitPullStrategyFastForwardOnly,
+		EnvironmentVariables: map[string]string{"GIT_TERMINAL_PROMPT": "0"},
+	})
+	require.NoError(t, pullError)
+	require.Equal(t, []CommandDetails{{
+		Arguments:            []string{"pull", "--ff-only", "origin", "main"},
+		WorkingDirectory:     "/workspace/repo",
+		EnvironmentVariables: map[string]string{"GIT_TERMINAL_PROMPT": "0"},
+	}}, executor.recordedDetails)
+}
+
+func TestGitPullMessages(t *testing.T) {
+	formatter := CommandMessageFormatter{}
+	testCases := []struct {
+		name            string
+		arguments       []string
+		result          ExecutionResult
+		failure         error
+		stage           messageStage
+		expectedMessage string
+	}{
+		{
+			name:            "start_fast_forward",
+			arguments:       []string{"pull", "--ff-only", "origin", "main"},
+			stage:           messageStageStart,
+			expectedMessage: "Pulling main from origin in /workspace/repo (fast-forward only)",
+		},
+		{
+			name:            "success_rebase",
+			arguments:       []string{"pull", "--rebase", "origin", "main"},
+			stage:           messageStageSuccess,
+			expectedMessage: "Pulled main from origin in /workspace/repo (rebase)",
+		},
+		{
+			name:            "start_upstream_merge",
+			arguments:       []string{"pull", "--no-rebase"},
+			stage:           messageStageStart,
+			expectedMessage: "Pulling upstream changes in /workspace/repo (merge)",
+		},
+		{
+			name:            "start_remote_without_branch",
+			arguments:       []string{"pull", "upstream"},
+			stage:           messageStageStart,
+			expectedMessage: "Pulling the current branch from upstream in /workspace/repo",
+		},
+		{
+			name:            "failure_with_stderr",
+			arguments:       []string{"pull", "--ff-only", "origin", "main"},
+			result:          ExecutionResult{ExitCode: 128, StandardError: "fatal: Not possible to fast-forward, aborting."},
+			stage:           messageStageFailure,
+			expectedMessage: "Failed to pull main from origin in /workspace/repo (exit code 128: fatal: Not possible to fast-forward, aborting.)",
+		},
+		{
+			name:            "execution_failure_upstream",
+			arguments:       []string{"pull", "--ff-only"},
+			failure:         errors.New("context canceled"),
+			stage:           messageStageExecutionFailure,
+			expectedMessage: "Unable to pull upstream changes in /workspace/repo: context canceled",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(subtest *testing.T) {
+			command := ShellCommand{Name: CommandGit, Details: CommandDetails{Arguments: testCase.arguments, WorkingDirectory: "/workspace/repo"}}
+			message := formatter.buildMessage(command, testCase.result, testCase.failure, testCase.stage)
+			require.Equal(subtest, testCase.expectedMessage, message)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package execshell
+
+const (
+	localeAllEnvironmentVariableConstant  = "LC_ALL"
+	localeLangEnvironmentVariableConstant = "LANG"
+	pinnedLocaleValueConstant             = "C"
+)
+
+// pinCommandLocale sets LC_ALL=C and LANG=C for git and gh so their messages stay in English and stderr patterns used
+// by retry and conflict detection keep matching on machines with other locales. Commands that request localized
+// output, or whose environment already names LC_ALL or LANG (for example through WithEnvironment), run unchanged.
+func pinCommandLocale(command ShellCommand) ShellCommand {
+	if command.Details.LocalizedOutput || (command.Name != CommandGit && command.Name != CommandGitHub) {
+		return command
+	}
+	if _, localeSet := command.Details.EnvironmentVariables[localeAllEnvironmentVariableConstant]; localeSet {
+		return command
+	}
+	if _, langSet := command.Details.EnvironmentVariables[localeLangEnvironmentVariableConstant]; langSet {
+		return command
+	}
+
+	pinnedEnvironment := make(map[string]string, len(command.Details.EnvironmentVariables)+2)
+	for environmentKey, environmentValue := range command.Details.EnvironmentVariables {
+		pinnedEnvironment[environmentKey] = environmentValue
+	}
+	pinnedEnvironment[localeAllEnvironmentVariableConstant] = pinnedLocaleValueConstant
+	pinnedEnvironment[localeLangEnvironmentVariableConstant] = pinnedLocaleValueConstant
+	command.Details.EnvironmentVariables = pinnedEnvironment
+	return command
+}
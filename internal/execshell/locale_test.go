@@ -0,0 +1,90 @@
+package execshell_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const environmentPrintingScriptConstant = "#!/bin/sh\nenv\n"
+
+func TestOSCommandRunnerPinsLocale(testInstance *testing.T) {
+	if runtime.GOOS == "windows" {
+		testInstance.Skip("fake executables are shell scripts")
+	}
+
+	testCases := []struct {
+		name             string
+		command          execshell.ShellCommand
+		expectedLCAll    string
+		expectedLanguage string
+	}{
+		{
+			name:             "git_pinned_by_default",
+			command:          execshell.ShellCommand{Name: execshell.CommandGit},
+			expectedLCAll:    "C",
+			expectedLanguage: "C",
+		},
+		{
+			name:             "gh_pinned_by_default",
+			command:          execshell.ShellCommand{Name: execshell.CommandGitHub, Details: execshell.CommandDetails{EnvironmentVariables: map[string]string{"GH_PROMPT_DISABLED": "1"}}},
+			expectedLCAll:    "C",
+			expectedLanguage: "C",
+		},
+		{
+			name:             "curl_keeps_user_locale",
+			command:          execshell.ShellCommand{Name: execshell.CommandCurl},
+			expectedLCAll:    "de_DE.UTF-8",
+			expectedLanguage: "de_DE.UTF-8",
+		},
+		{
+			name:             "localized_output_opts_out",
+			command:          execshell.ShellCommand{Name: execshell.CommandGit, Details: execshell.CommandDetails{LocalizedOutput: true}},
+			expectedLCAll:    "de_DE.UTF-8",
+			expectedLanguage: "de_DE.UTF-8",
+		},
+		{
+			name:             "injected_locale_overrides_pin",
+			command:          execshell.ShellCommand{Name: execshell.CommandGit, Details: execshell.CommandDetails{EnvironmentVariables: map[string]string{"LANG": "fr_FR.UTF-8"}}},
+			expectedLCAll:    "de_DE.UTF-8",
+			expectedLanguage: "fr_FR.UTF-8",
+		},
+	}
+
+	executableDirectory := testInstance.TempDir()
+	for _, executableName := range []execshell.CommandName{execshell.CommandGit, execshell.CommandGitHub, execshell.CommandCurl} {
+		require.NoError(testInstance, os.WriteFile(filepath.Join(executableDirectory, string(executableName)), []byte(environmentPrintingScriptConstant), 0o755))
+	}
+	testInstance.Setenv("PATH", executableDirectory+string(os.PathListSeparator)+os.Getenv("PATH"))
+	testInstance.Setenv("LC_ALL", "de_DE.UTF-8")
+	testInstance.Setenv("LANG", "de_DE.UTF-8")
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			result, runError := execshell.NewOSCommandRunner().Run(context.Background(), testCase.command)
+			require.NoError(testInstance, runError)
+
+			environment := parseEnvironmentOutput(result.StandardOutput)
+			require.Equal(testInstance, testCase.expectedLCAll, environment["LC_ALL"])
+			require.Equal(testInstance, testCase.expectedLanguage, environment["LANG"])
+		})
+	}
+}
+
+func parseEnvironmentOutput(output string) map[string]string {
+	environment := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		name, value, found := strings.Cut(line, "=")
+		if found {
+			environment[name] = value
+		}
+	}
+	return environment
+}
@@ -47,6 +47,7 @@ const (
 	gitForceFlagConstant                  = "--force"
 	gitFetchSubcommandNameConstant        = "fetch"
 	gitPushSubcommandNameConstant         = "push"
+	gitPullSubcommandNameConstant         = "pull"
 	gitLSRemoteSubcommandNameConstant     = "ls-remote"
 	gitSymrefFlagConstant                 = "--symref"
 	gitHeadsFlagConstant                  = "--heads"
@@ -113,6 +114,16 @@ const (
 	gitFetchExecutionFailureTemplateConstant                        = "Unable to fetch %s from %s in %s: %s"
 	gitFetchWithoutRefsExecutionFailureTemplateConstant             = "Unable to fetch from %s in %s: %s"
 	gitFetchAllRemotesLabelConstant                                 = "all remotes"
+	gitPullStartTemplateConstant                                    = "Pulling %s from %s in %s%s"
+	gitPullFromUpstreamStartTemplateConstant                        = "Pulling upstream changes in %s%s"
+	gitPullSuccessTemplateConstant                                  = "Pulled %s from %s in %s%s"
+	gitPullFromUpstreamSuccessTemplateConstant                      = "Pulled upstream changes in %s%s"
+	gitPullFailureTemplateConstant                                  = "Failed to pull %s from %s in %s (exit code %d%s)"
+	gitPullFromUpstreamFailureTemplateConstant                      = "Failed to pull upstream changes in %s (exit code %d%s)"
+	gitPullExecutionFailureTemplateConstant                         = "Unable to pull %s from %s in %s: %s"
+	gitPullFromUpstreamExecutionFailureTemplateConstant             = "Unable to pull upstream changes in %s: %s"
+	gitPullStrategySuffixTemplateConstant                           = " (%s)"
+	gitPullCurrentBranchLabelConstant                               = "the current branch"
 	gitPushStartTemplateConstant                                    = "Pushing %s to %s from %s"
 	gitPushSuccessTemplateConstant                                  = "Pushed %s to %s from %s"
 	gitPushFailureTemplateConstant                                  = "Failed to push %s to %s from %s (exit code %d%s)"
@@ -247,6 +258,9 @@ func (formatter CommandMessageFormatter) isGitHubRepoViewCommand(arguments []str
 }
 
 func (formatter CommandMessageFormatter) buildMessage(command ShellCommand, result ExecutionResult, failure error, stage messageStage) string {
+	if command.Details.ShellWrapped {
+		return formatter.buildGenericMessage(command, result, failure, stage)
+	}
 	switch command.Name {
 	case CommandGit:
 		return formatter.describeGitMessage(command, result, failure, stage)
@@ -280,6 +294,8 @@ func (formatter CommandMessageFormatter) describeGitMessage(command ShellCommand
 		return formatter.describeGitFetchMessage(command, result, failure, stage)
 	case gitPushSubcommandNameConstant:
 		return formatter.describeGitPushMessage(command, result, failure, stage)
+	case gitPullSubcommandNameConstant:
+		return formatter.describeGitPullMessage(command, result, failure, stage)
 	case gitLSRemoteSubcommandNameConstant:
 		return formatter.describeGitLSRemoteMessage(command, result, failure, stage)
 	case gitAddSubcommandNameConstant:
@@ -523,6 +539,46 @@ func (formatter CommandMessageFormatter) describeGitFetchMessage(command ShellCo
 	}
 }
 
+func (formatter CommandMessageFormatter) describeGitPullMessage(command ShellCommand, result ExecutionResult, failure error, stage messageStage) string {
+	workingDirectory := formatter.describeWorkingDirectory(command)
+	arguments := command.Details.Arguments[1:]
+	remoteName, references := formatter.extractRemoteAndReferences(arguments)
+	trimmedRemote := strings.TrimSpace(remoteName)
+	joinedReferences := formatter.joinReferences(references)
+	if len(joinedReferences) == 0 {
+		joinedReferences = gitPullCurrentBranchLabelConstant
+	}
+	strategySuffix := ""
+	if strategyLabel := describeGitPullStrategy(arguments); len(strategyLabel) > 0 {
+		strategySuffix = fmt.Sprintf(gitPullStrategySuffixTemplateConstant, strategyLabel)
+	}
+
+	switch stage {
+	case messageStageStart:
+		if len(trimmedRemote) > 0 {
+			return fmt.Sprintf(gitPullStartTemplateConstant, joinedReferences, trimmedRemote, workingDirectory, strategySuffix)
+		}
+		return fmt.Sprintf(gitPullFromUpstreamStartTemplateConstant, workingDirectory, strategySuffix)
+	case messageStageSuccess:
+		if len(trimmedRemote) > 0 {
+			return fmt.Sprintf(gitPullSuccessTemplateConstant, joinedReferences, trimmedRemote, workingDirectory, strategySuffix)
+		}
+		return fmt.Sprintf(gitPullFromUpstreamSuccessTemplateConstant, workingDirectory, strategySuffix)
+	case messageStageFailure:
+		if len(trimmedRemote) > 0 {
+			return fmt.Sprintf(gitPullFailureTemplateConstant, joinedReferences, trimmedRemote, workingDirectory, result.ExitCode, formatter.formatStandardErrorSuffix(result.StandardError))
+		}
+		return fmt.Sprintf(gitPullFromUpstreamFailureTemplateConstant, workingDirectory, result.ExitCode, formatter.formatStandardErrorSuffix(result.StandardError))
+	case messageStageExecutionFailure:
+		if len(trimmedRemote) > 0 {
+			return fmt.Sprintf(gitPullExecutionFailureTemplateConstant, joinedReferences, trimmedRemote, workingDirectory, formatter.describeFailure(failure))
+		}
+		return fmt.Sprintf(gitPullFromUpstreamExecutionFailureTemplateConstant, workingDirectory, formatter.describeFailure(failure))
+	default:
+		return formatter.buildGenericMessage(command, result, failure, stage)
+	}
+}
+
 func (formatter CommandMessageFormatter) describeGitPushMessage(command ShellCommand, result ExecutionResult, failure error, stage messageStage) string {
 	workingDirectory := formatter.describeWorkingDirectory(command)
 	arguments := command.Details.Arguments
@@ -857,6 +913,9 @@ func (formatter CommandMessageFormatter) formatCommandLabel(command ShellCommand
 	if len(command.Details.Arguments) > 0 {
 		commandLabel = fmt.Sprintf("%s %s", commandLabel, strings.Join(command.Details.Arguments, commandArgumentsJoinSeparatorConstant))
 	}
+	if command.Details.ShellWrapped {
+		commandLabel = fmt.Sprintf(shellWrappedLabelTemplateConstant, commandLabel, loginShellName(command.Details), loginShellCommandFlagConstant)
+	}
 	workingDirectorySuffix := formatter.formatWorkingDirectorySuffix(command)
 	return fmt.Sprintf(commandLabelTemplateConstant, commandLabel, workingDirectorySuffix)
 }
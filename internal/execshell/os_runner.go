@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 )
@@ -24,8 +25,14 @@ func NewOSCommandRunner() *OSCommandRunner {
 
 // Run executes the supplied command using os/exec.
 func (runner *OSCommandRunner) Run(executionContext context.Context, command ShellCommand) (ExecutionResult, error) {
+	executablePath, lookupError := exec.LookPath(string(command.Name))
+	if lookupError != nil {
+		return ExecutionResult{}, ExecutableNotFoundError{Command: command.Name, Cause: lookupError}
+	}
+
+	command = pinCommandLocale(command)
 	commandArguments := append([]string{}, command.Details.Arguments...)
-	executable := exec.CommandContext(executionContext, string(command.Name), commandArguments...)
+	executable := exec.CommandContext(executionContext, executablePath, commandArguments...)
 
 	if len(command.Details.WorkingDirectory) > 0 {
 		executable.Dir = command.Details.WorkingDirectory
@@ -39,31 +46,43 @@ func (runner *OSCommandRunner) Run(executionContext context.Context, command She
 		executable.Env = mergedEnvironment
 	}
 
-	var standardOutputBuffer bytes.Buffer
-	var standardErrorBuffer bytes.Buffer
-	executable.Stdout = &standardOutputBuffer
-	executable.Stderr = &standardErrorBuffer
+	outputCaptureLimit := resolveOutputCaptureLimit(command.Details.OutputCaptureLimit, DefaultOutputCaptureLimit)
+	standardOutputBuffer := newCappedOutputBuffer(outputCaptureLimit)
+	standardErrorBuffer := newCappedOutputBuffer(outputCaptureLimit)
+	executable.Stdout = teeOutput(standardOutputBuffer, command.Details.OutputWriter)
+	executable.Stderr = teeOutput(standardErrorBuffer, command.Details.ErrorWriter)
 
 	if len(command.Details.StandardInput) > 0 {
 		executable.Stdin = bytes.NewReader(command.Details.StandardInput)
 	}
 
 	runError := executable.Run()
+	standardOutput, outputTruncated := standardOutputBuffer.result()
+	standardError, errorTruncated := standardErrorBuffer.result()
 	if runError != nil {
 		exitError := &exec.ExitError{}
 		if errors.As(runError, &exitError) {
 			return ExecutionResult{
-				StandardOutput: standardOutputBuffer.String(),
-				StandardError:  standardErrorBuffer.String(),
+				StandardOutput: standardOutput,
+				StandardError:  standardError,
 				ExitCode:       exitError.ExitCode(),
+				Truncated:      outputTruncated || errorTruncated,
 			}, nil
 		}
 		return ExecutionResult{}, runError
 	}
 
 	return ExecutionResult{
-		StandardOutput: standardOutputBuffer.String(),
-		StandardError:  standardErrorBuffer.String(),
+		StandardOutput: standardOutput,
+		StandardError:  standardError,
 		ExitCode:       0,
+		Truncated:      outputTruncated || errorTruncated,
 	}, nil
 }
+
+func teeOutput(captureBuffer io.Writer, streamWriter io.Writer) io.Writer {
+	if streamWriter == nil {
+		return captureBuffer
+	}
+	return io.MultiWriter(captureBuffer, streamWriter)
+}
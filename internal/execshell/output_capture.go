@@ -0,0 +1,128 @@
+package execshell
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultOutputCaptureLimit is the number of bytes kept from each of stdout and stderr when no other limit applies.
+	DefaultOutputCaptureLimit = 4 * 1024 * 1024
+	// UnlimitedOutputCapture disables truncation for a command whose output must be parsed in full.
+	UnlimitedOutputCapture = -1
+
+	outputTruncationMarkerTemplateConstant   = "\n[truncated %d bytes]"
+	outputTruncatedErrorTemplateConstant     = "%s %s output exceeded the capture limit and was truncated"
+	outputCaptureLimitContextKeyNameConstant = "outputCaptureLimit"
+)
+
+type outputCaptureLimitContextKey string
+
+// OutputTruncatedError reports that a command's output was cut at the capture limit, so parsing it would read partial data.
+type OutputTruncatedError struct {
+	Command   CommandName
+	Arguments []string
+}
+
+// Error names the command whose output was truncated.
+func (truncatedError OutputTruncatedError) Error() string {
+	return fmt.Sprintf(outputTruncatedErrorTemplateConstant, truncatedError.Command, strings.Join(truncatedError.Arguments, " "))
+}
+
+// RequireCompleteOutput returns an OutputTruncatedError when the result of the command was truncated. Callers that parse
+// the output call it before parsing.
+func RequireCompleteOutput(command CommandName, details CommandDetails, result ExecutionResult) error {
+	if !result.Truncated {
+		return nil
+	}
+	return OutputTruncatedError{Command: command, Arguments: details.Arguments}
+}
+
+// WithOutputCaptureLimit attaches a per-stream capture limit that replaces the executor's limit for every command run
+// with the returned context. Zero leaves the executor's limit in place.
+func WithOutputCaptureLimit(parentContext context.Context, byteLimit int) context.Context {
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	if byteLimit == 0 {
+		return parentContext
+	}
+	return context.WithValue(parentContext, outputCaptureLimitContextKey(outputCaptureLimitContextKeyNameConstant), byteLimit)
+}
+
+func outputCaptureLimitFromContext(executionContext context.Context, executorLimit int) int {
+	if executionContext == nil {
+		return executorLimit
+	}
+	if contextLimit, found := executionContext.Value(outputCaptureLimitContextKey(outputCaptureLimitContextKeyNameConstant)).(int); found {
+		return contextLimit
+	}
+	return executorLimit
+}
+
+type cappedOutputBuffer struct {
+	builder      strings.Builder
+	limit        int
+	droppedBytes int64
+}
+
+func newCappedOutputBuffer(limit int) *cappedOutputBuffer {
+	return &cappedOutputBuffer{limit: limit}
+}
+
+// Write keeps bytes up to the limit and counts the remainder so the writer never reports a short write to the process.
+func (buffer *cappedOutputBuffer) Write(data []byte) (int, error) {
+	if buffer.limit < 0 {
+		buffer.builder.Write(data)
+		return len(data), nil
+	}
+	remaining := buffer.limit - buffer.builder.Len()
+	if remaining <= 0 {
+		buffer.droppedBytes += int64(len(data))
+		return len(data), nil
+	}
+	if len(data) > remaining {
+		buffer.builder.Write(data[:remaining])
+		buffer.droppedBytes += int64(len(data) - remaining)
+		return len(data), nil
+	}
+	buffer.builder.Write(data)
+	return len(data), nil
+}
+
+func (buffer *cappedOutputBuffer) result() (string, bool) {
+	if buffer.droppedBytes == 0 {
+		return buffer.builder.String(), false
+	}
+	return buffer.builder.String() + fmt.Sprintf(outputTruncationMarkerTemplateConstant, buffer.droppedBytes), true
+}
+
+func resolveOutputCaptureLimit(commandLimit int, executorLimit int) int {
+	if commandLimit != 0 {
+		return commandLimit
+	}
+	if executorLimit != 0 {
+		return executorLimit
+	}
+	return DefaultOutputCaptureLimit
+}
+
+func truncateCapturedOutput(output string, limit int) (string, bool) {
+	if limit < 0 || len(output) <= limit {
+		return output, false
+	}
+	return output[:limit] + fmt.Sprintf(outputTruncationMarkerTemplateConstant, len(output)-limit), true
+}
+
+func enforceOutputCaptureLimit(result ExecutionResult, limit int) ExecutionResult {
+	if result.Truncated {
+		return result
+	}
+	standardOutput, outputTruncated := truncateCapturedOutput(result.StandardOutput, limit)
+	standardError, errorTruncated := truncateCapturedOutput(result.StandardError, limit)
+	result.StandardOutput = standardOutput
+	result.StandardError = standardError
+	result.Truncated = outputTruncated || errorTruncated
+	return result
+}
@@ -0,0 +1,107 @@
+package execshell_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubauth"
+)
+
+const testCapturedOutputConstant = "abcdefghij"
+
+func TestShellExecutorCapsCapturedOutput(testInstance *testing.T) {
+	testCases := []struct {
+		name              string
+		runner            execshell.CommandRunner
+		commandName       execshell.CommandName
+		arguments         []string
+		executorLimit     int
+		contextLimit      int
+		commandLimit      int
+		expectedOutput    string
+		expectedTruncated bool
+	}{
+		{
+			name:              "executor_limit_truncates",
+			runner:            &recordingCommandRunner{executionResult: execshell.ExecutionResult{StandardOutput: testCapturedOutputConstant}},
+			commandName:       execshell.CommandGit,
+			arguments:         []string{testCommandArgumentConstant},
+			executorLimit:     4,
+			expectedOutput:    "abcd\n[truncated 6 bytes]",
+			expectedTruncated: true,
+		},
+		{
+			name:           "command_limit_raises_executor_limit",
+			runner:         &recordingCommandRunner{executionResult: execshell.ExecutionResult{StandardOutput: testCapturedOutputConstant}},
+			commandName:    execshell.CommandGit,
+			arguments:      []string{testCommandArgumentConstant},
+			executorLimit:  4,
+			commandLimit:   len(testCapturedOutputConstant),
+			expectedOutput: testCapturedOutputConstant,
+		},
+		{
+			name:              "context_limit_replaces_executor_limit",
+			runner:            &recordingCommandRunner{executionResult: execshell.ExecutionResult{StandardOutput: testCapturedOutputConstant}},
+			commandName:       execshell.CommandGit,
+			arguments:         []string{testCommandArgumentConstant},
+			executorLimit:     execshell.UnlimitedOutputCapture,
+			contextLimit:      2,
+			expectedOutput:    "ab\n[truncated 8 bytes]",
+			expectedTruncated: true,
+		},
+		{
+			name:           "command_disables_limit",
+			runner:         &recordingCommandRunner{executionResult: execshell.ExecutionResult{StandardOutput: testCapturedOutputConstant}},
+			commandName:    execshell.CommandGit,
+			arguments:      []string{testCommandArgumentConstant},
+			executorLimit:  1,
+			commandLimit:   execshell.UnlimitedOutputCapture,
+			expectedOutput: testCapturedOutputConstant,
+		},
+		{
+			name:              "os_runner_truncates_while_capturing",
+			runner:            execshell.NewOSCommandRunner(),
+			commandName:       execshell.CommandName("printf"),
+			arguments:         []string{testCapturedOutputConstant},
+			executorLimit:     3,
+			expectedOutput:    "abc\n[truncated 7 bytes]",
+			expectedTruncated: true,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor, creationError := execshell.NewShellExecutor(zap.NewNop(), testCase.runner, false)
+			require.NoError(subtest, creationError)
+			executor.SetOutputCaptureLimit(testCase.executorLimit)
+
+			command := execshell.ShellCommand{
+				Name: testCase.commandName,
+				Details: execshell.CommandDetails{
+					Arguments:              testCase.arguments,
+					GitHubTokenRequirement: githubauth.TokenOptional,
+					OutputCaptureLimit:     testCase.commandLimit,
+				},
+			}
+			executionContext := execshell.WithOutputCaptureLimit(context.Background(), testCase.contextLimit)
+			executionResult, executionError := executor.Execute(executionContext, command)
+			require.NoError(subtest, executionError)
+			require.Equal(subtest, testCase.expectedOutput, executionResult.StandardOutput)
+			require.Equal(subtest, testCase.expectedTruncated, executionResult.Truncated)
+		})
+	}
+}
+
+func TestRequireCompleteOutput(testInstance *testing.T) {
+	details := execshell.CommandDetails{Arguments: []string{"for-each-ref", "refs/heads"}}
+	require.NoError(testInstance, execshell.RequireCompleteOutput(execshell.CommandGit, details, execshell.ExecutionResult{StandardOutput: testCapturedOutputConstant}))
+
+	truncatedError := execshell.RequireCompleteOutput(execshell.CommandGit, details, execshell.ExecutionResult{Truncated: true})
+	require.EqualError(testInstance, truncatedError, "git for-each-ref refs/heads output exceeded the capture limit and was truncated")
+	require.ErrorAs(testInstance, truncatedError, &execshell.OutputTruncatedError{})
+}
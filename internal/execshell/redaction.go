@@ -0,0 +1,68 @@
+package execshell
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	redactedValuePlaceholderConstant = "***"
+	minimumSecretValueLengthConstant = 4
+)
+
+var (
+	urlCredentialsPattern       = regexp.MustCompile(`(?i)([a-z][a-z0-9+.-]*://)[^/@\s:]+(:[^/@\s]*)?@`)
+	authorizationHeaderPattern  = regexp.MustCompile(`(?i)(authorization:\s*(?:bearer|token|basic)\s+)\S+`)
+	gitHubTokenPattern          = regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`)
+	secretAssignmentPattern     = regexp.MustCompile(`(?i)\b([A-Z0-9_]*(?:TOKEN|SECRET|PASSWORD|API_KEY)[A-Z0-9_]*=)\S+`)
+	secretEnvironmentKeyMarkers = []string{"TOKEN", "SECRET", "PASSWORD", "API_KEY", "CREDENTIAL"}
+	urlCredentialsReplacement   = "${1}" + redactedValuePlaceholderConstant + "@"
+	prefixedSecretReplacement   = "${1}" + redactedValuePlaceholderConstant
+)
+
+// RedactSecrets masks credentials embedded in text before it leaves the process.
+// It hides URL userinfo, authorization header values, GitHub token formats, secret-looking KEY=value assignments, and every provided secret value.
+func RedactSecrets(text string, secretValues ...string) string {
+	if len(text) == 0 {
+		return text
+	}
+
+	sortedSecrets := make([]string, 0, len(secretValues))
+	for _, secretValue := range secretValues {
+		trimmedSecret := strings.TrimSpace(secretValue)
+		if len(trimmedSecret) < minimumSecretValueLengthConstant {
+			continue
+		}
+		sortedSecrets = append(sortedSecrets, trimmedSecret)
+	}
+	sort.Slice(sortedSecrets, func(firstIndex int, secondIndex int) bool {
+		return len(sortedSecrets[firstIndex]) > len(sortedSecrets[secondIndex])
+	})
+
+	redacted := text
+	for _, secretValue := range sortedSecrets {
+		redacted = strings.ReplaceAll(redacted, secretValue, redactedValuePlaceholderConstant)
+	}
+
+	redacted = urlCredentialsPattern.ReplaceAllString(redacted, urlCredentialsReplacement)
+	redacted = authorizationHeaderPattern.ReplaceAllString(redacted, prefixedSecretReplacement)
+	redacted = secretAssignmentPattern.ReplaceAllString(redacted, prefixedSecretReplacement)
+	redacted = gitHubTokenPattern.ReplaceAllString(redacted, redactedValuePlaceholderConstant)
+	return redacted
+}
+
+// CommandSecretValues returns environment values attached to the command whose keys look like credentials.
+func CommandSecretValues(command ShellCommand) []string {
+	var secretValues []string
+	for environmentKey, environmentValue := range command.Details.EnvironmentVariables {
+		upperKey := strings.ToUpper(environmentKey)
+		for _, marker := range secretEnvironmentKeyMarkers {
+			if strings.Contains(upperKey, marker) {
+				secretValues = append(secretValues, environmentValue)
+				break
+			}
+		}
+	}
+	return secretValues
+}
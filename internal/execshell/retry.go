@@ -0,0 +1,100 @@
+package execshell
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttemptsConstant = 3
+	defaultRetryDelayConstant       = 2 * time.Second
+	commandRetryMessageConstant     = "transient command failure; retrying idempotent command"
+	attemptFieldNameConstant        = "attempt"
+)
+
+// RetryPolicy controls automatic retries. Only commands whose CommandDetails.Idempotent is set are retried, and only
+// when their stderr matches one of TransientErrorPatterns (case-insensitive). MaxAttempts counts the first run; values
+// below two disable retries.
+type RetryPolicy struct {
+	MaxAttempts            int
+	Delay                  time.Duration
+	TransientErrorPatterns []string
+	// FailureClassifiers decide for a command name whether a failure is transient, ahead of TransientErrorPatterns.
+	FailureClassifiers map[CommandName]FailureClassifier
+}
+
+// FailureClassifier inspects a failed command's output. classified is false when the output carries nothing the
+// classifier recognizes, which leaves the decision to TransientErrorPatterns.
+type FailureClassifier func(result ExecutionResult) (transient bool, classified bool)
+
+// DefaultTransientErrorPatterns lists stderr fragments emitted by git and gh for network failures that usually clear on
+// their own.
+func DefaultTransientErrorPatterns() []string {
+	return []string{
+		"could not resolve host",
+		"connection reset by peer",
+		"connection timed out",
+		"operation timed out",
+		"the remote end hung up unexpectedly",
+		"early eof",
+		"tls handshake timeout",
+		"temporary failure in name resolution",
+		"http 502",
+		"http 503",
+		"http 504",
+	}
+}
+
+// DefaultRetryPolicy retries idempotent commands up to three times, two seconds apart, on DefaultTransientErrorPatterns.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:            defaultRetryMaxAttemptsConstant,
+		Delay:                  defaultRetryDelayConstant,
+		TransientErrorPatterns: DefaultTransientErrorPatterns(),
+	}
+}
+
+// SetRetryPolicy configures automatic retries of idempotent commands. The zero policy disables retries.
+func (executor *ShellExecutor) SetRetryPolicy(policy RetryPolicy) {
+	executor.retryPolicy = policy
+}
+
+// shouldRetry reports whether a failed attempt may run again. Non-idempotent commands are never retried, even when
+// their stderr looks transient.
+func (policy RetryPolicy) shouldRetry(command ShellCommand, result ExecutionResult, attempt int) bool {
+	if !command.Details.Idempotent || attempt >= policy.MaxAttempts {
+		return false
+	}
+	if classifier, exists := policy.FailureClassifiers[command.Name]; exists && classifier != nil {
+		if transient, classified := classifier(result); classified {
+			return transient
+		}
+	}
+	return policy.isTransient(result.StandardError)
+}
+
+func (policy RetryPolicy) isTransient(standardError string) bool {
+	normalizedError := strings.ToLower(standardError)
+	for _, pattern := range policy.TransientErrorPatterns {
+		trimmedPattern := strings.ToLower(strings.TrimSpace(pattern))
+		if len(trimmedPattern) > 0 && strings.Contains(normalizedError, trimmedPattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func waitForRetry(executionContext context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return executionContext.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-executionContext.Done():
+		return executionContext.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,275 @@
+package execshell_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubauth"
+)
+
+const (
+	testTransientStandardErrorConstant = "fatal: unable to access 'https://github.com/owner/repo/': Could not resolve host: github.com"
+	testPermanentStandardErrorConstant = "fatal: not a git repository"
+	testModuleRootRelativePathConstant = "../.."
+	testCommandDetailsTypeNameConstant = "CommandDetails"
+	testIdempotentFieldNameConstant    = "Idempotent"
+	testArgumentsFieldNameConstant     = "Arguments"
+)
+
+var mutatingGitSubcommands = map[string]struct{}{
+	"add": {}, "am": {}, "branch": {}, "checkout": {}, "cherry-pick": {}, "clean": {}, "commit": {}, "gc": {},
+	"merge": {}, "mv": {}, "pull": {}, "push": {}, "rebase": {}, "reset": {}, "rm": {}, "stash": {}, "switch": {},
+	"tag": {}, "update-ref": {}, "worktree": {},
+}
+
+type sequencedCommandRunner struct {
+	results          []execshell.ExecutionResult
+	recordedCommands []execshell.ShellCommand
+}
+
+func (runner *sequencedCommandRunner) Run(executionContext context.Context, command execshell.ShellCommand) (execshell.ExecutionResult, error) {
+	runner.recordedCommands = append(runner.recordedCommands, command)
+	result := runner.results[len(runner.results)-1]
+	if len(runner.recordedCommands) <= len(runner.results) {
+		result = runner.results[len(runner.recordedCommands)-1]
+	}
+	return result, nil
+}
+
+func TestShellExecutorRetriesOnlyIdempotentCommands(testInstance *testing.T) {
+	transientFailure := execshell.ExecutionResult{ExitCode: 128, StandardError: testTransientStandardErrorConstant}
+	permanentFailure := execshell.ExecutionResult{ExitCode: 128, StandardError: testPermanentStandardErrorConstant}
+	success := execshell.ExecutionResult{StandardOutput: "ok"}
+
+	testCases := []struct {
+		name             string
+		idempotent       bool
+		results          []execshell.ExecutionResult
+		expectedAttempts int
+		expectError      bool
+	}{
+		{name: "idempotent_transient_then_success", idempotent: true, results: []execshell.ExecutionResult{transientFailure, success}, expectedAttempts: 2},
+		{name: "idempotent_transient_exhausts_attempts", idempotent: true, results: []execshell.ExecutionResult{transientFailure}, expectedAttempts: 3, expectError: true},
+		{name: "idempotent_permanent_failure_not_retried", idempotent: true, results: []execshell.ExecutionResult{permanentFailure}, expectedAttempts: 1, expectError: true},
+		{name: "non_idempotent_transient_not_retried", idempotent: false, results: []execshell.ExecutionResult{transientFailure, success}, expectedAttempts: 1, expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			runner := &sequencedCommandRunner{results: testCase.results}
+			executor, creationError := execshell.NewShellExecutor(zap.NewNop(), runner, false)
+			require.NoError(subtest, creationError)
+			policy := execshell.DefaultRetryPolicy()
+			policy.Delay = 0
+			executor.SetRetryPolicy(policy)
+
+			_, executionError := executor.ExecuteGit(context.Background(), execshell.CommandDetails{
+				Arguments:  []string{"ls-remote", "origin"},
+				Idempotent: testCase.idempotent,
+			})
+			if testCase.expectError {
+				var commandError execshell.CommandFailedError
+				require.True(subtest, errors.As(executionError, &commandError))
+			} else {
+				require.NoError(subtest, executionError)
+			}
+			require.Len(subtest, runner.recordedCommands, testCase.expectedAttempts)
+		})
+	}
+}
+
+func TestShellExecutorFailureClassifierOverridesPatterns(testInstance *testing.T) {
+	testCases := []struct {
+		name             string
+		standardError    string
+		expectedAttempts int
+	}{
+		{name: "classified_transient_retried", standardError: "gh: Server Error (HTTP 500)", expectedAttempts: 2},
+		{name: "classified_permanent_not_retried_despite_pattern", standardError: "gh: connection reset by peer (HTTP 401)", expectedAttempts: 1},
+		{name: "unclassified_falls_back_to_patterns", standardError: testTransientStandardErrorConstant, expectedAttempts: 2},
+	}
+
+	classifier := func(result execshell.ExecutionResult) (bool, bool) {
+		switch {
+		case strings.Contains(result.StandardError, "HTTP 500"):
+			return true, true
+		case strings.Contains(result.StandardError, "HTTP 401"):
+			return false, true
+		default:
+			return false, false
+		}
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			runner := &sequencedCommandRunner{results: []execshell.ExecutionResult{{ExitCode: 1, StandardError: testCase.standardError}, {StandardOutput: "ok"}}}
+			executor, creationError := execshell.NewShellExecutor(zap.NewNop(), runner, false)
+			require.NoError(subtest, creationError)
+			policy := execshell.DefaultRetryPolicy()
+			policy.Delay = 0
+			policy.FailureClassifiers = map[execshell.CommandName]execshell.FailureClassifier{execshell.CommandGitHub: classifier}
+			executor.SetRetryPolicy(policy)
+
+			_, _ = executor.ExecuteGitHubCLI(context.Background(), execshell.CommandDetails{Arguments: []string{"api", "repos/owner/repo"}, GitHubTokenRequirement: githubauth.TokenOptional, Idempotent: true})
+			require.Len(subtest, runner.recordedCommands, testCase.expectedAttempts)
+		})
+	}
+}
+
+func TestShellExecutorWithoutRetryPolicyRunsOnce(testInstance *testing.T) {
+	runner := &sequencedCommandRunner{results: []execshell.ExecutionResult{{ExitCode: 128, StandardError: testTransientStandardErrorConstant}}}
+	executor, creationError := execshell.NewShellExecutor(zap.NewNop(), runner, false)
+	require.NoError(testInstance, creationError)
+
+	_, executionError := executor.ExecuteGit(context.Background(), execshell.CommandDetails{Arguments: []string{"status"}, Idempotent: true})
+	require.Error(testInstance, executionError)
+	require.Len(testInstance, runner.recordedCommands, 1)
+}
+
+// TestCommandDetailsDeclareIdempotency walks the module sources and requires every CommandDetails literal outside tests
+// to state Idempotent explicitly, and never as true for a git subcommand that changes repository or remote state.
+func TestCommandDetailsDeclareIdempotency(testInstance *testing.T) {
+	moduleRoot, rootError := filepath.Abs(testModuleRootRelativePathConstant)
+	require.NoError(testInstance, rootError)
+
+	fileSet := token.NewFileSet()
+	packageFiles := map[string][]*ast.File{}
+	walkError := filepath.WalkDir(moduleRoot, func(path string, entry fs.DirEntry, walkError error) error {
+		if walkError != nil {
+			return walkError
+		}
+		if entry.IsDir() {
+			if strings.HasPrefix(entry.Name(), ".") || entry.Name() == "testdata" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		parsedFile, parseError := parser.ParseFile(fileSet, path, nil, 0)
+		if parseError != nil {
+			return parseError
+		}
+		packageFiles[filepath.Dir(path)] = append(packageFiles[filepath.Dir(path)], parsedFile)
+		return nil
+	})
+	require.NoError(testInstance, walkError)
+
+	literalCount := 0
+	for _, files := range packageFiles {
+		stringConstants := collectStringConstants(files)
+		for _, parsedFile := range files {
+			ast.Inspect(parsedFile, func(node ast.Node) bool {
+				literal, isLiteral := node.(*ast.CompositeLit)
+				if !isLiteral || !isCommandDetailsType(literal.Type) {
+					return true
+				}
+				literalCount++
+				position := fileSet.Position(literal.Pos())
+				location := fmt.Sprintf("%s:%d", strings.TrimPrefix(position.Filename, moduleRoot+string(filepath.Separator)), position.Line)
+
+				idempotentValue, declared := literalField(literal, testIdempotentFieldNameConstant)
+				require.Truef(testInstance, declared, "%s: CommandDetails must set Idempotent explicitly", location)
+
+				idempotentIdentifier, isIdentifier := idempotentValue.(*ast.Ident)
+				if !isIdentifier || idempotentIdentifier.Name != "true" {
+					return true
+				}
+				argumentsValue, hasArguments := literalField(literal, testArgumentsFieldNameConstant)
+				if !hasArguments {
+					return true
+				}
+				argumentsLiteral, isArgumentsLiteral := argumentsValue.(*ast.CompositeLit)
+				if !isArgumentsLiteral || len(argumentsLiteral.Elts) == 0 {
+					return true
+				}
+				subcommand, resolved := resolveStringExpression(argumentsLiteral.Elts[0], stringConstants)
+				if !resolved {
+					return true
+				}
+				_, mutating := mutatingGitSubcommands[subcommand]
+				require.Falsef(testInstance, mutating, "%s: git %s changes state and must not be marked idempotent", location, subcommand)
+				return true
+			})
+		}
+	}
+	require.NotZero(testInstance, literalCount)
+}
+
+func isCommandDetailsType(expression ast.Expr) bool {
+	switch typed := expression.(type) {
+	case *ast.Ident:
+		return typed.Name == testCommandDetailsTypeNameConstant
+	case *ast.SelectorExpr:
+		return typed.Sel.Name == testCommandDetailsTypeNameConstant
+	default:
+		return false
+	}
+}
+
+func literalField(literal *ast.CompositeLit, fieldName string) (ast.Expr, bool) {
+	for _, element := range literal.Elts {
+		keyValue, isKeyValue := element.(*ast.KeyValueExpr)
+		if !isKeyValue {
+			continue
+		}
+		if key, isIdentifier := keyValue.Key.(*ast.Ident); isIdentifier && key.Name == fieldName {
+			return keyValue.Value, true
+		}
+	}
+	return nil, false
+}
+
+func collectStringConstants(files []*ast.File) map[string]string {
+	constants := map[string]string{}
+	for _, parsedFile := range files {
+		for _, declaration := range parsedFile.Decls {
+			generalDeclaration, isGeneral := declaration.(*ast.GenDecl)
+			if !isGeneral || generalDeclaration.Tok != token.CONST {
+				continue
+			}
+			for _, specification := range generalDeclaration.Specs {
+				valueSpecification := specification.(*ast.ValueSpec)
+				for nameIndex, name := range valueSpecification.Names {
+					if nameIndex >= len(valueSpecification.Values) {
+						continue
+					}
+					if value, resolved := resolveStringExpression(valueSpecification.Values[nameIndex], nil); resolved {
+						constants[name.Name] = value
+					}
+				}
+			}
+		}
+	}
+	return constants
+}
+
+func resolveStringExpression(expression ast.Expr, constants map[string]string) (string, bool) {
+	switch typed := expression.(type) {
+	case *ast.BasicLit:
+		if typed.Kind != token.STRING {
+			return "", false
+		}
+		value, unquoteError := strconv.Unquote(typed.Value)
+		return value, unquoteError == nil
+	case *ast.Ident:
+		value, found := constants[typed.Name]
+		return value, found
+	default:
+		return "", false
+	}
+}
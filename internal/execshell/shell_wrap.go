@@ -0,0 +1,61 @@
+package execshell
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultLoginShellConstant                 = "sh"
+	loginShellCommandFlagConstant             = "-lc"
+	shellQuoteConstant                        = "'"
+	shellEscapedQuoteConstant                 = `'\''`
+	shellSafeCharactersConstant               = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789@%+=:,./_-"
+	shellWrappedLabelTemplateConstant         = "%s (via %s %s)"
+	shellWrappingRefusedErrorTemplateConstant = "shell wrapping is not allowed for the built-in %s command"
+)
+
+// ShellWrappingRefusedError reports a built-in git, gh, curl, or ssh invocation that asked to run through a login shell.
+type ShellWrappingRefusedError struct {
+	Command CommandName
+}
+
+// Error describes the refused invocation.
+func (refusedError ShellWrappingRefusedError) Error() string {
+	return fmt.Sprintf(shellWrappingRefusedErrorTemplateConstant, refusedError.Command)
+}
+
+// wrapInLoginShell rewrites a ShellWrapped command into `<shell> -lc '<command line>'`; other commands are returned unchanged.
+func wrapInLoginShell(command ShellCommand) ShellCommand {
+	if !command.Details.ShellWrapped {
+		return command
+	}
+	wrapped := command
+	wrapped.Name = CommandName(loginShellName(command.Details))
+	wrapped.Details.Arguments = []string{loginShellCommandFlagConstant, shellCommandLine(command)}
+	return wrapped
+}
+
+func loginShellName(details CommandDetails) string {
+	if shell := strings.TrimSpace(details.Shell); len(shell) > 0 {
+		return shell
+	}
+	return defaultLoginShellConstant
+}
+
+// shellCommandLine joins the command name and arguments into one line with every word quoted for a POSIX shell.
+func shellCommandLine(command ShellCommand) string {
+	words := make([]string, 0, len(command.Details.Arguments)+1)
+	words = append(words, quoteShellWord(string(command.Name)))
+	for _, argument := range command.Details.Arguments {
+		words = append(words, quoteShellWord(argument))
+	}
+	return strings.Join(words, commandArgumentsJoinSeparatorConstant)
+}
+
+func quoteShellWord(word string) string {
+	if len(word) > 0 && len(strings.Trim(word, shellSafeCharactersConstant)) == 0 {
+		return word
+	}
+	return shellQuoteConstant + strings.ReplaceAll(word, shellQuoteConstant, shellEscapedQuoteConstant) + shellQuoteConstant
+}
@@ -0,0 +1,79 @@
+package execshell_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+func TestShellExecutorWrapsCommandsInLoginShell(testInstance *testing.T) {
+	testCases := []struct {
+		name              string
+		details           execshell.CommandDetails
+		expectedName      execshell.CommandName
+		expectedArguments []string
+		expectedMessage   string
+	}{
+		{
+			name:              "direct_execution_by_default",
+			details:           execshell.CommandDetails{Arguments: []string{"run", "build"}},
+			expectedName:      execshell.CommandName("npm"),
+			expectedArguments: []string{"run", "build"},
+			expectedMessage:   "Running npm run build",
+		},
+		{
+			name:              "login_shell_quotes_arguments",
+			details:           execshell.CommandDetails{Arguments: []string{"run", "it's done", "&&", "--flag=a b"}, ShellWrapped: true},
+			expectedName:      execshell.CommandName("sh"),
+			expectedArguments: []string{"-lc", `npm run 'it'\''s done' '&&' '--flag=a b'`},
+			expectedMessage:   "Running npm run it's done && --flag=a b (via sh -lc)",
+		},
+		{
+			name:              "configured_shell",
+			details:           execshell.CommandDetails{Arguments: []string{"test"}, ShellWrapped: true, Shell: "bash", WorkingDirectory: "/tmp/repo"},
+			expectedName:      execshell.CommandName("bash"),
+			expectedArguments: []string{"-lc", "npm test"},
+			expectedMessage:   "Running npm test (via bash -lc) (in /tmp/repo)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			observerCore, observedLogs := observer.New(zap.InfoLevel)
+			recordingRunner := &recordingCommandRunner{}
+			shellExecutor, creationError := execshell.NewShellExecutor(zap.New(observerCore), recordingRunner, true)
+			require.NoError(testInstance, creationError)
+
+			_, executionError := shellExecutor.Execute(context.Background(), execshell.ShellCommand{Name: execshell.CommandName("npm"), Details: testCase.details})
+			require.NoError(testInstance, executionError)
+
+			require.Len(testInstance, recordingRunner.recordedCommands, 1)
+			require.Equal(testInstance, testCase.expectedName, recordingRunner.recordedCommands[0].Name)
+			require.Equal(testInstance, testCase.expectedArguments, recordingRunner.recordedCommands[0].Details.Arguments)
+			require.Equal(testInstance, testCase.expectedMessage, observedLogs.All()[0].Message)
+		})
+	}
+}
+
+func TestShellExecutorRefusesShellWrappingForBuiltInCommands(testInstance *testing.T) {
+	recordingRunner := &recordingCommandRunner{}
+	shellExecutor, creationError := execshell.NewShellExecutor(zap.NewNop(), recordingRunner, false)
+	require.NoError(testInstance, creationError)
+
+	details := execshell.CommandDetails{Arguments: []string{testCommandArgumentConstant}, ShellWrapped: true}
+	executions := map[execshell.CommandName]func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error){
+		execshell.CommandGit:    shellExecutor.ExecuteGit,
+		execshell.CommandGitHub: shellExecutor.ExecuteGitHubCLI,
+		execshell.CommandCurl:   shellExecutor.ExecuteCurl,
+	}
+	for commandName, execute := range executions {
+		_, executionError := execute(context.Background(), details)
+		require.ErrorIs(testInstance, executionError, execshell.ShellWrappingRefusedError{Command: commandName})
+	}
+	require.Empty(testInstance, recordingRunner.recordedCommands)
+}
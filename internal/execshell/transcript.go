@@ -0,0 +1,171 @@
+package execshell
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	transcriptFilePermissionsConstant       = 0o600
+	transcriptStandardErrorLimitConstant    = 2048
+	transcriptTruncationSuffixConstant      = "…[truncated]"
+	transcriptExecutionFailureExitCode      = -1
+	transcriptOpenErrorTemplateConstant     = "unable to open command log %s: %w"
+	transcriptEncodeErrorTemplateConstant   = "unable to encode command log entry: %w"
+	transcriptWriteErrorTemplateConstant    = "unable to write command log entry: %w"
+	commandObserverContextKeyNameConstant   = "commandObserver"
+	transcriptLineTerminatorConstant        = '\n'
+	transcriptRecordStartTimeLayoutConstant = time.RFC3339Nano
+)
+
+type commandObserverContextKey string
+
+// CommandRecord describes one external command after it finished.
+type CommandRecord struct {
+	Command       ShellCommand
+	StartedAt     time.Time
+	Duration      time.Duration
+	ExitCode      int
+	StandardError string
+	Failure       error
+}
+
+// CommandObserver receives a record for every command run by a ShellExecutor.
+type CommandObserver interface {
+	ObserveCommand(record CommandRecord)
+}
+
+// WithCommandObserver attaches an observer to the context so executors report every command run with it.
+func WithCommandObserver(parentContext context.Context, observer CommandObserver) context.Context {
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	if observer == nil {
+		return parentContext
+	}
+	return context.WithValue(parentContext, commandObserverContextKey(commandObserverContextKeyNameConstant), observer)
+}
+
+// CommandObserverFromContext returns the observer attached with WithCommandObserver, if any.
+func CommandObserverFromContext(executionContext context.Context) (CommandObserver, bool) {
+	if executionContext == nil {
+		return nil, false
+	}
+	observer, found := executionContext.Value(commandObserverContextKey(commandObserverContextKeyNameConstant)).(CommandObserver)
+	return observer, found && observer != nil
+}
+
+// CommandTranscript writes one redacted JSON line per observed command.
+// Each line is written straight to the destination so an interrupted run still leaves every completed entry behind.
+type CommandTranscript struct {
+	mutex  sync.Mutex
+	writer io.Writer
+	closer io.Closer
+	err    error
+}
+
+type transcriptEntry struct {
+	Name             string   `json:"name"`
+	Arguments        []string `json:"args"`
+	WorkingDirectory string   `json:"cwd"`
+	Start            string   `json:"start"`
+	DurationMillis   float64  `json:"duration_ms"`
+	ExitCode         int      `json:"exit_code"`
+	StandardError    string   `json:"stderr,omitempty"`
+	Error            string   `json:"error,omitempty"`
+}
+
+// NewCommandTranscript writes transcript entries to the provided writer.
+func NewCommandTranscript(writer io.Writer) *CommandTranscript {
+	return &CommandTranscript{writer: writer}
+}
+
+// OpenCommandTranscript creates or truncates the file at path and writes transcript entries to it.
+func OpenCommandTranscript(path string) (*CommandTranscript, error) {
+	transcriptFile, openError := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, transcriptFilePermissionsConstant)
+	if openError != nil {
+		return nil, fmt.Errorf(transcriptOpenErrorTemplateConstant, path, openError)
+	}
+	return &CommandTranscript{writer: transcriptFile, closer: transcriptFile}, nil
+}
+
+// ObserveCommand appends the redacted record as a JSON line. The first write failure is retained and reported by Err.
+func (transcript *CommandTranscript) ObserveCommand(record CommandRecord) {
+	if transcript == nil || transcript.writer == nil {
+		return
+	}
+
+	secretValues := CommandSecretValues(record.Command)
+	redactedArguments := make([]string, 0, len(record.Command.Details.Arguments))
+	for _, argument := range record.Command.Details.Arguments {
+		redactedArguments = append(redactedArguments, RedactSecrets(argument, secretValues...))
+	}
+
+	entry := transcriptEntry{
+		Name:             string(record.Command.Name),
+		Arguments:        redactedArguments,
+		WorkingDirectory: record.Command.Details.WorkingDirectory,
+		Start:            record.StartedAt.UTC().Format(transcriptRecordStartTimeLayoutConstant),
+		DurationMillis:   float64(record.Duration) / float64(time.Millisecond),
+		ExitCode:         record.ExitCode,
+		StandardError:    truncateTranscriptText(RedactSecrets(record.StandardError, secretValues...)),
+	}
+	if record.Failure != nil {
+		entry.ExitCode = transcriptExecutionFailureExitCode
+		entry.Error = RedactSecrets(record.Failure.Error(), secretValues...)
+	}
+
+	encodedEntry, encodeError := json.Marshal(entry)
+
+	transcript.mutex.Lock()
+	defer transcript.mutex.Unlock()
+	if transcript.err != nil {
+		return
+	}
+	if encodeError != nil {
+		transcript.err = fmt.Errorf(transcriptEncodeErrorTemplateConstant, encodeError)
+		return
+	}
+	if _, writeError := transcript.writer.Write(append(encodedEntry, transcriptLineTerminatorConstant)); writeError != nil {
+		transcript.err = fmt.Errorf(transcriptWriteErrorTemplateConstant, writeError)
+	}
+}
+
+// Err reports the first failure encountered while writing entries.
+func (transcript *CommandTranscript) Err() error {
+	if transcript == nil {
+		return nil
+	}
+	transcript.mutex.Lock()
+	defer transcript.mutex.Unlock()
+	return transcript.err
+}
+
+// Close releases the underlying file when the transcript owns one.
+func (transcript *CommandTranscript) Close() error {
+	if transcript == nil || transcript.closer == nil {
+		return nil
+	}
+	transcript.mutex.Lock()
+	defer transcript.mutex.Unlock()
+	closeError := transcript.closer.Close()
+	transcript.closer = nil
+	transcript.writer = nil
+	return closeError
+}
+
+func truncateTranscriptText(text string) string {
+	if len(text) <= transcriptStandardErrorLimitConstant {
+		return text
+	}
+	runes := []rune(text)
+	if len(runes) <= transcriptStandardErrorLimitConstant {
+		return text
+	}
+	return string(runes[:transcriptStandardErrorLimitConstant]) + transcriptTruncationSuffixConstant
+}
@@ -0,0 +1,115 @@
+package ghcr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PurgeReasonUntagged explains a candidate selected because it carries no tags.
+	PurgeReasonUntagged = "untagged"
+	// PurgeReasonForcedApproval explains an approved version deleted with force although the policy no longer selects it.
+	PurgeReasonForcedApproval = "approved with force; no longer matches the purge policy"
+
+	purgeReasonLabelsTemplateConstant      = "untagged; labels %s"
+	purgeReasonPullRequestTemplateConstant = "pull request tag(s) %s"
+	purgeReasonPullRequestTagTemplate      = "%s #%d %s"
+	purgeReasonListSeparatorConstant       = ", "
+	approvalRefusedMessageConstant         = "Refusing approved GHCR package version that no longer matches the purge policy"
+)
+
+// PurgeCandidate describes a version a purge policy selected for deletion and the reason it was selected.
+type PurgeCandidate struct {
+	VersionID int64
+	Digest    string
+	Tags      []string
+	Size      int64
+	// UpdatedAt is when the version last changed; zero when the listing omitted it.
+	UpdatedAt time.Time
+	Reason    string
+	// Rule is the retention rule that selected the version; empty for pull request and forced approval purges.
+	Rule RetentionRule
+}
+
+// approvalGate restricts a purge to the digests of PurgeRequest.ApprovedDigests and remembers which of them the
+// policy selected, so the remaining ones can be refused or, with ForceApproved, deleted afterwards.
+type approvalGate struct {
+	enabled  bool
+	approved map[string]bool
+}
+
+func newApprovalGate(request PurgeRequest) *approvalGate {
+	if request.ApprovedDigests == nil {
+		return &approvalGate{}
+	}
+	approved := make(map[string]bool, len(request.ApprovedDigests))
+	for _, digest := range request.ApprovedDigests {
+		trimmedDigest := strings.TrimSpace(digest)
+		if len(trimmedDigest) > 0 {
+			approved[trimmedDigest] = false
+		}
+	}
+	return &approvalGate{enabled: true, approved: approved}
+}
+
+// admits reports whether a policy candidate may be purged and marks its digest as selected by the policy.
+func (gate *approvalGate) admits(version PackageVersion) bool {
+	if !gate.enabled {
+		return true
+	}
+	if _, approved := gate.approved[version.Name]; !approved {
+		return false
+	}
+	gate.approved[version.Name] = true
+	return true
+}
+
+// purgeUnselectedApprovals handles approved digests present in the package that the policy did not select: they are
+// deleted when ForceApproved is set and recorded in RefusedDigests otherwise. Approved digests absent from the package
+// are ignored, since an approval file may span several packages.
+func (service *PackageVersionService) purgeUnselectedApprovals(executionContext context.Context, request PurgeRequest, versions []PackageVersion, gate *approvalGate, result *PurgeResult) error {
+	if !gate.enabled {
+		return nil
+	}
+	for versionIndex := range versions {
+		version := versions[versionIndex]
+		selected, approved := gate.approved[version.Name]
+		if !approved || selected {
+			continue
+		}
+		gate.approved[version.Name] = true
+		if !request.ForceApproved {
+			service.logger.Warn(
+				approvalRefusedMessageConstant,
+				zap.Int64(versionIdentifierLogFieldNameConstant, version.ID),
+				zap.String(versionDigestLogFieldNameConstant, version.Name),
+			)
+			result.RefusedDigests = append(result.RefusedDigests, version.Name)
+			continue
+		}
+		if deleteError := service.purgeVersion(executionContext, request, version, PurgeReasonForcedApproval, "", result); deleteError != nil {
+			return deleteError
+		}
+	}
+	return nil
+}
+
+func labelFilterPurgeReason(filters []LabelFilter) string {
+	descriptions := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		descriptions = append(descriptions, filter.String())
+	}
+	return fmt.Sprintf(purgeReasonLabelsTemplateConstant, strings.Join(descriptions, purgeReasonListSeparatorConstant))
+}
+
+func pullRequestPurgeReason(evaluated []PullRequestTaggedVersion) string {
+	descriptions := make([]string, 0, len(evaluated))
+	for _, taggedVersion := range evaluated {
+		descriptions = append(descriptions, fmt.Sprintf(purgeReasonPullRequestTagTemplate, taggedVersion.Tag, taggedVersion.PullRequestNumber, taggedVersion.State))
+	}
+	return fmt.Sprintf(purgeReasonPullRequestTemplateConstant, strings.Join(descriptions, purgeReasonListSeparatorConstant))
+}
@@ -0,0 +1,92 @@
+package ghcr_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/ghcr"
+)
+
+func TestPurgeUntaggedVersionsApprovedDigests(testingInstance *testing.T) {
+	updatedAt := time.Date(2026, time.March, 4, 5, 6, 7, 0, time.UTC)
+	versions := []ghcr.PackageVersion{
+		{ID: 11, Name: "sha256:approved", UpdatedAt: updatedAt},
+		{ID: 12, Name: "sha256:unapproved"},
+		{ID: 13, Name: "sha256:retagged", Metadata: ghcr.PackageVersionMetadata{Container: ghcr.PackageVersionContainerMetadata{Tags: []string{"v1"}}}},
+	}
+
+	testCases := []struct {
+		name            string
+		approvedDigests []string
+		forceApproved   bool
+		expectedDeleted []int64
+		expectedRefused []string
+		expectedReasons []string
+	}{
+		{
+			name:            "without_approval_deletes_every_candidate",
+			expectedDeleted: []int64{11, 12},
+			expectedReasons: []string{ghcr.PurgeReasonUntagged, ghcr.PurgeReasonUntagged},
+		},
+		{
+			name:            "deletes_only_approved_candidates",
+			approvedDigests: []string{"sha256:approved", "sha256:elsewhere"},
+			expectedDeleted: []int64{11},
+			expectedReasons: []string{ghcr.PurgeReasonUntagged},
+		},
+		{
+			name:            "refuses_approved_digest_outside_policy",
+			approvedDigests: []string{"sha256:approved", "sha256:retagged"},
+			expectedDeleted: []int64{11},
+			expectedRefused: []string{"sha256:retagged"},
+			expectedReasons: []string{ghcr.PurgeReasonUntagged},
+		},
+		{
+			name:            "force_deletes_approved_digest_outside_policy",
+			approvedDigests: []string{"sha256:retagged"},
+			forceApproved:   true,
+			expectedDeleted: []int64{13},
+			expectedReasons: []string{ghcr.PurgeReasonForcedApproval},
+		},
+		{
+			name:            "empty_approval_deletes_nothing",
+			approvedDigests: []string{},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testingInstance.Run(testCase.name, func(subtest *testing.T) {
+			store := &recordingVersionStore{versions: versions}
+			service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), &stubHTTPClient{}, ghcr.ServiceConfiguration{})
+			require.NoError(subtest, serviceError)
+			service.SetVersionStore(store)
+
+			result, purgeError := service.PurgeUntaggedVersions(context.Background(), ghcr.PurgeRequest{
+				Owner:           testOwnerNameConstant,
+				PackageName:     testPackageNameConstant,
+				OwnerType:       ghcr.OrganizationOwnerType,
+				Token:           testTokenValueConstant,
+				ApprovedDigests: testCase.approvedDigests,
+				ForceApproved:   testCase.forceApproved,
+			})
+			require.NoError(subtest, purgeError)
+			require.Equal(subtest, testCase.expectedDeleted, store.deletedIDs)
+			require.Equal(subtest, testCase.expectedRefused, result.RefusedDigests)
+
+			var reasons []string
+			for _, candidate := range result.Candidates {
+				reasons = append(reasons, candidate.Reason)
+				require.Equal(subtest, candidate.VersionID, candidate.Size)
+				if candidate.Digest == "sha256:approved" {
+					require.Equal(subtest, updatedAt, candidate.UpdatedAt)
+				}
+			}
+			require.Equal(subtest, testCase.expectedReasons, reasons)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package ghcr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	labelFilterSeparatorConstant          = "="
+	labelFilterInvalidTemplateConstant    = "label filter %q must have the form key=value"
+	registryBlobsPathSegmentConstant      = "blobs"
+	configBlobStatusErrorTemplateConstant = "unexpected status code %d for config blob %s"
+	configBlobDecodeErrorTemplateConstant = "unable to decode config blob %s: %w"
+	manifestConfigMissingTemplateConstant = "manifest %s has no config blob"
+	versionLabelsUnavailableMessage       = "GHCR package version labels unavailable; skipping version"
+	labelMatchedVersionsLogFieldName      = "label_matched_versions"
+	manifestFetchesLogFieldName           = "manifest_fetches"
+)
+
+// ErrSnapshotLabelsNotRecorded indicates a label filter was evaluated against a snapshot recorded without labels.
+var ErrSnapshotLabelsNotRecorded = errors.New("package snapshot does not record image labels; dump it again with --filter-label")
+
+// LabelFilter selects package versions whose image config carries the label Key with exactly Value.
+type LabelFilter struct {
+	Key   string
+	Value string
+}
+
+// ParseLabelFilter parses a key=value expression. The value may be empty but the key may not.
+func ParseLabelFilter(expression string) (LabelFilter, error) {
+	key, value, found := strings.Cut(strings.TrimSpace(expression), labelFilterSeparatorConstant)
+	key = strings.TrimSpace(key)
+	if !found || len(key) == 0 {
+		return LabelFilter{}, fmt.Errorf(labelFilterInvalidTemplateConstant, expression)
+	}
+	return LabelFilter{Key: key, Value: strings.TrimSpace(value)}, nil
+}
+
+// String renders the filter as key=value.
+func (filter LabelFilter) String() string {
+	return filter.Key + labelFilterSeparatorConstant + filter.Value
+}
+
+func matchesLabelFilters(labels map[string]string, filters []LabelFilter) bool {
+	for _, filter := range filters {
+		value, present := labels[filter.Key]
+		if !present || value != filter.Value {
+			return false
+		}
+	}
+	return true
+}
+
+type registryImageConfig struct {
+	Config struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"config"`
+}
+
+// versionLabels reads the image config labels of a version and reports how many registry requests it made.
+// Labels are cached per digest, so repeated lookups of the same digest make no requests.
+func (service *PackageVersionService) versionLabels(executionContext context.Context, request PurgeRequest, version PackageVersion) (map[string]string, int, error) {
+	service.labelCacheMutex.Lock()
+	cachedLabels, cached := service.labelCache[version.Name]
+	service.labelCacheMutex.Unlock()
+	if cached {
+		return cachedLabels, 0, nil
+	}
+
+	if !strings.HasPrefix(version.Name, registryDigestPrefixConstant) {
+		return nil, 0, fmt.Errorf("%s: %q", versionDigestMissingMessageConstant, version.Name)
+	}
+
+	fetches := 1
+	manifest, manifestError := service.fetchManifest(executionContext, request, version.Name)
+	if manifestError != nil {
+		return nil, fetches, manifestError
+	}
+	if len(manifest.Manifests) > 0 {
+		fetches++
+		childDigest := manifest.Manifests[0].Digest
+		manifest, manifestError = service.fetchManifest(executionContext, request, childDigest)
+		if manifestError != nil {
+			return nil, fetches, manifestError
+		}
+	}
+	if len(manifest.Config.Digest) == 0 {
+		return nil, fetches, fmt.Errorf(manifestConfigMissingTemplateConstant, version.Name)
+	}
+
+	fetches++
+	labels, configError := service.fetchConfigLabels(executionContext, request, manifest.Config.Digest)
+	if configError != nil {
+		return nil, fetches, configError
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	service.labelCacheMutex.Lock()
+	service.labelCache[version.Name] = labels
+	service.labelCacheMutex.Unlock()
+	return labels, fetches, nil
+}
+
+func (service *PackageVersionService) fetchConfigLabels(executionContext context.Context, request PurgeRequest, digest string) (map[string]string, error) {
+	blobURL, urlBuildError := service.buildBlobURL(request.Owner, request.PackageName, digest)
+	if urlBuildError != nil {
+		return nil, urlBuildError
+	}
+
+	httpRequest, requestCreationError := http.NewRequestWithContext(executionContext, http.MethodGet, blobURL, nil)
+	if requestCreationError != nil {
+		return nil, fmt.Errorf(requestCreationErrorTemplateConstant, http.MethodGet, blobURL, requestCreationError)
+	}
+	httpRequest.Header.Set(authorizationHeaderNameConstant, fmt.Sprintf(bearerTokenTemplateConstant, base64.StdEncoding.EncodeToString([]byte(request.Token))))
+
+	httpResponse, requestError := service.httpClient.Do(httpRequest)
+	if requestError != nil {
+		return nil, fmt.Errorf(requestExecutionErrorTemplateConstant, requestError)
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, httpResponse.Body)
+		return nil, fmt.Errorf(configBlobStatusErrorTemplateConstant, httpResponse.StatusCode, digest)
+	}
+
+	var imageConfig registryImageConfig
+	if decodeError := json.NewDecoder(httpResponse.Body).Decode(&imageConfig); decodeError != nil {
+		return nil, fmt.Errorf(configBlobDecodeErrorTemplateConstant, digest, decodeError)
+	}
+	return imageConfig.Config.Labels, nil
+}
+
+func (service *PackageVersionService) buildBlobURL(owner string, packageName string, digest string) (string, error) {
+	registryURL, parseError := url.Parse(service.registryBaseURL)
+	if parseError != nil {
+		return "", parseError
+	}
+
+	pathSegments := []string{
+		strings.TrimSuffix(registryURL.Path, "/"),
+		registryAPIVersionPathSegmentConstant,
+		url.PathEscape(strings.ToLower(owner)),
+		url.PathEscape(strings.ToLower(packageName)),
+		registryBlobsPathSegmentConstant,
+		url.PathEscape(digest),
+	}
+
+	registryURL.Path = strings.Join(pathSegments, "/")
+	registryURL.RawQuery = ""
+	return registryURL.String(), nil
+}
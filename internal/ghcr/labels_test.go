@@ -0,0 +1,142 @@
+package ghcr_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/ghcr"
+)
+
+const (
+	labelFilterVersionsPageConstant   = `[{"id":1,"name":"sha256:aaa","size":10,"metadata":{"container":{"tags":[]}}},{"id":2,"name":"sha256:bbb","size":20,"metadata":{"container":{"tags":[]}}},{"id":3,"name":"sha256:ccc","metadata":{"container":{"tags":["latest"]}}}]`
+	labelFilterImageManifestConstant  = `{"config":{"digest":"sha256:config-aaa","size":1},"layers":[]}`
+	labelFilterIndexManifestConstant  = `{"manifests":[{"digest":"sha256:child","size":1}]}`
+	labelFilterChildManifestConstant  = `{"config":{"digest":"sha256:config-bbb","size":1},"layers":[]}`
+	labelFilterMatchingConfigConstant = `{"config":{"Labels":{"org.opencontainers.image.source":"https://github.com/test-owner/test-package","run_id":"42"}}}`
+	labelFilterOtherConfigConstant    = `{"config":{"Labels":{"run_id":"7"}}}`
+)
+
+func TestPurgeUntaggedVersionsFiltersByLabels(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	httpClient := &stubHTTPClient{
+		responses: []stubHTTPResponse{
+			{response: buildHTTPResponse(http.StatusOK, labelFilterVersionsPageConstant)},
+			{response: buildHTTPResponse(http.StatusOK, labelFilterImageManifestConstant)},
+			{response: buildHTTPResponse(http.StatusOK, labelFilterMatchingConfigConstant)},
+			{response: buildHTTPResponse(http.StatusOK, labelFilterIndexManifestConstant)},
+			{response: buildHTTPResponse(http.StatusOK, labelFilterChildManifestConstant)},
+			{response: buildHTTPResponse(http.StatusOK, labelFilterOtherConfigConstant)},
+			{response: buildHTTPResponse(http.StatusOK, labelFilterVersionsPageConstant)},
+		},
+	}
+	service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), httpClient, ghcr.ServiceConfiguration{PageSize: 3})
+	require.NoError(testingInstance, serviceError)
+
+	request := ghcr.PurgeRequest{
+		Owner:        testOwnerNameConstant,
+		PackageName:  testPackageNameConstant,
+		OwnerType:    ghcr.UserOwnerType,
+		Token:        testTokenValueConstant,
+		DryRun:       true,
+		LabelFilters: []ghcr.LabelFilter{{Key: "run_id", Value: "42"}},
+	}
+
+	firstResult, firstError := service.PurgeUntaggedVersions(context.Background(), request)
+	require.NoError(testingInstance, firstError)
+	require.Equal(testingInstance, 2, firstResult.UntaggedVersions)
+	require.Equal(testingInstance, 1, firstResult.LabelMatchedVersions)
+	require.Equal(testingInstance, 5, firstResult.ManifestFetches)
+	require.Equal(testingInstance, int64(10), firstResult.ReclaimableBytes)
+	require.Equal(testingInstance, "https://ghcr.io/v2/test-owner/test-package/blobs/sha256:config-aaa", httpClient.recordedURLs[2])
+
+	cachedResult, cachedError := service.PurgeUntaggedVersions(context.Background(), request)
+	require.NoError(testingInstance, cachedError)
+	require.Equal(testingInstance, 1, cachedResult.LabelMatchedVersions)
+	require.Equal(testingInstance, 0, cachedResult.ManifestFetches)
+	require.Len(testingInstance, httpClient.recordedURLs, 7)
+}
+
+func TestSnapshotReplaysLabelFilters(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	request := ghcr.PurgeRequest{
+		Owner:        testOwnerNameConstant,
+		PackageName:  testPackageNameConstant,
+		OwnerType:    ghcr.UserOwnerType,
+		Token:        testTokenValueConstant,
+		DryRun:       true,
+		LabelFilters: []ghcr.LabelFilter{{Key: "run_id", Value: "42"}},
+	}
+
+	liveClient := &stubHTTPClient{
+		responses: []stubHTTPResponse{
+			{response: buildHTTPResponse(http.StatusOK, labelFilterVersionsPageConstant)},
+			{response: buildHTTPResponse(http.StatusOK, labelFilterImageManifestConstant)},
+			{response: buildHTTPResponse(http.StatusOK, labelFilterMatchingConfigConstant)},
+			{response: buildHTTPResponse(http.StatusOK, labelFilterIndexManifestConstant)},
+			{response: buildHTTPResponse(http.StatusOK, labelFilterChildManifestConstant)},
+			{response: buildHTTPResponse(http.StatusOK, labelFilterOtherConfigConstant)},
+			{response: buildHTTPResponse(http.StatusOK, "[]")},
+		},
+	}
+	liveService, liveServiceError := ghcr.NewPackageVersionService(zap.NewNop(), liveClient, ghcr.ServiceConfiguration{PageSize: 3})
+	require.NoError(testingInstance, liveServiceError)
+	recorder := ghcr.NewSnapshotRecorder()
+	liveService.SetVersionStore(recorder.Wrap(liveService.VersionStore()))
+	_, liveError := liveService.PurgeUntaggedVersions(context.Background(), request)
+	require.NoError(testingInstance, liveError)
+
+	replayService, replayServiceError := ghcr.NewPackageVersionService(zap.NewNop(), &stubHTTPClient{}, ghcr.ServiceConfiguration{})
+	require.NoError(testingInstance, replayServiceError)
+	replayService.SetVersionStore(ghcr.NewSnapshotVersionStore(recorder.Snapshot()))
+	replayResult, replayError := replayService.PurgeUntaggedVersions(context.Background(), request)
+	require.NoError(testingInstance, replayError)
+	require.Equal(testingInstance, 1, replayResult.LabelMatchedVersions)
+	require.Equal(testingInstance, 0, replayResult.ManifestFetches)
+
+	unlabeledSnapshot := ghcr.Snapshot{Packages: []ghcr.SnapshotPackage{{
+		Owner:       testOwnerNameConstant,
+		OwnerType:   ghcr.UserOwnerType,
+		PackageName: testPackageNameConstant,
+		Versions:    []ghcr.PackageVersion{{ID: 1, Name: "sha256:aaa"}},
+	}}}
+	replayService.SetVersionStore(ghcr.NewSnapshotVersionStore(unlabeledSnapshot))
+	_, unlabeledError := replayService.PurgeUntaggedVersions(context.Background(), request)
+	require.ErrorIs(testingInstance, unlabeledError, ghcr.ErrSnapshotLabelsNotRecorded)
+}
+
+func TestParseLabelFilter(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	testCases := []struct {
+		name           string
+		expression     string
+		expectedFilter ghcr.LabelFilter
+		expectedError  string
+	}{
+		{name: "key_value", expression: "run_id=42", expectedFilter: ghcr.LabelFilter{Key: "run_id", Value: "42"}},
+		{name: "value_with_separator", expression: "org.opencontainers.image.source=https://x/y?a=b", expectedFilter: ghcr.LabelFilter{Key: "org.opencontainers.image.source", Value: "https://x/y?a=b"}},
+		{name: "empty_value", expression: "run_id=", expectedFilter: ghcr.LabelFilter{Key: "run_id"}},
+		{name: "missing_separator", expression: "run_id", expectedError: `label filter "run_id" must have the form key=value`},
+		{name: "missing_key", expression: "=42", expectedError: `label filter "=42" must have the form key=value`},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testingInstance.Run(testCase.name, func(subtest *testing.T) {
+			subtest.Parallel()
+			labelFilter, parseError := ghcr.ParseLabelFilter(testCase.expression)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, parseError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, parseError)
+			require.Equal(subtest, testCase.expectedFilter, labelFilter)
+		})
+	}
+}
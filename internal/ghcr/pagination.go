@@ -0,0 +1,157 @@
+package ghcr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	linkHeaderNameConstant               = "Link"
+	linkEntrySeparatorConstant           = ","
+	linkParameterSeparatorConstant       = ";"
+	linkRelationParameterNameConstant    = "rel"
+	linkNextRelationConstant             = "next"
+	linkTargetOpeningConstant            = "<"
+	linkTargetClosingConstant            = ">"
+	linkParameterQuoteCharactersConstant = "\""
+	linkParameterAssignmentConstant      = "="
+	paginationRevisitErrorTemplate       = "pagination returned already fetched page %s"
+	paginationForeignHostErrorTemplate   = "pagination link %s leaves %s"
+	paginationInvalidLinkErrorTemplate   = "invalid pagination link %q: %w"
+	pageURLLogFieldNameConstant          = "page_url"
+	pageItemCountLogFieldNameConstant    = "page_items"
+)
+
+// pageDecoder decodes one page body, keeps its items, and returns how many it held.
+type pageDecoder func(body io.Reader) (int, error)
+
+// fetchLinkedPages requests firstURL and then every URL named by the rel="next" entry of the RFC 5988 Link header until
+// a response carries none. Following the links instead of counting pages keeps the iteration correct when GitHub
+// changes page sizes, and a URL is never requested twice. Links that leave the host of firstURL are refused so the
+// token is only ever sent to the API it was issued for.
+func (service *PackageVersionService) fetchLinkedPages(executionContext context.Context, firstURL string, token string, pageMessage string, decodePage pageDecoder, logFields ...zap.Field) error {
+	firstPage, parseError := url.Parse(firstURL)
+	if parseError != nil {
+		return parseError
+	}
+
+	fetchedURLs := map[string]struct{}{}
+	pageURL := firstURL
+	for pageNumber := 1; len(pageURL) > 0; pageNumber++ {
+		if _, fetched := fetchedURLs[pageURL]; fetched {
+			return fmt.Errorf(paginationRevisitErrorTemplate, pageURL)
+		}
+		fetchedURLs[pageURL] = struct{}{}
+
+		linkHeader, itemCount, fetchError := service.fetchLinkedPage(executionContext, pageURL, token, decodePage)
+		if fetchError != nil {
+			return fetchError
+		}
+
+		pageFields := append([]zap.Field{
+			zap.Int(pageLogFieldNameConstant, pageNumber),
+			zap.String(pageURLLogFieldNameConstant, pageURL),
+			zap.Int(pageItemCountLogFieldNameConstant, itemCount),
+		}, logFields...)
+		service.logger.Debug(pageMessage, pageFields...)
+
+		nextURL, linkError := nextPageURL(pageURL, linkHeader)
+		if linkError != nil {
+			return linkError
+		}
+		if len(nextURL) > 0 && !sameOrigin(firstPage, nextURL) {
+			return fmt.Errorf(paginationForeignHostErrorTemplate, nextURL, firstPage.Host)
+		}
+		pageURL = nextURL
+	}
+
+	return nil
+}
+
+func (service *PackageVersionService) fetchLinkedPage(executionContext context.Context, pageURL string, token string, decodePage pageDecoder) (string, int, error) {
+	httpRequest, requestCreationError := http.NewRequestWithContext(executionContext, http.MethodGet, pageURL, nil)
+	if requestCreationError != nil {
+		return "", 0, fmt.Errorf(requestCreationErrorTemplateConstant, http.MethodGet, pageURL, requestCreationError)
+	}
+
+	httpRequest.Header.Set(acceptHeaderNameConstant, acceptHeaderValueConstant)
+	httpRequest.Header.Set(authorizationHeaderNameConstant, fmt.Sprintf(bearerTokenTemplateConstant, token))
+
+	httpResponse, requestError := service.httpClient.Do(httpRequest)
+	if requestError != nil {
+		return "", 0, fmt.Errorf(requestExecutionErrorTemplateConstant, requestError)
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		responseBody, _ := io.ReadAll(httpResponse.Body)
+		return "", 0, fmt.Errorf(
+			unexpectedStatusCodeWithBodyTemplateConstant,
+			httpResponse.StatusCode,
+			http.MethodGet,
+			pageURL,
+			strings.TrimSpace(string(responseBody)),
+		)
+	}
+
+	itemCount, decodeError := decodePage(httpResponse.Body)
+	if decodeError != nil {
+		return "", 0, decodeError
+	}
+	return httpResponse.Header.Get(linkHeaderNameConstant), itemCount, nil
+}
+
+// nextPageURL returns the rel="next" target of a Link header resolved against the current page URL, or an empty string
+// when the header names no next page.
+func nextPageURL(currentURL string, linkHeader string) (string, error) {
+	for _, entry := range strings.Split(linkHeader, linkEntrySeparatorConstant) {
+		segments := strings.Split(entry, linkParameterSeparatorConstant)
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, linkTargetOpeningConstant) || !strings.HasSuffix(target, linkTargetClosingConstant) {
+			continue
+		}
+		if !linkHasNextRelation(segments[1:]) {
+			continue
+		}
+
+		base, baseError := url.Parse(currentURL)
+		if baseError != nil {
+			return "", baseError
+		}
+		reference, referenceError := url.Parse(strings.TrimSuffix(strings.TrimPrefix(target, linkTargetOpeningConstant), linkTargetClosingConstant))
+		if referenceError != nil {
+			return "", fmt.Errorf(paginationInvalidLinkErrorTemplate, target, referenceError)
+		}
+		return base.ResolveReference(reference).String(), nil
+	}
+	return "", nil
+}
+
+func linkHasNextRelation(parameters []string) bool {
+	for _, parameter := range parameters {
+		name, value, found := strings.Cut(strings.TrimSpace(parameter), linkParameterAssignmentConstant)
+		if !found || !strings.EqualFold(strings.TrimSpace(name), linkRelationParameterNameConstant) {
+			continue
+		}
+		for _, relation := range strings.Fields(strings.Trim(strings.TrimSpace(value), linkParameterQuoteCharactersConstant)) {
+			if strings.EqualFold(relation, linkNextRelationConstant) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sameOrigin(firstPage *url.URL, candidateURL string) bool {
+	candidate, parseError := url.Parse(candidateURL)
+	if parseError != nil {
+		return false
+	}
+	return strings.EqualFold(candidate.Scheme, firstPage.Scheme) && strings.EqualFold(candidate.Host, firstPage.Host)
+}
@@ -0,0 +1,175 @@
+package ghcr_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/ghcr"
+)
+
+// offsetPagedRegistry serves package versions the way GitHub does: page N holds the versions at offset (N-1)*per_page
+// of the current listing, so every deletion shifts the versions behind it one slot forward.
+type offsetPagedRegistry struct {
+	mutex         sync.Mutex
+	versionIDs    []int64
+	requestedURLs []string
+	deletedIDs    []int64
+	linkTemplate  string
+}
+
+func (registry *offsetPagedRegistry) ServeHTTP(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if httpRequest.Method == http.MethodDelete {
+		versionID, parseError := parseVersionIdentifierFromPath(httpRequest.URL.Path)
+		if parseError != nil {
+			responseWriter.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		registry.deletedIDs = append(registry.deletedIDs, versionID)
+		for index, remainingID := range registry.versionIDs {
+			if remainingID == versionID {
+				registry.versionIDs = append(registry.versionIDs[:index], registry.versionIDs[index+1:]...)
+				break
+			}
+		}
+		responseWriter.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	registry.requestedURLs = append(registry.requestedURLs, httpRequest.URL.String())
+	pageSize, _ := strconv.Atoi(httpRequest.URL.Query().Get("per_page"))
+	pageNumber, pageError := strconv.Atoi(httpRequest.URL.Query().Get("page"))
+	if pageError != nil {
+		pageNumber = 1
+	}
+
+	start := min((pageNumber-1)*pageSize, len(registry.versionIDs))
+	end := min(start+pageSize, len(registry.versionIDs))
+	if end < len(registry.versionIDs) {
+		responseWriter.Header().Set("Link", fmt.Sprintf(registry.linkTemplate, httpRequest.URL.Path, pageSize, pageNumber+1))
+	}
+
+	page := make([]map[string]any, 0, end-start)
+	for _, versionID := range registry.versionIDs[start:end] {
+		page = append(page, map[string]any{"id": versionID, "metadata": map[string]any{"container": map[string]any{"tags": []string{}}}})
+	}
+	responseWriter.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(responseWriter).Encode(page)
+}
+
+func TestPurgeUntaggedVersionsFollowsLinkHeaders(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	testCases := []struct {
+		name         string
+		linkTemplate string
+	}{
+		{
+			name:         "relative_next_link",
+			linkTemplate: `<%s?per_page=%d&page=%d>; rel="next"`,
+		},
+		{
+			name:         "next_listed_after_other_relations",
+			linkTemplate: `<%[1]s?per_page=%[2]d&page=1>; rel="first", <%[1]s?per_page=%[2]d&page=%[3]d>; rel="next"`,
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+		testingInstance.Run(testCase.name, func(testingSubInstance *testing.T) {
+			testingSubInstance.Parallel()
+
+			registry := &offsetPagedRegistry{versionIDs: []int64{1, 2, 3, 4, 5}, linkTemplate: testCase.linkTemplate}
+			server := httptest.NewServer(registry)
+			defer server.Close()
+
+			service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), server.Client(), ghcr.ServiceConfiguration{BaseURL: server.URL, PageSize: 2})
+			require.NoError(testingSubInstance, serviceError)
+
+			result, purgeError := service.PurgeUntaggedVersions(context.Background(), ghcr.PurgeRequest{
+				Owner:       testOwnerNameConstant,
+				PackageName: testPackageNameConstant,
+				OwnerType:   ghcr.UserOwnerType,
+				Token:       testTokenValueConstant,
+			})
+			require.NoError(testingSubInstance, purgeError)
+			require.Equal(testingSubInstance, 5, result.TotalVersions)
+			require.Equal(testingSubInstance, 5, result.DeletedVersions)
+			require.Equal(testingSubInstance, []int64{1, 2, 3, 4, 5}, registry.deletedIDs)
+			require.Empty(testingSubInstance, registry.versionIDs)
+			require.Len(testingSubInstance, registry.requestedURLs, 3)
+
+			seenURLs := map[string]struct{}{}
+			for _, requestedURL := range registry.requestedURLs {
+				_, seen := seenURLs[requestedURL]
+				require.False(testingSubInstance, seen, requestedURL)
+				seenURLs[requestedURL] = struct{}{}
+			}
+		})
+	}
+}
+
+func TestListVersionsRejectsUnsafePaginationLinks(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	testCases := []struct {
+		name          string
+		linkHeader    func(serverURL string, requestPath string) string
+		expectedError func(server *httptest.Server) string
+	}{
+		{
+			name: "link_to_same_page",
+			linkHeader: func(serverURL string, requestPath string) string {
+				return fmt.Sprintf(`<%s%s?per_page=100>; rel="next"`, serverURL, requestPath)
+			},
+			expectedError: func(server *httptest.Server) string {
+				return "pagination returned already fetched page " + server.URL + "/users/test-owner/packages/container/test-package/versions?per_page=100"
+			},
+		},
+		{
+			name: "link_to_foreign_host",
+			linkHeader: func(string, string) string {
+				return `<https://elsewhere.example/versions?page=2>; rel="next"`
+			},
+			expectedError: func(server *httptest.Server) string {
+				return "pagination link https://elsewhere.example/versions?page=2 leaves " + server.Listener.Addr().String()
+			},
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+		testingInstance.Run(testCase.name, func(testingSubInstance *testing.T) {
+			testingSubInstance.Parallel()
+
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				responseWriter.Header().Set("Link", testCase.linkHeader(server.URL, httpRequest.URL.Path))
+				_, _ = responseWriter.Write([]byte(`[{"id":1,"metadata":{"container":{"tags":["latest"]}}}]`))
+			}))
+			defer server.Close()
+
+			service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), server.Client(), ghcr.ServiceConfiguration{BaseURL: server.URL})
+			require.NoError(testingSubInstance, serviceError)
+
+			_, countError := service.CountVersions(context.Background(), ghcr.PurgeRequest{
+				Owner:       testOwnerNameConstant,
+				PackageName: testPackageNameConstant,
+				OwnerType:   ghcr.UserOwnerType,
+				Token:       testTokenValueConstant,
+			})
+			require.EqualError(testingSubInstance, countError, testCase.expectedError(server))
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package ghcr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	oauthScopesHeaderNameConstant               = "X-OAuth-Scopes"
+	oauthScopeSeparatorConstant                 = ","
+	deletePackagesScopeConstant                 = "delete:packages"
+	permissionProbePageSizeConstant             = 1
+	permissionSentinelVersionIDConstant         = int64(0)
+	deletePermissionMissingTemplateConstant     = "token lacks %s for %s %s"
+	deletePermissionScopesDetailTemplate        = " (token scopes: %s)"
+	deletePermissionNoScopesDetailConstant      = "none"
+	permissionProbeUnexpectedStatusTemplate     = "unable to determine delete permission for %s %s: %s %s returned status %d: %s"
+	permissionCheckMessageConstant              = "Checked GHCR delete permission"
+	permissionCheckMethodLogFieldNameConstant   = "method"
+	permissionCheckAllowedLogFieldNameConstant  = "allowed"
+	permissionCheckMethodOAuthScopesConstant    = "oauth_scopes"
+	permissionCheckMethodSentinelDeleteConstant = "sentinel_delete"
+)
+
+// DeletePermissionError reports a token that cannot delete packages of the owner. Scopes lists the classic token scopes
+// GitHub reported and is empty for fine-grained, app, and GITHUB_TOKEN tokens, which carry no scope header.
+type DeletePermissionError struct {
+	Owner     string
+	OwnerType OwnerType
+	Scopes    []string
+	Classic   bool
+}
+
+// Error describes the missing permission.
+func (permissionError DeletePermissionError) Error() string {
+	message := fmt.Sprintf(deletePermissionMissingTemplateConstant, deletePackagesScopeConstant, permissionError.OwnerType, permissionError.Owner)
+	if !permissionError.Classic {
+		return message
+	}
+	scopes := deletePermissionNoScopesDetailConstant
+	if len(permissionError.Scopes) > 0 {
+		scopes = strings.Join(permissionError.Scopes, oauthScopeSeparatorConstant+" ")
+	}
+	return message + fmt.Sprintf(deletePermissionScopesDetailTemplate, scopes)
+}
+
+// CheckDeletePermission verifies that the request's token may delete versions of the package before a purge deletes
+// anything. Classic tokens are judged by the X-OAuth-Scopes header of a one-version listing. Tokens without that header
+// are probed with a DELETE of a version ID that never exists: 403 means the token lacks the permission, while 404 means
+// the request was authorized and found nothing to delete. A missing permission is returned as a DeletePermissionError.
+func (service *PackageVersionService) CheckDeletePermission(executionContext context.Context, request PurgeRequest) error {
+	normalizedRequest, normalizationError := normalizePurgeRequest(request)
+	if normalizationError != nil {
+		return normalizationError
+	}
+
+	probeURL, urlBuildError := service.buildVersionsURL(normalizedRequest.OwnerType, normalizedRequest.Owner, normalizedRequest.PackageName)
+	if urlBuildError != nil {
+		return urlBuildError
+	}
+	probeURL, urlBuildError = withPageSize(probeURL, permissionProbePageSizeConstant)
+	if urlBuildError != nil {
+		return urlBuildError
+	}
+
+	probeResponse, probeError := service.sendPermissionProbe(executionContext, http.MethodGet, probeURL, normalizedRequest.Token)
+	if probeError != nil {
+		return probeError
+	}
+	defer probeResponse.Body.Close()
+
+	if probeResponse.StatusCode != http.StatusOK {
+		return permissionProbeStatusError(normalizedRequest, http.MethodGet, probeURL, probeResponse)
+	}
+
+	if scopeValues, classicToken := probeResponse.Header[http.CanonicalHeaderKey(oauthScopesHeaderNameConstant)]; classicToken {
+		scopes := parseOAuthScopes(scopeValues)
+		allowed := containsScope(scopes, deletePackagesScopeConstant)
+		service.logPermissionCheck(normalizedRequest, permissionCheckMethodOAuthScopesConstant, allowed)
+		if !allowed {
+			return DeletePermissionError{Owner: normalizedRequest.Owner, OwnerType: normalizedRequest.OwnerType, Scopes: scopes, Classic: true}
+		}
+		return nil
+	}
+
+	sentinelURL, sentinelURLError := service.buildVersionURL(normalizedRequest.OwnerType, normalizedRequest.Owner, normalizedRequest.PackageName, permissionSentinelVersionIDConstant)
+	if sentinelURLError != nil {
+		return sentinelURLError
+	}
+
+	sentinelResponse, sentinelError := service.sendPermissionProbe(executionContext, http.MethodDelete, sentinelURL, normalizedRequest.Token)
+	if sentinelError != nil {
+		return sentinelError
+	}
+	defer sentinelResponse.Body.Close()
+
+	switch sentinelResponse.StatusCode {
+	case http.StatusNotFound:
+		service.logPermissionCheck(normalizedRequest, permissionCheckMethodSentinelDeleteConstant, true)
+		return nil
+	case http.StatusForbidden:
+		service.logPermissionCheck(normalizedRequest, permissionCheckMethodSentinelDeleteConstant, false)
+		return DeletePermissionError{Owner: normalizedRequest.Owner, OwnerType: normalizedRequest.OwnerType}
+	default:
+		return permissionProbeStatusError(normalizedRequest, http.MethodDelete, sentinelURL, sentinelResponse)
+	}
+}
+
+func (service *PackageVersionService) sendPermissionProbe(executionContext context.Context, method string, targetURL string, token string) (*http.Response, error) {
+	probeRequest, requestCreationError := http.NewRequestWithContext(executionContext, method, targetURL, nil)
+	if requestCreationError != nil {
+		return nil, fmt.Errorf(requestCreationErrorTemplateConstant, method, targetURL, requestCreationError)
+	}
+
+	probeRequest.Header.Set(acceptHeaderNameConstant, acceptHeaderValueConstant)
+	probeRequest.Header.Set(authorizationHeaderNameConstant, fmt.Sprintf(bearerTokenTemplateConstant, token))
+
+	probeResponse, probeError := service.httpClient.Do(probeRequest)
+	if probeError != nil {
+		return nil, fmt.Errorf(requestExecutionErrorTemplateConstant, probeError)
+	}
+	return probeResponse, nil
+}
+
+func (service *PackageVersionService) logPermissionCheck(request PurgeRequest, method string, allowed bool) {
+	service.logger.Info(
+		permissionCheckMessageConstant,
+		zap.String(ownerLogFieldNameConstant, request.Owner),
+		zap.String(packageLogFieldNameConstant, request.PackageName),
+		zap.String(ownerTypeLogFieldNameConstant, string(request.OwnerType)),
+		zap.String(permissionCheckMethodLogFieldNameConstant, method),
+		zap.Bool(permissionCheckAllowedLogFieldNameConstant, allowed),
+	)
+}
+
+func permissionProbeStatusError(request PurgeRequest, method string, targetURL string, response *http.Response) error {
+	responseBody, _ := io.ReadAll(response.Body)
+	return fmt.Errorf(permissionProbeUnexpectedStatusTemplate, request.OwnerType, request.Owner, method, targetURL, response.StatusCode, strings.TrimSpace(string(responseBody)))
+}
+
+func withPageSize(listingURL string, pageSize int) (string, error) {
+	parsedURL, parseError := url.Parse(listingURL)
+	if parseError != nil {
+		return "", parseError
+	}
+	queryParameters := parsedURL.Query()
+	queryParameters.Set(perPageQueryParameterNameConstant, fmt.Sprintf("%d", pageSize))
+	parsedURL.RawQuery = queryParameters.Encode()
+	return parsedURL.String(), nil
+}
+
+func parseOAuthScopes(headerValues []string) []string {
+	scopes := make([]string, 0)
+	for _, headerValue := range headerValues {
+		for _, scope := range strings.Split(headerValue, oauthScopeSeparatorConstant) {
+			trimmedScope := strings.TrimSpace(scope)
+			if len(trimmedScope) > 0 {
+				scopes = append(scopes, trimmedScope)
+			}
+		}
+	}
+	return scopes
+}
+
+func containsScope(scopes []string, expectedScope string) bool {
+	for _, scope := range scopes {
+		if strings.EqualFold(scope, expectedScope) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package ghcr_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/ghcr"
+)
+
+func TestPackageVersionServiceCheckDeletePermission(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	classicResponse := func(scopes string) *http.Response {
+		response := buildHTTPResponse(http.StatusOK, "[]")
+		response.Header.Set("X-OAuth-Scopes", scopes)
+		return response
+	}
+
+	testCases := []struct {
+		name            string
+		responses       []stubHTTPResponse
+		expectedError   string
+		expectedMethods []string
+		expectedURLs    []string
+	}{
+		{
+			name:            "classic_token_with_delete_scope",
+			responses:       []stubHTTPResponse{{response: classicResponse("repo, write:packages, delete:packages")}},
+			expectedMethods: []string{http.MethodGet},
+			expectedURLs:    []string{"https://api.github.com/orgs/test-owner/packages/container/test-package/versions?per_page=1"},
+		},
+		{
+			name:            "classic_token_without_delete_scope",
+			responses:       []stubHTTPResponse{{response: classicResponse("repo, write:packages")}},
+			expectedError:   "token lacks delete:packages for org test-owner (token scopes: repo, write:packages)",
+			expectedMethods: []string{http.MethodGet},
+		},
+		{
+			name:            "classic_token_without_scopes",
+			responses:       []stubHTTPResponse{{response: classicResponse("")}},
+			expectedError:   "token lacks delete:packages for org test-owner (token scopes: none)",
+			expectedMethods: []string{http.MethodGet},
+		},
+		{
+			name: "fine_grained_token_authorized",
+			responses: []stubHTTPResponse{
+				{response: buildHTTPResponse(http.StatusOK, "[]")},
+				{response: buildHTTPResponse(http.StatusNotFound, `{"message":"Not Found"}`)},
+			},
+			expectedMethods: []string{http.MethodGet, http.MethodDelete},
+			expectedURLs: []string{
+				"https://api.github.com/orgs/test-owner/packages/container/test-package/versions?per_page=1",
+				"https://api.github.com/orgs/test-owner/packages/container/test-package/versions/0",
+			},
+		},
+		{
+			name: "fine_grained_token_forbidden",
+			responses: []stubHTTPResponse{
+				{response: buildHTTPResponse(http.StatusOK, "[]")},
+				{response: buildHTTPResponse(http.StatusForbidden, `{"message":"Resource not accessible by integration"}`)},
+			},
+			expectedError:   "token lacks delete:packages for org test-owner",
+			expectedMethods: []string{http.MethodGet, http.MethodDelete},
+		},
+		{
+			name: "sentinel_unexpected_status",
+			responses: []stubHTTPResponse{
+				{response: buildHTTPResponse(http.StatusOK, "[]")},
+				{response: buildHTTPResponse(http.StatusUnauthorized, `{"message":"Bad credentials"}`)},
+			},
+			expectedError:   `unable to determine delete permission for org test-owner: DELETE https://api.github.com/orgs/test-owner/packages/container/test-package/versions/0 returned status 401: {"message":"Bad credentials"}`,
+			expectedMethods: []string{http.MethodGet, http.MethodDelete},
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+		testingInstance.Run(testCase.name, func(testingSubInstance *testing.T) {
+			testingSubInstance.Parallel()
+
+			client := &stubHTTPClient{responses: testCase.responses}
+			service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), client, ghcr.ServiceConfiguration{})
+			require.NoError(testingSubInstance, serviceError)
+
+			permissionError := service.CheckDeletePermission(context.Background(), ghcr.PurgeRequest{
+				Owner:       testOwnerNameConstant,
+				PackageName: testPackageNameConstant,
+				OwnerType:   ghcr.OrganizationOwnerType,
+				Token:       testTokenValueConstant,
+			})
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(testingSubInstance, permissionError, testCase.expectedError)
+			} else {
+				require.NoError(testingSubInstance, permissionError)
+			}
+			require.Equal(testingSubInstance, testCase.expectedMethods, client.recordedMethods)
+			if testCase.expectedURLs != nil {
+				require.Equal(testingSubInstance, testCase.expectedURLs, client.recordedURLs)
+			}
+		})
+	}
+}
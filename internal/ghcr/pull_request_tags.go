@@ -0,0 +1,183 @@
+package ghcr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PullRequestStateOpen marks a pull request that is still open; its versions are kept.
+	PullRequestStateOpen = "open"
+	// PullRequestStateClosed marks a pull request closed without merging; its versions are purge candidates.
+	PullRequestStateClosed = "closed"
+	// PullRequestStateMerged marks a merged pull request; its versions are purge candidates.
+	PullRequestStateMerged = "merged"
+	// PullRequestStateUnknown marks a pull request whose state could not be resolved; its versions are kept.
+	PullRequestStateUnknown = "unknown"
+
+	pullRequestTagPrefixMissingMessageConstant   = "pull request tag prefix must be provided"
+	pullRequestStateLookupMissingMessageConstant = "pull request state lookup must be provided"
+	pullRequestStateLookupErrorTemplateConstant  = "unable to resolve pull request states: %w"
+	pullRequestPurgeStartMessageConstant         = "Starting GHCR pull request version purge"
+	pullRequestPurgeCompleteMessageConstant      = "Completed GHCR pull request version purge"
+	pullRequestTagPrefixLogFieldNameConstant     = "pr_tag_prefix"
+	pullRequestVersionsLogFieldNameConstant      = "pull_request_versions"
+	pullRequestNumbersLogFieldNameConstant       = "pull_request_numbers"
+)
+
+// PullRequestStateLookup resolves pull request states by number. Numbers absent from the result are treated as
+// unknown, which keeps their versions.
+type PullRequestStateLookup interface {
+	ResolvePullRequestStates(executionContext context.Context, pullRequestNumbers []int) (map[int]string, error)
+}
+
+// PullRequestTaggedVersion records one pull request tag of a version and the pull request state it resolved to.
+// Candidate is shared by every tag of the version: it is true only when all of them name closed or merged pull requests
+// and the version carries no tag outside the pull request prefix.
+type PullRequestTaggedVersion struct {
+	VersionID         int64
+	Tag               string
+	PullRequestNumber int
+	State             string
+	Candidate         bool
+}
+
+// ParsePullRequestTag extracts the pull request number from a tag of the form <prefix><number>.
+func ParsePullRequestTag(tag string, prefix string) (int, bool) {
+	trimmedPrefix := strings.TrimSpace(prefix)
+	if len(trimmedPrefix) == 0 || !strings.HasPrefix(tag, trimmedPrefix) {
+		return 0, false
+	}
+	pullRequestNumber, parseError := strconv.Atoi(strings.TrimPrefix(tag, trimmedPrefix))
+	if parseError != nil || pullRequestNumber <= 0 {
+		return 0, false
+	}
+	return pullRequestNumber, true
+}
+
+// PurgePullRequestVersions removes versions tagged with the request's pull request tag prefix once every pull request
+// they name is closed or merged. Versions of open pull requests, of pull requests whose state is unknown, versions
+// that also carry a tag outside the prefix, such as latest, and versions without such a tag are kept.
+func (service *PackageVersionService) PurgePullRequestVersions(executionContext context.Context, request PurgeRequest) (PurgeResult, error) {
+	request, validationError := normalizePurgeRequest(request)
+	if validationError != nil {
+		return PurgeResult{}, validationError
+	}
+	request.PullRequestTagPrefix = strings.TrimSpace(request.PullRequestTagPrefix)
+	if len(request.PullRequestTagPrefix) == 0 {
+		return PurgeResult{}, errors.New(pullRequestTagPrefixMissingMessageConstant)
+	}
+	if request.PullRequestStates == nil {
+		return PurgeResult{}, errors.New(pullRequestStateLookupMissingMessageConstant)
+	}
+
+	service.logger.Info(
+		pullRequestPurgeStartMessageConstant,
+		zap.String(ownerLogFieldNameConstant, request.Owner),
+		zap.String(packageLogFieldNameConstant, request.PackageName),
+		zap.String(pullRequestTagPrefixLogFieldNameConstant, request.PullRequestTagPrefix),
+		zap.Bool(dryRunLogFieldNameConstant, request.DryRun),
+	)
+
+	result := PurgeResult{}
+	versions, fetchError := service.store.ListVersions(executionContext, request)
+	if fetchError != nil {
+		return result, fetchError
+	}
+	result.TotalVersions = len(versions)
+
+	versionNumbers := make([][]pullRequestTag, len(versions))
+	sharedVersions := make([]bool, len(versions))
+	uniqueNumbers := map[int]struct{}{}
+	for versionIndex, version := range versions {
+		if !version.HasTags() {
+			result.UntaggedVersions++
+			continue
+		}
+		for _, tag := range version.Metadata.Container.Tags {
+			pullRequestNumber, matched := ParsePullRequestTag(tag, request.PullRequestTagPrefix)
+			if !matched {
+				sharedVersions[versionIndex] = true
+				continue
+			}
+			versionNumbers[versionIndex] = append(versionNumbers[versionIndex], pullRequestTag{tag: tag, number: pullRequestNumber})
+			uniqueNumbers[pullRequestNumber] = struct{}{}
+		}
+	}
+
+	pullRequestNumbers := make([]int, 0, len(uniqueNumbers))
+	for pullRequestNumber := range uniqueNumbers {
+		pullRequestNumbers = append(pullRequestNumbers, pullRequestNumber)
+	}
+	sort.Ints(pullRequestNumbers)
+
+	states := map[int]string{}
+	if len(pullRequestNumbers) > 0 {
+		resolvedStates, lookupError := request.PullRequestStates.ResolvePullRequestStates(executionContext, pullRequestNumbers)
+		if lookupError != nil {
+			return result, fmt.Errorf(pullRequestStateLookupErrorTemplateConstant, lookupError)
+		}
+		states = resolvedStates
+	}
+
+	gate := newApprovalGate(request)
+	for versionIndex, version := range versions {
+		tags := versionNumbers[versionIndex]
+		if len(tags) == 0 {
+			continue
+		}
+
+		candidate := !sharedVersions[versionIndex]
+		evaluated := make([]PullRequestTaggedVersion, 0, len(tags))
+		for _, tag := range tags {
+			state, known := states[tag.number]
+			if !known {
+				state = PullRequestStateUnknown
+			}
+			if state != PullRequestStateClosed && state != PullRequestStateMerged {
+				candidate = false
+			}
+			evaluated = append(evaluated, PullRequestTaggedVersion{VersionID: version.ID, Tag: tag.tag, PullRequestNumber: tag.number, State: state})
+		}
+		for evaluatedIndex := range evaluated {
+			evaluated[evaluatedIndex].Candidate = candidate
+		}
+		result.PullRequestVersions = append(result.PullRequestVersions, evaluated...)
+
+		if !candidate || !gate.admits(version) {
+			continue
+		}
+		if deleteError := service.purgeVersion(executionContext, request, version, pullRequestPurgeReason(evaluated), "", &result); deleteError != nil {
+			return result, deleteError
+		}
+	}
+	if approvalError := service.purgeUnselectedApprovals(executionContext, request, versions, gate, &result); approvalError != nil {
+		return result, approvalError
+	}
+
+	service.logger.Info(
+		pullRequestPurgeCompleteMessageConstant,
+		zap.String(ownerLogFieldNameConstant, request.Owner),
+		zap.String(packageLogFieldNameConstant, request.PackageName),
+		zap.Int(totalVersionsLogFieldNameConstant, result.TotalVersions),
+		zap.Int(pullRequestVersionsLogFieldNameConstant, len(result.PullRequestVersions)),
+		zap.Int(pullRequestNumbersLogFieldNameConstant, len(pullRequestNumbers)),
+		zap.Int(deletedVersionsLogFieldNameConstant, result.DeletedVersions),
+		zap.Int(retainedVersionsLogFieldNameConstant, result.RetainedVersions),
+		zap.Int(failedVersionsLogFieldNameConstant, len(result.Failures)),
+		zap.Int64(reclaimableBytesLogFieldNameConstant, result.ReclaimableBytes),
+	)
+
+	return result, nil
+}
+
+type pullRequestTag struct {
+	tag    string
+	number int
+}
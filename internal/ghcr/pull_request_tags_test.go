@@ -0,0 +1,161 @@
+package ghcr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/ghcr"
+)
+
+const testPullRequestTagPrefixConstant = "pr-"
+
+type recordingVersionStore struct {
+	versions   []ghcr.PackageVersion
+	deletedIDs []int64
+}
+
+func (store *recordingVersionStore) ListVersions(context.Context, ghcr.PurgeRequest) ([]ghcr.PackageVersion, error) {
+	return store.versions, nil
+}
+
+func (store *recordingVersionStore) VersionSize(_ context.Context, _ ghcr.PurgeRequest, version ghcr.PackageVersion) int64 {
+	return version.ID
+}
+
+func (store *recordingVersionStore) DeleteVersion(_ context.Context, _ ghcr.PurgeRequest, versionID int64) error {
+	store.deletedIDs = append(store.deletedIDs, versionID)
+	return nil
+}
+
+func (store *recordingVersionStore) VersionLabels(context.Context, ghcr.PurgeRequest, ghcr.PackageVersion) (map[string]string, int, error) {
+	return nil, 0, nil
+}
+
+type stubPullRequestStateLookup struct {
+	states         map[int]string
+	lookupError    error
+	requestedBatch []int
+	lookups        int
+}
+
+func (lookup *stubPullRequestStateLookup) ResolvePullRequestStates(_ context.Context, pullRequestNumbers []int) (map[int]string, error) {
+	lookup.lookups++
+	lookup.requestedBatch = append([]int{}, pullRequestNumbers...)
+	return lookup.states, lookup.lookupError
+}
+
+func taggedVersion(versionID int64, tags ...string) ghcr.PackageVersion {
+	return ghcr.PackageVersion{ID: versionID, Metadata: ghcr.PackageVersionMetadata{Container: ghcr.PackageVersionContainerMetadata{Tags: tags}}}
+}
+
+func TestParsePullRequestTag(testingInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		tag            string
+		expectedNumber int
+		expectedMatch  bool
+	}{
+		{name: "matches_prefix_and_number", tag: "pr-42", expectedNumber: 42, expectedMatch: true},
+		{name: "rejects_other_prefix", tag: "sha-42"},
+		{name: "rejects_non_numeric_suffix", tag: "pr-42-amd64"},
+		{name: "rejects_zero", tag: "pr-0"},
+		{name: "rejects_bare_prefix", tag: "pr-"},
+	}
+
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subtest *testing.T) {
+			pullRequestNumber, matched := ghcr.ParsePullRequestTag(testCase.tag, testPullRequestTagPrefixConstant)
+			require.Equal(subtest, testCase.expectedMatch, matched)
+			require.Equal(subtest, testCase.expectedNumber, pullRequestNumber)
+		})
+	}
+}
+
+func TestPurgePullRequestVersions(testingInstance *testing.T) {
+	versions := []ghcr.PackageVersion{
+		taggedVersion(11, "pr-1", "sha-aaa"),
+		taggedVersion(12, "pr-2"),
+		taggedVersion(13, "pr-3"),
+		taggedVersion(14, "pr-4"),
+		taggedVersion(15, "pr-1", "pr-2"),
+		taggedVersion(16, "latest"),
+		{ID: 17},
+		taggedVersion(18, "pr-1", "pr-3"),
+	}
+	states := map[int]string{1: ghcr.PullRequestStateMerged, 2: ghcr.PullRequestStateOpen, 3: ghcr.PullRequestStateClosed}
+
+	testCases := []struct {
+		name                string
+		dryRun              bool
+		lookupError         error
+		expectedDeletedIDs  []int64
+		expectedDeleted     int
+		expectedReclaimable int64
+		expectedError       string
+	}{
+		{
+			name:                "deletes_versions_of_closed_and_merged_pull_requests",
+			expectedDeletedIDs:  []int64{13, 18},
+			expectedDeleted:     2,
+			expectedReclaimable: 31,
+		},
+		{
+			name:                "dry_run_sizes_candidates_only",
+			dryRun:              true,
+			expectedReclaimable: 31,
+		},
+		{
+			name:          "lookup_failures_abort_before_deleting",
+			lookupError:   errors.New("graphql unavailable"),
+			expectedError: "unable to resolve pull request states: graphql unavailable",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subtest *testing.T) {
+			store := &recordingVersionStore{versions: versions}
+			lookup := &stubPullRequestStateLookup{states: states, lookupError: testCase.lookupError}
+			service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), &stubHTTPClient{}, ghcr.ServiceConfiguration{})
+			require.NoError(subtest, serviceError)
+			service.SetVersionStore(store)
+
+			result, purgeError := service.PurgePullRequestVersions(context.Background(), ghcr.PurgeRequest{
+				Owner:                testOwnerNameConstant,
+				PackageName:          testPackageNameConstant,
+				OwnerType:            ghcr.UserOwnerType,
+				Token:                testTokenValueConstant,
+				DryRun:               testCase.dryRun,
+				PullRequestTagPrefix: testPullRequestTagPrefixConstant,
+				PullRequestStates:    lookup,
+			})
+			require.Equal(subtest, 1, lookup.lookups)
+			require.Equal(subtest, []int{1, 2, 3, 4}, lookup.requestedBatch)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, purgeError, testCase.expectedError)
+				require.Empty(subtest, store.deletedIDs)
+				return
+			}
+
+			require.NoError(subtest, purgeError)
+			require.Equal(subtest, testCase.expectedDeletedIDs, store.deletedIDs)
+			require.Equal(subtest, testCase.expectedDeleted, result.DeletedVersions)
+			require.Equal(subtest, testCase.expectedReclaimable, result.ReclaimableBytes)
+			require.Equal(subtest, 8, result.TotalVersions)
+			require.Equal(subtest, 1, result.UntaggedVersions)
+			require.Equal(subtest, []ghcr.PullRequestTaggedVersion{
+				{VersionID: 11, Tag: "pr-1", PullRequestNumber: 1, State: ghcr.PullRequestStateMerged},
+				{VersionID: 12, Tag: "pr-2", PullRequestNumber: 2, State: ghcr.PullRequestStateOpen},
+				{VersionID: 13, Tag: "pr-3", PullRequestNumber: 3, State: ghcr.PullRequestStateClosed, Candidate: true},
+				{VersionID: 14, Tag: "pr-4", PullRequestNumber: 4, State: ghcr.PullRequestStateUnknown},
+				{VersionID: 15, Tag: "pr-1", PullRequestNumber: 1, State: ghcr.PullRequestStateMerged},
+				{VersionID: 15, Tag: "pr-2", PullRequestNumber: 2, State: ghcr.PullRequestStateOpen},
+				{VersionID: 18, Tag: "pr-1", PullRequestNumber: 1, State: ghcr.PullRequestStateMerged, Candidate: true},
+				{VersionID: 18, Tag: "pr-3", PullRequestNumber: 3, State: ghcr.PullRequestStateClosed, Candidate: true},
+			}, result.PullRequestVersions)
+		})
+	}
+}
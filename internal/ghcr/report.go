@@ -0,0 +1,231 @@
+package ghcr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	packageTypeQueryParameterNameConstant = "package_type"
+	packagesDecodeErrorTemplateConstant   = "unable to decode packages: %w"
+	packageReportErrorTemplateConstant    = "unable to report package %s: %w"
+	reportStartMessageConstant            = "Starting GHCR package storage report"
+	reportPackageMessageConstant          = "Reported GHCR package storage"
+	reportCompleteMessageConstant         = "Completed GHCR package storage report"
+	totalPackagesLogFieldNameConstant     = "total_packages"
+	totalBytesLogFieldNameConstant        = "total_bytes"
+	packageListingPageMessageConstant     = "Fetched GHCR packages page"
+)
+
+// Package describes one package as returned by the GitHub Packages listing API.
+type Package struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	PackageType string    `json:"package_type"`
+	Visibility  string    `json:"visibility"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PackageListRequest identifies the owner whose container packages are listed.
+type PackageListRequest struct {
+	Owner     string
+	OwnerType OwnerType
+	Token     string
+}
+
+// PackageReport summarizes the versions and storage of one container package.
+type PackageReport struct {
+	PackageName      string
+	TotalVersions    int
+	TaggedVersions   int
+	UntaggedVersions int
+	TotalBytes       int64
+	// OldestVersion and NewestVersion are the creation times of the oldest and newest versions; both are zero for packages without versions.
+	OldestVersion time.Time
+	NewestVersion time.Time
+}
+
+// ListPackages follows the listing pages through every container package of the owner.
+func (service *PackageVersionService) ListPackages(executionContext context.Context, request PackageListRequest) ([]Package, error) {
+	request, validationError := normalizePackageListRequest(request)
+	if validationError != nil {
+		return nil, validationError
+	}
+
+	packagesURL, urlBuildError := service.buildPackagesURL(request.OwnerType, request.Owner)
+	if urlBuildError != nil {
+		return nil, urlBuildError
+	}
+
+	var packages []Package
+	decodePage := func(body io.Reader) (int, error) {
+		var page []Package
+		if decodeError := json.NewDecoder(body).Decode(&page); decodeError != nil {
+			return 0, fmt.Errorf(packagesDecodeErrorTemplateConstant, decodeError)
+		}
+		packages = append(packages, page...)
+		return len(page), nil
+	}
+
+	pagingError := service.fetchLinkedPages(
+		executionContext,
+		packagesURL,
+		request.Token,
+		packageListingPageMessageConstant,
+		decodePage,
+		zap.String(ownerLogFieldNameConstant, request.Owner),
+	)
+	if pagingError != nil {
+		return nil, pagingError
+	}
+
+	return packages, nil
+}
+
+// ReportPackage lists every version of the package and summarizes counts, size, and version dates without deleting anything.
+func (service *PackageVersionService) ReportPackage(executionContext context.Context, request PurgeRequest) (PackageReport, error) {
+	normalizedRequest, validationError := normalizePurgeRequest(request)
+	if validationError != nil {
+		return PackageReport{}, validationError
+	}
+
+	report := PackageReport{PackageName: normalizedRequest.PackageName}
+	versions, fetchError := service.store.ListVersions(executionContext, normalizedRequest)
+	if fetchError != nil {
+		return PackageReport{}, fetchError
+	}
+
+	for versionIndex := range versions {
+		version := versions[versionIndex]
+		report.TotalVersions++
+		report.TotalBytes += service.store.VersionSize(executionContext, normalizedRequest, version)
+		if version.HasTags() {
+			report.TaggedVersions++
+		} else {
+			report.UntaggedVersions++
+		}
+		if version.CreatedAt.IsZero() {
+			continue
+		}
+		if report.OldestVersion.IsZero() || version.CreatedAt.Before(report.OldestVersion) {
+			report.OldestVersion = version.CreatedAt
+		}
+		if version.CreatedAt.After(report.NewestVersion) {
+			report.NewestVersion = version.CreatedAt
+		}
+	}
+
+	return report, nil
+}
+
+// ReportPackages reports every container package of the owner, largest first; packages of equal size are ordered by name.
+func (service *PackageVersionService) ReportPackages(executionContext context.Context, request PackageListRequest) ([]PackageReport, error) {
+	request, validationError := normalizePackageListRequest(request)
+	if validationError != nil {
+		return nil, validationError
+	}
+
+	service.logger.Info(
+		reportStartMessageConstant,
+		zap.String(ownerLogFieldNameConstant, request.Owner),
+		zap.String(ownerTypeLogFieldNameConstant, string(request.OwnerType)),
+	)
+
+	packages, listError := service.ListPackages(executionContext, request)
+	if listError != nil {
+		return nil, listError
+	}
+
+	reports := make([]PackageReport, 0, len(packages))
+	for _, listedPackage := range packages {
+		report, reportError := service.ReportPackage(executionContext, PurgeRequest{
+			Owner:       request.Owner,
+			PackageName: listedPackage.Name,
+			OwnerType:   request.OwnerType,
+			Token:       request.Token,
+		})
+		if reportError != nil {
+			return nil, fmt.Errorf(packageReportErrorTemplateConstant, listedPackage.Name, reportError)
+		}
+		service.logger.Debug(
+			reportPackageMessageConstant,
+			zap.String(packageLogFieldNameConstant, report.PackageName),
+			zap.Int(totalVersionsLogFieldNameConstant, report.TotalVersions),
+			zap.Int64(totalBytesLogFieldNameConstant, report.TotalBytes),
+		)
+		reports = append(reports, report)
+	}
+
+	SortPackageReports(reports)
+
+	service.logger.Info(
+		reportCompleteMessageConstant,
+		zap.String(ownerLogFieldNameConstant, request.Owner),
+		zap.Int(totalPackagesLogFieldNameConstant, len(reports)),
+	)
+
+	return reports, nil
+}
+
+// SortPackageReports orders reports by total size, largest first, breaking ties by package name.
+func SortPackageReports(reports []PackageReport) {
+	sort.SliceStable(reports, func(leftIndex int, rightIndex int) bool {
+		if reports[leftIndex].TotalBytes != reports[rightIndex].TotalBytes {
+			return reports[leftIndex].TotalBytes > reports[rightIndex].TotalBytes
+		}
+		return reports[leftIndex].PackageName < reports[rightIndex].PackageName
+	})
+}
+
+func normalizePackageListRequest(request PackageListRequest) (PackageListRequest, error) {
+	trimmedToken := strings.TrimSpace(request.Token)
+	if len(trimmedToken) == 0 {
+		return PackageListRequest{}, errors.New(tokenMissingErrorMessageConstant)
+	}
+	trimmedOwner := strings.TrimSpace(request.Owner)
+	if len(trimmedOwner) == 0 {
+		return PackageListRequest{}, errors.New(ownerMissingErrorMessageConstant)
+	}
+	if len(strings.TrimSpace(string(request.OwnerType))) == 0 {
+		return PackageListRequest{}, errors.New(ownerTypeMissingErrorMessageConstant)
+	}
+
+	request.Token = trimmedToken
+	request.Owner = trimmedOwner
+	return request, nil
+}
+
+func (service *PackageVersionService) buildPackagesURL(ownerType OwnerType, owner string) (string, error) {
+	baseURL, parseError := url.Parse(service.baseURL)
+	if parseError != nil {
+		return "", parseError
+	}
+
+	baseURL.Path = strings.TrimSuffix(baseURL.Path, "/")
+
+	pathSegments := []string{
+		baseURL.Path,
+		ownerType.PathSegment(),
+		url.PathEscape(owner),
+		packagesPathSegmentConstant,
+	}
+
+	baseURL.Path = strings.Join(pathSegments, "/")
+
+	queryParameters := baseURL.Query()
+	queryParameters.Set(packageTypeQueryParameterNameConstant, containerPathSegmentConstant)
+	queryParameters.Set(perPageQueryParameterNameConstant, fmt.Sprintf("%d", service.pageSize))
+	baseURL.RawQuery = queryParameters.Encode()
+
+	return baseURL.String(), nil
+}
@@ -0,0 +1,100 @@
+package ghcr_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/ghcr"
+)
+
+func TestPackageVersionServiceReportPackages(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	client := &stubHTTPClient{
+		responses: []stubHTTPResponse{
+			{response: buildHTTPResponse(http.StatusOK, `[{"id":1,"name":"small","package_type":"container"},{"id":2,"name":"large","package_type":"container"}]`)},
+			{response: buildHTTPResponse(http.StatusOK, `[{"id":11,"size":10,"created_at":"2024-03-01T00:00:00Z","metadata":{"container":{"tags":["latest"]}}}]`)},
+			{response: buildHTTPResponse(http.StatusOK, `[{"id":21,"size":300,"created_at":"2024-05-01T00:00:00Z","metadata":{"container":{"tags":["v2"]}}},{"id":22,"size":200,"created_at":"2023-01-15T00:00:00Z","metadata":{"container":{"tags":[]}}},{"id":23,"size":100,"created_at":"2024-02-01T00:00:00Z","metadata":{"container":{"tags":[]}}}]`)},
+		},
+	}
+
+	service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), client, ghcr.ServiceConfiguration{PageSize: 3})
+	require.NoError(testingInstance, serviceError)
+
+	reports, reportError := service.ReportPackages(context.Background(), ghcr.PackageListRequest{
+		Owner:     testOwnerNameConstant,
+		OwnerType: ghcr.OrganizationOwnerType,
+		Token:     testTokenValueConstant,
+	})
+	require.NoError(testingInstance, reportError)
+	require.Equal(testingInstance, []ghcr.PackageReport{
+		{
+			PackageName:      "large",
+			TotalVersions:    3,
+			TaggedVersions:   1,
+			UntaggedVersions: 2,
+			TotalBytes:       600,
+			OldestVersion:    time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC),
+			NewestVersion:    time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			PackageName:    "small",
+			TotalVersions:  1,
+			TaggedVersions: 1,
+			TotalBytes:     10,
+			OldestVersion:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			NewestVersion:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}, reports)
+	require.Equal(testingInstance, []string{http.MethodGet, http.MethodGet, http.MethodGet}, client.recordedMethods)
+	require.Equal(testingInstance, "https://api.github.com/orgs/test-owner/packages?package_type=container&per_page=3", client.recordedURLs[0])
+	require.Equal(testingInstance, "https://api.github.com/orgs/test-owner/packages/container/small/versions?per_page=3", client.recordedURLs[1])
+}
+
+func TestPackageVersionServiceListPackagesFailures(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	testCases := []struct {
+		name          string
+		request       ghcr.PackageListRequest
+		responses     []stubHTTPResponse
+		expectedError string
+	}{
+		{
+			name:          "missing_token",
+			request:       ghcr.PackageListRequest{Owner: testOwnerNameConstant, OwnerType: ghcr.OrganizationOwnerType},
+			expectedError: "authentication token must be provided",
+		},
+		{
+			name:          "missing_owner",
+			request:       ghcr.PackageListRequest{OwnerType: ghcr.OrganizationOwnerType, Token: testTokenValueConstant},
+			expectedError: "owner must be provided",
+		},
+		{
+			name:          "unexpected_status",
+			request:       ghcr.PackageListRequest{Owner: testOwnerNameConstant, OwnerType: ghcr.UserOwnerType, Token: testTokenValueConstant},
+			responses:     []stubHTTPResponse{{response: buildHTTPResponse(http.StatusForbidden, `{"message":"Forbidden"}`)}},
+			expectedError: "unexpected status code 403 for GET https://api.github.com/users/test-owner/packages?package_type=container&per_page=100: {\"message\":\"Forbidden\"}",
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+
+		testingInstance.Run(testCase.name, func(testingSubInstance *testing.T) {
+			testingSubInstance.Parallel()
+
+			client := &stubHTTPClient{responses: testCase.responses}
+			service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), client, ghcr.ServiceConfiguration{})
+			require.NoError(testingSubInstance, serviceError)
+
+			_, listError := service.ListPackages(context.Background(), testCase.request)
+			require.EqualError(testingSubInstance, listError, testCase.expectedError)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package ghcr
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	retentionKeepLastNegativeMessageConstant   = "keep-last must not be negative"
+	retentionOlderThanNegativeMessageConstant  = "older-than must not be negative"
+	retentionInvalidPatternTemplateConstant    = "invalid tag pattern %q: %w"
+	retentionInvalidProtectedTemplateConstant  = "invalid protected tag %q: %w"
+	purgeReasonTagPatternTemplateConstant      = "tag(s) %s match %s"
+	purgeReasonRetentionLabelsTemplateConstant = "%s; labels %s"
+)
+
+// RetentionRule names the step of the retention policy that selected a version for deletion or saved it.
+type RetentionRule string
+
+const (
+	// RetentionRuleProtected keeps versions carrying a protected tag.
+	RetentionRuleProtected RetentionRule = "protected"
+	// RetentionRuleUntagged selects versions without tags.
+	RetentionRuleUntagged RetentionRule = "untagged"
+	// RetentionRuleTagPattern selects tagged versions whose every tag matches a tag pattern.
+	RetentionRuleTagPattern RetentionRule = "tag-pattern"
+	// RetentionRuleTagged keeps tagged versions that no tag pattern selects.
+	RetentionRuleTagged RetentionRule = "tagged"
+	// RetentionRuleOlderThan keeps candidates updated more recently than the age threshold.
+	RetentionRuleOlderThan RetentionRule = "older-than"
+	// RetentionRuleKeepLast keeps the newest remaining candidates.
+	RetentionRuleKeepLast RetentionRule = "keep-last"
+	// RetentionRuleLabelFilter keeps candidates whose image config labels do not match the label filters.
+	RetentionRuleLabelFilter RetentionRule = "label-filter"
+)
+
+// RetentionStep describes one step of the retention policy evaluation order.
+type RetentionStep struct {
+	Rules       []RetentionRule
+	Description string
+}
+
+// RetentionRuleOrder lists the retention steps in the order a purge applies them. Each step only sees the versions
+// the previous steps left as candidates, so earlier steps take precedence.
+var RetentionRuleOrder = []RetentionStep{
+	{Rules: []RetentionRule{RetentionRuleProtected}, Description: "versions carrying a protected tag are always kept"},
+	{Rules: []RetentionRule{RetentionRuleUntagged, RetentionRuleTagPattern, RetentionRuleTagged}, Description: "untagged versions and tagged versions whose every tag matches a tag pattern become candidates; other tagged versions are kept"},
+	{Rules: []RetentionRule{RetentionRuleOlderThan}, Description: "candidates updated more recently than the age threshold, or of unknown age, are kept"},
+	{Rules: []RetentionRule{RetentionRuleKeepLast}, Description: "the newest remaining candidates up to the keep count are kept"},
+	{Rules: []RetentionRule{RetentionRuleLabelFilter}, Description: "candidates whose image config labels do not match the label filters are kept"},
+}
+
+// RetentionPolicy combines the rules that decide which versions a purge deletes. The zero policy selects every
+// untagged version, matching the purge without retention rules.
+type RetentionPolicy struct {
+	// ProtectedTags lists tags, or path.Match globs over tags, whose versions are never deleted.
+	ProtectedTags []string
+	// TagPatterns lists path.Match globs; a tagged version is a candidate when every one of its tags matches one.
+	TagPatterns []string
+	// OlderThan keeps candidates updated within this duration of ReferenceTime; zero disables the age rule.
+	OlderThan time.Duration
+	// KeepLast keeps this many of the newest candidates left after the age rule; zero disables the count rule.
+	KeepLast int
+	// ReferenceTime anchors OlderThan; zero means the time of the purge.
+	ReferenceTime time.Time
+}
+
+// Enabled reports whether the policy configures any rule beyond the default untagged selection.
+func (policy RetentionPolicy) Enabled() bool {
+	return len(policy.ProtectedTags) > 0 || len(policy.TagPatterns) > 0 || policy.OlderThan > 0 || policy.KeepLast > 0
+}
+
+// Validate reports negative thresholds and malformed tag globs.
+func (policy RetentionPolicy) Validate() error {
+	if policy.KeepLast < 0 {
+		return errors.New(retentionKeepLastNegativeMessageConstant)
+	}
+	if policy.OlderThan < 0 {
+		return errors.New(retentionOlderThanNegativeMessageConstant)
+	}
+	for _, pattern := range policy.TagPatterns {
+		if _, matchError := path.Match(pattern, ""); matchError != nil {
+			return fmt.Errorf(retentionInvalidPatternTemplateConstant, pattern, matchError)
+		}
+	}
+	for _, protectedTag := range policy.ProtectedTags {
+		if _, matchError := path.Match(protectedTag, ""); matchError != nil {
+			return fmt.Errorf(retentionInvalidProtectedTemplateConstant, protectedTag, matchError)
+		}
+	}
+	return nil
+}
+
+// RetentionDecision records whether a version is deleted and the rule that selected or saved it.
+type RetentionDecision struct {
+	Version PackageVersion
+	Delete  bool
+	Rule    RetentionRule
+}
+
+// EvaluateRetention applies the policy to versions in RetentionRuleOrder and returns one decision per version, in
+// input order. Label filters are not evaluated here because they need registry requests; the caller narrows the
+// deleted decisions with them afterwards.
+func EvaluateRetention(versions []PackageVersion, policy RetentionPolicy, now time.Time) []RetentionDecision {
+	decisions := make([]RetentionDecision, len(versions))
+	candidateIndexes := make([]int, 0, len(versions))
+	for versionIndex, version := range versions {
+		decisions[versionIndex] = RetentionDecision{Version: version}
+		switch {
+		case anyTagMatches(version.Metadata.Container.Tags, policy.ProtectedTags):
+			decisions[versionIndex].Rule = RetentionRuleProtected
+		case !version.HasTags():
+			decisions[versionIndex].Delete = true
+			decisions[versionIndex].Rule = RetentionRuleUntagged
+		case len(policy.TagPatterns) > 0 && everyTagMatches(version.Metadata.Container.Tags, policy.TagPatterns):
+			decisions[versionIndex].Delete = true
+			decisions[versionIndex].Rule = RetentionRuleTagPattern
+		default:
+			decisions[versionIndex].Rule = RetentionRuleTagged
+		}
+		if decisions[versionIndex].Delete {
+			candidateIndexes = append(candidateIndexes, versionIndex)
+		}
+	}
+
+	if policy.OlderThan > 0 {
+		if !policy.ReferenceTime.IsZero() {
+			now = policy.ReferenceTime
+		}
+		threshold := now.Add(-policy.OlderThan)
+		remainingIndexes := candidateIndexes[:0]
+		for _, candidateIndex := range candidateIndexes {
+			timestamp := retentionTimestamp(versions[candidateIndex])
+			if timestamp.IsZero() || timestamp.After(threshold) {
+				decisions[candidateIndex].Delete = false
+				decisions[candidateIndex].Rule = RetentionRuleOlderThan
+				continue
+			}
+			remainingIndexes = append(remainingIndexes, candidateIndex)
+		}
+		candidateIndexes = remainingIndexes
+	}
+
+	if policy.KeepLast > 0 {
+		sort.SliceStable(candidateIndexes, func(left int, right int) bool {
+			return newerVersion(versions[candidateIndexes[left]], versions[candidateIndexes[right]])
+		})
+		for rank, candidateIndex := range candidateIndexes {
+			if rank >= policy.KeepLast {
+				break
+			}
+			decisions[candidateIndex].Delete = false
+			decisions[candidateIndex].Rule = RetentionRuleKeepLast
+		}
+	}
+
+	return decisions
+}
+
+// retentionTimestamp returns when the version last changed, falling back to its publication time.
+func retentionTimestamp(version PackageVersion) time.Time {
+	if !version.UpdatedAt.IsZero() {
+		return version.UpdatedAt
+	}
+	return version.CreatedAt
+}
+
+// newerVersion orders versions newest first; versions of unknown age sort last and ties fall back to the higher ID.
+func newerVersion(left PackageVersion, right PackageVersion) bool {
+	leftTimestamp := retentionTimestamp(left)
+	rightTimestamp := retentionTimestamp(right)
+	if !leftTimestamp.Equal(rightTimestamp) {
+		return leftTimestamp.After(rightTimestamp)
+	}
+	return left.ID > right.ID
+}
+
+func anyTagMatches(tags []string, patterns []string) bool {
+	for _, tag := range tags {
+		if tagMatches(tag, patterns) {
+			return true
+		}
+	}
+	return false
+}
+
+func everyTagMatches(tags []string, patterns []string) bool {
+	for _, tag := range tags {
+		if !tagMatches(tag, patterns) {
+			return false
+		}
+	}
+	return true
+}
+
+func tagMatches(tag string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, tag); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// retentionPurgeReason explains why a retention decision selected a version, extending it with the label filters
+// the version also satisfied.
+func retentionPurgeReason(decision RetentionDecision, policy RetentionPolicy, labelFilters []LabelFilter) string {
+	reason := PurgeReasonUntagged
+	if decision.Rule == RetentionRuleTagPattern {
+		reason = fmt.Sprintf(purgeReasonTagPatternTemplateConstant, strings.Join(decision.Version.Metadata.Container.Tags, purgeReasonListSeparatorConstant), strings.Join(policy.TagPatterns, purgeReasonListSeparatorConstant))
+	}
+	if len(labelFilters) == 0 {
+		return reason
+	}
+	if decision.Rule == RetentionRuleUntagged {
+		return labelFilterPurgeReason(labelFilters)
+	}
+	descriptions := make([]string, 0, len(labelFilters))
+	for _, filter := range labelFilters {
+		descriptions = append(descriptions, filter.String())
+	}
+	return fmt.Sprintf(purgeReasonRetentionLabelsTemplateConstant, reason, strings.Join(descriptions, purgeReasonListSeparatorConstant))
+}
+
+// SurvivingVersion describes a version a retention policy kept and the rule that saved it.
+type SurvivingVersion struct {
+	VersionID int64
+	Digest    string
+	Tags      []string
+	Rule      RetentionRule
+}
+
+func newSurvivingVersion(version PackageVersion, rule RetentionRule) SurvivingVersion {
+	return SurvivingVersion{
+		VersionID: version.ID,
+		Digest:    version.Name,
+		Tags:      append([]string(nil), version.Metadata.Container.Tags...),
+		Rule:      rule,
+	}
+}
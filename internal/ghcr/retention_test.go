@@ -0,0 +1,210 @@
+package ghcr_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/ghcr"
+)
+
+func retentionTestVersion(identifier int64, daysOld int, tags ...string) ghcr.PackageVersion {
+	version := ghcr.PackageVersion{ID: identifier, Name: "sha256:" + string(rune('a'+identifier)), Metadata: ghcr.PackageVersionMetadata{Container: ghcr.PackageVersionContainerMetadata{Tags: tags}}}
+	if daysOld >= 0 {
+		version.UpdatedAt = retentionTestNow.AddDate(0, 0, -daysOld)
+	}
+	return version
+}
+
+var retentionTestNow = time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+
+func TestEvaluateRetention(testingInstance *testing.T) {
+	day := 24 * time.Hour
+	testCases := []struct {
+		name             string
+		versions         []ghcr.PackageVersion
+		policy           ghcr.RetentionPolicy
+		expectedRules    []ghcr.RetentionRule
+		expectedSelected []int64
+	}{
+		{
+			name:             "zero_policy_selects_untagged_only",
+			versions:         []ghcr.PackageVersion{retentionTestVersion(1, 1), retentionTestVersion(2, 1, "v1")},
+			expectedRules:    []ghcr.RetentionRule{ghcr.RetentionRuleUntagged, ghcr.RetentionRuleTagged},
+			expectedSelected: []int64{1},
+		},
+		{
+			name:             "tag_pattern_requires_every_tag_to_match",
+			versions:         []ghcr.PackageVersion{retentionTestVersion(1, 1, "pr-1"), retentionTestVersion(2, 1, "pr-2", "latest"), retentionTestVersion(3, 1)},
+			policy:           ghcr.RetentionPolicy{TagPatterns: []string{"pr-*"}},
+			expectedRules:    []ghcr.RetentionRule{ghcr.RetentionRuleTagPattern, ghcr.RetentionRuleTagged, ghcr.RetentionRuleUntagged},
+			expectedSelected: []int64{1, 3},
+		},
+		{
+			name:             "protection_wins_over_tag_pattern",
+			versions:         []ghcr.PackageVersion{retentionTestVersion(1, 30, "release-1"), retentionTestVersion(2, 30, "release-2")},
+			policy:           ghcr.RetentionPolicy{TagPatterns: []string{"release-*"}, ProtectedTags: []string{"release-1"}, OlderThan: day},
+			expectedRules:    []ghcr.RetentionRule{ghcr.RetentionRuleProtected, ghcr.RetentionRuleTagPattern},
+			expectedSelected: []int64{2},
+		},
+		{
+			name:             "protected_glob_keeps_any_matching_tag",
+			versions:         []ghcr.PackageVersion{retentionTestVersion(1, 5, "pr-1", "stable-2026")},
+			policy:           ghcr.RetentionPolicy{TagPatterns: []string{"*"}, ProtectedTags: []string{"stable-*"}},
+			expectedRules:    []ghcr.RetentionRule{ghcr.RetentionRuleProtected},
+			expectedSelected: []int64{},
+		},
+		{
+			name:             "older_than_keeps_recent_and_undated_candidates",
+			versions:         []ghcr.PackageVersion{retentionTestVersion(1, 10), retentionTestVersion(2, 2), retentionTestVersion(3, -1), retentionTestVersion(4, 2, "v1")},
+			policy:           ghcr.RetentionPolicy{OlderThan: 7 * day},
+			expectedRules:    []ghcr.RetentionRule{ghcr.RetentionRuleUntagged, ghcr.RetentionRuleOlderThan, ghcr.RetentionRuleOlderThan, ghcr.RetentionRuleTagged},
+			expectedSelected: []int64{1},
+		},
+		{
+			name:             "keep_last_saves_newest_candidates",
+			versions:         []ghcr.PackageVersion{retentionTestVersion(1, 9), retentionTestVersion(2, 3), retentionTestVersion(3, 6), retentionTestVersion(4, 1, "v1")},
+			policy:           ghcr.RetentionPolicy{KeepLast: 2},
+			expectedRules:    []ghcr.RetentionRule{ghcr.RetentionRuleUntagged, ghcr.RetentionRuleKeepLast, ghcr.RetentionRuleKeepLast, ghcr.RetentionRuleTagged},
+			expectedSelected: []int64{1},
+		},
+		{
+			name:             "keep_last_applies_to_versions_surviving_the_age_rule",
+			versions:         []ghcr.PackageVersion{retentionTestVersion(1, 1), retentionTestVersion(2, 20), retentionTestVersion(3, 30), retentionTestVersion(4, 40)},
+			policy:           ghcr.RetentionPolicy{OlderThan: 7 * day, KeepLast: 1},
+			expectedRules:    []ghcr.RetentionRule{ghcr.RetentionRuleOlderThan, ghcr.RetentionRuleKeepLast, ghcr.RetentionRuleUntagged, ghcr.RetentionRuleUntagged},
+			expectedSelected: []int64{3, 4},
+		},
+		{
+			name:             "keep_last_breaks_ties_by_identifier_and_ranks_undated_last",
+			versions:         []ghcr.PackageVersion{retentionTestVersion(1, -1), retentionTestVersion(2, 4), retentionTestVersion(3, 4)},
+			policy:           ghcr.RetentionPolicy{KeepLast: 1},
+			expectedRules:    []ghcr.RetentionRule{ghcr.RetentionRuleUntagged, ghcr.RetentionRuleUntagged, ghcr.RetentionRuleKeepLast},
+			expectedSelected: []int64{1, 2},
+		},
+		{
+			name:             "keep_last_larger_than_candidates_keeps_all",
+			versions:         []ghcr.PackageVersion{retentionTestVersion(1, 4), retentionTestVersion(2, 5, "pr-2")},
+			policy:           ghcr.RetentionPolicy{TagPatterns: []string{"pr-*"}, KeepLast: 5},
+			expectedRules:    []ghcr.RetentionRule{ghcr.RetentionRuleKeepLast, ghcr.RetentionRuleKeepLast},
+			expectedSelected: []int64{},
+		},
+		{
+			name:             "all_rules_combined",
+			versions:         []ghcr.PackageVersion{retentionTestVersion(1, 50, "pr-1", "keep"), retentionTestVersion(2, 50, "pr-2"), retentionTestVersion(3, 40, "pr-3"), retentionTestVersion(4, 2, "pr-4"), retentionTestVersion(5, 60, "v1"), retentionTestVersion(6, 70)},
+			policy:           ghcr.RetentionPolicy{ProtectedTags: []string{"keep"}, TagPatterns: []string{"pr-*"}, OlderThan: 7 * day, KeepLast: 1},
+			expectedRules:    []ghcr.RetentionRule{ghcr.RetentionRuleProtected, ghcr.RetentionRuleTagPattern, ghcr.RetentionRuleKeepLast, ghcr.RetentionRuleOlderThan, ghcr.RetentionRuleTagged, ghcr.RetentionRuleUntagged},
+			expectedSelected: []int64{2, 6},
+		},
+		{
+			name:             "reference_time_overrides_now",
+			versions:         []ghcr.PackageVersion{retentionTestVersion(1, 10)},
+			policy:           ghcr.RetentionPolicy{OlderThan: 7 * day, ReferenceTime: retentionTestNow.AddDate(0, 0, -5)},
+			expectedRules:    []ghcr.RetentionRule{ghcr.RetentionRuleOlderThan},
+			expectedSelected: []int64{},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testingInstance.Run(testCase.name, func(subtest *testing.T) {
+			decisions := ghcr.EvaluateRetention(testCase.versions, testCase.policy, retentionTestNow)
+			require.Len(subtest, decisions, len(testCase.versions))
+
+			rules := make([]ghcr.RetentionRule, 0, len(decisions))
+			selected := make([]int64, 0, len(decisions))
+			for decisionIndex, decision := range decisions {
+				require.Equal(subtest, testCase.versions[decisionIndex].ID, decision.Version.ID)
+				rules = append(rules, decision.Rule)
+				if decision.Delete {
+					selected = append(selected, decision.Version.ID)
+				}
+			}
+			require.Equal(subtest, testCase.expectedRules, rules)
+			require.Equal(subtest, testCase.expectedSelected, selected)
+		})
+	}
+}
+
+func TestRetentionPolicyValidate(testingInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		policy        ghcr.RetentionPolicy
+		expectedError string
+	}{
+		{name: "zero_policy_is_valid"},
+		{name: "negative_keep_last", policy: ghcr.RetentionPolicy{KeepLast: -1}, expectedError: "keep-last must not be negative"},
+		{name: "negative_older_than", policy: ghcr.RetentionPolicy{OlderThan: -time.Hour}, expectedError: "older-than must not be negative"},
+		{name: "malformed_tag_pattern", policy: ghcr.RetentionPolicy{TagPatterns: []string{"pr-["}}, expectedError: `invalid tag pattern "pr-[": syntax error in pattern`},
+		{name: "malformed_protected_tag", policy: ghcr.RetentionPolicy{ProtectedTags: []string{"["}}, expectedError: `invalid protected tag "[": syntax error in pattern`},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testingInstance.Run(testCase.name, func(subtest *testing.T) {
+			validationError := testCase.policy.Validate()
+			if len(testCase.expectedError) == 0 {
+				require.NoError(subtest, validationError)
+				return
+			}
+			require.EqualError(subtest, validationError, testCase.expectedError)
+		})
+	}
+}
+
+func TestPurgeUntaggedVersionsAppliesRetentionPolicy(testingInstance *testing.T) {
+	store := &recordingVersionStore{versions: []ghcr.PackageVersion{
+		retentionTestVersion(1, 30, "pr-1"),
+		retentionTestVersion(2, 30, "pr-2", "stable"),
+		retentionTestVersion(3, 1),
+		retentionTestVersion(4, 30),
+	}}
+	service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), &stubHTTPClient{}, ghcr.ServiceConfiguration{})
+	require.NoError(testingInstance, serviceError)
+	service.SetVersionStore(store)
+
+	result, purgeError := service.PurgeUntaggedVersions(context.Background(), ghcr.PurgeRequest{
+		Owner:       testOwnerNameConstant,
+		PackageName: testPackageNameConstant,
+		OwnerType:   ghcr.OrganizationOwnerType,
+		Token:       testTokenValueConstant,
+		DryRun:      true,
+		Retention:   ghcr.RetentionPolicy{ProtectedTags: []string{"stable"}, TagPatterns: []string{"pr-*"}, OlderThan: 7 * 24 * time.Hour, ReferenceTime: retentionTestNow},
+	})
+	require.NoError(testingInstance, purgeError)
+	require.Equal(testingInstance, 2, result.UntaggedVersions)
+
+	candidateRules := map[int64]ghcr.RetentionRule{}
+	candidateReasons := map[int64]string{}
+	for _, candidate := range result.Candidates {
+		candidateRules[candidate.VersionID] = candidate.Rule
+		candidateReasons[candidate.VersionID] = candidate.Reason
+	}
+	require.Equal(testingInstance, map[int64]ghcr.RetentionRule{1: ghcr.RetentionRuleTagPattern, 4: ghcr.RetentionRuleUntagged}, candidateRules)
+	require.Equal(testingInstance, "tag(s) pr-1 match pr-*", candidateReasons[1])
+	require.Equal(testingInstance, ghcr.PurgeReasonUntagged, candidateReasons[4])
+
+	survivorRules := map[int64]ghcr.RetentionRule{}
+	for _, survivor := range result.Survivors {
+		survivorRules[survivor.VersionID] = survivor.Rule
+	}
+	require.Equal(testingInstance, map[int64]ghcr.RetentionRule{2: ghcr.RetentionRuleProtected, 3: ghcr.RetentionRuleOlderThan}, survivorRules)
+}
+
+func TestPurgeUntaggedVersionsRejectsInvalidRetentionPolicy(testingInstance *testing.T) {
+	service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), &stubHTTPClient{}, ghcr.ServiceConfiguration{})
+	require.NoError(testingInstance, serviceError)
+	service.SetVersionStore(&recordingVersionStore{})
+
+	_, purgeError := service.PurgeUntaggedVersions(context.Background(), ghcr.PurgeRequest{
+		Owner:       testOwnerNameConstant,
+		PackageName: testPackageNameConstant,
+		OwnerType:   ghcr.OrganizationOwnerType,
+		Token:       testTokenValueConstant,
+		Retention:   ghcr.RetentionPolicy{KeepLast: -2},
+	})
+	require.EqualError(testingInstance, purgeError, "keep-last must not be negative")
+}
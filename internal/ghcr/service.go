@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -20,7 +22,6 @@ const (
 	authorizationHeaderNameConstant              = "Authorization"
 	bearerTokenTemplateConstant                  = "Bearer %s"
 	perPageQueryParameterNameConstant            = "per_page"
-	pageQueryParameterNameConstant               = "page"
 	defaultPageSizeConstant                      = 100
 	packagesPathSegmentConstant                  = "packages"
 	containerPathSegmentConstant                 = "container"
@@ -49,8 +50,24 @@ const (
 	ownerMissingErrorMessageConstant             = "owner must be provided"
 	packageMissingErrorMessageConstant           = "package name must be provided"
 	ownerTypeMissingErrorMessageConstant         = "owner type must be provided"
+	lastTaggedVersionIndicatorConstant           = "last tagged version"
+	purgeRetainedMessageConstant                 = "GHCR retained package version by registry policy"
+	retainedVersionsLogFieldNameConstant         = "retained_versions"
+	retentionReasonLogFieldNameConstant          = "reason"
+	retainedByRegistryPolicyReasonConstant       = "last tagged version cannot be deleted"
+	packageDeletionFailureTemplateConstant       = "failed to delete package %s: %s"
+	packageTypeMissingErrorMessageConstant       = "package type must be provided"
+	packageDeleteMessageConstant                 = "Deleting GHCR package"
+	packageTypeLogFieldNameConstant              = "package_type"
+	reclaimableBytesLogFieldNameConstant         = "reclaimable_bytes"
+	purgeDeleteFailedMessageConstant             = "Failed to delete GHCR package version"
+	failedVersionsLogFieldNameConstant           = "failed_versions"
+	statusCodeLogFieldNameConstant               = "status_code"
 )
 
+// ContainerPackageType identifies container images in the GitHub Packages API.
+const ContainerPackageType = containerPathSegmentConstant
+
 var deleteSuccessStatusCodes = map[int]struct{}{
 	http.StatusNoContent: {},
 	http.StatusAccepted:  {},
@@ -65,6 +82,8 @@ type HTTPClient interface {
 type ServiceConfiguration struct {
 	BaseURL  string
 	PageSize int
+	// RegistryBaseURL locates the container registry used to read manifest sizes; defaults to https://ghcr.io.
+	RegistryBaseURL string
 }
 
 // PurgeRequest captures the information required to delete untagged versions.
@@ -74,6 +93,30 @@ type PurgeRequest struct {
 	OwnerType   OwnerType
 	Token       string
 	DryRun      bool
+	// FailFast stops the purge at the first failed version deletion instead of recording the failure and continuing.
+	FailFast bool
+	// LabelFilters restricts the purge to untagged versions whose image config carries every listed label.
+	// Evaluating them fetches each candidate's manifest and config blob from the registry.
+	LabelFilters []LabelFilter
+	// PullRequestTagPrefix selects the versions PurgePullRequestVersions evaluates: those tagged <prefix><number>.
+	PullRequestTagPrefix string
+	// PullRequestStates resolves the state of the pull requests named by those tags.
+	PullRequestStates PullRequestStateLookup
+	// ApprovedDigests, when non-nil, limits deletion to policy candidates whose digest is listed. Listed digests the
+	// policy no longer selects are refused unless ForceApproved is set.
+	ApprovedDigests []string
+	ForceApproved   bool
+	// Retention decides which versions PurgeUntaggedVersions deletes; the zero policy selects every untagged version.
+	Retention RetentionPolicy
+}
+
+// VersionDeletionFailure records a package version whose deletion failed during a purge.
+type VersionDeletionFailure struct {
+	VersionID int64
+	Digest    string
+	// StatusCode is the HTTP status returned by the registry, or zero when the request never completed.
+	StatusCode int
+	Message    string
 }
 
 // PurgeResult contains summary statistics from a purge operation.
@@ -81,14 +124,74 @@ type PurgeResult struct {
 	TotalVersions    int
 	UntaggedVersions int
 	DeletedVersions  int
+	// RetainedVersions counts versions GHCR refused to delete because they are the last tagged version of the package.
+	RetainedVersions int
+	// TaggedVersions counts versions carrying at least one tag; only populated by CountVersions.
+	TaggedVersions int
+	// DeletedPackages counts whole packages removed through the package-level endpoint, independent of DeletedVersions.
+	DeletedPackages int
+	// ReclaimableBytes estimates the storage freed by the purge: deleted versions in a real run, deletion candidates during a dry run,
+	// and every version when populated by CountVersions.
+	ReclaimableBytes int64
+	// Failures lists version deletions that failed while the purge continued past them.
+	Failures []VersionDeletionFailure
+	// LabelMatchedVersions counts untagged versions that satisfied the request's label filters.
+	LabelMatchedVersions int
+	// ManifestFetches counts the registry manifest and config blob requests made to evaluate label filters.
+	ManifestFetches int
+	// PullRequestVersions describes every pull request tag PurgePullRequestVersions evaluated, with the state that decided it.
+	PullRequestVersions []PullRequestTaggedVersion
+	// Candidates lists every version the purge selected, with its size and the policy reason that selected it.
+	Candidates []PurgeCandidate
+	// RefusedDigests lists approved digests left in place because the purge policy no longer selects them.
+	RefusedDigests []string
+	// Survivors lists the versions an enabled retention policy kept, with the rule that saved each of them.
+	Survivors []SurvivingVersion
+}
+
+// PackageDeletionRequest captures the information required to delete an entire package.
+type PackageDeletionRequest struct {
+	Owner       string
+	OwnerType   OwnerType
+	PackageType string
+	PackageName string
+	Token       string
+}
+
+var errVersionRetainedByRegistryPolicy = errors.New(retainedByRegistryPolicyReasonConstant)
+
+type versionDeletionStatusError struct {
+	versionID  int64
+	statusCode int
+	body       string
+}
+
+func (deletionError versionDeletionStatusError) Error() string {
+	return fmt.Sprintf(deletionFailureTemplateConstant, deletionError.versionID, deletionError.body)
+}
+
+// VersionStore lists, sizes, and deletes package versions. The purge and count policies run against it,
+// so they can evaluate either the live GHCR API or a recorded Snapshot.
+type VersionStore interface {
+	// ListVersions returns every version of the package. The listing is complete before any caller acts on it, so
+	// deletions cannot shift pages underneath the iteration.
+	ListVersions(executionContext context.Context, request PurgeRequest) ([]PackageVersion, error)
+	VersionSize(executionContext context.Context, request PurgeRequest, version PackageVersion) int64
+	DeleteVersion(executionContext context.Context, request PurgeRequest, versionID int64) error
+	// VersionLabels returns the image config labels of a version and the number of registry requests made to read them.
+	VersionLabels(executionContext context.Context, request PurgeRequest, version PackageVersion) (map[string]string, int, error)
 }
 
 // PackageVersionService interacts with the GHCR REST API.
 type PackageVersionService struct {
-	logger     *zap.Logger
-	httpClient HTTPClient
-	baseURL    string
-	pageSize   int
+	logger          *zap.Logger
+	store           VersionStore
+	httpClient      HTTPClient
+	baseURL         string
+	registryBaseURL string
+	pageSize        int
+	labelCache      map[string]map[string]string
+	labelCacheMutex sync.Mutex
 }
 
 // NewPackageVersionService constructs a service with sane defaults.
@@ -108,40 +211,51 @@ func NewPackageVersionService(logger *zap.Logger, httpClient HTTPClient, configu
 		resolvedBaseURL = defaultBaseURLConstant
 	}
 
+	resolvedRegistryBaseURL := strings.TrimSpace(configuration.RegistryBaseURL)
+	if len(resolvedRegistryBaseURL) == 0 {
+		resolvedRegistryBaseURL = defaultRegistryBaseURLConstant
+	}
+
 	resolvedPageSize := configuration.PageSize
 	if resolvedPageSize <= 0 {
 		resolvedPageSize = defaultPageSizeConstant
 	}
 
-	return &PackageVersionService{
-		logger:     resolvedLogger,
-		httpClient: resolvedClient,
-		baseURL:    resolvedBaseURL,
-		pageSize:   resolvedPageSize,
-	}, nil
+	service := &PackageVersionService{
+		logger:          resolvedLogger,
+		httpClient:      resolvedClient,
+		baseURL:         resolvedBaseURL,
+		registryBaseURL: resolvedRegistryBaseURL,
+		pageSize:        resolvedPageSize,
+		labelCache:      map[string]map[string]string{},
+	}
+	service.store = apiVersionStore{service: service}
+	return service, nil
 }
 
-// PurgeUntaggedVersions removes untagged container versions and returns summary counts.
-func (service *PackageVersionService) PurgeUntaggedVersions(executionContext context.Context, request PurgeRequest) (PurgeResult, error) {
-	trimmedToken := strings.TrimSpace(request.Token)
-	if len(trimmedToken) == 0 {
-		return PurgeResult{}, errors.New(tokenMissingErrorMessageConstant)
-	}
-	trimmedOwner := strings.TrimSpace(request.Owner)
-	if len(trimmedOwner) == 0 {
-		return PurgeResult{}, errors.New(ownerMissingErrorMessageConstant)
-	}
-	trimmedPackageName := strings.TrimSpace(request.PackageName)
-	if len(trimmedPackageName) == 0 {
-		return PurgeResult{}, errors.New(packageMissingErrorMessageConstant)
-	}
-	if len(strings.TrimSpace(string(request.OwnerType))) == 0 {
-		return PurgeResult{}, errors.New(ownerTypeMissingErrorMessageConstant)
+// VersionStore returns the store the purge and count policies currently read from.
+func (service *PackageVersionService) VersionStore() VersionStore {
+	return service.store
+}
+
+// SetVersionStore replaces the store the purge and count policies read from; nil restores the GHCR API store.
+func (service *PackageVersionService) SetVersionStore(store VersionStore) {
+	if store == nil {
+		service.store = apiVersionStore{service: service}
+		return
 	}
+	service.store = store
+}
 
-	request.Token = trimmedToken
-	request.Owner = trimmedOwner
-	request.PackageName = trimmedPackageName
+// PurgeUntaggedVersions removes the container versions the request's retention policy selects, untagged versions by
+// default, and returns summary counts.
+func (service *PackageVersionService) PurgeUntaggedVersions(executionContext context.Context, request PurgeRequest) (PurgeResult, error) {
+	request, validationError := normalizePurgeRequest(request)
+	if validationError != nil {
+		return PurgeResult{}, validationError
+	}
+	trimmedOwner := request.Owner
+	trimmedPackageName := request.PackageName
 
 	service.logger.Info(
 		purgeStartMessageConstant,
@@ -153,57 +267,50 @@ func (service *PackageVersionService) PurgeUntaggedVersions(executionContext con
 	)
 
 	result := PurgeResult{}
-	pageNumber := 1
-	for {
-		versions, fetchError := service.fetchPage(executionContext, request, pageNumber)
-		if fetchError != nil {
-			return result, fetchError
-		}
+	versions, fetchError := service.store.ListVersions(executionContext, request)
+	if fetchError != nil {
+		return result, fetchError
+	}
+	result.TotalVersions = len(versions)
 
-		versionCount := len(versions)
-		if versionCount == 0 {
-			break
+	gate := newApprovalGate(request)
+	reportSurvivors := request.Retention.Enabled()
+	for _, decision := range EvaluateRetention(versions, request.Retention, time.Now()) {
+		version := decision.Version
+		if !version.HasTags() {
+			result.UntaggedVersions++
 		}
-
-		service.logger.Debug(
-			purgePageMessageConstant,
-			zap.String(ownerLogFieldNameConstant, trimmedOwner),
-			zap.String(packageLogFieldNameConstant, trimmedPackageName),
-			zap.Int(pageLogFieldNameConstant, pageNumber),
-			zap.Int(totalVersionsLogFieldNameConstant, versionCount),
-		)
-
-		result.TotalVersions += versionCount
-
-		for versionIndex := range versions {
-			version := versions[versionIndex]
-			if version.HasTags() {
-				continue
+		if !decision.Delete {
+			if reportSurvivors {
+				result.Survivors = append(result.Survivors, newSurvivingVersion(version, decision.Rule))
 			}
+			continue
+		}
 
-			result.UntaggedVersions++
-			service.logger.Info(
-				purgeDeleteMessageConstant,
-				zap.Int64(versionIdentifierLogFieldNameConstant, version.ID),
-				zap.Bool(dryRunLogFieldNameConstant, request.DryRun),
-			)
-
-			if request.DryRun {
-				service.logger.Debug(
-					purgeDryRunSkipMessageConstant,
-					zap.Int64(versionIdentifierLogFieldNameConstant, version.ID),
-				)
-				continue
+		if len(request.LabelFilters) > 0 {
+			matched, labelError := service.versionMatchesLabelFilters(executionContext, request, version, &result)
+			if labelError != nil {
+				return result, labelError
 			}
-
-			deleteError := service.deleteVersion(executionContext, request, version.ID)
-			if deleteError != nil {
-				return result, deleteError
+			if !matched {
+				if reportSurvivors {
+					result.Survivors = append(result.Survivors, newSurvivingVersion(version, RetentionRuleLabelFilter))
+				}
+				continue
 			}
-			result.DeletedVersions++
+			result.LabelMatchedVersions++
 		}
 
-		pageNumber++
+		if !gate.admits(version) {
+			continue
+		}
+		reason := retentionPurgeReason(decision, request.Retention, request.LabelFilters)
+		if deleteError := service.purgeVersion(executionContext, request, version, reason, decision.Rule, &result); deleteError != nil {
+			return result, deleteError
+		}
+	}
+	if approvalError := service.purgeUnselectedApprovals(executionContext, request, versions, gate, &result); approvalError != nil {
+		return result, approvalError
 	}
 
 	service.logger.Info(
@@ -213,48 +320,260 @@ func (service *PackageVersionService) PurgeUntaggedVersions(executionContext con
 		zap.Int(totalVersionsLogFieldNameConstant, result.TotalVersions),
 		zap.Int(untaggedVersionsLogFieldNameConstant, result.UntaggedVersions),
 		zap.Int(deletedVersionsLogFieldNameConstant, result.DeletedVersions),
+		zap.Int(retainedVersionsLogFieldNameConstant, result.RetainedVersions),
+		zap.Int(failedVersionsLogFieldNameConstant, len(result.Failures)),
+		zap.Int64(reclaimableBytesLogFieldNameConstant, result.ReclaimableBytes),
+		zap.Int(labelMatchedVersionsLogFieldName, result.LabelMatchedVersions),
+		zap.Int(manifestFetchesLogFieldName, result.ManifestFetches),
 	)
 
 	return result, nil
 }
 
-func (service *PackageVersionService) fetchPage(executionContext context.Context, request PurgeRequest, pageNumber int) ([]packageVersion, error) {
-	versionsURL, urlBuildError := service.buildVersionsURL(request.OwnerType, request.Owner, request.PackageName, pageNumber)
+// purgeVersion deletes one candidate version, or sizes it during a dry run, and records the outcome in result. Only a
+// failed deletion under FailFast is returned; other failures are recorded and the purge continues.
+func (service *PackageVersionService) purgeVersion(executionContext context.Context, request PurgeRequest, version PackageVersion, reason string, rule RetentionRule, result *PurgeResult) error {
+	service.logger.Info(
+		purgeDeleteMessageConstant,
+		zap.Int64(versionIdentifierLogFieldNameConstant, version.ID),
+		zap.Bool(dryRunLogFieldNameConstant, request.DryRun),
+	)
+
+	versionSize := service.store.VersionSize(executionContext, request, version)
+	result.Candidates = append(result.Candidates, PurgeCandidate{
+		VersionID: version.ID,
+		Digest:    version.Name,
+		Tags:      append([]string(nil), version.Metadata.Container.Tags...),
+		Size:      versionSize,
+		UpdatedAt: version.UpdatedAt,
+		Reason:    reason,
+		Rule:      rule,
+	})
+
+	if request.DryRun {
+		service.logger.Debug(
+			purgeDryRunSkipMessageConstant,
+			zap.Int64(versionIdentifierLogFieldNameConstant, version.ID),
+		)
+		result.ReclaimableBytes += versionSize
+		return nil
+	}
+
+	deleteError := service.store.DeleteVersion(executionContext, request, version.ID)
+	if errors.Is(deleteError, errVersionRetainedByRegistryPolicy) {
+		service.logger.Warn(
+			purgeRetainedMessageConstant,
+			zap.Int64(versionIdentifierLogFieldNameConstant, version.ID),
+			zap.String(retentionReasonLogFieldNameConstant, retainedByRegistryPolicyReasonConstant),
+		)
+		result.RetainedVersions++
+		return nil
+	}
+	if deleteError != nil {
+		if request.FailFast {
+			return deleteError
+		}
+		failure := newVersionDeletionFailure(version, deleteError)
+		service.logger.Warn(
+			purgeDeleteFailedMessageConstant,
+			zap.Int64(versionIdentifierLogFieldNameConstant, failure.VersionID),
+			zap.String(versionDigestLogFieldNameConstant, failure.Digest),
+			zap.Int(statusCodeLogFieldNameConstant, failure.StatusCode),
+			zap.Error(deleteError),
+		)
+		result.Failures = append(result.Failures, failure)
+		return nil
+	}
+	result.DeletedVersions++
+	result.ReclaimableBytes += versionSize
+	return nil
+}
+
+func (service *PackageVersionService) versionMatchesLabelFilters(executionContext context.Context, request PurgeRequest, version PackageVersion, result *PurgeResult) (bool, error) {
+	labels, fetches, labelError := service.store.VersionLabels(executionContext, request, version)
+	result.ManifestFetches += fetches
+	if errors.Is(labelError, ErrSnapshotLabelsNotRecorded) {
+		return false, labelError
+	}
+	if labelError != nil {
+		service.logger.Warn(
+			versionLabelsUnavailableMessage,
+			zap.Int64(versionIdentifierLogFieldNameConstant, version.ID),
+			zap.String(versionDigestLogFieldNameConstant, version.Name),
+			zap.Error(labelError),
+		)
+		return false, nil
+	}
+	return matchesLabelFilters(labels, request.LabelFilters), nil
+}
+
+func newVersionDeletionFailure(version PackageVersion, deleteError error) VersionDeletionFailure {
+	failure := VersionDeletionFailure{VersionID: version.ID, Digest: version.Name, Message: deleteError.Error()}
+	var statusError versionDeletionStatusError
+	if errors.As(deleteError, &statusError) {
+		failure.StatusCode = statusError.statusCode
+		failure.Message = statusError.body
+	}
+	return failure
+}
+
+// CountVersions lists every version of the package and reports total, tagged, and untagged counts and their combined size without deleting anything.
+func (service *PackageVersionService) CountVersions(executionContext context.Context, request PurgeRequest) (PurgeResult, error) {
+	normalizedRequest, validationError := normalizePurgeRequest(request)
+	if validationError != nil {
+		return PurgeResult{}, validationError
+	}
+
+	result := PurgeResult{}
+	versions, fetchError := service.store.ListVersions(executionContext, normalizedRequest)
+	if fetchError != nil {
+		return result, fetchError
+	}
+
+	result.TotalVersions = len(versions)
+	for versionIndex := range versions {
+		result.ReclaimableBytes += service.store.VersionSize(executionContext, normalizedRequest, versions[versionIndex])
+		if versions[versionIndex].HasTags() {
+			result.TaggedVersions++
+			continue
+		}
+		result.UntaggedVersions++
+	}
+
+	return result, nil
+}
+
+// DeletePackage removes an entire package, including every version, through the package-level DELETE endpoint.
+func (service *PackageVersionService) DeletePackage(executionContext context.Context, request PackageDeletionRequest) error {
+	trimmedToken := strings.TrimSpace(request.Token)
+	if len(trimmedToken) == 0 {
+		return errors.New(tokenMissingErrorMessageConstant)
+	}
+	trimmedOwner := strings.TrimSpace(request.Owner)
+	if len(trimmedOwner) == 0 {
+		return errors.New(ownerMissingErrorMessageConstant)
+	}
+	trimmedPackageName := strings.TrimSpace(request.PackageName)
+	if len(trimmedPackageName) == 0 {
+		return errors.New(packageMissingErrorMessageConstant)
+	}
+	if len(strings.TrimSpace(string(request.OwnerType))) == 0 {
+		return errors.New(ownerTypeMissingErrorMessageConstant)
+	}
+	trimmedPackageType := strings.TrimSpace(request.PackageType)
+	if len(trimmedPackageType) == 0 {
+		return errors.New(packageTypeMissingErrorMessageConstant)
+	}
+
+	deleteURL, urlBuildError := service.buildPackageURL(request.OwnerType, trimmedOwner, trimmedPackageType, trimmedPackageName)
 	if urlBuildError != nil {
-		return nil, urlBuildError
+		return urlBuildError
 	}
 
-	httpRequest, requestCreationError := http.NewRequestWithContext(executionContext, http.MethodGet, versionsURL, nil)
-	if requestCreationError != nil {
-		return nil, fmt.Errorf(requestCreationErrorTemplateConstant, http.MethodGet, versionsURL, requestCreationError)
+	service.logger.Info(
+		packageDeleteMessageConstant,
+		zap.String(ownerLogFieldNameConstant, trimmedOwner),
+		zap.String(packageLogFieldNameConstant, trimmedPackageName),
+		zap.String(packageTypeLogFieldNameConstant, trimmedPackageType),
+		zap.String(ownerTypeLogFieldNameConstant, string(request.OwnerType)),
+	)
+
+	deleteRequest, deleteRequestCreationError := http.NewRequestWithContext(executionContext, http.MethodDelete, deleteURL, nil)
+	if deleteRequestCreationError != nil {
+		return fmt.Errorf(requestCreationErrorTemplateConstant, http.MethodDelete, deleteURL, deleteRequestCreationError)
 	}
 
-	httpRequest.Header.Set(acceptHeaderNameConstant, acceptHeaderValueConstant)
-	httpRequest.Header.Set(authorizationHeaderNameConstant, fmt.Sprintf(bearerTokenTemplateConstant, request.Token))
+	deleteRequest.Header.Set(acceptHeaderNameConstant, acceptHeaderValueConstant)
+	deleteRequest.Header.Set(authorizationHeaderNameConstant, fmt.Sprintf(bearerTokenTemplateConstant, trimmedToken))
+
+	deleteResponse, deleteError := service.httpClient.Do(deleteRequest)
+	if deleteError != nil {
+		return fmt.Errorf(requestExecutionErrorTemplateConstant, deleteError)
+	}
+	defer deleteResponse.Body.Close()
 
-	httpResponse, requestError := service.httpClient.Do(httpRequest)
-	if requestError != nil {
-		return nil, fmt.Errorf(requestExecutionErrorTemplateConstant, requestError)
+	if _, ok := deleteSuccessStatusCodes[deleteResponse.StatusCode]; !ok {
+		responseBody, _ := io.ReadAll(deleteResponse.Body)
+		return fmt.Errorf(packageDeletionFailureTemplateConstant, trimmedPackageName, strings.TrimSpace(string(responseBody)))
 	}
-	defer httpResponse.Body.Close()
 
-	if httpResponse.StatusCode != http.StatusOK {
-		responseBody, _ := io.ReadAll(httpResponse.Body)
-		return nil, fmt.Errorf(
-			unexpectedStatusCodeWithBodyTemplateConstant,
-			httpResponse.StatusCode,
-			http.MethodGet,
-			versionsURL,
-			strings.TrimSpace(string(responseBody)),
-		)
+	return nil
+}
+
+func normalizePurgeRequest(request PurgeRequest) (PurgeRequest, error) {
+	trimmedToken := strings.TrimSpace(request.Token)
+	if len(trimmedToken) == 0 {
+		return PurgeRequest{}, errors.New(tokenMissingErrorMessageConstant)
+	}
+	trimmedOwner := strings.TrimSpace(request.Owner)
+	if len(trimmedOwner) == 0 {
+		return PurgeRequest{}, errors.New(ownerMissingErrorMessageConstant)
+	}
+	trimmedPackageName := strings.TrimSpace(request.PackageName)
+	if len(trimmedPackageName) == 0 {
+		return PurgeRequest{}, errors.New(packageMissingErrorMessageConstant)
+	}
+	if len(strings.TrimSpace(string(request.OwnerType))) == 0 {
+		return PurgeRequest{}, errors.New(ownerTypeMissingErrorMessageConstant)
+	}
+	if retentionError := request.Retention.Validate(); retentionError != nil {
+		return PurgeRequest{}, retentionError
 	}
 
-	var versions []packageVersion
-	decodeError := json.NewDecoder(httpResponse.Body).Decode(&versions)
-	if decodeError != nil {
-		return nil, fmt.Errorf(responseDecodeErrorTemplateConstant, decodeError)
+	request.Token = trimmedToken
+	request.Owner = trimmedOwner
+	request.PackageName = trimmedPackageName
+	return request, nil
+}
+
+type apiVersionStore struct {
+	service *PackageVersionService
+}
+
+func (store apiVersionStore) ListVersions(executionContext context.Context, request PurgeRequest) ([]PackageVersion, error) {
+	return store.service.listVersions(executionContext, request)
+}
+
+func (store apiVersionStore) VersionSize(executionContext context.Context, request PurgeRequest, version PackageVersion) int64 {
+	return store.service.versionSize(executionContext, request, version)
+}
+
+func (store apiVersionStore) DeleteVersion(executionContext context.Context, request PurgeRequest, versionID int64) error {
+	return store.service.deleteVersion(executionContext, request, versionID)
+}
+
+func (store apiVersionStore) VersionLabels(executionContext context.Context, request PurgeRequest, version PackageVersion) (map[string]string, int, error) {
+	return store.service.versionLabels(executionContext, request, version)
+}
+
+func (service *PackageVersionService) listVersions(executionContext context.Context, request PurgeRequest) ([]PackageVersion, error) {
+	versionsURL, urlBuildError := service.buildVersionsURL(request.OwnerType, request.Owner, request.PackageName)
+	if urlBuildError != nil {
+		return nil, urlBuildError
 	}
 
+	versions := make([]PackageVersion, 0)
+	decodePage := func(body io.Reader) (int, error) {
+		var page []PackageVersion
+		if decodeError := json.NewDecoder(body).Decode(&page); decodeError != nil {
+			return 0, fmt.Errorf(responseDecodeErrorTemplateConstant, decodeError)
+		}
+		versions = append(versions, page...)
+		return len(page), nil
+	}
+
+	pagingError := service.fetchLinkedPages(
+		executionContext,
+		versionsURL,
+		request.Token,
+		purgePageMessageConstant,
+		decodePage,
+		zap.String(ownerLogFieldNameConstant, request.Owner),
+		zap.String(packageLogFieldNameConstant, request.PackageName),
+	)
+	if pagingError != nil {
+		return nil, pagingError
+	}
 	return versions, nil
 }
 
@@ -280,13 +599,26 @@ func (service *PackageVersionService) deleteVersion(executionContext context.Con
 
 	if _, ok := deleteSuccessStatusCodes[deleteResponse.StatusCode]; !ok {
 		responseBody, _ := io.ReadAll(deleteResponse.Body)
-		return fmt.Errorf(deletionFailureTemplateConstant, versionID, strings.TrimSpace(string(responseBody)))
+		if deleteResponse.StatusCode == http.StatusBadRequest && isLastTaggedVersionResponse(responseBody) {
+			return errVersionRetainedByRegistryPolicy
+		}
+		return versionDeletionStatusError{versionID: versionID, statusCode: deleteResponse.StatusCode, body: strings.TrimSpace(string(responseBody))}
 	}
 
 	return nil
 }
 
-func (service *PackageVersionService) buildVersionsURL(ownerType OwnerType, owner string, packageName string, pageNumber int) (string, error) {
+func isLastTaggedVersionResponse(responseBody []byte) bool {
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if decodeError := json.Unmarshal(responseBody, &payload); decodeError != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(payload.Message), lastTaggedVersionIndicatorConstant)
+}
+
+func (service *PackageVersionService) buildVersionsURL(ownerType OwnerType, owner string, packageName string) (string, error) {
 	baseURL, parseError := url.Parse(service.baseURL)
 	if parseError != nil {
 		return "", parseError
@@ -310,7 +642,6 @@ func (service *PackageVersionService) buildVersionsURL(ownerType OwnerType, owne
 
 	queryParameters := baseURL.Query()
 	queryParameters.Set(perPageQueryParameterNameConstant, fmt.Sprintf("%d", service.pageSize))
-	queryParameters.Set(pageQueryParameterNameConstant, fmt.Sprintf("%d", pageNumber))
 	baseURL.RawQuery = queryParameters.Encode()
 
 	return baseURL.String(), nil
@@ -343,19 +674,54 @@ func (service *PackageVersionService) buildVersionURL(ownerType OwnerType, owner
 	return baseURL.String(), nil
 }
 
-type packageVersion struct {
+func (service *PackageVersionService) buildPackageURL(ownerType OwnerType, owner string, packageType string, packageName string) (string, error) {
+	baseURL, parseError := url.Parse(service.baseURL)
+	if parseError != nil {
+		return "", parseError
+	}
+
+	baseURL.Path = strings.TrimSuffix(baseURL.Path, "/")
+
+	pathSegments := []string{
+		baseURL.Path,
+		ownerType.PathSegment(),
+		url.PathEscape(owner),
+		packagesPathSegmentConstant,
+		url.PathEscape(packageType),
+		url.PathEscape(packageName),
+	}
+
+	baseURL.Path = strings.Join(pathSegments, "/")
+	baseURL.RawQuery = ""
+
+	return baseURL.String(), nil
+}
+
+// PackageVersion describes one container version as returned by the GitHub Packages API.
+type PackageVersion struct {
 	ID       int64                  `json:"id"`
-	Metadata packageVersionMetadata `json:"metadata"`
+	Name     string                 `json:"name"`
+	Size     *int64                 `json:"size"`
+	Metadata PackageVersionMetadata `json:"metadata"`
+	// CreatedAt is when the version was published; zero when the listing omitted it.
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is when the version last changed; zero when the listing omitted it.
+	UpdatedAt time.Time `json:"updated_at"`
+	// Labels holds the image config labels recorded in a snapshot; nil means they were never read.
+	Labels map[string]string `json:"labels"`
 }
 
-type packageVersionMetadata struct {
-	Container packageVersionContainerMetadata `json:"container"`
+// PackageVersionMetadata holds the package-type specific metadata of a version.
+type PackageVersionMetadata struct {
+	Container PackageVersionContainerMetadata `json:"container"`
 }
 
-type packageVersionContainerMetadata struct {
+// PackageVersionContainerMetadata lists the tags attached to a container version.
+type PackageVersionContainerMetadata struct {
 	Tags []string `json:"tags"`
 }
 
-func (version packageVersion) HasTags() bool {
+// HasTags reports whether the version carries at least one tag.
+func (version PackageVersion) HasTags() bool {
 	return len(version.Metadata.Container.Tags) > 0
 }
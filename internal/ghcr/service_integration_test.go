@@ -25,7 +25,7 @@ const (
 	expectedAuthorizationHeaderName = "Authorization"
 	expectedAcceptHeaderValue       = "application/vnd.github+json"
 	expectedBearerHeaderTemplate    = "Bearer %s"
-	pageQueryParameterName          = "page"
+	cursorQueryParameterName        = "cursor"
 )
 
 func TestPackageVersionServiceIntegration(testingInstance *testing.T) {
@@ -100,18 +100,16 @@ func TestPackageVersionServiceIntegration(testingInstance *testing.T) {
 }
 
 func handleIntegrationGet(testingInstance *testing.T, responseWriter http.ResponseWriter, httpRequest *http.Request) {
-	query := httpRequest.URL.Query()
-	pageValue := query.Get(pageQueryParameterName)
-	require.NotEmpty(testingInstance, pageValue)
-
-	switch pageValue {
-	case "1":
-		payload := fmt.Sprintf(`[{"id":%d,"metadata":{"container":{"tags":[]}}},{"id":%d,"metadata":{"container":{"tags":["latest"]}}}]`, untaggedVersionIdentifier, taggedVersionIdentifier)
-		responseWriter.Header().Set("Content-Type", "application/json")
-		_, _ = responseWriter.Write([]byte(payload))
+	responseWriter.Header().Set("Content-Type", "application/json")
+	switch httpRequest.URL.Query().Get(cursorQueryParameterName) {
+	case "":
+		responseWriter.Header().Set("Link", fmt.Sprintf(`<%s?%s=second>; rel="next"`, httpRequest.URL.Path, cursorQueryParameterName))
+		_, _ = fmt.Fprintf(responseWriter, `[{"id":%d,"metadata":{"container":{"tags":[]}}}]`, untaggedVersionIdentifier)
+	case "second":
+		_, _ = fmt.Fprintf(responseWriter, `[{"id":%d,"metadata":{"container":{"tags":["latest"]}}}]`, taggedVersionIdentifier)
 	default:
-		responseWriter.Header().Set("Content-Type", "application/json")
-		_, _ = responseWriter.Write([]byte("[]"))
+		testingInstance.Errorf("unexpected cursor %q", httpRequest.URL.RawQuery)
+		responseWriter.WriteHeader(http.StatusBadRequest)
 	}
 }
 
@@ -22,11 +22,13 @@ const (
 	testUntaggedVersionID        = int64(1001)
 	testTaggedVersionID          = int64(1002)
 	errorMessageTemplateConstant = "request %d not configured"
+	lastTaggedVersionPayload     = `{"message":"You cannot delete the last tagged version of a package. You must delete the package instead.","documentation_url":"https://docs.github.com/rest/packages/packages#delete-a-package-version-for-a-user","status":"400"}`
 )
 
 type stubHTTPClient struct {
 	responses       []stubHTTPResponse
 	recordedMethods []string
+	recordedURLs    []string
 }
 
 type stubHTTPResponse struct {
@@ -36,6 +38,7 @@ type stubHTTPResponse struct {
 
 func (client *stubHTTPClient) Do(request *http.Request) (*http.Response, error) {
 	client.recordedMethods = append(client.recordedMethods, request.Method)
+	client.recordedURLs = append(client.recordedURLs, request.URL.String())
 	if len(client.responses) == 0 {
 		return nil, fmt.Errorf(errorMessageTemplateConstant, len(client.recordedMethods))
 	}
@@ -163,12 +166,10 @@ func TestPackageVersionServiceDryRunSkipsDeletion(testingInstance *testing.T) {
 	testingInstance.Parallel()
 
 	pageOneVersions := fmt.Sprintf(`[{"id":%d,"metadata":{"container":{"tags":[]}}},{"id":%d,"metadata":{"container":{"tags":["latest"]}}}]`, testUntaggedVersionID, testTaggedVersionID)
-	emptyPage := "[]"
 
 	client := &stubHTTPClient{
 		responses: []stubHTTPResponse{
 			{response: buildHTTPResponse(http.StatusOK, pageOneVersions)},
-			{response: buildHTTPResponse(http.StatusOK, emptyPage)},
 		},
 	}
 
@@ -186,20 +187,80 @@ func TestPackageVersionServiceDryRunSkipsDeletion(testingInstance *testing.T) {
 	require.Equal(testingInstance, 2, result.TotalVersions)
 	require.Equal(testingInstance, 1, result.UntaggedVersions)
 	require.Equal(testingInstance, 0, result.DeletedVersions)
-	require.Equal(testingInstance, []string{http.MethodGet, http.MethodGet}, client.recordedMethods)
+	require.Equal(testingInstance, []string{http.MethodGet}, client.recordedMethods)
+}
+
+func TestPackageVersionServiceDryRunEstimatesReclaimableBytes(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	pageOneVersions := `[{"id":1,"name":"sha256:aaa","size":700,"metadata":{"container":{"tags":[]}}},{"id":2,"name":"sha256:bbb","metadata":{"container":{"tags":[]}}},{"id":3,"name":"sha256:ccc","metadata":{"container":{"tags":["latest"]}}}]`
+	manifest := `{"config":{"size":100},"layers":[{"size":1000},{"size":2000}]}`
+
+	client := &stubHTTPClient{
+		responses: []stubHTTPResponse{
+			{response: buildHTTPResponse(http.StatusOK, pageOneVersions)},
+			{response: buildHTTPResponse(http.StatusOK, manifest)},
+		},
+	}
+
+	service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), client, ghcr.ServiceConfiguration{PageSize: 3, RegistryBaseURL: "https://registry.example"})
+	require.NoError(testingInstance, serviceError)
+
+	result, purgeError := service.PurgeUntaggedVersions(context.Background(), ghcr.PurgeRequest{
+		Owner:       "Test-Owner",
+		PackageName: testPackageNameConstant,
+		OwnerType:   ghcr.UserOwnerType,
+		Token:       testTokenValueConstant,
+		DryRun:      true,
+	})
+	require.NoError(testingInstance, purgeError)
+	require.Equal(testingInstance, 2, result.UntaggedVersions)
+	require.Equal(testingInstance, int64(3800), result.ReclaimableBytes)
+	require.Equal(testingInstance, "https://registry.example/v2/test-owner/test-package/manifests/sha256:bbb", client.recordedURLs[1])
+}
+
+func TestPackageVersionServiceSumsIndexManifestChildren(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	pageOneVersions := `[{"id":1,"name":"sha256:index","metadata":{"container":{"tags":[]}}}]`
+	indexManifest := `{"manifests":[{"digest":"sha256:amd64","size":500},{"digest":"sha256:arm64","size":500}]}`
+	amdManifest := `{"config":{"size":10},"layers":[{"size":90}]}`
+	armManifest := `{"config":{"size":20},"layers":[{"size":180}]}`
+
+	client := &stubHTTPClient{
+		responses: []stubHTTPResponse{
+			{response: buildHTTPResponse(http.StatusOK, pageOneVersions)},
+			{response: buildHTTPResponse(http.StatusOK, indexManifest)},
+			{response: buildHTTPResponse(http.StatusOK, amdManifest)},
+			{response: buildHTTPResponse(http.StatusOK, armManifest)},
+			{response: buildHTTPResponse(http.StatusNoContent, "")},
+		},
+	}
+
+	service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), client, ghcr.ServiceConfiguration{PageSize: 1})
+	require.NoError(testingInstance, serviceError)
+
+	result, purgeError := service.PurgeUntaggedVersions(context.Background(), ghcr.PurgeRequest{
+		Owner:       testOwnerNameConstant,
+		PackageName: testPackageNameConstant,
+		OwnerType:   ghcr.UserOwnerType,
+		Token:       testTokenValueConstant,
+	})
+	require.NoError(testingInstance, purgeError)
+	require.Equal(testingInstance, 1, result.DeletedVersions)
+	require.Equal(testingInstance, int64(300), result.ReclaimableBytes)
+	require.Equal(testingInstance, []string{http.MethodGet, http.MethodGet, http.MethodGet, http.MethodGet, http.MethodDelete}, client.recordedMethods)
 }
 
 func TestPackageVersionServiceDeletesUntaggedVersions(testingInstance *testing.T) {
 	testingInstance.Parallel()
 
 	pageOneVersions := fmt.Sprintf(`[{"id":%d,"metadata":{"container":{"tags":[]}}}]`, testUntaggedVersionID)
-	emptyPage := "[]"
 
 	client := &stubHTTPClient{
 		responses: []stubHTTPResponse{
 			{response: buildHTTPResponse(http.StatusOK, pageOneVersions)},
 			{response: buildHTTPResponse(http.StatusNoContent, "")},
-			{response: buildHTTPResponse(http.StatusOK, emptyPage)},
 		},
 	}
 
@@ -216,7 +277,171 @@ func TestPackageVersionServiceDeletesUntaggedVersions(testingInstance *testing.T
 	require.Equal(testingInstance, 1, result.TotalVersions)
 	require.Equal(testingInstance, 1, result.UntaggedVersions)
 	require.Equal(testingInstance, 1, result.DeletedVersions)
-	require.Equal(testingInstance, []string{http.MethodGet, http.MethodDelete, http.MethodGet}, client.recordedMethods)
+	require.Equal(testingInstance, []string{http.MethodGet, http.MethodDelete}, client.recordedMethods)
+}
+
+func TestPackageVersionServiceRetainsLastTaggedVersion(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	secondUntaggedVersionID := int64(1003)
+	pageOneVersions := fmt.Sprintf(`[{"id":%d,"name":"sha256:first","size":0,"metadata":{"container":{"tags":[]}}},{"id":%d,"name":"sha256:second","size":0,"metadata":{"container":{"tags":[]}}}]`, testUntaggedVersionID, secondUntaggedVersionID)
+
+	testCases := []struct {
+		name             string
+		deleteResponse   *http.Response
+		failFast         bool
+		expectedError    string
+		expectedRetained int
+		expectedDeleted  int
+		expectedFailures []ghcr.VersionDeletionFailure
+	}{
+		{
+			name:             "last_tagged_version_payload",
+			deleteResponse:   buildHTTPResponse(http.StatusBadRequest, lastTaggedVersionPayload),
+			expectedRetained: 1,
+			expectedDeleted:  1,
+		},
+		{
+			name:            "other_bad_request_payload_continues",
+			deleteResponse:  buildHTTPResponse(http.StatusBadRequest, `{"message":"Validation Failed"}`),
+			expectedDeleted: 1,
+			expectedFailures: []ghcr.VersionDeletionFailure{
+				{VersionID: testUntaggedVersionID, Digest: "sha256:first", StatusCode: http.StatusBadRequest, Message: `{"message":"Validation Failed"}`},
+			},
+		},
+		{
+			name:           "other_bad_request_payload_fail_fast",
+			deleteResponse: buildHTTPResponse(http.StatusBadRequest, `{"message":"Validation Failed"}`),
+			failFast:       true,
+			expectedError:  "failed to delete version 1001: {\"message\":\"Validation Failed\"}",
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+
+		testingInstance.Run(testCase.name, func(testingSubInstance *testing.T) {
+			testingSubInstance.Parallel()
+
+			client := &stubHTTPClient{
+				responses: []stubHTTPResponse{
+					{response: buildHTTPResponse(http.StatusOK, pageOneVersions)},
+					{response: testCase.deleteResponse},
+					{response: buildHTTPResponse(http.StatusNoContent, "")},
+				},
+			}
+
+			service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), client, ghcr.ServiceConfiguration{PageSize: 2})
+			require.NoError(testingSubInstance, serviceError)
+
+			result, purgeError := service.PurgeUntaggedVersions(context.Background(), ghcr.PurgeRequest{
+				Owner:       testOwnerNameConstant,
+				PackageName: testPackageNameConstant,
+				OwnerType:   ghcr.UserOwnerType,
+				Token:       testTokenValueConstant,
+				FailFast:    testCase.failFast,
+			})
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(testingSubInstance, purgeError, testCase.expectedError)
+				return
+			}
+			require.NoError(testingSubInstance, purgeError)
+			require.Equal(testingSubInstance, 2, result.UntaggedVersions)
+			require.Equal(testingSubInstance, testCase.expectedDeleted, result.DeletedVersions)
+			require.Equal(testingSubInstance, testCase.expectedRetained, result.RetainedVersions)
+			require.Equal(testingSubInstance, testCase.expectedFailures, result.Failures)
+			require.Equal(testingSubInstance, []string{http.MethodGet, http.MethodDelete, http.MethodDelete}, client.recordedMethods)
+		})
+	}
+}
+
+func TestPackageVersionServiceCountVersions(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	pageOneVersions := fmt.Sprintf(`[{"id":%d,"metadata":{"container":{"tags":[]}}},{"id":%d,"metadata":{"container":{"tags":["latest"]}}}]`, testUntaggedVersionID, testTaggedVersionID)
+
+	client := &stubHTTPClient{
+		responses: []stubHTTPResponse{
+			{response: buildHTTPResponse(http.StatusOK, pageOneVersions)},
+		},
+	}
+
+	service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), client, ghcr.ServiceConfiguration{PageSize: 2})
+	require.NoError(testingInstance, serviceError)
+
+	result, countError := service.CountVersions(context.Background(), ghcr.PurgeRequest{
+		Owner:       testOwnerNameConstant,
+		PackageName: testPackageNameConstant,
+		OwnerType:   ghcr.UserOwnerType,
+		Token:       testTokenValueConstant,
+	})
+	require.NoError(testingInstance, countError)
+	require.Equal(testingInstance, ghcr.PurgeResult{TotalVersions: 2, UntaggedVersions: 1, TaggedVersions: 1}, result)
+	require.Equal(testingInstance, []string{http.MethodGet}, client.recordedMethods)
+}
+
+func TestPackageVersionServiceDeletePackage(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	validRequest := ghcr.PackageDeletionRequest{
+		Owner:       testOwnerNameConstant,
+		OwnerType:   ghcr.OrganizationOwnerType,
+		PackageType: ghcr.ContainerPackageType,
+		PackageName: testPackageNameConstant,
+		Token:       testTokenValueConstant,
+	}
+
+	testCases := []struct {
+		name          string
+		request       ghcr.PackageDeletionRequest
+		responses     []stubHTTPResponse
+		expectedError string
+		expectedURLs  []string
+	}{
+		{
+			name:         "deletes_package",
+			request:      validRequest,
+			responses:    []stubHTTPResponse{{response: buildHTTPResponse(http.StatusNoContent, "")}},
+			expectedURLs: []string{"https://api.github.com/orgs/test-owner/packages/container/test-package"},
+		},
+		{
+			name:          "reports_failure_body",
+			request:       validRequest,
+			responses:     []stubHTTPResponse{{response: buildHTTPResponse(http.StatusForbidden, `{"message":"Forbidden"}`)}},
+			expectedError: "failed to delete package test-package: {\"message\":\"Forbidden\"}",
+			expectedURLs:  []string{"https://api.github.com/orgs/test-owner/packages/container/test-package"},
+		},
+		{
+			name: "missing_package_type",
+			request: ghcr.PackageDeletionRequest{
+				Owner:       testOwnerNameConstant,
+				OwnerType:   ghcr.OrganizationOwnerType,
+				PackageName: testPackageNameConstant,
+				Token:       testTokenValueConstant,
+			},
+			expectedError: "package type must be provided",
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+
+		testingInstance.Run(testCase.name, func(testingSubInstance *testing.T) {
+			testingSubInstance.Parallel()
+
+			client := &stubHTTPClient{responses: testCase.responses}
+			service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), client, ghcr.ServiceConfiguration{})
+			require.NoError(testingSubInstance, serviceError)
+
+			deleteError := service.DeletePackage(context.Background(), testCase.request)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(testingSubInstance, deleteError, testCase.expectedError)
+			} else {
+				require.NoError(testingSubInstance, deleteError)
+			}
+			require.Equal(testingSubInstance, testCase.expectedURLs, client.recordedURLs)
+		})
+	}
 }
 
 func buildHTTPResponse(statusCode int, body string) *http.Response {
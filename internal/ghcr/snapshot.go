@@ -0,0 +1,229 @@
+package ghcr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+const (
+	snapshotDecodeErrorTemplateConstant         = "unable to decode package snapshot: %w"
+	snapshotEncodeErrorTemplateConstant         = "unable to encode package snapshot: %w"
+	snapshotPackageInvalidErrorTemplateConstant = "snapshot package %d: %w"
+	snapshotPackageNotFoundTemplateConstant     = "%w: %s/%s"
+	snapshotIndentConstant                      = "  "
+)
+
+// ErrSnapshotPackageNotFound indicates a purge requested a package the snapshot did not record.
+var ErrSnapshotPackageNotFound = errors.New("package not recorded in snapshot")
+
+// ErrSnapshotReadOnly indicates a deletion was attempted against a recorded snapshot.
+var ErrSnapshotReadOnly = errors.New("package snapshots are read-only; deletions require the GHCR API")
+
+// Snapshot records package version listings so purge policies can be evaluated offline.
+type Snapshot struct {
+	Packages []SnapshotPackage `json:"packages"`
+}
+
+// SnapshotPackage records every version of one package together with the owner it belongs to.
+type SnapshotPackage struct {
+	Owner       string           `json:"owner"`
+	OwnerType   OwnerType        `json:"owner_type"`
+	PackageName string           `json:"package"`
+	Versions    []PackageVersion `json:"versions"`
+}
+
+// ReadSnapshot decodes and validates a snapshot previously written by Snapshot.Write.
+func ReadSnapshot(reader io.Reader) (Snapshot, error) {
+	var snapshot Snapshot
+	if decodeError := json.NewDecoder(reader).Decode(&snapshot); decodeError != nil {
+		return Snapshot{}, fmt.Errorf(snapshotDecodeErrorTemplateConstant, decodeError)
+	}
+
+	for packageIndex := range snapshot.Packages {
+		recordedPackage := &snapshot.Packages[packageIndex]
+		recordedPackage.Owner = strings.TrimSpace(recordedPackage.Owner)
+		recordedPackage.PackageName = strings.TrimSpace(recordedPackage.PackageName)
+		if len(recordedPackage.Owner) == 0 {
+			return Snapshot{}, fmt.Errorf(snapshotPackageInvalidErrorTemplateConstant, packageIndex, errors.New(ownerMissingErrorMessageConstant))
+		}
+		if len(recordedPackage.PackageName) == 0 {
+			return Snapshot{}, fmt.Errorf(snapshotPackageInvalidErrorTemplateConstant, packageIndex, errors.New(packageMissingErrorMessageConstant))
+		}
+		ownerType, ownerTypeError := ParseOwnerType(string(recordedPackage.OwnerType))
+		if ownerTypeError != nil {
+			return Snapshot{}, fmt.Errorf(snapshotPackageInvalidErrorTemplateConstant, packageIndex, ownerTypeError)
+		}
+		recordedPackage.OwnerType = ownerType
+	}
+
+	return snapshot, nil
+}
+
+// Write encodes the snapshot as indented JSON.
+func (snapshot Snapshot) Write(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", snapshotIndentConstant)
+	if encodeError := encoder.Encode(snapshot); encodeError != nil {
+		return fmt.Errorf(snapshotEncodeErrorTemplateConstant, encodeError)
+	}
+	return nil
+}
+
+// NewSnapshotVersionStore returns a read-only VersionStore that serves listings and sizes from the snapshot without network access.
+// Sizes missing from the snapshot count as zero, and every deletion fails with ErrSnapshotReadOnly.
+func NewSnapshotVersionStore(snapshot Snapshot) VersionStore {
+	return snapshotVersionStore{snapshot: snapshot}
+}
+
+type snapshotVersionStore struct {
+	snapshot Snapshot
+}
+
+func (store snapshotVersionStore) ListVersions(_ context.Context, request PurgeRequest) ([]PackageVersion, error) {
+	for _, recordedPackage := range store.snapshot.Packages {
+		if !snapshotPackageMatches(recordedPackage, request.Owner, request.PackageName) {
+			continue
+		}
+		return append([]PackageVersion{}, recordedPackage.Versions...), nil
+	}
+	return nil, fmt.Errorf(snapshotPackageNotFoundTemplateConstant, ErrSnapshotPackageNotFound, request.Owner, request.PackageName)
+}
+
+func (store snapshotVersionStore) VersionSize(_ context.Context, _ PurgeRequest, version PackageVersion) int64 {
+	if version.Size == nil {
+		return 0
+	}
+	return *version.Size
+}
+
+func (store snapshotVersionStore) DeleteVersion(context.Context, PurgeRequest, int64) error {
+	return ErrSnapshotReadOnly
+}
+
+func (store snapshotVersionStore) VersionLabels(_ context.Context, _ PurgeRequest, version PackageVersion) (map[string]string, int, error) {
+	if version.Labels == nil {
+		return nil, 0, ErrSnapshotLabelsNotRecorded
+	}
+	return version.Labels, 0, nil
+}
+
+// SnapshotRecorder captures the listings and resolved sizes a VersionStore serves so they can be written as a Snapshot.
+type SnapshotRecorder struct {
+	mutex    sync.Mutex
+	packages []SnapshotPackage
+}
+
+// NewSnapshotRecorder constructs an empty recorder.
+func NewSnapshotRecorder() *SnapshotRecorder {
+	return &SnapshotRecorder{}
+}
+
+// Wrap returns a VersionStore that delegates to the provided store and records everything it lists.
+// Sizes and labels resolved from the registry are stored on the recorded versions so a replay reports the same totals.
+func (recorder *SnapshotRecorder) Wrap(store VersionStore) VersionStore {
+	return recordingVersionStore{recorder: recorder, store: store}
+}
+
+// Snapshot returns the packages recorded so far.
+func (recorder *SnapshotRecorder) Snapshot() Snapshot {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	packages := make([]SnapshotPackage, 0, len(recorder.packages))
+	for _, recordedPackage := range recorder.packages {
+		recordedPackage.Versions = append([]PackageVersion{}, recordedPackage.Versions...)
+		packages = append(packages, recordedPackage)
+	}
+	return Snapshot{Packages: packages}
+}
+
+func (recorder *SnapshotRecorder) recordVersions(request PurgeRequest, versions []PackageVersion) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recordedPackage := recorder.packageFor(request)
+	recordedPackage.Versions = append([]PackageVersion{}, versions...)
+}
+
+func (recorder *SnapshotRecorder) recordSize(request PurgeRequest, versionID int64, size int64) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recordedPackage := recorder.packageFor(request)
+	for versionIndex := range recordedPackage.Versions {
+		recordedVersion := &recordedPackage.Versions[versionIndex]
+		if recordedVersion.ID != versionID || recordedVersion.Size != nil {
+			continue
+		}
+		recordedSize := size
+		recordedVersion.Size = &recordedSize
+	}
+}
+
+func (recorder *SnapshotRecorder) recordLabels(request PurgeRequest, versionID int64, labels map[string]string) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recordedPackage := recorder.packageFor(request)
+	for versionIndex := range recordedPackage.Versions {
+		if recordedPackage.Versions[versionIndex].ID == versionID {
+			recordedPackage.Versions[versionIndex].Labels = labels
+		}
+	}
+}
+
+func (recorder *SnapshotRecorder) packageFor(request PurgeRequest) *SnapshotPackage {
+	for packageIndex := range recorder.packages {
+		if snapshotPackageMatches(recorder.packages[packageIndex], request.Owner, request.PackageName) {
+			return &recorder.packages[packageIndex]
+		}
+	}
+	recorder.packages = append(recorder.packages, SnapshotPackage{
+		Owner:       request.Owner,
+		OwnerType:   request.OwnerType,
+		PackageName: request.PackageName,
+	})
+	return &recorder.packages[len(recorder.packages)-1]
+}
+
+type recordingVersionStore struct {
+	recorder *SnapshotRecorder
+	store    VersionStore
+}
+
+func (store recordingVersionStore) ListVersions(executionContext context.Context, request PurgeRequest) ([]PackageVersion, error) {
+	versions, listError := store.store.ListVersions(executionContext, request)
+	if listError != nil {
+		return versions, listError
+	}
+	store.recorder.recordVersions(request, versions)
+	return versions, nil
+}
+
+func (store recordingVersionStore) VersionSize(executionContext context.Context, request PurgeRequest, version PackageVersion) int64 {
+	size := store.store.VersionSize(executionContext, request, version)
+	store.recorder.recordSize(request, version.ID, size)
+	return size
+}
+
+func (store recordingVersionStore) DeleteVersion(executionContext context.Context, request PurgeRequest, versionID int64) error {
+	return store.store.DeleteVersion(executionContext, request, versionID)
+}
+
+func (store recordingVersionStore) VersionLabels(executionContext context.Context, request PurgeRequest, version PackageVersion) (map[string]string, int, error) {
+	labels, fetches, labelError := store.store.VersionLabels(executionContext, request, version)
+	if labelError != nil {
+		return labels, fetches, labelError
+	}
+	store.recorder.recordLabels(request, version.ID, labels)
+	return labels, fetches, nil
+}
+
+func snapshotPackageMatches(recordedPackage SnapshotPackage, owner string, packageName string) bool {
+	return strings.EqualFold(recordedPackage.Owner, owner) && strings.EqualFold(recordedPackage.PackageName, packageName)
+}
@@ -0,0 +1,138 @@
+package ghcr_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/ghcr"
+)
+
+func TestSnapshotRecordingReplaysIdenticalDryRun(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	pageOneVersions := `[{"id":1,"name":"sha256:aaa","size":700,"metadata":{"container":{"tags":[]}}},{"id":2,"name":"sha256:bbb","metadata":{"container":{"tags":[]}}},{"id":3,"name":"sha256:ccc","metadata":{"container":{"tags":["latest"]}}}]`
+	manifest := `{"config":{"size":100},"layers":[{"size":1000},{"size":2000}]}`
+
+	liveClient := &stubHTTPClient{
+		responses: []stubHTTPResponse{
+			{response: buildHTTPResponse(http.StatusOK, pageOneVersions)},
+			{response: buildHTTPResponse(http.StatusOK, manifest)},
+			{response: buildHTTPResponse(http.StatusOK, "[]")},
+		},
+	}
+	liveService, liveServiceError := ghcr.NewPackageVersionService(zap.NewNop(), liveClient, ghcr.ServiceConfiguration{PageSize: 3})
+	require.NoError(testingInstance, liveServiceError)
+	recorder := ghcr.NewSnapshotRecorder()
+	liveService.SetVersionStore(recorder.Wrap(liveService.VersionStore()))
+
+	request := ghcr.PurgeRequest{
+		Owner:       testOwnerNameConstant,
+		PackageName: testPackageNameConstant,
+		OwnerType:   ghcr.OrganizationOwnerType,
+		Token:       testTokenValueConstant,
+		DryRun:      true,
+	}
+	liveResult, liveError := liveService.PurgeUntaggedVersions(context.Background(), request)
+	require.NoError(testingInstance, liveError)
+
+	var encoded bytes.Buffer
+	require.NoError(testingInstance, recorder.Snapshot().Write(&encoded))
+	snapshot, readError := ghcr.ReadSnapshot(&encoded)
+	require.NoError(testingInstance, readError)
+	require.Len(testingInstance, snapshot.Packages, 1)
+	require.Equal(testingInstance, ghcr.OrganizationOwnerType, snapshot.Packages[0].OwnerType)
+	require.Len(testingInstance, snapshot.Packages[0].Versions, 3)
+
+	replayClient := &stubHTTPClient{}
+	replayService, replayServiceError := ghcr.NewPackageVersionService(zap.NewNop(), replayClient, ghcr.ServiceConfiguration{})
+	require.NoError(testingInstance, replayServiceError)
+	replayService.SetVersionStore(ghcr.NewSnapshotVersionStore(snapshot))
+
+	replayResult, replayError := replayService.PurgeUntaggedVersions(context.Background(), request)
+	require.NoError(testingInstance, replayError)
+	require.Equal(testingInstance, liveResult, replayResult)
+	require.Equal(testingInstance, int64(3800), replayResult.ReclaimableBytes)
+
+	countResult, countError := replayService.CountVersions(context.Background(), request)
+	require.NoError(testingInstance, countError)
+	require.Equal(testingInstance, 3, countResult.TotalVersions)
+	require.Equal(testingInstance, 1, countResult.TaggedVersions)
+	require.Empty(testingInstance, replayClient.recordedURLs)
+}
+
+func TestSnapshotVersionStoreRefusesDeletionsAndUnknownPackages(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	snapshot := ghcr.Snapshot{Packages: []ghcr.SnapshotPackage{{
+		Owner:       testOwnerNameConstant,
+		OwnerType:   ghcr.UserOwnerType,
+		PackageName: testPackageNameConstant,
+		Versions:    []ghcr.PackageVersion{{ID: testUntaggedVersionID, Name: "sha256:aaa"}},
+	}}}
+	service, serviceError := ghcr.NewPackageVersionService(zap.NewNop(), &stubHTTPClient{}, ghcr.ServiceConfiguration{})
+	require.NoError(testingInstance, serviceError)
+	service.SetVersionStore(ghcr.NewSnapshotVersionStore(snapshot))
+
+	_, purgeError := service.PurgeUntaggedVersions(context.Background(), ghcr.PurgeRequest{
+		Owner:       testOwnerNameConstant,
+		PackageName: testPackageNameConstant,
+		OwnerType:   ghcr.UserOwnerType,
+		Token:       testTokenValueConstant,
+		FailFast:    true,
+	})
+	require.ErrorIs(testingInstance, purgeError, ghcr.ErrSnapshotReadOnly)
+
+	_, missingError := service.CountVersions(context.Background(), ghcr.PurgeRequest{
+		Owner:       testOwnerNameConstant,
+		PackageName: "other-package",
+		OwnerType:   ghcr.UserOwnerType,
+		Token:       testTokenValueConstant,
+	})
+	require.ErrorIs(testingInstance, missingError, ghcr.ErrSnapshotPackageNotFound)
+}
+
+func TestReadSnapshotValidation(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	testCases := []struct {
+		name          string
+		contents      string
+		expectedError string
+	}{
+		{
+			name:          "malformed_json",
+			contents:      `{"packages":`,
+			expectedError: "unable to decode package snapshot: unexpected EOF",
+		},
+		{
+			name:          "missing_owner",
+			contents:      `{"packages":[{"owner_type":"user","package":"app"}]}`,
+			expectedError: "snapshot package 0: owner must be provided",
+		},
+		{
+			name:          "missing_package",
+			contents:      `{"packages":[{"owner":"acme","owner_type":"org"}]}`,
+			expectedError: "snapshot package 0: package name must be provided",
+		},
+		{
+			name:          "unsupported_owner_type",
+			contents:      `{"packages":[{"owner":"acme","owner_type":"team","package":"app"}]}`,
+			expectedError: `snapshot package 0: owner type "team" is not supported`,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testingInstance.Run(testCase.name, func(subtest *testing.T) {
+			subtest.Parallel()
+			_, readError := ghcr.ReadSnapshot(strings.NewReader(testCase.contents))
+			require.EqualError(subtest, readError, testCase.expectedError)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package ghcr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRegistryBaseURLConstant        = "https://ghcr.io"
+	registryAPIVersionPathSegmentConstant = "v2"
+	registryManifestsPathSegmentConstant  = "manifests"
+	registryManifestAcceptHeaderConstant  = "application/vnd.oci.image.index.v1+json, application/vnd.docker.distribution.manifest.list.v2+json, application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json"
+	registryDigestPrefixConstant          = "sha256:"
+	manifestStatusErrorTemplateConstant   = "unexpected status code %d for manifest %s"
+	manifestDecodeErrorTemplateConstant   = "unable to decode manifest %s: %w"
+	versionSizeUnavailableMessageConstant = "GHCR package version size unavailable"
+	versionDigestLogFieldNameConstant     = "digest"
+	versionDigestMissingMessageConstant   = "package version has no manifest digest"
+)
+
+type registryManifest struct {
+	Config    registryDescriptor   `json:"config"`
+	Layers    []registryDescriptor `json:"layers"`
+	Manifests []registryDescriptor `json:"manifests"`
+}
+
+type registryDescriptor struct {
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+}
+
+func (manifest registryManifest) imageSize() int64 {
+	total := manifest.Config.Size
+	for _, layer := range manifest.Layers {
+		total += layer.Size
+	}
+	return total
+}
+
+// versionSize returns the storage a version occupies, preferring the size reported by the versions listing and
+// otherwise summing the config and layer sizes from its registry manifest. Unresolvable sizes count as zero.
+func (service *PackageVersionService) versionSize(executionContext context.Context, request PurgeRequest, version PackageVersion) int64 {
+	if version.Size != nil {
+		return *version.Size
+	}
+
+	size, sizeError := service.manifestSize(executionContext, request, version.Name)
+	if sizeError != nil {
+		service.logger.Debug(
+			versionSizeUnavailableMessageConstant,
+			zap.Int64(versionIdentifierLogFieldNameConstant, version.ID),
+			zap.String(versionDigestLogFieldNameConstant, version.Name),
+			zap.Error(sizeError),
+		)
+		return 0
+	}
+	return size
+}
+
+func (service *PackageVersionService) manifestSize(executionContext context.Context, request PurgeRequest, digest string) (int64, error) {
+	if !strings.HasPrefix(digest, registryDigestPrefixConstant) {
+		return 0, fmt.Errorf("%s: %q", versionDigestMissingMessageConstant, digest)
+	}
+
+	manifest, manifestError := service.fetchManifest(executionContext, request, digest)
+	if manifestError != nil {
+		return 0, manifestError
+	}
+	if len(manifest.Manifests) == 0 {
+		return manifest.imageSize(), nil
+	}
+
+	total := int64(0)
+	for _, descriptor := range manifest.Manifests {
+		childManifest, childError := service.fetchManifest(executionContext, request, descriptor.Digest)
+		if childError != nil {
+			return 0, childError
+		}
+		total += childManifest.imageSize()
+	}
+	return total, nil
+}
+
+func (service *PackageVersionService) fetchManifest(executionContext context.Context, request PurgeRequest, digest string) (registryManifest, error) {
+	manifestURL, urlBuildError := service.buildManifestURL(request.Owner, request.PackageName, digest)
+	if urlBuildError != nil {
+		return registryManifest{}, urlBuildError
+	}
+
+	httpRequest, requestCreationError := http.NewRequestWithContext(executionContext, http.MethodGet, manifestURL, nil)
+	if requestCreationError != nil {
+		return registryManifest{}, fmt.Errorf(requestCreationErrorTemplateConstant, http.MethodGet, manifestURL, requestCreationError)
+	}
+	httpRequest.Header.Set(acceptHeaderNameConstant, registryManifestAcceptHeaderConstant)
+	httpRequest.Header.Set(authorizationHeaderNameConstant, fmt.Sprintf(bearerTokenTemplateConstant, base64.StdEncoding.EncodeToString([]byte(request.Token))))
+
+	httpResponse, requestError := service.httpClient.Do(httpRequest)
+	if requestError != nil {
+		return registryManifest{}, fmt.Errorf(requestExecutionErrorTemplateConstant, requestError)
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, httpResponse.Body)
+		return registryManifest{}, fmt.Errorf(manifestStatusErrorTemplateConstant, httpResponse.StatusCode, digest)
+	}
+
+	var manifest registryManifest
+	if decodeError := json.NewDecoder(httpResponse.Body).Decode(&manifest); decodeError != nil {
+		return registryManifest{}, fmt.Errorf(manifestDecodeErrorTemplateConstant, digest, decodeError)
+	}
+	return manifest, nil
+}
+
+func (service *PackageVersionService) buildManifestURL(owner string, packageName string, digest string) (string, error) {
+	registryURL, parseError := url.Parse(service.registryBaseURL)
+	if parseError != nil {
+		return "", parseError
+	}
+
+	pathSegments := []string{
+		strings.TrimSuffix(registryURL.Path, "/"),
+		registryAPIVersionPathSegmentConstant,
+		url.PathEscape(strings.ToLower(owner)),
+		url.PathEscape(strings.ToLower(packageName)),
+		registryManifestsPathSegmentConstant,
+		url.PathEscape(digest),
+	}
+
+	registryURL.Path = strings.Join(pathSegments, "/")
+	registryURL.RawQuery = ""
+	return registryURL.String(), nil
+}
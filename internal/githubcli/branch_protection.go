@@ -0,0 +1,219 @@
+package githubcli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubauth"
+)
+
+const (
+	graphQLBranchProtectionRulesTemplateConstant = "query { repository(owner: %s, name: %s) { branchProtectionRules(first: 100) { nodes { pattern allowsDeletions allowsForcePushes } } } }"
+	listBranchProtectionRulesOperationConstant   = OperationName("ListBranchProtectionRules")
+	repositoryNotFoundMessageConstant            = "repository not found"
+	patternDoubleWildcardConstant                = "**"
+	patternDoubleWildcardExpressionConstant      = ".*"
+	patternSingleWildcardExpressionConstant      = "[^/]*"
+	patternSingleCharacterExpressionConstant     = "[^/]"
+	patternExpressionAnchorStartConstant         = "^"
+	patternExpressionAnchorEndConstant           = "$"
+	patternWildcardCharactersConstant            = "*?["
+)
+
+// BranchProtectionRule describes one repository protection rule and the restrictions it applies to matching branches.
+type BranchProtectionRule struct {
+	Pattern           string
+	AllowsDeletions   bool
+	AllowsForcePushes bool
+}
+
+// BranchProtectionRules lists the branch name patterns a repository protects.
+type BranchProtectionRules struct {
+	Patterns []string
+	Rules    []BranchProtectionRule
+}
+
+// Protects reports whether any protection rule pattern matches the branch name.
+// Patterns follow GitHub's fnmatch semantics: "*" and "?" stop at "/", "**" spans path segments, and bracket classes are honored.
+func (rules BranchProtectionRules) Protects(branchName string) bool {
+	trimmedBranch := strings.TrimSpace(branchName)
+	if len(trimmedBranch) == 0 {
+		return false
+	}
+	for _, pattern := range rules.Patterns {
+		if branchPatternMatches(pattern, trimmedBranch) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchingRules returns the protection rules whose pattern matches the branch name, in repository order.
+func (rules BranchProtectionRules) MatchingRules(branchName string) []BranchProtectionRule {
+	trimmedBranch := strings.TrimSpace(branchName)
+	if len(trimmedBranch) == 0 {
+		return nil
+	}
+	var matchingRules []BranchProtectionRule
+	for _, rule := range rules.Rules {
+		if branchPatternMatches(rule.Pattern, trimmedBranch) {
+			matchingRules = append(matchingRules, rule)
+		}
+	}
+	return matchingRules
+}
+
+// IsWildcardBranchPattern reports whether the pattern uses wildcards or character classes rather than naming one branch.
+func IsWildcardBranchPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, patternWildcardCharactersConstant)
+}
+
+// ListBranchProtectionRules retrieves the repository's branch protection rule patterns via GraphQL.
+func (client *Client) ListBranchProtectionRules(executionContext context.Context, repository string) (BranchProtectionRules, error) {
+	repositoryIdentifier := strings.TrimSpace(repository)
+	owner, name, found := strings.Cut(repositoryIdentifier, ownerRepositorySeparatorConstant)
+	if !found || len(owner) == 0 || len(name) == 0 {
+		return BranchProtectionRules{}, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: invalidOwnerRepositoryMessageConstant}
+	}
+
+	query := fmt.Sprintf(graphQLBranchProtectionRulesTemplateConstant, strconv.Quote(owner), strconv.Quote(name))
+	commandDetails := execshell.CommandDetails{
+		Arguments: []string{
+			apiSubcommandConstant,
+			graphQLEndpointConstant,
+			fieldFlagConstant,
+			fmt.Sprintf(graphQLQueryFieldTemplateConstant, query),
+		},
+		GitHubTokenRequirement: githubauth.TokenRequired,
+		Idempotent:             true,
+	}
+
+	executionResult, executionError := client.runGitHubCLI(executionContext, commandDetails)
+	if executionError != nil {
+		return BranchProtectionRules{}, OperationError{Operation: listBranchProtectionRulesOperationConstant, Cause: executionError}
+	}
+
+	var response struct {
+		Data struct {
+			Repository *struct {
+				BranchProtectionRules struct {
+					Nodes []struct {
+						Pattern           string `json:"pattern"`
+						AllowsDeletions   bool   `json:"allowsDeletions"`
+						AllowsForcePushes bool   `json:"allowsForcePushes"`
+					} `json:"nodes"`
+				} `json:"branchProtectionRules"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if decodingError := json.Unmarshal([]byte(executionResult.StandardOutput), &response); decodingError != nil {
+		return BranchProtectionRules{}, ResponseDecodingError{Operation: listBranchProtectionRulesOperationConstant, Cause: decodingError}
+	}
+	if response.Data.Repository == nil {
+		return BranchProtectionRules{}, OperationError{Operation: listBranchProtectionRulesOperationConstant, Cause: InvalidInputError{FieldName: repositoryFieldNameConstant, Message: repositoryNotFoundMessageConstant}}
+	}
+
+	rules := BranchProtectionRules{}
+	for _, node := range response.Data.Repository.BranchProtectionRules.Nodes {
+		trimmedPattern := strings.TrimSpace(node.Pattern)
+		if len(trimmedPattern) == 0 {
+			continue
+		}
+		rules.Patterns = append(rules.Patterns, trimmedPattern)
+		rules.Rules = append(rules.Rules, BranchProtectionRule{Pattern: trimmedPattern, AllowsDeletions: node.AllowsDeletions, AllowsForcePushes: node.AllowsForcePushes})
+	}
+	return rules, nil
+}
+
+// CachedBranchProtectionProvider reports branch protection through CheckBranchProtection, querying each branch at
+// most once per run. A failed lookup is remembered for the whole repository, so the remaining branches of a repository
+// whose protection cannot be read do not repeat the failing call.
+type CachedBranchProtectionProvider struct {
+	client   *Client
+	mutex    sync.Mutex
+	branches map[string]map[string]bool
+	failures map[string]error
+}
+
+// NewCachedBranchProtectionProvider constructs a provider backed by the client.
+func NewCachedBranchProtectionProvider(client *Client) *CachedBranchProtectionProvider {
+	return &CachedBranchProtectionProvider{client: client, branches: make(map[string]map[string]bool), failures: make(map[string]error)}
+}
+
+// BranchProtected reports whether GitHub protects the branch, or returns the repository's earlier lookup failure.
+func (provider *CachedBranchProtectionProvider) BranchProtected(executionContext context.Context, repository string, branchName string) (bool, error) {
+	cacheKey := metadataCacheKey(repository)
+	trimmedBranch := strings.TrimSpace(branchName)
+
+	provider.mutex.Lock()
+	if failure, failed := provider.failures[cacheKey]; failed {
+		provider.mutex.Unlock()
+		return false, failure
+	}
+	protected, cached := provider.branches[cacheKey][trimmedBranch]
+	provider.mutex.Unlock()
+	if cached {
+		return protected, nil
+	}
+
+	protected, protectionError := provider.client.CheckBranchProtection(executionContext, repository, trimmedBranch)
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	if protectionError != nil {
+		provider.failures[cacheKey] = protectionError
+		return false, protectionError
+	}
+	if provider.branches[cacheKey] == nil {
+		provider.branches[cacheKey] = make(map[string]bool)
+	}
+	provider.branches[cacheKey][trimmedBranch] = protected
+	return protected, nil
+}
+
+func branchPatternMatches(pattern string, branchName string) bool {
+	expression, compileError := regexp.Compile(branchPatternExpression(strings.TrimSpace(pattern)))
+	if compileError != nil {
+		return false
+	}
+	return expression.MatchString(branchName)
+}
+
+func branchPatternExpression(pattern string) string {
+	var builder strings.Builder
+	builder.WriteString(patternExpressionAnchorStartConstant)
+	for index := 0; index < len(pattern); index++ {
+		character := pattern[index]
+		switch {
+		case strings.HasPrefix(pattern[index:], patternDoubleWildcardConstant):
+			builder.WriteString(patternDoubleWildcardExpressionConstant)
+			index++
+		case character == '*':
+			builder.WriteString(patternSingleWildcardExpressionConstant)
+		case character == '?':
+			builder.WriteString(patternSingleCharacterExpressionConstant)
+		case character == '[':
+			closingOffset := strings.IndexByte(pattern[index+1:], ']')
+			if closingOffset < 0 {
+				builder.WriteString(regexp.QuoteMeta(string(character)))
+				continue
+			}
+			classBody := pattern[index+1 : index+1+closingOffset]
+			if strings.HasPrefix(classBody, "!") {
+				classBody = "^" + classBody[1:]
+			}
+			builder.WriteString("[" + classBody + "]")
+			index += closingOffset + 1
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(character)))
+		}
+	}
+	builder.WriteString(patternExpressionAnchorEndConstant)
+	return builder.String()
+}
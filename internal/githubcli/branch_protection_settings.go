@@ -0,0 +1,237 @@
+package githubcli
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	getBranchProtectionOperationNameConstant   = OperationName("GetBranchProtection")
+	applyBranchProtectionOperationNameConstant = OperationName("ApplyBranchProtection")
+)
+
+// BranchProtection is the typed form of a branch protection rule. ParseBranchProtection reads it from the protection
+// API response and UpdatePayload renders it as the request body for the protection PUT endpoint, so the read and write
+// models stay in one place. Nil sections mean the rule does not configure them.
+type BranchProtection struct {
+	RequiredStatusChecks       *RequiredStatusChecks
+	EnforceAdmins              bool
+	RequiredPullRequestReviews *RequiredPullRequestReviews
+	Restrictions               *BranchRestrictions
+	RequiredLinearHistory      bool
+	AllowForcePushes           bool
+	AllowDeletions             bool
+	LockBranch                 bool
+}
+
+// RequiredStatusChecks lists the status checks that must pass before merging.
+type RequiredStatusChecks struct {
+	Strict   bool
+	Contexts []string
+}
+
+// RequiredPullRequestReviews describes the review requirements for merging.
+type RequiredPullRequestReviews struct {
+	DismissStaleReviews          bool
+	RequireCodeOwnerReviews      bool
+	RequiredApprovingReviewCount int
+}
+
+// BranchRestrictions lists the users, teams, and apps allowed to push to the branch.
+type BranchRestrictions struct {
+	Users []string
+	Teams []string
+	Apps  []string
+}
+
+type branchProtectionEnabledSetting struct {
+	Enabled bool `json:"enabled"`
+}
+
+type branchProtectionStatusCheck struct {
+	Context string `json:"context"`
+}
+
+type branchProtectionActor struct {
+	Login string `json:"login"`
+	Slug  string `json:"slug"`
+}
+
+type branchProtectionResponse struct {
+	RequiredStatusChecks *struct {
+		Strict   bool                          `json:"strict"`
+		Contexts []string                      `json:"contexts"`
+		Checks   []branchProtectionStatusCheck `json:"checks"`
+	} `json:"required_status_checks"`
+	EnforceAdmins              *branchProtectionEnabledSetting `json:"enforce_admins"`
+	RequiredPullRequestReviews *struct {
+		DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+		RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	} `json:"required_pull_request_reviews"`
+	Restrictions *struct {
+		Users []branchProtectionActor `json:"users"`
+		Teams []branchProtectionActor `json:"teams"`
+		Apps  []branchProtectionActor `json:"apps"`
+	} `json:"restrictions"`
+	RequiredLinearHistory *branchProtectionEnabledSetting `json:"required_linear_history"`
+	AllowForcePushes      *branchProtectionEnabledSetting `json:"allow_force_pushes"`
+	AllowDeletions        *branchProtectionEnabledSetting `json:"allow_deletions"`
+	LockBranch            *branchProtectionEnabledSetting `json:"lock_branch"`
+}
+
+type branchProtectionStatusChecksPayload struct {
+	Strict   bool     `json:"strict"`
+	Contexts []string `json:"contexts"`
+}
+
+type branchProtectionReviewsPayload struct {
+	DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+	RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+}
+
+type branchProtectionRestrictionsPayload struct {
+	Users []string `json:"users"`
+	Teams []string `json:"teams"`
+	Apps  []string `json:"apps"`
+}
+
+type branchProtectionPayload struct {
+	RequiredStatusChecks       *branchProtectionStatusChecksPayload `json:"required_status_checks"`
+	EnforceAdmins              bool                                 `json:"enforce_admins"`
+	RequiredPullRequestReviews *branchProtectionReviewsPayload      `json:"required_pull_request_reviews"`
+	Restrictions               *branchProtectionRestrictionsPayload `json:"restrictions"`
+	RequiredLinearHistory      bool                                 `json:"required_linear_history,omitempty"`
+	LockBranch                 bool                                 `json:"lock_branch"`
+	AllowForcePushes           bool                                 `json:"allow_force_pushes"`
+	AllowDeletions             bool                                 `json:"allow_deletions"`
+}
+
+// ParseBranchProtection decodes a branch protection API response. Status check names come from the legacy contexts
+// list, falling back to the checks list when contexts is empty.
+func ParseBranchProtection(payload []byte) (BranchProtection, error) {
+	var response branchProtectionResponse
+	if decodingError := json.Unmarshal(payload, &response); decodingError != nil {
+		return BranchProtection{}, decodingError
+	}
+
+	protection := BranchProtection{
+		EnforceAdmins:         enabledSetting(response.EnforceAdmins),
+		RequiredLinearHistory: enabledSetting(response.RequiredLinearHistory),
+		AllowForcePushes:      enabledSetting(response.AllowForcePushes),
+		AllowDeletions:        enabledSetting(response.AllowDeletions),
+		LockBranch:            enabledSetting(response.LockBranch),
+	}
+
+	if response.RequiredStatusChecks != nil {
+		contexts := append([]string{}, response.RequiredStatusChecks.Contexts...)
+		if len(contexts) == 0 {
+			for _, check := range response.RequiredStatusChecks.Checks {
+				contexts = append(contexts, check.Context)
+			}
+		}
+		protection.RequiredStatusChecks = &RequiredStatusChecks{Strict: response.RequiredStatusChecks.Strict, Contexts: contexts}
+	}
+
+	if response.RequiredPullRequestReviews != nil {
+		protection.RequiredPullRequestReviews = &RequiredPullRequestReviews{
+			DismissStaleReviews:          response.RequiredPullRequestReviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      response.RequiredPullRequestReviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: response.RequiredPullRequestReviews.RequiredApprovingReviewCount,
+		}
+	}
+
+	if response.Restrictions != nil {
+		protection.Restrictions = &BranchRestrictions{
+			Users: actorNames(response.Restrictions.Users),
+			Teams: actorNames(response.Restrictions.Teams),
+			Apps:  actorNames(response.Restrictions.Apps),
+		}
+	}
+
+	return protection, nil
+}
+
+// UpdatePayload renders the protection as the JSON body accepted by the branch protection PUT endpoint.
+func (protection BranchProtection) UpdatePayload() ([]byte, error) {
+	payload := branchProtectionPayload{
+		EnforceAdmins:         protection.EnforceAdmins,
+		RequiredLinearHistory: protection.RequiredLinearHistory,
+		LockBranch:            protection.LockBranch,
+		AllowForcePushes:      protection.AllowForcePushes,
+		AllowDeletions:        protection.AllowDeletions,
+	}
+	if protection.RequiredStatusChecks != nil {
+		payload.RequiredStatusChecks = &branchProtectionStatusChecksPayload{
+			Strict:   protection.RequiredStatusChecks.Strict,
+			Contexts: nonNilStrings(protection.RequiredStatusChecks.Contexts),
+		}
+	}
+	if protection.RequiredPullRequestReviews != nil {
+		payload.RequiredPullRequestReviews = &branchProtectionReviewsPayload{
+			DismissStaleReviews:          protection.RequiredPullRequestReviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      protection.RequiredPullRequestReviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: protection.RequiredPullRequestReviews.RequiredApprovingReviewCount,
+		}
+	}
+	if protection.Restrictions != nil {
+		payload.Restrictions = &branchProtectionRestrictionsPayload{
+			Users: nonNilStrings(protection.Restrictions.Users),
+			Teams: nonNilStrings(protection.Restrictions.Teams),
+			Apps:  nonNilStrings(protection.Restrictions.Apps),
+		}
+	}
+	return json.Marshal(payload)
+}
+
+// GetBranchProtection retrieves the protection rule of the branch. The boolean is false, with no error, when the branch is unprotected.
+func (client *Client) GetBranchProtection(executionContext context.Context, repository string, branchName string) (BranchProtection, bool, error) {
+	responseBody, protected, responseError := client.GetBranchProtectionResponse(executionContext, repository, branchName)
+	if responseError != nil || !protected {
+		return BranchProtection{}, false, responseError
+	}
+
+	protection, decodingError := ParseBranchProtection(responseBody)
+	if decodingError != nil {
+		return BranchProtection{}, false, ResponseDecodingError{Operation: getBranchProtectionOperationNameConstant, Cause: decodingError}
+	}
+
+	return protection, true, nil
+}
+
+// ApplyBranchProtection replaces the protection rule of the branch with the provided one.
+func (client *Client) ApplyBranchProtection(executionContext context.Context, repository string, branchName string, protection BranchProtection) error {
+	return client.applyBranchProtection(executionContext, applyBranchProtectionOperationNameConstant, repository, branchName, protection)
+}
+
+func (client *Client) applyBranchProtection(executionContext context.Context, operation OperationName, repository string, branchName string, protection BranchProtection) error {
+	request, describeError := describeBranchProtection(operation, repository, branchName, protection)
+	if describeError != nil {
+		return describeError
+	}
+	return client.sendIntendedRequest(executionContext, request)
+}
+
+func enabledSetting(setting *branchProtectionEnabledSetting) bool {
+	return setting != nil && setting.Enabled
+}
+
+func actorNames(actors []branchProtectionActor) []string {
+	names := make([]string, 0, len(actors))
+	for _, actor := range actors {
+		if len(actor.Login) > 0 {
+			names = append(names, actor.Login)
+			continue
+		}
+		names = append(names, actor.Slug)
+	}
+	return names
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,182 @@
+package githubcli_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const testBranchProtectionSettingsResponseConstant = `{
+  "required_status_checks": {"strict": true, "contexts": [], "checks": [{"context": "ci/build"}, {"context": "ci/lint"}]},
+  "enforce_admins": {"enabled": true},
+  "required_pull_request_reviews": {"dismiss_stale_reviews": true, "require_code_owner_reviews": false, "required_approving_review_count": 2},
+  "restrictions": {"users": [{"login": "octocat"}], "teams": [{"slug": "maintainers"}], "apps": []},
+  "required_linear_history": {"enabled": true},
+  "allow_force_pushes": {"enabled": false},
+  "allow_deletions": {"enabled": false},
+  "lock_branch": {"enabled": false}
+}`
+
+func TestParseBranchProtection(testInstance *testing.T) {
+	testCases := []struct {
+		name               string
+		payload            string
+		expectedProtection githubcli.BranchProtection
+		expectError        bool
+	}{
+		{
+			name:    "full_rule",
+			payload: testBranchProtectionSettingsResponseConstant,
+			expectedProtection: githubcli.BranchProtection{
+				RequiredStatusChecks:       &githubcli.RequiredStatusChecks{Strict: true, Contexts: []string{"ci/build", "ci/lint"}},
+				EnforceAdmins:              true,
+				RequiredPullRequestReviews: &githubcli.RequiredPullRequestReviews{DismissStaleReviews: true, RequiredApprovingReviewCount: 2},
+				Restrictions:               &githubcli.BranchRestrictions{Users: []string{"octocat"}, Teams: []string{"maintainers"}, Apps: []string{}},
+				RequiredLinearHistory:      true,
+			},
+		},
+		{
+			name:               "deletions_allowed_without_sections",
+			payload:            `{"enforce_admins":{"enabled":false},"allow_deletions":{"enabled":true}}`,
+			expectedProtection: githubcli.BranchProtection{AllowDeletions: true},
+		},
+		{
+			name:        "invalid_json",
+			payload:     "{",
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			protection, parseError := githubcli.ParseBranchProtection([]byte(testCase.payload))
+			if testCase.expectError {
+				require.Error(testInstance, parseError)
+				return
+			}
+			require.NoError(testInstance, parseError)
+			require.Equal(testInstance, testCase.expectedProtection, protection)
+		})
+	}
+}
+
+func TestBranchProtectionUpdatePayload(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		protection      githubcli.BranchProtection
+		expectedPayload string
+	}{
+		{
+			name:            "empty_sections_are_null",
+			protection:      githubcli.BranchProtection{EnforceAdmins: true, LockBranch: true},
+			expectedPayload: `{"required_status_checks":null,"enforce_admins":true,"required_pull_request_reviews":null,"restrictions":null,"lock_branch":true,"allow_force_pushes":false,"allow_deletions":false}`,
+		},
+		{
+			name: "configured_sections",
+			protection: githubcli.BranchProtection{
+				RequiredStatusChecks:       &githubcli.RequiredStatusChecks{Strict: true, Contexts: []string{"ci/build"}},
+				RequiredPullRequestReviews: &githubcli.RequiredPullRequestReviews{RequiredApprovingReviewCount: 1},
+				Restrictions:               &githubcli.BranchRestrictions{Users: []string{"octocat"}},
+				RequiredLinearHistory:      true,
+			},
+			expectedPayload: `{"required_status_checks":{"strict":true,"contexts":["ci/build"]},"enforce_admins":false,"required_pull_request_reviews":{"dismiss_stale_reviews":false,"require_code_owner_reviews":false,"required_approving_review_count":1},"restrictions":{"users":["octocat"],"teams":[],"apps":[]},"required_linear_history":true,"lock_branch":false,"allow_force_pushes":false,"allow_deletions":false}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			payload, payloadError := testCase.protection.UpdatePayload()
+			require.NoError(testInstance, payloadError)
+			require.JSONEq(testInstance, testCase.expectedPayload, string(payload))
+		})
+	}
+}
+
+func TestGetBranchProtection(testInstance *testing.T) {
+	testCases := []struct {
+		name              string
+		branchName        string
+		executeFunc       func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error)
+		expectedProtected bool
+		expectedChecks    []string
+		expectedErrorType any
+	}{
+		{
+			name:       "protected",
+			branchName: "main",
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: testBranchProtectionSettingsResponseConstant}, nil
+			},
+			expectedProtected: true,
+			expectedChecks:    []string{"ci/build", "ci/lint"},
+		},
+		{
+			name:       "not_protected",
+			branchName: "main",
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, execshell.CommandFailedError{Command: execshell.ShellCommand{Name: execshell.CommandGitHub}, Result: execshell.ExecutionResult{ExitCode: 1, StandardError: "gh: Branch not protected (HTTP 404)"}}
+			},
+		},
+		{
+			name:       "command_failure",
+			branchName: "main",
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, errors.New("network down")
+			},
+			expectedErrorType: githubcli.OperationError{},
+		},
+		{
+			name:       "malformed_response",
+			branchName: "main",
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: "{"}, nil
+			},
+			expectedErrorType: githubcli.ResponseDecodingError{},
+		},
+		{
+			name:              "missing_branch",
+			branchName:        " ",
+			expectedErrorType: githubcli.InvalidInputError{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			executor := &stubGitHubExecutor{executeFunc: testCase.executeFunc}
+			client, creationError := githubcli.NewClient(executor)
+			require.NoError(testInstance, creationError)
+
+			protection, protected, protectionError := client.GetBranchProtection(context.Background(), "owner/example", testCase.branchName)
+			if testCase.expectedErrorType != nil {
+				require.Error(testInstance, protectionError)
+				require.IsType(testInstance, testCase.expectedErrorType, protectionError)
+				return
+			}
+			require.NoError(testInstance, protectionError)
+			require.Equal(testInstance, testCase.expectedProtected, protected)
+			if testCase.expectedProtected {
+				require.Equal(testInstance, testCase.expectedChecks, protection.RequiredStatusChecks.Contexts)
+				require.Equal(testInstance, []string{"api", "repos/owner/example/branches/main/protection", "-X", "GET", "-H", "Accept: application/vnd.github+json"}, executor.recordedDetails[0].Arguments)
+			}
+		})
+	}
+}
+
+func TestApplyBranchProtection(testInstance *testing.T) {
+	executor := &stubGitHubExecutor{}
+	client, creationError := githubcli.NewClient(executor)
+	require.NoError(testInstance, creationError)
+
+	protection, parseError := githubcli.ParseBranchProtection([]byte(testBranchProtectionSettingsResponseConstant))
+	require.NoError(testInstance, parseError)
+
+	require.NoError(testInstance, client.ApplyBranchProtection(context.Background(), "owner/example", "master", protection))
+	require.Len(testInstance, executor.recordedDetails, 1)
+	require.Equal(testInstance, []string{"api", "repos/owner/example/branches/master/protection", "-X", "PUT", "--input", "-", "-H", "Accept: application/vnd.github+json"}, executor.recordedDetails[0].Arguments)
+	require.JSONEq(testInstance, `{"required_status_checks":{"strict":true,"contexts":["ci/build","ci/lint"]},"enforce_admins":true,"required_pull_request_reviews":{"dismiss_stale_reviews":true,"require_code_owner_reviews":false,"required_approving_review_count":2},"restrictions":{"users":["octocat"],"teams":["maintainers"],"apps":[]},"required_linear_history":true,"lock_branch":false,"allow_force_pushes":false,"allow_deletions":false}`, string(executor.recordedDetails[0].StandardInput))
+}
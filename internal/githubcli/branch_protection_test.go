@@ -0,0 +1,170 @@
+package githubcli_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const testBranchProtectionResponseConstant = `{"data":{"repository":{"branchProtectionRules":{"nodes":[{"pattern":"main"},{"pattern":"release/*","allowsDeletions":true,"allowsForcePushes":false},{"pattern":" "}]}}}}`
+
+func TestBranchProtectionRulesProtects(testInstance *testing.T) {
+	testCases := []struct {
+		name       string
+		patterns   []string
+		branchName string
+		expected   bool
+	}{
+		{name: "exact_match", patterns: []string{"main"}, branchName: "main", expected: true},
+		{name: "exact_mismatch", patterns: []string{"main"}, branchName: "maintenance", expected: false},
+		{name: "single_wildcard_within_segment", patterns: []string{"release/*"}, branchName: "release/1.0", expected: true},
+		{name: "single_wildcard_stops_at_separator", patterns: []string{"release/*"}, branchName: "release/1.0/hotfix", expected: false},
+		{name: "double_wildcard_spans_segments", patterns: []string{"release/**"}, branchName: "release/1.0/hotfix", expected: true},
+		{name: "question_mark", patterns: []string{"v?"}, branchName: "v1", expected: true},
+		{name: "character_class", patterns: []string{"stable-[0-9]"}, branchName: "stable-7", expected: true},
+		{name: "negated_character_class", patterns: []string{"stable-[!0-9]"}, branchName: "stable-7", expected: false},
+		{name: "literal_dot", patterns: []string{"v1.0"}, branchName: "v1x0", expected: false},
+		{name: "no_patterns", branchName: "main", expected: false},
+		{name: "blank_branch", patterns: []string{"**"}, branchName: " ", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			rules := githubcli.BranchProtectionRules{Patterns: testCase.patterns}
+			require.Equal(subtest, testCase.expected, rules.Protects(testCase.branchName))
+		})
+	}
+}
+
+func TestBranchProtectionRulesMatchingRules(testInstance *testing.T) {
+	rules := githubcli.BranchProtectionRules{Rules: []githubcli.BranchProtectionRule{
+		{Pattern: "ma*", AllowsForcePushes: true},
+		{Pattern: "master"},
+		{Pattern: "release/*", AllowsDeletions: true},
+	}}
+
+	testCases := []struct {
+		name             string
+		branchName       string
+		expectedPatterns []string
+	}{
+		{name: "wildcard_matches_main", branchName: "main", expectedPatterns: []string{"ma*"}},
+		{name: "wildcard_and_literal_match_master", branchName: "master", expectedPatterns: []string{"ma*", "master"}},
+		{name: "nothing_matches", branchName: "develop"},
+		{name: "blank_branch", branchName: " "},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			var patterns []string
+			for _, rule := range rules.MatchingRules(testCase.branchName) {
+				patterns = append(patterns, rule.Pattern)
+			}
+			require.Equal(subtest, testCase.expectedPatterns, patterns)
+		})
+	}
+}
+
+func TestListBranchProtectionRules(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		repository    string
+		executeFunc   func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error)
+		expectedRules githubcli.BranchProtectionRules
+		expectedError bool
+	}{
+		{
+			name:       "patterns_returned",
+			repository: "owner/example",
+			executeFunc: func(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: testBranchProtectionResponseConstant}, nil
+			},
+			expectedRules: githubcli.BranchProtectionRules{
+				Patterns: []string{"main", "release/*"},
+				Rules: []githubcli.BranchProtectionRule{
+					{Pattern: "main"},
+					{Pattern: "release/*", AllowsDeletions: true},
+				},
+			},
+		},
+		{
+			name:          "invalid_repository",
+			repository:    "example",
+			expectedError: true,
+		},
+		{
+			name:       "missing_repository",
+			repository: "owner/missing",
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: `{"data":{"repository":null}}`}, nil
+			},
+			expectedError: true,
+		},
+		{
+			name:       "command_failure",
+			repository: "owner/example",
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, errors.New("graphql failure")
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubGitHubExecutor{executeFunc: testCase.executeFunc}
+			client, clientError := githubcli.NewClient(executor)
+			require.NoError(subtest, clientError)
+
+			rules, rulesError := client.ListBranchProtectionRules(context.Background(), testCase.repository)
+			if testCase.expectedError {
+				require.Error(subtest, rulesError)
+				return
+			}
+			require.NoError(subtest, rulesError)
+			require.Equal(subtest, testCase.expectedRules, rules)
+			require.Len(subtest, executor.recordedDetails, 1)
+			require.Equal(subtest, []string{"api", "graphql", "-f"}, executor.recordedDetails[0].Arguments[:3])
+			require.Contains(subtest, executor.recordedDetails[0].Arguments[3], `repository(owner: "owner", name: "example")`)
+		})
+	}
+}
+
+func TestCachedBranchProtectionProvider(testInstance *testing.T) {
+	executor := &stubGitHubExecutor{executeFunc: func(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+		switch details.Arguments[1] {
+		case "repos/owner/example/branches/main/protection":
+			return execshell.ExecutionResult{}, nil
+		case "repos/owner/example/branches/feature/protection":
+			return execshell.ExecutionResult{}, execshell.CommandFailedError{Command: execshell.ShellCommand{Name: execshell.CommandGitHub}, Result: execshell.ExecutionResult{ExitCode: 1, StandardError: "HTTP 404: Not Found"}}
+		default:
+			return execshell.ExecutionResult{}, execshell.CommandFailedError{Command: execshell.ShellCommand{Name: execshell.CommandGitHub}, Result: execshell.ExecutionResult{ExitCode: 1, StandardError: "HTTP 403: Forbidden"}}
+		}
+	}}
+	client, clientError := githubcli.NewClient(executor)
+	require.NoError(testInstance, clientError)
+
+	provider := githubcli.NewCachedBranchProtectionProvider(client)
+	for _, repository := range []string{"owner/example", "OWNER/Example"} {
+		protected, protectionError := provider.BranchProtected(context.Background(), repository, "main")
+		require.NoError(testInstance, protectionError)
+		require.True(testInstance, protected)
+
+		protected, protectionError = provider.BranchProtected(context.Background(), repository, "feature")
+		require.NoError(testInstance, protectionError)
+		require.False(testInstance, protected)
+	}
+	require.Len(testInstance, executor.recordedDetails, 2)
+
+	for _, branchName := range []string{"main", "feature", "release"} {
+		_, protectionError := provider.BranchProtected(context.Background(), "owner/locked", branchName)
+		var operationError githubcli.OperationError
+		require.ErrorAs(testInstance, protectionError, &operationError)
+	}
+	require.Len(testInstance, executor.recordedDetails, 3)
+}
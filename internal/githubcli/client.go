@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/temirov/gix/internal/execshell"
 	"github.com/temirov/gix/internal/githubauth"
@@ -45,7 +46,7 @@ const (
 	executorNotConfiguredMessageConstant       = "github cli executor not configured"
 	pullRequestLimitDefaultValueConstant       = 100
 	pullRequestJSONFieldsConstant              = "number,title,headRefName"
-	repoViewJSONFieldsConstant                 = "defaultBranchRef,nameWithOwner,description,isInOrganization"
+	repoViewJSONFieldsConstant                 = "defaultBranchRef,nameWithOwner,description,isInOrganization,deleteBranchOnMerge"
 	operationErrorMessageTemplateConstant      = "%s operation failed"
 	operationErrorWithCauseTemplateConstant    = "%s operation failed: %s"
 	responseDecodingErrorTemplateConstant      = "%s response decoding failed: %s"
@@ -66,8 +67,11 @@ const (
 	updatePullRequestOperationNameConstant     = OperationName("UpdatePullRequestBase")
 	checkBranchProtectionOperationNameConstant = OperationName("CheckBranchProtection")
 	createPullRequestOperationNameConstant     = OperationName("CreatePullRequest")
-	httpNotFoundIndicatorConstant              = "http 404"
-	statusNotFoundIndicatorConstant            = "status 404"
+	lockBranchOperationNameConstant            = OperationName("LockBranch")
+	existingPullRequestJSONFieldsConstant      = "number,url"
+	pullRequestAlreadyExistsIndicatorConstant  = "already exists"
+	pullRequestURLPathSegmentConstant          = "/pull/"
+	pullRequestURLMissingTemplateConstant      = "no pull request URL in output %q"
 )
 
 // OperationName describes a named GitHub CLI workflow supported by the client.
@@ -84,11 +88,17 @@ const (
 )
 
 // RepositoryMetadata contains key details resolved from GitHub.
+// DeleteBranchOnMerge reports whether GitHub deletes head branches once their pull requests merge.
+// MergeQueueEnabled reports whether the default branch uses a merge queue; MergeQueueKnown is false when the lookup
+// cannot tell, because gh repo view does not expose merge queues and only batched GraphQL lookups resolve them.
 type RepositoryMetadata struct {
-	NameWithOwner    string
-	Description      string
-	DefaultBranch    string
-	IsInOrganization bool
+	NameWithOwner       string
+	Description         string
+	DefaultBranch       string
+	IsInOrganization    bool
+	DeleteBranchOnMerge bool
+	MergeQueueEnabled   bool
+	MergeQueueKnown     bool
 }
 
 // PullRequest represents minimal PR details returned by GitHub CLI.
@@ -115,6 +125,14 @@ type PullRequestCreateOptions struct {
 	Draft      bool
 }
 
+// CreatedPullRequest identifies the pull request returned by CreatePullRequest.
+// Existing reports that an open pull request for the same head and base was found instead of a new one being created.
+type CreatedPullRequest struct {
+	Number   int
+	URL      string
+	Existing bool
+}
+
 // PagesConfiguration describes the desired GitHub Pages configuration.
 type PagesConfiguration struct {
 	SourceBranch string
@@ -145,7 +163,10 @@ type GitHubCommandExecutor interface {
 
 // Client coordinates GitHub CLI invocations through execshell.
 type Client struct {
-	executor GitHubCommandExecutor
+	executor    GitHubCommandExecutor
+	responses   *responseCache
+	accessMutex sync.Mutex
+	tokenAccess TokenAccess
 }
 
 var (
@@ -220,7 +241,7 @@ func NewClient(executor GitHubCommandExecutor) (*Client, error) {
 	if executor == nil {
 		return nil, ErrExecutorNotConfigured
 	}
-	return &Client{executor: executor}, nil
+	return &Client{executor: executor, responses: newResponseCache()}, nil
 }
 
 // ResolveRepoMetadata retrieves canonical metadata for a repository using gh repo view.
@@ -239,9 +260,10 @@ func (client *Client) ResolveRepoMetadata(executionContext context.Context, repo
 			repoViewJSONFieldsConstant,
 		},
 		GitHubTokenRequirement: githubauth.TokenRequired,
+		Idempotent:             true,
 	}
 
-	executionResult, executionError := client.executor.ExecuteGitHubCLI(executionContext, commandDetails)
+	executionResult, executionError := client.executeCachedRead(executionContext, commandDetails)
 	if executionError != nil {
 		return RepositoryMetadata{}, OperationError{Operation: repositoryMetadataOperationNameConstant, Cause: executionError}
 	}
@@ -252,7 +274,8 @@ func (client *Client) ResolveRepoMetadata(executionContext context.Context, repo
 		DefaultBranchRef struct {
 			Name string `json:"name"`
 		} `json:"defaultBranchRef"`
-		IsInOrganization bool `json:"isInOrganization"`
+		IsInOrganization    bool `json:"isInOrganization"`
+		DeleteBranchOnMerge bool `json:"deleteBranchOnMerge"`
 	}
 
 	decodingError := json.Unmarshal([]byte(executionResult.StandardOutput), &response)
@@ -261,10 +284,11 @@ func (client *Client) ResolveRepoMetadata(executionContext context.Context, repo
 	}
 
 	return RepositoryMetadata{
-		NameWithOwner:    response.NameWithOwner,
-		Description:      response.Description,
-		DefaultBranch:    response.DefaultBranchRef.Name,
-		IsInOrganization: response.IsInOrganization,
+		NameWithOwner:       response.NameWithOwner,
+		Description:         response.Description,
+		DefaultBranch:       response.DefaultBranchRef.Name,
+		IsInOrganization:    response.IsInOrganization,
+		DeleteBranchOnMerge: response.DeleteBranchOnMerge,
 	}, nil
 }
 
@@ -304,9 +328,10 @@ func (client *Client) ListPullRequests(executionContext context.Context, reposit
 			strconv.Itoa(resultLimit),
 		},
 		GitHubTokenRequirement: githubauth.TokenOptional,
+		Idempotent:             true,
 	}
 
-	executionResult, executionError := client.executor.ExecuteGitHubCLI(executionContext, commandDetails)
+	executionResult, executionError := client.runGitHubCLI(executionContext, commandDetails)
 	if executionError != nil {
 		return nil, OperationError{Operation: listPullRequestsOperationNameConstant, Cause: executionError}
 	}
@@ -334,30 +359,31 @@ func (client *Client) ListPullRequests(executionContext context.Context, reposit
 	return pullRequests, nil
 }
 
-// CreatePullRequest opens a pull request using gh pr create.
-func (client *Client) CreatePullRequest(executionContext context.Context, options PullRequestCreateOptions) error {
+// CreatePullRequest opens a pull request using gh pr create and returns its number and URL.
+// When an open pull request for the same head and base already exists, that pull request is returned with Existing set instead of failing.
+func (client *Client) CreatePullRequest(executionContext context.Context, options PullRequestCreateOptions) (CreatedPullRequest, error) {
 	if client.executor == nil {
-		return ErrExecutorNotConfigured
+		return CreatedPullRequest{}, ErrExecutorNotConfigured
 	}
 
 	repositoryIdentifier := strings.TrimSpace(options.Repository)
 	if len(repositoryIdentifier) == 0 {
-		return InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
+		return CreatedPullRequest{}, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
 	}
 
 	title := strings.TrimSpace(options.Title)
 	if len(title) == 0 {
-		return InvalidInputError{FieldName: titleFlagConstant, Message: requiredValueMessageConstant}
+		return CreatedPullRequest{}, InvalidInputError{FieldName: titleFlagConstant, Message: requiredValueMessageConstant}
 	}
 
 	head := strings.TrimSpace(options.Head)
 	if len(head) == 0 {
-		return InvalidInputError{FieldName: sourceBranchFieldNameConstant, Message: requiredValueMessageConstant}
+		return CreatedPullRequest{}, InvalidInputError{FieldName: sourceBranchFieldNameConstant, Message: requiredValueMessageConstant}
 	}
 
 	base := strings.TrimSpace(options.Base)
 	if len(base) == 0 {
-		return InvalidInputError{FieldName: baseBranchFieldNameConstant, Message: requiredValueMessageConstant}
+		return CreatedPullRequest{}, InvalidInputError{FieldName: baseBranchFieldNameConstant, Message: requiredValueMessageConstant}
 	}
 
 	arguments := []string{
@@ -382,114 +408,109 @@ func (client *Client) CreatePullRequest(executionContext context.Context, option
 	commandDetails := execshell.CommandDetails{
 		Arguments:              arguments,
 		GitHubTokenRequirement: githubauth.TokenRequired,
+		Idempotent:             false,
 	}
-	_, executionError := client.executor.ExecuteGitHubCLI(executionContext, commandDetails)
+	executionResult, executionError := client.executeMutation(executionContext, commandDetails)
 	if executionError != nil {
-		return OperationError{Operation: createPullRequestOperationNameConstant, Cause: executionError}
-	}
-
-	return nil
-}
-
-// UpdatePagesConfig updates the GitHub Pages configuration using gh api.
-func (client *Client) UpdatePagesConfig(executionContext context.Context, repository string, configuration PagesConfiguration) error {
-	repositoryIdentifier := strings.TrimSpace(repository)
-	if len(repositoryIdentifier) == 0 {
-		return InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
-	}
-
-	if len(strings.TrimSpace(configuration.SourceBranch)) == 0 {
-		return InvalidInputError{FieldName: sourceBranchFieldNameConstant, Message: requiredValueMessageConstant}
+		if pullRequestAlreadyExists(executionError) {
+			existingPullRequest, found, lookupError := client.findOpenPullRequest(executionContext, repositoryIdentifier, head, base)
+			if lookupError != nil {
+				return CreatedPullRequest{}, lookupError
+			}
+			if found {
+				return existingPullRequest, nil
+			}
+		}
+		return CreatedPullRequest{}, OperationError{Operation: createPullRequestOperationNameConstant, Cause: executionError}
 	}
 
-	payload := struct {
-		Source struct {
-			Branch string `json:"branch"`
-			Path   string `json:"path"`
-		} `json:"source"`
-	}{}
-
-	payload.Source.Branch = configuration.SourceBranch
-	payload.Source.Path = configuration.SourcePath
-
-	payloadBytes, encodingError := json.Marshal(payload)
-	if encodingError != nil {
-		return PayloadEncodingError{Operation: updatePagesOperationNameConstant, Cause: encodingError}
+	createdPullRequest, parseError := parseCreatedPullRequestURL(executionResult.StandardOutput)
+	if parseError != nil {
+		return CreatedPullRequest{}, ResponseDecodingError{Operation: createPullRequestOperationNameConstant, Cause: parseError}
 	}
+	return createdPullRequest, nil
+}
 
+func (client *Client) findOpenPullRequest(executionContext context.Context, repository string, head string, base string) (CreatedPullRequest, bool, error) {
 	commandDetails := execshell.CommandDetails{
 		Arguments: []string{
-			apiSubcommandConstant,
-			fmt.Sprintf(pagesEndpointTemplateConstant, repositoryIdentifier),
-			methodFlagConstant,
-			httpMethodPutConstant,
-			inputFlagConstant,
-			stdinReferenceConstant,
-			acceptHeaderFlagConstant,
-			acceptHeaderValueConstant,
+			pullRequestSubcommandConstant,
+			listSubcommandConstant,
+			repoFlagConstant,
+			repository,
+			headFlagConstant,
+			head,
+			baseFlagConstant,
+			base,
+			stateFlagConstant,
+			string(PullRequestStateOpen),
+			jsonFlagConstant,
+			existingPullRequestJSONFieldsConstant,
+			limitFlagConstant,
+			strconv.Itoa(1),
 		},
-		StandardInput:          payloadBytes,
-		GitHubTokenRequirement: githubauth.TokenOptional,
+		GitHubTokenRequirement: githubauth.TokenRequired,
+		Idempotent:             true,
 	}
-
-	_, executionError := client.executor.ExecuteGitHubCLI(executionContext, commandDetails)
+	executionResult, executionError := client.runGitHubCLI(executionContext, commandDetails)
 	if executionError != nil {
-		return OperationError{Operation: updatePagesOperationNameConstant, Cause: executionError}
+		return CreatedPullRequest{}, false, OperationError{Operation: createPullRequestOperationNameConstant, Cause: executionError}
 	}
 
-	return nil
-}
-
-// GetPagesConfig retrieves the GitHub Pages configuration for a repository.
-func (client *Client) GetPagesConfig(executionContext context.Context, repository string) (PagesStatus, error) {
-	repositoryIdentifier := strings.TrimSpace(repository)
-	if len(repositoryIdentifier) == 0 {
-		return PagesStatus{}, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
+	var response []struct {
+		Number int    `json:"number"`
+		URL    string `json:"url"`
 	}
-
-	commandDetails := execshell.CommandDetails{
-		Arguments: []string{
-			apiSubcommandConstant,
-			fmt.Sprintf(pagesEndpointTemplateConstant, repositoryIdentifier),
-			methodFlagConstant,
-			httpMethodGetConstant,
-			acceptHeaderFlagConstant,
-			acceptHeaderValueConstant,
-		},
-		GitHubTokenRequirement: githubauth.TokenOptional,
+	if decodingError := json.Unmarshal([]byte(executionResult.StandardOutput), &response); decodingError != nil {
+		return CreatedPullRequest{}, false, ResponseDecodingError{Operation: createPullRequestOperationNameConstant, Cause: decodingError}
 	}
-
-	executionResult, executionError := client.executor.ExecuteGitHubCLI(executionContext, commandDetails)
-	if executionError != nil {
-		return PagesStatus{}, OperationError{Operation: getPagesOperationNameConstant, Cause: executionError}
+	if len(response) == 0 {
+		return CreatedPullRequest{}, false, nil
 	}
+	return CreatedPullRequest{Number: response[0].Number, URL: response[0].URL, Existing: true}, true, nil
+}
 
-	trimmedOutput := strings.TrimSpace(executionResult.StandardOutput)
-	if len(trimmedOutput) == 0 || trimmedOutput == pagesNullResponseConstant {
-		return PagesStatus{Enabled: false}, nil
+func pullRequestAlreadyExists(executionError error) bool {
+	var commandFailure execshell.CommandFailedError
+	if !errors.As(executionError, &commandFailure) {
+		return false
 	}
+	return strings.Contains(strings.ToLower(commandFailure.Result.StandardError), pullRequestAlreadyExistsIndicatorConstant)
+}
 
-	var response struct {
-		BuildType string `json:"build_type"`
-		Source    struct {
-			Branch string `json:"branch"`
-			Path   string `json:"path"`
-		} `json:"source"`
+func parseCreatedPullRequestURL(output string) (CreatedPullRequest, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for lineIndex := len(lines) - 1; lineIndex >= 0; lineIndex-- {
+		candidate := strings.TrimSpace(lines[lineIndex])
+		separatorIndex := strings.LastIndex(candidate, pullRequestURLPathSegmentConstant)
+		if separatorIndex == -1 {
+			continue
+		}
+		number, parseError := strconv.Atoi(candidate[separatorIndex+len(pullRequestURLPathSegmentConstant):])
+		if parseError != nil {
+			continue
+		}
+		return CreatedPullRequest{Number: number, URL: candidate}, nil
 	}
+	return CreatedPullRequest{}, fmt.Errorf(pullRequestURLMissingTemplateConstant, strings.TrimSpace(output))
+}
 
-	decodingError := json.Unmarshal([]byte(trimmedOutput), &response)
-	if decodingError != nil {
-		return PagesStatus{}, ResponseDecodingError{Operation: getPagesOperationNameConstant, Cause: decodingError}
+// UpdatePagesConfig updates the GitHub Pages configuration using gh api.
+func (client *Client) UpdatePagesConfig(executionContext context.Context, repository string, configuration PagesConfiguration) error {
+	request, describeError := client.DescribeUpdatePagesConfig(repository, configuration)
+	if describeError != nil {
+		return describeError
 	}
+	return client.sendIntendedRequest(executionContext, request)
+}
 
-	pagesStatus := PagesStatus{
-		Enabled:      true,
-		BuildType:    PagesBuildType(response.BuildType),
-		SourceBranch: response.Source.Branch,
-		SourcePath:   response.Source.Path,
+// GetPagesConfig retrieves the GitHub Pages configuration for a repository.
+func (client *Client) GetPagesConfig(executionContext context.Context, repository string) (PagesStatus, error) {
+	responseBody, responseError := client.GetPagesConfigResponse(executionContext, repository)
+	if responseError != nil {
+		return PagesStatus{}, responseError
 	}
-
-	return pagesStatus, nil
+	return ParsePagesStatus(responseBody)
 }
 
 // SetDefaultBranch updates the default branch for the repository.
@@ -516,9 +537,10 @@ func (client *Client) SetDefaultBranch(executionContext context.Context, reposit
 			acceptHeaderValueConstant,
 		},
 		GitHubTokenRequirement: githubauth.TokenRequired,
+		Idempotent:             false,
 	}
 
-	_, executionError := client.executor.ExecuteGitHubCLI(executionContext, commandDetails)
+	_, executionError := client.executeMutation(executionContext, commandDetails)
 	if executionError != nil {
 		return OperationError{Operation: updateDefaultBranchOperationNameConstant, Cause: executionError}
 	}
@@ -553,9 +575,10 @@ func (client *Client) UpdatePullRequestBase(executionContext context.Context, re
 			trimmedBase,
 		},
 		GitHubTokenRequirement: githubauth.TokenOptional,
+		Idempotent:             false,
 	}
 
-	_, executionError := client.executor.ExecuteGitHubCLI(executionContext, commandDetails)
+	_, executionError := client.executeMutation(executionContext, commandDetails)
 	if executionError != nil {
 		return OperationError{Operation: updatePullRequestOperationNameConstant, Cause: executionError}
 	}
@@ -585,29 +608,30 @@ func (client *Client) CheckBranchProtection(executionContext context.Context, re
 			acceptHeaderValueConstant,
 		},
 		GitHubTokenRequirement: githubauth.TokenOptional,
+		Idempotent:             true,
 	}
 
-	_, executionError := client.executor.ExecuteGitHubCLI(executionContext, commandDetails)
+	_, executionError := client.executeCachedRead(executionContext, commandDetails)
 	if executionError == nil {
 		return true, nil
 	}
 
-	var commandFailure execshell.CommandFailedError
-	if errors.As(executionError, &commandFailure) {
-		if branchProtectionNotFound(commandFailure.Result) {
-			return false, nil
-		}
+	if notFoundError(executionError) {
+		return false, nil
 	}
 
 	return false, OperationError{Operation: checkBranchProtectionOperationNameConstant, Cause: executionError}
 }
 
-func branchProtectionNotFound(result execshell.ExecutionResult) bool {
-	if len(result.StandardError) == 0 && len(result.StandardOutput) == 0 {
-		return false
-	}
-
-	combinedOutput := strings.ToLower(result.StandardError + " " + result.StandardOutput)
+// LockBranch applies a branch protection rule that makes the branch read-only.
+func (client *Client) LockBranch(executionContext context.Context, repository string, branchName string) error {
+	return client.applyBranchProtection(executionContext, lockBranchOperationNameConstant, repository, branchName, lockedBranchProtection())
+}
 
-	return strings.Contains(combinedOutput, httpNotFoundIndicatorConstant) || strings.Contains(combinedOutput, statusNotFoundIndicatorConstant)
+// lockedBranchProtection is the rule LockBranch applies: read-only for everyone, administrators included.
+func lockedBranchProtection() BranchProtection {
+	return BranchProtection{
+		EnforceAdmins: true,
+		LockBranch:    true,
+	}
 }
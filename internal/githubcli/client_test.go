@@ -54,6 +54,10 @@ const (
 	testBranchProtectionUnexpectedStatusCaseNameConstant = "branch_protection_unexpected_status"
 	testBranchProtectionCommandFailureCaseNameConstant   = "branch_protection_command_failure"
 	testBranchProtectionValidationCaseNameConstant       = "branch_protection_validation"
+	testLockBranchSuccessCaseNameConstant                = "lock_branch_success"
+	testLockBranchCommandFailureCaseNameConstant         = "lock_branch_command_failure"
+	testLockBranchValidationCaseNameConstant             = "lock_branch_validation"
+	testArchiveBranchConstant                            = "archive/main-2024-05-01"
 	testHTTPNotFoundStandardErrorMessageConstant         = "gh: Not Found (HTTP 404)"
 	testHTTPForbiddenStandardErrorMessageConstant        = "gh: Forbidden (HTTP 403)"
 )
@@ -94,7 +98,7 @@ func TestResolveRepoMetadata(testInstance *testing.T) {
 			repository: testRepositoryIdentifierConstant,
 			executor: &stubGitHubExecutor{
 				executeFunc: func(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
-					return execshell.ExecutionResult{StandardOutput: `{"nameWithOwner":"owner/example","description":"Example repo","defaultBranchRef":{"name":"main"},"isInOrganization":true}`}, nil
+					return execshell.ExecutionResult{StandardOutput: `{"nameWithOwner":"owner/example","description":"Example repo","defaultBranchRef":{"name":"main"},"isInOrganization":true,"deleteBranchOnMerge":true}`}, nil
 				},
 			},
 			verify: func(testInstance *testing.T, metadata githubcli.RepositoryMetadata, executor *stubGitHubExecutor) {
@@ -102,6 +106,8 @@ func TestResolveRepoMetadata(testInstance *testing.T) {
 				require.Equal(testInstance, "Example repo", metadata.Description)
 				require.Equal(testInstance, "main", metadata.DefaultBranch)
 				require.True(testInstance, metadata.IsInOrganization)
+				require.True(testInstance, metadata.DeleteBranchOnMerge)
+				require.False(testInstance, metadata.MergeQueueKnown)
 				require.Len(testInstance, executor.recordedDetails, 1)
 				require.Contains(testInstance, executor.recordedDetails[0].Arguments, testRepositoryIdentifierConstant)
 			},
@@ -124,6 +130,15 @@ func TestResolveRepoMetadata(testInstance *testing.T) {
 			expectError: true,
 			errorType:   githubcli.OperationError{},
 		},
+		{
+			name:       "truncated_output",
+			repository: testRepositoryIdentifierConstant,
+			executor: &stubGitHubExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: `{"nameWithOwner":"owner/example"` + "\n[truncated 64 bytes]", Truncated: true}, nil
+			}},
+			expectError: true,
+			errorType:   githubcli.OperationError{},
+		},
 		{
 			name:        testResolveInputFailureCaseNameConstant,
 			repository:  "  ",
@@ -524,16 +539,20 @@ func TestUpdatePullRequestBase(testInstance *testing.T) {
 
 func TestCreatePullRequest(testInstance *testing.T) {
 	testCases := []struct {
-		name        string
-		options     githubcli.PullRequestCreateOptions
-		executor    *stubGitHubExecutor
-		expectError bool
-		errorType   any
-		verify      func(testInstance *testing.T, executor *stubGitHubExecutor)
+		name           string
+		options        githubcli.PullRequestCreateOptions
+		executor       *stubGitHubExecutor
+		expectError    bool
+		errorType      any
+		expectedResult githubcli.CreatedPullRequest
+		verify         func(testInstance *testing.T, executor *stubGitHubExecutor)
 	}{
 		{
-			name:     testCreatePullRequestSuccessCaseNameConstant,
-			executor: &stubGitHubExecutor{},
+			name: testCreatePullRequestSuccessCaseNameConstant,
+			executor: &stubGitHubExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: "Creating pull request for feature into main\n\nhttps://github.com/owner/example/pull/42\n"}, nil
+			}},
+			expectedResult: githubcli.CreatedPullRequest{Number: 42, URL: "https://github.com/owner/example/pull/42"},
 			options: githubcli.PullRequestCreateOptions{
 				Repository: testRepositoryIdentifierConstant,
 				Title:      testPullRequestTitleConstant,
@@ -577,6 +596,44 @@ func TestCreatePullRequest(testInstance *testing.T) {
 			expectError: true,
 			errorType:   githubcli.OperationError{},
 		},
+		{
+			name: "create_pull_request_returns_existing",
+			executor: &stubGitHubExecutor{executeFunc: func(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				if details.Arguments[1] == "list" {
+					return execshell.ExecutionResult{StandardOutput: `[{"number":7,"url":"https://github.com/owner/example/pull/7"}]`}, nil
+				}
+				return execshell.ExecutionResult{}, execshell.CommandFailedError{
+					Command: execshell.ShellCommand{Name: execshell.CommandGitHub},
+					Result:  execshell.ExecutionResult{ExitCode: 1, StandardError: "a pull request for branch \"feature\" into branch \"main\" already exists:\nhttps://github.com/owner/example/pull/7"},
+				}
+			}},
+			options: githubcli.PullRequestCreateOptions{
+				Repository: testRepositoryIdentifierConstant,
+				Title:      testPullRequestTitleConstant,
+				Base:       testBaseBranchConstant,
+				Head:       testPullRequestHeadConstant,
+			},
+			expectedResult: githubcli.CreatedPullRequest{Number: 7, URL: "https://github.com/owner/example/pull/7", Existing: true},
+			verify: func(testInstance *testing.T, executor *stubGitHubExecutor) {
+				require.Len(testInstance, executor.recordedDetails, 2)
+				require.Equal(testInstance, []string{
+					"pr", "list", "--repo", testRepositoryIdentifierConstant, "--head", testPullRequestHeadConstant,
+					"--base", testBaseBranchConstant, "--state", "open", "--json", "number,url", "--limit", "1",
+				}, executor.recordedDetails[1].Arguments)
+			},
+		},
+		{
+			name:     "create_pull_request_unparseable_output",
+			executor: &stubGitHubExecutor{},
+			options: githubcli.PullRequestCreateOptions{
+				Repository: testRepositoryIdentifierConstant,
+				Title:      testPullRequestTitleConstant,
+				Base:       testBaseBranchConstant,
+				Head:       testPullRequestHeadConstant,
+			},
+			expectError: true,
+			errorType:   githubcli.ResponseDecodingError{},
+		},
 		{
 			name:        testCreatePullRequestValidationCaseNameConstant,
 			executor:    &stubGitHubExecutor{},
@@ -591,12 +648,13 @@ func TestCreatePullRequest(testInstance *testing.T) {
 			client, creationError := githubcli.NewClient(testCase.executor)
 			require.NoError(testInstance, creationError)
 
-			executionError := client.CreatePullRequest(context.Background(), testCase.options)
+			createdPullRequest, executionError := client.CreatePullRequest(context.Background(), testCase.options)
 			if testCase.expectError {
 				require.Error(testInstance, executionError)
 				require.IsType(testInstance, testCase.errorType, executionError)
 			} else {
 				require.NoError(testInstance, executionError)
+				require.Equal(testInstance, testCase.expectedResult, createdPullRequest)
 				if testCase.verify != nil {
 					testCase.verify(testInstance, testCase.executor)
 				}
@@ -679,3 +737,73 @@ func TestCheckBranchProtection(testInstance *testing.T) {
 		})
 	}
 }
+
+func TestLockBranch(testInstance *testing.T) {
+	testCases := []struct {
+		name        string
+		repository  string
+		branchName  string
+		executor    *stubGitHubExecutor
+		expectError bool
+		errorType   any
+		verify      func(testing.TB, *stubGitHubExecutor)
+	}{
+		{
+			name:       testLockBranchSuccessCaseNameConstant,
+			repository: testRepositoryIdentifierConstant,
+			branchName: testArchiveBranchConstant,
+			executor:   &stubGitHubExecutor{},
+			verify: func(testInstance testing.TB, executor *stubGitHubExecutor) {
+				require.Len(testInstance, executor.recordedDetails, 1)
+				details := executor.recordedDetails[0]
+				require.Equal(testInstance, []string{
+					"api",
+					fmt.Sprintf("repos/%s/branches/%s/protection", testRepositoryIdentifierConstant, testArchiveBranchConstant),
+					"-X",
+					"PUT",
+					"--input",
+					"-",
+					"-H",
+					"Accept: application/vnd.github+json",
+				}, details.Arguments)
+				require.JSONEq(testInstance, `{"required_status_checks":null,"enforce_admins":true,"required_pull_request_reviews":null,"restrictions":null,"lock_branch":true,"allow_force_pushes":false,"allow_deletions":false}`, string(details.StandardInput))
+			},
+		},
+		{
+			name:       testLockBranchCommandFailureCaseNameConstant,
+			repository: testRepositoryIdentifierConstant,
+			branchName: testArchiveBranchConstant,
+			executor: &stubGitHubExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, execshell.CommandFailedError{Command: execshell.ShellCommand{Name: execshell.CommandGitHub}, Result: execshell.ExecutionResult{ExitCode: 1, StandardError: testHTTPForbiddenStandardErrorMessageConstant}}
+			}},
+			expectError: true,
+			errorType:   githubcli.OperationError{},
+		},
+		{
+			name:        testLockBranchValidationCaseNameConstant,
+			repository:  testRepositoryIdentifierConstant,
+			branchName:  " ",
+			executor:    &stubGitHubExecutor{},
+			expectError: true,
+			errorType:   githubcli.InvalidInputError{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			client, creationError := githubcli.NewClient(testCase.executor)
+			require.NoError(testInstance, creationError)
+
+			lockError := client.LockBranch(context.Background(), testCase.repository, testCase.branchName)
+			if testCase.expectError {
+				require.Error(testInstance, lockError)
+				require.IsType(testInstance, testCase.errorType, lockError)
+				return
+			}
+			require.NoError(testInstance, lockError)
+			if testCase.verify != nil {
+				testCase.verify(testInstance, testCase.executor)
+			}
+		})
+	}
+}
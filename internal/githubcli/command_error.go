@@ -0,0 +1,200 @@
+package githubcli
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	// GitHubErrorCodeNotFound is the GraphQL error type reported for missing repositories and other objects.
+	GitHubErrorCodeNotFound = "NOT_FOUND"
+	// GitHubErrorCodeRateLimited is the GraphQL error type reported when the rate limit is exhausted.
+	GitHubErrorCodeRateLimited = "RATE_LIMITED"
+
+	jsonObjectStartConstant        = "{"
+	rateLimitMessageFragment       = "rate limit"
+	statusCodeSubmatchIndex        = 1
+	statusCodeSubmatchCount        = 2
+	statusCodeMinimumValueConstant = 100
+)
+
+// httpStatusPattern matches the status gh prints on failed requests: "gh: Not Found (HTTP 404)" in current 2.x
+// releases and "HTTP 404: Not Found (https://...)" in early ones.
+var httpStatusPattern = regexp.MustCompile(`\bHTTP (\d{3})\b`)
+
+// GitHubCommandError carries the HTTP status and GitHub error details parsed from a failed gh command. It wraps the
+// execshell failure, so callers matching execshell.CommandFailedError keep working.
+type GitHubCommandError struct {
+	// StatusCode is the HTTP status of the failed request, or zero when gh did not report one.
+	StatusCode int
+	// GitHubErrorCode is the first error code of the response body: a REST validation code such as "already_exists"
+	// or a GraphQL error type such as NOT_FOUND.
+	GitHubErrorCode string
+	// Message is the message of the response body, or the first line of stderr when no body was printed.
+	Message          string
+	DocumentationURL string
+	Cause            error
+}
+
+// Error describes the underlying command failure.
+func (commandError GitHubCommandError) Error() string {
+	if commandError.Cause == nil {
+		return commandError.Message
+	}
+	return commandError.Cause.Error()
+}
+
+// Unwrap exposes the underlying command failure.
+func (commandError GitHubCommandError) Unwrap() error {
+	return commandError.Cause
+}
+
+// NotFound reports whether GitHub answered that the requested object does not exist.
+func (commandError GitHubCommandError) NotFound() bool {
+	return commandError.StatusCode == http.StatusNotFound || commandError.GitHubErrorCode == GitHubErrorCodeNotFound
+}
+
+// RateLimited reports whether the request was refused by the primary or secondary rate limit. GitHub answers with
+// 429, or with 403 and a rate limit message, so the message is consulted for 403 responses only.
+func (commandError GitHubCommandError) RateLimited() bool {
+	switch {
+	case commandError.StatusCode == http.StatusTooManyRequests, commandError.GitHubErrorCode == GitHubErrorCodeRateLimited:
+		return true
+	case commandError.StatusCode == http.StatusForbidden:
+		return strings.Contains(strings.ToLower(commandError.Message), rateLimitMessageFragment)
+	default:
+		return false
+	}
+}
+
+// Transient reports whether the failure is a server-side error that usually clears when the request is repeated.
+func (commandError GitHubCommandError) Transient() bool {
+	switch commandError.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// ClassifyTransientFailure decides whether a failed gh command may be retried from the status parsed out of its
+// output. classified is false when gh reported neither a status nor an error code, leaving the decision to the
+// retry policy's stderr patterns.
+func ClassifyTransientFailure(result execshell.ExecutionResult) (transient bool, classified bool) {
+	commandError := parseGitHubCommandError(result, nil)
+	if commandError.StatusCode == 0 && len(commandError.GitHubErrorCode) == 0 {
+		return false, false
+	}
+	return commandError.Transient(), true
+}
+
+// IsNotFound reports whether an error returned by the client means GitHub answered that the requested object does
+// not exist, as opposed to a lookup that failed.
+func IsNotFound(executionError error) bool {
+	return notFoundError(executionError)
+}
+
+// notFoundError reports whether a gh failure means the requested object does not exist.
+func notFoundError(executionError error) bool {
+	var commandError GitHubCommandError
+	return errors.As(executionError, &commandError) && commandError.NotFound()
+}
+
+// classifyCommandError wraps a failed gh command in a GitHubCommandError. Other errors are returned unchanged.
+func classifyCommandError(executionError error) error {
+	var commandFailure execshell.CommandFailedError
+	if executionError == nil || !errors.As(executionError, &commandFailure) {
+		return executionError
+	}
+	return parseGitHubCommandError(commandFailure.Result, executionError)
+}
+
+func parseGitHubCommandError(result execshell.ExecutionResult, cause error) GitHubCommandError {
+	commandError := GitHubCommandError{Cause: cause}
+	for _, output := range []string{result.StandardOutput, result.StandardError} {
+		if body, parsed := parseErrorBody(output); parsed {
+			commandError.Message = body.Message
+			commandError.DocumentationURL = body.DocumentationURL
+			commandError.GitHubErrorCode = body.errorCode()
+			commandError.StatusCode = body.statusCode()
+			break
+		}
+	}
+
+	if submatches := httpStatusPattern.FindStringSubmatch(result.StandardError); len(submatches) == statusCodeSubmatchCount {
+		if statusCode, parseError := strconv.Atoi(submatches[statusCodeSubmatchIndex]); parseError == nil {
+			commandError.StatusCode = statusCode
+		}
+	}
+	if len(commandError.Message) == 0 {
+		commandError.Message = firstLine(result.StandardError)
+	}
+	return commandError
+}
+
+type githubErrorBody struct {
+	Message          string          `json:"message"`
+	DocumentationURL string          `json:"documentation_url"`
+	Status           json.RawMessage `json:"status"`
+	Errors           []struct {
+		Code    string `json:"code"`
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// parseErrorBody decodes the JSON error body gh api prints on failure. Bodies without a message or errors are not
+// error bodies and are rejected.
+func parseErrorBody(output string) (githubErrorBody, bool) {
+	startIndex := strings.Index(output, jsonObjectStartConstant)
+	if startIndex == -1 {
+		return githubErrorBody{}, false
+	}
+	var body githubErrorBody
+	if decodeError := json.NewDecoder(strings.NewReader(output[startIndex:])).Decode(&body); decodeError != nil {
+		return githubErrorBody{}, false
+	}
+	if len(body.Message) == 0 && len(body.Errors) == 0 {
+		return githubErrorBody{}, false
+	}
+	if len(body.Message) == 0 {
+		body.Message = body.Errors[0].Message
+	}
+	return body, true
+}
+
+func (body githubErrorBody) errorCode() string {
+	for _, bodyError := range body.Errors {
+		if len(bodyError.Code) > 0 {
+			return bodyError.Code
+		}
+		if len(bodyError.Type) > 0 {
+			return bodyError.Type
+		}
+	}
+	return ""
+}
+
+// statusCode reads the status field, which the REST API sends as a string ("404") and some endpoints as a number.
+func (body githubErrorBody) statusCode() int {
+	statusText := strings.Trim(strings.TrimSpace(string(body.Status)), `"`)
+	statusCode, parseError := strconv.Atoi(statusText)
+	if parseError != nil || statusCode < statusCodeMinimumValueConstant {
+		return 0
+	}
+	return statusCode
+}
+
+func firstLine(text string) string {
+	trimmedText := strings.TrimSpace(text)
+	if lineEnd := strings.IndexByte(trimmedText, '\n'); lineEnd != -1 {
+		return strings.TrimSpace(trimmedText[:lineEnd])
+	}
+	return trimmedText
+}
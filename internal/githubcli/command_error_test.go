@@ -0,0 +1,134 @@
+package githubcli_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+func TestGitHubCommandErrorParsesCapturedOutput(testInstance *testing.T) {
+	testCases := []struct {
+		name              string
+		result            execshell.ExecutionResult
+		expectedStatus    int
+		expectedCode      string
+		expectedMessage   string
+		expectedDocsURL   string
+		expectNotFound    bool
+		expectRateLimited bool
+		expectTransient   bool
+	}{
+		{
+			name:            "gh_2_40_api_not_found_suffix",
+			result:          execshell.ExecutionResult{StandardError: "gh: Not Found (HTTP 404)\n"},
+			expectedStatus:  404,
+			expectedMessage: "gh: Not Found (HTTP 404)",
+			expectNotFound:  true,
+		},
+		{
+			name:            "gh_2_0_api_not_found_prefix",
+			result:          execshell.ExecutionResult{StandardError: "HTTP 404: Not Found (https://api.github.com/repos/owner/example/branches/main/protection)\n"},
+			expectedStatus:  404,
+			expectedMessage: "HTTP 404: Not Found (https://api.github.com/repos/owner/example/branches/main/protection)",
+			expectNotFound:  true,
+		},
+		{
+			name: "gh_2_x_api_body_on_stdout",
+			result: execshell.ExecutionResult{
+				StandardOutput: `{"message":"Branch not protected","documentation_url":"https://docs.github.com/rest/branches/branch-protection#get-branch-protection","status":"404"}`,
+				StandardError:  "gh: Branch not protected (HTTP 404)\n",
+			},
+			expectedStatus:  404,
+			expectedMessage: "Branch not protected",
+			expectedDocsURL: "https://docs.github.com/rest/branches/branch-protection#get-branch-protection",
+			expectNotFound:  true,
+		},
+		{
+			name: "primary_rate_limit",
+			result: execshell.ExecutionResult{
+				StandardOutput: `{"message":"API rate limit exceeded for user ID 1.","documentation_url":"https://docs.github.com/rest/overview/resources-in-the-rest-api#rate-limiting"}`,
+				StandardError:  "gh: API rate limit exceeded for user ID 1. (HTTP 403)\n",
+			},
+			expectedStatus:    403,
+			expectedMessage:   "API rate limit exceeded for user ID 1.",
+			expectedDocsURL:   "https://docs.github.com/rest/overview/resources-in-the-rest-api#rate-limiting",
+			expectRateLimited: true,
+		},
+		{
+			name:            "forbidden_without_rate_limit",
+			result:          execshell.ExecutionResult{StandardError: "gh: Resource not accessible by integration (HTTP 403)\n"},
+			expectedStatus:  403,
+			expectedMessage: "gh: Resource not accessible by integration (HTTP 403)",
+		},
+		{
+			name: "validation_failure_code",
+			result: execshell.ExecutionResult{
+				StandardOutput: `{"message":"Validation Failed","errors":[{"resource":"PullRequest","code":"custom","message":"A pull request already exists for owner:feature."}],"documentation_url":"https://docs.github.com/rest/pulls/pulls#create-a-pull-request","status":"422"}`,
+				StandardError:  "gh: Validation Failed (HTTP 422)\n",
+			},
+			expectedStatus:  422,
+			expectedCode:    "custom",
+			expectedMessage: "Validation Failed",
+			expectedDocsURL: "https://docs.github.com/rest/pulls/pulls#create-a-pull-request",
+		},
+		{
+			name: "graphql_not_found_type",
+			result: execshell.ExecutionResult{
+				StandardOutput: `{"data":{"repository":null},"errors":[{"type":"NOT_FOUND","path":["repository"],"message":"Could not resolve to a Repository with the name 'owner/missing'."}]}`,
+				StandardError:  "gh: Could not resolve to a Repository with the name 'owner/missing'.\n",
+			},
+			expectedCode:    githubcli.GitHubErrorCodeNotFound,
+			expectedMessage: "Could not resolve to a Repository with the name 'owner/missing'.",
+			expectNotFound:  true,
+		},
+		{
+			name:            "graphql_bad_gateway",
+			result:          execshell.ExecutionResult{StandardError: "HTTP 502: Bad Gateway (https://api.github.com/graphql)\n"},
+			expectedStatus:  502,
+			expectedMessage: "HTTP 502: Bad Gateway (https://api.github.com/graphql)",
+			expectTransient: true,
+		},
+		{
+			name:            "network_failure_without_status",
+			result:          execshell.ExecutionResult{StandardError: "error connecting to api.github.com\ncheck your internet connection or https://githubstatus.com\n"},
+			expectedMessage: "error connecting to api.github.com",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			testCase.result.ExitCode = 1
+			executor := &stubGitHubExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return testCase.result, execshell.CommandFailedError{Command: execshell.ShellCommand{Name: execshell.CommandGitHub}, Result: testCase.result}
+			}}
+			client, clientError := githubcli.NewClient(executor)
+			require.NoError(subtest, clientError)
+
+			_, executionError := client.ListPullRequests(context.Background(), testRepositoryIdentifierConstant, githubcli.PullRequestListOptions{State: githubcli.PullRequestStateOpen, BaseBranch: testBaseBranchConstant})
+			var operationError githubcli.OperationError
+			require.True(subtest, errors.As(executionError, &operationError))
+			var commandError githubcli.GitHubCommandError
+			require.True(subtest, errors.As(executionError, &commandError))
+			var commandFailure execshell.CommandFailedError
+			require.True(subtest, errors.As(executionError, &commandFailure))
+
+			require.Equal(subtest, testCase.expectedStatus, commandError.StatusCode)
+			require.Equal(subtest, testCase.expectedCode, commandError.GitHubErrorCode)
+			require.Equal(subtest, testCase.expectedMessage, commandError.Message)
+			require.Equal(subtest, testCase.expectedDocsURL, commandError.DocumentationURL)
+			require.Equal(subtest, testCase.expectNotFound, commandError.NotFound())
+			require.Equal(subtest, testCase.expectRateLimited, commandError.RateLimited())
+			require.Equal(subtest, testCase.expectTransient, commandError.Transient())
+
+			transient, classified := githubcli.ClassifyTransientFailure(testCase.result)
+			require.Equal(subtest, testCase.expectedStatus != 0 || len(testCase.expectedCode) > 0, classified)
+			require.Equal(subtest, testCase.expectTransient, transient)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package githubcli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubauth"
+)
+
+const (
+	pullRequestEndpointTemplateConstant = "repos/%s/pulls/%d"
+	pullRequestBaseFieldNameConstant    = "base"
+)
+
+// IntendedRequest describes a mutating GitHub API request without sending it. Payload holds the JSON body.
+type IntendedRequest struct {
+	Operation OperationName
+	Method    string
+	Endpoint  string
+	Payload   []byte
+}
+
+// DescribeUpdatePagesConfig returns the request UpdatePagesConfig sends.
+func (client *Client) DescribeUpdatePagesConfig(repository string, configuration PagesConfiguration) (IntendedRequest, error) {
+	repositoryIdentifier := strings.TrimSpace(repository)
+	if len(repositoryIdentifier) == 0 {
+		return IntendedRequest{}, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	if len(strings.TrimSpace(configuration.SourceBranch)) == 0 {
+		return IntendedRequest{}, InvalidInputError{FieldName: sourceBranchFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	payload := struct {
+		Source struct {
+			Branch string `json:"branch"`
+			Path   string `json:"path"`
+		} `json:"source"`
+	}{}
+
+	payload.Source.Branch = configuration.SourceBranch
+	payload.Source.Path = configuration.SourcePath
+
+	payloadBytes, encodingError := json.Marshal(payload)
+	if encodingError != nil {
+		return IntendedRequest{}, PayloadEncodingError{Operation: updatePagesOperationNameConstant, Cause: encodingError}
+	}
+
+	return IntendedRequest{
+		Operation: updatePagesOperationNameConstant,
+		Method:    httpMethodPutConstant,
+		Endpoint:  fmt.Sprintf(pagesEndpointTemplateConstant, repositoryIdentifier),
+		Payload:   payloadBytes,
+	}, nil
+}
+
+// DescribeSetDefaultBranch returns the request SetDefaultBranch sends. SetDefaultBranch passes the branch as a gh api
+// field, which gh encodes as the same JSON body.
+func (client *Client) DescribeSetDefaultBranch(repository string, branchName string) (IntendedRequest, error) {
+	repositoryIdentifier := strings.TrimSpace(repository)
+	if len(repositoryIdentifier) == 0 {
+		return IntendedRequest{}, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	trimmedBranch := strings.TrimSpace(branchName)
+	if len(trimmedBranch) == 0 {
+		return IntendedRequest{}, InvalidInputError{FieldName: defaultBranchFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	payloadBytes, encodingError := json.Marshal(map[string]string{defaultBranchFieldNameConstant: trimmedBranch})
+	if encodingError != nil {
+		return IntendedRequest{}, PayloadEncodingError{Operation: updateDefaultBranchOperationNameConstant, Cause: encodingError}
+	}
+
+	return IntendedRequest{
+		Operation: updateDefaultBranchOperationNameConstant,
+		Method:    httpMethodPatchConstant,
+		Endpoint:  fmt.Sprintf(repositoryEndpointTemplateConstant, repositoryIdentifier),
+		Payload:   payloadBytes,
+	}, nil
+}
+
+// DescribeUpdatePullRequestBase returns the REST request equivalent to the gh pr edit call UpdatePullRequestBase runs.
+func (client *Client) DescribeUpdatePullRequestBase(repository string, pullRequestNumber int, baseBranch string) (IntendedRequest, error) {
+	repositoryIdentifier := strings.TrimSpace(repository)
+	if len(repositoryIdentifier) == 0 {
+		return IntendedRequest{}, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	if pullRequestNumber <= 0 {
+		return IntendedRequest{}, InvalidInputError{FieldName: pullRequestNumberFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	trimmedBase := strings.TrimSpace(baseBranch)
+	if len(trimmedBase) == 0 {
+		return IntendedRequest{}, InvalidInputError{FieldName: baseBranchFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	payloadBytes, encodingError := json.Marshal(map[string]string{pullRequestBaseFieldNameConstant: trimmedBase})
+	if encodingError != nil {
+		return IntendedRequest{}, PayloadEncodingError{Operation: updatePullRequestOperationNameConstant, Cause: encodingError}
+	}
+
+	return IntendedRequest{
+		Operation: updatePullRequestOperationNameConstant,
+		Method:    httpMethodPatchConstant,
+		Endpoint:  fmt.Sprintf(pullRequestEndpointTemplateConstant, repositoryIdentifier, pullRequestNumber),
+		Payload:   payloadBytes,
+	}, nil
+}
+
+// DescribeLockBranch returns the request LockBranch sends.
+func (client *Client) DescribeLockBranch(repository string, branchName string) (IntendedRequest, error) {
+	return describeBranchProtection(lockBranchOperationNameConstant, repository, branchName, lockedBranchProtection())
+}
+
+// DescribeApplyBranchProtection returns the request ApplyBranchProtection sends.
+func (client *Client) DescribeApplyBranchProtection(repository string, branchName string, protection BranchProtection) (IntendedRequest, error) {
+	return describeBranchProtection(applyBranchProtectionOperationNameConstant, repository, branchName, protection)
+}
+
+func describeBranchProtection(operation OperationName, repository string, branchName string, protection BranchProtection) (IntendedRequest, error) {
+	repositoryIdentifier := strings.TrimSpace(repository)
+	if len(repositoryIdentifier) == 0 {
+		return IntendedRequest{}, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	trimmedBranch := strings.TrimSpace(branchName)
+	if len(trimmedBranch) == 0 {
+		return IntendedRequest{}, InvalidInputError{FieldName: sourceBranchFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	payloadBytes, encodingError := protection.UpdatePayload()
+	if encodingError != nil {
+		return IntendedRequest{}, PayloadEncodingError{Operation: operation, Cause: encodingError}
+	}
+
+	return IntendedRequest{
+		Operation: operation,
+		Method:    httpMethodPutConstant,
+		Endpoint:  fmt.Sprintf(branchProtectionEndpointTemplateConstant, repositoryIdentifier, trimmedBranch),
+		Payload:   payloadBytes,
+	}, nil
+}
+
+// sendIntendedRequest sends a described request through gh api with its payload on standard input.
+func (client *Client) sendIntendedRequest(executionContext context.Context, request IntendedRequest) error {
+	commandDetails := execshell.CommandDetails{
+		Arguments: []string{
+			apiSubcommandConstant,
+			request.Endpoint,
+			methodFlagConstant,
+			request.Method,
+			inputFlagConstant,
+			stdinReferenceConstant,
+			acceptHeaderFlagConstant,
+			acceptHeaderValueConstant,
+		},
+		StandardInput:          request.Payload,
+		GitHubTokenRequirement: githubauth.TokenOptional,
+		Idempotent:             false,
+	}
+
+	if _, executionError := client.executeMutation(executionContext, commandDetails); executionError != nil {
+		return OperationError{Operation: request.Operation, Cause: executionError}
+	}
+
+	return nil
+}
+
+// GetPagesConfigResponse returns the raw GitHub Pages response body for a repository, trimmed of surrounding whitespace.
+func (client *Client) GetPagesConfigResponse(executionContext context.Context, repository string) ([]byte, error) {
+	repositoryIdentifier := strings.TrimSpace(repository)
+	if len(repositoryIdentifier) == 0 {
+		return nil, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments: []string{
+			apiSubcommandConstant,
+			fmt.Sprintf(pagesEndpointTemplateConstant, repositoryIdentifier),
+			methodFlagConstant,
+			httpMethodGetConstant,
+			acceptHeaderFlagConstant,
+			acceptHeaderValueConstant,
+		},
+		GitHubTokenRequirement: githubauth.TokenOptional,
+		Idempotent:             true,
+	}
+
+	executionResult, executionError := client.executeCachedRead(executionContext, commandDetails)
+	if executionError != nil {
+		return nil, OperationError{Operation: getPagesOperationNameConstant, Cause: executionError}
+	}
+
+	return []byte(strings.TrimSpace(executionResult.StandardOutput)), nil
+}
+
+// ParsePagesStatus decodes a GitHub Pages response body. Empty and null bodies describe disabled Pages.
+func ParsePagesStatus(responseBody []byte) (PagesStatus, error) {
+	trimmedOutput := strings.TrimSpace(string(responseBody))
+	if len(trimmedOutput) == 0 || trimmedOutput == pagesNullResponseConstant {
+		return PagesStatus{Enabled: false}, nil
+	}
+
+	var response struct {
+		BuildType string `json:"build_type"`
+		Source    struct {
+			Branch string `json:"branch"`
+			Path   string `json:"path"`
+		} `json:"source"`
+	}
+
+	decodingError := json.Unmarshal([]byte(trimmedOutput), &response)
+	if decodingError != nil {
+		return PagesStatus{}, ResponseDecodingError{Operation: getPagesOperationNameConstant, Cause: decodingError}
+	}
+
+	return PagesStatus{
+		Enabled:      true,
+		BuildType:    PagesBuildType(response.BuildType),
+		SourceBranch: response.Source.Branch,
+		SourcePath:   response.Source.Path,
+	}, nil
+}
+
+// GetBranchProtectionResponse returns the raw protection response body of the branch. The boolean is false, with no
+// error, when the branch is unprotected.
+func (client *Client) GetBranchProtectionResponse(executionContext context.Context, repository string, branchName string) ([]byte, bool, error) {
+	repositoryIdentifier := strings.TrimSpace(repository)
+	if len(repositoryIdentifier) == 0 {
+		return nil, false, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	trimmedBranch := strings.TrimSpace(branchName)
+	if len(trimmedBranch) == 0 {
+		return nil, false, InvalidInputError{FieldName: sourceBranchFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments: []string{
+			apiSubcommandConstant,
+			fmt.Sprintf(branchProtectionEndpointTemplateConstant, repositoryIdentifier, trimmedBranch),
+			methodFlagConstant,
+			httpMethodGetConstant,
+			acceptHeaderFlagConstant,
+			acceptHeaderValueConstant,
+		},
+		GitHubTokenRequirement: githubauth.TokenOptional,
+		Idempotent:             true,
+	}
+
+	executionResult, executionError := client.executeCachedRead(executionContext, commandDetails)
+	if executionError != nil {
+		if notFoundError(executionError) {
+			return nil, false, nil
+		}
+		return nil, false, OperationError{Operation: getBranchProtectionOperationNameConstant, Cause: executionError}
+	}
+
+	return []byte(strings.TrimSpace(executionResult.StandardOutput)), true, nil
+}
@@ -0,0 +1,92 @@
+package githubcli_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+func TestDescribedRequestsMatchSentRequests(testInstance *testing.T) {
+	testCases := []struct {
+		name     string
+		describe func(*githubcli.Client) (githubcli.IntendedRequest, error)
+		send     func(*githubcli.Client) error
+	}{
+		{
+			name: "update_pages",
+			describe: func(client *githubcli.Client) (githubcli.IntendedRequest, error) {
+				return client.DescribeUpdatePagesConfig(testRepositoryIdentifierConstant, githubcli.PagesConfiguration{SourceBranch: testTargetBranchConstant, SourcePath: testPagesSourcePathConstant})
+			},
+			send: func(client *githubcli.Client) error {
+				return client.UpdatePagesConfig(context.Background(), testRepositoryIdentifierConstant, githubcli.PagesConfiguration{SourceBranch: testTargetBranchConstant, SourcePath: testPagesSourcePathConstant})
+			},
+		},
+		{
+			name: "lock_branch",
+			describe: func(client *githubcli.Client) (githubcli.IntendedRequest, error) {
+				return client.DescribeLockBranch(testRepositoryIdentifierConstant, testArchiveBranchConstant)
+			},
+			send: func(client *githubcli.Client) error {
+				return client.LockBranch(context.Background(), testRepositoryIdentifierConstant, testArchiveBranchConstant)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubGitHubExecutor{}
+			client, creationError := githubcli.NewClient(executor)
+			require.NoError(subtest, creationError)
+
+			request, describeError := testCase.describe(client)
+			require.NoError(subtest, describeError)
+			require.Empty(subtest, executor.recordedDetails)
+
+			require.NoError(subtest, testCase.send(client))
+			require.Len(subtest, executor.recordedDetails, 1)
+			require.Equal(subtest, request.Endpoint, executor.recordedDetails[0].Arguments[1])
+			require.Equal(subtest, request.Method, executor.recordedDetails[0].Arguments[3])
+			require.JSONEq(subtest, string(request.Payload), string(executor.recordedDetails[0].StandardInput))
+		})
+	}
+}
+
+func TestGetBranchProtectionResponse(testInstance *testing.T) {
+	testCases := []struct {
+		name              string
+		executeFunc       func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error)
+		expectedBody      string
+		expectedProtected bool
+	}{
+		{
+			name: "protected",
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: "  {\"enforce_admins\":{\"enabled\":true}}\n"}, nil
+			},
+			expectedBody:      `{"enforce_admins":{"enabled":true}}`,
+			expectedProtected: true,
+		},
+		{
+			name: "not_protected",
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, execshell.CommandFailedError{Command: execshell.ShellCommand{Name: execshell.CommandGitHub}, Result: execshell.ExecutionResult{ExitCode: 1, StandardError: "gh: Branch not protected (HTTP 404)"}}
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			client, creationError := githubcli.NewClient(&stubGitHubExecutor{executeFunc: testCase.executeFunc})
+			require.NoError(subtest, creationError)
+
+			body, protected, responseError := client.GetBranchProtectionResponse(context.Background(), testRepositoryIdentifierConstant, testBaseBranchConstant)
+			require.NoError(subtest, responseError)
+			require.Equal(subtest, testCase.expectedProtected, protected)
+			require.Equal(subtest, testCase.expectedBody, string(body))
+		})
+	}
+}
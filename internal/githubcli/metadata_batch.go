@@ -0,0 +1,190 @@
+package githubcli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubauth"
+)
+
+const (
+	graphQLEndpointConstant                  = "graphql"
+	graphQLQueryFieldTemplateConstant        = "query=%s"
+	graphQLRepositoryAliasTemplateConstant   = "r%d"
+	graphQLRepositorySelectionTemplate       = "%s: repository(owner: %s, name: %s) { nameWithOwner description defaultBranchRef { name } isInOrganization deleteBranchOnMerge mergeQueue { url } }"
+	graphQLQueryOpeningConstant              = "query {"
+	graphQLQueryClosingConstant              = "}"
+	graphQLSelectionSeparatorConstant        = " "
+	ownerRepositorySeparatorConstant         = "/"
+	invalidOwnerRepositoryMessageConstant    = "expected owner/name"
+	batchRepositoryMetadataOperationConstant = OperationName("ResolveRepoMetadataBatch")
+	// DefaultMetadataBatchSize is the number of repositories requested per GraphQL query.
+	DefaultMetadataBatchSize = 50
+)
+
+// ResolveRepoMetadataBatch retrieves metadata for several owner/name repositories with one GraphQL query.
+// Repositories GitHub does not return are absent from the result map, which is keyed by the requested identifier.
+func (client *Client) ResolveRepoMetadataBatch(executionContext context.Context, repositories []string) (map[string]RepositoryMetadata, error) {
+	selections := make([]string, 0, len(repositories))
+	aliasToRepository := make(map[string]string, len(repositories))
+	for repositoryIndex, repository := range repositories {
+		repositoryIdentifier := strings.TrimSpace(repository)
+		owner, name, found := strings.Cut(repositoryIdentifier, ownerRepositorySeparatorConstant)
+		if !found || len(owner) == 0 || len(name) == 0 {
+			return nil, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: invalidOwnerRepositoryMessageConstant}
+		}
+
+		alias := fmt.Sprintf(graphQLRepositoryAliasTemplateConstant, repositoryIndex)
+		aliasToRepository[alias] = repositoryIdentifier
+		selections = append(selections, fmt.Sprintf(graphQLRepositorySelectionTemplate, alias, strconv.Quote(owner), strconv.Quote(name)))
+	}
+
+	if len(selections) == 0 {
+		return map[string]RepositoryMetadata{}, nil
+	}
+
+	query := strings.Join([]string{graphQLQueryOpeningConstant, strings.Join(selections, graphQLSelectionSeparatorConstant), graphQLQueryClosingConstant}, graphQLSelectionSeparatorConstant)
+	commandDetails := execshell.CommandDetails{
+		Arguments: []string{
+			apiSubcommandConstant,
+			graphQLEndpointConstant,
+			fieldFlagConstant,
+			fmt.Sprintf(graphQLQueryFieldTemplateConstant, query),
+		},
+		GitHubTokenRequirement: githubauth.TokenRequired,
+		Idempotent:             true,
+	}
+
+	executionResult, executionError := client.runGitHubCLI(executionContext, commandDetails)
+	if executionError != nil {
+		return nil, OperationError{Operation: batchRepositoryMetadataOperationConstant, Cause: executionError}
+	}
+
+	var response struct {
+		Data map[string]*struct {
+			NameWithOwner    string `json:"nameWithOwner"`
+			Description      string `json:"description"`
+			DefaultBranchRef *struct {
+				Name string `json:"name"`
+			} `json:"defaultBranchRef"`
+			IsInOrganization    bool `json:"isInOrganization"`
+			DeleteBranchOnMerge bool `json:"deleteBranchOnMerge"`
+			MergeQueue          *struct {
+				URL string `json:"url"`
+			} `json:"mergeQueue"`
+		} `json:"data"`
+	}
+	if decodingError := json.Unmarshal([]byte(executionResult.StandardOutput), &response); decodingError != nil {
+		return nil, ResponseDecodingError{Operation: batchRepositoryMetadataOperationConstant, Cause: decodingError}
+	}
+
+	results := make(map[string]RepositoryMetadata, len(response.Data))
+	for alias, repositoryData := range response.Data {
+		repositoryIdentifier, known := aliasToRepository[alias]
+		if !known || repositoryData == nil {
+			continue
+		}
+		metadata := RepositoryMetadata{
+			NameWithOwner:       repositoryData.NameWithOwner,
+			Description:         repositoryData.Description,
+			IsInOrganization:    repositoryData.IsInOrganization,
+			DeleteBranchOnMerge: repositoryData.DeleteBranchOnMerge,
+			MergeQueueEnabled:   repositoryData.MergeQueue != nil,
+			MergeQueueKnown:     true,
+		}
+		if repositoryData.DefaultBranchRef != nil {
+			metadata.DefaultBranch = repositoryData.DefaultBranchRef.Name
+		}
+		results[repositoryIdentifier] = metadata
+	}
+
+	return results, nil
+}
+
+// BatchedMetadataProvider serves repository metadata from batched GraphQL prefetches, falling back to gh repo view.
+type BatchedMetadataProvider struct {
+	client    *Client
+	batchSize int
+	mutex     sync.Mutex
+	cache     map[string]RepositoryMetadata
+}
+
+// NewBatchedMetadataProvider constructs a provider that groups lookups into batches of batchSize repositories.
+func NewBatchedMetadataProvider(client *Client, batchSize int) *BatchedMetadataProvider {
+	if batchSize <= 0 {
+		batchSize = DefaultMetadataBatchSize
+	}
+	return &BatchedMetadataProvider{client: client, batchSize: batchSize, cache: make(map[string]RepositoryMetadata)}
+}
+
+// PrefetchRepoMetadata resolves metadata for uncached repositories in GraphQL batches.
+// Batches that fail are left uncached so later lookups fall back to individual requests.
+func (provider *BatchedMetadataProvider) PrefetchRepoMetadata(executionContext context.Context, repositories []string) {
+	pending := provider.uncachedRepositories(repositories)
+	for batchStart := 0; batchStart < len(pending); batchStart += provider.batchSize {
+		batchEnd := batchStart + provider.batchSize
+		if batchEnd > len(pending) {
+			batchEnd = len(pending)
+		}
+
+		batchResults, batchError := provider.client.ResolveRepoMetadataBatch(executionContext, pending[batchStart:batchEnd])
+		if batchError != nil {
+			continue
+		}
+
+		provider.mutex.Lock()
+		for repositoryIdentifier, metadata := range batchResults {
+			provider.cache[metadataCacheKey(repositoryIdentifier)] = metadata
+		}
+		provider.mutex.Unlock()
+	}
+}
+
+// ResolveRepoMetadata returns prefetched metadata when available and otherwise queries the repository directly.
+// Each prefetched entry is served once so lookups made after a repository changes observe fresh metadata.
+func (provider *BatchedMetadataProvider) ResolveRepoMetadata(executionContext context.Context, repository string) (RepositoryMetadata, error) {
+	cacheKey := metadataCacheKey(repository)
+
+	provider.mutex.Lock()
+	cachedMetadata, cached := provider.cache[cacheKey]
+	delete(provider.cache, cacheKey)
+	provider.mutex.Unlock()
+	if cached {
+		return cachedMetadata, nil
+	}
+
+	return provider.client.ResolveRepoMetadata(executionContext, repository)
+}
+
+func (provider *BatchedMetadataProvider) uncachedRepositories(repositories []string) []string {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	seen := make(map[string]struct{}, len(repositories))
+	pending := make([]string, 0, len(repositories))
+	for _, repository := range repositories {
+		repositoryIdentifier := strings.TrimSpace(repository)
+		if !strings.Contains(repositoryIdentifier, ownerRepositorySeparatorConstant) {
+			continue
+		}
+		cacheKey := metadataCacheKey(repositoryIdentifier)
+		if _, cached := provider.cache[cacheKey]; cached {
+			continue
+		}
+		if _, duplicate := seen[cacheKey]; duplicate {
+			continue
+		}
+		seen[cacheKey] = struct{}{}
+		pending = append(pending, repositoryIdentifier)
+	}
+	return pending
+}
+
+func metadataCacheKey(repository string) string {
+	return strings.ToLower(strings.TrimSpace(repository))
+}
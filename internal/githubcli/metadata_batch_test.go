@@ -0,0 +1,147 @@
+package githubcli_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const (
+	testBatchFirstRepositoryConstant  = "owner/alpha"
+	testBatchSecondRepositoryConstant = "owner/beta"
+	testBatchGraphQLResponseConstant  = `{"data":{"r0":{"nameWithOwner":"canonical/alpha","description":"Alpha","defaultBranchRef":{"name":"main"},"isInOrganization":true,"deleteBranchOnMerge":true,"mergeQueue":{"url":"https://github.com/canonical/alpha/queue/main"}},"r1":null}}`
+	testBatchRepoViewResponseConstant = `{"nameWithOwner":"owner/beta","description":"","defaultBranchRef":{"name":"trunk"},"isInOrganization":false}`
+)
+
+func TestResolveRepoMetadataBatch(testInstance *testing.T) {
+	testCases := []struct {
+		name             string
+		repositories     []string
+		executeFunc      func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error)
+		expectedMetadata map[string]githubcli.RepositoryMetadata
+		expectedError    bool
+		verify           func(testing.TB, []execshell.CommandDetails)
+	}{
+		{
+			name:         "batch_success",
+			repositories: []string{testBatchFirstRepositoryConstant, testBatchSecondRepositoryConstant},
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: testBatchGraphQLResponseConstant}, nil
+			},
+			expectedMetadata: map[string]githubcli.RepositoryMetadata{
+				testBatchFirstRepositoryConstant: {NameWithOwner: "canonical/alpha", Description: "Alpha", DefaultBranch: "main", IsInOrganization: true, DeleteBranchOnMerge: true, MergeQueueEnabled: true, MergeQueueKnown: true},
+			},
+			verify: func(testingInstance testing.TB, details []execshell.CommandDetails) {
+				require.Len(testingInstance, details, 1)
+				require.Equal(testingInstance, []string{"api", "graphql", "-f"}, details[0].Arguments[:3])
+				query := details[0].Arguments[3]
+				require.Contains(testingInstance, query, `r0: repository(owner: "owner", name: "alpha")`)
+				require.Contains(testingInstance, query, `r1: repository(owner: "owner", name: "beta")`)
+				require.Contains(testingInstance, query, "deleteBranchOnMerge mergeQueue { url }")
+			},
+		},
+		{
+			name:          "invalid_repository",
+			repositories:  []string{"invalid"},
+			expectedError: true,
+			verify: func(testingInstance testing.TB, details []execshell.CommandDetails) {
+				require.Empty(testingInstance, details)
+			},
+		},
+		{
+			name:         "command_failure",
+			repositories: []string{testBatchFirstRepositoryConstant},
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, errors.New("graphql failure")
+			},
+			expectedError: true,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubGitHubExecutor{executeFunc: testCase.executeFunc}
+			client, clientError := githubcli.NewClient(executor)
+			require.NoError(subtest, clientError)
+
+			metadata, batchError := client.ResolveRepoMetadataBatch(context.Background(), testCase.repositories)
+			if testCase.expectedError {
+				require.Error(subtest, batchError)
+			} else {
+				require.NoError(subtest, batchError)
+				require.Equal(subtest, testCase.expectedMetadata, metadata)
+			}
+			if testCase.verify != nil {
+				testCase.verify(subtest, executor.recordedDetails)
+			}
+		})
+	}
+}
+
+func TestBatchedMetadataProvider(testInstance *testing.T) {
+	testCases := []struct {
+		name                  string
+		batchFails            bool
+		expectedGraphQLCalls  int
+		expectedRepoViewCalls int
+	}{
+		{
+			name:                  "prefetched_metadata_served_from_batch",
+			expectedGraphQLCalls:  2,
+			expectedRepoViewCalls: 1,
+		},
+		{
+			name:                  "graphql_failure_falls_back_to_repo_view",
+			batchFails:            true,
+			expectedGraphQLCalls:  2,
+			expectedRepoViewCalls: 2,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			graphQLCalls := 0
+			repoViewCalls := 0
+			executor := &stubGitHubExecutor{executeFunc: func(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				if details.Arguments[0] == "api" {
+					graphQLCalls++
+					if testCase.batchFails {
+						return execshell.ExecutionResult{}, errors.New("graphql failure")
+					}
+					if strings.Contains(details.Arguments[3], "alpha") {
+						return execshell.ExecutionResult{StandardOutput: `{"data":{"r0":{"nameWithOwner":"canonical/alpha","defaultBranchRef":{"name":"main"}}}}`}, nil
+					}
+					return execshell.ExecutionResult{StandardOutput: `{"data":{"r0":null}}`}, nil
+				}
+				repoViewCalls++
+				return execshell.ExecutionResult{StandardOutput: testBatchRepoViewResponseConstant}, nil
+			}}
+			client, clientError := githubcli.NewClient(executor)
+			require.NoError(subtest, clientError)
+
+			provider := githubcli.NewBatchedMetadataProvider(client, 1)
+			provider.PrefetchRepoMetadata(context.Background(), []string{testBatchFirstRepositoryConstant, testBatchSecondRepositoryConstant, "OWNER/ALPHA"})
+
+			firstMetadata, firstError := provider.ResolveRepoMetadata(context.Background(), testBatchFirstRepositoryConstant)
+			require.NoError(subtest, firstError)
+			secondMetadata, secondError := provider.ResolveRepoMetadata(context.Background(), testBatchSecondRepositoryConstant)
+			require.NoError(subtest, secondError)
+
+			if !testCase.batchFails {
+				require.Equal(subtest, "canonical/alpha", firstMetadata.NameWithOwner)
+				require.Equal(subtest, "main", firstMetadata.DefaultBranch)
+			}
+			require.Equal(subtest, "trunk", secondMetadata.DefaultBranch)
+			require.Equal(subtest, testCase.expectedGraphQLCalls, graphQLCalls)
+			require.Equal(subtest, testCase.expectedRepoViewCalls, repoViewCalls)
+		})
+	}
+}
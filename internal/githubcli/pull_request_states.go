@@ -0,0 +1,133 @@
+package githubcli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubauth"
+)
+
+const (
+	graphQLPullRequestAliasTemplateConstant     = "p%d"
+	graphQLPullRequestSelectionTemplateConstant = "%s: pullRequest(number: %d) { state }"
+	graphQLPullRequestRepositoryTemplate        = "repository(owner: %s, name: %s) {"
+	pullRequestStatesOperationConstant          = OperationName("ResolvePullRequestStates")
+	graphQLRepositoryFieldConstant              = "repository"
+	graphQLPullRequestErrorPathLengthConstant   = 2
+	// DefaultPullRequestStateBatchSize is the number of pull requests requested per GraphQL query.
+	DefaultPullRequestStateBatchSize = 50
+)
+
+// ResolvePullRequestStates retrieves the states of several pull requests of one owner/name repository with one
+// GraphQL query. Pull requests GitHub does not return, including numbers it reports as NOT_FOUND, are absent from the
+// result map, which is keyed by number.
+func (client *Client) ResolvePullRequestStates(executionContext context.Context, repository string, pullRequestNumbers []int) (map[int]PullRequestState, error) {
+	repositoryIdentifier := strings.TrimSpace(repository)
+	owner, name, found := strings.Cut(repositoryIdentifier, ownerRepositorySeparatorConstant)
+	if !found || len(owner) == 0 || len(name) == 0 {
+		return nil, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: invalidOwnerRepositoryMessageConstant}
+	}
+
+	selections := make([]string, 0, len(pullRequestNumbers))
+	aliasToNumber := make(map[string]int, len(pullRequestNumbers))
+	for _, pullRequestNumber := range pullRequestNumbers {
+		if pullRequestNumber <= 0 {
+			return nil, InvalidInputError{FieldName: pullRequestNumberFieldNameConstant, Message: requiredValueMessageConstant}
+		}
+		alias := fmt.Sprintf(graphQLPullRequestAliasTemplateConstant, pullRequestNumber)
+		if _, duplicate := aliasToNumber[alias]; duplicate {
+			continue
+		}
+		aliasToNumber[alias] = pullRequestNumber
+		selections = append(selections, fmt.Sprintf(graphQLPullRequestSelectionTemplateConstant, alias, pullRequestNumber))
+	}
+
+	if len(selections) == 0 {
+		return map[int]PullRequestState{}, nil
+	}
+
+	query := strings.Join([]string{
+		graphQLQueryOpeningConstant,
+		fmt.Sprintf(graphQLPullRequestRepositoryTemplate, strconv.Quote(owner), strconv.Quote(name)),
+		strings.Join(selections, graphQLSelectionSeparatorConstant),
+		graphQLQueryClosingConstant,
+		graphQLQueryClosingConstant,
+	}, graphQLSelectionSeparatorConstant)
+	commandDetails := execshell.CommandDetails{
+		Arguments: []string{
+			apiSubcommandConstant,
+			graphQLEndpointConstant,
+			fieldFlagConstant,
+			fmt.Sprintf(graphQLQueryFieldTemplateConstant, query),
+		},
+		GitHubTokenRequirement: githubauth.TokenRequired,
+		Idempotent:             true,
+	}
+
+	executionResult, executionError := client.executeCachedRead(executionContext, commandDetails)
+	if executionError != nil {
+		partialResult, recovered := missingPullRequestsResult(executionError, aliasToNumber)
+		if !recovered {
+			return nil, OperationError{Operation: pullRequestStatesOperationConstant, Cause: executionError}
+		}
+		executionResult = partialResult
+	}
+
+	var response pullRequestStatesResponse
+	if decodingError := json.Unmarshal([]byte(executionResult.StandardOutput), &response); decodingError != nil {
+		return nil, ResponseDecodingError{Operation: pullRequestStatesOperationConstant, Cause: decodingError}
+	}
+
+	states := make(map[int]PullRequestState, len(response.Data.Repository))
+	for alias, pullRequestData := range response.Data.Repository {
+		pullRequestNumber, known := aliasToNumber[alias]
+		if !known || pullRequestData == nil {
+			continue
+		}
+		states[pullRequestNumber] = PullRequestState(strings.ToLower(strings.TrimSpace(pullRequestData.State)))
+	}
+
+	return states, nil
+}
+
+type pullRequestStatesResponse struct {
+	Data struct {
+		Repository map[string]*struct {
+			State string `json:"state"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []struct {
+		Type string   `json:"type"`
+		Path []string `json:"path"`
+	} `json:"errors"`
+}
+
+// missingPullRequestsResult recovers the output of a query whose only errors are NOT_FOUND for individual pull
+// requests. gh exits non-zero for such responses even though the other pull requests of the batch resolved.
+func missingPullRequestsResult(executionError error, aliasToNumber map[string]int) (execshell.ExecutionResult, bool) {
+	var commandFailure execshell.CommandFailedError
+	if !errors.As(executionError, &commandFailure) {
+		return execshell.ExecutionResult{}, false
+	}
+	var response pullRequestStatesResponse
+	if decodingError := json.Unmarshal([]byte(commandFailure.Result.StandardOutput), &response); decodingError != nil {
+		return execshell.ExecutionResult{}, false
+	}
+	if response.Data.Repository == nil || len(response.Errors) == 0 {
+		return execshell.ExecutionResult{}, false
+	}
+	for _, responseError := range response.Errors {
+		if responseError.Type != GitHubErrorCodeNotFound || len(responseError.Path) != graphQLPullRequestErrorPathLengthConstant || responseError.Path[0] != graphQLRepositoryFieldConstant {
+			return execshell.ExecutionResult{}, false
+		}
+		if _, known := aliasToNumber[responseError.Path[1]]; !known {
+			return execshell.ExecutionResult{}, false
+		}
+	}
+	return commandFailure.Result, true
+}
@@ -0,0 +1,121 @@
+package githubcli_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const (
+	testPullRequestStatesRepositoryConstant = "owner/alpha"
+	testPullRequestStatesResponseConstant   = `{"data":{"repository":{"p7":{"state":"MERGED"},"p8":{"state":"OPEN"},"p9":null}}}`
+	testPullRequestStatesNotFoundConstant   = `{"data":{"repository":{"p7":{"state":"CLOSED"},"p9":null}},"errors":[{"type":"NOT_FOUND","path":["repository","p9"],"message":"Could not resolve to a PullRequest with the number of 9."}]}`
+	testRepositoryNotFoundConstant          = `{"data":{"repository":null},"errors":[{"type":"NOT_FOUND","path":["repository"],"message":"Could not resolve to a Repository with the name 'owner/alpha'."}]}`
+)
+
+func TestResolvePullRequestStates(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		repository     string
+		numbers        []int
+		executeFunc    func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error)
+		expectedStates map[int]githubcli.PullRequestState
+		expectedError  bool
+		verify         func(testing.TB, []execshell.CommandDetails)
+	}{
+		{
+			name:       "batch_success",
+			repository: testPullRequestStatesRepositoryConstant,
+			numbers:    []int{7, 8, 9, 7},
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: testPullRequestStatesResponseConstant}, nil
+			},
+			expectedStates: map[int]githubcli.PullRequestState{7: "merged", 8: "open"},
+			verify: func(testingInstance testing.TB, details []execshell.CommandDetails) {
+				require.Len(testingInstance, details, 1)
+				require.Equal(testingInstance, []string{"api", "graphql", "-f"}, details[0].Arguments[:3])
+				query := details[0].Arguments[3]
+				require.Contains(testingInstance, query, `repository(owner: "owner", name: "alpha")`)
+				require.Contains(testingInstance, query, "p7: pullRequest(number: 7) { state }")
+				require.Contains(testingInstance, query, "p9: pullRequest(number: 9) { state }")
+			},
+		},
+		{
+			name:           "no_numbers",
+			repository:     testPullRequestStatesRepositoryConstant,
+			expectedStates: map[int]githubcli.PullRequestState{},
+			verify: func(testingInstance testing.TB, details []execshell.CommandDetails) {
+				require.Empty(testingInstance, details)
+			},
+		},
+		{
+			name:          "invalid_repository",
+			repository:    "invalid",
+			numbers:       []int{7},
+			expectedError: true,
+			verify: func(testingInstance testing.TB, details []execshell.CommandDetails) {
+				require.Empty(testingInstance, details)
+			},
+		},
+		{
+			name:          "invalid_number",
+			repository:    testPullRequestStatesRepositoryConstant,
+			numbers:       []int{0},
+			expectedError: true,
+		},
+		{
+			name:       "missing_pull_request_left_unknown",
+			repository: testPullRequestStatesRepositoryConstant,
+			numbers:    []int{7, 9},
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				result := execshell.ExecutionResult{StandardOutput: testPullRequestStatesNotFoundConstant, ExitCode: 1}
+				return result, execshell.CommandFailedError{Result: result}
+			},
+			expectedStates: map[int]githubcli.PullRequestState{7: "closed"},
+		},
+		{
+			name:       "missing_repository_fails",
+			repository: testPullRequestStatesRepositoryConstant,
+			numbers:    []int{7},
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				result := execshell.ExecutionResult{StandardOutput: testRepositoryNotFoundConstant, ExitCode: 1}
+				return result, execshell.CommandFailedError{Result: result}
+			},
+			expectedError: true,
+		},
+		{
+			name:       "command_failure",
+			repository: testPullRequestStatesRepositoryConstant,
+			numbers:    []int{7},
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, errors.New("graphql failure")
+			},
+			expectedError: true,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubGitHubExecutor{executeFunc: testCase.executeFunc}
+			client, clientError := githubcli.NewClient(executor)
+			require.NoError(subtest, clientError)
+
+			states, resolveError := client.ResolvePullRequestStates(context.Background(), testCase.repository, testCase.numbers)
+			if testCase.expectedError {
+				require.Error(subtest, resolveError)
+			} else {
+				require.NoError(subtest, resolveError)
+				require.Equal(subtest, testCase.expectedStates, states)
+			}
+			if testCase.verify != nil {
+				testCase.verify(subtest, executor.recordedDetails)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package githubcli
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubauth"
+)
+
+const (
+	repositorySettingsJSONFieldsConstant       = "description,repositoryTopics"
+	addTopicFlagConstant                       = "--add-topic"
+	removeTopicFlagConstant                    = "--remove-topic"
+	descriptionFlagConstant                    = "--description"
+	repositoryEditFieldNameConstant            = "edit"
+	repositoryEditNoChangesMessageConstant     = "no changes requested"
+	getRepositorySettingsOperationNameConstant = OperationName("GetRepositorySettings")
+	editRepositoryOperationNameConstant        = OperationName("EditRepository")
+)
+
+// RepositorySettings captures the editable descriptive settings of a repository.
+type RepositorySettings struct {
+	Description string
+	Topics      []string
+}
+
+// RepositoryEditOptions describes the changes applied by EditRepository.
+// The description is only changed when UpdateDescription is set, so it can be cleared with an empty value.
+type RepositoryEditOptions struct {
+	AddTopics         []string
+	RemoveTopics      []string
+	Description       string
+	UpdateDescription bool
+}
+
+// GetRepositorySettings retrieves the repository description and topics using gh repo view.
+func (client *Client) GetRepositorySettings(executionContext context.Context, repository string) (RepositorySettings, error) {
+	repositoryIdentifier := strings.TrimSpace(repository)
+	if len(repositoryIdentifier) == 0 {
+		return RepositorySettings{}, InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments: []string{
+			repoSubcommandConstant,
+			viewSubcommandConstant,
+			repositoryIdentifier,
+			jsonFlagConstant,
+			repositorySettingsJSONFieldsConstant,
+		},
+		GitHubTokenRequirement: githubauth.TokenRequired,
+		Idempotent:             true,
+	}
+
+	executionResult, executionError := client.executeCachedRead(executionContext, commandDetails)
+	if executionError != nil {
+		return RepositorySettings{}, OperationError{Operation: getRepositorySettingsOperationNameConstant, Cause: executionError}
+	}
+
+	var response struct {
+		Description      string `json:"description"`
+		RepositoryTopics []struct {
+			Name string `json:"name"`
+		} `json:"repositoryTopics"`
+	}
+
+	decodingError := json.Unmarshal([]byte(executionResult.StandardOutput), &response)
+	if decodingError != nil {
+		return RepositorySettings{}, ResponseDecodingError{Operation: getRepositorySettingsOperationNameConstant, Cause: decodingError}
+	}
+
+	topics := make([]string, 0, len(response.RepositoryTopics))
+	for _, topic := range response.RepositoryTopics {
+		if trimmedTopic := strings.TrimSpace(topic.Name); len(trimmedTopic) > 0 {
+			topics = append(topics, trimmedTopic)
+		}
+	}
+
+	return RepositorySettings{Description: response.Description, Topics: topics}, nil
+}
+
+// EditRepository adds and removes topics and updates the description using gh repo edit.
+func (client *Client) EditRepository(executionContext context.Context, repository string, options RepositoryEditOptions) error {
+	repositoryIdentifier := strings.TrimSpace(repository)
+	if len(repositoryIdentifier) == 0 {
+		return InvalidInputError{FieldName: repositoryFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	arguments := []string{repoSubcommandConstant, editSubcommandConstant, repositoryIdentifier}
+	baseArgumentCount := len(arguments)
+	for _, topic := range options.AddTopics {
+		if trimmedTopic := strings.TrimSpace(topic); len(trimmedTopic) > 0 {
+			arguments = append(arguments, addTopicFlagConstant, trimmedTopic)
+		}
+	}
+	for _, topic := range options.RemoveTopics {
+		if trimmedTopic := strings.TrimSpace(topic); len(trimmedTopic) > 0 {
+			arguments = append(arguments, removeTopicFlagConstant, trimmedTopic)
+		}
+	}
+	if options.UpdateDescription {
+		arguments = append(arguments, descriptionFlagConstant, options.Description)
+	}
+	if len(arguments) == baseArgumentCount {
+		return InvalidInputError{FieldName: repositoryEditFieldNameConstant, Message: repositoryEditNoChangesMessageConstant}
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments:              arguments,
+		GitHubTokenRequirement: githubauth.TokenRequired,
+		Idempotent:             false,
+	}
+
+	_, executionError := client.executeMutation(executionContext, commandDetails)
+	if executionError != nil {
+		return OperationError{Operation: editRepositoryOperationNameConstant, Cause: executionError}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package githubcli_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const testRepositorySettingsResponseConstant = `{"description":"Example tools","repositoryTopics":[{"name":"go"},{"name":"cli"}]}`
+
+func TestGetRepositorySettings(testInstance *testing.T) {
+	testCases := []struct {
+		name             string
+		repository       string
+		executeFunc      func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error)
+		expectedSettings githubcli.RepositorySettings
+		expectedError    bool
+	}{
+		{
+			name:       "settings_returned",
+			repository: "owner/example",
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: testRepositorySettingsResponseConstant}, nil
+			},
+			expectedSettings: githubcli.RepositorySettings{Description: "Example tools", Topics: []string{"go", "cli"}},
+		},
+		{
+			name:       "no_topics",
+			repository: "owner/example",
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: `{"description":"","repositoryTopics":null}`}, nil
+			},
+			expectedSettings: githubcli.RepositorySettings{Topics: []string{}},
+		},
+		{
+			name:          "missing_repository",
+			repository:    " ",
+			expectedError: true,
+		},
+		{
+			name:       "command_failure",
+			repository: "owner/example",
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, errors.New("view failure")
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubGitHubExecutor{executeFunc: testCase.executeFunc}
+			client, clientError := githubcli.NewClient(executor)
+			require.NoError(subtest, clientError)
+
+			settings, settingsError := client.GetRepositorySettings(context.Background(), testCase.repository)
+			if testCase.expectedError {
+				require.Error(subtest, settingsError)
+				return
+			}
+			require.NoError(subtest, settingsError)
+			require.Equal(subtest, testCase.expectedSettings, settings)
+			require.Len(subtest, executor.recordedDetails, 1)
+			require.Equal(subtest, []string{"repo", "view", "owner/example", "--json", "description,repositoryTopics"}, executor.recordedDetails[0].Arguments)
+		})
+	}
+}
+
+func TestEditRepository(testInstance *testing.T) {
+	testCases := []struct {
+		name              string
+		repository        string
+		options           githubcli.RepositoryEditOptions
+		executeFunc       func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error)
+		expectedArguments []string
+		expectedError     bool
+	}{
+		{
+			name:       "topics_and_description",
+			repository: "owner/example",
+			options: githubcli.RepositoryEditOptions{
+				AddTopics:         []string{"go", " "},
+				RemoveTopics:      []string{"legacy"},
+				Description:       "Example tools",
+				UpdateDescription: true,
+			},
+			expectedArguments: []string{"repo", "edit", "owner/example", "--add-topic", "go", "--remove-topic", "legacy", "--description", "Example tools"},
+		},
+		{
+			name:              "clear_description",
+			repository:        "owner/example",
+			options:           githubcli.RepositoryEditOptions{UpdateDescription: true},
+			expectedArguments: []string{"repo", "edit", "owner/example", "--description", ""},
+		},
+		{
+			name:          "no_changes",
+			repository:    "owner/example",
+			options:       githubcli.RepositoryEditOptions{AddTopics: []string{" "}},
+			expectedError: true,
+		},
+		{
+			name:          "missing_repository",
+			repository:    "",
+			options:       githubcli.RepositoryEditOptions{AddTopics: []string{"go"}},
+			expectedError: true,
+		},
+		{
+			name:       "command_failure",
+			repository: "owner/example",
+			options:    githubcli.RepositoryEditOptions{AddTopics: []string{"go"}},
+			executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, errors.New("edit failure")
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubGitHubExecutor{executeFunc: testCase.executeFunc}
+			client, clientError := githubcli.NewClient(executor)
+			require.NoError(subtest, clientError)
+
+			editError := client.EditRepository(context.Background(), testCase.repository, testCase.options)
+			if testCase.expectedError {
+				require.Error(subtest, editError)
+				return
+			}
+			require.NoError(subtest, editError)
+			require.Len(subtest, executor.recordedDetails, 1)
+			require.Equal(subtest, testCase.expectedArguments, executor.recordedDetails[0].Arguments)
+		})
+	}
+}
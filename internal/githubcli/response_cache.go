@@ -0,0 +1,182 @@
+package githubcli
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	// DefaultResponseCacheTimeToLive bounds how long a cached read is reused within one run.
+	DefaultResponseCacheTimeToLive       = 5 * time.Minute
+	responseCacheKeySeparatorConstant    = "\x1f"
+	responseCacheHitLogMessageConstant   = "GitHub CLI response served from cache"
+	responseCacheFreshLogMessageConstant = "GitHub CLI response cache bypassed for a fresh read"
+	responseCacheCommandLogFieldConstant = "command"
+	responseCacheHitsLogFieldConstant    = "cache_hits"
+	responseCacheMissesLogFieldConstant  = "cache_misses"
+)
+
+type freshResponsesContextKey struct{}
+
+// WithFreshResponses marks reads made with the returned context to bypass the response cache, for callers that re-read
+// state after mutating it. The fresh responses still replace the cached ones.
+func WithFreshResponses(executionContext context.Context) context.Context {
+	if executionContext == nil {
+		executionContext = context.Background()
+	}
+	return context.WithValue(executionContext, freshResponsesContextKey{}, true)
+}
+
+func freshResponsesRequested(executionContext context.Context) bool {
+	if executionContext == nil {
+		return false
+	}
+	fresh, _ := executionContext.Value(freshResponsesContextKey{}).(bool)
+	return fresh
+}
+
+// ResponseCacheConfiguration tunes the in-memory cache of idempotent gh reads.
+type ResponseCacheConfiguration struct {
+	// TimeToLive bounds the reuse of a cached response; zero keeps DefaultResponseCacheTimeToLive and a negative value
+	// disables the cache.
+	TimeToLive time.Duration
+	// Logger receives debug events with the running hit and miss counts.
+	Logger *zap.Logger
+}
+
+// ResponseCacheStatistics counts the reads the cache answered and the reads that reached gh.
+type ResponseCacheStatistics struct {
+	Hits   int
+	Misses int
+}
+
+type cachedResponse struct {
+	result   execshell.ExecutionResult
+	storedAt time.Time
+}
+
+// responseCache keeps successful gh read results keyed by their normalized arguments. Failures are never cached, and
+// every mutation issued through the client empties the cache so later reads observe it.
+type responseCache struct {
+	mutex      sync.Mutex
+	timeToLive time.Duration
+	logger     *zap.Logger
+	now        func() time.Time
+	entries    map[string]cachedResponse
+	statistics ResponseCacheStatistics
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{
+		timeToLive: DefaultResponseCacheTimeToLive,
+		logger:     zap.NewNop(),
+		now:        time.Now,
+		entries:    map[string]cachedResponse{},
+	}
+}
+
+// ConfigureResponseCache replaces the time to live and logger of the client's response cache.
+func (client *Client) ConfigureResponseCache(configuration ResponseCacheConfiguration) {
+	if client.responses == nil {
+		client.responses = newResponseCache()
+	}
+	client.responses.mutex.Lock()
+	defer client.responses.mutex.Unlock()
+
+	client.responses.timeToLive = DefaultResponseCacheTimeToLive
+	if configuration.TimeToLive != 0 {
+		client.responses.timeToLive = configuration.TimeToLive
+	}
+	if configuration.Logger != nil {
+		client.responses.logger = configuration.Logger
+	}
+	client.responses.entries = map[string]cachedResponse{}
+}
+
+// ResponseCacheStatistics reports how many reads the response cache answered so far.
+func (client *Client) ResponseCacheStatistics() ResponseCacheStatistics {
+	if client.responses == nil {
+		return ResponseCacheStatistics{}
+	}
+	client.responses.mutex.Lock()
+	defer client.responses.mutex.Unlock()
+
+	return client.responses.statistics
+}
+
+// executeCachedRead runs an idempotent gh read, answering repeated calls with the same arguments from the cache.
+func (client *Client) executeCachedRead(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	cache := client.responses
+	if cache == nil {
+		return client.runGitHubCLI(executionContext, details)
+	}
+	cacheKey := responseCacheKey(details)
+
+	cache.mutex.Lock()
+	enabled := cache.timeToLive > 0
+	if enabled && freshResponsesRequested(executionContext) {
+		cache.logger.Debug(responseCacheFreshLogMessageConstant, zap.String(responseCacheCommandLogFieldConstant, strings.Join(details.Arguments, " ")))
+	} else if entry, cached := cache.entries[cacheKey]; enabled && cached && cache.now().Sub(entry.storedAt) < cache.timeToLive {
+		cache.statistics.Hits++
+		cache.logger.Debug(responseCacheHitLogMessageConstant,
+			zap.String(responseCacheCommandLogFieldConstant, strings.Join(details.Arguments, " ")),
+			zap.Int(responseCacheHitsLogFieldConstant, cache.statistics.Hits),
+			zap.Int(responseCacheMissesLogFieldConstant, cache.statistics.Misses),
+		)
+		cache.mutex.Unlock()
+		return entry.result, nil
+	}
+	if enabled {
+		cache.statistics.Misses++
+	}
+	cache.mutex.Unlock()
+
+	executionResult, executionError := client.runGitHubCLI(executionContext, details)
+	if executionError != nil || !enabled {
+		return executionResult, executionError
+	}
+
+	cache.mutex.Lock()
+	cache.entries[cacheKey] = cachedResponse{result: executionResult, storedAt: cache.now()}
+	cache.mutex.Unlock()
+	return executionResult, nil
+}
+
+// executeMutation runs a gh command that changes GitHub state and drops every cached read it may have invalidated.
+// Mutations fail fast with ErrReadOnlyToken, without reaching gh, once the preflight found the token read-only.
+func (client *Client) executeMutation(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	if client.TokenAccess() == TokenAccessReadOnly {
+		return execshell.ExecutionResult{}, ErrReadOnlyToken
+	}
+	if client.responses != nil {
+		client.responses.mutex.Lock()
+		client.responses.entries = map[string]cachedResponse{}
+		client.responses.mutex.Unlock()
+	}
+
+	return client.runGitHubCLI(executionContext, details)
+}
+
+// runGitHubCLI executes a gh command and wraps a failure in a GitHubCommandError carrying the parsed HTTP status.
+func (client *Client) runGitHubCLI(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	executionResult, executionError := client.executor.ExecuteGitHubCLI(executionContext, details)
+	if executionError != nil {
+		return executionResult, classifyCommandError(executionError)
+	}
+	return executionResult, execshell.RequireCompleteOutput(execshell.CommandGitHub, details, executionResult)
+}
+
+func responseCacheKey(details execshell.CommandDetails) string {
+	normalizedArguments := make([]string, 0, len(details.Arguments)+1)
+	normalizedArguments = append(normalizedArguments, strings.TrimSpace(details.WorkingDirectory))
+	for _, argument := range details.Arguments {
+		normalizedArguments = append(normalizedArguments, strings.ToLower(strings.TrimSpace(argument)))
+	}
+	return strings.Join(normalizedArguments, responseCacheKeySeparatorConstant)
+}
@@ -0,0 +1,141 @@
+package githubcli_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const (
+	responseCacheRepositoryConstant     = "owner/example"
+	responseCacheMetadataConstant       = `{"nameWithOwner":"owner/example","defaultBranchRef":{"name":"main"}}`
+	responseCacheHitLogMessageConstant  = "GitHub CLI response served from cache"
+	responseCacheHitsLogFieldConstant   = "cache_hits"
+	responseCacheFailureMessageConstant = "transient failure"
+)
+
+func TestClientResponseCache(testInstance *testing.T) {
+	testCases := []struct {
+		name               string
+		timeToLive         time.Duration
+		failFirstCall      bool
+		calls              func(context.Context, *githubcli.Client) error
+		expectedExecutions int
+		expectedStatistics githubcli.ResponseCacheStatistics
+	}{
+		{
+			name: "repeated_reads_served_from_cache",
+			calls: func(executionContext context.Context, client *githubcli.Client) error {
+				if _, readError := client.ResolveRepoMetadata(executionContext, responseCacheRepositoryConstant); readError != nil {
+					return readError
+				}
+				_, readError := client.ResolveRepoMetadata(executionContext, "Owner/Example ")
+				return readError
+			},
+			expectedExecutions: 1,
+			expectedStatistics: githubcli.ResponseCacheStatistics{Hits: 1, Misses: 1},
+		},
+		{
+			name: "fresh_reads_bypass_cache",
+			calls: func(executionContext context.Context, client *githubcli.Client) error {
+				if _, readError := client.ResolveRepoMetadata(executionContext, responseCacheRepositoryConstant); readError != nil {
+					return readError
+				}
+				_, readError := client.ResolveRepoMetadata(githubcli.WithFreshResponses(executionContext), responseCacheRepositoryConstant)
+				return readError
+			},
+			expectedExecutions: 2,
+			expectedStatistics: githubcli.ResponseCacheStatistics{Misses: 2},
+		},
+		{
+			name: "mutations_invalidate_cached_reads",
+			calls: func(executionContext context.Context, client *githubcli.Client) error {
+				if _, readError := client.ResolveRepoMetadata(executionContext, responseCacheRepositoryConstant); readError != nil {
+					return readError
+				}
+				if mutationError := client.SetDefaultBranch(executionContext, responseCacheRepositoryConstant, "main"); mutationError != nil {
+					return mutationError
+				}
+				_, readError := client.ResolveRepoMetadata(executionContext, responseCacheRepositoryConstant)
+				return readError
+			},
+			expectedExecutions: 3,
+			expectedStatistics: githubcli.ResponseCacheStatistics{Misses: 2},
+		},
+		{
+			name:          "failures_are_not_cached",
+			failFirstCall: true,
+			calls: func(executionContext context.Context, client *githubcli.Client) error {
+				if _, readError := client.GetPagesConfig(executionContext, responseCacheRepositoryConstant); readError == nil {
+					return errors.New("expected the first read to fail")
+				}
+				_, readError := client.GetPagesConfig(executionContext, responseCacheRepositoryConstant)
+				return readError
+			},
+			expectedExecutions: 2,
+			expectedStatistics: githubcli.ResponseCacheStatistics{Misses: 2},
+		},
+		{
+			name:       "expired_entries_are_refetched",
+			timeToLive: time.Nanosecond,
+			calls: func(executionContext context.Context, client *githubcli.Client) error {
+				if _, readError := client.ResolveRepoMetadata(executionContext, responseCacheRepositoryConstant); readError != nil {
+					return readError
+				}
+				time.Sleep(time.Millisecond)
+				_, readError := client.ResolveRepoMetadata(executionContext, responseCacheRepositoryConstant)
+				return readError
+			},
+			expectedExecutions: 2,
+			expectedStatistics: githubcli.ResponseCacheStatistics{Misses: 2},
+		},
+		{
+			name:       "negative_time_to_live_disables_cache",
+			timeToLive: -1,
+			calls: func(executionContext context.Context, client *githubcli.Client) error {
+				if _, readError := client.ResolveRepoMetadata(executionContext, responseCacheRepositoryConstant); readError != nil {
+					return readError
+				}
+				_, readError := client.ResolveRepoMetadata(executionContext, responseCacheRepositoryConstant)
+				return readError
+			},
+			expectedExecutions: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executions := 0
+			executor := &stubGitHubExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				executions++
+				if testCase.failFirstCall && executions == 1 {
+					return execshell.ExecutionResult{}, errors.New(responseCacheFailureMessageConstant)
+				}
+				return execshell.ExecutionResult{StandardOutput: responseCacheMetadataConstant}, nil
+			}}
+			client, clientError := githubcli.NewClient(executor)
+			require.NoError(subtest, clientError)
+
+			logCore, observedLogs := observer.New(zap.DebugLevel)
+			client.ConfigureResponseCache(githubcli.ResponseCacheConfiguration{TimeToLive: testCase.timeToLive, Logger: zap.New(logCore)})
+
+			require.NoError(subtest, testCase.calls(context.Background(), client))
+			require.Equal(subtest, testCase.expectedExecutions, executions)
+			require.Equal(subtest, testCase.expectedStatistics, client.ResponseCacheStatistics())
+
+			hitLogs := observedLogs.FilterMessage(responseCacheHitLogMessageConstant).All()
+			require.Len(subtest, hitLogs, testCase.expectedStatistics.Hits)
+			for hitIndex, hitLog := range hitLogs {
+				require.Equal(subtest, int64(hitIndex+1), hitLog.ContextMap()[responseCacheHitsLogFieldConstant])
+			}
+		})
+	}
+}
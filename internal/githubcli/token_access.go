@@ -0,0 +1,100 @@
+package githubcli
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubauth"
+)
+
+const (
+	authenticatedUserEndpointConstant      = "user"
+	includeHeadersFlagConstant             = "--include"
+	oauthScopesHeaderPrefixConstant        = "x-oauth-scopes:"
+	oauthScopeSeparatorConstant            = ","
+	repositoryScopeConstant                = "repo"
+	publicRepositoryScopeConstant          = "public_repo"
+	readOnlyTokenMessageConstant           = "requires a token with repo write access"
+	detectTokenAccessOperationNameConstant = OperationName("DetectTokenAccess")
+)
+
+// TokenAccess describes what the GitHub token used by the client may do.
+type TokenAccess string
+
+// Token access enumerations.
+const (
+	// TokenAccessUnknown means the preflight did not run or the token does not report its scopes, as fine-grained and
+	// GitHub App tokens do. Mutations are attempted and GitHub decides.
+	TokenAccessUnknown TokenAccess = TokenAccess("unknown")
+	// TokenAccessReadOnly means the token lacks the repo and public_repo scopes, so every mutation fails fast.
+	TokenAccessReadOnly TokenAccess = TokenAccess("read-only")
+	// TokenAccessReadWrite means the token carries the repo or public_repo scope.
+	TokenAccessReadWrite TokenAccess = TokenAccess("read-write")
+)
+
+// ErrReadOnlyToken reports a mutation refused before it was sent because the token is read-only.
+var ErrReadOnlyToken = errors.New(readOnlyTokenMessageConstant)
+
+// DetectTokenAccess runs the auth preflight: it reads the authenticated user with response headers and derives the
+// token access from the X-OAuth-Scopes header of classic tokens. The result is recorded on the client, where every
+// mutating method consults it. Tokens without that header are reported as TokenAccessUnknown.
+func (client *Client) DetectTokenAccess(executionContext context.Context) (TokenAccess, error) {
+	commandDetails := execshell.CommandDetails{
+		Arguments: []string{
+			apiSubcommandConstant,
+			authenticatedUserEndpointConstant,
+			includeHeadersFlagConstant,
+		},
+		GitHubTokenRequirement: githubauth.TokenOptional,
+		Idempotent:             true,
+	}
+
+	executionResult, executionError := client.runGitHubCLI(executionContext, commandDetails)
+	if executionError != nil {
+		return TokenAccessUnknown, OperationError{Operation: detectTokenAccessOperationNameConstant, Cause: executionError}
+	}
+
+	access := parseTokenAccess(executionResult.StandardOutput)
+	client.SetTokenAccess(access)
+	return access, nil
+}
+
+// SetTokenAccess records the token access that mutating methods consult.
+func (client *Client) SetTokenAccess(access TokenAccess) {
+	client.accessMutex.Lock()
+	defer client.accessMutex.Unlock()
+	client.tokenAccess = access
+}
+
+// TokenAccess reports the token access recorded by the last preflight, or TokenAccessUnknown.
+func (client *Client) TokenAccess() TokenAccess {
+	client.accessMutex.Lock()
+	defer client.accessMutex.Unlock()
+	if len(client.tokenAccess) == 0 {
+		return TokenAccessUnknown
+	}
+	return client.tokenAccess
+}
+
+// parseTokenAccess reads the X-OAuth-Scopes header from gh api --include output; headers end at the first blank line.
+func parseTokenAccess(output string) TokenAccess {
+	for _, line := range strings.Split(output, "\n") {
+		trimmedLine := strings.TrimSpace(line)
+		if len(trimmedLine) == 0 {
+			break
+		}
+		if !strings.HasPrefix(strings.ToLower(trimmedLine), oauthScopesHeaderPrefixConstant) {
+			continue
+		}
+		for _, scope := range strings.Split(trimmedLine[len(oauthScopesHeaderPrefixConstant):], oauthScopeSeparatorConstant) {
+			switch strings.TrimSpace(scope) {
+			case repositoryScopeConstant, publicRepositoryScopeConstant:
+				return TokenAccessReadWrite
+			}
+		}
+		return TokenAccessReadOnly
+	}
+	return TokenAccessUnknown
+}
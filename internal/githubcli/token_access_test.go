@@ -0,0 +1,134 @@
+package githubcli_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+func TestDetectTokenAccess(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		output         string
+		executeError   error
+		expectedAccess githubcli.TokenAccess
+		expectedError  bool
+	}{
+		{
+			name:           "repo_scope_is_read_write",
+			output:         "HTTP/2.0 200 OK\r\nX-Oauth-Scopes: read:org, repo\r\n\r\n{\"login\":\"octocat\"}",
+			expectedAccess: githubcli.TokenAccessReadWrite,
+		},
+		{
+			name:           "public_repo_scope_is_read_write",
+			output:         "HTTP/2.0 200 OK\nX-Oauth-Scopes: public_repo\n\n{}",
+			expectedAccess: githubcli.TokenAccessReadWrite,
+		},
+		{
+			name:           "scopes_without_repo_are_read_only",
+			output:         "HTTP/2.0 200 OK\nX-Oauth-Scopes: read:org, read:packages\n\n{}",
+			expectedAccess: githubcli.TokenAccessReadOnly,
+		},
+		{
+			name:           "empty_scopes_are_read_only",
+			output:         "HTTP/2.0 200 OK\nX-Oauth-Scopes: \n\n{}",
+			expectedAccess: githubcli.TokenAccessReadOnly,
+		},
+		{
+			name:           "missing_header_is_unknown",
+			output:         "HTTP/2.0 200 OK\nX-Github-Request-Id: 1\n\n{\"x-oauth-scopes: repo\":true}",
+			expectedAccess: githubcli.TokenAccessUnknown,
+		},
+		{
+			name:           "preflight_failure_is_unknown",
+			executeError:   errors.New("gh: Bad credentials (HTTP 401)"),
+			expectedAccess: githubcli.TokenAccessUnknown,
+			expectedError:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubGitHubExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: testCase.output}, testCase.executeError
+			}}
+			client, clientError := githubcli.NewClient(executor)
+			require.NoError(subtest, clientError)
+
+			access, detectionError := client.DetectTokenAccess(context.Background())
+			if testCase.expectedError {
+				require.Error(subtest, detectionError)
+			} else {
+				require.NoError(subtest, detectionError)
+			}
+			require.Equal(subtest, testCase.expectedAccess, access)
+			require.Equal(subtest, testCase.expectedAccess, client.TokenAccess())
+			require.Len(subtest, executor.recordedDetails, 1)
+			require.Equal(subtest, []string{"api", "user", "--include"}, executor.recordedDetails[0].Arguments)
+		})
+	}
+}
+
+func TestReadOnlyTokenRefusesMutations(testInstance *testing.T) {
+	testCases := []struct {
+		name       string
+		access     githubcli.TokenAccess
+		mutate     func(*githubcli.Client) error
+		expectSent bool
+	}{
+		{
+			name:   "read_only_refuses_default_branch_update",
+			access: githubcli.TokenAccessReadOnly,
+			mutate: func(client *githubcli.Client) error {
+				return client.SetDefaultBranch(context.Background(), "owner/example", "main")
+			},
+		},
+		{
+			name:   "read_only_refuses_pages_update",
+			access: githubcli.TokenAccessReadOnly,
+			mutate: func(client *githubcli.Client) error {
+				return client.UpdatePagesConfig(context.Background(), "owner/example", githubcli.PagesConfiguration{SourceBranch: "main", SourcePath: "/"})
+			},
+		},
+		{
+			name:   "unknown_access_sends_mutation",
+			access: githubcli.TokenAccessUnknown,
+			mutate: func(client *githubcli.Client) error {
+				return client.SetDefaultBranch(context.Background(), "owner/example", "main")
+			},
+			expectSent: true,
+		},
+		{
+			name:   "read_write_sends_mutation",
+			access: githubcli.TokenAccessReadWrite,
+			mutate: func(client *githubcli.Client) error {
+				return client.SetDefaultBranch(context.Background(), "owner/example", "main")
+			},
+			expectSent: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubGitHubExecutor{}
+			client, clientError := githubcli.NewClient(executor)
+			require.NoError(subtest, clientError)
+			client.SetTokenAccess(testCase.access)
+
+			mutationError := testCase.mutate(client)
+			if testCase.expectSent {
+				require.NoError(subtest, mutationError)
+				require.Len(subtest, executor.recordedDetails, 1)
+				return
+			}
+			require.ErrorIs(subtest, mutationError, githubcli.ErrReadOnlyToken)
+			require.Contains(subtest, mutationError.Error(), "requires a token with repo write access")
+			require.Empty(subtest, executor.recordedDetails)
+		})
+	}
+}
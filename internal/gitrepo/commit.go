@@ -0,0 +1,60 @@
+package gitrepo
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	gitCommitSubcommandConstant            = "commit"
+	gitCommitNoVerifyFlagConstant          = "--no-verify"
+	gitCommitMessageFlagConstant           = "-m"
+	automatedCommitOriginPrefixConstant    = "created by gix "
+	automatedCommitOriginSeparatorConstant = "\n\n"
+)
+
+type commitHooksContextKey struct{}
+
+// CommitOptions configures a commit gix creates on the user's behalf.
+type CommitOptions struct {
+	Message string
+	// NoVerify skips pre-commit and commit-msg hooks so repository-local hooks cannot block or rewrite the commit.
+	NoVerify bool
+}
+
+// WithCommitHooks records whether automated commits should run repository hooks. Commits skip hooks unless this is
+// set to true.
+func WithCommitHooks(parentContext context.Context, runHooks bool) context.Context {
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	return context.WithValue(parentContext, commitHooksContextKey{}, runHooks)
+}
+
+// CommitHooksEnabled reports whether automated commits should run repository hooks.
+func CommitHooksEnabled(executionContext context.Context) bool {
+	if executionContext == nil {
+		return false
+	}
+	runHooks, _ := executionContext.Value(commitHooksContextKey{}).(bool)
+	return runHooks
+}
+
+// AutomatedCommitOptions builds options for a commit created by the named gix command. The message gains a trailing
+// paragraph naming the command, and hooks are skipped unless the context enables them.
+func AutomatedCommitOptions(executionContext context.Context, commandName string, message string) CommitOptions {
+	commitMessage := message
+	if trimmedCommand := strings.TrimSpace(commandName); len(trimmedCommand) > 0 {
+		commitMessage = strings.TrimRight(message, "\n") + automatedCommitOriginSeparatorConstant + automatedCommitOriginPrefixConstant + trimmedCommand
+	}
+	return CommitOptions{Message: commitMessage, NoVerify: !CommitHooksEnabled(executionContext)}
+}
+
+// CommitArguments returns the git arguments that create the commit described by the options.
+func CommitArguments(options CommitOptions) []string {
+	arguments := []string{gitCommitSubcommandConstant}
+	if options.NoVerify {
+		arguments = append(arguments, gitCommitNoVerifyFlagConstant)
+	}
+	return append(arguments, gitCommitMessageFlagConstant, options.Message)
+}
@@ -0,0 +1,48 @@
+package gitrepo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+func TestAutomatedCommitArguments(testInstance *testing.T) {
+	testCases := []struct {
+		name              string
+		executionContext  context.Context
+		commandName       string
+		message           string
+		expectedArguments []string
+	}{
+		{
+			name:              "hooks_skipped_by_default",
+			executionContext:  context.Background(),
+			commandName:       "branch default",
+			message:           "CI: switch workflow branch filters to master",
+			expectedArguments: []string{"commit", "--no-verify", "-m", "CI: switch workflow branch filters to master\n\ncreated by gix branch default"},
+		},
+		{
+			name:              "hooks_enabled",
+			executionContext:  gitrepo.WithCommitHooks(context.Background(), true),
+			commandName:       "repo rm",
+			message:           "chore: ignore purged paths\n",
+			expectedArguments: []string{"commit", "-m", "chore: ignore purged paths\n\ncreated by gix repo rm"},
+		},
+		{
+			name:              "hooks_disabled_explicitly",
+			executionContext:  gitrepo.WithCommitHooks(context.Background(), false),
+			message:           "docs: update",
+			expectedArguments: []string{"commit", "--no-verify", "-m", "docs: update"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			options := gitrepo.AutomatedCommitOptions(testCase.executionContext, testCase.commandName, testCase.message)
+			require.Equal(subtest, testCase.expectedArguments, gitrepo.CommitArguments(options))
+		})
+	}
+}
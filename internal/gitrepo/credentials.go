@@ -0,0 +1,88 @@
+package gitrepo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	gitCredentialSubcommandConstant         = "credential"
+	gitCredentialRejectActionConstant       = "reject"
+	credentialRemoteURLFieldNameConstant    = "remote_url"
+	credentialHTTPSSchemeConstant           = "https"
+	credentialRemoteURLMessageConstant      = "must be an https URL with a host"
+	credentialProtocolLineTemplateConstant  = "protocol=%s\n"
+	credentialHostLineTemplateConstant      = "host=%s\n"
+	credentialPathLineTemplateConstant      = "path=%s\n"
+	credentialUsernameLineTemplateConstant  = "username=%s\n"
+	credentialInputTerminatorConstant       = "\n"
+	credentialEscapedNewlineConstant        = `\n`
+	credentialPathSeparatorConstant         = "/"
+	credentialRejectCommandTemplateConstant = "printf '%s' | git credential reject"
+	rejectCredentialsOperationNameConstant  = RepositoryOperationName("RejectRemoteCredentials")
+)
+
+// CredentialRejectCommand renders the shell command that erases the stored HTTPS credentials of the remote URL, for
+// hints printed when gix leaves the erasure to the user. It returns an empty string for URLs that are not HTTPS.
+func CredentialRejectCommand(remoteURL string) string {
+	rejectInput, inputError := credentialRejectInput(remoteURL)
+	if inputError != nil {
+		return ""
+	}
+	return fmt.Sprintf(credentialRejectCommandTemplateConstant, strings.ReplaceAll(rejectInput, credentialInputTerminatorConstant, credentialEscapedNewlineConstant))
+}
+
+// RejectRemoteCredentials asks the configured git credential helpers to forget the HTTPS credentials stored for the
+// remote URL. The request names the URL's path and user, so helpers that key credentials by path or user keep those of
+// other repositories on the same host. Remotes moved to another host otherwise keep offering the old host's
+// credentials, which surfaces as an opaque authentication failure when terminal prompts are disabled.
+func (manager *RepositoryManager) RejectRemoteCredentials(executionContext context.Context, repositoryPath string, remoteURL string) error {
+	trimmedPath := strings.TrimSpace(repositoryPath)
+	if len(trimmedPath) == 0 {
+		return InvalidRepositoryInputError{FieldName: repositoryPathFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	rejectInput, inputError := credentialRejectInput(remoteURL)
+	if inputError != nil {
+		return inputError
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments:        []string{gitCredentialSubcommandConstant, gitCredentialRejectActionConstant},
+		WorkingDirectory: trimmedPath,
+		StandardInput:    []byte(rejectInput),
+		Idempotent:       false,
+	}
+
+	if _, executionError := manager.executor.ExecuteGit(executionContext, commandDetails); executionError != nil {
+		return RepositoryOperationError{Operation: rejectCredentialsOperationNameConstant, Cause: executionError}
+	}
+	return nil
+}
+
+func credentialRejectInput(remoteURL string) (string, error) {
+	trimmedURL := strings.TrimSpace(remoteURL)
+	if len(trimmedURL) == 0 {
+		return "", InvalidRepositoryInputError{FieldName: credentialRemoteURLFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+	parsedURL, parseError := url.Parse(trimmedURL)
+	if parseError != nil || parsedURL.Scheme != credentialHTTPSSchemeConstant || len(parsedURL.Host) == 0 {
+		return "", InvalidRepositoryInputError{FieldName: credentialRemoteURLFieldNameConstant, Message: credentialRemoteURLMessageConstant}
+	}
+
+	var inputBuilder strings.Builder
+	fmt.Fprintf(&inputBuilder, credentialProtocolLineTemplateConstant, parsedURL.Scheme)
+	fmt.Fprintf(&inputBuilder, credentialHostLineTemplateConstant, parsedURL.Host)
+	if repositoryPath := strings.TrimPrefix(parsedURL.Path, credentialPathSeparatorConstant); len(repositoryPath) > 0 {
+		fmt.Fprintf(&inputBuilder, credentialPathLineTemplateConstant, repositoryPath)
+	}
+	if parsedURL.User != nil && len(parsedURL.User.Username()) > 0 {
+		fmt.Fprintf(&inputBuilder, credentialUsernameLineTemplateConstant, parsedURL.User.Username())
+	}
+	inputBuilder.WriteString(credentialInputTerminatorConstant)
+	return inputBuilder.String(), nil
+}
@@ -0,0 +1,72 @@
+package gitrepo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+func TestRejectRemoteCredentials(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		remoteURL     string
+		executionErr  error
+		expectedInput string
+		expectedError any
+	}{
+		{
+			name:          "reject_names_repository_path",
+			remoteURL:     " https://git.example.com/origin/example.git ",
+			expectedInput: "protocol=https\nhost=git.example.com\npath=origin/example.git\n\n",
+		},
+		{
+			name:          "reject_names_user",
+			remoteURL:     "https://deploy@git.example.com:8443/origin/example.git",
+			expectedInput: "protocol=https\nhost=git.example.com:8443\npath=origin/example.git\nusername=deploy\n\n",
+		},
+		{
+			name:          "reject_failure",
+			remoteURL:     "https://git.example.com/origin/example.git",
+			executionErr:  errors.New("failed"),
+			expectedError: gitrepo.RepositoryOperationError{},
+		},
+		{
+			name:          "remote_url_required",
+			expectedError: gitrepo.InvalidRepositoryInputError{},
+		},
+		{
+			name:          "ssh_remote_rejected",
+			remoteURL:     "git@git.example.com:origin/example.git",
+			expectedError: gitrepo.InvalidRepositoryInputError{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubGitExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, testCase.executionErr
+			}}
+			manager, creationError := gitrepo.NewRepositoryManager(executor)
+			require.NoError(subtest, creationError)
+
+			rejectError := manager.RejectRemoteCredentials(context.Background(), testRepositoryPathConstant, testCase.remoteURL)
+			if testCase.expectedError != nil {
+				require.IsType(subtest, testCase.expectedError, rejectError)
+				return
+			}
+			require.NoError(subtest, rejectError)
+			require.Len(subtest, executor.recordedDetails, 1)
+			require.Equal(subtest, []string{"credential", "reject"}, executor.recordedDetails[0].Arguments)
+			require.Equal(subtest, testCase.expectedInput, string(executor.recordedDetails[0].StandardInput))
+			require.Equal(subtest, testRepositoryPathConstant, executor.recordedDetails[0].WorkingDirectory)
+			require.False(subtest, executor.recordedDetails[0].Idempotent)
+		})
+	}
+
+	require.Equal(testInstance, `printf 'protocol=https\nhost=github.example.com\npath=origin/example.git\n\n' | git credential reject`, gitrepo.CredentialRejectCommand("https://github.example.com/origin/example.git"))
+}
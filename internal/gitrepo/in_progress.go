@@ -0,0 +1,80 @@
+package gitrepo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	gitAbsoluteGitDirFlagConstant    = "--absolute-git-dir"
+	inProgressOperationNameConstant  = RepositoryOperationName("InProgressOperation")
+	rebaseMergeDirectoryNameConstant = "rebase-merge"
+	rebaseApplyDirectoryNameConstant = "rebase-apply"
+	mergeHeadFileNameConstant        = "MERGE_HEAD"
+	cherryPickHeadFileNameConstant   = "CHERRY_PICK_HEAD"
+)
+
+// InProgressOperation names an unfinished git operation that leaves the repository in an intermediate state.
+type InProgressOperation string
+
+// Unfinished operations detected from marker files under the git directory.
+const (
+	InProgressOperationNone       InProgressOperation = InProgressOperation("")
+	InProgressOperationRebase     InProgressOperation = InProgressOperation("rebase")
+	InProgressOperationMerge      InProgressOperation = InProgressOperation("merge")
+	InProgressOperationCherryPick InProgressOperation = InProgressOperation("cherry-pick")
+)
+
+var inProgressOperationMarkers = []struct {
+	markerName string
+	operation  InProgressOperation
+}{
+	{markerName: rebaseMergeDirectoryNameConstant, operation: InProgressOperationRebase},
+	{markerName: rebaseApplyDirectoryNameConstant, operation: InProgressOperationRebase},
+	{markerName: mergeHeadFileNameConstant, operation: InProgressOperationMerge},
+	{markerName: cherryPickHeadFileNameConstant, operation: InProgressOperationCherryPick},
+}
+
+// Active reports whether an operation is in progress.
+func (operation InProgressOperation) Active() bool {
+	return operation != InProgressOperationNone
+}
+
+// InProgressOperation detects an unfinished rebase, merge, or cherry-pick by looking for rebase-merge, rebase-apply,
+// MERGE_HEAD, and CHERRY_PICK_HEAD under the repository's git directory. It returns InProgressOperationNone when none exist.
+func (manager *RepositoryManager) InProgressOperation(executionContext context.Context, repositoryPath string) (InProgressOperation, error) {
+	if manager == nil || manager.executor == nil {
+		return InProgressOperationNone, ErrGitExecutorNotConfigured
+	}
+
+	trimmedPath := strings.TrimSpace(repositoryPath)
+	if len(trimmedPath) == 0 {
+		return InProgressOperationNone, InvalidRepositoryInputError{FieldName: repositoryPathFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments:        []string{gitRevParseSubcommandConstant, gitAbsoluteGitDirFlagConstant},
+		WorkingDirectory: trimmedPath,
+		Idempotent:       true,
+	}
+	executionResult, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
+	if executionError != nil {
+		return InProgressOperationNone, RepositoryOperationError{Operation: inProgressOperationNameConstant, Cause: executionError}
+	}
+
+	gitDirectory := strings.TrimSpace(executionResult.StandardOutput)
+	if len(gitDirectory) == 0 {
+		return InProgressOperationNone, nil
+	}
+
+	for _, marker := range inProgressOperationMarkers {
+		if _, statError := os.Stat(filepath.Join(gitDirectory, marker.markerName)); statError == nil {
+			return marker.operation, nil
+		}
+	}
+	return InProgressOperationNone, nil
+}
@@ -0,0 +1,83 @@
+package gitrepo_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+func TestInProgressOperation(testInstance *testing.T) {
+	testCases := []struct {
+		name              string
+		markerDirectories []string
+		markerFiles       []string
+		expectedOperation gitrepo.InProgressOperation
+	}{
+		{
+			name:              "no_operation",
+			expectedOperation: gitrepo.InProgressOperationNone,
+		},
+		{
+			name:              "interactive_rebase",
+			markerDirectories: []string{"rebase-merge"},
+			expectedOperation: gitrepo.InProgressOperationRebase,
+		},
+		{
+			name:              "apply_rebase",
+			markerDirectories: []string{"rebase-apply"},
+			expectedOperation: gitrepo.InProgressOperationRebase,
+		},
+		{
+			name:              "merge",
+			markerFiles:       []string{"MERGE_HEAD"},
+			expectedOperation: gitrepo.InProgressOperationMerge,
+		},
+		{
+			name:              "cherry_pick",
+			markerFiles:       []string{"CHERRY_PICK_HEAD"},
+			expectedOperation: gitrepo.InProgressOperationCherryPick,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			gitDirectory := subtest.TempDir()
+			for _, directoryName := range testCase.markerDirectories {
+				require.NoError(subtest, os.Mkdir(filepath.Join(gitDirectory, directoryName), 0o755))
+			}
+			for _, fileName := range testCase.markerFiles {
+				require.NoError(subtest, os.WriteFile(filepath.Join(gitDirectory, fileName), []byte("abc123\n"), 0o644))
+			}
+
+			executor := &stubGitExecutor{executeFunc: func(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				require.Equal(subtest, []string{"rev-parse", "--absolute-git-dir"}, details.Arguments)
+				return execshell.ExecutionResult{StandardOutput: gitDirectory + "\n"}, nil
+			}}
+			manager, managerError := gitrepo.NewRepositoryManager(executor)
+			require.NoError(subtest, managerError)
+
+			operation, operationError := manager.InProgressOperation(context.Background(), testRepositoryPathConstant)
+			require.NoError(subtest, operationError)
+			require.Equal(subtest, testCase.expectedOperation, operation)
+			require.Equal(subtest, testCase.expectedOperation != gitrepo.InProgressOperationNone, operation.Active())
+		})
+	}
+}
+
+func TestInProgressOperationPropagatesGitErrors(testInstance *testing.T) {
+	executor := &stubGitExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+		return execshell.ExecutionResult{}, errors.New("not a git repository")
+	}}
+	manager, managerError := gitrepo.NewRepositoryManager(executor)
+	require.NoError(testInstance, managerError)
+
+	_, operationError := manager.InProgressOperation(context.Background(), testRepositoryPathConstant)
+	require.ErrorAs(testInstance, operationError, &gitrepo.RepositoryOperationError{})
+}
@@ -0,0 +1,161 @@
+package gitrepo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	// LocalBranchRefPattern selects local branch references for ListRefs.
+	LocalBranchRefPattern = "refs/heads"
+	// RemoteBranchRefPattern selects remote-tracking branch references for ListRefs.
+	RemoteBranchRefPattern = "refs/remotes"
+
+	gitForEachRefSubcommandConstant = "for-each-ref"
+	refFieldSeparatorConstant       = "\x00"
+	refFieldCountConstant           = 8
+	refFormatFlagConstant           = "--format=%(refname)%00%(objectname)%00%(upstream)%00%(upstream:track)%00%(committerdate:iso-strict)%00%(HEAD)%00%(upstream:remotename)%00%(upstream:remoteref)"
+	refHeadMarkerConstant           = "*"
+	refTrackGoneConstant            = "gone"
+	refTrackAheadPrefixConstant     = "ahead "
+	refTrackBehindPrefixConstant    = "behind "
+	refTrackSeparatorConstant       = ","
+	localBranchRefPrefixConstant    = LocalBranchRefPattern + "/"
+	listRefsOperationNameConstant   = RepositoryOperationName("ListRefs")
+	refLineMalformedTemplate        = "malformed for-each-ref line %q"
+	refCommitterDateInvalidTemplate = "invalid committer date %q for %s: %w"
+)
+
+// RefEntry describes one reference reported by git for-each-ref.
+// UpstreamTrack holds git's raw tracking summary, such as "[ahead 1, behind 2]" or "[gone]"; it is empty when the
+// reference has no upstream or matches it. Head is true for the branch checked out in the worktree.
+// UpstreamRemote and UpstreamMergeRef name the remote and the remote reference the branch pulls from.
+type RefEntry struct {
+	Name             string
+	ObjectName       string
+	Upstream         string
+	UpstreamTrack    string
+	CommitterDate    time.Time
+	Head             bool
+	UpstreamRemote   string
+	UpstreamMergeRef string
+}
+
+// BranchName returns the reference name without the refs/heads/ prefix for local branches and the full name otherwise.
+func (entry RefEntry) BranchName() string {
+	return strings.TrimPrefix(entry.Name, localBranchRefPrefixConstant)
+}
+
+// UpstreamBranchName returns the branch name on the upstream remote, or an empty string when no upstream is configured.
+func (entry RefEntry) UpstreamBranchName() string {
+	return strings.TrimPrefix(entry.UpstreamMergeRef, localBranchRefPrefixConstant)
+}
+
+// Ahead returns the number of commits the reference has that its upstream lacks.
+func (entry RefEntry) Ahead() int {
+	return entry.trackCount(refTrackAheadPrefixConstant)
+}
+
+// Behind returns the number of commits the upstream has that the reference lacks.
+func (entry RefEntry) Behind() int {
+	return entry.trackCount(refTrackBehindPrefixConstant)
+}
+
+// UpstreamGone reports whether the configured upstream no longer exists.
+func (entry RefEntry) UpstreamGone() bool {
+	return strings.Trim(entry.UpstreamTrack, "[]") == refTrackGoneConstant
+}
+
+func (entry RefEntry) trackCount(prefix string) int {
+	for _, part := range strings.Split(strings.Trim(entry.UpstreamTrack, "[]"), refTrackSeparatorConstant) {
+		trimmedPart := strings.TrimSpace(part)
+		if !strings.HasPrefix(trimmedPart, prefix) {
+			continue
+		}
+		count, parseError := strconv.Atoi(strings.TrimPrefix(trimmedPart, prefix))
+		if parseError == nil {
+			return count
+		}
+	}
+	return 0
+}
+
+// ListRefs reads the references matching patterns with a single git for-each-ref invocation.
+// Patterns follow for-each-ref semantics, so LocalBranchRefPattern lists every local branch; no patterns list every reference.
+func (manager *RepositoryManager) ListRefs(executionContext context.Context, repositoryPath string, patterns ...string) ([]RefEntry, error) {
+	if manager == nil || manager.executor == nil {
+		return nil, ErrGitExecutorNotConfigured
+	}
+
+	trimmedPath := strings.TrimSpace(repositoryPath)
+	if len(trimmedPath) == 0 {
+		return nil, InvalidRepositoryInputError{FieldName: repositoryPathFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	arguments := append([]string{gitForEachRefSubcommandConstant, refFormatFlagConstant}, patterns...)
+	commandDetails := execshell.CommandDetails{
+		Arguments:          arguments,
+		WorkingDirectory:   trimmedPath,
+		OutputCaptureLimit: execshell.UnlimitedOutputCapture,
+		Idempotent:         true,
+	}
+
+	executionResult, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
+	if executionError != nil {
+		return nil, RepositoryOperationError{Operation: listRefsOperationNameConstant, Cause: executionError}
+	}
+	if truncatedError := execshell.RequireCompleteOutput(execshell.CommandGit, commandDetails, executionResult); truncatedError != nil {
+		return nil, RepositoryOperationError{Operation: listRefsOperationNameConstant, Cause: truncatedError}
+	}
+
+	entries, parseError := ParseRefEntries(executionResult.StandardOutput)
+	if parseError != nil {
+		return nil, RepositoryOperationError{Operation: listRefsOperationNameConstant, Cause: parseError}
+	}
+	return entries, nil
+}
+
+// ParseRefEntries parses output produced by the ListRefs for-each-ref format.
+func ParseRefEntries(output string) ([]RefEntry, error) {
+	entries := make([]RefEntry, 0)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		fields := strings.Split(line, refFieldSeparatorConstant)
+		if len(fields) != refFieldCountConstant {
+			return nil, fmt.Errorf(refLineMalformedTemplate, line)
+		}
+
+		entry := RefEntry{
+			Name:             fields[0],
+			ObjectName:       fields[1],
+			Upstream:         fields[2],
+			UpstreamTrack:    fields[3],
+			Head:             strings.TrimSpace(fields[5]) == refHeadMarkerConstant,
+			UpstreamRemote:   fields[6],
+			UpstreamMergeRef: fields[7],
+		}
+		if trimmedDate := strings.TrimSpace(fields[4]); len(trimmedDate) > 0 {
+			committerDate, dateError := time.Parse(time.RFC3339, trimmedDate)
+			if dateError != nil {
+				return nil, fmt.Errorf(refCommitterDateInvalidTemplate, trimmedDate, entry.Name, dateError)
+			}
+			entry.CommitterDate = committerDate
+		}
+		entries = append(entries, entry)
+	}
+
+	if scanError := scanner.Err(); scanError != nil {
+		return nil, scanError
+	}
+	return entries, nil
+}
@@ -0,0 +1,100 @@
+package gitrepo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const refListOutputConstant = "refs/heads/main\x00aaa111\x00refs/remotes/origin/main\x00\x002024-05-06T07:08:09+02:00\x00*\x00origin\x00refs/heads/main\n" +
+	"refs/heads/feature/x\x00bbb222\x00refs/remotes/upstream/feature/x\x00[ahead 2, behind 3]\x002024-05-01T00:00:00Z\x00 \x00upstream\x00refs/heads/feature/x\n" +
+	"refs/heads/stale\x00ccc333\x00refs/remotes/origin/stale\x00[gone]\x002024-01-01T00:00:00Z\x00 \x00origin\x00refs/heads/stale\n" +
+	"refs/heads/local-only\x00ddd444\x00\x00\x002024-02-01T00:00:00Z\x00 \x00\x00\n"
+
+func TestListRefs(testInstance *testing.T) {
+	executionCount := 0
+	executor := &stubGitExecutor{executeFunc: func(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+		executionCount++
+		require.Equal(testInstance, []string{
+			"for-each-ref",
+			"--format=%(refname)%00%(objectname)%00%(upstream)%00%(upstream:track)%00%(committerdate:iso-strict)%00%(HEAD)%00%(upstream:remotename)%00%(upstream:remoteref)",
+			gitrepo.LocalBranchRefPattern,
+		}, details.Arguments)
+		require.Equal(testInstance, testRepositoryPathConstant, details.WorkingDirectory)
+		return execshell.ExecutionResult{StandardOutput: refListOutputConstant}, nil
+	}}
+	manager, managerError := gitrepo.NewRepositoryManager(executor)
+	require.NoError(testInstance, managerError)
+
+	entries, listError := manager.ListRefs(context.Background(), testRepositoryPathConstant, gitrepo.LocalBranchRefPattern)
+	require.NoError(testInstance, listError)
+	require.Equal(testInstance, 1, executionCount)
+	require.Len(testInstance, entries, 4)
+
+	testCases := []struct {
+		name           string
+		entry          gitrepo.RefEntry
+		expectedBranch string
+		expectedHead   bool
+		expectedAhead  int
+		expectedBehind int
+		expectedGone   bool
+		expectedDate   time.Time
+		expectedRemote string
+		expectedMerge  string
+	}{
+		{name: "current_in_sync", entry: entries[0], expectedBranch: "main", expectedHead: true, expectedDate: time.Date(2024, 5, 6, 5, 8, 9, 0, time.UTC), expectedRemote: "origin", expectedMerge: "main"},
+		{name: "diverged", entry: entries[1], expectedBranch: "feature/x", expectedAhead: 2, expectedBehind: 3, expectedDate: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), expectedRemote: "upstream", expectedMerge: "feature/x"},
+		{name: "upstream_gone", entry: entries[2], expectedBranch: "stale", expectedGone: true, expectedDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), expectedRemote: "origin", expectedMerge: "stale"},
+		{name: "no_upstream", entry: entries[3], expectedBranch: "local-only", expectedDate: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			require.Equal(subtest, testCase.expectedBranch, testCase.entry.BranchName())
+			require.Equal(subtest, testCase.expectedHead, testCase.entry.Head)
+			require.Equal(subtest, testCase.expectedAhead, testCase.entry.Ahead())
+			require.Equal(subtest, testCase.expectedBehind, testCase.entry.Behind())
+			require.Equal(subtest, testCase.expectedGone, testCase.entry.UpstreamGone())
+			require.True(subtest, testCase.expectedDate.Equal(testCase.entry.CommitterDate))
+			require.Equal(subtest, testCase.expectedRemote, testCase.entry.UpstreamRemote)
+			require.Equal(subtest, testCase.expectedMerge, testCase.entry.UpstreamBranchName())
+		})
+	}
+}
+
+func TestListRefsErrors(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		output        string
+		truncated     bool
+		executionErr  error
+		expectedError string
+	}{
+		{name: "git_failure", executionErr: errors.New("not a git repository"), expectedError: "ListRefs operation failed: not a git repository"},
+		{name: "malformed_line", output: "refs/heads/main\x00aaa\n", expectedError: "ListRefs operation failed: malformed for-each-ref line \"refs/heads/main\\x00aaa\""},
+		{name: "truncated_output", output: "refs/heads/main\n[truncated 9 bytes]", truncated: true, expectedError: "ListRefs operation failed: git for-each-ref --format=%(refname)%00%(objectname)%00%(upstream)%00%(upstream:track)%00%(committerdate:iso-strict)%00%(HEAD)%00%(upstream:remotename)%00%(upstream:remoteref) output exceeded the capture limit and was truncated"},
+		{name: "invalid_date", output: "refs/heads/main\x00aaa\x00\x00\x00yesterday\x00*\x00\x00\n", expectedError: "ListRefs operation failed: invalid committer date \"yesterday\" for refs/heads/main"},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubGitExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: testCase.output, Truncated: testCase.truncated}, testCase.executionErr
+			}}
+			manager, managerError := gitrepo.NewRepositoryManager(executor)
+			require.NoError(subtest, managerError)
+
+			_, listError := manager.ListRefs(context.Background(), testRepositoryPathConstant)
+			require.ErrorContains(subtest, listError, testCase.expectedError)
+			var operationError gitrepo.RepositoryOperationError
+			require.ErrorAs(subtest, listError, &operationError)
+		})
+	}
+}
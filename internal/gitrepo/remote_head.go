@@ -0,0 +1,146 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	gitSymbolicRefSubcommandConstant       = "symbolic-ref"
+	gitQuietFlagConstant                   = "--quiet"
+	gitConfigSubcommandConstant            = "config"
+	gitConfigGetFlagConstant               = "--get"
+	gitLSRemoteSubcommandConstant          = "ls-remote"
+	gitSymrefFlagConstant                  = "--symref"
+	remoteHeadReferenceTemplateConstant    = "refs/remotes/%s/HEAD"
+	remoteBranchPrefixTemplateConstant     = "refs/remotes/%s/"
+	remoteHeadConfigKeyTemplateConstant    = "remote.%s.head"
+	refsHeadsPrefixConstant                = "refs/heads/"
+	symrefLinePrefixConstant               = "ref:"
+	remoteHeadOptionsFieldNameConstant     = "options"
+	remoteHeadRefreshOfflineMessage        = "refresh requires network access"
+	remoteHeadUnavailableMessageConstant   = "remote HEAD unavailable"
+	resolveRemoteHeadOperationNameConstant = RepositoryOperationName("ResolveRemoteHead")
+)
+
+// ErrRemoteHeadUnavailable indicates that no tier could determine the remote HEAD branch.
+var ErrRemoteHeadUnavailable = errors.New(remoteHeadUnavailableMessageConstant)
+
+// RemoteHeadSource identifies the tier that produced a remote HEAD resolution.
+type RemoteHeadSource string
+
+// Remote HEAD resolution tiers, in the order they are consulted.
+const (
+	RemoteHeadSourceSymbolicRef RemoteHeadSource = RemoteHeadSource("symbolic-ref")
+	RemoteHeadSourceConfig      RemoteHeadSource = RemoteHeadSource("config")
+	RemoteHeadSourceLSRemote    RemoteHeadSource = RemoteHeadSource("ls-remote")
+)
+
+// Local reports whether the source was read from the clone without contacting the remote.
+func (source RemoteHeadSource) Local() bool {
+	return source == RemoteHeadSourceSymbolicRef || source == RemoteHeadSourceConfig
+}
+
+// RemoteHead describes the branch a remote's HEAD points at and where that answer came from.
+type RemoteHead struct {
+	Branch string
+	Source RemoteHeadSource
+}
+
+// RemoteHeadOptions tunes how ResolveRemoteHead consults its tiers.
+// Refresh skips the local tiers and asks the remote directly; Offline never contacts the remote.
+type RemoteHeadOptions struct {
+	Refresh bool
+	Offline bool
+}
+
+// ResolveRemoteHead determines the remote's default branch, preferring data already present in the clone.
+// It reads refs/remotes/<remote>/HEAD, then remote.<remote>.head, and only runs git ls-remote --symref when neither is set or a refresh is requested.
+func (manager *RepositoryManager) ResolveRemoteHead(executionContext context.Context, repositoryPath string, remoteName string, options RemoteHeadOptions) (RemoteHead, error) {
+	trimmedPath := strings.TrimSpace(repositoryPath)
+	if len(trimmedPath) == 0 {
+		return RemoteHead{}, InvalidRepositoryInputError{FieldName: repositoryPathFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	trimmedRemote := strings.TrimSpace(remoteName)
+	if len(trimmedRemote) == 0 {
+		return RemoteHead{}, InvalidRepositoryInputError{FieldName: remoteNameFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	if options.Refresh && options.Offline {
+		return RemoteHead{}, InvalidRepositoryInputError{FieldName: remoteHeadOptionsFieldNameConstant, Message: remoteHeadRefreshOfflineMessage}
+	}
+
+	if !options.Refresh {
+		if branch := manager.readSymbolicRemoteHead(executionContext, trimmedPath, trimmedRemote); len(branch) > 0 {
+			return RemoteHead{Branch: branch, Source: RemoteHeadSourceSymbolicRef}, nil
+		}
+		if branch := manager.readConfiguredRemoteHead(executionContext, trimmedPath, trimmedRemote); len(branch) > 0 {
+			return RemoteHead{Branch: branch, Source: RemoteHeadSourceConfig}, nil
+		}
+	}
+
+	if options.Offline {
+		return RemoteHead{}, RepositoryOperationError{Operation: resolveRemoteHeadOperationNameConstant, Cause: ErrRemoteHeadUnavailable}
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments:        []string{gitLSRemoteSubcommandConstant, gitSymrefFlagConstant, trimmedRemote, gitHeadReferenceConstant},
+		WorkingDirectory: trimmedPath,
+		Idempotent:       true,
+	}
+	executionResult, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
+	if executionError != nil {
+		return RemoteHead{}, RepositoryOperationError{Operation: resolveRemoteHeadOperationNameConstant, Cause: executionError}
+	}
+
+	branch := parseSymrefHead(executionResult.StandardOutput)
+	if len(branch) == 0 {
+		return RemoteHead{}, RepositoryOperationError{Operation: resolveRemoteHeadOperationNameConstant, Cause: ErrRemoteHeadUnavailable}
+	}
+	return RemoteHead{Branch: branch, Source: RemoteHeadSourceLSRemote}, nil
+}
+
+func (manager *RepositoryManager) readSymbolicRemoteHead(executionContext context.Context, repositoryPath string, remoteName string) string {
+	commandDetails := execshell.CommandDetails{
+		Arguments:        []string{gitSymbolicRefSubcommandConstant, gitQuietFlagConstant, fmt.Sprintf(remoteHeadReferenceTemplateConstant, remoteName)},
+		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
+	}
+	executionResult, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
+	if executionError != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(executionResult.StandardOutput), fmt.Sprintf(remoteBranchPrefixTemplateConstant, remoteName))
+}
+
+func (manager *RepositoryManager) readConfiguredRemoteHead(executionContext context.Context, repositoryPath string, remoteName string) string {
+	commandDetails := execshell.CommandDetails{
+		Arguments:        []string{gitConfigSubcommandConstant, gitConfigGetFlagConstant, fmt.Sprintf(remoteHeadConfigKeyTemplateConstant, remoteName)},
+		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
+	}
+	executionResult, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
+	if executionError != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(executionResult.StandardOutput), refsHeadsPrefixConstant)
+}
+
+func parseSymrefHead(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(line, symrefLinePrefixConstant) {
+			continue
+		}
+		referenceParts := strings.Fields(strings.TrimPrefix(line, symrefLinePrefixConstant))
+		if len(referenceParts) == 0 {
+			continue
+		}
+		return strings.TrimPrefix(referenceParts[0], refsHeadsPrefixConstant)
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package gitrepo_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const (
+	testSymbolicRefCommandConstant = "symbolic-ref --quiet refs/remotes/origin/HEAD"
+	testConfigHeadCommandConstant  = "config --get remote.origin.head"
+	testLSRemoteCommandConstant    = "ls-remote --symref origin HEAD"
+)
+
+func TestResolveRemoteHead(testInstance *testing.T) {
+	testCases := []struct {
+		name             string
+		outputs          map[string]string
+		options          gitrepo.RemoteHeadOptions
+		expectedHead     gitrepo.RemoteHead
+		expectedCommands []string
+		expectedError    error
+	}{
+		{
+			name:             "symbolic_ref",
+			outputs:          map[string]string{testSymbolicRefCommandConstant: "refs/remotes/origin/main\n"},
+			expectedHead:     gitrepo.RemoteHead{Branch: "main", Source: gitrepo.RemoteHeadSourceSymbolicRef},
+			expectedCommands: []string{testSymbolicRefCommandConstant},
+		},
+		{
+			name:             "configured_head",
+			outputs:          map[string]string{testConfigHeadCommandConstant: "refs/heads/trunk\n"},
+			expectedHead:     gitrepo.RemoteHead{Branch: "trunk", Source: gitrepo.RemoteHeadSourceConfig},
+			expectedCommands: []string{testSymbolicRefCommandConstant, testConfigHeadCommandConstant},
+		},
+		{
+			name:             "ls_remote_fallback",
+			outputs:          map[string]string{testLSRemoteCommandConstant: "ref: refs/heads/master\tHEAD\nabc123\tHEAD\n"},
+			expectedHead:     gitrepo.RemoteHead{Branch: "master", Source: gitrepo.RemoteHeadSourceLSRemote},
+			expectedCommands: []string{testSymbolicRefCommandConstant, testConfigHeadCommandConstant, testLSRemoteCommandConstant},
+		},
+		{
+			name: "refresh_skips_local_tiers",
+			outputs: map[string]string{
+				testSymbolicRefCommandConstant: "refs/remotes/origin/main\n",
+				testLSRemoteCommandConstant:    "ref: refs/heads/master\tHEAD\n",
+			},
+			options:          gitrepo.RemoteHeadOptions{Refresh: true},
+			expectedHead:     gitrepo.RemoteHead{Branch: "master", Source: gitrepo.RemoteHeadSourceLSRemote},
+			expectedCommands: []string{testLSRemoteCommandConstant},
+		},
+		{
+			name:             "offline_without_local_data",
+			options:          gitrepo.RemoteHeadOptions{Offline: true},
+			expectedCommands: []string{testSymbolicRefCommandConstant, testConfigHeadCommandConstant},
+			expectedError:    gitrepo.ErrRemoteHeadUnavailable,
+		},
+		{
+			name:             "ls_remote_without_symref",
+			outputs:          map[string]string{testLSRemoteCommandConstant: "abc123\tHEAD\n"},
+			expectedCommands: []string{testSymbolicRefCommandConstant, testConfigHeadCommandConstant, testLSRemoteCommandConstant},
+			expectedError:    gitrepo.ErrRemoteHeadUnavailable,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubGitExecutor{executeFunc: func(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				output, found := testCase.outputs[strings.Join(details.Arguments, " ")]
+				if !found {
+					return execshell.ExecutionResult{}, errors.New("exit status 1")
+				}
+				return execshell.ExecutionResult{StandardOutput: output}, nil
+			}}
+			manager, managerError := gitrepo.NewRepositoryManager(executor)
+			require.NoError(subtest, managerError)
+
+			head, resolveError := manager.ResolveRemoteHead(context.Background(), testRepositoryPathConstant, testRemoteNameConstant, testCase.options)
+
+			recordedCommands := make([]string, 0, len(executor.recordedDetails))
+			for _, details := range executor.recordedDetails {
+				require.Equal(subtest, testRepositoryPathConstant, details.WorkingDirectory)
+				recordedCommands = append(recordedCommands, strings.Join(details.Arguments, " "))
+			}
+			require.Equal(subtest, testCase.expectedCommands, recordedCommands)
+
+			if testCase.expectedError != nil {
+				require.ErrorIs(subtest, resolveError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, resolveError)
+			require.Equal(subtest, testCase.expectedHead, head)
+			require.Equal(subtest, testCase.expectedHead.Source != gitrepo.RemoteHeadSourceLSRemote, head.Source.Local())
+		})
+	}
+}
+
+func TestResolveRemoteHeadRejectsOfflineRefresh(testInstance *testing.T) {
+	manager, managerError := gitrepo.NewRepositoryManager(&stubGitExecutor{})
+	require.NoError(testInstance, managerError)
+
+	_, resolveError := manager.ResolveRemoteHead(context.Background(), testRepositoryPathConstant, testRemoteNameConstant, gitrepo.RemoteHeadOptions{Refresh: true, Offline: true})
+	require.ErrorAs(testInstance, resolveError, &gitrepo.InvalidRepositoryInputError{})
+}
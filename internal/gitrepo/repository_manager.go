@@ -22,6 +22,7 @@ const (
 	gitRemoteSubcommandConstant               = "remote"
 	gitRemoteGetURLSubcommandConstant         = "get-url"
 	gitRemoteSetURLSubcommandConstant         = "set-url"
+	gitRemotePushFlagConstant                 = "--push"
 	repositoryPathFieldNameConstant           = "repository_path"
 	branchNameFieldNameConstant               = "branch_name"
 	startPointFieldNameConstant               = "start_point"
@@ -39,6 +40,8 @@ const (
 	currentBranchOperationNameConstant        = RepositoryOperationName("GetCurrentBranch")
 	getRemoteURLOperationNameConstant         = RepositoryOperationName("GetRemoteURL")
 	setRemoteURLOperationNameConstant         = RepositoryOperationName("SetRemoteURL")
+	getRemotePushURLOperationNameConstant     = RepositoryOperationName("GetRemotePushURL")
+	setRemotePushURLOperationNameConstant     = RepositoryOperationName("SetRemotePushURL")
 )
 
 // GitCommandExecutor exposes the subset of execshell functionality required by RepositoryManager.
@@ -107,6 +110,7 @@ func (manager *RepositoryManager) CheckCleanWorktree(executionContext context.Co
 	commandDetails := execshell.CommandDetails{
 		Arguments:        []string{gitStatusSubcommandConstant, gitStatusPorcelainFlagConstant},
 		WorkingDirectory: trimmedPath,
+		Idempotent:       true,
 	}
 
 	executionResult, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
@@ -133,6 +137,7 @@ func (manager *RepositoryManager) CheckoutBranch(executionContext context.Contex
 	commandDetails := execshell.CommandDetails{
 		Arguments:        []string{gitCheckoutSubcommandConstant, trimmedBranch},
 		WorkingDirectory: trimmedPath,
+		Idempotent:       false,
 	}
 
 	_, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
@@ -163,6 +168,7 @@ func (manager *RepositoryManager) CreateBranch(executionContext context.Context,
 	commandDetails := execshell.CommandDetails{
 		Arguments:        commandArguments,
 		WorkingDirectory: trimmedPath,
+		Idempotent:       false,
 	}
 
 	_, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
@@ -193,6 +199,7 @@ func (manager *RepositoryManager) DeleteBranch(executionContext context.Context,
 	commandDetails := execshell.CommandDetails{
 		Arguments:        commandArguments,
 		WorkingDirectory: trimmedPath,
+		Idempotent:       false,
 	}
 
 	_, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
@@ -212,6 +219,7 @@ func (manager *RepositoryManager) GetCurrentBranch(executionContext context.Cont
 	commandDetails := execshell.CommandDetails{
 		Arguments:        []string{gitRevParseSubcommandConstant, gitAbbrevRefFlagConstant, gitHeadReferenceConstant},
 		WorkingDirectory: trimmedPath,
+		Idempotent:       true,
 	}
 
 	executionResult, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
@@ -237,6 +245,7 @@ func (manager *RepositoryManager) GetRemoteURL(executionContext context.Context,
 	commandDetails := execshell.CommandDetails{
 		Arguments:        []string{gitRemoteSubcommandConstant, gitRemoteGetURLSubcommandConstant, trimmedRemote},
 		WorkingDirectory: trimmedPath,
+		Idempotent:       true,
 	}
 
 	executionResult, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
@@ -267,6 +276,7 @@ func (manager *RepositoryManager) SetRemoteURL(executionContext context.Context,
 	commandDetails := execshell.CommandDetails{
 		Arguments:        []string{gitRemoteSubcommandConstant, gitRemoteSetURLSubcommandConstant, trimmedRemote, trimmedRemoteURL},
 		WorkingDirectory: trimmedPath,
+		Idempotent:       false,
 	}
 
 	_, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
@@ -275,3 +285,59 @@ func (manager *RepositoryManager) SetRemoteURL(executionContext context.Context,
 	}
 	return nil
 }
+
+// GetRemotePushURL returns the URL git pushes to for the given remote. Git reports the fetch URL when no separate push URL is configured.
+func (manager *RepositoryManager) GetRemotePushURL(executionContext context.Context, repositoryPath string, remoteName string) (string, error) {
+	trimmedPath := strings.TrimSpace(repositoryPath)
+	if len(trimmedPath) == 0 {
+		return "", InvalidRepositoryInputError{FieldName: repositoryPathFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	trimmedRemote := strings.TrimSpace(remoteName)
+	if len(trimmedRemote) == 0 {
+		return "", InvalidRepositoryInputError{FieldName: remoteNameFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments:        []string{gitRemoteSubcommandConstant, gitRemoteGetURLSubcommandConstant, gitRemotePushFlagConstant, trimmedRemote},
+		WorkingDirectory: trimmedPath,
+		Idempotent:       true,
+	}
+
+	executionResult, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
+	if executionError != nil {
+		return "", RepositoryOperationError{Operation: getRemotePushURLOperationNameConstant, Cause: executionError}
+	}
+
+	return strings.TrimSpace(executionResult.StandardOutput), nil
+}
+
+// SetRemotePushURL sets the push URL for a remote, leaving its fetch URL unchanged.
+func (manager *RepositoryManager) SetRemotePushURL(executionContext context.Context, repositoryPath string, remoteName string, remoteURL string) error {
+	trimmedPath := strings.TrimSpace(repositoryPath)
+	if len(trimmedPath) == 0 {
+		return InvalidRepositoryInputError{FieldName: repositoryPathFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	trimmedRemote := strings.TrimSpace(remoteName)
+	if len(trimmedRemote) == 0 {
+		return InvalidRepositoryInputError{FieldName: remoteNameFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	trimmedRemoteURL := strings.TrimSpace(remoteURL)
+	if len(trimmedRemoteURL) == 0 {
+		return InvalidRepositoryInputError{FieldName: remoteURLFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments:        []string{gitRemoteSubcommandConstant, gitRemoteSetURLSubcommandConstant, gitRemotePushFlagConstant, trimmedRemote, trimmedRemoteURL},
+		WorkingDirectory: trimmedPath,
+		Idempotent:       false,
+	}
+
+	_, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
+	if executionError != nil {
+		return RepositoryOperationError{Operation: setRemotePushURLOperationNameConstant, Cause: executionError}
+	}
+	return nil
+}
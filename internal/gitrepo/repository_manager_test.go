@@ -404,6 +404,65 @@ func TestSetRemoteURL(testInstance *testing.T) {
 	}
 }
 
+func TestRemotePushURL(testInstance *testing.T) {
+	testCases := []struct {
+		name              string
+		executor          *stubGitExecutor
+		set               bool
+		expectError       bool
+		expected          string
+		expectedArguments []string
+	}{
+		{
+			name: "get_push_url",
+			executor: &stubGitExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: testRemoteURLConstant + "\n"}, nil
+			}},
+			expected:          testRemoteURLConstant,
+			expectedArguments: []string{"remote", "get-url", "--push", testRemoteNameConstant},
+		},
+		{
+			name: "set_push_url",
+			executor: &stubGitExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, nil
+			}},
+			set:               true,
+			expectedArguments: []string{"remote", "set-url", "--push", testRemoteNameConstant, testRemoteURLConstant},
+		},
+		{
+			name: "get_push_url_error",
+			executor: &stubGitExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, errors.New("failed")
+			}},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testInstance *testing.T) {
+			manager, creationError := gitrepo.NewRepositoryManager(testCase.executor)
+			require.NoError(testInstance, creationError)
+
+			var pushURL string
+			var executionError error
+			if testCase.set {
+				executionError = manager.SetRemotePushURL(context.Background(), testRepositoryPathConstant, testRemoteNameConstant, testRemoteURLConstant)
+			} else {
+				pushURL, executionError = manager.GetRemotePushURL(context.Background(), testRepositoryPathConstant, testRemoteNameConstant)
+			}
+			if testCase.expectError {
+				require.Error(testInstance, executionError)
+				require.IsType(testInstance, gitrepo.RepositoryOperationError{}, executionError)
+				return
+			}
+			require.NoError(testInstance, executionError)
+			require.Equal(testInstance, testCase.expected, pushURL)
+			require.Len(testInstance, testCase.executor.recordedDetails, 1)
+			require.Equal(testInstance, testCase.expectedArguments, testCase.executor.recordedDetails[0].Arguments)
+		})
+	}
+}
+
 func TestParseRemoteURL(testInstance *testing.T) {
 	testCases := []struct {
 		name        string
@@ -0,0 +1,67 @@
+package gitrepo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	gitIsShallowRepositoryFlagConstant  = "--is-shallow-repository"
+	gitFetchSubcommandConstant          = "fetch"
+	gitUnshallowFlagConstant            = "--unshallow"
+	gitShallowTrueOutputConstant        = "true"
+	isShallowOperationNameConstant      = RepositoryOperationName("IsShallow")
+	unshallowFetchOperationNameConstant = RepositoryOperationName("UnshallowFetch")
+)
+
+// IsShallow reports whether the repository is a shallow clone whose history was truncated by a --depth clone or fetch.
+// History-based answers such as commit counts and merge bases are unreliable until it is unshallowed.
+func (manager *RepositoryManager) IsShallow(executionContext context.Context, repositoryPath string) (bool, error) {
+	if manager == nil || manager.executor == nil {
+		return false, ErrGitExecutorNotConfigured
+	}
+
+	trimmedPath := strings.TrimSpace(repositoryPath)
+	if len(trimmedPath) == 0 {
+		return false, InvalidRepositoryInputError{FieldName: repositoryPathFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	executionResult, executionError := manager.executor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitRevParseSubcommandConstant, gitIsShallowRepositoryFlagConstant},
+		WorkingDirectory: trimmedPath,
+		Idempotent:       true,
+	})
+	if executionError != nil {
+		return false, RepositoryOperationError{Operation: isShallowOperationNameConstant, Cause: executionError}
+	}
+	return strings.TrimSpace(executionResult.StandardOutput) == gitShallowTrueOutputConstant, nil
+}
+
+// UnshallowFetch fetches the missing history of a shallow clone from the remote with git fetch --unshallow.
+func (manager *RepositoryManager) UnshallowFetch(executionContext context.Context, repositoryPath string, remoteName string) error {
+	if manager == nil || manager.executor == nil {
+		return ErrGitExecutorNotConfigured
+	}
+
+	trimmedPath := strings.TrimSpace(repositoryPath)
+	if len(trimmedPath) == 0 {
+		return InvalidRepositoryInputError{FieldName: repositoryPathFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	trimmedRemote := strings.TrimSpace(remoteName)
+	if len(trimmedRemote) == 0 {
+		return InvalidRepositoryInputError{FieldName: remoteNameFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments:        []string{gitFetchSubcommandConstant, gitUnshallowFlagConstant, trimmedRemote},
+		WorkingDirectory: trimmedPath,
+		Idempotent:       true,
+	}
+	if _, executionError := manager.executor.ExecuteGit(executionContext, commandDetails); executionError != nil {
+		return RepositoryOperationError{Operation: unshallowFetchOperationNameConstant, Cause: executionError}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package gitrepo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+func TestIsShallow(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		output          string
+		executionError  error
+		expectedShallow bool
+		expectError     bool
+	}{
+		{name: "shallow_clone", output: "true\n", expectedShallow: true},
+		{name: "full_clone", output: "false\n"},
+		{name: "git_failure", executionError: errors.New("not a git repository"), expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubGitExecutor{executeFunc: func(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				require.Equal(subtest, []string{"rev-parse", "--is-shallow-repository"}, details.Arguments)
+				require.Equal(subtest, testRepositoryPathConstant, details.WorkingDirectory)
+				return execshell.ExecutionResult{StandardOutput: testCase.output}, testCase.executionError
+			}}
+			manager, managerError := gitrepo.NewRepositoryManager(executor)
+			require.NoError(subtest, managerError)
+
+			shallow, shallowError := manager.IsShallow(context.Background(), testRepositoryPathConstant)
+			if testCase.expectError {
+				require.ErrorAs(subtest, shallowError, &gitrepo.RepositoryOperationError{})
+				return
+			}
+			require.NoError(subtest, shallowError)
+			require.Equal(subtest, testCase.expectedShallow, shallow)
+		})
+	}
+}
+
+func TestUnshallowFetch(testInstance *testing.T) {
+	executor := &stubGitExecutor{}
+	manager, managerError := gitrepo.NewRepositoryManager(executor)
+	require.NoError(testInstance, managerError)
+
+	require.NoError(testInstance, manager.UnshallowFetch(context.Background(), testRepositoryPathConstant, "origin"))
+	require.Len(testInstance, executor.recordedDetails, 1)
+	require.Equal(testInstance, []string{"fetch", "--unshallow", "origin"}, executor.recordedDetails[0].Arguments)
+	require.Equal(testInstance, testRepositoryPathConstant, executor.recordedDetails[0].WorkingDirectory)
+
+	require.ErrorAs(testInstance, manager.UnshallowFetch(context.Background(), testRepositoryPathConstant, " "), &gitrepo.InvalidRepositoryInputError{})
+}
@@ -0,0 +1,218 @@
+package gitrepo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	gitStatusPorcelainVersionTwoFlagConstant = "--porcelain=v2"
+	gitStatusBranchFlagConstant              = "--branch"
+	statusOperationNameConstant              = RepositoryOperationName("Status")
+	statusHeaderPrefixConstant               = "# "
+	statusBranchHeadHeaderConstant           = "branch.head"
+	statusBranchUpstreamHeaderConstant       = "branch.upstream"
+	statusBranchAheadBehindHeaderConstant    = "branch.ab"
+	statusDetachedHeadValueConstant          = "(detached)"
+	statusOrdinaryEntryPrefixConstant        = "1"
+	statusRenamedEntryPrefixConstant         = "2"
+	statusUnmergedEntryPrefixConstant        = "u"
+	statusUntrackedEntryPrefixConstant       = "?"
+	statusUnchangedMarkerConstant            = '.'
+	statusOrdinaryEntryFieldCountConstant    = 9
+	statusRenamedEntryFieldCountConstant     = 10
+	statusUnmergedEntryFieldCountConstant    = 11
+	statusRenamedPathSeparatorConstant       = "\t"
+	statusQuotedPathPrefixConstant           = "\""
+	statusSummarySeparatorConstant           = ", "
+	statusSummaryEntryTemplateConstant       = "%d %s"
+	statusSummaryStagedLabelConstant         = "staged"
+	statusSummaryUnstagedLabelConstant       = "unstaged"
+	statusSummaryUntrackedLabelConstant      = "untracked"
+	statusSummaryConflictedLabelConstant     = "conflicted"
+	statusSummaryCleanConstant               = "clean"
+	statusParseErrorTemplateConstant         = "unable to parse git status output: %w"
+)
+
+// RenamedEntry records a path renamed or copied in the index or worktree.
+type RenamedEntry struct {
+	OriginalPath string
+	Path         string
+}
+
+// StatusReport summarizes `git status --porcelain=v2 --branch` output by change category.
+type StatusReport struct {
+	BranchName     string
+	UpstreamBranch string
+	Detached       bool
+	Ahead          int
+	Behind         int
+	Staged         []string
+	Unstaged       []string
+	Untracked      []string
+	Conflicted     []string
+	Renamed        []RenamedEntry
+}
+
+// Clean reports whether the worktree has no staged, unstaged, untracked, or conflicted paths.
+func (report StatusReport) Clean() bool {
+	return len(report.Staged) == 0 && len(report.Unstaged) == 0 && len(report.Untracked) == 0 && len(report.Conflicted) == 0
+}
+
+// Summary describes the non-empty change categories, e.g. "2 staged, 1 untracked".
+func (report StatusReport) Summary() string {
+	categories := []struct {
+		label string
+		count int
+	}{
+		{label: statusSummaryStagedLabelConstant, count: len(report.Staged)},
+		{label: statusSummaryUnstagedLabelConstant, count: len(report.Unstaged)},
+		{label: statusSummaryUntrackedLabelConstant, count: len(report.Untracked)},
+		{label: statusSummaryConflictedLabelConstant, count: len(report.Conflicted)},
+	}
+
+	parts := make([]string, 0, len(categories))
+	for _, category := range categories {
+		if category.count == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf(statusSummaryEntryTemplateConstant, category.count, category.label))
+	}
+	if len(parts) == 0 {
+		return statusSummaryCleanConstant
+	}
+	return strings.Join(parts, statusSummarySeparatorConstant)
+}
+
+// Status inspects the repository worktree and branch header using porcelain v2 output.
+func (manager *RepositoryManager) Status(executionContext context.Context, repositoryPath string) (StatusReport, error) {
+	trimmedPath := strings.TrimSpace(repositoryPath)
+	if len(trimmedPath) == 0 {
+		return StatusReport{}, InvalidRepositoryInputError{FieldName: repositoryPathFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	commandDetails := execshell.CommandDetails{
+		Arguments:        []string{gitStatusSubcommandConstant, gitStatusPorcelainVersionTwoFlagConstant, gitStatusBranchFlagConstant},
+		WorkingDirectory: trimmedPath,
+		Idempotent:       true,
+	}
+
+	executionResult, executionError := manager.executor.ExecuteGit(executionContext, commandDetails)
+	if executionError != nil {
+		return StatusReport{}, RepositoryOperationError{Operation: statusOperationNameConstant, Cause: executionError}
+	}
+
+	report, parseError := ParseStatusReport(executionResult.StandardOutput)
+	if parseError != nil {
+		return StatusReport{}, RepositoryOperationError{Operation: statusOperationNameConstant, Cause: parseError}
+	}
+	return report, nil
+}
+
+// ParseStatusReport converts `git status --porcelain=v2 --branch` output into a StatusReport.
+func ParseStatusReport(statusOutput string) (StatusReport, error) {
+	report := StatusReport{}
+	scanner := bufio.NewScanner(strings.NewReader(statusOutput))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(line, statusHeaderPrefixConstant) {
+			if headerError := report.applyHeader(strings.TrimPrefix(line, statusHeaderPrefixConstant)); headerError != nil {
+				return StatusReport{}, fmt.Errorf(statusParseErrorTemplateConstant, headerError)
+			}
+			continue
+		}
+
+		entryType, _, _ := strings.Cut(line, " ")
+		switch entryType {
+		case statusOrdinaryEntryPrefixConstant:
+			fields := strings.SplitN(line, " ", statusOrdinaryEntryFieldCountConstant)
+			if len(fields) < statusOrdinaryEntryFieldCountConstant {
+				continue
+			}
+			report.applyChange(fields[1], unquoteStatusPath(fields[statusOrdinaryEntryFieldCountConstant-1]))
+		case statusRenamedEntryPrefixConstant:
+			fields := strings.SplitN(line, " ", statusRenamedEntryFieldCountConstant)
+			if len(fields) < statusRenamedEntryFieldCountConstant {
+				continue
+			}
+			currentPath, originalPath, _ := strings.Cut(fields[statusRenamedEntryFieldCountConstant-1], statusRenamedPathSeparatorConstant)
+			currentPath = unquoteStatusPath(currentPath)
+			report.applyChange(fields[1], currentPath)
+			report.Renamed = append(report.Renamed, RenamedEntry{OriginalPath: unquoteStatusPath(originalPath), Path: currentPath})
+		case statusUnmergedEntryPrefixConstant:
+			fields := strings.SplitN(line, " ", statusUnmergedEntryFieldCountConstant)
+			if len(fields) < statusUnmergedEntryFieldCountConstant {
+				continue
+			}
+			report.Conflicted = append(report.Conflicted, unquoteStatusPath(fields[statusUnmergedEntryFieldCountConstant-1]))
+		case statusUntrackedEntryPrefixConstant:
+			report.Untracked = append(report.Untracked, unquoteStatusPath(strings.TrimPrefix(line, statusUntrackedEntryPrefixConstant+" ")))
+		}
+	}
+
+	if scanError := scanner.Err(); scanError != nil {
+		return StatusReport{}, fmt.Errorf(statusParseErrorTemplateConstant, scanError)
+	}
+
+	return report, nil
+}
+
+func (report *StatusReport) applyHeader(header string) error {
+	headerName, headerValue, _ := strings.Cut(header, " ")
+	headerValue = strings.TrimSpace(headerValue)
+	switch headerName {
+	case statusBranchHeadHeaderConstant:
+		if headerValue == statusDetachedHeadValueConstant {
+			report.Detached = true
+			return nil
+		}
+		report.BranchName = headerValue
+	case statusBranchUpstreamHeaderConstant:
+		report.UpstreamBranch = headerValue
+	case statusBranchAheadBehindHeaderConstant:
+		aheadValue, behindValue, _ := strings.Cut(headerValue, " ")
+		ahead, aheadError := strconv.Atoi(strings.TrimPrefix(aheadValue, "+"))
+		if aheadError != nil {
+			return aheadError
+		}
+		behind, behindError := strconv.Atoi(strings.TrimPrefix(behindValue, "-"))
+		if behindError != nil {
+			return behindError
+		}
+		report.Ahead = ahead
+		report.Behind = behind
+	}
+	return nil
+}
+
+func (report *StatusReport) applyChange(statusCode string, path string) {
+	if len(statusCode) < 2 {
+		return
+	}
+	if statusCode[0] != statusUnchangedMarkerConstant {
+		report.Staged = append(report.Staged, path)
+	}
+	if statusCode[1] != statusUnchangedMarkerConstant {
+		report.Unstaged = append(report.Unstaged, path)
+	}
+}
+
+func unquoteStatusPath(path string) string {
+	if !strings.HasPrefix(path, statusQuotedPathPrefixConstant) {
+		return path
+	}
+	unquoted, unquoteError := strconv.Unquote(path)
+	if unquoteError != nil {
+		return path
+	}
+	return unquoted
+}
@@ -0,0 +1,138 @@
+package gitrepo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const (
+	testStatusCleanOutputConstant = "# branch.oid 1111111111111111111111111111111111111111\n# branch.head main\n# branch.upstream origin/main\n# branch.ab +0 -0\n"
+	testStatusDirtyOutputConstant = "# branch.oid 1111111111111111111111111111111111111111\n" +
+		"# branch.head feature/status\n" +
+		"# branch.upstream origin/feature/status\n" +
+		"# branch.ab +2 -3\n" +
+		"1 M. N... 100644 100644 100644 aaaaaaa bbbbbbb staged.go\n" +
+		"1 .M N... 100644 100644 100644 aaaaaaa aaaaaaa unstaged file.go\n" +
+		"1 MM N... 100644 100644 100644 aaaaaaa bbbbbbb both.go\n" +
+		"2 R. N... 100644 100644 100644 aaaaaaa aaaaaaa R100 renamed.go\toriginal.go\n" +
+		"u UU N... 100644 100644 100644 100644 aaaaaaa bbbbbbb ccccccc conflicted.go\n" +
+		"? \"untracked file.txt\"\n" +
+		"! ignored.log\n"
+	testStatusDetachedOutputConstant = "# branch.oid 1111111111111111111111111111111111111111\n# branch.head (detached)\n"
+)
+
+func TestParseStatusReport(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		output          string
+		expectedReport  gitrepo.StatusReport
+		expectedClean   bool
+		expectedSummary string
+	}{
+		{
+			name:            "clean_tracking_branch",
+			output:          testStatusCleanOutputConstant,
+			expectedReport:  gitrepo.StatusReport{BranchName: "main", UpstreamBranch: "origin/main"},
+			expectedClean:   true,
+			expectedSummary: "clean",
+		},
+		{
+			name:   "categorized_changes",
+			output: testStatusDirtyOutputConstant,
+			expectedReport: gitrepo.StatusReport{
+				BranchName:     "feature/status",
+				UpstreamBranch: "origin/feature/status",
+				Ahead:          2,
+				Behind:         3,
+				Staged:         []string{"staged.go", "both.go", "renamed.go"},
+				Unstaged:       []string{"unstaged file.go", "both.go"},
+				Untracked:      []string{"untracked file.txt"},
+				Conflicted:     []string{"conflicted.go"},
+				Renamed:        []gitrepo.RenamedEntry{{OriginalPath: "original.go", Path: "renamed.go"}},
+			},
+			expectedClean:   false,
+			expectedSummary: "3 staged, 2 unstaged, 1 untracked, 1 conflicted",
+		},
+		{
+			name:            "detached_head",
+			output:          testStatusDetachedOutputConstant,
+			expectedReport:  gitrepo.StatusReport{Detached: true},
+			expectedClean:   true,
+			expectedSummary: "clean",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			report, parseError := gitrepo.ParseStatusReport(testCase.output)
+			require.NoError(subtest, parseError)
+			require.Equal(subtest, testCase.expectedReport, report)
+			require.Equal(subtest, testCase.expectedClean, report.Clean())
+			require.Equal(subtest, testCase.expectedSummary, report.Summary())
+		})
+	}
+}
+
+func TestRepositoryManagerStatus(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		repositoryPath string
+		executor       *stubGitExecutor
+		expectedBranch string
+		expectError    bool
+		errorType      any
+	}{
+		{
+			name:           "status_success",
+			repositoryPath: testRepositoryPathConstant,
+			executor: &stubGitExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{StandardOutput: testStatusCleanOutputConstant}, nil
+			}},
+			expectedBranch: "main",
+		},
+		{
+			name:           "status_error",
+			repositoryPath: testRepositoryPathConstant,
+			executor: &stubGitExecutor{executeFunc: func(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+				return execshell.ExecutionResult{}, errors.New("status failure")
+			}},
+			expectError: true,
+			errorType:   gitrepo.RepositoryOperationError{},
+		},
+		{
+			name:           "status_validation",
+			repositoryPath: "  ",
+			executor:       &stubGitExecutor{},
+			expectError:    true,
+			errorType:      gitrepo.InvalidRepositoryInputError{},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			manager, managerError := gitrepo.NewRepositoryManager(testCase.executor)
+			require.NoError(subtest, managerError)
+
+			report, statusError := manager.Status(context.Background(), testCase.repositoryPath)
+			if testCase.expectError {
+				require.Error(subtest, statusError)
+				require.IsType(subtest, testCase.errorType, statusError)
+				return
+			}
+
+			require.NoError(subtest, statusError)
+			require.Equal(subtest, testCase.expectedBranch, report.BranchName)
+			require.Len(subtest, testCase.executor.recordedDetails, 1)
+			require.Equal(subtest, []string{"status", "--porcelain=v2", "--branch"}, testCase.executor.recordedDetails[0].Arguments)
+			require.Equal(subtest, testCase.repositoryPath, testCase.executor.recordedDetails[0].WorkingDirectory)
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -20,19 +22,56 @@ import (
 )
 
 const (
-	commandUseConstant                  = "branch-default"
-	commandUseTemplateConstant          = commandUseConstant + " <target-branch>"
-	commandShortDescriptionConstant     = "Set the repository default branch"
-	commandLongDescriptionConstant      = "branch-default retargets workflows, updates GitHub configuration, and evaluates safety gates before promoting the requested branch, automatically detecting the current default branch."
-	taskNameTemplateConstant            = "Promote default branch to %s"
-	taskActionBranchDefaultTypeConstant = "branch.default"
-	taskOptionTargetBranchKeyConstant   = "target"
+	commandUseConstant                     = "branch-default"
+	commandUseTemplateConstant             = commandUseConstant + " <target-branch>"
+	commandShortDescriptionConstant        = "Set the repository default branch"
+	commandLongDescriptionConstant         = "branch-default retargets workflows, updates GitHub configuration, and evaluates safety gates before promoting the requested branch, automatically detecting the current default branch."
+	taskNameTemplateConstant               = "Promote default branch to %s"
+	taskActionBranchDefaultTypeConstant    = "branch.default"
+	taskOptionTargetBranchKeyConstant      = "target"
+	taskOptionRetainSourceKeyConstant      = "retain_source"
+	retainSourceFlagNameConstant           = "retain-source"
+	retainSourceFlagDescriptionConstant    = "Retire the previous default branch once safety gates pass: delete it, or archive it as a locked archive/<branch>-<date> ref"
+	retainSourceInvalidTemplateConstant    = "unsupported --retain-source value %q (expected delete or archive)"
+	taskOptionOverridesKeyConstant         = "overrides"
+	taskOptionLeaveTombstoneKeyConstant    = "leave_tombstone"
+	leaveTombstoneFlagNameConstant         = "leave-tombstone"
+	leaveTombstoneFlagDescription          = "Before retiring the previous default branch, push a commit to it adding a BRANCH_MOVED.md notice that names the new default"
+	leaveTombstoneWithoutRetentionError    = "--leave-tombstone requires --retain-source delete or archive"
+	taskOptionWriteInstructionsKeyConstant = "write_instructions"
+	writeInstructionsFlagNameConstant      = "write-instructions"
+	writeInstructionsFlagDescription       = "Write one markdown file per repository with the steps contributors follow to update their clones into this directory"
+	taskOptionUpdateDocsKeyConstant        = "update_docs"
+	taskOptionDocsPatternsKeyConstant      = "docs_patterns"
+	updateDocsFlagNameConstant             = "update-docs"
+	updateDocsFlagDescription              = "Rewrite default-branch references such as /tree/<branch> and branch=<branch> badges in documentation files and commit them with the workflow updates"
+	docsPatternsFlagNameConstant           = "docs-patterns"
+	docsPatternsFlagDescription            = "Comma-separated file globs scanned by --update-docs (default README*,CONTRIBUTING*,docs/**/*.md)"
+	docsPatternsWithoutUpdateError         = "--docs-patterns requires --update-docs"
+	taskOptionPhaseKeyConstant             = "phase"
+	taskOptionGracePeriodKeyConstant       = "grace_period"
+	phaseFlagNameConstant                  = "phase"
+	phaseFlagDescription                   = "Split the migration: announce switches the default branch, retargets pull requests, and records a marker without retiring the source; enforce later verifies the marker and retires it"
+	gracePeriodFlagNameConstant            = "grace-period"
+	gracePeriodFlagDescription             = "Minimum time between announce and enforce, such as 72h or 7d; enforce skips repositories announced more recently"
+	enforceWithoutRetentionError           = "--phase enforce requires --retain-source delete or archive"
+	gracePeriodWithoutEnforceError         = "--grace-period requires --phase enforce"
+	unmatchedOverrideMessageConstant       = "branch-default override matched no discovered repository"
+	overrideKeyLogFieldConstant            = "override"
 )
 
 type commandOptions struct {
-	debugLoggingEnabled bool
-	repositoryRoots     []string
-	targetBranch        migrate.BranchName
+	debugLoggingEnabled   bool
+	repositoryRoots       []string
+	targetBranch          migrate.BranchName
+	retainSource          migrate.SourceRetentionMode
+	leaveTombstone        bool
+	instructionsDirectory string
+	updateDocs            bool
+	docsPatterns          []string
+	phase                 migrate.MigrationPhase
+	gracePeriod           time.Duration
+	overrides             *migrate.RepositoryOverrides
 }
 
 // LoggerProvider supplies a zap logger instance.
@@ -63,6 +102,14 @@ func (builder *CommandBuilder) Build() (*cobra.Command, error) {
 		RunE:          builder.runDefault,
 	}
 
+	command.Flags().String(retainSourceFlagNameConstant, "", flagutils.FormatChoiceUsage("", retainSourceChoices(), retainSourceFlagDescriptionConstant))
+	flagutils.AddToggleFlag(command.Flags(), nil, leaveTombstoneFlagNameConstant, "", false, leaveTombstoneFlagDescription)
+	command.Flags().String(writeInstructionsFlagNameConstant, "", writeInstructionsFlagDescription)
+	flagutils.AddToggleFlag(command.Flags(), nil, updateDocsFlagNameConstant, "", false, updateDocsFlagDescription)
+	command.Flags().StringSlice(docsPatternsFlagNameConstant, nil, docsPatternsFlagDescription)
+	command.Flags().String(phaseFlagNameConstant, "", flagutils.FormatChoiceUsage("", phaseChoices(), phaseFlagDescription))
+	command.Flags().String(gracePeriodFlagNameConstant, "", gracePeriodFlagDescription)
+
 	return command, nil
 }
 
@@ -85,6 +132,11 @@ func (builder *CommandBuilder) runDefault(command *cobra.Command, arguments []st
 	}
 
 	logger := builder.resolveLogger(options.debugLoggingEnabled)
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), struct {
+		Options   commandOptions
+		DryRun    bool
+		AssumeYes bool
+	}{Options: options, DryRun: dryRun, AssumeYes: assumeYes})
 	humanReadable := false
 	if builder.HumanReadableLoggingProvider != nil {
 		humanReadable = builder.HumanReadableLoggingProvider()
@@ -137,6 +189,30 @@ func (builder *CommandBuilder) runDefault(command *cobra.Command, arguments []st
 	actionOptions := map[string]any{
 		taskOptionTargetBranchKeyConstant: string(options.targetBranch),
 	}
+	if len(options.retainSource) > 0 {
+		actionOptions[taskOptionRetainSourceKeyConstant] = string(options.retainSource)
+	}
+	if options.leaveTombstone {
+		actionOptions[taskOptionLeaveTombstoneKeyConstant] = true
+	}
+	if len(options.instructionsDirectory) > 0 {
+		actionOptions[taskOptionWriteInstructionsKeyConstant] = options.instructionsDirectory
+	}
+	if options.updateDocs {
+		actionOptions[taskOptionUpdateDocsKeyConstant] = true
+	}
+	if len(options.docsPatterns) > 0 {
+		actionOptions[taskOptionDocsPatternsKeyConstant] = options.docsPatterns
+	}
+	if len(options.phase) > 0 {
+		actionOptions[taskOptionPhaseKeyConstant] = string(options.phase)
+	}
+	if options.gracePeriod > 0 {
+		actionOptions[taskOptionGracePeriodKeyConstant] = options.gracePeriod.String()
+	}
+	if options.overrides.Len() > 0 {
+		actionOptions[taskOptionOverridesKeyConstant] = options.overrides
+	}
 
 	taskDefinition := workflow.TaskDefinition{
 		Name:        fmt.Sprintf(taskNameTemplateConstant, string(options.targetBranch)),
@@ -149,9 +225,14 @@ func (builder *CommandBuilder) runDefault(command *cobra.Command, arguments []st
 	runtimeOptions := workflow.RuntimeOptions{
 		DryRun:    dryRun,
 		AssumeYes: assumeYes,
+		LockRoots: true,
 	}
 
-	return taskRunner.Run(command.Context(), options.repositoryRoots, []workflow.TaskDefinition{taskDefinition}, runtimeOptions)
+	runError := taskRunner.Run(command.Context(), options.repositoryRoots, []workflow.TaskDefinition{taskDefinition}, runtimeOptions)
+	for _, unmatchedKey := range options.overrides.UnmatchedKeys() {
+		logger.Warn(unmatchedOverrideMessageConstant, zap.String(overrideKeyLogFieldConstant, unmatchedKey))
+	}
+	return runError
 }
 
 func (builder *CommandBuilder) parseOptions(command *cobra.Command, arguments []string) (commandOptions, error) {
@@ -167,14 +248,16 @@ func (builder *CommandBuilder) parseOptions(command *cobra.Command, arguments []
 		}
 	}
 
-	repositoryRoots, resolveRootsError := rootutils.Resolve(command, nil, configuration.RepositoryRoots)
+	repositoryRoots, resolveRootsError := rootutils.ResolveWithoutPositional(command, nil, configuration.RepositoryRoots)
 	if resolveRootsError != nil {
 		return commandOptions{}, resolveRootsError
 	}
 
 	targetBranchName := strings.TrimSpace(configuration.TargetBranch)
+	targetBranchFromArgument := false
 	if len(arguments) > 0 {
 		targetBranchName = strings.TrimSpace(arguments[0])
+		targetBranchFromArgument = len(targetBranchName) > 0
 	}
 
 	if len(targetBranchName) == 0 {
@@ -183,13 +266,146 @@ func (builder *CommandBuilder) parseOptions(command *cobra.Command, arguments []
 
 	targetBranch := migrate.BranchName(targetBranchName)
 
+	retainSourceValue := configuration.RetainSource
+	if command != nil {
+		flagValue, flagChanged, flagError := flagutils.StringFlag(command, retainSourceFlagNameConstant)
+		if flagError != nil && !errors.Is(flagError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, flagError
+		}
+		if flagChanged {
+			retainSourceValue = flagValue
+		}
+	}
+	retainSource, retainSourceError := parseRetainSource(retainSourceValue)
+	if retainSourceError != nil {
+		return commandOptions{}, retainSourceError
+	}
+
+	leaveTombstone := configuration.LeaveTombstone
+	if command != nil {
+		flagValue, flagChanged, flagError := flagutils.BoolFlag(command, leaveTombstoneFlagNameConstant)
+		if flagError != nil && !errors.Is(flagError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, flagError
+		}
+		if flagChanged {
+			leaveTombstone = flagValue
+		}
+	}
+	if leaveTombstone && len(retainSource) == 0 {
+		return commandOptions{}, errors.New(leaveTombstoneWithoutRetentionError)
+	}
+
+	instructionsDirectory := configuration.WriteInstructions
+	if command != nil {
+		flagValue, flagChanged, flagError := flagutils.StringFlag(command, writeInstructionsFlagNameConstant)
+		if flagError != nil && !errors.Is(flagError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, flagError
+		}
+		if flagChanged {
+			instructionsDirectory = strings.TrimSpace(flagValue)
+		}
+	}
+
+	updateDocs := configuration.UpdateDocs
+	docsPatterns := configuration.DocsPatterns
+	if command != nil {
+		updateDocsValue, updateDocsChanged, updateDocsError := flagutils.BoolFlag(command, updateDocsFlagNameConstant)
+		if updateDocsError != nil && !errors.Is(updateDocsError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, updateDocsError
+		}
+		if updateDocsChanged {
+			updateDocs = updateDocsValue
+		}
+		docsPatternsValue, docsPatternsChanged, docsPatternsError := flagutils.StringSliceFlag(command, docsPatternsFlagNameConstant)
+		if docsPatternsError != nil && !errors.Is(docsPatternsError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, docsPatternsError
+		}
+		if docsPatternsChanged {
+			if !updateDocs {
+				return commandOptions{}, errors.New(docsPatternsWithoutUpdateError)
+			}
+			docsPatterns = docsPatternsValue
+		}
+	}
+
+	phaseValue := configuration.Phase
+	gracePeriodValue := configuration.GracePeriod
+	if command != nil {
+		flagValue, flagChanged, flagError := flagutils.StringFlag(command, phaseFlagNameConstant)
+		if flagError != nil && !errors.Is(flagError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, flagError
+		}
+		if flagChanged {
+			phaseValue = flagValue
+		}
+		graceValue, graceChanged, graceError := flagutils.StringFlag(command, gracePeriodFlagNameConstant)
+		if graceError != nil && !errors.Is(graceError, flagutils.ErrFlagNotDefined) {
+			return commandOptions{}, graceError
+		}
+		if graceChanged {
+			gracePeriodValue = graceValue
+		}
+	}
+	phase, phaseError := migrate.ParseMigrationPhase(phaseValue)
+	if phaseError != nil {
+		return commandOptions{}, phaseError
+	}
+	gracePeriod, gracePeriodError := migrate.ParseGracePeriod(gracePeriodValue)
+	if gracePeriodError != nil {
+		return commandOptions{}, gracePeriodError
+	}
+	if phase == migrate.MigrationPhaseEnforce && len(retainSource) == 0 {
+		return commandOptions{}, errors.New(enforceWithoutRetentionError)
+	}
+	if gracePeriod > 0 && phase != migrate.MigrationPhaseEnforce {
+		return commandOptions{}, errors.New(gracePeriodWithoutEnforceError)
+	}
+
+	configuredOverrides := make(map[string]migrate.RepositoryOverride, len(configuration.Overrides))
+	for key, override := range configuration.Overrides {
+		if targetBranchFromArgument {
+			override.TargetBranch = ""
+		}
+		configuredOverrides[key] = override
+	}
+	overrides, overridesError := migrate.NewRepositoryOverrides(configuredOverrides)
+	if overridesError != nil {
+		return commandOptions{}, overridesError
+	}
+
 	return commandOptions{
-		debugLoggingEnabled: debugEnabled,
-		repositoryRoots:     repositoryRoots,
-		targetBranch:        targetBranch,
+		debugLoggingEnabled:   debugEnabled,
+		repositoryRoots:       repositoryRoots,
+		targetBranch:          targetBranch,
+		retainSource:          retainSource,
+		leaveTombstone:        leaveTombstone,
+		instructionsDirectory: instructionsDirectory,
+		updateDocs:            updateDocs,
+		docsPatterns:          docsPatterns,
+		phase:                 phase,
+		gracePeriod:           gracePeriod,
+		overrides:             overrides,
 	}, nil
 }
 
+func retainSourceChoices() []string {
+	return []string{string(migrate.SourceRetentionDelete), string(migrate.SourceRetentionArchive)}
+}
+
+func phaseChoices() []string {
+	return []string{string(migrate.MigrationPhaseAnnounce), string(migrate.MigrationPhaseEnforce)}
+}
+
+func parseRetainSource(rawValue string) (migrate.SourceRetentionMode, error) {
+	normalized := migrate.SourceRetentionMode(strings.ToLower(strings.TrimSpace(rawValue)))
+	switch normalized {
+	case "", migrate.SourceRetentionDelete, migrate.SourceRetentionArchive:
+		return normalized, nil
+	default:
+		return "", fmt.Errorf(retainSourceInvalidTemplateConstant, rawValue)
+	}
+}
+
 func (builder *CommandBuilder) resolveLogger(enableDebug bool) *zap.Logger {
 	var logger *zap.Logger
 	if builder.LoggerProvider != nil {
@@ -2,14 +2,16 @@ package cli_test
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
-	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
 	migrate "github.com/temirov/gix/internal/migrate"
 	"github.com/temirov/gix/internal/migrate/cli"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
@@ -29,7 +31,7 @@ func TestCommandUsesConfigurationRootsAndTargetBranch(t *testing.T) {
 
 	root := "/tmp/migrate-root"
 	discoverer := &fakeRepositoryDiscoverer{repositories: []string{root}}
-	executor := &stubGitExecutor{}
+	executor := execshelltest.NewPermissiveExecutor()
 	manager := stubGitRepositoryManager{}
 	runner := &recordingTaskRunner{}
 
@@ -75,7 +77,7 @@ func TestCommandFlagsOverrideConfiguration(t *testing.T) {
 	flagRoot := "/tmp/flag-root"
 
 	discoverer := &fakeRepositoryDiscoverer{repositories: []string{flagRoot}}
-	executor := &stubGitExecutor{}
+	executor := execshelltest.NewPermissiveExecutor()
 	manager := stubGitRepositoryManager{}
 	runner := &recordingTaskRunner{}
 
@@ -114,6 +116,480 @@ func TestCommandFlagsOverrideConfiguration(t *testing.T) {
 	require.True(t, runner.runtimeOptions.AssumeYes)
 }
 
+func TestCommandRetainSourceOption(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configuredRetention  string
+		arguments            []string
+		expectedRetention    any
+		expectedErrorMessage string
+	}{
+		{
+			name:              "flag_selects_archive",
+			arguments:         []string{"--retain-source", "archive"},
+			expectedRetention: "archive",
+		},
+		{
+			name:                "configuration_selects_delete",
+			configuredRetention: "delete",
+			arguments:           []string{},
+			expectedRetention:   "delete",
+		},
+		{
+			name:              "omitted_keeps_source",
+			arguments:         []string{},
+			expectedRetention: nil,
+		},
+		{
+			name:                 "unsupported_value_errors",
+			arguments:            []string{"--retain-source", "freeze"},
+			expectedErrorMessage: "unsupported --retain-source value \"freeze\" (expected delete or archive)",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			root := "/tmp/migrate-retain-root"
+			runner := &recordingTaskRunner{}
+
+			builder := cli.CommandBuilder{
+				LoggerProvider:       func() *zap.Logger { return zap.NewNop() },
+				Discoverer:           &fakeRepositoryDiscoverer{repositories: []string{root}},
+				GitExecutor:          execshelltest.NewPermissiveExecutor(),
+				GitRepositoryManager: stubGitRepositoryManager{},
+				ConfigurationProvider: func() migrate.CommandConfiguration {
+					return migrate.CommandConfiguration{
+						RepositoryRoots: []string{root},
+						TargetBranch:    "master",
+						RetainSource:    testCase.configuredRetention,
+					}
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			executionError := command.Execute()
+			if len(testCase.expectedErrorMessage) > 0 {
+				require.EqualError(subtest, executionError, testCase.expectedErrorMessage)
+				return
+			}
+			require.NoError(subtest, executionError)
+
+			require.Len(subtest, runner.definitions, 1)
+			require.Equal(subtest, testCase.expectedRetention, runner.definitions[0].Actions[0].Options["retain_source"])
+		})
+	}
+}
+
+func TestCommandLeaveTombstoneOption(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configuredTombstone  bool
+		arguments            []string
+		expectedTombstone    any
+		expectedErrorMessage string
+	}{
+		{
+			name:              "flag_with_archive",
+			arguments:         []string{"--retain-source", "archive", "--leave-tombstone"},
+			expectedTombstone: true,
+		},
+		{
+			name:                "configuration_with_delete",
+			configuredTombstone: true,
+			arguments:           []string{"--retain-source", "delete"},
+			expectedTombstone:   true,
+		},
+		{
+			name:              "omitted",
+			arguments:         []string{"--retain-source", "delete"},
+			expectedTombstone: nil,
+		},
+		{
+			name:                 "requires_retention",
+			arguments:            []string{"--leave-tombstone"},
+			expectedErrorMessage: "--leave-tombstone requires --retain-source delete or archive",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			root := "/tmp/migrate-tombstone-root"
+			runner := &recordingTaskRunner{}
+
+			builder := cli.CommandBuilder{
+				LoggerProvider:       func() *zap.Logger { return zap.NewNop() },
+				Discoverer:           &fakeRepositoryDiscoverer{repositories: []string{root}},
+				GitExecutor:          execshelltest.NewPermissiveExecutor(),
+				GitRepositoryManager: stubGitRepositoryManager{},
+				ConfigurationProvider: func() migrate.CommandConfiguration {
+					return migrate.CommandConfiguration{
+						RepositoryRoots: []string{root},
+						TargetBranch:    "master",
+						LeaveTombstone:  testCase.configuredTombstone,
+					}
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			executionError := command.Execute()
+			if len(testCase.expectedErrorMessage) > 0 {
+				require.EqualError(subtest, executionError, testCase.expectedErrorMessage)
+				return
+			}
+			require.NoError(subtest, executionError)
+
+			require.Len(subtest, runner.definitions, 1)
+			require.Equal(subtest, testCase.expectedTombstone, runner.definitions[0].Actions[0].Options["leave_tombstone"])
+		})
+	}
+}
+
+func TestCommandPhaseOption(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configuredPhase      string
+		arguments            []string
+		expectedPhase        any
+		expectedGracePeriod  any
+		expectedErrorMessage string
+	}{
+		{
+			name:          "announce_flag",
+			arguments:     []string{"--phase", "announce"},
+			expectedPhase: "announce",
+		},
+		{
+			name:                "enforce_with_grace_period",
+			arguments:           []string{"--phase", "enforce", "--retain-source", "delete", "--grace-period", "7d"},
+			expectedPhase:       "enforce",
+			expectedGracePeriod: "168h0m0s",
+		},
+		{
+			name:            "configuration_phase",
+			configuredPhase: "announce",
+			expectedPhase:   "announce",
+		},
+		{
+			name:                 "unknown_phase",
+			arguments:            []string{"--phase", "later"},
+			expectedErrorMessage: "unsupported phase \"later\" (expected announce or enforce)",
+		},
+		{
+			name:                 "enforce_requires_retention",
+			arguments:            []string{"--phase", "enforce"},
+			expectedErrorMessage: "--phase enforce requires --retain-source delete or archive",
+		},
+		{
+			name:                 "grace_period_requires_enforce",
+			arguments:            []string{"--phase", "announce", "--grace-period", "72h"},
+			expectedErrorMessage: "--grace-period requires --phase enforce",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			root := "/tmp/migrate-phase-root"
+			runner := &recordingTaskRunner{}
+
+			builder := cli.CommandBuilder{
+				LoggerProvider:       func() *zap.Logger { return zap.NewNop() },
+				Discoverer:           &fakeRepositoryDiscoverer{repositories: []string{root}},
+				GitExecutor:          execshelltest.NewPermissiveExecutor(),
+				GitRepositoryManager: stubGitRepositoryManager{},
+				ConfigurationProvider: func() migrate.CommandConfiguration {
+					return migrate.CommandConfiguration{
+						RepositoryRoots: []string{root},
+						TargetBranch:    "master",
+						Phase:           testCase.configuredPhase,
+					}
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			executionError := command.Execute()
+			if len(testCase.expectedErrorMessage) > 0 {
+				require.EqualError(subtest, executionError, testCase.expectedErrorMessage)
+				return
+			}
+			require.NoError(subtest, executionError)
+
+			require.Len(subtest, runner.definitions, 1)
+			actionOptions := runner.definitions[0].Actions[0].Options
+			require.Equal(subtest, testCase.expectedPhase, actionOptions["phase"])
+			require.Equal(subtest, testCase.expectedGracePeriod, actionOptions["grace_period"])
+		})
+	}
+}
+
+func TestCommandWriteInstructionsOption(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configuredDirectory  string
+		arguments            []string
+		expectedInstructions any
+	}{
+		{
+			name:                 "flag",
+			arguments:            []string{"--write-instructions", " /tmp/instructions "},
+			expectedInstructions: "/tmp/instructions",
+		},
+		{
+			name:                 "configuration",
+			configuredDirectory:  "/tmp/configured-instructions",
+			expectedInstructions: "/tmp/configured-instructions",
+		},
+		{
+			name:                 "flag_overrides_configuration",
+			configuredDirectory:  "/tmp/configured-instructions",
+			arguments:            []string{"--write-instructions", "/tmp/flag-instructions"},
+			expectedInstructions: "/tmp/flag-instructions",
+		},
+		{
+			name:                 "omitted",
+			expectedInstructions: nil,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			root := "/tmp/migrate-instructions-root"
+			runner := &recordingTaskRunner{}
+
+			builder := cli.CommandBuilder{
+				LoggerProvider:       func() *zap.Logger { return zap.NewNop() },
+				Discoverer:           &fakeRepositoryDiscoverer{repositories: []string{root}},
+				GitExecutor:          execshelltest.NewPermissiveExecutor(),
+				GitRepositoryManager: stubGitRepositoryManager{},
+				ConfigurationProvider: func() migrate.CommandConfiguration {
+					return migrate.CommandConfiguration{
+						RepositoryRoots:   []string{root},
+						TargetBranch:      "master",
+						WriteInstructions: testCase.configuredDirectory,
+					}
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			require.NoError(subtest, command.Execute())
+			require.Len(subtest, runner.definitions, 1)
+			require.Equal(subtest, testCase.expectedInstructions, runner.definitions[0].Actions[0].Options["write_instructions"])
+		})
+	}
+}
+
+func TestCommandUpdateDocsOption(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configuration        migrate.CommandConfiguration
+		arguments            []string
+		expectedUpdateDocs   any
+		expectedDocsPatterns any
+		expectedErrorMessage string
+	}{
+		{
+			name:               "flag",
+			arguments:          []string{"--update-docs"},
+			expectedUpdateDocs: true,
+		},
+		{
+			name:                 "flag_with_patterns",
+			arguments:            []string{"--update-docs", "--docs-patterns", "README*,guides/**/*.md"},
+			expectedUpdateDocs:   true,
+			expectedDocsPatterns: []string{"README*", "guides/**/*.md"},
+		},
+		{
+			name:                 "configuration",
+			configuration:        migrate.CommandConfiguration{UpdateDocs: true, DocsPatterns: []string{"docs/*.md"}},
+			expectedUpdateDocs:   true,
+			expectedDocsPatterns: []string{"docs/*.md"},
+		},
+		{
+			name: "omitted",
+		},
+		{
+			name:                 "patterns_require_update_docs",
+			arguments:            []string{"--docs-patterns", "README*"},
+			expectedErrorMessage: "--docs-patterns requires --update-docs",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			root := "/tmp/migrate-docs-root"
+			runner := &recordingTaskRunner{}
+
+			builder := cli.CommandBuilder{
+				LoggerProvider:       func() *zap.Logger { return zap.NewNop() },
+				Discoverer:           &fakeRepositoryDiscoverer{repositories: []string{root}},
+				GitExecutor:          execshelltest.NewPermissiveExecutor(),
+				GitRepositoryManager: stubGitRepositoryManager{},
+				ConfigurationProvider: func() migrate.CommandConfiguration {
+					configuration := testCase.configuration
+					configuration.RepositoryRoots = []string{root}
+					configuration.TargetBranch = "master"
+					return configuration
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			executionError := command.Execute()
+			if len(testCase.expectedErrorMessage) > 0 {
+				require.EqualError(subtest, executionError, testCase.expectedErrorMessage)
+				return
+			}
+			require.NoError(subtest, executionError)
+
+			require.Len(subtest, runner.definitions, 1)
+			actionOptions := runner.definitions[0].Actions[0].Options
+			require.Equal(subtest, testCase.expectedUpdateDocs, actionOptions["update_docs"])
+			require.Equal(subtest, testCase.expectedDocsPatterns, actionOptions["docs_patterns"])
+		})
+	}
+}
+
+func TestCommandRepositoryOverrides(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		arguments            []string
+		expectedTarget       string
+		expectedTargetBranch string
+	}{
+		{
+			name:                 "configured_override_target_applies",
+			arguments:            []string{},
+			expectedTarget:       "master",
+			expectedTargetBranch: "trunk",
+		},
+		{
+			name:                 "argument_overrides_configured_target",
+			arguments:            []string{"stable"},
+			expectedTarget:       "stable",
+			expectedTargetBranch: "",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			root := "/tmp/migrate-override-root"
+			runner := &recordingTaskRunner{}
+
+			builder := cli.CommandBuilder{
+				LoggerProvider:       func() *zap.Logger { return zap.NewNop() },
+				Discoverer:           &fakeRepositoryDiscoverer{repositories: []string{root}},
+				GitExecutor:          execshelltest.NewPermissiveExecutor(),
+				GitRepositoryManager: stubGitRepositoryManager{},
+				ConfigurationProvider: func() migrate.CommandConfiguration {
+					return migrate.CommandConfiguration{
+						RepositoryRoots: []string{root},
+						TargetBranch:    "master",
+						Overrides: map[string]migrate.RepositoryOverride{
+							"temirov/trunk-service": {TargetBranch: "trunk", SourceBranch: "develop"},
+						},
+					}
+				},
+				TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			bindRootAndExecutionFlags(command)
+
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+			require.NoError(subtest, command.Execute())
+
+			require.Len(subtest, runner.definitions, 1)
+			actionOptions := runner.definitions[0].Actions[0].Options
+			require.Equal(subtest, testCase.expectedTarget, actionOptions["target"])
+
+			overrides, overridesPresent := actionOptions["overrides"].(*migrate.RepositoryOverrides)
+			require.True(subtest, overridesPresent)
+			key, override, matched := overrides.Resolve("temirov/trunk-service")
+			require.True(subtest, matched)
+			require.Equal(subtest, "temirov/trunk-service", key)
+			require.Equal(subtest, testCase.expectedTargetBranch, override.TargetBranch)
+			require.Equal(subtest, "develop", override.SourceBranch)
+		})
+	}
+}
+
+func TestCommandWarnsAboutUnmatchedOverridesWhenRunFails(t *testing.T) {
+	root := "/tmp/migrate-unmatched-root"
+	runFailure := errors.New("task runner failed")
+	runner := &recordingTaskRunner{runError: runFailure}
+	observedCore, observedLogs := observer.New(zap.WarnLevel)
+
+	builder := cli.CommandBuilder{
+		LoggerProvider:       func() *zap.Logger { return zap.New(observedCore) },
+		Discoverer:           &fakeRepositoryDiscoverer{repositories: []string{root}},
+		GitExecutor:          execshelltest.NewPermissiveExecutor(),
+		GitRepositoryManager: stubGitRepositoryManager{},
+		ConfigurationProvider: func() migrate.CommandConfiguration {
+			return migrate.CommandConfiguration{
+				RepositoryRoots: []string{root},
+				TargetBranch:    "master",
+				Overrides: map[string]migrate.RepositoryOverride{
+					"Acme/Web.Site": {TargetBranch: "trunk"},
+				},
+			}
+		},
+		TaskRunnerFactory: func(workflow.Dependencies) cli.TaskRunnerExecutor { return runner },
+	}
+
+	command, buildError := builder.Build()
+	require.NoError(t, buildError)
+	bindRootAndExecutionFlags(command)
+
+	command.SetContext(context.Background())
+	command.SetArgs([]string{})
+	require.ErrorIs(t, command.Execute(), runFailure)
+
+	warnings := observedLogs.FilterMessage("branch-default override matched no discovered repository").All()
+	require.Len(t, warnings, 1)
+	require.Equal(t, "Acme/Web.Site", warnings[0].ContextMap()["override"])
+}
+
 func TestCommandDisplaysHelpWhenRootsMissing(t *testing.T) {
 	t.Helper()
 
@@ -143,13 +619,14 @@ type recordingTaskRunner struct {
 	roots          []string
 	definitions    []workflow.TaskDefinition
 	runtimeOptions workflow.RuntimeOptions
+	runError       error
 }
 
 func (runner *recordingTaskRunner) Run(_ context.Context, roots []string, definitions []workflow.TaskDefinition, options workflow.RuntimeOptions) error {
 	runner.roots = append([]string{}, roots...)
 	runner.definitions = append([]workflow.TaskDefinition{}, definitions...)
 	runner.runtimeOptions = options
-	return nil
+	return runner.runError
 }
 
 type fakeRepositoryDiscoverer struct {
@@ -162,16 +639,6 @@ func (discoverer *fakeRepositoryDiscoverer) DiscoverRepositories(roots []string)
 	return append([]string{}, discoverer.repositories...), nil
 }
 
-type stubGitExecutor struct{}
-
-func (stubGitExecutor) ExecuteGit(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return execshell.ExecutionResult{}, nil
-}
-
-func (stubGitExecutor) ExecuteGitHubCLI(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return execshell.ExecutionResult{}, nil
-}
-
 type stubGitRepositoryManager struct{}
 
 func (stubGitRepositoryManager) CheckCleanWorktree(context.Context, string) (bool, error) {
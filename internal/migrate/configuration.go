@@ -10,9 +10,17 @@ var migrateConfigurationRepositoryPathSanitizer = pathutils.NewRepositoryPathSan
 
 // CommandConfiguration captures persisted configuration for promoting a default branch.
 type CommandConfiguration struct {
-	EnableDebugLogging bool     `mapstructure:"debug"`
-	RepositoryRoots    []string `mapstructure:"roots"`
-	TargetBranch       string   `mapstructure:"to"`
+	EnableDebugLogging bool                          `mapstructure:"debug"`
+	RepositoryRoots    []string                      `mapstructure:"roots"`
+	TargetBranch       string                        `mapstructure:"to"`
+	RetainSource       string                        `mapstructure:"retain_source"`
+	LeaveTombstone     bool                          `mapstructure:"leave_tombstone"`
+	WriteInstructions  string                        `mapstructure:"write_instructions"`
+	UpdateDocs         bool                          `mapstructure:"update_docs"`
+	DocsPatterns       []string                      `mapstructure:"docs_patterns"`
+	Phase              string                        `mapstructure:"phase"`
+	GracePeriod        string                        `mapstructure:"grace_period"`
+	Overrides          map[string]RepositoryOverride `mapstructure:"overrides"`
 }
 
 // DefaultCommandConfiguration returns baseline configuration values for default branch promotion.
@@ -32,5 +40,30 @@ func (configuration CommandConfiguration) Sanitize() CommandConfiguration {
 	if len(sanitized.TargetBranch) == 0 {
 		sanitized.TargetBranch = string(BranchMaster)
 	}
+	sanitized.RetainSource = strings.ToLower(strings.TrimSpace(configuration.RetainSource))
+	sanitized.WriteInstructions = strings.TrimSpace(configuration.WriteInstructions)
+	sanitized.Phase = strings.ToLower(strings.TrimSpace(configuration.Phase))
+	sanitized.GracePeriod = strings.TrimSpace(configuration.GracePeriod)
+	sanitized.DocsPatterns = nil
+	for _, pattern := range configuration.DocsPatterns {
+		if trimmedPattern := strings.TrimSpace(pattern); len(trimmedPattern) > 0 {
+			sanitized.DocsPatterns = append(sanitized.DocsPatterns, trimmedPattern)
+		}
+	}
+	sanitized.Overrides = nil
+	for key, override := range configuration.Overrides {
+		trimmedKey := strings.TrimSpace(key)
+		if len(trimmedKey) == 0 {
+			continue
+		}
+		if sanitized.Overrides == nil {
+			sanitized.Overrides = make(map[string]RepositoryOverride, len(configuration.Overrides))
+		}
+		sanitized.Overrides[trimmedKey] = RepositoryOverride{
+			TargetBranch: strings.TrimSpace(override.TargetBranch),
+			SourceBranch: strings.TrimSpace(override.SourceBranch),
+			Skip:         override.Skip,
+		}
+	}
 	return sanitized
 }
@@ -0,0 +1,229 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	documentationFileFieldNameConstant        = "documentation_file"
+	documentationReplacementsFieldConstant    = "replacements"
+	documentationRewriteLogMessageConstant    = "Rewriting branch references in documentation file"
+	documentationCompletionLogMessageConstant = "Documentation rewrite completed"
+	documentationUpdatedFilesFieldConstant    = "updated_documentation"
+	documentationGitDirectoryNameConstant     = ".git"
+	documentationPathSeparatorConstant        = "/"
+	documentationDoubleWildcardConstant       = "**"
+	documentationInvalidPatternTemplate       = "invalid documentation pattern %q: %w"
+	documentationWalkErrorTemplateConstant    = "unable to scan documentation files: %w"
+	readDocumentationErrorTemplateConstant    = "unable to read documentation file %s: %w"
+	statDocumentationErrorTemplateConstant    = "unable to stat documentation file %s: %w"
+	writeDocumentationErrorTemplateConstant   = "unable to write documentation file %s: %w"
+	// The trailing group stands in for a word boundary that also rejects "-" and ".", so "main-old" and "main.v2" stay.
+	documentationBranchEndExpressionConstant = `([^\w.-]|$)`
+	// A URL of the repository itself: any host followed by path segments that end in /<owner>/<name>, which covers
+	// github.com links as well as badge services that embed the repository in their path.
+	repositoryURLExpressionTemplateConstant  = `https?://[^\s()<>"']*?/(?i:%s)`
+	treeReferencePatternTemplateConstant     = `(?m)(%s/(?:tree|blob)/)%s` + documentationBranchEndExpressionConstant
+	branchParameterPatternTemplateConstant   = `(?m)(%s/[^\s()<>"']*[?&]branch=)%s` + documentationBranchEndExpressionConstant
+	badgePathPatternTemplateConstant         = `(\(%s/(?:[^\s()]*/)?)%s(\))`
+	documentationReplacementTemplateConstant = "${1}%s${2}"
+)
+
+// DefaultDocumentationPatterns lists the files scanned for branch references when no patterns are configured.
+var DefaultDocumentationPatterns = []string{"README*", "CONTRIBUTING*", "docs/**/*.md"}
+
+// DocumentationRewriteConfig describes the documentation rewrite inputs. Patterns are slash-separated globs relative to
+// the repository root in which "**" spans any number of directories.
+// RepositoryIdentifier is the owner/name of the repository; only links into that repository are rewritten.
+type DocumentationRewriteConfig struct {
+	RepositoryPath       string
+	RepositoryIdentifier string
+	Patterns             []string
+	SourceBranch         BranchName
+	TargetBranch         BranchName
+}
+
+// DocumentationFileUpdate records how many branch references were rewritten in one file.
+type DocumentationFileUpdate struct {
+	Path         string
+	Replacements int
+}
+
+// DocumentationOutcome captures documentation rewrite results.
+type DocumentationOutcome struct {
+	UpdatedFiles []DocumentationFileUpdate
+}
+
+// Paths returns the repository-relative paths of the updated files.
+func (outcome DocumentationOutcome) Paths() []string {
+	paths := make([]string, 0, len(outcome.UpdatedFiles))
+	for _, update := range outcome.UpdatedFiles {
+		paths = append(paths, update.Path)
+	}
+	return paths
+}
+
+// DocumentationRewriter updates branch references in links to the repository itself, such as /tree/<branch> and
+// /blob/<branch> paths, branch=<branch> badge parameters, and badge URLs ending in /<branch>), in repository
+// documentation. Links to other repositories keep their branch.
+type DocumentationRewriter struct {
+	logger *zap.Logger
+}
+
+// NewDocumentationRewriter constructs a DocumentationRewriter.
+func NewDocumentationRewriter(logger *zap.Logger) *DocumentationRewriter {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &DocumentationRewriter{logger: logger}
+}
+
+// Rewrite replaces source branch references with the target branch in every file matching the configured patterns.
+func (rewriter *DocumentationRewriter) Rewrite(_ context.Context, config DocumentationRewriteConfig) (DocumentationOutcome, error) {
+	outcome := DocumentationOutcome{UpdatedFiles: []DocumentationFileUpdate{}}
+
+	repositoryIdentifier := strings.Trim(strings.TrimSpace(config.RepositoryIdentifier), documentationPathSeparatorConstant)
+	if len(repositoryIdentifier) == 0 {
+		return DocumentationOutcome{}, InvalidInputError{FieldName: repositoryIdentifierFieldNameConstant, Message: requiredValueMessageConstant}
+	}
+
+	patterns := config.Patterns
+	if len(patterns) == 0 {
+		patterns = DefaultDocumentationPatterns
+	}
+	for _, pattern := range patterns {
+		if _, matchError := path.Match(pattern, ""); matchError != nil {
+			return DocumentationOutcome{}, fmt.Errorf(documentationInvalidPatternTemplate, pattern, matchError)
+		}
+	}
+
+	quotedSource := regexp.QuoteMeta(string(config.SourceBranch))
+	repositoryURLExpression := fmt.Sprintf(repositoryURLExpressionTemplateConstant, regexp.QuoteMeta(repositoryIdentifier))
+	referencePatterns := []*regexp.Regexp{
+		regexp.MustCompile(fmt.Sprintf(treeReferencePatternTemplateConstant, repositoryURLExpression, quotedSource)),
+		regexp.MustCompile(fmt.Sprintf(branchParameterPatternTemplateConstant, repositoryURLExpression, quotedSource)),
+		regexp.MustCompile(fmt.Sprintf(badgePathPatternTemplateConstant, repositoryURLExpression, quotedSource)),
+	}
+	replacement := fmt.Sprintf(documentationReplacementTemplateConstant, string(config.TargetBranch))
+
+	walkError := filepath.WalkDir(config.RepositoryPath, func(filePath string, directoryEntry fs.DirEntry, walkError error) error {
+		if walkError != nil {
+			return walkError
+		}
+		if directoryEntry.IsDir() {
+			if directoryEntry.Name() == documentationGitDirectoryNameConstant {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !directoryEntry.Type().IsRegular() {
+			return nil
+		}
+		relativePath, relativeError := filepath.Rel(config.RepositoryPath, filePath)
+		if relativeError != nil {
+			return relativeError
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if !matchesAnyDocumentationPattern(patterns, relativePath) {
+			return nil
+		}
+
+		replacements, processingError := rewriter.processDocumentationFile(filePath, referencePatterns, replacement)
+		if processingError != nil {
+			return processingError
+		}
+		if replacements > 0 {
+			outcome.UpdatedFiles = append(outcome.UpdatedFiles, DocumentationFileUpdate{Path: relativePath, Replacements: replacements})
+		}
+		return nil
+	})
+	if walkError != nil {
+		return DocumentationOutcome{}, fmt.Errorf(documentationWalkErrorTemplateConstant, walkError)
+	}
+
+	rewriter.logger.Info(documentationCompletionLogMessageConstant,
+		zap.String(repositoryPathFieldNameConstant, config.RepositoryPath),
+		zap.Strings(documentationUpdatedFilesFieldConstant, outcome.Paths()),
+	)
+
+	return outcome, nil
+}
+
+func (rewriter *DocumentationRewriter) processDocumentationFile(filePath string, referencePatterns []*regexp.Regexp, replacement string) (int, error) {
+	fileContent, readError := os.ReadFile(filePath)
+	if readError != nil {
+		return 0, fmt.Errorf(readDocumentationErrorTemplateConstant, filePath, readError)
+	}
+
+	updatedContent := string(fileContent)
+	replacements := 0
+	for _, referencePattern := range referencePatterns {
+		matchCount := len(referencePattern.FindAllStringIndex(updatedContent, -1))
+		if matchCount == 0 {
+			continue
+		}
+		replacements += matchCount
+		updatedContent = referencePattern.ReplaceAllString(updatedContent, replacement)
+	}
+	if replacements == 0 {
+		return 0, nil
+	}
+
+	fileInfo, infoError := os.Stat(filePath)
+	if infoError != nil {
+		return 0, fmt.Errorf(statDocumentationErrorTemplateConstant, filePath, infoError)
+	}
+	if writeError := os.WriteFile(filePath, []byte(updatedContent), fileInfo.Mode().Perm()); writeError != nil {
+		return 0, fmt.Errorf(writeDocumentationErrorTemplateConstant, filePath, writeError)
+	}
+
+	rewriter.logger.Info(documentationRewriteLogMessageConstant,
+		zap.String(documentationFileFieldNameConstant, filePath),
+		zap.Int(documentationReplacementsFieldConstant, replacements),
+	)
+	return replacements, nil
+}
+
+func matchesAnyDocumentationPattern(patterns []string, relativePath string) bool {
+	pathSegments := strings.Split(relativePath, documentationPathSeparatorConstant)
+	for _, pattern := range patterns {
+		patternSegments := strings.Split(strings.Trim(strings.TrimSpace(pattern), documentationPathSeparatorConstant), documentationPathSeparatorConstant)
+		if documentationSegmentsMatch(patternSegments, pathSegments) {
+			return true
+		}
+	}
+	return false
+}
+
+// documentationSegmentsMatch matches path segments against glob segments, letting a "**" segment consume zero or more
+// path segments.
+func documentationSegmentsMatch(patternSegments []string, pathSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(pathSegments) == 0
+	}
+	if patternSegments[0] == documentationDoubleWildcardConstant {
+		for consumed := 0; consumed <= len(pathSegments); consumed++ {
+			if documentationSegmentsMatch(patternSegments[1:], pathSegments[consumed:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(pathSegments) == 0 {
+		return false
+	}
+	matched, matchError := path.Match(patternSegments[0], pathSegments[0])
+	if matchError != nil || !matched {
+		return false
+	}
+	return documentationSegmentsMatch(patternSegments[1:], pathSegments[1:])
+}
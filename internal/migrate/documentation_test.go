@@ -0,0 +1,113 @@
+package migrate_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	migrate "github.com/temirov/gix/internal/migrate"
+)
+
+func TestDocumentationRewriterScenarios(testInstance *testing.T) {
+	testCases := []struct {
+		name             string
+		identifier       string
+		patterns         []string
+		files            map[string]string
+		expectedFiles    map[string]string
+		expectedOutcome  []migrate.DocumentationFileUpdate
+		expectedErrorMsg string
+	}{
+		{
+			name:       "default_patterns",
+			identifier: "o/r",
+			files: map[string]string{
+				"README.md":          "[![CI](https://github.com/o/r/actions/workflows/ci.yml/badge.svg?branch=main)](https://github.com/o/r/tree/main)\n",
+				"CONTRIBUTING.md":    "Browse https://github.com/o/r/tree/main/docs and read https://github.com/O/R/blob/main/LICENSE first.\n",
+				"docs/guide/dev.md":  "![Last commit](https://img.shields.io/github/last-commit/o/r/main)\n",
+				"docs/notes.txt":     "https://github.com/o/r/tree/main\n",
+				"src/README.md":      "https://github.com/o/r/tree/main\n",
+				"docs/untouched.md":  "The mainline lives at https://github.com/o/r/tree/mainline and ?branch=main-old keeps its name.\n",
+				"docs/nested/top.md": "See (main) and main for context.\n",
+				"docs/others.md":     "Built on https://github.com/other/lib/blob/main/README.md, https://github.com/o/r-fork/tree/main, and /tree/main.\n",
+			},
+			expectedFiles: map[string]string{
+				"README.md":          "[![CI](https://github.com/o/r/actions/workflows/ci.yml/badge.svg?branch=master)](https://github.com/o/r/tree/master)\n",
+				"CONTRIBUTING.md":    "Browse https://github.com/o/r/tree/master/docs and read https://github.com/O/R/blob/master/LICENSE first.\n",
+				"docs/guide/dev.md":  "![Last commit](https://img.shields.io/github/last-commit/o/r/master)\n",
+				"docs/notes.txt":     "https://github.com/o/r/tree/main\n",
+				"src/README.md":      "https://github.com/o/r/tree/main\n",
+				"docs/untouched.md":  "The mainline lives at https://github.com/o/r/tree/mainline and ?branch=main-old keeps its name.\n",
+				"docs/nested/top.md": "See (main) and main for context.\n",
+				"docs/others.md":     "Built on https://github.com/other/lib/blob/main/README.md, https://github.com/o/r-fork/tree/main, and /tree/main.\n",
+			},
+			expectedOutcome: []migrate.DocumentationFileUpdate{
+				{Path: "CONTRIBUTING.md", Replacements: 2},
+				{Path: "README.md", Replacements: 2},
+				{Path: "docs/guide/dev.md", Replacements: 1},
+			},
+		},
+		{
+			name:       "custom_patterns",
+			identifier: "o/r",
+			patterns:   []string{"**/*.txt"},
+			files: map[string]string{
+				"README.md":      "https://github.com/o/r/tree/main\n",
+				"docs/notes.txt": "https://github.com/o/r/tree/main and https://img.shields.io/github/actions/workflow/status/o/r/ci.yml?branch=main\n",
+			},
+			expectedFiles: map[string]string{
+				"README.md":      "https://github.com/o/r/tree/main\n",
+				"docs/notes.txt": "https://github.com/o/r/tree/master and https://img.shields.io/github/actions/workflow/status/o/r/ci.yml?branch=master\n",
+			},
+			expectedOutcome: []migrate.DocumentationFileUpdate{{Path: "docs/notes.txt", Replacements: 2}},
+		},
+		{
+			name:             "missing_repository_identifier",
+			files:            map[string]string{"README.md": "https://github.com/o/r/tree/main\n"},
+			expectedErrorMsg: "repository_identifier: value required",
+		},
+		{
+			name:             "invalid_pattern",
+			identifier:       "o/r",
+			patterns:         []string{"docs/[.md"},
+			files:            map[string]string{"README.md": "https://github.com/o/r/tree/main\n"},
+			expectedErrorMsg: `invalid documentation pattern "docs/[.md": syntax error in pattern`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			repositoryDirectory := subtest.TempDir()
+			for relativePath, content := range testCase.files {
+				filePath := filepath.Join(repositoryDirectory, filepath.FromSlash(relativePath))
+				require.NoError(subtest, os.MkdirAll(filepath.Dir(filePath), 0o755))
+				require.NoError(subtest, os.WriteFile(filePath, []byte(content), 0o644))
+			}
+
+			rewriter := migrate.NewDocumentationRewriter(zap.NewNop())
+			outcome, rewriteError := rewriter.Rewrite(context.Background(), migrate.DocumentationRewriteConfig{
+				RepositoryPath:       repositoryDirectory,
+				RepositoryIdentifier: testCase.identifier,
+				Patterns:             testCase.patterns,
+				SourceBranch:         migrate.BranchMain,
+				TargetBranch:         migrate.BranchMaster,
+			})
+			if len(testCase.expectedErrorMsg) > 0 {
+				require.EqualError(subtest, rewriteError, testCase.expectedErrorMsg)
+				return
+			}
+			require.NoError(subtest, rewriteError)
+			require.Equal(subtest, testCase.expectedOutcome, outcome.UpdatedFiles)
+
+			for relativePath, expectedContent := range testCase.expectedFiles {
+				content, readError := os.ReadFile(filepath.Join(repositoryDirectory, filepath.FromSlash(relativePath)))
+				require.NoError(subtest, readError)
+				require.Equal(subtest, expectedContent, string(content), relativePath)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+const (
+	instructionsTemplateNameConstant           = "instructions"
+	instructionsFileExtensionConstant          = ".md"
+	instructionsFileNameSeparatorConstant      = "-"
+	instructionsIdentifierSeparatorConstant    = "/"
+	instructionsFilePermissionsConstant        = 0o644
+	instructionsDirectoryPermissionsConstant   = 0o755
+	instructionsFileSystemMissingConstant      = "file system unavailable for contributor instructions"
+	instructionsIdentifierMissingConstant      = "repository identifier required for contributor instructions"
+	instructionsRenderErrorTemplateConstant    = "unable to render contributor instructions for %s: %w"
+	instructionsDirectoryErrorTemplateConstant = "unable to create contributor instructions directory %s: %w"
+	instructionsWriteErrorTemplateConstant     = "unable to write contributor instructions %s: %w"
+)
+
+const instructionsTemplateConstant = "# {{.RepositoryIdentifier}}: default branch moved to `{{.TargetBranch}}`\n\n" +
+	"The default branch of {{.RepositoryIdentifier}} moved from `{{.SourceBranch}}` to `{{.TargetBranch}}`.\n" +
+	"{{if .ArchivedSourceBranch}}`{{.SourceBranch}}` was archived as the locked branch `{{.ArchivedSourceBranch}}`.\n" +
+	"{{else if .SourceBranchDeleted}}`{{.SourceBranch}}` was deleted from {{.RemoteName}}.\n" +
+	"{{else}}`{{.SourceBranch}}` still exists on {{.RemoteName}} but no longer receives updates.\n{{end}}\n" +
+	"Update your local clone:\n\n" +
+	"```shell\n" +
+	"git fetch {{.RemoteName}} --prune\n" +
+	"git checkout {{.TargetBranch}}\n" +
+	"git branch --set-upstream-to={{.RemoteName}}/{{.TargetBranch}} {{.TargetBranch}}\n" +
+	"git remote set-head {{.RemoteName}} --auto\n" +
+	"git branch -d {{.SourceBranch}}\n" +
+	"```\n\n" +
+	"From now on pull with `git pull {{.RemoteName}} {{.TargetBranch}}`, and rebase work that started from `{{.SourceBranch}}` with `git rebase {{.RemoteName}}/{{.TargetBranch}}`.\n" +
+	"To start new repositories on `{{.TargetBranch}}` as well, run `git config --global init.defaultBranch {{.TargetBranch}}`.\n"
+
+var instructionsTemplate = template.Must(template.New(instructionsTemplateNameConstant).Parse(instructionsTemplateConstant))
+
+var (
+	errInstructionsFileSystemMissing = errors.New(instructionsFileSystemMissingConstant)
+	errInstructionsIdentifierMissing = errors.New(instructionsIdentifierMissingConstant)
+)
+
+// ContributorInstructions carries the values rendered into the post-migration steps contributors follow to update
+// their local clones.
+type ContributorInstructions struct {
+	RepositoryIdentifier string
+	RemoteName           string
+	SourceBranch         string
+	TargetBranch         string
+	ArchivedSourceBranch string
+	SourceBranchDeleted  bool
+}
+
+// NewContributorInstructions describes a migration's outcome for contributors using the branch names and remote of
+// the options and the retirement recorded in the result.
+func NewContributorInstructions(options MigrationOptions, result MigrationResult) ContributorInstructions {
+	return ContributorInstructions{
+		RepositoryIdentifier: options.RepositoryIdentifier,
+		RemoteName:           options.RepositoryRemoteName,
+		SourceBranch:         string(options.SourceBranch),
+		TargetBranch:         string(options.TargetBranch),
+		ArchivedSourceBranch: result.ArchivedSourceBranch,
+		SourceBranchDeleted:  result.SourceBranchDeleted,
+	}
+}
+
+// RenderContributorInstructions renders the markdown instruction block for one repository.
+func RenderContributorInstructions(instructions ContributorInstructions) (string, error) {
+	var rendered strings.Builder
+	if executeError := instructionsTemplate.Execute(&rendered, instructions); executeError != nil {
+		return "", fmt.Errorf(instructionsRenderErrorTemplateConstant, instructions.RepositoryIdentifier, executeError)
+	}
+	return rendered.String(), nil
+}
+
+// WriteIndented writes the rendered instructions indented like plan bodies, for inclusion in summaries and plans.
+func (instructions ContributorInstructions) WriteIndented(writer io.Writer) error {
+	rendered, renderError := RenderContributorInstructions(instructions)
+	if renderError != nil {
+		return renderError
+	}
+	writePlanBody(writer, []byte(rendered))
+	return nil
+}
+
+// ContributorInstructionsFileName names the markdown file written for a repository, replacing the owner separator so
+// every repository gets one file directly inside the instructions directory.
+func ContributorInstructionsFileName(repositoryIdentifier string) string {
+	return strings.ReplaceAll(strings.TrimSpace(repositoryIdentifier), instructionsIdentifierSeparatorConstant, instructionsFileNameSeparatorConstant) + instructionsFileExtensionConstant
+}
+
+// WriteContributorInstructions renders the instructions into a markdown file inside directory and returns its path.
+func WriteContributorInstructions(fileSystem shared.FileSystem, directory string, instructions ContributorInstructions) (string, error) {
+	if fileSystem == nil {
+		return "", errInstructionsFileSystemMissing
+	}
+	if len(strings.TrimSpace(instructions.RepositoryIdentifier)) == 0 {
+		return "", errInstructionsIdentifierMissing
+	}
+	rendered, renderError := RenderContributorInstructions(instructions)
+	if renderError != nil {
+		return "", renderError
+	}
+	if directoryError := fileSystem.MkdirAll(directory, instructionsDirectoryPermissionsConstant); directoryError != nil {
+		return "", fmt.Errorf(instructionsDirectoryErrorTemplateConstant, directory, directoryError)
+	}
+	instructionsPath := filepath.Join(directory, ContributorInstructionsFileName(instructions.RepositoryIdentifier))
+	if writeError := fileSystem.WriteFile(instructionsPath, []byte(rendered), instructionsFilePermissionsConstant); writeError != nil {
+		return "", fmt.Errorf(instructionsWriteErrorTemplateConstant, instructionsPath, writeError)
+	}
+	return instructionsPath, nil
+}
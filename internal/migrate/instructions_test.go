@@ -0,0 +1,82 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/repos/filesystem"
+)
+
+func TestRenderContributorInstructionsDescribesRetirement(testInstance *testing.T) {
+	options := MigrationOptions{
+		RepositoryIdentifier: "owner/example",
+		RepositoryRemoteName: "upstream",
+		SourceBranch:         BranchMain,
+		TargetBranch:         BranchMaster,
+	}
+
+	testCases := []struct {
+		name             string
+		result           MigrationResult
+		expectedStatus   string
+		unexpectedStatus string
+	}{
+		{
+			name:             "source_branch_archived",
+			result:           MigrationResult{ArchivedSourceBranch: "archive/main-2026-10-16"},
+			expectedStatus:   "`main` was archived as the locked branch `archive/main-2026-10-16`.\n",
+			unexpectedStatus: "was deleted",
+		},
+		{
+			name:             "source_branch_deleted",
+			result:           MigrationResult{SourceBranchDeleted: true},
+			expectedStatus:   "`main` was deleted from upstream.\n",
+			unexpectedStatus: "was archived",
+		},
+		{
+			name:             "source_branch_kept",
+			expectedStatus:   "`main` still exists on upstream but no longer receives updates.\n",
+			unexpectedStatus: "was deleted",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			rendered, renderError := RenderContributorInstructions(NewContributorInstructions(options, testCase.result))
+			require.NoError(subtest, renderError)
+			require.Contains(subtest, rendered, "# owner/example: default branch moved to `master`\n")
+			require.Contains(subtest, rendered, testCase.expectedStatus)
+			require.NotContains(subtest, rendered, testCase.unexpectedStatus)
+			require.Contains(subtest, rendered, "git fetch upstream --prune\ngit checkout master\ngit branch --set-upstream-to=upstream/master master\ngit remote set-head upstream --auto\ngit branch -d main\n")
+			require.Contains(subtest, rendered, "`git pull upstream master`")
+			require.Contains(subtest, rendered, "`git config --global init.defaultBranch master`")
+		})
+	}
+}
+
+func TestWriteContributorInstructionsWritesOneFilePerRepository(testInstance *testing.T) {
+	directory := filepath.Join(testInstance.TempDir(), "instructions")
+	instructions := ContributorInstructions{
+		RepositoryIdentifier: "owner/example",
+		RemoteName:           "origin",
+		SourceBranch:         "main",
+		TargetBranch:         "master",
+	}
+
+	instructionsPath, writeError := WriteContributorInstructions(filesystem.OSFileSystem{}, directory, instructions)
+	require.NoError(testInstance, writeError)
+	require.Equal(testInstance, filepath.Join(directory, "owner-example.md"), instructionsPath)
+
+	expected, renderError := RenderContributorInstructions(instructions)
+	require.NoError(testInstance, renderError)
+	written, readError := os.ReadFile(instructionsPath)
+	require.NoError(testInstance, readError)
+	require.Equal(testInstance, expected, string(written))
+
+	_, missingIdentifierError := WriteContributorInstructions(filesystem.OSFileSystem{}, directory, ContributorInstructions{TargetBranch: "master"})
+	require.ErrorIs(testInstance, missingIdentifierError, errInstructionsIdentifierMissing)
+}
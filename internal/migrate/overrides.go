@@ -0,0 +1,104 @@
+package migrate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/temirov/gix/internal/repos/selection"
+)
+
+const (
+	overridePatternMetaCharactersConstant = "*?["
+	overridesDescriptionTemplateConstant  = "%d override(s)"
+)
+
+// RepositoryOverride adjusts default-branch promotion for repositories matched by an overrides key.
+type RepositoryOverride struct {
+	TargetBranch string `mapstructure:"to"`
+	SourceBranch string `mapstructure:"from"`
+	Skip         bool   `mapstructure:"skip"`
+}
+
+type repositoryOverrideEntry struct {
+	key      string
+	matcher  selection.Matcher
+	override RepositoryOverride
+}
+
+// RepositoryOverrides resolves per-repository overrides keyed by owner/repo or path glob and records which keys matched.
+// Literal keys take precedence over glob keys; within each group keys are consulted alphabetically.
+type RepositoryOverrides struct {
+	mutex       sync.Mutex
+	entries     []repositoryOverrideEntry
+	matchedKeys map[string]struct{}
+}
+
+// NewRepositoryOverrides validates the override keys and constructs a resolver.
+func NewRepositoryOverrides(overrides map[string]RepositoryOverride) (*RepositoryOverrides, error) {
+	entries := make([]repositoryOverrideEntry, 0, len(overrides))
+	for key, override := range overrides {
+		matcher, matcherError := selection.NewMatcher([]string{key}, nil)
+		if matcherError != nil {
+			return nil, matcherError
+		}
+		entries = append(entries, repositoryOverrideEntry{key: key, matcher: matcher, override: override})
+	}
+	sort.SliceStable(entries, func(leftIndex int, rightIndex int) bool {
+		leftLiteral := !strings.ContainsAny(entries[leftIndex].key, overridePatternMetaCharactersConstant)
+		rightLiteral := !strings.ContainsAny(entries[rightIndex].key, overridePatternMetaCharactersConstant)
+		if leftLiteral != rightLiteral {
+			return leftLiteral
+		}
+		return entries[leftIndex].key < entries[rightIndex].key
+	})
+	return &RepositoryOverrides{entries: entries, matchedKeys: make(map[string]struct{})}, nil
+}
+
+// Resolve returns the first override whose key matches any candidate and records the key as used.
+func (overrides *RepositoryOverrides) Resolve(candidates ...string) (string, RepositoryOverride, bool) {
+	if overrides == nil {
+		return "", RepositoryOverride{}, false
+	}
+	for _, entry := range overrides.entries {
+		if !entry.matcher.Matches(candidates...) {
+			continue
+		}
+		overrides.mutex.Lock()
+		overrides.matchedKeys[entry.key] = struct{}{}
+		overrides.mutex.Unlock()
+		return entry.key, entry.override, true
+	}
+	return "", RepositoryOverride{}, false
+}
+
+// UnmatchedKeys lists the override keys that have not matched any resolved repository, sorted alphabetically.
+func (overrides *RepositoryOverrides) UnmatchedKeys() []string {
+	if overrides == nil {
+		return nil
+	}
+	overrides.mutex.Lock()
+	defer overrides.mutex.Unlock()
+	unmatched := make([]string, 0)
+	for _, entry := range overrides.entries {
+		if _, matched := overrides.matchedKeys[entry.key]; !matched {
+			unmatched = append(unmatched, entry.key)
+		}
+	}
+	sort.Strings(unmatched)
+	return unmatched
+}
+
+// Len reports the number of configured overrides.
+func (overrides *RepositoryOverrides) Len() int {
+	if overrides == nil {
+		return 0
+	}
+	return len(overrides.entries)
+}
+
+// String summarizes the overrides for plan output.
+func (overrides *RepositoryOverrides) String() string {
+	return fmt.Sprintf(overridesDescriptionTemplateConstant, overrides.Len())
+}
@@ -0,0 +1,71 @@
+package migrate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	migrate "github.com/temirov/gix/internal/migrate"
+)
+
+func TestRepositoryOverridesResolve(testInstance *testing.T) {
+	overrides, overridesError := migrate.NewRepositoryOverrides(map[string]migrate.RepositoryOverride{
+		"temirov/*":      {TargetBranch: "main"},
+		"temirov/legacy": {TargetBranch: "trunk", SourceBranch: "develop"},
+		"*/archived-*":   {Skip: true},
+		"ghost/missing":  {TargetBranch: "trunk"},
+	})
+	require.NoError(testInstance, overridesError)
+
+	testCases := []struct {
+		name             string
+		candidates       []string
+		expectedKey      string
+		expectedOverride migrate.RepositoryOverride
+		expectedMatch    bool
+	}{
+		{
+			name:             "literal_key_wins_over_glob",
+			candidates:       []string{"Temirov/Legacy", "/work/legacy", "legacy"},
+			expectedKey:      "temirov/legacy",
+			expectedOverride: migrate.RepositoryOverride{TargetBranch: "trunk", SourceBranch: "develop"},
+			expectedMatch:    true,
+		},
+		{
+			name:             "glob_key_matches_owner",
+			candidates:       []string{"temirov/gix", "/work/gix", "gix"},
+			expectedKey:      "temirov/*",
+			expectedOverride: migrate.RepositoryOverride{TargetBranch: "main"},
+			expectedMatch:    true,
+		},
+		{
+			name:             "skip_override",
+			candidates:       []string{"other/archived-tools"},
+			expectedKey:      "*/archived-*",
+			expectedOverride: migrate.RepositoryOverride{Skip: true},
+			expectedMatch:    true,
+		},
+		{
+			name:          "no_match",
+			candidates:    []string{"other/tools", "/work/tools", "tools"},
+			expectedMatch: false,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			key, override, matched := overrides.Resolve(testCase.candidates...)
+			require.Equal(subtest, testCase.expectedMatch, matched)
+			require.Equal(subtest, testCase.expectedKey, key)
+			require.Equal(subtest, testCase.expectedOverride, override)
+		})
+	}
+
+	require.Equal(testInstance, []string{"ghost/missing"}, overrides.UnmatchedKeys())
+}
+
+func TestRepositoryOverridesRejectInvalidPattern(testInstance *testing.T) {
+	_, overridesError := migrate.NewRepositoryOverrides(map[string]migrate.RepositoryOverride{"[broken": {}})
+	require.Error(testInstance, overridesError)
+}
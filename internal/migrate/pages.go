@@ -37,44 +37,54 @@ func (manager *PagesManager) EnsureLegacyBranch(executionContext context.Context
 		return false, nil
 	}
 
-	status, statusError := manager.githubClient.GetPagesConfig(executionContext, config.RepositoryIdentifier)
+	status, statusError := manager.githubClient.GetPagesConfig(githubcli.WithFreshResponses(executionContext), config.RepositoryIdentifier)
 	if statusError != nil {
 		return false, statusError
 	}
 
+	configuration, updateRequired := manager.legacyBranchUpdate(status, config)
+	if !updateRequired {
+		return false, nil
+	}
+
+	updateError := manager.githubClient.UpdatePagesConfig(executionContext, config.RepositoryIdentifier, configuration)
+	if updateError != nil {
+		return false, updateError
+	}
+
+	manager.logger.Info(pagesUpdateLogMessageConstant,
+		zap.String(pagesSourceBranchFieldNameConstant, status.SourceBranch),
+		zap.String(pagesTargetBranchFieldNameConstant, string(config.TargetBranch)),
+		zap.String(pagesSourcePathFieldNameConstant, status.SourcePath),
+	)
+
+	return true, nil
+}
+
+// legacyBranchUpdate returns the Pages configuration to apply when legacy builds publish from the source branch.
+func (manager *PagesManager) legacyBranchUpdate(status githubcli.PagesStatus, config PagesUpdateConfig) (githubcli.PagesConfiguration, bool) {
 	if !status.Enabled {
 		manager.logger.Debug(pagesSkipLogMessageConstant, zap.String(pagesBuildTypeFieldNameConstant, string(status.BuildType)))
-		return false, nil
+		return githubcli.PagesConfiguration{}, false
 	}
 
 	if status.BuildType != githubcli.PagesBuildTypeLegacy {
 		manager.logger.Debug(pagesSkipLogMessageConstant, zap.String(pagesBuildTypeFieldNameConstant, string(status.BuildType)))
-		return false, nil
+		return githubcli.PagesConfiguration{}, false
 	}
 
 	if status.SourceBranch != string(config.SourceBranch) {
 		manager.logger.Debug(pagesSkipLogMessageConstant, zap.String(pagesSourceBranchFieldNameConstant, status.SourceBranch))
-		return false, nil
+		return githubcli.PagesConfiguration{}, false
 	}
 
 	if status.SourceBranch == string(config.TargetBranch) {
 		manager.logger.Debug(pagesSkipLogMessageConstant, zap.String(pagesSourceBranchFieldNameConstant, status.SourceBranch))
-		return false, nil
+		return githubcli.PagesConfiguration{}, false
 	}
 
-	updateError := manager.githubClient.UpdatePagesConfig(executionContext, config.RepositoryIdentifier, githubcli.PagesConfiguration{
+	return githubcli.PagesConfiguration{
 		SourceBranch: string(config.TargetBranch),
 		SourcePath:   status.SourcePath,
-	})
-	if updateError != nil {
-		return false, updateError
-	}
-
-	manager.logger.Info(pagesUpdateLogMessageConstant,
-		zap.String(pagesSourceBranchFieldNameConstant, status.SourceBranch),
-		zap.String(pagesTargetBranchFieldNameConstant, string(config.TargetBranch)),
-		zap.String(pagesSourcePathFieldNameConstant, status.SourcePath),
-	)
-
-	return true, nil
+	}, true
 }
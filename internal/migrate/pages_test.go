@@ -58,8 +58,40 @@ func (stub *stubGitHubOperations) SetDefaultBranch(context.Context, string, stri
 	return nil
 }
 
-func (stub *stubGitHubOperations) CheckBranchProtection(context.Context, string, string) (bool, error) {
-	return false, nil
+func (stub *stubGitHubOperations) GetBranchProtection(context.Context, string, string) (githubcli.BranchProtection, bool, error) {
+	return githubcli.BranchProtection{}, false, nil
+}
+
+func (stub *stubGitHubOperations) ListBranchProtectionRules(context.Context, string) (githubcli.BranchProtectionRules, error) {
+	return githubcli.BranchProtectionRules{}, nil
+}
+
+func (stub *stubGitHubOperations) LockBranch(context.Context, string, string) error {
+	return nil
+}
+
+func (stub *stubGitHubOperations) GetPagesConfigResponse(context.Context, string) ([]byte, error) {
+	return nil, nil
+}
+
+func (stub *stubGitHubOperations) GetBranchProtectionResponse(context.Context, string, string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (stub *stubGitHubOperations) DescribeUpdatePagesConfig(repository string, configuration githubcli.PagesConfiguration) (githubcli.IntendedRequest, error) {
+	return (&githubcli.Client{}).DescribeUpdatePagesConfig(repository, configuration)
+}
+
+func (stub *stubGitHubOperations) DescribeSetDefaultBranch(repository string, branchName string) (githubcli.IntendedRequest, error) {
+	return (&githubcli.Client{}).DescribeSetDefaultBranch(repository, branchName)
+}
+
+func (stub *stubGitHubOperations) DescribeUpdatePullRequestBase(repository string, pullRequestNumber int, baseBranch string) (githubcli.IntendedRequest, error) {
+	return (&githubcli.Client{}).DescribeUpdatePullRequestBase(repository, pullRequestNumber, baseBranch)
+}
+
+func (stub *stubGitHubOperations) DescribeLockBranch(repository string, branchName string) (githubcli.IntendedRequest, error) {
+	return (&githubcli.Client{}).DescribeLockBranch(repository, branchName)
 }
 
 func TestPagesManagerScenarios(testInstance *testing.T) {
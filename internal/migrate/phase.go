@@ -0,0 +1,287 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	// AnnouncementNotesRef is the git notes ref holding the announce-phase markers. Each note is attached to the source
+	// branch tip at announce time.
+	AnnouncementNotesRef = "refs/notes/gix-branch-default"
+
+	gitNotesCommandNameConstant             = "notes"
+	gitNotesRefFlagTemplateConstant         = "--ref=%s"
+	gitNotesAddCommandConstant              = "add"
+	gitNotesListCommandConstant             = "list"
+	gitNotesShowCommandConstant             = "show"
+	gitNotesForceFlagConstant               = "--force"
+	gitNotesMessageFlagConstant             = "--message"
+	gitFetchCommandNameConstant             = "fetch"
+	gitRevParseCommandNameConstant          = "rev-parse"
+	gitForcedRefspecTemplateConstant        = "+%s:%s"
+	remoteBranchRefTemplateConstant         = "refs/remotes/%s/%s"
+	announcementSourceKeyConstant           = "source"
+	announcementTargetKeyConstant           = "target"
+	announcementTimeKeyConstant             = "announced"
+	announcementLineTemplateConstant        = "%s: %s\n"
+	announcementKeySeparatorConstant        = ":"
+	announcementRecordErrorTemplateConstant = "unable to record announce marker: %w"
+	announcementListErrorTemplateConstant   = "unable to list announce markers: %w"
+	announcementPushWarningTemplateConstant = "ANNOUNCE-PUSH-SKIP: %s"
+	enforceSkipWarningTemplateConstant      = "ENFORCE-SKIP: %s"
+	enforceMissingMarkerTemplateConstant    = "no announce marker for %s → %s"
+	enforceSwitchedBackTemplateConstant     = "default branch is %s again, not %s; it was switched back after announce"
+	enforceSourceMissingTemplateConstant    = "source branch %s no longer exists on %s"
+	enforceSourceMovedTemplateConstant      = "source branch %s moved since it was announced on %s"
+	enforceGracePeriodTemplateConstant      = "grace period ends %s"
+	enforceMetadataErrorTemplateConstant    = "unable to verify default branch: %w"
+	enforceRetentionRequiredMessage         = "enforce phase requires retain_source delete or archive"
+	graceWithoutEnforceMessageConstant      = "grace period requires the enforce phase"
+	unsupportedPhaseTemplateConstant        = "unsupported phase %q (expected announce or enforce)"
+	phaseFieldNameConstant                  = "phase"
+	gracePeriodFieldNameConstant            = "grace_period"
+	gracePeriodDaySuffixConstant            = "d"
+	gracePeriodInvalidTemplateConstant      = "invalid grace period %q (expected a duration such as 72h or 7d)"
+)
+
+// MigrationPhase splits a migration into a reversible announcement and a later enforcement.
+type MigrationPhase string
+
+// Supported migration phases. The empty phase performs the whole migration in one run.
+const (
+	// MigrationPhaseAnnounce switches the default branch, retargets pull requests, and copies protection, but never
+	// retires the source branch. It records an announce marker in AnnouncementNotesRef instead.
+	MigrationPhaseAnnounce MigrationPhase = MigrationPhase("announce")
+	// MigrationPhaseEnforce verifies the announce marker and the default branch and then retires the source branch
+	// behind the usual safety gates.
+	MigrationPhaseEnforce MigrationPhase = MigrationPhase("enforce")
+)
+
+// ParseMigrationPhase normalizes a user-supplied phase; the empty string selects the single-run migration.
+func ParseMigrationPhase(rawValue string) (MigrationPhase, error) {
+	normalized := MigrationPhase(strings.ToLower(strings.TrimSpace(rawValue)))
+	switch normalized {
+	case "", MigrationPhaseAnnounce, MigrationPhaseEnforce:
+		return normalized, nil
+	default:
+		return "", fmt.Errorf(unsupportedPhaseTemplateConstant, rawValue)
+	}
+}
+
+// ParseGracePeriod accepts Go durations and whole days written as <N>d; the empty string means no grace period.
+func ParseGracePeriod(rawValue string) (time.Duration, error) {
+	trimmedValue := strings.TrimSpace(rawValue)
+	if len(trimmedValue) == 0 {
+		return 0, nil
+	}
+	var gracePeriod time.Duration
+	var parseError error
+	if dayCount, isDays := strings.CutSuffix(trimmedValue, gracePeriodDaySuffixConstant); isDays {
+		var days int
+		days, parseError = strconv.Atoi(dayCount)
+		gracePeriod = time.Duration(days) * 24 * time.Hour
+	} else {
+		gracePeriod, parseError = time.ParseDuration(trimmedValue)
+	}
+	if parseError != nil || gracePeriod < 0 {
+		return 0, fmt.Errorf(gracePeriodInvalidTemplateConstant, rawValue)
+	}
+	return gracePeriod, nil
+}
+
+// Announcement is the announce-phase marker found for a repository.
+type Announcement struct {
+	SourceBranch BranchName
+	TargetBranch BranchName
+	AnnouncedAt  time.Time
+	// Commit is the source branch tip the marker is attached to.
+	Commit string
+}
+
+// FindAnnouncement fetches the announce markers from the remote and returns the most recent one recorded for the
+// target branch. Local markers are used when the remote has none.
+func (service *Service) FindAnnouncement(executionContext context.Context, repositoryPath string, remoteName string, targetBranch BranchName) (Announcement, bool, error) {
+	service.fetchAnnouncements(executionContext, repositoryPath, remoteName)
+
+	listResult, listError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitNotesCommandNameConstant, notesRefFlag(), gitNotesListCommandConstant},
+		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
+	})
+	if listError != nil {
+		return Announcement{}, false, fmt.Errorf(announcementListErrorTemplateConstant, listError)
+	}
+
+	announcements := make([]Announcement, 0)
+	for _, line := range strings.Split(listResult.StandardOutput, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		showResult, showError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+			Arguments:        []string{gitNotesCommandNameConstant, notesRefFlag(), gitNotesShowCommandConstant, fields[1]},
+			WorkingDirectory: repositoryPath,
+			Idempotent:       true,
+		})
+		if showError != nil {
+			continue
+		}
+		announcement, parsed := parseAnnouncement(showResult.StandardOutput)
+		if !parsed || announcement.TargetBranch != targetBranch {
+			continue
+		}
+		announcement.Commit = fields[1]
+		announcements = append(announcements, announcement)
+	}
+	if len(announcements) == 0 {
+		return Announcement{}, false, nil
+	}
+	sort.SliceStable(announcements, func(left int, right int) bool {
+		return announcements[left].AnnouncedAt.After(announcements[right].AnnouncedAt)
+	})
+	return announcements[0], true, nil
+}
+
+// recordAnnouncement attaches the announce marker to the source branch tip and pushes the notes ref when updates are
+// pushed. A failed push leaves the marker local and is reported as a warning.
+func (service *Service) recordAnnouncement(executionContext context.Context, options MigrationOptions) ([]string, error) {
+	service.fetchAnnouncements(executionContext, options.RepositoryPath, options.RepositoryRemoteName)
+
+	payload := strings.Join([]string{
+		fmt.Sprintf(announcementLineTemplateConstant, announcementSourceKeyConstant, options.SourceBranch),
+		fmt.Sprintf(announcementLineTemplateConstant, announcementTargetKeyConstant, options.TargetBranch),
+		fmt.Sprintf(announcementLineTemplateConstant, announcementTimeKeyConstant, service.clock.Now().UTC().Format(time.RFC3339)),
+	}, "")
+	if _, addError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments: []string{
+			gitNotesCommandNameConstant,
+			notesRefFlag(),
+			gitNotesAddCommandConstant,
+			gitNotesForceFlagConstant,
+			gitNotesMessageFlagConstant,
+			payload,
+			remoteBranchRef(options.RepositoryRemoteName, options.SourceBranch),
+		},
+		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       false,
+	}); addError != nil {
+		return nil, fmt.Errorf(announcementRecordErrorTemplateConstant, addError)
+	}
+
+	if !options.PushUpdates {
+		return nil, nil
+	}
+	if _, pushError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitPushCommandNameConstant, options.RepositoryRemoteName, AnnouncementNotesRef},
+		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       false,
+	}); pushError != nil {
+		service.logger.Warn(
+			"Announce marker push failed",
+			zap.String(repositoryPathFieldNameConstant, options.RepositoryPath),
+			zap.Error(pushError),
+		)
+		return []string{fmt.Sprintf(announcementPushWarningTemplateConstant, summarizeCommandError(pushError))}, nil
+	}
+	return nil, nil
+}
+
+// verifyAnnouncement checks the announce-phase state before enforcement and returns the reason to skip the
+// repository, or an empty string when enforcement may proceed.
+func (service *Service) verifyAnnouncement(executionContext context.Context, options MigrationOptions) (string, error) {
+	announcement, found, findError := service.FindAnnouncement(executionContext, options.RepositoryPath, options.RepositoryRemoteName, options.TargetBranch)
+	if findError != nil {
+		return "", findError
+	}
+	if !found || announcement.SourceBranch != options.SourceBranch {
+		return fmt.Sprintf(enforceMissingMarkerTemplateConstant, options.SourceBranch, options.TargetBranch), nil
+	}
+
+	metadata, metadataError := service.gitHubClient.ResolveRepoMetadata(executionContext, options.RepositoryIdentifier)
+	if metadataError != nil {
+		return "", fmt.Errorf(enforceMetadataErrorTemplateConstant, metadataError)
+	}
+	if currentDefault := BranchName(strings.TrimSpace(metadata.DefaultBranch)); currentDefault != options.TargetBranch {
+		return fmt.Sprintf(enforceSwitchedBackTemplateConstant, currentDefault, options.TargetBranch), nil
+	}
+
+	if options.GracePeriod > 0 {
+		graceEnd := announcement.AnnouncedAt.Add(options.GracePeriod)
+		if service.clock.Now().Before(graceEnd) {
+			return fmt.Sprintf(enforceGracePeriodTemplateConstant, graceEnd.UTC().Format(time.RFC3339)), nil
+		}
+	}
+
+	_, _ = service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitFetchCommandNameConstant, options.RepositoryRemoteName, string(options.SourceBranch)},
+		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       true,
+	})
+	tipResult, tipError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitRevParseCommandNameConstant, remoteBranchRef(options.RepositoryRemoteName, options.SourceBranch)},
+		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       true,
+	})
+	if tipError != nil {
+		return fmt.Sprintf(enforceSourceMissingTemplateConstant, options.SourceBranch, options.RepositoryRemoteName), nil
+	}
+	if strings.TrimSpace(tipResult.StandardOutput) != announcement.Commit {
+		return fmt.Sprintf(enforceSourceMovedTemplateConstant, options.SourceBranch, announcement.AnnouncedAt.UTC().Format(time.RFC3339)), nil
+	}
+	return "", nil
+}
+
+// fetchAnnouncements refreshes the local notes ref from the remote; a remote without markers is not an error.
+func (service *Service) fetchAnnouncements(executionContext context.Context, repositoryPath string, remoteName string) {
+	if _, fetchError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitFetchCommandNameConstant, remoteName, fmt.Sprintf(gitForcedRefspecTemplateConstant, AnnouncementNotesRef, AnnouncementNotesRef)},
+		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
+	}); fetchError != nil {
+		service.logger.Debug("Announce markers not fetched", zap.String(repositoryPathFieldNameConstant, repositoryPath), zap.Error(fetchError))
+	}
+}
+
+func parseAnnouncement(payload string) (Announcement, bool) {
+	announcement := Announcement{}
+	for _, line := range strings.Split(payload, "\n") {
+		key, value, found := strings.Cut(line, announcementKeySeparatorConstant)
+		if !found {
+			continue
+		}
+		trimmedValue := strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case announcementSourceKeyConstant:
+			announcement.SourceBranch = BranchName(trimmedValue)
+		case announcementTargetKeyConstant:
+			announcement.TargetBranch = BranchName(trimmedValue)
+		case announcementTimeKeyConstant:
+			announcedAt, parseError := time.Parse(time.RFC3339, trimmedValue)
+			if parseError != nil {
+				return Announcement{}, false
+			}
+			announcement.AnnouncedAt = announcedAt
+		}
+	}
+	if len(announcement.SourceBranch) == 0 || len(announcement.TargetBranch) == 0 || announcement.AnnouncedAt.IsZero() {
+		return Announcement{}, false
+	}
+	return announcement, true
+}
+
+func notesRefFlag() string {
+	return fmt.Sprintf(gitNotesRefFlagTemplateConstant, AnnouncementNotesRef)
+}
+
+func remoteBranchRef(remoteName string, branch BranchName) string {
+	return fmt.Sprintf(remoteBranchRefTemplateConstant, remoteName, string(branch))
+}
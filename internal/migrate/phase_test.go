@@ -0,0 +1,230 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+	"github.com/temirov/gix/internal/githubauth"
+	"github.com/temirov/gix/internal/githubcli"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const (
+	testAnnouncedCommitConstant = "4f2c1a9e"
+	testAnnouncementPayload     = "source: main\ntarget: master\nannounced: 2024-05-01T12:00:00Z\n"
+)
+
+func TestServiceExecuteAnnouncePhaseKeepsSourceBranch(testInstance *testing.T) {
+	testInstance.Setenv(githubauth.EnvGitHubCLIToken, testGitHubTokenValue)
+	testInstance.Setenv(githubauth.EnvGitHubToken, testGitHubTokenValue)
+
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(execshelltest.NewPermissiveExecutor())
+	require.NoError(testInstance, managerError)
+
+	githubOperations := &recordingGitHubOperations{}
+	gitExecutor := execshelltest.NewPermissiveExecutor()
+
+	service, serviceError := NewService(ServiceDependencies{
+		Logger:            zap.NewNop(),
+		RepositoryManager: repositoryManager,
+		GitHubClient:      githubOperations,
+		GitExecutor:       gitExecutor,
+		Clock:             fixedClock{instant: time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)},
+	})
+	require.NoError(testInstance, serviceError)
+
+	result, executionError := service.Execute(context.Background(), MigrationOptions{
+		RepositoryPath:       testInstance.TempDir(),
+		RepositoryRemoteName: "origin",
+		RepositoryIdentifier: "owner/example",
+		WorkflowsDirectory:   ".github/workflows",
+		SourceBranch:         BranchMain,
+		TargetBranch:         BranchMaster,
+		PushUpdates:          true,
+		RetainSource:         SourceRetentionDelete,
+		Phase:                MigrationPhaseAnnounce,
+	})
+	require.NoError(testInstance, executionError)
+	require.True(testInstance, githubOperations.defaultBranchSet)
+	require.True(testInstance, result.DefaultBranchUpdated)
+	require.True(testInstance, result.AnnouncementRecorded)
+	require.False(testInstance, result.SourceBranchDeleted)
+	require.Equal(testInstance, [][]string{
+		{"fetch", "origin", "+refs/notes/gix-branch-default:refs/notes/gix-branch-default"},
+		{"notes", "--ref=refs/notes/gix-branch-default", "add", "--force", "--message", testAnnouncementPayload, "refs/remotes/origin/main"},
+		{"push", "origin", "refs/notes/gix-branch-default"},
+	}, gitExecutor.ExecutedArguments(execshell.CommandGit))
+}
+
+func TestServiceExecuteEnforcePhaseVerifiesAnnouncement(testInstance *testing.T) {
+	testInstance.Setenv(githubauth.EnvGitHubCLIToken, testGitHubTokenValue)
+	testInstance.Setenv(githubauth.EnvGitHubToken, testGitHubTokenValue)
+
+	testCases := []struct {
+		name               string
+		notesList          string
+		currentDefault     string
+		sourceTip          string
+		gracePeriod        time.Duration
+		expectedSkipReason string
+	}{
+		{
+			name:           "retires_announced_source",
+			notesList:      "9d0e " + testAnnouncedCommitConstant + "\n",
+			currentDefault: "master",
+			sourceTip:      testAnnouncedCommitConstant,
+			gracePeriod:    72 * time.Hour,
+		},
+		{
+			name:               "missing_marker",
+			currentDefault:     "master",
+			expectedSkipReason: "no announce marker for main → master",
+		},
+		{
+			name:               "switched_back",
+			notesList:          "9d0e " + testAnnouncedCommitConstant + "\n",
+			currentDefault:     "main",
+			sourceTip:          testAnnouncedCommitConstant,
+			expectedSkipReason: "default branch is main again, not master; it was switched back after announce",
+		},
+		{
+			name:               "inside_grace_period",
+			notesList:          "9d0e " + testAnnouncedCommitConstant + "\n",
+			currentDefault:     "master",
+			sourceTip:          testAnnouncedCommitConstant,
+			gracePeriod:        7 * 24 * time.Hour,
+			expectedSkipReason: "grace period ends 2024-05-08T12:00:00Z",
+		},
+		{
+			name:               "source_moved",
+			notesList:          "9d0e " + testAnnouncedCommitConstant + "\n",
+			currentDefault:     "master",
+			sourceTip:          "7b3d5e01",
+			expectedSkipReason: "source branch main moved since it was announced on 2024-05-01T12:00:00Z",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			repositoryManager, managerError := gitrepo.NewRepositoryManager(execshelltest.NewPermissiveExecutor())
+			require.NoError(subtest, managerError)
+
+			githubOperations := &recordingGitHubOperations{metadata: githubcli.RepositoryMetadata{DefaultBranch: testCase.currentDefault}}
+			gitExecutor := execshelltest.NewPermissiveExecutor()
+			gitExecutor.OnGit("notes", "--ref=refs/notes/gix-branch-default", "list").ReturnOutput(testCase.notesList)
+			gitExecutor.OnGit("notes", "--ref=refs/notes/gix-branch-default", "show", testAnnouncedCommitConstant).ReturnOutput(testAnnouncementPayload)
+			gitExecutor.OnGit("rev-parse", "refs/remotes/origin/main").ReturnOutput(testCase.sourceTip + "\n")
+
+			service, serviceError := NewService(ServiceDependencies{
+				Logger:            zap.NewNop(),
+				RepositoryManager: repositoryManager,
+				GitHubClient:      githubOperations,
+				GitExecutor:       gitExecutor,
+				Clock:             fixedClock{instant: time.Date(2024, time.May, 5, 12, 0, 0, 0, time.UTC)},
+			})
+			require.NoError(subtest, serviceError)
+
+			result, executionError := service.Execute(context.Background(), MigrationOptions{
+				RepositoryPath:       subtest.TempDir(),
+				RepositoryRemoteName: "origin",
+				RepositoryIdentifier: "owner/example",
+				WorkflowsDirectory:   ".github/workflows",
+				SourceBranch:         BranchMain,
+				TargetBranch:         BranchMaster,
+				RetainSource:         SourceRetentionDelete,
+				Phase:                MigrationPhaseEnforce,
+				GracePeriod:          testCase.gracePeriod,
+			})
+			require.NoError(subtest, executionError)
+			require.False(subtest, githubOperations.defaultBranchSet)
+			require.False(subtest, result.DefaultBranchUpdated)
+			require.Equal(subtest, testCase.expectedSkipReason, result.EnforceSkipReason)
+			if len(testCase.expectedSkipReason) > 0 {
+				require.False(subtest, result.SourceBranchDeleted)
+				require.Equal(subtest, []string{"ENFORCE-SKIP: " + testCase.expectedSkipReason}, result.Warnings)
+				require.NotContains(subtest, gitExecutor.ExecutedArguments(execshell.CommandGit), []string{"push", "origin", "--delete", "main"})
+				return
+			}
+			require.True(subtest, result.SourceBranchDeleted)
+			require.Contains(subtest, gitExecutor.ExecutedArguments(execshell.CommandGit), []string{"push", "origin", "--delete", "main"})
+		})
+	}
+}
+
+func TestServiceExecuteRejectsInvalidPhaseOptions(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		phase         MigrationPhase
+		retainSource  SourceRetentionMode
+		gracePeriod   time.Duration
+		expectedField string
+	}{
+		{name: "unknown_phase", phase: MigrationPhase("later"), expectedField: "phase"},
+		{name: "enforce_without_retention", phase: MigrationPhaseEnforce, expectedField: "phase"},
+		{name: "grace_without_enforce", phase: MigrationPhaseAnnounce, gracePeriod: time.Hour, expectedField: "grace_period"},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			repositoryManager, managerError := gitrepo.NewRepositoryManager(execshelltest.NewPermissiveExecutor())
+			require.NoError(subtest, managerError)
+			service, serviceError := NewService(ServiceDependencies{
+				RepositoryManager: repositoryManager,
+				GitHubClient:      &recordingGitHubOperations{},
+				GitExecutor:       execshelltest.NewPermissiveExecutor(),
+			})
+			require.NoError(subtest, serviceError)
+
+			_, executionError := service.Execute(context.Background(), MigrationOptions{
+				RepositoryPath:       subtest.TempDir(),
+				RepositoryRemoteName: "origin",
+				RepositoryIdentifier: "owner/example",
+				WorkflowsDirectory:   ".github/workflows",
+				SourceBranch:         BranchMain,
+				TargetBranch:         BranchMaster,
+				RetainSource:         testCase.retainSource,
+				Phase:                testCase.phase,
+				GracePeriod:          testCase.gracePeriod,
+			})
+			var inputError InvalidInputError
+			require.ErrorAs(subtest, executionError, &inputError)
+			require.Equal(subtest, testCase.expectedField, inputError.FieldName)
+		})
+	}
+}
+
+func TestParseGracePeriod(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expected      time.Duration
+		expectedError bool
+	}{
+		{name: "empty", value: "", expected: 0},
+		{name: "hours", value: "72h", expected: 72 * time.Hour},
+		{name: "days", value: "7d", expected: 7 * 24 * time.Hour},
+		{name: "negative", value: "-1h", expectedError: true},
+		{name: "garbage", value: "soon", expectedError: true},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			gracePeriod, parseError := ParseGracePeriod(testCase.value)
+			if testCase.expectedError {
+				require.Error(subtest, parseError)
+				return
+			}
+			require.NoError(subtest, parseError)
+			require.Equal(subtest, testCase.expected, gracePeriod)
+		})
+	}
+}
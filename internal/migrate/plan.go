@@ -0,0 +1,276 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const (
+	planPagesDescriptionTemplateConstant         = "update Pages source %s → %s"
+	planDefaultBranchDescriptionTemplateConstant = "set default branch %s → %s"
+	planRetargetDescriptionTemplateConstant      = "retarget pull request #%d %s → %s"
+	planLockDescriptionTemplateConstant          = "lock archive branch %s (after the safety gates pass)"
+	planRequestLineTemplateConstant              = "PLAN-REQUEST: %s %s (%s)\n"
+	planSourceProtectionLineTemplateConstant     = "PLAN-PROTECTION: %s (current protection of %s)\n"
+	planInstructionsLineTemplateConstant         = "PLAN-INSTRUCTIONS: %s (steps for contributors after the migration)\n"
+	planCurrentHeadingConstant                   = "current:\n"
+	planPayloadHeadingConstant                   = "payload:\n"
+	planNoCurrentStateConstant                   = "none"
+	planBodyIndentConstant                       = "  "
+	planLineTerminatorConstant                   = "\n"
+	planJSONNullConstant                         = "null"
+	planVolatileUpdatedAtKeyConstant             = "updated_at"
+	planTokenQueryIndicatorConstant              = "token"
+	planURLSchemeSeparatorConstant               = "://"
+	planBranchProtectionEndpointTemplateConstant = "repos/%s/branches/%s/protection"
+)
+
+// PlannedRequest pairs a mutation a migration would send with the current state it replaces. CurrentBody holds the
+// sanitized, pretty-printed response body and is empty when there is nothing to compare against.
+type PlannedRequest struct {
+	Description string
+	Request     githubcli.IntendedRequest
+	CurrentBody []byte
+}
+
+// MigrationPlan lists the GitHub requests a migration would send. SourceProtection holds the sanitized protection
+// response body of the source branch, which the safety gates read before deletion or archiving, and is empty when the
+// branch is unprotected. Instructions describe the steps contributors follow once the migration ran.
+type MigrationPlan struct {
+	SourceBranch     BranchName
+	Repository       string
+	Requests         []PlannedRequest
+	SourceProtection []byte
+	Instructions     ContributorInstructions
+	Warnings         []string
+}
+
+// Plan reads the current GitHub state and describes the requests Execute would send without changing anything. Reads
+// that fail become warnings, as they do during Execute.
+func (service *Service) Plan(executionContext context.Context, options MigrationOptions) (MigrationPlan, error) {
+	if validationError := service.validateOptions(options); validationError != nil {
+		return MigrationPlan{}, validationError
+	}
+
+	plan := MigrationPlan{SourceBranch: options.SourceBranch, Repository: options.RepositoryIdentifier}
+	retiresSource := options.Phase != MigrationPhaseAnnounce
+	plan.Instructions = NewContributorInstructions(options, MigrationResult{
+		SourceBranchDeleted: retiresSource && (options.DeleteSourceBranch || options.RetainSource == SourceRetentionDelete),
+	})
+
+	if options.Phase != MigrationPhaseEnforce {
+		if pagesError := service.planPagesUpdate(executionContext, options, &plan); pagesError != nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf(pagesUpdateWarningTemplateConstant, options.RepositoryIdentifier, summarizeCommandError(pagesError)))
+		}
+
+		defaultBranchRequest, describeError := service.gitHubClient.DescribeSetDefaultBranch(options.RepositoryIdentifier, string(options.TargetBranch))
+		if describeError != nil {
+			return MigrationPlan{}, describeError
+		}
+		plan.Requests = append(plan.Requests, PlannedRequest{
+			Description: fmt.Sprintf(planDefaultBranchDescriptionTemplateConstant, options.SourceBranch, options.TargetBranch),
+			Request:     defaultBranchRequest,
+		})
+	}
+
+	pullRequests, listError := service.gitHubClient.ListPullRequests(executionContext, options.RepositoryIdentifier, githubcli.PullRequestListOptions{
+		State:       githubcli.PullRequestStateOpen,
+		BaseBranch:  string(options.SourceBranch),
+		ResultLimit: defaultPullRequestQueryLimit,
+	})
+	if listError != nil {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf(pullRequestListWarningTemplateConstant, options.RepositoryIdentifier, summarizeCommandError(listError)))
+	}
+	for _, pullRequest := range pullRequests {
+		retargetRequest, retargetError := service.gitHubClient.DescribeUpdatePullRequestBase(options.RepositoryIdentifier, pullRequest.Number, string(options.TargetBranch))
+		if retargetError != nil {
+			return MigrationPlan{}, retargetError
+		}
+		plan.Requests = append(plan.Requests, PlannedRequest{
+			Description: fmt.Sprintf(planRetargetDescriptionTemplateConstant, pullRequest.Number, options.SourceBranch, options.TargetBranch),
+			Request:     retargetRequest,
+		})
+	}
+
+	sourceProtection, sourceProtected, protectionError := service.gitHubClient.GetBranchProtectionResponse(executionContext, options.RepositoryIdentifier, string(options.SourceBranch))
+	if protectionError != nil {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf(branchProtectionWarningTemplateConstant, summarizeCommandError(protectionError)))
+	} else if sourceProtected {
+		sanitizedProtection, sanitizeError := SanitizeResponseBody(sourceProtection)
+		if sanitizeError != nil {
+			return MigrationPlan{}, sanitizeError
+		}
+		plan.SourceProtection = sanitizedProtection
+	}
+
+	if retiresSource && options.RetainSource == SourceRetentionArchive {
+		archivedBranch := ArchiveBranchName(options.SourceBranch, service.clock.Now())
+		lockRequest, lockDescribeError := service.gitHubClient.DescribeLockBranch(options.RepositoryIdentifier, archivedBranch)
+		if lockDescribeError != nil {
+			return MigrationPlan{}, lockDescribeError
+		}
+		plannedLock := PlannedRequest{Description: fmt.Sprintf(planLockDescriptionTemplateConstant, archivedBranch), Request: lockRequest}
+		archiveProtection, archiveProtected, archiveProtectionError := service.gitHubClient.GetBranchProtectionResponse(executionContext, options.RepositoryIdentifier, archivedBranch)
+		if archiveProtectionError != nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf(branchProtectionWarningTemplateConstant, summarizeCommandError(archiveProtectionError)))
+		} else if archiveProtected {
+			sanitizedProtection, sanitizeError := SanitizeResponseBody(archiveProtection)
+			if sanitizeError != nil {
+				return MigrationPlan{}, sanitizeError
+			}
+			plannedLock.CurrentBody = sanitizedProtection
+		}
+		plan.Requests = append(plan.Requests, plannedLock)
+		plan.Instructions.ArchivedSourceBranch = archivedBranch
+		plan.Instructions.SourceBranchDeleted = false
+	}
+
+	return plan, nil
+}
+
+func (service *Service) planPagesUpdate(executionContext context.Context, options MigrationOptions, plan *MigrationPlan) error {
+	responseBody, responseError := service.gitHubClient.GetPagesConfigResponse(executionContext, options.RepositoryIdentifier)
+	if responseError != nil {
+		return responseError
+	}
+	status, parseError := githubcli.ParsePagesStatus(responseBody)
+	if parseError != nil {
+		return parseError
+	}
+
+	configuration, updateRequired := service.pagesManager.legacyBranchUpdate(status, PagesUpdateConfig{
+		RepositoryIdentifier: options.RepositoryIdentifier,
+		SourceBranch:         options.SourceBranch,
+		TargetBranch:         options.TargetBranch,
+	})
+	if !updateRequired {
+		return nil
+	}
+
+	request, describeError := service.gitHubClient.DescribeUpdatePagesConfig(options.RepositoryIdentifier, configuration)
+	if describeError != nil {
+		return describeError
+	}
+	currentBody, sanitizeError := SanitizeResponseBody(responseBody)
+	if sanitizeError != nil {
+		return sanitizeError
+	}
+	plan.Requests = append(plan.Requests, PlannedRequest{
+		Description: fmt.Sprintf(planPagesDescriptionTemplateConstant, status.SourceBranch, configuration.SourceBranch),
+		Request:     request,
+		CurrentBody: currentBody,
+	})
+	return nil
+}
+
+// SanitizeResponseBody pretty-prints a GitHub response body without its volatile fields: updated_at timestamps and URLs
+// that carry credentials or token query parameters. Empty and null bodies yield nil.
+func SanitizeResponseBody(responseBody []byte) ([]byte, error) {
+	trimmedBody := bytes.TrimSpace(responseBody)
+	if len(trimmedBody) == 0 || string(trimmedBody) == planJSONNullConstant {
+		return nil, nil
+	}
+
+	var document any
+	if decodeError := json.Unmarshal(trimmedBody, &document); decodeError != nil {
+		return nil, decodeError
+	}
+
+	return json.MarshalIndent(stripVolatileFields(document), "", planBodyIndentConstant)
+}
+
+func stripVolatileFields(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, nested := range typed {
+			if key == planVolatileUpdatedAtKeyConstant {
+				delete(typed, key)
+				continue
+			}
+			if text, isText := nested.(string); isText && urlCarriesCredentials(text) {
+				delete(typed, key)
+				continue
+			}
+			typed[key] = stripVolatileFields(nested)
+		}
+		return typed
+	case []any:
+		for index, nested := range typed {
+			typed[index] = stripVolatileFields(nested)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+func urlCarriesCredentials(text string) bool {
+	if !strings.Contains(text, planURLSchemeSeparatorConstant) {
+		return false
+	}
+	parsedURL, parseError := url.Parse(text)
+	if parseError != nil {
+		return false
+	}
+	if parsedURL.User != nil {
+		return true
+	}
+	for parameter := range parsedURL.Query() {
+		if strings.Contains(strings.ToLower(parameter), planTokenQueryIndicatorConstant) {
+			return true
+		}
+	}
+	return false
+}
+
+// Render writes the plan as PLAN-REQUEST blocks, each followed by the current state it replaces and the pretty-printed
+// payload, then the source branch protection, the contributor instructions, and any warnings.
+func (plan MigrationPlan) Render(writer io.Writer) error {
+	for _, planned := range plan.Requests {
+		fmt.Fprintf(writer, planRequestLineTemplateConstant, planned.Request.Method, planned.Request.Endpoint, planned.Description)
+		fmt.Fprint(writer, planCurrentHeadingConstant)
+		writePlanBody(writer, planned.CurrentBody)
+		var payload bytes.Buffer
+		if indentError := json.Indent(&payload, planned.Request.Payload, "", planBodyIndentConstant); indentError != nil {
+			return indentError
+		}
+		fmt.Fprint(writer, planPayloadHeadingConstant)
+		writePlanBody(writer, payload.Bytes())
+	}
+	if len(plan.SourceProtection) > 0 {
+		endpoint := fmt.Sprintf(planBranchProtectionEndpointTemplateConstant, plan.Repository, plan.SourceBranch)
+		fmt.Fprintf(writer, planSourceProtectionLineTemplateConstant, endpoint, plan.SourceBranch)
+		writePlanBody(writer, plan.SourceProtection)
+	}
+	if len(plan.Instructions.TargetBranch) > 0 {
+		fmt.Fprintf(writer, planInstructionsLineTemplateConstant, plan.Repository)
+		if instructionsError := plan.Instructions.WriteIndented(writer); instructionsError != nil {
+			return instructionsError
+		}
+	}
+	for _, warning := range plan.Warnings {
+		fmt.Fprintln(writer, warning)
+	}
+	return nil
+}
+
+func writePlanBody(writer io.Writer, body []byte) {
+	if len(body) == 0 {
+		fmt.Fprint(writer, planBodyIndentConstant+planNoCurrentStateConstant+planLineTerminatorConstant)
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(string(body), planLineTerminatorConstant), planLineTerminatorConstant) {
+		if len(line) == 0 {
+			fmt.Fprint(writer, planLineTerminatorConstant)
+			continue
+		}
+		fmt.Fprint(writer, planBodyIndentConstant+line+planLineTerminatorConstant)
+	}
+}
@@ -1,16 +1,67 @@
 package migrate
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/temirov/gix/internal/githubcli"
+)
+
 const (
-	safetyReasonOpenPullRequestsConstant = "open pull requests still target source branch"
-	safetyReasonBranchProtectedConstant  = "source branch is protected"
-	safetyReasonWorkflowMentionsConstant = "workflow files still reference source branch"
+	safetyReasonOpenPullRequestsConstant         = "open pull requests still target source branch"
+	safetyReasonBranchProtectedConstant          = "source branch is protected"
+	safetyReasonWorkflowMentionsConstant         = "workflow files still reference source branch"
+	safetyReasonDeletionsRestrictedConstant      = "branch protection restricts deletions"
+	safetyReasonBranchLockedConstant             = "source branch is locked"
+	safetyReasonRequiredChecksTemplateConstant   = "required checks would be lost (%s)"
+	safetyReasonRequiredReviewsTemplateConstant  = "required approving reviews would be lost (%d)"
+	safetyReasonPushRestrictionsConstant         = "push restrictions would be lost"
+	safetyReasonListSeparatorConstant            = ", "
+	safetyReasonPatternDeletionsTemplateConstant = "protection rule %q matching %s restricts deletions"
+	safetyReasonPatternForcePushTemplateConstant = "protection rule %q matching %s blocks force pushes"
+	safetyReasonBranchConjunctionConstant        = " and "
 )
 
-// SafetyInputs captures conditions that influence branch deletion safety.
+// SafetyInputs captures conditions that influence branch deletion safety. When BranchProtection is set its rules
+// produce specific blocking reasons; BranchProtected alone yields the generic protected reason. ProtectionRuleMatches
+// carries wildcard protection rules, which the per-branch protection lookup does not report.
 type SafetyInputs struct {
-	OpenPullRequestCount int
-	BranchProtected      bool
-	WorkflowMentions     bool
+	OpenPullRequestCount  int
+	BranchProtected       bool
+	BranchProtection      *githubcli.BranchProtection
+	ProtectionRuleMatches []ProtectionRuleMatch
+	WorkflowMentions      bool
+}
+
+// ProtectionRuleMatch records a wildcard protection rule and the migration branches its pattern matches.
+type ProtectionRuleMatch struct {
+	Rule          githubcli.BranchProtectionRule
+	MatchesSource bool
+	MatchesTarget bool
+	SourceBranch  BranchName
+	TargetBranch  BranchName
+}
+
+// matchProtectionRules pairs the repository's wildcard protection rules with the source and target branches they match.
+func matchProtectionRules(rules githubcli.BranchProtectionRules, sourceBranch BranchName, targetBranch BranchName) []ProtectionRuleMatch {
+	var matches []ProtectionRuleMatch
+	for _, rule := range rules.Rules {
+		if !githubcli.IsWildcardBranchPattern(rule.Pattern) {
+			continue
+		}
+		patternRules := githubcli.BranchProtectionRules{Patterns: []string{rule.Pattern}}
+		match := ProtectionRuleMatch{
+			Rule:          rule,
+			MatchesSource: patternRules.Protects(string(sourceBranch)),
+			MatchesTarget: patternRules.Protects(string(targetBranch)),
+			SourceBranch:  sourceBranch,
+			TargetBranch:  targetBranch,
+		}
+		if match.MatchesSource || match.MatchesTarget {
+			matches = append(matches, match)
+		}
+	}
+	return matches
 }
 
 // SafetyStatus conveys whether it is safe to delete the source branch.
@@ -28,12 +79,56 @@ func (SafetyEvaluator) Evaluate(inputs SafetyInputs) SafetyStatus {
 	if inputs.OpenPullRequestCount > 0 {
 		blockingReasons = append(blockingReasons, safetyReasonOpenPullRequestsConstant)
 	}
-	if inputs.BranchProtected {
+	if inputs.BranchProtection != nil {
+		blockingReasons = append(blockingReasons, branchProtectionBlockingReasons(*inputs.BranchProtection)...)
+	} else if inputs.BranchProtected {
 		blockingReasons = append(blockingReasons, safetyReasonBranchProtectedConstant)
 	}
+	blockingReasons = append(blockingReasons, protectionRuleBlockingReasons(inputs.ProtectionRuleMatches)...)
 	if inputs.WorkflowMentions {
 		blockingReasons = append(blockingReasons, safetyReasonWorkflowMentionsConstant)
 	}
 
 	return SafetyStatus{SafeToDelete: len(blockingReasons) == 0, BlockingReasons: blockingReasons}
 }
+
+func branchProtectionBlockingReasons(protection githubcli.BranchProtection) []string {
+	reasons := make([]string, 0, 4)
+	if !protection.AllowDeletions {
+		reasons = append(reasons, safetyReasonDeletionsRestrictedConstant)
+	}
+	if protection.LockBranch {
+		reasons = append(reasons, safetyReasonBranchLockedConstant)
+	}
+	if protection.RequiredStatusChecks != nil && len(protection.RequiredStatusChecks.Contexts) > 0 {
+		checkList := strings.Join(protection.RequiredStatusChecks.Contexts, safetyReasonListSeparatorConstant)
+		reasons = append(reasons, fmt.Sprintf(safetyReasonRequiredChecksTemplateConstant, checkList))
+	}
+	if protection.RequiredPullRequestReviews != nil && protection.RequiredPullRequestReviews.RequiredApprovingReviewCount > 0 {
+		reasons = append(reasons, fmt.Sprintf(safetyReasonRequiredReviewsTemplateConstant, protection.RequiredPullRequestReviews.RequiredApprovingReviewCount))
+	}
+	if protection.Restrictions != nil {
+		reasons = append(reasons, safetyReasonPushRestrictionsConstant)
+	}
+	return reasons
+}
+
+func protectionRuleBlockingReasons(matches []ProtectionRuleMatch) []string {
+	var reasons []string
+	for _, match := range matches {
+		if !match.MatchesSource {
+			continue
+		}
+		matchedBranches := string(match.SourceBranch)
+		if match.MatchesTarget {
+			matchedBranches += safetyReasonBranchConjunctionConstant + string(match.TargetBranch)
+		}
+		if !match.Rule.AllowsDeletions {
+			reasons = append(reasons, fmt.Sprintf(safetyReasonPatternDeletionsTemplateConstant, match.Rule.Pattern, matchedBranches))
+		}
+		if !match.Rule.AllowsForcePushes {
+			reasons = append(reasons, fmt.Sprintf(safetyReasonPatternForcePushTemplateConstant, match.Rule.Pattern, matchedBranches))
+		}
+	}
+	return reasons
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/temirov/gix/internal/githubcli"
 	migrate "github.com/temirov/gix/internal/migrate"
 )
 
@@ -42,6 +43,44 @@ func TestSafetyEvaluatorScenarios(testInstance *testing.T) {
 			expectedSafe:    false,
 			expectedReasons: []string{"source branch is protected"},
 		},
+		{
+			name: "protection_restricts_deletions",
+			inputs: migrate.SafetyInputs{
+				BranchProtected:  true,
+				BranchProtection: &githubcli.BranchProtection{EnforceAdmins: true},
+			},
+			expectedSafe:    false,
+			expectedReasons: []string{"branch protection restricts deletions"},
+		},
+		{
+			name: "protection_rules_would_be_lost",
+			inputs: migrate.SafetyInputs{
+				BranchProtected: true,
+				BranchProtection: &githubcli.BranchProtection{
+					RequiredStatusChecks:       &githubcli.RequiredStatusChecks{Contexts: []string{"ci/build", "ci/lint"}},
+					RequiredPullRequestReviews: &githubcli.RequiredPullRequestReviews{RequiredApprovingReviewCount: 2},
+					Restrictions:               &githubcli.BranchRestrictions{Users: []string{"octocat"}},
+					AllowDeletions:             true,
+					LockBranch:                 true,
+				},
+			},
+			expectedSafe: false,
+			expectedReasons: []string{
+				"source branch is locked",
+				"required checks would be lost (ci/build, ci/lint)",
+				"required approving reviews would be lost (2)",
+				"push restrictions would be lost",
+			},
+		},
+		{
+			name: "protection_permits_deletion",
+			inputs: migrate.SafetyInputs{
+				BranchProtected:  true,
+				BranchProtection: &githubcli.BranchProtection{AllowDeletions: true},
+			},
+			expectedSafe:    true,
+			expectedReasons: []string{},
+		},
 		{
 			name: "workflow_mentions",
 			inputs: migrate.SafetyInputs{
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -12,47 +13,65 @@ import (
 	"github.com/temirov/gix/internal/githubauth"
 	"github.com/temirov/gix/internal/githubcli"
 	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/repos/shared"
 	"github.com/temirov/gix/internal/utils"
 )
 
 const (
-	repositoryPathFieldNameConstant                 = "repository_path"
-	remoteNameFieldNameConstant                     = "remote_name"
-	repositoryIdentifierFieldNameConstant           = "repository_identifier"
-	workflowsDirectoryFieldNameConstant             = "workflows_directory"
-	sourceBranchFieldNameConstant                   = "source_branch"
-	targetBranchFieldNameConstant                   = "target_branch"
-	gitAddCommandNameConstant                       = "add"
-	gitAllFlagConstant                              = "-A"
-	gitCommitCommandNameConstant                    = "commit"
-	gitMessageFlagConstant                          = "-m"
-	gitPushCommandNameConstant                      = "push"
-	gitBranchCommandNameConstant                    = "branch"
-	gitDeleteForceFlagConstant                      = "-D"
-	gitPushDeleteFlagConstant                       = "--delete"
-	workflowCommitMessageTemplateConstant           = "CI: switch workflow branch filters to %s"
-	cleanWorktreeRequiredMessageConstant            = "repository worktree must be clean before migration"
-	repositoryManagerMissingMessageConstant         = "repository manager not configured"
-	githubClientMissingMessageConstant              = "GitHub client not configured"
-	gitExecutorMissingMessageConstant               = "git executor not configured"
-	workflowRewriteErrorTemplateConstant            = "workflow rewrite failed: %w"
-	workflowStageErrorTemplateConstant              = "unable to stage workflow updates: %w"
-	workflowCommitErrorTemplateConstant             = "unable to commit workflow updates: %w"
-	workflowPushErrorTemplateConstant               = "unable to push workflow updates: %w"
-	pagesUpdateErrorTemplateConstant                = "GitHub Pages update failed: %w"
-	pagesUpdateWarningMessageConstant               = "GitHub Pages update skipped"
-	pagesUpdateWarningTemplateConstant              = "PAGES-SKIP: %s (%s)"
-	defaultBranchUpdateErrorMessageTemplateConstant = "DEFAULT-BRANCH-UPDATE repository=%s path=%s source=%s target=%s"
-	pullRequestListErrorTemplateConstant            = "unable to list pull requests: %w"
-	pullRequestListWarningTemplateConstant          = "PR-LIST-SKIP: %s (%s)"
-	pullRequestRetargetErrorTemplateConstant        = "unable to retarget pull request #%d: %w"
-	pullRequestRetargetWarningTemplateConstant      = "PR-RETARGET-SKIP: #%d (%s)"
-	branchProtectionCheckErrorTemplateConstant      = "unable to determine branch protection: %w"
-	branchProtectionWarningTemplateConstant         = "PROTECTION-SKIP: %s"
-	localBranchDeleteErrorTemplateConstant          = "unable to delete local source branch: %w"
-	remoteBranchDeleteErrorTemplateConstant         = "unable to delete remote source branch: %w"
-	branchDeletionWarningTemplateConstant           = "DELETE-SKIP: %s"
-	branchDeletionSkippedMessageConstant            = "Skipping source branch deletion because safety gates blocked deletion"
+	repositoryPathFieldNameConstant                    = "repository_path"
+	remoteNameFieldNameConstant                        = "remote_name"
+	repositoryIdentifierFieldNameConstant              = "repository_identifier"
+	workflowsDirectoryFieldNameConstant                = "workflows_directory"
+	sourceBranchFieldNameConstant                      = "source_branch"
+	targetBranchFieldNameConstant                      = "target_branch"
+	gitAddCommandNameConstant                          = "add"
+	gitAllFlagConstant                                 = "-A"
+	automatedCommitCommandNameConstant                 = "branch default"
+	gitPushCommandNameConstant                         = "push"
+	gitBranchCommandNameConstant                       = "branch"
+	gitDeleteForceFlagConstant                         = "-D"
+	gitPushDeleteFlagConstant                          = "--delete"
+	gitUpdateRefCommandNameConstant                    = "update-ref"
+	gitUpdateRefDeleteFlagConstant                     = "-d"
+	workflowCommitMessageTemplateConstant              = "CI: switch workflow branch filters to %s"
+	branchReferencesCommitMessageTemplateConstant      = "Switch workflow and documentation branch references to %s"
+	cleanWorktreeRequiredMessageConstant               = "repository worktree must be clean before migration"
+	dirtyWorktreeErrorTemplateConstant                 = "%w (%s)"
+	operationInProgressMessageConstant                 = "repository has an unfinished git operation; finish or abort it before migration"
+	operationInProgressErrorTemplateConstant           = "%w (%s in progress)"
+	repositoryManagerMissingMessageConstant            = "repository manager not configured"
+	githubClientMissingMessageConstant                 = "GitHub client not configured"
+	gitExecutorMissingMessageConstant                  = "git executor not configured"
+	workflowRewriteErrorTemplateConstant               = "workflow rewrite failed: %w"
+	documentationRewriteErrorTemplateConstant          = "documentation rewrite failed: %w"
+	workflowStageErrorTemplateConstant                 = "unable to stage workflow updates: %w"
+	workflowCommitErrorTemplateConstant                = "unable to commit workflow updates: %w"
+	workflowPushErrorTemplateConstant                  = "unable to push workflow updates: %w"
+	pagesUpdateErrorTemplateConstant                   = "GitHub Pages update failed: %w"
+	pagesUpdateWarningMessageConstant                  = "GitHub Pages update skipped"
+	pagesUpdateWarningTemplateConstant                 = "PAGES-SKIP: %s (%s)"
+	defaultBranchUpdateErrorMessageTemplateConstant    = "DEFAULT-BRANCH-UPDATE repository=%s path=%s source=%s target=%s"
+	pullRequestListErrorTemplateConstant               = "unable to list pull requests: %w"
+	pullRequestListWarningTemplateConstant             = "PR-LIST-SKIP: %s (%s)"
+	pullRequestRetargetErrorTemplateConstant           = "unable to retarget pull request #%d: %w"
+	pullRequestRetargetWarningTemplateConstant         = "PR-RETARGET-SKIP: #%d (%s)"
+	branchProtectionCheckErrorTemplateConstant         = "unable to determine branch protection: %w"
+	branchProtectionWarningTemplateConstant            = "PROTECTION-SKIP: %s"
+	localBranchDeleteErrorTemplateConstant             = "unable to delete local source branch: %w"
+	remoteBranchDeleteErrorTemplateConstant            = "unable to delete remote source branch: %w"
+	branchDeletionWarningTemplateConstant              = "DELETE-SKIP: %s"
+	branchDeletionSkippedMessageConstant               = "Skipping source branch deletion because safety gates blocked deletion"
+	archiveBranchNameTemplateConstant                  = "archive/%s-%s"
+	archiveBranchDateLayoutConstant                    = "2006-01-02"
+	archivePushRefspecTemplateConstant                 = "refs/remotes/%s/%s:refs/heads/%s"
+	remoteTrackingReferenceTemplateConstant            = "refs/remotes/%s/%s"
+	remoteTrackingReferenceDeleteErrorTemplateConstant = "unable to delete remote-tracking ref %s: %w"
+	archivePushErrorTemplateConstant                   = "unable to push archive branch %s: %w"
+	archiveLockErrorTemplateConstant                   = "unable to lock archive branch %s: %w"
+	archiveWarningTemplateConstant                     = "ARCHIVE-SKIP: %s"
+	archiveLockWarningTemplateConstant                 = "ARCHIVE-LOCK-SKIP: %s"
+	unsupportedRetentionModeTemplateConstant           = "unsupported source retention mode %q (expected delete or archive)"
+	retainSourceFieldNameConstant                      = "retain_source"
 )
 
 // InvalidInputError describes migration option validation failures.
@@ -72,6 +91,7 @@ type ServiceDependencies struct {
 	RepositoryManager *gitrepo.RepositoryManager
 	GitHubClient      GitHubOperations
 	GitExecutor       CommandExecutor
+	Clock             shared.Clock
 }
 
 // MigrationOptions configures the migrate workflow.
@@ -85,6 +105,19 @@ type MigrationOptions struct {
 	PushUpdates          bool
 	EnableDebugLogging   bool
 	DeleteSourceBranch   bool
+	RetainSource         SourceRetentionMode
+	// LeaveTombstone commits and pushes a BRANCH_MOVED.md notice to the source branch before it is deleted or archived.
+	// The source branch is kept when the notice cannot be pushed.
+	LeaveTombstone bool
+	// UpdateDocumentation rewrites branch references in the files matching DocumentationPatterns and commits them
+	// together with the workflow updates.
+	UpdateDocumentation bool
+	// DocumentationPatterns overrides DefaultDocumentationPatterns when non-empty.
+	DocumentationPatterns []string
+	// Phase splits the migration into announce and enforce runs; empty performs it in one run.
+	Phase MigrationPhase
+	// GracePeriod is the minimum time between announce and enforce; enforce skips repositories announced more recently.
+	GracePeriod time.Duration
 }
 
 // WorkflowOutcome captures workflow rewrite results.
@@ -96,11 +129,19 @@ type WorkflowOutcome struct {
 // MigrationResult captures the observable outcomes.
 type MigrationResult struct {
 	WorkflowOutcome           WorkflowOutcome
+	DocumentationOutcome      DocumentationOutcome
 	PagesConfigurationUpdated bool
 	DefaultBranchUpdated      bool
 	RetargetedPullRequests    []int
 	SafetyStatus              SafetyStatus
-	Warnings                  []string
+	SourceBranchDeleted       bool
+	ArchivedSourceBranch      string
+	TombstoneCommitted        bool
+	// AnnouncementRecorded reports that the announce phase attached its marker to the source branch.
+	AnnouncementRecorded bool
+	// EnforceSkipReason explains why the enforce phase left the repository untouched.
+	EnforceSkipReason string
+	Warnings          []string
 }
 
 // DefaultBranchUpdateError describes default-branch update failures with context.
@@ -143,8 +184,10 @@ type Service struct {
 	gitHubClient      GitHubOperations
 	gitExecutor       CommandExecutor
 	workflowRewriter  *WorkflowRewriter
+	docsRewriter      *DocumentationRewriter
 	pagesManager      *PagesManager
 	safetyEvaluator   SafetyEvaluator
+	clock             shared.Clock
 	warnings          []string
 }
 
@@ -155,6 +198,8 @@ var (
 	errGitHubClientMissing      = errors.New(githubClientMissingMessageConstant)
 	errGitExecutorMissing       = errors.New(gitExecutorMissingMessageConstant)
 	errCleanWorktreeRequired    = errors.New(cleanWorktreeRequiredMessageConstant)
+	// ErrOperationInProgress indicates migration was refused because a merge, rebase, or cherry-pick is unfinished.
+	ErrOperationInProgress = errors.New(operationInProgressMessageConstant)
 )
 
 // NewService constructs a Service with the provided dependencies.
@@ -174,6 +219,11 @@ func NewService(dependencies ServiceDependencies) (*Service, error) {
 		logger = zap.NewNop()
 	}
 
+	clock := dependencies.Clock
+	if clock == nil {
+		clock = shared.SystemClock{}
+	}
+
 	workflowRewriter := NewWorkflowRewriter(logger)
 	pagesManager := NewPagesManager(logger, dependencies.GitHubClient)
 
@@ -183,8 +233,10 @@ func NewService(dependencies ServiceDependencies) (*Service, error) {
 		gitHubClient:      dependencies.GitHubClient,
 		gitExecutor:       dependencies.GitExecutor,
 		workflowRewriter:  workflowRewriter,
+		docsRewriter:      NewDocumentationRewriter(logger),
 		pagesManager:      pagesManager,
 		safetyEvaluator:   SafetyEvaluator{},
+		clock:             clock,
 	}
 
 	return service, nil
@@ -212,6 +264,14 @@ func (service *Service) Execute(executionContext context.Context, options Migrat
 		return MigrationResult{}, validationError
 	}
 
+	inProgressOperation, inProgressError := service.repositoryManager.InProgressOperation(executionContext, options.RepositoryPath)
+	if inProgressError != nil {
+		return MigrationResult{}, inProgressError
+	}
+	if inProgressOperation.Active() {
+		return MigrationResult{}, fmt.Errorf(operationInProgressErrorTemplateConstant, ErrOperationInProgress, inProgressOperation)
+	}
+
 	requireClean := true
 	contextAccessor := utils.NewCommandContextAccessor()
 	if branchContext, exists := contextAccessor.BranchContext(executionContext); exists {
@@ -219,12 +279,12 @@ func (service *Service) Execute(executionContext context.Context, options Migrat
 	}
 
 	if requireClean {
-		cleanWorktree, cleanError := service.repositoryManager.CheckCleanWorktree(executionContext, options.RepositoryPath)
-		if cleanError != nil {
-			return MigrationResult{}, cleanError
+		statusReport, statusError := service.repositoryManager.Status(executionContext, options.RepositoryPath)
+		if statusError != nil {
+			return MigrationResult{}, statusError
 		}
-		if !cleanWorktree {
-			return MigrationResult{}, errCleanWorktreeRequired
+		if !statusReport.Clean() {
+			return MigrationResult{}, fmt.Errorf(dirtyWorktreeErrorTemplateConstant, errCleanWorktreeRequired, statusReport.Summary())
 		}
 	}
 
@@ -232,6 +292,19 @@ func (service *Service) Execute(executionContext context.Context, options Migrat
 		return MigrationResult{}, tokenError
 	}
 
+	if options.Phase == MigrationPhaseEnforce {
+		skipReason, verifyError := service.verifyAnnouncement(executionContext, options)
+		if verifyError != nil {
+			return MigrationResult{}, verifyError
+		}
+		if len(skipReason) > 0 {
+			return MigrationResult{
+				EnforceSkipReason: skipReason,
+				Warnings:          []string{fmt.Sprintf(enforceSkipWarningTemplateConstant, skipReason)},
+			}, nil
+		}
+	}
+
 	workflowOutcome, rewriteError := service.workflowRewriter.Rewrite(executionContext, WorkflowRewriteConfig{
 		RepositoryPath:     options.RepositoryPath,
 		WorkflowsDirectory: options.WorkflowsDirectory,
@@ -242,7 +315,22 @@ func (service *Service) Execute(executionContext context.Context, options Migrat
 		return MigrationResult{}, fmt.Errorf(workflowRewriteErrorTemplateConstant, rewriteError)
 	}
 
-	workflowCommitted, workflowCommitError := service.commitWorkflowChanges(executionContext, options, workflowOutcome)
+	documentationOutcome := DocumentationOutcome{UpdatedFiles: []DocumentationFileUpdate{}}
+	if options.UpdateDocumentation {
+		rewrittenDocumentation, documentationError := service.docsRewriter.Rewrite(executionContext, DocumentationRewriteConfig{
+			RepositoryPath:       options.RepositoryPath,
+			RepositoryIdentifier: options.RepositoryIdentifier,
+			Patterns:             options.DocumentationPatterns,
+			SourceBranch:         options.SourceBranch,
+			TargetBranch:         options.TargetBranch,
+		})
+		if documentationError != nil {
+			return MigrationResult{}, fmt.Errorf(documentationRewriteErrorTemplateConstant, documentationError)
+		}
+		documentationOutcome = rewrittenDocumentation
+	}
+
+	workflowCommitted, workflowCommitError := service.commitWorkflowChanges(executionContext, options, workflowOutcome, documentationOutcome)
 	if workflowCommitError != nil {
 		return MigrationResult{}, workflowCommitError
 	}
@@ -254,34 +342,39 @@ func (service *Service) Execute(executionContext context.Context, options Migrat
 		}
 	}
 
-	pagesUpdated, pagesError := service.pagesManager.EnsureLegacyBranch(executionContext, PagesUpdateConfig{
-		RepositoryIdentifier: options.RepositoryIdentifier,
-		SourceBranch:         options.SourceBranch,
-		TargetBranch:         options.TargetBranch,
-	})
-	if pagesError != nil {
-		if isNonCriticalPagesError(pagesError) {
-			service.logger.Warn(
-				pagesUpdateWarningMessageConstant,
-				zap.String(repositoryPathFieldNameConstant, options.RepositoryPath),
-				zap.String(repositoryIdentifierFieldNameConstant, options.RepositoryIdentifier),
-				zap.Error(pagesError),
-			)
-			warning := fmt.Sprintf(pagesUpdateWarningTemplateConstant, options.RepositoryIdentifier, summarizeCommandError(pagesError))
-			service.warnings = append(service.warnings, warning)
-			pagesUpdated = false
-		} else {
-			return MigrationResult{}, fmt.Errorf(pagesUpdateErrorTemplateConstant, pagesError)
-		}
-	}
-
-	if err := service.gitHubClient.SetDefaultBranch(executionContext, options.RepositoryIdentifier, string(options.TargetBranch)); err != nil {
-		return MigrationResult{}, DefaultBranchUpdateError{
-			RepositoryPath:       options.RepositoryPath,
+	// The enforce phase relies on the announce phase for Pages and the default branch, which it has just verified.
+	pagesUpdated := false
+	if options.Phase != MigrationPhaseEnforce {
+		var pagesError error
+		pagesUpdated, pagesError = service.pagesManager.EnsureLegacyBranch(executionContext, PagesUpdateConfig{
 			RepositoryIdentifier: options.RepositoryIdentifier,
 			SourceBranch:         options.SourceBranch,
 			TargetBranch:         options.TargetBranch,
-			Cause:                err,
+		})
+		if pagesError != nil {
+			if isNonCriticalPagesError(pagesError) {
+				service.logger.Warn(
+					pagesUpdateWarningMessageConstant,
+					zap.String(repositoryPathFieldNameConstant, options.RepositoryPath),
+					zap.String(repositoryIdentifierFieldNameConstant, options.RepositoryIdentifier),
+					zap.Error(pagesError),
+				)
+				warning := fmt.Sprintf(pagesUpdateWarningTemplateConstant, options.RepositoryIdentifier, summarizeCommandError(pagesError))
+				service.warnings = append(service.warnings, warning)
+				pagesUpdated = false
+			} else {
+				return MigrationResult{}, fmt.Errorf(pagesUpdateErrorTemplateConstant, pagesError)
+			}
+		}
+
+		if err := service.gitHubClient.SetDefaultBranch(executionContext, options.RepositoryIdentifier, string(options.TargetBranch)); err != nil {
+			return MigrationResult{}, DefaultBranchUpdateError{
+				RepositoryPath:       options.RepositoryPath,
+				RepositoryIdentifier: options.RepositoryIdentifier,
+				SourceBranch:         options.SourceBranch,
+				TargetBranch:         options.TargetBranch,
+				Cause:                err,
+			}
 		}
 	}
 
@@ -304,7 +397,9 @@ func (service *Service) Execute(executionContext context.Context, options Migrat
 	retargeted, retargetWarnings := service.retargetPullRequests(executionContext, options, pullRequests)
 	service.warnings = append(service.warnings, retargetWarnings...)
 
-	branchProtected, protectionError := service.gitHubClient.CheckBranchProtection(executionContext, options.RepositoryIdentifier, string(options.SourceBranch))
+	var branchProtection *githubcli.BranchProtection
+	// The protection is copied onto the target, so it is read fresh rather than from the run's response cache.
+	protection, branchProtected, protectionError := service.gitHubClient.GetBranchProtection(githubcli.WithFreshResponses(executionContext), options.RepositoryIdentifier, string(options.SourceBranch))
 	if protectionError != nil {
 		service.logger.Warn(
 			"Branch protection check failed",
@@ -314,31 +409,73 @@ func (service *Service) Execute(executionContext context.Context, options Migrat
 		warning := fmt.Sprintf(branchProtectionWarningTemplateConstant, summarizeCommandError(protectionError))
 		service.warnings = append(service.warnings, warning)
 		branchProtected = true
+	} else if branchProtected {
+		branchProtection = &protection
+	}
+
+	var protectionRuleMatches []ProtectionRuleMatch
+	protectionRules, protectionRulesError := service.gitHubClient.ListBranchProtectionRules(executionContext, options.RepositoryIdentifier)
+	if protectionRulesError != nil {
+		service.logger.Warn(
+			"Branch protection rule listing failed",
+			zap.String(repositoryIdentifierFieldNameConstant, options.RepositoryIdentifier),
+			zap.Error(protectionRulesError),
+		)
+		warning := fmt.Sprintf(branchProtectionWarningTemplateConstant, summarizeCommandError(protectionRulesError))
+		service.warnings = append(service.warnings, warning)
+		branchProtected = true
+	} else {
+		protectionRuleMatches = matchProtectionRules(protectionRules, options.SourceBranch, options.TargetBranch)
 	}
 
 	safetyStatus := service.safetyEvaluator.Evaluate(SafetyInputs{
-		OpenPullRequestCount: len(pullRequests),
-		BranchProtected:      branchProtected,
-		WorkflowMentions:     workflowOutcome.RemainingMainReferences,
+		OpenPullRequestCount:  len(pullRequests),
+		BranchProtected:       branchProtected,
+		BranchProtection:      branchProtection,
+		ProtectionRuleMatches: protectionRuleMatches,
+		WorkflowMentions:      workflowOutcome.RemainingMainReferences,
 	})
 
 	result := MigrationResult{
 		WorkflowOutcome:           workflowOutcome,
+		DocumentationOutcome:      documentationOutcome,
 		PagesConfigurationUpdated: pagesUpdated,
-		DefaultBranchUpdated:      true,
+		DefaultBranchUpdated:      options.Phase != MigrationPhaseEnforce,
 		RetargetedPullRequests:    retargeted,
 		SafetyStatus:              safetyStatus,
 		Warnings:                  append([]string(nil), service.warnings...),
 	}
 
-	if options.DeleteSourceBranch {
+	if options.Phase == MigrationPhaseAnnounce {
+		markerWarnings, markerError := service.recordAnnouncement(executionContext, options)
+		if markerError != nil {
+			return MigrationResult{}, markerError
+		}
+		result.AnnouncementRecorded = true
+		result.Warnings = append(result.Warnings, markerWarnings...)
+		return result, nil
+	}
+
+	if options.RetainSource == SourceRetentionArchive {
 		if !result.SafetyStatus.SafeToDelete {
 			service.logger.Warn(
 				branchDeletionSkippedMessageConstant,
 				zap.String(repositoryPathFieldNameConstant, options.RepositoryPath),
 				zap.String(sourceBranchFieldNameConstant, string(options.SourceBranch)),
 			)
-		} else {
+		} else if service.tombstoneBeforeRetirement(executionContext, options, &result) {
+			archivedBranch, archiveWarnings := service.archiveSourceBranch(executionContext, options)
+			result.ArchivedSourceBranch = archivedBranch
+			result.Warnings = append(result.Warnings, archiveWarnings...)
+		}
+	} else if options.DeleteSourceBranch || options.RetainSource == SourceRetentionDelete {
+		if !result.SafetyStatus.SafeToDelete {
+			service.logger.Warn(
+				branchDeletionSkippedMessageConstant,
+				zap.String(repositoryPathFieldNameConstant, options.RepositoryPath),
+				zap.String(sourceBranchFieldNameConstant, string(options.SourceBranch)),
+			)
+		} else if service.tombstoneBeforeRetirement(executionContext, options, &result) {
 			if deletionError := service.deleteSourceBranch(executionContext, options); deletionError != nil {
 				service.logger.Warn(
 					"Source branch deletion failed",
@@ -347,6 +484,8 @@ func (service *Service) Execute(executionContext context.Context, options Migrat
 				)
 				warning := fmt.Sprintf(branchDeletionWarningTemplateConstant, summarizeCommandError(deletionError))
 				result.Warnings = append(result.Warnings, warning)
+			} else {
+				result.SourceBranchDeleted = true
 			}
 		}
 	}
@@ -386,26 +525,56 @@ func (service *Service) validateOptions(options MigrationOptions) error {
 	if len(strings.TrimSpace(string(options.TargetBranch))) == 0 {
 		return InvalidInputError{FieldName: targetBranchFieldNameConstant, Message: requiredValueMessageConstant}
 	}
+	switch options.RetainSource {
+	case "", SourceRetentionDelete, SourceRetentionArchive:
+	default:
+		return InvalidInputError{FieldName: retainSourceFieldNameConstant, Message: fmt.Sprintf(unsupportedRetentionModeTemplateConstant, string(options.RetainSource))}
+	}
+	if options.LeaveTombstone && len(options.RetainSource) == 0 && !options.DeleteSourceBranch {
+		return InvalidInputError{FieldName: leaveTombstoneFieldNameConstant, Message: leaveTombstoneRequiresRetentionConstant}
+	}
+	switch options.Phase {
+	case "", MigrationPhaseAnnounce:
+	case MigrationPhaseEnforce:
+		if len(options.RetainSource) == 0 && !options.DeleteSourceBranch {
+			return InvalidInputError{FieldName: phaseFieldNameConstant, Message: enforceRetentionRequiredMessage}
+		}
+	default:
+		return InvalidInputError{FieldName: phaseFieldNameConstant, Message: fmt.Sprintf(unsupportedPhaseTemplateConstant, string(options.Phase))}
+	}
+	if options.GracePeriod != 0 && options.Phase != MigrationPhaseEnforce {
+		return InvalidInputError{FieldName: gracePeriodFieldNameConstant, Message: graceWithoutEnforceMessageConstant}
+	}
 	return nil
 }
 
-func (service *Service) commitWorkflowChanges(executionContext context.Context, options MigrationOptions, outcome WorkflowOutcome) (bool, error) {
-	if len(outcome.UpdatedFiles) == 0 {
+// commitWorkflowChanges stages the rewritten workflows and documentation files and records them in one commit.
+func (service *Service) commitWorkflowChanges(executionContext context.Context, options MigrationOptions, outcome WorkflowOutcome, documentation DocumentationOutcome) (bool, error) {
+	if len(outcome.UpdatedFiles) == 0 && len(documentation.UpdatedFiles) == 0 {
 		return false, nil
 	}
 
-	addArguments := []string{gitAddCommandNameConstant, gitAllFlagConstant, options.WorkflowsDirectory}
+	addArguments := []string{gitAddCommandNameConstant, gitAllFlagConstant}
+	if len(outcome.UpdatedFiles) > 0 {
+		addArguments = append(addArguments, options.WorkflowsDirectory)
+	}
+	addArguments = append(addArguments, documentation.Paths()...)
 	if _, stageError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
 		Arguments:        addArguments,
 		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       false,
 	}); stageError != nil {
 		return false, fmt.Errorf(workflowStageErrorTemplateConstant, stageError)
 	}
 
 	commitMessage := fmt.Sprintf(workflowCommitMessageTemplateConstant, string(options.TargetBranch))
+	if len(documentation.UpdatedFiles) > 0 {
+		commitMessage = fmt.Sprintf(branchReferencesCommitMessageTemplateConstant, string(options.TargetBranch))
+	}
 	_, commitError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
-		Arguments:        []string{gitCommitCommandNameConstant, gitMessageFlagConstant, commitMessage},
+		Arguments:        gitrepo.CommitArguments(gitrepo.AutomatedCommitOptions(executionContext, automatedCommitCommandNameConstant, commitMessage)),
 		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       false,
 	})
 	if commitError != nil {
 		var commandFailure execshell.CommandFailedError
@@ -424,6 +593,7 @@ func (service *Service) pushWorkflowChanges(executionContext context.Context, op
 	if _, pushError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
 		Arguments:        pushArguments,
 		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       false,
 	}); pushError != nil {
 		return fmt.Errorf(workflowPushErrorTemplateConstant, pushError)
 	}
@@ -435,6 +605,7 @@ func (service *Service) deleteSourceBranch(executionContext context.Context, opt
 	if _, deleteLocalError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
 		Arguments:        deleteLocalArguments,
 		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       false,
 	}); deleteLocalError != nil {
 		return fmt.Errorf(localBranchDeleteErrorTemplateConstant, deleteLocalError)
 	}
@@ -443,6 +614,7 @@ func (service *Service) deleteSourceBranch(executionContext context.Context, opt
 	if _, deleteRemoteError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
 		Arguments:        deleteRemoteArguments,
 		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       false,
 	}); deleteRemoteError != nil {
 		return fmt.Errorf(remoteBranchDeleteErrorTemplateConstant, deleteRemoteError)
 	}
@@ -450,6 +622,77 @@ func (service *Service) deleteSourceBranch(executionContext context.Context, opt
 	return nil
 }
 
+func (service *Service) archiveSourceBranch(executionContext context.Context, options MigrationOptions) (string, []string) {
+	archivedBranch := ArchiveBranchName(options.SourceBranch, service.clock.Now())
+
+	pushArchiveArguments := []string{
+		gitPushCommandNameConstant,
+		options.RepositoryRemoteName,
+		fmt.Sprintf(archivePushRefspecTemplateConstant, options.RepositoryRemoteName, string(options.SourceBranch), archivedBranch),
+	}
+	if _, pushError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        pushArchiveArguments,
+		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       false,
+	}); pushError != nil {
+		archiveError := fmt.Errorf(archivePushErrorTemplateConstant, archivedBranch, pushError)
+		service.logger.Warn(
+			"Source branch archive failed",
+			zap.String(repositoryPathFieldNameConstant, options.RepositoryPath),
+			zap.Error(archiveError),
+		)
+		return "", []string{fmt.Sprintf(archiveWarningTemplateConstant, summarizeCommandError(archiveError))}
+	}
+
+	deleteRemoteArguments := []string{gitPushCommandNameConstant, options.RepositoryRemoteName, gitPushDeleteFlagConstant, string(options.SourceBranch)}
+	if _, deleteRemoteError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        deleteRemoteArguments,
+		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       false,
+	}); deleteRemoteError != nil {
+		archiveError := fmt.Errorf(remoteBranchDeleteErrorTemplateConstant, deleteRemoteError)
+		service.logger.Warn(
+			"Source branch archive failed",
+			zap.String(repositoryPathFieldNameConstant, options.RepositoryPath),
+			zap.Error(archiveError),
+		)
+		return archivedBranch, []string{fmt.Sprintf(archiveWarningTemplateConstant, summarizeCommandError(archiveError))}
+	}
+
+	var warnings []string
+	trackingReference := fmt.Sprintf(remoteTrackingReferenceTemplateConstant, options.RepositoryRemoteName, string(options.SourceBranch))
+	if _, deleteTrackingError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitUpdateRefCommandNameConstant, gitUpdateRefDeleteFlagConstant, trackingReference},
+		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       false,
+	}); deleteTrackingError != nil {
+		trackingError := fmt.Errorf(remoteTrackingReferenceDeleteErrorTemplateConstant, trackingReference, deleteTrackingError)
+		service.logger.Warn(
+			"Stale remote-tracking ref removal failed",
+			zap.String(repositoryPathFieldNameConstant, options.RepositoryPath),
+			zap.Error(trackingError),
+		)
+		warnings = append(warnings, fmt.Sprintf(archiveWarningTemplateConstant, summarizeCommandError(trackingError)))
+	}
+
+	if lockError := service.gitHubClient.LockBranch(executionContext, options.RepositoryIdentifier, archivedBranch); lockError != nil {
+		wrappedLockError := fmt.Errorf(archiveLockErrorTemplateConstant, archivedBranch, lockError)
+		service.logger.Warn(
+			"Archive branch protection failed",
+			zap.String(repositoryIdentifierFieldNameConstant, options.RepositoryIdentifier),
+			zap.Error(wrappedLockError),
+		)
+		warnings = append(warnings, fmt.Sprintf(archiveLockWarningTemplateConstant, summarizeCommandError(wrappedLockError)))
+	}
+
+	return archivedBranch, warnings
+}
+
+// ArchiveBranchName returns the archive ref name used when retaining the source branch.
+func ArchiveBranchName(sourceBranch BranchName, archivedAt time.Time) string {
+	return fmt.Sprintf(archiveBranchNameTemplateConstant, string(sourceBranch), archivedAt.Format(archiveBranchDateLayoutConstant))
+}
+
 func (service *Service) retargetPullRequests(executionContext context.Context, options MigrationOptions, pullRequests []githubcli.PullRequest) ([]int, []string) {
 	retargeted := make([]int, 0, len(pullRequests))
 	warnings := make([]string, 0)
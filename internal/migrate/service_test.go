@@ -3,47 +3,50 @@ package migrate
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
 	"github.com/temirov/gix/internal/githubauth"
 	"github.com/temirov/gix/internal/githubcli"
 	"github.com/temirov/gix/internal/gitrepo"
 )
 
-type stubCommandExecutor struct{}
-
-func (stubCommandExecutor) ExecuteGit(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return execshell.ExecutionResult{}, nil
-}
-
-func (stubCommandExecutor) ExecuteGitHubCLI(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return execshell.ExecutionResult{}, nil
-}
-
-type stubGitCommandExecutor struct{}
-
-func (stubGitCommandExecutor) ExecuteGit(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return execshell.ExecutionResult{}, nil
-}
-
 type recordingGitHubOperations struct {
 	pagesError         error
 	listError          error
 	retargetErrors     map[int]error
 	protectionError    error
+	protection         *githubcli.BranchProtection
+	protectionRules    githubcli.BranchProtectionRules
 	defaultBranchError error
 	defaultBranchSet   bool
 	pullRequests       []githubcli.PullRequest
 	retargetedNumbers  []int
+	lockError          error
+	lockedBranches     []string
+	pagesResponse      []byte
+	protectionBodies   map[string][]byte
+	metadata           githubcli.RepositoryMetadata
+}
+
+type fixedClock struct {
+	instant time.Time
+}
+
+func (clock fixedClock) Now() time.Time {
+	return clock.instant
 }
 
 func (operations *recordingGitHubOperations) ResolveRepoMetadata(context.Context, string) (githubcli.RepositoryMetadata, error) {
-	return githubcli.RepositoryMetadata{}, nil
+	return operations.metadata, nil
 }
 
 func (operations *recordingGitHubOperations) GetPagesConfig(context.Context, string) (githubcli.PagesStatus, error) {
@@ -82,11 +85,54 @@ func (operations *recordingGitHubOperations) SetDefaultBranch(context.Context, s
 	return nil
 }
 
-func (operations *recordingGitHubOperations) CheckBranchProtection(context.Context, string, string) (bool, error) {
+func (operations *recordingGitHubOperations) ListBranchProtectionRules(context.Context, string) (githubcli.BranchProtectionRules, error) {
+	return operations.protectionRules, nil
+}
+
+func (operations *recordingGitHubOperations) GetBranchProtection(context.Context, string, string) (githubcli.BranchProtection, bool, error) {
+	if operations.protectionError != nil {
+		return githubcli.BranchProtection{}, false, operations.protectionError
+	}
+	if operations.protection != nil {
+		return *operations.protection, true, nil
+	}
+	return githubcli.BranchProtection{}, false, nil
+}
+
+func (operations *recordingGitHubOperations) LockBranch(_ context.Context, _ string, branchName string) error {
+	operations.lockedBranches = append(operations.lockedBranches, branchName)
+	return operations.lockError
+}
+
+func (operations *recordingGitHubOperations) GetPagesConfigResponse(context.Context, string) ([]byte, error) {
+	if operations.pagesError != nil {
+		return nil, operations.pagesError
+	}
+	return operations.pagesResponse, nil
+}
+
+func (operations *recordingGitHubOperations) GetBranchProtectionResponse(_ context.Context, _ string, branchName string) ([]byte, bool, error) {
 	if operations.protectionError != nil {
-		return false, operations.protectionError
+		return nil, false, operations.protectionError
 	}
-	return false, nil
+	body, protected := operations.protectionBodies[branchName]
+	return body, protected, nil
+}
+
+func (operations *recordingGitHubOperations) DescribeUpdatePagesConfig(repository string, configuration githubcli.PagesConfiguration) (githubcli.IntendedRequest, error) {
+	return (&githubcli.Client{}).DescribeUpdatePagesConfig(repository, configuration)
+}
+
+func (operations *recordingGitHubOperations) DescribeSetDefaultBranch(repository string, branchName string) (githubcli.IntendedRequest, error) {
+	return (&githubcli.Client{}).DescribeSetDefaultBranch(repository, branchName)
+}
+
+func (operations *recordingGitHubOperations) DescribeUpdatePullRequestBase(repository string, pullRequestNumber int, baseBranch string) (githubcli.IntendedRequest, error) {
+	return (&githubcli.Client{}).DescribeUpdatePullRequestBase(repository, pullRequestNumber, baseBranch)
+}
+
+func (operations *recordingGitHubOperations) DescribeLockBranch(repository string, branchName string) (githubcli.IntendedRequest, error) {
+	return (&githubcli.Client{}).DescribeLockBranch(repository, branchName)
 }
 
 func makeCommandFailedError(message string) error {
@@ -105,7 +151,7 @@ func TestServiceExecuteContinuesWhenPagesLookupFails(testInstance *testing.T) {
 	testInstance.Setenv(githubauth.EnvGitHubCLIToken, testGitHubTokenValue)
 	testInstance.Setenv(githubauth.EnvGitHubToken, testGitHubTokenValue)
 
-	repositoryExecutor := stubGitCommandExecutor{}
+	repositoryExecutor := execshelltest.NewPermissiveExecutor()
 	repositoryManager, managerError := gitrepo.NewRepositoryManager(repositoryExecutor)
 	require.NoError(testInstance, managerError)
 
@@ -120,7 +166,7 @@ func TestServiceExecuteContinuesWhenPagesLookupFails(testInstance *testing.T) {
 		Logger:            zap.NewNop(),
 		RepositoryManager: repositoryManager,
 		GitHubClient:      githubOperations,
-		GitExecutor:       stubCommandExecutor{},
+		GitExecutor:       execshelltest.NewPermissiveExecutor(),
 	})
 	require.NoError(testInstance, serviceError)
 
@@ -148,7 +194,7 @@ func TestServiceExecuteWarnsWhenRetargetFails(testInstance *testing.T) {
 	testInstance.Setenv(githubauth.EnvGitHubCLIToken, testGitHubTokenValue)
 	testInstance.Setenv(githubauth.EnvGitHubToken, testGitHubTokenValue)
 
-	repositoryExecutor := stubGitCommandExecutor{}
+	repositoryExecutor := execshelltest.NewPermissiveExecutor()
 	repositoryManager, managerError := gitrepo.NewRepositoryManager(repositoryExecutor)
 	require.NoError(testInstance, managerError)
 
@@ -163,7 +209,7 @@ func TestServiceExecuteWarnsWhenRetargetFails(testInstance *testing.T) {
 		Logger:            zap.NewNop(),
 		RepositoryManager: repositoryManager,
 		GitHubClient:      githubOperations,
-		GitExecutor:       stubCommandExecutor{},
+		GitExecutor:       execshelltest.NewPermissiveExecutor(),
 	})
 	require.NoError(testInstance, serviceError)
 
@@ -187,7 +233,7 @@ func TestServiceExecuteWarnsWhenBranchProtectionFails(testInstance *testing.T) {
 	testInstance.Setenv(githubauth.EnvGitHubCLIToken, testGitHubTokenValue)
 	testInstance.Setenv(githubauth.EnvGitHubToken, testGitHubTokenValue)
 
-	repositoryExecutor := stubGitCommandExecutor{}
+	repositoryExecutor := execshelltest.NewPermissiveExecutor()
 	repositoryManager, managerError := gitrepo.NewRepositoryManager(repositoryExecutor)
 	require.NoError(testInstance, managerError)
 
@@ -199,7 +245,7 @@ func TestServiceExecuteWarnsWhenBranchProtectionFails(testInstance *testing.T) {
 		Logger:            zap.NewNop(),
 		RepositoryManager: repositoryManager,
 		GitHubClient:      githubOperations,
-		GitExecutor:       stubCommandExecutor{},
+		GitExecutor:       execshelltest.NewPermissiveExecutor(),
 	})
 	require.NoError(testInstance, serviceError)
 
@@ -220,11 +266,111 @@ func TestServiceExecuteWarnsWhenBranchProtectionFails(testInstance *testing.T) {
 	require.False(testInstance, result.SafetyStatus.SafeToDelete)
 }
 
+func TestServiceExecuteReportsSpecificBranchProtectionReasons(testInstance *testing.T) {
+	testInstance.Setenv(githubauth.EnvGitHubCLIToken, testGitHubTokenValue)
+	testInstance.Setenv(githubauth.EnvGitHubToken, testGitHubTokenValue)
+
+	repositoryExecutor := execshelltest.NewPermissiveExecutor()
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(repositoryExecutor)
+	require.NoError(testInstance, managerError)
+
+	githubOperations := &recordingGitHubOperations{
+		protection: &githubcli.BranchProtection{
+			RequiredStatusChecks: &githubcli.RequiredStatusChecks{Contexts: []string{"ci/build"}},
+		},
+	}
+
+	service, serviceError := NewService(ServiceDependencies{
+		Logger:            zap.NewNop(),
+		RepositoryManager: repositoryManager,
+		GitHubClient:      githubOperations,
+		GitExecutor:       execshelltest.NewPermissiveExecutor(),
+	})
+	require.NoError(testInstance, serviceError)
+
+	options := MigrationOptions{
+		RepositoryPath:       testInstance.TempDir(),
+		RepositoryRemoteName: "origin",
+		RepositoryIdentifier: "owner/example",
+		WorkflowsDirectory:   ".github/workflows",
+		SourceBranch:         BranchMain,
+		TargetBranch:         BranchMaster,
+	}
+
+	result, executionError := service.Execute(context.Background(), options)
+	require.NoError(testInstance, executionError)
+	require.False(testInstance, result.SafetyStatus.SafeToDelete)
+	require.Equal(testInstance, []string{"branch protection restricts deletions", "required checks would be lost (ci/build)"}, result.SafetyStatus.BlockingReasons)
+}
+
+func TestServiceExecuteReportsWildcardProtectionRules(testInstance *testing.T) {
+	testInstance.Setenv(githubauth.EnvGitHubCLIToken, testGitHubTokenValue)
+	testInstance.Setenv(githubauth.EnvGitHubToken, testGitHubTokenValue)
+
+	testCases := []struct {
+		name            string
+		rules           []githubcli.BranchProtectionRule
+		expectedSafe    bool
+		expectedReasons []string
+	}{
+		{
+			name:            "wildcard_matches_source_and_target",
+			rules:           []githubcli.BranchProtectionRule{{Pattern: "ma*"}},
+			expectedReasons: []string{`protection rule "ma*" matching main and master restricts deletions`, `protection rule "ma*" matching main and master blocks force pushes`},
+		},
+		{
+			name:            "wildcard_allows_deletion",
+			rules:           []githubcli.BranchProtectionRule{{Pattern: "m*n", AllowsDeletions: true}},
+			expectedReasons: []string{`protection rule "m*n" matching main blocks force pushes`},
+		},
+		{
+			name:         "wildcard_matches_target_only",
+			rules:        []githubcli.BranchProtectionRule{{Pattern: "mast*"}},
+			expectedSafe: true,
+		},
+		{
+			name:         "literal_rule_left_to_branch_lookup",
+			rules:        []githubcli.BranchProtectionRule{{Pattern: "main"}},
+			expectedSafe: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			repositoryManager, managerError := gitrepo.NewRepositoryManager(execshelltest.NewPermissiveExecutor())
+			require.NoError(subtest, managerError)
+
+			githubOperations := &recordingGitHubOperations{protectionRules: githubcli.BranchProtectionRules{Rules: testCase.rules}}
+			service, serviceError := NewService(ServiceDependencies{
+				Logger:            zap.NewNop(),
+				RepositoryManager: repositoryManager,
+				GitHubClient:      githubOperations,
+				GitExecutor:       execshelltest.NewPermissiveExecutor(),
+			})
+			require.NoError(subtest, serviceError)
+
+			result, executionError := service.Execute(context.Background(), MigrationOptions{
+				RepositoryPath:       subtest.TempDir(),
+				RepositoryRemoteName: "origin",
+				RepositoryIdentifier: "owner/example",
+				WorkflowsDirectory:   ".github/workflows",
+				SourceBranch:         BranchMain,
+				TargetBranch:         BranchMaster,
+			})
+			require.NoError(subtest, executionError)
+			require.Equal(subtest, testCase.expectedSafe, result.SafetyStatus.SafeToDelete)
+			if !testCase.expectedSafe {
+				require.Equal(subtest, testCase.expectedReasons, result.SafetyStatus.BlockingReasons)
+			}
+		})
+	}
+}
+
 func TestServiceExecuteReturnsActionableDefaultBranchError(testInstance *testing.T) {
 	testInstance.Setenv(githubauth.EnvGitHubCLIToken, testGitHubTokenValue)
 	testInstance.Setenv(githubauth.EnvGitHubToken, testGitHubTokenValue)
 
-	repositoryExecutor := stubGitCommandExecutor{}
+	repositoryExecutor := execshelltest.NewPermissiveExecutor()
 	repositoryManager, managerError := gitrepo.NewRepositoryManager(repositoryExecutor)
 	require.NoError(testInstance, managerError)
 
@@ -249,7 +395,7 @@ func TestServiceExecuteReturnsActionableDefaultBranchError(testInstance *testing
 		Logger:            zap.NewNop(),
 		RepositoryManager: repositoryManager,
 		GitHubClient:      githubOperations,
-		GitExecutor:       stubCommandExecutor{},
+		GitExecutor:       execshelltest.NewPermissiveExecutor(),
 	})
 	require.NoError(testInstance, serviceError)
 
@@ -290,7 +436,7 @@ func TestServiceExecuteFailsWhenGitHubTokenMissing(testInstance *testing.T) {
 	testInstance.Setenv(githubauth.EnvGitHubToken, "")
 	testInstance.Setenv(githubauth.EnvGitHubAPIToken, "")
 
-	repositoryExecutor := stubGitCommandExecutor{}
+	repositoryExecutor := execshelltest.NewPermissiveExecutor()
 	repositoryManager, managerError := gitrepo.NewRepositoryManager(repositoryExecutor)
 	require.NoError(testInstance, managerError)
 
@@ -300,7 +446,7 @@ func TestServiceExecuteFailsWhenGitHubTokenMissing(testInstance *testing.T) {
 		Logger:            zap.NewNop(),
 		RepositoryManager: repositoryManager,
 		GitHubClient:      githubOperations,
-		GitExecutor:       stubCommandExecutor{},
+		GitExecutor:       execshelltest.NewPermissiveExecutor(),
 	})
 	require.NoError(testInstance, serviceError)
 
@@ -330,3 +476,201 @@ func TestServiceExecuteFailsWhenGitHubTokenMissing(testInstance *testing.T) {
 	require.Contains(testInstance, errorMessage, "missing GitHub authentication token")
 	require.False(testInstance, githubOperations.defaultBranchSet)
 }
+
+func TestServiceExecuteArchivesSourceBranch(testInstance *testing.T) {
+	testInstance.Setenv(githubauth.EnvGitHubCLIToken, testGitHubTokenValue)
+	testInstance.Setenv(githubauth.EnvGitHubToken, testGitHubTokenValue)
+
+	testCases := []struct {
+		name                 string
+		lockError            error
+		expectedWarning      string
+		expectedGitArguments [][]string
+	}{
+		{
+			name: "archive_and_lock",
+			expectedGitArguments: [][]string{
+				{"push", "origin", "refs/remotes/origin/main:refs/heads/archive/main-2024-05-01"},
+				{"push", "origin", "--delete", "main"},
+				{"update-ref", "-d", "refs/remotes/origin/main"},
+			},
+		},
+		{
+			name:            "lock_failure_warns",
+			lockError:       makeCommandFailedError("HTTP 403: Upgrade to GitHub Pro"),
+			expectedWarning: "ARCHIVE-LOCK-SKIP",
+			expectedGitArguments: [][]string{
+				{"push", "origin", "refs/remotes/origin/main:refs/heads/archive/main-2024-05-01"},
+				{"push", "origin", "--delete", "main"},
+				{"update-ref", "-d", "refs/remotes/origin/main"},
+			},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			repositoryManager, managerError := gitrepo.NewRepositoryManager(execshelltest.NewPermissiveExecutor())
+			require.NoError(subtest, managerError)
+
+			githubOperations := &recordingGitHubOperations{lockError: testCase.lockError}
+			gitExecutor := execshelltest.NewPermissiveExecutor()
+
+			service, serviceError := NewService(ServiceDependencies{
+				Logger:            zap.NewNop(),
+				RepositoryManager: repositoryManager,
+				GitHubClient:      githubOperations,
+				GitExecutor:       gitExecutor,
+				Clock:             fixedClock{instant: time.Date(2024, time.May, 1, 23, 0, 0, 0, time.UTC)},
+			})
+			require.NoError(subtest, serviceError)
+
+			result, executionError := service.Execute(context.Background(), MigrationOptions{
+				RepositoryPath:       subtest.TempDir(),
+				RepositoryRemoteName: "origin",
+				RepositoryIdentifier: "owner/example",
+				WorkflowsDirectory:   ".github/workflows",
+				SourceBranch:         BranchMain,
+				TargetBranch:         BranchMaster,
+				RetainSource:         SourceRetentionArchive,
+			})
+			require.NoError(subtest, executionError)
+			require.True(subtest, result.SafetyStatus.SafeToDelete)
+			require.Equal(subtest, "archive/main-2024-05-01", result.ArchivedSourceBranch)
+			require.False(subtest, result.SourceBranchDeleted)
+			require.Equal(subtest, testCase.expectedGitArguments, gitExecutor.ExecutedArguments(execshell.CommandGit))
+			require.Equal(subtest, []string{"archive/main-2024-05-01"}, githubOperations.lockedBranches)
+			if len(testCase.expectedWarning) > 0 {
+				require.Contains(subtest, strings.Join(result.Warnings, " "), testCase.expectedWarning)
+			} else {
+				require.Empty(subtest, result.Warnings)
+			}
+		})
+	}
+}
+
+func TestServiceExecuteCommitsDocumentationWithWorkflows(testInstance *testing.T) {
+	testInstance.Setenv(githubauth.EnvGitHubCLIToken, testGitHubTokenValue)
+	testInstance.Setenv(githubauth.EnvGitHubToken, testGitHubTokenValue)
+
+	repositoryPath := testInstance.TempDir()
+	workflowsDirectory := filepath.Join(repositoryPath, ".github", "workflows")
+	require.NoError(testInstance, os.MkdirAll(workflowsDirectory, 0o755))
+	require.NoError(testInstance, os.WriteFile(filepath.Join(workflowsDirectory, "ci.yml"), []byte("on: { push: { branches: [main] } }\n"), 0o644))
+	require.NoError(testInstance, os.WriteFile(filepath.Join(repositoryPath, "README.md"), []byte("https://github.com/owner/example/actions/workflows/ci.yml/badge.svg?branch=main\n"), 0o644))
+
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(execshelltest.NewPermissiveExecutor())
+	require.NoError(testInstance, managerError)
+	gitExecutor := execshelltest.NewPermissiveExecutor()
+
+	service, serviceError := NewService(ServiceDependencies{
+		Logger:            zap.NewNop(),
+		RepositoryManager: repositoryManager,
+		GitHubClient:      &recordingGitHubOperations{},
+		GitExecutor:       gitExecutor,
+	})
+	require.NoError(testInstance, serviceError)
+
+	result, executionError := service.Execute(context.Background(), MigrationOptions{
+		RepositoryPath:       repositoryPath,
+		RepositoryRemoteName: "origin",
+		RepositoryIdentifier: "owner/example",
+		WorkflowsDirectory:   ".github/workflows",
+		SourceBranch:         BranchMain,
+		TargetBranch:         BranchMaster,
+		UpdateDocumentation:  true,
+	})
+	require.NoError(testInstance, executionError)
+	require.Equal(testInstance, []DocumentationFileUpdate{{Path: "README.md", Replacements: 1}}, result.DocumentationOutcome.UpdatedFiles)
+
+	executedArguments := gitExecutor.ExecutedArguments(execshell.CommandGit)
+	require.GreaterOrEqual(testInstance, len(executedArguments), 2)
+	require.Equal(testInstance, []string{"add", "-A", ".github/workflows", "README.md"}, executedArguments[0])
+	require.Contains(testInstance, strings.Join(executedArguments[1], " "), "Switch workflow and documentation branch references to master")
+}
+
+func TestServiceExecuteRejectsUnknownRetentionMode(testInstance *testing.T) {
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(execshelltest.NewPermissiveExecutor())
+	require.NoError(testInstance, managerError)
+
+	service, serviceError := NewService(ServiceDependencies{
+		Logger:            zap.NewNop(),
+		RepositoryManager: repositoryManager,
+		GitHubClient:      &recordingGitHubOperations{},
+		GitExecutor:       execshelltest.NewPermissiveExecutor(),
+	})
+	require.NoError(testInstance, serviceError)
+
+	_, executionError := service.Execute(context.Background(), MigrationOptions{
+		RepositoryPath:       testInstance.TempDir(),
+		RepositoryRemoteName: "origin",
+		RepositoryIdentifier: "owner/example",
+		WorkflowsDirectory:   ".github/workflows",
+		SourceBranch:         BranchMain,
+		TargetBranch:         BranchMaster,
+		RetainSource:         SourceRetentionMode("freeze"),
+	})
+
+	var inputError InvalidInputError
+	require.ErrorAs(testInstance, executionError, &inputError)
+	require.Equal(testInstance, "retain_source", inputError.FieldName)
+}
+
+func TestServiceExecuteReportsDirtyWorktreeCategories(testInstance *testing.T) {
+	repositoryExecutor := execshelltest.NewExecutor()
+	repositoryExecutor.On(execshell.CommandGit, execshelltest.AnyArguments()).ReturnOutput("# branch.head main\n1 M. N... 100644 100644 100644 aaaaaaa bbbbbbb staged.go\n? notes.txt\n")
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(repositoryExecutor)
+	require.NoError(testInstance, managerError)
+
+	service, serviceError := NewService(ServiceDependencies{
+		Logger:            zap.NewNop(),
+		RepositoryManager: repositoryManager,
+		GitHubClient:      &recordingGitHubOperations{},
+		GitExecutor:       execshelltest.NewPermissiveExecutor(),
+	})
+	require.NoError(testInstance, serviceError)
+
+	_, executionError := service.Execute(context.Background(), MigrationOptions{
+		RepositoryPath:       testInstance.TempDir(),
+		RepositoryRemoteName: "origin",
+		RepositoryIdentifier: "owner/example",
+		WorkflowsDirectory:   ".github/workflows",
+		SourceBranch:         BranchMain,
+		TargetBranch:         BranchMaster,
+	})
+
+	require.ErrorIs(testInstance, executionError, errCleanWorktreeRequired)
+	require.EqualError(testInstance, executionError, "repository worktree must be clean before migration (1 staged, 1 untracked)")
+}
+
+func TestServiceExecuteRefusesInProgressOperations(testInstance *testing.T) {
+	gitDirectory := testInstance.TempDir()
+	require.NoError(testInstance, os.Mkdir(filepath.Join(gitDirectory, "rebase-merge"), 0o755))
+
+	repositoryExecutor := execshelltest.NewPermissiveExecutor()
+	repositoryExecutor.On(execshell.CommandGit, execshelltest.ArgumentPrefix("rev-parse")).ReturnOutput(gitDirectory + "\n")
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(repositoryExecutor)
+	require.NoError(testInstance, managerError)
+
+	gitHubOperations := &recordingGitHubOperations{}
+	service, serviceError := NewService(ServiceDependencies{
+		Logger:            zap.NewNop(),
+		RepositoryManager: repositoryManager,
+		GitHubClient:      gitHubOperations,
+		GitExecutor:       execshelltest.NewPermissiveExecutor(),
+	})
+	require.NoError(testInstance, serviceError)
+
+	_, executionError := service.Execute(context.Background(), MigrationOptions{
+		RepositoryPath:       testInstance.TempDir(),
+		RepositoryRemoteName: "origin",
+		RepositoryIdentifier: "owner/example",
+		WorkflowsDirectory:   ".github/workflows",
+		SourceBranch:         BranchMain,
+		TargetBranch:         BranchMaster,
+	})
+
+	require.ErrorIs(testInstance, executionError, ErrOperationInProgress)
+	require.EqualError(testInstance, executionError, "repository has an unfinished git operation; finish or abort it before migration (rebase in progress)")
+	require.False(testInstance, gitHubOperations.defaultBranchSet)
+}
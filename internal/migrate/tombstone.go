@@ -0,0 +1,164 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const (
+	// TombstoneFileName names the notice file committed to the source branch before it is retired.
+	TombstoneFileName                       = "BRANCH_MOVED.md"
+	tombstoneTemplateNameConstant           = "tombstone"
+	tombstoneWorktreeDirectoryPattern       = "gix-tombstone-*"
+	tombstoneWorktreeSubdirectoryConstant   = "worktree"
+	tombstoneFilePermissionsConstant        = 0o644
+	gitWorktreeCommandNameConstant          = "worktree"
+	gitWorktreeRemoveCommandNameConstant    = "remove"
+	gitDetachFlagConstant                   = "--detach"
+	gitForceFlagConstant                    = "--force"
+	tombstoneStartPointTemplateConstant     = "refs/remotes/%s/%s"
+	tombstonePushRefspecTemplateConstant    = "HEAD:refs/heads/%s"
+	tombstoneCommitMessageTemplateConstant  = "Docs: note that %s moved to %s"
+	tombstoneWorktreeErrorTemplateConstant  = "unable to check out %s for the tombstone: %w"
+	tombstoneRenderErrorTemplateConstant    = "unable to render %s: %w"
+	tombstoneWriteErrorTemplateConstant     = "unable to write %s: %w"
+	tombstoneStageErrorTemplateConstant     = "unable to stage %s: %w"
+	tombstoneCommitErrorTemplateConstant    = "unable to commit %s: %w"
+	tombstonePushErrorTemplateConstant      = "unable to push tombstone to %s: %w"
+	tombstoneWarningTemplateConstant        = "TOMBSTONE-SKIP: %s"
+	tombstoneFailedMessageConstant          = "Tombstone commit failed; leaving the source branch in place"
+	tombstoneCleanupFailedMessageConstant   = "Tombstone worktree cleanup failed"
+	leaveTombstoneFieldNameConstant         = "leave_tombstone"
+	leaveTombstoneRequiresRetentionConstant = "requires retain_source delete or archive"
+)
+
+const tombstoneTemplateConstant = "# `{{.SourceBranch}}` has moved\n\n" +
+	"The default branch of {{.RepositoryIdentifier}} is now `{{.TargetBranch}}`.\n" +
+	"`{{.SourceBranch}}` no longer receives updates and is being retired.\n\n" +
+	"Point scripts, documentation, and existing clones at `{{.TargetBranch}}`:\n\n" +
+	"```shell\n" +
+	"git fetch {{.RemoteName}}\n" +
+	"git checkout {{.TargetBranch}}\n" +
+	"git branch --set-upstream-to={{.RemoteName}}/{{.TargetBranch}}\n" +
+	"```\n"
+
+var tombstoneTemplate = template.Must(template.New(tombstoneTemplateNameConstant).Parse(tombstoneTemplateConstant))
+
+// TombstoneNotice carries the values rendered into the tombstone notice.
+type TombstoneNotice struct {
+	RepositoryIdentifier string
+	RemoteName           string
+	SourceBranch         string
+	TargetBranch         string
+}
+
+// RenderTombstoneNotice renders the BRANCH_MOVED.md notice pointing readers of the source branch at the target branch.
+func RenderTombstoneNotice(notice TombstoneNotice) (string, error) {
+	var rendered strings.Builder
+	if executeError := tombstoneTemplate.Execute(&rendered, notice); executeError != nil {
+		return "", executeError
+	}
+	return rendered.String(), nil
+}
+
+func (service *Service) leaveTombstone(executionContext context.Context, options MigrationOptions) error {
+	notice, renderError := RenderTombstoneNotice(TombstoneNotice{
+		RepositoryIdentifier: options.RepositoryIdentifier,
+		RemoteName:           options.RepositoryRemoteName,
+		SourceBranch:         string(options.SourceBranch),
+		TargetBranch:         string(options.TargetBranch),
+	})
+	if renderError != nil {
+		return fmt.Errorf(tombstoneRenderErrorTemplateConstant, TombstoneFileName, renderError)
+	}
+
+	temporaryDirectory, temporaryDirectoryError := os.MkdirTemp("", tombstoneWorktreeDirectoryPattern)
+	if temporaryDirectoryError != nil {
+		return fmt.Errorf(tombstoneWorktreeErrorTemplateConstant, string(options.SourceBranch), temporaryDirectoryError)
+	}
+	defer os.RemoveAll(temporaryDirectory)
+	worktreePath := filepath.Join(temporaryDirectory, tombstoneWorktreeSubdirectoryConstant)
+
+	startPoint := fmt.Sprintf(tombstoneStartPointTemplateConstant, options.RepositoryRemoteName, string(options.SourceBranch))
+	if _, worktreeError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitWorktreeCommandNameConstant, gitAddCommandNameConstant, gitDetachFlagConstant, worktreePath, startPoint},
+		WorkingDirectory: options.RepositoryPath,
+		Idempotent:       false,
+	}); worktreeError != nil {
+		return fmt.Errorf(tombstoneWorktreeErrorTemplateConstant, string(options.SourceBranch), worktreeError)
+	}
+	defer service.removeTombstoneWorktree(executionContext, options.RepositoryPath, worktreePath)
+
+	if writeError := os.WriteFile(filepath.Join(worktreePath, TombstoneFileName), []byte(notice), tombstoneFilePermissionsConstant); writeError != nil {
+		return fmt.Errorf(tombstoneWriteErrorTemplateConstant, TombstoneFileName, writeError)
+	}
+
+	if _, stageError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitAddCommandNameConstant, TombstoneFileName},
+		WorkingDirectory: worktreePath,
+		Idempotent:       false,
+	}); stageError != nil {
+		return fmt.Errorf(tombstoneStageErrorTemplateConstant, TombstoneFileName, stageError)
+	}
+
+	commitMessage := fmt.Sprintf(tombstoneCommitMessageTemplateConstant, string(options.SourceBranch), string(options.TargetBranch))
+	if _, commitError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        gitrepo.CommitArguments(gitrepo.AutomatedCommitOptions(executionContext, automatedCommitCommandNameConstant, commitMessage)),
+		WorkingDirectory: worktreePath,
+		Idempotent:       false,
+	}); commitError != nil {
+		return fmt.Errorf(tombstoneCommitErrorTemplateConstant, TombstoneFileName, commitError)
+	}
+
+	pushRefspec := fmt.Sprintf(tombstonePushRefspecTemplateConstant, string(options.SourceBranch))
+	if _, pushError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitPushCommandNameConstant, options.RepositoryRemoteName, pushRefspec},
+		WorkingDirectory: worktreePath,
+		Idempotent:       false,
+	}); pushError != nil {
+		return fmt.Errorf(tombstonePushErrorTemplateConstant, string(options.SourceBranch), pushError)
+	}
+
+	return nil
+}
+
+func (service *Service) removeTombstoneWorktree(executionContext context.Context, repositoryPath string, worktreePath string) {
+	if _, removeError := service.gitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{gitWorktreeCommandNameConstant, gitWorktreeRemoveCommandNameConstant, gitForceFlagConstant, worktreePath},
+		WorkingDirectory: repositoryPath,
+		Idempotent:       false,
+	}); removeError != nil {
+		service.logger.Warn(
+			tombstoneCleanupFailedMessageConstant,
+			zap.String(repositoryPathFieldNameConstant, repositoryPath),
+			zap.Error(removeError),
+		)
+	}
+}
+
+func (service *Service) tombstoneBeforeRetirement(executionContext context.Context, options MigrationOptions, result *MigrationResult) bool {
+	if !options.LeaveTombstone {
+		return true
+	}
+	if tombstoneError := service.leaveTombstone(executionContext, options); tombstoneError != nil {
+		service.logger.Warn(
+			tombstoneFailedMessageConstant,
+			zap.String(repositoryPathFieldNameConstant, options.RepositoryPath),
+			zap.String(sourceBranchFieldNameConstant, string(options.SourceBranch)),
+			zap.Error(tombstoneError),
+		)
+		result.Warnings = append(result.Warnings, fmt.Sprintf(tombstoneWarningTemplateConstant, summarizeCommandError(tombstoneError)))
+		return false
+	}
+	result.TombstoneCommitted = true
+	return true
+}
@@ -0,0 +1,204 @@
+package migrate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+	"github.com/temirov/gix/internal/githubauth"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const tombstoneWorktreePlaceholderConstant = "<worktree>"
+
+// tombstoneGitExecutor scripts the worktree and push commands of the tombstone flow. The worktree add command creates
+// the directory it names, and the push of the notice captures the BRANCH_MOVED.md file it publishes.
+type tombstoneGitExecutor struct {
+	*execshelltest.Executor
+	worktreePath string
+	notice       string
+}
+
+func newTombstoneGitExecutor(pushError error) *tombstoneGitExecutor {
+	executor := &tombstoneGitExecutor{Executor: execshelltest.NewPermissiveExecutor()}
+	executor.On(execshell.CommandGit, execshelltest.ArgumentPrefix("worktree", "add")).Respond(func(details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+		executor.worktreePath = details.Arguments[3]
+		return execshell.ExecutionResult{}, os.MkdirAll(executor.worktreePath, 0o755)
+	})
+	executor.OnGit("push", "origin", "HEAD:refs/heads/main").Respond(func(execshell.CommandDetails) (execshell.ExecutionResult, error) {
+		contents, readError := os.ReadFile(filepath.Join(executor.worktreePath, TombstoneFileName))
+		if readError != nil {
+			return execshell.ExecutionResult{}, readError
+		}
+		executor.notice = string(contents)
+		return execshell.ExecutionResult{}, pushError
+	})
+	return executor
+}
+
+// gitArguments returns the recorded git arguments with the temporary worktree path replaced by a placeholder.
+func (executor *tombstoneGitExecutor) gitArguments() [][]string {
+	recordedArguments := executor.ExecutedArguments(execshell.CommandGit)
+	for _, arguments := range recordedArguments {
+		for argumentIndex := range arguments {
+			if arguments[argumentIndex] == executor.worktreePath {
+				arguments[argumentIndex] = tombstoneWorktreePlaceholderConstant
+			}
+		}
+	}
+	return recordedArguments
+}
+
+// pushWorkingDirectory returns the directory the tombstone notice was pushed from.
+func (executor *tombstoneGitExecutor) pushWorkingDirectory() string {
+	for _, command := range executor.Executed() {
+		if slices.Equal(command.Details.Arguments, []string{"push", "origin", "HEAD:refs/heads/main"}) {
+			return command.Details.WorkingDirectory
+		}
+	}
+	return ""
+}
+
+func TestServiceExecuteLeavesTombstoneBeforeRetirement(testInstance *testing.T) {
+	testInstance.Setenv(githubauth.EnvGitHubCLIToken, testGitHubTokenValue)
+	testInstance.Setenv(githubauth.EnvGitHubToken, testGitHubTokenValue)
+
+	tombstoneArguments := [][]string{
+		{"worktree", "add", "--detach", "<worktree>", "refs/remotes/origin/main"},
+		{"add", "BRANCH_MOVED.md"},
+		{"commit", "--no-verify", "-m", "Docs: note that main moved to master\n\ncreated by gix branch default"},
+		{"push", "origin", "HEAD:refs/heads/main"},
+		{"worktree", "remove", "--force", "<worktree>"},
+	}
+
+	testCases := []struct {
+		name                 string
+		retainSource         SourceRetentionMode
+		pushError            error
+		expectedTombstone    bool
+		expectedArchive      string
+		expectedDeleted      bool
+		expectedWarnings     []string
+		expectedGitArguments [][]string
+	}{
+		{
+			name:              "archive_after_tombstone",
+			retainSource:      SourceRetentionArchive,
+			expectedTombstone: true,
+			expectedArchive:   "archive/main-2024-05-01",
+			expectedGitArguments: append(append([][]string{}, tombstoneArguments...),
+				[]string{"push", "origin", "refs/remotes/origin/main:refs/heads/archive/main-2024-05-01"},
+				[]string{"push", "origin", "--delete", "main"},
+				[]string{"update-ref", "-d", "refs/remotes/origin/main"},
+			),
+		},
+		{
+			name:              "delete_after_tombstone",
+			retainSource:      SourceRetentionDelete,
+			expectedTombstone: true,
+			expectedDeleted:   true,
+			expectedGitArguments: append(append([][]string{}, tombstoneArguments...),
+				[]string{"branch", "-D", "main"},
+				[]string{"push", "origin", "--delete", "main"},
+			),
+		},
+		{
+			name:                 "push_failure_keeps_source",
+			retainSource:         SourceRetentionDelete,
+			pushError:            makeCommandFailedError("remote: protected branch hook declined"),
+			expectedWarnings:     []string{"TOMBSTONE-SKIP: remote: protected branch hook declined"},
+			expectedGitArguments: tombstoneArguments,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			repositoryManager, managerError := gitrepo.NewRepositoryManager(execshelltest.NewPermissiveExecutor())
+			require.NoError(subtest, managerError)
+
+			githubOperations := &recordingGitHubOperations{}
+			gitExecutor := newTombstoneGitExecutor(testCase.pushError)
+
+			service, serviceError := NewService(ServiceDependencies{
+				Logger:            zap.NewNop(),
+				RepositoryManager: repositoryManager,
+				GitHubClient:      githubOperations,
+				GitExecutor:       gitExecutor,
+				Clock:             fixedClock{instant: time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)},
+			})
+			require.NoError(subtest, serviceError)
+
+			result, executionError := service.Execute(context.Background(), MigrationOptions{
+				RepositoryPath:       subtest.TempDir(),
+				RepositoryRemoteName: "origin",
+				RepositoryIdentifier: "owner/example",
+				WorkflowsDirectory:   ".github/workflows",
+				SourceBranch:         BranchMain,
+				TargetBranch:         BranchMaster,
+				RetainSource:         testCase.retainSource,
+				LeaveTombstone:       true,
+			})
+			require.NoError(subtest, executionError)
+			require.Equal(subtest, testCase.expectedTombstone, result.TombstoneCommitted)
+			require.Equal(subtest, testCase.expectedArchive, result.ArchivedSourceBranch)
+			require.Equal(subtest, testCase.expectedDeleted, result.SourceBranchDeleted)
+			require.Equal(subtest, testCase.expectedWarnings, result.Warnings)
+			require.Equal(subtest, testCase.expectedGitArguments, gitExecutor.gitArguments())
+			require.Equal(subtest, gitExecutor.worktreePath, gitExecutor.pushWorkingDirectory())
+			require.Contains(subtest, gitExecutor.notice, "The default branch of owner/example is now `master`.")
+			require.NoDirExists(subtest, filepath.Dir(gitExecutor.worktreePath))
+		})
+	}
+}
+
+func TestServiceExecuteRejectsTombstoneWithoutRetention(testInstance *testing.T) {
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(execshelltest.NewPermissiveExecutor())
+	require.NoError(testInstance, managerError)
+
+	service, serviceError := NewService(ServiceDependencies{
+		Logger:            zap.NewNop(),
+		RepositoryManager: repositoryManager,
+		GitHubClient:      &recordingGitHubOperations{},
+		GitExecutor:       execshelltest.NewPermissiveExecutor(),
+	})
+	require.NoError(testInstance, serviceError)
+
+	_, executionError := service.Execute(context.Background(), MigrationOptions{
+		RepositoryPath:       testInstance.TempDir(),
+		RepositoryRemoteName: "origin",
+		RepositoryIdentifier: "owner/example",
+		WorkflowsDirectory:   ".github/workflows",
+		SourceBranch:         BranchMain,
+		TargetBranch:         BranchMaster,
+		LeaveTombstone:       true,
+	})
+	require.EqualError(testInstance, executionError, "leave_tombstone: requires retain_source delete or archive")
+}
+
+func TestRenderTombstoneNotice(testInstance *testing.T) {
+	notice, renderError := RenderTombstoneNotice(TombstoneNotice{
+		RepositoryIdentifier: "owner/example",
+		RemoteName:           "origin",
+		SourceBranch:         "main",
+		TargetBranch:         "master",
+	})
+	require.NoError(testInstance, renderError)
+	require.Equal(testInstance, "# `main` has moved\n\n"+
+		"The default branch of owner/example is now `master`.\n"+
+		"`main` no longer receives updates and is being retired.\n\n"+
+		"Point scripts, documentation, and existing clones at `master`:\n\n"+
+		"```shell\n"+
+		"git fetch origin\n"+
+		"git checkout master\n"+
+		"git branch --set-upstream-to=origin/master\n"+
+		"```\n", notice)
+}
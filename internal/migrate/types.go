@@ -22,15 +22,29 @@ type MigrationExecutor interface {
 	Execute(executionContext context.Context, options MigrationOptions) (MigrationResult, error)
 }
 
+// GitHubRequestDescriber reads current GitHub settings verbatim and describes the request behind each mutation of
+// GitHubOperations without sending it, so dry runs can show the payloads next to the state they replace.
+type GitHubRequestDescriber interface {
+	GetPagesConfigResponse(executionContext context.Context, repository string) ([]byte, error)
+	GetBranchProtectionResponse(executionContext context.Context, repository string, branchName string) ([]byte, bool, error)
+	DescribeUpdatePagesConfig(repository string, configuration githubcli.PagesConfiguration) (githubcli.IntendedRequest, error)
+	DescribeSetDefaultBranch(repository string, branchName string) (githubcli.IntendedRequest, error)
+	DescribeUpdatePullRequestBase(repository string, pullRequestNumber int, baseBranch string) (githubcli.IntendedRequest, error)
+	DescribeLockBranch(repository string, branchName string) (githubcli.IntendedRequest, error)
+}
+
 // GitHubOperations exposes the GitHub workflows required for migration.
 type GitHubOperations interface {
+	GitHubRequestDescriber
 	ResolveRepoMetadata(executionContext context.Context, repository string) (githubcli.RepositoryMetadata, error)
 	GetPagesConfig(executionContext context.Context, repository string) (githubcli.PagesStatus, error)
 	UpdatePagesConfig(executionContext context.Context, repository string, configuration githubcli.PagesConfiguration) error
 	ListPullRequests(executionContext context.Context, repository string, options githubcli.PullRequestListOptions) ([]githubcli.PullRequest, error)
 	UpdatePullRequestBase(executionContext context.Context, repository string, pullRequestNumber int, baseBranch string) error
 	SetDefaultBranch(executionContext context.Context, repository string, branchName string) error
-	CheckBranchProtection(executionContext context.Context, repository string, branchName string) (bool, error)
+	GetBranchProtection(executionContext context.Context, repository string, branchName string) (githubcli.BranchProtection, bool, error)
+	ListBranchProtectionRules(executionContext context.Context, repository string) (githubcli.BranchProtectionRules, error)
+	LockBranch(executionContext context.Context, repository string, branchName string) error
 }
 
 // BranchName describes a git branch identifier.
@@ -41,3 +55,12 @@ const (
 	BranchMain   BranchName = BranchName("main")
 	BranchMaster BranchName = BranchName("master")
 )
+
+// SourceRetentionMode describes how the source branch is retired once migration completes.
+type SourceRetentionMode string
+
+// Supported source retention modes.
+const (
+	SourceRetentionDelete  SourceRetentionMode = SourceRetentionMode("delete")
+	SourceRetentionArchive SourceRetentionMode = SourceRetentionMode("archive")
+)
@@ -0,0 +1,5 @@
+// Package notify delivers run summaries to external services.
+//
+// It provides the Notifier interface and WebhookNotifier, which POSTs either
+// the summary as JSON or a Slack-compatible message rendered from a template.
+package notify
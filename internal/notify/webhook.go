@@ -0,0 +1,159 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	// DefaultTimeout bounds how long a single notification may take, including connection setup.
+	DefaultTimeout = 10 * time.Second
+
+	webhookTemplateNameConstant          = "notification"
+	contentTypeHeaderConstant            = "Content-Type"
+	jsonContentTypeConstant              = "application/json"
+	webhookURLMissingErrorMessage        = "notification webhook URL is empty"
+	webhookURLInvalidErrorMessage        = "invalid notification webhook URL: must be an absolute http or https URL"
+	webhookTemplateParseErrorTemplate    = "invalid notification template: %w"
+	webhookTemplateRenderErrorTemplate   = "unable to render notification template: %w"
+	webhookPayloadEncodeErrorTemplate    = "unable to encode notification payload: %w"
+	webhookRequestBuildErrorMessage      = "unable to build notification request"
+	webhookRequestErrorTemplate          = "notification request to %s failed: %w"
+	webhookUnexpectedStatusErrorTemplate = "notification request to %s returned status %d"
+	webhookResponseDrainLimitConstant    = 4096
+)
+
+// Notifier delivers a run summary. The payload is encoded as JSON or used as template data, so exported fields and
+// json tags form its wire format.
+type Notifier interface {
+	Notify(executionContext context.Context, payload any) error
+}
+
+// HTTPClient abstracts the Do method of http.Client for easier testing.
+type HTTPClient interface {
+	Do(request *http.Request) (*http.Response, error)
+}
+
+// Configuration describes the notify: block of an operation.
+type Configuration struct {
+	// WebhookURL receives a POST after each run; notifications are disabled when it is empty.
+	WebhookURL string `mapstructure:"webhook_url"`
+	// Template renders a Slack-compatible {"text": ...} message from the payload fields; when empty the payload is
+	// posted as JSON.
+	Template string `mapstructure:"template"`
+}
+
+// Sanitize trims configured values.
+func (configuration Configuration) Sanitize() Configuration {
+	sanitized := configuration
+	sanitized.WebhookURL = strings.TrimSpace(configuration.WebhookURL)
+	sanitized.Template = strings.TrimSpace(configuration.Template)
+	return sanitized
+}
+
+// Enabled reports whether a webhook URL is configured.
+func (configuration Configuration) Enabled() bool {
+	return len(strings.TrimSpace(configuration.WebhookURL)) > 0
+}
+
+// WebhookNotifier POSTs run summaries to a webhook URL.
+type WebhookNotifier struct {
+	webhookURL string
+	// webhookHost names the endpoint in errors; webhook URLs often embed secrets, so the full URL is never reported.
+	webhookHost string
+	template    *template.Template
+	httpClient  HTTPClient
+	timeout     time.Duration
+}
+
+// slackMessage is the minimal message body accepted by Slack incoming webhooks and compatible services.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// NewWebhookNotifier validates the configuration and returns a notifier for it. A nil client uses http.DefaultClient.
+func NewWebhookNotifier(configuration Configuration, httpClient HTTPClient) (*WebhookNotifier, error) {
+	sanitized := configuration.Sanitize()
+	if !sanitized.Enabled() {
+		return nil, errors.New(webhookURLMissingErrorMessage)
+	}
+	parsedURL, parseError := url.Parse(sanitized.WebhookURL)
+	if parseError != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return nil, errors.New(webhookURLInvalidErrorMessage)
+	}
+
+	notifier := &WebhookNotifier{webhookURL: sanitized.WebhookURL, webhookHost: parsedURL.Host, httpClient: httpClient, timeout: DefaultTimeout}
+	if notifier.httpClient == nil {
+		notifier.httpClient = http.DefaultClient
+	}
+	if len(sanitized.Template) > 0 {
+		parsedTemplate, templateError := template.New(webhookTemplateNameConstant).Option("missingkey=error").Parse(sanitized.Template)
+		if templateError != nil {
+			return nil, fmt.Errorf(webhookTemplateParseErrorTemplate, templateError)
+		}
+		notifier.template = parsedTemplate
+	}
+	return notifier, nil
+}
+
+// Notify POSTs the payload and fails on transport errors, on non-2xx responses, or when DefaultTimeout elapses.
+func (notifier *WebhookNotifier) Notify(executionContext context.Context, payload any) error {
+	body, bodyError := notifier.renderBody(payload)
+	if bodyError != nil {
+		return bodyError
+	}
+
+	timeoutContext, cancel := context.WithTimeout(executionContext, notifier.timeout)
+	defer cancel()
+
+	request, requestError := http.NewRequestWithContext(timeoutContext, http.MethodPost, notifier.webhookURL, bytes.NewReader(body))
+	if requestError != nil {
+		return errors.New(webhookRequestBuildErrorMessage)
+	}
+	request.Header.Set(contentTypeHeaderConstant, jsonContentTypeConstant)
+
+	response, responseError := notifier.httpClient.Do(request)
+	if responseError != nil {
+		var urlError *url.Error
+		if errors.As(responseError, &urlError) {
+			responseError = urlError.Err
+		}
+		return fmt.Errorf(webhookRequestErrorTemplate, notifier.webhookHost, responseError)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, webhookResponseDrainLimitConstant))
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf(webhookUnexpectedStatusErrorTemplate, notifier.webhookHost, response.StatusCode)
+	}
+	return nil
+}
+
+func (notifier *WebhookNotifier) renderBody(payload any) ([]byte, error) {
+	if notifier.template == nil {
+		encoded, encodeError := json.Marshal(payload)
+		if encodeError != nil {
+			return nil, fmt.Errorf(webhookPayloadEncodeErrorTemplate, encodeError)
+		}
+		return encoded, nil
+	}
+
+	var rendered strings.Builder
+	if renderError := notifier.template.Execute(&rendered, payload); renderError != nil {
+		return nil, fmt.Errorf(webhookTemplateRenderErrorTemplate, renderError)
+	}
+	encoded, encodeError := json.Marshal(slackMessage{Text: rendered.String()})
+	if encodeError != nil {
+		return nil, fmt.Errorf(webhookPayloadEncodeErrorTemplate, encodeError)
+	}
+	return encoded, nil
+}
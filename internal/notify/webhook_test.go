@@ -0,0 +1,128 @@
+package notify_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/notify"
+)
+
+type recordingHTTPClient struct {
+	statusCode  int
+	err         error
+	body        string
+	contentType string
+	deadline    time.Duration
+}
+
+func (client *recordingHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	body, readError := io.ReadAll(request.Body)
+	if readError != nil {
+		return nil, readError
+	}
+	client.body = string(body)
+	client.contentType = request.Header.Get("Content-Type")
+	if deadline, hasDeadline := request.Context().Deadline(); hasDeadline {
+		client.deadline = time.Until(deadline)
+	}
+	if client.err != nil {
+		return nil, client.err
+	}
+	return &http.Response{StatusCode: client.statusCode, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+type summary struct {
+	PackageCount  int    `json:"package_count"`
+	ReclaimedSize string `json:"reclaimed_size"`
+}
+
+func TestWebhookNotifierNotify(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configuration notify.Configuration
+		client        *recordingHTTPClient
+		expectedBody  string
+		expectedError string
+	}{
+		{
+			name:          "json_payload",
+			configuration: notify.Configuration{WebhookURL: "https://hooks.example.com/purge"},
+			client:        &recordingHTTPClient{statusCode: http.StatusNoContent},
+			expectedBody:  `{"package_count":3,"reclaimed_size":"1.5 MiB"}`,
+		},
+		{
+			name:          "slack_template",
+			configuration: notify.Configuration{WebhookURL: "https://hooks.example.com/purge", Template: "Purged {{.PackageCount}} packages, reclaimed {{.ReclaimedSize}}"},
+			client:        &recordingHTTPClient{statusCode: http.StatusOK},
+			expectedBody:  `{"text":"Purged 3 packages, reclaimed 1.5 MiB"}`,
+		},
+		{
+			name:          "unexpected_status",
+			configuration: notify.Configuration{WebhookURL: "https://hooks.example.com/services/SECRET"},
+			client:        &recordingHTTPClient{statusCode: http.StatusInternalServerError},
+			expectedBody:  `{"package_count":3,"reclaimed_size":"1.5 MiB"}`,
+			expectedError: "notification request to hooks.example.com returned status 500",
+		},
+		{
+			name:          "transport_failure",
+			configuration: notify.Configuration{WebhookURL: "https://hooks.example.com/services/SECRET"},
+			client:        &recordingHTTPClient{err: errors.New("connection refused")},
+			expectedBody:  `{"package_count":3,"reclaimed_size":"1.5 MiB"}`,
+			expectedError: "notification request to hooks.example.com failed: connection refused",
+		},
+		{
+			name:          "unknown_template_field",
+			configuration: notify.Configuration{WebhookURL: "https://hooks.example.com/purge", Template: "{{.Missing}}"},
+			client:        &recordingHTTPClient{statusCode: http.StatusOK},
+			expectedError: "unable to render notification template",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			notifier, notifierError := notify.NewWebhookNotifier(testCase.configuration, testCase.client)
+			require.NoError(t, notifierError)
+
+			notifyError := notifier.Notify(context.Background(), summary{PackageCount: 3, ReclaimedSize: "1.5 MiB"})
+			if len(testCase.expectedError) > 0 {
+				require.ErrorContains(t, notifyError, testCase.expectedError)
+				require.NotContains(t, notifyError.Error(), "SECRET")
+			} else {
+				require.NoError(t, notifyError)
+			}
+			require.Equal(t, testCase.expectedBody, testCase.client.body)
+			if len(testCase.expectedBody) > 0 {
+				require.Equal(t, "application/json", testCase.client.contentType)
+				require.Greater(t, testCase.client.deadline, time.Duration(0))
+				require.LessOrEqual(t, testCase.client.deadline, notify.DefaultTimeout)
+			}
+		})
+	}
+}
+
+func TestNewWebhookNotifierValidatesConfiguration(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configuration notify.Configuration
+		expectedError string
+	}{
+		{name: "missing_url", configuration: notify.Configuration{WebhookURL: "  "}, expectedError: "notification webhook URL is empty"},
+		{name: "relative_url", configuration: notify.Configuration{WebhookURL: "hooks/purge"}, expectedError: "invalid notification webhook URL"},
+		{name: "unsupported_scheme", configuration: notify.Configuration{WebhookURL: "ftp://hooks.example.com/purge"}, expectedError: "invalid notification webhook URL"},
+		{name: "invalid_template", configuration: notify.Configuration{WebhookURL: "https://hooks.example.com/purge", Template: "{{.PackageCount"}, expectedError: "invalid notification template"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, notifierError := notify.NewWebhookNotifier(testCase.configuration, nil)
+			require.ErrorContains(t, notifierError, testCase.expectedError)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package packages
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/temirov/gix/internal/ui"
+)
+
+const (
+	noAnnotationsFlagNameConstant        = "no-annotations"
+	noAnnotationsFlagDescriptionConstant = "Do not emit GitHub Actions ::warning:: and ::error:: annotations when running under GitHub Actions"
+	annotationParameterNameConstant      = "annotations"
+	failureAnnotationTitleConstant       = "repo packages delete"
+	rateLimitAnnotationTitleConstant     = "repo packages delete rate limit"
+	failureAnnotationTemplateConstant    = "%s/%s version %d (%s) was not deleted: status %d %s"
+	rateLimitMessageFragmentConstant     = "rate limit"
+)
+
+// annotatePurgeFailures mirrors per-version deletion failures as workflow annotations. Rate-limited deletions are
+// warnings because a later run can retry them; every other failure is an error.
+func annotatePurgeFailures(annotations *ui.AnnotationEmitter, failures []PurgeFailure) {
+	for _, failure := range failures {
+		message := fmt.Sprintf(failureAnnotationTemplateConstant, failure.Owner, failure.PackageName, failure.VersionID, failure.Digest, failure.StatusCode, failure.Message)
+		if isRateLimitedFailure(failure) {
+			annotations.Warning(rateLimitAnnotationTitleConstant, message)
+			continue
+		}
+		annotations.Error(failureAnnotationTitleConstant, message)
+	}
+}
+
+// annotatePartialPurge mirrors the final partial-failure summary as an error annotation.
+func annotatePartialPurge(annotations *ui.AnnotationEmitter, failures []PurgeFailure) {
+	annotations.Error(failureAnnotationTitleConstant, PartialPurgeError{Failures: failures}.Error())
+}
+
+func isRateLimitedFailure(failure PurgeFailure) bool {
+	if failure.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return failure.StatusCode == http.StatusForbidden && strings.Contains(strings.ToLower(failure.Message), rateLimitMessageFragmentConstant)
+}
@@ -0,0 +1,197 @@
+package packages
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/temirov/gix/internal/ghcr"
+)
+
+const (
+	candidateDigestColumnConstant        = "digest"
+	candidateTagsColumnConstant          = "tags"
+	candidateSizeColumnConstant          = "size"
+	candidateUpdatedAtColumnConstant     = "updated_at"
+	candidateReasonColumnConstant        = "reason"
+	candidateTagSeparatorConstant        = ";"
+	candidateDigestColumnMissingMessage  = "candidates file has no digest column"
+	candidateFileDecodeErrorTemplate     = "unable to parse candidates file: %w"
+	approvalRefusedTemplateConstant      = "PACKAGES-APPROVAL-REFUSED: %s/%s digest=%s no longer matches the purge policy; pass --force to delete it\n"
+	approvalMissingTemplateConstant      = "PACKAGES-APPROVAL-MISSING: digest=%s was not found in any evaluated package\n"
+	approvalRefusedErrorTemplateConstant = "%d approved digest(s) no longer match the purge policy; pass --force to delete them"
+	candidatesExportedTemplateConstant   = "PACKAGES-CANDIDATES-EXPORTED: %s (%d candidate(s))\n"
+	candidatesExportWriteErrorTemplate   = "unable to write purge candidates %s: %w"
+	approvedCandidatesReadErrorTemplate  = "unable to read approved candidates %s: %w"
+	candidateLedgerParameterNameConstant = "candidate_ledger"
+	approvedDigestsParameterNameConstant = "approved_digests"
+	forceApprovedParameterNameConstant   = "force_approved"
+	candidateSizeFormatBaseConstant      = 10
+	candidateUpdatedAtLayoutConstant     = time.RFC3339
+	candidateDigestColumnNotFoundIndex   = -1
+)
+
+var candidateColumns = []string{
+	candidateDigestColumnConstant,
+	candidateTagsColumnConstant,
+	candidateSizeColumnConstant,
+	candidateUpdatedAtColumnConstant,
+	candidateReasonColumnConstant,
+}
+
+// ApprovalRefusal identifies an approved digest left in place because the purge policy no longer selects it.
+type ApprovalRefusal struct {
+	Owner       string
+	PackageName string
+	Digest      string
+}
+
+// CandidateLedger accumulates purge candidates and approval refusals across every package processed in a run.
+type CandidateLedger struct {
+	mutex       sync.Mutex
+	candidates  []ghcr.PurgeCandidate
+	refusals    []ApprovalRefusal
+	seenDigests map[string]struct{}
+}
+
+// Record stores the candidates a purge selected and the approved digests it refused for one package.
+func (ledger *CandidateLedger) Record(owner string, packageName string, result ghcr.PurgeResult) []ApprovalRefusal {
+	if ledger == nil {
+		return nil
+	}
+	ledger.mutex.Lock()
+	defer ledger.mutex.Unlock()
+	if ledger.seenDigests == nil {
+		ledger.seenDigests = map[string]struct{}{}
+	}
+
+	ledger.candidates = append(ledger.candidates, result.Candidates...)
+	for _, candidate := range result.Candidates {
+		ledger.seenDigests[candidate.Digest] = struct{}{}
+	}
+	refusals := make([]ApprovalRefusal, 0, len(result.RefusedDigests))
+	for _, digest := range result.RefusedDigests {
+		ledger.seenDigests[digest] = struct{}{}
+		refusals = append(refusals, ApprovalRefusal{Owner: owner, PackageName: packageName, Digest: digest})
+	}
+	ledger.refusals = append(ledger.refusals, refusals...)
+	return refusals
+}
+
+// Candidates returns every recorded candidate in the order it was added.
+func (ledger *CandidateLedger) Candidates() []ghcr.PurgeCandidate {
+	if ledger == nil {
+		return nil
+	}
+	ledger.mutex.Lock()
+	defer ledger.mutex.Unlock()
+	return append([]ghcr.PurgeCandidate(nil), ledger.candidates...)
+}
+
+// Refusals returns every recorded approval refusal in the order it was added.
+func (ledger *CandidateLedger) Refusals() []ApprovalRefusal {
+	if ledger == nil {
+		return nil
+	}
+	ledger.mutex.Lock()
+	defer ledger.mutex.Unlock()
+	return append([]ApprovalRefusal(nil), ledger.refusals...)
+}
+
+// UnmatchedDigests returns the provided digests that no evaluated package selected or refused.
+func (ledger *CandidateLedger) UnmatchedDigests(digests []string) []string {
+	if ledger == nil {
+		return nil
+	}
+	ledger.mutex.Lock()
+	defer ledger.mutex.Unlock()
+	unmatched := make([]string, 0)
+	for _, digest := range digests {
+		if _, seen := ledger.seenDigests[digest]; !seen {
+			unmatched = append(unmatched, digest)
+		}
+	}
+	return unmatched
+}
+
+// WriteCandidatesCSV writes candidates as CSV with digest, tags, size, updated_at, and reason columns. Tags are joined
+// with semicolons, size is in bytes, and updated_at is RFC 3339 or empty when unknown.
+func WriteCandidatesCSV(writer io.Writer, candidates []ghcr.PurgeCandidate) error {
+	csvWriter := csv.NewWriter(writer)
+	if headerError := csvWriter.Write(candidateColumns); headerError != nil {
+		return headerError
+	}
+	for _, candidate := range candidates {
+		updatedAt := ""
+		if !candidate.UpdatedAt.IsZero() {
+			updatedAt = candidate.UpdatedAt.UTC().Format(candidateUpdatedAtLayoutConstant)
+		}
+		record := []string{
+			candidate.Digest,
+			strings.Join(candidate.Tags, candidateTagSeparatorConstant),
+			strconv.FormatInt(candidate.Size, candidateSizeFormatBaseConstant),
+			updatedAt,
+			candidate.Reason,
+		}
+		if recordError := csvWriter.Write(record); recordError != nil {
+			return recordError
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// ReadApprovedDigests reads the digest column of a candidates file, which may have been edited by hand. Other columns
+// are ignored, blank digests are skipped, and the returned slice is never nil so an empty file approves nothing.
+func ReadApprovedDigests(reader io.Reader) ([]string, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, headerError := csvReader.Read()
+	if errors.Is(headerError, io.EOF) {
+		return []string{}, nil
+	}
+	if headerError != nil {
+		return nil, fmt.Errorf(candidateFileDecodeErrorTemplate, headerError)
+	}
+	digestColumn := candidateDigestColumnNotFoundIndex
+	for columnIndex, columnName := range header {
+		if strings.EqualFold(strings.TrimSpace(columnName), candidateDigestColumnConstant) {
+			digestColumn = columnIndex
+			break
+		}
+	}
+	if digestColumn == candidateDigestColumnNotFoundIndex {
+		return nil, errors.New(candidateDigestColumnMissingMessage)
+	}
+
+	digests := []string{}
+	seen := map[string]struct{}{}
+	for {
+		record, recordError := csvReader.Read()
+		if errors.Is(recordError, io.EOF) {
+			return digests, nil
+		}
+		if recordError != nil {
+			return nil, fmt.Errorf(candidateFileDecodeErrorTemplate, recordError)
+		}
+		if digestColumn >= len(record) {
+			continue
+		}
+		digest := strings.TrimSpace(record[digestColumn])
+		if len(digest) == 0 {
+			continue
+		}
+		if _, duplicate := seen[digest]; duplicate {
+			continue
+		}
+		seen[digest] = struct{}{}
+		digests = append(digests, digest)
+	}
+}
@@ -0,0 +1,68 @@
+package packages_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/ghcr"
+	"github.com/temirov/gix/internal/packages"
+)
+
+func TestWriteCandidatesCSV(testInstance *testing.T) {
+	candidates := []ghcr.PurgeCandidate{
+		{Digest: "sha256:aaa", Size: 4096, UpdatedAt: time.Date(2026, time.March, 4, 5, 6, 7, 0, time.UTC), Reason: ghcr.PurgeReasonUntagged},
+		{Digest: "sha256:bbb", Tags: []string{"pr-7", "pr-8"}, Size: 10, Reason: "pull request tag(s) pr-7 #7 merged, pr-8 #8 closed"},
+	}
+
+	var encoded bytes.Buffer
+	require.NoError(testInstance, packages.WriteCandidatesCSV(&encoded, candidates))
+	require.Equal(testInstance, "digest,tags,size,updated_at,reason\n"+
+		"sha256:aaa,,4096,2026-03-04T05:06:07Z,untagged\n"+
+		"sha256:bbb,pr-7;pr-8,10,,\"pull request tag(s) pr-7 #7 merged, pr-8 #8 closed\"\n", encoded.String())
+
+	digests, readError := packages.ReadApprovedDigests(&encoded)
+	require.NoError(testInstance, readError)
+	require.Equal(testInstance, []string{"sha256:aaa", "sha256:bbb"}, digests)
+}
+
+func TestReadApprovedDigests(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		contents        string
+		expectedDigests []string
+		expectedError   string
+	}{
+		{
+			name:            "hand_edited_file",
+			contents:        "reason,Digest\nkeep,\"sha256:aaa\"\n,\nagain, sha256:aaa\nshort\nok,sha256:bbb\n",
+			expectedDigests: []string{"sha256:aaa", "sha256:bbb"},
+		},
+		{
+			name:            "empty_file_approves_nothing",
+			contents:        "",
+			expectedDigests: []string{},
+		},
+		{
+			name:          "missing_digest_column",
+			contents:      "tags,size\nlatest,10\n",
+			expectedError: "candidates file has no digest column",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			digests, readError := packages.ReadApprovedDigests(strings.NewReader(testCase.contents))
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, readError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, readError)
+			require.Equal(subtest, testCase.expectedDigests, digests)
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package packages
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -11,8 +13,11 @@ import (
 	"github.com/temirov/gix/internal/ghcr"
 	"github.com/temirov/gix/internal/githubcli"
 	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/notify"
 	"github.com/temirov/gix/internal/repos/dependencies"
+	"github.com/temirov/gix/internal/repos/prompt"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/ui"
 	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 	rootutils "github.com/temirov/gix/internal/utils/roots"
@@ -23,7 +28,6 @@ const (
 	packagesPurgeCommandUseConstant                           = "repo-packages-purge"
 	packagesPurgeCommandShortDescriptionConstant              = "Delete untagged GHCR versions"
 	packagesPurgeCommandLongDescriptionConstant               = "repo-packages-purge removes untagged container versions from GitHub Container Registry."
-	unexpectedArgumentsErrorMessageConstant                   = "repo-packages-purge does not accept positional arguments"
 	commandExecutionErrorTemplateConstant                     = "repo-packages-purge failed: %w"
 	packageFlagNameConstant                                   = "package"
 	packageFlagDescriptionConstant                            = "Container package name in GHCR"
@@ -41,6 +45,41 @@ const (
 	repositoryMetadataFailedMessageConstant                   = "Failed to resolve repository metadata"
 	repositoryPurgeFailedMessageConstant                      = "repo-packages-purge failed for repository"
 	ownerRepoSeparatorConstant                                = "/"
+	entirePackageFlagNameConstant                             = "entire-package"
+	entirePackageFlagDescriptionConstant                      = "Delete the whole package instead of its untagged versions; requires typing the package name to confirm"
+	forceFlagNameConstant                                     = "force"
+	forceFlagDescriptionConstant                              = "Allow --entire-package to delete packages that still have tagged versions, or --approved-candidates to delete approved digests the purge policy no longer selects"
+	forceWithoutEntirePackageErrorMessageConstant             = "--force requires --entire-package or --approved-candidates"
+	reclaimPlanTotalTemplateConstant                          = "PLAN-PACKAGES-RECLAIM-TOTAL: %s (%d bytes) across %d package(s)\n"
+	reclaimedTotalTemplateConstant                            = "PACKAGES-RECLAIMED-TOTAL: %s (%d bytes) across %d package(s)\n"
+	failedTotalTemplateConstant                               = "PACKAGES-FAILED-TOTAL: %d version deletion(s) failed across %d package(s)\n"
+	failFastFlagNameConstant                                  = "fail-fast"
+	failFastFlagDescriptionConstant                           = "Abort on the first failed version deletion instead of continuing and exiting with the partial-failure code"
+	snapshotFlagNameConstant                                  = "snapshot"
+	snapshotFlagDescriptionConstant                           = "Evaluate the purge against a recorded version snapshot file instead of GHCR; implies --dry-run"
+	dumpSnapshotFlagNameConstant                              = "dump-snapshot"
+	dumpSnapshotFlagDescriptionConstant                       = "Write the version listings read from GHCR to a snapshot file for later --snapshot runs; implies --dry-run"
+	snapshotFlagsConflictErrorMessageConstant                 = "--snapshot and --dump-snapshot cannot be combined"
+	snapshotReadErrorTemplateConstant                         = "unable to read package snapshot %s: %w"
+	snapshotWriteErrorTemplateConstant                        = "unable to write package snapshot %s: %w"
+	snapshotWrittenTemplateConstant                           = "PACKAGES-SNAPSHOT-WRITTEN: %s (%d package(s))\n"
+	filterLabelFlagNameConstant                               = "filter-label"
+	filterLabelFlagDescriptionConstant                        = "Only purge untagged versions whose image config has this label (key=value, repeatable; all must match). Fetches each candidate's manifest and config blob"
+	filterLabelEntirePackageConflictErrorMessageConstant      = "--filter-label cannot be combined with --entire-package"
+	skipPermissionCheckFlagNameConstant                       = "skip-permission-check"
+	skipPermissionCheckFlagDescriptionConstant                = "Skip the check that the token may delete packages before the first deletion"
+	pullRequestTagPrefixFlagNameConstant                      = "pr-tag-prefix"
+	pullRequestTagPrefixFlagDescriptionConstant               = "Purge versions tagged <prefix><number> (for example pr-123) whose pull request is closed or merged instead of untagged versions"
+	pullRequestTagPrefixEntirePackageConflictMessageConstant  = "--pr-tag-prefix cannot be combined with --entire-package"
+	pullRequestTagPrefixFilterLabelConflictMessageConstant    = "--pr-tag-prefix cannot be combined with --filter-label"
+	pullRequestTagPrefixSnapshotConflictMessageConstant       = "--pr-tag-prefix cannot be combined with --snapshot because pull request states are not recorded"
+	exportCandidatesFlagNameConstant                          = "export-candidates"
+	exportCandidatesFlagDescriptionConstant                   = "Write the versions the purge policy selects to a CSV file (digest, tags, size, updated_at, reason) for approval; implies --dry-run"
+	approvedCandidatesFlagNameConstant                        = "approved-candidates"
+	approvedCandidatesFlagDescriptionConstant                 = "Delete exactly the digests listed in a candidates CSV file, refusing those the purge policy no longer selects unless --force"
+	candidatesFlagsConflictErrorMessageConstant               = "--export-candidates and --approved-candidates cannot be combined"
+	candidatesEntirePackageConflictErrorMessageConstant       = "--export-candidates and --approved-candidates cannot be combined with --entire-package"
+	approvedCandidatesSnapshotConflictErrorMessageConstant    = "--approved-candidates cannot be combined with --snapshot or --dump-snapshot"
 )
 
 // LoggerProvider supplies a zap logger instance.
@@ -70,16 +109,34 @@ type CommandBuilder struct {
 	WorkingDirectoryResolver   WorkingDirectoryResolver
 	RepositoryDiscoverer       shared.RepositoryDiscoverer
 	TaskRunnerFactory          func(workflow.Dependencies) TaskRunnerExecutor
+	PhraseConfirmerFactory     func(*cobra.Command) shared.PhraseConfirmationPrompter
+	PullRequestStateResolver   PullRequestStateResolver
+	NotificationHTTPClient     notify.HTTPClient
 }
 
 // WorkingDirectoryResolver resolves the directory containing the active repository.
 type WorkingDirectoryResolver func() (string, error)
 
 type commandExecutionOptions struct {
-	PackageNameOverride string
-	DryRun              bool
-	TokenSource         TokenSourceConfiguration
-	RepositoryRoots     []string
+	PackageNameOverride  string
+	DryRun               bool
+	TokenSource          TokenSourceConfiguration
+	RepositoryRoots      []string
+	EntirePackage        bool
+	Force                bool
+	FailFast             bool
+	SnapshotPath         string
+	DumpSnapshotPath     string
+	LabelFilters         []ghcr.LabelFilter
+	NoAnnotations        bool
+	SkipPermissionCheck  bool
+	PullRequestTagPrefix string
+	// ExportCandidatesPath receives the policy's purge candidates as CSV; ApprovedCandidatesPath names a CSV file
+	// whose digest column limits deletion.
+	ExportCandidatesPath   string
+	ApprovedCandidatesPath string
+	Retention              ghcr.RetentionPolicy
+	Notification           notify.Configuration
 }
 
 // Build constructs the repo-packages-purge command with purge functionality.
@@ -87,20 +144,28 @@ func (builder *CommandBuilder) Build() (*cobra.Command, error) {
 	purgeCommand := &cobra.Command{
 		Use:   packagesPurgeCommandUseConstant,
 		Short: packagesPurgeCommandShortDescriptionConstant,
-		Long:  packagesPurgeCommandLongDescriptionConstant,
+		Long:  purgeCommandLongDescription(),
 		RunE:  builder.runPurge,
 	}
 
 	purgeCommand.Flags().String(packageFlagNameConstant, "", packageFlagDescriptionConstant)
+	flagutils.AddToggleFlag(purgeCommand.Flags(), nil, entirePackageFlagNameConstant, "", false, entirePackageFlagDescriptionConstant)
+	flagutils.AddToggleFlag(purgeCommand.Flags(), nil, forceFlagNameConstant, "", false, forceFlagDescriptionConstant)
+	flagutils.AddToggleFlag(purgeCommand.Flags(), nil, failFastFlagNameConstant, "", false, failFastFlagDescriptionConstant)
+	purgeCommand.Flags().String(snapshotFlagNameConstant, "", snapshotFlagDescriptionConstant)
+	purgeCommand.Flags().String(dumpSnapshotFlagNameConstant, "", dumpSnapshotFlagDescriptionConstant)
+	purgeCommand.Flags().StringArray(filterLabelFlagNameConstant, nil, filterLabelFlagDescriptionConstant)
+	flagutils.AddToggleFlag(purgeCommand.Flags(), nil, noAnnotationsFlagNameConstant, "", false, noAnnotationsFlagDescriptionConstant)
+	flagutils.AddToggleFlag(purgeCommand.Flags(), nil, skipPermissionCheckFlagNameConstant, "", false, skipPermissionCheckFlagDescriptionConstant)
+	purgeCommand.Flags().String(pullRequestTagPrefixFlagNameConstant, "", pullRequestTagPrefixFlagDescriptionConstant)
+	purgeCommand.Flags().String(exportCandidatesFlagNameConstant, "", exportCandidatesFlagDescriptionConstant)
+	purgeCommand.Flags().String(approvedCandidatesFlagNameConstant, "", approvedCandidatesFlagDescriptionConstant)
+	addRetentionFlags(purgeCommand)
 
 	return purgeCommand, nil
 }
 
 func (builder *CommandBuilder) runPurge(command *cobra.Command, arguments []string) error {
-	if len(arguments) > 0 {
-		return errors.New(unexpectedArgumentsErrorMessageConstant)
-	}
-
 	logger := builder.resolveLogger()
 	executionFlags, executionFlagsAvailable := flagutils.ResolveExecutionFlags(command)
 
@@ -108,8 +173,33 @@ func (builder *CommandBuilder) runPurge(command *cobra.Command, arguments []stri
 	if optionsError != nil {
 		return optionsError
 	}
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), executionOptions)
+	annotations := builder.resolveAnnotationEmitter(command, executionOptions.NoAnnotations)
+
+	if len(executionOptions.SnapshotPath) > 0 {
+		return builder.replaySnapshot(command, logger, executionOptions, annotations)
+	}
+
+	var approvedDigests []string
+	if len(executionOptions.ApprovedCandidatesPath) > 0 {
+		readDigests, readError := builder.readApprovedCandidates(executionOptions.ApprovedCandidatesPath)
+		if readError != nil {
+			return readError
+		}
+		approvedDigests = readDigests
+	}
+
+	notifier, notifierError := builder.resolvePurgeNotifier(executionOptions.Notification)
+	if notifierError != nil {
+		return notifierError
+	}
+
+	var snapshotRecorder *ghcr.SnapshotRecorder
+	if len(executionOptions.DumpSnapshotPath) > 0 {
+		snapshotRecorder = ghcr.NewSnapshotRecorder()
+	}
 
-	purgeService, serviceError := builder.resolvePurgeService(logger)
+	purgeService, serviceError := builder.resolvePurgeService(logger, snapshotRecorder)
 	if serviceError != nil {
 		return serviceError
 	}
@@ -174,12 +264,47 @@ func (builder *CommandBuilder) runPurge(command *cobra.Command, arguments []stri
 
 	taskRunner := resolveTaskRunner(builder.TaskRunnerFactory, taskDependencies)
 
+	storageTally := &StorageTally{}
+	failureTally := &PurgeFailureTally{}
+	candidateLedger := &CandidateLedger{}
 	actionOptions := map[string]any{
 		"service":           purgeService,
 		"metadata_resolver": repositoryMetadataResolver,
 		"token_source":      executionOptions.TokenSource,
 		"package_override":  executionOptions.PackageNameOverride,
 		"dry_run":           executionOptions.DryRun,
+		"storage_tally":     storageTally,
+		"fail_fast":         executionOptions.FailFast,
+
+		candidateLedgerParameterNameConstant: candidateLedger,
+	}
+	if approvedDigests != nil {
+		actionOptions[approvedDigestsParameterNameConstant] = approvedDigests
+		actionOptions[forceApprovedParameterNameConstant] = executionOptions.Force
+	}
+	if executionOptions.SkipPermissionCheck {
+		actionOptions["skip_permission_check"] = true
+	}
+	if annotations.Enabled() {
+		actionOptions[annotationParameterNameConstant] = annotations
+	}
+	if len(executionOptions.LabelFilters) > 0 {
+		actionOptions["label_filters"] = executionOptions.LabelFilters
+	}
+	if executionOptions.Retention.Enabled() {
+		actionOptions[retentionParameterNameConstant] = executionOptions.Retention
+	}
+	if len(executionOptions.PullRequestTagPrefix) > 0 {
+		actionOptions["pr_tag_prefix"] = executionOptions.PullRequestTagPrefix
+		actionOptions["pull_request_states"] = builder.resolvePullRequestStateResolver(githubClient)
+	}
+	if !executionOptions.FailFast {
+		actionOptions["failure_tally"] = failureTally
+	}
+	if executionOptions.EntirePackage {
+		actionOptions["entire_package"] = true
+		actionOptions["force"] = executionOptions.Force
+		actionOptions["phrase_confirmer"] = builder.resolvePhraseConfirmer(command)
 	}
 
 	taskDefinition := workflow.TaskDefinition{
@@ -195,7 +320,166 @@ func (builder *CommandBuilder) runPurge(command *cobra.Command, arguments []stri
 		AssumeYes: executionFlags.AssumeYes,
 	}
 
-	return taskRunner.Run(command.Context(), executionOptions.RepositoryRoots, []workflow.TaskDefinition{taskDefinition}, runtimeOptions)
+	runError := taskRunner.Run(command.Context(), executionOptions.RepositoryRoots, []workflow.TaskDefinition{taskDefinition}, runtimeOptions)
+	if runError != nil {
+		sendPurgeNotification(command.Context(), logger, notifier, buildPurgeNotification(executionOptions.DryRun, storageTally, failureTally, runError))
+		return runError
+	}
+
+	if snapshotRecorder != nil {
+		if writeError := writeSnapshot(command, executionOptions.DumpSnapshotPath, snapshotRecorder.Snapshot()); writeError != nil {
+			return writeError
+		}
+	}
+	if len(executionOptions.ExportCandidatesPath) > 0 {
+		if writeError := writeCandidatesExport(command, executionOptions.ExportCandidatesPath, candidateLedger.Candidates()); writeError != nil {
+			return writeError
+		}
+	}
+
+	totalsError := reportPurgeTotals(command, executionOptions.DryRun, storageTally, failureTally, annotations)
+	sendPurgeNotification(command.Context(), logger, notifier, buildPurgeNotification(executionOptions.DryRun, storageTally, failureTally, totalsError))
+	if totalsError != nil {
+		return totalsError
+	}
+	return reportApprovalOutcome(command, approvedDigests, candidateLedger)
+}
+
+func (builder *CommandBuilder) replaySnapshot(command *cobra.Command, logger *zap.Logger, executionOptions commandExecutionOptions, annotations *ui.AnnotationEmitter) error {
+	snapshot, snapshotError := builder.readSnapshot(executionOptions.SnapshotPath)
+	if snapshotError != nil {
+		return snapshotError
+	}
+
+	snapshotResolver := &DefaultPurgeServiceResolver{EnvironmentLookup: builder.EnvironmentLookup, Snapshot: &snapshot}
+	purgeService, serviceError := snapshotResolver.Resolve(logger)
+	if serviceError != nil {
+		return serviceError
+	}
+
+	environment := &workflow.Environment{Output: command.OutOrStdout(), Errors: command.ErrOrStderr(), DryRun: true}
+	storageTally := &StorageTally{}
+	failureTally := &PurgeFailureTally{}
+	candidateLedger := &CandidateLedger{}
+	for _, recordedPackage := range snapshot.Packages {
+		if len(executionOptions.PackageNameOverride) > 0 && !strings.EqualFold(executionOptions.PackageNameOverride, recordedPackage.PackageName) {
+			continue
+		}
+		options := PurgeOptions{
+			Owner:         recordedPackage.Owner,
+			PackageName:   recordedPackage.PackageName,
+			OwnerType:     recordedPackage.OwnerType,
+			TokenSource:   executionOptions.TokenSource,
+			DryRun:        true,
+			EntirePackage: executionOptions.EntirePackage,
+			Force:         executionOptions.Force,
+			FailFast:      executionOptions.FailFast,
+			LabelFilters:  executionOptions.LabelFilters,
+			Retention:     executionOptions.Retention,
+		}
+		if purgeError := runPackagesPurge(command.Context(), environment, purgeService, options, storageTally, failureTally, candidateLedger, annotations); purgeError != nil {
+			return purgeError
+		}
+	}
+
+	if len(executionOptions.ExportCandidatesPath) > 0 {
+		if writeError := writeCandidatesExport(command, executionOptions.ExportCandidatesPath, candidateLedger.Candidates()); writeError != nil {
+			return writeError
+		}
+	}
+
+	return reportPurgeTotals(command, true, storageTally, failureTally, annotations)
+}
+
+func (builder *CommandBuilder) readSnapshot(snapshotPath string) (ghcr.Snapshot, error) {
+	fileReader := builder.FileReader
+	if fileReader == nil {
+		fileReader = os.ReadFile
+	}
+
+	contents, readError := fileReader(snapshotPath)
+	if readError != nil {
+		return ghcr.Snapshot{}, fmt.Errorf(snapshotReadErrorTemplateConstant, snapshotPath, readError)
+	}
+
+	snapshot, decodeError := ghcr.ReadSnapshot(bytes.NewReader(contents))
+	if decodeError != nil {
+		return ghcr.Snapshot{}, fmt.Errorf(snapshotReadErrorTemplateConstant, snapshotPath, decodeError)
+	}
+	return snapshot, nil
+}
+
+func writeSnapshot(command *cobra.Command, snapshotPath string, snapshot ghcr.Snapshot) error {
+	var encoded bytes.Buffer
+	if encodeError := snapshot.Write(&encoded); encodeError != nil {
+		return fmt.Errorf(snapshotWriteErrorTemplateConstant, snapshotPath, encodeError)
+	}
+	if writeError := os.WriteFile(snapshotPath, encoded.Bytes(), 0o644); writeError != nil {
+		return fmt.Errorf(snapshotWriteErrorTemplateConstant, snapshotPath, writeError)
+	}
+	fmt.Fprintf(command.OutOrStdout(), snapshotWrittenTemplateConstant, snapshotPath, len(snapshot.Packages))
+	return nil
+}
+
+func (builder *CommandBuilder) readApprovedCandidates(candidatesPath string) ([]string, error) {
+	fileReader := builder.FileReader
+	if fileReader == nil {
+		fileReader = os.ReadFile
+	}
+
+	contents, readError := fileReader(candidatesPath)
+	if readError != nil {
+		return nil, fmt.Errorf(approvedCandidatesReadErrorTemplate, candidatesPath, readError)
+	}
+
+	digests, decodeError := ReadApprovedDigests(bytes.NewReader(contents))
+	if decodeError != nil {
+		return nil, fmt.Errorf(approvedCandidatesReadErrorTemplate, candidatesPath, decodeError)
+	}
+	return digests, nil
+}
+
+func writeCandidatesExport(command *cobra.Command, candidatesPath string, candidates []ghcr.PurgeCandidate) error {
+	var encoded bytes.Buffer
+	if encodeError := WriteCandidatesCSV(&encoded, candidates); encodeError != nil {
+		return fmt.Errorf(candidatesExportWriteErrorTemplate, candidatesPath, encodeError)
+	}
+	if writeError := os.WriteFile(candidatesPath, encoded.Bytes(), 0o644); writeError != nil {
+		return fmt.Errorf(candidatesExportWriteErrorTemplate, candidatesPath, writeError)
+	}
+	fmt.Fprintf(command.OutOrStdout(), candidatesExportedTemplateConstant, candidatesPath, len(candidates))
+	return nil
+}
+
+// reportApprovalOutcome lists approved digests no evaluated package contained and fails when any were refused.
+func reportApprovalOutcome(command *cobra.Command, approvedDigests []string, candidateLedger *CandidateLedger) error {
+	if approvedDigests == nil {
+		return nil
+	}
+	for _, digest := range candidateLedger.UnmatchedDigests(approvedDigests) {
+		fmt.Fprintf(command.ErrOrStderr(), approvalMissingTemplateConstant, digest)
+	}
+	if refusals := candidateLedger.Refusals(); len(refusals) > 0 {
+		return fmt.Errorf(approvalRefusedErrorTemplateConstant, len(refusals))
+	}
+	return nil
+}
+
+func reportPurgeTotals(command *cobra.Command, dryRun bool, storageTally *StorageTally, failureTally *PurgeFailureTally, annotations *ui.AnnotationEmitter) error {
+	if packageCount, byteCount := storageTally.Totals(); packageCount > 0 {
+		totalTemplate := reclaimedTotalTemplateConstant
+		if dryRun {
+			totalTemplate = reclaimPlanTotalTemplateConstant
+		}
+		fmt.Fprintf(command.OutOrStdout(), totalTemplate, utils.FormatByteSize(byteCount), byteCount, packageCount)
+	}
+
+	if failures := failureTally.Records(); len(failures) > 0 {
+		fmt.Fprintf(command.ErrOrStderr(), failedTotalTemplateConstant, len(failures), countFailedPackages(failures))
+		annotatePartialPurge(annotations, failures)
+		return PartialPurgeError{Failures: failures}
+	}
+	return nil
 }
 
 func (builder *CommandBuilder) parseCommandOptions(command *cobra.Command, arguments []string, executionFlags utils.ExecutionFlags, executionFlagsAvailable bool) (commandExecutionOptions, error) {
@@ -207,7 +491,7 @@ func (builder *CommandBuilder) parseCommandOptions(command *cobra.Command, argum
 	}
 	packageValue := selectOptionalStringValue(packageFlagValue, configuration.Purge.PackageName)
 
-	parsedTokenSource, tokenParseError := ParseTokenSource(defaultTokenSourceValueConstant)
+	parsedTokenSource, tokenParseError := ResolveConfiguredTokenSource(configuration.Purge.Token)
 	if tokenParseError != nil {
 		return commandExecutionOptions{}, fmt.Errorf(tokenSourceParseErrorTemplateConstant, tokenParseError)
 	}
@@ -217,21 +501,172 @@ func (builder *CommandBuilder) parseCommandOptions(command *cobra.Command, argum
 		dryRunValue = executionFlags.DryRun
 	}
 
-	repositoryRoots, rootsError := rootutils.Resolve(command, arguments, configuration.Purge.RepositoryRoots)
-	if rootsError != nil {
-		return commandExecutionOptions{}, rootsError
+	snapshotPath, snapshotFlagError := command.Flags().GetString(snapshotFlagNameConstant)
+	if snapshotFlagError != nil {
+		return commandExecutionOptions{}, snapshotFlagError
+	}
+	dumpSnapshotPath, dumpSnapshotFlagError := command.Flags().GetString(dumpSnapshotFlagNameConstant)
+	if dumpSnapshotFlagError != nil {
+		return commandExecutionOptions{}, dumpSnapshotFlagError
+	}
+	snapshotPath = strings.TrimSpace(snapshotPath)
+	dumpSnapshotPath = strings.TrimSpace(dumpSnapshotPath)
+	if len(snapshotPath) > 0 && len(dumpSnapshotPath) > 0 {
+		return commandExecutionOptions{}, errors.New(snapshotFlagsConflictErrorMessageConstant)
+	}
+	if len(snapshotPath) > 0 || len(dumpSnapshotPath) > 0 {
+		dryRunValue = true
+	}
+
+	exportCandidatesPath, exportCandidatesFlagError := command.Flags().GetString(exportCandidatesFlagNameConstant)
+	if exportCandidatesFlagError != nil {
+		return commandExecutionOptions{}, exportCandidatesFlagError
+	}
+	approvedCandidatesPath, approvedCandidatesFlagError := command.Flags().GetString(approvedCandidatesFlagNameConstant)
+	if approvedCandidatesFlagError != nil {
+		return commandExecutionOptions{}, approvedCandidatesFlagError
+	}
+	exportCandidatesPath = strings.TrimSpace(exportCandidatesPath)
+	approvedCandidatesPath = strings.TrimSpace(approvedCandidatesPath)
+	if len(exportCandidatesPath) > 0 && len(approvedCandidatesPath) > 0 {
+		return commandExecutionOptions{}, errors.New(candidatesFlagsConflictErrorMessageConstant)
+	}
+	if len(approvedCandidatesPath) > 0 && (len(snapshotPath) > 0 || len(dumpSnapshotPath) > 0) {
+		return commandExecutionOptions{}, errors.New(approvedCandidatesSnapshotConflictErrorMessageConstant)
+	}
+	if len(exportCandidatesPath) > 0 {
+		dryRunValue = true
+	}
+
+	var repositoryRoots []string
+	if len(snapshotPath) == 0 {
+		resolvedRoots, rootsError := rootutils.Resolve(command, arguments, configuration.Purge.RepositoryRoots)
+		if rootsError != nil {
+			return commandExecutionOptions{}, rootsError
+		}
+		repositoryRoots = resolvedRoots
+	}
+
+	entirePackageValue, _, entirePackageError := flagutils.BoolFlag(command, entirePackageFlagNameConstant)
+	if entirePackageError != nil && !errors.Is(entirePackageError, flagutils.ErrFlagNotDefined) {
+		return commandExecutionOptions{}, entirePackageError
+	}
+	forceValue, _, forceError := flagutils.BoolFlag(command, forceFlagNameConstant)
+	if forceError != nil && !errors.Is(forceError, flagutils.ErrFlagNotDefined) {
+		return commandExecutionOptions{}, forceError
+	}
+	if forceValue && !entirePackageValue && len(approvedCandidatesPath) == 0 {
+		return commandExecutionOptions{}, errors.New(forceWithoutEntirePackageErrorMessageConstant)
+	}
+	if entirePackageValue && (len(exportCandidatesPath) > 0 || len(approvedCandidatesPath) > 0) {
+		return commandExecutionOptions{}, errors.New(candidatesEntirePackageConflictErrorMessageConstant)
+	}
+
+	failFastValue := configuration.Purge.FailFast
+	failFastFlagValue, failFastFlagSet, failFastError := flagutils.BoolFlag(command, failFastFlagNameConstant)
+	if failFastError != nil && !errors.Is(failFastError, flagutils.ErrFlagNotDefined) {
+		return commandExecutionOptions{}, failFastError
+	}
+	if failFastFlagSet {
+		failFastValue = failFastFlagValue
+	}
+
+	labelFilterValues, labelFilterFlagError := command.Flags().GetStringArray(filterLabelFlagNameConstant)
+	if labelFilterFlagError != nil {
+		return commandExecutionOptions{}, labelFilterFlagError
+	}
+	labelFilters := make([]ghcr.LabelFilter, 0, len(labelFilterValues))
+	for _, labelFilterValue := range labelFilterValues {
+		labelFilter, labelFilterError := ghcr.ParseLabelFilter(labelFilterValue)
+		if labelFilterError != nil {
+			return commandExecutionOptions{}, labelFilterError
+		}
+		labelFilters = append(labelFilters, labelFilter)
+	}
+	noAnnotationsValue, _, noAnnotationsError := flagutils.BoolFlag(command, noAnnotationsFlagNameConstant)
+	if noAnnotationsError != nil && !errors.Is(noAnnotationsError, flagutils.ErrFlagNotDefined) {
+		return commandExecutionOptions{}, noAnnotationsError
+	}
+
+	skipPermissionCheckValue := configuration.Purge.SkipPermissionCheck
+	skipPermissionCheckFlagValue, skipPermissionCheckFlagSet, skipPermissionCheckError := flagutils.BoolFlag(command, skipPermissionCheckFlagNameConstant)
+	if skipPermissionCheckError != nil && !errors.Is(skipPermissionCheckError, flagutils.ErrFlagNotDefined) {
+		return commandExecutionOptions{}, skipPermissionCheckError
+	}
+	if skipPermissionCheckFlagSet {
+		skipPermissionCheckValue = skipPermissionCheckFlagValue
+	}
+
+	if len(labelFilters) > 0 && entirePackageValue {
+		return commandExecutionOptions{}, errors.New(filterLabelEntirePackageConflictErrorMessageConstant)
+	}
+
+	pullRequestTagPrefixValue := configuration.Purge.PullRequestTagPrefix
+	pullRequestTagPrefixFlagValue, pullRequestTagPrefixFlagSet, pullRequestTagPrefixError := flagutils.StringFlag(command, pullRequestTagPrefixFlagNameConstant)
+	if pullRequestTagPrefixError != nil && !errors.Is(pullRequestTagPrefixError, flagutils.ErrFlagNotDefined) {
+		return commandExecutionOptions{}, pullRequestTagPrefixError
+	}
+	if pullRequestTagPrefixFlagSet {
+		pullRequestTagPrefixValue = strings.TrimSpace(pullRequestTagPrefixFlagValue)
+	}
+	if len(pullRequestTagPrefixValue) > 0 {
+		switch {
+		case entirePackageValue:
+			return commandExecutionOptions{}, errors.New(pullRequestTagPrefixEntirePackageConflictMessageConstant)
+		case len(labelFilters) > 0:
+			return commandExecutionOptions{}, errors.New(pullRequestTagPrefixFilterLabelConflictMessageConstant)
+		case len(snapshotPath) > 0:
+			return commandExecutionOptions{}, errors.New(pullRequestTagPrefixSnapshotConflictMessageConstant)
+		}
+	}
+
+	retentionPolicy, retentionError := parseRetentionFlags(command)
+	if retentionError != nil {
+		return commandExecutionOptions{}, retentionError
+	}
+	if combinationError := validateRetentionCombination(retentionPolicy, entirePackageValue, pullRequestTagPrefixValue); combinationError != nil {
+		return commandExecutionOptions{}, combinationError
 	}
 
 	executionOptions := commandExecutionOptions{
-		PackageNameOverride: packageValue,
-		DryRun:              dryRunValue,
-		TokenSource:         parsedTokenSource,
-		RepositoryRoots:     repositoryRoots,
+		PackageNameOverride:  packageValue,
+		DryRun:               dryRunValue,
+		TokenSource:          parsedTokenSource,
+		RepositoryRoots:      repositoryRoots,
+		EntirePackage:        entirePackageValue,
+		Force:                forceValue,
+		FailFast:             failFastValue,
+		SnapshotPath:         snapshotPath,
+		DumpSnapshotPath:     dumpSnapshotPath,
+		LabelFilters:         labelFilters,
+		NoAnnotations:        noAnnotationsValue,
+		SkipPermissionCheck:  skipPermissionCheckValue,
+		PullRequestTagPrefix: pullRequestTagPrefixValue,
+
+		ExportCandidatesPath:   exportCandidatesPath,
+		ApprovedCandidatesPath: approvedCandidatesPath,
+		Retention:              retentionPolicy,
+		Notification:           configuration.Purge.Notify,
 	}
 
 	return executionOptions, nil
 }
 
+func (builder *CommandBuilder) resolveAnnotationEmitter(command *cobra.Command, disabled bool) *ui.AnnotationEmitter {
+	environmentLookup := builder.EnvironmentLookup
+	if environmentLookup == nil {
+		environmentLookup = os.LookupEnv
+	}
+	return ui.NewAnnotationEmitter(command.OutOrStdout(), ui.EnvironmentLookup(environmentLookup), disabled)
+}
+
+func (builder *CommandBuilder) resolvePullRequestStateResolver(githubClient *githubcli.Client) PullRequestStateResolver {
+	if builder.PullRequestStateResolver != nil {
+		return builder.PullRequestStateResolver
+	}
+	return githubClient
+}
+
 func (builder *CommandBuilder) resolveLogger() *zap.Logger {
 	if builder.LoggerProvider == nil {
 		return zap.NewNop()
@@ -254,7 +689,7 @@ func (builder *CommandBuilder) resolveConfiguration() Configuration {
 	return configuration.Sanitize()
 }
 
-func (builder *CommandBuilder) resolvePurgeService(logger *zap.Logger) (PurgeExecutor, error) {
+func (builder *CommandBuilder) resolvePurgeService(logger *zap.Logger, snapshotRecorder *ghcr.SnapshotRecorder) (PurgeExecutor, error) {
 	if builder.ServiceResolver != nil {
 		return builder.ServiceResolver.Resolve(logger)
 	}
@@ -264,11 +699,22 @@ func (builder *CommandBuilder) resolvePurgeService(logger *zap.Logger) (PurgeExe
 		EnvironmentLookup: builder.EnvironmentLookup,
 		FileReader:        builder.FileReader,
 		TokenResolver:     builder.TokenResolver,
+		SnapshotRecorder:  snapshotRecorder,
 	}
 
 	return defaultResolver.Resolve(logger)
 }
 
+func (builder *CommandBuilder) resolvePhraseConfirmer(command *cobra.Command) shared.PhraseConfirmationPrompter {
+	if builder.PhraseConfirmerFactory != nil {
+		if confirmer := builder.PhraseConfirmerFactory(command); confirmer != nil {
+			return confirmer
+		}
+	}
+
+	return prompt.NewIOConfirmationPrompter(command.InOrStdin(), command.OutOrStdout())
+}
+
 func selectOptionalStringValue(flagValue string, configurationValue string) string {
 	trimmedFlagValue := strings.TrimSpace(flagValue)
 	if len(trimmedFlagValue) > 0 {
@@ -2,15 +2,24 @@ package packages_test
 
 import (
 	"context"
+	"errors"
 	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"github.com/temirov/gix/internal/execshell"
 	"github.com/temirov/gix/internal/ghcr"
+	"github.com/temirov/gix/internal/githubcli"
+	"github.com/temirov/gix/internal/notify"
 	packages "github.com/temirov/gix/internal/packages"
+	"github.com/temirov/gix/internal/utils"
 	flagutils "github.com/temirov/gix/internal/utils/flags"
 	"github.com/temirov/gix/internal/workflow"
 )
@@ -148,3 +157,532 @@ func TestCommandHonorsPackageFlag(t *testing.T) {
 	action := runner.definitions[0].Actions[0]
 	require.Equal(t, "custom", action.Options["package_override"])
 }
+
+func TestCommandEntirePackageFlags(t *testing.T) {
+	testCases := []struct {
+		name          string
+		flags         map[string]string
+		expectedError string
+		expectEntire  bool
+		expectForce   bool
+	}{
+		{
+			name:         "entire_package",
+			flags:        map[string]string{"entire-package": "true"},
+			expectEntire: true,
+		},
+		{
+			name:         "entire_package_forced",
+			flags:        map[string]string{"entire-package": "true", "force": "true"},
+			expectEntire: true,
+			expectForce:  true,
+		},
+		{
+			name:          "force_requires_entire_package",
+			flags:         map[string]string{"force": "true"},
+			expectedError: "--force requires --entire-package or --approved-candidates",
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := packages.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				ConfigurationProvider: func() packages.Configuration {
+					return packages.Configuration{Purge: packages.PurgeConfiguration{RepositoryRoots: []string{"/workspace"}}}
+				},
+				ServiceResolver:            stubServiceResolver{executor: stubPurgeExecutor{}},
+				RepositoryMetadataResolver: stubMetadataResolver{},
+				RepositoryDiscoverer:       stubDiscoverer{},
+				GitExecutor:                stubGitExecutor{},
+				TaskRunnerFactory: func(deps workflow.Dependencies) packages.TaskRunnerExecutor {
+					runner.dependencies = deps
+					return runner
+				},
+			}
+
+			command, err := builder.Build()
+			require.NoError(subtest, err)
+			flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Name: flagutils.DefaultRootFlagName, Usage: flagutils.DefaultRootFlagUsage, Enabled: true})
+			for flagName, flagValue := range testCase.flags {
+				require.NoError(subtest, command.Flags().Set(flagName, flagValue))
+			}
+			command.SetOut(io.Discard)
+			command.SetErr(io.Discard)
+
+			err = command.Execute()
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, err, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, err)
+			action := runner.definitions[0].Actions[0]
+			require.Equal(subtest, testCase.expectEntire, action.Options["entire_package"])
+			require.Equal(subtest, testCase.expectForce, action.Options["force"])
+			require.NotNil(subtest, action.Options["phrase_confirmer"])
+		})
+	}
+}
+
+type stubPullRequestStates struct{}
+
+func (stubPullRequestStates) ResolvePullRequestStates(context.Context, string, []int) (map[int]githubcli.PullRequestState, error) {
+	return map[int]githubcli.PullRequestState{}, nil
+}
+
+func TestCommandPullRequestTagPrefix(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configuredPrefix     string
+		flags                map[string]string
+		expectedError        string
+		expectedActionPrefix any
+	}{
+		{
+			name:                 "flag_enables_pull_request_mode",
+			flags:                map[string]string{"pr-tag-prefix": "pr-"},
+			expectedActionPrefix: "pr-",
+		},
+		{
+			name:                 "configuration_enables_pull_request_mode",
+			configuredPrefix:     "preview-",
+			expectedActionPrefix: "preview-",
+		},
+		{
+			name:                 "flag_overrides_configuration",
+			configuredPrefix:     "preview-",
+			flags:                map[string]string{"pr-tag-prefix": "pr-"},
+			expectedActionPrefix: "pr-",
+		},
+		{
+			name: "untagged_mode_by_default",
+		},
+		{
+			name:          "conflicts_with_entire_package",
+			flags:         map[string]string{"pr-tag-prefix": "pr-", "entire-package": "true"},
+			expectedError: "--pr-tag-prefix cannot be combined with --entire-package",
+		},
+		{
+			name:          "conflicts_with_filter_label",
+			flags:         map[string]string{"pr-tag-prefix": "pr-", "filter-label": "stage=preview"},
+			expectedError: "--pr-tag-prefix cannot be combined with --filter-label",
+		},
+		{
+			name:          "conflicts_with_snapshot",
+			flags:         map[string]string{"pr-tag-prefix": "pr-", "snapshot": "versions.json"},
+			expectedError: "--pr-tag-prefix cannot be combined with --snapshot because pull request states are not recorded",
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := packages.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				ConfigurationProvider: func() packages.Configuration {
+					return packages.Configuration{Purge: packages.PurgeConfiguration{RepositoryRoots: []string{"/workspace"}, PullRequestTagPrefix: testCase.configuredPrefix}}
+				},
+				ServiceResolver:            stubServiceResolver{executor: stubPurgeExecutor{}},
+				RepositoryMetadataResolver: stubMetadataResolver{},
+				RepositoryDiscoverer:       stubDiscoverer{},
+				GitExecutor:                stubGitExecutor{},
+				PullRequestStateResolver:   stubPullRequestStates{},
+				TaskRunnerFactory: func(deps workflow.Dependencies) packages.TaskRunnerExecutor {
+					runner.dependencies = deps
+					return runner
+				},
+			}
+
+			command, err := builder.Build()
+			require.NoError(subtest, err)
+			flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Name: flagutils.DefaultRootFlagName, Usage: flagutils.DefaultRootFlagUsage, Enabled: true})
+			for flagName, flagValue := range testCase.flags {
+				require.NoError(subtest, command.Flags().Set(flagName, flagValue))
+			}
+			command.SetOut(io.Discard)
+			command.SetErr(io.Discard)
+
+			err = command.Execute()
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, err, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, err)
+			action := runner.definitions[0].Actions[0]
+			require.Equal(subtest, testCase.expectedActionPrefix, action.Options["pr_tag_prefix"])
+			if testCase.expectedActionPrefix == nil {
+				require.Nil(subtest, action.Options["pull_request_states"])
+				return
+			}
+			require.Equal(subtest, stubPullRequestStates{}, action.Options["pull_request_states"])
+		})
+	}
+}
+
+type failingHTTPClient struct {
+	requestCount int
+}
+
+func (client *failingHTTPClient) Do(*http.Request) (*http.Response, error) {
+	client.requestCount++
+	return nil, errors.New("network access is not allowed during snapshot replay")
+}
+
+func TestCommandReplaysSnapshotWithoutAPICalls(t *testing.T) {
+	snapshotContents := `{"packages":[
+		{"owner":"acme","owner_type":"org","package":"app","versions":[
+			{"id":1,"name":"sha256:aaa","size":300,"metadata":{"container":{"tags":[]}}},
+			{"id":2,"name":"sha256:bbb","size":500,"metadata":{"container":{"tags":[]}}},
+			{"id":3,"name":"sha256:ccc","size":900,"metadata":{"container":{"tags":["latest"]}}}
+		]},
+		{"owner":"acme","owner_type":"org","package":"worker","versions":[
+			{"id":4,"name":"sha256:ddd","size":100,"metadata":{"container":{"tags":[]}}}
+		]}
+	]}`
+
+	testCases := []struct {
+		name           string
+		flags          map[string]string
+		expectedOutput string
+		expectedError  string
+	}{
+		{
+			name:  "all_packages",
+			flags: map[string]string{},
+			expectedOutput: "PLAN-PACKAGES-RECLAIM: acme/app would free " + utils.FormatByteSize(800) + " (800 bytes)\n" +
+				"PLAN-PACKAGES-RECLAIM: acme/worker would free " + utils.FormatByteSize(100) + " (100 bytes)\n" +
+				"PLAN-PACKAGES-RECLAIM-TOTAL: " + utils.FormatByteSize(900) + " (900 bytes) across 2 package(s)\n",
+		},
+		{
+			name:  "package_filter",
+			flags: map[string]string{"package": "worker"},
+			expectedOutput: "PLAN-PACKAGES-RECLAIM: acme/worker would free " + utils.FormatByteSize(100) + " (100 bytes)\n" +
+				"PLAN-PACKAGES-RECLAIM-TOTAL: " + utils.FormatByteSize(100) + " (100 bytes) across 1 package(s)\n",
+		},
+		{
+			name:          "conflicting_snapshot_flags",
+			flags:         map[string]string{"dump-snapshot": "out.json"},
+			expectedError: "--snapshot and --dump-snapshot cannot be combined",
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			snapshotPath := filepath.Join(subtest.TempDir(), "snapshot.json")
+			require.NoError(subtest, os.WriteFile(snapshotPath, []byte(snapshotContents), 0o644))
+
+			httpClient := &failingHTTPClient{}
+			builder := packages.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				HTTPClient:     httpClient,
+				GitExecutor:    stubGitExecutor{},
+				TaskRunnerFactory: func(workflow.Dependencies) packages.TaskRunnerExecutor {
+					subtest.Fatal("snapshot replay must not run repository tasks")
+					return nil
+				},
+			}
+
+			command, err := builder.Build()
+			require.NoError(subtest, err)
+			flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Name: flagutils.DefaultRootFlagName, Usage: flagutils.DefaultRootFlagUsage, Enabled: true})
+			require.NoError(subtest, command.Flags().Set("snapshot", snapshotPath))
+			for flagName, flagValue := range testCase.flags {
+				require.NoError(subtest, command.Flags().Set(flagName, flagValue))
+			}
+			outputBuffer := &strings.Builder{}
+			command.SetOut(outputBuffer)
+			command.SetErr(io.Discard)
+			command.SetContext(context.Background())
+
+			err = command.Execute()
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, err, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, err)
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+			require.Zero(subtest, httpClient.requestCount)
+		})
+	}
+}
+
+func TestCommandCandidateFlags(t *testing.T) {
+	approvedFileContents := "digest,tags,size,updated_at,reason\nsha256:aaa,,10,,untagged\n"
+	testCases := []struct {
+		name                  string
+		flags                 map[string]string
+		expectedError         string
+		expectDryRun          bool
+		expectApprovedDigests any
+		expectForceApproved   any
+	}{
+		{
+			name:         "export_implies_dry_run",
+			flags:        map[string]string{"export-candidates": "candidates.csv"},
+			expectDryRun: true,
+		},
+		{
+			name:                  "approved_candidates_forced",
+			flags:                 map[string]string{"approved-candidates": "approved.csv", "force": "true"},
+			expectApprovedDigests: []string{"sha256:aaa"},
+			expectForceApproved:   true,
+		},
+		{
+			name:          "export_and_approved_conflict",
+			flags:         map[string]string{"export-candidates": "candidates.csv", "approved-candidates": "approved.csv"},
+			expectedError: "--export-candidates and --approved-candidates cannot be combined",
+		},
+		{
+			name:          "approved_with_entire_package_conflict",
+			flags:         map[string]string{"approved-candidates": "approved.csv", "entire-package": "true"},
+			expectedError: "--export-candidates and --approved-candidates cannot be combined with --entire-package",
+		},
+		{
+			name:          "approved_with_snapshot_conflict",
+			flags:         map[string]string{"approved-candidates": "approved.csv", "dump-snapshot": "snapshot.json"},
+			expectedError: "--approved-candidates cannot be combined with --snapshot or --dump-snapshot",
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			workingDirectory := subtest.TempDir()
+			runner := &recordingTaskRunner{}
+			builder := packages.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				ConfigurationProvider: func() packages.Configuration {
+					return packages.Configuration{Purge: packages.PurgeConfiguration{RepositoryRoots: []string{"/workspace"}}}
+				},
+				ServiceResolver:            stubServiceResolver{executor: stubPurgeExecutor{}},
+				RepositoryMetadataResolver: stubMetadataResolver{},
+				RepositoryDiscoverer:       stubDiscoverer{},
+				GitExecutor:                stubGitExecutor{},
+				FileReader: func(path string) ([]byte, error) {
+					require.Equal(subtest, filepath.Join(workingDirectory, "approved.csv"), path)
+					return []byte(approvedFileContents), nil
+				},
+				TaskRunnerFactory: func(deps workflow.Dependencies) packages.TaskRunnerExecutor {
+					runner.dependencies = deps
+					return runner
+				},
+			}
+
+			command, err := builder.Build()
+			require.NoError(subtest, err)
+			flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Name: flagutils.DefaultRootFlagName, Usage: flagutils.DefaultRootFlagUsage, Enabled: true})
+			for flagName, flagValue := range testCase.flags {
+				if strings.HasSuffix(flagValue, ".csv") || strings.HasSuffix(flagValue, ".json") {
+					flagValue = filepath.Join(workingDirectory, flagValue)
+				}
+				require.NoError(subtest, command.Flags().Set(flagName, flagValue))
+			}
+			command.SetOut(io.Discard)
+			command.SetErr(io.Discard)
+
+			err = command.Execute()
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, err, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, err)
+			action := runner.definitions[0].Actions[0]
+			require.Equal(subtest, testCase.expectDryRun, action.Options["dry_run"])
+			require.Equal(subtest, testCase.expectApprovedDigests, action.Options["approved_digests"])
+			require.Equal(subtest, testCase.expectForceApproved, action.Options["force_approved"])
+			require.NotNil(subtest, action.Options["candidate_ledger"])
+
+			if exportPath, exported := testCase.flags["export-candidates"]; exported {
+				exportedContents, readError := os.ReadFile(filepath.Join(workingDirectory, exportPath))
+				require.NoError(subtest, readError)
+				require.Equal(subtest, "digest,tags,size,updated_at,reason\n", string(exportedContents))
+			}
+		})
+	}
+}
+
+func TestCommandRetentionFlags(t *testing.T) {
+	testCases := []struct {
+		name              string
+		flags             map[string][]string
+		expectedError     string
+		expectedRetention any
+	}{
+		{
+			name: "retention_policy_forwarded",
+			flags: map[string][]string{
+				"keep-last":   {"3"},
+				"older-than":  {"30d"},
+				"tag-pattern": {"pr-*", "sha-*"},
+				"protect-tag": {"latest"},
+			},
+			expectedRetention: ghcr.RetentionPolicy{KeepLast: 3, OlderThan: 30 * 24 * time.Hour, TagPatterns: []string{"pr-*", "sha-*"}, ProtectedTags: []string{"latest"}},
+		},
+		{
+			name: "no_retention_flags_omit_policy",
+		},
+		{
+			name:          "invalid_age",
+			flags:         map[string][]string{"older-than": {"soon"}},
+			expectedError: `invalid --older-than "soon": time: invalid duration "soon"`,
+		},
+		{
+			name:          "negative_keep_last",
+			flags:         map[string][]string{"keep-last": {"-1"}},
+			expectedError: "keep-last must not be negative",
+		},
+		{
+			name:          "entire_package_conflict",
+			flags:         map[string][]string{"keep-last": {"2"}, "entire-package": {"true"}},
+			expectedError: "--keep-last, --older-than, --tag-pattern, and --protect-tag cannot be combined with --entire-package",
+		},
+		{
+			name:          "pull_request_prefix_conflict",
+			flags:         map[string][]string{"protect-tag": {"latest"}, "pr-tag-prefix": {"pr-"}},
+			expectedError: "--keep-last, --older-than, --tag-pattern, and --protect-tag cannot be combined with --pr-tag-prefix",
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			runner := &recordingTaskRunner{}
+			builder := packages.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				ConfigurationProvider: func() packages.Configuration {
+					return packages.Configuration{Purge: packages.PurgeConfiguration{RepositoryRoots: []string{"/workspace"}}}
+				},
+				ServiceResolver:            stubServiceResolver{executor: stubPurgeExecutor{}},
+				RepositoryMetadataResolver: stubMetadataResolver{},
+				RepositoryDiscoverer:       stubDiscoverer{},
+				GitExecutor:                stubGitExecutor{},
+				TaskRunnerFactory: func(deps workflow.Dependencies) packages.TaskRunnerExecutor {
+					runner.dependencies = deps
+					return runner
+				},
+			}
+
+			command, err := builder.Build()
+			require.NoError(subtest, err)
+			require.Contains(subtest, command.Long, "1. protected: versions carrying a protected tag are always kept")
+			require.Contains(subtest, command.Long, "4. keep-last: ")
+			flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Name: flagutils.DefaultRootFlagName, Usage: flagutils.DefaultRootFlagUsage, Enabled: true})
+			for flagName, flagValues := range testCase.flags {
+				for _, flagValue := range flagValues {
+					require.NoError(subtest, command.Flags().Set(flagName, flagValue))
+				}
+			}
+			command.SetOut(io.Discard)
+			command.SetErr(io.Discard)
+
+			err = command.Execute()
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, err, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, err)
+			require.Equal(subtest, testCase.expectedRetention, runner.definitions[0].Actions[0].Options["retention"])
+		})
+	}
+}
+
+type recordingNotificationClient struct {
+	bodies     []string
+	statusCode int
+}
+
+func (client *recordingNotificationClient) Do(request *http.Request) (*http.Response, error) {
+	body, readError := io.ReadAll(request.Body)
+	if readError != nil {
+		return nil, readError
+	}
+	client.bodies = append(client.bodies, string(body))
+	return &http.Response{StatusCode: client.statusCode, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+type failingTaskRunner struct {
+	err error
+}
+
+func (runner failingTaskRunner) Run(context.Context, []string, []workflow.TaskDefinition, workflow.RuntimeOptions) error {
+	return runner.err
+}
+
+func TestCommandSendsPurgeNotification(t *testing.T) {
+	testCases := []struct {
+		name          string
+		runError      error
+		statusCode    int
+		template      string
+		expectedBody  string
+		expectedError string
+	}{
+		{
+			name:         "json_payload",
+			statusCode:   http.StatusOK,
+			expectedBody: `{"command":"repo-packages-purge","dry_run":true,"package_count":0,"reclaimed_bytes":0,"reclaimed_size":"` + utils.FormatByteSize(0) + `","packages":[],"failure_count":0,"failed_package_count":0,"failures":[]}`,
+		},
+		{
+			name:         "slack_template",
+			statusCode:   http.StatusOK,
+			template:     "Purged {{.PackageCount}} package(s), {{.FailureCount}} failure(s)",
+			expectedBody: `{"text":"Purged 0 package(s), 0 failure(s)"}`,
+		},
+		{
+			name:         "webhook_failure_keeps_exit_code",
+			statusCode:   http.StatusInternalServerError,
+			expectedBody: `{"command":"repo-packages-purge","dry_run":true,"package_count":0,"reclaimed_bytes":0,"reclaimed_size":"` + utils.FormatByteSize(0) + `","packages":[],"failure_count":0,"failed_package_count":0,"failures":[]}`,
+		},
+		{
+			name:          "aborted_run",
+			runError:      errors.New("token lacks delete:packages for org acme"),
+			statusCode:    http.StatusOK,
+			expectedBody:  `{"command":"repo-packages-purge","dry_run":true,"package_count":0,"reclaimed_bytes":0,"reclaimed_size":"` + utils.FormatByteSize(0) + `","packages":[],"failure_count":0,"failed_package_count":0,"failures":[],"error":"token lacks delete:packages for org acme"}`,
+			expectedError: "token lacks delete:packages for org acme",
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+		t.Run(testCase.name, func(subtest *testing.T) {
+			notificationClient := &recordingNotificationClient{statusCode: testCase.statusCode}
+			builder := packages.CommandBuilder{
+				LoggerProvider: func() *zap.Logger { return zap.NewNop() },
+				ConfigurationProvider: func() packages.Configuration {
+					return packages.Configuration{Purge: packages.PurgeConfiguration{
+						RepositoryRoots: []string{"/src"},
+						DryRun:          true,
+						Notify:          notify.Configuration{WebhookURL: "https://hooks.example.com/purge", Template: testCase.template},
+					}}
+				},
+				ServiceResolver:            stubServiceResolver{executor: stubPurgeExecutor{}},
+				RepositoryMetadataResolver: stubMetadataResolver{},
+				RepositoryDiscoverer:       stubDiscoverer{},
+				GitExecutor:                stubGitExecutor{},
+				NotificationHTTPClient:     notificationClient,
+				TaskRunnerFactory: func(workflow.Dependencies) packages.TaskRunnerExecutor {
+					return failingTaskRunner{err: testCase.runError}
+				},
+			}
+
+			command, err := builder.Build()
+			require.NoError(subtest, err)
+			flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Name: flagutils.DefaultRootFlagName, Usage: flagutils.DefaultRootFlagUsage, Enabled: true})
+			command.SetOut(io.Discard)
+			command.SetErr(io.Discard)
+			command.SetContext(context.Background())
+
+			err = command.Execute()
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, err, testCase.expectedError)
+			} else {
+				require.NoError(subtest, err)
+			}
+			require.Equal(subtest, []string{testCase.expectedBody}, notificationClient.bodies)
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package packages
 
 import (
+	"strings"
+
+	"github.com/temirov/gix/internal/notify"
 	pathutils "github.com/temirov/gix/internal/utils/path"
 )
 
@@ -20,6 +23,14 @@ type PurgeConfiguration struct {
 	PackageName     string   `mapstructure:"package"`
 	DryRun          bool     `mapstructure:"dry_run"`
 	RepositoryRoots []string `mapstructure:"roots"`
+	FailFast        bool     `mapstructure:"fail_fast"`
+	Token           string   `mapstructure:"token"`
+	// SkipPermissionCheck disables the delete permission preflight.
+	SkipPermissionCheck bool `mapstructure:"skip_permission_check"`
+	// PullRequestTagPrefix purges versions tagged <prefix><number> whose pull request is closed or merged.
+	PullRequestTagPrefix string `mapstructure:"pr_tag_prefix"`
+	// Notify posts a summary of each run to a webhook.
+	Notify notify.Configuration `mapstructure:"notify"`
 }
 
 // DefaultConfiguration supplies baseline values for packages configuration.
@@ -40,5 +51,7 @@ func (configuration Configuration) Sanitize() Configuration {
 func (configuration PurgeConfiguration) Sanitize() PurgeConfiguration {
 	sanitized := configuration
 	sanitized.RepositoryRoots = packagesConfigurationRepositoryPathSanitizer.Sanitize(configuration.RepositoryRoots)
+	sanitized.PullRequestTagPrefix = strings.TrimSpace(configuration.PullRequestTagPrefix)
+	sanitized.Notify = configuration.Notify.Sanitize()
 	return sanitized
 }
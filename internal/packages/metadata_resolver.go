@@ -29,6 +29,8 @@ type RepositoryMetadata struct {
 	Owner              string
 	OwnerType          ghcr.OwnerType
 	DefaultPackageName string
+	// Repository is the canonical owner/name of the repository, used to look up its pull requests.
+	Repository string
 }
 
 // RepositoryMetadataResolver resolves repository metadata used by the purge command.
@@ -101,8 +103,10 @@ func (resolver *DefaultRepositoryMetadataResolver) ResolveMetadata(
 	}
 
 	resolvedOwner := ownerCandidate
+	resolvedRepository := repositoryIdentifier
 	trimmedNameWithOwner := strings.TrimSpace(metadata.NameWithOwner)
 	if len(trimmedNameWithOwner) > 0 {
+		resolvedRepository = trimmedNameWithOwner
 		ownerFromMetadata, ownerParseError := parseOwnerFromNameWithOwner(trimmedNameWithOwner)
 		if ownerParseError != nil {
 			return RepositoryMetadata{}, fmt.Errorf(
@@ -122,6 +126,7 @@ func (resolver *DefaultRepositoryMetadataResolver) ResolveMetadata(
 		Owner:              resolvedOwner,
 		OwnerType:          ownerType,
 		DefaultPackageName: repositoryName,
+		Repository:         resolvedRepository,
 	}, nil
 }
 
@@ -46,6 +46,7 @@ func TestDefaultRepositoryMetadataResolverResolvesMetadata(testInstance *testing
 		expectedOwner       string
 		expectedOwnerType   ghcr.OwnerType
 		expectedPackageName string
+		expectedRepository  string
 	}{
 		{
 			name:           "metadata_owner_overrides_remote",
@@ -58,6 +59,7 @@ func TestDefaultRepositoryMetadataResolverResolvesMetadata(testInstance *testing
 			expectedOwner:       metadataResolverMetadataOwnerConstant,
 			expectedOwnerType:   ghcr.OrganizationOwnerType,
 			expectedPackageName: metadataResolverPrimaryPackageNameConstant,
+			expectedRepository:  metadataResolverMetadataNameWithOwnerConstant,
 		},
 		{
 			name:           "falls_back_to_remote_owner_when_metadata_missing",
@@ -70,6 +72,7 @@ func TestDefaultRepositoryMetadataResolverResolvesMetadata(testInstance *testing
 			expectedOwner:       metadataResolverPrimaryRemoteOwnerConstant,
 			expectedOwnerType:   ghcr.UserOwnerType,
 			expectedPackageName: metadataResolverPrimaryPackageNameConstant,
+			expectedRepository:  metadataResolverPrimaryRemoteOwnerConstant + "/" + metadataResolverPrimaryPackageNameConstant,
 		},
 		{
 			name:           "supports_multiple_repositories",
@@ -82,6 +85,7 @@ func TestDefaultRepositoryMetadataResolverResolvesMetadata(testInstance *testing
 			expectedOwner:       metadataResolverAlternateMetadataOwnerConstant,
 			expectedOwnerType:   ghcr.OrganizationOwnerType,
 			expectedPackageName: metadataResolverAlternatePackageNameConstant,
+			expectedRepository:  metadataResolverAlternateNameWithOwnerConstant,
 		},
 	}
 
@@ -108,6 +112,7 @@ func TestDefaultRepositoryMetadataResolverResolvesMetadata(testInstance *testing
 			require.Equal(subTest, testCase.expectedOwner, resolvedMetadata.Owner)
 			require.Equal(subTest, testCase.expectedOwnerType, resolvedMetadata.OwnerType)
 			require.Equal(subTest, testCase.expectedPackageName, resolvedMetadata.DefaultPackageName)
+			require.Equal(subTest, testCase.expectedRepository, resolvedMetadata.Repository)
 		})
 	}
 }
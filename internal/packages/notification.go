@@ -0,0 +1,115 @@
+package packages
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/notify"
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	purgeNotificationCommandConstant = packagesPurgeCommandUseConstant
+	purgeNotificationFailedMessage   = "Failed to send purge notification"
+)
+
+// PurgeNotification is the payload sent to the notify: webhook after a purge run. Templates reference its fields,
+// e.g. {{.ReclaimedSize}}; JSON payloads use the json tag names.
+type PurgeNotification struct {
+	Command            string                     `json:"command"`
+	DryRun             bool                       `json:"dry_run"`
+	PackageCount       int                        `json:"package_count"`
+	ReclaimedBytes     int64                      `json:"reclaimed_bytes"`
+	ReclaimedSize      string                     `json:"reclaimed_size"`
+	Packages           []PurgeNotificationPackage `json:"packages"`
+	FailureCount       int                        `json:"failure_count"`
+	FailedPackageCount int                        `json:"failed_package_count"`
+	Failures           []PurgeNotificationFailure `json:"failures"`
+	// Error describes why the run aborted; it is empty for completed and partially failed runs.
+	Error string `json:"error,omitempty"`
+}
+
+// PurgeNotificationPackage reports the storage one package reclaimed, or would reclaim in a dry run.
+type PurgeNotificationPackage struct {
+	Owner          string `json:"owner"`
+	PackageName    string `json:"package"`
+	ReclaimedBytes int64  `json:"reclaimed_bytes"`
+	ReclaimedSize  string `json:"reclaimed_size"`
+}
+
+// PurgeNotificationFailure identifies one failed version deletion in a PurgeNotification.
+type PurgeNotificationFailure struct {
+	Owner       string `json:"owner"`
+	PackageName string `json:"package"`
+	VersionID   int64  `json:"version_id"`
+	Digest      string `json:"digest,omitempty"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// resolvePurgeNotifier returns the configured webhook notifier, or nil when notifications are disabled.
+func (builder *CommandBuilder) resolvePurgeNotifier(configuration notify.Configuration) (notify.Notifier, error) {
+	if !configuration.Enabled() {
+		return nil, nil
+	}
+	notifier, notifierError := notify.NewWebhookNotifier(configuration, builder.NotificationHTTPClient)
+	if notifierError != nil {
+		return nil, notifierError
+	}
+	return notifier, nil
+}
+
+func buildPurgeNotification(dryRun bool, storageTally *StorageTally, failureTally *PurgeFailureTally, runError error) PurgeNotification {
+	packageCount, byteCount := storageTally.Totals()
+	failures := failureTally.Records()
+	notification := PurgeNotification{
+		Command:            purgeNotificationCommandConstant,
+		DryRun:             dryRun,
+		PackageCount:       packageCount,
+		ReclaimedBytes:     byteCount,
+		ReclaimedSize:      utils.FormatByteSize(byteCount),
+		Packages:           make([]PurgeNotificationPackage, 0, packageCount),
+		FailureCount:       len(failures),
+		FailedPackageCount: countFailedPackages(failures),
+		Failures:           make([]PurgeNotificationFailure, 0, len(failures)),
+	}
+	for _, packageStorage := range storageTally.Packages() {
+		notification.Packages = append(notification.Packages, PurgeNotificationPackage{
+			Owner:          packageStorage.Owner,
+			PackageName:    packageStorage.PackageName,
+			ReclaimedBytes: packageStorage.ByteCount,
+			ReclaimedSize:  utils.FormatByteSize(packageStorage.ByteCount),
+		})
+	}
+	for _, failure := range failures {
+		notification.Failures = append(notification.Failures, PurgeNotificationFailure{
+			Owner:       failure.Owner,
+			PackageName: failure.PackageName,
+			VersionID:   failure.VersionID,
+			Digest:      failure.Digest,
+			StatusCode:  failure.StatusCode,
+			Message:     failure.Message,
+		})
+	}
+	var partialPurgeError PartialPurgeError
+	if runError != nil && !errors.As(runError, &partialPurgeError) {
+		notification.Error = runError.Error()
+	}
+	return notification
+}
+
+// sendPurgeNotification delivers the run summary. It ignores cancellation of the run so interrupted purges are still
+// reported, and delivery failures are logged as warnings without changing the command's outcome.
+func sendPurgeNotification(executionContext context.Context, logger *zap.Logger, notifier notify.Notifier, notification PurgeNotification) {
+	if notifier == nil {
+		return
+	}
+	if executionContext == nil {
+		executionContext = context.Background()
+	}
+	if notifyError := notifier.Notify(context.WithoutCancel(executionContext), notification); notifyError != nil {
+		logger.Warn(purgeNotificationFailedMessage, zap.Error(notifyError))
+	}
+}
@@ -0,0 +1,47 @@
+package packages
+
+import (
+	"fmt"
+
+	"github.com/temirov/gix/internal/ghcr"
+	"github.com/temirov/gix/internal/utils"
+)
+
+const partialPurgeErrorTemplateConstant = "%d package version deletion(s) failed across %d package(s)"
+
+// PurgeFailure identifies a failed version deletion together with the package it belongs to.
+type PurgeFailure struct {
+	Owner       string
+	PackageName string
+	ghcr.VersionDeletionFailure
+}
+
+// PartialPurgeError reports that a purge ran to completion but one or more version deletions failed.
+type PartialPurgeError struct {
+	utils.PartialFailure
+	Failures []PurgeFailure
+}
+
+// Error summarizes how many deletions failed and across how many packages.
+func (partialPurgeError PartialPurgeError) Error() string {
+	return fmt.Sprintf(partialPurgeErrorTemplateConstant, len(partialPurgeError.Failures), countFailedPackages(partialPurgeError.Failures))
+}
+
+// PurgeFailureTally accumulates failed version deletions across every package processed in a run.
+type PurgeFailureTally = utils.Tally[PurgeFailure]
+
+func newPartialPurgeError(owner string, packageName string, versionFailures []ghcr.VersionDeletionFailure) PartialPurgeError {
+	failures := make([]PurgeFailure, 0, len(versionFailures))
+	for _, versionFailure := range versionFailures {
+		failures = append(failures, PurgeFailure{Owner: owner, PackageName: packageName, VersionDeletionFailure: versionFailure})
+	}
+	return PartialPurgeError{Failures: failures}
+}
+
+func countFailedPackages(failures []PurgeFailure) int {
+	packageKeys := make(map[string]struct{}, len(failures))
+	for _, failure := range failures {
+		packageKeys[failure.Owner+ownerRepoSeparatorConstant+failure.PackageName] = struct{}{}
+	}
+	return len(packageKeys)
+}
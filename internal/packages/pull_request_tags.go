@@ -0,0 +1,65 @@
+package packages
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const (
+	pullRequestBatchFailedMessageConstant     = "Pull request state batch failed; keeping its versions"
+	pullRequestRepositoryLogFieldNameConstant = "repository"
+	pullRequestBatchLogFieldNameConstant      = "pull_request_numbers"
+)
+
+// PullRequestStateResolver resolves the states of pull requests of one owner/name repository in a single request.
+type PullRequestStateResolver interface {
+	ResolvePullRequestStates(executionContext context.Context, repository string, pullRequestNumbers []int) (map[int]githubcli.PullRequestState, error)
+}
+
+// pullRequestStateLookup adapts a PullRequestStateResolver to the GHCR purge, splitting the lookups into batches of
+// githubcli.DefaultPullRequestStateBatchSize. A failed batch is logged and its pull requests stay unknown, so their
+// versions are kept rather than aborting the purge.
+type pullRequestStateLookup struct {
+	logger     *zap.Logger
+	resolver   PullRequestStateResolver
+	repository string
+	batchSize  int
+}
+
+func (lookup pullRequestStateLookup) ResolvePullRequestStates(executionContext context.Context, pullRequestNumbers []int) (map[int]string, error) {
+	batchSize := lookup.batchSize
+	if batchSize <= 0 {
+		batchSize = githubcli.DefaultPullRequestStateBatchSize
+	}
+
+	states := make(map[int]string, len(pullRequestNumbers))
+	for batchStart := 0; batchStart < len(pullRequestNumbers); batchStart += batchSize {
+		batchEnd := batchStart + batchSize
+		if batchEnd > len(pullRequestNumbers) {
+			batchEnd = len(pullRequestNumbers)
+		}
+		batch := pullRequestNumbers[batchStart:batchEnd]
+
+		batchStates, batchError := lookup.resolver.ResolvePullRequestStates(executionContext, lookup.repository, batch)
+		if batchError != nil {
+			if cancellationError := executionContext.Err(); cancellationError != nil {
+				return nil, cancellationError
+			}
+			lookup.logger.Warn(
+				pullRequestBatchFailedMessageConstant,
+				zap.String(pullRequestRepositoryLogFieldNameConstant, lookup.repository),
+				zap.Ints(pullRequestBatchLogFieldNameConstant, batch),
+				zap.Error(batchError),
+			)
+			continue
+		}
+		for pullRequestNumber, state := range batchStates {
+			states[pullRequestNumber] = strings.ToLower(string(state))
+		}
+	}
+	return states, nil
+}
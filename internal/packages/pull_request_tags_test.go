@@ -0,0 +1,178 @@
+package packages_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/ghcr"
+	"github.com/temirov/gix/internal/githubcli"
+	packages "github.com/temirov/gix/internal/packages"
+)
+
+const (
+	pullRequestTagsTestRepositoryConstant = "owner/repository"
+	pullRequestTagsTestPrefixConstant     = "pr-"
+)
+
+type stubPullRequestStateResolver struct {
+	batches      [][]int
+	repositories []string
+	failingBatch int
+}
+
+func (resolver *stubPullRequestStateResolver) ResolvePullRequestStates(executionContext context.Context, repository string, pullRequestNumbers []int) (map[int]githubcli.PullRequestState, error) {
+	resolver.batches = append(resolver.batches, append([]int(nil), pullRequestNumbers...))
+	resolver.repositories = append(resolver.repositories, repository)
+	if len(resolver.batches) == resolver.failingBatch {
+		return nil, errors.New("graphql failure")
+	}
+	states := make(map[int]githubcli.PullRequestState, len(pullRequestNumbers))
+	for _, pullRequestNumber := range pullRequestNumbers {
+		states[pullRequestNumber] = githubcli.PullRequestState("MERGED")
+	}
+	return states, nil
+}
+
+func TestPurgeServicePullRequestTagMode(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	testCases := []struct {
+		name                string
+		repository          string
+		withResolver        bool
+		expectedError       string
+		expectedPullRequest bool
+	}{
+		{
+			name:                "dispatches_pull_request_purge",
+			repository:          pullRequestTagsTestRepositoryConstant,
+			withResolver:        true,
+			expectedPullRequest: true,
+		},
+		{
+			name:          "missing_repository",
+			withResolver:  true,
+			expectedError: "pull request tag purge requires the repository that owns the pull requests",
+		},
+		{
+			name:          "missing_resolver",
+			repository:    pullRequestTagsTestRepositoryConstant,
+			expectedError: "pull request tag purge requires a pull request state resolver",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testingInstance.Run(testCase.name, func(subtest *testing.T) {
+			subtest.Parallel()
+
+			packageService := &stubPackageVersionAPI{result: ghcr.PurgeResult{TotalVersions: 2, DeletedVersions: 1}}
+			service, serviceError := packages.NewPurgeService(zap.NewNop(), packageService, &stubTokenResolver{token: "resolved-token"})
+			require.NoError(subtest, serviceError)
+
+			options := packages.PurgeOptions{
+				Owner:                 "owner",
+				PackageName:           "package",
+				OwnerType:             ghcr.OrganizationOwnerType,
+				TokenSource:           packages.TokenSourceConfiguration{Type: packages.TokenSourceTypeEnvironment, Reference: "ENV"},
+				DryRun:                true,
+				PullRequestTagPrefix:  pullRequestTagsTestPrefixConstant,
+				PullRequestRepository: testCase.repository,
+			}
+			if testCase.withResolver {
+				options.PullRequestStates = &stubPullRequestStateResolver{}
+			}
+
+			result, executionError := service.Execute(context.Background(), options)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, executionError, testCase.expectedError)
+				require.False(subtest, packageService.pullRequestCall)
+				return
+			}
+			require.NoError(subtest, executionError)
+			require.Equal(subtest, packageService.result, result)
+			require.Equal(subtest, testCase.expectedPullRequest, packageService.pullRequestCall)
+			require.False(subtest, packageService.called)
+			require.Equal(subtest, pullRequestTagsTestPrefixConstant, packageService.request.PullRequestTagPrefix)
+			require.NotNil(subtest, packageService.request.PullRequestStates)
+		})
+	}
+}
+
+func TestPullRequestStateLookupBatchesAndToleratesFailures(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	testCases := []struct {
+		name                string
+		numberCount         int
+		failingBatch        int
+		expectedBatchSizes  []int
+		expectedStatesCount int
+	}{
+		{
+			name:                "single_batch",
+			numberCount:         3,
+			expectedBatchSizes:  []int{3},
+			expectedStatesCount: 3,
+		},
+		{
+			name:                "split_into_batches",
+			numberCount:         120,
+			expectedBatchSizes:  []int{50, 50, 20},
+			expectedStatesCount: 120,
+		},
+		{
+			name:                "failed_batch_stays_unknown",
+			numberCount:         120,
+			failingBatch:        2,
+			expectedBatchSizes:  []int{50, 50, 20},
+			expectedStatesCount: 70,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testingInstance.Run(testCase.name, func(subtest *testing.T) {
+			subtest.Parallel()
+
+			packageService := &stubPackageVersionAPI{}
+			service, serviceError := packages.NewPurgeService(zap.NewNop(), packageService, &stubTokenResolver{token: "resolved-token"})
+			require.NoError(subtest, serviceError)
+
+			resolver := &stubPullRequestStateResolver{failingBatch: testCase.failingBatch}
+			_, executionError := service.Execute(context.Background(), packages.PurgeOptions{
+				Owner:                 "owner",
+				PackageName:           "package",
+				OwnerType:             ghcr.OrganizationOwnerType,
+				TokenSource:           packages.TokenSourceConfiguration{Type: packages.TokenSourceTypeEnvironment, Reference: "ENV"},
+				DryRun:                true,
+				PullRequestTagPrefix:  pullRequestTagsTestPrefixConstant,
+				PullRequestRepository: pullRequestTagsTestRepositoryConstant,
+				PullRequestStates:     resolver,
+			})
+			require.NoError(subtest, executionError)
+
+			pullRequestNumbers := make([]int, 0, testCase.numberCount)
+			for pullRequestNumber := 1; pullRequestNumber <= testCase.numberCount; pullRequestNumber++ {
+				pullRequestNumbers = append(pullRequestNumbers, pullRequestNumber)
+			}
+			states, lookupError := packageService.request.PullRequestStates.ResolvePullRequestStates(context.Background(), pullRequestNumbers)
+			require.NoError(subtest, lookupError)
+			require.Len(subtest, states, testCase.expectedStatesCount)
+			for _, state := range states {
+				require.Equal(subtest, ghcr.PullRequestStateMerged, state)
+			}
+
+			batchSizes := make([]int, 0, len(resolver.batches))
+			for batchIndex, batch := range resolver.batches {
+				batchSizes = append(batchSizes, len(batch))
+				require.Equal(subtest, pullRequestTagsTestRepositoryConstant, resolver.repositories[batchIndex])
+			}
+			require.Equal(subtest, testCase.expectedBatchSizes, batchSizes)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package packages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/ghcr"
+	"github.com/temirov/gix/internal/utils"
+	flagutils "github.com/temirov/gix/internal/utils/flags"
+)
+
+const (
+	packagesReportCommandUseConstant              = "repo-packages-report"
+	packagesReportCommandShortDescriptionConstant = "Summarize container package storage for an owner"
+	packagesReportCommandLongDescriptionConstant  = "repo-packages-report lists every container package of an owner with its version counts, total size, and oldest and newest version dates, largest first. It never deletes anything."
+	reportOwnerFlagNameConstant                   = "owner"
+	reportOwnerFlagDescriptionConstant            = "GitHub organization or user whose container packages are reported"
+	reportOwnerTypeFlagNameConstant               = "owner-type"
+	reportOwnerTypeFlagDescriptionConstant        = "Owner scope"
+	reportFormatFlagNameConstant                  = "format"
+	reportFormatFlagDescriptionConstant           = "Output format"
+	reportTopFlagNameConstant                     = "top"
+	reportTopFlagDescriptionConstant              = "Only report the N largest packages (0 reports every package)"
+	reportOwnerMissingErrorMessageConstant        = "--owner must be provided"
+	reportTopNegativeErrorMessageConstant         = "--top must not be negative"
+	reportTokenResolutionErrorTemplateConstant    = "unable to resolve packages token: %w"
+	reportCommandErrorTemplateConstant            = "repo-packages-report failed: %w"
+)
+
+// PackageReporter summarizes the container packages of an owner without modifying them.
+type PackageReporter interface {
+	ReportPackages(executionContext context.Context, request ghcr.PackageListRequest) ([]ghcr.PackageReport, error)
+}
+
+// ReportCommandBuilder assembles the repo-packages-report command.
+type ReportCommandBuilder struct {
+	LoggerProvider        LoggerProvider
+	ConfigurationProvider ConfigurationProvider
+	Reporter              PackageReporter
+	HTTPClient            ghcr.HTTPClient
+	EnvironmentLookup     EnvironmentLookup
+	FileReader            FileReader
+	TokenResolver         TokenResolver
+}
+
+type reportCommandOptions struct {
+	Owner     string
+	OwnerType ghcr.OwnerType
+	Format    ReportFormat
+	Top       int
+}
+
+// Build constructs the repo-packages-report command.
+func (builder *ReportCommandBuilder) Build() (*cobra.Command, error) {
+	reportCommand := &cobra.Command{
+		Use:   packagesReportCommandUseConstant,
+		Short: packagesReportCommandShortDescriptionConstant,
+		Long:  packagesReportCommandLongDescriptionConstant,
+		Args:  cobra.NoArgs,
+		RunE:  builder.run,
+	}
+
+	reportCommand.Flags().String(reportOwnerFlagNameConstant, "", reportOwnerFlagDescriptionConstant)
+	reportCommand.Flags().String(reportOwnerTypeFlagNameConstant, string(ghcr.OrganizationOwnerType), flagutils.FormatChoiceUsage(string(ghcr.OrganizationOwnerType), []string{string(ghcr.OrganizationOwnerType), string(ghcr.UserOwnerType)}, reportOwnerTypeFlagDescriptionConstant))
+	reportCommand.Flags().String(reportFormatFlagNameConstant, string(ReportFormatTable), flagutils.FormatChoiceUsage(string(ReportFormatTable), ReportFormats(), reportFormatFlagDescriptionConstant))
+	reportCommand.Flags().Int(reportTopFlagNameConstant, 0, reportTopFlagDescriptionConstant)
+
+	return reportCommand, nil
+}
+
+func (builder *ReportCommandBuilder) run(command *cobra.Command, _ []string) error {
+	logger := builder.resolveLogger()
+
+	options, optionsError := parseReportCommandOptions(command)
+	if optionsError != nil {
+		return optionsError
+	}
+	utils.LogEffectiveConfiguration(logger, command.CommandPath(), options)
+
+	configuration := DefaultConfiguration()
+	if builder.ConfigurationProvider != nil {
+		configuration = builder.ConfigurationProvider()
+	}
+	tokenSource, tokenSourceError := ResolveConfiguredTokenSource(configuration.Purge.Token)
+	if tokenSourceError != nil {
+		return fmt.Errorf(tokenSourceParseErrorTemplateConstant, tokenSourceError)
+	}
+	tokenResolver := builder.TokenResolver
+	if tokenResolver == nil {
+		tokenResolver = NewTokenResolver(builder.EnvironmentLookup, builder.FileReader)
+	}
+	token, tokenError := tokenResolver.ResolveToken(command.Context(), tokenSource)
+	if tokenError != nil {
+		return fmt.Errorf(reportTokenResolutionErrorTemplateConstant, tokenError)
+	}
+
+	reporter, reporterError := builder.resolveReporter(logger)
+	if reporterError != nil {
+		return reporterError
+	}
+
+	reports, reportError := reporter.ReportPackages(command.Context(), ghcr.PackageListRequest{
+		Owner:     options.Owner,
+		OwnerType: options.OwnerType,
+		Token:     token,
+	})
+	if reportError != nil {
+		return fmt.Errorf(reportCommandErrorTemplateConstant, reportError)
+	}
+
+	ghcr.SortPackageReports(reports)
+	if options.Top > 0 && len(reports) > options.Top {
+		reports = reports[:options.Top]
+	}
+
+	return RenderPackageReports(command.OutOrStdout(), reports, options.Format)
+}
+
+func parseReportCommandOptions(command *cobra.Command) (reportCommandOptions, error) {
+	ownerValue, ownerError := command.Flags().GetString(reportOwnerFlagNameConstant)
+	if ownerError != nil {
+		return reportCommandOptions{}, ownerError
+	}
+	ownerValue = strings.TrimSpace(ownerValue)
+	if len(ownerValue) == 0 {
+		return reportCommandOptions{}, errors.New(reportOwnerMissingErrorMessageConstant)
+	}
+
+	ownerTypeValue, ownerTypeFlagError := command.Flags().GetString(reportOwnerTypeFlagNameConstant)
+	if ownerTypeFlagError != nil {
+		return reportCommandOptions{}, ownerTypeFlagError
+	}
+	ownerType, ownerTypeError := ghcr.ParseOwnerType(ownerTypeValue)
+	if ownerTypeError != nil {
+		return reportCommandOptions{}, ownerTypeError
+	}
+
+	formatValue, formatFlagError := command.Flags().GetString(reportFormatFlagNameConstant)
+	if formatFlagError != nil {
+		return reportCommandOptions{}, formatFlagError
+	}
+	reportFormat, formatError := ParseReportFormat(formatValue)
+	if formatError != nil {
+		return reportCommandOptions{}, formatError
+	}
+
+	topValue, topFlagError := command.Flags().GetInt(reportTopFlagNameConstant)
+	if topFlagError != nil {
+		return reportCommandOptions{}, topFlagError
+	}
+	if topValue < 0 {
+		return reportCommandOptions{}, errors.New(reportTopNegativeErrorMessageConstant)
+	}
+
+	return reportCommandOptions{Owner: ownerValue, OwnerType: ownerType, Format: reportFormat, Top: topValue}, nil
+}
+
+func (builder *ReportCommandBuilder) resolveLogger() *zap.Logger {
+	if builder.LoggerProvider == nil {
+		return zap.NewNop()
+	}
+
+	logger := builder.LoggerProvider()
+	if logger == nil {
+		return zap.NewNop()
+	}
+
+	return logger
+}
+
+func (builder *ReportCommandBuilder) resolveReporter(logger *zap.Logger) (PackageReporter, error) {
+	if builder.Reporter != nil {
+		return builder.Reporter, nil
+	}
+
+	serviceResolver := &DefaultPurgeServiceResolver{EnvironmentLookup: builder.EnvironmentLookup}
+	return ghcr.NewPackageVersionService(logger, builder.HTTPClient, serviceResolver.resolveServiceConfiguration())
+}
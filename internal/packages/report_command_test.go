@@ -0,0 +1,120 @@
+package packages_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/ghcr"
+	packages "github.com/temirov/gix/internal/packages"
+)
+
+type recordingPackageReporter struct {
+	requests []ghcr.PackageListRequest
+	reports  []ghcr.PackageReport
+}
+
+func (reporter *recordingPackageReporter) ReportPackages(_ context.Context, request ghcr.PackageListRequest) ([]ghcr.PackageReport, error) {
+	reporter.requests = append(reporter.requests, request)
+	return append([]ghcr.PackageReport{}, reporter.reports...), nil
+}
+
+func TestReportCommandRendersSortedReports(t *testing.T) {
+	reports := []ghcr.PackageReport{
+		{PackageName: "empty"},
+		{
+			PackageName:      "api",
+			TotalVersions:    3,
+			TaggedVersions:   1,
+			UntaggedVersions: 2,
+			TotalBytes:       2048,
+			OldestVersion:    time.Date(2023, 1, 15, 8, 0, 0, 0, time.UTC),
+			NewestVersion:    time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC),
+		},
+		{PackageName: "web", TotalVersions: 1, TaggedVersions: 1, TotalBytes: 512},
+	}
+
+	testCases := []struct {
+		name           string
+		arguments      []string
+		expectedOutput string
+		expectedError  string
+		expectedType   ghcr.OwnerType
+	}{
+		{
+			name:         "table_default",
+			arguments:    []string{"--owner", "myorg"},
+			expectedType: ghcr.OrganizationOwnerType,
+			expectedOutput: "PACKAGE  VERSIONS  TAGGED  UNTAGGED  SIZE     OLDEST      NEWEST\n" +
+				"api      3         1       2         2.0 KiB  2023-01-15  2024-05-01\n" +
+				"web      1         1       0         512 B    -           -\n" +
+				"empty    0         0       0         0 B      -           -\n",
+		},
+		{
+			name:         "csv_top",
+			arguments:    []string{"--owner", "myorg", "--format", "csv", "--top", "2"},
+			expectedType: ghcr.OrganizationOwnerType,
+			expectedOutput: "package,versions,tagged_versions,untagged_versions,total_bytes,oldest_version,newest_version\n" +
+				"api,3,1,2,2048,2023-01-15T08:00:00Z,2024-05-01T09:30:00Z\n" +
+				"web,1,1,0,512,,\n",
+		},
+		{
+			name:         "json_user_owner",
+			arguments:    []string{"--owner", "someone", "--owner-type", "user", "--format", "json", "--top", "1"},
+			expectedType: ghcr.UserOwnerType,
+			expectedOutput: "[\n  {\n    \"package\": \"api\",\n    \"versions\": 3,\n    \"tagged_versions\": 1,\n    \"untagged_versions\": 2,\n" +
+				"    \"total_bytes\": 2048,\n    \"oldest_version\": \"2023-01-15T08:00:00Z\",\n    \"newest_version\": \"2024-05-01T09:30:00Z\"\n  }\n]\n",
+		},
+		{
+			name:          "missing_owner",
+			arguments:     []string{},
+			expectedError: "--owner must be provided",
+		},
+		{
+			name:          "negative_top",
+			arguments:     []string{"--owner", "myorg", "--top", "-1"},
+			expectedError: "--top must not be negative",
+		},
+		{
+			name:          "unsupported_format",
+			arguments:     []string{"--owner", "myorg", "--format", "yaml"},
+			expectedError: "unsupported report format \"yaml\" (expected table, csv, or json)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(subtest *testing.T) {
+			reporter := &recordingPackageReporter{reports: reports}
+			builder := packages.ReportCommandBuilder{
+				Reporter: reporter,
+				EnvironmentLookup: func(key string) (string, bool) {
+					return "packages-token", key == "GITHUB_PACKAGES_TOKEN"
+				},
+			}
+
+			command, buildError := builder.Build()
+			require.NoError(subtest, buildError)
+			outputBuffer := &bytes.Buffer{}
+			command.SetOut(outputBuffer)
+			command.SetErr(io.Discard)
+			command.SetContext(context.Background())
+			command.SetArgs(testCase.arguments)
+
+			executeError := command.Execute()
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, executeError, testCase.expectedError)
+				require.Empty(subtest, reporter.requests)
+				return
+			}
+			require.NoError(subtest, executeError)
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+			require.Len(subtest, reporter.requests, 1)
+			require.Equal(subtest, testCase.expectedType, reporter.requests[0].OwnerType)
+			require.Equal(subtest, "packages-token", reporter.requests[0].Token)
+		})
+	}
+}
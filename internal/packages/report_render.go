@@ -0,0 +1,198 @@
+package packages
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/temirov/gix/internal/ghcr"
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	reportFormatTableValueConstant          = "table"
+	reportFormatCSVValueConstant            = "csv"
+	reportFormatJSONValueConstant           = "json"
+	reportFormatUnsupportedTemplateConstant = "unsupported report format %q (expected table, csv, or json)"
+	reportTableHeaderPackageConstant        = "PACKAGE"
+	reportTableHeaderVersionsConstant       = "VERSIONS"
+	reportTableHeaderTaggedConstant         = "TAGGED"
+	reportTableHeaderUntaggedConstant       = "UNTAGGED"
+	reportTableHeaderSizeConstant           = "SIZE"
+	reportTableHeaderOldestConstant         = "OLDEST"
+	reportTableHeaderNewestConstant         = "NEWEST"
+	reportCSVHeaderPackageConstant          = "package"
+	reportCSVHeaderVersionsConstant         = "versions"
+	reportCSVHeaderTaggedConstant           = "tagged_versions"
+	reportCSVHeaderUntaggedConstant         = "untagged_versions"
+	reportCSVHeaderTotalBytesConstant       = "total_bytes"
+	reportCSVHeaderOldestConstant           = "oldest_version"
+	reportCSVHeaderNewestConstant           = "newest_version"
+	reportTableDateLayoutConstant           = "2006-01-02"
+	reportTableEmptyValueConstant           = "-"
+	reportTableColumnSeparatorConstant      = "\t"
+	reportTableLineTerminatorConstant       = "\n"
+	reportTableMinimumWidthConstant         = 0
+	reportTableTabWidthConstant             = 8
+	reportTablePaddingConstant              = 2
+	reportTablePaddingCharacterConstant     = ' '
+	reportJSONIndentConstant                = "  "
+)
+
+// ReportFormat enumerates the supported package report renderings.
+type ReportFormat string
+
+// Supported report formats.
+const (
+	ReportFormatTable ReportFormat = reportFormatTableValueConstant
+	ReportFormatCSV   ReportFormat = reportFormatCSVValueConstant
+	ReportFormatJSON  ReportFormat = reportFormatJSONValueConstant
+)
+
+// ReportFormats lists the supported report formats in display order.
+func ReportFormats() []string {
+	return []string{string(ReportFormatTable), string(ReportFormatCSV), string(ReportFormatJSON)}
+}
+
+// ParseReportFormat normalizes a user-supplied report format, defaulting to table when empty.
+func ParseReportFormat(rawValue string) (ReportFormat, error) {
+	normalized := strings.ToLower(strings.TrimSpace(rawValue))
+	if len(normalized) == 0 {
+		return ReportFormatTable, nil
+	}
+	switch ReportFormat(normalized) {
+	case ReportFormatTable, ReportFormatCSV, ReportFormatJSON:
+		return ReportFormat(normalized), nil
+	default:
+		return "", fmt.Errorf(reportFormatUnsupportedTemplateConstant, rawValue)
+	}
+}
+
+type packageReportRecord struct {
+	PackageName      string `json:"package"`
+	TotalVersions    int    `json:"versions"`
+	TaggedVersions   int    `json:"tagged_versions"`
+	UntaggedVersions int    `json:"untagged_versions"`
+	TotalBytes       int64  `json:"total_bytes"`
+	OldestVersion    string `json:"oldest_version,omitempty"`
+	NewestVersion    string `json:"newest_version,omitempty"`
+}
+
+// RenderPackageReports writes the package reports to the writer in the requested format, preserving their order.
+func RenderPackageReports(writer io.Writer, reports []ghcr.PackageReport, format ReportFormat) error {
+	switch format {
+	case ReportFormatTable:
+		return renderPackageReportTable(writer, reports)
+	case ReportFormatCSV:
+		return renderPackageReportCSV(writer, reports)
+	case ReportFormatJSON:
+		return renderPackageReportJSON(writer, reports)
+	default:
+		return fmt.Errorf(reportFormatUnsupportedTemplateConstant, string(format))
+	}
+}
+
+func renderPackageReportTable(writer io.Writer, reports []ghcr.PackageReport) error {
+	tableWriter := tabwriter.NewWriter(writer, reportTableMinimumWidthConstant, reportTableTabWidthConstant, reportTablePaddingConstant, reportTablePaddingCharacterConstant, 0)
+	header := []string{
+		reportTableHeaderPackageConstant,
+		reportTableHeaderVersionsConstant,
+		reportTableHeaderTaggedConstant,
+		reportTableHeaderUntaggedConstant,
+		reportTableHeaderSizeConstant,
+		reportTableHeaderOldestConstant,
+		reportTableHeaderNewestConstant,
+	}
+	if _, writeError := io.WriteString(tableWriter, strings.Join(header, reportTableColumnSeparatorConstant)+reportTableLineTerminatorConstant); writeError != nil {
+		return writeError
+	}
+	for _, report := range reports {
+		row := []string{
+			report.PackageName,
+			strconv.Itoa(report.TotalVersions),
+			strconv.Itoa(report.TaggedVersions),
+			strconv.Itoa(report.UntaggedVersions),
+			utils.FormatByteSize(report.TotalBytes),
+			formatReportTableDate(report.OldestVersion),
+			formatReportTableDate(report.NewestVersion),
+		}
+		if _, writeError := io.WriteString(tableWriter, strings.Join(row, reportTableColumnSeparatorConstant)+reportTableLineTerminatorConstant); writeError != nil {
+			return writeError
+		}
+	}
+	return tableWriter.Flush()
+}
+
+func renderPackageReportCSV(writer io.Writer, reports []ghcr.PackageReport) error {
+	csvWriter := csv.NewWriter(writer)
+	header := []string{
+		reportCSVHeaderPackageConstant,
+		reportCSVHeaderVersionsConstant,
+		reportCSVHeaderTaggedConstant,
+		reportCSVHeaderUntaggedConstant,
+		reportCSVHeaderTotalBytesConstant,
+		reportCSVHeaderOldestConstant,
+		reportCSVHeaderNewestConstant,
+	}
+	if writeError := csvWriter.Write(header); writeError != nil {
+		return writeError
+	}
+	for _, report := range reports {
+		record := newPackageReportRecord(report)
+		row := []string{
+			record.PackageName,
+			strconv.Itoa(record.TotalVersions),
+			strconv.Itoa(record.TaggedVersions),
+			strconv.Itoa(record.UntaggedVersions),
+			strconv.FormatInt(record.TotalBytes, 10),
+			record.OldestVersion,
+			record.NewestVersion,
+		}
+		if writeError := csvWriter.Write(row); writeError != nil {
+			return writeError
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func renderPackageReportJSON(writer io.Writer, reports []ghcr.PackageReport) error {
+	records := make([]packageReportRecord, 0, len(reports))
+	for _, report := range reports {
+		records = append(records, newPackageReportRecord(report))
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", reportJSONIndentConstant)
+	return encoder.Encode(records)
+}
+
+func newPackageReportRecord(report ghcr.PackageReport) packageReportRecord {
+	return packageReportRecord{
+		PackageName:      report.PackageName,
+		TotalVersions:    report.TotalVersions,
+		TaggedVersions:   report.TaggedVersions,
+		UntaggedVersions: report.UntaggedVersions,
+		TotalBytes:       report.TotalBytes,
+		OldestVersion:    formatReportTimestamp(report.OldestVersion),
+		NewestVersion:    formatReportTimestamp(report.NewestVersion),
+	}
+}
+
+func formatReportTimestamp(timestamp time.Time) string {
+	if timestamp.IsZero() {
+		return ""
+	}
+	return timestamp.UTC().Format(time.RFC3339)
+}
+
+func formatReportTableDate(timestamp time.Time) string {
+	if timestamp.IsZero() {
+		return reportTableEmptyValueConstant
+	}
+	return timestamp.UTC().Format(reportTableDateLayoutConstant)
+}
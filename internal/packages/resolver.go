@@ -1,6 +1,7 @@
 package packages
 
 import (
+	"context"
 	"os"
 	"strings"
 
@@ -14,10 +15,15 @@ type DefaultPurgeServiceResolver struct {
 	EnvironmentLookup EnvironmentLookup
 	FileReader        FileReader
 	TokenResolver     TokenResolver
+	// Snapshot, when set, replaces the GHCR API with the recorded listings and skips token resolution; deletions are refused.
+	Snapshot *ghcr.Snapshot
+	// SnapshotRecorder, when set, records every listing the purge reads from the GHCR API.
+	SnapshotRecorder *ghcr.SnapshotRecorder
 }
 
 const (
 	serviceBaseURLEnvironmentVariableNameConstant = "GIX_REPO_PACKAGES_PURGE_BASE_URL"
+	snapshotTokenPlaceholderConstant              = "snapshot"
 )
 
 // Resolve creates a purge executor using configured collaborators or sensible defaults.
@@ -33,6 +39,14 @@ func (resolver *DefaultPurgeServiceResolver) Resolve(logger *zap.Logger) (PurgeE
 		resolvedTokenResolver = NewTokenResolver(resolver.EnvironmentLookup, resolver.FileReader)
 	}
 
+	switch {
+	case resolver.Snapshot != nil:
+		packageService.SetVersionStore(ghcr.NewSnapshotVersionStore(*resolver.Snapshot))
+		resolvedTokenResolver = snapshotTokenResolver{}
+	case resolver.SnapshotRecorder != nil:
+		packageService.SetVersionStore(resolver.SnapshotRecorder.Wrap(packageService.VersionStore()))
+	}
+
 	purgeService, purgeServiceError := NewPurgeService(logger, packageService, resolvedTokenResolver)
 	if purgeServiceError != nil {
 		return nil, purgeServiceError
@@ -59,3 +73,9 @@ func (resolver *DefaultPurgeServiceResolver) resolveServiceConfiguration() ghcr.
 
 	return ghcr.ServiceConfiguration{BaseURL: trimmedBaseURL}
 }
+
+type snapshotTokenResolver struct{}
+
+func (snapshotTokenResolver) ResolveToken(context.Context, TokenSourceConfiguration) (string, error) {
+	return snapshotTokenPlaceholderConstant, nil
+}
@@ -0,0 +1,143 @@
+package packages
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/temirov/gix/internal/ghcr"
+	"github.com/temirov/gix/internal/workflow"
+)
+
+const (
+	keepLastFlagNameConstant                      = "keep-last"
+	keepLastFlagDescriptionConstant               = "Keep the newest N versions the purge would otherwise delete"
+	olderThanFlagNameConstant                     = "older-than"
+	olderThanFlagDescriptionConstant              = "Only delete versions last updated longer ago than this age (for example 720h or 30d)"
+	tagPatternFlagNameConstant                    = "tag-pattern"
+	tagPatternFlagDescriptionConstant             = "Also delete tagged versions whose every tag matches this glob (repeatable)"
+	protectTagFlagNameConstant                    = "protect-tag"
+	protectTagFlagDescriptionConstant             = "Never delete versions carrying a tag that matches this glob (repeatable)"
+	retentionEntirePackageConflictMessageConstant = "--keep-last, --older-than, --tag-pattern, and --protect-tag cannot be combined with --entire-package"
+	retentionPullRequestConflictMessageConstant   = "--keep-last, --older-than, --tag-pattern, and --protect-tag cannot be combined with --pr-tag-prefix"
+	retentionAgeInvalidTemplateConstant           = "invalid --older-than %q: %w"
+	retentionAgeDaySuffixConstant                 = "d"
+	retentionParameterNameConstant                = "retention"
+	retentionOrderHeadingConstant                 = "Retention rules apply in this order; the dry run labels every version with the rule that decided it:"
+	retentionOrderLineTemplateConstant            = "\n  %d. %s: %s"
+	retentionRuleSeparatorConstant                = "/"
+	retentionDeletePlanTemplate                   = "PLAN-PACKAGES-DELETE: %s/%s version=%d digest=%s tags=%s rule=%s\n"
+	retentionKeepPlanTemplate                     = "PLAN-PACKAGES-KEEP: %s/%s version=%d digest=%s tags=%s rule=%s\n"
+	retentionTagSeparator                         = ","
+)
+
+// purgeCommandLongDescription documents the retention evaluation order from the same list the purge applies.
+func purgeCommandLongDescription() string {
+	var description strings.Builder
+	description.WriteString(packagesPurgeCommandLongDescriptionConstant)
+	description.WriteString("\n\n")
+	description.WriteString(retentionOrderHeadingConstant)
+	for stepIndex, step := range ghcr.RetentionRuleOrder {
+		ruleNames := make([]string, 0, len(step.Rules))
+		for _, rule := range step.Rules {
+			ruleNames = append(ruleNames, string(rule))
+		}
+		fmt.Fprintf(&description, retentionOrderLineTemplateConstant, stepIndex+1, strings.Join(ruleNames, retentionRuleSeparatorConstant), step.Description)
+	}
+	return description.String()
+}
+
+func addRetentionFlags(command *cobra.Command) {
+	command.Flags().Int(keepLastFlagNameConstant, 0, keepLastFlagDescriptionConstant)
+	command.Flags().String(olderThanFlagNameConstant, "", olderThanFlagDescriptionConstant)
+	command.Flags().StringArray(tagPatternFlagNameConstant, nil, tagPatternFlagDescriptionConstant)
+	command.Flags().StringArray(protectTagFlagNameConstant, nil, protectTagFlagDescriptionConstant)
+}
+
+func parseRetentionFlags(command *cobra.Command) (ghcr.RetentionPolicy, error) {
+	keepLast, keepLastError := command.Flags().GetInt(keepLastFlagNameConstant)
+	if keepLastError != nil {
+		return ghcr.RetentionPolicy{}, keepLastError
+	}
+	olderThanValue, olderThanError := command.Flags().GetString(olderThanFlagNameConstant)
+	if olderThanError != nil {
+		return ghcr.RetentionPolicy{}, olderThanError
+	}
+	tagPatterns, tagPatternsError := command.Flags().GetStringArray(tagPatternFlagNameConstant)
+	if tagPatternsError != nil {
+		return ghcr.RetentionPolicy{}, tagPatternsError
+	}
+	protectedTags, protectedTagsError := command.Flags().GetStringArray(protectTagFlagNameConstant)
+	if protectedTagsError != nil {
+		return ghcr.RetentionPolicy{}, protectedTagsError
+	}
+
+	policy := ghcr.RetentionPolicy{
+		KeepLast:      keepLast,
+		TagPatterns:   trimNonEmpty(tagPatterns),
+		ProtectedTags: trimNonEmpty(protectedTags),
+	}
+	if trimmedOlderThan := strings.TrimSpace(olderThanValue); len(trimmedOlderThan) > 0 {
+		olderThan, parseError := parseRetentionAge(trimmedOlderThan)
+		if parseError != nil {
+			return ghcr.RetentionPolicy{}, fmt.Errorf(retentionAgeInvalidTemplateConstant, olderThanValue, parseError)
+		}
+		policy.OlderThan = olderThan
+	}
+	if validationError := policy.Validate(); validationError != nil {
+		return ghcr.RetentionPolicy{}, validationError
+	}
+	return policy, nil
+}
+
+// parseRetentionAge accepts Go durations and whole days written as <N>d.
+func parseRetentionAge(value string) (time.Duration, error) {
+	if dayCount, isDays := strings.CutSuffix(value, retentionAgeDaySuffixConstant); isDays {
+		days, parseError := strconv.Atoi(dayCount)
+		if parseError != nil {
+			return 0, parseError
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+func validateRetentionCombination(policy ghcr.RetentionPolicy, entirePackage bool, pullRequestTagPrefix string) error {
+	if !policy.Enabled() {
+		return nil
+	}
+	if entirePackage {
+		return errors.New(retentionEntirePackageConflictMessageConstant)
+	}
+	if len(pullRequestTagPrefix) > 0 {
+		return errors.New(retentionPullRequestConflictMessageConstant)
+	}
+	return nil
+}
+
+func trimNonEmpty(values []string) []string {
+	var trimmed []string
+	for _, value := range values {
+		if trimmedValue := strings.TrimSpace(value); len(trimmedValue) > 0 {
+			trimmed = append(trimmed, trimmedValue)
+		}
+	}
+	return trimmed
+}
+
+// reportRetentionPlan lists, during a dry run, every version a retention policy selects or keeps with its rule.
+func reportRetentionPlan(environment *workflow.Environment, options PurgeOptions, result ghcr.PurgeResult) {
+	if !options.DryRun || !options.Retention.Enabled() || environment.Output == nil {
+		return
+	}
+	for _, candidate := range result.Candidates {
+		fmt.Fprintf(environment.Output, retentionDeletePlanTemplate, options.Owner, options.PackageName, candidate.VersionID, candidate.Digest, strings.Join(candidate.Tags, retentionTagSeparator), candidate.Rule)
+	}
+	for _, survivor := range result.Survivors {
+		fmt.Fprintf(environment.Output, retentionKeepPlanTemplate, options.Owner, options.PackageName, survivor.VersionID, survivor.Digest, strings.Join(survivor.Tags, retentionTagSeparator), survivor.Rule)
+	}
+}
@@ -0,0 +1,85 @@
+package packages
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/ghcr"
+	"github.com/temirov/gix/internal/workflow"
+)
+
+func TestPackagesPurgeActionReportsRetentionRules(testInstance *testing.T) {
+	retentionResult := ghcr.PurgeResult{
+		Candidates: []ghcr.PurgeCandidate{
+			{VersionID: 11, Digest: "sha256:aaa", Tags: []string{"pr-7"}, Rule: ghcr.RetentionRuleTagPattern},
+			{VersionID: 12, Digest: "sha256:bbb", Rule: ghcr.RetentionRuleUntagged},
+		},
+		Survivors: []ghcr.SurvivingVersion{
+			{VersionID: 13, Digest: "sha256:ccc", Tags: []string{"latest", "v2"}, Rule: ghcr.RetentionRuleProtected},
+			{VersionID: 14, Digest: "sha256:ddd", Rule: ghcr.RetentionRuleKeepLast},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		dryRun         bool
+		retention      any
+		expectedOutput string
+	}{
+		{
+			name:      "dry_run_labels_every_version",
+			dryRun:    true,
+			retention: ghcr.RetentionPolicy{KeepLast: 1, TagPatterns: []string{"pr-*"}, ProtectedTags: []string{"latest"}},
+			expectedOutput: "PLAN-PACKAGES-DELETE: acme/service version=11 digest=sha256:aaa tags=pr-7 rule=tag-pattern\n" +
+				"PLAN-PACKAGES-DELETE: acme/service version=12 digest=sha256:bbb tags= rule=untagged\n" +
+				"PLAN-PACKAGES-KEEP: acme/service version=13 digest=sha256:ccc tags=latest,v2 rule=protected\n" +
+				"PLAN-PACKAGES-KEEP: acme/service version=14 digest=sha256:ddd tags= rule=keep-last\n",
+		},
+		{
+			name:      "execution_stays_quiet",
+			retention: ghcr.RetentionPolicy{KeepLast: 1},
+		},
+		{
+			name:   "dry_run_without_policy_stays_quiet",
+			dryRun: true,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			outputBuffer := &bytes.Buffer{}
+			environment := &workflow.Environment{Output: outputBuffer}
+			repository := &workflow.RepositoryState{Path: "/tmp/service"}
+			capturedOptions := PurgeOptions{}
+			parameters := map[string]any{
+				"service":           capturingPurgeExecutor{result: retentionResult, options: &capturedOptions},
+				"metadata_resolver": staticMetadataResolver{},
+				"token_source":      TokenSourceConfiguration{},
+				"dry_run":           testCase.dryRun,
+			}
+			if testCase.retention != nil {
+				parameters["retention"] = testCase.retention
+			}
+
+			require.NoError(subtest, handlePackagesPurgeAction(context.Background(), environment, repository, parameters))
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+			if testCase.retention != nil {
+				require.Equal(subtest, testCase.retention, capturedOptions.Retention)
+			}
+		})
+	}
+}
+
+func TestPurgeCommandLongDescriptionFollowsRuleOrder(testInstance *testing.T) {
+	require.Equal(testInstance, "repo-packages-purge removes untagged container versions from GitHub Container Registry.\n\n"+
+		"Retention rules apply in this order; the dry run labels every version with the rule that decided it:\n"+
+		"  1. protected: versions carrying a protected tag are always kept\n"+
+		"  2. untagged/tag-pattern/tagged: untagged versions and tagged versions whose every tag matches a tag pattern become candidates; other tagged versions are kept\n"+
+		"  3. older-than: candidates updated more recently than the age threshold, or of unknown age, are kept\n"+
+		"  4. keep-last: the newest remaining candidates up to the keep count are kept\n"+
+		"  5. label-filter: candidates whose image config labels do not match the label filters are kept", purgeCommandLongDescription())
+}
@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/temirov/gix/internal/ghcr"
+	"github.com/temirov/gix/internal/repos/shared"
 )
 
 const (
@@ -27,22 +28,61 @@ const (
 	deletedVersionsLogFieldNameConstant          = "deleted_versions"
 	untaggedVersionsLogFieldNameConstant         = "untagged_versions"
 	totalVersionsLogFieldNameConstant            = "total_versions"
+	retainedVersionsLogFieldNameConstant         = "retained_versions"
+	failedVersionsLogFieldNameConstant           = "failed_versions"
 	tokenResolutionErrorTemplateConstant         = "unable to resolve authentication token: %w"
 	purgeExecutionErrorTemplateConstant          = "unable to purge package versions: %w"
+	deletedPackagesLogFieldNameConstant          = "deleted_packages"
+	taggedVersionsLogFieldNameConstant           = "tagged_versions"
+	packageInspectionErrorTemplateConstant       = "unable to inspect package versions: %w"
+	packageDeletionErrorTemplateConstant         = "unable to delete package: %w"
+	taggedVersionsRefusalTemplateConstant        = "%w: %s/%s has %d tagged version(s)"
+	phrasePromptTemplateConstant                 = "Type the package name (%s) to delete %s/%s and all %d version(s): "
+	phraseConfirmerMissingErrorMessageConstant   = "entire package deletion requires a confirmation prompt"
+	packageDeletionDeclinedMessageConstant       = "Entire package deletion not confirmed"
+	permissionPreflightErrorTemplateConstant     = "delete permission preflight failed: %w"
+	pullRequestRepositoryMissingMessageConstant  = "pull request tag purge requires the repository that owns the pull requests"
+	pullRequestResolverMissingMessageConstant    = "pull request tag purge requires a pull request state resolver"
 )
 
+// ErrPackageHasTaggedVersions indicates an entire-package deletion was refused because tagged versions exist and force was not requested.
+var ErrPackageHasTaggedVersions = errors.New("package has tagged versions; pass --force to delete it")
+
 // PackageVersionAPI describes the GHCR operations used by the purge service.
 type PackageVersionAPI interface {
 	PurgeUntaggedVersions(executionContext context.Context, request ghcr.PurgeRequest) (ghcr.PurgeResult, error)
+	PurgePullRequestVersions(executionContext context.Context, request ghcr.PurgeRequest) (ghcr.PurgeResult, error)
+	CountVersions(executionContext context.Context, request ghcr.PurgeRequest) (ghcr.PurgeResult, error)
+	DeletePackage(executionContext context.Context, request ghcr.PackageDeletionRequest) error
+	CheckDeletePermission(executionContext context.Context, request ghcr.PurgeRequest) error
 }
 
-// PurgeOptions represents validated inputs for package purging.
+// PurgeOptions represents validated inputs for package purging. EntirePackage switches from version purging to deleting the whole package.
 type PurgeOptions struct {
-	Owner       string
-	PackageName string
-	OwnerType   ghcr.OwnerType
-	TokenSource TokenSourceConfiguration
-	DryRun      bool
+	Owner           string
+	PackageName     string
+	OwnerType       ghcr.OwnerType
+	TokenSource     TokenSourceConfiguration
+	DryRun          bool
+	EntirePackage   bool
+	Force           bool
+	FailFast        bool
+	PhraseConfirmer shared.PhraseConfirmationPrompter
+	// LabelFilters restricts the purge to untagged versions whose image config carries every listed label.
+	LabelFilters []ghcr.LabelFilter
+	// SkipPermissionCheck disables the delete permission preflight that otherwise runs before any real deletion.
+	SkipPermissionCheck bool
+	// PullRequestTagPrefix switches from untagged versions to versions tagged <prefix><number> whose pull request in
+	// PullRequestRepository is closed or merged.
+	PullRequestTagPrefix  string
+	PullRequestRepository string
+	PullRequestStates     PullRequestStateResolver
+	// ApprovedDigests, when non-nil, restricts deletion to the listed digests the purge policy still selects; listed
+	// digests it no longer selects are refused unless ForceApproved is set.
+	ApprovedDigests []string
+	ForceApproved   bool
+	// Retention decides which versions the purge deletes; the zero policy selects every untagged version.
+	Retention ghcr.RetentionPolicy
 }
 
 // PurgeExecutor defines the behavior required by the command layer.
@@ -79,6 +119,8 @@ func NewPurgeService(logger *zap.Logger, packageService PackageVersionAPI, token
 }
 
 // Execute performs the purge workflow for the provided options.
+// Unless the run is a dry run or SkipPermissionCheck is set, the token's delete permission is verified before anything is deleted.
+// Failed version deletions are returned together with the result as a PartialPurgeError unless FailFast aborts the purge first.
 func (service *PurgeService) Execute(executionContext context.Context, options PurgeOptions) (ghcr.PurgeResult, error) {
 	trimmedOwner := strings.TrimSpace(options.Owner)
 	if len(trimmedOwner) == 0 {
@@ -113,14 +155,40 @@ func (service *PurgeService) Execute(executionContext context.Context, options P
 	}
 
 	purgeRequest := ghcr.PurgeRequest{
-		Owner:       trimmedOwner,
-		PackageName: trimmedPackageName,
-		OwnerType:   options.OwnerType,
-		Token:       resolvedToken,
-		DryRun:      options.DryRun,
+		Owner:           trimmedOwner,
+		PackageName:     trimmedPackageName,
+		OwnerType:       options.OwnerType,
+		Token:           resolvedToken,
+		DryRun:          options.DryRun,
+		FailFast:        options.FailFast,
+		LabelFilters:    options.LabelFilters,
+		ApprovedDigests: options.ApprovedDigests,
+		ForceApproved:   options.ForceApproved,
+		Retention:       options.Retention,
+	}
+
+	if !options.DryRun && !options.SkipPermissionCheck {
+		if permissionError := service.packageService.CheckDeletePermission(executionContext, purgeRequest); permissionError != nil {
+			return ghcr.PurgeResult{}, fmt.Errorf(permissionPreflightErrorTemplateConstant, permissionError)
+		}
+	}
+
+	if options.EntirePackage {
+		return service.deleteEntirePackage(executionContext, options, purgeRequest)
 	}
 
-	purgeResult, purgeError := service.packageService.PurgeUntaggedVersions(executionContext, purgeRequest)
+	purgeVersions := service.packageService.PurgeUntaggedVersions
+	if len(strings.TrimSpace(options.PullRequestTagPrefix)) > 0 {
+		lookup, lookupError := service.pullRequestStateLookup(options)
+		if lookupError != nil {
+			return ghcr.PurgeResult{}, lookupError
+		}
+		purgeRequest.PullRequestTagPrefix = options.PullRequestTagPrefix
+		purgeRequest.PullRequestStates = lookup
+		purgeVersions = service.packageService.PurgePullRequestVersions
+	}
+
+	purgeResult, purgeError := purgeVersions(executionContext, purgeRequest)
 	if purgeError != nil {
 		return ghcr.PurgeResult{}, fmt.Errorf(purgeExecutionErrorTemplateConstant, purgeError)
 	}
@@ -130,7 +198,80 @@ func (service *PurgeService) Execute(executionContext context.Context, options P
 		zap.Int(totalVersionsLogFieldNameConstant, purgeResult.TotalVersions),
 		zap.Int(untaggedVersionsLogFieldNameConstant, purgeResult.UntaggedVersions),
 		zap.Int(deletedVersionsLogFieldNameConstant, purgeResult.DeletedVersions),
+		zap.Int(retainedVersionsLogFieldNameConstant, purgeResult.RetainedVersions),
+		zap.Int(failedVersionsLogFieldNameConstant, len(purgeResult.Failures)),
 	)
 
+	if len(purgeResult.Failures) > 0 {
+		return purgeResult, newPartialPurgeError(trimmedOwner, trimmedPackageName, purgeResult.Failures)
+	}
+
 	return purgeResult, nil
 }
+
+func (service *PurgeService) pullRequestStateLookup(options PurgeOptions) (ghcr.PullRequestStateLookup, error) {
+	repository := strings.TrimSpace(options.PullRequestRepository)
+	if len(repository) == 0 {
+		return nil, errors.New(pullRequestRepositoryMissingMessageConstant)
+	}
+	if options.PullRequestStates == nil {
+		return nil, errors.New(pullRequestResolverMissingMessageConstant)
+	}
+	return pullRequestStateLookup{logger: service.logger, resolver: options.PullRequestStates, repository: repository}, nil
+}
+
+func (service *PurgeService) deleteEntirePackage(executionContext context.Context, options PurgeOptions, purgeRequest ghcr.PurgeRequest) (ghcr.PurgeResult, error) {
+	inspectionResult, inspectionError := service.packageService.CountVersions(executionContext, purgeRequest)
+	if inspectionError != nil {
+		return ghcr.PurgeResult{}, fmt.Errorf(packageInspectionErrorTemplateConstant, inspectionError)
+	}
+
+	if inspectionResult.TaggedVersions > 0 && !options.Force {
+		return inspectionResult, fmt.Errorf(taggedVersionsRefusalTemplateConstant, ErrPackageHasTaggedVersions, purgeRequest.Owner, purgeRequest.PackageName, inspectionResult.TaggedVersions)
+	}
+
+	if options.DryRun {
+		return inspectionResult, nil
+	}
+
+	if options.PhraseConfirmer == nil {
+		return inspectionResult, errors.New(phraseConfirmerMissingErrorMessageConstant)
+	}
+
+	confirmed, confirmationError := options.PhraseConfirmer.ConfirmPhrase(
+		fmt.Sprintf(phrasePromptTemplateConstant, purgeRequest.PackageName, purgeRequest.Owner, purgeRequest.PackageName, inspectionResult.TotalVersions),
+		purgeRequest.PackageName,
+	)
+	if confirmationError != nil {
+		return inspectionResult, confirmationError
+	}
+	if !confirmed {
+		service.logger.Info(
+			packageDeletionDeclinedMessageConstant,
+			zap.String(ownerLogFieldNameConstant, purgeRequest.Owner),
+			zap.String(packageLogFieldNameConstant, purgeRequest.PackageName),
+		)
+		return inspectionResult, nil
+	}
+
+	deletionRequest := ghcr.PackageDeletionRequest{
+		Owner:       purgeRequest.Owner,
+		OwnerType:   purgeRequest.OwnerType,
+		PackageType: ghcr.ContainerPackageType,
+		PackageName: purgeRequest.PackageName,
+		Token:       purgeRequest.Token,
+	}
+	if deletionError := service.packageService.DeletePackage(executionContext, deletionRequest); deletionError != nil {
+		return inspectionResult, fmt.Errorf(packageDeletionErrorTemplateConstant, deletionError)
+	}
+	inspectionResult.DeletedPackages = 1
+
+	service.logger.Info(
+		purgeServiceSummaryMessageConstant,
+		zap.Int(totalVersionsLogFieldNameConstant, inspectionResult.TotalVersions),
+		zap.Int(taggedVersionsLogFieldNameConstant, inspectionResult.TaggedVersions),
+		zap.Int(deletedPackagesLogFieldNameConstant, inspectionResult.DeletedPackages),
+	)
+
+	return inspectionResult, nil
+}
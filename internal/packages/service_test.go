@@ -117,10 +117,30 @@ func TestPurgeServiceInvokesPackageService(testingInstance *testing.T) {
 }
 
 type stubPackageVersionAPI struct {
-	request ghcr.PurgeRequest
-	result  ghcr.PurgeResult
-	err     error
-	called  bool
+	request         ghcr.PurgeRequest
+	result          ghcr.PurgeResult
+	err             error
+	called          bool
+	countResult     ghcr.PurgeResult
+	deletionRequest *ghcr.PackageDeletionRequest
+	permissionError error
+	permissionCalls int
+	pullRequestCall bool
+}
+
+func (service *stubPackageVersionAPI) CheckDeletePermission(executionContext context.Context, request ghcr.PurgeRequest) error {
+	service.permissionCalls++
+	return service.permissionError
+}
+
+func (service *stubPackageVersionAPI) CountVersions(executionContext context.Context, request ghcr.PurgeRequest) (ghcr.PurgeResult, error) {
+	service.request = request
+	return service.countResult, nil
+}
+
+func (service *stubPackageVersionAPI) DeletePackage(executionContext context.Context, request ghcr.PackageDeletionRequest) error {
+	service.deletionRequest = &request
+	return nil
 }
 
 func (service *stubPackageVersionAPI) PurgeUntaggedVersions(executionContext context.Context, request ghcr.PurgeRequest) (ghcr.PurgeResult, error) {
@@ -132,6 +152,123 @@ func (service *stubPackageVersionAPI) PurgeUntaggedVersions(executionContext con
 	return service.result, nil
 }
 
+func (service *stubPackageVersionAPI) PurgePullRequestVersions(executionContext context.Context, request ghcr.PurgeRequest) (ghcr.PurgeResult, error) {
+	service.pullRequestCall = true
+	service.request = request
+	if service.err != nil {
+		return ghcr.PurgeResult{}, service.err
+	}
+	return service.result, nil
+}
+
+type stubPhraseConfirmer struct {
+	response       string
+	prompts        []string
+	expectedPhrase string
+}
+
+func (confirmer *stubPhraseConfirmer) ConfirmPhrase(prompt string, expectedPhrase string) (bool, error) {
+	confirmer.prompts = append(confirmer.prompts, prompt)
+	confirmer.expectedPhrase = expectedPhrase
+	return confirmer.response == expectedPhrase, nil
+}
+
+func TestPurgeServiceDeletesEntirePackage(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	testCases := []struct {
+		name                  string
+		countResult           ghcr.PurgeResult
+		force                 bool
+		dryRun                bool
+		confirmationResponse  string
+		expectedError         error
+		expectedPrompts       int
+		expectedDeletion      bool
+		expectedDeletedCounts int
+	}{
+		{
+			name:                  "untagged_package_confirmed",
+			countResult:           ghcr.PurgeResult{TotalVersions: 3, UntaggedVersions: 3},
+			confirmationResponse:  "package",
+			expectedPrompts:       1,
+			expectedDeletion:      true,
+			expectedDeletedCounts: 1,
+		},
+		{
+			name:                 "confirmation_phrase_mismatch",
+			countResult:          ghcr.PurgeResult{TotalVersions: 3, UntaggedVersions: 3},
+			confirmationResponse: "y",
+			expectedPrompts:      1,
+		},
+		{
+			name:          "tagged_versions_refused_without_force",
+			countResult:   ghcr.PurgeResult{TotalVersions: 3, UntaggedVersions: 1, TaggedVersions: 2},
+			expectedError: packages.ErrPackageHasTaggedVersions,
+		},
+		{
+			name:                  "tagged_versions_forced",
+			countResult:           ghcr.PurgeResult{TotalVersions: 3, UntaggedVersions: 1, TaggedVersions: 2},
+			force:                 true,
+			confirmationResponse:  "package",
+			expectedPrompts:       1,
+			expectedDeletion:      true,
+			expectedDeletedCounts: 1,
+		},
+		{
+			name:        "dry_run_skips_prompt_and_deletion",
+			countResult: ghcr.PurgeResult{TotalVersions: 3, UntaggedVersions: 3},
+			dryRun:      true,
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+
+		testingInstance.Run(testCase.name, func(testingSubInstance *testing.T) {
+			testingSubInstance.Parallel()
+
+			packageService := &stubPackageVersionAPI{countResult: testCase.countResult}
+			service, serviceError := packages.NewPurgeService(zap.NewNop(), packageService, &stubTokenResolver{token: "resolved-token"})
+			require.NoError(testingSubInstance, serviceError)
+
+			confirmer := &stubPhraseConfirmer{response: testCase.confirmationResponse}
+			result, executionError := service.Execute(context.Background(), packages.PurgeOptions{
+				Owner:           "owner",
+				PackageName:     "package",
+				OwnerType:       ghcr.OrganizationOwnerType,
+				TokenSource:     packages.TokenSourceConfiguration{Type: packages.TokenSourceTypeEnvironment, Reference: "ENV"},
+				DryRun:          testCase.dryRun,
+				EntirePackage:   true,
+				Force:           testCase.force,
+				PhraseConfirmer: confirmer,
+			})
+			if testCase.expectedError != nil {
+				require.ErrorIs(testingSubInstance, executionError, testCase.expectedError)
+			} else {
+				require.NoError(testingSubInstance, executionError)
+			}
+
+			require.False(testingSubInstance, packageService.called)
+			require.Len(testingSubInstance, confirmer.prompts, testCase.expectedPrompts)
+			require.Equal(testingSubInstance, testCase.expectedDeletedCounts, result.DeletedPackages)
+			require.Zero(testingSubInstance, result.DeletedVersions)
+			if !testCase.expectedDeletion {
+				require.Nil(testingSubInstance, packageService.deletionRequest)
+				return
+			}
+			require.Equal(testingSubInstance, "package", confirmer.expectedPhrase)
+			require.Equal(testingSubInstance, ghcr.PackageDeletionRequest{
+				Owner:       "owner",
+				OwnerType:   ghcr.OrganizationOwnerType,
+				PackageType: ghcr.ContainerPackageType,
+				PackageName: "package",
+				Token:       "resolved-token",
+			}, *packageService.deletionRequest)
+		})
+	}
+}
+
 type stubTokenResolver struct {
 	token  string
 	err    error
@@ -145,3 +282,97 @@ func (resolver *stubTokenResolver) ResolveToken(resolutionContext context.Contex
 	}
 	return resolver.token, nil
 }
+
+func TestPurgeServiceReportsPartialPurge(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	failure := ghcr.VersionDeletionFailure{VersionID: 42, Digest: "sha256:def", StatusCode: 403, Message: "forbidden"}
+	packageService := &stubPackageVersionAPI{
+		result: ghcr.PurgeResult{UntaggedVersions: 2, DeletedVersions: 1, Failures: []ghcr.VersionDeletionFailure{failure}},
+	}
+
+	service, serviceError := packages.NewPurgeService(zap.NewNop(), packageService, &stubTokenResolver{token: "resolved-token"})
+	require.NoError(testingInstance, serviceError)
+
+	result, executionError := service.Execute(context.Background(), packages.PurgeOptions{
+		Owner:       "owner",
+		PackageName: "package",
+		OwnerType:   ghcr.OrganizationOwnerType,
+		TokenSource: packages.TokenSourceConfiguration{Type: packages.TokenSourceTypeEnvironment, Reference: "ENV"},
+		FailFast:    true,
+	})
+	require.True(testingInstance, packageService.request.FailFast)
+	require.Equal(testingInstance, packageService.result, result)
+
+	var partialPurgeError packages.PartialPurgeError
+	require.ErrorAs(testingInstance, executionError, &partialPurgeError)
+	require.Equal(testingInstance, []packages.PurgeFailure{{Owner: "owner", PackageName: "package", VersionDeletionFailure: failure}}, partialPurgeError.Failures)
+	require.EqualError(testingInstance, executionError, "1 package version deletion(s) failed across 1 package(s)")
+}
+
+func TestPurgeServiceDeletePermissionPreflight(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	permissionError := ghcr.DeletePermissionError{Owner: "owner", OwnerType: ghcr.OrganizationOwnerType}
+
+	testCases := []struct {
+		name                    string
+		dryRun                  bool
+		entirePackage           bool
+		skipPermissionCheck     bool
+		expectedPermissionCalls int
+		expectedError           string
+	}{
+		{
+			name:                    "missing_permission_aborts_before_purge",
+			expectedPermissionCalls: 1,
+			expectedError:           "delete permission preflight failed: token lacks delete:packages for org owner",
+		},
+		{
+			name:                    "missing_permission_aborts_before_package_deletion",
+			entirePackage:           true,
+			expectedPermissionCalls: 1,
+			expectedError:           "delete permission preflight failed: token lacks delete:packages for org owner",
+		},
+		{
+			name:                "skip_permission_check",
+			skipPermissionCheck: true,
+		},
+		{
+			name:   "dry_run_skips_preflight",
+			dryRun: true,
+		},
+	}
+
+	for index := range testCases {
+		testCase := testCases[index]
+
+		testingInstance.Run(testCase.name, func(testingSubInstance *testing.T) {
+			testingSubInstance.Parallel()
+
+			packageService := &stubPackageVersionAPI{permissionError: permissionError}
+			service, serviceError := packages.NewPurgeService(zap.NewNop(), packageService, &stubTokenResolver{token: "resolved-token"})
+			require.NoError(testingSubInstance, serviceError)
+
+			_, executionError := service.Execute(context.Background(), packages.PurgeOptions{
+				Owner:               "owner",
+				PackageName:         "package",
+				OwnerType:           ghcr.OrganizationOwnerType,
+				TokenSource:         packages.TokenSourceConfiguration{Type: packages.TokenSourceTypeEnvironment, Reference: "ENV"},
+				DryRun:              testCase.dryRun,
+				EntirePackage:       testCase.entirePackage,
+				SkipPermissionCheck: testCase.skipPermissionCheck,
+				PhraseConfirmer:     &stubPhraseConfirmer{},
+			})
+			require.Equal(testingSubInstance, testCase.expectedPermissionCalls, packageService.permissionCalls)
+			if len(testCase.expectedError) == 0 {
+				require.NoError(testingSubInstance, executionError)
+				return
+			}
+			require.EqualError(testingSubInstance, executionError, testCase.expectedError)
+			require.ErrorAs(testingSubInstance, executionError, &ghcr.DeletePermissionError{})
+			require.False(testingSubInstance, packageService.called)
+			require.Nil(testingSubInstance, packageService.deletionRequest)
+		})
+	}
+}
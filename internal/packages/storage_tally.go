@@ -0,0 +1,41 @@
+package packages
+
+import "github.com/temirov/gix/internal/utils"
+
+// PackageStorage records the reclaimable bytes of one package processed in a run.
+type PackageStorage struct {
+	Owner       string
+	PackageName string
+	ByteCount   int64
+}
+
+// StorageTally accumulates reclaimable storage across every package processed in a run.
+type StorageTally struct {
+	packages utils.Tally[PackageStorage]
+}
+
+// Add records the reclaimable bytes of one package.
+func (tally *StorageTally) Add(owner string, packageName string, byteCount int64) {
+	if tally == nil {
+		return
+	}
+	tally.packages.Add(PackageStorage{Owner: owner, PackageName: packageName, ByteCount: byteCount})
+}
+
+// Totals reports the number of packages recorded and their combined reclaimable bytes.
+func (tally *StorageTally) Totals() (int, int64) {
+	packages := tally.Packages()
+	var byteCount int64
+	for _, packageStorage := range packages {
+		byteCount += packageStorage.ByteCount
+	}
+	return len(packages), byteCount
+}
+
+// Packages returns the reclaimable storage recorded for each package, in the order the packages were processed.
+func (tally *StorageTally) Packages() []PackageStorage {
+	if tally == nil {
+		return nil
+	}
+	return tally.packages.Records()
+}
@@ -6,10 +6,27 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/temirov/gix/internal/ghcr"
+	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/ui"
+	"github.com/temirov/gix/internal/utils"
 	"github.com/temirov/gix/internal/workflow"
 )
 
-const taskActionPackagesPurge = "repo.packages.purge"
+const (
+	taskActionPackagesPurge         = "repo.packages.purge"
+	retainedVersionsSummaryTemplate = "PACKAGES-RETAINED: %s/%s kept %d version(s) GHCR refuses to delete because each is the package's last tagged version\n"
+	packageDeletePlanTemplate       = "PLAN-PACKAGE-DELETE: %s/%s entire package (%d version(s), %d tagged)\n"
+	packageDeletedTemplate          = "PACKAGE-DELETED: %s/%s entire package removed (%d version(s))\n"
+	packageDeleteSkipTemplate       = "PACKAGE-DELETE-SKIP: %s/%s confirmation phrase did not match\n"
+	reclaimPlanTemplate             = "PLAN-PACKAGES-RECLAIM: %s/%s would free %s (%d bytes)\n"
+	reclaimedTemplate               = "PACKAGES-RECLAIMED: %s/%s freed %s (%d bytes)\n"
+	versionDeleteFailedTemplate     = "PACKAGES-DELETE-FAILED: %s/%s version=%d digest=%s status=%d message=%s\n"
+	labelFilterSummaryTemplate      = "PACKAGES-LABEL-FILTER: %s/%s %d of %d untagged version(s) match %s (%d manifest fetch(es))\n"
+	labelFilterListSeparator        = ", "
+	pullRequestDeletePlanTemplate   = "PLAN-PACKAGES-PR-DELETE: %s/%s tag=%s -> #%d (%s) version=%d\n"
+	pullRequestSummaryTemplate      = "PACKAGES-PR-TAGS: %s/%s %d version(s) from closed or merged pull requests, %d kept for open or unknown pull requests\n"
+)
 
 func init() {
 	workflow.RegisterTaskAction(taskActionPackagesPurge, handlePackagesPurgeAction)
@@ -50,6 +67,22 @@ func handlePackagesPurgeAction(ctx context.Context, environment *workflow.Enviro
 		dryRun = value
 	}
 
+	entirePackage, _ := parameters["entire_package"].(bool)
+	force, _ := parameters["force"].(bool)
+	phraseConfirmer, _ := parameters["phrase_confirmer"].(shared.PhraseConfirmationPrompter)
+	storageTally, _ := parameters["storage_tally"].(*StorageTally)
+	failureTally, _ := parameters["failure_tally"].(*PurgeFailureTally)
+	failFast, _ := parameters["fail_fast"].(bool)
+	labelFilters, _ := parameters["label_filters"].([]ghcr.LabelFilter)
+	annotations, _ := parameters[annotationParameterNameConstant].(*ui.AnnotationEmitter)
+	skipPermissionCheck, _ := parameters["skip_permission_check"].(bool)
+	pullRequestTagPrefix, _ := parameters["pr_tag_prefix"].(string)
+	pullRequestStates, _ := parameters["pull_request_states"].(PullRequestStateResolver)
+	candidateLedger, _ := parameters[candidateLedgerParameterNameConstant].(*CandidateLedger)
+	approvedDigests, _ := parameters[approvedDigestsParameterNameConstant].([]string)
+	forceApproved, _ := parameters[forceApprovedParameterNameConstant].(bool)
+	retention, _ := parameters[retentionParameterNameConstant].(ghcr.RetentionPolicy)
+
 	metadata, metadataError := resolver.ResolveMetadata(ctx, repository.Path)
 	if metadataError != nil {
 		return fmt.Errorf("packages metadata resolution failed: %w", metadataError)
@@ -61,17 +94,144 @@ func handlePackagesPurgeAction(ctx context.Context, environment *workflow.Enviro
 	}
 
 	options := PurgeOptions{
-		Owner:       metadata.Owner,
-		PackageName: packageName,
-		OwnerType:   metadata.OwnerType,
-		TokenSource: tokenSource,
-		DryRun:      dryRun,
+		Owner:               metadata.Owner,
+		PackageName:         packageName,
+		OwnerType:           metadata.OwnerType,
+		TokenSource:         tokenSource,
+		DryRun:              dryRun,
+		EntirePackage:       entirePackage,
+		Force:               force,
+		FailFast:            failFast,
+		PhraseConfirmer:     phraseConfirmer,
+		LabelFilters:        labelFilters,
+		SkipPermissionCheck: skipPermissionCheck,
+		ApprovedDigests:     approvedDigests,
+		ForceApproved:       forceApproved,
+		Retention:           retention,
+	}
+	if len(strings.TrimSpace(pullRequestTagPrefix)) > 0 {
+		options.PullRequestTagPrefix = pullRequestTagPrefix
+		options.PullRequestRepository = metadata.Repository
+		options.PullRequestStates = pullRequestStates
 	}
 
-	_, executionError := service.Execute(ctx, options)
-	if executionError != nil {
+	return runPackagesPurge(ctx, environment, service, options, storageTally, failureTally, candidateLedger, annotations)
+}
+
+func runPackagesPurge(ctx context.Context, environment *workflow.Environment, service PurgeExecutor, options PurgeOptions, storageTally *StorageTally, failureTally *PurgeFailureTally, candidateLedger *CandidateLedger, annotations *ui.AnnotationEmitter) error {
+	result, executionError := service.Execute(ctx, options)
+	var partialPurgeError PartialPurgeError
+	partialPurge := errors.As(executionError, &partialPurgeError)
+	if executionError != nil && !partialPurge {
 		return fmt.Errorf("packages purge execution failed: %w", executionError)
 	}
 
+	if options.EntirePackage {
+		reportEntirePackageDeletion(environment, options, result)
+		if options.DryRun || result.DeletedPackages > 0 {
+			reportReclaimedStorage(environment, storageTally, options, result)
+		}
+		return nil
+	}
+
+	if len(options.LabelFilters) > 0 && environment.Output != nil {
+		fmt.Fprintf(environment.Output, labelFilterSummaryTemplate, options.Owner, options.PackageName, result.LabelMatchedVersions, result.UntaggedVersions, describeLabelFilters(options.LabelFilters), result.ManifestFetches)
+	}
+	if len(options.PullRequestTagPrefix) > 0 {
+		reportPullRequestVersions(environment, options, result)
+	}
+	reportRetentionPlan(environment, options, result)
+	reportApprovalRefusals(environment, candidateLedger.Record(options.Owner, options.PackageName, result))
+	if result.RetainedVersions > 0 && environment.Output != nil {
+		fmt.Fprintf(environment.Output, retainedVersionsSummaryTemplate, options.Owner, options.PackageName, result.RetainedVersions)
+	}
+	reportReclaimedStorage(environment, storageTally, options, result)
+
+	if partialPurge {
+		reportPurgeFailures(environment, partialPurgeError)
+		annotatePurgeFailures(annotations, partialPurgeError.Failures)
+		if failureTally == nil {
+			return executionError
+		}
+		failureTally.Add(partialPurgeError.Failures...)
+	}
+
 	return nil
 }
+
+func reportPullRequestVersions(environment *workflow.Environment, options PurgeOptions, result ghcr.PurgeResult) {
+	if environment.Output == nil {
+		return
+	}
+
+	candidateVersions := map[int64]struct{}{}
+	keptVersions := map[int64]struct{}{}
+	for _, taggedVersion := range result.PullRequestVersions {
+		if !taggedVersion.Candidate {
+			keptVersions[taggedVersion.VersionID] = struct{}{}
+			continue
+		}
+		candidateVersions[taggedVersion.VersionID] = struct{}{}
+		if options.DryRun {
+			fmt.Fprintf(environment.Output, pullRequestDeletePlanTemplate, options.Owner, options.PackageName, taggedVersion.Tag, taggedVersion.PullRequestNumber, taggedVersion.State, taggedVersion.VersionID)
+		}
+	}
+	fmt.Fprintf(environment.Output, pullRequestSummaryTemplate, options.Owner, options.PackageName, len(candidateVersions), len(keptVersions))
+}
+
+func reportApprovalRefusals(environment *workflow.Environment, refusals []ApprovalRefusal) {
+	if environment.Errors == nil {
+		return
+	}
+	for _, refusal := range refusals {
+		fmt.Fprintf(environment.Errors, approvalRefusedTemplateConstant, refusal.Owner, refusal.PackageName, refusal.Digest)
+	}
+}
+
+func describeLabelFilters(labelFilters []ghcr.LabelFilter) string {
+	descriptions := make([]string, 0, len(labelFilters))
+	for _, labelFilter := range labelFilters {
+		descriptions = append(descriptions, labelFilter.String())
+	}
+	return strings.Join(descriptions, labelFilterListSeparator)
+}
+
+func reportPurgeFailures(environment *workflow.Environment, partialPurgeError PartialPurgeError) {
+	if environment.Errors == nil {
+		return
+	}
+	for _, failure := range partialPurgeError.Failures {
+		fmt.Fprintf(environment.Errors, versionDeleteFailedTemplate, failure.Owner, failure.PackageName, failure.VersionID, failure.Digest, failure.StatusCode, failure.Message)
+	}
+}
+
+func reportReclaimedStorage(environment *workflow.Environment, storageTally *StorageTally, options PurgeOptions, result ghcr.PurgeResult) {
+	if result.ReclaimableBytes <= 0 {
+		return
+	}
+	storageTally.Add(options.Owner, options.PackageName, result.ReclaimableBytes)
+	if environment.Output == nil {
+		return
+	}
+
+	template := reclaimedTemplate
+	if options.DryRun {
+		template = reclaimPlanTemplate
+	}
+	fmt.Fprintf(environment.Output, template, options.Owner, options.PackageName, utils.FormatByteSize(result.ReclaimableBytes), result.ReclaimableBytes)
+}
+
+func reportEntirePackageDeletion(environment *workflow.Environment, options PurgeOptions, result ghcr.PurgeResult) {
+	if environment.Output == nil {
+		return
+	}
+
+	switch {
+	case options.DryRun:
+		fmt.Fprintf(environment.Output, packageDeletePlanTemplate, options.Owner, options.PackageName, result.TotalVersions, result.TaggedVersions)
+	case result.DeletedPackages > 0:
+		fmt.Fprintf(environment.Output, packageDeletedTemplate, options.Owner, options.PackageName, result.TotalVersions)
+	default:
+		fmt.Fprintf(environment.Output, packageDeleteSkipTemplate, options.Owner, options.PackageName)
+	}
+}
@@ -0,0 +1,344 @@
+package packages
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/ghcr"
+	"github.com/temirov/gix/internal/ui"
+	"github.com/temirov/gix/internal/utils"
+	"github.com/temirov/gix/internal/workflow"
+)
+
+type resultPurgeExecutor struct {
+	result ghcr.PurgeResult
+}
+
+func (executor resultPurgeExecutor) Execute(context.Context, PurgeOptions) (ghcr.PurgeResult, error) {
+	return executor.result, nil
+}
+
+type capturingPurgeExecutor struct {
+	result  ghcr.PurgeResult
+	options *PurgeOptions
+}
+
+func (executor capturingPurgeExecutor) Execute(_ context.Context, options PurgeOptions) (ghcr.PurgeResult, error) {
+	*executor.options = options
+	return executor.result, nil
+}
+
+type staticMetadataResolver struct{}
+
+func (staticMetadataResolver) ResolveMetadata(context.Context, string) (RepositoryMetadata, error) {
+	return RepositoryMetadata{Owner: "acme", OwnerType: ghcr.OrganizationOwnerType, DefaultPackageName: "service", Repository: "acme/service"}, nil
+}
+
+func TestPackagesPurgeActionSummarizesRetainedVersions(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		result         ghcr.PurgeResult
+		expectedOutput string
+	}{
+		{
+			name:           "retained_versions_explained",
+			result:         ghcr.PurgeResult{UntaggedVersions: 3, DeletedVersions: 2, RetainedVersions: 1},
+			expectedOutput: "PACKAGES-RETAINED: acme/service kept 1 version(s) GHCR refuses to delete because each is the package's last tagged version\n",
+		},
+		{
+			name:           "nothing_retained",
+			result:         ghcr.PurgeResult{UntaggedVersions: 2, DeletedVersions: 2},
+			expectedOutput: "",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			outputBuffer := &bytes.Buffer{}
+			environment := &workflow.Environment{Output: outputBuffer}
+			repository := &workflow.RepositoryState{Path: "/tmp/service"}
+
+			actionError := handlePackagesPurgeAction(context.Background(), environment, repository, map[string]any{
+				"service":           resultPurgeExecutor{result: testCase.result},
+				"metadata_resolver": staticMetadataResolver{},
+				"token_source":      TokenSourceConfiguration{},
+			})
+			require.NoError(subtest, actionError)
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+		})
+	}
+}
+
+func TestPackagesPurgeActionReportsEntirePackageDeletion(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		result         ghcr.PurgeResult
+		dryRun         bool
+		expectedOutput string
+	}{
+		{
+			name:           "dry_run_plan",
+			result:         ghcr.PurgeResult{TotalVersions: 4, TaggedVersions: 1, UntaggedVersions: 3},
+			dryRun:         true,
+			expectedOutput: "PLAN-PACKAGE-DELETE: acme/service entire package (4 version(s), 1 tagged)\n",
+		},
+		{
+			name:           "package_deleted",
+			result:         ghcr.PurgeResult{TotalVersions: 4, UntaggedVersions: 4, DeletedPackages: 1},
+			expectedOutput: "PACKAGE-DELETED: acme/service entire package removed (4 version(s))\n",
+		},
+		{
+			name:           "confirmation_declined",
+			result:         ghcr.PurgeResult{TotalVersions: 4, UntaggedVersions: 4},
+			expectedOutput: "PACKAGE-DELETE-SKIP: acme/service confirmation phrase did not match\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			outputBuffer := &bytes.Buffer{}
+			environment := &workflow.Environment{Output: outputBuffer}
+			repository := &workflow.RepositoryState{Path: "/tmp/service"}
+
+			actionError := handlePackagesPurgeAction(context.Background(), environment, repository, map[string]any{
+				"service":           resultPurgeExecutor{result: testCase.result},
+				"metadata_resolver": staticMetadataResolver{},
+				"token_source":      TokenSourceConfiguration{},
+				"dry_run":           testCase.dryRun,
+				"entire_package":    true,
+			})
+			require.NoError(subtest, actionError)
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+		})
+	}
+}
+
+func TestPackagesPurgeActionReportsReclaimableStorage(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		result         ghcr.PurgeResult
+		dryRun         bool
+		entirePackage  bool
+		expectedOutput string
+		expectedBytes  int64
+	}{
+		{
+			name:           "dry_run_estimate",
+			result:         ghcr.PurgeResult{UntaggedVersions: 2, ReclaimableBytes: 3 * 1024 * 1024},
+			dryRun:         true,
+			expectedOutput: "PLAN-PACKAGES-RECLAIM: acme/service would free 3.0 MiB (3145728 bytes)\n",
+			expectedBytes:  3 * 1024 * 1024,
+		},
+		{
+			name:           "real_run_reclaimed",
+			result:         ghcr.PurgeResult{UntaggedVersions: 1, DeletedVersions: 1, ReclaimableBytes: 2048},
+			expectedOutput: "PACKAGES-RECLAIMED: acme/service freed 2.0 KiB (2048 bytes)\n",
+			expectedBytes:  2048,
+		},
+		{
+			name:           "declined_entire_package_reclaims_nothing",
+			result:         ghcr.PurgeResult{TotalVersions: 1, ReclaimableBytes: 2048},
+			entirePackage:  true,
+			expectedOutput: "PACKAGE-DELETE-SKIP: acme/service confirmation phrase did not match\n",
+			expectedBytes:  0,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			outputBuffer := &bytes.Buffer{}
+			environment := &workflow.Environment{Output: outputBuffer}
+			repository := &workflow.RepositoryState{Path: "/tmp/service"}
+			storageTally := &StorageTally{}
+
+			actionError := handlePackagesPurgeAction(context.Background(), environment, repository, map[string]any{
+				"service":           resultPurgeExecutor{result: testCase.result},
+				"metadata_resolver": staticMetadataResolver{},
+				"token_source":      TokenSourceConfiguration{},
+				"dry_run":           testCase.dryRun,
+				"entire_package":    testCase.entirePackage,
+				"storage_tally":     storageTally,
+			})
+			require.NoError(subtest, actionError)
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+			_, totalBytes := storageTally.Totals()
+			require.Equal(subtest, testCase.expectedBytes, totalBytes)
+
+			notification := buildPurgeNotification(testCase.dryRun, storageTally, nil, nil)
+			require.Equal(subtest, testCase.expectedBytes, notification.ReclaimedBytes)
+			expectedPackages := []PurgeNotificationPackage{}
+			if testCase.expectedBytes > 0 {
+				expectedPackages = append(expectedPackages, PurgeNotificationPackage{Owner: "acme", PackageName: "service", ReclaimedBytes: testCase.expectedBytes, ReclaimedSize: utils.FormatByteSize(testCase.expectedBytes)})
+			}
+			require.Equal(subtest, expectedPackages, notification.Packages)
+		})
+	}
+}
+
+type partialPurgeExecutor struct {
+	result ghcr.PurgeResult
+}
+
+func (executor partialPurgeExecutor) Execute(_ context.Context, options PurgeOptions) (ghcr.PurgeResult, error) {
+	return executor.result, newPartialPurgeError(options.Owner, options.PackageName, executor.result.Failures)
+}
+
+func TestPackagesPurgeActionReportsFailedDeletions(testInstance *testing.T) {
+	failedResult := ghcr.PurgeResult{
+		UntaggedVersions: 2,
+		DeletedVersions:  1,
+		Failures:         []ghcr.VersionDeletionFailure{{VersionID: 7, Digest: "sha256:abc", StatusCode: 500, Message: "boom"}},
+	}
+	expectedFailure := PurgeFailure{Owner: "acme", PackageName: "service", VersionDeletionFailure: failedResult.Failures[0]}
+
+	testCases := []struct {
+		name             string
+		useTally         bool
+		expectError      bool
+		expectedFailures []PurgeFailure
+	}{
+		{
+			name:             "tally_collects_failures",
+			useTally:         true,
+			expectedFailures: []PurgeFailure{expectedFailure},
+		},
+		{
+			name:        "without_tally_returns_partial_error",
+			expectError: true,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			errorBuffer := &bytes.Buffer{}
+			environment := &workflow.Environment{Output: &bytes.Buffer{}, Errors: errorBuffer}
+			repository := &workflow.RepositoryState{Path: "/tmp/service"}
+			parameters := map[string]any{
+				"service":           partialPurgeExecutor{result: failedResult},
+				"metadata_resolver": staticMetadataResolver{},
+				"token_source":      TokenSourceConfiguration{},
+			}
+			failureTally := &PurgeFailureTally{}
+			if testCase.useTally {
+				parameters["failure_tally"] = failureTally
+			}
+
+			actionError := handlePackagesPurgeAction(context.Background(), environment, repository, parameters)
+			require.Equal(subtest, "PACKAGES-DELETE-FAILED: acme/service version=7 digest=sha256:abc status=500 message=boom\n", errorBuffer.String())
+			if testCase.expectError {
+				var partialPurgeError PartialPurgeError
+				require.ErrorAs(subtest, actionError, &partialPurgeError)
+				require.Equal(subtest, 2, partialPurgeError.ProcessExitCode())
+				return
+			}
+			require.NoError(subtest, actionError)
+			require.Equal(subtest, testCase.expectedFailures, failureTally.Records())
+		})
+	}
+}
+
+func TestPackagesPurgeActionAnnotatesFailedDeletions(testInstance *testing.T) {
+	testCases := []struct {
+		name               string
+		failure            ghcr.VersionDeletionFailure
+		githubActions      bool
+		expectedAnnotation string
+	}{
+		{
+			name:               "error_annotation",
+			failure:            ghcr.VersionDeletionFailure{VersionID: 7, Digest: "sha256:abc", StatusCode: 500, Message: "boom"},
+			githubActions:      true,
+			expectedAnnotation: "::error title=repo packages delete::acme/service version 7 (sha256:abc) was not deleted: status 500 boom\n",
+		},
+		{
+			name:               "rate_limit_warning",
+			failure:            ghcr.VersionDeletionFailure{VersionID: 8, Digest: "sha256:def", StatusCode: 403, Message: "API rate limit exceeded"},
+			githubActions:      true,
+			expectedAnnotation: "::warning title=repo packages delete rate limit::acme/service version 8 (sha256:def) was not deleted: status 403 API rate limit exceeded\n",
+		},
+		{
+			name:    "outside_github_actions",
+			failure: ghcr.VersionDeletionFailure{VersionID: 7, Digest: "sha256:abc", StatusCode: 500, Message: "boom"},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			annotationBuffer := &bytes.Buffer{}
+			environmentLookup := func(key string) (string, bool) {
+				return "true", testCase.githubActions && key == ui.GitHubActionsEnvironmentVariable
+			}
+			environment := &workflow.Environment{Output: &bytes.Buffer{}, Errors: &bytes.Buffer{}}
+			parameters := map[string]any{
+				"service":                       partialPurgeExecutor{result: ghcr.PurgeResult{Failures: []ghcr.VersionDeletionFailure{testCase.failure}}},
+				"metadata_resolver":             staticMetadataResolver{},
+				"token_source":                  TokenSourceConfiguration{},
+				"failure_tally":                 &PurgeFailureTally{},
+				annotationParameterNameConstant: ui.NewAnnotationEmitter(annotationBuffer, environmentLookup, false),
+			}
+
+			actionError := handlePackagesPurgeAction(context.Background(), environment, &workflow.RepositoryState{Path: "/tmp/service"}, parameters)
+			require.NoError(subtest, actionError)
+			require.Equal(subtest, testCase.expectedAnnotation, annotationBuffer.String())
+		})
+	}
+}
+
+func TestPackagesPurgeActionReportsPullRequestVersions(testInstance *testing.T) {
+	pullRequestResult := ghcr.PurgeResult{
+		TotalVersions:   3,
+		DeletedVersions: 1,
+		PullRequestVersions: []ghcr.PullRequestTaggedVersion{
+			{VersionID: 11, Tag: "pr-7", PullRequestNumber: 7, State: ghcr.PullRequestStateMerged, Candidate: true},
+			{VersionID: 12, Tag: "pr-8", PullRequestNumber: 8, State: ghcr.PullRequestStateOpen},
+			{VersionID: 13, Tag: "pr-9", PullRequestNumber: 9, State: ghcr.PullRequestStateUnknown},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		dryRun         bool
+		expectedOutput string
+	}{
+		{
+			name:   "dry_run_lists_candidates",
+			dryRun: true,
+			expectedOutput: "PLAN-PACKAGES-PR-DELETE: acme/service tag=pr-7 -> #7 (merged) version=11\n" +
+				"PACKAGES-PR-TAGS: acme/service 1 version(s) from closed or merged pull requests, 2 kept for open or unknown pull requests\n",
+		},
+		{
+			name:           "execution_summarizes",
+			expectedOutput: "PACKAGES-PR-TAGS: acme/service 1 version(s) from closed or merged pull requests, 2 kept for open or unknown pull requests\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			outputBuffer := &bytes.Buffer{}
+			environment := &workflow.Environment{Output: outputBuffer}
+			repository := &workflow.RepositoryState{Path: "/tmp/service"}
+			capturedOptions := PurgeOptions{}
+
+			actionError := handlePackagesPurgeAction(context.Background(), environment, repository, map[string]any{
+				"service":           capturingPurgeExecutor{result: pullRequestResult, options: &capturedOptions},
+				"metadata_resolver": staticMetadataResolver{},
+				"token_source":      TokenSourceConfiguration{},
+				"dry_run":           testCase.dryRun,
+				"pr_tag_prefix":     "pr-",
+			})
+			require.NoError(subtest, actionError)
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+			require.Equal(subtest, "pr-", capturedOptions.PullRequestTagPrefix)
+			require.Equal(subtest, "acme/service", capturedOptions.PullRequestRepository)
+		})
+	}
+}
@@ -12,6 +12,7 @@ const (
 	tokenSourceSeparatorConstant               = ":"
 	environmentTokenSourceTypeValueConstant    = "env"
 	fileTokenSourceTypeValueConstant           = "file"
+	configuredTokenSourceTypeValueConstant     = "configured"
 	tokenSourceMissingErrorMessageConstant     = "token source must be provided"
 	environmentNameMissingErrorMessageConstant = "environment variable name must be provided"
 	filePathMissingErrorMessageConstant        = "token file path must be provided"
@@ -30,6 +31,7 @@ type TokenSourceType string
 const (
 	TokenSourceTypeEnvironment TokenSourceType = TokenSourceType(environmentTokenSourceTypeValueConstant)
 	TokenSourceTypeFile        TokenSourceType = TokenSourceType(fileTokenSourceTypeValueConstant)
+	TokenSourceTypeConfigured  TokenSourceType = TokenSourceType(configuredTokenSourceTypeValueConstant)
 )
 
 // TokenSourceConfiguration specifies how to locate a credentials token.
@@ -101,6 +103,16 @@ func ParseTokenSource(sourceValue string) (TokenSourceConfiguration, error) {
 	}
 }
 
+// ResolveConfiguredTokenSource selects the token source for packages commands. A token supplied through configuration,
+// directly or through a token_file key, takes precedence over the GITHUB_PACKAGES_TOKEN environment variable.
+func ResolveConfiguredTokenSource(configuredToken string) (TokenSourceConfiguration, error) {
+	trimmedToken := strings.TrimSpace(configuredToken)
+	if len(trimmedToken) > 0 {
+		return TokenSourceConfiguration{Type: TokenSourceTypeConfigured, Reference: trimmedToken}, nil
+	}
+	return ParseTokenSource(defaultTokenSourceValueConstant)
+}
+
 type tokenResolver struct {
 	environmentLookup EnvironmentLookup
 	fileReader        FileReader
@@ -135,6 +147,12 @@ func (resolver *tokenResolver) ResolveToken(resolutionContext context.Context, s
 			return "", fmt.Errorf(fileTokenEmptyErrorTemplateConstant, source.Reference)
 		}
 		return trimmedValue, nil
+	case TokenSourceTypeConfigured:
+		trimmedValue := strings.TrimSpace(source.Reference)
+		if len(trimmedValue) == 0 {
+			return "", errors.New(tokenSourceMissingErrorMessageConstant)
+		}
+		return trimmedValue, nil
 	default:
 		return "", fmt.Errorf(unsupportedTokenSourceTemplateConstant, source.Type)
 	}
@@ -136,6 +136,11 @@ func TestTokenResolverResolvesValues(testingInstance *testing.T) {
 			configuration: packages.TokenSourceConfiguration{Type: packages.TokenSourceTypeFile, Reference: "/tmp/empty.txt"},
 			expectError:   true,
 		},
+		{
+			name:          "configured_success",
+			configuration: packages.TokenSourceConfiguration{Type: packages.TokenSourceTypeConfigured, Reference: " configured-token "},
+			expected:      "configured-token",
+		},
 	}
 
 	fileContents["/tmp/empty.txt"] = []byte("   \n")
@@ -157,3 +162,15 @@ func TestTokenResolverResolvesValues(testingInstance *testing.T) {
 		})
 	}
 }
+
+func TestResolveConfiguredTokenSourcePrefersConfiguredToken(testingInstance *testing.T) {
+	testingInstance.Parallel()
+
+	configuredSource, configuredError := packages.ResolveConfiguredTokenSource("configured-token")
+	require.NoError(testingInstance, configuredError)
+	require.Equal(testingInstance, packages.TokenSourceConfiguration{Type: packages.TokenSourceTypeConfigured, Reference: "configured-token"}, configuredSource)
+
+	defaultSource, defaultError := packages.ResolveConfiguredTokenSource("  ")
+	require.NoError(testingInstance, defaultError)
+	require.Equal(testingInstance, packages.TokenSourceConfiguration{Type: packages.TokenSourceTypeEnvironment, Reference: "GITHUB_PACKAGES_TOKEN"}, defaultSource)
+}
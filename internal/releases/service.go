@@ -99,6 +99,7 @@ func (service *Service) Release(executionContext context.Context, options Option
 		Arguments:            []string{gitTagSubcommandConstant, gitTagAnnotatedFlagConstant, tagName, gitTagMessageFlagConstant, message},
 		WorkingDirectory:     repositoryPath,
 		EnvironmentVariables: environment,
+		Idempotent:           false,
 	}); err != nil {
 		return Result{}, fmt.Errorf(annotateTagFailureTemplateConstant, tagName, err)
 	}
@@ -107,6 +108,7 @@ func (service *Service) Release(executionContext context.Context, options Option
 		Arguments:            []string{gitPushSubcommandConstant, remoteName, tagName},
 		WorkingDirectory:     repositoryPath,
 		EnvironmentVariables: environment,
+		Idempotent:           false,
 	}); err != nil {
 		return Result{}, fmt.Errorf(pushTagFailureTemplateConstant, tagName, remoteName, err)
 	}
@@ -1,12 +1,15 @@
 package dependencies
 
 import (
+	"io"
+
 	"github.com/temirov/gix/internal/execshell"
 	"github.com/temirov/gix/internal/githubcli"
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/discovery"
 	"github.com/temirov/gix/internal/repos/filesystem"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
 	"go.uber.org/zap"
 )
 
@@ -37,6 +40,11 @@ func ResolveGitExecutor(existing shared.GitExecutor, logger *zap.Logger, humanRe
 	if creationError != nil {
 		return nil, creationError
 	}
+	retryPolicy := execshell.DefaultRetryPolicy()
+	retryPolicy.FailureClassifiers = map[execshell.CommandName]execshell.FailureClassifier{
+		execshell.CommandGitHub: githubcli.ClassifyTransientFailure,
+	}
+	shellExecutor.SetRetryPolicy(retryPolicy)
 	return shellExecutor, nil
 }
 
@@ -55,3 +63,12 @@ func ResolveGitHubResolver(existing shared.GitHubMetadataResolver, executor shar
 	}
 	return githubcli.NewClient(executor)
 }
+
+// ResolveOutputReporter returns a Reporter for executor events written to writer.
+// Structured output emits each event as a JSON log line using the structured log schema; otherwise lines are written verbatim.
+func ResolveOutputReporter(writer io.Writer, structuredOutput bool) shared.Reporter {
+	if !structuredOutput {
+		return shared.NewWriterReporter(writer)
+	}
+	return shared.NewEventReporter(utils.NewStructuredEventLogger(writer))
+}
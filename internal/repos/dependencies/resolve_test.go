@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
 	"github.com/temirov/gix/internal/githubcli"
 	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/dependencies"
@@ -39,7 +40,7 @@ func TestResolveFileSystem(t *testing.T) {
 func TestResolveGitExecutor(t *testing.T) {
 	t.Parallel()
 
-	existing := stubGitExecutor{}
+	existing := execshelltest.NewPermissiveExecutor()
 	reused, reuseError := dependencies.ResolveGitExecutor(existing, nil, false)
 	require.NoError(t, reuseError)
 	require.Equal(t, existing, reused)
@@ -60,7 +61,7 @@ func TestResolveGitRepositoryManager(t *testing.T) {
 	require.NoError(t, reuseError)
 	require.Equal(t, existing, reused)
 
-	manager, managerError := dependencies.ResolveGitRepositoryManager(nil, stubGitExecutor{})
+	manager, managerError := dependencies.ResolveGitRepositoryManager(nil, execshelltest.NewPermissiveExecutor())
 	require.NoError(t, managerError)
 	require.IsType(t, &gitrepo.RepositoryManager{}, manager)
 
@@ -76,7 +77,7 @@ func TestResolveGitHubResolver(t *testing.T) {
 	require.NoError(t, resolveError)
 	require.Equal(t, existing, resolved)
 
-	resolver, resolverError := dependencies.ResolveGitHubResolver(nil, stubGitExecutor{})
+	resolver, resolverError := dependencies.ResolveGitHubResolver(nil, execshelltest.NewPermissiveExecutor())
 	require.NoError(t, resolverError)
 	require.IsType(t, &githubcli.Client{}, resolver)
 
@@ -84,16 +85,6 @@ func TestResolveGitHubResolver(t *testing.T) {
 	require.ErrorIs(t, executorError, githubcli.ErrExecutorNotConfigured)
 }
 
-type stubGitExecutor struct{}
-
-func (stubGitExecutor) ExecuteGit(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return execshell.ExecutionResult{}, nil
-}
-
-func (stubGitExecutor) ExecuteGitHubCLI(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return execshell.ExecutionResult{}, nil
-}
-
 type stubRepositoryManager struct{}
 
 func (stubRepositoryManager) CheckCleanWorktree(executionContext context.Context, repositoryPath string) (bool, error) {
@@ -0,0 +1,104 @@
+package discovery
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	bareHeadFileNameConstant         = "HEAD"
+	bareObjectsDirectoryNameConstant = "objects"
+	bareRefsDirectoryNameConstant    = "refs"
+	bareConfigFileNameConstant       = "config"
+	gitConfigCoreSectionConstant     = "[core]"
+	gitConfigSectionPrefixConstant   = "["
+	gitConfigBareKeyConstant         = "bare"
+	gitConfigAssignmentConstant      = "="
+	gitConfigTrueValueConstant       = "true"
+)
+
+// IsBareRepository reports whether the directory is itself a git directory whose configuration sets core.bare=true,
+// as bare clones and --mirror clones do.
+func IsBareRepository(directoryPath string) bool {
+	headInfo, headError := os.Stat(filepath.Join(directoryPath, bareHeadFileNameConstant))
+	if headError != nil || !headInfo.Mode().IsRegular() {
+		return false
+	}
+	for _, requiredDirectory := range []string{bareObjectsDirectoryNameConstant, bareRefsDirectoryNameConstant} {
+		directoryInfo, directoryError := os.Stat(filepath.Join(directoryPath, requiredDirectory))
+		if directoryError != nil || !directoryInfo.IsDir() {
+			return false
+		}
+	}
+	return configurationDeclaresBare(filepath.Join(directoryPath, bareConfigFileNameConstant))
+}
+
+func configurationDeclaresBare(configurationPath string) bool {
+	configurationFile, openError := os.Open(configurationPath)
+	if openError != nil {
+		return false
+	}
+	defer configurationFile.Close()
+
+	inCoreSection := false
+	scanner := bufio.NewScanner(configurationFile)
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if strings.HasPrefix(line, gitConfigSectionPrefixConstant) {
+			inCoreSection = line == gitConfigCoreSectionConstant
+			continue
+		}
+		if !inCoreSection {
+			continue
+		}
+		key, value, hasValue := strings.Cut(line, gitConfigAssignmentConstant)
+		if !hasValue || strings.TrimSpace(key) != gitConfigBareKeyConstant {
+			continue
+		}
+		return strings.TrimSpace(value) == gitConfigTrueValueConstant
+	}
+	return false
+}
+
+// DiscoverBareRepositories walks the provided roots and returns bare repositories. Worktree metadata directories are
+// not descended into, and a bare repository's own contents are not searched for further repositories.
+func DiscoverBareRepositories(roots []string) ([]string, error) {
+	seen := make(map[string]struct{})
+	var repositories []string
+
+	for _, root := range roots {
+		normalizedRoot, normalizationError := filepath.Abs(root)
+		if normalizationError != nil {
+			return nil, normalizationError
+		}
+
+		walkError := filepath.WalkDir(normalizedRoot, func(path string, directoryEntry fs.DirEntry, walkError error) error {
+			if walkError != nil || !directoryEntry.IsDir() {
+				return nil
+			}
+			if directoryEntry.Name() == gitMetadataDirectoryNameConstant {
+				return fs.SkipDir
+			}
+			if !IsBareRepository(path) {
+				return nil
+			}
+
+			repositoryPath := filepath.Clean(path)
+			if _, alreadySeen := seen[repositoryPath]; !alreadySeen {
+				seen[repositoryPath] = struct{}{}
+				repositories = append(repositories, repositoryPath)
+			}
+			return fs.SkipDir
+		})
+		if walkError != nil {
+			return nil, walkError
+		}
+	}
+
+	sort.Strings(repositories)
+	return repositories, nil
+}
@@ -0,0 +1,65 @@
+package discovery_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/repos/discovery"
+)
+
+const (
+	bareConfigurationContent     = "[core]\n\trepositoryformatversion = 0\n\tbare = true\n[remote \"origin\"]\n\turl = https://github.com/octocat/sample.git\n\tmirror = true\n"
+	worktreeConfigurationContent = "[core]\n\trepositoryformatversion = 0\n\tbare = false\n"
+	bareRemoteOnlyConfiguration  = "[core]\n\trepositoryformatversion = 0\n[remote \"origin\"]\n\tbare = true\n"
+)
+
+func createGitDirectory(testInstance *testing.T, directoryPath string, configurationContent string) {
+	testInstance.Helper()
+	for _, directoryName := range []string{"objects", "refs"} {
+		require.NoError(testInstance, os.MkdirAll(filepath.Join(directoryPath, directoryName), repositoryDirectoryPermissions))
+	}
+	require.NoError(testInstance, os.WriteFile(filepath.Join(directoryPath, "HEAD"), []byte("ref: refs/heads/main\n"), 0o644))
+	require.NoError(testInstance, os.WriteFile(filepath.Join(directoryPath, "config"), []byte(configurationContent), 0o644))
+}
+
+func TestIsBareRepository(testInstance *testing.T) {
+	testCases := []struct {
+		name                 string
+		configurationContent string
+		skipObjects          bool
+		expectedBare         bool
+	}{
+		{name: "mirror_clone", configurationContent: bareConfigurationContent, expectedBare: true},
+		{name: "worktree_git_directory", configurationContent: worktreeConfigurationContent},
+		{name: "bare_key_outside_core_section", configurationContent: bareRemoteOnlyConfiguration},
+		{name: "missing_objects_directory", configurationContent: bareConfigurationContent, skipObjects: true},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			repositoryPath := filepath.Join(subtest.TempDir(), "sample.git")
+			createGitDirectory(subtest, repositoryPath, testCase.configurationContent)
+			if testCase.skipObjects {
+				require.NoError(subtest, os.RemoveAll(filepath.Join(repositoryPath, "objects")))
+			}
+
+			require.Equal(subtest, testCase.expectedBare, discovery.IsBareRepository(repositoryPath))
+		})
+	}
+}
+
+func TestDiscoverBareRepositories(testInstance *testing.T) {
+	rootDirectory := testInstance.TempDir()
+	mirrorPath := filepath.Join(rootDirectory, "backups", "sample.git")
+	createGitDirectory(testInstance, mirrorPath, bareConfigurationContent)
+	createGitDirectory(testInstance, filepath.Join(mirrorPath, "nested.git"), bareConfigurationContent)
+	createGitDirectory(testInstance, filepath.Join(rootDirectory, "work", "sample", gitMetadataDirectoryName), worktreeConfigurationContent)
+
+	repositories, discoveryError := discovery.DiscoverBareRepositories([]string{rootDirectory})
+	require.NoError(testInstance, discoveryError)
+	require.Equal(testInstance, []string{mirrorPath}, repositories)
+}
@@ -0,0 +1,86 @@
+package discovery
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const parentDirectoryReferenceConstant = ".."
+
+// ContainmentRelationship pairs a repository with the nearest discovered repository whose working tree contains it.
+type ContainmentRelationship struct {
+	ParentPath string
+	ChildPath  string
+}
+
+// Containment describes how discovered repositories nest inside one another.
+type Containment struct {
+	Relationships []ContainmentRelationship
+	parentPaths   map[string]struct{}
+}
+
+// DetectContainment links every repository path to its nearest containing repository path.
+// Relationships are ordered by parent path and then child path.
+func DetectContainment(repositoryPaths []string) Containment {
+	cleanedPaths := make([]string, 0, len(repositoryPaths))
+	seenPaths := make(map[string]struct{}, len(repositoryPaths))
+	for _, repositoryPath := range repositoryPaths {
+		trimmedPath := strings.TrimSpace(repositoryPath)
+		if len(trimmedPath) == 0 {
+			continue
+		}
+		cleanedPath := filepath.Clean(trimmedPath)
+		if _, alreadySeen := seenPaths[cleanedPath]; alreadySeen {
+			continue
+		}
+		seenPaths[cleanedPath] = struct{}{}
+		cleanedPaths = append(cleanedPaths, cleanedPath)
+	}
+
+	containment := Containment{parentPaths: make(map[string]struct{})}
+	for _, childPath := range cleanedPaths {
+		nearestParent := ""
+		for _, candidateParent := range cleanedPaths {
+			if !isContainedPath(candidateParent, childPath) {
+				continue
+			}
+			if len(candidateParent) > len(nearestParent) {
+				nearestParent = candidateParent
+			}
+		}
+		if len(nearestParent) == 0 {
+			continue
+		}
+		containment.Relationships = append(containment.Relationships, ContainmentRelationship{ParentPath: nearestParent, ChildPath: childPath})
+		containment.parentPaths[nearestParent] = struct{}{}
+	}
+
+	sort.Slice(containment.Relationships, func(firstIndex int, secondIndex int) bool {
+		first := containment.Relationships[firstIndex]
+		second := containment.Relationships[secondIndex]
+		if first.ParentPath == second.ParentPath {
+			return first.ChildPath < second.ChildPath
+		}
+		return first.ParentPath < second.ParentPath
+	})
+
+	return containment
+}
+
+// HasNestedRepositories reports whether another discovered repository lives inside the provided repository path.
+func (containment Containment) HasNestedRepositories(repositoryPath string) bool {
+	if containment.parentPaths == nil {
+		return false
+	}
+	_, isParent := containment.parentPaths[filepath.Clean(strings.TrimSpace(repositoryPath))]
+	return isParent
+}
+
+func isContainedPath(parentPath string, childPath string) bool {
+	relativePath, relativeError := filepath.Rel(parentPath, childPath)
+	if relativeError != nil || relativePath == "." {
+		return false
+	}
+	return relativePath != parentDirectoryReferenceConstant && !strings.HasPrefix(relativePath, parentDirectoryReferenceConstant+string(filepath.Separator))
+}
@@ -0,0 +1,64 @@
+package discovery_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/repos/discovery"
+)
+
+func TestDetectContainment(testInstance *testing.T) {
+	testCases := []struct {
+		name                  string
+		repositoryPaths       []string
+		expectedRelationships []discovery.ContainmentRelationship
+		expectedParents       []string
+		expectedLeaves        []string
+	}{
+		{
+			name:            "siblings_are_not_nested",
+			repositoryPaths: []string{"/work/alpha", "/work/beta", "/work/alphabet"},
+			expectedLeaves:  []string{"/work/alpha", "/work/beta", "/work/alphabet"},
+		},
+		{
+			name:            "nearest_parent_is_reported",
+			repositoryPaths: []string{"/work/outer/inner/deepest", "/work/outer", "/work/outer/inner", "/work/outer/other"},
+			expectedRelationships: []discovery.ContainmentRelationship{
+				{ParentPath: "/work/outer", ChildPath: "/work/outer/inner"},
+				{ParentPath: "/work/outer", ChildPath: "/work/outer/other"},
+				{ParentPath: "/work/outer/inner", ChildPath: "/work/outer/inner/deepest"},
+			},
+			expectedParents: []string{"/work/outer", "/work/outer/inner", "/work/outer/"},
+			expectedLeaves:  []string{"/work/outer/inner/deepest", "/work/outer/other"},
+		},
+		{
+			name:            "duplicates_and_blank_paths_ignored",
+			repositoryPaths: []string{"/work/outer", "/work/outer/", " ", "/work/outer/inner"},
+			expectedRelationships: []discovery.ContainmentRelationship{
+				{ParentPath: "/work/outer", ChildPath: "/work/outer/inner"},
+			},
+			expectedParents: []string{"/work/outer"},
+			expectedLeaves:  []string{"/work/outer/inner", ""},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			containment := discovery.DetectContainment(testCase.repositoryPaths)
+			require.Equal(subtest, testCase.expectedRelationships, containment.Relationships)
+			for _, parentPath := range testCase.expectedParents {
+				require.True(subtest, containment.HasNestedRepositories(parentPath), parentPath)
+			}
+			for _, leafPath := range testCase.expectedLeaves {
+				require.False(subtest, containment.HasNestedRepositories(leafPath), leafPath)
+			}
+		})
+	}
+}
+
+func TestContainmentZeroValue(testInstance *testing.T) {
+	var containment discovery.Containment
+	require.Empty(testInstance, containment.Relationships)
+	require.False(testInstance, containment.HasNestedRepositories("/work/outer"))
+}
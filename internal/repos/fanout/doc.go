@@ -0,0 +1,3 @@
+// Package fanout runs one command in every discovered repository, with bounded concurrency and per-repository output
+// prefixes. ForEach exposes the bounded worker pool to other per-repository work.
+package fanout
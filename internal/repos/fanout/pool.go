@@ -0,0 +1,24 @@
+package fanout
+
+import "sync"
+
+// ForEach calls work once for every index below count, running at most jobs calls at once, and returns after the last
+// call finished. Jobs below one run the calls one at a time.
+func ForEach(count int, jobs int, work func(index int)) {
+	if jobs < minimumJobsConstant {
+		jobs = minimumJobsConstant
+	}
+
+	slots := make(chan struct{}, jobs)
+	var waitGroup sync.WaitGroup
+	for index := 0; index < count; index++ {
+		slots <- struct{}{}
+		waitGroup.Add(1)
+		go func(index int) {
+			defer waitGroup.Done()
+			defer func() { <-slots }()
+			work(index)
+		}(index)
+	}
+	waitGroup.Wait()
+}
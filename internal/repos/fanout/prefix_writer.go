@@ -0,0 +1,57 @@
+package fanout
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+const prefixedLineTerminatorConstant = '\n'
+
+// prefixedLineWriter forwards complete lines to a shared destination, each preceded by the repository prefix. The
+// mutex is shared by every writer of a run so lines from concurrent repositories never interleave mid-line.
+type prefixedLineWriter struct {
+	mutex       *sync.Mutex
+	destination io.Writer
+	prefix      string
+	pending     []byte
+}
+
+func newPrefixedLineWriter(mutex *sync.Mutex, destination io.Writer, prefix string) *prefixedLineWriter {
+	return &prefixedLineWriter{mutex: mutex, destination: destination, prefix: prefix}
+}
+
+// Write buffers partial lines and writes every complete line with the prefix.
+func (writer *prefixedLineWriter) Write(data []byte) (int, error) {
+	writer.pending = append(writer.pending, data...)
+	for {
+		lineEnd := bytes.IndexByte(writer.pending, prefixedLineTerminatorConstant)
+		if lineEnd < 0 {
+			return len(data), nil
+		}
+		if writeError := writer.writeLine(writer.pending[:lineEnd+1]); writeError != nil {
+			return 0, writeError
+		}
+		writer.pending = writer.pending[lineEnd+1:]
+	}
+}
+
+// Flush writes a trailing partial line, terminating it.
+func (writer *prefixedLineWriter) Flush() error {
+	if len(writer.pending) == 0 {
+		return nil
+	}
+	line := append(writer.pending, prefixedLineTerminatorConstant)
+	writer.pending = nil
+	return writer.writeLine(line)
+}
+
+func (writer *prefixedLineWriter) writeLine(line []byte) error {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	if _, writeError := io.WriteString(writer.destination, writer.prefix); writeError != nil {
+		return writeError
+	}
+	_, writeError := writer.destination.Write(line)
+	return writeError
+}
@@ -0,0 +1,94 @@
+package fanout
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	jsonIndentConstant             = "  "
+	failedLineTemplateConstant     = "EXEC-FAILED: %s exit=%d (%s)\n"
+	skippedLineTemplateConstant    = "EXEC-SKIPPED: %s %s\n"
+	summaryLineTemplateConstant    = "EXEC-SUMMARY: %d succeeded, %d failed, %d skipped\n"
+	unsupportedFormatTemplate      = "unsupported output format %q (expected text or json)"
+	durationRoundingUnitConstant   = time.Millisecond
+	defaultOutputFormatStringValue = "text"
+	jsonOutputFormatStringValue    = "json"
+)
+
+// OutputFormat enumerates the supported result renderings.
+type OutputFormat string
+
+// Supported output formats.
+const (
+	OutputFormatText OutputFormat = OutputFormat(defaultOutputFormatStringValue)
+	OutputFormatJSON OutputFormat = OutputFormat(jsonOutputFormatStringValue)
+)
+
+// OutputFormats lists the supported formats in display order.
+func OutputFormats() []string {
+	return []string{string(OutputFormatText), string(OutputFormatJSON)}
+}
+
+// ParseOutputFormat normalizes a user-supplied format, defaulting to text when empty.
+func ParseOutputFormat(rawValue string) (OutputFormat, error) {
+	normalized := strings.ToLower(strings.TrimSpace(rawValue))
+	if len(normalized) == 0 {
+		return OutputFormatText, nil
+	}
+	switch OutputFormat(normalized) {
+	case OutputFormatText, OutputFormatJSON:
+		return OutputFormat(normalized), nil
+	default:
+		return "", fmt.Errorf(unsupportedFormatTemplate, rawValue)
+	}
+}
+
+// JSONReport is the document written by the json output format.
+type JSONReport struct {
+	Command []string        `json:"command"`
+	Results []JSONResultRow `json:"results"`
+}
+
+// JSONResultRow carries one repository's outcome with its duration in milliseconds.
+type JSONResultRow struct {
+	Result
+	DurationMilliseconds int64 `json:"duration_ms"`
+}
+
+// WriteJSONReport renders the results as an indented json document.
+func WriteJSONReport(writer io.Writer, command []string, results []Result) error {
+	report := JSONReport{Command: command, Results: make([]JSONResultRow, 0, len(results))}
+	for _, result := range results {
+		report.Results = append(report.Results, JSONResultRow{Result: result, DurationMilliseconds: result.Duration.Milliseconds()})
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", jsonIndentConstant)
+	return encoder.Encode(report)
+}
+
+// WriteTextSummary writes an EXEC-FAILED or EXEC-SKIPPED line for each repository that did not succeed, followed by an
+// EXEC-SUMMARY line. Dry-run results produce no summary.
+func WriteTextSummary(writer io.Writer, results []Result) {
+	if len(results) > 0 && results[0].Status == StatusPlanned {
+		return
+	}
+
+	succeeded, failed, skipped := 0, 0, 0
+	for _, result := range results {
+		switch result.Status {
+		case StatusSucceeded:
+			succeeded++
+		case StatusFailed:
+			failed++
+			fmt.Fprintf(writer, failedLineTemplateConstant, result.RepositoryPath, result.ExitCode, result.Duration.Round(durationRoundingUnitConstant))
+		case StatusSkipped:
+			skipped++
+			fmt.Fprintf(writer, skippedLineTemplateConstant, result.RepositoryPath, result.Message)
+		}
+	}
+	fmt.Fprintf(writer, summaryLineTemplateConstant, succeeded, failed, skipped)
+}
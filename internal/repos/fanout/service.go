@@ -0,0 +1,193 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	commandMissingErrorMessageConstant   = "a command to run must be provided after --"
+	executorMissingErrorMessageConstant  = "command executor must be provided"
+	repositoryPrefixTemplateConstant     = "[%s] "
+	planLineTemplateConstant             = "PLAN-EXEC: %s: %s\n"
+	partialExecutionErrorTemplate        = "%d repository command(s) failed"
+	commandNotFoundExitCodeConstant      = 127
+	executionErrorExitCodeConstant       = -1
+	commandLineSeparatorConstant         = " "
+	minimumJobsConstant                  = 1
+	failFastSkipReasonConstant           = "skipped after an earlier failure (--fail-fast)"
+	contextCancelledSkipReasonTemplate   = "skipped: %v"
+	commandFailedMessageTemplateConstant = "command exited with code %d"
+)
+
+// Status classifies the outcome of the command in one repository.
+type Status string
+
+// Supported statuses.
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusSkipped   Status = "skipped"
+	StatusPlanned   Status = "planned"
+)
+
+// CommandExecutor runs arbitrary commands; *execshell.ShellExecutor satisfies it.
+type CommandExecutor interface {
+	Execute(executionContext context.Context, command execshell.ShellCommand) (execshell.ExecutionResult, error)
+}
+
+// Options describe one fan-out run. Jobs bounds how many repositories run at once and defaults to one. FailFast stops
+// starting new repositories after the first failure; commands already running are left to finish.
+type Options struct {
+	RepositoryPaths []string
+	Command         []string
+	Jobs            int
+	FailFast        bool
+	DryRun          bool
+	Output          io.Writer
+	Errors          io.Writer
+}
+
+// Result records the outcome of the command in one repository. ExitCode is 127 when the executable was not found and
+// -1 when the command could not be started for another reason.
+type Result struct {
+	RepositoryPath string        `json:"path"`
+	Status         Status        `json:"status"`
+	ExitCode       int           `json:"exit_code"`
+	Duration       time.Duration `json:"-"`
+	Message        string        `json:"message,omitempty"`
+}
+
+// PartialExecutionError reports that the run completed but the command failed in one or more repositories.
+type PartialExecutionError struct {
+	utils.PartialFailure
+	Failures []Result
+}
+
+// Error summarizes how many repositories failed.
+func (partialError PartialExecutionError) Error() string {
+	return fmt.Sprintf(partialExecutionErrorTemplate, len(partialError.Failures))
+}
+
+// Runner executes a command across repositories.
+type Runner struct {
+	executor CommandExecutor
+}
+
+// NewRunner constructs a Runner around the command executor.
+func NewRunner(executor CommandExecutor) (*Runner, error) {
+	if executor == nil {
+		return nil, errors.New(executorMissingErrorMessageConstant)
+	}
+	return &Runner{executor: executor}, nil
+}
+
+// Run executes the command in every repository and returns the results in repository order. Command output is streamed
+// to Output and Errors with a "[<path>] " prefix on every line. During a dry run each repository gets a PLAN-EXEC line
+// and nothing runs. Failures are returned together with the results as a PartialExecutionError.
+func (runner *Runner) Run(executionContext context.Context, options Options) ([]Result, error) {
+	if len(options.Command) == 0 || len(strings.TrimSpace(options.Command[0])) == 0 {
+		return nil, errors.New(commandMissingErrorMessageConstant)
+	}
+
+	output := resolveWriter(options.Output)
+	errorOutput := resolveWriter(options.Errors)
+	results := make([]Result, len(options.RepositoryPaths))
+
+	if options.DryRun {
+		commandLine := strings.Join(options.Command, commandLineSeparatorConstant)
+		for index, repositoryPath := range options.RepositoryPaths {
+			fmt.Fprintf(output, planLineTemplateConstant, repositoryPath, commandLine)
+			results[index] = Result{RepositoryPath: repositoryPath, Status: StatusPlanned}
+		}
+		return results, nil
+	}
+
+	var outputMutex sync.Mutex
+	var stopped atomic.Bool
+	ForEach(len(options.RepositoryPaths), options.Jobs, func(index int) {
+		repositoryPath := options.RepositoryPaths[index]
+		if stopped.Load() {
+			results[index] = Result{RepositoryPath: repositoryPath, Status: StatusSkipped, ExitCode: executionErrorExitCodeConstant, Message: failFastSkipReasonConstant}
+			return
+		}
+		if contextError := executionContext.Err(); contextError != nil {
+			results[index] = Result{RepositoryPath: repositoryPath, Status: StatusSkipped, ExitCode: executionErrorExitCodeConstant, Message: fmt.Sprintf(contextCancelledSkipReasonTemplate, contextError)}
+			return
+		}
+
+		prefix := fmt.Sprintf(repositoryPrefixTemplateConstant, repositoryPath)
+		outputWriter := newPrefixedLineWriter(&outputMutex, output, prefix)
+		errorWriter := newPrefixedLineWriter(&outputMutex, errorOutput, prefix)
+		result := runner.runInRepository(executionContext, repositoryPath, options.Command, outputWriter, errorWriter)
+		_ = outputWriter.Flush()
+		_ = errorWriter.Flush()
+
+		results[index] = result
+		if result.Status == StatusFailed && options.FailFast {
+			stopped.Store(true)
+		}
+	})
+
+	failures := make([]Result, 0)
+	for _, result := range results {
+		if result.Status == StatusFailed {
+			failures = append(failures, result)
+		}
+	}
+	if len(failures) > 0 {
+		return results, PartialExecutionError{Failures: failures}
+	}
+	return results, nil
+}
+
+func (runner *Runner) runInRepository(executionContext context.Context, repositoryPath string, commandArguments []string, outputWriter io.Writer, errorWriter io.Writer) Result {
+	command := execshell.ShellCommand{
+		Name: execshell.CommandName(commandArguments[0]),
+		Details: execshell.CommandDetails{
+			Arguments:        commandArguments[1:],
+			WorkingDirectory: repositoryPath,
+			Idempotent:       false,
+			OutputWriter:     outputWriter,
+			ErrorWriter:      errorWriter,
+		},
+	}
+
+	startedAt := time.Now()
+	_, executionError := runner.executor.Execute(executionContext, command)
+	result := Result{RepositoryPath: repositoryPath, Status: StatusSucceeded, Duration: time.Since(startedAt)}
+	if executionError == nil {
+		return result
+	}
+
+	result.Status = StatusFailed
+	var commandFailure execshell.CommandFailedError
+	switch {
+	case errors.As(executionError, &commandFailure):
+		result.ExitCode = commandFailure.Result.ExitCode
+		result.Message = fmt.Sprintf(commandFailedMessageTemplateConstant, commandFailure.Result.ExitCode)
+	case execshell.IsExecutableNotFound(executionError):
+		result.ExitCode = commandNotFoundExitCodeConstant
+		result.Message = executionError.Error()
+	default:
+		result.ExitCode = executionErrorExitCodeConstant
+		result.Message = executionError.Error()
+	}
+	return result
+}
+
+func resolveWriter(writer io.Writer) io.Writer {
+	if writer == nil {
+		return io.Discard
+	}
+	return writer
+}
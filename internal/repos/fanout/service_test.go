@@ -0,0 +1,203 @@
+package fanout_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/repos/fanout"
+)
+
+const (
+	fanoutAlphaPathConstant = "/tmp/fanout/alpha"
+	fanoutBetaPathConstant  = "/tmp/fanout/beta"
+	fanoutGammaPathConstant = "/tmp/fanout/gamma"
+)
+
+type stubCommandExecutor struct {
+	mutex          sync.Mutex
+	failingPaths   map[string]int
+	executedPaths  []string
+	running        int
+	maximumRunning int
+	delay          time.Duration
+}
+
+func (executor *stubCommandExecutor) Execute(_ context.Context, command execshell.ShellCommand) (execshell.ExecutionResult, error) {
+	executor.mutex.Lock()
+	executor.executedPaths = append(executor.executedPaths, command.Details.WorkingDirectory)
+	executor.running++
+	if executor.running > executor.maximumRunning {
+		executor.maximumRunning = executor.running
+	}
+	executor.mutex.Unlock()
+
+	time.Sleep(executor.delay)
+	fmt.Fprintf(command.Details.OutputWriter, "line one\nline %s", command.Details.Arguments[0])
+
+	executor.mutex.Lock()
+	executor.running--
+	executor.mutex.Unlock()
+
+	if exitCode, failing := executor.failingPaths[command.Details.WorkingDirectory]; failing {
+		io.WriteString(command.Details.ErrorWriter, "boom\n")
+		return execshell.ExecutionResult{ExitCode: exitCode}, execshell.CommandFailedError{Command: command, Result: execshell.ExecutionResult{ExitCode: exitCode}}
+	}
+	return execshell.ExecutionResult{}, nil
+}
+
+func TestRunnerRunReportsPerRepositoryResults(testInstance *testing.T) {
+	repositories := []string{fanoutAlphaPathConstant, fanoutBetaPathConstant, fanoutGammaPathConstant}
+	testCases := []struct {
+		name             string
+		options          fanout.Options
+		failingPaths     map[string]int
+		expectedStatuses []fanout.Status
+		expectedExecuted int
+		expectedOutput   string
+		expectedErrors   string
+		expectPartial    bool
+	}{
+		{
+			name:             "all_succeed_with_prefixed_output",
+			options:          fanout.Options{RepositoryPaths: repositories[:2], Command: []string{"echo", "two"}},
+			expectedStatuses: []fanout.Status{fanout.StatusSucceeded, fanout.StatusSucceeded},
+			expectedExecuted: 2,
+			expectedOutput: "[/tmp/fanout/alpha] line one\n[/tmp/fanout/alpha] line two\n" +
+				"[/tmp/fanout/beta] line one\n[/tmp/fanout/beta] line two\n",
+		},
+		{
+			name:             "failure_continues_without_fail_fast",
+			options:          fanout.Options{RepositoryPaths: repositories, Command: []string{"echo", "two"}},
+			failingPaths:     map[string]int{fanoutAlphaPathConstant: 3},
+			expectedStatuses: []fanout.Status{fanout.StatusFailed, fanout.StatusSucceeded, fanout.StatusSucceeded},
+			expectedExecuted: 3,
+			expectedErrors:   "[/tmp/fanout/alpha] boom\n",
+			expectPartial:    true,
+		},
+		{
+			name:             "fail_fast_skips_remaining_repositories",
+			options:          fanout.Options{RepositoryPaths: repositories, Command: []string{"echo", "two"}, FailFast: true},
+			failingPaths:     map[string]int{fanoutAlphaPathConstant: 3},
+			expectedStatuses: []fanout.Status{fanout.StatusFailed, fanout.StatusSkipped, fanout.StatusSkipped},
+			expectedExecuted: 1,
+			expectedErrors:   "[/tmp/fanout/alpha] boom\n",
+			expectPartial:    true,
+		},
+		{
+			name:             "dry_run_prints_plan",
+			options:          fanout.Options{RepositoryPaths: repositories[:2], Command: []string{"git", "status", "--short"}, DryRun: true},
+			expectedStatuses: []fanout.Status{fanout.StatusPlanned, fanout.StatusPlanned},
+			expectedOutput:   "PLAN-EXEC: /tmp/fanout/alpha: git status --short\nPLAN-EXEC: /tmp/fanout/beta: git status --short\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &stubCommandExecutor{failingPaths: testCase.failingPaths}
+			runner, runnerError := fanout.NewRunner(executor)
+			require.NoError(subtest, runnerError)
+
+			var output, errorOutput bytes.Buffer
+			options := testCase.options
+			options.Output = &output
+			options.Errors = &errorOutput
+
+			results, runError := runner.Run(context.Background(), options)
+			if testCase.expectPartial {
+				var partialError fanout.PartialExecutionError
+				require.ErrorAs(subtest, runError, &partialError)
+				require.Equal(subtest, 2, partialError.ProcessExitCode())
+			} else {
+				require.NoError(subtest, runError)
+			}
+
+			statuses := make([]fanout.Status, 0, len(results))
+			for _, result := range results {
+				statuses = append(statuses, result.Status)
+			}
+			require.Equal(subtest, testCase.expectedStatuses, statuses)
+			require.Len(subtest, executor.executedPaths, testCase.expectedExecuted)
+			if len(testCase.expectedOutput) > 0 {
+				require.Equal(subtest, testCase.expectedOutput, output.String())
+			}
+			require.Equal(subtest, testCase.expectedErrors, errorOutput.String())
+		})
+	}
+}
+
+func TestRunnerRunBoundsConcurrencyByJobs(testInstance *testing.T) {
+	executor := &stubCommandExecutor{delay: 20 * time.Millisecond}
+	runner, runnerError := fanout.NewRunner(executor)
+	require.NoError(testInstance, runnerError)
+
+	repositories := []string{fanoutAlphaPathConstant, fanoutBetaPathConstant, fanoutGammaPathConstant, "/tmp/fanout/delta"}
+	results, runError := runner.Run(context.Background(), fanout.Options{RepositoryPaths: repositories, Command: []string{"echo", "two"}, Jobs: 2})
+	require.NoError(testInstance, runError)
+	require.Len(testInstance, results, len(repositories))
+	require.LessOrEqual(testInstance, executor.maximumRunning, 2)
+	for index, result := range results {
+		require.Equal(testInstance, repositories[index], result.RepositoryPath)
+	}
+}
+
+func TestRunnerRunRequiresCommand(testInstance *testing.T) {
+	runner, runnerError := fanout.NewRunner(&stubCommandExecutor{})
+	require.NoError(testInstance, runnerError)
+
+	_, runError := runner.Run(context.Background(), fanout.Options{RepositoryPaths: []string{fanoutAlphaPathConstant}})
+	require.Error(testInstance, runError)
+
+	_, constructorError := fanout.NewRunner(nil)
+	require.Error(testInstance, constructorError)
+}
+
+func TestRunnerRunMapsMissingExecutableToExitCode127(testInstance *testing.T) {
+	runner, runnerError := fanout.NewRunner(missingExecutableExecutor{})
+	require.NoError(testInstance, runnerError)
+
+	results, runError := runner.Run(context.Background(), fanout.Options{RepositoryPaths: []string{fanoutAlphaPathConstant}, Command: []string{"no-such-tool"}})
+	require.Error(testInstance, runError)
+	require.Equal(testInstance, 127, results[0].ExitCode)
+}
+
+type missingExecutableExecutor struct{}
+
+func (missingExecutableExecutor) Execute(context.Context, execshell.ShellCommand) (execshell.ExecutionResult, error) {
+	return execshell.ExecutionResult{}, execshell.ExecutableNotFoundError{Command: "no-such-tool", Cause: errors.New("executable file not found in $PATH")}
+}
+
+func TestReportWriters(testInstance *testing.T) {
+	results := []fanout.Result{
+		{RepositoryPath: fanoutAlphaPathConstant, Status: fanout.StatusSucceeded, Duration: 1500 * time.Millisecond},
+		{RepositoryPath: fanoutBetaPathConstant, Status: fanout.StatusFailed, ExitCode: 3, Duration: 20 * time.Millisecond},
+		{RepositoryPath: fanoutGammaPathConstant, Status: fanout.StatusSkipped, ExitCode: -1, Message: "skipped after an earlier failure (--fail-fast)"},
+	}
+
+	var summary bytes.Buffer
+	fanout.WriteTextSummary(&summary, results)
+	require.Equal(testInstance, "EXEC-FAILED: /tmp/fanout/beta exit=3 (20ms)\n"+
+		"EXEC-SKIPPED: /tmp/fanout/gamma skipped after an earlier failure (--fail-fast)\n"+
+		"EXEC-SUMMARY: 1 succeeded, 1 failed, 1 skipped\n", summary.String())
+
+	var document bytes.Buffer
+	require.NoError(testInstance, fanout.WriteJSONReport(&document, []string{"make", "test"}, results))
+	var decoded map[string]any
+	require.NoError(testInstance, json.Unmarshal(document.Bytes(), &decoded))
+	rows := decoded["results"].([]any)
+	require.Len(testInstance, rows, 3)
+	require.Equal(testInstance, map[string]any{"path": fanoutAlphaPathConstant, "status": "succeeded", "exit_code": float64(0), "duration_ms": float64(1500)}, rows[0])
+	require.Equal(testInstance, float64(3), rows[1].(map[string]any)["exit_code"])
+
+	_, formatError := fanout.ParseOutputFormat("xml")
+	require.EqualError(testInstance, formatError, "unsupported output format \"xml\" (expected text or json)")
+}
@@ -38,3 +38,8 @@ func (OSFileSystem) ReadFile(path string) ([]byte, error) {
 func (OSFileSystem) WriteFile(path string, data []byte, permissions fs.FileMode) error {
 	return os.WriteFile(path, data, permissions)
 }
+
+// Remove deletes a file or an empty directory.
+func (OSFileSystem) Remove(path string) error {
+	return os.Remove(path)
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
 	repoerrors "github.com/temirov/gix/internal/repos/errors"
 	"github.com/temirov/gix/internal/repos/shared"
 )
@@ -18,6 +19,7 @@ import (
 const (
 	gitIgnoreFileName              = ".gitignore"
 	gitCommitMessage               = "chore: ignore purged paths"
+	automatedCommitCommandName     = "repo rm"
 	planMessageTemplate            = "PLAN-HISTORY-PURGE: %s paths=%s remote=%s push=%t restore=%t push_missing=%t\n"
 	skipMessageTemplate            = "HISTORY-SKIP: %s (no matching history for %s)\n"
 	successMessageTemplate         = "HISTORY-PURGE: %s removed=%s remote=%s push=%t restore=%t push_missing=%t\n"
@@ -249,7 +251,7 @@ func (executor Executor) ensureGitIgnore(ctx context.Context, repositoryPath str
 	if _, err := executor.executeGit(ctx, repositoryPath, "add", gitIgnoreFileName); err != nil {
 		return err
 	}
-	_, _ = executor.executeGit(ctx, repositoryPath, "commit", "-m", gitCommitMessage)
+	_, _ = executor.executeGit(ctx, repositoryPath, gitrepo.CommitArguments(gitrepo.AutomatedCommitOptions(ctx, automatedCommitCommandName, gitCommitMessage))...)
 
 	return nil
 }
@@ -283,7 +285,7 @@ func (executor Executor) runFilterRepo(ctx context.Context, repositoryPath strin
 }
 
 func (executor Executor) cleanupFilterRepo(ctx context.Context, repositoryPath string) error {
-	listResult, err := executor.executeGit(ctx, repositoryPath, gitForEachRefSubcommand, gitFormatFlag, "refs/filter-repo/")
+	listResult, err := executor.listRefs(ctx, repositoryPath, gitFormatFlag, "refs/filter-repo/")
 	if err != nil {
 		var commandFailed execshell.CommandFailedError
 		if errors.As(err, &commandFailed) {
@@ -353,7 +355,7 @@ func (executor Executor) restoreUpstreams(ctx context.Context, repositoryPath st
 		return err
 	}
 
-	listResult, err := executor.executeGit(ctx, repositoryPath, gitForEachRefSubcommand, gitFormatFlag, gitRefsHeadsPrefix)
+	listResult, err := executor.listRefs(ctx, repositoryPath, gitFormatFlag, gitRefsHeadsPrefix)
 	if err != nil {
 		return err
 	}
@@ -372,7 +374,7 @@ func (executor Executor) restoreUpstreams(ctx context.Context, repositoryPath st
 }
 
 func (executor Executor) attachUpstream(ctx context.Context, repositoryPath string, branch string, remoteName string, pushMissing bool) error {
-	upstreamResult, err := executor.executeGit(ctx, repositoryPath, gitForEachRefSubcommand, gitUpstreamFormatFlag, gitRefsHeadsPrefix+branch)
+	upstreamResult, err := executor.listRefs(ctx, repositoryPath, gitUpstreamFormatFlag, gitRefsHeadsPrefix+branch)
 	if err != nil {
 		return err
 	}
@@ -436,10 +438,25 @@ func (executor Executor) executeGit(ctx context.Context, repositoryPath string,
 	details := execshell.CommandDetails{
 		Arguments:        arguments,
 		WorkingDirectory: repositoryPath,
+		Idempotent:       false,
 	}
 	return executor.dependencies.GitExecutor.ExecuteGit(ctx, details)
 }
 
+// listRefs runs git for-each-ref with the arguments and fails when the listing was truncated at the capture limit.
+func (executor Executor) listRefs(ctx context.Context, repositoryPath string, arguments ...string) (execshell.ExecutionResult, error) {
+	details := execshell.CommandDetails{
+		Arguments:        append([]string{gitForEachRefSubcommand}, arguments...),
+		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
+	}
+	listResult, err := executor.dependencies.GitExecutor.ExecuteGit(ctx, details)
+	if err != nil {
+		return listResult, err
+	}
+	return listResult, execshell.RequireCompleteOutput(execshell.CommandGit, details, listResult)
+}
+
 func (executor Executor) printf(format string, values ...any) {
 	if executor.dependencies.Output == nil {
 		return
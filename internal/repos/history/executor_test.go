@@ -8,52 +8,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
 	"github.com/temirov/gix/internal/repos/filesystem"
 	"github.com/temirov/gix/internal/repos/history"
 	"github.com/temirov/gix/internal/repos/shared"
 )
 
-type scriptedGitExecutor struct {
-	responses      map[string]execshell.ExecutionResult
-	errorFactories map[string]func(execshell.CommandDetails) error
-	commands       []execshell.CommandDetails
-}
-
-func newScriptedGitExecutor() *scriptedGitExecutor {
-	return &scriptedGitExecutor{
-		responses:      map[string]execshell.ExecutionResult{},
-		errorFactories: map[string]func(execshell.CommandDetails) error{},
-	}
-}
-
-func commandKey(arguments []string) string {
-	return strings.Join(arguments, " ")
-}
-
-func (executor *scriptedGitExecutor) setResponse(arguments []string, result execshell.ExecutionResult) {
-	executor.responses[commandKey(arguments)] = result
-}
-
-func (executor *scriptedGitExecutor) setError(arguments []string, factory func(execshell.CommandDetails) error) {
-	executor.errorFactories[commandKey(arguments)] = factory
-}
-
-func (executor *scriptedGitExecutor) ExecuteGit(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	executor.commands = append(executor.commands, details)
-	key := commandKey(details.Arguments)
-	if factory, exists := executor.errorFactories[key]; exists {
-		return execshell.ExecutionResult{}, factory(details)
-	}
-	if result, exists := executor.responses[key]; exists {
-		return result, nil
-	}
-	return execshell.ExecutionResult{}, nil
-}
-
-func (executor *scriptedGitExecutor) ExecuteGitHubCLI(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
-	return execshell.ExecutionResult{}, nil
-}
-
 type stubRepositoryManager struct {
 	remoteURL string
 }
@@ -75,8 +35,8 @@ func (manager stubRepositoryManager) SetRemoteURL(context.Context, string, strin
 }
 
 func TestExecutorDryRunProducesPlan(testInstance *testing.T) {
-	executor := newScriptedGitExecutor()
-	executor.setResponse([]string{"rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"}, execshell.ExecutionResult{StandardOutput: "origin/main\n"})
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}").Return(execshell.ExecutionResult{StandardOutput: "origin/main\n"})
 
 	repoManager := stubRepositoryManager{remoteURL: "https://github.com/example/repo.git"}
 	outputBuffer := &strings.Builder{}
@@ -105,14 +65,14 @@ func TestExecutorDryRunProducesPlan(testInstance *testing.T) {
 	executionError := service.Execute(context.Background(), options)
 	require.NoError(testInstance, executionError)
 	require.Contains(testInstance, outputBuffer.String(), "PLAN-HISTORY-PURGE")
-	require.Len(testInstance, executor.commands, 1)
-	require.Equal(testInstance, []string{"rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"}, executor.commands[0].Arguments)
+	require.Len(testInstance, executor.Executed(), 1)
+	require.Equal(testInstance, []string{"rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"}, executor.Executed()[0].Details.Arguments)
 }
 
 func TestExecutorSkipsWhenPathsMissing(testInstance *testing.T) {
-	executor := newScriptedGitExecutor()
-	executor.setResponse([]string{"rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"}, execshell.ExecutionResult{StandardOutput: "origin/main\n"})
-	executor.setResponse([]string{"rev-list", "--all", "--", "secrets.txt"}, execshell.ExecutionResult{StandardOutput: ""})
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}").Return(execshell.ExecutionResult{StandardOutput: "origin/main\n"})
+	executor.OnGit("rev-list", "--all", "--", "secrets.txt").Return(execshell.ExecutionResult{StandardOutput: ""})
 
 	repoManager := stubRepositoryManager{remoteURL: "https://github.com/example/repo.git"}
 	outputBuffer := &strings.Builder{}
@@ -142,10 +102,7 @@ func TestExecutorSkipsWhenPathsMissing(testInstance *testing.T) {
 	executionError := service.Execute(context.Background(), options)
 	require.NoError(testInstance, executionError)
 
-	commandHistory := make([][]string, 0, len(executor.commands))
-	for _, details := range executor.commands {
-		commandHistory = append(commandHistory, details.Arguments)
-	}
+	commandHistory := executor.ExecutedArguments(execshell.CommandGit)
 
 	require.Contains(testInstance, commandHistory, []string{"fetch", "--prune", "--tags", "origin"})
 	require.Contains(testInstance, commandHistory, []string{"add", ".gitignore"})
@@ -154,15 +111,15 @@ func TestExecutorSkipsWhenPathsMissing(testInstance *testing.T) {
 }
 
 func TestExecutorRunsFilterRepoAndPush(testInstance *testing.T) {
-	executor := newScriptedGitExecutor()
-	executor.setResponse([]string{"rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"}, execshell.ExecutionResult{StandardOutput: "origin/main\n"})
-	executor.setResponse([]string{"rev-list", "--all", "--", "missing.txt"}, execshell.ExecutionResult{StandardOutput: ""})
-	executor.setResponse([]string{"rev-list", "--all", "--", "secrets.txt"}, execshell.ExecutionResult{StandardOutput: "abcd1234\n"})
-	executor.setResponse([]string{"for-each-ref", "--format=%(refname)", "refs/filter-repo/"}, execshell.ExecutionResult{StandardOutput: ""})
-	executor.setResponse([]string{"remote"}, execshell.ExecutionResult{StandardOutput: "origin\n"})
-	executor.setResponse([]string{"for-each-ref", "--format=%(refname)", "refs/heads/"}, execshell.ExecutionResult{StandardOutput: "refs/heads/main"})
-	executor.setResponse([]string{"for-each-ref", "--format=%(upstream:short)", "refs/heads/main"}, execshell.ExecutionResult{StandardOutput: "origin/main\n"})
-	executor.setResponse([]string{"show-ref", "--quiet", "refs/remotes/origin/main"}, execshell.ExecutionResult{})
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}").Return(execshell.ExecutionResult{StandardOutput: "origin/main\n"})
+	executor.OnGit("rev-list", "--all", "--", "missing.txt").Return(execshell.ExecutionResult{StandardOutput: ""})
+	executor.OnGit("rev-list", "--all", "--", "secrets.txt").Return(execshell.ExecutionResult{StandardOutput: "abcd1234\n"})
+	executor.OnGit("for-each-ref", "--format=%(refname)", "refs/filter-repo/").Return(execshell.ExecutionResult{StandardOutput: ""})
+	executor.OnGit("remote").Return(execshell.ExecutionResult{StandardOutput: "origin\n"})
+	executor.OnGit("for-each-ref", "--format=%(refname)", "refs/heads/").Return(execshell.ExecutionResult{StandardOutput: "refs/heads/main"})
+	executor.OnGit("for-each-ref", "--format=%(upstream:short)", "refs/heads/main").Return(execshell.ExecutionResult{StandardOutput: "origin/main\n"})
+	executor.OnGit("show-ref", "--quiet", "refs/remotes/origin/main").Return(execshell.ExecutionResult{})
 
 	repoManager := stubRepositoryManager{remoteURL: "git@github.com:example/repo.git"}
 	outputBuffer := &strings.Builder{}
@@ -191,9 +148,9 @@ func TestExecutorRunsFilterRepoAndPush(testInstance *testing.T) {
 	require.NoError(testInstance, executionError)
 	require.Contains(testInstance, outputBuffer.String(), "HISTORY-PURGE")
 
-	executedCommands := make([]string, 0, len(executor.commands))
-	for _, details := range executor.commands {
-		executedCommands = append(executedCommands, strings.Join(details.Arguments, " "))
+	executedCommands := make([]string, 0, len(executor.Executed()))
+	for _, command := range executor.Executed() {
+		executedCommands = append(executedCommands, strings.Join(command.Details.Arguments, " "))
 	}
 
 	require.Contains(testInstance, executedCommands, "fetch --prune --tags origin")
@@ -203,11 +160,9 @@ func TestExecutorRunsFilterRepoAndPush(testInstance *testing.T) {
 }
 
 func TestExecutorFailsWhenFetchingRemoteRefsFails(testInstance *testing.T) {
-	executor := newScriptedGitExecutor()
-	executor.setResponse([]string{"rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"}, execshell.ExecutionResult{StandardOutput: "origin/main\n"})
-	executor.setError([]string{"fetch", "--prune", "--tags", "origin"}, func(details execshell.CommandDetails) error {
-		return execshell.CommandFailedError{Command: execshell.ShellCommand{Name: execshell.CommandGit, Details: details}, Result: execshell.ExecutionResult{ExitCode: 128}}
-	})
+	executor := execshelltest.NewPermissiveExecutor()
+	executor.OnGit("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}").Return(execshell.ExecutionResult{StandardOutput: "origin/main\n"})
+	executor.OnGit("fetch", "--prune", "--tags", "origin").FailWith(128, "")
 
 	repoManager := stubRepositoryManager{remoteURL: "https://github.com/example/repo.git"}
 	outputBuffer := &strings.Builder{}
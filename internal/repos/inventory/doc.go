@@ -0,0 +1,2 @@
+// Package inventory collects locally derivable facts about discovered repositories for listing commands.
+package inventory
@@ -0,0 +1,118 @@
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	tableHeaderPathConstant        = "PATH"
+	tableHeaderRepositoryConstant  = "REPOSITORY"
+	tableHeaderBranchConstant      = "BRANCH"
+	tableHeaderDirtyConstant       = "DIRTY"
+	tableHeaderOriginConstant      = "ORIGIN"
+	tableEmptyValueConstant        = "-"
+	tableColumnSeparatorConstant   = "\t"
+	tableMinimumWidthConstant      = 0
+	tableTabWidthConstant          = 8
+	tablePaddingConstant           = 2
+	tablePaddingCharacterConstant  = ' '
+	jsonIndentConstant             = "  "
+	unsupportedFormatTemplate      = "unsupported output format %q (expected table, json, or paths)"
+	pathsLineTemplateConstant      = "%s\n"
+	tableLineTerminatorConstant    = "\n"
+	defaultOutputFormatStringValue = "table"
+)
+
+// OutputFormat enumerates the supported listing renderings.
+type OutputFormat string
+
+// Supported output formats.
+const (
+	OutputFormatTable OutputFormat = OutputFormat(defaultOutputFormatStringValue)
+	OutputFormatJSON  OutputFormat = "json"
+	OutputFormatPaths OutputFormat = "paths"
+)
+
+// OutputFormats lists the supported formats in display order.
+func OutputFormats() []string {
+	return []string{string(OutputFormatTable), string(OutputFormatJSON), string(OutputFormatPaths)}
+}
+
+// ParseOutputFormat normalizes a user-supplied format, defaulting to table when empty.
+func ParseOutputFormat(rawValue string) (OutputFormat, error) {
+	normalized := strings.ToLower(strings.TrimSpace(rawValue))
+	if len(normalized) == 0 {
+		return OutputFormatTable, nil
+	}
+	switch OutputFormat(normalized) {
+	case OutputFormatTable, OutputFormatJSON, OutputFormatPaths:
+		return OutputFormat(normalized), nil
+	default:
+		return "", fmt.Errorf(unsupportedFormatTemplate, rawValue)
+	}
+}
+
+// Render writes the repository facts to the writer using the requested format.
+func Render(writer io.Writer, repositories []RepositoryFacts, format OutputFormat) error {
+	switch format {
+	case OutputFormatJSON:
+		return renderJSON(writer, repositories)
+	case OutputFormatPaths:
+		return renderPaths(writer, repositories)
+	case OutputFormatTable:
+		return renderTable(writer, repositories)
+	default:
+		return fmt.Errorf(unsupportedFormatTemplate, string(format))
+	}
+}
+
+func renderJSON(writer io.Writer, repositories []RepositoryFacts) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", jsonIndentConstant)
+	if repositories == nil {
+		repositories = []RepositoryFacts{}
+	}
+	return encoder.Encode(repositories)
+}
+
+func renderPaths(writer io.Writer, repositories []RepositoryFacts) error {
+	for _, repository := range repositories {
+		if _, writeError := fmt.Fprintf(writer, pathsLineTemplateConstant, repository.Path); writeError != nil {
+			return writeError
+		}
+	}
+	return nil
+}
+
+func renderTable(writer io.Writer, repositories []RepositoryFacts) error {
+	tableWriter := tabwriter.NewWriter(writer, tableMinimumWidthConstant, tableTabWidthConstant, tablePaddingConstant, tablePaddingCharacterConstant, 0)
+	header := []string{tableHeaderPathConstant, tableHeaderRepositoryConstant, tableHeaderBranchConstant, tableHeaderDirtyConstant, tableHeaderOriginConstant}
+	if _, writeError := io.WriteString(tableWriter, strings.Join(header, tableColumnSeparatorConstant)+tableLineTerminatorConstant); writeError != nil {
+		return writeError
+	}
+	for _, repository := range repositories {
+		row := []string{
+			repository.Path,
+			displayValue(repository.OwnerRepository()),
+			displayValue(repository.CurrentBranch),
+			strconv.FormatBool(repository.Dirty),
+			displayValue(repository.OriginURL),
+		}
+		if _, writeError := io.WriteString(tableWriter, strings.Join(row, tableColumnSeparatorConstant)+tableLineTerminatorConstant); writeError != nil {
+			return writeError
+		}
+	}
+	return tableWriter.Flush()
+}
+
+func displayValue(value string) string {
+	if len(strings.TrimSpace(value)) == 0 {
+		return tableEmptyValueConstant
+	}
+	return value
+}
@@ -0,0 +1,169 @@
+package inventory
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+const parentDirectoryPrefixConstant = ".."
+
+// RepositoryFacts describes the locally derivable state of a single repository.
+type RepositoryFacts struct {
+	Path          string `json:"path"`
+	OriginURL     string `json:"origin_url"`
+	Owner         string `json:"owner"`
+	Name          string `json:"name"`
+	CurrentBranch string `json:"branch"`
+	Dirty         bool   `json:"dirty"`
+}
+
+// OwnerRepository returns the owner/name tuple or an empty string when the origin could not be parsed.
+func (facts RepositoryFacts) OwnerRepository() string {
+	if len(facts.Owner) == 0 || len(facts.Name) == 0 {
+		return ""
+	}
+	return facts.Owner + "/" + facts.Name
+}
+
+// Options constrain which discovered repositories are reported.
+type Options struct {
+	Roots           []string
+	ExcludePatterns []string
+	MaxDepth        int
+	Owner           string
+}
+
+// Service gathers repository facts using discovery and local git inspection only.
+type Service struct {
+	discoverer shared.RepositoryDiscoverer
+	gitManager shared.GitRepositoryManager
+}
+
+// NewService constructs a Service from the provided collaborators.
+func NewService(discoverer shared.RepositoryDiscoverer, gitManager shared.GitRepositoryManager) *Service {
+	return &Service{discoverer: discoverer, gitManager: gitManager}
+}
+
+// Collect discovers repositories under the configured roots and returns their facts in discovery order.
+func (service *Service) Collect(executionContext context.Context, options Options) ([]RepositoryFacts, error) {
+	repositories, discoveryError := service.discoverer.DiscoverRepositories(options.Roots)
+	if discoveryError != nil {
+		return nil, discoveryError
+	}
+
+	absoluteRoots := absolutePaths(options.Roots)
+	ownerFilter := strings.TrimSpace(options.Owner)
+
+	collected := make([]RepositoryFacts, 0, len(repositories))
+	for _, repositoryPath := range repositories {
+		relativePath, depth := relativeToRoots(repositoryPath, absoluteRoots)
+		if options.MaxDepth > 0 && depth > options.MaxDepth {
+			continue
+		}
+		if matchesAnyPattern(repositoryPath, relativePath, options.ExcludePatterns) {
+			continue
+		}
+
+		facts, inspectError := service.inspect(executionContext, repositoryPath)
+		if inspectError != nil {
+			return nil, inspectError
+		}
+		if len(ownerFilter) > 0 && !strings.EqualFold(facts.Owner, ownerFilter) {
+			continue
+		}
+		collected = append(collected, facts)
+	}
+
+	return collected, nil
+}
+
+func (service *Service) inspect(executionContext context.Context, repositoryPath string) (RepositoryFacts, error) {
+	facts := RepositoryFacts{Path: repositoryPath}
+
+	originURL, originError := service.gitManager.GetRemoteURL(executionContext, repositoryPath, shared.OriginRemoteNameConstant)
+	if execshell.IsExecutableNotFound(originError) {
+		return RepositoryFacts{}, originError
+	}
+	if originError == nil {
+		facts.OriginURL = strings.TrimSpace(originURL)
+		if parsedRemote, parseError := gitrepo.ParseRemoteURL(facts.OriginURL); parseError == nil {
+			facts.Owner = parsedRemote.Owner
+			facts.Name = parsedRemote.Repository
+		}
+	}
+
+	branchName, branchError := service.gitManager.GetCurrentBranch(executionContext, repositoryPath)
+	if execshell.IsExecutableNotFound(branchError) {
+		return RepositoryFacts{}, branchError
+	}
+	if branchError == nil {
+		facts.CurrentBranch = strings.TrimSpace(branchName)
+	}
+
+	clean, cleanError := service.gitManager.CheckCleanWorktree(executionContext, repositoryPath)
+	if execshell.IsExecutableNotFound(cleanError) {
+		return RepositoryFacts{}, cleanError
+	}
+	if cleanError == nil {
+		facts.Dirty = !clean
+	}
+
+	return facts, nil
+}
+
+func absolutePaths(paths []string) []string {
+	absolute := make([]string, 0, len(paths))
+	for _, candidate := range paths {
+		resolved, resolveError := filepath.Abs(candidate)
+		if resolveError != nil {
+			continue
+		}
+		absolute = append(absolute, filepath.Clean(resolved))
+	}
+	return absolute
+}
+
+func relativeToRoots(repositoryPath string, roots []string) (string, int) {
+	cleanedPath := filepath.Clean(repositoryPath)
+	bestRelative := ""
+	bestDepth := -1
+	for _, root := range roots {
+		relativePath, relativeError := filepath.Rel(root, cleanedPath)
+		if relativeError != nil || strings.HasPrefix(relativePath, parentDirectoryPrefixConstant) {
+			continue
+		}
+		depth := 0
+		if relativePath != "." {
+			depth = len(strings.Split(filepath.ToSlash(relativePath), "/"))
+		}
+		if bestDepth == -1 || depth < bestDepth {
+			bestDepth = depth
+			bestRelative = filepath.ToSlash(relativePath)
+		}
+	}
+	if bestDepth == -1 {
+		return filepath.Base(cleanedPath), 0
+	}
+	return bestRelative, bestDepth
+}
+
+func matchesAnyPattern(repositoryPath string, relativePath string, patterns []string) bool {
+	baseName := filepath.Base(repositoryPath)
+	for _, pattern := range patterns {
+		trimmedPattern := strings.TrimSpace(pattern)
+		if len(trimmedPattern) == 0 {
+			continue
+		}
+		for _, candidate := range []string{baseName, relativePath, filepath.ToSlash(repositoryPath)} {
+			if matched, matchError := filepath.Match(trimmedPattern, candidate); matchError == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
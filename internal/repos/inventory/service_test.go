@@ -0,0 +1,194 @@
+package inventory_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/repos/inventory"
+)
+
+const (
+	inventoryRootConstant          = "/tmp/inventory-root"
+	inventoryAlphaPathConstant     = "/tmp/inventory-root/alpha"
+	inventoryBetaPathConstant      = "/tmp/inventory-root/group/beta"
+	inventoryGammaPathConstant     = "/tmp/inventory-root/group/nested/gamma"
+	inventoryAlphaOriginConstant   = "git@github.com:Acme/alpha.git"
+	inventoryBetaOriginConstant    = "https://github.com/other/beta.git"
+	inventoryGammaOriginConstant   = "ssh://git@github.com/acme/gamma.git"
+	inventoryDefaultBranchConstant = "main"
+)
+
+type stubDiscoverer struct {
+	repositories []string
+}
+
+func (discoverer stubDiscoverer) DiscoverRepositories([]string) ([]string, error) {
+	return append([]string{}, discoverer.repositories...), nil
+}
+
+type stubGitManager struct {
+	origins     map[string]string
+	dirtyPaths  map[string]bool
+	branchCalls int
+}
+
+func (manager *stubGitManager) CheckCleanWorktree(_ context.Context, repositoryPath string) (bool, error) {
+	return !manager.dirtyPaths[repositoryPath], nil
+}
+
+func (manager *stubGitManager) GetCurrentBranch(context.Context, string) (string, error) {
+	manager.branchCalls++
+	return inventoryDefaultBranchConstant, nil
+}
+
+func (manager *stubGitManager) GetRemoteURL(_ context.Context, repositoryPath string, _ string) (string, error) {
+	return manager.origins[repositoryPath], nil
+}
+
+func (manager *stubGitManager) SetRemoteURL(context.Context, string, string, string) error {
+	return nil
+}
+
+func TestServiceCollectAppliesFilters(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		options       inventory.Options
+		expectedPaths []string
+	}{
+		{
+			name:          "no_filters",
+			options:       inventory.Options{Roots: []string{inventoryRootConstant}},
+			expectedPaths: []string{inventoryAlphaPathConstant, inventoryBetaPathConstant, inventoryGammaPathConstant},
+		},
+		{
+			name:          "max_depth_limits_nesting",
+			options:       inventory.Options{Roots: []string{inventoryRootConstant}, MaxDepth: 2},
+			expectedPaths: []string{inventoryAlphaPathConstant, inventoryBetaPathConstant},
+		},
+		{
+			name:          "exclude_matches_directory_name",
+			options:       inventory.Options{Roots: []string{inventoryRootConstant}, ExcludePatterns: []string{"be*"}},
+			expectedPaths: []string{inventoryAlphaPathConstant, inventoryGammaPathConstant},
+		},
+		{
+			name:          "exclude_matches_relative_path",
+			options:       inventory.Options{Roots: []string{inventoryRootConstant}, ExcludePatterns: []string{"group/*/*"}},
+			expectedPaths: []string{inventoryAlphaPathConstant, inventoryBetaPathConstant},
+		},
+		{
+			name:          "owner_filter_is_case_insensitive",
+			options:       inventory.Options{Roots: []string{inventoryRootConstant}, Owner: "acme"},
+			expectedPaths: []string{inventoryAlphaPathConstant, inventoryGammaPathConstant},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			discoverer := stubDiscoverer{repositories: []string{inventoryAlphaPathConstant, inventoryBetaPathConstant, inventoryGammaPathConstant}}
+			manager := &stubGitManager{
+				origins: map[string]string{
+					inventoryAlphaPathConstant: inventoryAlphaOriginConstant,
+					inventoryBetaPathConstant:  inventoryBetaOriginConstant,
+					inventoryGammaPathConstant: inventoryGammaOriginConstant,
+				},
+			}
+
+			service := inventory.NewService(discoverer, manager)
+			repositories, collectError := service.Collect(context.Background(), testCase.options)
+			require.NoError(subtest, collectError)
+
+			collectedPaths := make([]string, 0, len(repositories))
+			for _, repository := range repositories {
+				collectedPaths = append(collectedPaths, repository.Path)
+			}
+			require.Equal(subtest, testCase.expectedPaths, collectedPaths)
+		})
+	}
+}
+
+func TestServiceCollectReportsRepositoryFacts(testInstance *testing.T) {
+	discoverer := stubDiscoverer{repositories: []string{inventoryAlphaPathConstant}}
+	manager := &stubGitManager{
+		origins:    map[string]string{inventoryAlphaPathConstant: inventoryAlphaOriginConstant},
+		dirtyPaths: map[string]bool{inventoryAlphaPathConstant: true},
+	}
+
+	service := inventory.NewService(discoverer, manager)
+	repositories, collectError := service.Collect(context.Background(), inventory.Options{Roots: []string{inventoryRootConstant}})
+	require.NoError(testInstance, collectError)
+	require.Equal(testInstance, []inventory.RepositoryFacts{{
+		Path:          inventoryAlphaPathConstant,
+		OriginURL:     inventoryAlphaOriginConstant,
+		Owner:         "Acme",
+		Name:          "alpha",
+		CurrentBranch: inventoryDefaultBranchConstant,
+		Dirty:         true,
+	}}, repositories)
+	require.Equal(testInstance, "Acme/alpha", repositories[0].OwnerRepository())
+}
+
+func TestRenderFormats(testInstance *testing.T) {
+	repositories := []inventory.RepositoryFacts{
+		{Path: inventoryAlphaPathConstant, OriginURL: inventoryAlphaOriginConstant, Owner: "Acme", Name: "alpha", CurrentBranch: inventoryDefaultBranchConstant, Dirty: true},
+		{Path: inventoryBetaPathConstant},
+	}
+
+	testCases := []struct {
+		name           string
+		rawFormat      string
+		expectParseErr bool
+		verify         func(testing.TB, string)
+	}{
+		{
+			name:      "paths",
+			rawFormat: "paths",
+			verify: func(testingInstance testing.TB, output string) {
+				require.Equal(testingInstance, inventoryAlphaPathConstant+"\n"+inventoryBetaPathConstant+"\n", output)
+			},
+		},
+		{
+			name:      "json",
+			rawFormat: "JSON",
+			verify: func(testingInstance testing.TB, output string) {
+				var decoded []inventory.RepositoryFacts
+				require.NoError(testingInstance, json.Unmarshal([]byte(output), &decoded))
+				require.Equal(testingInstance, repositories, decoded)
+			},
+		},
+		{
+			name:      "table_defaults_when_empty",
+			rawFormat: "",
+			verify: func(testingInstance testing.TB, output string) {
+				require.Contains(testingInstance, output, "PATH")
+				require.Contains(testingInstance, output, "Acme/alpha")
+				require.Contains(testingInstance, output, "true")
+			},
+		},
+		{
+			name:           "unsupported_format",
+			rawFormat:      "xml",
+			expectParseErr: true,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			outputFormat, parseError := inventory.ParseOutputFormat(testCase.rawFormat)
+			if testCase.expectParseErr {
+				require.Error(subtest, parseError)
+				return
+			}
+			require.NoError(subtest, parseError)
+
+			outputBuffer := &bytes.Buffer{}
+			require.NoError(subtest, inventory.Render(outputBuffer, repositories, outputFormat))
+			testCase.verify(subtest, outputBuffer.String())
+		})
+	}
+}
@@ -49,3 +49,23 @@ func (prompter *IOConfirmationPrompter) Confirm(prompt string) (shared.Confirmat
 		return shared.ConfirmationResult{}, nil
 	}
 }
+
+// ConfirmPhrase writes the prompt and reports whether the response matches the expected phrase exactly.
+func (prompter *IOConfirmationPrompter) ConfirmPhrase(prompt string, expectedPhrase string) (bool, error) {
+	if prompter.writer != nil {
+		if _, writeError := io.WriteString(prompter.writer, prompt); writeError != nil {
+			return false, writeError
+		}
+	}
+
+	response, readError := prompter.reader.ReadString('\n')
+	if readError != nil && readError != io.EOF {
+		return false, readError
+	}
+
+	trimmedPhrase := strings.TrimSpace(expectedPhrase)
+	if len(trimmedPhrase) == 0 {
+		return false, nil
+	}
+	return strings.TrimSpace(response) == trimmedPhrase, nil
+}
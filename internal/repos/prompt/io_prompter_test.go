@@ -105,3 +105,64 @@ func TestIOConfirmationPrompterConfirm(testInstance *testing.T) {
 		})
 	}
 }
+
+func TestIOConfirmationPrompterConfirmPhrase(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		reader         io.Reader
+		expectedPhrase string
+		expectedResult bool
+		expectedError  error
+	}{
+		{
+			name:           "exact_phrase",
+			reader:         strings.NewReader("service\n"),
+			expectedPhrase: "service",
+			expectedResult: true,
+		},
+		{
+			name:           "phrase_with_surrounding_whitespace",
+			reader:         strings.NewReader("  service  \n"),
+			expectedPhrase: "service",
+			expectedResult: true,
+		},
+		{
+			name:           "affirmative_is_not_phrase",
+			reader:         strings.NewReader("y\n"),
+			expectedPhrase: "service",
+		},
+		{
+			name:           "case_mismatch",
+			reader:         strings.NewReader("Service\n"),
+			expectedPhrase: "service",
+		},
+		{
+			name:           "empty_expected_phrase",
+			reader:         strings.NewReader("\n"),
+			expectedPhrase: "",
+		},
+		{
+			name:           "read_error",
+			reader:         failingReader{err: errors.New("read failure")},
+			expectedPhrase: "service",
+			expectedError:  errors.New("read failure"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(testingInstance *testing.T) {
+			writer := &recordingWriter{}
+			prompter := prompt.NewIOConfirmationPrompter(testCase.reader, writer)
+			confirmed, err := prompter.ConfirmPhrase(promptMessageConstant, testCase.expectedPhrase)
+
+			if testCase.expectedError != nil {
+				require.ErrorContains(testingInstance, err, testCase.expectedError.Error())
+				return
+			}
+
+			require.NoError(testingInstance, err)
+			require.Equal(testingInstance, testCase.expectedResult, confirmed)
+			require.Equal(testingInstance, promptMessageConstant, writer.buffer.String())
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+const (
+	originRemoteNameConstant          = "origin"
+	schemeSeparatorConstant           = "://"
+	scpPathSeparatorConstant          = ":"
+	urlPathSeparatorConstant          = "/"
+	httpsSchemeConstant               = "https"
+	httpSchemeConstant                = "http"
+	sshSchemeConstant                 = "ssh"
+	gitSchemeConstant                 = "git"
+	unknownOwnerConstant              = "(unknown)"
+	totalRowLabelConstant             = "TOTAL"
+	reportTableHeaderOwnerConstant    = "OWNER"
+	reportTableHeaderTotalConstant    = "TOTAL"
+	reportTableColumnSeparator        = "\t"
+	reportTableLineTerminator         = "\n"
+	reportTablePaddingConstant        = 2
+	reportTableTabWidthConstant       = 8
+	reportJSONIndentConstant          = "  "
+	reportUnsupportedFormatTemplate   = "unsupported report format %q (expected table or json)"
+	reportDefaultFormatStringConstant = "table"
+)
+
+// URLClass names the shape of an origin URL in a protocol distribution report.
+type URLClass string
+
+// URL classes in report column order.
+const (
+	// URLClassHTTPS matches https:// and http:// URLs.
+	URLClassHTTPS URLClass = URLClass("https")
+	// URLClassSSHURL matches ssh:// URLs, which the conversion calls the ssh protocol.
+	URLClassSSHURL URLClass = URLClass("ssh-url")
+	// URLClassSSHSCP matches scp-like user@host:owner/repo URLs, which the conversion calls the git protocol.
+	URLClassSSHSCP URLClass = URLClass("ssh-scp")
+	// URLClassGit matches git:// URLs.
+	URLClassGit URLClass = URLClass("git")
+	// URLClassOther matches every other origin, such as local paths.
+	URLClassOther URLClass = URLClass("other")
+)
+
+// URLClasses lists the URL classes in report column order.
+func URLClasses() []URLClass {
+	return []URLClass{URLClassHTTPS, URLClassSSHURL, URLClassSSHSCP, URLClassGit, URLClassOther}
+}
+
+// ReportFormat enumerates the renderings of a protocol distribution report.
+type ReportFormat string
+
+// Supported report formats.
+const (
+	ReportFormatTable ReportFormat = ReportFormat(reportDefaultFormatStringConstant)
+	ReportFormatJSON  ReportFormat = ReportFormat("json")
+)
+
+// ReportFormats lists the supported report formats in display order.
+func ReportFormats() []string {
+	return []string{string(ReportFormatTable), string(ReportFormatJSON)}
+}
+
+// ParseReportFormat normalizes a user-supplied report format, defaulting to table when empty.
+func ParseReportFormat(rawValue string) (ReportFormat, error) {
+	normalized := strings.ToLower(strings.TrimSpace(rawValue))
+	if len(normalized) == 0 {
+		return ReportFormatTable, nil
+	}
+	switch ReportFormat(normalized) {
+	case ReportFormatTable, ReportFormatJSON:
+		return ReportFormat(normalized), nil
+	default:
+		return "", fmt.Errorf(reportUnsupportedFormatTemplate, rawValue)
+	}
+}
+
+// ProtocolCounts counts origins per URL class.
+type ProtocolCounts struct {
+	HTTPS  int `json:"https"`
+	SSHURL int `json:"ssh_url"`
+	SSHSCP int `json:"ssh_scp"`
+	Git    int `json:"git"`
+	Other  int `json:"other"`
+	Total  int `json:"total"`
+}
+
+func (counts *ProtocolCounts) add(class URLClass) {
+	switch class {
+	case URLClassHTTPS:
+		counts.HTTPS++
+	case URLClassSSHURL:
+		counts.SSHURL++
+	case URLClassSSHSCP:
+		counts.SSHSCP++
+	case URLClassGit:
+		counts.Git++
+	default:
+		counts.Other++
+	}
+	counts.Total++
+}
+
+func (counts ProtocolCounts) values() []int {
+	return []int{counts.HTTPS, counts.SSHURL, counts.SSHSCP, counts.Git, counts.Other, counts.Total}
+}
+
+// OwnerDistribution counts the origins of one owner per URL class.
+type OwnerDistribution struct {
+	Owner string `json:"owner"`
+	ProtocolCounts
+}
+
+// DistributionReport summarizes how the discovered origins are spelled, per owner and overall. Repositories without
+// an origin are not counted.
+type DistributionReport struct {
+	Owners []OwnerDistribution `json:"owners"`
+	Total  ProtocolCounts      `json:"total"`
+}
+
+// ClassifyRemoteURL reports the URL class of an origin URL.
+func ClassifyRemoteURL(remoteURL string) URLClass {
+	trimmedURL := strings.TrimSpace(remoteURL)
+	if scheme, _, hasScheme := strings.Cut(trimmedURL, schemeSeparatorConstant); hasScheme {
+		switch strings.ToLower(scheme) {
+		case httpsSchemeConstant, httpSchemeConstant:
+			return URLClassHTTPS
+		case sshSchemeConstant:
+			return URLClassSSHURL
+		case gitSchemeConstant:
+			return URLClassGit
+		default:
+			return URLClassOther
+		}
+	}
+	hostPart, pathPart, hasPath := strings.Cut(trimmedURL, scpPathSeparatorConstant)
+	if hasPath && len(hostPart) > 0 && len(pathPart) > 0 && !strings.Contains(hostPart, urlPathSeparatorConstant) {
+		return URLClassSSHSCP
+	}
+	return URLClassOther
+}
+
+// remoteOwner returns the first path segment of an origin URL, or unknownOwnerConstant when it has none.
+func remoteOwner(remoteURL string, class URLClass) string {
+	trimmedURL := strings.TrimSpace(remoteURL)
+	var repositoryPath string
+	switch class {
+	case URLClassHTTPS, URLClassSSHURL, URLClassGit:
+		_, location, _ := strings.Cut(trimmedURL, schemeSeparatorConstant)
+		_, repositoryPath, _ = strings.Cut(location, urlPathSeparatorConstant)
+	case URLClassSSHSCP:
+		_, repositoryPath, _ = strings.Cut(trimmedURL, scpPathSeparatorConstant)
+	default:
+		return unknownOwnerConstant
+	}
+	owner, _, hasRepository := strings.Cut(strings.TrimPrefix(repositoryPath, urlPathSeparatorConstant), urlPathSeparatorConstant)
+	if !hasRepository || len(owner) == 0 {
+		return unknownOwnerConstant
+	}
+	return owner
+}
+
+// CollectDistribution discovers the repositories under roots and counts their origin URLs per owner and URL class.
+// Owners are listed alphabetically, compared case-insensitively.
+func CollectDistribution(executionContext context.Context, discoverer shared.RepositoryDiscoverer, gitManager shared.GitRepositoryManager, roots []string) (DistributionReport, error) {
+	repositories, discoveryError := discoverer.DiscoverRepositories(roots)
+	if discoveryError != nil {
+		return DistributionReport{}, discoveryError
+	}
+
+	countsByOwner := map[string]*OwnerDistribution{}
+	report := DistributionReport{}
+	for _, repositoryPath := range repositories {
+		remoteURL, remoteError := gitManager.GetRemoteURL(executionContext, repositoryPath, originRemoteNameConstant)
+		if remoteError != nil || len(strings.TrimSpace(remoteURL)) == 0 {
+			continue
+		}
+		class := ClassifyRemoteURL(remoteURL)
+		owner := remoteOwner(remoteURL, class)
+		ownerKey := strings.ToLower(owner)
+		distribution, known := countsByOwner[ownerKey]
+		if !known {
+			distribution = &OwnerDistribution{Owner: owner}
+			countsByOwner[ownerKey] = distribution
+		}
+		distribution.add(class)
+		report.Total.add(class)
+	}
+
+	ownerKeys := make([]string, 0, len(countsByOwner))
+	for ownerKey := range countsByOwner {
+		ownerKeys = append(ownerKeys, ownerKey)
+	}
+	sort.Strings(ownerKeys)
+	report.Owners = make([]OwnerDistribution, 0, len(ownerKeys))
+	for _, ownerKey := range ownerKeys {
+		report.Owners = append(report.Owners, *countsByOwner[ownerKey])
+	}
+	return report, nil
+}
+
+// RenderDistribution writes the report to the writer using the requested format.
+func RenderDistribution(writer io.Writer, report DistributionReport, format ReportFormat) error {
+	switch format {
+	case ReportFormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", reportJSONIndentConstant)
+		return encoder.Encode(report)
+	case ReportFormatTable:
+		return renderDistributionTable(writer, report)
+	default:
+		return fmt.Errorf(reportUnsupportedFormatTemplate, string(format))
+	}
+}
+
+func renderDistributionTable(writer io.Writer, report DistributionReport) error {
+	tableWriter := tabwriter.NewWriter(writer, 0, reportTableTabWidthConstant, reportTablePaddingConstant, ' ', 0)
+	header := []string{reportTableHeaderOwnerConstant}
+	for _, class := range URLClasses() {
+		header = append(header, strings.ToUpper(string(class)))
+	}
+	header = append(header, reportTableHeaderTotalConstant)
+	if _, writeError := io.WriteString(tableWriter, strings.Join(header, reportTableColumnSeparator)+reportTableLineTerminator); writeError != nil {
+		return writeError
+	}
+
+	rows := append([]OwnerDistribution{}, report.Owners...)
+	rows = append(rows, OwnerDistribution{Owner: totalRowLabelConstant, ProtocolCounts: report.Total})
+	for _, row := range rows {
+		cells := []string{row.Owner}
+		for _, value := range row.values() {
+			cells = append(cells, strconv.Itoa(value))
+		}
+		if _, writeError := io.WriteString(tableWriter, strings.Join(cells, reportTableColumnSeparator)+reportTableLineTerminator); writeError != nil {
+			return writeError
+		}
+	}
+	return tableWriter.Flush()
+}
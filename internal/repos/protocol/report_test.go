@@ -0,0 +1,134 @@
+package protocol_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/repos/protocol"
+)
+
+type staticRepositoryDiscoverer struct {
+	repositories []string
+}
+
+func (discoverer staticRepositoryDiscoverer) DiscoverRepositories([]string) ([]string, error) {
+	return discoverer.repositories, nil
+}
+
+type mappedRemoteManager struct {
+	stubGitManager
+	remoteURLs map[string]string
+}
+
+func (manager *mappedRemoteManager) GetRemoteURL(_ context.Context, repositoryPath string, _ string) (string, error) {
+	return manager.remoteURLs[repositoryPath], nil
+}
+
+func TestClassifyRemoteURL(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		remoteURL     string
+		expectedClass protocol.URLClass
+	}{
+		{name: "https", remoteURL: "https://github.com/octo/tools.git", expectedClass: protocol.URLClassHTTPS},
+		{name: "http", remoteURL: "http://git.example.com/octo/tools.git", expectedClass: protocol.URLClassHTTPS},
+		{name: "ssh_url", remoteURL: "ssh://git@github.com/octo/tools.git", expectedClass: protocol.URLClassSSHURL},
+		{name: "ssh_scp", remoteURL: "git@github.com:octo/tools.git", expectedClass: protocol.URLClassSSHSCP},
+		{name: "ssh_scp_without_user", remoteURL: "github.com:octo/tools.git", expectedClass: protocol.URLClassSSHSCP},
+		{name: "git_daemon", remoteURL: "git://github.com/octo/tools.git", expectedClass: protocol.URLClassGit},
+		{name: "local_path", remoteURL: "/srv/git/tools.git", expectedClass: protocol.URLClassOther},
+		{name: "file_url", remoteURL: "file:///srv/git/tools.git", expectedClass: protocol.URLClassOther},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			require.Equal(subtest, testCase.expectedClass, protocol.ClassifyRemoteURL(testCase.remoteURL))
+		})
+	}
+}
+
+func TestCollectAndRenderDistribution(testInstance *testing.T) {
+	remoteURLs := map[string]string{
+		"/repos/a": "https://github.com/octo/a.git",
+		"/repos/b": "git@github.com:Octo/b.git",
+		"/repos/c": "ssh://git@github.com/acme/c.git",
+		"/repos/d": "git://github.com/acme/d.git",
+		"/repos/e": "/srv/git/e.git",
+		"/repos/f": "",
+	}
+	discoverer := staticRepositoryDiscoverer{repositories: []string{"/repos/a", "/repos/b", "/repos/c", "/repos/d", "/repos/e", "/repos/f"}}
+
+	report, collectError := protocol.CollectDistribution(context.Background(), discoverer, &mappedRemoteManager{remoteURLs: remoteURLs}, []string{"/repos"})
+	require.NoError(testInstance, collectError)
+
+	testCases := []struct {
+		name           string
+		format         protocol.ReportFormat
+		expectedOutput string
+	}{
+		{
+			name:   "table",
+			format: protocol.ReportFormatTable,
+			expectedOutput: "OWNER      HTTPS  SSH-URL  SSH-SCP  GIT  OTHER  TOTAL\n" +
+				"(unknown)  0      0        0        0    1      1\n" +
+				"acme       0      1        0        1    0      2\n" +
+				"octo       1      0        1        0    0      2\n" +
+				"TOTAL      1      1        1        1    1      5\n",
+		},
+		{
+			name:   "json",
+			format: protocol.ReportFormatJSON,
+			expectedOutput: `{
+  "owners": [
+    {
+      "owner": "(unknown)",
+      "https": 0,
+      "ssh_url": 0,
+      "ssh_scp": 0,
+      "git": 0,
+      "other": 1,
+      "total": 1
+    },
+    {
+      "owner": "acme",
+      "https": 0,
+      "ssh_url": 1,
+      "ssh_scp": 0,
+      "git": 1,
+      "other": 0,
+      "total": 2
+    },
+    {
+      "owner": "octo",
+      "https": 1,
+      "ssh_url": 0,
+      "ssh_scp": 1,
+      "git": 0,
+      "other": 0,
+      "total": 2
+    }
+  ],
+  "total": {
+    "https": 1,
+    "ssh_url": 1,
+    "ssh_scp": 1,
+    "git": 1,
+    "other": 1,
+    "total": 5
+  }
+}
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			output := &bytes.Buffer{}
+			require.NoError(subtest, protocol.RenderDistribution(output, report, testCase.format))
+			require.Equal(subtest, testCase.expectedOutput, output.String())
+		})
+	}
+}
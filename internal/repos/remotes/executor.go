@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/temirov/gix/internal/gitrepo"
 	repoerrors "github.com/temirov/gix/internal/repos/errors"
 	"github.com/temirov/gix/internal/repos/shared"
 )
@@ -16,10 +17,18 @@ const (
 	skipSameMessage                  = "UPDATE-REMOTE-SKIP: %s (already canonical)\n"
 	skipTargetMessage                = "UPDATE-REMOTE-SKIP: %s (error: could not construct target URL)\n"
 	planMessage                      = "PLAN-UPDATE-REMOTE: %s origin %s → %s\n"
+	planPushMessage                  = "PLAN-UPDATE-REMOTE: %s origin push %s → %s\n"
 	promptTemplate                   = "Update 'origin' in '%s' to canonical (%s → %s)? [a/N/y] "
+	promptPushTemplate               = "Update 'origin' push URL in '%s' to canonical (%s → %s)? [a/N/y] "
 	declinedMessage                  = "UPDATE-REMOTE-SKIP: user declined for %s\n"
 	successMessage                   = "UPDATE-REMOTE-DONE: %s origin now %s\n"
+	successPushMessage               = "UPDATE-REMOTE-DONE: %s origin push now %s\n"
 	failureMessage                   = "UPDATE-REMOTE-SKIP: %s (error: failed to set origin URL)\n"
+	failurePushMessage               = "UPDATE-REMOTE-SKIP: %s (error: failed to set origin push URL)\n"
+	credentialHintMessage            = "UPDATE-REMOTE-CREDENTIALS: %s host changed %s → %s; cached credentials for %s can cause authentication failures, erase them with: %s\n"
+	credentialPlanMessage            = "PLAN-ERASE-CREDENTIALS: %s credentials for %s\n"
+	credentialErasedMessage          = "UPDATE-REMOTE-CREDENTIALS: %s erased stored credentials for %s\n"
+	credentialEraseFailedMessage     = "UPDATE-REMOTE-CREDENTIALS: %s (error: failed to erase credentials for %s) erase them with: %s\n"
 	ownerRepoNotDetectedErrorMessage = "owner repository not detected"
 	unknownProtocolErrorTemplate     = "unknown protocol %s"
 	gitProtocolURLTemplate           = "git@github.com:%s.git"
@@ -28,17 +37,38 @@ const (
 )
 
 // Options configures the remote update workflow.
+// When IncludePushURL is set, a push URL that names a different repository than the canonical one is rewritten as well.
+// When EraseStaleCredentials is set, an HTTPS origin that moves to another host has the old host's stored credentials
+// erased; otherwise a hint names the command that erases them.
 type Options struct {
 	RepositoryPath           shared.RepositoryPath
 	CurrentOriginURL         *shared.RemoteURL
 	OriginOwnerRepository    *shared.OwnerRepository
+	CurrentPushURL           *shared.RemoteURL
+	PushOwnerRepository      *shared.OwnerRepository
+	IncludePushURL           bool
 	CanonicalOwnerRepository *shared.OwnerRepository
 	RemoteProtocol           shared.RemoteProtocol
 	DryRun                   bool
 	ConfirmationPolicy       shared.ConfirmationPolicy
 	OwnerConstraint          *shared.OwnerSlug
+	EraseStaleCredentials    bool
 }
 
+// Outcome reports what Execute did with the origin remote of a repository.
+type Outcome int
+
+const (
+	// OutcomeSkipped means the remote needed no change or could not be changed.
+	OutcomeSkipped Outcome = iota
+	// OutcomePlanned means a dry run reported the change without applying it.
+	OutcomePlanned
+	// OutcomeApplied means the remote was rewritten.
+	OutcomeApplied
+	// OutcomeDeclined means the user declined the change at the prompt.
+	OutcomeDeclined
+)
+
 // Dependencies captures collaborators required to update remotes.
 type Dependencies struct {
 	GitManager shared.GitRepositoryManager
@@ -56,13 +86,13 @@ func NewExecutor(dependencies Dependencies) *Executor {
 	return &Executor{dependencies: dependencies}
 }
 
-// Execute performs the remote update according to the provided options.
-func (executor *Executor) Execute(executionContext context.Context, options Options) error {
+// Execute performs the remote update according to the provided options and reports its outcome.
+func (executor *Executor) Execute(executionContext context.Context, options Options) (Outcome, error) {
 	repositoryPath := options.RepositoryPath.String()
 
 	if options.OriginOwnerRepository == nil {
 		executor.printfOutput(skipParseMessage, repositoryPath)
-		return repoerrors.WrapMessage(
+		return OutcomeSkipped, repoerrors.WrapMessage(
 			repoerrors.OperationCanonicalRemote,
 			repositoryPath,
 			repoerrors.ErrOriginOwnerMissing,
@@ -72,7 +102,7 @@ func (executor *Executor) Execute(executionContext context.Context, options Opti
 
 	if options.CanonicalOwnerRepository == nil {
 		executor.printfOutput(skipCanonicalMessage, repositoryPath)
-		return repoerrors.WrapMessage(
+		return OutcomeSkipped, repoerrors.WrapMessage(
 			repoerrors.OperationCanonicalRemote,
 			repositoryPath,
 			repoerrors.ErrCanonicalOwnerMissing,
@@ -83,15 +113,17 @@ func (executor *Executor) Execute(executionContext context.Context, options Opti
 	originOwner := options.OriginOwnerRepository.String()
 	canonicalOwner := options.CanonicalOwnerRepository.String()
 
-	if strings.EqualFold(originOwner, canonicalOwner) {
+	originCanonical := strings.EqualFold(originOwner, canonicalOwner)
+	pushOutdated := pushURLOutdated(options, canonicalOwner)
+	if originCanonical && !pushOutdated {
 		executor.printfOutput(skipSameMessage, repositoryPath)
-		return nil
+		return OutcomeSkipped, nil
 	}
 
 	targetURL, targetError := BuildRemoteURL(options.RemoteProtocol, canonicalOwner)
 	if targetError != nil {
 		executor.printfOutput(skipTargetMessage, repositoryPath)
-		return repoerrors.WrapMessage(
+		return OutcomeSkipped, repoerrors.WrapMessage(
 			repoerrors.OperationCanonicalRemote,
 			repositoryPath,
 			repoerrors.ErrRemoteURLBuildFailed,
@@ -104,17 +136,38 @@ func (executor *Executor) Execute(executionContext context.Context, options Opti
 		currentOriginURL = options.CurrentOriginURL.String()
 	}
 
+	currentPushURL := ""
+	if options.CurrentPushURL != nil {
+		currentPushURL = options.CurrentPushURL.String()
+	}
+
+	staleCredentialHost := staleCredentialHost(currentOriginURL, targetURL)
+	if originCanonical {
+		staleCredentialHost = ""
+	}
+
 	if options.DryRun {
-		executor.printfOutput(planMessage, repositoryPath, currentOriginURL, targetURL)
-		return nil
+		if !originCanonical {
+			executor.printfOutput(planMessage, repositoryPath, currentOriginURL, targetURL)
+		}
+		if len(staleCredentialHost) > 0 && options.EraseStaleCredentials {
+			executor.printfOutput(credentialPlanMessage, repositoryPath, staleCredentialHost)
+		}
+		if pushOutdated {
+			executor.printfOutput(planPushMessage, repositoryPath, currentPushURL, targetURL)
+		}
+		return OutcomePlanned, nil
 	}
 
 	if options.ConfirmationPolicy.ShouldPrompt() && executor.dependencies.Prompter != nil {
 		prompt := fmt.Sprintf(promptTemplate, repositoryPath, originOwner, canonicalOwner)
+		if originCanonical {
+			prompt = fmt.Sprintf(promptPushTemplate, repositoryPath, options.PushOwnerRepository.String(), canonicalOwner)
+		}
 		confirmationResult, promptError := executor.dependencies.Prompter.Confirm(prompt)
 		if promptError != nil {
 			executor.printfOutput(skipTargetMessage, repositoryPath)
-			return repoerrors.WrapMessage(
+			return OutcomeSkipped, repoerrors.WrapMessage(
 				repoerrors.OperationCanonicalRemote,
 				repositoryPath,
 				repoerrors.ErrUserConfirmationFailed,
@@ -123,13 +176,13 @@ func (executor *Executor) Execute(executionContext context.Context, options Opti
 		}
 		if !confirmationResult.Confirmed {
 			executor.printfOutput(declinedMessage, repositoryPath)
-			return nil
+			return OutcomeDeclined, nil
 		}
 	}
 
 	if executor.dependencies.GitManager == nil {
 		executor.printfOutput(failureMessage, repositoryPath)
-		return repoerrors.WrapMessage(
+		return OutcomeSkipped, repoerrors.WrapMessage(
 			repoerrors.OperationCanonicalRemote,
 			repositoryPath,
 			repoerrors.ErrGitManagerUnavailable,
@@ -137,23 +190,91 @@ func (executor *Executor) Execute(executionContext context.Context, options Opti
 		)
 	}
 
-	updateError := executor.dependencies.GitManager.SetRemoteURL(executionContext, repositoryPath, shared.OriginRemoteNameConstant, targetURL)
-	if updateError != nil {
-		executor.printfOutput(failureMessage, repositoryPath)
-		return repoerrors.WrapMessage(
+	if !originCanonical {
+		updateError := executor.dependencies.GitManager.SetRemoteURL(executionContext, repositoryPath, shared.OriginRemoteNameConstant, targetURL)
+		if updateError != nil {
+			executor.printfOutput(failureMessage, repositoryPath)
+			return OutcomeSkipped, repoerrors.WrapMessage(
+				repoerrors.OperationCanonicalRemote,
+				repositoryPath,
+				repoerrors.ErrRemoteUpdateFailed,
+				fmt.Sprintf(failureMessage, repositoryPath),
+			)
+		}
+		executor.printfOutput(successMessage, repositoryPath, targetURL)
+		executor.handleStaleCredentials(executionContext, repositoryPath, staleCredentialHost, currentOriginURL, targetURL, options.EraseStaleCredentials)
+	}
+
+	if !pushOutdated {
+		return OutcomeApplied, nil
+	}
+	pushURLManager, supportsPushURL := executor.dependencies.GitManager.(shared.GitRemotePushURLManager)
+	if !supportsPushURL || pushURLManager.SetRemotePushURL(executionContext, repositoryPath, shared.OriginRemoteNameConstant, targetURL) != nil {
+		executor.printfOutput(failurePushMessage, repositoryPath)
+		return OutcomeSkipped, repoerrors.WrapMessage(
 			repoerrors.OperationCanonicalRemote,
 			repositoryPath,
 			repoerrors.ErrRemoteUpdateFailed,
-			fmt.Sprintf(failureMessage, repositoryPath),
+			fmt.Sprintf(failurePushMessage, repositoryPath),
 		)
 	}
+	executor.printfOutput(successPushMessage, repositoryPath, targetURL)
+	return OutcomeApplied, nil
+}
+
+// pushURLOutdated reports whether a separately configured push URL names a repository other than the canonical one.
+func pushURLOutdated(options Options, canonicalOwner string) bool {
+	if !options.IncludePushURL || options.CurrentPushURL == nil || options.PushOwnerRepository == nil {
+		return false
+	}
+	if options.CurrentOriginURL != nil && options.CurrentPushURL.String() == options.CurrentOriginURL.String() {
+		return false
+	}
+	return !strings.EqualFold(options.PushOwnerRepository.String(), canonicalOwner)
+}
 
-	executor.printfOutput(successMessage, repositoryPath, targetURL)
-	return nil
+// handleStaleCredentials erases or reports the stored credentials of the origin URL that moved away from staleHost.
+func (executor *Executor) handleStaleCredentials(executionContext context.Context, repositoryPath string, staleHost string, staleURL string, targetURL string, erase bool) {
+	if len(staleHost) == 0 {
+		return
+	}
+	rejectCommand := gitrepo.CredentialRejectCommand(staleURL)
+	if !erase {
+		executor.printfOutput(credentialHintMessage, repositoryPath, staleHost, remoteHost(targetURL), staleHost, rejectCommand)
+		return
+	}
+	credentialRejecter, supportsRejection := executor.dependencies.GitManager.(shared.GitCredentialRejecter)
+	if !supportsRejection || credentialRejecter.RejectRemoteCredentials(executionContext, repositoryPath, staleURL) != nil {
+		executor.printfOutput(credentialEraseFailedMessage, repositoryPath, staleHost, rejectCommand)
+		return
+	}
+	executor.printfOutput(credentialErasedMessage, repositoryPath, staleHost)
+}
+
+// staleCredentialHost returns the host whose stored credentials become stale when an HTTPS origin moves to another
+// host, or an empty string when the host is unchanged. SSH origins authenticate with keys, so they have none.
+func staleCredentialHost(currentOriginURL string, targetURL string) string {
+	currentRemote, currentParseError := gitrepo.ParseRemoteURL(currentOriginURL)
+	if currentParseError != nil || currentRemote.Protocol != gitrepo.RemoteProtocolHTTPS {
+		return ""
+	}
+	targetHost := remoteHost(targetURL)
+	if len(targetHost) == 0 || strings.EqualFold(currentRemote.Host, targetHost) {
+		return ""
+	}
+	return currentRemote.Host
+}
+
+func remoteHost(remoteURL string) string {
+	parsedRemote, parseError := gitrepo.ParseRemoteURL(remoteURL)
+	if parseError != nil {
+		return ""
+	}
+	return parsedRemote.Host
 }
 
 // Execute performs the remote update workflow using transient executor state.
-func Execute(executionContext context.Context, dependencies Dependencies, options Options) error {
+func Execute(executionContext context.Context, dependencies Dependencies, options Options) (Outcome, error) {
 	return NewExecutor(dependencies).Execute(executionContext, options)
 }
 
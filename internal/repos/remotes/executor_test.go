@@ -15,8 +15,21 @@ import (
 )
 
 type stubGitManager struct {
-	urlsSet  []string
-	setError error
+	urlsSet      []string
+	pushURLsSet  []string
+	setError     error
+	rejectedURLs []string
+	rejectError  error
+}
+
+func (manager *stubGitManager) RejectRemoteCredentials(ctx context.Context, repositoryPath string, remoteURL string) error {
+	manager.rejectedURLs = append(manager.rejectedURLs, remoteURL)
+	return manager.rejectError
+}
+
+func (manager *stubGitManager) SetRemotePushURL(ctx context.Context, repositoryPath string, remoteName string, remoteURL string) error {
+	manager.pushURLsSet = append(manager.pushURLsSet, remoteURL)
+	return nil
 }
 
 func (manager *stubGitManager) CheckCleanWorktree(ctx context.Context, repositoryPath string) (bool, error) {
@@ -89,6 +102,7 @@ func TestExecutorBehaviors(t *testing.T) {
 		expectedError    repoerrors.Sentinel
 		expectedUpdates  int
 		expectPromptCall bool
+		expectedOutcome  remotes.Outcome
 	}{
 		{
 			name: "skip_missing_origin",
@@ -132,6 +146,7 @@ func TestExecutorBehaviors(t *testing.T) {
 				remotesTestCurrentOriginURL,
 				remotesTestCanonicalURL,
 			),
+			expectedOutcome: remotes.OutcomePlanned,
 		},
 		{
 			name: "prompter_declines",
@@ -146,6 +161,7 @@ func TestExecutorBehaviors(t *testing.T) {
 			prompter:         &stubPrompter{result: shared.ConfirmationResult{Confirmed: false}},
 			expectedOutput:   fmt.Sprintf(remotesTestDeclinedMessage, remotesTestRepositoryPath),
 			expectPromptCall: true,
+			expectedOutcome:  remotes.OutcomeDeclined,
 		},
 		{
 			name: "prompter_accepts_once",
@@ -161,6 +177,7 @@ func TestExecutorBehaviors(t *testing.T) {
 			expectedOutput:   fmt.Sprintf(remotesTestSuccessMessage, remotesTestRepositoryPath, remotesTestCanonicalURL),
 			expectedUpdates:  1,
 			expectPromptCall: true,
+			expectedOutcome:  remotes.OutcomeApplied,
 		},
 		{
 			name: "prompter_accepts_all",
@@ -176,6 +193,7 @@ func TestExecutorBehaviors(t *testing.T) {
 			expectedOutput:   fmt.Sprintf(remotesTestSuccessMessage, remotesTestRepositoryPath, remotesTestCanonicalURL),
 			expectedUpdates:  1,
 			expectPromptCall: true,
+			expectedOutcome:  remotes.OutcomeApplied,
 		},
 		{
 			name: "prompter_error_returns_contextual_error",
@@ -205,6 +223,7 @@ func TestExecutorBehaviors(t *testing.T) {
 			gitManager:      &stubGitManager{},
 			expectedOutput:  fmt.Sprintf(remotesTestSuccessMessage, remotesTestRepositoryPath, remotesTestCanonicalURL),
 			expectedUpdates: 1,
+			expectedOutcome: remotes.OutcomeApplied,
 		},
 		{
 			name: "remote_update_failure_returns_error",
@@ -231,7 +250,8 @@ func TestExecutorBehaviors(t *testing.T) {
 				Reporter:   shared.NewWriterReporter(outputBuffer),
 			})
 
-			executionError := executor.Execute(context.Background(), testCase.options)
+			outcome, executionError := executor.Execute(context.Background(), testCase.options)
+			require.Equal(testingInstance, testCase.expectedOutcome, outcome)
 
 			if testCase.expectedError != "" {
 				require.Error(testingInstance, executionError)
@@ -262,6 +282,209 @@ func TestExecutorBehaviors(t *testing.T) {
 	}
 }
 
+func TestExecutorIncludePushURL(t *testing.T) {
+	repositoryPath, repositoryPathError := shared.NewRepositoryPath(remotesTestRepositoryPath)
+	require.NoError(t, repositoryPathError)
+	originURL, originURLError := shared.NewRemoteURL(remotesTestCurrentOriginURL)
+	require.NoError(t, originURLError)
+	canonicalURL, canonicalURLError := shared.NewRemoteURL(remotesTestCanonicalURL)
+	require.NoError(t, canonicalURLError)
+	forkPushURL, forkPushURLError := shared.NewRemoteURL("git@github.com:fork/example.git")
+	require.NoError(t, forkPushURLError)
+	originOwnerRepository, originOwnerError := shared.NewOwnerRepository(remotesTestOriginOwnerRepository)
+	require.NoError(t, originOwnerError)
+	canonicalOwnerRepository, canonicalOwnerError := shared.NewOwnerRepository(remotesTestCanonicalOwnerRepo)
+	require.NoError(t, canonicalOwnerError)
+	forkOwnerRepository, forkOwnerError := shared.NewOwnerRepository("fork/example")
+	require.NoError(t, forkOwnerError)
+
+	testCases := []struct {
+		name                string
+		options             remotes.Options
+		expectedOutput      string
+		expectedUpdates     []string
+		expectedPushUpdates []string
+	}{
+		{
+			name: "push_url_updated_when_origin_canonical",
+			options: remotes.Options{
+				CurrentOriginURL:      cloneRemoteURL(canonicalURL),
+				OriginOwnerRepository: cloneOwnerRepository(canonicalOwnerRepository),
+				CurrentPushURL:        cloneRemoteURL(forkPushURL),
+				PushOwnerRepository:   cloneOwnerRepository(forkOwnerRepository),
+				IncludePushURL:        true,
+			},
+			expectedOutput:      fmt.Sprintf("UPDATE-REMOTE-DONE: %s origin push now %s\n", remotesTestRepositoryPath, remotesTestCanonicalURL),
+			expectedPushUpdates: []string{remotesTestCanonicalURL},
+		},
+		{
+			name: "both_urls_updated",
+			options: remotes.Options{
+				CurrentOriginURL:      cloneRemoteURL(originURL),
+				OriginOwnerRepository: cloneOwnerRepository(originOwnerRepository),
+				CurrentPushURL:        cloneRemoteURL(forkPushURL),
+				PushOwnerRepository:   cloneOwnerRepository(forkOwnerRepository),
+				IncludePushURL:        true,
+			},
+			expectedOutput: fmt.Sprintf(remotesTestSuccessMessage, remotesTestRepositoryPath, remotesTestCanonicalURL) +
+				fmt.Sprintf("UPDATE-REMOTE-DONE: %s origin push now %s\n", remotesTestRepositoryPath, remotesTestCanonicalURL),
+			expectedUpdates:     []string{remotesTestCanonicalURL},
+			expectedPushUpdates: []string{remotesTestCanonicalURL},
+		},
+		{
+			name: "dry_run_plans_both_urls",
+			options: remotes.Options{
+				CurrentOriginURL:      cloneRemoteURL(originURL),
+				OriginOwnerRepository: cloneOwnerRepository(originOwnerRepository),
+				CurrentPushURL:        cloneRemoteURL(forkPushURL),
+				PushOwnerRepository:   cloneOwnerRepository(forkOwnerRepository),
+				IncludePushURL:        true,
+				DryRun:                true,
+			},
+			expectedOutput: fmt.Sprintf(remotesTestPlanMessage, remotesTestRepositoryPath, remotesTestCurrentOriginURL, remotesTestCanonicalURL) +
+				fmt.Sprintf("PLAN-UPDATE-REMOTE: %s origin push %s → %s\n", remotesTestRepositoryPath, "git@github.com:fork/example.git", remotesTestCanonicalURL),
+		},
+		{
+			name: "push_url_ignored_without_flag",
+			options: remotes.Options{
+				CurrentOriginURL:      cloneRemoteURL(canonicalURL),
+				OriginOwnerRepository: cloneOwnerRepository(canonicalOwnerRepository),
+				CurrentPushURL:        cloneRemoteURL(forkPushURL),
+				PushOwnerRepository:   cloneOwnerRepository(forkOwnerRepository),
+			},
+			expectedOutput: fmt.Sprintf("UPDATE-REMOTE-SKIP: %s (already canonical)\n", remotesTestRepositoryPath),
+		},
+		{
+			name: "push_url_following_fetch_url_left_alone",
+			options: remotes.Options{
+				CurrentOriginURL:      cloneRemoteURL(originURL),
+				OriginOwnerRepository: cloneOwnerRepository(originOwnerRepository),
+				CurrentPushURL:        cloneRemoteURL(originURL),
+				PushOwnerRepository:   cloneOwnerRepository(originOwnerRepository),
+				IncludePushURL:        true,
+			},
+			expectedOutput:  fmt.Sprintf(remotesTestSuccessMessage, remotesTestRepositoryPath, remotesTestCanonicalURL),
+			expectedUpdates: []string{remotesTestCanonicalURL},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(testingInstance *testing.T) {
+			outputBuffer := &bytes.Buffer{}
+			gitManager := &stubGitManager{}
+			options := testCase.options
+			options.RepositoryPath = repositoryPath
+			options.CanonicalOwnerRepository = cloneOwnerRepository(canonicalOwnerRepository)
+			options.RemoteProtocol = shared.RemoteProtocolHTTPS
+			options.ConfirmationPolicy = shared.ConfirmationAssumeYes
+
+			executor := remotes.NewExecutor(remotes.Dependencies{GitManager: gitManager, Reporter: shared.NewWriterReporter(outputBuffer)})
+			_, executionError := executor.Execute(context.Background(), options)
+			require.NoError(testingInstance, executionError)
+			require.Equal(testingInstance, testCase.expectedOutput, outputBuffer.String())
+			require.Equal(testingInstance, testCase.expectedUpdates, gitManager.urlsSet)
+			require.Equal(testingInstance, testCase.expectedPushUpdates, gitManager.pushURLsSet)
+		})
+	}
+}
+
+func TestExecutorStaleCredentials(t *testing.T) {
+	const (
+		enterpriseOriginURL = "https://git.example.com/origin/example.git"
+		rejectCommand       = `printf 'protocol=https\nhost=git.example.com\npath=origin/example.git\n\n' | git credential reject`
+	)
+	repositoryPath, repositoryPathError := shared.NewRepositoryPath(remotesTestRepositoryPath)
+	require.NoError(t, repositoryPathError)
+	originOwnerRepository, originOwnerError := shared.NewOwnerRepository(remotesTestOriginOwnerRepository)
+	require.NoError(t, originOwnerError)
+	canonicalOwnerRepository, canonicalOwnerError := shared.NewOwnerRepository(remotesTestCanonicalOwnerRepo)
+	require.NoError(t, canonicalOwnerError)
+
+	testCases := []struct {
+		name                 string
+		currentOriginURL     string
+		protocol             shared.RemoteProtocol
+		eraseCredentials     bool
+		dryRun               bool
+		rejectError          error
+		expectedOutput       string
+		expectedRejectedURLs []string
+	}{
+		{
+			name:             "host_change_prints_hint",
+			currentOriginURL: enterpriseOriginURL,
+			protocol:         shared.RemoteProtocolHTTPS,
+			expectedOutput: fmt.Sprintf(remotesTestSuccessMessage, remotesTestRepositoryPath, remotesTestCanonicalURL) +
+				fmt.Sprintf("UPDATE-REMOTE-CREDENTIALS: %s host changed git.example.com → github.com; cached credentials for git.example.com can cause authentication failures, erase them with: %s\n", remotesTestRepositoryPath, rejectCommand),
+		},
+		{
+			name:             "host_change_erases_credentials",
+			currentOriginURL: enterpriseOriginURL,
+			protocol:         shared.RemoteProtocolSSH,
+			eraseCredentials: true,
+			expectedOutput: fmt.Sprintf(remotesTestSuccessMessage, remotesTestRepositoryPath, "ssh://git@github.com/canonical/example.git") +
+				fmt.Sprintf("UPDATE-REMOTE-CREDENTIALS: %s erased stored credentials for git.example.com\n", remotesTestRepositoryPath),
+			expectedRejectedURLs: []string{enterpriseOriginURL},
+		},
+		{
+			name:             "erase_failure_falls_back_to_hint",
+			currentOriginURL: enterpriseOriginURL,
+			protocol:         shared.RemoteProtocolHTTPS,
+			eraseCredentials: true,
+			rejectError:      stdErrors.New("helper failed"),
+			expectedOutput: fmt.Sprintf(remotesTestSuccessMessage, remotesTestRepositoryPath, remotesTestCanonicalURL) +
+				fmt.Sprintf("UPDATE-REMOTE-CREDENTIALS: %s (error: failed to erase credentials for git.example.com) erase them with: %s\n", remotesTestRepositoryPath, rejectCommand),
+			expectedRejectedURLs: []string{enterpriseOriginURL},
+		},
+		{
+			name:             "dry_run_plans_erasure",
+			currentOriginURL: enterpriseOriginURL,
+			protocol:         shared.RemoteProtocolHTTPS,
+			eraseCredentials: true,
+			dryRun:           true,
+			expectedOutput: fmt.Sprintf(remotesTestPlanMessage, remotesTestRepositoryPath, enterpriseOriginURL, remotesTestCanonicalURL) +
+				fmt.Sprintf("PLAN-ERASE-CREDENTIALS: %s credentials for git.example.com\n", remotesTestRepositoryPath),
+		},
+		{
+			name:             "same_host_has_no_hint",
+			currentOriginURL: remotesTestCurrentOriginURL,
+			protocol:         shared.RemoteProtocolHTTPS,
+			eraseCredentials: true,
+			expectedOutput:   fmt.Sprintf(remotesTestSuccessMessage, remotesTestRepositoryPath, remotesTestCanonicalURL),
+		},
+		{
+			name:             "ssh_origin_has_no_credentials",
+			currentOriginURL: "git@git.example.com:origin/example.git",
+			protocol:         shared.RemoteProtocolHTTPS,
+			expectedOutput:   fmt.Sprintf(remotesTestSuccessMessage, remotesTestRepositoryPath, remotesTestCanonicalURL),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(testingInstance *testing.T) {
+			currentOriginURL, currentOriginURLError := shared.NewRemoteURL(testCase.currentOriginURL)
+			require.NoError(testingInstance, currentOriginURLError)
+			outputBuffer := &bytes.Buffer{}
+			gitManager := &stubGitManager{rejectError: testCase.rejectError}
+
+			executor := remotes.NewExecutor(remotes.Dependencies{GitManager: gitManager, Reporter: shared.NewWriterReporter(outputBuffer)})
+			_, executionError := executor.Execute(context.Background(), remotes.Options{
+				RepositoryPath:           repositoryPath,
+				CurrentOriginURL:         &currentOriginURL,
+				OriginOwnerRepository:    cloneOwnerRepository(originOwnerRepository),
+				CanonicalOwnerRepository: cloneOwnerRepository(canonicalOwnerRepository),
+				RemoteProtocol:           testCase.protocol,
+				DryRun:                   testCase.dryRun,
+				ConfirmationPolicy:       shared.ConfirmationAssumeYes,
+				EraseStaleCredentials:    testCase.eraseCredentials,
+			})
+			require.NoError(testingInstance, executionError)
+			require.Equal(testingInstance, testCase.expectedOutput, outputBuffer.String())
+			require.Equal(testingInstance, testCase.expectedRejectedURLs, gitManager.rejectedURLs)
+		})
+	}
+}
+
 func cloneOwnerRepository(value shared.OwnerRepository) *shared.OwnerRepository {
 	clone := value
 	return &clone
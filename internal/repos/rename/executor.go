@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/temirov/gix/internal/gitrepo"
 	repoerrors "github.com/temirov/gix/internal/repos/errors"
 	"github.com/temirov/gix/internal/repos/shared"
 )
@@ -14,6 +15,8 @@ import (
 const (
 	planSkipAlreadyMessage            = "PLAN-SKIP (already normalized): %s\n"
 	planSkipDirtyMessage              = "PLAN-SKIP (dirty worktree): %s\n"
+	planSkipDirtyDetailedMessage      = "PLAN-SKIP (dirty worktree: %s): %s\n"
+	planSkipInProgressMessage         = "PLAN-SKIP (%s in progress): %s\n"
 	planSkipParentMissingMessage      = "PLAN-SKIP (target parent missing): %s\n"
 	planSkipParentNotDirectoryMessage = "PLAN-SKIP (target parent not directory): %s\n"
 	planSkipExistsMessage             = "PLAN-SKIP (target exists): %s\n"
@@ -25,6 +28,8 @@ const (
 	promptTemplate                    = "Rename '%s' → '%s'? [a/N/y] "
 	skipMessage                       = "SKIP: %s\n"
 	skipDirtyMessage                  = "SKIP (dirty worktree): %s\n"
+	skipDirtyDetailedMessage          = "SKIP (dirty worktree: %s): %s\n"
+	skipInProgressMessage             = "SKIP (%s in progress): %s\n"
 	skipAlreadyNormalizedMessage      = "SKIP (already normalized): %s\n"
 	successMessage                    = "Renamed %s → %s\n"
 	failureMessage                    = "ERROR: rename failed for %s → %s\n"
@@ -45,11 +50,12 @@ type Options struct {
 
 // Dependencies supplies collaborators required to evaluate rename operations.
 type Dependencies struct {
-	FileSystem shared.FileSystem
-	GitManager shared.GitRepositoryManager
-	Prompter   shared.ConfirmationPrompter
-	Clock      shared.Clock
-	Reporter   shared.Reporter
+	FileSystem   shared.FileSystem
+	GitManager   shared.GitRepositoryManager
+	Prompter     shared.ConfirmationPrompter
+	Clock        shared.Clock
+	Reporter     shared.Reporter
+	PlanRecorder PlanRecorder
 }
 
 // Executor orchestrates rename planning and execution for repositories.
@@ -131,11 +137,19 @@ func (executor *Executor) Execute(executionContext context.Context, options Opti
 		return ensureError
 	}
 
+	var appliedEntry PlanEntry
+	if executor.dependencies.PlanRecorder != nil {
+		appliedEntry = executor.planEntry(executionContext, oldAbsolutePath, newAbsolutePath)
+	}
+
 	if renameError := executor.performRename(oldAbsolutePath, newAbsolutePath); renameError != nil {
 		return renameError
 	}
 
 	executor.printfOutput(successMessage, oldAbsolutePath, newAbsolutePath)
+	if executor.dependencies.PlanRecorder != nil {
+		executor.dependencies.PlanRecorder.RecordPlannedRename(appliedEntry)
+	}
 	return nil
 }
 
@@ -148,13 +162,24 @@ func (executor *Executor) printPlan(executionContext context.Context, oldAbsolut
 	caseOnlyRename := isCaseOnlyRename(oldAbsolutePath, newAbsolutePath)
 	parentDetails := executor.parentDirectoryDetails(newAbsolutePath)
 
-	switch {
-	case oldAbsolutePath == newAbsolutePath:
+	if oldAbsolutePath == newAbsolutePath {
 		executor.printfOutput(planSkipAlreadyMessage, oldAbsolutePath)
 		return
-	case requireClean && !executor.isClean(executionContext, oldAbsolutePath):
-		executor.printfOutput(planSkipDirtyMessage, oldAbsolutePath)
+	}
+
+	if inProgressOperation := executor.inProgressOperation(executionContext, oldAbsolutePath); inProgressOperation.Active() {
+		executor.printfOutput(planSkipInProgressMessage, inProgressOperation, oldAbsolutePath)
 		return
+	}
+
+	if requireClean {
+		if clean, dirtySummary := executor.worktreeState(executionContext, oldAbsolutePath); !clean {
+			executor.printDirty(planSkipDirtyMessage, planSkipDirtyDetailedMessage, dirtySummary, oldAbsolutePath)
+			return
+		}
+	}
+
+	switch {
 	case parentDetails.exists && !parentDetails.isDirectory:
 		executor.printfOutput(planSkipParentNotDirectoryMessage, parentDetails.path)
 		return
@@ -168,10 +193,12 @@ func (executor *Executor) printPlan(executionContext context.Context, oldAbsolut
 
 	if caseOnlyRename {
 		executor.printfOutput(planCaseOnlyMessage, oldAbsolutePath, newAbsolutePath)
+		executor.recordPlannedRename(executionContext, oldAbsolutePath, newAbsolutePath)
 		return
 	}
 
 	executor.printfOutput(planReadyMessage, oldAbsolutePath, newAbsolutePath)
+	executor.recordPlannedRename(executionContext, oldAbsolutePath, newAbsolutePath)
 }
 
 func (executor *Executor) evaluatePrerequisites(executionContext context.Context, oldAbsolutePath string, newAbsolutePath string, requireClean bool, ensureParentDirectories bool) (bool, error) {
@@ -183,11 +210,18 @@ func (executor *Executor) evaluatePrerequisites(executionContext context.Context
 		return true, nil
 	}
 
-	if requireClean && !executor.isClean(executionContext, oldAbsolutePath) {
-		executor.printfOutput(skipDirtyMessage, oldAbsolutePath)
+	if inProgressOperation := executor.inProgressOperation(executionContext, oldAbsolutePath); inProgressOperation.Active() {
+		executor.printfOutput(skipInProgressMessage, inProgressOperation, oldAbsolutePath)
 		return true, nil
 	}
 
+	if requireClean {
+		if clean, dirtySummary := executor.worktreeState(executionContext, oldAbsolutePath); !clean {
+			executor.printDirty(skipDirtyMessage, skipDirtyDetailedMessage, dirtySummary, oldAbsolutePath)
+			return true, nil
+		}
+	}
+
 	if parentDetails.exists && !parentDetails.isDirectory {
 		executor.printfOutput(errorParentNotDirectoryMessage, parentDetails.path)
 		return true, repoerrors.WrapMessage(
@@ -221,16 +255,47 @@ func (executor *Executor) evaluatePrerequisites(executionContext context.Context
 	return false, nil
 }
 
-func (executor *Executor) isClean(executionContext context.Context, repositoryPath string) bool {
+func (executor *Executor) worktreeState(executionContext context.Context, repositoryPath string) (bool, string) {
 	if executor.dependencies.GitManager == nil {
-		return false
+		return false, ""
+	}
+
+	if statusReporter, supportsStatus := executor.dependencies.GitManager.(shared.GitRepositoryStatusReporter); supportsStatus {
+		statusReport, statusError := statusReporter.Status(executionContext, repositoryPath)
+		if statusError != nil {
+			return false, ""
+		}
+		if statusReport.Clean() {
+			return true, ""
+		}
+		return false, statusReport.Summary()
 	}
 
 	clean, cleanError := executor.dependencies.GitManager.CheckCleanWorktree(executionContext, repositoryPath)
 	if cleanError != nil {
-		return false
+		return false, ""
+	}
+	return clean, ""
+}
+
+func (executor *Executor) inProgressOperation(executionContext context.Context, repositoryPath string) gitrepo.InProgressOperation {
+	inProgressReporter, supportsInProgress := executor.dependencies.GitManager.(shared.GitRepositoryInProgressReporter)
+	if !supportsInProgress {
+		return gitrepo.InProgressOperationNone
+	}
+	inProgressOperation, inProgressError := inProgressReporter.InProgressOperation(executionContext, repositoryPath)
+	if inProgressError != nil {
+		return gitrepo.InProgressOperationNone
+	}
+	return inProgressOperation
+}
+
+func (executor *Executor) printDirty(plainTemplate string, detailedTemplate string, dirtySummary string, repositoryPath string) {
+	if len(dirtySummary) == 0 {
+		executor.printfOutput(plainTemplate, repositoryPath)
+		return
 	}
-	return clean
+	executor.printfOutput(detailedTemplate, dirtySummary, repositoryPath)
 }
 
 func (executor *Executor) parentDirectoryDetails(path string) parentDirectoryInformation {
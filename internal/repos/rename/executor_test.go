@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"io/fs"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/temirov/gix/internal/gitrepo"
 	repoerrors "github.com/temirov/gix/internal/repos/errors"
 	"github.com/temirov/gix/internal/repos/rename"
 	"github.com/temirov/gix/internal/repos/shared"
@@ -25,6 +27,7 @@ type stubFileSystem struct {
 	absError           error
 	renameError        error
 	fileContents       map[string][]byte
+	removedPaths       []string
 }
 
 func (fileSystem *stubFileSystem) Stat(path string) (fs.FileInfo, error) {
@@ -47,6 +50,12 @@ func (fileSystem *stubFileSystem) Rename(oldPath string, newPath string) error {
 	}
 	fileSystem.existingPaths[newPath] = true
 	delete(fileSystem.existingPaths, oldPath)
+	for existingPath, exists := range fileSystem.existingPaths {
+		if exists && strings.HasPrefix(existingPath, oldPath+"/") {
+			fileSystem.existingPaths[newPath+strings.TrimPrefix(existingPath, oldPath)] = true
+			delete(fileSystem.existingPaths, existingPath)
+		}
+	}
 	return nil
 }
 
@@ -69,6 +78,17 @@ func (fileSystem *stubFileSystem) MkdirAll(path string, permissions fs.FileMode)
 	return nil
 }
 
+func (fileSystem *stubFileSystem) Remove(path string) error {
+	for existingPath, exists := range fileSystem.existingPaths {
+		if exists && strings.HasPrefix(existingPath, path+"/") {
+			return errors.New("directory not empty")
+		}
+	}
+	fileSystem.removedPaths = append(fileSystem.removedPaths, path)
+	delete(fileSystem.existingPaths, path)
+	return nil
+}
+
 func (fileSystem *stubFileSystem) ReadFile(path string) ([]byte, error) {
 	if fileSystem.fileContents == nil {
 		fileSystem.fileContents = map[string][]byte{}
@@ -112,6 +132,24 @@ func (manager stubGitManager) CheckCleanWorktree(ctx context.Context, repository
 	return manager.clean, nil
 }
 
+type statusReportingGitManager struct {
+	stubGitManager
+	report gitrepo.StatusReport
+}
+
+func (manager statusReportingGitManager) Status(context.Context, string) (gitrepo.StatusReport, error) {
+	return manager.report, nil
+}
+
+type inProgressGitManager struct {
+	stubGitManager
+	operation gitrepo.InProgressOperation
+}
+
+func (manager inProgressGitManager) InProgressOperation(context.Context, string) (gitrepo.InProgressOperation, error) {
+	return manager.operation, nil
+}
+
 func (manager stubGitManager) GetCurrentBranch(ctx context.Context, repositoryPath string) (string, error) {
 	return "", nil
 }
@@ -329,6 +367,62 @@ func TestExecutorBehaviors(testInstance *testing.T) {
 			expectedOutput:  fmt.Sprintf("SKIP (dirty worktree): %s\n", renameTestProjectFolderPath),
 			expectedRenames: 0,
 		},
+		{
+			name: "skip_dirty_worktree_reports_categories",
+			options: rename.Options{
+				RepositoryPath:     projectPath,
+				DesiredFolderName:  renameTestDesiredFolderName,
+				CleanPolicy:        shared.CleanWorktreeRequired,
+				ConfirmationPolicy: shared.ConfirmationAssumeYes,
+			},
+			fileSystem: &stubFileSystem{
+				existingPaths: map[string]bool{
+					renameTestRootDirectory:     true,
+					renameTestProjectFolderPath: true,
+				},
+			},
+			gitManager: statusReportingGitManager{
+				stubGitManager: stubGitManager{clean: true},
+				report:         gitrepo.StatusReport{Staged: []string{"main.go"}, Untracked: []string{"notes.txt", "tmp.log"}},
+			},
+			expectedOutput:  fmt.Sprintf("SKIP (dirty worktree: 1 staged, 2 untracked): %s\n", renameTestProjectFolderPath),
+			expectedRenames: 0,
+		},
+		{
+			name: "skip_in_progress_operation",
+			options: rename.Options{
+				RepositoryPath:     projectPath,
+				DesiredFolderName:  renameTestDesiredFolderName,
+				ConfirmationPolicy: shared.ConfirmationAssumeYes,
+			},
+			fileSystem: &stubFileSystem{
+				existingPaths: map[string]bool{
+					renameTestRootDirectory:     true,
+					renameTestProjectFolderPath: true,
+				},
+			},
+			gitManager:      inProgressGitManager{stubGitManager: stubGitManager{clean: true}, operation: gitrepo.InProgressOperationRebase},
+			expectedOutput:  fmt.Sprintf("SKIP (rebase in progress): %s\n", renameTestProjectFolderPath),
+			expectedRenames: 0,
+		},
+		{
+			name: "plan_skip_in_progress_operation",
+			options: rename.Options{
+				RepositoryPath:     projectPath,
+				DesiredFolderName:  renameTestDesiredFolderName,
+				DryRun:             true,
+				ConfirmationPolicy: shared.ConfirmationAssumeYes,
+			},
+			fileSystem: &stubFileSystem{
+				existingPaths: map[string]bool{
+					renameTestRootDirectory:     true,
+					renameTestProjectFolderPath: true,
+				},
+			},
+			gitManager:      inProgressGitManager{stubGitManager: stubGitManager{clean: true}, operation: gitrepo.InProgressOperationMerge},
+			expectedOutput:  fmt.Sprintf("PLAN-SKIP (merge in progress): %s\n", renameTestProjectFolderPath),
+			expectedRenames: 0,
+		},
 		{
 			name: "already_normalized_skip",
 			options: rename.Options{
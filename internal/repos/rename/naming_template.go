@@ -0,0 +1,157 @@
+package rename
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+const (
+	// DefaultNamingTemplateText names a directory after the repository alone, preserving the historical layout.
+	DefaultNamingTemplateText = "{{.Name}}"
+	// OwnerNamingTemplateText nests the repository directory under its owner; the --owner flag is shorthand for it.
+	OwnerNamingTemplateText = "{{.Owner}}/{{.Name}}"
+
+	namingTemplateName                     = "naming_template"
+	namingTemplateSeparatorCharacters      = "/\\"
+	namingTemplatePlaceholderValue         = "placeholder"
+	namingTemplateParseErrorTemplate       = "invalid naming template %q: %w"
+	namingTemplateRenderErrorTemplate      = "naming template %q: %w"
+	namingTemplateEmptyResultMessage       = "rendered an empty directory name"
+	namingTemplateInjectedSeparatorMessage = "produced a path separator the template does not contain"
+	namingTemplateInvalidSegmentTemplate   = "rendered an invalid path segment %q"
+)
+
+// NamingTemplateFields exposes the repository attributes available to naming templates.
+type NamingTemplateFields struct {
+	Owner string
+	Name  string
+	Host  string
+}
+
+// NamingTemplate renders target directory names from repository attributes.
+// Path separators are only allowed where the template text itself contains them.
+type NamingTemplate struct {
+	text     string
+	template *template.Template
+}
+
+// ParseNamingTemplate validates the template text and returns a reusable NamingTemplate.
+// Blank text yields the default repository-name template.
+func ParseNamingTemplate(text string) (NamingTemplate, error) {
+	trimmedText := strings.TrimSpace(text)
+	if len(trimmedText) == 0 {
+		trimmedText = DefaultNamingTemplateText
+	}
+
+	parsedTemplate, parseError := template.New(namingTemplateName).Option("missingkey=error").Parse(trimmedText)
+	if parseError != nil {
+		return NamingTemplate{}, fmt.Errorf(namingTemplateParseErrorTemplate, trimmedText, parseError)
+	}
+
+	namingTemplate := NamingTemplate{text: trimmedText, template: parsedTemplate}
+	placeholderFields := NamingTemplateFields{Owner: namingTemplatePlaceholderValue, Name: namingTemplatePlaceholderValue, Host: namingTemplatePlaceholderValue}
+	if _, renderError := namingTemplate.Render(placeholderFields); renderError != nil {
+		return NamingTemplate{}, fmt.Errorf(namingTemplateParseErrorTemplate, trimmedText, errors.Unwrap(renderError))
+	}
+	return namingTemplate, nil
+}
+
+// Text returns the template source.
+func (namingTemplate NamingTemplate) Text() string {
+	if len(namingTemplate.text) == 0 {
+		return DefaultNamingTemplateText
+	}
+	return namingTemplate.text
+}
+
+// IsDefault reports whether the template reproduces the repository-name layout.
+func (namingTemplate NamingTemplate) IsDefault() bool {
+	return namingTemplate.Text() == DefaultNamingTemplateText
+}
+
+// IsOwner reports whether the template reproduces the owner/name layout selected by --owner.
+func (namingTemplate NamingTemplate) IsOwner() bool {
+	return namingTemplate.Text() == OwnerNamingTemplateText
+}
+
+// Render produces the relative directory path for the provided fields.
+// Separators contributed by field values are rejected, as are empty, "." and ".." segments.
+func (namingTemplate NamingTemplate) Render(fields NamingTemplateFields) (string, error) {
+	if namingTemplate.template == nil {
+		parsedTemplate, parseError := ParseNamingTemplate(namingTemplate.text)
+		if parseError != nil {
+			return "", parseError
+		}
+		namingTemplate = parsedTemplate
+	}
+
+	rendered, renderError := namingTemplate.execute(fields)
+	if renderError != nil {
+		return "", fmt.Errorf(namingTemplateRenderErrorTemplate, namingTemplate.text, renderError)
+	}
+
+	separatorFreeFields := NamingTemplateFields{
+		Owner: stripNamingSeparators(fields.Owner),
+		Name:  stripNamingSeparators(fields.Name),
+		Host:  stripNamingSeparators(fields.Host),
+	}
+	literalRendered, literalError := namingTemplate.execute(separatorFreeFields)
+	if literalError != nil {
+		return "", fmt.Errorf(namingTemplateRenderErrorTemplate, namingTemplate.text, literalError)
+	}
+	if countNamingSeparators(rendered) != countNamingSeparators(literalRendered) {
+		return "", fmt.Errorf(namingTemplateRenderErrorTemplate, namingTemplate.text, errors.New(namingTemplateInjectedSeparatorMessage))
+	}
+
+	trimmedRendered := strings.TrimSpace(rendered)
+	if len(trimmedRendered) == 0 {
+		return "", fmt.Errorf(namingTemplateRenderErrorTemplate, namingTemplate.text, errors.New(namingTemplateEmptyResultMessage))
+	}
+
+	segments := strings.FieldsFunc(trimmedRendered, isNamingSeparator)
+	if len(segments) != countNamingSeparators(trimmedRendered)+1 {
+		return "", fmt.Errorf(namingTemplateRenderErrorTemplate, namingTemplate.text, fmt.Errorf(namingTemplateInvalidSegmentTemplate, trimmedRendered))
+	}
+	for _, segment := range segments {
+		trimmedSegment := strings.TrimSpace(segment)
+		if len(trimmedSegment) == 0 || trimmedSegment == "." || trimmedSegment == ".." {
+			return "", fmt.Errorf(namingTemplateRenderErrorTemplate, namingTemplate.text, fmt.Errorf(namingTemplateInvalidSegmentTemplate, segment))
+		}
+	}
+
+	return filepath.Join(segments...), nil
+}
+
+func (namingTemplate NamingTemplate) execute(fields NamingTemplateFields) (string, error) {
+	var builder strings.Builder
+	if executeError := namingTemplate.template.Execute(&builder, fields); executeError != nil {
+		return "", executeError
+	}
+	return builder.String(), nil
+}
+
+func isNamingSeparator(character rune) bool {
+	return strings.ContainsRune(namingTemplateSeparatorCharacters, character)
+}
+
+func countNamingSeparators(value string) int {
+	count := 0
+	for _, character := range value {
+		if isNamingSeparator(character) {
+			count++
+		}
+	}
+	return count
+}
+
+func stripNamingSeparators(value string) string {
+	return strings.Map(func(character rune) rune {
+		if isNamingSeparator(character) {
+			return -1
+		}
+		return character
+	}, value)
+}
@@ -0,0 +1,91 @@
+package rename_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/repos/rename"
+)
+
+func TestParseNamingTemplate(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		text          string
+		expectedText  string
+		expectedError string
+	}{
+		{name: "blank_uses_default", text: "  ", expectedText: rename.DefaultNamingTemplateText},
+		{name: "flat_owner_layout", text: "{{.Owner}}__{{.Name}}", expectedText: "{{.Owner}}__{{.Name}}"},
+		{name: "syntax_error", text: "{{.Owner", expectedError: `invalid naming template "{{.Owner": template: naming_template:1: unclosed action`},
+		{name: "unknown_field", text: "{{.Branch}}", expectedError: `invalid naming template "{{.Branch}}": template: naming_template:1:2: executing "naming_template" at <.Branch>: can't evaluate field Branch in type rename.NamingTemplateFields`},
+		{name: "empty_result", text: "{{if false}}x{{end}}", expectedError: `invalid naming template "{{if false}}x{{end}}": rendered an empty directory name`},
+		{name: "parent_segment", text: "../{{.Name}}", expectedError: `invalid naming template "../{{.Name}}": rendered an invalid path segment ".."`},
+		{name: "absolute_path", text: "/{{.Name}}", expectedError: `invalid naming template "/{{.Name}}": rendered an invalid path segment "/placeholder"`},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			namingTemplate, parseError := rename.ParseNamingTemplate(testCase.text)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, parseError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, parseError)
+			require.Equal(subtest, testCase.expectedText, namingTemplate.Text())
+		})
+	}
+}
+
+func TestNamingTemplateRender(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		text           string
+		fields         rename.NamingTemplateFields
+		expectedFolder string
+		expectedError  string
+	}{
+		{
+			name:           "flat_owner_layout",
+			text:           "{{.Owner}}__{{.Name}}",
+			fields:         rename.NamingTemplateFields{Owner: "owner", Name: "example"},
+			expectedFolder: "owner__example",
+		},
+		{
+			name:           "template_separator_allowed",
+			text:           "{{.Host}}/{{.Owner}}/{{.Name}}",
+			fields:         rename.NamingTemplateFields{Owner: "owner", Name: "example", Host: "github.com"},
+			expectedFolder: filepath.Join("github.com", "owner", "example"),
+		},
+		{
+			name:          "field_separator_rejected",
+			text:          "{{.Owner}}__{{.Name}}",
+			fields:        rename.NamingTemplateFields{Owner: "owner", Name: "nested/example"},
+			expectedError: `naming template "{{.Owner}}__{{.Name}}": produced a path separator the template does not contain`,
+		},
+		{
+			name:          "missing_segment_rejected",
+			text:          "{{.Host}}/{{.Name}}",
+			fields:        rename.NamingTemplateFields{Name: "example"},
+			expectedError: `naming template "{{.Host}}/{{.Name}}": rendered an invalid path segment "/example"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			namingTemplate, parseError := rename.ParseNamingTemplate(testCase.text)
+			require.NoError(subtest, parseError)
+
+			folderName, renderError := namingTemplate.Render(testCase.fields)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, renderError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, renderError)
+			require.Equal(subtest, testCase.expectedFolder, folderName)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package rename
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	repoerrors "github.com/temirov/gix/internal/repos/errors"
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+const (
+	planFilePermissionsConstant         = fs.FileMode(0o644)
+	gitMetadataDirectoryNameConstant    = ".git"
+	planFileReadErrorTemplateConstant   = "failed to read rename plan %s: %w"
+	planFileParseErrorTemplateConstant  = "failed to parse rename plan %s: %w"
+	planFileWriteErrorTemplateConstant  = "failed to write rename plan %s: %w"
+	planFileEncodeErrorTemplateConstant = "failed to encode rename plan: %w"
+	planFileInvalidEntryTemplate        = "rename plan %s entry %d requires source and target"
+	applySkipMessageTemplate            = "APPLY-SKIP (%s): %s → %s\n"
+	applySkipSourceMissingReason        = "source missing"
+	applySkipNotRepositoryReason        = "source is not a git repository"
+	applySkipOriginUnavailableReason    = "origin unavailable"
+	applySkipOriginChangedReason        = "origin changed"
+	applySkipTargetExistsReason         = "target exists"
+	applySkipTargetParentReason         = "target parent not directory"
+	applySkipDeclinedReason             = "declined"
+)
+
+var errPlanFileSystemUnavailable = errors.New("rename plan requires a filesystem")
+
+// PlanEntry records one planned directory rename together with the origin observed while planning.
+type PlanEntry struct {
+	Source string `yaml:"source"`
+	Target string `yaml:"target"`
+	Origin string `yaml:"origin,omitempty"`
+}
+
+// PlanFile is the YAML document written by --plan-file and consumed by --apply-plan.
+type PlanFile struct {
+	Renames []PlanEntry `yaml:"renames"`
+}
+
+// PlanRecorder receives renames that passed dry-run planning, or that were applied when the run is not a dry run.
+type PlanRecorder interface {
+	RecordPlannedRename(entry PlanEntry)
+}
+
+// WritePlanFile serializes the plan to the provided path.
+func WritePlanFile(fileSystem shared.FileSystem, path string, plan PlanFile) error {
+	if fileSystem == nil {
+		return errPlanFileSystemUnavailable
+	}
+	if plan.Renames == nil {
+		plan.Renames = []PlanEntry{}
+	}
+	encoded, encodeError := yaml.Marshal(plan)
+	if encodeError != nil {
+		return fmt.Errorf(planFileEncodeErrorTemplateConstant, encodeError)
+	}
+	if writeError := fileSystem.WriteFile(path, encoded, planFilePermissionsConstant); writeError != nil {
+		return fmt.Errorf(planFileWriteErrorTemplateConstant, path, writeError)
+	}
+	return nil
+}
+
+// ReadPlanFile loads and validates a plan previously written by WritePlanFile.
+func ReadPlanFile(fileSystem shared.FileSystem, path string) (PlanFile, error) {
+	if fileSystem == nil {
+		return PlanFile{}, errPlanFileSystemUnavailable
+	}
+	contents, readError := fileSystem.ReadFile(path)
+	if readError != nil {
+		return PlanFile{}, fmt.Errorf(planFileReadErrorTemplateConstant, path, readError)
+	}
+	var plan PlanFile
+	if parseError := yaml.Unmarshal(contents, &plan); parseError != nil {
+		return PlanFile{}, fmt.Errorf(planFileParseErrorTemplateConstant, path, parseError)
+	}
+	for entryIndex := range plan.Renames {
+		plan.Renames[entryIndex].Source = strings.TrimSpace(plan.Renames[entryIndex].Source)
+		plan.Renames[entryIndex].Target = strings.TrimSpace(plan.Renames[entryIndex].Target)
+		plan.Renames[entryIndex].Origin = strings.TrimSpace(plan.Renames[entryIndex].Origin)
+		if len(plan.Renames[entryIndex].Source) == 0 || len(plan.Renames[entryIndex].Target) == 0 {
+			return PlanFile{}, fmt.Errorf(planFileInvalidEntryTemplate, path, entryIndex+1)
+		}
+	}
+	return plan, nil
+}
+
+// ApplyPlan executes each saved rename whose preconditions still hold and reports skipped entries with reasons.
+// In dry-run mode entries are validated and reported without moving any directory.
+func (executor *Executor) ApplyPlan(executionContext context.Context, plan PlanFile, dryRun bool, confirmationPolicy shared.ConfirmationPolicy) error {
+	if executor.dependencies.FileSystem == nil {
+		return repoerrors.Wrap(repoerrors.OperationRenameDirectories, "", repoerrors.ErrFilesystemUnavailable, nil)
+	}
+
+	for _, entry := range plan.Renames {
+		sourcePath := filepath.Clean(entry.Source)
+		targetPath := filepath.Clean(entry.Target)
+
+		if skipReason := executor.planEntrySkipReason(executionContext, entry, sourcePath, targetPath); len(skipReason) > 0 {
+			executor.printfOutput(applySkipMessageTemplate, skipReason, sourcePath, targetPath)
+			continue
+		}
+
+		if dryRun {
+			executor.printfOutput(planReadyMessage, sourcePath, targetPath)
+			continue
+		}
+
+		if confirmationPolicy.ShouldPrompt() && executor.dependencies.Prompter != nil {
+			confirmationResult, promptError := executor.dependencies.Prompter.Confirm(fmt.Sprintf(promptTemplate, sourcePath, targetPath))
+			if promptError != nil {
+				return repoerrors.Wrap(repoerrors.OperationRenameDirectories, sourcePath, repoerrors.ErrUserConfirmationFailed, promptError)
+			}
+			if !confirmationResult.Confirmed {
+				executor.printfOutput(applySkipMessageTemplate, applySkipDeclinedReason, sourcePath, targetPath)
+				continue
+			}
+		}
+
+		if ensureError := executor.ensureParentDirectory(targetPath, true); ensureError != nil {
+			return ensureError
+		}
+		if renameError := executor.performRename(sourcePath, targetPath); renameError != nil {
+			return renameError
+		}
+		executor.printfOutput(successMessage, sourcePath, targetPath)
+	}
+
+	return nil
+}
+
+func (executor *Executor) planEntrySkipReason(executionContext context.Context, entry PlanEntry, sourcePath string, targetPath string) string {
+	if _, sourceError := executor.dependencies.FileSystem.Stat(sourcePath); sourceError != nil {
+		return applySkipSourceMissingReason
+	}
+	if _, gitError := executor.dependencies.FileSystem.Stat(filepath.Join(sourcePath, gitMetadataDirectoryNameConstant)); gitError != nil {
+		return applySkipNotRepositoryReason
+	}
+
+	if len(entry.Origin) > 0 {
+		if executor.dependencies.GitManager == nil {
+			return applySkipOriginUnavailableReason
+		}
+		currentOrigin, originError := executor.dependencies.GitManager.GetRemoteURL(executionContext, sourcePath, shared.OriginRemoteNameConstant)
+		if originError != nil {
+			return applySkipOriginUnavailableReason
+		}
+		if strings.TrimSpace(currentOrigin) != entry.Origin {
+			return applySkipOriginChangedReason
+		}
+	}
+
+	if executor.targetExists(targetPath) && !isCaseOnlyRename(sourcePath, targetPath) {
+		return applySkipTargetExistsReason
+	}
+	parentDetails := executor.parentDirectoryDetails(targetPath)
+	if parentDetails.exists && !parentDetails.isDirectory {
+		return applySkipTargetParentReason
+	}
+	return ""
+}
+
+func (executor *Executor) recordPlannedRename(executionContext context.Context, oldAbsolutePath string, newAbsolutePath string) {
+	if executor.dependencies.PlanRecorder == nil {
+		return
+	}
+	executor.dependencies.PlanRecorder.RecordPlannedRename(executor.planEntry(executionContext, oldAbsolutePath, newAbsolutePath))
+}
+
+// planEntry describes a rename together with the source's origin; it must be built before the source moves.
+func (executor *Executor) planEntry(executionContext context.Context, oldAbsolutePath string, newAbsolutePath string) PlanEntry {
+	entry := PlanEntry{Source: oldAbsolutePath, Target: newAbsolutePath}
+	if executor.dependencies.GitManager != nil {
+		if originURL, originError := executor.dependencies.GitManager.GetRemoteURL(executionContext, oldAbsolutePath, shared.OriginRemoteNameConstant); originError == nil {
+			entry.Origin = strings.TrimSpace(originURL)
+		}
+	}
+	return entry
+}
@@ -0,0 +1,169 @@
+package rename_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/repos/rename"
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+const (
+	planTestFilePath       = "/tmp/rename-plan.yaml"
+	planTestOriginURL      = "git@github.com:owner/example.git"
+	planTestOtherOriginURL = "git@github.com:someone/else.git"
+)
+
+type originGitManager struct {
+	stubGitManager
+	origins map[string]string
+}
+
+func (manager originGitManager) GetRemoteURL(_ context.Context, repositoryPath string, _ string) (string, error) {
+	return manager.origins[repositoryPath], nil
+}
+
+type recordingPlanRecorder struct {
+	entries []rename.PlanEntry
+}
+
+func (recorder *recordingPlanRecorder) RecordPlannedRename(entry rename.PlanEntry) {
+	recorder.entries = append(recorder.entries, entry)
+}
+
+func TestExecutorRecordsPlannedRenames(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		dryRun          bool
+		expectedRenames [][2]string
+	}{
+		{
+			name:   "dry_run_records_plan",
+			dryRun: true,
+		},
+		{
+			name:            "applied_rename_recorded",
+			expectedRenames: [][2]string{{renameTestLegacyFolderPath, renameTestTargetFolderPath}},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			recorder := &recordingPlanRecorder{}
+			fileSystem := &stubFileSystem{existingPaths: map[string]bool{
+				renameTestRootDirectory:              true,
+				renameTestLegacyFolderPath:           true,
+				renameTestLegacyFolderPath + "/.git": true,
+			}}
+			dependencies := rename.Dependencies{
+				FileSystem:   fileSystem,
+				GitManager:   originGitManager{stubGitManager: stubGitManager{clean: true}, origins: map[string]string{renameTestLegacyFolderPath: planTestOriginURL}},
+				Reporter:     shared.NewWriterReporter(&bytes.Buffer{}),
+				PlanRecorder: recorder,
+			}
+
+			executionError := rename.Execute(context.Background(), dependencies, rename.Options{
+				RepositoryPath:     mustRepositoryPath(subtest, renameTestLegacyFolderPath),
+				DesiredFolderName:  renameTestDesiredFolderName,
+				DryRun:             testCase.dryRun,
+				ConfirmationPolicy: shared.ConfirmationAssumeYes,
+			})
+			require.NoError(subtest, executionError)
+			require.Equal(subtest, []rename.PlanEntry{{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath, Origin: planTestOriginURL}}, recorder.entries)
+			require.Equal(subtest, testCase.expectedRenames, fileSystem.renamedPairs)
+		})
+	}
+}
+
+func TestPlanFileRoundTrip(testInstance *testing.T) {
+	fileSystem := &stubFileSystem{}
+	plan := rename.PlanFile{Renames: []rename.PlanEntry{{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath, Origin: planTestOriginURL}}}
+
+	require.NoError(testInstance, rename.WritePlanFile(fileSystem, planTestFilePath, plan))
+	require.Contains(testInstance, string(fileSystem.fileContents[planTestFilePath]), "renames:")
+
+	loadedPlan, readError := rename.ReadPlanFile(fileSystem, planTestFilePath)
+	require.NoError(testInstance, readError)
+	require.Equal(testInstance, plan, loadedPlan)
+
+	fileSystem.fileContents[planTestFilePath] = []byte("renames:\n  - source: /tmp/legacy\n")
+	_, invalidError := rename.ReadPlanFile(fileSystem, planTestFilePath)
+	require.Error(testInstance, invalidError)
+}
+
+func TestExecutorApplyPlan(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		entry           rename.PlanEntry
+		existingPaths   map[string]bool
+		currentOrigin   string
+		dryRun          bool
+		expectedOutput  string
+		expectedRenames [][2]string
+	}{
+		{
+			name:            "applies_valid_entry",
+			entry:           rename.PlanEntry{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath, Origin: planTestOriginURL},
+			existingPaths:   map[string]bool{renameTestLegacyFolderPath: true, renameTestLegacyFolderPath + "/.git": true, renameTestRootDirectory: true},
+			currentOrigin:   planTestOriginURL,
+			expectedOutput:  "Renamed /tmp/legacy → /tmp/example\n",
+			expectedRenames: [][2]string{{renameTestLegacyFolderPath, renameTestTargetFolderPath}},
+		},
+		{
+			name:           "dry_run_validates_without_moving",
+			entry:          rename.PlanEntry{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath, Origin: planTestOriginURL},
+			existingPaths:  map[string]bool{renameTestLegacyFolderPath: true, renameTestLegacyFolderPath + "/.git": true, renameTestRootDirectory: true},
+			currentOrigin:  planTestOriginURL,
+			dryRun:         true,
+			expectedOutput: "PLAN-OK: /tmp/legacy → /tmp/example\n",
+		},
+		{
+			name:           "skips_missing_source",
+			entry:          rename.PlanEntry{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath},
+			existingPaths:  map[string]bool{renameTestRootDirectory: true},
+			expectedOutput: "APPLY-SKIP (source missing): /tmp/legacy → /tmp/example\n",
+		},
+		{
+			name:           "skips_non_repository",
+			entry:          rename.PlanEntry{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath},
+			existingPaths:  map[string]bool{renameTestLegacyFolderPath: true, renameTestRootDirectory: true},
+			expectedOutput: "APPLY-SKIP (source is not a git repository): /tmp/legacy → /tmp/example\n",
+		},
+		{
+			name:           "skips_changed_origin",
+			entry:          rename.PlanEntry{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath, Origin: planTestOriginURL},
+			existingPaths:  map[string]bool{renameTestLegacyFolderPath: true, renameTestLegacyFolderPath + "/.git": true, renameTestRootDirectory: true},
+			currentOrigin:  planTestOtherOriginURL,
+			expectedOutput: "APPLY-SKIP (origin changed): /tmp/legacy → /tmp/example\n",
+		},
+		{
+			name:           "skips_occupied_target",
+			entry:          rename.PlanEntry{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath},
+			existingPaths:  map[string]bool{renameTestLegacyFolderPath: true, renameTestLegacyFolderPath + "/.git": true, renameTestTargetFolderPath: true},
+			expectedOutput: "APPLY-SKIP (target exists): /tmp/legacy → /tmp/example\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			outputBuffer := &bytes.Buffer{}
+			fileSystem := &stubFileSystem{existingPaths: testCase.existingPaths}
+			executor := rename.NewExecutor(rename.Dependencies{
+				FileSystem: fileSystem,
+				GitManager: originGitManager{origins: map[string]string{renameTestLegacyFolderPath: testCase.currentOrigin}},
+				Reporter:   shared.NewWriterReporter(outputBuffer),
+				Clock:      stubClock{},
+			})
+
+			applyError := executor.ApplyPlan(context.Background(), rename.PlanFile{Renames: []rename.PlanEntry{testCase.entry}}, testCase.dryRun, shared.ConfirmationPolicyFromBool(true))
+			require.NoError(subtest, applyError)
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+			require.Equal(subtest, testCase.expectedRenames, fileSystem.renamedPairs)
+		})
+	}
+}
@@ -50,6 +50,35 @@ func (planner DirectoryPlanner) Plan(includeOwner bool, finalOwnerRepository str
 	return plan
 }
 
+// PlanWithTemplate evaluates the desired directory layout by rendering the naming template.
+// The default and owner templates reproduce Plan exactly; other templates fall back to the default folder name when the owner/repository identifier is unknown.
+func (planner DirectoryPlanner) PlanWithTemplate(namingTemplate NamingTemplate, finalOwnerRepository string, defaultFolderName string, host string) (DirectoryPlan, error) {
+	if namingTemplate.IsDefault() || namingTemplate.IsOwner() {
+		return planner.Plan(namingTemplate.IsOwner(), finalOwnerRepository, defaultFolderName), nil
+	}
+
+	ownerSegment, repositorySegment, parseSucceeded := splitOwnerRepository(finalOwnerRepository)
+	if !parseSucceeded {
+		return planner.Plan(false, finalOwnerRepository, defaultFolderName), nil
+	}
+
+	folderName, renderError := namingTemplate.Render(NamingTemplateFields{Owner: ownerSegment, Name: repositorySegment, Host: strings.TrimSpace(host)})
+	if renderError != nil {
+		return DirectoryPlan{}, renderError
+	}
+
+	return DirectoryPlan{
+		FolderName:        folderName,
+		OwnerSegment:      ownerSegment,
+		RepositorySegment: repositorySegment,
+	}, nil
+}
+
+// Nested reports whether the folder name spans more than one directory level.
+func (plan DirectoryPlan) Nested() bool {
+	return strings.ContainsRune(filepath.Clean(strings.TrimSpace(plan.FolderName)), filepath.Separator)
+}
+
 // IsNoop determines whether the repository already resides at the desired location.
 func (plan DirectoryPlan) IsNoop(repositoryPath string, currentFolderName string) bool {
 	trimmedTarget := strings.TrimSpace(plan.FolderName)
@@ -57,7 +86,7 @@ func (plan DirectoryPlan) IsNoop(repositoryPath string, currentFolderName string
 		return true
 	}
 
-	if plan.IncludeOwner {
+	if plan.IncludeOwner || plan.Nested() {
 		cleanedRepositoryPath := filepath.Clean(repositoryPath)
 		expectedSuffix := filepath.Clean(trimmedTarget)
 		return strings.HasSuffix(cleanedRepositoryPath, expectedSuffix)
@@ -125,3 +125,89 @@ func TestDirectoryPlanIsNoop(testInstance *testing.T) {
 		})
 	}
 }
+
+func TestDirectoryPlannerPlanWithTemplate(testInstance *testing.T) {
+	planner := rename.NewDirectoryPlanner()
+	testCases := []struct {
+		name           string
+		templateText   string
+		finalOwnerRepo string
+		host           string
+		expectedPlan   rename.DirectoryPlan
+		repositoryPath string
+		currentFolder  string
+		expectedIsNoop bool
+		expectedNested bool
+	}{
+		{
+			name:           "default_template_matches_plan",
+			templateText:   rename.DefaultNamingTemplateText,
+			finalOwnerRepo: plannerOwnerRepositoryConstant,
+			expectedPlan:   planner.Plan(false, plannerOwnerRepositoryConstant, plannerDefaultFolderNameConstant),
+			repositoryPath: plannerRepositoryPathConstant,
+			currentFolder:  plannerDefaultFolderNameConstant,
+			expectedIsNoop: true,
+		},
+		{
+			name:           "owner_template_matches_owner_plan",
+			templateText:   rename.OwnerNamingTemplateText,
+			finalOwnerRepo: plannerOwnerRepositoryConstant,
+			expectedPlan:   planner.Plan(true, plannerOwnerRepositoryConstant, plannerDefaultFolderNameConstant),
+			repositoryPath: plannerOwnerRepositoryPathConstant,
+			currentFolder:  plannerDefaultFolderNameConstant,
+			expectedIsNoop: true,
+			expectedNested: true,
+		},
+		{
+			name:           "flat_owner_template",
+			templateText:   "{{.Owner}}__{{.Name}}",
+			finalOwnerRepo: plannerOwnerRepositoryConstant,
+			expectedPlan: rename.DirectoryPlan{
+				FolderName:        "owner__example",
+				OwnerSegment:      "owner",
+				RepositorySegment: "example",
+			},
+			repositoryPath: plannerRepositoryPathConstant,
+			currentFolder:  plannerDefaultFolderNameConstant,
+			expectedIsNoop: false,
+		},
+		{
+			name:           "host_template_nests_directories",
+			templateText:   "{{.Host}}/{{.Owner}}/{{.Name}}",
+			finalOwnerRepo: plannerOwnerRepositoryConstant,
+			host:           "github.com",
+			expectedPlan: rename.DirectoryPlan{
+				FolderName:        filepath.Join("github.com", "owner", "example"),
+				OwnerSegment:      "owner",
+				RepositorySegment: "example",
+			},
+			repositoryPath: "/tmp/github.com/owner/example",
+			currentFolder:  plannerDefaultFolderNameConstant,
+			expectedIsNoop: true,
+			expectedNested: true,
+		},
+		{
+			name:           "missing_identifier_uses_default",
+			templateText:   "{{.Owner}}__{{.Name}}",
+			finalOwnerRepo: "",
+			expectedPlan:   planner.Plan(false, "", plannerDefaultFolderNameConstant),
+			repositoryPath: plannerRepositoryPathConstant,
+			currentFolder:  plannerDefaultFolderNameConstant,
+			expectedIsNoop: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			namingTemplate, parseError := rename.ParseNamingTemplate(testCase.templateText)
+			require.NoError(subtest, parseError)
+
+			plan, planError := planner.PlanWithTemplate(namingTemplate, testCase.finalOwnerRepo, plannerDefaultFolderNameConstant, testCase.host)
+			require.NoError(subtest, planError)
+			require.Equal(subtest, testCase.expectedPlan, plan)
+			require.Equal(subtest, testCase.expectedNested, plan.Nested())
+			require.Equal(subtest, testCase.expectedIsNoop, plan.IsNoop(testCase.repositoryPath, testCase.currentFolder))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package rename
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	repoerrors "github.com/temirov/gix/internal/repos/errors"
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+const (
+	undoSkipMessageTemplate            = "UNDO-SKIP (%s): %s → %s\n"
+	undoReadyMessageTemplate           = "UNDO-OK: %s → %s\n"
+	undoSuccessMessageTemplate         = "Restored %s → %s\n"
+	undoFailedMessageTemplate          = "UNDO-FAILED: %s → %s\n"
+	undoRemovedDirectoryTemplate       = "Removed empty directory %s\n"
+	undoPromptTemplate                 = "Restore '%s' → '%s'? [a/N/y] "
+	undoFailuresErrorTemplate          = "rename undo failed for %d of %d entries"
+	undoSkipRenamedMissingReason       = "renamed path missing"
+	undoSkipRenamedNotRepositoryReason = "renamed path is not a git repository"
+	undoSkipOriginalExistsReason       = "original path exists"
+	undoSkipOriginalParentReason       = "original parent not directory"
+)
+
+// UndoPlan moves directories renamed by a plan back to their original locations, processing entries in reverse order.
+// Each entry is checked first: the renamed path must still exist with the recorded origin and the original path must be free.
+// Directories left empty by a restore, such as owner directories created for the rename, are removed when the filesystem
+// supports it. Every entry is reported, and in dry-run mode entries are validated without moving anything.
+func (executor *Executor) UndoPlan(executionContext context.Context, plan PlanFile, dryRun bool, confirmationPolicy shared.ConfirmationPolicy) error {
+	if executor.dependencies.FileSystem == nil {
+		return repoerrors.Wrap(repoerrors.OperationRenameDirectories, "", repoerrors.ErrFilesystemUnavailable, nil)
+	}
+
+	failedEntries := 0
+	for entryIndex := len(plan.Renames) - 1; entryIndex >= 0; entryIndex-- {
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			return cancellationError
+		}
+		entry := plan.Renames[entryIndex]
+		renamedPath := filepath.Clean(entry.Target)
+		originalPath := filepath.Clean(entry.Source)
+
+		if skipReason := executor.undoEntrySkipReason(executionContext, entry, renamedPath, originalPath); len(skipReason) > 0 {
+			executor.printfOutput(undoSkipMessageTemplate, skipReason, renamedPath, originalPath)
+			continue
+		}
+
+		if dryRun {
+			executor.printfOutput(undoReadyMessageTemplate, renamedPath, originalPath)
+			continue
+		}
+
+		if confirmationPolicy.ShouldPrompt() && executor.dependencies.Prompter != nil {
+			confirmationResult, promptError := executor.dependencies.Prompter.Confirm(fmt.Sprintf(undoPromptTemplate, renamedPath, originalPath))
+			if promptError != nil {
+				return repoerrors.Wrap(repoerrors.OperationRenameDirectories, renamedPath, repoerrors.ErrUserConfirmationFailed, promptError)
+			}
+			if !confirmationResult.Confirmed {
+				executor.printfOutput(undoSkipMessageTemplate, applySkipDeclinedReason, renamedPath, originalPath)
+				continue
+			}
+		}
+
+		if ensureError := executor.ensureParentDirectory(originalPath, true); ensureError != nil {
+			executor.printfOutput(undoFailedMessageTemplate, renamedPath, originalPath)
+			failedEntries++
+			continue
+		}
+		if renameError := executor.performRename(renamedPath, originalPath); renameError != nil {
+			executor.printfOutput(undoFailedMessageTemplate, renamedPath, originalPath)
+			failedEntries++
+			continue
+		}
+		executor.printfOutput(undoSuccessMessageTemplate, renamedPath, originalPath)
+		executor.removeEmptiedDirectory(renamedPath, originalPath)
+	}
+
+	if failedEntries > 0 {
+		return fmt.Errorf(undoFailuresErrorTemplate, failedEntries, len(plan.Renames))
+	}
+	return nil
+}
+
+func (executor *Executor) undoEntrySkipReason(executionContext context.Context, entry PlanEntry, renamedPath string, originalPath string) string {
+	if _, renamedError := executor.dependencies.FileSystem.Stat(renamedPath); renamedError != nil {
+		return undoSkipRenamedMissingReason
+	}
+	if _, gitError := executor.dependencies.FileSystem.Stat(filepath.Join(renamedPath, gitMetadataDirectoryNameConstant)); gitError != nil {
+		return undoSkipRenamedNotRepositoryReason
+	}
+
+	if len(entry.Origin) > 0 {
+		if executor.dependencies.GitManager == nil {
+			return applySkipOriginUnavailableReason
+		}
+		currentOrigin, originError := executor.dependencies.GitManager.GetRemoteURL(executionContext, renamedPath, shared.OriginRemoteNameConstant)
+		if originError != nil {
+			return applySkipOriginUnavailableReason
+		}
+		if strings.TrimSpace(currentOrigin) != entry.Origin {
+			return applySkipOriginChangedReason
+		}
+	}
+
+	if executor.targetExists(originalPath) && !isCaseOnlyRename(renamedPath, originalPath) {
+		return undoSkipOriginalExistsReason
+	}
+	parentDetails := executor.parentDirectoryDetails(originalPath)
+	if parentDetails.exists && !parentDetails.isDirectory {
+		return undoSkipOriginalParentReason
+	}
+	return ""
+}
+
+func (executor *Executor) removeEmptiedDirectory(renamedPath string, originalPath string) {
+	remover, supportsRemoval := executor.dependencies.FileSystem.(shared.DirectoryRemover)
+	if !supportsRemoval {
+		return
+	}
+	vacatedDirectory := filepath.Dir(renamedPath)
+	if vacatedDirectory == filepath.Dir(originalPath) || isPathWithin(originalPath, vacatedDirectory) {
+		return
+	}
+	if removeError := remover.Remove(vacatedDirectory); removeError == nil {
+		executor.printfOutput(undoRemovedDirectoryTemplate, vacatedDirectory)
+	}
+}
+
+func isPathWithin(path string, directory string) bool {
+	relativePath, relativeError := filepath.Rel(directory, path)
+	if relativeError != nil {
+		return false
+	}
+	return relativePath != ".." && !strings.HasPrefix(relativePath, ".."+string(filepath.Separator))
+}
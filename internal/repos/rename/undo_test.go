@@ -0,0 +1,126 @@
+package rename_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/repos/rename"
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+const (
+	undoTestOwnerFirstPath  = "/tmp/owner/first"
+	undoTestOwnerSecondPath = "/tmp/owner/second"
+	undoTestFirstPath       = "/tmp/first"
+	undoTestSecondPath      = "/tmp/second"
+)
+
+func TestExecutorUndoPlan(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		entries         []rename.PlanEntry
+		existingPaths   map[string]bool
+		origins         map[string]string
+		renameError     error
+		dryRun          bool
+		expectedOutput  string
+		expectedRenames [][2]string
+		expectedRemoved []string
+		expectedError   string
+	}{
+		{
+			name: "restores_in_reverse_order_and_removes_emptied_owner_directory",
+			entries: []rename.PlanEntry{
+				{Source: undoTestFirstPath, Target: undoTestOwnerFirstPath, Origin: planTestOriginURL},
+				{Source: undoTestSecondPath, Target: undoTestOwnerSecondPath},
+			},
+			existingPaths: map[string]bool{
+				renameTestRootDirectory:           true,
+				renameTestOwnerDirectoryPath:      true,
+				undoTestOwnerFirstPath:            true,
+				undoTestOwnerFirstPath + "/.git":  true,
+				undoTestOwnerSecondPath:           true,
+				undoTestOwnerSecondPath + "/.git": true,
+			},
+			origins: map[string]string{undoTestOwnerFirstPath: planTestOriginURL},
+			expectedOutput: "Restored /tmp/owner/second → /tmp/second\n" +
+				"Restored /tmp/owner/first → /tmp/first\n" +
+				"Removed empty directory /tmp/owner\n",
+			expectedRenames: [][2]string{{undoTestOwnerSecondPath, undoTestSecondPath}, {undoTestOwnerFirstPath, undoTestFirstPath}},
+			expectedRemoved: []string{renameTestOwnerDirectoryPath},
+		},
+		{
+			name:           "dry_run_validates_without_moving",
+			entries:        []rename.PlanEntry{{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath}},
+			existingPaths:  map[string]bool{renameTestRootDirectory: true, renameTestTargetFolderPath: true, renameTestTargetFolderPath + "/.git": true},
+			dryRun:         true,
+			expectedOutput: "UNDO-OK: /tmp/example → /tmp/legacy\n",
+		},
+		{
+			name:           "skips_missing_renamed_path",
+			entries:        []rename.PlanEntry{{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath}},
+			existingPaths:  map[string]bool{renameTestRootDirectory: true},
+			expectedOutput: "UNDO-SKIP (renamed path missing): /tmp/example → /tmp/legacy\n",
+		},
+		{
+			name:           "skips_non_repository",
+			entries:        []rename.PlanEntry{{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath}},
+			existingPaths:  map[string]bool{renameTestRootDirectory: true, renameTestTargetFolderPath: true},
+			expectedOutput: "UNDO-SKIP (renamed path is not a git repository): /tmp/example → /tmp/legacy\n",
+		},
+		{
+			name:           "skips_changed_origin",
+			entries:        []rename.PlanEntry{{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath, Origin: planTestOriginURL}},
+			existingPaths:  map[string]bool{renameTestRootDirectory: true, renameTestTargetFolderPath: true, renameTestTargetFolderPath + "/.git": true},
+			origins:        map[string]string{renameTestTargetFolderPath: planTestOtherOriginURL},
+			expectedOutput: "UNDO-SKIP (origin changed): /tmp/example → /tmp/legacy\n",
+		},
+		{
+			name:           "skips_occupied_original_path",
+			entries:        []rename.PlanEntry{{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath}},
+			existingPaths:  map[string]bool{renameTestRootDirectory: true, renameTestTargetFolderPath: true, renameTestTargetFolderPath + "/.git": true, renameTestLegacyFolderPath: true},
+			expectedOutput: "UNDO-SKIP (original path exists): /tmp/example → /tmp/legacy\n",
+		},
+		{
+			name: "reports_failed_entries_and_continues",
+			entries: []rename.PlanEntry{
+				{Source: renameTestLegacyFolderPath, Target: renameTestTargetFolderPath},
+				{Source: renameTestProjectFolderPath, Target: undoTestOwnerSecondPath},
+			},
+			existingPaths: map[string]bool{renameTestRootDirectory: true, renameTestTargetFolderPath: true, renameTestTargetFolderPath + "/.git": true},
+			renameError:   errors.New("permission denied"),
+			expectedOutput: "UNDO-SKIP (renamed path missing): /tmp/owner/second → /tmp/project\n" +
+				"ERROR: rename failed for /tmp/example → /tmp/legacy: permission denied\n" +
+				"UNDO-FAILED: /tmp/example → /tmp/legacy\n",
+			expectedError: "rename undo failed for 1 of 2 entries",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			outputBuffer := &bytes.Buffer{}
+			fileSystem := &stubFileSystem{existingPaths: testCase.existingPaths, renameError: testCase.renameError}
+			executor := rename.NewExecutor(rename.Dependencies{
+				FileSystem: fileSystem,
+				GitManager: originGitManager{origins: testCase.origins},
+				Reporter:   shared.NewWriterReporter(outputBuffer),
+				Clock:      stubClock{},
+			})
+
+			undoError := executor.UndoPlan(context.Background(), rename.PlanFile{Renames: testCase.entries}, testCase.dryRun, shared.ConfirmationPolicyFromBool(true))
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, undoError, testCase.expectedError)
+			} else {
+				require.NoError(subtest, undoError)
+			}
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+			require.Equal(subtest, testCase.expectedRenames, fileSystem.renamedPairs)
+			require.Equal(subtest, testCase.expectedRemoved, fileSystem.removedPaths)
+		})
+	}
+}
@@ -0,0 +1,2 @@
+// Package selection filters repositories with include and exclude glob patterns shared by commands and workflow steps.
+package selection
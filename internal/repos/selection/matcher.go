@@ -0,0 +1,98 @@
+package selection
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const invalidPatternErrorTemplateConstant = "invalid repository filter pattern %q: %w"
+
+// Matcher selects repositories using include ("only") and exclude ("skip") glob patterns.
+// Patterns use path.Match syntax and are compared case-insensitively against every candidate value.
+type Matcher struct {
+	includePatterns []string
+	excludePatterns []string
+}
+
+// NewMatcher validates the provided patterns and constructs a Matcher.
+func NewMatcher(includePatterns []string, excludePatterns []string) (Matcher, error) {
+	normalizedInclude, includeError := normalizePatterns(includePatterns)
+	if includeError != nil {
+		return Matcher{}, includeError
+	}
+	normalizedExclude, excludeError := normalizePatterns(excludePatterns)
+	if excludeError != nil {
+		return Matcher{}, excludeError
+	}
+	return Matcher{includePatterns: normalizedInclude, excludePatterns: normalizedExclude}, nil
+}
+
+// Empty reports whether the matcher selects every repository.
+func (matcher Matcher) Empty() bool {
+	return len(matcher.includePatterns) == 0 && len(matcher.excludePatterns) == 0
+}
+
+// Matches reports whether a repository described by the candidate values passes the filters.
+// A repository is selected when any candidate matches an include pattern (or no include patterns exist)
+// and no candidate matches an exclude pattern.
+func (matcher Matcher) Matches(candidates ...string) bool {
+	normalizedCandidates := normalizeCandidates(candidates)
+	if len(matcher.includePatterns) > 0 && !matchesAny(matcher.includePatterns, normalizedCandidates) {
+		return false
+	}
+	return !matchesAny(matcher.excludePatterns, normalizedCandidates)
+}
+
+// RepositoryCandidates returns the values a repository is matched against: its owner/repo identifiers, path, and directory name.
+func RepositoryCandidates(repositoryPath string, ownerRepositories ...string) []string {
+	candidates := make([]string, 0, len(ownerRepositories)+2)
+	for _, ownerRepository := range ownerRepositories {
+		if trimmed := strings.TrimSpace(ownerRepository); len(trimmed) > 0 {
+			candidates = append(candidates, trimmed)
+		}
+	}
+	if trimmedPath := strings.TrimSpace(repositoryPath); len(trimmedPath) > 0 {
+		candidates = append(candidates, filepath.ToSlash(filepath.Clean(trimmedPath)), filepath.Base(trimmedPath))
+	}
+	return candidates
+}
+
+func normalizePatterns(patterns []string) ([]string, error) {
+	normalized := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		trimmed := strings.ToLower(filepath.ToSlash(strings.TrimSpace(pattern)))
+		if len(trimmed) == 0 {
+			continue
+		}
+		if _, matchError := path.Match(trimmed, ""); matchError != nil {
+			return nil, fmt.Errorf(invalidPatternErrorTemplateConstant, pattern, matchError)
+		}
+		normalized = append(normalized, trimmed)
+	}
+	return normalized, nil
+}
+
+func normalizeCandidates(candidates []string) []string {
+	normalized := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		trimmed := strings.ToLower(strings.TrimSpace(candidate))
+		if len(trimmed) == 0 {
+			continue
+		}
+		normalized = append(normalized, trimmed)
+	}
+	return normalized
+}
+
+func matchesAny(patterns []string, candidates []string) bool {
+	for _, pattern := range patterns {
+		for _, candidate := range candidates {
+			if matched, _ := path.Match(pattern, candidate); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
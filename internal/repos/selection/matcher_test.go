@@ -0,0 +1,71 @@
+package selection_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/repos/selection"
+)
+
+const (
+	selectionRepositoryPathConstant  = "/workspace/legacy/example"
+	selectionOwnerRepositoryConstant = "LegacyOrg/example"
+)
+
+func TestMatcherMatches(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		includePatterns []string
+		excludePatterns []string
+		expectedMatch   bool
+	}{
+		{
+			name:          "empty_matcher_selects_everything",
+			expectedMatch: true,
+		},
+		{
+			name:            "only_matches_owner_repository_case_insensitively",
+			includePatterns: []string{"legacyorg/*"},
+			expectedMatch:   true,
+		},
+		{
+			name:            "only_rejects_unmatched_repository",
+			includePatterns: []string{"modern/*"},
+			expectedMatch:   false,
+		},
+		{
+			name:            "only_matches_path",
+			includePatterns: []string{"/workspace/legacy/*"},
+			expectedMatch:   true,
+		},
+		{
+			name:            "skip_matches_directory_name",
+			excludePatterns: []string{"exam*"},
+			expectedMatch:   false,
+		},
+		{
+			name:            "skip_overrides_only",
+			includePatterns: []string{"legacyorg/*"},
+			excludePatterns: []string{"*/example"},
+			expectedMatch:   false,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			matcher, matcherError := selection.NewMatcher(testCase.includePatterns, testCase.excludePatterns)
+			require.NoError(subtest, matcherError)
+
+			candidates := selection.RepositoryCandidates(selectionRepositoryPathConstant, selectionOwnerRepositoryConstant)
+			require.Equal(subtest, testCase.expectedMatch, matcher.Matches(candidates...))
+		})
+	}
+}
+
+func TestNewMatcherRejectsInvalidPattern(testInstance *testing.T) {
+	matcher, matcherError := selection.NewMatcher([]string{"["}, nil)
+	require.Error(testInstance, matcherError)
+	require.True(testInstance, matcher.Empty())
+}
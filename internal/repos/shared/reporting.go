@@ -4,6 +4,15 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	eventFieldNameConstant       = "event"
+	eventPrefixSeparatorConstant = ":"
+	eventLineSeparatorConstant   = "\n"
 )
 
 // Reporter emits formatted executor events to an underlying sink.
@@ -29,3 +38,38 @@ func (reporter writerReporter) Printf(format string, args ...any) {
 	}
 	fmt.Fprintf(reporter.writer, format, args...)
 }
+
+type eventReporter struct {
+	logger *zap.Logger
+}
+
+// NewEventReporter constructs a Reporter that emits every formatted line as an info event on the provided logger.
+// Lines beginning with an uppercase label such as PLAN-OK carry that label in the event field.
+func NewEventReporter(logger *zap.Logger) Reporter {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return eventReporter{logger: logger}
+}
+
+func (reporter eventReporter) Printf(format string, args ...any) {
+	for _, line := range strings.Split(fmt.Sprintf(format, args...), eventLineSeparatorConstant) {
+		trimmedLine := strings.TrimSpace(line)
+		if len(trimmedLine) == 0 {
+			continue
+		}
+		if label := eventLabel(trimmedLine); len(label) > 0 {
+			reporter.logger.Info(trimmedLine, zap.String(eventFieldNameConstant, label))
+			continue
+		}
+		reporter.logger.Info(trimmedLine)
+	}
+}
+
+func eventLabel(line string) string {
+	label, _, found := strings.Cut(line, eventPrefixSeparatorConstant)
+	if !found || len(label) == 0 || strings.ToUpper(label) != label || strings.ContainsAny(label, " \t") {
+		return ""
+	}
+	return label
+}
@@ -0,0 +1,64 @@
+package shared_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+func TestEventReporterEmitsLabeledEvents(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		format           string
+		arguments        []any
+		expectedMessages []string
+		expectedEvents   []string
+	}{
+		{
+			name:             "labeled_line",
+			format:           "PLAN-OK: %s → %s\n",
+			arguments:        []any{"/tmp/old", "/tmp/new"},
+			expectedMessages: []string{"PLAN-OK: /tmp/old → /tmp/new"},
+			expectedEvents:   []string{"PLAN-OK"},
+		},
+		{
+			name:             "unlabeled_line",
+			format:           "Renamed %s → %s\n",
+			arguments:        []any{"/tmp/old", "/tmp/new"},
+			expectedMessages: []string{"Renamed /tmp/old → /tmp/new"},
+			expectedEvents:   []string{""},
+		},
+		{
+			name:             "multiple_lines",
+			format:           "UPDATE-REMOTE-DONE: %s origin now %s\n\nPLAN-SKIP (already): %s\n",
+			arguments:        []any{"/tmp/repo", "git@github.com:owner/repo.git", "/tmp/repo"},
+			expectedMessages: []string{"UPDATE-REMOTE-DONE: /tmp/repo origin now git@github.com:owner/repo.git", "PLAN-SKIP (already): /tmp/repo"},
+			expectedEvents:   []string{"UPDATE-REMOTE-DONE", ""},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			core, recorded := observer.New(zapcore.InfoLevel)
+			shared.NewEventReporter(zap.New(core)).Printf(testCase.format, testCase.arguments...)
+
+			entries := recorded.AllUntimed()
+			require.Len(t, entries, len(testCase.expectedMessages))
+			for entryIndex, entry := range entries {
+				require.Equal(t, testCase.expectedMessages[entryIndex], entry.Message)
+				eventValue, _ := entry.ContextMap()["event"].(string)
+				require.Equal(t, testCase.expectedEvents[entryIndex], eventValue)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/temirov/gix/internal/execshell"
 	"github.com/temirov/gix/internal/githubcli"
+	"github.com/temirov/gix/internal/gitrepo"
 )
 
 const (
@@ -317,6 +318,11 @@ type FileSystem interface {
 	WriteFile(path string, data []byte, permissions fs.FileMode) error
 }
 
+// DirectoryRemover is implemented by filesystems that can remove an empty directory; removing a non-empty directory fails.
+type DirectoryRemover interface {
+	Remove(path string) error
+}
+
 // ConfirmationResult captures the outcome of a user confirmation prompt.
 type ConfirmationResult struct {
 	Confirmed  bool
@@ -328,6 +334,11 @@ type ConfirmationPrompter interface {
 	Confirm(prompt string) (ConfirmationResult, error)
 }
 
+// PhraseConfirmationPrompter requires the user to type an exact phrase before destructive actions proceed.
+type PhraseConfirmationPrompter interface {
+	ConfirmPhrase(prompt string, expectedPhrase string) (bool, error)
+}
+
 // GitExecutor exposes the subset of shell execution used by repository services.
 type GitExecutor interface {
 	ExecuteGit(executionContext context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error)
@@ -342,11 +353,46 @@ type GitRepositoryManager interface {
 	SetRemoteURL(executionContext context.Context, repositoryPath string, remoteName string, remoteURL string) error
 }
 
+// GitRepositoryStatusReporter exposes categorized worktree status for managers that support it.
+type GitRepositoryStatusReporter interface {
+	Status(executionContext context.Context, repositoryPath string) (gitrepo.StatusReport, error)
+}
+
+// GitRepositoryInProgressReporter exposes detection of unfinished merges, rebases, and cherry-picks for managers that support it.
+type GitRepositoryInProgressReporter interface {
+	InProgressOperation(executionContext context.Context, repositoryPath string) (gitrepo.InProgressOperation, error)
+}
+
+// GitRemotePushURLManager exposes push URL updates for managers that support them.
+type GitRemotePushURLManager interface {
+	SetRemotePushURL(executionContext context.Context, repositoryPath string, remoteName string, remoteURL string) error
+}
+
+// GitCredentialRejecter exposes erasure of the stored HTTPS credentials of a remote URL for managers that support it.
+type GitCredentialRejecter interface {
+	RejectRemoteCredentials(executionContext context.Context, repositoryPath string, remoteURL string) error
+}
+
+// GitRepositoryRefLister exposes batched reference reads for managers that support it.
+type GitRepositoryRefLister interface {
+	ListRefs(executionContext context.Context, repositoryPath string, patterns ...string) ([]gitrepo.RefEntry, error)
+}
+
 // GitHubMetadataResolver resolves canonical repository metadata via GitHub CLI.
 type GitHubMetadataResolver interface {
 	ResolveRepoMetadata(executionContext context.Context, repository string) (githubcli.RepositoryMetadata, error)
 }
 
+// BranchProtectionResolver reports whether GitHub protects a branch of a repository.
+type BranchProtectionResolver interface {
+	BranchProtected(executionContext context.Context, repository string, branchName string) (bool, error)
+}
+
+// GitHubMetadataPrefetcher warms repository metadata for many repositories ahead of individual lookups.
+type GitHubMetadataPrefetcher interface {
+	PrefetchRepoMetadata(executionContext context.Context, repositories []string)
+}
+
 // RepositoryDiscoverer locates Git repositories for bulk operations.
 type RepositoryDiscoverer interface {
 	DiscoverRepositories(roots []string) ([]string, error)
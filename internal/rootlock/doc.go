@@ -0,0 +1,6 @@
+// Package rootlock keeps concurrent gix runs from mutating the same repository roots.
+//
+// Locker keeps one lock file per normalized root under the user state directory, so runs whose root sets overlap
+// exclude each other. Each file carries an operating system file lock that ends with its process, and records the
+// owning process and its start time for the error shown to a blocked run.
+package rootlock
@@ -0,0 +1,265 @@
+package rootlock
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	applicationDirectoryNameConstant     = "gix"
+	locksDirectoryNameConstant           = "locks"
+	xdgStateHomeEnvironmentVariable      = "XDG_STATE_HOME"
+	localDirectoryNameConstant           = ".local"
+	stateDirectoryNameConstant           = "state"
+	lockFileExtensionConstant            = ".lock"
+	lockKeySeparatorConstant             = "\n"
+	lockKeyLengthConstant                = 16
+	lockDirectoryPermissionsConstant     = 0o700
+	lockFilePermissionsConstant          = 0o600
+	acquireAttemptsConstant              = 3
+	sameDayStartTimeLayoutConstant       = "15:04"
+	otherDayStartTimeLayoutConstant      = "2006-01-02 15:04"
+	heldErrorTemplateConstant            = "another gix run (pid %d, started %s) holds the lock for these roots; wait for it to finish or pass --no-lock"
+	heldUnknownOwnerErrorMessage         = "another gix run holds the lock for these roots; wait for it to finish or pass --no-lock"
+	stateDirectoryErrorTemplateConstant  = "unable to determine the gix state directory: %w"
+	createDirectoryErrorTemplateConstant = "unable to create lock directory %s: %w"
+	writeLockErrorTemplateConstant       = "unable to write lock file %s: %w"
+	removeLockErrorTemplateConstant      = "unable to remove lock file %s: %w"
+	resolveRootErrorTemplateConstant     = "unable to resolve root %s: %w"
+)
+
+// ErrRootsLocked reports that another running gix process holds the lock for the same roots.
+var ErrRootsLocked = errors.New("roots locked by another gix run")
+
+// HeldError describes the live process that holds the lock for one of the requested roots. PID is zero when the holder
+// had not written its record yet.
+type HeldError struct {
+	PID       int
+	StartedAt time.Time
+	LockPath  string
+	now       time.Time
+}
+
+// Error names the owning process and when it started. The date is included only when the run started on another day.
+func (heldError HeldError) Error() string {
+	if heldError.PID == 0 {
+		return heldUnknownOwnerErrorMessage
+	}
+	startedAt := heldError.StartedAt.Local()
+	layout := sameDayStartTimeLayoutConstant
+	now := heldError.now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if startedAt.Format(time.DateOnly) != now.Local().Format(time.DateOnly) {
+		layout = otherDayStartTimeLayoutConstant
+	}
+	return fmt.Sprintf(heldErrorTemplateConstant, heldError.PID, startedAt.Format(layout))
+}
+
+// Is matches ErrRootsLocked.
+func (heldError HeldError) Is(target error) bool {
+	return target == ErrRootsLocked
+}
+
+type lockRecord struct {
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"started_at"`
+	Roots     []string  `json:"roots"`
+}
+
+// Locker acquires root locks in one directory.
+type Locker struct {
+	directory string
+	pid       int
+	clock     func() time.Time
+}
+
+// NewLocker constructs a Locker that keeps its lock files in directory on behalf of the current process.
+func NewLocker(directory string) *Locker {
+	return &Locker{directory: directory, pid: os.Getpid(), clock: time.Now}
+}
+
+// DefaultDirectory returns the lock directory under the user state directory: $XDG_STATE_HOME/gix/locks, falling back
+// to ~/.local/state/gix/locks.
+func DefaultDirectory() (string, error) {
+	stateHome := strings.TrimSpace(os.Getenv(xdgStateHomeEnvironmentVariable))
+	if len(stateHome) == 0 {
+		homeDirectory, homeError := os.UserHomeDir()
+		if homeError != nil {
+			return "", fmt.Errorf(stateDirectoryErrorTemplateConstant, homeError)
+		}
+		stateHome = filepath.Join(homeDirectory, localDirectoryNameConstant, stateDirectoryNameConstant)
+	}
+	return filepath.Join(stateHome, applicationDirectoryNameConstant, locksDirectoryNameConstant), nil
+}
+
+// NormalizeRoots returns the absolute, cleaned, sorted, and deduplicated roots that identify a lock.
+func NormalizeRoots(roots []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(roots))
+	normalized := make([]string, 0, len(roots))
+	for _, root := range roots {
+		trimmedRoot := strings.TrimSpace(root)
+		if len(trimmedRoot) == 0 {
+			continue
+		}
+		absoluteRoot, absoluteError := filepath.Abs(trimmedRoot)
+		if absoluteError != nil {
+			return nil, fmt.Errorf(resolveRootErrorTemplateConstant, trimmedRoot, absoluteError)
+		}
+		if _, duplicate := seen[absoluteRoot]; duplicate {
+			continue
+		}
+		seen[absoluteRoot] = struct{}{}
+		normalized = append(normalized, absoluteRoot)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// Key derives the lock file name for normalized roots.
+func Key(normalizedRoots []string) string {
+	digest := sha256.Sum256([]byte(strings.Join(normalizedRoots, lockKeySeparatorConstant)))
+	return hex.EncodeToString(digest[:])[:lockKeyLengthConstant]
+}
+
+// RootLockPath returns the lock file that guards one normalized root.
+func RootLockPath(directory string, normalizedRoot string) string {
+	return filepath.Join(directory, Key([]string{normalizedRoot})+lockFileExtensionConstant)
+}
+
+// Lock is a held set of root locks.
+type Lock struct {
+	paths []string
+	files []*os.File
+}
+
+// Paths returns the lock file paths, one per root.
+func (lock *Lock) Paths() []string {
+	if lock == nil {
+		return nil
+	}
+	return lock.paths
+}
+
+// Release removes the lock files and releases their locks. Releasing a nil or already released lock does nothing.
+func (lock *Lock) Release() error {
+	if lock == nil {
+		return nil
+	}
+	releaseErrors := make([]error, 0, len(lock.files))
+	for fileIndex, lockFile := range lock.files {
+		if releaseError := releaseLockFile(lockFile, lock.paths[fileIndex]); releaseError != nil {
+			releaseErrors = append(releaseErrors, fmt.Errorf(removeLockErrorTemplateConstant, lock.paths[fileIndex], releaseError))
+		}
+	}
+	lock.paths = nil
+	lock.files = nil
+	return errors.Join(releaseErrors...)
+}
+
+// Acquire takes one lock per root, so runs whose root sets overlap exclude each other. It returns a HeldError when a
+// running process holds any of them. The locks are operating system file locks that end with the owning process, so a
+// lock file left behind by a process that is no longer running is simply taken over.
+func (locker *Locker) Acquire(roots []string) (*Lock, error) {
+	normalizedRoots, normalizeError := NormalizeRoots(roots)
+	if normalizeError != nil {
+		return nil, normalizeError
+	}
+	if directoryError := os.MkdirAll(locker.directory, lockDirectoryPermissionsConstant); directoryError != nil {
+		return nil, fmt.Errorf(createDirectoryErrorTemplateConstant, locker.directory, directoryError)
+	}
+
+	record := lockRecord{PID: locker.pid, StartedAt: locker.clock().UTC(), Roots: normalizedRoots}
+	lock := &Lock{}
+	for _, root := range normalizedRoots {
+		lockPath := RootLockPath(locker.directory, root)
+		lockFile, lockError := locker.lockRoot(lockPath, record)
+		if lockError != nil {
+			_ = lock.Release()
+			return nil, lockError
+		}
+		lock.paths = append(lock.paths, lockPath)
+		lock.files = append(lock.files, lockFile)
+	}
+	return lock, nil
+}
+
+// lockRoot opens and locks the lock file, then records the owner in it. A holder that releases between the open and
+// the lock removes the file first, so the lock is retried until it is taken on the file that is still in place.
+func (locker *Locker) lockRoot(lockPath string, record lockRecord) (*os.File, error) {
+	for attempt := 0; attempt < acquireAttemptsConstant; attempt++ {
+		lockFile, openError := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, lockFilePermissionsConstant)
+		if openError != nil {
+			return nil, fmt.Errorf(writeLockErrorTemplateConstant, lockPath, openError)
+		}
+		locked, lockError := tryLockFile(lockFile)
+		if lockError != nil {
+			_ = lockFile.Close()
+			return nil, fmt.Errorf(writeLockErrorTemplateConstant, lockPath, lockError)
+		}
+		if !locked {
+			_ = lockFile.Close()
+			return nil, locker.heldError(lockPath)
+		}
+		if !lockFileInPlace(lockFile, lockPath) {
+			_ = lockFile.Close()
+			continue
+		}
+		if writeError := writeLockRecord(lockFile, record); writeError != nil {
+			_ = releaseLockFile(lockFile, lockPath)
+			return nil, fmt.Errorf(writeLockErrorTemplateConstant, lockPath, writeError)
+		}
+		return lockFile, nil
+	}
+	return nil, locker.heldError(lockPath)
+}
+
+func (locker *Locker) heldError(lockPath string) HeldError {
+	existingRecord, _ := readLockRecord(lockPath)
+	return HeldError{PID: existingRecord.PID, StartedAt: existingRecord.StartedAt, LockPath: lockPath, now: locker.clock()}
+}
+
+func lockFileInPlace(lockFile *os.File, lockPath string) bool {
+	openedInfo, openedError := lockFile.Stat()
+	if openedError != nil {
+		return false
+	}
+	currentInfo, currentError := os.Stat(lockPath)
+	if currentError != nil {
+		return false
+	}
+	return os.SameFile(openedInfo, currentInfo)
+}
+
+func writeLockRecord(lockFile *os.File, record lockRecord) error {
+	encodedRecord, encodeError := json.Marshal(record)
+	if encodeError != nil {
+		return encodeError
+	}
+	if truncateError := lockFile.Truncate(0); truncateError != nil {
+		return truncateError
+	}
+	_, writeError := lockFile.WriteAt(encodedRecord, 0)
+	return writeError
+}
+
+func readLockRecord(lockPath string) (lockRecord, error) {
+	content, readError := os.ReadFile(lockPath)
+	if readError != nil {
+		return lockRecord{}, readError
+	}
+	record := lockRecord{}
+	if decodeError := json.Unmarshal(content, &record); decodeError != nil {
+		return lockRecord{}, decodeError
+	}
+	return record, nil
+}
@@ -0,0 +1,126 @@
+package rootlock
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocker(directory string, pid int) *Locker {
+	return &Locker{
+		directory: directory,
+		pid:       pid,
+		clock:     func() time.Time { return time.Date(2025, time.March, 4, 10, 2, 0, 0, time.Local) },
+	}
+}
+
+func TestLockerAcquire(testInstance *testing.T) {
+	const heldMessage = "another gix run (pid 1234, started 10:02) holds the lock for these roots; wait for it to finish or pass --no-lock"
+
+	testCases := []struct {
+		name          string
+		holderExited  bool
+		secondRoots   []string
+		expectedError string
+	}{
+		{
+			name:          "live_holder_blocks_same_roots",
+			secondRoots:   []string{"/work/b", "/work/a/", "/work/a"},
+			expectedError: heldMessage,
+		},
+		{
+			name:          "live_holder_blocks_overlapping_roots",
+			secondRoots:   []string{"/work/b", "/work/c"},
+			expectedError: heldMessage,
+		},
+		{
+			name:         "lock_file_of_exited_holder_is_taken_over",
+			holderExited: true,
+			secondRoots:  []string{"/work/a", "/work/b"},
+		},
+		{
+			name:        "disjoint_roots_do_not_conflict",
+			secondRoots: []string{"/work/c"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			lockDirectory := filepath.Join(subtest.TempDir(), "locks")
+			holderRoots := []string{"/work/a", "/work/b"}
+
+			var holderLock *Lock
+			if testCase.holderExited {
+				require.NoError(subtest, os.MkdirAll(lockDirectory, 0o700))
+				for _, root := range holderRoots {
+					require.NoError(subtest, os.WriteFile(RootLockPath(lockDirectory, root), []byte(`{"pid":1234,"started_at":"2025-03-04T10:02:00Z"}`), 0o600))
+				}
+			} else {
+				acquiredLock, holderError := newTestLocker(lockDirectory, 1234).Acquire(holderRoots)
+				require.NoError(subtest, holderError)
+				holderLock = acquiredLock
+			}
+
+			secondLock, secondError := newTestLocker(lockDirectory, 5678).Acquire(testCase.secondRoots)
+			if len(testCase.expectedError) > 0 {
+				require.ErrorIs(subtest, secondError, ErrRootsLocked)
+				require.EqualError(subtest, secondError, testCase.expectedError)
+				require.Nil(subtest, secondLock)
+				require.NoError(subtest, holderLock.Release())
+				return
+			}
+			require.NoError(subtest, secondError)
+			lockPaths := secondLock.Paths()
+			require.Len(subtest, lockPaths, len(testCase.secondRoots))
+			for _, lockPath := range lockPaths {
+				record, readError := readLockRecord(lockPath)
+				require.NoError(subtest, readError)
+				require.Equal(subtest, 5678, record.PID)
+			}
+
+			require.NoError(subtest, secondLock.Release())
+			for _, lockPath := range lockPaths {
+				require.NoFileExists(subtest, lockPath)
+			}
+			require.NoError(subtest, secondLock.Release())
+			require.NoError(subtest, holderLock.Release())
+
+			entries, readError := os.ReadDir(lockDirectory)
+			require.NoError(subtest, readError)
+			require.Empty(subtest, entries)
+		})
+	}
+}
+
+func TestLockerAcquireReleasesEarlierRootsWhenBlocked(testInstance *testing.T) {
+	lockDirectory := filepath.Join(testInstance.TempDir(), "locks")
+	holderLock, holderError := newTestLocker(lockDirectory, 1234).Acquire([]string{"/work/b"})
+	require.NoError(testInstance, holderError)
+
+	_, blockedError := newTestLocker(lockDirectory, 5678).Acquire([]string{"/work/a", "/work/b"})
+	require.ErrorIs(testInstance, blockedError, ErrRootsLocked)
+	require.NoFileExists(testInstance, RootLockPath(lockDirectory, "/work/a"))
+
+	require.NoError(testInstance, holderLock.Release())
+}
+
+func TestHeldErrorIncludesDateForEarlierDays(testInstance *testing.T) {
+	heldError := HeldError{
+		PID:       42,
+		StartedAt: time.Date(2025, time.March, 3, 23, 15, 0, 0, time.Local),
+		now:       time.Date(2025, time.March, 4, 8, 0, 0, 0, time.Local),
+	}
+	require.Equal(testInstance, "another gix run (pid 42, started 2025-03-03 23:15) holds the lock for these roots; wait for it to finish or pass --no-lock", heldError.Error())
+}
+
+func TestDefaultDirectoryPrefersXDGStateHome(testInstance *testing.T) {
+	stateHome := testInstance.TempDir()
+	testInstance.Setenv("XDG_STATE_HOME", stateHome)
+
+	directory, directoryError := DefaultDirectory()
+	require.NoError(testInstance, directoryError)
+	require.Equal(testInstance, filepath.Join(stateHome, "gix", "locks"), directory)
+}
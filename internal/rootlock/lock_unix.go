@@ -0,0 +1,29 @@
+//go:build !windows
+
+package rootlock
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// tryLockFile takes an exclusive flock on the file without waiting. It reports false when another open file holds it.
+func tryLockFile(lockFile *os.File) (bool, error) {
+	lockError := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(lockError, syscall.EWOULDBLOCK) {
+		return false, nil
+	}
+	return lockError == nil, lockError
+}
+
+// releaseLockFile removes the lock file while it is still locked and then closes it, which drops the flock. Removing
+// first means a waiting process that opened the old file notices it is no longer in place instead of sharing the lock
+// with a process that creates a new one.
+func releaseLockFile(lockFile *os.File, lockPath string) error {
+	removeError := os.Remove(lockPath)
+	if errors.Is(removeError, os.ErrNotExist) {
+		removeError = nil
+	}
+	return errors.Join(removeError, lockFile.Close())
+}
@@ -0,0 +1,38 @@
+//go:build windows
+
+package rootlock
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockRegionOffsetHighConstant places the locked byte far past the record, so other processes can still read who holds
+// the lock.
+const lockRegionOffsetHighConstant = 0x7fffffff
+
+// tryLockFile takes an exclusive lock on one byte of the file without waiting. It reports false when another handle
+// holds it.
+func tryLockFile(lockFile *os.File) (bool, error) {
+	overlapped := windows.Overlapped{OffsetHigh: lockRegionOffsetHighConstant}
+	lockError := windows.LockFileEx(windows.Handle(lockFile.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, &overlapped)
+	if errors.Is(lockError, windows.ERROR_LOCK_VIOLATION) {
+		return false, nil
+	}
+	return lockError == nil, lockError
+}
+
+// releaseLockFile unlocks and closes the lock file before removing it, because Windows refuses to remove open files.
+// A waiting process that has the file open keeps it in place, which is harmless.
+func releaseLockFile(lockFile *os.File, lockPath string) error {
+	overlapped := windows.Overlapped{OffsetHigh: lockRegionOffsetHighConstant}
+	unlockError := windows.UnlockFileEx(windows.Handle(lockFile.Fd()), 0, 1, 0, &overlapped)
+	closeError := lockFile.Close()
+	removeError := os.Remove(lockPath)
+	if errors.Is(removeError, os.ErrNotExist) || errors.Is(removeError, os.ErrPermission) || errors.Is(removeError, windows.ERROR_SHARING_VIOLATION) {
+		removeError = nil
+	}
+	return errors.Join(unlockError, closeError, removeError)
+}
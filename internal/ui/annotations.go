@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// GitHubActionsEnvironmentVariable is set to "true" by the GitHub Actions runner for every step.
+	GitHubActionsEnvironmentVariable = "GITHUB_ACTIONS"
+
+	annotationLevelWarningConstant         = "warning"
+	annotationLevelErrorConstant           = "error"
+	annotationTemplateConstant             = "::%s::%s\n"
+	annotationWithTitleTemplateConstant    = "::%s title=%s::%s\n"
+	annotationEscapePercentConstant        = "%25"
+	annotationEscapeCarriageReturnConstant = "%0D"
+	annotationEscapeLineFeedConstant       = "%0A"
+	annotationEscapeColonConstant          = "%3A"
+	annotationEscapeCommaConstant          = "%2C"
+)
+
+// EnvironmentLookup obtains an environment variable value.
+type EnvironmentLookup func(key string) (string, bool)
+
+// AnnotationEmitter writes GitHub Actions ::warning:: and ::error:: workflow commands. A nil or disabled emitter writes
+// nothing, so callers can annotate unconditionally.
+type AnnotationEmitter struct {
+	writer io.Writer
+}
+
+// NewAnnotationEmitter returns an emitter writing to the writer when the process runs inside GitHub Actions and
+// annotations were not disabled; otherwise it returns nil.
+func NewAnnotationEmitter(writer io.Writer, environmentLookup EnvironmentLookup, disabled bool) *AnnotationEmitter {
+	if writer == nil || environmentLookup == nil || disabled {
+		return nil
+	}
+	environmentValue, found := environmentLookup(GitHubActionsEnvironmentVariable)
+	if !found {
+		return nil
+	}
+	runningInActions, parseError := strconv.ParseBool(strings.TrimSpace(environmentValue))
+	if parseError != nil || !runningInActions {
+		return nil
+	}
+	return &AnnotationEmitter{writer: writer}
+}
+
+// Enabled reports whether the emitter writes annotations.
+func (emitter *AnnotationEmitter) Enabled() bool {
+	return emitter != nil && emitter.writer != nil
+}
+
+// Warning emits a warning annotation with an optional title.
+func (emitter *AnnotationEmitter) Warning(title string, message string) {
+	emitter.emit(annotationLevelWarningConstant, title, message)
+}
+
+// Error emits an error annotation with an optional title.
+func (emitter *AnnotationEmitter) Error(title string, message string) {
+	emitter.emit(annotationLevelErrorConstant, title, message)
+}
+
+func (emitter *AnnotationEmitter) emit(level string, title string, message string) {
+	if !emitter.Enabled() {
+		return
+	}
+	trimmedTitle := strings.TrimSpace(title)
+	if len(trimmedTitle) == 0 {
+		fmt.Fprintf(emitter.writer, annotationTemplateConstant, level, escapeAnnotationMessage(message))
+		return
+	}
+	fmt.Fprintf(emitter.writer, annotationWithTitleTemplateConstant, level, escapeAnnotationProperty(trimmedTitle), escapeAnnotationMessage(message))
+}
+
+func escapeAnnotationMessage(message string) string {
+	escaped := strings.ReplaceAll(strings.TrimRight(message, "\n"), "%", annotationEscapePercentConstant)
+	escaped = strings.ReplaceAll(escaped, "\r", annotationEscapeCarriageReturnConstant)
+	return strings.ReplaceAll(escaped, "\n", annotationEscapeLineFeedConstant)
+}
+
+func escapeAnnotationProperty(property string) string {
+	escaped := escapeAnnotationMessage(property)
+	escaped = strings.ReplaceAll(escaped, ":", annotationEscapeColonConstant)
+	return strings.ReplaceAll(escaped, ",", annotationEscapeCommaConstant)
+}
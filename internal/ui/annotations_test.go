@@ -0,0 +1,64 @@
+package ui_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/ui"
+)
+
+func TestAnnotationEmitter(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		environment    map[string]string
+		disabled       bool
+		emit           func(*ui.AnnotationEmitter)
+		expectedOutput string
+	}{
+		{
+			name:        "error_with_title",
+			environment: map[string]string{ui.GitHubActionsEnvironmentVariable: "true"},
+			emit: func(emitter *ui.AnnotationEmitter) {
+				emitter.Error("packages: owner/app", "version 7 failed: 100% broken")
+			},
+			expectedOutput: "::error title=packages%3A owner/app::version 7 failed: 100%25 broken\n",
+		},
+		{
+			name:           "warning_without_title",
+			environment:    map[string]string{ui.GitHubActionsEnvironmentVariable: "true"},
+			emit:           func(emitter *ui.AnnotationEmitter) { emitter.Warning("", "line one\nline two") },
+			expectedOutput: "::warning::line one%0Aline two\n",
+		},
+		{
+			name: "outside_github_actions",
+			emit: func(emitter *ui.AnnotationEmitter) { emitter.Error("title", "message") },
+		},
+		{
+			name:        "non_true_value_ignored",
+			environment: map[string]string{ui.GitHubActionsEnvironmentVariable: "no"},
+			emit:        func(emitter *ui.AnnotationEmitter) { emitter.Error("title", "message") },
+		},
+		{
+			name:        "disabled_by_flag",
+			environment: map[string]string{ui.GitHubActionsEnvironmentVariable: "true"},
+			disabled:    true,
+			emit:        func(emitter *ui.AnnotationEmitter) { emitter.Warning("title", "message") },
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			var output bytes.Buffer
+			lookup := func(key string) (string, bool) {
+				value, found := testCase.environment[key]
+				return value, found
+			}
+			emitter := ui.NewAnnotationEmitter(&output, lookup, testCase.disabled)
+			testCase.emit(emitter)
+			require.Equal(subtest, testCase.expectedOutput, output.String())
+			require.Equal(subtest, len(testCase.expectedOutput) > 0, emitter.Enabled())
+		})
+	}
+}
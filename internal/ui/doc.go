@@ -0,0 +1,5 @@
+// Package ui formats user-facing output that is shared across commands.
+//
+// It provides AnnotationEmitter, which mirrors failures and warnings as
+// GitHub Actions workflow commands so they surface as run annotations.
+package ui
@@ -0,0 +1,33 @@
+package utils
+
+import "fmt"
+
+const (
+	byteSizeUnitBaseConstant           = 1024
+	byteSizeBytesTemplateConstant      = "%d B"
+	byteSizeFractionalTemplateConstant = "%.1f %s"
+	byteSizeNegativeSignConstant       = "-"
+)
+
+var byteSizeUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// FormatByteSize renders a byte count using binary units (B, KiB, MiB, ...) with one decimal place above a kibibyte.
+func FormatByteSize(byteCount int64) string {
+	sign := ""
+	magnitude := uint64(byteCount)
+	if byteCount < 0 {
+		sign = byteSizeNegativeSignConstant
+		magnitude = -magnitude
+	}
+	if magnitude < byteSizeUnitBaseConstant {
+		return sign + fmt.Sprintf(byteSizeBytesTemplateConstant, magnitude)
+	}
+
+	value := float64(magnitude)
+	unitIndex := -1
+	for value >= byteSizeUnitBaseConstant && unitIndex < len(byteSizeUnits)-1 {
+		value /= byteSizeUnitBaseConstant
+		unitIndex++
+	}
+	return sign + fmt.Sprintf(byteSizeFractionalTemplateConstant, value, byteSizeUnits[unitIndex])
+}
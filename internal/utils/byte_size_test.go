@@ -0,0 +1,35 @@
+package utils_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/utils"
+)
+
+func TestFormatByteSize(testInstance *testing.T) {
+	testCases := []struct {
+		name      string
+		byteCount int64
+		expected  string
+	}{
+		{name: "zero", byteCount: 0, expected: "0 B"},
+		{name: "bytes", byteCount: 1023, expected: "1023 B"},
+		{name: "kibibyte", byteCount: 1024, expected: "1.0 KiB"},
+		{name: "fractional_mebibytes", byteCount: 5*1024*1024 + 512*1024, expected: "5.5 MiB"},
+		{name: "gibibytes", byteCount: 3 * 1024 * 1024 * 1024, expected: "3.0 GiB"},
+		{name: "negative", byteCount: -2048, expected: "-2.0 KiB"},
+		{name: "negative_bytes", byteCount: -5, expected: "-5 B"},
+		{name: "maximum", byteCount: math.MaxInt64, expected: "8.0 EiB"},
+		{name: "minimum", byteCount: math.MinInt64, expected: "-8.0 EiB"},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			require.Equal(subtest, testCase.expected, utils.FormatByteSize(testCase.byteCount))
+		})
+	}
+}
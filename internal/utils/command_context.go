@@ -11,6 +11,8 @@ const (
 	branchContextKeyConstant                = commandContextKey("branchContext")
 	executionFlagsContextKeyConstant        = commandContextKey("executionFlags")
 	logLevelContextKeyConstant              = commandContextKey("logLevel")
+	rootLockDisabledContextKeyConstant      = commandContextKey("rootLockDisabled")
+	readOnlyDegradationContextKeyConstant   = commandContextKey("readOnlyDegradation")
 )
 
 type commandContextKey string
@@ -159,3 +161,39 @@ func (accessor CommandContextAccessor) LogLevel(executionContext context.Context
 	}
 	return value, true
 }
+
+// WithRootLockDisabled records whether mutating runs skip the root lock, as requested with --no-lock.
+func (accessor CommandContextAccessor) WithRootLockDisabled(parentContext context.Context, disabled bool) context.Context {
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	return context.WithValue(parentContext, rootLockDisabledContextKeyConstant, disabled)
+}
+
+// RootLockDisabled reports whether the provided context asks mutating runs to skip the root lock.
+func (accessor CommandContextAccessor) RootLockDisabled(executionContext context.Context) bool {
+	if executionContext == nil {
+		return false
+	}
+	disabled, _ := executionContext.Value(rootLockDisabledContextKeyConstant).(bool)
+	return disabled
+}
+
+// WithReadOnlyDegradation records whether mutating runs fall back to a dry run when the GitHub token is read-only, as
+// requested with --degrade-readonly.
+func (accessor CommandContextAccessor) WithReadOnlyDegradation(parentContext context.Context, enabled bool) context.Context {
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	return context.WithValue(parentContext, readOnlyDegradationContextKeyConstant, enabled)
+}
+
+// ReadOnlyDegradation reports whether the provided context asks mutating runs to degrade to a dry run under a
+// read-only GitHub token.
+func (accessor CommandContextAccessor) ReadOnlyDegradation(executionContext context.Context) bool {
+	if executionContext == nil {
+		return false
+	}
+	enabled, _ := executionContext.Value(readOnlyDegradationContextKeyConstant).(bool)
+	return enabled
+}
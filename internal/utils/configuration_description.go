@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	effectiveConfigurationMessageConstant   = "Effective command configuration"
+	effectiveConfigurationCommandFieldName  = "command"
+	effectiveConfigurationValuesFieldName   = "configuration"
+	maskedConfigurationValueConstant        = "***"
+	mapstructureTagNameConstant             = "mapstructure"
+	configurationTagSeparatorConstant       = ","
+	configurationTagIgnoreConstant          = "-"
+	configurationDescriptionMaximumDepth    = 8
+	configurationDescriptionTruncatedMarker = "..."
+	syncPackagePathConstant                 = "sync"
+	environmentVariableNameSuffixConstant   = "env"
+)
+
+var secretConfigurationFieldFragments = []string{"token", "secret", "password", "credential", "apikey", "api_key", "privatekey", "private_key", "webhook"}
+
+// LogEffectiveConfiguration logs the resolved configuration a command is about to run with at debug level.
+// The configuration is rendered by DescribeConfiguration, so secret fields are masked.
+func LogEffectiveConfiguration(logger *zap.Logger, commandName string, configuration any) {
+	if logger == nil || !logger.Core().Enabled(zap.DebugLevel) {
+		return
+	}
+	logger.Debug(effectiveConfigurationMessageConstant,
+		zap.String(effectiveConfigurationCommandFieldName, commandName),
+		zap.Any(effectiveConfigurationValuesFieldName, DescribeConfiguration(configuration)),
+	)
+}
+
+// DescribeConfiguration renders a configuration value as maps, slices, and scalars suitable for structured logging.
+// Struct fields, including unexported ones, are keyed by their mapstructure tag, falling back to the field name.
+// Functions, channels, sync primitives, and collaborators held in non-empty interfaces are omitted, exported values implementing fmt.Stringer are rendered as
+// strings, and non-empty fields whose name suggests a secret (token, secret, password, credential, API or private key,
+// webhook) are replaced with "***". Fields ending in "env" name an environment variable rather than hold its value and are kept.
+func DescribeConfiguration(configuration any) any {
+	return describeConfigurationValue(reflect.ValueOf(configuration), 0)
+}
+
+func describeConfigurationValue(value reflect.Value, depth int) any {
+	if !value.IsValid() {
+		return nil
+	}
+	if depth > configurationDescriptionMaximumDepth {
+		return configurationDescriptionTruncatedMarker
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		if stringer, isStringer := stringerOf(value); isStringer {
+			return stringer.String()
+		}
+		return describeConfigurationValue(value.Elem(), depth+1)
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil
+	}
+
+	if stringer, isStringer := stringerOf(value); isStringer {
+		return stringer.String()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		described := make(map[string]any, value.NumField())
+		valueType := value.Type()
+		for fieldIndex := 0; fieldIndex < value.NumField(); fieldIndex++ {
+			field := valueType.Field(fieldIndex)
+			if !describableConfigurationFieldType(field.Type) {
+				continue
+			}
+			fieldName, include := configurationFieldName(field)
+			if !include {
+				continue
+			}
+			fieldValue := value.Field(fieldIndex)
+			if isSecretConfigurationField(field.Name) || isSecretConfigurationField(fieldName) {
+				described[fieldName] = maskConfigurationValue(fieldValue)
+				continue
+			}
+			described[fieldName] = describeConfigurationValue(fieldValue, depth+1)
+		}
+		return described
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil
+		}
+		described := make([]any, 0, value.Len())
+		for elementIndex := 0; elementIndex < value.Len(); elementIndex++ {
+			described = append(described, describeConfigurationValue(value.Index(elementIndex), depth+1))
+		}
+		return described
+	case reflect.Map:
+		if value.IsNil() {
+			return nil
+		}
+		described := make(map[string]any, value.Len())
+		keys := value.MapKeys()
+		sort.Slice(keys, func(leftIndex int, rightIndex int) bool {
+			return fmt.Sprint(describeConfigurationValue(keys[leftIndex], depth+1)) < fmt.Sprint(describeConfigurationValue(keys[rightIndex], depth+1))
+		})
+		for _, key := range keys {
+			keyName := fmt.Sprint(describeConfigurationValue(key, depth+1))
+			if isSecretConfigurationField(keyName) {
+				described[keyName] = maskConfigurationValue(value.MapIndex(key))
+				continue
+			}
+			described[keyName] = describeConfigurationValue(value.MapIndex(key), depth+1)
+		}
+		return described
+	default:
+		return scalarConfigurationValue(value)
+	}
+}
+
+func scalarConfigurationValue(value reflect.Value) any {
+	switch value.Kind() {
+	case reflect.Bool:
+		return value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint()
+	case reflect.Float32, reflect.Float64:
+		return value.Float()
+	case reflect.String:
+		return value.String()
+	default:
+		if value.CanInterface() {
+			return value.Interface()
+		}
+		return value.String()
+	}
+}
+
+func describableConfigurationFieldType(fieldType reflect.Type) bool {
+	switch fieldType.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return false
+	case reflect.Interface:
+		return fieldType.NumMethod() == 0
+	}
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType.PkgPath() != syncPackagePathConstant
+}
+
+func stringerOf(value reflect.Value) (fmt.Stringer, bool) {
+	if !value.CanInterface() {
+		return nil, false
+	}
+	stringer, isStringer := value.Interface().(fmt.Stringer)
+	return stringer, isStringer
+}
+
+func configurationFieldName(field reflect.StructField) (string, bool) {
+	tagName, _, _ := strings.Cut(field.Tag.Get(mapstructureTagNameConstant), configurationTagSeparatorConstant)
+	if tagName == configurationTagIgnoreConstant {
+		return "", false
+	}
+	if len(tagName) > 0 {
+		return tagName, true
+	}
+	return field.Name, true
+}
+
+func isSecretConfigurationField(name string) bool {
+	lowered := strings.ToLower(name)
+	if strings.HasSuffix(lowered, environmentVariableNameSuffixConstant) {
+		return false
+	}
+	for _, fragment := range secretConfigurationFieldFragments {
+		if strings.Contains(lowered, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+func maskConfigurationValue(value reflect.Value) any {
+	if !value.IsValid() || value.IsZero() {
+		return ""
+	}
+	return maskedConfigurationValueConstant
+}
@@ -0,0 +1,118 @@
+package utils_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/temirov/gix/internal/utils"
+)
+
+type describedNestedConfiguration struct {
+	Owner  string `mapstructure:"owner"`
+	Secret string `mapstructure:"client_secret"`
+}
+
+type describedConfiguration struct {
+	RemoteName     string                       `mapstructure:"remote"`
+	Roots          []string                     `mapstructure:"roots"`
+	Limit          int                          `mapstructure:"limit"`
+	DryRun         bool                         `mapstructure:"dry_run"`
+	TokenEnv       string                       `mapstructure:"token_env"`
+	APIToken       string                       `mapstructure:"api_token"`
+	Password       string                       `mapstructure:"password"`
+	WebhookURL     string                       `mapstructure:"webhook_url"`
+	Nested         describedNestedConfiguration `mapstructure:"nested"`
+	Ignored        string                       `mapstructure:"-"`
+	Timeout        time.Duration
+	Labels         map[string]string
+	Callback       func()
+	Mutex          sync.Mutex
+	Collaborator   interface{ Run() }
+	internalOption string
+}
+
+func TestDescribeConfiguration(testInstance *testing.T) {
+	configuration := describedConfiguration{
+		RemoteName:     "origin",
+		Roots:          []string{"/tmp/a", "/tmp/b"},
+		Limit:          5,
+		DryRun:         true,
+		TokenEnv:       "GITHUB_TOKEN",
+		APIToken:       "ghp_example",
+		WebhookURL:     "https://hooks.slack.com/services/T000/B000/XXXX",
+		Nested:         describedNestedConfiguration{Owner: "temirov", Secret: "hidden"},
+		Ignored:        "skip",
+		Timeout:        90 * time.Second,
+		Labels:         map[string]string{"b": "2", "a": "1", "access_token": "abc"},
+		Callback:       func() {},
+		internalOption: "internal",
+	}
+
+	described, isMap := utils.DescribeConfiguration(&configuration).(map[string]any)
+	require.True(testInstance, isMap)
+
+	testCases := []struct {
+		name     string
+		key      string
+		expected any
+	}{
+		{name: "tag_name", key: "remote", expected: "origin"},
+		{name: "slice", key: "roots", expected: []any{"/tmp/a", "/tmp/b"}},
+		{name: "integer", key: "limit", expected: int64(5)},
+		{name: "boolean", key: "dry_run", expected: true},
+		{name: "environment_variable_name_kept", key: "token_env", expected: "GITHUB_TOKEN"},
+		{name: "secret_masked", key: "api_token", expected: "***"},
+		{name: "webhook_url_masked", key: "webhook_url", expected: "***"},
+		{name: "empty_secret_blank", key: "password", expected: ""},
+		{name: "nested_struct", key: "nested", expected: map[string]any{"owner": "temirov", "client_secret": "***"}},
+		{name: "stringer", key: "Timeout", expected: "1m30s"},
+		{name: "map_secret_masked", key: "Labels", expected: map[string]any{"a": "1", "b": "2", "access_token": "***"}},
+		{name: "unexported_field", key: "internalOption", expected: "internal"},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			require.Equal(subtest, testCase.expected, described[testCase.key])
+		})
+	}
+
+	for _, omittedKey := range []string{"-", "Ignored", "Callback", "Mutex", "Collaborator"} {
+		require.NotContains(testInstance, described, omittedKey)
+	}
+}
+
+func TestLogEffectiveConfigurationRequiresDebugLevel(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		level           zapcore.Level
+		expectedEntries int
+	}{
+		{name: "debug", level: zapcore.DebugLevel, expectedEntries: 1},
+		{name: "info", level: zapcore.InfoLevel, expectedEntries: 0},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			core, observedLogs := observer.New(testCase.level)
+			utils.LogEffectiveConfiguration(zap.New(core), "gix repo-remote-update", describedConfiguration{RemoteName: "origin", APIToken: "secret"})
+
+			entries := observedLogs.FilterMessage("Effective command configuration").All()
+			require.Len(subtest, entries, testCase.expectedEntries)
+			if testCase.expectedEntries == 0 {
+				return
+			}
+			contextMap := entries[0].ContextMap()
+			require.Equal(subtest, "gix repo-remote-update", contextMap["command"])
+			configuration, isMap := contextMap["configuration"].(map[string]any)
+			require.True(subtest, isMap)
+			require.Equal(subtest, "origin", configuration["remote"])
+			require.Equal(subtest, "***", configuration["api_token"])
+		})
+	}
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/viper"
@@ -14,6 +15,7 @@ const (
 	configurationReadErrorTemplateConstant          = "failed to read configuration: %w"
 	configurationUnmarshalErrorTemplateConstant     = "failed to parse configuration: %w"
 	embeddedConfigurationMergeErrorTemplateConstant = "failed to merge embedded configuration: %w"
+	configurationSecretFileErrorTemplateConstant    = "failed to resolve configuration secret files: %w"
 )
 
 // ConfigurationLoader wraps Viper to load structured configuration files and environment overrides.
@@ -25,6 +27,7 @@ type ConfigurationLoader struct {
 	environmentKeyReplacer    *strings.Replacer
 	embeddedConfiguration     []byte
 	embeddedConfigurationType string
+	configurationFilesSkipped bool
 }
 
 // LoadedConfiguration surfaces metadata about the resolved configuration.
@@ -64,6 +67,26 @@ func (loader *ConfigurationLoader) SetEmbeddedConfiguration(configurationData []
 	loader.embeddedConfiguration = duplicatedData
 }
 
+// SetSearchPaths replaces the directories searched for configuration files.
+func (loader *ConfigurationLoader) SetSearchPaths(searchPaths []string) {
+	if loader == nil {
+		return
+	}
+
+	duplicatedSearchPaths := make([]string, len(searchPaths))
+	copy(duplicatedSearchPaths, searchPaths)
+	loader.searchPaths = duplicatedSearchPaths
+}
+
+// SetConfigurationFilesSkipped controls whether configuration files are ignored, leaving embedded defaults and environment overrides.
+func (loader *ConfigurationLoader) SetConfigurationFilesSkipped(skipped bool) {
+	if loader == nil {
+		return
+	}
+
+	loader.configurationFilesSkipped = skipped
+}
+
 // LoadConfiguration populates targetConfiguration using configuration files, defaults, and environment variables.
 func (loader *ConfigurationLoader) LoadConfiguration(configurationFilePath string, defaultValues map[string]any, targetConfiguration any) (LoadedConfiguration, error) {
 	viperInstance := viper.New()
@@ -85,8 +108,10 @@ func (loader *ConfigurationLoader) LoadConfiguration(configurationFilePath strin
 		viperInstance.SetConfigType(loader.configurationType)
 	}
 
-	for _, searchPath := range loader.searchPaths {
-		viperInstance.AddConfigPath(searchPath)
+	if !loader.configurationFilesSkipped {
+		for _, searchPath := range loader.searchPaths {
+			viperInstance.AddConfigPath(searchPath)
+		}
 	}
 
 	viperInstance.SetEnvPrefix(loader.environmentPrefix)
@@ -99,24 +124,31 @@ func (loader *ConfigurationLoader) LoadConfiguration(configurationFilePath strin
 		viperInstance.SetDefault(defaultKey, defaultValue)
 	}
 
-	if len(configurationFilePath) > 0 {
-		viperInstance.SetConfigFile(configurationFilePath)
-	}
+	if !loader.configurationFilesSkipped {
+		if len(configurationFilePath) > 0 {
+			viperInstance.SetConfigFile(configurationFilePath)
+		}
 
-	readError := viperInstance.MergeInConfig()
-	if readError != nil {
-		if _, isNotFound := readError.(viper.ConfigFileNotFoundError); !isNotFound {
-			return LoadedConfiguration{}, fmt.Errorf(configurationReadErrorTemplateConstant, readError)
+		readError := viperInstance.MergeInConfig()
+		if readError != nil {
+			if _, isNotFound := readError.(viper.ConfigFileNotFoundError); !isNotFound {
+				return LoadedConfiguration{}, fmt.Errorf(configurationReadErrorTemplateConstant, readError)
+			}
 		}
 	}
 
+	if secretFileError := applyResolvedSecretFiles(viperInstance, os.ReadFile); secretFileError != nil {
+		return LoadedConfiguration{}, fmt.Errorf(configurationSecretFileErrorTemplateConstant, secretFileError)
+	}
+
 	unmarshalError := viperInstance.Unmarshal(targetConfiguration)
 	if unmarshalError != nil {
 		return LoadedConfiguration{}, fmt.Errorf(configurationUnmarshalErrorTemplateConstant, unmarshalError)
 	}
 
-	loadedConfiguration := LoadedConfiguration{
-		ConfigFileUsed: viperInstance.ConfigFileUsed(),
+	loadedConfiguration := LoadedConfiguration{}
+	if !loader.configurationFilesSkipped {
+		loadedConfiguration.ConfigFileUsed = viperInstance.ConfigFileUsed()
 	}
 
 	return loadedConfiguration, nil
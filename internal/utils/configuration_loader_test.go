@@ -261,3 +261,25 @@ func TestConfigurationLoaderExplicitFileOverridesSearchPaths(t *testing.T) {
 	require.Equal(t, testOverriddenLogLevelConstant, loadedConfiguration.Common.LogLevel)
 	require.Equal(t, explicitConfigPath, metadata.ConfigFileUsed)
 }
+
+func TestConfigurationLoaderSkipsConfigurationFiles(t *testing.T) {
+	searchDirectory := t.TempDir()
+	searchConfigPath := filepath.Join(searchDirectory, testConfigFileNameConstant)
+	require.NoError(t, os.WriteFile(searchConfigPath, []byte(fmt.Sprintf(testConfigContentTemplateConstant, testFileLogLevelConstant)), 0o600))
+
+	loader := utils.NewConfigurationLoader(testConfigurationNameConstant, testConfigurationTypeConstant, testEnvironmentPrefixConstant, []string{searchDirectory})
+	loader.SetEmbeddedConfiguration([]byte(fmt.Sprintf(testConfigContentTemplateConstant, testEmbeddedLogLevelConstant)), testConfigurationTypeConstant)
+	loader.SetConfigurationFilesSkipped(true)
+
+	loadedConfiguration := configurationFixture{}
+	metadata, loadError := loader.LoadConfiguration(searchConfigPath, nil, &loadedConfiguration)
+	require.NoError(t, loadError)
+	require.Equal(t, testEmbeddedLogLevelConstant, loadedConfiguration.Common.LogLevel)
+	require.Empty(t, metadata.ConfigFileUsed)
+
+	t.Setenv(testEnvironmentPrefixConstant+"_COMMON_LOG_LEVEL", testOverriddenLogLevelConstant)
+	environmentConfiguration := configurationFixture{}
+	_, environmentLoadError := loader.LoadConfiguration("", nil, &environmentConfiguration)
+	require.NoError(t, environmentLoadError)
+	require.Equal(t, testOverriddenLogLevelConstant, environmentConfiguration.Common.LogLevel)
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	pathutils "github.com/temirov/gix/internal/utils/path"
+)
+
+const (
+	tokenKeySuffixConstant                  = "token"
+	tokenKeySeparatorConstant               = "_"
+	secretFileKeySuffixConstant             = "_file"
+	secretFileLineTerminatorsConstant       = "\r\n"
+	secretFileConflictErrorTemplateConstant = "configuration keys %s and %s are both set; use only one"
+	secretFileReadErrorTemplateConstant     = "unable to read %s file %s: %w"
+	secretFileEmptyErrorTemplateConstant    = "%s file %s is empty"
+	secretFileMissingPathTemplateConstant   = "%s must name a file"
+)
+
+// SecretFileReader reads a secret file referenced by a *_token_file configuration key.
+type SecretFileReader func(path string) ([]byte, error)
+
+// IsTokenFileKey reports whether the configuration key names a token file, such as token_file or api_token_file.
+func IsTokenFileKey(key string) bool {
+	if !strings.HasSuffix(key, secretFileKeySuffixConstant) {
+		return false
+	}
+	tokenKey := strings.TrimSuffix(key, secretFileKeySuffixConstant)
+	return tokenKey == tokenKeySuffixConstant || strings.HasSuffix(tokenKey, tokenKeySeparatorConstant+tokenKeySuffixConstant)
+}
+
+// ResolveSecretFiles replaces every *_token_file entry in the settings tree with its *_token sibling holding the file
+// contents. Paths are tilde-expanded and the trailing newline is trimmed. Setting both keys, naming a missing file, or
+// naming an empty file is an error. Nested maps and lists of maps, such as operation options, are resolved as well.
+func ResolveSecretFiles(settings map[string]any, readFile SecretFileReader) (map[string]any, error) {
+	if readFile == nil {
+		readFile = os.ReadFile
+	}
+	resolved, resolveError := resolveSecretFilesInValue(settings, "", readFile, pathutils.NewHomeExpander())
+	if resolveError != nil {
+		return nil, resolveError
+	}
+	resolvedSettings, _ := resolved.(map[string]any)
+	return resolvedSettings, nil
+}
+
+func resolveSecretFilesInValue(value any, keyPath string, readFile SecretFileReader, homeExpander *pathutils.HomeExpander) (any, error) {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		return resolveSecretFilesInMap(typedValue, keyPath, readFile, homeExpander)
+	case []any:
+		resolvedItems := make([]any, len(typedValue))
+		for itemIndex, item := range typedValue {
+			resolvedItem, itemError := resolveSecretFilesInValue(item, fmt.Sprintf("%s[%d]", keyPath, itemIndex), readFile, homeExpander)
+			if itemError != nil {
+				return nil, itemError
+			}
+			resolvedItems[itemIndex] = resolvedItem
+		}
+		return resolvedItems, nil
+	default:
+		return value, nil
+	}
+}
+
+func resolveSecretFilesInMap(settings map[string]any, keyPath string, readFile SecretFileReader, homeExpander *pathutils.HomeExpander) (map[string]any, error) {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	resolvedSettings := make(map[string]any, len(settings))
+	for _, key := range keys {
+		resolvedValue, valueError := resolveSecretFilesInValue(settings[key], joinConfigurationKeyPath(keyPath, key), readFile, homeExpander)
+		if valueError != nil {
+			return nil, valueError
+		}
+		resolvedSettings[key] = resolvedValue
+	}
+
+	for _, key := range keys {
+		if !IsTokenFileKey(key) {
+			continue
+		}
+		filePath := strings.TrimSpace(fmt.Sprint(resolvedSettings[key]))
+		if resolvedSettings[key] == nil || len(filePath) == 0 {
+			continue
+		}
+
+		tokenKey := strings.TrimSuffix(key, secretFileKeySuffixConstant)
+		fileKeyPath := joinConfigurationKeyPath(keyPath, key)
+		tokenKeyPath := joinConfigurationKeyPath(keyPath, tokenKey)
+		if existingToken, exists := resolvedSettings[tokenKey]; exists && existingToken != nil && len(strings.TrimSpace(fmt.Sprint(existingToken))) > 0 {
+			return nil, fmt.Errorf(secretFileConflictErrorTemplateConstant, tokenKeyPath, fileKeyPath)
+		}
+
+		expandedPath := homeExpander.Expand(filePath)
+		if len(expandedPath) == 0 {
+			return nil, fmt.Errorf(secretFileMissingPathTemplateConstant, fileKeyPath)
+		}
+		contents, readError := readFile(expandedPath)
+		if readError != nil {
+			return nil, fmt.Errorf(secretFileReadErrorTemplateConstant, tokenKeyPath, expandedPath, readError)
+		}
+		secret := strings.TrimRight(string(contents), secretFileLineTerminatorsConstant)
+		if len(strings.TrimSpace(secret)) == 0 {
+			return nil, fmt.Errorf(secretFileEmptyErrorTemplateConstant, tokenKeyPath, expandedPath)
+		}
+		resolvedSettings[tokenKey] = secret
+		delete(resolvedSettings, key)
+	}
+
+	return resolvedSettings, nil
+}
+
+func applyResolvedSecretFiles(viperInstance *viper.Viper, readFile SecretFileReader) error {
+	settings := viperInstance.AllSettings()
+	resolvedSettings, resolveError := ResolveSecretFiles(settings, readFile)
+	if resolveError != nil {
+		return resolveError
+	}
+	for key, resolvedValue := range resolvedSettings {
+		if reflect.DeepEqual(resolvedValue, settings[key]) {
+			continue
+		}
+		viperInstance.Set(key, resolvedValue)
+	}
+	return nil
+}
+
+func joinConfigurationKeyPath(parentPath string, key string) string {
+	if len(parentPath) == 0 {
+		return key
+	}
+	return parentPath + environmentKeySeparatorOldConstant + key
+}
@@ -0,0 +1,155 @@
+package utils_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	testSecretFilePathConstant           = "/secrets/packages-token"
+	testSecretFileContentsConstant       = "ghp_example\n"
+	testSecretFileValueConstant          = "ghp_example"
+	testSecretFileEmptyPathConstant      = "/secrets/empty-token"
+	testSecretFileMissingPathConstant    = "/secrets/missing-token"
+	testSecretConfigFileContentsConstant = "operations:\n  - operation: repo-packages-purge\n    with:\n      token_file: %s\n"
+)
+
+func TestIsTokenFileKey(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected bool
+	}{
+		{key: "token_file", expected: true},
+		{key: "api_token_file", expected: true},
+		{key: "token", expected: false},
+		{key: "snapshot_file", expected: false},
+		{key: "tokens_file", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.key, func(subtest *testing.T) {
+			require.Equal(subtest, testCase.expected, utils.IsTokenFileKey(testCase.key))
+		})
+	}
+}
+
+func TestResolveSecretFiles(t *testing.T) {
+	fileContents := map[string]string{
+		testSecretFilePathConstant:      testSecretFileContentsConstant,
+		testSecretFileEmptyPathConstant: "\n",
+	}
+	readFile := func(path string) ([]byte, error) {
+		contents, found := fileContents[path]
+		if !found {
+			return nil, os.ErrNotExist
+		}
+		return []byte(contents), nil
+	}
+
+	testCases := []struct {
+		name             string
+		settings         map[string]any
+		expected         map[string]any
+		expectedErrorSub string
+	}{
+		{
+			name:     "top_level_token_file",
+			settings: map[string]any{"api_token_file": testSecretFilePathConstant},
+			expected: map[string]any{"api_token": testSecretFileValueConstant},
+		},
+		{
+			name: "operation_options_token_file",
+			settings: map[string]any{
+				"operations": []any{
+					map[string]any{"operation": "repo-packages-purge", "with": map[string]any{"token_file": testSecretFilePathConstant}},
+				},
+			},
+			expected: map[string]any{
+				"operations": []any{
+					map[string]any{"operation": "repo-packages-purge", "with": map[string]any{"token": testSecretFileValueConstant}},
+				},
+			},
+		},
+		{
+			name:     "unrelated_file_keys_untouched",
+			settings: map[string]any{"snapshot_file": testSecretFilePathConstant},
+			expected: map[string]any{"snapshot_file": testSecretFilePathConstant},
+		},
+		{
+			name:             "both_forms_conflict",
+			settings:         map[string]any{"token": "inline", "token_file": testSecretFilePathConstant},
+			expectedErrorSub: "configuration keys token and token_file are both set",
+		},
+		{
+			name:             "missing_file",
+			settings:         map[string]any{"common": map[string]any{"token_file": testSecretFileMissingPathConstant}},
+			expectedErrorSub: "unable to read common.token file " + testSecretFileMissingPathConstant,
+		},
+		{
+			name:             "empty_file",
+			settings:         map[string]any{"token_file": testSecretFileEmptyPathConstant},
+			expectedErrorSub: "token file " + testSecretFileEmptyPathConstant + " is empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(subtest *testing.T) {
+			resolved, resolveError := utils.ResolveSecretFiles(testCase.settings, readFile)
+			if len(testCase.expectedErrorSub) > 0 {
+				require.Error(subtest, resolveError)
+				require.Contains(subtest, resolveError.Error(), testCase.expectedErrorSub)
+				return
+			}
+			require.NoError(subtest, resolveError)
+			require.Equal(subtest, testCase.expected, resolved)
+		})
+	}
+}
+
+func TestResolveSecretFilesExpandsHomeDirectory(t *testing.T) {
+	homeDirectory := t.TempDir()
+	t.Setenv("HOME", homeDirectory)
+
+	var requestedPath string
+	readFile := func(path string) ([]byte, error) {
+		requestedPath = path
+		if path != filepath.Join(homeDirectory, ".config", "token") {
+			return nil, errors.New("unexpected path")
+		}
+		return []byte(testSecretFileContentsConstant), nil
+	}
+
+	resolved, resolveError := utils.ResolveSecretFiles(map[string]any{"token_file": "~/.config/token"}, readFile)
+	require.NoError(t, resolveError)
+	require.Equal(t, filepath.Join(homeDirectory, ".config", "token"), requestedPath)
+	require.Equal(t, map[string]any{"token": testSecretFileValueConstant}, resolved)
+}
+
+func TestConfigurationLoaderResolvesTokenFiles(t *testing.T) {
+	configurationDirectory := t.TempDir()
+	tokenPath := filepath.Join(configurationDirectory, "packages-token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte(testSecretFileContentsConstant), 0o600))
+
+	configurationPath := filepath.Join(configurationDirectory, testConfigFileNameConstant)
+	require.NoError(t, os.WriteFile(configurationPath, []byte(fmt.Sprintf(testSecretConfigFileContentsConstant, tokenPath)), 0o600))
+
+	loader := utils.NewConfigurationLoader(testConfigurationNameConstant, testConfigurationTypeConstant, testEnvironmentPrefixConstant, nil)
+
+	loadedConfiguration := struct {
+		Operations []struct {
+			Operation string         `mapstructure:"operation"`
+			With      map[string]any `mapstructure:"with"`
+		} `mapstructure:"operations"`
+	}{}
+	_, loadError := loader.LoadConfiguration(configurationPath, nil, &loadedConfiguration)
+	require.NoError(t, loadError)
+	require.Len(t, loadedConfiguration.Operations, 1)
+	require.Equal(t, map[string]any{"token": testSecretFileValueConstant}, loadedConfiguration.Operations[0].With)
+}
@@ -7,6 +7,8 @@ const (
 	DefaultRootFlagName = "roots"
 	// DefaultRootFlagUsage describes the shared repository root flag purpose.
 	DefaultRootFlagUsage = "Repository roots to scan (repeatable; nested paths ignored)"
+	// RootArgumentsUsage documents positional repository roots in command usage lines.
+	RootArgumentsUsage = "[root ...]"
 	// DryRunFlagName exposes the shared dry-run flag name.
 	DryRunFlagName = "dry-run"
 	// DryRunFlagUsage describes the shared dry-run flag purpose.
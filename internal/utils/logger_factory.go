@@ -2,7 +2,9 @@ package utils
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -15,11 +17,13 @@ const (
 	logLevelErrorStringConstant          = "error"
 	logFormatStructuredStringConstant    = "structured"
 	logFormatConsoleStringConstant       = "console"
+	logFormatJSONStringConstant          = "json"
 	jsonZapEncodingStringConstant        = "json"
 	consoleZapEncodingStringConstant     = "console"
 	unsupportedLogLevelTemplateConstant  = "unsupported log level: %s"
 	unsupportedLogFormatTemplateConstant = "unsupported log format: %s"
 	timeFieldNameConstant                = "time"
+	structuredTimeFieldNameConstant      = "ts"
 	levelFieldNameConstant               = "level"
 	structuredMessageFieldNameConstant   = "msg"
 	consoleMessageFieldNameConstant      = "message"
@@ -28,6 +32,9 @@ const (
 	stacktraceFieldNameConstant          = "stacktrace"
 	humanReadableTimeLayoutConstant      = "15:04:05"
 	emptyStringConstant                  = ""
+	defaultSamplingInitialConstant       = 100
+	defaultSamplingThereafterConstant    = 100
+	invalidSamplingTemplateConstant      = "invalid log sampling: initial=%d thereafter=%d"
 )
 
 // LogLevel enumerates supported logging granularities.
@@ -48,11 +55,33 @@ type LogFormat string
 const (
 	LogFormatStructured LogFormat = LogFormat(logFormatStructuredStringConstant)
 	LogFormatConsole    LogFormat = LogFormat(logFormatConsoleStringConstant)
+	LogFormatJSON       LogFormat = LogFormat(logFormatJSONStringConstant)
 )
 
+// Structured reports whether the format emits JSON events; json is an alias of structured.
+func (format LogFormat) Structured() bool {
+	normalized := LogFormat(strings.ToLower(strings.TrimSpace(string(format))))
+	return normalized == LogFormatStructured || normalized == LogFormatJSON
+}
+
 // LoggerFactory builds zap.Logger instances with consistent configuration.
 type LoggerFactory struct{}
 
+// LoggingSamplingOptions configures zap sampling for the diagnostic logger.
+// Zero values keep the default of logging the first 100 identical entries per second and every 100th thereafter.
+type LoggingSamplingOptions struct {
+	Initial    int `mapstructure:"initial"`
+	Thereafter int `mapstructure:"thereafter"`
+}
+
+// LoggingOptions tunes diagnostic logger output beyond level and format.
+// The zero value reproduces the default logger configuration.
+type LoggingOptions struct {
+	Sampling        LoggingSamplingOptions `mapstructure:"sampling"`
+	Caller          bool                   `mapstructure:"caller"`
+	ErrorStacktrace bool                   `mapstructure:"error_stacktrace"`
+}
+
 // LoggerOutputs bundles diagnostic and console loggers.
 type LoggerOutputs struct {
 	DiagnosticLogger *zap.Logger
@@ -69,6 +98,7 @@ var logLevelMapping = map[LogLevel]zapcore.Level{
 var logFormatEncodingMapping = map[LogFormat]string{
 	LogFormatStructured: jsonZapEncodingStringConstant,
 	LogFormatConsole:    consoleZapEncodingStringConstant,
+	LogFormatJSON:       jsonZapEncodingStringConstant,
 }
 
 // NewLoggerFactory constructs a new logger factory.
@@ -87,6 +117,11 @@ func (factory *LoggerFactory) CreateLogger(requestedLogLevel LogLevel, requested
 
 // CreateLoggerOutputs builds both diagnostic and console loggers for the requested configuration.
 func (factory *LoggerFactory) CreateLoggerOutputs(requestedLogLevel LogLevel, requestedLogFormat LogFormat) (LoggerOutputs, error) {
+	return factory.CreateLoggerOutputsWithOptions(requestedLogLevel, requestedLogFormat, LoggingOptions{})
+}
+
+// CreateLoggerOutputsWithOptions builds both diagnostic and console loggers, applying sampling, caller, and stacktrace options to the diagnostic logger.
+func (factory *LoggerFactory) CreateLoggerOutputsWithOptions(requestedLogLevel LogLevel, requestedLogFormat LogFormat, loggingOptions LoggingOptions) (LoggerOutputs, error) {
 	zapLogLevel, levelExists := logLevelMapping[requestedLogLevel]
 	if !levelExists {
 		return LoggerOutputs{}, fmt.Errorf(unsupportedLogLevelTemplateConstant, requestedLogLevel)
@@ -96,7 +131,12 @@ func (factory *LoggerFactory) CreateLoggerOutputs(requestedLogLevel LogLevel, re
 		return LoggerOutputs{}, fmt.Errorf(unsupportedLogFormatTemplateConstant, requestedLogFormat)
 	}
 
-	diagnosticLogger, diagnosticError := factory.buildDiagnosticLogger(zapLogLevel, requestedLogFormat)
+	samplingConfiguration, samplingError := loggingOptions.Sampling.samplingConfiguration()
+	if samplingError != nil {
+		return LoggerOutputs{}, samplingError
+	}
+
+	diagnosticLogger, diagnosticError := factory.buildDiagnosticLogger(zapLogLevel, requestedLogFormat, samplingConfiguration, loggingOptions)
 	if diagnosticError != nil {
 		return LoggerOutputs{}, diagnosticError
 	}
@@ -114,10 +154,11 @@ func (factory *LoggerFactory) CreateLoggerOutputs(requestedLogLevel LogLevel, re
 	return LoggerOutputs{DiagnosticLogger: diagnosticLogger, ConsoleLogger: consoleLogger}, nil
 }
 
-func (factory *LoggerFactory) buildDiagnosticLogger(zapLogLevel zapcore.Level, requestedLogFormat LogFormat) (*zap.Logger, error) {
+func (factory *LoggerFactory) buildDiagnosticLogger(zapLogLevel zapcore.Level, requestedLogFormat LogFormat, samplingConfiguration *zap.SamplingConfig, loggingOptions LoggingOptions) (*zap.Logger, error) {
 	configuration := zap.NewProductionConfig()
 	configuration.Level = zap.NewAtomicLevelAt(zapLogLevel)
-	configuration.DisableStacktrace = true
+	configuration.Sampling = samplingConfiguration
+	configuration.DisableStacktrace = !loggingOptions.ErrorStacktrace
 
 	switch requestedLogFormat {
 	case LogFormatConsole:
@@ -130,20 +171,64 @@ func (factory *LoggerFactory) buildDiagnosticLogger(zapLogLevel zapcore.Level, r
 		configuration.EncoderConfig.CallerKey = emptyStringConstant
 		configuration.EncoderConfig.StacktraceKey = emptyStringConstant
 		configuration.EncoderConfig.NameKey = emptyStringConstant
-		configuration.DisableCaller = true
 	default:
 		configuration.Encoding = jsonZapEncodingStringConstant
-		configuration.EncoderConfig.TimeKey = timeFieldNameConstant
-		configuration.EncoderConfig.LevelKey = levelFieldNameConstant
-		configuration.EncoderConfig.MessageKey = structuredMessageFieldNameConstant
-		configuration.EncoderConfig.NameKey = nameFieldNameConstant
+		configuration.EncoderConfig = structuredEncoderConfig()
+	}
+
+	configuration.DisableCaller = !loggingOptions.Caller
+	configuration.EncoderConfig.CallerKey = emptyStringConstant
+	if loggingOptions.Caller {
 		configuration.EncoderConfig.CallerKey = callerFieldNameConstant
+	}
+
+	if loggingOptions.ErrorStacktrace {
 		configuration.EncoderConfig.StacktraceKey = stacktraceFieldNameConstant
 	}
 
 	return configuration.Build()
 }
 
+// NewStructuredEventLogger builds an info-level logger that writes unsampled JSON events to writer using the structured log schema.
+// Every event carries the level, ts, and msg base fields.
+func NewStructuredEventLogger(writer io.Writer) *zap.Logger {
+	if writer == nil {
+		writer = os.Stdout
+	}
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(structuredEncoderConfig()),
+		zapcore.Lock(zapcore.AddSync(writer)),
+		zapcore.InfoLevel,
+	)
+	return zap.New(core)
+}
+
+func structuredEncoderConfig() zapcore.EncoderConfig {
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.TimeKey = structuredTimeFieldNameConstant
+	encoderConfig.LevelKey = levelFieldNameConstant
+	encoderConfig.MessageKey = structuredMessageFieldNameConstant
+	encoderConfig.NameKey = nameFieldNameConstant
+	encoderConfig.CallerKey = callerFieldNameConstant
+	encoderConfig.StacktraceKey = stacktraceFieldNameConstant
+	return encoderConfig
+}
+
+func (sampling LoggingSamplingOptions) samplingConfiguration() (*zap.SamplingConfig, error) {
+	if sampling.Initial < 0 || sampling.Thereafter < 0 {
+		return nil, fmt.Errorf(invalidSamplingTemplateConstant, sampling.Initial, sampling.Thereafter)
+	}
+	initial := sampling.Initial
+	if initial == 0 {
+		initial = defaultSamplingInitialConstant
+	}
+	thereafter := sampling.Thereafter
+	if thereafter == 0 {
+		thereafter = defaultSamplingThereafterConstant
+	}
+	return &zap.SamplingConfig{Initial: initial, Thereafter: thereafter}, nil
+}
+
 func (factory *LoggerFactory) buildConsoleLogger(zapLogLevel zapcore.Level) (*zap.Logger, error) {
 	encoderConfig := zapcore.EncoderConfig{
 		MessageKey:    consoleMessageFieldNameConstant,
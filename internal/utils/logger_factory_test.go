@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"github.com/temirov/gix/internal/utils"
 )
@@ -24,6 +25,8 @@ const (
 	testInvalidLogFormatConstant                   = "invalid"
 	testLogMessageConstant                         = "logger_factory_test_message"
 	testConsoleLogMessageConstant                  = "console_event_message"
+	testLoggerFactoryCallerFileConstant            = "logger_factory_test.go"
+	testLoggerFactoryStacktraceKeyConstant         = "\"stacktrace\""
 )
 
 func TestLoggerFactoryCreateLogger(testInstance *testing.T) {
@@ -147,3 +150,171 @@ func TestLoggerFactoryCreateLogger(testInstance *testing.T) {
 		})
 	}
 }
+
+func TestLoggerFactoryLoggingOptions(testInstance *testing.T) {
+	testCases := []struct {
+		name               string
+		requestedLogFormat utils.LogFormat
+		loggingOptions     utils.LoggingOptions
+		entryCount         int
+		expectedLineCount  int
+		expectCaller       bool
+		expectStacktrace   bool
+		expectError        bool
+	}{
+		{
+			name:               "structured_defaults",
+			requestedLogFormat: utils.LogFormatStructured,
+			entryCount:         250,
+			expectedLineCount:  101,
+		},
+		{
+			name:               "console_defaults",
+			requestedLogFormat: utils.LogFormatConsole,
+			entryCount:         3,
+			expectedLineCount:  3,
+		},
+		{
+			name:               "custom_sampling",
+			requestedLogFormat: utils.LogFormatStructured,
+			loggingOptions:     utils.LoggingOptions{Sampling: utils.LoggingSamplingOptions{Initial: 2, Thereafter: 50}},
+			entryCount:         120,
+			expectedLineCount:  4,
+		},
+		{
+			name:               "console_caller_enabled",
+			requestedLogFormat: utils.LogFormatConsole,
+			loggingOptions:     utils.LoggingOptions{Caller: true},
+			entryCount:         1,
+			expectedLineCount:  1,
+			expectCaller:       true,
+		},
+		{
+			name:               "structured_caller_enabled",
+			requestedLogFormat: utils.LogFormatStructured,
+			loggingOptions:     utils.LoggingOptions{Caller: true},
+			entryCount:         1,
+			expectedLineCount:  1,
+			expectCaller:       true,
+		},
+		{
+			name:               "error_stacktrace_enabled",
+			requestedLogFormat: utils.LogFormatStructured,
+			loggingOptions:     utils.LoggingOptions{Caller: true, ErrorStacktrace: true},
+			entryCount:         1,
+			expectedLineCount:  1,
+			expectCaller:       true,
+			expectStacktrace:   true,
+		},
+		{
+			name:               "negative_sampling_rejected",
+			requestedLogFormat: utils.LogFormatStructured,
+			loggingOptions:     utils.LoggingOptions{Sampling: utils.LoggingSamplingOptions{Initial: -1}},
+			expectError:        true,
+		},
+	}
+
+	for testCaseIndex, testCase := range testCases {
+		testInstance.Run(fmt.Sprintf(testLoggerFactorySubtestTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
+			pipeReader, pipeWriter, pipeError := os.Pipe()
+			require.NoError(testInstance, pipeError)
+
+			originalStderr := os.Stderr
+			os.Stderr = pipeWriter
+			loggerOutputs, creationError := utils.NewLoggerFactory().CreateLoggerOutputsWithOptions(utils.LogLevelDebug, testCase.requestedLogFormat, testCase.loggingOptions)
+			os.Stderr = originalStderr
+
+			if testCase.expectError {
+				require.Error(testInstance, creationError)
+				require.NoError(testInstance, pipeWriter.Close())
+				require.NoError(testInstance, pipeReader.Close())
+				return
+			}
+			require.NoError(testInstance, creationError)
+
+			for entryIndex := 0; entryIndex < testCase.entryCount; entryIndex++ {
+				loggerOutputs.DiagnosticLogger.Error(testLogMessageConstant)
+			}
+			_ = loggerOutputs.DiagnosticLogger.Sync()
+			require.NoError(testInstance, pipeWriter.Close())
+
+			capturedOutput, readError := io.ReadAll(pipeReader)
+			require.NoError(testInstance, readError)
+			require.NoError(testInstance, pipeReader.Close())
+
+			loggedEntries := 0
+			for _, line := range bytes.Split(bytes.TrimSpace(capturedOutput), []byte("\n")) {
+				if bytes.Contains(line, []byte(testLogMessageConstant)) {
+					loggedEntries++
+				}
+			}
+			require.Equal(testInstance, testCase.expectedLineCount, loggedEntries)
+
+			outputText := string(capturedOutput)
+			if testCase.expectCaller {
+				require.Contains(testInstance, outputText, testLoggerFactoryCallerFileConstant)
+			} else {
+				require.NotContains(testInstance, outputText, testLoggerFactoryCallerFileConstant)
+			}
+			if testCase.expectStacktrace {
+				require.Contains(testInstance, outputText, testLoggerFactoryStacktraceKeyConstant)
+			} else {
+				require.NotContains(testInstance, outputText, testLoggerFactoryStacktraceKeyConstant)
+			}
+		})
+	}
+}
+
+func TestStructuredLogSchema(testInstance *testing.T) {
+	requiredFields := []string{"level", "msg", "ts"}
+
+	testCases := []struct {
+		name               string
+		requestedLogFormat utils.LogFormat
+	}{
+		{name: "structured", requestedLogFormat: utils.LogFormatStructured},
+		{name: "json", requestedLogFormat: utils.LogFormatJSON},
+	}
+
+	for testCaseIndex, testCase := range testCases {
+		testInstance.Run(fmt.Sprintf(testLoggerFactorySubtestTemplateConstant, testCaseIndex, testCase.name), func(testInstance *testing.T) {
+			require.True(testInstance, testCase.requestedLogFormat.Structured())
+
+			pipeReader, pipeWriter, pipeError := os.Pipe()
+			require.NoError(testInstance, pipeError)
+
+			originalStderr := os.Stderr
+			os.Stderr = pipeWriter
+			loggerOutputs, creationError := utils.NewLoggerFactory().CreateLoggerOutputs(utils.LogLevelDebug, testCase.requestedLogFormat)
+			os.Stderr = originalStderr
+			require.NoError(testInstance, creationError)
+
+			loggerOutputs.DiagnosticLogger.Debug(testLogMessageConstant)
+			loggerOutputs.DiagnosticLogger.Info(testLogMessageConstant)
+			loggerOutputs.DiagnosticLogger.Warn(testLogMessageConstant)
+			loggerOutputs.DiagnosticLogger.Error(testLogMessageConstant, zap.Error(errors.New(testLogMessageConstant)))
+			loggerOutputs.ConsoleLogger.Info(testConsoleLogMessageConstant)
+			_ = loggerOutputs.DiagnosticLogger.Sync()
+			require.NoError(testInstance, pipeWriter.Close())
+
+			diagnosticOutput, readError := io.ReadAll(pipeReader)
+			require.NoError(testInstance, readError)
+			require.NoError(testInstance, pipeReader.Close())
+
+			eventOutput := &bytes.Buffer{}
+			eventLogger := utils.NewStructuredEventLogger(eventOutput)
+			eventLogger.Info("PLAN-OK: /tmp/a → /tmp/b", zap.String("event", "PLAN-OK"))
+			eventLogger.Info("gix version: v1.0.0", zap.String("version", "v1.0.0"))
+
+			emittedLines := append(bytes.Split(bytes.TrimSpace(diagnosticOutput), []byte("\n")), bytes.Split(bytes.TrimSpace(eventOutput.Bytes()), []byte("\n"))...)
+			require.Len(testInstance, emittedLines, 6)
+			for _, line := range emittedLines {
+				decoded := map[string]any{}
+				require.NoError(testInstance, json.Unmarshal(line, &decoded), string(line))
+				for _, fieldName := range requiredFields {
+					require.Contains(testInstance, decoded, fieldName, string(line))
+				}
+			}
+		})
+	}
+}
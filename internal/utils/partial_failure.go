@@ -0,0 +1,13 @@
+package utils
+
+// PartialFailureExitCode is the process exit code of a run that went through every repository or package but failed
+// for some of them, so a partial failure is distinguishable from an aborted run.
+const PartialFailureExitCode = 2
+
+// PartialFailure gives the error embedding it the partial-failure exit code.
+type PartialFailure struct{}
+
+// ProcessExitCode returns PartialFailureExitCode.
+func (PartialFailure) ProcessExitCode() int {
+	return PartialFailureExitCode
+}
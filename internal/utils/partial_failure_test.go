@@ -0,0 +1,27 @@
+package utils_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/utils"
+)
+
+type embeddedPartialFailureError struct {
+	utils.PartialFailure
+}
+
+func (embeddedPartialFailureError) Error() string {
+	return "partial failure"
+}
+
+func TestPartialFailureExitCodeReachesWrappingErrors(testInstance *testing.T) {
+	wrappedError := fmt.Errorf("run finished: %w", embeddedPartialFailureError{})
+
+	var exitCoder interface{ ProcessExitCode() int }
+	require.True(testInstance, errors.As(wrappedError, &exitCoder))
+	require.Equal(testInstance, utils.PartialFailureExitCode, exitCoder.ProcessExitCode())
+}
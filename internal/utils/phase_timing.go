@@ -0,0 +1,192 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PhaseConfiguration times configuration loading and logger setup.
+	PhaseConfiguration = "configuration"
+	// PhaseDiscovery times repository discovery and inspection.
+	PhaseDiscovery = "discovery"
+	// PhaseProcessing times the per-repository work of a command.
+	PhaseProcessing = "processing"
+	// PhaseReporting times summaries and report output written after processing.
+	PhaseReporting = "reporting"
+
+	phaseTimerContextKeyConstant          = commandContextKey("phaseTimer")
+	phaseTimingSummaryTemplateConstant    = "done in %s"
+	phaseTimingPhasesTemplateConstant     = "%s: %s"
+	phaseTimingPhaseTemplateConstant      = "%s %s"
+	phaseTimingRepositoriesTemplate       = "%s across %d repos"
+	phaseTimingPhaseSeparatorConstant     = ", "
+	phaseTimingTotalLogFieldNameConstant  = "total_duration"
+	phaseTimingPhaseLogFieldTemplate      = "%s_duration"
+	phaseTimingRepositoriesLogFieldName   = "repository_count"
+	phaseTimingSummaryLogMessageConstant  = "command timing"
+	phaseTimingSubSecondPrecisionConstant = time.Millisecond
+	phaseTimingSecondPrecisionConstant    = time.Second
+)
+
+// PhaseDuration records the accumulated duration of one named phase.
+type PhaseDuration struct {
+	Name     string
+	Duration time.Duration
+}
+
+// PhaseTimingSummary captures the total run duration and the phases recorded during it.
+type PhaseTimingSummary struct {
+	Total time.Duration
+	// Phases lists recorded phases in the order they first started.
+	Phases []PhaseDuration
+	// RepositoryCount is the number of repositories processed, or zero when no command reported one.
+	RepositoryCount int
+}
+
+// PhaseTimer accumulates phase durations for a single command run. A phase started more than once accumulates, so
+// commands executing several workflows report one figure per phase. All methods are safe on a nil timer.
+type PhaseTimer struct {
+	mutex           sync.Mutex
+	clock           func() time.Time
+	startedAt       time.Time
+	phaseOrder      []string
+	phaseDurations  map[string]time.Duration
+	repositoryCount int
+}
+
+// NewPhaseTimer constructs a PhaseTimer whose total duration starts now.
+func NewPhaseTimer() *PhaseTimer {
+	return newPhaseTimerWithClock(time.Now)
+}
+
+func newPhaseTimerWithClock(clock func() time.Time) *PhaseTimer {
+	return &PhaseTimer{clock: clock, startedAt: clock(), phaseDurations: map[string]time.Duration{}}
+}
+
+// StartPhase begins timing the named phase and returns the function that ends it.
+func (timer *PhaseTimer) StartPhase(phase string) func() {
+	if timer == nil {
+		return func() {}
+	}
+	phaseStartedAt := timer.clock()
+	return func() {
+		timer.addPhaseDuration(phase, timer.clock().Sub(phaseStartedAt))
+	}
+}
+
+// RecordRepositoryCount adds to the number of repositories processed during the run.
+func (timer *PhaseTimer) RecordRepositoryCount(count int) {
+	if timer == nil {
+		return
+	}
+	timer.mutex.Lock()
+	defer timer.mutex.Unlock()
+	timer.repositoryCount += count
+}
+
+// Summary reports the total duration elapsed since the timer was created together with every recorded phase.
+func (timer *PhaseTimer) Summary() PhaseTimingSummary {
+	if timer == nil {
+		return PhaseTimingSummary{}
+	}
+	timer.mutex.Lock()
+	defer timer.mutex.Unlock()
+	phases := make([]PhaseDuration, 0, len(timer.phaseOrder))
+	for _, phase := range timer.phaseOrder {
+		phases = append(phases, PhaseDuration{Name: phase, Duration: timer.phaseDurations[phase]})
+	}
+	return PhaseTimingSummary{Total: timer.clock().Sub(timer.startedAt), Phases: phases, RepositoryCount: timer.repositoryCount}
+}
+
+func (timer *PhaseTimer) addPhaseDuration(phase string, duration time.Duration) {
+	timer.mutex.Lock()
+	defer timer.mutex.Unlock()
+	if _, recorded := timer.phaseDurations[phase]; !recorded {
+		timer.phaseOrder = append(timer.phaseOrder, phase)
+	}
+	timer.phaseDurations[phase] += duration
+}
+
+// String renders the summary line, for example "done in 1m42s: discovery 12s, processing 1m25s across 87 repos,
+// reporting 5s". The repository count is attached to the processing phase.
+func (summary PhaseTimingSummary) String() string {
+	total := fmt.Sprintf(phaseTimingSummaryTemplateConstant, formatPhaseDuration(summary.Total))
+	if len(summary.Phases) == 0 {
+		return total
+	}
+	descriptions := make([]string, 0, len(summary.Phases))
+	for _, phase := range summary.Phases {
+		description := fmt.Sprintf(phaseTimingPhaseTemplateConstant, phase.Name, formatPhaseDuration(phase.Duration))
+		if phase.Name == PhaseProcessing && summary.RepositoryCount > 0 {
+			description = fmt.Sprintf(phaseTimingRepositoriesTemplate, description, summary.RepositoryCount)
+		}
+		descriptions = append(descriptions, description)
+	}
+	return fmt.Sprintf(phaseTimingPhasesTemplateConstant, total, strings.Join(descriptions, phaseTimingPhaseSeparatorConstant))
+}
+
+// LogFields returns the summary as structured fields for the diagnostic log: total_duration, one <phase>_duration
+// field per phase, and repository_count.
+func (summary PhaseTimingSummary) LogFields() []zap.Field {
+	fields := make([]zap.Field, 0, len(summary.Phases)+2)
+	fields = append(fields, zap.Duration(phaseTimingTotalLogFieldNameConstant, summary.Total))
+	for _, phase := range summary.Phases {
+		fields = append(fields, zap.Duration(fmt.Sprintf(phaseTimingPhaseLogFieldTemplate, phase.Name), phase.Duration))
+	}
+	return append(fields, zap.Int(phaseTimingRepositoriesLogFieldName, summary.RepositoryCount))
+}
+
+// LogPhaseTimingSummary writes the summary to the diagnostic logger.
+func LogPhaseTimingSummary(logger *zap.Logger, summary PhaseTimingSummary) {
+	if logger == nil {
+		return
+	}
+	logger.Info(phaseTimingSummaryLogMessageConstant, summary.LogFields()...)
+}
+
+// WithPhaseTimer attaches the phase timer to the provided context.
+func (accessor CommandContextAccessor) WithPhaseTimer(parentContext context.Context, timer *PhaseTimer) context.Context {
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	if timer == nil {
+		return parentContext
+	}
+	return context.WithValue(parentContext, phaseTimerContextKeyConstant, timer)
+}
+
+// PhaseTimer extracts the phase timer from the provided context.
+func (accessor CommandContextAccessor) PhaseTimer(executionContext context.Context) (*PhaseTimer, bool) {
+	if executionContext == nil {
+		return nil, false
+	}
+	timer, timerAvailable := executionContext.Value(phaseTimerContextKeyConstant).(*PhaseTimer)
+	return timer, timerAvailable
+}
+
+// StartPhase begins timing the named phase on the timer carried by the context and returns the function that ends it.
+// Commands opt into timing by wrapping their phases with it; without a timer in the context it does nothing.
+func StartPhase(executionContext context.Context, phase string) func() {
+	timer, _ := NewCommandContextAccessor().PhaseTimer(executionContext)
+	return timer.StartPhase(phase)
+}
+
+// RecordRepositoryCount adds to the repository count of the timer carried by the context.
+func RecordRepositoryCount(executionContext context.Context, count int) {
+	timer, _ := NewCommandContextAccessor().PhaseTimer(executionContext)
+	timer.RecordRepositoryCount(count)
+}
+
+// formatPhaseDuration rounds to whole seconds, or to milliseconds for durations under a second.
+func formatPhaseDuration(duration time.Duration) string {
+	if duration < phaseTimingSecondPrecisionConstant {
+		return duration.Round(phaseTimingSubSecondPrecisionConstant).String()
+	}
+	return duration.Round(phaseTimingSecondPrecisionConstant).String()
+}
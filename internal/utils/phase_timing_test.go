@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type steppingClock struct {
+	current time.Time
+}
+
+func (clock *steppingClock) now() time.Time {
+	return clock.current
+}
+
+func (clock *steppingClock) advance(duration time.Duration) {
+	clock.current = clock.current.Add(duration)
+}
+
+func TestPhaseTimerSummary(t *testing.T) {
+	clock := &steppingClock{current: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	timer := newPhaseTimerWithClock(clock.now)
+	executionContext := NewCommandContextAccessor().WithPhaseTimer(context.Background(), timer)
+
+	stopDiscovery := StartPhase(executionContext, PhaseDiscovery)
+	clock.advance(12 * time.Second)
+	stopDiscovery()
+
+	stopProcessing := StartPhase(executionContext, PhaseProcessing)
+	clock.advance(time.Minute)
+	stopProcessing()
+	stopProcessing = StartPhase(executionContext, PhaseProcessing)
+	clock.advance(25 * time.Second)
+	stopProcessing()
+	RecordRepositoryCount(executionContext, 80)
+	RecordRepositoryCount(executionContext, 7)
+
+	stopReporting := StartPhase(executionContext, PhaseReporting)
+	clock.advance(5*time.Second + 200*time.Millisecond)
+	stopReporting()
+
+	summary := timer.Summary()
+	require.Equal(t, "done in 1m42s: discovery 12s, processing 1m25s across 87 repos, reporting 5s", summary.String())
+	require.Equal(t, 87, summary.RepositoryCount)
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	LogPhaseTimingSummary(zap.New(core), summary)
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	require.Equal(t, 102*time.Second+200*time.Millisecond, fields["total_duration"])
+	require.Equal(t, 12*time.Second, fields["discovery_duration"])
+	require.Equal(t, 85*time.Second, fields["processing_duration"])
+	require.Equal(t, int64(87), fields["repository_count"])
+}
+
+func TestPhaseTimingSummaryString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		summary  PhaseTimingSummary
+		expected string
+	}{
+		{
+			name:     "no phases",
+			summary:  PhaseTimingSummary{Total: 350 * time.Millisecond},
+			expected: "done in 350ms",
+		},
+		{
+			name: "processing without repositories",
+			summary: PhaseTimingSummary{
+				Total:  2 * time.Second,
+				Phases: []PhaseDuration{{Name: PhaseConfiguration, Duration: 4 * time.Millisecond}, {Name: PhaseProcessing, Duration: 1500 * time.Millisecond}},
+			},
+			expected: "done in 2s: configuration 4ms, processing 2s",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(subtest *testing.T) {
+			require.Equal(subtest, testCase.expected, testCase.summary.String())
+		})
+	}
+}
+
+func TestStartPhaseWithoutTimerIsNoop(t *testing.T) {
+	require.NotPanics(t, func() {
+		StartPhase(context.Background(), PhaseDiscovery)()
+		RecordRepositoryCount(context.Background(), 3)
+	})
+	require.Equal(t, PhaseTimingSummary{}, (*PhaseTimer)(nil).Summary())
+}
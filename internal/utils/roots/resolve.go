@@ -26,8 +26,25 @@ func PositionalRootsUnsupportedError() error {
 	return errors.New(positionalRootsUnsupportedMessage)
 }
 
-// Resolve determines the repository roots for a command, enforcing --roots usage.
+// Resolve determines the repository roots for a command.
+// Positional arguments are treated as additional roots and merged with --roots; configured roots apply only when neither is supplied.
 func Resolve(command *cobra.Command, positional []string, configured []string) ([]string, error) {
+	flagRoots, flagError := FlagValues(command)
+	if flagError != nil {
+		return nil, flagError
+	}
+
+	explicitRoots := sanitizer.Sanitize(append(append([]string{}, flagRoots...), positional...))
+	if len(explicitRoots) > 0 {
+		return explicitRoots, nil
+	}
+
+	return resolveConfigured(command, configured)
+}
+
+// ResolveWithoutPositional determines the repository roots for a command whose positional arguments serve another purpose.
+// Any leftover positional arguments are rejected instead of being treated as roots.
+func ResolveWithoutPositional(command *cobra.Command, positional []string, configured []string) ([]string, error) {
 	if len(sanitizer.Sanitize(positional)) > 0 {
 		if command != nil {
 			_ = command.Help()
@@ -43,22 +60,17 @@ func Resolve(command *cobra.Command, positional []string, configured []string) (
 		return flagRoots, nil
 	}
 
-	configuredRoots := sanitizer.Sanitize(configured)
-	if len(configuredRoots) > 0 {
-		return configuredRoots, nil
-	}
-
-	if command != nil {
-		_ = command.Help()
-	}
-	return nil, MissingRootsError()
+	return resolveConfigured(command, configured)
 }
 
-// FlagValues returns sanitized root values from the command flag set.
+// FlagValues returns sanitized root values from the command flag set, reading them from standard input for --roots -.
 func FlagValues(command *cobra.Command) ([]string, error) {
 	if command == nil {
 		return nil, nil
 	}
+	if expansionError := ExpandStandardInput(command); expansionError != nil {
+		return nil, expansionError
+	}
 	values, err := command.Flags().GetStringSlice(flagutils.DefaultRootFlagName)
 	if err != nil {
 		return nil, err
@@ -71,6 +83,18 @@ func SanitizeConfigured(configured []string) []string {
 	return sanitizer.Sanitize(configured)
 }
 
+func resolveConfigured(command *cobra.Command, configured []string) ([]string, error) {
+	configuredRoots := sanitizer.Sanitize(configured)
+	if len(configuredRoots) > 0 {
+		return configuredRoots, nil
+	}
+
+	if command != nil {
+		_ = command.Help()
+	}
+	return nil, MissingRootsError()
+}
+
 // MissingRootsMessage exposes the canonical missing-roots error text.
 func MissingRootsMessage() string {
 	return missingRootsErrorMessage
@@ -41,9 +41,17 @@ func TestResolveRootSelectionScenarios(testInstance *testing.T) {
 			expectedRoots: []string{filepath.Join(homeDirectory, "configured")},
 		},
 		{
-			name:          "errors_when_positional_roots_provided",
-			positional:    []string{"relative/root"},
-			expectedError: rootutils.PositionalRootsUnsupportedMessage(),
+			name:          "merges_positional_roots_with_flag_roots",
+			flagArguments: []string{"--" + flagutils.DefaultRootFlagName, "/flag/root"},
+			positional:    []string{tildeInput, "/flag/root"},
+			configured:    []string{"/configured/root"},
+			expectedRoots: []string{"/flag/root", expectedTilde},
+		},
+		{
+			name:          "positional_roots_replace_configured_roots",
+			positional:    []string{tildeInput},
+			configured:    []string{"/configured/root"},
+			expectedRoots: []string{expectedTilde},
 		},
 		{
 			name:          "errors_when_roots_missing",
@@ -86,3 +94,41 @@ func TestSanitizeConfiguredRemovesEmptyValues(testInstance *testing.T) {
 	require.NoError(testInstance, homeDirectoryError)
 	require.Equal(testInstance, filepath.Join(homeDirectory, "configured"), sanitized[0])
 }
+
+func TestResolveWithoutPositionalRejectsPositionalRoots(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		positional    []string
+		configured    []string
+		expectedRoots []string
+		expectedError string
+	}{
+		{
+			name:          "errors_when_positional_roots_provided",
+			positional:    []string{"relative/root"},
+			expectedError: rootutils.PositionalRootsUnsupportedMessage(),
+		},
+		{
+			name:          "falls_back_to_configured_roots",
+			configured:    []string{"/configured/root"},
+			expectedRoots: []string{"/configured/root"},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			command := &cobra.Command{Use: "root-test"}
+			flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Name: flagutils.DefaultRootFlagName, Enabled: true})
+
+			resolvedRoots, resolveError := rootutils.ResolveWithoutPositional(command, testCase.positional, testCase.configured)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, resolveError, testCase.expectedError)
+				return
+			}
+
+			require.NoError(subtest, resolveError)
+			require.Equal(subtest, testCase.expectedRoots, resolvedRoots)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package roots
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	flagutils "github.com/temirov/gix/internal/utils/flags"
+)
+
+const (
+	// StandardInputRootValue is the --roots value that reads newline-separated repository paths from standard input.
+	StandardInputRootValue                    = "-"
+	standardInputCommentPrefixConstant        = "#"
+	standardInputMixedRootsMessageConstant    = "--roots - reads every root from standard input and cannot be combined with other roots"
+	standardInputEmptyMessageConstant         = "--roots - read no repository paths from standard input"
+	standardInputReadErrorTemplateConstant    = "unable to read repository paths from standard input: %w"
+	standardInputNotDirectoryTemplateConstant = "standard input path %q is not a directory"
+	standardInputReplaceErrorTemplateConstant = "unable to apply repository paths from standard input: %w"
+	standardInputConsumedMessageConstant      = "standard input was consumed by --roots -, so confirmations cannot be answered; rerun with --yes or --dry-run"
+)
+
+// ErrStandardInputConsumed is returned by reads of standard input after --roots - consumed it, so confirmation prompts
+// fail with an explanation instead of reading the closed stream as a refusal.
+var ErrStandardInputConsumed = errors.New(standardInputConsumedMessageConstant)
+
+type consumedStandardInput struct{}
+
+func (consumedStandardInput) Read([]byte) (int, error) {
+	return 0, ErrStandardInputConsumed
+}
+
+// ExpandStandardInput replaces a --roots value of "-" with the repository paths read from the command's standard
+// input. Blank lines and lines starting with # are skipped, and every remaining path must be an existing directory.
+// The command's input is then marked consumed so interactive prompts cannot read from it. Calling it again after the
+// expansion is a no-op.
+func ExpandStandardInput(command *cobra.Command) error {
+	if command == nil {
+		return nil
+	}
+	rootsFlag := command.Flags().Lookup(flagutils.DefaultRootFlagName)
+	if rootsFlag == nil {
+		return nil
+	}
+	values, valuesError := command.Flags().GetStringSlice(flagutils.DefaultRootFlagName)
+	if valuesError != nil {
+		return valuesError
+	}
+
+	standardInputRequested := false
+	for _, value := range values {
+		if strings.TrimSpace(value) == StandardInputRootValue {
+			standardInputRequested = true
+		}
+	}
+	if !standardInputRequested {
+		return nil
+	}
+	if len(values) != 1 {
+		return errors.New(standardInputMixedRootsMessageConstant)
+	}
+
+	repositoryPaths, readError := readStandardInputRoots(command)
+	if readError != nil {
+		return readError
+	}
+
+	sliceValue, isSliceValue := rootsFlag.Value.(pflag.SliceValue)
+	if !isSliceValue {
+		return fmt.Errorf(standardInputReplaceErrorTemplateConstant, fmt.Errorf("flag %s is not a list", flagutils.DefaultRootFlagName))
+	}
+	if replaceError := sliceValue.Replace(repositoryPaths); replaceError != nil {
+		return fmt.Errorf(standardInputReplaceErrorTemplateConstant, replaceError)
+	}
+	command.SetIn(consumedStandardInput{})
+	return nil
+}
+
+func readStandardInputRoots(command *cobra.Command) ([]string, error) {
+	repositoryPaths := []string{}
+	scanner := bufio.NewScanner(command.InOrStdin())
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, standardInputCommentPrefixConstant) {
+			continue
+		}
+		repositoryPaths = append(repositoryPaths, line)
+	}
+	if scanError := scanner.Err(); scanError != nil {
+		return nil, fmt.Errorf(standardInputReadErrorTemplateConstant, scanError)
+	}
+
+	sanitizedPaths := sanitizer.Sanitize(repositoryPaths)
+	if len(sanitizedPaths) == 0 {
+		return nil, errors.New(standardInputEmptyMessageConstant)
+	}
+	for _, repositoryPath := range sanitizedPaths {
+		pathInfo, statError := os.Stat(repositoryPath)
+		if statError != nil || !pathInfo.IsDir() {
+			return nil, fmt.Errorf(standardInputNotDirectoryTemplateConstant, repositoryPath)
+		}
+	}
+	return sanitizedPaths, nil
+}
@@ -0,0 +1,92 @@
+package roots_test
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	flagutils "github.com/temirov/gix/internal/utils/flags"
+	rootutils "github.com/temirov/gix/internal/utils/roots"
+)
+
+func TestFlagValuesReadsRootsFromStandardInput(testInstance *testing.T) {
+	firstRoot := testInstance.TempDir()
+	secondRoot := testInstance.TempDir()
+	missingRoot := filepath.Join(firstRoot, "missing")
+
+	testCases := []struct {
+		name          string
+		flagArguments []string
+		standardInput string
+		expectedRoots []string
+		expectedError string
+		inputConsumed bool
+	}{
+		{
+			name:          "skips_blank_and_comment_lines",
+			flagArguments: []string{"--" + flagutils.DefaultRootFlagName, rootutils.StandardInputRootValue},
+			standardInput: fmt.Sprintf("# piped roots\n\n  %s  \n%s\n%s\n", firstRoot, secondRoot, firstRoot),
+			expectedRoots: []string{firstRoot, secondRoot},
+			inputConsumed: true,
+		},
+		{
+			name:          "rejects_paths_that_are_not_directories",
+			flagArguments: []string{"--" + flagutils.DefaultRootFlagName, rootutils.StandardInputRootValue},
+			standardInput: missingRoot + "\n",
+			expectedError: fmt.Sprintf("standard input path %q is not a directory", missingRoot),
+		},
+		{
+			name:          "rejects_mixed_roots",
+			flagArguments: []string{"--" + flagutils.DefaultRootFlagName, rootutils.StandardInputRootValue, "--" + flagutils.DefaultRootFlagName, firstRoot},
+			standardInput: secondRoot + "\n",
+			expectedError: "--roots - reads every root from standard input and cannot be combined with other roots",
+		},
+		{
+			name:          "rejects_empty_input",
+			flagArguments: []string{"--" + flagutils.DefaultRootFlagName, rootutils.StandardInputRootValue},
+			standardInput: "# nothing here\n\n",
+			expectedError: "--roots - read no repository paths from standard input",
+		},
+		{
+			name:          "leaves_regular_roots_and_input_untouched",
+			flagArguments: []string{"--" + flagutils.DefaultRootFlagName, firstRoot},
+			standardInput: "yes\n",
+			expectedRoots: []string{firstRoot},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			command := &cobra.Command{Use: "root-test"}
+			flagutils.BindRootFlags(command, flagutils.RootFlagValues{}, flagutils.RootFlagDefinition{Name: flagutils.DefaultRootFlagName, Enabled: true})
+			command.SetIn(strings.NewReader(testCase.standardInput))
+			require.NoError(subtest, command.ParseFlags(testCase.flagArguments))
+
+			flagRoots, flagError := rootutils.FlagValues(command)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, flagError, testCase.expectedError)
+				return
+			}
+
+			require.NoError(subtest, flagError)
+			require.Equal(subtest, testCase.expectedRoots, flagRoots)
+
+			repeatedRoots, repeatedError := rootutils.FlagValues(command)
+			require.NoError(subtest, repeatedError)
+			require.Equal(subtest, testCase.expectedRoots, repeatedRoots)
+
+			_, readError := io.ReadAll(command.InOrStdin())
+			if testCase.inputConsumed {
+				require.ErrorIs(subtest, readError, rootutils.ErrStandardInputConsumed)
+				return
+			}
+			require.NoError(subtest, readError)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package utils
+
+import "sync"
+
+// Tally accumulates records across every repository or package processed in a run. It is safe for concurrent use, and
+// a nil tally discards what is added to it.
+type Tally[Record any] struct {
+	mutex   sync.Mutex
+	records []Record
+}
+
+// Add records values in the order given.
+func (tally *Tally[Record]) Add(records ...Record) {
+	if tally == nil {
+		return
+	}
+	tally.mutex.Lock()
+	defer tally.mutex.Unlock()
+	tally.records = append(tally.records, records...)
+}
+
+// Records lists the recorded values in the order they were added.
+func (tally *Tally[Record]) Records() []Record {
+	if tally == nil {
+		return nil
+	}
+	tally.mutex.Lock()
+	defer tally.mutex.Unlock()
+	return append([]Record(nil), tally.records...)
+}
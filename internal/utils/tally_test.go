@@ -0,0 +1,43 @@
+package utils_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/utils"
+)
+
+func TestTallyRecordsValuesInOrder(testInstance *testing.T) {
+	tally := &utils.Tally[string]{}
+	tally.Add("alpha")
+	tally.Add("beta", "gamma")
+
+	records := tally.Records()
+	require.Equal(testInstance, []string{"alpha", "beta", "gamma"}, records)
+
+	records[0] = "changed"
+	require.Equal(testInstance, "alpha", tally.Records()[0])
+}
+
+func TestTallyAcceptsConcurrentAdds(testInstance *testing.T) {
+	const writerCount = 16
+	tally := &utils.Tally[int]{}
+	var waitGroup sync.WaitGroup
+	for writerIndex := 0; writerIndex < writerCount; writerIndex++ {
+		waitGroup.Add(1)
+		go func(value int) {
+			defer waitGroup.Done()
+			tally.Add(value)
+		}(writerIndex)
+	}
+	waitGroup.Wait()
+	require.Len(testInstance, tally.Records(), writerCount)
+}
+
+func TestNilTallyDiscardsValues(testInstance *testing.T) {
+	var tally *utils.Tally[string]
+	require.NotPanics(testInstance, func() { tally.Add("alpha") })
+	require.Nil(testInstance, tally.Records())
+}
@@ -140,6 +140,7 @@ func (detector *Detector) resolveRepositoryRoot(executionContext context.Context
 	executionResult, executionError := detector.executeGit(executionContext, execshell.CommandDetails{
 		Arguments:        []string{gitRevParseSubcommandConstant, gitShowTopLevelFlagConstant},
 		WorkingDirectory: detector.workingDirectory,
+		Idempotent:       true,
 	})
 	if executionError != nil {
 		return detector.workingDirectory
@@ -157,6 +158,7 @@ func (detector *Detector) describeVersion(executionContext context.Context, repo
 	executionResult, executionError := detector.executeGit(executionContext, execshell.CommandDetails{
 		Arguments:        arguments,
 		WorkingDirectory: repositoryRoot,
+		Idempotent:       true,
 	})
 	if executionError != nil {
 		return ""
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -29,25 +30,53 @@ const (
 	OperationTypeBranchDefault      OperationType = OperationType("default-branch")
 	OperationTypeAuditReport        OperationType = OperationType("audit-report")
 	OperationTypeApplyTasks         OperationType = OperationType("apply-tasks")
+	OperationTypeEditRepository     OperationType = OperationType("edit-repo")
+	OperationTypeCreatePullRequest  OperationType = OperationType("pull-request")
+	OperationTypeCommit             OperationType = OperationType("commit")
 )
 
 // Configuration describes the ordered workflow steps loaded from YAML or JSON.
 type Configuration struct {
 	Steps []StepConfiguration
+	// RepositoryTimeout bounds all steps run on one repository; zero means unlimited.
+	RepositoryTimeout time.Duration
+	// Environment holds variables added to every command of every step; step env values override them.
+	Environment map[string]string
+	// WorkingBranch, when set, moves the file changes of each repository onto a dedicated branch.
+	WorkingBranch *WorkingBranchConfiguration
 }
 
 type workflowFile struct {
-	Workflow []workflowStepWrapper `yaml:"workflow" json:"workflow"`
+	Repository workflowRepositorySettings `yaml:"repository" json:"repository"`
+	Env        map[string]string          `yaml:"env" json:"env"`
+	Branch     *workflowBranchSettings    `yaml:"branch" json:"branch"`
+	Workflow   []workflowStepWrapper      `yaml:"workflow" json:"workflow"`
+}
+
+type workflowRepositorySettings struct {
+	Timeout string `yaml:"timeout" json:"timeout"`
 }
 
 type workflowStepWrapper struct {
 	Step StepConfiguration `yaml:"step" json:"step"`
 }
 
-// StepConfiguration associates an operation type with declarative options.
+// StepConfiguration associates an operation type with declarative options and optional repository filters.
 type StepConfiguration struct {
+	// ID names the step so later steps can read its outputs as {{ .Steps.<id>.<output> }}.
+	ID        string         `yaml:"id" json:"id"`
 	Operation OperationType  `yaml:"operation" json:"operation"`
 	Options   map[string]any `yaml:"with" json:"with"`
+	Only      []string       `yaml:"only" json:"only"`
+	Skip      []string       `yaml:"skip" json:"skip"`
+	// Timeout bounds the step on one repository, for example "5m"; blank selects DefaultStepTimeout and "0" means unlimited.
+	Timeout string `yaml:"timeout" json:"timeout"`
+	// Env adds variables to every command the step runs. Values are templates over repository facts and may reference
+	// process variables as ${NAME}, which are resolved only when a command starts.
+	Env map[string]string `yaml:"env" json:"env"`
+	// Commit selects how an apply-tasks step records file changes: "commit" (the default) commits them on a task
+	// branch, while "stage" only stages them on the current branch for a later commit step.
+	Commit string `yaml:"commit" json:"commit"`
 }
 
 // LoadConfiguration reads the workflow definition from disk and performs basic validation.
@@ -71,7 +100,21 @@ func LoadConfiguration(filePath string) (Configuration, error) {
 		return Configuration{}, fmt.Errorf(configurationParseErrorTemplateConstant, workflowError)
 	}
 
-	configuration := Configuration{Steps: make([]StepConfiguration, 0, len(parsedWorkflow.Workflow))}
+	repositoryTimeout := DefaultRepositoryTimeout
+	if trimmedRepositoryTimeout := strings.TrimSpace(parsedWorkflow.Repository.Timeout); len(trimmedRepositoryTimeout) > 0 {
+		parsedTimeout, timeoutError := parseWorkflowTimeout(trimmedRepositoryTimeout)
+		if timeoutError != nil {
+			return Configuration{}, fmt.Errorf(configurationParseErrorTemplateConstant, fmt.Errorf(repositoryTimeoutInvalidTemplateConstant, parsedWorkflow.Repository.Timeout, timeoutError))
+		}
+		repositoryTimeout = parsedTimeout
+	}
+
+	workingBranch, workingBranchError := buildWorkingBranchConfiguration(parsedWorkflow.Branch)
+	if workingBranchError != nil {
+		return Configuration{}, fmt.Errorf(configurationParseErrorTemplateConstant, workingBranchError)
+	}
+
+	configuration := Configuration{Steps: make([]StepConfiguration, 0, len(parsedWorkflow.Workflow)), RepositoryTimeout: repositoryTimeout, Environment: parsedWorkflow.Env, WorkingBranch: workingBranch}
 	for index := range parsedWorkflow.Workflow {
 		configuration.Steps = append(configuration.Steps, parsedWorkflow.Workflow[index].Step)
 	}
@@ -122,6 +122,25 @@ func TestBuildOperations(testInstance *testing.T) {
 				require.True(testingInstance, renameOperation.IncludeOwner)
 			},
 		},
+		{
+			name: "builds rename operation with naming template",
+			configuration: workflow.Configuration{
+				Steps: []workflow.StepConfiguration{
+					{
+						Operation: workflow.OperationTypeRenameDirectories,
+						Options: map[string]any{
+							"naming_template": "{{.Owner}}__{{.Name}}",
+						},
+					},
+				},
+			},
+			expectedOperationType: workflow.OperationTypeRenameDirectories,
+			assertFunc: func(testingInstance *testing.T, operation workflow.Operation) {
+				renameOperation, castSucceeded := operation.(*workflow.RenameOperation)
+				require.True(testingInstance, castSucceeded)
+				require.Equal(testingInstance, "{{.Owner}}__{{.Name}}", renameOperation.NamingTemplate)
+			},
+		},
 		{
 			name: "builds task operation",
 			configuration: workflow.Configuration{
@@ -172,6 +191,17 @@ func TestBuildOperationsMissingOperation(testInstance *testing.T) {
 	require.ErrorContains(testInstance, buildError, "workflow step missing operation name")
 }
 
+func TestBuildOperationsRejectsInvalidNamingTemplate(testInstance *testing.T) {
+	configuration := workflow.Configuration{
+		Steps: []workflow.StepConfiguration{
+			{Operation: workflow.OperationTypeRenameDirectories, Options: map[string]any{"naming_template": "{{.Owner}}/{{.Branch}}"}},
+		},
+	}
+
+	_, buildError := workflow.BuildOperations(configuration)
+	require.ErrorContains(testInstance, buildError, `invalid naming template "{{.Owner}}/{{.Branch}}"`)
+}
+
 func TestBuildOperationsApplyTasksValidation(testInstance *testing.T) {
 	configuration := workflow.Configuration{
 		Steps: []workflow.StepConfiguration{
@@ -246,3 +276,82 @@ func TestLoadConfigurationMissingOperation(testInstance *testing.T) {
 	require.Error(testInstance, loadError)
 	require.ErrorContains(testInstance, loadError, "workflow step missing operation name")
 }
+
+func TestBuildOperationsValidatesStepTemplates(testInstance *testing.T) {
+	testCases := []struct {
+		name                 string
+		options              map[string]any
+		expectedErrorMessage string
+	}{
+		{
+			name:    "valid_template",
+			options: map[string]any{"owner": "{{ .Owner }}"},
+		},
+		{
+			name:    "escaped_delimiter",
+			options: map[string]any{"owner": `\{{ not a template }}`},
+		},
+		{
+			name:                 "malformed_template",
+			options:              map[string]any{"owner": "{{ .Owner "},
+			expectedErrorMessage: "workflow step update-canonical-remote has an invalid template at with.owner",
+		},
+		{
+			name: "nested_malformed_template",
+			options: map[string]any{
+				"tasks": []any{map[string]any{"name": "{{ if }}"}},
+			},
+			expectedErrorMessage: "workflow step update-canonical-remote has an invalid template at with.tasks[0].name",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(testingInstance *testing.T) {
+			configuration := workflow.Configuration{
+				Steps: []workflow.StepConfiguration{{
+					Operation: workflow.OperationTypeCanonicalRemote,
+					Options:   testCase.options,
+				}},
+			}
+
+			_, buildError := workflow.BuildOperations(configuration)
+			if len(testCase.expectedErrorMessage) == 0 {
+				require.NoError(testingInstance, buildError)
+				return
+			}
+			require.Error(testingInstance, buildError)
+			require.ErrorContains(testingInstance, buildError, testCase.expectedErrorMessage)
+		})
+	}
+}
+
+func TestLoadConfigurationParsesStepFilters(testInstance *testing.T) {
+	tempDirectory := testInstance.TempDir()
+	configurationPath := filepath.Join(tempDirectory, configurationTestFileName)
+	contents := `workflow:
+  - step:
+      operation: convert-protocol
+      only:
+        - legacy-owner/*
+      skip:
+        - "*/archived-*"
+      with:
+        from: https
+        to: ssh
+`
+	require.NoError(testInstance, os.WriteFile(configurationPath, []byte(contents), 0o644))
+
+	configuration, loadError := workflow.LoadConfiguration(configurationPath)
+	require.NoError(testInstance, loadError)
+	require.Len(testInstance, configuration.Steps, 1)
+	require.Equal(testInstance, []string{"legacy-owner/*"}, configuration.Steps[0].Only)
+	require.Equal(testInstance, []string{"*/archived-*"}, configuration.Steps[0].Skip)
+
+	operations, buildError := workflow.BuildOperations(configuration)
+	require.NoError(testInstance, buildError)
+	require.Len(testInstance, operations, 1)
+	filteredOperation, isFiltered := operations[0].(*workflow.FilteredOperation)
+	require.True(testInstance, isFiltered)
+	require.IsType(testInstance, &workflow.ProtocolConversionOperation{}, filteredOperation.Unwrap())
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/temirov/gix/internal/execshell"
 	repoerrors "github.com/temirov/gix/internal/repos/errors"
 )
 
@@ -12,6 +13,10 @@ func logRepositoryOperationError(environment *Environment, err error) bool {
 		return true
 	}
 
+	if execshell.IsExecutableNotFound(err) {
+		return false
+	}
+
 	var operationError repoerrors.OperationError
 	if !errors.As(err, &operationError) {
 		return false
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -15,7 +16,9 @@ import (
 	"github.com/temirov/gix/internal/execshell"
 	"github.com/temirov/gix/internal/githubcli"
 	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/repos/discovery"
 	"github.com/temirov/gix/internal/repos/shared"
+	"github.com/temirov/gix/internal/utils"
 	pathutils "github.com/temirov/gix/internal/utils/path"
 )
 
@@ -37,6 +40,10 @@ type Dependencies struct {
 	Prompter             shared.ConfirmationPrompter
 	Output               io.Writer
 	Errors               io.Writer
+	// Reporter receives rename, remote, and protocol executor events; nil writes them to Output.
+	Reporter shared.Reporter
+	// RootLockDirectory holds the root lock files of mutating runs; empty uses the user state directory.
+	RootLockDirectory string
 }
 
 // RuntimeOptions captures user-provided execution modifiers.
@@ -48,6 +55,17 @@ type RuntimeOptions struct {
 	CaptureInitialWorktreeStatus         bool
 	// SkipRepositoryMetadata disables GitHub metadata resolution during repository inspections.
 	SkipRepositoryMetadata bool
+	// Offline disables every inspection check that contacts GitHub or the git remote.
+	Offline bool
+	// RepositoryTimeout bounds all tasks run on one repository; zero means unlimited.
+	RepositoryTimeout time.Duration
+	// IncludeBareRepositories adds bare repositories, such as --mirror clones, to the discovered repositories.
+	IncludeBareRepositories bool
+	// WorkingBranch, when set, moves each repository's task commits onto a dedicated branch.
+	WorkingBranch *WorkingBranchConfiguration
+	// LockRoots holds the root lock for the run so concurrent mutating runs on the same roots fail fast; dry runs
+	// never lock.
+	LockRoots bool
 }
 
 // Executor coordinates workflow operation execution.
@@ -63,7 +81,7 @@ func NewExecutor(operations []Operation, dependencies Dependencies) *Executor {
 
 // Execute orchestrates workflow operations across discovered repositories.
 func (executor *Executor) Execute(executionContext context.Context, roots []string, runtimeOptions RuntimeOptions) error {
-	requireGitHubClient := !runtimeOptions.SkipRepositoryMetadata
+	requireGitHubClient := !runtimeOptions.SkipRepositoryMetadata && !runtimeOptions.Offline
 	if executor.dependencies.RepositoryDiscoverer == nil || executor.dependencies.GitExecutor == nil || executor.dependencies.RepositoryManager == nil || (requireGitHubClient && executor.dependencies.GitHubClient == nil) {
 		return errors.New(workflowExecutorDependenciesMessage)
 	}
@@ -75,19 +93,45 @@ func (executor *Executor) Execute(executionContext context.Context, roots []stri
 		return errors.New(workflowExecutorMissingRootsMessage)
 	}
 
+	tokenAccess := executor.resolveTokenAccess(executionContext, &runtimeOptions)
+
+	if runtimeOptions.LockRoots && !runtimeOptions.DryRun {
+		releaseRootLock, lockError := executor.acquireRootLock(executionContext, sanitizedRoots)
+		if lockError != nil {
+			return lockError
+		}
+		defer releaseRootLock()
+	}
+
+	var repositoryMetadata shared.GitHubMetadataResolver
+	var branchProtection shared.BranchProtectionResolver
+	if executor.dependencies.GitHubClient != nil {
+		if !runtimeOptions.SkipRepositoryMetadata {
+			repositoryMetadata = githubcli.NewBatchedMetadataProvider(executor.dependencies.GitHubClient, githubcli.DefaultMetadataBatchSize)
+		}
+		branchProtection = githubcli.NewCachedBranchProtectionProvider(executor.dependencies.GitHubClient)
+	}
+
 	auditService := audit.NewService(
 		executor.dependencies.RepositoryDiscoverer,
 		executor.dependencies.RepositoryManager,
 		executor.dependencies.GitExecutor,
-		executor.dependencies.GitHubClient,
+		repositoryMetadata,
 		executor.dependencies.Output,
 		executor.dependencies.Errors,
 	)
+	if runtimeOptions.Offline {
+		auditService.DisableCheckCategory(audit.CheckCategoryRemote)
+	}
+	auditService.SetIncludeBareRepositories(runtimeOptions.IncludeBareRepositories)
 
+	stopDiscoveryPhase := utils.StartPhase(executionContext, utils.PhaseDiscovery)
 	inspections, inspectionError := auditService.DiscoverInspections(executionContext, sanitizedRoots, false, false, audit.InspectionDepthFull)
 	if inspectionError != nil {
+		stopDiscoveryPhase()
 		return fmt.Errorf(workflowRepositoryLoadErrorTemplate, inspectionError)
 	}
+	auditService.ReportUntrustedOwnership()
 
 	repositoryStates := make([]*RepositoryState, 0, len(inspections))
 	existingRepositories := make(map[string]struct{})
@@ -99,13 +143,14 @@ func (executor *Executor) Execute(executionContext context.Context, roots []stri
 	}
 
 	for _, sanitizedRoot := range sanitizedRoots {
-		if _, alreadyPresent := existingRepositories[sanitizedRoot]; alreadyPresent {
+		if _, alreadyPresent := existingRepositories[sanitizedRoot]; alreadyPresent || auditService.HasUntrustedOwnership(sanitizedRoot) {
 			continue
 		}
 		if executor.dependencies.GitExecutor != nil {
 			commandDetails := execshell.CommandDetails{
 				Arguments:        []string{"rev-parse", "--is-inside-work-tree"},
 				WorkingDirectory: sanitizedRoot,
+				Idempotent:       true,
 			}
 			result, gitError := executor.dependencies.GitExecutor.ExecuteGit(executionContext, commandDetails)
 			if gitError != nil || strings.TrimSpace(result.StandardOutput) != "true" {
@@ -118,8 +163,10 @@ func (executor *Executor) Execute(executionContext context.Context, roots []stri
 		repositoryStates = append(repositoryStates, state)
 	}
 
+	notifyRepositoryObservers(repositoryStates, executor.dependencies.Output, executor.dependencies.Reporter)
+
 	if runtimeOptions.IncludeNestedRepositories {
-		markNestedRepositoryAncestors(repositoryStates)
+		markNestedRepositoryAncestors(repositoryStates, auditService.Containment())
 	}
 
 	if runtimeOptions.CaptureInitialWorktreeStatus {
@@ -137,36 +184,61 @@ func (executor *Executor) Execute(executionContext context.Context, roots []stri
 		})
 	}
 
+	stopDiscoveryPhase()
+	utils.RecordRepositoryCount(executionContext, len(repositoryStates))
+
 	promptState := NewPromptState(runtimeOptions.AssumeYes)
 	dispatchingPrompter := newPromptDispatcher(executor.dependencies.Prompter, promptState)
 
 	state := &State{Roots: sanitizedRoots, Repositories: repositoryStates}
 	environment := &Environment{
-		AuditService:      auditService,
-		GitExecutor:       executor.dependencies.GitExecutor,
-		RepositoryManager: executor.dependencies.RepositoryManager,
-		GitHubClient:      executor.dependencies.GitHubClient,
-		FileSystem:        executor.dependencies.FileSystem,
-		Prompter:          dispatchingPrompter,
-		PromptState:       promptState,
-		Output:            executor.dependencies.Output,
-		Errors:            executor.dependencies.Errors,
-		Logger:            executor.dependencies.Logger,
-		DryRun:            runtimeOptions.DryRun,
+		AuditService:       auditService,
+		GitExecutor:        executor.dependencies.GitExecutor,
+		RepositoryManager:  executor.dependencies.RepositoryManager,
+		GitHubClient:       executor.dependencies.GitHubClient,
+		RepositoryMetadata: repositoryMetadata,
+		BranchProtection:   branchProtection,
+		FileSystem:         executor.dependencies.FileSystem,
+		Prompter:           dispatchingPrompter,
+		PromptState:        promptState,
+		Output:             executor.dependencies.Output,
+		Errors:             executor.dependencies.Errors,
+		Reporter:           executor.dependencies.Reporter,
+		Logger:             executor.dependencies.Logger,
+		DryRun:             runtimeOptions.DryRun,
+		TokenAccess:        tokenAccess,
+		RepositoryTimeout:  runtimeOptions.RepositoryTimeout,
+		WorkingBranch:      runtimeOptions.WorkingBranch,
 	}
 	environment.State = state
 
+	stopProcessingPhase := utils.StartPhase(executionContext, utils.PhaseProcessing)
 	for operationIndex := range executor.operations {
 		operation := executor.operations[operationIndex]
 		if operation == nil {
 			continue
 		}
 		if executeError := operation.Execute(executionContext, environment, state); executeError != nil {
+			stopProcessingPhase()
+			if executionContext.Err() != nil {
+				stopReportingPhase := utils.StartPhase(executionContext, utils.PhaseReporting)
+				reportSourceRetentionSummary(environment)
+				reportFilterSkipSummary(environment)
+				reportStepOutputs(environment)
+				stopReportingPhase()
+			}
 			return fmt.Errorf(workflowExecutionErrorTemplateConstant, operation.Name(), executeError)
 		}
 	}
+	stopProcessingPhase()
+
+	stopReportingPhase := utils.StartPhase(executionContext, utils.PhaseReporting)
+	defer stopReportingPhase()
+	reportSourceRetentionSummary(environment)
+	reportFilterSkipSummary(environment)
+	reportStepOutputs(environment)
 
-	return nil
+	return writeRenamePlans(environment)
 }
 
 func repositoryPathDepth(path string) int {
@@ -178,34 +250,25 @@ func repositoryPathDepth(path string) int {
 	return strings.Count(normalized, "/")
 }
 
-func markNestedRepositoryAncestors(repositories []*RepositoryState) {
-	for ancestorIndex := range repositories {
-		ancestorPath := repositories[ancestorIndex].Path
-		for candidateIndex := range repositories {
-			if ancestorIndex == candidateIndex {
-				continue
-			}
-			descendantPath := repositories[candidateIndex].Path
-			if isAncestorPath(ancestorPath, descendantPath) {
-				repositories[ancestorIndex].HasNestedRepositories = true
-				break
-			}
+func markNestedRepositoryAncestors(repositories []*RepositoryState, containment discovery.Containment) {
+	for repositoryIndex := range repositories {
+		if containment.HasNestedRepositories(repositories[repositoryIndex].Path) {
+			repositories[repositoryIndex].HasNestedRepositories = true
 		}
 	}
 }
 
-func isAncestorPath(potentialAncestor string, potentialDescendant string) bool {
-	if len(strings.TrimSpace(potentialAncestor)) == 0 || len(strings.TrimSpace(potentialDescendant)) == 0 {
-		return false
+// notifyRepositoryObservers hands the inspected repository paths to every sink that groups output by repository.
+func notifyRepositoryObservers(repositories []*RepositoryState, sinks ...any) {
+	repositoryPaths := make([]string, 0, len(repositories))
+	for _, repository := range repositories {
+		repositoryPaths = append(repositoryPaths, repository.Path)
 	}
-	relativePath, relativeError := filepath.Rel(potentialAncestor, potentialDescendant)
-	if relativeError != nil {
-		return false
-	}
-	if relativePath == "." {
-		return false
+	for _, sink := range sinks {
+		if observer, isObserver := sink.(RepositoryObserver); isObserver {
+			observer.ObserveRepositories(repositoryPaths)
+		}
 	}
-	return !strings.HasPrefix(relativePath, "..")
 }
 
 func captureInitialCleanStatuses(executionContext context.Context, manager *gitrepo.RepositoryManager, repositories []*RepositoryState) {
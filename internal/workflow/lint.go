@@ -0,0 +1,373 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	lintStepKeyConstant                    = "step"
+	lintStepOperationKeyConstant           = "operation"
+	lintStepOptionsKeyConstant             = "with"
+	lintStepOnlyKeyConstant                = "only"
+	lintStepSkipKeyConstant                = "skip"
+	lintStepOrderKeyConstant               = "order"
+	lintStepTimeoutKeyConstant             = "timeout"
+	lintStepEnvironmentKeyConstant         = "env"
+	lintStepCommitKeyConstant              = "commit"
+	lintStepIdentifierKeyConstant          = "id"
+	lintSharedRootsKeyConstant             = "roots"
+	lintSharedDryRunKeyConstant            = "dry_run"
+	lintSharedAssumeYesKeyConstant         = "assume_yes"
+	lintSharedDebugKeyConstant             = "debug"
+	lintUnknownKeyMessageTemplateConstant  = "unknown key %q"
+	lintUnknownActionMessageTemplate       = "unknown task action type %q"
+	lintExpectedMappingMessageConstant     = "expected a mapping"
+	lintIndexedLocationTemplateConstant    = "%s[%d]"
+	lintNestedLocationTemplateConstant     = "%s.%s"
+	lintStepLocationTemplateConstant       = "workflow[%d].step"
+	lintSummaryTaskListTemplateConstant    = "%s: %d task(s): %s"
+	lintSummaryFilterTemplateConstant      = "%s [%s: %s]"
+	lintSummaryTargetsTemplateConstant     = "%s: %d target(s)"
+	lintSummaryProtocolTemplateConstant    = "%s: %s -> %s"
+	lintSummaryOwnerTemplateConstant       = "%s: owner %s"
+	lintSummaryOutputTemplateConstant      = "%s: write %s"
+	lintSummaryPlanFileTemplateConstant    = "%s: plan file %s"
+	lintSummaryListSeparatorConstant       = ", "
+	lintReadErrorTemplateConstant          = "failed to read workflow configuration: %w"
+	lintIssueFormatTemplateConstant        = "step %d: %s: %s"
+	lintIssueWithoutStepTemplateConstant   = "%s: %s"
+	lintFileLevelLocationConstant          = "workflow"
+	lintInvalidStepDescriptionConstant     = "(invalid)"
+	lintStepSummaryLineTemplateConstant    = "%d. %s"
+	lintStepSummaryInvalidTemplateConstant = "%d. %s %s"
+)
+
+var (
+	lintStepKeys         = []string{lintStepOperationKeyConstant, lintStepOptionsKeyConstant, lintStepOnlyKeyConstant, lintStepSkipKeyConstant, lintStepOrderKeyConstant, lintStepTimeoutKeyConstant, lintStepEnvironmentKeyConstant, lintStepCommitKeyConstant, lintStepIdentifierKeyConstant}
+	lintSharedOptionKeys = []string{lintSharedRootsKeyConstant, lintSharedDryRunKeyConstant, lintSharedAssumeYesKeyConstant, lintSharedDebugKeyConstant}
+	lintOperationKeys    = map[OperationType][]string{
+		OperationTypeProtocolConversion: {optionFromKeyConstant, optionToKeyConstant},
+		OperationTypeCanonicalRemote:    {optionOwnerKeyConstant, optionRenameDirectoryKeyConstant, optionIncludeOwnerKeyConstant, optionRequireCleanKeyConstant, optionIncludePushURLKeyConstant, optionEraseCredentialsKeyConstant},
+		OperationTypeRenameDirectories:  {optionRequireCleanKeyConstant, optionIncludeOwnerKeyConstant, optionPlanFileKeyConstant, optionNamingTemplateKeyConstant},
+		OperationTypeBranchDefault:      {optionTargetsKeyConstant},
+		OperationTypeAuditReport:        {optionOutputPathKeyConstant, optionFailOnNestedKeyConstant, optionSortKeyConstant, optionReportFormatKeyConstant, optionMinimumScoreKeyConstant},
+		OperationTypeApplyTasks:         {optionTasksKeyConstant},
+		OperationTypeEditRepository:     {optionAddTopicsKeyConstant, optionRemoveTopicsKeyConstant, optionDescriptionKeyConstant},
+		OperationTypeCreatePullRequest:  {optionTaskPRTitleKeyConstant, optionTaskPRBodyKeyConstant, optionTaskPRBaseKeyConstant, optionPullRequestHeadKeyConstant, optionTaskPRDraftKeyConstant},
+		OperationTypeCommit:             {optionCommitMessageKeyConstant},
+	}
+	lintBranchTargetKeys = []string{optionRemoteNameKeyConstant, optionSourceBranchKeyConstant, optionTargetBranchKeyConstant, optionPushToRemoteKeyConstant, optionDeleteSourceBranchKeyConstant, optionRetainSourceKeyConstant, optionLeaveTombstoneKeyConstant, optionWriteInstructionsKeyConstant, optionUpdateDocsKeyConstant, optionDocsPatternsKeyConstant, optionPhaseKeyConstant, optionGracePeriodKeyConstant}
+	lintTaskKeys         = []string{optionTaskNameKeyConstant, optionTaskEnsureCleanKeyConstant, optionTaskBranchKeyConstant, optionTaskFilesKeyConstant, optionTaskCommitMessageKeyConstant, optionTaskPullRequestKeyConstant, optionTaskActionsKeyConstant}
+	lintTaskBranchKeys   = []string{optionTaskBranchNameKeyConstant, optionTaskBranchStartPointKeyConstant, optionTaskBranchPushRemoteKeyConstant}
+	lintTaskFileKeys     = []string{optionTaskFilePathKeyConstant, optionTaskFileContentKeyConstant, optionTaskFileModeKeyConstant, optionTaskFilePermissionsKeyConstant}
+	lintTaskPRKeys       = []string{optionTaskPRTitleKeyConstant, optionTaskPRBodyKeyConstant, optionTaskPRBaseKeyConstant, optionTaskPRDraftKeyConstant}
+	lintTaskActionKeys   = []string{optionTaskActionTypeKeyConstant, optionTaskActionOptionsKeyConstant}
+)
+
+// LintIssue describes one problem found while linting a workflow configuration.
+// StepNumber is the one-based step position, or zero for problems that concern the whole file.
+type LintIssue struct {
+	StepNumber int
+	Location   string
+	Message    string
+}
+
+// String renders the issue on a single stable line.
+func (issue LintIssue) String() string {
+	if issue.StepNumber == 0 {
+		return fmt.Sprintf(lintIssueWithoutStepTemplateConstant, issue.Location, issue.Message)
+	}
+	return fmt.Sprintf(lintIssueFormatTemplateConstant, issue.StepNumber, issue.Location, issue.Message)
+}
+
+// LintStepSummary describes what a workflow step would do when run.
+type LintStepSummary struct {
+	StepNumber  int
+	Operation   OperationType
+	Description string
+	Valid       bool
+}
+
+// String renders the summary as a numbered line.
+func (summary LintStepSummary) String() string {
+	if !summary.Valid {
+		return fmt.Sprintf(lintStepSummaryInvalidTemplateConstant, summary.StepNumber, summary.Description, lintInvalidStepDescriptionConstant)
+	}
+	return fmt.Sprintf(lintStepSummaryLineTemplateConstant, summary.StepNumber, summary.Description)
+}
+
+// LintReport collects the step summaries and problems found in a workflow configuration.
+type LintReport struct {
+	Steps  []LintStepSummary
+	Issues []LintIssue
+}
+
+// Valid reports whether the configuration produced no issues.
+func (report LintReport) Valid() bool {
+	return len(report.Issues) == 0
+}
+
+// LintConfigurationFile validates a workflow file without touching any repository.
+// It reports unknown keys, unsupported operations and task actions, option decoding failures, malformed templates, and invalid only/skip filters.
+func LintConfigurationFile(filePath string) (LintReport, error) {
+	trimmedPath := strings.TrimSpace(filePath)
+	if len(trimmedPath) == 0 {
+		return LintReport{}, errors.New(configurationPathRequiredMessageConstant)
+	}
+
+	contentBytes, readError := os.ReadFile(trimmedPath)
+	if readError != nil {
+		return LintReport{}, fmt.Errorf(lintReadErrorTemplateConstant, readError)
+	}
+
+	report := LintReport{}
+	configuration, loadError := LoadConfiguration(trimmedPath)
+	if loadError != nil {
+		report.Issues = append(report.Issues, LintIssue{Location: lintFileLevelLocationConstant, Message: loadError.Error()})
+		return report, nil
+	}
+
+	rawSteps := decodeRawWorkflowSteps(contentBytes)
+	outputCatalog := newStepOutputCatalog()
+	for stepIndex := range configuration.Steps {
+		step := configuration.Steps[stepIndex]
+		stepNumber := stepIndex + 1
+		var rawStep map[string]any
+		if stepIndex < len(rawSteps) {
+			rawStep = rawSteps[stepIndex]
+		}
+
+		stepIssues := lintStepKeysAndOptions(stepNumber, step, rawStep)
+		operation, buildError := buildOperationFromStep(step)
+		if buildError == nil {
+			buildError = applyStepCommitMode(operation, step)
+		}
+		if buildError == nil {
+			buildError = validateWorkingBranchTasks(operation, configuration.WorkingBranch)
+		}
+		if buildError == nil {
+			_, buildError = applyStepFilters(operation, step)
+		}
+		if buildError == nil {
+			_, buildError = applyStepTimeout(operation, step)
+		}
+		if buildError == nil {
+			_, buildError = applyStepEnvironment(operation, configuration.Environment, step)
+		}
+		if _, referencesError := outputCatalog.register(step, configuration.Environment); referencesError != nil && buildError == nil {
+			buildError = referencesError
+		}
+		if buildError != nil {
+			stepIssues = append(stepIssues, LintIssue{StepNumber: stepNumber, Location: fmt.Sprintf(lintStepLocationTemplateConstant, stepIndex), Message: buildError.Error()})
+		}
+		if operation != nil {
+			stepIssues = append(stepIssues, lintTaskActionTypes(stepNumber, operation)...)
+		}
+
+		report.Issues = append(report.Issues, stepIssues...)
+		report.Steps = append(report.Steps, LintStepSummary{
+			StepNumber:  stepNumber,
+			Operation:   step.Operation,
+			Description: describeLintedStep(step, operation),
+			Valid:       len(stepIssues) == 0,
+		})
+	}
+
+	return report, nil
+}
+
+func decodeRawWorkflowSteps(contentBytes []byte) []map[string]any {
+	var rawFile struct {
+		Workflow []map[string]any `yaml:"workflow"`
+	}
+	if unmarshalError := yaml.Unmarshal(contentBytes, &rawFile); unmarshalError != nil {
+		return nil
+	}
+
+	rawSteps := make([]map[string]any, 0, len(rawFile.Workflow))
+	for _, wrapper := range rawFile.Workflow {
+		stepValue, _ := wrapper[lintStepKeyConstant].(map[string]any)
+		rawSteps = append(rawSteps, stepValue)
+	}
+	return rawSteps
+}
+
+func lintStepKeysAndOptions(stepNumber int, step StepConfiguration, rawStep map[string]any) []LintIssue {
+	stepLocation := fmt.Sprintf(lintStepLocationTemplateConstant, stepNumber-1)
+	issues := lintUnknownKeys(stepNumber, stepLocation, rawStep, lintStepKeys)
+
+	allowedOptionKeys, supported := lintOperationKeys[step.Operation]
+	if !supported {
+		return issues
+	}
+
+	optionsLocation := fmt.Sprintf(lintNestedLocationTemplateConstant, stepLocation, lintStepOptionsKeyConstant)
+	issues = append(issues, lintUnknownKeys(stepNumber, optionsLocation, step.Options, append(append([]string{}, allowedOptionKeys...), lintSharedOptionKeys...))...)
+
+	switch step.Operation {
+	case OperationTypeBranchDefault:
+		issues = append(issues, lintNestedEntries(stepNumber, optionsLocation, step.Options, optionTargetsKeyConstant, lintBranchTargetKeys)...)
+	case OperationTypeApplyTasks:
+		taskEntries, _ := lookupOption(step.Options, optionTasksKeyConstant).([]any)
+		issues = append(issues, lintNestedEntries(stepNumber, optionsLocation, step.Options, optionTasksKeyConstant, lintTaskKeys)...)
+		for taskIndex, taskEntry := range taskEntries {
+			taskOptions, isMapping := taskEntry.(map[string]any)
+			if !isMapping {
+				continue
+			}
+			taskLocation := lintTaskLocation(stepNumber, taskIndex)
+			issues = append(issues, lintNestedMapping(stepNumber, taskLocation, taskOptions, optionTaskBranchKeyConstant, lintTaskBranchKeys)...)
+			issues = append(issues, lintNestedMapping(stepNumber, taskLocation, taskOptions, optionTaskPullRequestKeyConstant, lintTaskPRKeys)...)
+			issues = append(issues, lintNestedEntries(stepNumber, taskLocation, taskOptions, optionTaskFilesKeyConstant, lintTaskFileKeys)...)
+			issues = append(issues, lintNestedEntries(stepNumber, taskLocation, taskOptions, optionTaskActionsKeyConstant, lintTaskActionKeys)...)
+		}
+	}
+
+	return issues
+}
+
+func lintNestedEntries(stepNumber int, parentLocation string, parent map[string]any, key string, allowedKeys []string) []LintIssue {
+	entries, isSequence := lookupOption(parent, key).([]any)
+	if !isSequence {
+		return nil
+	}
+
+	var issues []LintIssue
+	for entryIndex, entry := range entries {
+		entryLocation := fmt.Sprintf(lintIndexedLocationTemplateConstant, fmt.Sprintf(lintNestedLocationTemplateConstant, parentLocation, key), entryIndex)
+		entryMapping, isMapping := entry.(map[string]any)
+		if !isMapping {
+			issues = append(issues, LintIssue{StepNumber: stepNumber, Location: entryLocation, Message: lintExpectedMappingMessageConstant})
+			continue
+		}
+		issues = append(issues, lintUnknownKeys(stepNumber, entryLocation, entryMapping, allowedKeys)...)
+	}
+	return issues
+}
+
+func lintNestedMapping(stepNumber int, parentLocation string, parent map[string]any, key string, allowedKeys []string) []LintIssue {
+	nested, isMapping := lookupOption(parent, key).(map[string]any)
+	if !isMapping {
+		return nil
+	}
+	return lintUnknownKeys(stepNumber, fmt.Sprintf(lintNestedLocationTemplateConstant, parentLocation, key), nested, allowedKeys)
+}
+
+func lintUnknownKeys(stepNumber int, location string, entries map[string]any, allowedKeys []string) []LintIssue {
+	allowed := make(map[string]struct{}, len(allowedKeys))
+	for _, allowedKey := range allowedKeys {
+		allowed[allowedKey] = struct{}{}
+	}
+
+	unknownKeys := make([]string, 0)
+	for key := range entries {
+		normalizedKey := strings.ToLower(strings.TrimSpace(key))
+		if _, known := allowed[normalizedKey]; known {
+			continue
+		}
+		unknownKeys = append(unknownKeys, key)
+	}
+	sort.Strings(unknownKeys)
+
+	issues := make([]LintIssue, 0, len(unknownKeys))
+	for _, unknownKey := range unknownKeys {
+		issues = append(issues, LintIssue{StepNumber: stepNumber, Location: location, Message: fmt.Sprintf(lintUnknownKeyMessageTemplateConstant, unknownKey)})
+	}
+	return issues
+}
+
+func lintTaskActionTypes(stepNumber int, operation Operation) []LintIssue {
+	taskOperation, isTaskOperation := unwrapLintedOperation(operation).(*TaskOperation)
+	if !isTaskOperation {
+		return nil
+	}
+
+	var issues []LintIssue
+	for taskIndex, task := range taskOperation.tasks {
+		for actionIndex, action := range task.Actions {
+			normalizedType := strings.ToLower(strings.TrimSpace(action.Type))
+			if _, registered := taskActionHandlers[normalizedType]; registered {
+				continue
+			}
+			actionsLocation := fmt.Sprintf(lintNestedLocationTemplateConstant, lintTaskLocation(stepNumber, taskIndex), optionTaskActionsKeyConstant)
+			location := fmt.Sprintf(lintIndexedLocationTemplateConstant, actionsLocation, actionIndex)
+			issues = append(issues, LintIssue{StepNumber: stepNumber, Location: location, Message: fmt.Sprintf(lintUnknownActionMessageTemplate, action.Type)})
+		}
+	}
+	return issues
+}
+
+func lintTaskLocation(stepNumber int, taskIndex int) string {
+	stepLocation := fmt.Sprintf(lintStepLocationTemplateConstant, stepNumber-1)
+	optionsLocation := fmt.Sprintf(lintNestedLocationTemplateConstant, stepLocation, lintStepOptionsKeyConstant)
+	tasksLocation := fmt.Sprintf(lintNestedLocationTemplateConstant, optionsLocation, optionTasksKeyConstant)
+	return fmt.Sprintf(lintIndexedLocationTemplateConstant, tasksLocation, taskIndex)
+}
+
+func describeLintedStep(step StepConfiguration, operation Operation) string {
+	description := string(step.Operation)
+	switch typedOperation := unwrapLintedOperation(operation).(type) {
+	case *ProtocolConversionOperation:
+		description = fmt.Sprintf(lintSummaryProtocolTemplateConstant, step.Operation, typedOperation.FromProtocol, typedOperation.ToProtocol)
+	case *CanonicalRemoteOperation:
+		if len(typedOperation.OwnerConstraint) > 0 {
+			description = fmt.Sprintf(lintSummaryOwnerTemplateConstant, step.Operation, typedOperation.OwnerConstraint)
+		}
+	case *RenameOperation:
+		if len(typedOperation.PlanFilePath) > 0 {
+			description = fmt.Sprintf(lintSummaryPlanFileTemplateConstant, step.Operation, typedOperation.PlanFilePath)
+		}
+	case *BranchMigrationOperation:
+		description = fmt.Sprintf(lintSummaryTargetsTemplateConstant, step.Operation, len(typedOperation.Targets))
+	case *AuditReportOperation:
+		if typedOperation.WriteToFile {
+			description = fmt.Sprintf(lintSummaryOutputTemplateConstant, step.Operation, typedOperation.OutputPath)
+		}
+	case *TaskOperation:
+		taskNames := make([]string, 0, len(typedOperation.tasks))
+		for _, task := range typedOperation.tasks {
+			taskNames = append(taskNames, strconv.Quote(task.Name))
+		}
+		description = fmt.Sprintf(lintSummaryTaskListTemplateConstant, step.Operation, len(taskNames), strings.Join(taskNames, lintSummaryListSeparatorConstant))
+	}
+
+	if len(step.Only) > 0 {
+		description = fmt.Sprintf(lintSummaryFilterTemplateConstant, description, lintStepOnlyKeyConstant, strings.Join(step.Only, lintSummaryListSeparatorConstant))
+	}
+	if len(step.Skip) > 0 {
+		description = fmt.Sprintf(lintSummaryFilterTemplateConstant, description, lintStepSkipKeyConstant, strings.Join(step.Skip, lintSummaryListSeparatorConstant))
+	}
+	return description
+}
+
+func unwrapLintedOperation(operation Operation) Operation {
+	if timedOperation, isTimed := operation.(*TimedOperation); isTimed {
+		operation = timedOperation.Unwrap()
+	}
+	if filteredOperation, isFiltered := operation.(*FilteredOperation); isFiltered {
+		operation = filteredOperation.Unwrap()
+	}
+	if environmentOperation, hasEnvironment := operation.(*EnvironmentOperation); hasEnvironment {
+		operation = environmentOperation.Unwrap()
+	}
+	if templatedOperation, isTemplated := operation.(*TemplatedOperation); isTemplated {
+		return templatedOperation.Unwrap()
+	}
+	return operation
+}
+
+func lookupOption(entries map[string]any, key string) any {
+	for entryKey, value := range entries {
+		if strings.ToLower(strings.TrimSpace(entryKey)) == key {
+			return value
+		}
+	}
+	return nil
+}
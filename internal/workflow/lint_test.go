@@ -0,0 +1,161 @@
+package workflow_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/workflow"
+)
+
+func TestLintConfigurationFile(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		content        string
+		expectedSteps  []string
+		expectedIssues []string
+	}{
+		{
+			name: "valid_workflow_with_shared_defaults",
+			content: `operations:
+  - operation: repo-protocol-convert
+    with: &protocol_defaults
+      roots: [~/Development]
+      dry_run: false
+      from: https
+      to: ssh
+workflow:
+  - step:
+      order: 1
+      operation: convert-protocol
+      with:
+        <<: *protocol_defaults
+  - step:
+      operation: apply-tasks
+      skip: ["archived/*"]
+      with:
+        tasks:
+          - name: Add Notes
+            branch:
+              name: notes
+            files:
+              - path: NOTES.md
+                content: "{{ .Repository.Name }}"
+            pull_request:
+              title: Add notes
+  - step:
+      operation: audit-report
+      with:
+        output: ./audit.csv
+`,
+			expectedSteps: []string{
+				"1. convert-protocol: https -> ssh",
+				`2. apply-tasks: 1 task(s): "Add Notes" [skip: archived/*]`,
+				"3. audit-report: write ./audit.csv",
+			},
+		},
+		{
+			name: "unknown_keys_and_actions_reported",
+			content: `workflow:
+  - step:
+      operation: apply-tasks
+      when: always
+      with:
+        tasks:
+          - name: Broken
+            colour: blue
+            files:
+              - path: a.txt
+                content: a
+                owner: root
+            actions:
+              - type: repo.unknown
+  - step:
+      operation: default-branch
+      with:
+        targets:
+          - target_branch: main
+            push: true
+`,
+			expectedSteps: []string{
+				`1. apply-tasks: 1 task(s): "Broken" (invalid)`,
+				"2. default-branch: 1 target(s) (invalid)",
+			},
+			expectedIssues: []string{
+				`step 1: workflow[0].step: unknown key "when"`,
+				`step 1: workflow[0].step.with.tasks[0]: unknown key "colour"`,
+				`step 1: workflow[0].step.with.tasks[0].files[0]: unknown key "owner"`,
+				`step 1: workflow[0].step.with.tasks[0].actions[0]: unknown task action type "repo.unknown"`,
+				`step 2: workflow[1].step.with.targets[0]: unknown key "push"`,
+			},
+		},
+		{
+			name: "build_failures_reported",
+			content: `workflow:
+  - step:
+      operation: convert-protocol
+      with:
+        from: https
+  - step:
+      operation: audit-report
+      only: ["[broken"]
+  - step:
+      operation: teleport
+`,
+			expectedSteps: []string{
+				"1. convert-protocol (invalid)",
+				"2. audit-report [only: [broken] (invalid)",
+				"3. teleport (invalid)",
+			},
+			expectedIssues: []string{
+				"step 1: workflow[0].step: convert-protocol step requires a valid 'to' protocol",
+				`step 2: workflow[1].step: workflow step audit-report has invalid only/skip filters: invalid repository filter pattern "[broken": syntax error in pattern`,
+				"step 3: workflow[2].step: unsupported workflow operation: teleport",
+			},
+		},
+		{
+			name:           "load_failure_reported",
+			content:        "workflow:\n  - step:\n      with: {}\n",
+			expectedIssues: []string{"workflow: workflow step missing operation name"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			configurationPath := filepath.Join(subtest.TempDir(), configurationTestFileName)
+			require.NoError(subtest, os.WriteFile(configurationPath, []byte(testCase.content), 0o644))
+
+			report, lintError := workflow.LintConfigurationFile(configurationPath)
+			require.NoError(subtest, lintError)
+
+			renderedSteps := []string{}
+			for _, stepSummary := range report.Steps {
+				renderedSteps = append(renderedSteps, stepSummary.String())
+			}
+			renderedIssues := []string{}
+			for _, issue := range report.Issues {
+				renderedIssues = append(renderedIssues, issue.String())
+			}
+
+			if testCase.expectedSteps == nil {
+				require.Empty(subtest, renderedSteps)
+			} else {
+				require.Equal(subtest, testCase.expectedSteps, renderedSteps)
+			}
+			if testCase.expectedIssues == nil {
+				require.Empty(subtest, renderedIssues)
+				require.True(subtest, report.Valid())
+			} else {
+				require.Equal(subtest, testCase.expectedIssues, renderedIssues)
+				require.False(subtest, report.Valid())
+			}
+		})
+	}
+}
+
+func TestLintConfigurationFileMissingFile(testInstance *testing.T) {
+	_, lintError := workflow.LintConfigurationFile(filepath.Join(testInstance.TempDir(), "missing.yaml"))
+	require.Error(testInstance, lintError)
+}
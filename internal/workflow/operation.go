@@ -3,12 +3,14 @@ package workflow
 import (
 	"context"
 	"io"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/temirov/gix/internal/audit"
 	"github.com/temirov/gix/internal/githubcli"
 	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/repos/rename"
 	"github.com/temirov/gix/internal/repos/shared"
 )
 
@@ -20,19 +22,37 @@ type Operation interface {
 
 // Environment exposes shared dependencies for workflow operations.
 type Environment struct {
-	AuditService        *audit.Service
-	GitExecutor         shared.GitExecutor
-	RepositoryManager   *gitrepo.RepositoryManager
-	GitHubClient        *githubcli.Client
-	FileSystem          shared.FileSystem
-	Prompter            shared.ConfirmationPrompter
-	PromptState         *PromptState
-	Output              io.Writer
-	Errors              io.Writer
-	Logger              *zap.Logger
-	DryRun              bool
-	State               *State
-	auditReportExecuted bool
+	AuditService       *audit.Service
+	GitExecutor        shared.GitExecutor
+	RepositoryManager  *gitrepo.RepositoryManager
+	GitHubClient       *githubcli.Client
+	RepositoryMetadata shared.GitHubMetadataResolver
+	BranchProtection   shared.BranchProtectionResolver
+	FileSystem         shared.FileSystem
+	Prompter           shared.ConfirmationPrompter
+	PromptState        *PromptState
+	Output             io.Writer
+	Errors             io.Writer
+	Reporter           shared.Reporter
+	Logger             *zap.Logger
+	DryRun             bool
+	// TokenAccess is what the auth preflight found the GitHub token may do.
+	TokenAccess githubcli.TokenAccess
+	// RepositoryTimeout bounds all tasks run on one repository; zero means unlimited.
+	RepositoryTimeout time.Duration
+	// WorkingBranch, when set, moves each repository's task commits onto a dedicated branch.
+	WorkingBranch             *WorkingBranchConfiguration
+	State                     *State
+	auditReportExecuted       bool
+	archivedSourceBranches    int
+	deletedSourceBranches     int
+	filterSkippedRepositories int
+	renamePlans               map[string]*rename.PlanFile
+	renamePlanPaths           []string
+	stagedTaskNames           map[string][]string
+	workingBranches           map[string]string
+	stepOutputsReport         []stepOutputsReportEntry
+	clock                     shared.Clock
 }
 
 // OperationDefaults captures fallback behaviors shared across operations.
@@ -43,10 +63,27 @@ type OperationDefaults struct {
 // ApplyDefaults configures operations with shared fallback options when not explicitly set.
 func ApplyDefaults(operations []Operation, defaults OperationDefaults) {
 	for operationIndex := range operations {
-		renameOperation, isRename := operations[operationIndex].(*RenameOperation)
+		renameOperation, isRename := unwrapIdentifiedOperation(operations[operationIndex]).(*RenameOperation)
 		if !isRename {
 			continue
 		}
 		renameOperation.ApplyRequireCleanDefault(defaults.RequireClean)
 	}
 }
+
+func unwrapIdentifiedOperation(operation Operation) Operation {
+	if identifiedOperation, isIdentified := operation.(*IdentifiedOperation); isIdentified {
+		operation = identifiedOperation.Unwrap()
+	}
+	if templatedOperation, isTemplated := operation.(*TemplatedOperation); isTemplated {
+		return templatedOperation.Unwrap()
+	}
+	return operation
+}
+
+func (environment *Environment) executorReporter() shared.Reporter {
+	if environment.Reporter != nil {
+		return environment.Reporter
+	}
+	return shared.NewWriterReporter(environment.Output)
+}
@@ -26,12 +26,22 @@ const (
 	auditCSVHeaderInSyncConstant          = "in_sync"
 	auditCSVHeaderRemoteProtocolConstant  = "remote_protocol"
 	auditCSVHeaderOriginCanonicalConstant = "origin_matches_canonical"
+	auditCSVHeaderLastActivityConstant    = "last_activity"
+	auditCSVHeaderDeleteOnMergeConstant   = "delete_branch_on_merge"
+	auditCSVHeaderMergeQueueConstant      = "merge_queue"
+	auditCSVHeaderHealthScoreConstant     = "health_score"
+	auditReadOnlyTokenMessageConstant     = "AUDIT-TOKEN: read-only GitHub token; the audit only reads from GitHub, so every check runs\n"
 )
 
-// AuditReportOperation emits an audit CSV summarizing repository state.
+// AuditReportOperation emits an audit CSV, markdown, or json document summarizing repository state. MinimumScore drops
+// repositories whose health score is below it.
 type AuditReportOperation struct {
-	OutputPath  string
-	WriteToFile bool
+	OutputPath   string
+	WriteToFile  bool
+	FailOnNested bool
+	SortOrder    audit.ReportSortOrder
+	ReportFormat audit.ReportFormat
+	MinimumScore int
 }
 
 // Name identifies the operation type.
@@ -91,6 +101,47 @@ func (operation *AuditReportOperation) Execute(executionContext context.Context,
 		}()
 	}
 
+	inspections := make([]audit.RepositoryInspection, 0, len(state.Repositories))
+	for repositoryIndex := range state.Repositories {
+		inspections = append(inspections, state.Repositories[repositoryIndex].Inspection)
+	}
+	audit.SortInspections(inspections, operation.SortOrder)
+	inspections = audit.FilterInspectionsByScore(inspections, operation.MinimumScore)
+
+	if operation.ReportFormat == audit.ReportFormatJSON {
+		weights := audit.DefaultScoreWeights()
+		if environment.AuditService != nil {
+			weights = environment.AuditService.ScoreWeights()
+		}
+		if writeError := audit.WriteJSONReport(writer, inspections, weights); writeError != nil {
+			return writeError
+		}
+		if operation.WriteToFile && environment.Output != nil {
+			fmt.Fprintf(environment.Output, auditWriteMessageTemplateConstant, destination)
+		}
+		if environment.AuditService != nil {
+			return environment.AuditService.NestedRepositoriesError(operation.FailOnNested)
+		}
+		return nil
+	}
+
+	if operation.ReportFormat == audit.ReportFormatMarkdown {
+		report := audit.MarkdownReport{Inspections: inspections}
+		if environment.AuditService != nil {
+			report = environment.AuditService.MarkdownReport(inspections)
+		}
+		if writeError := audit.WriteMarkdownReport(writer, report); writeError != nil {
+			return writeError
+		}
+		if operation.WriteToFile && environment.Output != nil {
+			fmt.Fprintf(environment.Output, auditWriteMessageTemplateConstant, destination)
+		}
+		if environment.AuditService != nil {
+			return environment.AuditService.NestedRepositoriesError(operation.FailOnNested)
+		}
+		return nil
+	}
+
 	csvWriter := csv.NewWriter(writer)
 	header := []string{
 		auditCSVHeaderFolderNameConstant,
@@ -101,15 +152,18 @@ func (operation *AuditReportOperation) Execute(executionContext context.Context,
 		auditCSVHeaderInSyncConstant,
 		auditCSVHeaderRemoteProtocolConstant,
 		auditCSVHeaderOriginCanonicalConstant,
+		auditCSVHeaderLastActivityConstant,
+		auditCSVHeaderDeleteOnMergeConstant,
+		auditCSVHeaderMergeQueueConstant,
+		auditCSVHeaderHealthScoreConstant,
 	}
 
 	if writeError := csvWriter.Write(header); writeError != nil {
 		return writeError
 	}
 
-	for repositoryIndex := range state.Repositories {
-		repository := state.Repositories[repositoryIndex]
-		row := buildAuditReportRow(repository.Inspection)
+	for inspectionIndex := range inspections {
+		row := buildAuditReportRow(inspections[inspectionIndex])
 		if writeError := csvWriter.Write(row); writeError != nil {
 			return writeError
 		}
@@ -124,6 +178,9 @@ func (operation *AuditReportOperation) Execute(executionContext context.Context,
 		fmt.Fprintf(environment.Output, auditWriteMessageTemplateConstant, destination)
 	}
 
+	if environment.AuditService != nil {
+		return environment.AuditService.ReportContainment(operation.FailOnNested)
+	}
 	return nil
 }
 
@@ -146,6 +203,9 @@ func buildAuditReportRow(inspection audit.RepositoryInspection) []string {
 	inSync := inspection.InSyncStatus
 	remoteProtocol := string(inspection.RemoteProtocol)
 	originMatches := string(inspection.OriginMatchesCanonical)
+	lastActivity := inspection.LastActivity.String()
+	deleteBranchOnMerge := audit.TernaryOrNotApplicable(inspection.DeleteBranchOnMerge)
+	mergeQueue := audit.TernaryOrNotApplicable(inspection.MergeQueue)
 
 	if !inspection.IsGitRepository {
 		finalRepository = string(audit.TernaryValueNotApplicable)
@@ -154,6 +214,9 @@ func buildAuditReportRow(inspection audit.RepositoryInspection) []string {
 		inSync = audit.TernaryValueNotApplicable
 		remoteProtocol = string(audit.TernaryValueNotApplicable)
 		originMatches = string(audit.TernaryValueNotApplicable)
+		lastActivity = string(audit.TernaryValueNotApplicable)
+		deleteBranchOnMerge = audit.TernaryValueNotApplicable
+		mergeQueue = audit.TernaryValueNotApplicable
 	}
 
 	return []string{
@@ -165,5 +228,9 @@ func buildAuditReportRow(inspection audit.RepositoryInspection) []string {
 		string(inSync),
 		remoteProtocol,
 		originMatches,
+		lastActivity,
+		string(deleteBranchOnMerge),
+		string(mergeQueue),
+		inspection.HealthScore.String(),
 	}
 }
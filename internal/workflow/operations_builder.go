@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/temirov/gix/internal/audit"
 	"github.com/temirov/gix/internal/repos/shared"
 )
 
@@ -18,13 +19,37 @@ const (
 // BuildOperations converts the declarative configuration into executable operations.
 func BuildOperations(configuration Configuration) ([]Operation, error) {
 	operations := make([]Operation, 0, len(configuration.Steps))
+	outputCatalog := newStepOutputCatalog()
 	for stepIndex := range configuration.Steps {
 		step := configuration.Steps[stepIndex]
 		operation, buildError := buildOperationFromStep(step)
 		if buildError != nil {
 			return nil, buildError
 		}
-		operations = append(operations, operation)
+		stepReferences, referencesError := outputCatalog.register(step, configuration.Environment)
+		if referencesError != nil {
+			return nil, referencesError
+		}
+		if commitModeError := applyStepCommitMode(operation, step); commitModeError != nil {
+			return nil, commitModeError
+		}
+		if workingBranchError := validateWorkingBranchTasks(operation, configuration.WorkingBranch); workingBranchError != nil {
+			return nil, workingBranchError
+		}
+		templatedOperation := applyStepTemplates(operation, step, stepReferences)
+		environmentOperation, environmentError := applyStepEnvironment(templatedOperation, configuration.Environment, step)
+		if environmentError != nil {
+			return nil, environmentError
+		}
+		filteredOperation, filterError := applyStepFilters(environmentOperation, step)
+		if filterError != nil {
+			return nil, filterError
+		}
+		timedOperation, timeoutError := applyStepTimeout(filteredOperation, step)
+		if timeoutError != nil {
+			return nil, timeoutError
+		}
+		operations = append(operations, applyStepIdentity(timedOperation, step, stepReferences))
 	}
 	return operations, nil
 }
@@ -35,8 +60,14 @@ func buildOperationFromStep(step StepConfiguration) (Operation, error) {
 		return nil, errors.New(configurationOperationMissingMessageConstant)
 	}
 
-	normalizedOptions := step.Options
+	if templateError := validateStepTemplates(step); templateError != nil {
+		return nil, templateError
+	}
+
+	return buildOperationFromOptions(resolvedOperation, step.Options)
+}
 
+func buildOperationFromOptions(resolvedOperation OperationType, normalizedOptions map[string]any) (Operation, error) {
 	switch resolvedOperation {
 	case OperationTypeProtocolConversion:
 		return buildProtocolConversionOperation(normalizedOptions)
@@ -50,6 +81,12 @@ func buildOperationFromStep(step StepConfiguration) (Operation, error) {
 		return buildAuditReportOperation(normalizedOptions)
 	case OperationTypeApplyTasks:
 		return buildTaskOperation(normalizedOptions)
+	case OperationTypeEditRepository:
+		return buildEditRepositoryOperation(normalizedOptions)
+	case OperationTypeCreatePullRequest:
+		return buildCreatePullRequestOperation(normalizedOptions)
+	case OperationTypeCommit:
+		return buildCommitOperation(normalizedOptions)
 	default:
 		return nil, fmt.Errorf("unsupported workflow operation: %s", resolvedOperation)
 	}
@@ -97,7 +134,39 @@ func buildCanonicalRemoteOperation(options map[string]any) (Operation, error) {
 		return nil, ownerError
 	}
 
-	return &CanonicalRemoteOperation{OwnerConstraint: strings.TrimSpace(ownerValue)}, nil
+	return canonicalRemoteOperationFromOptions(strings.TrimSpace(ownerValue), reader)
+}
+
+func canonicalRemoteOperationFromOptions(ownerConstraint string, reader optionReader) (*CanonicalRemoteOperation, error) {
+	renameDirectory, _, renameDirectoryError := reader.boolValue(optionRenameDirectoryKeyConstant)
+	if renameDirectoryError != nil {
+		return nil, renameDirectoryError
+	}
+	includeOwner, _, includeOwnerError := reader.boolValue(optionIncludeOwnerKeyConstant)
+	if includeOwnerError != nil {
+		return nil, includeOwnerError
+	}
+	requireClean, _, requireCleanError := reader.boolValue(optionRequireCleanKeyConstant)
+	if requireCleanError != nil {
+		return nil, requireCleanError
+	}
+	includePushURL, _, includePushURLError := reader.boolValue(optionIncludePushURLKeyConstant)
+	if includePushURLError != nil {
+		return nil, includePushURLError
+	}
+	eraseCredentials, _, eraseCredentialsError := reader.boolValue(optionEraseCredentialsKeyConstant)
+	if eraseCredentialsError != nil {
+		return nil, eraseCredentialsError
+	}
+
+	return &CanonicalRemoteOperation{
+		OwnerConstraint:    ownerConstraint,
+		RenameDirectory:    renameDirectory,
+		RenameIncludeOwner: includeOwner,
+		RenameRequireClean: requireClean,
+		IncludePushURL:     includePushURL,
+		EraseCredentials:   eraseCredentials,
+	}, nil
 }
 
 func buildRenameOperation(options map[string]any) (Operation, error) {
@@ -110,10 +179,20 @@ func buildRenameOperation(options map[string]any) (Operation, error) {
 	if includeOwnerError != nil {
 		return nil, includeOwnerError
 	}
+	planFilePath, _, planFileError := reader.stringValue(optionPlanFileKeyConstant)
+	if planFileError != nil {
+		return nil, planFileError
+	}
+	namingTemplate, namingTemplateError := readNamingTemplateOption(reader)
+	if namingTemplateError != nil {
+		return nil, namingTemplateError
+	}
 	return &RenameOperation{
 		RequireCleanWorktree: requireClean,
 		requireCleanExplicit: requireCleanExplicit,
 		IncludeOwner:         includeOwner,
+		NamingTemplate:       namingTemplate,
+		PlanFilePath:         planFilePath,
 	}, nil
 }
 
@@ -150,13 +229,44 @@ func buildBranchMigrationOperation(options map[string]any) (Operation, error) {
 		if deleteSourceBranchError != nil {
 			return nil, deleteSourceBranchError
 		}
+		retainSourceValue, _, retainSourceError := targetReader.stringValue(optionRetainSourceKeyConstant)
+		if retainSourceError != nil {
+			return nil, retainSourceError
+		}
+		leaveTombstoneValue, _, leaveTombstoneError := targetReader.boolValue(optionLeaveTombstoneKeyConstant)
+		if leaveTombstoneError != nil {
+			return nil, leaveTombstoneError
+		}
+		instructionsDirectoryValue, _, instructionsDirectoryError := targetReader.stringValue(optionWriteInstructionsKeyConstant)
+		if instructionsDirectoryError != nil {
+			return nil, instructionsDirectoryError
+		}
+		updateDocsValue, _, updateDocsError := targetReader.boolValue(optionUpdateDocsKeyConstant)
+		if updateDocsError != nil {
+			return nil, updateDocsError
+		}
+		docsPatternsValue, _, docsPatternsError := targetReader.stringSliceValue(optionDocsPatternsKeyConstant)
+		if docsPatternsError != nil {
+			return nil, docsPatternsError
+		}
+		phase, gracePeriod, phaseError := readBranchMigrationPhase(targetReader)
+		if phaseError != nil {
+			return nil, phaseError
+		}
 
 		targets = append(targets, BranchMigrationTarget{
-			RemoteName:         defaultRemoteName(remoteNameExists, remoteNameValue),
-			SourceBranch:       defaultSourceBranch(sourceExists, sourceBranchValue),
-			TargetBranch:       defaultTargetBranch(targetExists, targetBranchValue),
-			PushToRemote:       defaultPushToRemote(pushToRemoteExists, pushToRemoteValue),
-			DeleteSourceBranch: defaultDeleteSourceBranch(deleteSourceBranchExists, deleteSourceBranchValue),
+			RemoteName:            defaultRemoteName(remoteNameExists, remoteNameValue),
+			SourceBranch:          defaultSourceBranch(sourceExists, sourceBranchValue),
+			TargetBranch:          defaultTargetBranch(targetExists, targetBranchValue),
+			PushToRemote:          defaultPushToRemote(pushToRemoteExists, pushToRemoteValue),
+			DeleteSourceBranch:    defaultDeleteSourceBranch(deleteSourceBranchExists, deleteSourceBranchValue),
+			RetainSource:          strings.ToLower(retainSourceValue),
+			LeaveTombstone:        leaveTombstoneValue,
+			InstructionsDirectory: instructionsDirectoryValue,
+			UpdateDocumentation:   updateDocsValue,
+			DocumentationPatterns: docsPatternsValue,
+			Phase:                 phase,
+			GracePeriod:           gracePeriod,
 		})
 	}
 
@@ -170,7 +280,39 @@ func buildAuditReportOperation(options map[string]any) (Operation, error) {
 		return nil, outputError
 	}
 
-	return &AuditReportOperation{OutputPath: strings.TrimSpace(outputPath), WriteToFile: outputExists && len(strings.TrimSpace(outputPath)) > 0}, nil
+	failOnNested, _, failOnNestedError := reader.boolValue(optionFailOnNestedKeyConstant)
+	if failOnNestedError != nil {
+		return nil, failOnNestedError
+	}
+
+	sortValue, sortExists, sortError := reader.stringValue(optionSortKeyConstant)
+	if sortError != nil {
+		return nil, sortError
+	}
+	var sortOrder audit.ReportSortOrder
+	if sortExists && len(sortValue) > 0 {
+		parsedSortOrder, parseSortError := audit.ParseReportSortOrder(sortValue)
+		if parseSortError != nil {
+			return nil, parseSortError
+		}
+		sortOrder = parsedSortOrder
+	}
+
+	formatValue, _, formatError := reader.stringValue(optionReportFormatKeyConstant)
+	if formatError != nil {
+		return nil, formatError
+	}
+	reportFormat, parseFormatError := audit.ParseReportFormat(formatValue)
+	if parseFormatError != nil {
+		return nil, parseFormatError
+	}
+
+	minimumScore, minimumScoreError := readIntOption(options, optionMinimumScoreKeyConstant)
+	if minimumScoreError != nil {
+		return nil, minimumScoreError
+	}
+
+	return &AuditReportOperation{OutputPath: strings.TrimSpace(outputPath), WriteToFile: outputExists && len(strings.TrimSpace(outputPath)) > 0, FailOnNested: failOnNested, SortOrder: sortOrder, ReportFormat: reportFormat, MinimumScore: minimumScore}, nil
 }
 
 func parseProtocolValue(raw string) (shared.RemoteProtocol, error) {
@@ -0,0 +1,222 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const (
+	stepCommitModeCommitConstant            = "commit"
+	stepCommitModeStageConstant             = "stage"
+	optionCommitMessageKeyConstant          = "message"
+	defaultCollectedCommitMessageConstant   = "Apply workflow changes\n\n{{ range .Steps }}- {{ . }}\n{{ end }}"
+	commitLogPrefixApply                    = "COMMIT"
+	commitLogPrefixPlan                     = "PLAN-COMMIT"
+	commitLogPrefixSkip                     = "COMMIT-SKIP"
+	commitStepsSeparatorConstant            = ","
+	commitAppliedTemplateConstant           = "%s: %s steps=%s\n"
+	commitSkippedTemplateConstant           = "%s: %s %s\n"
+	commitNothingStagedReasonConstant       = "nothing staged"
+	commitNoContributionReasonConstant      = "no stage-only step staged changes"
+	commitDiffExitCodeChangedConstant       = 1
+	stepCommitModeInvalidTemplateConstant   = "workflow step %s has invalid commit mode %q (expected %q or %q)"
+	stepCommitModeStageOnlyTemplateConstant = "workflow step %s cannot stage changes for a deferred commit; only %s steps support commit: %s"
+	stageOnlyTaskPullRequestTemplate        = "task %q in a stage-only step cannot open a pull request"
+	stageOnlyTaskBranchTemplate             = "task %q in a stage-only step cannot create a branch; staged changes stay on the current branch"
+	commitStagedCheckErrorTemplateConstant  = "failed to inspect staged changes in %s: %w"
+	commitMessageRenderErrorTemplate        = "failed to render commit message for %s: %w"
+	commitCreateErrorTemplateConstant       = "failed to commit staged changes in %s: %w"
+	taskActionCommitStaged                  = "repo.commit.staged"
+)
+
+// CommitTemplateData exposes templating values for the commit step message. Steps lists the stage-only tasks that
+// staged changes in the repository, in the order they ran.
+type CommitTemplateData struct {
+	TaskTemplateData
+	Steps []string
+}
+
+// CommitOperation creates a single commit per repository from the changes staged by earlier stage-only steps.
+// Repositories with nothing staged are skipped.
+type CommitOperation struct {
+	MessageTemplate string
+}
+
+// Name identifies the operation type.
+func (operation *CommitOperation) Name() string {
+	return string(OperationTypeCommit)
+}
+
+func buildCommitOperation(options map[string]any) (Operation, error) {
+	reader := newOptionReader(options)
+	messageTemplate, messageExists, messageError := reader.stringValue(optionCommitMessageKeyConstant)
+	if messageError != nil {
+		return nil, messageError
+	}
+	if !messageExists || len(strings.TrimSpace(messageTemplate)) == 0 {
+		messageTemplate = defaultCollectedCommitMessageConstant
+	}
+	return &CommitOperation{MessageTemplate: messageTemplate}, nil
+}
+
+// applyStepCommitMode configures a step declared with commit: stage to stage its changes without committing them.
+func applyStepCommitMode(operation Operation, step StepConfiguration) error {
+	commitMode := strings.ToLower(strings.TrimSpace(step.Commit))
+	switch commitMode {
+	case "", stepCommitModeCommitConstant:
+		return nil
+	case stepCommitModeStageConstant:
+	default:
+		return fmt.Errorf(stepCommitModeInvalidTemplateConstant, step.Operation, step.Commit, stepCommitModeCommitConstant, stepCommitModeStageConstant)
+	}
+
+	taskOperation, isTaskOperation := operation.(*TaskOperation)
+	if !isTaskOperation {
+		return fmt.Errorf(stepCommitModeStageOnlyTemplateConstant, step.Operation, OperationTypeApplyTasks, stepCommitModeStageConstant)
+	}
+	for taskIndex := range taskOperation.tasks {
+		task := &taskOperation.tasks[taskIndex]
+		if task.PullRequest != nil {
+			return fmt.Errorf(stageOnlyTaskPullRequestTemplate, task.Name)
+		}
+		if len(strings.TrimSpace(task.Branch.NameTemplate)) > 0 || len(strings.TrimSpace(task.Branch.StartPointTemplate)) > 0 {
+			return fmt.Errorf(stageOnlyTaskBranchTemplate, task.Name)
+		}
+		task.StageOnly = true
+	}
+	return nil
+}
+
+// Execute commits the staged changes of every repository that a stage-only step contributed to.
+func (operation *CommitOperation) Execute(executionContext context.Context, environment *Environment, state *State) error {
+	if operation == nil || environment == nil || state == nil {
+		return nil
+	}
+
+	for _, repository := range state.Repositories {
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			return cancellationError
+		}
+		if repository == nil {
+			continue
+		}
+		if commitError := operation.commitRepository(executionContext, environment, repository); commitError != nil {
+			return commitError
+		}
+	}
+	return nil
+}
+
+func (operation *CommitOperation) commitRepository(executionContext context.Context, environment *Environment, repository *RepositoryState) error {
+	contributingSteps := environment.stagedContributions(repository.Path)
+	defer environment.clearStagedContributions(repository.Path)
+
+	if len(contributingSteps) == 0 {
+		environment.printCommitLine(commitSkippedTemplateConstant, commitLogPrefixSkip, repository.Path, commitNoContributionReasonConstant)
+		return nil
+	}
+
+	if environment.DryRun {
+		environment.printCommitLine(commitAppliedTemplateConstant, commitLogPrefixPlan, repository.Path, strings.Join(contributingSteps, commitStepsSeparatorConstant))
+		return nil
+	}
+
+	staged, stagedError := hasStagedChanges(executionContext, environment, repository.Path)
+	if stagedError != nil {
+		return fmt.Errorf(commitStagedCheckErrorTemplateConstant, repository.Path, stagedError)
+	}
+	if !staged {
+		environment.printCommitLine(commitSkippedTemplateConstant, commitLogPrefixSkip, repository.Path, commitNothingStagedReasonConstant)
+		return nil
+	}
+
+	commitMessage, renderError := renderCommitMessage(operation.MessageTemplate, CommitTemplateData{
+		TaskTemplateData: buildTaskTemplateData(repository, TaskDefinition{}),
+		Steps:            contributingSteps,
+	})
+	if renderError != nil {
+		return fmt.Errorf(commitMessageRenderErrorTemplate, repository.Path, renderError)
+	}
+
+	arguments := gitrepo.CommitArguments(gitrepo.CommitOptions{Message: commitMessage, NoVerify: !gitrepo.CommitHooksEnabled(executionContext)})
+	if _, commitError := environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: arguments, WorkingDirectory: repository.Path, Idempotent: false}); commitError != nil {
+		return fmt.Errorf(commitCreateErrorTemplateConstant, repository.Path, commitError)
+	}
+	environment.printCommitLine(commitAppliedTemplateConstant, commitLogPrefixApply, repository.Path, strings.Join(contributingSteps, commitStepsSeparatorConstant))
+	return nil
+}
+
+// handleCommitStagedAction runs the commit step for one repository when the workflow executes through task definitions.
+func handleCommitStagedAction(ctx context.Context, environment *Environment, repository *RepositoryState, parameters map[string]any) error {
+	messageTemplate, _, messageError := newOptionReader(parameters).stringValue(optionCommitMessageKeyConstant)
+	if messageError != nil {
+		return messageError
+	}
+	if len(strings.TrimSpace(messageTemplate)) == 0 {
+		messageTemplate = defaultCollectedCommitMessageConstant
+	}
+	operation := &CommitOperation{MessageTemplate: messageTemplate}
+	return operation.commitRepository(ctx, environment, repository)
+}
+
+func renderCommitMessage(messageTemplate string, data CommitTemplateData) (string, error) {
+	parsedTemplate, parseError := parseWorkflowTemplate(messageTemplate)
+	if parseError != nil {
+		return "", parseError
+	}
+	var buffer bytes.Buffer
+	if executeError := parsedTemplate.Execute(&buffer, data); executeError != nil {
+		return "", executeError
+	}
+	return strings.TrimSpace(buffer.String()), nil
+}
+
+// hasStagedChanges reports whether the index differs from HEAD; git diff --cached --quiet exits with 1 when it does.
+func hasStagedChanges(executionContext context.Context, environment *Environment, repositoryPath string) (bool, error) {
+	_, diffError := environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{
+		Arguments:        []string{"diff", "--cached", "--quiet"},
+		WorkingDirectory: repositoryPath,
+		Idempotent:       true,
+	})
+	if diffError == nil {
+		return false, nil
+	}
+	var commandFailure execshell.CommandFailedError
+	if errors.As(diffError, &commandFailure) && commandFailure.Result.ExitCode == commitDiffExitCodeChangedConstant {
+		return true, nil
+	}
+	return false, diffError
+}
+
+func (environment *Environment) recordStagedContribution(repositoryPath string, taskName string) {
+	if environment.stagedTaskNames == nil {
+		environment.stagedTaskNames = map[string][]string{}
+	}
+	for _, existingName := range environment.stagedTaskNames[repositoryPath] {
+		if existingName == taskName {
+			return
+		}
+	}
+	environment.stagedTaskNames[repositoryPath] = append(environment.stagedTaskNames[repositoryPath], taskName)
+}
+
+func (environment *Environment) stagedContributions(repositoryPath string) []string {
+	return append([]string(nil), environment.stagedTaskNames[repositoryPath]...)
+}
+
+func (environment *Environment) clearStagedContributions(repositoryPath string) {
+	delete(environment.stagedTaskNames, repositoryPath)
+}
+
+func (environment *Environment) printCommitLine(format string, arguments ...any) {
+	if environment.Output == nil {
+		return
+	}
+	fmt.Fprintf(environment.Output, format, arguments...)
+}
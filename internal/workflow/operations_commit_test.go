@@ -0,0 +1,163 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const commitTestRepositoryPathConstant = "/repositories/sample"
+
+func stageOnlyTaskStep(taskName string, filePath string) StepConfiguration {
+	return StepConfiguration{
+		Operation: OperationTypeApplyTasks,
+		Commit:    stepCommitModeStageConstant,
+		Options: map[string]any{
+			optionTasksKeyConstant: []any{
+				map[string]any{
+					optionTaskNameKeyConstant: taskName,
+					optionTaskFilesKeyConstant: []any{
+						map[string]any{optionTaskFilePathKeyConstant: filePath, optionTaskFileContentKeyConstant: taskName},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCommitStepAggregatesStagedSteps(testInstance *testing.T) {
+	testCases := []struct {
+		name                string
+		steps               []StepConfiguration
+		dryRun              bool
+		stagedChanges       bool
+		expectedOutput      string
+		expectedCommitSteps string
+	}{
+		{
+			name: "commits_contributing_steps_once",
+			steps: []StepConfiguration{
+				stageOnlyTaskStep("Add license", "LICENSE"),
+				stageOnlyTaskStep("Add notice", "NOTICE"),
+				{Operation: OperationTypeCommit, Options: map[string]any{optionCommitMessageKeyConstant: "chore({{ .Repository.Name }}): {{ range .Steps }}[{{ . }}]{{ end }}"}},
+			},
+			stagedChanges:       true,
+			expectedOutput:      "COMMIT: /repositories/sample steps=Add license,Add notice\n",
+			expectedCommitSteps: "chore(sample): [Add license][Add notice]",
+		},
+		{
+			name: "skips_repository_without_contributions",
+			steps: []StepConfiguration{
+				{Operation: OperationTypeCommit},
+			},
+			expectedOutput: "COMMIT-SKIP: /repositories/sample no stage-only step staged changes\n",
+		},
+		{
+			name: "skips_repository_with_nothing_staged",
+			steps: []StepConfiguration{
+				stageOnlyTaskStep("Add license", "LICENSE"),
+				{Operation: OperationTypeCommit},
+			},
+			expectedOutput: "COMMIT-SKIP: /repositories/sample nothing staged\n",
+		},
+		{
+			name: "dry_run_plans_commit",
+			steps: []StepConfiguration{
+				stageOnlyTaskStep("Add license", "LICENSE"),
+				{Operation: OperationTypeCommit},
+			},
+			dryRun:         true,
+			expectedOutput: "PLAN-COMMIT: /repositories/sample steps=Add license\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			operations, buildError := BuildOperations(Configuration{Steps: testCase.steps})
+			require.NoError(subtest, buildError)
+
+			gitExecutor := execshelltest.NewPermissiveExecutor()
+			if testCase.stagedChanges {
+				gitExecutor.OnGit("diff", "--cached", "--quiet").FailWith(commitDiffExitCodeChangedConstant, "")
+			}
+			repositoryManager, managerError := gitrepo.NewRepositoryManager(gitExecutor)
+			require.NoError(subtest, managerError)
+			outputBuffer := &bytes.Buffer{}
+			environment := &Environment{GitExecutor: gitExecutor, RepositoryManager: repositoryManager, FileSystem: newFakeFileSystem(nil), Output: outputBuffer, DryRun: testCase.dryRun}
+			repository := NewRepositoryState(audit.RepositoryInspection{Path: commitTestRepositoryPathConstant, FinalOwnerRepo: "octocat/sample", RemoteDefaultBranch: "main"})
+			state := &State{Repositories: []*RepositoryState{repository}}
+
+			for _, operation := range operations {
+				outputBuffer.Reset()
+				require.NoError(subtest, operation.Execute(context.Background(), environment, state))
+			}
+
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+			require.Empty(subtest, environment.stagedContributions(commitTestRepositoryPathConstant))
+
+			executedArguments := gitExecutor.ExecutedArguments(execshell.CommandGit)
+			commitArguments := make([][]string, 0, 1)
+			for _, arguments := range executedArguments {
+				require.NotEqual(subtest, "checkout", arguments[0])
+				require.NotEqual(subtest, "push", arguments[0])
+				if arguments[0] == "commit" {
+					commitArguments = append(commitArguments, arguments)
+				}
+			}
+			if len(testCase.expectedCommitSteps) == 0 {
+				require.Empty(subtest, commitArguments)
+				return
+			}
+			expectedCommitArguments := gitrepo.CommitArguments(gitrepo.CommitOptions{Message: testCase.expectedCommitSteps, NoVerify: !gitrepo.CommitHooksEnabled(context.Background())})
+			require.Equal(subtest, [][]string{expectedCommitArguments}, commitArguments)
+			require.Contains(subtest, executedArguments, []string{"add", "LICENSE"})
+			require.Contains(subtest, executedArguments, []string{"add", "NOTICE"})
+		})
+	}
+}
+
+func TestStepCommitModeValidation(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		step          StepConfiguration
+		expectedError string
+	}{
+		{
+			name:          "rejects_unknown_mode",
+			step:          StepConfiguration{Operation: OperationTypeApplyTasks, Commit: "later", Options: stageOnlyTaskStep("Add license", "LICENSE").Options},
+			expectedError: `workflow step apply-tasks has invalid commit mode "later" (expected "commit" or "stage")`,
+		},
+		{
+			name:          "rejects_stage_on_non_task_step",
+			step:          StepConfiguration{Operation: OperationTypeAuditReport, Commit: stepCommitModeStageConstant},
+			expectedError: "workflow step audit-report cannot stage changes for a deferred commit; only apply-tasks steps support commit: stage",
+		},
+		{
+			name: "rejects_stage_with_pull_request",
+			step: StepConfiguration{Operation: OperationTypeApplyTasks, Commit: stepCommitModeStageConstant, Options: map[string]any{
+				optionTasksKeyConstant: []any{map[string]any{
+					optionTaskNameKeyConstant:        "Add license",
+					optionTaskFilesKeyConstant:       []any{map[string]any{optionTaskFilePathKeyConstant: "LICENSE", optionTaskFileContentKeyConstant: "MIT"}},
+					optionTaskPullRequestKeyConstant: map[string]any{optionTaskPRTitleKeyConstant: "Add license"},
+				}},
+			}},
+			expectedError: `task "Add license" in a stage-only step cannot open a pull request`,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			_, buildError := BuildOperations(Configuration{Steps: []StepConfiguration{testCase.step}})
+			require.EqualError(subtest, buildError, testCase.expectedError)
+		})
+	}
+}
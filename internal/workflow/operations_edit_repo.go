@@ -0,0 +1,233 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const (
+	taskActionEditRepository                 = "repo.edit"
+	editRepositoryPlanMessageTemplate        = "EDIT-REPO-PLAN: %s (%s) %s\n"
+	editRepositoryApplyMessageTemplate       = "EDIT-REPO-APPLY: %s (%s) %s\n"
+	editRepositoryNoopMessageTemplate        = "EDIT-REPO-NOOP: %s (%s) already up to date\n"
+	editRepositorySkipMessageTemplate        = "EDIT-REPO-SKIP: %s reason=no GitHub repository\n"
+	editRepositoryAddTopicTemplate           = "+topic:%s"
+	editRepositoryRemoveTopicTemplate        = "-topic:%s"
+	editRepositoryDescriptionTemplate        = "description:%s -> %s"
+	editRepositoryDeltaSeparator             = " "
+	editRepositoryErrorTemplate              = "edit-repo: %w"
+	editRepositoryMissingChangesMessage      = "edit-repo step requires add_topics, remove_topics, or description"
+	editRepositoryConflictingTopicTemplate   = "edit-repo step cannot both add and remove topic %q"
+	editRepositoryMissingGitHubClientMessage = "edit-repo step requires a GitHub client"
+)
+
+// EditRepositoryOperation adds and removes GitHub topics and updates the repository description.
+// Only the difference between the current and desired state is applied; repositories that already match are left untouched.
+type EditRepositoryOperation struct {
+	AddTopics         []string
+	RemoveTopics      []string
+	Description       string
+	UpdateDescription bool
+}
+
+type repositoryEditDelta struct {
+	addTopics          []string
+	removeTopics       []string
+	currentDescription string
+	desiredDescription string
+	updateDescription  bool
+}
+
+// Name identifies the operation type.
+func (operation *EditRepositoryOperation) Name() string {
+	return string(OperationTypeEditRepository)
+}
+
+// Execute compares each repository's topics and description with the desired state and applies the difference.
+func (operation *EditRepositoryOperation) Execute(executionContext context.Context, environment *Environment, state *State) error {
+	if environment == nil || state == nil {
+		return nil
+	}
+
+	for _, repository := range state.Repositories {
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			return cancellationError
+		}
+		if repository == nil {
+			continue
+		}
+
+		repositoryIdentifier := editRepositoryIdentifier(repository)
+		if len(repositoryIdentifier) == 0 {
+			if environment.Output != nil {
+				fmt.Fprintf(environment.Output, editRepositorySkipMessageTemplate, repository.Path)
+			}
+			continue
+		}
+
+		if environment.GitHubClient == nil {
+			return errors.New(editRepositoryMissingGitHubClientMessage)
+		}
+
+		currentSettings, settingsError := environment.GitHubClient.GetRepositorySettings(executionContext, repositoryIdentifier)
+		if settingsError != nil {
+			return fmt.Errorf(editRepositoryErrorTemplate, settingsError)
+		}
+
+		delta := operation.deltaFrom(currentSettings)
+		if delta.isEmpty() {
+			if environment.Output != nil {
+				fmt.Fprintf(environment.Output, editRepositoryNoopMessageTemplate, repository.Path, repositoryIdentifier)
+			}
+			continue
+		}
+
+		if environment.DryRun {
+			if environment.Output != nil {
+				fmt.Fprintf(environment.Output, editRepositoryPlanMessageTemplate, repository.Path, repositoryIdentifier, delta.describe())
+			}
+			continue
+		}
+
+		editOptions := githubcli.RepositoryEditOptions{
+			AddTopics:         delta.addTopics,
+			RemoveTopics:      delta.removeTopics,
+			Description:       delta.desiredDescription,
+			UpdateDescription: delta.updateDescription,
+		}
+		if editError := environment.GitHubClient.EditRepository(executionContext, repositoryIdentifier, editOptions); editError != nil {
+			return fmt.Errorf(editRepositoryErrorTemplate, editError)
+		}
+
+		if environment.Output != nil {
+			fmt.Fprintf(environment.Output, editRepositoryApplyMessageTemplate, repository.Path, repositoryIdentifier, delta.describe())
+		}
+	}
+
+	return nil
+}
+
+func (operation *EditRepositoryOperation) deltaFrom(current githubcli.RepositorySettings) repositoryEditDelta {
+	currentTopics := make(map[string]struct{}, len(current.Topics))
+	for _, topic := range current.Topics {
+		currentTopics[normalizeRepositoryTopic(topic)] = struct{}{}
+	}
+
+	delta := repositoryEditDelta{currentDescription: current.Description}
+	for _, topic := range operation.AddTopics {
+		if _, present := currentTopics[topic]; !present {
+			delta.addTopics = append(delta.addTopics, topic)
+		}
+	}
+	for _, topic := range operation.RemoveTopics {
+		if _, present := currentTopics[topic]; present {
+			delta.removeTopics = append(delta.removeTopics, topic)
+		}
+	}
+	if operation.UpdateDescription && strings.TrimSpace(current.Description) != operation.Description {
+		delta.desiredDescription = operation.Description
+		delta.updateDescription = true
+	}
+	return delta
+}
+
+func (delta repositoryEditDelta) isEmpty() bool {
+	return len(delta.addTopics) == 0 && len(delta.removeTopics) == 0 && !delta.updateDescription
+}
+
+func (delta repositoryEditDelta) describe() string {
+	changes := make([]string, 0, len(delta.addTopics)+len(delta.removeTopics)+1)
+	for _, topic := range delta.addTopics {
+		changes = append(changes, fmt.Sprintf(editRepositoryAddTopicTemplate, topic))
+	}
+	for _, topic := range delta.removeTopics {
+		changes = append(changes, fmt.Sprintf(editRepositoryRemoveTopicTemplate, topic))
+	}
+	if delta.updateDescription {
+		changes = append(changes, fmt.Sprintf(editRepositoryDescriptionTemplate, strconv.Quote(delta.currentDescription), strconv.Quote(delta.desiredDescription)))
+	}
+	return strings.Join(changes, editRepositoryDeltaSeparator)
+}
+
+func buildEditRepositoryOperation(options map[string]any) (Operation, error) {
+	reader := newOptionReader(options)
+
+	addTopics, _, addTopicsError := reader.stringSliceValue(optionAddTopicsKeyConstant)
+	if addTopicsError != nil {
+		return nil, addTopicsError
+	}
+	removeTopics, _, removeTopicsError := reader.stringSliceValue(optionRemoveTopicsKeyConstant)
+	if removeTopicsError != nil {
+		return nil, removeTopicsError
+	}
+	description, descriptionExists, descriptionError := reader.stringValue(optionDescriptionKeyConstant)
+	if descriptionError != nil {
+		return nil, descriptionError
+	}
+
+	operation := &EditRepositoryOperation{
+		AddTopics:         normalizeRepositoryTopics(addTopics),
+		RemoveTopics:      normalizeRepositoryTopics(removeTopics),
+		Description:       description,
+		UpdateDescription: descriptionExists,
+	}
+	if len(operation.AddTopics) == 0 && len(operation.RemoveTopics) == 0 && !operation.UpdateDescription {
+		return nil, errors.New(editRepositoryMissingChangesMessage)
+	}
+
+	addedTopics := make(map[string]struct{}, len(operation.AddTopics))
+	for _, topic := range operation.AddTopics {
+		addedTopics[topic] = struct{}{}
+	}
+	for _, topic := range operation.RemoveTopics {
+		if _, added := addedTopics[topic]; added {
+			return nil, fmt.Errorf(editRepositoryConflictingTopicTemplate, topic)
+		}
+	}
+
+	return operation, nil
+}
+
+func handleEditRepositoryAction(ctx context.Context, environment *Environment, repository *RepositoryState, parameters map[string]any) error {
+	operation, operationError := buildEditRepositoryOperation(parameters)
+	if operationError != nil {
+		return operationError
+	}
+	state := &State{Repositories: []*RepositoryState{repository}}
+	return operation.Execute(ctx, environment, state)
+}
+
+func editRepositoryIdentifier(repository *RepositoryState) string {
+	for _, candidate := range []string{repository.Inspection.FinalOwnerRepo, repository.Inspection.CanonicalOwnerRepo, repository.Inspection.OriginOwnerRepo} {
+		if trimmed := strings.TrimSpace(candidate); len(trimmed) > 0 {
+			return trimmed
+		}
+	}
+	return ""
+}
+
+func normalizeRepositoryTopics(topics []string) []string {
+	normalized := make([]string, 0, len(topics))
+	seen := make(map[string]struct{}, len(topics))
+	for _, topic := range topics {
+		normalizedTopic := normalizeRepositoryTopic(topic)
+		if len(normalizedTopic) == 0 {
+			continue
+		}
+		if _, duplicate := seen[normalizedTopic]; duplicate {
+			continue
+		}
+		seen[normalizedTopic] = struct{}{}
+		normalized = append(normalized, normalizedTopic)
+	}
+	return normalized
+}
+
+func normalizeRepositoryTopic(topic string) string {
+	return strings.ToLower(strings.TrimSpace(topic))
+}
@@ -0,0 +1,116 @@
+package workflow
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const editRepositoryCurrentSettingsConstant = `{"description":"Old tools","repositoryTopics":[{"name":"go"},{"name":"legacy"}]}`
+
+type editRepositoryExecutor struct {
+	recordedArguments [][]string
+}
+
+func (executor *editRepositoryExecutor) ExecuteGitHubCLI(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	executor.recordedArguments = append(executor.recordedArguments, details.Arguments)
+	if len(details.Arguments) >= 2 && details.Arguments[1] == "view" {
+		return execshell.ExecutionResult{StandardOutput: editRepositoryCurrentSettingsConstant}, nil
+	}
+	return execshell.ExecutionResult{}, nil
+}
+
+func TestEditRepositoryOperationAppliesDelta(testInstance *testing.T) {
+	testCases := []struct {
+		name              string
+		options           map[string]any
+		dryRun            bool
+		expectedOutput    string
+		expectedArguments [][]string
+	}{
+		{
+			name:           "dry_run_prints_delta",
+			options:        map[string]any{"add_topics": []any{"go", "CLI"}, "remove_topics": []any{"legacy", "absent"}, "description": "New tools"},
+			dryRun:         true,
+			expectedOutput: "EDIT-REPO-PLAN: /repositories/example (owner/example) +topic:cli -topic:legacy description:\"Old tools\" -> \"New tools\"\n",
+			expectedArguments: [][]string{
+				{"repo", "view", "owner/example", "--json", "description,repositoryTopics"},
+			},
+		},
+		{
+			name:           "applies_only_changes",
+			options:        map[string]any{"add_topics": []any{"go", "cli"}, "description": "Old tools"},
+			expectedOutput: "EDIT-REPO-APPLY: /repositories/example (owner/example) +topic:cli\n",
+			expectedArguments: [][]string{
+				{"repo", "view", "owner/example", "--json", "description,repositoryTopics"},
+				{"repo", "edit", "owner/example", "--add-topic", "cli"},
+			},
+		},
+		{
+			name:           "noop_when_state_matches",
+			options:        map[string]any{"add_topics": "go", "remove_topics": []any{"absent"}, "description": "Old tools"},
+			expectedOutput: "EDIT-REPO-NOOP: /repositories/example (owner/example) already up to date\n",
+			expectedArguments: [][]string{
+				{"repo", "view", "owner/example", "--json", "description,repositoryTopics"},
+			},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			executor := &editRepositoryExecutor{}
+			githubClient, clientError := githubcli.NewClient(executor)
+			require.NoError(subtest, clientError)
+
+			outputBuffer := &strings.Builder{}
+			environment := &Environment{GitHubClient: githubClient, Output: outputBuffer, DryRun: testCase.dryRun}
+			repository := &RepositoryState{
+				Path:       "/repositories/example",
+				Inspection: audit.RepositoryInspection{FinalOwnerRepo: "owner/example"},
+			}
+
+			require.NoError(subtest, handleEditRepositoryAction(context.Background(), environment, repository, testCase.options))
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+			require.Equal(subtest, testCase.expectedArguments, executor.recordedArguments)
+		})
+	}
+}
+
+func TestBuildEditRepositoryOperationValidation(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		options       map[string]any
+		expectedError string
+	}{
+		{
+			name:          "requires_changes",
+			options:       map[string]any{},
+			expectedError: editRepositoryMissingChangesMessage,
+		},
+		{
+			name:          "conflicting_topics",
+			options:       map[string]any{"add_topics": []any{"Go"}, "remove_topics": []any{"go"}},
+			expectedError: `edit-repo step cannot both add and remove topic "go"`,
+		},
+		{
+			name:          "non_string_topics",
+			options:       map[string]any{"add_topics": []any{1}},
+			expectedError: "option add_topics entries must be strings",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			_, buildError := buildEditRepositoryOperation(testCase.options)
+			require.EqualError(subtest, buildError, testCase.expectedError)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const (
+	workflowEnvironmentLocationConstant        = "env"
+	stepEnvironmentKeyInvalidTemplateConstant  = "workflow step %s has an invalid env name %q"
+	stepEnvironmentRenderErrorTemplateConstant = "workflow step %s could not render env %s for %s: %w"
+	environmentVariableNameForbiddenCharacters = "= \t\n"
+)
+
+// EnvironmentOperation runs a workflow operation with the step's environment variables added to every command it
+// executes. Values are templates rendered against each repository's facts; ${NAME} references are resolved by the
+// executor when a command starts, so secrets never appear in the rendered step environment.
+type EnvironmentOperation struct {
+	operation   Operation
+	environment map[string]string
+}
+
+func applyStepEnvironment(operation Operation, workflowEnvironment map[string]string, step StepConfiguration) (Operation, error) {
+	environment := mergeStepEnvironment(workflowEnvironment, step.Env)
+	if len(environment) == 0 {
+		return operation, nil
+	}
+	for environmentKey := range environment {
+		if len(strings.TrimSpace(environmentKey)) == 0 || strings.ContainsAny(environmentKey, environmentVariableNameForbiddenCharacters) {
+			return nil, fmt.Errorf(stepEnvironmentKeyInvalidTemplateConstant, step.Operation, environmentKey)
+		}
+	}
+	if templateError := validateTemplateValue(string(step.Operation), workflowEnvironmentLocationConstant, stringMapToAny(environment)); templateError != nil {
+		return nil, templateError
+	}
+	return &EnvironmentOperation{operation: operation, environment: environment}, nil
+}
+
+// mergeStepEnvironment layers the step env over the workflow env; step values win.
+func mergeStepEnvironment(workflowEnvironment map[string]string, stepEnvironment map[string]string) map[string]string {
+	if len(workflowEnvironment) == 0 && len(stepEnvironment) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(workflowEnvironment)+len(stepEnvironment))
+	for environmentKey, environmentValue := range workflowEnvironment {
+		merged[environmentKey] = environmentValue
+	}
+	for environmentKey, environmentValue := range stepEnvironment {
+		merged[environmentKey] = environmentValue
+	}
+	return merged
+}
+
+// Name returns the wrapped operation name.
+func (operation *EnvironmentOperation) Name() string {
+	return operation.operation.Name()
+}
+
+// Unwrap returns the operation the environment applies to.
+func (operation *EnvironmentOperation) Unwrap() Operation {
+	return operation.operation
+}
+
+// Environment returns a copy of the merged workflow and step environment templates.
+func (operation *EnvironmentOperation) Environment() map[string]string {
+	return mergeStepEnvironment(nil, operation.environment)
+}
+
+// Execute runs the wrapped operation with the environment attached to the context. Templated values need repository
+// facts, so the operation then runs once per repository.
+func (operation *EnvironmentOperation) Execute(executionContext context.Context, environment *Environment, state *State) error {
+	if state == nil || !environmentHasTemplates(operation.environment) {
+		return operation.operation.Execute(execshell.WithEnvironment(executionContext, operation.environment), environment, state)
+	}
+
+	for _, repository := range state.Repositories {
+		if repository == nil {
+			continue
+		}
+		renderedEnvironment, renderError := renderStepEnvironment(operation.Name(), operation.environment, repository)
+		if renderError != nil {
+			return renderError
+		}
+		repositoryState := &State{Roots: state.Roots, Repositories: []*RepositoryState{repository}}
+		if executionError := executeWithState(execshell.WithEnvironment(executionContext, renderedEnvironment), operation.operation, environment, repositoryState); executionError != nil {
+			return executionError
+		}
+	}
+	return nil
+}
+
+func executeWithState(executionContext context.Context, operation Operation, environment *Environment, state *State) error {
+	if environment == nil {
+		return operation.Execute(executionContext, environment, state)
+	}
+	previousState := environment.State
+	environment.State = state
+	defer func() {
+		environment.State = previousState
+	}()
+	return operation.Execute(executionContext, environment, state)
+}
+
+// renderStepEnvironment renders templated environment values against the repository facts available to task templates.
+func renderStepEnvironment(stepName string, environmentTemplates map[string]string, repository *RepositoryState) (map[string]string, error) {
+	if len(environmentTemplates) == 0 {
+		return nil, nil
+	}
+	templateData := buildTaskTemplateData(repository, TaskDefinition{})
+	rendered := make(map[string]string, len(environmentTemplates))
+	environmentKeys := make([]string, 0, len(environmentTemplates))
+	for environmentKey := range environmentTemplates {
+		environmentKeys = append(environmentKeys, environmentKey)
+	}
+	sort.Strings(environmentKeys)
+	for _, environmentKey := range environmentKeys {
+		environmentValue := environmentTemplates[environmentKey]
+		if !containsWorkflowTemplate(environmentValue) {
+			rendered[environmentKey] = environmentValue
+			continue
+		}
+		parsedTemplate, parseError := parseWorkflowTemplate(environmentValue)
+		if parseError != nil {
+			return nil, fmt.Errorf(stepEnvironmentRenderErrorTemplateConstant, stepName, environmentKey, repository.Path, parseError)
+		}
+		var buffer bytes.Buffer
+		if executeError := parsedTemplate.Execute(&buffer, templateData); executeError != nil {
+			return nil, fmt.Errorf(stepEnvironmentRenderErrorTemplateConstant, stepName, environmentKey, repository.Path, executeError)
+		}
+		rendered[environmentKey] = buffer.String()
+	}
+	return rendered, nil
+}
+
+func environmentHasTemplates(environment map[string]string) bool {
+	for _, environmentValue := range environment {
+		if containsWorkflowTemplate(environmentValue) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringMapToAny(values map[string]string) map[string]any {
+	converted := make(map[string]any, len(values))
+	for key, value := range values {
+		converted[key] = value
+	}
+	return converted
+}
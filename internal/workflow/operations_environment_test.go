@@ -0,0 +1,105 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/execshell"
+)
+
+const testEnvironmentActionTypeConstant = "test.action.environment"
+
+func TestLoadConfigurationEnvironment(testInstance *testing.T) {
+	contents := "env:\n  HTTPS_PROXY: http://proxy.internal:3128\n  GIT_SSH_COMMAND: ssh -o BatchMode=yes\nworkflow:\n  - step:\n      operation: audit-report\n      env:\n        GIT_SSH_COMMAND: ssh -i ~/.ssh/{{ .Name }}\n        GH_TOKEN: ${CI_PACKAGES_TOKEN}\n"
+	configurationPath := filepath.Join(testInstance.TempDir(), "workflow.yaml")
+	require.NoError(testInstance, os.WriteFile(configurationPath, []byte(contents), 0o600))
+
+	configuration, loadError := LoadConfiguration(configurationPath)
+	require.NoError(testInstance, loadError)
+	require.Equal(testInstance, map[string]string{"HTTPS_PROXY": "http://proxy.internal:3128", "GIT_SSH_COMMAND": "ssh -o BatchMode=yes"}, configuration.Environment)
+
+	operations, buildError := BuildOperations(configuration)
+	require.NoError(testInstance, buildError)
+	require.Len(testInstance, operations, 1)
+
+	operation := operations[0]
+	if timedOperation, isTimed := operation.(*TimedOperation); isTimed {
+		operation = timedOperation.Unwrap()
+	}
+	environmentOperation, hasEnvironment := operation.(*EnvironmentOperation)
+	require.True(testInstance, hasEnvironment)
+	require.Equal(testInstance, map[string]string{
+		"HTTPS_PROXY":     "http://proxy.internal:3128",
+		"GIT_SSH_COMMAND": "ssh -i ~/.ssh/{{ .Name }}",
+		"GH_TOKEN":        "${CI_PACKAGES_TOKEN}",
+	}, environmentOperation.Environment())
+}
+
+func TestApplyStepEnvironmentValidation(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		workflowEnv     map[string]string
+		stepEnv         map[string]string
+		expectedWrapped bool
+		expectedError   string
+	}{
+		{name: "no_environment"},
+		{name: "workflow_only", workflowEnv: map[string]string{"HTTPS_PROXY": "http://proxy"}, expectedWrapped: true},
+		{name: "invalid_name", stepEnv: map[string]string{"BAD=NAME": "value"}, expectedError: `workflow step apply-tasks has an invalid env name "BAD=NAME"`},
+		{name: "invalid_template", stepEnv: map[string]string{"GIT_DIR": "{{ .Path "}, expectedError: "workflow step apply-tasks has an invalid template at env.GIT_DIR"},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			operation, wrapError := applyStepEnvironment(&blockingOperation{}, testCase.workflowEnv, StepConfiguration{Operation: OperationTypeApplyTasks, Env: testCase.stepEnv})
+			if len(testCase.expectedError) > 0 {
+				require.ErrorContains(subtest, wrapError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, wrapError)
+			_, isWrapped := operation.(*EnvironmentOperation)
+			require.Equal(subtest, testCase.expectedWrapped, isWrapped)
+		})
+	}
+}
+
+func TestTaskOperationAttachesRenderedEnvironment(testInstance *testing.T) {
+	originalHandler, handlerExists := taskActionHandlers[testEnvironmentActionTypeConstant]
+	observedEnvironments := map[string]map[string]string{}
+	RegisterTaskAction(testEnvironmentActionTypeConstant, func(executionContext context.Context, _ *Environment, repository *RepositoryState, _ map[string]any) error {
+		observedEnvironment, _ := execshell.EnvironmentFromContext(executionContext)
+		observedEnvironments[repository.Path] = observedEnvironment
+		return nil
+	})
+	defer func() {
+		if handlerExists {
+			taskActionHandlers[testEnvironmentActionTypeConstant] = originalHandler
+		} else {
+			delete(taskActionHandlers, testEnvironmentActionTypeConstant)
+		}
+	}()
+
+	operation := &TaskOperation{tasks: []TaskDefinition{{
+		Name:        "Verify protocol",
+		Actions:     []TaskActionDefinition{{Type: testEnvironmentActionTypeConstant}},
+		Environment: map[string]string{"GIT_SSH_COMMAND": "ssh -i /keys/{{ .Repository.Name }}", "GH_TOKEN": "${CI_TOKEN}"},
+	}}}
+	environment := &Environment{Output: &bytes.Buffer{}, DryRun: true}
+	state := &State{Repositories: []*RepositoryState{
+		NewRepositoryState(audit.RepositoryInspection{Path: "/repositories/alpha", FinalOwnerRepo: "acme/alpha"}),
+		NewRepositoryState(audit.RepositoryInspection{Path: "/repositories/beta", FinalOwnerRepo: "acme/beta"}),
+	}}
+
+	require.NoError(testInstance, operation.Execute(context.Background(), environment, state))
+	require.Equal(testInstance, map[string]map[string]string{
+		"/repositories/alpha": {"GIT_SSH_COMMAND": "ssh -i /keys/alpha", "GH_TOKEN": "${CI_TOKEN}"},
+		"/repositories/beta":  {"GIT_SSH_COMMAND": "ssh -i /keys/beta", "GH_TOKEN": "${CI_TOKEN}"},
+	}, observedEnvironments)
+}
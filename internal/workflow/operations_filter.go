@@ -0,0 +1,103 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/temirov/gix/internal/repos/selection"
+)
+
+const (
+	taskLogPrefixFiltered                        = "TASK-FILTERED"
+	stepFilterErrorTemplateConstant              = "workflow step %s has invalid only/skip filters: %w"
+	stepFilterSkipMessageTemplateConstant        = "%s: %s %s excluded by only/skip filters\n"
+	stepFilterSkipSummaryMessageTemplateConstant = "WORKFLOW-FILTER: %d repository step(s) skipped by only/skip filters\n"
+)
+
+// FilteredOperation restricts a workflow operation to repositories selected by the step's only/skip filters.
+type FilteredOperation struct {
+	operation Operation
+	matcher   selection.Matcher
+}
+
+func applyStepFilters(operation Operation, step StepConfiguration) (Operation, error) {
+	matcher, matcherError := selection.NewMatcher(step.Only, step.Skip)
+	if matcherError != nil {
+		return nil, fmt.Errorf(stepFilterErrorTemplateConstant, step.Operation, matcherError)
+	}
+	if matcher.Empty() {
+		return operation, nil
+	}
+	return &FilteredOperation{operation: operation, matcher: matcher}, nil
+}
+
+// Name returns the wrapped operation name.
+func (operation *FilteredOperation) Name() string {
+	return operation.operation.Name()
+}
+
+// Unwrap returns the operation restricted by the filters.
+func (operation *FilteredOperation) Unwrap() Operation {
+	return operation.operation
+}
+
+// Filter returns the repository matcher built from the step's only/skip lists.
+func (operation *FilteredOperation) Filter() selection.Matcher {
+	return operation.matcher
+}
+
+// Execute runs the wrapped operation against the repositories selected by the step filters.
+func (operation *FilteredOperation) Execute(executionContext context.Context, environment *Environment, state *State) error {
+	if state == nil {
+		return operation.operation.Execute(executionContext, environment, state)
+	}
+
+	selectedRepositories := make([]*RepositoryState, 0, len(state.Repositories))
+	for _, repository := range state.Repositories {
+		if repository == nil {
+			continue
+		}
+		if !repositoryMatchesFilter(operation.matcher, repository) {
+			recordFilterSkip(environment, operation.Name(), repository)
+			continue
+		}
+		selectedRepositories = append(selectedRepositories, repository)
+	}
+
+	filteredState := &State{Roots: state.Roots, Repositories: selectedRepositories}
+	if environment == nil {
+		return operation.operation.Execute(executionContext, environment, filteredState)
+	}
+
+	previousState := environment.State
+	environment.State = filteredState
+	defer func() {
+		environment.State = previousState
+	}()
+	return operation.operation.Execute(executionContext, environment, filteredState)
+}
+
+func repositoryMatchesFilter(matcher selection.Matcher, repository *RepositoryState) bool {
+	if matcher.Empty() {
+		return true
+	}
+	inspection := repository.Inspection
+	return matcher.Matches(selection.RepositoryCandidates(repository.Path, inspection.FinalOwnerRepo, inspection.CanonicalOwnerRepo, inspection.OriginOwnerRepo)...)
+}
+
+func recordFilterSkip(environment *Environment, stepName string, repository *RepositoryState) {
+	if environment == nil {
+		return
+	}
+	environment.filterSkippedRepositories++
+	if environment.Output != nil {
+		fmt.Fprintf(environment.Output, stepFilterSkipMessageTemplateConstant, taskLogPrefixFiltered, stepName, repository.Path)
+	}
+}
+
+func reportFilterSkipSummary(environment *Environment) {
+	if environment == nil || environment.Output == nil || environment.filterSkippedRepositories == 0 {
+		return
+	}
+	fmt.Fprintf(environment.Output, stepFilterSkipSummaryMessageTemplateConstant, environment.filterSkippedRepositories)
+}
@@ -0,0 +1,104 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/repos/selection"
+)
+
+type recordingOperation struct {
+	receivedPaths []string
+}
+
+func (operation *recordingOperation) Name() string {
+	return "recording"
+}
+
+func (operation *recordingOperation) Execute(_ context.Context, _ *Environment, state *State) error {
+	for _, repository := range state.Repositories {
+		operation.receivedPaths = append(operation.receivedPaths, repository.Path)
+	}
+	return nil
+}
+
+func TestFilteredOperationRestrictsRepositories(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		step          StepConfiguration
+		expectedPaths []string
+		expectedSkips int
+	}{
+		{
+			name:          "without_filters_runs_everywhere",
+			step:          StepConfiguration{Operation: "recording"},
+			expectedPaths: []string{"/repositories/alpha", "/repositories/beta"},
+		},
+		{
+			name:          "only_matches_owner_repository",
+			step:          StepConfiguration{Operation: "recording", Only: []string{"legacy/*"}},
+			expectedPaths: []string{"/repositories/alpha"},
+			expectedSkips: 1,
+		},
+		{
+			name:          "skip_matches_path",
+			step:          StepConfiguration{Operation: "recording", Skip: []string{"/repositories/alpha"}},
+			expectedPaths: []string{"/repositories/beta"},
+			expectedSkips: 1,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			inner := &recordingOperation{}
+			operation, filterError := applyStepFilters(inner, testCase.step)
+			require.NoError(subtest, filterError)
+
+			output := &bytes.Buffer{}
+			state := &State{Repositories: []*RepositoryState{
+				NewRepositoryState(audit.RepositoryInspection{Path: "/repositories/alpha", FinalOwnerRepo: "legacy/alpha"}),
+				NewRepositoryState(audit.RepositoryInspection{Path: "/repositories/beta", FinalOwnerRepo: "modern/beta"}),
+			}}
+			environment := &Environment{Output: output, State: state}
+
+			require.NoError(subtest, operation.Execute(context.Background(), environment, state))
+			require.Equal(subtest, testCase.expectedPaths, inner.receivedPaths)
+			require.Equal(subtest, testCase.expectedSkips, environment.filterSkippedRepositories)
+			require.Same(subtest, state, environment.State)
+			if testCase.expectedSkips > 0 {
+				require.Contains(subtest, output.String(), taskLogPrefixFiltered+": recording ")
+			}
+		})
+	}
+}
+
+func TestTaskOperationRecordsFilterSkipsSeparately(testInstance *testing.T) {
+	matcher, matcherError := selection.NewMatcher([]string{"legacy/*"}, nil)
+	require.NoError(testInstance, matcherError)
+
+	output := &bytes.Buffer{}
+	environment := &Environment{Output: output}
+	state := &State{Repositories: []*RepositoryState{
+		NewRepositoryState(audit.RepositoryInspection{Path: "/repositories/beta", FinalOwnerRepo: "modern/beta"}),
+	}}
+	operation := &TaskOperation{tasks: []TaskDefinition{{Name: "Convert", RepositoryFilter: matcher}}}
+
+	require.NoError(testInstance, operation.Execute(context.Background(), environment, state))
+	require.Equal(testInstance, "TASK-FILTERED: Convert /repositories/beta excluded by only/skip filters\n", output.String())
+	require.NotContains(testInstance, output.String(), taskLogPrefixSkip)
+	require.Equal(testInstance, 1, environment.filterSkippedRepositories)
+}
+
+func TestBuildOperationsRejectsInvalidStepFilter(testInstance *testing.T) {
+	_, buildError := BuildOperations(Configuration{Steps: []StepConfiguration{{
+		Operation: OperationTypeCanonicalRemote,
+		Only:      []string{"["},
+	}}})
+	require.Error(testInstance, buildError)
+	require.Contains(testInstance, buildError.Error(), "invalid only/skip filters")
+}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	migrate "github.com/temirov/gix/internal/migrate"
+	"github.com/temirov/gix/internal/repos/selection"
+	"github.com/temirov/gix/internal/repos/shared"
 )
 
 const (
@@ -17,12 +20,27 @@ const (
 	migrationSuccessMessageTemplateConstant            = "WORKFLOW-DEFAULT: %s (%s → %s) safe_to_delete=%t\n"
 	migrationIdentifierMissingMessageConstant          = "repository identifier unavailable for default-branch target"
 	migrationExecutionErrorTemplateConstant            = "default branch update failed: %w"
+	migrationPlanErrorTemplateConstant                 = "default branch plan failed: %w"
 	migrationRefreshErrorTemplateConstant              = "failed to refresh repository after default branch update: %w"
 	migrationDependenciesMissingMessageConstant        = "default branch update requires repository manager, git executor, and GitHub client"
 	migrationMultipleTargetsUnsupportedMessageConstant = "default branch update requires exactly one target configuration"
 	migrationMetadataResolutionErrorTemplateConstant   = "default branch metadata resolution failed: %w"
 	migrationMetadataMissingMessageConstant            = "repository metadata missing default branch for update"
 	migrationSkipMessageTemplateConstant               = "WORKFLOW-DEFAULT-SKIP: %s already defaults to %s\n"
+	migrationArchivedMessageTemplateConstant           = "WORKFLOW-DEFAULT-ARCHIVE: %s %s → %s (locked)\n"
+	migrationDeletedMessageTemplateConstant            = "WORKFLOW-DEFAULT-DELETE: %s %s\n"
+	migrationTombstoneMessageTemplateConstant          = "WORKFLOW-DEFAULT-TOMBSTONE: %s %s (%s)\n"
+	migrationDocumentationMessageTemplateConstant      = "WORKFLOW-DEFAULT-DOCS: %s %s replacements=%d\n"
+	migrationRetentionSummaryTemplateConstant          = "WORKFLOW-DEFAULT-SUMMARY: archived=%d deleted=%d\n"
+	migrationOverrideAppliedTemplateConstant           = "WORKFLOW-DEFAULT-OVERRIDE: %s matched %q (target=%s source=%s)\n"
+	migrationOverrideAutomaticBranchConstant           = "auto"
+	migrationOverrideSkipTemplateConstant              = "WORKFLOW-DEFAULT-SKIP: %s skipped by override %q\n"
+	migrationInstructionsMessageTemplateConstant       = "WORKFLOW-DEFAULT-INSTRUCTIONS: %s\n"
+	migrationInstructionsFileMessageTemplateConstant   = "WORKFLOW-DEFAULT-INSTRUCTIONS-FILE: %s %s\n"
+	migrationAnnouncedMessageTemplateConstant          = "WORKFLOW-DEFAULT-ANNOUNCE: %s %s → %s (marker %s)\n"
+	migrationEnforceSkipMessageTemplateConstant        = "WORKFLOW-DEFAULT-ENFORCE-SKIP: %s %s\n"
+	migrationAnnouncementLookupErrorTemplateConstant   = "default branch announce marker lookup failed: %w"
+	migrationAnnouncementMissingTemplateConstant       = "no announce marker for %s"
 )
 
 // BranchMigrationTarget describes branch migration behavior for discovered repositories.
@@ -32,6 +50,19 @@ type BranchMigrationTarget struct {
 	TargetBranch       string
 	PushToRemote       bool
 	DeleteSourceBranch bool
+	RetainSource       string
+	LeaveTombstone     bool
+	// UpdateDocumentation rewrites branch references in documentation files matching DocumentationPatterns, or the
+	// migrate package defaults when none are set, and commits them with the workflow updates.
+	UpdateDocumentation   bool
+	DocumentationPatterns []string
+	// InstructionsDirectory receives one markdown file per repository with the post-migration steps for contributors.
+	InstructionsDirectory string
+	// Phase selects the announce or enforce half of a two-phase migration; empty migrates in one run. The enforce phase
+	// takes the source branch from the announce marker when SourceBranch is empty.
+	Phase string
+	// GracePeriod is the minimum age of the announce marker before the enforce phase retires the source branch.
+	GracePeriod time.Duration
 }
 
 // BranchMigrationOperation performs default-branch migrations for configured targets.
@@ -77,6 +108,9 @@ func (operation *BranchMigrationOperation) Execute(executionContext context.Cont
 	repositories := state.CloneRepositories()
 
 	for repositoryIndex := range repositories {
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			return cancellationError
+		}
 		repositoryState := repositories[repositoryIndex]
 		if repositoryState == nil {
 			continue
@@ -93,9 +127,23 @@ func (operation *BranchMigrationOperation) Execute(executionContext context.Cont
 		}
 		targetBranch := migrate.BranchName(targetBranchValue)
 
+		phase := migrate.MigrationPhase(strings.TrimSpace(target.Phase))
 		sourceBranchValue := strings.TrimSpace(target.SourceBranch)
+		if len(sourceBranchValue) == 0 && phase == migrate.MigrationPhaseEnforce {
+			announcement, announced, announcementError := migrationService.FindAnnouncement(executionContext, repositoryState.Path, target.RemoteName, targetBranch)
+			if announcementError != nil {
+				return fmt.Errorf(migrationAnnouncementLookupErrorTemplateConstant, announcementError)
+			}
+			if !announced {
+				if environment.Output != nil {
+					fmt.Fprintf(environment.Output, migrationEnforceSkipMessageTemplateConstant, repositoryState.Path, fmt.Sprintf(migrationAnnouncementMissingTemplateConstant, targetBranchValue))
+				}
+				continue
+			}
+			sourceBranchValue = string(announcement.SourceBranch)
+		}
 		if len(sourceBranchValue) == 0 {
-			metadata, metadataError := environment.GitHubClient.ResolveRepoMetadata(executionContext, repositoryIdentifier)
+			metadata, metadataError := environment.repositoryMetadataResolver().ResolveRepoMetadata(executionContext, repositoryIdentifier)
 			if metadataError != nil {
 				return fmt.Errorf(migrationMetadataResolutionErrorTemplateConstant, metadataError)
 			}
@@ -114,19 +162,35 @@ func (operation *BranchMigrationOperation) Execute(executionContext context.Cont
 		}
 
 		options := migrate.MigrationOptions{
-			RepositoryPath:       repositoryState.Path,
-			RepositoryRemoteName: target.RemoteName,
-			RepositoryIdentifier: repositoryIdentifier,
-			WorkflowsDirectory:   defaultMigrationWorkflowsDirectoryConstant,
-			SourceBranch:         sourceBranch,
-			TargetBranch:         targetBranch,
-			PushUpdates:          target.PushToRemote,
-			DeleteSourceBranch:   target.DeleteSourceBranch,
+			RepositoryPath:        repositoryState.Path,
+			RepositoryRemoteName:  target.RemoteName,
+			RepositoryIdentifier:  repositoryIdentifier,
+			WorkflowsDirectory:    defaultMigrationWorkflowsDirectoryConstant,
+			SourceBranch:          sourceBranch,
+			TargetBranch:          targetBranch,
+			PushUpdates:           target.PushToRemote,
+			DeleteSourceBranch:    target.DeleteSourceBranch,
+			RetainSource:          migrate.SourceRetentionMode(strings.TrimSpace(target.RetainSource)),
+			LeaveTombstone:        target.LeaveTombstone,
+			UpdateDocumentation:   target.UpdateDocumentation,
+			DocumentationPatterns: target.DocumentationPatterns,
+			Phase:                 phase,
+			GracePeriod:           target.GracePeriod,
 		}
 
 		if environment.DryRun {
 			if environment.Output != nil {
 				fmt.Fprintf(environment.Output, migrationDryRunMessageTemplateConstant, repositoryState.Path, sourceBranchValue, targetBranchValue)
+				plan, planError := migrationService.Plan(executionContext, options)
+				if planError != nil {
+					return fmt.Errorf(migrationPlanErrorTemplateConstant, planError)
+				}
+				if renderError := plan.Render(environment.Output); renderError != nil {
+					return fmt.Errorf(migrationPlanErrorTemplateConstant, renderError)
+				}
+				if instructionsError := writeMigrationInstructions(environment, repositoryState.Path, target.InstructionsDirectory, plan.Instructions); instructionsError != nil {
+					return instructionsError
+				}
 			}
 			continue
 		}
@@ -140,11 +204,47 @@ func (operation *BranchMigrationOperation) Execute(executionContext context.Cont
 			return fmt.Errorf(migrationExecutionErrorTemplateConstant, executionError)
 		}
 
+		if len(result.EnforceSkipReason) > 0 {
+			if environment.Output != nil {
+				fmt.Fprintf(environment.Output, migrationEnforceSkipMessageTemplateConstant, repositoryState.Path, result.EnforceSkipReason)
+			}
+			continue
+		}
+
 		if environment.Output != nil {
 			fmt.Fprintf(environment.Output, migrationSuccessMessageTemplateConstant, repositoryState.Path, sourceBranchValue, targetBranchValue, result.SafetyStatus.SafeToDelete)
+			for _, documentationUpdate := range result.DocumentationOutcome.UpdatedFiles {
+				fmt.Fprintf(environment.Output, migrationDocumentationMessageTemplateConstant, repositoryState.Path, documentationUpdate.Path, documentationUpdate.Replacements)
+			}
+			if result.AnnouncementRecorded {
+				fmt.Fprintf(environment.Output, migrationAnnouncedMessageTemplateConstant, repositoryState.Path, sourceBranchValue, targetBranchValue, migrate.AnnouncementNotesRef)
+			}
+			if result.TombstoneCommitted {
+				fmt.Fprintf(environment.Output, migrationTombstoneMessageTemplateConstant, repositoryState.Path, sourceBranchValue, migrate.TombstoneFileName)
+			}
+			if len(result.ArchivedSourceBranch) > 0 {
+				fmt.Fprintf(environment.Output, migrationArchivedMessageTemplateConstant, repositoryState.Path, sourceBranchValue, result.ArchivedSourceBranch)
+			}
+			if result.SourceBranchDeleted {
+				fmt.Fprintf(environment.Output, migrationDeletedMessageTemplateConstant, repositoryState.Path, sourceBranchValue)
+			}
 			for _, warning := range result.Warnings {
 				fmt.Fprintln(environment.Output, warning)
 			}
+			instructions := migrate.NewContributorInstructions(options, result)
+			fmt.Fprintf(environment.Output, migrationInstructionsMessageTemplateConstant, repositoryState.Path)
+			if instructionsError := instructions.WriteIndented(environment.Output); instructionsError != nil {
+				return instructionsError
+			}
+			if instructionsError := writeMigrationInstructions(environment, repositoryState.Path, target.InstructionsDirectory, instructions); instructionsError != nil {
+				return instructionsError
+			}
+		}
+		if len(result.ArchivedSourceBranch) > 0 {
+			environment.archivedSourceBranches++
+		}
+		if result.SourceBranchDeleted {
+			environment.deletedSourceBranches++
 		}
 
 		if refreshError := repositoryState.Refresh(executionContext, environment.AuditService); refreshError != nil {
@@ -155,6 +255,50 @@ func (operation *BranchMigrationOperation) Execute(executionContext context.Cont
 	return nil
 }
 
+// readBranchMigrationPhase reads the phase and grace_period options of a default-branch target.
+func readBranchMigrationPhase(reader optionReader) (string, time.Duration, error) {
+	phaseValue, _, phaseValueError := reader.stringValue(optionPhaseKeyConstant)
+	if phaseValueError != nil {
+		return "", 0, phaseValueError
+	}
+	phase, phaseError := migrate.ParseMigrationPhase(phaseValue)
+	if phaseError != nil {
+		return "", 0, phaseError
+	}
+	gracePeriodValue, _, gracePeriodValueError := reader.stringValue(optionGracePeriodKeyConstant)
+	if gracePeriodValueError != nil {
+		return "", 0, gracePeriodValueError
+	}
+	gracePeriod, gracePeriodError := migrate.ParseGracePeriod(gracePeriodValue)
+	if gracePeriodError != nil {
+		return "", 0, gracePeriodError
+	}
+	return string(phase), gracePeriod, nil
+}
+
+func writeMigrationInstructions(environment *Environment, repositoryPath string, directory string, instructions migrate.ContributorInstructions) error {
+	trimmedDirectory := strings.TrimSpace(directory)
+	if len(trimmedDirectory) == 0 {
+		return nil
+	}
+	instructionsPath, writeError := migrate.WriteContributorInstructions(environment.FileSystem, trimmedDirectory, instructions)
+	if writeError != nil {
+		return writeError
+	}
+	fmt.Fprintf(environment.Output, migrationInstructionsFileMessageTemplateConstant, repositoryPath, instructionsPath)
+	return nil
+}
+
+func reportSourceRetentionSummary(environment *Environment) {
+	if environment == nil || environment.Output == nil {
+		return
+	}
+	if environment.archivedSourceBranches == 0 && environment.deletedSourceBranches == 0 {
+		return
+	}
+	fmt.Fprintf(environment.Output, migrationRetentionSummaryTemplateConstant, environment.archivedSourceBranches, environment.deletedSourceBranches)
+}
+
 func resolveRepositoryIdentifier(repositoryState *RepositoryState) (string, error) {
 	if repositoryState == nil {
 		return "", errors.New(migrationIdentifierMissingMessageConstant)
@@ -175,3 +319,44 @@ func resolveRepositoryIdentifier(repositoryState *RepositoryState) (string, erro
 
 	return "", errors.New(migrationIdentifierMissingMessageConstant)
 }
+
+func (environment *Environment) repositoryMetadataResolver() shared.GitHubMetadataResolver {
+	if environment.RepositoryMetadata != nil {
+		return environment.RepositoryMetadata
+	}
+	return environment.GitHubClient
+}
+
+func applyBranchMigrationOverride(environment *Environment, repository *RepositoryState, overrides *migrate.RepositoryOverrides, target BranchMigrationTarget) (BranchMigrationTarget, bool) {
+	inspection := repository.Inspection
+	candidates := selection.RepositoryCandidates(repository.Path, inspection.FinalOwnerRepo, inspection.CanonicalOwnerRepo, inspection.OriginOwnerRepo)
+	overrideKey, override, matched := overrides.Resolve(candidates...)
+	if !matched {
+		return target, true
+	}
+
+	if override.Skip {
+		if environment.Output != nil {
+			fmt.Fprintf(environment.Output, migrationOverrideSkipTemplateConstant, repository.Path, overrideKey)
+		}
+		return target, false
+	}
+
+	if len(override.TargetBranch) > 0 {
+		target.TargetBranch = override.TargetBranch
+	}
+	if len(override.SourceBranch) > 0 {
+		target.SourceBranch = override.SourceBranch
+	}
+	if environment.Output != nil {
+		fmt.Fprintf(environment.Output, migrationOverrideAppliedTemplateConstant, repository.Path, overrideKey, displayOverrideBranch(target.TargetBranch), displayOverrideBranch(target.SourceBranch))
+	}
+	return target, true
+}
+
+func displayOverrideBranch(branchName string) string {
+	if len(strings.TrimSpace(branchName)) == 0 {
+		return migrationOverrideAutomaticBranchConstant
+	}
+	return branchName
+}
@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"bytes"
 	"context"
 	"strings"
 	"testing"
@@ -127,3 +128,69 @@ func TestBranchMigrationOperationReturnsActionableDefaultBranchError(testInstanc
 	require.Contains(testInstance, errorMessage, "GraphQL: branch not found")
 	require.NotContains(testInstance, errorMessage, "default branch update failed")
 }
+
+func TestBranchMigrationOperationEnforceSkipsRepositoryWithoutAnnouncement(testInstance *testing.T) {
+	testInstance.Setenv(githubauth.EnvGitHubCLIToken, "test-token")
+	testInstance.Setenv(githubauth.EnvGitHubToken, "test-token")
+	executor := fakeGitExecutor{}
+
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(executor)
+	require.NoError(testInstance, managerError)
+
+	githubClient, clientError := githubcli.NewClient(executor)
+	require.NoError(testInstance, clientError)
+
+	operation := &BranchMigrationOperation{Targets: []BranchMigrationTarget{
+		{RemoteName: "origin", TargetBranch: "master", RetainSource: "delete", Phase: string(migrate.MigrationPhaseEnforce)},
+	}}
+
+	repositoryPath := testInstance.TempDir()
+	state := &State{
+		Repositories: []*RepositoryState{
+			{Path: repositoryPath, Inspection: audit.RepositoryInspection{CanonicalOwnerRepo: "owner/example"}},
+		},
+	}
+
+	outputBuffer := &bytes.Buffer{}
+	environment := &Environment{
+		RepositoryManager: repositoryManager,
+		GitExecutor:       executor,
+		GitHubClient:      githubClient,
+		Output:            outputBuffer,
+	}
+
+	require.NoError(testInstance, operation.Execute(context.Background(), environment, state))
+	require.Equal(testInstance, "WORKFLOW-DEFAULT-ENFORCE-SKIP: "+repositoryPath+" no announce marker for master\n", outputBuffer.String())
+}
+
+func TestBranchDefaultActionSkipsRepositoryWithSkipOverride(testInstance *testing.T) {
+	overrides, overridesError := migrate.NewRepositoryOverrides(map[string]migrate.RepositoryOverride{
+		"temirov/frozen": {Skip: true},
+	})
+	require.NoError(testInstance, overridesError)
+
+	outputBuffer := &strings.Builder{}
+	environment := &Environment{Output: outputBuffer}
+	repository := &RepositoryState{Path: "/tmp/frozen", Inspection: audit.RepositoryInspection{FinalOwnerRepo: "temirov/frozen"}}
+
+	actionError := handleBranchDefaultAction(context.Background(), environment, repository, map[string]any{"target": "master", "overrides": overrides})
+	require.NoError(testInstance, actionError)
+	require.Equal(testInstance, "WORKFLOW-DEFAULT-SKIP: /tmp/frozen skipped by override \"temirov/frozen\"\n", outputBuffer.String())
+	require.Empty(testInstance, overrides.UnmatchedKeys())
+}
+
+func TestApplyBranchMigrationOverrideReplacesBranches(testInstance *testing.T) {
+	overrides, overridesError := migrate.NewRepositoryOverrides(map[string]migrate.RepositoryOverride{
+		"service-*": {TargetBranch: "trunk"},
+	})
+	require.NoError(testInstance, overridesError)
+
+	outputBuffer := &strings.Builder{}
+	environment := &Environment{Output: outputBuffer}
+	repository := &RepositoryState{Path: "/work/service-api"}
+
+	target, proceed := applyBranchMigrationOverride(environment, repository, overrides, BranchMigrationTarget{TargetBranch: "master"})
+	require.True(testInstance, proceed)
+	require.Equal(testInstance, "trunk", target.TargetBranch)
+	require.Equal(testInstance, "WORKFLOW-DEFAULT-OVERRIDE: /work/service-api matched \"service-*\" (target=trunk source=auto)\n", outputBuffer.String())
+}
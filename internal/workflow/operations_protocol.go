@@ -32,10 +32,13 @@ func (operation *ProtocolConversionOperation) Execute(executionContext context.C
 	dependencies := conversion.Dependencies{
 		GitManager: environment.RepositoryManager,
 		Prompter:   environment.Prompter,
-		Reporter:   shared.NewWriterReporter(environment.Output),
+		Reporter:   environment.executorReporter(),
 	}
 
 	for repositoryIndex := range state.Repositories {
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			return cancellationError
+		}
 		repository := state.Repositories[repositoryIndex]
 
 		actualProtocol, actualProtocolError := shared.ParseRemoteProtocol(string(repository.Inspection.RemoteProtocol))
@@ -0,0 +1,172 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/temirov/gix/internal/githubcli"
+)
+
+const (
+	taskActionCreatePullRequest                 = "repo.pull-request.create"
+	optionPullRequestHeadKeyConstant            = "head"
+	createPullRequestPlanMessageTemplate        = "PULL-REQUEST-PLAN: %s (%s) %s -> %s title=%q\n"
+	createPullRequestCreatedMessageTemplate     = "PULL-REQUEST-CREATED: %s (%s) #%d %s\n"
+	createPullRequestExistingMessageTemplate    = "PULL-REQUEST-EXISTS: %s (%s) #%d %s\n"
+	createPullRequestSkipMessageTemplate        = "PULL-REQUEST-SKIP: %s reason=%s\n"
+	createPullRequestNoRepositoryReason         = "no GitHub repository"
+	createPullRequestNoHeadReason               = "head branch unknown"
+	createPullRequestNoBaseReason               = "base branch unknown"
+	createPullRequestSameBranchReason           = "head matches base"
+	createPullRequestErrorTemplate              = "pull-request: %w"
+	createPullRequestMissingTitleMessage        = "pull-request step requires title"
+	createPullRequestMissingGitHubClientMessage = "pull-request step requires a GitHub client"
+)
+
+// CreatePullRequestOperation opens a pull request from each repository's head branch into its base branch.
+// Head defaults to the checked-out branch and base to the remote default branch; an already open pull request for the same pair is reported instead of duplicated.
+type CreatePullRequestOperation struct {
+	Title string
+	Body  string
+	Base  string
+	Head  string
+	Draft bool
+}
+
+// Name identifies the operation type.
+func (operation *CreatePullRequestOperation) Name() string {
+	return string(OperationTypeCreatePullRequest)
+}
+
+// Execute opens, or finds, the pull request for every repository in the state.
+func (operation *CreatePullRequestOperation) Execute(executionContext context.Context, environment *Environment, state *State) error {
+	if environment == nil || state == nil {
+		return nil
+	}
+
+	for _, repository := range state.Repositories {
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			return cancellationError
+		}
+		if repository == nil {
+			continue
+		}
+		if executionError := operation.executeForRepository(executionContext, environment, repository); executionError != nil {
+			return executionError
+		}
+	}
+	return nil
+}
+
+func (operation *CreatePullRequestOperation) executeForRepository(executionContext context.Context, environment *Environment, repository *RepositoryState) error {
+	repositoryIdentifier := editRepositoryIdentifier(repository)
+	if len(repositoryIdentifier) == 0 {
+		operation.reportSkip(environment, repository, createPullRequestNoRepositoryReason)
+		return nil
+	}
+
+	head := operation.Head
+	if len(head) == 0 && environment.RepositoryManager != nil {
+		currentBranch, branchError := environment.RepositoryManager.GetCurrentBranch(executionContext, repository.Path)
+		if branchError != nil {
+			return fmt.Errorf(createPullRequestErrorTemplate, branchError)
+		}
+		head = strings.TrimSpace(currentBranch)
+	}
+	if len(head) == 0 {
+		operation.reportSkip(environment, repository, createPullRequestNoHeadReason)
+		return nil
+	}
+
+	base := operation.Base
+	if len(base) == 0 {
+		base = strings.TrimSpace(repository.Inspection.RemoteDefaultBranch)
+	}
+	if len(base) == 0 {
+		operation.reportSkip(environment, repository, createPullRequestNoBaseReason)
+		return nil
+	}
+	if head == base {
+		operation.reportSkip(environment, repository, createPullRequestSameBranchReason)
+		return nil
+	}
+
+	if environment.DryRun {
+		if environment.Output != nil {
+			fmt.Fprintf(environment.Output, createPullRequestPlanMessageTemplate, repository.Path, repositoryIdentifier, head, base, operation.Title)
+		}
+		return nil
+	}
+
+	if environment.GitHubClient == nil {
+		return errors.New(createPullRequestMissingGitHubClientMessage)
+	}
+
+	pullRequest, createError := environment.GitHubClient.CreatePullRequest(executionContext, githubcli.PullRequestCreateOptions{
+		Repository: repositoryIdentifier,
+		Title:      operation.Title,
+		Body:       operation.Body,
+		Base:       base,
+		Head:       head,
+		Draft:      operation.Draft,
+	})
+	if createError != nil {
+		return fmt.Errorf(createPullRequestErrorTemplate, createError)
+	}
+
+	if environment.Output != nil {
+		messageTemplate := createPullRequestCreatedMessageTemplate
+		if pullRequest.Existing {
+			messageTemplate = createPullRequestExistingMessageTemplate
+		}
+		fmt.Fprintf(environment.Output, messageTemplate, repository.Path, repositoryIdentifier, pullRequest.Number, pullRequest.URL)
+	}
+	return nil
+}
+
+func (operation *CreatePullRequestOperation) reportSkip(environment *Environment, repository *RepositoryState, reason string) {
+	if environment.Output != nil {
+		fmt.Fprintf(environment.Output, createPullRequestSkipMessageTemplate, repository.Path, reason)
+	}
+}
+
+func buildCreatePullRequestOperation(options map[string]any) (Operation, error) {
+	reader := newOptionReader(options)
+
+	title, _, titleError := reader.stringValue(optionTaskPRTitleKeyConstant)
+	if titleError != nil {
+		return nil, titleError
+	}
+	if len(title) == 0 {
+		return nil, errors.New(createPullRequestMissingTitleMessage)
+	}
+	body, _, bodyError := reader.stringValue(optionTaskPRBodyKeyConstant)
+	if bodyError != nil {
+		return nil, bodyError
+	}
+	base, _, baseError := reader.stringValue(optionTaskPRBaseKeyConstant)
+	if baseError != nil {
+		return nil, baseError
+	}
+	head, _, headError := reader.stringValue(optionPullRequestHeadKeyConstant)
+	if headError != nil {
+		return nil, headError
+	}
+	draft, _, draftError := reader.boolValue(optionTaskPRDraftKeyConstant)
+	if draftError != nil {
+		return nil, draftError
+	}
+
+	return &CreatePullRequestOperation{Title: title, Body: body, Base: base, Head: head, Draft: draft}, nil
+}
+
+func handleCreatePullRequestAction(ctx context.Context, environment *Environment, repository *RepositoryState, parameters map[string]any) error {
+	operation, operationError := buildCreatePullRequestOperation(parameters)
+	if operationError != nil {
+		return operationError
+	}
+	state := &State{Repositories: []*RepositoryState{repository}}
+	return operation.Execute(ctx, environment, state)
+}
@@ -0,0 +1,101 @@
+package workflow
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+type pullRequestExecutor struct {
+	existing          bool
+	recordedArguments [][]string
+}
+
+func (executor *pullRequestExecutor) ExecuteGitHubCLI(_ context.Context, details execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	executor.recordedArguments = append(executor.recordedArguments, details.Arguments)
+	if details.Arguments[1] == "list" {
+		return execshell.ExecutionResult{StandardOutput: `[{"number":3,"url":"https://github.com/owner/example/pull/3"}]`}, nil
+	}
+	if executor.existing {
+		return execshell.ExecutionResult{}, execshell.CommandFailedError{
+			Command: execshell.ShellCommand{Name: execshell.CommandGitHub, Details: details},
+			Result:  execshell.ExecutionResult{ExitCode: 1, StandardError: "a pull request for branch \"automation/remote\" into branch \"main\" already exists"},
+		}
+	}
+	return execshell.ExecutionResult{StandardOutput: "https://github.com/owner/example/pull/12\n"}, nil
+}
+
+func TestCreatePullRequestOperation(testInstance *testing.T) {
+	testCases := []struct {
+		name              string
+		options           map[string]any
+		existing          bool
+		dryRun            bool
+		expectedOutput    string
+		expectedArguments [][]string
+	}{
+		{
+			name:           "creates_from_current_branch_into_default",
+			options:        map[string]any{"title": "chore: update remote", "body": "Automated"},
+			expectedOutput: "PULL-REQUEST-CREATED: /repositories/example (owner/example) #12 https://github.com/owner/example/pull/12\n",
+			expectedArguments: [][]string{
+				{"pr", "create", "--repo", "owner/example", "--base", "main", "--head", "automation/remote", "--title", "chore: update remote", "--body", "Automated"},
+			},
+		},
+		{
+			name:           "reports_existing_pull_request",
+			options:        map[string]any{"title": "chore: update remote", "draft": true},
+			existing:       true,
+			expectedOutput: "PULL-REQUEST-EXISTS: /repositories/example (owner/example) #3 https://github.com/owner/example/pull/3\n",
+			expectedArguments: [][]string{
+				{"pr", "create", "--repo", "owner/example", "--base", "main", "--head", "automation/remote", "--title", "chore: update remote", "--body", "", "--draft"},
+				{"pr", "list", "--repo", "owner/example", "--head", "automation/remote", "--base", "main", "--state", "open", "--json", "number,url", "--limit", "1"},
+			},
+		},
+		{
+			name:           "dry_run_prints_plan",
+			options:        map[string]any{"title": "chore: update remote", "base": "develop"},
+			dryRun:         true,
+			expectedOutput: "PULL-REQUEST-PLAN: /repositories/example (owner/example) automation/remote -> develop title=\"chore: update remote\"\n",
+		},
+		{
+			name:           "skips_when_head_matches_base",
+			options:        map[string]any{"title": "chore: update remote", "head": "main"},
+			expectedOutput: "PULL-REQUEST-SKIP: /repositories/example reason=head matches base\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			githubExecutor := &pullRequestExecutor{existing: testCase.existing}
+			githubClient, clientError := githubcli.NewClient(githubExecutor)
+			require.NoError(subtest, clientError)
+			repositoryManager, managerError := gitrepo.NewRepositoryManager(&recordingGitExecutor{currentBranch: "automation/remote"})
+			require.NoError(subtest, managerError)
+
+			outputBuffer := &strings.Builder{}
+			environment := &Environment{GitHubClient: githubClient, RepositoryManager: repositoryManager, Output: outputBuffer, DryRun: testCase.dryRun}
+			repository := &RepositoryState{
+				Path:       "/repositories/example",
+				Inspection: audit.RepositoryInspection{FinalOwnerRepo: "owner/example", RemoteDefaultBranch: "main"},
+			}
+
+			require.NoError(subtest, handleCreatePullRequestAction(context.Background(), environment, repository, testCase.options))
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+			require.Equal(subtest, testCase.expectedArguments, githubExecutor.recordedArguments)
+		})
+	}
+}
+
+func TestBuildCreatePullRequestOperationRequiresTitle(testInstance *testing.T) {
+	_, buildError := buildCreatePullRequestOperation(map[string]any{"body": "Automated"})
+	require.EqualError(testInstance, buildError, createPullRequestMissingTitleMessage)
+}
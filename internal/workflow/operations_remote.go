@@ -3,6 +3,7 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/temirov/gix/internal/repos/remotes"
 	"github.com/temirov/gix/internal/repos/shared"
@@ -10,11 +11,22 @@ import (
 
 const (
 	canonicalRemoteRefreshErrorTemplateConstant = "failed to refresh repository after canonical remote update: %w"
+	combinedPlanSeparatorConstant               = " | "
+	combinedPlanLineTemplateConstant            = "%s\n"
 )
 
 // CanonicalRemoteOperation updates origin URLs to their canonical GitHub equivalents.
+// When RenameDirectory is set, each repository's directory is also renamed to its canonical name in the same pass,
+// unless the user declined the remote update.
+// When IncludePushURL is set, a separately configured origin push URL is canonicalized along with the fetch URL.
+// When EraseCredentials is set, an HTTPS origin that moves to another host has the old host's stored credentials erased.
 type CanonicalRemoteOperation struct {
-	OwnerConstraint string
+	OwnerConstraint    string
+	RenameDirectory    bool
+	RenameIncludeOwner bool
+	RenameRequireClean bool
+	IncludePushURL     bool
+	EraseCredentials   bool
 }
 
 // Name identifies the operation type.
@@ -28,13 +40,19 @@ func (operation *CanonicalRemoteOperation) Execute(executionContext context.Cont
 		return nil
 	}
 
+	outputReporter := environment.executorReporter()
 	dependencies := remotes.Dependencies{
 		GitManager: environment.RepositoryManager,
 		Prompter:   environment.Prompter,
-		Reporter:   shared.NewWriterReporter(environment.Output),
+		Reporter:   outputReporter,
 	}
+	renameOperation := &RenameOperation{IncludeOwner: operation.RenameIncludeOwner, RequireCleanWorktree: operation.RenameRequireClean}
+	combinePlans := operation.RenameDirectory && environment.DryRun
 
 	for repositoryIndex := range state.Repositories {
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			return cancellationError
+		}
 		repository := state.Repositories[repositoryIndex]
 		originOwnerRepository, originOwnerError := shared.ParseOwnerRepositoryOptional(repository.Inspection.OriginOwnerRepo)
 		if originOwnerError != nil {
@@ -62,6 +80,16 @@ func (operation *CanonicalRemoteOperation) Execute(executionContext context.Cont
 			return fmt.Errorf("canonical remote update: %w", currentRemoteURLError)
 		}
 
+		currentPushURL, currentPushURLError := shared.ParseRemoteURLOptional(repository.Inspection.OriginPushURL)
+		if currentPushURLError != nil {
+			return fmt.Errorf("canonical remote update: %w", currentPushURLError)
+		}
+
+		pushOwnerRepository, pushOwnerError := shared.ParseOwnerRepositoryOptional(repository.Inspection.OriginPushOwnerRepo)
+		if pushOwnerError != nil {
+			return fmt.Errorf("canonical remote update: %w", pushOwnerError)
+		}
+
 		remoteProtocol, remoteProtocolError := shared.ParseRemoteProtocol(string(repository.Inspection.RemoteProtocol))
 		if remoteProtocolError != nil {
 			return fmt.Errorf("canonical remote update: %w", remoteProtocolError)
@@ -76,14 +104,26 @@ func (operation *CanonicalRemoteOperation) Execute(executionContext context.Cont
 			RepositoryPath:           repositoryPath,
 			CurrentOriginURL:         currentRemoteURL,
 			OriginOwnerRepository:    originOwnerRepository,
+			CurrentPushURL:           currentPushURL,
+			PushOwnerRepository:      pushOwnerRepository,
+			IncludePushURL:           operation.IncludePushURL,
 			CanonicalOwnerRepository: canonicalOwnerRepository,
 			RemoteProtocol:           remoteProtocol,
 			DryRun:                   environment.DryRun,
 			ConfirmationPolicy:       shared.ConfirmationPolicyFromBool(assumeYes),
 			OwnerConstraint:          ownerConstraint,
+			EraseStaleCredentials:    operation.EraseCredentials,
+		}
+
+		if combinePlans {
+			if planError := operation.planCombined(executionContext, environment, state, repositoryIndex, dependencies, options, renameOperation, outputReporter); planError != nil {
+				return planError
+			}
+			continue
 		}
 
-		if executionError := remotes.Execute(executionContext, dependencies, options); executionError != nil {
+		outcome, executionError := remotes.Execute(executionContext, dependencies, options)
+		if executionError != nil {
 			if logRepositoryOperationError(environment, executionError) {
 				continue
 			}
@@ -91,13 +131,75 @@ func (operation *CanonicalRemoteOperation) Execute(executionContext context.Cont
 		}
 
 		if environment.DryRun {
+			publishPlannedRemoteURL(executionContext, options)
 			continue
 		}
 
 		if refreshError := repository.Refresh(executionContext, environment.AuditService); refreshError != nil {
 			return fmt.Errorf(canonicalRemoteRefreshErrorTemplateConstant, refreshError)
 		}
+		PublishStringOutput(executionContext, StepOutputNewRemoteURL, repository.Inspection.OriginURL)
+
+		if operation.RenameDirectory && outcome != remotes.OutcomeDeclined {
+			renameDependencies := renameOperation.renameDependencies(environment, outputReporter)
+			if renameError := renameOperation.renameRepository(executionContext, environment, state, repositoryIndex, renameDependencies); renameError != nil {
+				return renameError
+			}
+		}
 	}
 
 	return nil
 }
+
+// planCombined reports the remote URL change and the directory move for one repository as a single plan line.
+func (operation *CanonicalRemoteOperation) planCombined(executionContext context.Context, environment *Environment, state *State, repositoryIndex int, dependencies remotes.Dependencies, options remotes.Options, renameOperation *RenameOperation, outputReporter shared.Reporter) error {
+	capturedPlan := &capturingReporter{}
+	dependencies.Reporter = capturedPlan
+
+	_, remoteError := remotes.Execute(executionContext, dependencies, options)
+	if remoteError != nil && !logRepositoryOperationError(environment, remoteError) {
+		capturedPlan.flush(outputReporter)
+		return fmt.Errorf("canonical remote update: %w", remoteError)
+	}
+	if remoteError == nil {
+		publishPlannedRemoteURL(executionContext, options)
+	}
+
+	renameError := renameOperation.renameRepository(executionContext, environment, state, repositoryIndex, renameOperation.renameDependencies(environment, capturedPlan))
+	capturedPlan.flush(outputReporter)
+	return renameError
+}
+
+// publishPlannedRemoteURL publishes the origin URL a dry run would set: the canonical URL in the repository's
+// protocol, or the current URL when no canonical URL can be built.
+func publishPlannedRemoteURL(executionContext context.Context, options remotes.Options) {
+	if options.CanonicalOwnerRepository != nil {
+		if plannedURL, buildError := remotes.BuildRemoteURL(options.RemoteProtocol, options.CanonicalOwnerRepository.String()); buildError == nil {
+			PublishStringOutput(executionContext, StepOutputNewRemoteURL, plannedURL)
+			return
+		}
+	}
+	if options.CurrentOriginURL != nil {
+		PublishStringOutput(executionContext, StepOutputNewRemoteURL, options.CurrentOriginURL.String())
+	}
+}
+
+type capturingReporter struct {
+	lines []string
+}
+
+func (reporter *capturingReporter) Printf(format string, args ...any) {
+	for _, line := range strings.Split(fmt.Sprintf(format, args...), "\n") {
+		if trimmed := strings.TrimSpace(line); len(trimmed) > 0 {
+			reporter.lines = append(reporter.lines, trimmed)
+		}
+	}
+}
+
+func (reporter *capturingReporter) flush(target shared.Reporter) {
+	if len(reporter.lines) == 0 {
+		return
+	}
+	target.Printf(combinedPlanLineTemplateConstant, strings.Join(reporter.lines, combinedPlanSeparatorConstant))
+	reporter.lines = nil
+}
@@ -0,0 +1,123 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/repos/filesystem"
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+func TestCanonicalRemoteOperationCombinesRenamePlan(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		renameDirectory bool
+		expectedOutput  func(string) string
+	}{
+		{
+			name:            "combined_plan_entry",
+			renameDirectory: true,
+			expectedOutput: func(root string) string {
+				return "PLAN-UPDATE-REMOTE: " + filepath.Join(root, "old-name") + " origin https://github.com/owner/old-name.git → https://github.com/owner/new-name.git | PLAN-OK: " + filepath.Join(root, "old-name") + " → " + filepath.Join(root, "new-name") + "\n"
+			},
+		},
+		{
+			name:            "remote_plan_only",
+			renameDirectory: false,
+			expectedOutput: func(root string) string {
+				return "PLAN-UPDATE-REMOTE: " + filepath.Join(root, "old-name") + " origin https://github.com/owner/old-name.git → https://github.com/owner/new-name.git\n"
+			},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			root := subtest.TempDir()
+			repositoryPath := filepath.Join(root, "old-name")
+			require.NoError(subtest, os.MkdirAll(repositoryPath, 0o755))
+
+			outputBuffer := &strings.Builder{}
+			environment := &Environment{Output: outputBuffer, FileSystem: filesystem.OSFileSystem{}, DryRun: true}
+			state := &State{Repositories: []*RepositoryState{{
+				Path: repositoryPath,
+				Inspection: audit.RepositoryInspection{
+					Path:               repositoryPath,
+					FolderName:         "old-name",
+					DesiredFolderName:  "new-name",
+					OriginURL:          "https://github.com/owner/old-name.git",
+					OriginOwnerRepo:    "owner/old-name",
+					CanonicalOwnerRepo: "owner/new-name",
+					FinalOwnerRepo:     "owner/new-name",
+					RemoteProtocol:     audit.RemoteProtocolHTTPS,
+				},
+			}}}
+
+			operation := &CanonicalRemoteOperation{RenameDirectory: testCase.renameDirectory}
+			require.NoError(subtest, operation.Execute(context.Background(), environment, state))
+			require.Equal(subtest, testCase.expectedOutput(root), outputBuffer.String())
+		})
+	}
+}
+
+type decliningPrompter struct {
+	prompts []string
+}
+
+func (prompter *decliningPrompter) Confirm(prompt string) (shared.ConfirmationResult, error) {
+	prompter.prompts = append(prompter.prompts, prompt)
+	return shared.ConfirmationResult{Confirmed: false}, nil
+}
+
+func TestCanonicalRemoteOperationKeepsDirectoryWhenUpdateDeclined(testInstance *testing.T) {
+	root := testInstance.TempDir()
+	repositoryPath := filepath.Join(root, "old-name")
+	require.NoError(testInstance, os.MkdirAll(filepath.Join(repositoryPath, ".git"), 0o755))
+
+	repositoryManager, managerError := gitrepo.NewRepositoryManager(execshelltest.NewPermissiveExecutor())
+	require.NoError(testInstance, managerError)
+	prompter := &decliningPrompter{}
+	outputBuffer := &strings.Builder{}
+	environment := &Environment{
+		Output:            outputBuffer,
+		FileSystem:        filesystem.OSFileSystem{},
+		RepositoryManager: repositoryManager,
+		Prompter:          prompter,
+		PromptState:       NewPromptState(false),
+		AuditService: audit.NewService(
+			&stubRepositoryDiscoverer{repositories: []string{repositoryPath}},
+			&stubGitRepositoryManager{remoteURL: "https://github.com/owner/old-name.git"},
+			&stubGitExecutor{},
+			&stubGitHubMetadataResolver{},
+			&bytes.Buffer{},
+			&bytes.Buffer{},
+		),
+	}
+	state := &State{Repositories: []*RepositoryState{NewRepositoryState(audit.RepositoryInspection{
+		Path:               repositoryPath,
+		FolderName:         "old-name",
+		DesiredFolderName:  "new-name",
+		OriginURL:          "https://github.com/owner/old-name.git",
+		OriginOwnerRepo:    "owner/old-name",
+		CanonicalOwnerRepo: "owner/new-name",
+		FinalOwnerRepo:     "owner/new-name",
+		RemoteProtocol:     audit.RemoteProtocolHTTPS,
+	})}}
+
+	operation := &CanonicalRemoteOperation{RenameDirectory: true}
+	require.NoError(testInstance, operation.Execute(context.Background(), environment, state))
+
+	require.Len(testInstance, prompter.prompts, 1)
+	require.Equal(testInstance, "UPDATE-REMOTE-SKIP: user declined for "+repositoryPath+"\n", outputBuffer.String())
+	require.DirExists(testInstance, repositoryPath)
+	require.NoDirExists(testInstance, filepath.Join(root, "new-name"))
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/temirov/gix/internal/gitrepo"
 	"github.com/temirov/gix/internal/repos/rename"
 	"github.com/temirov/gix/internal/repos/shared"
 )
@@ -20,6 +21,8 @@ type RenameOperation struct {
 	RequireCleanWorktree bool
 	requireCleanExplicit bool
 	IncludeOwner         bool
+	NamingTemplate       string
+	PlanFilePath         string
 }
 
 // Name identifies the operation type.
@@ -33,76 +36,135 @@ func (operation *RenameOperation) Execute(executionContext context.Context, envi
 		return nil
 	}
 
-	directoryPlanner := rename.NewDirectoryPlanner()
+	dependencies := operation.renameDependencies(environment, environment.executorReporter())
+	for repositoryIndex := range state.Repositories {
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			return cancellationError
+		}
+		if renameError := operation.renameRepository(executionContext, environment, state, repositoryIndex, dependencies); renameError != nil {
+			return renameError
+		}
+	}
+
+	return nil
+}
+
+func (operation *RenameOperation) renameDependencies(environment *Environment, reporter shared.Reporter) rename.Dependencies {
 	dependencies := rename.Dependencies{
 		FileSystem: environment.FileSystem,
 		GitManager: environment.RepositoryManager,
 		Prompter:   environment.Prompter,
 		Clock:      shared.SystemClock{},
-		Reporter:   shared.NewWriterReporter(environment.Output),
+		Reporter:   reporter,
 	}
+	if planFilePath := strings.TrimSpace(operation.PlanFilePath); len(planFilePath) > 0 {
+		dependencies.PlanRecorder = environment.renamePlanRecorder(planFilePath)
+	}
+	return dependencies
+}
 
-	for repositoryIndex := range state.Repositories {
-		repository := state.Repositories[repositoryIndex]
-		repositoryPath, repositoryPathError := shared.NewRepositoryPath(repository.Path)
-		if repositoryPathError != nil {
-			return fmt.Errorf("rename directories: %w", repositoryPathError)
-		}
-		plan := directoryPlanner.Plan(operation.IncludeOwner, repository.Inspection.FinalOwnerRepo, repository.Inspection.DesiredFolderName)
-		desiredFolderName := plan.FolderName
-		if plan.IsNoop(repository.Path, repository.Inspection.FolderName) {
-			desiredFolderName = filepath.Base(repository.Path)
-		}
-		trimmedFolderName := strings.TrimSpace(desiredFolderName)
-		if len(trimmedFolderName) == 0 {
-			continue
-		}
+func (operation *RenameOperation) renameRepository(executionContext context.Context, environment *Environment, state *State, repositoryIndex int, dependencies rename.Dependencies) error {
+	repository := state.Repositories[repositoryIndex]
+	repositoryPath, repositoryPathError := shared.NewRepositoryPath(repository.Path)
+	if repositoryPathError != nil {
+		return fmt.Errorf("rename directories: %w", repositoryPathError)
+	}
+	namingTemplate, namingTemplateError := operation.namingTemplate()
+	if namingTemplateError != nil {
+		return fmt.Errorf("rename directories: %w", namingTemplateError)
+	}
+	plan, planError := rename.NewDirectoryPlanner().PlanWithTemplate(namingTemplate, repository.Inspection.FinalOwnerRepo, repository.Inspection.DesiredFolderName, originHost(repository.Inspection.OriginURL))
+	if planError != nil {
+		return fmt.Errorf("rename directories: %w", planError)
+	}
+	desiredFolderName := plan.FolderName
+	if plan.IsNoop(repository.Path, repository.Inspection.FolderName) {
+		desiredFolderName = filepath.Base(repository.Path)
+	}
+	trimmedFolderName := strings.TrimSpace(desiredFolderName)
+	if len(trimmedFolderName) == 0 {
+		return nil
+	}
 
-		assumeYes := false
-		if environment.PromptState != nil {
-			assumeYes = environment.PromptState.IsAssumeYesEnabled()
-		}
+	assumeYes := false
+	if environment.PromptState != nil {
+		assumeYes = environment.PromptState.IsAssumeYesEnabled()
+	}
 
-		originalPath := repositoryPath.String()
+	originalPath := repositoryPath.String()
 
-		options := rename.Options{
-			RepositoryPath:          repositoryPath,
-			DesiredFolderName:       trimmedFolderName,
-			DryRun:                  environment.DryRun,
-			CleanPolicy:             shared.CleanWorktreePolicyFromBool(operation.RequireCleanWorktree),
-			ConfirmationPolicy:      shared.ConfirmationPolicyFromBool(assumeYes),
-			IncludeOwner:            plan.IncludeOwner,
-			EnsureParentDirectories: plan.IncludeOwner,
-		}
+	options := rename.Options{
+		RepositoryPath:          repositoryPath,
+		DesiredFolderName:       trimmedFolderName,
+		DryRun:                  environment.DryRun,
+		CleanPolicy:             shared.CleanWorktreePolicyFromBool(operation.RequireCleanWorktree),
+		ConfirmationPolicy:      shared.ConfirmationPolicyFromBool(assumeYes),
+		IncludeOwner:            plan.IncludeOwner,
+		EnsureParentDirectories: plan.Nested(),
+	}
 
-		if executionError := rename.Execute(executionContext, dependencies, options); executionError != nil {
-			if logRepositoryOperationError(environment, executionError) {
-				continue
-			}
-			return fmt.Errorf("rename directories: %w", executionError)
+	if executionError := rename.Execute(executionContext, dependencies, options); executionError != nil {
+		if logRepositoryOperationError(environment, executionError) {
+			return nil
 		}
+		return fmt.Errorf("rename directories: %w", executionError)
+	}
 
-		if environment.DryRun {
-			continue
-		}
+	newPath := filepath.Join(filepath.Dir(originalPath), plan.FolderName)
+	if environment.DryRun {
+		PublishStringOutput(executionContext, StepOutputRenamedPath, newPath)
+		return nil
+	}
 
-		newPath := filepath.Join(filepath.Dir(originalPath), plan.FolderName)
-		if !renameCompleted(environment.FileSystem, originalPath, newPath) {
-			continue
-		}
+	if !renameCompleted(environment.FileSystem, originalPath, newPath) {
+		PublishStringOutput(executionContext, StepOutputRenamedPath, repository.Path)
+		return nil
+	}
 
-		if updateError := state.UpdateRepositoryPath(repositoryIndex, newPath); updateError != nil {
-			return updateError
-		}
+	if updateError := state.UpdateRepositoryPath(repositoryIndex, newPath); updateError != nil {
+		return updateError
+	}
 
-		if refreshError := repository.Refresh(executionContext, environment.AuditService); refreshError != nil {
-			return fmt.Errorf(renameRefreshErrorTemplateConstant, refreshError)
-		}
+	if refreshError := repository.Refresh(executionContext, environment.AuditService); refreshError != nil {
+		return fmt.Errorf(renameRefreshErrorTemplateConstant, refreshError)
 	}
 
+	PublishStringOutput(executionContext, StepOutputRenamedPath, repository.Path)
 	return nil
 }
 
+func (operation *RenameOperation) namingTemplate() (rename.NamingTemplate, error) {
+	if len(strings.TrimSpace(operation.NamingTemplate)) > 0 {
+		return rename.ParseNamingTemplate(operation.NamingTemplate)
+	}
+	if operation.IncludeOwner {
+		return rename.ParseNamingTemplate(rename.OwnerNamingTemplateText)
+	}
+	return rename.ParseNamingTemplate(rename.DefaultNamingTemplateText)
+}
+
+func readNamingTemplateOption(reader optionReader) (string, error) {
+	namingTemplate, _, namingTemplateError := reader.stringValue(optionNamingTemplateKeyConstant)
+	if namingTemplateError != nil {
+		return "", namingTemplateError
+	}
+	if len(namingTemplate) == 0 {
+		return "", nil
+	}
+	if _, parseError := rename.ParseNamingTemplate(namingTemplate); parseError != nil {
+		return "", parseError
+	}
+	return namingTemplate, nil
+}
+
+func originHost(originURL string) string {
+	remoteURL, parseError := gitrepo.ParseRemoteURL(originURL)
+	if parseError != nil {
+		return ""
+	}
+	return remoteURL.Host
+}
+
 // ApplyRequireCleanDefault enables clean-worktree enforcement when no explicit preference was configured.
 func (operation *RenameOperation) ApplyRequireCleanDefault(requireClean bool) {
 	if operation == nil {
@@ -131,3 +193,33 @@ func renameCompleted(fileSystem shared.FileSystem, originalPath string, newPath
 
 	return os.SameFile(originalInfo, newInfo)
 }
+
+type environmentRenamePlanRecorder struct {
+	plan *rename.PlanFile
+}
+
+func (recorder environmentRenamePlanRecorder) RecordPlannedRename(entry rename.PlanEntry) {
+	recorder.plan.Renames = append(recorder.plan.Renames, entry)
+}
+
+func (environment *Environment) renamePlanRecorder(planFilePath string) rename.PlanRecorder {
+	if environment.renamePlans == nil {
+		environment.renamePlans = make(map[string]*rename.PlanFile)
+	}
+	plan, exists := environment.renamePlans[planFilePath]
+	if !exists {
+		plan = &rename.PlanFile{}
+		environment.renamePlans[planFilePath] = plan
+		environment.renamePlanPaths = append(environment.renamePlanPaths, planFilePath)
+	}
+	return environmentRenamePlanRecorder{plan: plan}
+}
+
+func writeRenamePlans(environment *Environment) error {
+	for _, planFilePath := range environment.renamePlanPaths {
+		if writeError := rename.WritePlanFile(environment.FileSystem, planFilePath, *environment.renamePlans[planFilePath]); writeError != nil {
+			return writeError
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/repos/filesystem"
+	"github.com/temirov/gix/internal/repos/rename"
+)
+
+func TestRenameOperationWritesPlanFile(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		dryRun        bool
+		expectRenamed bool
+	}{
+		{name: "dry_run_records_planned_renames", dryRun: true},
+		{name: "applied_renames_recorded", expectRenamed: true},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			root := subtest.TempDir()
+			repositoryPath := filepath.Join(root, "old-name")
+			targetPath := filepath.Join(root, "new-name")
+			require.NoError(subtest, os.MkdirAll(filepath.Join(repositoryPath, ".git"), 0o755))
+			planFilePath := filepath.Join(root, "plan.yaml")
+
+			gitExecutor := execshelltest.NewPermissiveExecutor()
+			gitExecutor.OnGit("remote", "get-url", "origin").Return(execshell.ExecutionResult{StandardOutput: "https://github.com/owner/new-name.git\n"})
+			repositoryManager, managerError := gitrepo.NewRepositoryManager(gitExecutor)
+			require.NoError(subtest, managerError)
+			environment := &Environment{
+				Output:            &strings.Builder{},
+				FileSystem:        filesystem.OSFileSystem{},
+				RepositoryManager: repositoryManager,
+				PromptState:       NewPromptState(true),
+				AuditService: audit.NewService(
+					&stubRepositoryDiscoverer{repositories: []string{targetPath}},
+					&stubGitRepositoryManager{remoteURL: "https://github.com/owner/new-name.git"},
+					&stubGitExecutor{},
+					&stubGitHubMetadataResolver{},
+					&bytes.Buffer{},
+					&bytes.Buffer{},
+				),
+				DryRun: testCase.dryRun,
+			}
+			state := &State{Repositories: []*RepositoryState{NewRepositoryState(audit.RepositoryInspection{
+				Path:              repositoryPath,
+				FolderName:        "old-name",
+				DesiredFolderName: "new-name",
+				FinalOwnerRepo:    "owner/new-name",
+			})}}
+
+			operation := &RenameOperation{PlanFilePath: planFilePath}
+			require.NoError(subtest, operation.Execute(context.Background(), environment, state))
+			require.NoError(subtest, writeRenamePlans(environment))
+
+			plan, readError := rename.ReadPlanFile(filesystem.OSFileSystem{}, planFilePath)
+			require.NoError(subtest, readError)
+			require.Equal(subtest, []rename.PlanEntry{{Source: repositoryPath, Target: targetPath, Origin: "https://github.com/owner/new-name.git"}}, plan.Renames)
+
+			_, targetError := os.Stat(targetPath)
+			require.Equal(subtest, testCase.expectRenamed, targetError == nil)
+		})
+	}
+}
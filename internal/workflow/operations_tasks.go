@@ -10,10 +10,12 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"text/template"
+	"time"
 
 	"github.com/temirov/gix/internal/execshell"
 	"github.com/temirov/gix/internal/githubcli"
+	"github.com/temirov/gix/internal/gitrepo"
+	"github.com/temirov/gix/internal/repos/selection"
 )
 
 const (
@@ -80,13 +82,24 @@ func (operation *TaskOperation) Definitions() []TaskDefinition {
 
 // TaskDefinition describes a single repository task.
 type TaskDefinition struct {
-	Name        string
-	EnsureClean bool
-	Branch      TaskBranchDefinition
-	Files       []TaskFileDefinition
-	Actions     []TaskActionDefinition
-	Commit      TaskCommitDefinition
-	PullRequest *TaskPullRequestDefinition
+	Name             string
+	EnsureClean      bool
+	Branch           TaskBranchDefinition
+	Files            []TaskFileDefinition
+	Actions          []TaskActionDefinition
+	Commit           TaskCommitDefinition
+	PullRequest      *TaskPullRequestDefinition
+	RepositoryFilter selection.Matcher
+	// Timeout bounds the task on one repository; zero means unlimited.
+	Timeout time.Duration
+	// Environment holds templated variables added to every command the task runs on a repository.
+	Environment map[string]string
+	// StepID names the workflow step the task belongs to; outputs the task publishes are stored under it.
+	StepID string
+	// StepReferences lists the outputs of earlier steps that the task's templates read.
+	StepReferences []StepOutputReference
+	// StageOnly stages file changes on the current branch and leaves committing to a later commit step.
+	StageOnly bool
 }
 
 // TaskBranchDefinition describes branch behavior for a task.
@@ -124,10 +137,18 @@ type TaskPullRequestDefinition struct {
 }
 
 // TaskTemplateData exposes templating values for task rendering.
+// Path, Owner, Name, DefaultBranch, and OriginURL mirror the repository fields for concise step templates.
+// Steps holds the outputs earlier identified steps published for the repository, keyed by step id.
 type TaskTemplateData struct {
-	Task        TaskDefinition
-	Repository  TaskRepositoryTemplateData
-	Environment map[string]string
+	Task          TaskDefinition
+	Repository    TaskRepositoryTemplateData
+	Environment   map[string]string
+	Steps         map[string]StepOutputs
+	Path          string
+	Owner         string
+	Name          string
+	DefaultBranch string
+	OriginURL     string
 }
 
 // TaskRepositoryTemplateData provides repository metadata for templating.
@@ -137,6 +158,7 @@ type TaskRepositoryTemplateData struct {
 	Name                  string
 	FullName              string
 	DefaultBranch         string
+	OriginURL             string
 	PathDepth             int
 	InitialClean          bool
 	HasNestedRepositories bool
@@ -154,14 +176,67 @@ func (operation *TaskOperation) Execute(executionContext context.Context, enviro
 	}
 
 	for _, repository := range state.Repositories {
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			return cancellationError
+		}
 		if repository == nil {
 			continue
 		}
-		for _, task := range operation.tasks {
-			if err := operation.executeTask(executionContext, environment, repository, task); err != nil {
-				return err
+		if repositoryError := operation.executeRepositoryTasks(executionContext, environment, repository); repositoryError != nil {
+			return repositoryError
+		}
+	}
+
+	return nil
+}
+
+func (operation *TaskOperation) executeRepositoryTasks(executionContext context.Context, environment *Environment, repository *RepositoryState) error {
+	repositoryContext, cancelRepository := withOptionalTimeout(executionContext, environment.RepositoryTimeout)
+	defer cancelRepository()
+
+	workingBranch, proceed, workingBranchError := environment.enterWorkingBranch(repositoryContext, repository, operation.tasks)
+	if workingBranchError != nil || !proceed {
+		return workingBranchError
+	}
+	tasksError := operation.executeRepositoryTaskSequence(executionContext, repositoryContext, environment, repository)
+	return workingBranch.leave(repositoryContext, tasksError)
+}
+
+func (operation *TaskOperation) executeRepositoryTaskSequence(executionContext context.Context, repositoryContext context.Context, environment *Environment, repository *RepositoryState) error {
+	for _, task := range operation.tasks {
+		if !repositoryMatchesFilter(task.RepositoryFilter, repository) {
+			recordFilterSkip(environment, task.Name, repository)
+			repository.markStepSkipped(task.StepID)
+			continue
+		}
+		if errors.Is(repositoryContext.Err(), context.DeadlineExceeded) && executionContext.Err() == nil {
+			return fmt.Errorf(repositoryTimeoutExhaustedTemplateConstant, repository.Path, environment.RepositoryTimeout, task.Name, context.DeadlineExceeded)
+		}
+		if referenceError := repository.checkStepReferences(task); referenceError != nil {
+			return referenceError
+		}
+
+		taskEnvironment, environmentError := renderStepEnvironment(task.Name, task.Environment, repository)
+		if environmentError != nil {
+			return environmentError
+		}
+
+		outputRecorder := &stepOutputRecorder{}
+		taskContext, cancelTask := withOptionalTimeout(withStepOutputRecorder(execshell.WithEnvironment(repositoryContext, taskEnvironment), outputRecorder), task.Timeout)
+		taskError := operation.executeTask(taskContext, environment, repository, task)
+		taskDeadlineExceeded := errors.Is(taskContext.Err(), context.DeadlineExceeded)
+		cancelTask()
+		if taskError == nil {
+			environment.recordStepOutputs(repository, task.StepID, outputRecorder.outputs)
+			continue
+		}
+		if taskDeadlineExceeded && executionContext.Err() == nil {
+			if errors.Is(repositoryContext.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf(repositoryTimedOutTemplateConstant, repository.Path, environment.RepositoryTimeout, context.DeadlineExceeded)
 			}
+			return fmt.Errorf(taskTimedOutTemplateConstant, task.Name, task.Timeout, repository.Path, context.DeadlineExceeded)
 		}
+		return taskError
 	}
 
 	return nil
@@ -176,8 +251,17 @@ func (operation *TaskOperation) executeTask(executionContext context.Context, en
 		return planError
 	}
 
+	if workingBranch, onWorkingBranch := environment.activeWorkingBranch(repository.Path); onWorkingBranch && !taskDeclaresOwnBranch(task) {
+		plan.branchName = workingBranch
+		plan.startPoint = ""
+		plan.onWorkingBranch = true
+	}
+
 	if environment.DryRun {
 		plan.describe(environment, taskLogPrefixPlan)
+		if task.StageOnly && hasApplicableChanges(plan.fileChanges) {
+			environment.recordStagedContribution(repository.Path, task.Name)
+		}
 		if len(plan.actions) > 0 {
 			actionExecutor := newTaskActionExecutor(environment)
 			for _, action := range plan.actions {
@@ -190,6 +274,12 @@ func (operation *TaskOperation) executeTask(executionContext context.Context, en
 	}
 
 	executor := newTaskExecutor(environment, repository, plan)
+	if task.StageOnly {
+		return executor.ExecuteStageOnly(executionContext)
+	}
+	if plan.onWorkingBranch {
+		return executor.ExecuteOnWorkingBranch(executionContext)
+	}
 	return executor.Execute(executionContext)
 }
 
@@ -478,6 +568,8 @@ func buildTaskTemplateData(repository *RepositoryState, task TaskDefinition) Tas
 		defaultBranch = strings.TrimSpace(repository.Inspection.LocalBranch)
 	}
 
+	originURL := strings.TrimSpace(repository.Inspection.OriginURL)
+
 	return TaskTemplateData{
 		Task: task,
 		Repository: TaskRepositoryTemplateData{
@@ -486,11 +578,18 @@ func buildTaskTemplateData(repository *RepositoryState, task TaskDefinition) Tas
 			Name:                  name,
 			FullName:              repository.Inspection.FinalOwnerRepo,
 			DefaultBranch:         defaultBranch,
+			OriginURL:             originURL,
 			PathDepth:             repository.PathDepth,
 			InitialClean:          repository.InitialCleanWorktree,
 			HasNestedRepositories: repository.HasNestedRepositories,
 		},
-		Environment: map[string]string{},
+		Environment:   map[string]string{},
+		Steps:         repository.stepOutputsTemplateData(),
+		Path:          repository.Path,
+		Owner:         owner,
+		Name:          name,
+		DefaultBranch: defaultBranch,
+		OriginURL:     originURL,
 	}
 }
 
@@ -519,6 +618,8 @@ type taskPlan struct {
 	actions       []taskAction
 	skipReason    string
 	skipped       bool
+	// onWorkingBranch commits the task's changes on the checked-out workflow branch instead of a task branch.
+	onWorkingBranch bool
 }
 
 type taskPlanPullRequest struct {
@@ -682,16 +783,11 @@ func (planner taskPlanner) planActions() ([]taskAction, error) {
 			if len(normalizedKey) == 0 {
 				continue
 			}
-			switch typedValue := value.(type) {
-			case string:
-				rendered, renderError := planner.renderTemplate(typedValue, "")
-				if renderError != nil {
-					return nil, renderError
-				}
-				parameters[normalizedKey] = strings.TrimSpace(rendered)
-			default:
-				parameters[normalizedKey] = typedValue
+			renderedValue, renderError := planner.renderOptionValue(value)
+			if renderError != nil {
+				return nil, renderError
 			}
+			parameters[normalizedKey] = renderedValue
 		}
 
 		planned = append(planned, taskAction{
@@ -735,7 +831,7 @@ func (planner taskPlanner) renderTemplate(rawTemplate string, fallback string) (
 		return fallback, nil
 	}
 
-	tmpl, parseError := template.New("task").Parse(trimmed)
+	tmpl, parseError := parseWorkflowTemplate(trimmed)
 	if parseError != nil {
 		return "", parseError
 	}
@@ -747,6 +843,39 @@ func (planner taskPlanner) renderTemplate(rawTemplate string, fallback string) (
 	return buffer.String(), nil
 }
 
+func (planner taskPlanner) renderOptionValue(value any) (any, error) {
+	switch typedValue := value.(type) {
+	case string:
+		rendered, renderError := planner.renderTemplate(typedValue, "")
+		if renderError != nil {
+			return nil, renderError
+		}
+		return strings.TrimSpace(rendered), nil
+	case []string:
+		renderedValues := make([]string, 0, len(typedValue))
+		for _, element := range typedValue {
+			rendered, renderError := planner.renderTemplate(element, "")
+			if renderError != nil {
+				return nil, renderError
+			}
+			renderedValues = append(renderedValues, strings.TrimSpace(rendered))
+		}
+		return renderedValues, nil
+	case []any:
+		renderedValues := make([]any, 0, len(typedValue))
+		for _, element := range typedValue {
+			rendered, renderError := planner.renderOptionValue(element)
+			if renderError != nil {
+				return nil, renderError
+			}
+			renderedValues = append(renderedValues, rendered)
+		}
+		return renderedValues, nil
+	default:
+		return typedValue, nil
+	}
+}
+
 func (planner taskPlanner) defaultBranchName() string {
 	defaultName := strings.TrimSpace(planner.task.Name)
 	if len(defaultName) == 0 {
@@ -955,9 +1084,108 @@ func (executor taskExecutor) Execute(executionContext context.Context) error {
 	return nil
 }
 
+// ExecuteStageOnly writes and stages the task's file changes on the current branch without committing them, and
+// records the task as a contributor for the commit step.
+func (executor taskExecutor) ExecuteStageOnly(executionContext context.Context) error {
+	if executor.environment == nil {
+		return nil
+	}
+
+	if executor.plan.skipped {
+		executor.plan.describe(executor.environment, taskLogPrefixNoop)
+		return nil
+	}
+
+	hasFileChanges := hasApplicableChanges(executor.plan.fileChanges)
+	hasActions := len(executor.plan.actions) > 0
+	hasStagedContributions := len(executor.environment.stagedContributions(executor.repository.Path)) > 0
+
+	if executor.plan.task.EnsureClean && !hasStagedContributions {
+		clean, cleanError := executor.environment.RepositoryManager.CheckCleanWorktree(executionContext, executor.repository.Path)
+		if cleanError != nil {
+			return cleanError
+		}
+		if !clean {
+			executor.logf(taskLogPrefixSkip, "repository dirty", nil)
+			return nil
+		}
+	}
+
+	if hasFileChanges {
+		if err := executor.applyFileChanges(); err != nil {
+			return err
+		}
+		if err := executor.stageChanges(executionContext); err != nil {
+			return err
+		}
+		executor.environment.recordStagedContribution(executor.repository.Path, executor.plan.task.Name)
+	}
+
+	if hasActions {
+		if err := executor.executeActions(executionContext); err != nil {
+			return err
+		}
+	}
+
+	if hasFileChanges {
+		executor.logf(taskLogPrefixApply, "staged", nil)
+	}
+	return nil
+}
+
+// ExecuteOnWorkingBranch writes and commits the task's file changes on the checked-out workflow branch. Pushing and
+// the pull request are left to the workflow branch once every task of the repository ran.
+func (executor taskExecutor) ExecuteOnWorkingBranch(executionContext context.Context) error {
+	if executor.environment == nil {
+		return nil
+	}
+
+	if executor.plan.skipped {
+		executor.plan.describe(executor.environment, taskLogPrefixNoop)
+		return nil
+	}
+
+	hasFileChanges := hasApplicableChanges(executor.plan.fileChanges)
+	hasActions := len(executor.plan.actions) > 0
+
+	if executor.plan.task.EnsureClean {
+		clean, cleanError := executor.environment.RepositoryManager.CheckCleanWorktree(executionContext, executor.repository.Path)
+		if cleanError != nil {
+			return cleanError
+		}
+		if !clean {
+			executor.logf(taskLogPrefixSkip, "repository dirty", nil)
+			return nil
+		}
+	}
+
+	if hasFileChanges {
+		if err := executor.applyFileChanges(); err != nil {
+			return err
+		}
+		if err := executor.stageChanges(executionContext); err != nil {
+			return err
+		}
+		if err := executor.commitChanges(executionContext); err != nil {
+			return err
+		}
+	}
+
+	if hasActions {
+		if err := executor.executeActions(executionContext); err != nil {
+			return err
+		}
+	}
+
+	if hasFileChanges {
+		executor.logf(taskLogPrefixApply, "applied", map[string]any{"branch": executor.plan.branchName})
+	}
+	return nil
+}
+
 func (executor taskExecutor) branchExists(executionContext context.Context, branchName string) (bool, error) {
 	arguments := []string{"rev-parse", "--verify", branchName}
-	_, err := executor.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: arguments, WorkingDirectory: executor.repository.Path})
+	_, err := executor.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: arguments, WorkingDirectory: executor.repository.Path, Idempotent: true})
 	if err == nil {
 		return true, nil
 	}
@@ -973,7 +1201,7 @@ func (executor taskExecutor) checkoutBranch(executionContext context.Context, br
 		return nil
 	}
 	arguments := []string{"checkout", branch}
-	_, err := executor.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: arguments, WorkingDirectory: executor.repository.Path})
+	_, err := executor.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: arguments, WorkingDirectory: executor.repository.Path, Idempotent: false})
 	return err
 }
 
@@ -982,7 +1210,7 @@ func (executor taskExecutor) checkoutOrCreateTaskBranch(executionContext context
 	if len(strings.TrimSpace(executor.plan.startPoint)) > 0 {
 		arguments = append(arguments, executor.plan.startPoint)
 	}
-	_, err := executor.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: arguments, WorkingDirectory: executor.repository.Path})
+	_, err := executor.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: arguments, WorkingDirectory: executor.repository.Path, Idempotent: false})
 	return err
 }
 
@@ -1008,7 +1236,7 @@ func (executor taskExecutor) stageChanges(executionContext context.Context) erro
 		if !change.apply {
 			continue
 		}
-		_, err := executor.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: []string{"add", change.relativePath}, WorkingDirectory: executor.repository.Path})
+		_, err := executor.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: []string{"add", change.relativePath}, WorkingDirectory: executor.repository.Path, Idempotent: false})
 		if err != nil {
 			return err
 		}
@@ -1017,14 +1245,14 @@ func (executor taskExecutor) stageChanges(executionContext context.Context) erro
 }
 
 func (executor taskExecutor) commitChanges(executionContext context.Context) error {
-	arguments := []string{"commit", "-m", executor.plan.commitMessage}
-	_, err := executor.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: arguments, WorkingDirectory: executor.repository.Path})
+	arguments := gitrepo.CommitArguments(gitrepo.CommitOptions{Message: executor.plan.commitMessage, NoVerify: !gitrepo.CommitHooksEnabled(executionContext)})
+	_, err := executor.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: arguments, WorkingDirectory: executor.repository.Path, Idempotent: false})
 	return err
 }
 
 func (executor taskExecutor) pushBranch(executionContext context.Context) error {
 	arguments := []string{"push", "--set-upstream", executor.plan.task.Branch.PushRemote, executor.plan.branchName}
-	_, err := executor.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: arguments, WorkingDirectory: executor.repository.Path})
+	_, err := executor.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: arguments, WorkingDirectory: executor.repository.Path, Idempotent: false})
 	return err
 }
 
@@ -1092,7 +1320,7 @@ func createPullRequest(executionContext context.Context, environment *Environmen
 		return errors.New("GitHub client not configured for task execution")
 	}
 
-	return environment.GitHubClient.CreatePullRequest(executionContext, githubcli.PullRequestCreateOptions{
+	_, createError := environment.GitHubClient.CreatePullRequest(executionContext, githubcli.PullRequestCreateOptions{
 		Repository: options.Repository,
 		Title:      options.Title,
 		Body:       options.Body,
@@ -1100,4 +1328,5 @@ func createPullRequest(executionContext context.Context, environment *Environmen
 		Head:       options.Head,
 		Draft:      options.Draft,
 	})
+	return createError
 }
@@ -108,6 +108,62 @@ func TestTaskPlannerBuildPlanSupportsActions(testInstance *testing.T) {
 	require.Equal(testInstance, true, action.parameters["dryrun"])
 }
 
+func TestTaskPlannerRendersStepTemplateContext(testInstance *testing.T) {
+	inspection := audit.RepositoryInspection{
+		Path:                "/repositories/sample",
+		OriginURL:           "git@github.com:octocat/sample.git",
+		FinalOwnerRepo:      "octocat/sample",
+		RemoteDefaultBranch: "main",
+	}
+	repository := NewRepositoryState(inspection)
+
+	testCases := []struct {
+		name          string
+		optionValue   any
+		expectedValue any
+	}{
+		{
+			name:          "owner_and_name",
+			optionValue:   "gh repo edit {{ .Owner }}/{{ .Name }} --add-topic migrated",
+			expectedValue: "gh repo edit octocat/sample --add-topic migrated",
+		},
+		{
+			name:          "path_branch_and_origin",
+			optionValue:   "{{ .Path }} {{ .DefaultBranch }} {{ .OriginURL }}",
+			expectedValue: "/repositories/sample main git@github.com:octocat/sample.git",
+		},
+		{
+			name:          "escaped_delimiter_is_literal",
+			optionValue:   `\{{ literal }} {{ .Name }}`,
+			expectedValue: "{{ literal }} sample",
+		},
+		{
+			name:          "list_values_are_rendered",
+			optionValue:   []any{"{{ .Name }}.md", 3},
+			expectedValue: []any{"sample.md", 3},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			taskDefinition := TaskDefinition{
+				Name: "Templated",
+				Actions: []TaskActionDefinition{{
+					Type:    "repo.remote.update",
+					Options: map[string]any{"value": testCase.optionValue},
+				}},
+			}
+
+			planner := newTaskPlanner(taskDefinition, buildTaskTemplateData(repository, taskDefinition))
+			plan, planError := planner.BuildPlan(&Environment{FileSystem: newFakeFileSystem(nil)}, repository)
+			require.NoError(subtest, planError)
+			require.Len(subtest, plan.actions, 1)
+			require.Equal(subtest, testCase.expectedValue, plan.actions[0].parameters["value"])
+		})
+	}
+}
+
 func TestTaskExecutorExecuteActionsUnknownType(testInstance *testing.T) {
 	repository := NewRepositoryState(audit.RepositoryInspection{Path: "/repositories/sample"})
 	environment := &Environment{DryRun: true}
@@ -415,7 +471,7 @@ func TestTaskExecutorAppliesChanges(testInstance *testing.T) {
 		{"checkout", "main"},
 		{"checkout", "-B", "feature-sample-docs", "main"},
 		{"add", "docs/sample.md"},
-		{"commit", "-m", "docs: update Add Docs"},
+		{"commit", "--no-verify", "-m", "docs: update Add Docs"},
 		{"push", "--set-upstream", "origin", "feature-sample-docs"},
 		{"checkout", "master"},
 	}
@@ -590,3 +646,11 @@ func testBranchCleanupHandler(ctx context.Context, environment *Environment, rep
 
 	return nil
 }
+
+func TestTaskPlannerPassesEscapedOptionsThrough(testInstance *testing.T) {
+	planner := newTaskPlanner(TaskDefinition{}, TaskTemplateData{Owner: "octocat", Name: "sample"})
+
+	rendered, renderError := planner.renderOptionValue(EscapeTemplateDelimiters("{{.Owner}}__{{.Host}}"))
+	require.NoError(testInstance, renderError)
+	require.Equal(testInstance, "{{.Owner}}__{{.Host}}", rendered)
+}
@@ -0,0 +1,91 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultStepTimeout bounds one workflow step on one repository when the step does not set timeout.
+	DefaultStepTimeout = 10 * time.Minute
+	// DefaultRepositoryTimeout bounds all workflow steps on one repository when the workflow does not set repository.timeout.
+	DefaultRepositoryTimeout = time.Hour
+
+	stepTimeoutInvalidTemplateConstant         = "workflow step %s has invalid timeout %q: %w"
+	repositoryTimeoutInvalidTemplateConstant   = "workflow repository timeout %q is invalid: %w"
+	timeoutNegativeMessageConstant             = "timeout must not be negative"
+	stepTimedOutTemplateConstant               = "workflow step %s timed out after %s: %w"
+	taskTimedOutTemplateConstant               = "task %q timed out after %s for %s: %w"
+	repositoryTimedOutTemplateConstant         = "repository %s timed out after %s: %w"
+	repositoryTimeoutExhaustedTemplateConstant = "repository %s timed out after %s before task %q started: %w"
+)
+
+// TimedOperation bounds a workflow operation with the step's timeout.
+type TimedOperation struct {
+	operation Operation
+	timeout   time.Duration
+}
+
+func applyStepTimeout(operation Operation, step StepConfiguration) (Operation, error) {
+	trimmedTimeout := strings.TrimSpace(step.Timeout)
+	if len(trimmedTimeout) == 0 {
+		return operation, nil
+	}
+	timeout, parseError := parseWorkflowTimeout(trimmedTimeout)
+	if parseError != nil {
+		return nil, fmt.Errorf(stepTimeoutInvalidTemplateConstant, step.Operation, step.Timeout, parseError)
+	}
+	return &TimedOperation{operation: operation, timeout: timeout}, nil
+}
+
+func parseWorkflowTimeout(value string) (time.Duration, error) {
+	timeout, parseError := time.ParseDuration(strings.TrimSpace(value))
+	if parseError != nil {
+		return 0, parseError
+	}
+	if timeout < 0 {
+		return 0, errors.New(timeoutNegativeMessageConstant)
+	}
+	return timeout, nil
+}
+
+// Name returns the wrapped operation name.
+func (operation *TimedOperation) Name() string {
+	return operation.operation.Name()
+}
+
+// Unwrap returns the operation bounded by the timeout.
+func (operation *TimedOperation) Unwrap() Operation {
+	return operation.operation
+}
+
+// Timeout returns the configured step timeout; zero means unlimited.
+func (operation *TimedOperation) Timeout() time.Duration {
+	return operation.timeout
+}
+
+// Execute runs the wrapped operation under a context deadline and reports a deadline overrun as a timeout.
+func (operation *TimedOperation) Execute(executionContext context.Context, environment *Environment, state *State) error {
+	if operation.timeout <= 0 {
+		return operation.operation.Execute(executionContext, environment, state)
+	}
+
+	stepContext, cancel := context.WithTimeout(executionContext, operation.timeout)
+	defer cancel()
+
+	executionError := operation.operation.Execute(stepContext, environment, state)
+	if executionError != nil && errors.Is(stepContext.Err(), context.DeadlineExceeded) && executionContext.Err() == nil {
+		return fmt.Errorf(stepTimedOutTemplateConstant, operation.Name(), operation.timeout, context.DeadlineExceeded)
+	}
+	return executionError
+}
+
+func withOptionalTimeout(executionContext context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(executionContext)
+	}
+	return context.WithTimeout(executionContext, timeout)
+}
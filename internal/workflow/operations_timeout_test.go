@@ -0,0 +1,220 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+)
+
+const testTimeoutActionTypeConstant = "test.action.timeout"
+
+type blockingOperation struct{}
+
+func (operation *blockingOperation) Name() string {
+	return "blocking"
+}
+
+func (operation *blockingOperation) Execute(executionContext context.Context, _ *Environment, _ *State) error {
+	<-executionContext.Done()
+	return executionContext.Err()
+}
+
+func TestTaskOperationEnforcesTimeouts(testInstance *testing.T) {
+	originalHandler, handlerExists := taskActionHandlers[testTimeoutActionTypeConstant]
+	RegisterTaskAction(testTimeoutActionTypeConstant, func(executionContext context.Context, _ *Environment, _ *RepositoryState, parameters map[string]any) error {
+		if blocking, _ := parameters["block"].(bool); !blocking {
+			return nil
+		}
+		<-executionContext.Done()
+		return executionContext.Err()
+	})
+	defer func() {
+		if handlerExists {
+			taskActionHandlers[testTimeoutActionTypeConstant] = originalHandler
+		} else {
+			delete(taskActionHandlers, testTimeoutActionTypeConstant)
+		}
+	}()
+
+	testCases := []struct {
+		name              string
+		block             bool
+		taskTimeout       time.Duration
+		repositoryTimeout time.Duration
+		expectedError     string
+	}{
+		{
+			name:              "task_timeout",
+			block:             true,
+			taskTimeout:       20 * time.Millisecond,
+			repositoryTimeout: time.Minute,
+			expectedError:     `task "Hang" timed out after 20ms for /repositories/sample: context deadline exceeded`,
+		},
+		{
+			name:              "repository_timeout",
+			block:             true,
+			repositoryTimeout: 20 * time.Millisecond,
+			expectedError:     "repository /repositories/sample timed out after 20ms: context deadline exceeded",
+		},
+		{
+			name:        "fast_task_within_timeout",
+			taskTimeout: time.Minute,
+		},
+		{
+			name: "zero_timeouts_are_unlimited",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			operation := &TaskOperation{tasks: []TaskDefinition{{
+				Name:    "Hang",
+				Actions: []TaskActionDefinition{{Type: testTimeoutActionTypeConstant, Options: map[string]any{"block": testCase.block}}},
+				Timeout: testCase.taskTimeout,
+			}}}
+			environment := &Environment{Output: &bytes.Buffer{}, DryRun: true, RepositoryTimeout: testCase.repositoryTimeout}
+			state := &State{Repositories: []*RepositoryState{NewRepositoryState(audit.RepositoryInspection{Path: "/repositories/sample"})}}
+
+			executionError := operation.Execute(context.Background(), environment, state)
+			if len(testCase.expectedError) == 0 {
+				require.NoError(subtest, executionError)
+				return
+			}
+			require.EqualError(subtest, executionError, testCase.expectedError)
+			require.ErrorIs(subtest, executionError, context.DeadlineExceeded)
+		})
+	}
+}
+
+func TestTaskOperationStopsBetweenRepositoriesWhenCancelled(testInstance *testing.T) {
+	originalHandler, handlerExists := taskActionHandlers[testTimeoutActionTypeConstant]
+	var visitedRepositories []string
+	runContext, cancelRun := context.WithCancel(context.Background())
+	defer cancelRun()
+	RegisterTaskAction(testTimeoutActionTypeConstant, func(_ context.Context, _ *Environment, repository *RepositoryState, _ map[string]any) error {
+		visitedRepositories = append(visitedRepositories, repository.Path)
+		cancelRun()
+		return nil
+	})
+	defer func() {
+		if handlerExists {
+			taskActionHandlers[testTimeoutActionTypeConstant] = originalHandler
+		} else {
+			delete(taskActionHandlers, testTimeoutActionTypeConstant)
+		}
+	}()
+
+	operation := &TaskOperation{tasks: []TaskDefinition{{
+		Name:    "Visit",
+		Actions: []TaskActionDefinition{{Type: testTimeoutActionTypeConstant}},
+	}}}
+	environment := &Environment{Output: &bytes.Buffer{}, DryRun: true}
+	state := &State{Repositories: []*RepositoryState{
+		NewRepositoryState(audit.RepositoryInspection{Path: "/repositories/first"}),
+		NewRepositoryState(audit.RepositoryInspection{Path: "/repositories/second"}),
+	}}
+
+	executionError := operation.Execute(runContext, environment, state)
+	require.ErrorIs(testInstance, executionError, context.Canceled)
+	require.Equal(testInstance, []string{"/repositories/first"}, visitedRepositories)
+}
+
+func TestTimedOperationReportsStepTimeout(testInstance *testing.T) {
+	operation, wrapError := applyStepTimeout(&blockingOperation{}, StepConfiguration{Operation: OperationTypeApplyTasks, Timeout: "20ms"})
+	require.NoError(testInstance, wrapError)
+	timedOperation, isTimed := operation.(*TimedOperation)
+	require.True(testInstance, isTimed)
+	require.Equal(testInstance, 20*time.Millisecond, timedOperation.Timeout())
+
+	executionError := operation.Execute(context.Background(), &Environment{}, &State{})
+	require.EqualError(testInstance, executionError, "workflow step blocking timed out after 20ms: context deadline exceeded")
+}
+
+func TestApplyStepTimeoutValidation(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		timeout         string
+		expectedWrapped bool
+		expectedTimeout time.Duration
+		expectedError   string
+	}{
+		{name: "blank_keeps_default", timeout: " "},
+		{name: "zero_is_unlimited", timeout: "0", expectedWrapped: true},
+		{name: "duration", timeout: "90s", expectedWrapped: true, expectedTimeout: 90 * time.Second},
+		{name: "malformed", timeout: "soon", expectedError: `workflow step apply-tasks has invalid timeout "soon": time: invalid duration "soon"`},
+		{name: "negative", timeout: "-1m", expectedError: `workflow step apply-tasks has invalid timeout "-1m": timeout must not be negative`},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			baseOperation := &blockingOperation{}
+			operation, wrapError := applyStepTimeout(baseOperation, StepConfiguration{Operation: OperationTypeApplyTasks, Timeout: testCase.timeout})
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, wrapError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, wrapError)
+			timedOperation, isTimed := operation.(*TimedOperation)
+			require.Equal(subtest, testCase.expectedWrapped, isTimed)
+			if isTimed {
+				require.Equal(subtest, testCase.expectedTimeout, timedOperation.Timeout())
+				require.Same(subtest, baseOperation, timedOperation.Unwrap())
+			}
+		})
+	}
+}
+
+func TestLoadConfigurationRepositoryTimeout(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		contents        string
+		expectedTimeout time.Duration
+		expectedError   string
+	}{
+		{
+			name:            "default",
+			contents:        "workflow:\n  - step:\n      operation: audit-report\n",
+			expectedTimeout: DefaultRepositoryTimeout,
+		},
+		{
+			name:            "configured",
+			contents:        "repository:\n  timeout: 45m\nworkflow:\n  - step:\n      operation: audit-report\n      timeout: 2m\n",
+			expectedTimeout: 45 * time.Minute,
+		},
+		{
+			name:            "unlimited",
+			contents:        "repository:\n  timeout: 0s\nworkflow:\n  - step:\n      operation: audit-report\n",
+			expectedTimeout: 0,
+		},
+		{
+			name:          "invalid",
+			contents:      "repository:\n  timeout: forever\nworkflow:\n  - step:\n      operation: audit-report\n",
+			expectedError: `failed to parse workflow configuration: workflow repository timeout "forever" is invalid: time: invalid duration "forever"`,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			configurationPath := filepath.Join(subtest.TempDir(), "workflow.yaml")
+			require.NoError(subtest, os.WriteFile(configurationPath, []byte(testCase.contents), 0o600))
+
+			configuration, loadError := LoadConfiguration(configurationPath)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, loadError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, loadError)
+			require.Equal(subtest, testCase.expectedTimeout, configuration.RepositoryTimeout)
+		})
+	}
+}
@@ -17,7 +17,29 @@ const (
 	optionTargetBranchKeyConstant       = "target_branch"
 	optionPushToRemoteKeyConstant       = "push_to_remote"
 	optionDeleteSourceBranchKeyConstant = "delete_source_branch"
+	optionRetainSourceKeyConstant       = "retain_source"
+	optionLeaveTombstoneKeyConstant     = "leave_tombstone"
+	optionUpdateDocsKeyConstant         = "update_docs"
+	optionDocsPatternsKeyConstant       = "docs_patterns"
+	optionWriteInstructionsKeyConstant  = "write_instructions"
+	optionPhaseKeyConstant              = "phase"
+	optionGracePeriodKeyConstant        = "grace_period"
+	optionOverridesKeyConstant          = "overrides"
+	optionRenameDirectoryKeyConstant    = "rename_directory"
+	optionIncludePushURLKeyConstant     = "include_push_url"
+	optionEraseCredentialsKeyConstant   = "erase_stale_credentials"
 	optionOutputPathKeyConstant         = "output"
+	optionPlanFileKeyConstant           = "plan_file"
+	optionNamingTemplateKeyConstant     = "naming_template"
+	optionFailOnNestedKeyConstant       = "fail_on_nested"
+	optionSortKeyConstant               = "sort"
+	optionReportFormatKeyConstant       = "format"
+	optionMinimumScoreKeyConstant       = "min_score"
+	optionScoreWeightsKeyConstant       = "score_weights"
+	optionIdentityRulesKeyConstant      = "identity_rules"
+	optionAddTopicsKeyConstant          = "add_topics"
+	optionRemoveTopicsKeyConstant       = "remove_topics"
+	optionDescriptionKeyConstant        = "description"
 )
 
 type optionReader struct {
@@ -100,3 +122,32 @@ func (reader optionReader) mapValue(key string) (map[string]any, bool, error) {
 	}
 	return typed, true, nil
 }
+
+func (reader optionReader) stringSliceValue(key string) ([]string, bool, error) {
+	value, exists := reader.entries[key]
+	if !exists {
+		return nil, false, nil
+	}
+	switch typed := value.(type) {
+	case string:
+		return []string{strings.TrimSpace(typed)}, true, nil
+	case []string:
+		values := make([]string, 0, len(typed))
+		for _, entry := range typed {
+			values = append(values, strings.TrimSpace(entry))
+		}
+		return values, true, nil
+	case []any:
+		values := make([]string, 0, len(typed))
+		for _, entry := range typed {
+			stringEntry, isString := entry.(string)
+			if !isString {
+				return nil, true, fmt.Errorf("option %s entries must be strings", key)
+			}
+			values = append(values, strings.TrimSpace(stringEntry))
+		}
+		return values, true, nil
+	default:
+		return nil, true, fmt.Errorf("option %s must be a list of strings", key)
+	}
+}
@@ -0,0 +1,241 @@
+package workflow
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	planLabelSeparatorConstant           = ":"
+	planLabelMarkerConstant              = "PLAN"
+	planLineSeparatorConstant            = "\n"
+	planOpaqueCommandMarkerConstant      = "command="
+	planOpaqueLabelConstant              = "REPLACE-COMMAND-PLAN"
+	planOpaqueEntryTemplateConstant      = "opaque: would run %s"
+	planRepositoryHeaderTemplate         = "WORKFLOW-PLAN-REPOSITORY: %s (%d planned action(s))\n"
+	planWorkflowHeaderTemplate           = "WORKFLOW-PLAN-GLOBAL: %d planned action(s) not tied to one repository\n"
+	planEntryTemplate                    = "  %d. %s\n"
+	planTotalTemplate                    = "WORKFLOW-PLAN-TOTAL: %d planned action(s) across %d repository(ies), %d opaque\n"
+	planRepositoryPathBoundaryCharacters = " \t:()|,;"
+)
+
+// RepositoryObserver is notified of the repositories a workflow run inspected before any operation executes. It may be
+// notified more than once with the same paths when a sink serves as both output and reporter.
+type RepositoryObserver interface {
+	ObserveRepositories(repositoryPaths []string)
+}
+
+// PlanEntry is one planned action captured from a dry run.
+type PlanEntry struct {
+	Label       string
+	Description string
+	Opaque      bool
+}
+
+// RepositoryPlan lists the planned actions for one repository in the order the steps produced them.
+type RepositoryPlan struct {
+	RepositoryPath string
+	Entries        []PlanEntry
+}
+
+// PlanCollector captures the PLAN lines that dry-run steps write to their output or reporter and groups them by
+// repository. Lines without a PLAN label are passed through to the pass-through writer unchanged.
+type PlanCollector struct {
+	mutex              sync.Mutex
+	passthrough        io.Writer
+	pending            strings.Builder
+	repositoryPaths    []string
+	repositoryPlans    map[string]*RepositoryPlan
+	repositoryOrder    []string
+	workflowEntries    []PlanEntry
+	previousLabel      string
+	previousRepository string
+}
+
+// NewPlanCollector constructs a PlanCollector that forwards non-plan lines to passthrough.
+func NewPlanCollector(passthrough io.Writer) *PlanCollector {
+	if passthrough == nil {
+		passthrough = io.Discard
+	}
+	return &PlanCollector{passthrough: passthrough, repositoryPlans: map[string]*RepositoryPlan{}}
+}
+
+// ObserveRepositories records the repository paths plan lines are attributed to.
+func (collector *PlanCollector) ObserveRepositories(repositoryPaths []string) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	for _, repositoryPath := range repositoryPaths {
+		trimmedPath := strings.TrimSpace(repositoryPath)
+		if len(trimmedPath) == 0 || slices.Contains(collector.repositoryPaths, trimmedPath) {
+			continue
+		}
+		collector.repositoryPaths = append(collector.repositoryPaths, trimmedPath)
+	}
+	sort.SliceStable(collector.repositoryPaths, func(firstIndex int, secondIndex int) bool {
+		return len(collector.repositoryPaths[firstIndex]) > len(collector.repositoryPaths[secondIndex])
+	})
+}
+
+// Write buffers output and records every complete line.
+func (collector *PlanCollector) Write(payload []byte) (int, error) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	collector.pending.Write(payload)
+	buffered := collector.pending.String()
+	lastSeparator := strings.LastIndex(buffered, planLineSeparatorConstant)
+	if lastSeparator < 0 {
+		return len(payload), nil
+	}
+	collector.pending.Reset()
+	collector.pending.WriteString(buffered[lastSeparator+1:])
+	for _, line := range strings.Split(buffered[:lastSeparator], planLineSeparatorConstant) {
+		collector.recordLine(line)
+	}
+	return len(payload), nil
+}
+
+// Printf records formatted reporter events so executor plans are captured alongside step output.
+func (collector *PlanCollector) Printf(format string, args ...any) {
+	_, _ = collector.Write([]byte(fmt.Sprintf(format, args...)))
+}
+
+// Flush records a trailing line that was written without a newline.
+func (collector *PlanCollector) Flush() {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	if collector.pending.Len() == 0 {
+		return
+	}
+	line := collector.pending.String()
+	collector.pending.Reset()
+	collector.recordLine(line)
+}
+
+// RepositoryPlans returns the captured plans in the order their repositories first planned an action.
+func (collector *PlanCollector) RepositoryPlans() []RepositoryPlan {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	plans := make([]RepositoryPlan, 0, len(collector.repositoryOrder))
+	for _, repositoryPath := range collector.repositoryOrder {
+		plan := collector.repositoryPlans[repositoryPath]
+		plans = append(plans, RepositoryPlan{RepositoryPath: plan.RepositoryPath, Entries: append([]PlanEntry{}, plan.Entries...)})
+	}
+	return plans
+}
+
+// WorkflowEntries returns planned actions that name no inspected repository, such as workflow-wide audit reports.
+func (collector *PlanCollector) WorkflowEntries() []PlanEntry {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	return append([]PlanEntry{}, collector.workflowEntries...)
+}
+
+// Render writes the grouped plan followed by its totals.
+func (collector *PlanCollector) Render(writer io.Writer) {
+	collector.Flush()
+	repositoryPlans := collector.RepositoryPlans()
+	workflowEntries := collector.WorkflowEntries()
+
+	totalEntries := 0
+	opaqueEntries := 0
+	for _, plan := range repositoryPlans {
+		fmt.Fprintf(writer, planRepositoryHeaderTemplate, plan.RepositoryPath, len(plan.Entries))
+		totalEntries += len(plan.Entries)
+		opaqueEntries += renderPlanEntries(writer, plan.Entries)
+	}
+	if len(workflowEntries) > 0 {
+		fmt.Fprintf(writer, planWorkflowHeaderTemplate, len(workflowEntries))
+		totalEntries += len(workflowEntries)
+		opaqueEntries += renderPlanEntries(writer, workflowEntries)
+	}
+	fmt.Fprintf(writer, planTotalTemplate, totalEntries, len(repositoryPlans), opaqueEntries)
+}
+
+func renderPlanEntries(writer io.Writer, entries []PlanEntry) int {
+	opaqueEntries := 0
+	for entryIndex, entry := range entries {
+		if entry.Opaque {
+			opaqueEntries++
+		}
+		fmt.Fprintf(writer, planEntryTemplate, entryIndex+1, entry.Description)
+	}
+	return opaqueEntries
+}
+
+func (collector *PlanCollector) recordLine(line string) {
+	trimmedLine := strings.TrimSpace(line)
+	if len(trimmedLine) == 0 {
+		return
+	}
+
+	label, _, labelFound := strings.Cut(trimmedLine, planLabelSeparatorConstant)
+	if !labelFound || !strings.Contains(label, planLabelMarkerConstant) || strings.ToUpper(label) != label || strings.ContainsAny(label, " \t") {
+		fmt.Fprintln(collector.passthrough, line)
+		return
+	}
+
+	entry := PlanEntry{Label: label, Description: trimmedLine}
+	if label == planOpaqueLabelConstant {
+		if _, command, commandFound := strings.Cut(trimmedLine, planOpaqueCommandMarkerConstant); commandFound {
+			entry.Description = fmt.Sprintf(planOpaqueEntryTemplateConstant, strings.TrimSpace(command))
+			entry.Opaque = true
+		}
+	}
+
+	repositoryPath := collector.repositoryForLine(trimmedLine)
+	if len(repositoryPath) == 0 && label == collector.previousLabel {
+		repositoryPath = collector.previousRepository
+	}
+	collector.previousLabel = label
+	collector.previousRepository = repositoryPath
+
+	if len(repositoryPath) == 0 {
+		collector.workflowEntries = append(collector.workflowEntries, entry)
+		return
+	}
+
+	plan, exists := collector.repositoryPlans[repositoryPath]
+	if !exists {
+		plan = &RepositoryPlan{RepositoryPath: repositoryPath}
+		collector.repositoryPlans[repositoryPath] = plan
+		collector.repositoryOrder = append(collector.repositoryOrder, repositoryPath)
+	}
+	plan.Entries = append(plan.Entries, entry)
+}
+
+// repositoryForLine returns the longest observed repository path named in the line as a whole path, so a file inside
+// a repository does not attribute a workflow-wide line to that repository.
+func (collector *PlanCollector) repositoryForLine(line string) string {
+	for _, repositoryPath := range collector.repositoryPaths {
+		searchFrom := 0
+		for {
+			offset := strings.Index(line[searchFrom:], repositoryPath)
+			if offset < 0 {
+				break
+			}
+			start := searchFrom + offset
+			end := start + len(repositoryPath)
+			if isPlanPathBoundary(line, start-1) && isPlanPathBoundary(line, end) {
+				return repositoryPath
+			}
+			searchFrom = start + 1
+		}
+	}
+	return ""
+}
+
+func isPlanPathBoundary(line string, index int) bool {
+	if index < 0 || index >= len(line) {
+		return true
+	}
+	return strings.ContainsRune(planRepositoryPathBoundaryCharacters, rune(line[index]))
+}
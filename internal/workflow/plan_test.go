@@ -0,0 +1,92 @@
+package workflow_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/workflow"
+)
+
+const (
+	planTestAlphaRepositoryConstant = "/tmp/plan/alpha"
+	planTestBetaRepositoryConstant  = "/tmp/plan/alpha-beta"
+)
+
+func TestPlanCollectorGroupsPlanLines(testInstance *testing.T) {
+	testCases := []struct {
+		name                string
+		writes              []string
+		expectedPlan        string
+		expectedPassthrough string
+	}{
+		{
+			name: "groups_by_repository_in_step_order",
+			writes: []string{
+				"PLAN-UPDATE-REMOTE: /tmp/plan/alpha origin https://github.com/acme/old.git → https://github.com/acme/alpha.git\n",
+				"PLAN-OK: /tmp/plan/alpha-beta → /tmp/plan/beta\n",
+				"PLAN-OK: /tmp/plan/alpha → /tmp/plan/renamed\n",
+			},
+			expectedPlan: "WORKFLOW-PLAN-REPOSITORY: /tmp/plan/alpha (2 planned action(s))\n" +
+				"  1. PLAN-UPDATE-REMOTE: /tmp/plan/alpha origin https://github.com/acme/old.git → https://github.com/acme/alpha.git\n" +
+				"  2. PLAN-OK: /tmp/plan/alpha → /tmp/plan/renamed\n" +
+				"WORKFLOW-PLAN-REPOSITORY: /tmp/plan/alpha-beta (1 planned action(s))\n" +
+				"  1. PLAN-OK: /tmp/plan/alpha-beta → /tmp/plan/beta\n" +
+				"WORKFLOW-PLAN-TOTAL: 3 planned action(s) across 2 repository(ies), 0 opaque\n",
+		},
+		{
+			name: "continuation_lines_follow_their_repository",
+			writes: []string{
+				"TASK-PLAN: Add Notes /tmp/plan/alpha branch=automation base=main\n",
+				"TASK-PLAN: Add Notes file=NOTES.md action=write\n",
+			},
+			expectedPlan: "WORKFLOW-PLAN-REPOSITORY: /tmp/plan/alpha (2 planned action(s))\n" +
+				"  1. TASK-PLAN: Add Notes /tmp/plan/alpha branch=automation base=main\n" +
+				"  2. TASK-PLAN: Add Notes file=NOTES.md action=write\n" +
+				"WORKFLOW-PLAN-TOTAL: 2 planned action(s) across 1 repository(ies), 0 opaque\n",
+		},
+		{
+			name: "shell_commands_are_opaque",
+			writes: []string{
+				"REPLACE-PLAN: /tmp/plan/alpha file=go.mod replacements=1\n",
+				"REPLACE-COMMAND-PLAN: /tmp/plan/alpha command=go mod tidy\n",
+			},
+			expectedPlan: "WORKFLOW-PLAN-REPOSITORY: /tmp/plan/alpha (2 planned action(s))\n" +
+				"  1. REPLACE-PLAN: /tmp/plan/alpha file=go.mod replacements=1\n" +
+				"  2. opaque: would run go mod tidy\n" +
+				"WORKFLOW-PLAN-TOTAL: 2 planned action(s) across 1 repository(ies), 1 opaque\n",
+		},
+		{
+			name: "workflow_wide_plans_and_other_lines",
+			writes: []string{
+				"TASK-SKIP: Add Notes /tmp/plan/alpha dirty worktree\n",
+				"WORKFLOW-PLAN: audit report → /tmp/plan/alpha/report.csv",
+			},
+			expectedPlan: "WORKFLOW-PLAN-GLOBAL: 1 planned action(s) not tied to one repository\n" +
+				"  1. WORKFLOW-PLAN: audit report → /tmp/plan/alpha/report.csv\n" +
+				"WORKFLOW-PLAN-TOTAL: 1 planned action(s) across 0 repository(ies), 0 opaque\n",
+			expectedPassthrough: "TASK-SKIP: Add Notes /tmp/plan/alpha dirty worktree\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(fmt.Sprintf("%02d_%s", testCaseIndex, testCase.name), func(subtest *testing.T) {
+			passthrough := &bytes.Buffer{}
+			collector := workflow.NewPlanCollector(passthrough)
+			collector.ObserveRepositories([]string{planTestAlphaRepositoryConstant, planTestBetaRepositoryConstant})
+			collector.ObserveRepositories([]string{planTestAlphaRepositoryConstant})
+
+			for _, written := range testCase.writes {
+				collector.Printf("%s", written)
+			}
+
+			rendered := &bytes.Buffer{}
+			collector.Render(rendered)
+			require.Equal(subtest, testCase.expectedPlan, rendered.String())
+			require.Equal(subtest, testCase.expectedPassthrough, passthrough.String())
+		})
+	}
+}
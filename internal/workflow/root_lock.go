@@ -0,0 +1,50 @@
+package workflow
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/rootlock"
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	rootLockUnavailableLogMessageConstant = "Root lock unavailable; continuing without it"
+	rootLockReleaseLogMessageConstant     = "Failed to release root lock"
+	rootLockPathsLogFieldConstant         = "lock_paths"
+)
+
+// acquireRootLock takes the lock for the roots of a mutating run and returns the function that releases it. Runs
+// started with --no-lock skip the lock, and a state directory that cannot be determined only disables locking.
+func (executor *Executor) acquireRootLock(executionContext context.Context, roots []string) (func(), error) {
+	if utils.NewCommandContextAccessor().RootLockDisabled(executionContext) {
+		return func() {}, nil
+	}
+
+	logger := executor.dependencies.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	lockDirectory := executor.dependencies.RootLockDirectory
+	if len(lockDirectory) == 0 {
+		defaultDirectory, directoryError := rootlock.DefaultDirectory()
+		if directoryError != nil {
+			logger.Warn(rootLockUnavailableLogMessageConstant, zap.Error(directoryError))
+			return func() {}, nil
+		}
+		lockDirectory = defaultDirectory
+	}
+
+	lock, lockError := rootlock.NewLocker(lockDirectory).Acquire(roots)
+	if lockError != nil {
+		return nil, lockError
+	}
+	return func() {
+		lockPaths := lock.Paths()
+		if releaseError := lock.Release(); releaseError != nil {
+			logger.Warn(rootLockReleaseLogMessageConstant, zap.Strings(rootLockPathsLogFieldConstant, lockPaths), zap.Error(releaseError))
+		}
+	}, nil
+}
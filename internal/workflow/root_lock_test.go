@@ -0,0 +1,60 @@
+package workflow
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/rootlock"
+	"github.com/temirov/gix/internal/utils"
+)
+
+func TestExecutorAcquireRootLock(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		heldByOtherRun bool
+		noLock         bool
+		expectedError  error
+	}{
+		{name: "free_roots_are_locked_and_released"},
+		{name: "held_roots_fail_fast", heldByOtherRun: true, expectedError: rootlock.ErrRootsLocked},
+		{name: "no_lock_skips_held_roots", heldByOtherRun: true, noLock: true},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			lockDirectory := subtest.TempDir()
+			roots := []string{filepath.Join(lockDirectory, "repositories")}
+			normalizedRoots, normalizeError := rootlock.NormalizeRoots(roots)
+			require.NoError(subtest, normalizeError)
+			lockPath := rootlock.RootLockPath(lockDirectory, normalizedRoots[0])
+			if testCase.heldByOtherRun {
+				heldLock, heldError := rootlock.NewLocker(lockDirectory).Acquire(roots)
+				require.NoError(subtest, heldError)
+				defer func() { require.NoError(subtest, heldLock.Release()) }()
+			}
+
+			executionContext := context.Background()
+			if testCase.noLock {
+				executionContext = utils.NewCommandContextAccessor().WithRootLockDisabled(executionContext, true)
+			}
+			executor := NewExecutor(nil, Dependencies{RootLockDirectory: lockDirectory})
+
+			release, lockError := executor.acquireRootLock(executionContext, roots)
+			if testCase.expectedError != nil {
+				require.ErrorIs(subtest, lockError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, lockError)
+			if !testCase.heldByOtherRun {
+				require.FileExists(subtest, lockPath)
+			}
+			release()
+			if !testCase.heldByOtherRun {
+				require.NoFileExists(subtest, lockPath)
+			}
+		})
+	}
+}
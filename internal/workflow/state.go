@@ -20,6 +20,10 @@ type RepositoryState struct {
 	PathDepth             int
 	InitialCleanWorktree  bool
 	HasNestedRepositories bool
+	// stepOutputs holds the outputs each identified step published for the repository, keyed by step id.
+	stepOutputs map[string]StepOutputs
+	// skippedSteps holds the ids of steps the only/skip filters excluded for the repository.
+	skippedSteps map[string]struct{}
 }
 
 // NewRepositoryState constructs repository state from an inspection snapshot.
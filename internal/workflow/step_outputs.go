@@ -0,0 +1,347 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template/parse"
+)
+
+const (
+	// StepOutputRenamedPath names the repository path after a rename step, or the planned path during a dry run.
+	StepOutputRenamedPath = "renamed_path"
+	// StepOutputNewRemoteURL names the origin URL after a canonical remote update, or the planned URL during a dry run.
+	StepOutputNewRemoteURL = "new_remote_url"
+	// StepOutputDeletedBranchCount names the number of branches a branch cleanup deleted; dry runs publish zero.
+	StepOutputDeletedBranchCount = "deleted_branch_count"
+
+	stepOutputsTemplateFieldConstant        = "Steps"
+	stepOutputsTemplateVariableRootConstant = "$"
+	stepIdentifierPatternConstant           = `^[A-Za-z_][A-Za-z0-9_]*$`
+	stepIdentifierInvalidTemplateConstant   = "workflow step %s has invalid id %q: ids use letters, digits, and underscores and must not start with a digit"
+	stepIdentifierDuplicateTemplateConstant = "workflow step %s reuses id %q of an earlier step"
+	stepReferenceUnknownTemplateConstant    = "workflow step %s references outputs of step %q, which is not defined by an earlier step"
+	stepReferenceSkippedTemplateConstant    = "workflow step %s references outputs of step %s, which was skipped for %s by its only/skip filters"
+	stepReferenceMissingTemplateConstant    = "workflow step %s references output %s of step %s, which the step did not publish for %s"
+	stepOutputsReportTemplateConstant       = "WORKFLOW-OUTPUTS: %s %s %s\n"
+)
+
+var stepIdentifierPattern = regexp.MustCompile(stepIdentifierPatternConstant)
+
+// StepOutputs holds the named values one workflow step published for a repository. Values are strings or int64
+// numbers, so templates print them verbatim and the run report keeps their JSON types.
+type StepOutputs map[string]any
+
+// StepOutputReference names a step output that a workflow step's templates read as {{ .Steps.<id>.<output> }}.
+// Output is empty when the template reads the step's whole output map.
+type StepOutputReference struct {
+	StepID string
+	Output string
+}
+
+type stepOutputRecorderContextKey struct{}
+
+// stepOutputRecorder collects the outputs published while one task runs on one repository.
+type stepOutputRecorder struct {
+	outputs StepOutputs
+}
+
+func withStepOutputRecorder(parentContext context.Context, recorder *stepOutputRecorder) context.Context {
+	return context.WithValue(parentContext, stepOutputRecorderContextKey{}, recorder)
+}
+
+// PublishStringOutput records a string output of the workflow step running on the context's repository. It does
+// nothing outside a workflow step.
+func PublishStringOutput(executionContext context.Context, name string, value string) {
+	publishStepOutput(executionContext, name, value)
+}
+
+// PublishNumberOutput records a numeric output of the workflow step running on the context's repository. It does
+// nothing outside a workflow step.
+func PublishNumberOutput(executionContext context.Context, name string, value int64) {
+	publishStepOutput(executionContext, name, value)
+}
+
+func publishStepOutput(executionContext context.Context, name string, value any) {
+	if executionContext == nil {
+		return
+	}
+	recorder, _ := executionContext.Value(stepOutputRecorderContextKey{}).(*stepOutputRecorder)
+	trimmedName := strings.TrimSpace(name)
+	if recorder == nil || len(trimmedName) == 0 {
+		return
+	}
+	if recorder.outputs == nil {
+		recorder.outputs = StepOutputs{}
+	}
+	recorder.outputs[trimmedName] = value
+}
+
+// IdentifiedOperation carries a step's id and the step outputs its templates reference.
+type IdentifiedOperation struct {
+	operation  Operation
+	identifier string
+	references []StepOutputReference
+}
+
+func applyStepIdentity(operation Operation, step StepConfiguration, references []StepOutputReference) Operation {
+	identifier := strings.TrimSpace(step.ID)
+	if len(identifier) == 0 && len(references) == 0 {
+		return operation
+	}
+	return &IdentifiedOperation{operation: operation, identifier: identifier, references: references}
+}
+
+// Name returns the wrapped operation name.
+func (operation *IdentifiedOperation) Name() string {
+	return operation.operation.Name()
+}
+
+// Unwrap returns the identified operation.
+func (operation *IdentifiedOperation) Unwrap() Operation {
+	return operation.operation
+}
+
+// StepID returns the step id; it is empty for steps that only reference other steps.
+func (operation *IdentifiedOperation) StepID() string {
+	return operation.identifier
+}
+
+// References returns a copy of the step outputs the step's templates read.
+func (operation *IdentifiedOperation) References() []StepOutputReference {
+	return append([]StepOutputReference(nil), operation.references...)
+}
+
+// Execute runs the wrapped operation. Outputs are captured per task, so they are recorded when the step runs as tasks.
+func (operation *IdentifiedOperation) Execute(executionContext context.Context, environment *Environment, state *State) error {
+	return operation.operation.Execute(executionContext, environment, state)
+}
+
+// stepOutputCatalog tracks the step ids declared so far, so each step may only reference steps that run before it.
+type stepOutputCatalog struct {
+	identifiers map[string]struct{}
+}
+
+func newStepOutputCatalog() *stepOutputCatalog {
+	return &stepOutputCatalog{identifiers: map[string]struct{}{}}
+}
+
+// register validates the step id and the step outputs referenced by the step's options and env, then records the id.
+func (catalog *stepOutputCatalog) register(step StepConfiguration, workflowEnvironment map[string]string) ([]StepOutputReference, error) {
+	identifier := strings.TrimSpace(step.ID)
+	if len(identifier) > 0 && !stepIdentifierPattern.MatchString(identifier) {
+		return nil, fmt.Errorf(stepIdentifierInvalidTemplateConstant, step.Operation, step.ID)
+	}
+	stepLabel := stepDisplayName(identifier, string(step.Operation))
+
+	references := collectStepOutputReferences(step.Options, nil)
+	references = collectStepOutputReferences(stringMapToAny(mergeStepEnvironment(workflowEnvironment, step.Env)), references)
+	for _, reference := range references {
+		if _, declared := catalog.identifiers[reference.StepID]; !declared {
+			return nil, fmt.Errorf(stepReferenceUnknownTemplateConstant, stepLabel, reference.StepID)
+		}
+	}
+
+	if len(identifier) > 0 {
+		if _, duplicate := catalog.identifiers[identifier]; duplicate {
+			return nil, fmt.Errorf(stepIdentifierDuplicateTemplateConstant, step.Operation, identifier)
+		}
+		catalog.identifiers[identifier] = struct{}{}
+	}
+	return references, nil
+}
+
+func stepDisplayName(identifier string, fallback string) string {
+	if len(identifier) > 0 {
+		return identifier
+	}
+	return fallback
+}
+
+// collectStepOutputReferences appends the .Steps references of every templated string in value, skipping duplicates.
+func collectStepOutputReferences(value any, references []StepOutputReference) []StepOutputReference {
+	switch typedValue := value.(type) {
+	case string:
+		if !containsWorkflowTemplate(typedValue) {
+			return references
+		}
+		parsedTemplate, parseError := parseWorkflowTemplate(typedValue)
+		if parseError != nil {
+			return references
+		}
+		for _, definedTemplate := range parsedTemplate.Templates() {
+			if definedTemplate.Tree != nil {
+				references = collectNodeReferences(definedTemplate.Tree.Root, references)
+			}
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(typedValue))
+		for key := range typedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			references = collectStepOutputReferences(typedValue[key], references)
+		}
+	case []any:
+		for _, nestedValue := range typedValue {
+			references = collectStepOutputReferences(nestedValue, references)
+		}
+	case []string:
+		for _, nestedValue := range typedValue {
+			references = collectStepOutputReferences(nestedValue, references)
+		}
+	}
+	return references
+}
+
+func collectNodeReferences(node parse.Node, references []StepOutputReference) []StepOutputReference {
+	switch typedNode := node.(type) {
+	case *parse.ListNode:
+		if typedNode == nil {
+			return references
+		}
+		for _, childNode := range typedNode.Nodes {
+			references = collectNodeReferences(childNode, references)
+		}
+	case *parse.ActionNode:
+		references = collectNodeReferences(typedNode.Pipe, references)
+	case *parse.IfNode:
+		references = collectBranchReferences(&typedNode.BranchNode, references)
+	case *parse.RangeNode:
+		references = collectBranchReferences(&typedNode.BranchNode, references)
+	case *parse.WithNode:
+		references = collectBranchReferences(&typedNode.BranchNode, references)
+	case *parse.TemplateNode:
+		references = collectNodeReferences(typedNode.Pipe, references)
+	case *parse.PipeNode:
+		if typedNode == nil {
+			return references
+		}
+		for _, commandNode := range typedNode.Cmds {
+			for _, argumentNode := range commandNode.Args {
+				references = collectNodeReferences(argumentNode, references)
+			}
+		}
+	case *parse.FieldNode:
+		references = appendStepOutputReference(typedNode.Ident, references)
+	case *parse.VariableNode:
+		if len(typedNode.Ident) > 0 && typedNode.Ident[0] == stepOutputsTemplateVariableRootConstant {
+			references = appendStepOutputReference(typedNode.Ident[1:], references)
+		}
+	}
+	return references
+}
+
+func collectBranchReferences(branchNode *parse.BranchNode, references []StepOutputReference) []StepOutputReference {
+	references = collectNodeReferences(branchNode.Pipe, references)
+	references = collectNodeReferences(branchNode.List, references)
+	return collectNodeReferences(branchNode.ElseList, references)
+}
+
+func appendStepOutputReference(identifiers []string, references []StepOutputReference) []StepOutputReference {
+	if len(identifiers) < 2 || identifiers[0] != stepOutputsTemplateFieldConstant {
+		return references
+	}
+	reference := StepOutputReference{StepID: identifiers[1]}
+	if len(identifiers) > 2 {
+		reference.Output = identifiers[2]
+	}
+	for _, existingReference := range references {
+		if existingReference == reference {
+			return references
+		}
+	}
+	return append(references, reference)
+}
+
+// markStepSkipped remembers that the only/skip filters excluded the identified step for the repository.
+func (state *RepositoryState) markStepSkipped(stepID string) {
+	if len(stepID) == 0 {
+		return
+	}
+	if state.skippedSteps == nil {
+		state.skippedSteps = map[string]struct{}{}
+	}
+	state.skippedSteps[stepID] = struct{}{}
+}
+
+// recordStepOutputs merges the outputs the identified step published for the repository.
+func (state *RepositoryState) recordStepOutputs(stepID string, outputs StepOutputs) {
+	if len(stepID) == 0 {
+		return
+	}
+	if state.stepOutputs == nil {
+		state.stepOutputs = map[string]StepOutputs{}
+	}
+	recorded, exists := state.stepOutputs[stepID]
+	if !exists {
+		recorded = StepOutputs{}
+		state.stepOutputs[stepID] = recorded
+	}
+	for outputName, outputValue := range outputs {
+		recorded[outputName] = outputValue
+	}
+}
+
+// checkStepReferences fails when the task reads outputs of a step that was skipped for the repository or that did
+// not publish the referenced output.
+func (state *RepositoryState) checkStepReferences(task TaskDefinition) error {
+	stepLabel := stepDisplayName(task.StepID, task.Name)
+	for _, reference := range task.StepReferences {
+		if _, skipped := state.skippedSteps[reference.StepID]; skipped {
+			return fmt.Errorf(stepReferenceSkippedTemplateConstant, stepLabel, reference.StepID, state.Path)
+		}
+		if len(reference.Output) == 0 {
+			continue
+		}
+		if _, published := state.stepOutputs[reference.StepID][reference.Output]; !published {
+			return fmt.Errorf(stepReferenceMissingTemplateConstant, stepLabel, reference.Output, reference.StepID, state.Path)
+		}
+	}
+	return nil
+}
+
+// stepOutputsTemplateData copies the repository's step outputs for templates.
+func (state *RepositoryState) stepOutputsTemplateData() map[string]StepOutputs {
+	templateData := make(map[string]StepOutputs, len(state.stepOutputs))
+	for stepID, outputs := range state.stepOutputs {
+		copied := make(StepOutputs, len(outputs))
+		for outputName, outputValue := range outputs {
+			copied[outputName] = outputValue
+		}
+		templateData[stepID] = copied
+	}
+	return templateData
+}
+
+type stepOutputsReportEntry struct {
+	repositoryPath string
+	stepID         string
+	outputs        StepOutputs
+}
+
+func (environment *Environment) recordStepOutputs(repository *RepositoryState, stepID string, outputs StepOutputs) {
+	if len(stepID) == 0 || len(outputs) == 0 {
+		return
+	}
+	repository.recordStepOutputs(stepID, outputs)
+	environment.stepOutputsReport = append(environment.stepOutputsReport, stepOutputsReportEntry{repositoryPath: repository.Path, stepID: stepID, outputs: outputs})
+}
+
+// reportStepOutputs prints the outputs of every identified step as one JSON object per repository and step.
+func reportStepOutputs(environment *Environment) {
+	if environment == nil || environment.Output == nil {
+		return
+	}
+	for _, entry := range environment.stepOutputsReport {
+		encodedOutputs, encodeError := json.Marshal(entry.outputs)
+		if encodeError != nil {
+			continue
+		}
+		fmt.Fprintf(environment.Output, stepOutputsReportTemplateConstant, entry.repositoryPath, entry.stepID, encodedOutputs)
+	}
+}
@@ -0,0 +1,175 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/repos/selection"
+)
+
+const (
+	testPublishOutputActionType = "test.step-outputs.publish"
+	testCaptureOutputActionType = "test.step-outputs.capture"
+)
+
+var capturedStepOutputValues []any
+
+func init() {
+	RegisterTaskAction(testPublishOutputActionType, func(ctx context.Context, _ *Environment, repository *RepositoryState, _ map[string]any) error {
+		PublishStringOutput(ctx, StepOutputRenamedPath, repository.Path+"-renamed")
+		PublishNumberOutput(ctx, StepOutputDeletedBranchCount, 3)
+		return nil
+	})
+	RegisterTaskAction(testCaptureOutputActionType, func(_ context.Context, _ *Environment, _ *RepositoryState, parameters map[string]any) error {
+		capturedStepOutputValues = append(capturedStepOutputValues, parameters["value"])
+		return nil
+	})
+}
+
+func TestBuildOperationsValidatesStepOutputReferences(testInstance *testing.T) {
+	publishStep := StepConfiguration{ID: "rename", Operation: OperationTypeRenameDirectories}
+	testCases := []struct {
+		name               string
+		steps              []StepConfiguration
+		expectedError      string
+		expectedReferences []StepOutputReference
+	}{
+		{
+			name: "reference_to_earlier_step",
+			steps: []StepConfiguration{publishStep, {
+				Operation: OperationTypeCreatePullRequest,
+				Options:   map[string]any{"title": "Moved to {{ .Steps.rename.renamed_path }}", "body": "{{ if .Steps.rename }}{{ $.Steps.rename.renamed_path }}{{ end }}"},
+			}},
+			expectedReferences: []StepOutputReference{{StepID: "rename"}, {StepID: "rename", Output: "renamed_path"}},
+		},
+		{
+			name: "reference_from_env",
+			steps: []StepConfiguration{publishStep, {
+				Operation: OperationTypeCreatePullRequest,
+				Options:   map[string]any{"title": "Moved"},
+				Env:       map[string]string{"NEW_PATH": "{{ .Steps.rename.renamed_path }}"},
+			}},
+			expectedReferences: []StepOutputReference{{StepID: "rename", Output: "renamed_path"}},
+		},
+		{
+			name: "reference_to_later_step",
+			steps: []StepConfiguration{
+				{Operation: OperationTypeCreatePullRequest, Options: map[string]any{"title": "{{ .Steps.rename.renamed_path }}"}},
+				publishStep,
+			},
+			expectedError: `workflow step pull-request references outputs of step "rename", which is not defined by an earlier step`,
+		},
+		{
+			name:          "invalid_id",
+			steps:         []StepConfiguration{{ID: "re-name", Operation: OperationTypeRenameDirectories}},
+			expectedError: `workflow step rename-directories has invalid id "re-name"`,
+		},
+		{
+			name:          "duplicate_id",
+			steps:         []StepConfiguration{publishStep, publishStep},
+			expectedError: `workflow step rename-directories reuses id "rename" of an earlier step`,
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			operations, buildError := BuildOperations(Configuration{Steps: testCase.steps})
+			if len(testCase.expectedError) > 0 {
+				require.ErrorContains(subtest, buildError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, buildError)
+			require.Len(subtest, operations, 2)
+			identifiedOperation, isIdentified := operations[1].(*IdentifiedOperation)
+			require.True(subtest, isIdentified)
+			require.Empty(subtest, identifiedOperation.StepID())
+			require.Equal(subtest, testCase.expectedReferences, identifiedOperation.References())
+			publisher, publisherIdentified := operations[0].(*IdentifiedOperation)
+			require.True(subtest, publisherIdentified)
+			require.Equal(subtest, "rename", publisher.StepID())
+		})
+	}
+}
+
+func TestTaskOperationPassesStepOutputsToLaterSteps(testInstance *testing.T) {
+	excludeAll, matcherError := selection.NewMatcher(nil, []string{"/repositories/*"})
+	require.NoError(testInstance, matcherError)
+
+	publishTask := TaskDefinition{Name: "Publish", StepID: "rename", Actions: []TaskActionDefinition{{Type: testPublishOutputActionType}}}
+	captureTask := TaskDefinition{
+		Name:           "Capture",
+		StepID:         "announce",
+		StepReferences: []StepOutputReference{{StepID: "rename", Output: StepOutputRenamedPath}},
+		Actions: []TaskActionDefinition{{
+			Type:    testCaptureOutputActionType,
+			Options: map[string]any{"value": "{{ .Steps.rename.renamed_path }} ({{ .Steps.rename.deleted_branch_count }})"},
+		}},
+	}
+	skippedPublishTask := publishTask
+	skippedPublishTask.RepositoryFilter = excludeAll
+	unpublishedReferenceTask := captureTask
+	unpublishedReferenceTask.StepReferences = []StepOutputReference{{StepID: "rename", Output: "missing"}}
+
+	testCases := []struct {
+		name           string
+		tasks          []TaskDefinition
+		expectedValues []any
+		expectedOutput string
+		expectedError  string
+	}{
+		{
+			name:           "outputs_reach_later_step",
+			tasks:          []TaskDefinition{publishTask, captureTask},
+			expectedValues: []any{"/repositories/alpha-renamed (3)"},
+			expectedOutput: `WORKFLOW-OUTPUTS: /repositories/alpha rename {"deleted_branch_count":3,"renamed_path":"/repositories/alpha-renamed"}` + "\n",
+		},
+		{
+			name:          "referenced_step_skipped",
+			tasks:         []TaskDefinition{skippedPublishTask, captureTask},
+			expectedError: "workflow step announce references outputs of step rename, which was skipped for /repositories/alpha by its only/skip filters",
+		},
+		{
+			name:          "referenced_output_missing",
+			tasks:         []TaskDefinition{publishTask, unpublishedReferenceTask},
+			expectedError: "workflow step announce references output missing of step rename, which the step did not publish for /repositories/alpha",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			capturedStepOutputValues = nil
+			output := &bytes.Buffer{}
+			environment := &Environment{Output: output, DryRun: true}
+			state := &State{Repositories: []*RepositoryState{
+				NewRepositoryState(audit.RepositoryInspection{Path: "/repositories/alpha", FinalOwnerRepo: "legacy/alpha"}),
+			}}
+			operation := &TaskOperation{tasks: testCase.tasks}
+
+			executeError := operation.Execute(context.Background(), environment, state)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, executeError, testCase.expectedError)
+				require.Empty(subtest, capturedStepOutputValues)
+				return
+			}
+			require.NoError(subtest, executeError)
+			require.Equal(subtest, testCase.expectedValues, capturedStepOutputValues)
+
+			output.Reset()
+			reportStepOutputs(environment)
+			require.Equal(subtest, testCase.expectedOutput, output.String())
+		})
+	}
+}
+
+func TestPublishOutsideWorkflowStepIsIgnored(testInstance *testing.T) {
+	require.NotPanics(testInstance, func() {
+		PublishStringOutput(context.Background(), StepOutputNewRemoteURL, "git@github.com:owner/repository.git")
+		PublishNumberOutput(context.Background(), StepOutputDeletedBranchCount, 1)
+	})
+}
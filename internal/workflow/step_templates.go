@@ -0,0 +1,271 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	workflowTemplateNameConstant                = "task"
+	workflowTemplateOpenDelimiterConstant       = "{{"
+	workflowTemplateEscapedDelimiterConstant    = `\{{`
+	workflowTemplateLiteralDelimiterConstant    = `{{"{{"}}`
+	workflowTemplateOptionsRootConstant         = "with"
+	workflowTemplateKeyPathTemplateConstant     = "%s.%s"
+	workflowTemplateIndexPathTemplateConstant   = "%s[%s]"
+	stepTemplateValidationErrorTemplateConstant = "workflow step %s has an invalid template at %s: %w"
+	stepTemplateRenderErrorTemplateConstant     = "workflow step %s could not render the template at %s for %s: %w"
+	stepRenderedOptionsErrorTemplateConstant    = "workflow step %s has invalid options for %s: %w"
+)
+
+// selfRenderedStepOptions lists step options that the step renders with its own template data, so they reach the step
+// unrendered.
+var selfRenderedStepOptions = map[OperationType]map[string]struct{}{
+	OperationTypeRenameDirectories: {optionNamingTemplateKeyConstant: {}},
+}
+
+// TemplatedOperation runs a step whose with: options hold templates. Task steps render their parameters themselves;
+// every other step is rebuilt for each repository from its options rendered against the repository's facts and the
+// outputs earlier steps published for it, and then runs on that repository alone.
+type TemplatedOperation struct {
+	operation  Operation
+	step       StepConfiguration
+	references []StepOutputReference
+}
+
+func applyStepTemplates(operation Operation, step StepConfiguration, references []StepOutputReference) Operation {
+	operationType := OperationType(strings.TrimSpace(string(step.Operation)))
+	switch operationType {
+	case OperationTypeApplyTasks, OperationTypeCommit:
+		return operation
+	}
+	for optionKey, optionValue := range step.Options {
+		if isSelfRenderedStepOption(operationType, optionKey) {
+			continue
+		}
+		if containsTemplateValue(optionValue) {
+			return &TemplatedOperation{operation: operation, step: step, references: references}
+		}
+	}
+	return operation
+}
+
+// Name returns the wrapped operation name.
+func (operation *TemplatedOperation) Name() string {
+	return operation.operation.Name()
+}
+
+// Unwrap returns the operation built from the unrendered options.
+func (operation *TemplatedOperation) Unwrap() Operation {
+	return operation.operation
+}
+
+// Execute renders the step options for each repository and runs the step rebuilt from them on that repository.
+func (operation *TemplatedOperation) Execute(executionContext context.Context, environment *Environment, state *State) error {
+	if state == nil {
+		return operation.operation.Execute(executionContext, environment, state)
+	}
+
+	stepReferences := TaskDefinition{Name: operation.Name(), StepID: strings.TrimSpace(operation.step.ID), StepReferences: operation.references}
+	for _, repository := range state.Repositories {
+		if cancellationError := executionContext.Err(); cancellationError != nil {
+			return cancellationError
+		}
+		if repository == nil {
+			continue
+		}
+		if referenceError := repository.checkStepReferences(stepReferences); referenceError != nil {
+			return referenceError
+		}
+		repositoryOperation, buildError := operation.operationFor(repository)
+		if buildError != nil {
+			return buildError
+		}
+		repositoryState := &State{Roots: state.Roots, Repositories: []*RepositoryState{repository}}
+		if executionError := executeWithState(executionContext, repositoryOperation, environment, repositoryState); executionError != nil {
+			return executionError
+		}
+	}
+	return nil
+}
+
+func (operation *TemplatedOperation) operationFor(repository *RepositoryState) (Operation, error) {
+	stepName := stepDisplayName(strings.TrimSpace(operation.step.ID), operation.Name())
+	operationType := OperationType(strings.TrimSpace(string(operation.step.Operation)))
+	templateData := buildTaskTemplateData(repository, TaskDefinition{})
+
+	optionKeys := make([]string, 0, len(operation.step.Options))
+	for optionKey := range operation.step.Options {
+		optionKeys = append(optionKeys, optionKey)
+	}
+	sort.Strings(optionKeys)
+	renderedOptions := make(map[string]any, len(operation.step.Options))
+	for _, optionKey := range optionKeys {
+		optionValue := operation.step.Options[optionKey]
+		if isSelfRenderedStepOption(operationType, optionKey) {
+			renderedOptions[optionKey] = optionValue
+			continue
+		}
+		location := fmt.Sprintf(workflowTemplateKeyPathTemplateConstant, workflowTemplateOptionsRootConstant, optionKey)
+		renderedValue, renderError := renderTemplateValue(stepName, location, repository.Path, optionValue, templateData)
+		if renderError != nil {
+			return nil, renderError
+		}
+		renderedOptions[optionKey] = renderedValue
+	}
+
+	repositoryOperation, buildError := buildOperationFromOptions(operationType, renderedOptions)
+	if buildError != nil {
+		return nil, fmt.Errorf(stepRenderedOptionsErrorTemplateConstant, stepName, repository.Path, buildError)
+	}
+	if configuredRename, isRename := operation.operation.(*RenameOperation); isRename {
+		if repositoryRename, renamed := repositoryOperation.(*RenameOperation); renamed {
+			repositoryRename.ApplyRequireCleanDefault(configuredRename.RequireCleanWorktree)
+		}
+	}
+	return repositoryOperation, nil
+}
+
+func isSelfRenderedStepOption(operationType OperationType, optionKey string) bool {
+	_, selfRendered := selfRenderedStepOptions[operationType][strings.ToLower(strings.TrimSpace(optionKey))]
+	return selfRendered
+}
+
+func containsTemplateValue(value any) bool {
+	switch typedValue := value.(type) {
+	case string:
+		return containsWorkflowTemplate(typedValue)
+	case map[string]any:
+		for _, nestedValue := range typedValue {
+			if containsTemplateValue(nestedValue) {
+				return true
+			}
+		}
+	case []any:
+		for _, nestedValue := range typedValue {
+			if containsTemplateValue(nestedValue) {
+				return true
+			}
+		}
+	case []string:
+		for _, nestedValue := range typedValue {
+			if containsWorkflowTemplate(nestedValue) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renderTemplateValue renders every templated string in value, trimming the rendered text like task options.
+func renderTemplateValue(stepName string, location string, repositoryPath string, value any, templateData TaskTemplateData) (any, error) {
+	switch typedValue := value.(type) {
+	case string:
+		if !containsWorkflowTemplate(typedValue) {
+			return typedValue, nil
+		}
+		parsedTemplate, parseError := parseWorkflowTemplate(typedValue)
+		if parseError != nil {
+			return nil, fmt.Errorf(stepTemplateRenderErrorTemplateConstant, stepName, location, repositoryPath, parseError)
+		}
+		var buffer bytes.Buffer
+		if executeError := parsedTemplate.Execute(&buffer, templateData); executeError != nil {
+			return nil, fmt.Errorf(stepTemplateRenderErrorTemplateConstant, stepName, location, repositoryPath, executeError)
+		}
+		return strings.TrimSpace(buffer.String()), nil
+	case map[string]any:
+		rendered := make(map[string]any, len(typedValue))
+		for key, nestedValue := range typedValue {
+			renderedValue, renderError := renderTemplateValue(stepName, fmt.Sprintf(workflowTemplateKeyPathTemplateConstant, location, key), repositoryPath, nestedValue, templateData)
+			if renderError != nil {
+				return nil, renderError
+			}
+			rendered[key] = renderedValue
+		}
+		return rendered, nil
+	case []any:
+		rendered := make([]any, 0, len(typedValue))
+		for index, nestedValue := range typedValue {
+			renderedValue, renderError := renderTemplateValue(stepName, fmt.Sprintf(workflowTemplateIndexPathTemplateConstant, location, strconv.Itoa(index)), repositoryPath, nestedValue, templateData)
+			if renderError != nil {
+				return nil, renderError
+			}
+			rendered = append(rendered, renderedValue)
+		}
+		return rendered, nil
+	case []string:
+		rendered := make([]string, 0, len(typedValue))
+		for index, nestedValue := range typedValue {
+			renderedValue, renderError := renderTemplateValue(stepName, fmt.Sprintf(workflowTemplateIndexPathTemplateConstant, location, strconv.Itoa(index)), repositoryPath, nestedValue, templateData)
+			if renderError != nil {
+				return nil, renderError
+			}
+			rendered = append(rendered, renderedValue.(string))
+		}
+		return rendered, nil
+	default:
+		return value, nil
+	}
+}
+
+// parseWorkflowTemplate parses a workflow template, treating \{{ as a literal opening delimiter.
+func parseWorkflowTemplate(rawTemplate string) (*template.Template, error) {
+	return template.New(workflowTemplateNameConstant).Parse(escapeWorkflowTemplateDelimiters(rawTemplate))
+}
+
+// EscapeTemplateDelimiters marks every opening delimiter in value as literal so task option rendering passes the value through unchanged.
+func EscapeTemplateDelimiters(value string) string {
+	return strings.ReplaceAll(value, workflowTemplateOpenDelimiterConstant, workflowTemplateEscapedDelimiterConstant)
+}
+
+func escapeWorkflowTemplateDelimiters(rawTemplate string) string {
+	if !strings.Contains(rawTemplate, workflowTemplateEscapedDelimiterConstant) {
+		return rawTemplate
+	}
+	return strings.ReplaceAll(rawTemplate, workflowTemplateEscapedDelimiterConstant, workflowTemplateLiteralDelimiterConstant)
+}
+
+func containsWorkflowTemplate(value string) bool {
+	return strings.Contains(value, workflowTemplateOpenDelimiterConstant)
+}
+
+// validateStepTemplates parses every templated string in the step options so syntax errors surface at configuration time.
+func validateStepTemplates(step StepConfiguration) error {
+	return validateTemplateValue(string(step.Operation), workflowTemplateOptionsRootConstant, step.Options)
+}
+
+func validateTemplateValue(operationName string, location string, value any) error {
+	switch typedValue := value.(type) {
+	case string:
+		if !containsWorkflowTemplate(typedValue) {
+			return nil
+		}
+		if _, parseError := parseWorkflowTemplate(typedValue); parseError != nil {
+			return fmt.Errorf(stepTemplateValidationErrorTemplateConstant, operationName, location, parseError)
+		}
+	case map[string]any:
+		for key, nestedValue := range typedValue {
+			if validationError := validateTemplateValue(operationName, fmt.Sprintf(workflowTemplateKeyPathTemplateConstant, location, key), nestedValue); validationError != nil {
+				return validationError
+			}
+		}
+	case []any:
+		for index, nestedValue := range typedValue {
+			if validationError := validateTemplateValue(operationName, fmt.Sprintf(workflowTemplateIndexPathTemplateConstant, location, strconv.Itoa(index)), nestedValue); validationError != nil {
+				return validationError
+			}
+		}
+	case []string:
+		for index, nestedValue := range typedValue {
+			if validationError := validateTemplateValue(operationName, fmt.Sprintf(workflowTemplateIndexPathTemplateConstant, location, strconv.Itoa(index)), nestedValue); validationError != nil {
+				return validationError
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+)
+
+func TestTemplatedStepRendersOptionsPerRepository(testInstance *testing.T) {
+	renameStep := StepConfiguration{ID: "rename", Operation: OperationTypeApplyTasks, Options: map[string]any{
+		"tasks": []any{map[string]any{"name": "Publish", "files": []any{map[string]any{"path": "NOTES.md", "content": "notes"}}}},
+	}}
+	testCases := []struct {
+		name           string
+		step           StepConfiguration
+		expectedOutput string
+		expectedError  string
+	}{
+		{
+			name: "pull_request_title",
+			step: StepConfiguration{Operation: OperationTypeCreatePullRequest, Options: map[string]any{
+				"title": "Move {{ .Owner }}/{{ .Name }} to {{ .Steps.rename.renamed_path }}",
+				"head":  "feature",
+				"base":  "{{ .DefaultBranch }}",
+			}},
+			expectedOutput: `PULL-REQUEST-PLAN: /repositories/alpha (legacy/alpha) feature -> main title="Move legacy/alpha to /repositories/alpha-renamed"` + "\n" +
+				`PULL-REQUEST-PLAN: /repositories/beta (legacy/beta) feature -> trunk title="Move legacy/beta to /repositories/beta-renamed"` + "\n",
+		},
+		{
+			name:           "audit_output_path",
+			step:           StepConfiguration{Operation: OperationTypeAuditReport, Options: map[string]any{"output": "reports/{{ .Name }}.csv"}},
+			expectedOutput: "WORKFLOW-PLAN: audit report → reports/alpha.csv\nWORKFLOW-PLAN: audit report → reports/beta.csv\n",
+		},
+		{
+			name:          "missing_step_output",
+			step:          StepConfiguration{Operation: OperationTypeAuditReport, Options: map[string]any{"output": "{{ .Steps.rename.missing }}.csv"}},
+			expectedError: "workflow step audit-report references output missing of step rename, which the step did not publish for /repositories/alpha",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			operations, buildError := BuildOperations(Configuration{Steps: []StepConfiguration{renameStep, testCase.step}})
+			require.NoError(subtest, buildError)
+			require.Len(subtest, operations, 2)
+			stepOperation := operations[1]
+			if identifiedOperation, isIdentified := stepOperation.(*IdentifiedOperation); isIdentified {
+				stepOperation = identifiedOperation.Unwrap()
+			}
+			require.IsType(subtest, &TemplatedOperation{}, stepOperation)
+
+			state := &State{}
+			for _, repository := range []struct{ path, ownerRepo, defaultBranch string }{
+				{path: "/repositories/alpha", ownerRepo: "legacy/alpha", defaultBranch: "main"},
+				{path: "/repositories/beta", ownerRepo: "legacy/beta", defaultBranch: "trunk"},
+			} {
+				repositoryState := NewRepositoryState(audit.RepositoryInspection{Path: repository.path, FinalOwnerRepo: repository.ownerRepo, RemoteDefaultBranch: repository.defaultBranch})
+				repositoryState.recordStepOutputs("rename", StepOutputs{StepOutputRenamedPath: repository.path + "-renamed"})
+				state.Repositories = append(state.Repositories, repositoryState)
+			}
+			output := &bytes.Buffer{}
+			environment := &Environment{Output: output, DryRun: true, State: state}
+
+			executeError := operations[1].Execute(context.Background(), environment, state)
+			if len(testCase.expectedError) > 0 {
+				require.EqualError(subtest, executeError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, executeError)
+			require.Equal(subtest, testCase.expectedOutput, output.String())
+			require.Same(subtest, state, environment.State)
+		})
+	}
+}
+
+func TestApplyStepTemplatesLeavesSelfRenderedOptions(testInstance *testing.T) {
+	testCases := []struct {
+		name             string
+		step             StepConfiguration
+		expectedTemplate bool
+	}{
+		{name: "rename_naming_template", step: StepConfiguration{Operation: OperationTypeRenameDirectories, Options: map[string]any{"naming_template": "{{.Owner}}__{{.Name}}"}}},
+		{name: "commit_message", step: StepConfiguration{Operation: OperationTypeCommit, Options: map[string]any{"message": "Update {{ .Name }}"}}},
+		{name: "literal_options", step: StepConfiguration{Operation: OperationTypeAuditReport, Options: map[string]any{"output": "report.csv"}}},
+		{name: "rename_plan_file", step: StepConfiguration{Operation: OperationTypeRenameDirectories, Options: map[string]any{"plan_file": "{{ .Name }}.plan"}}, expectedTemplate: true},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			operations, buildError := BuildOperations(Configuration{Steps: []StepConfiguration{testCase.step}})
+			require.NoError(subtest, buildError)
+			_, isTemplated := operations[0].(*TemplatedOperation)
+			require.Equal(subtest, testCase.expectedTemplate, isTemplated)
+		})
+	}
+}
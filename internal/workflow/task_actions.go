@@ -5,11 +5,14 @@ import (
 	"encoding/csv"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/githubcli"
+	migrate "github.com/temirov/gix/internal/migrate"
 	"github.com/temirov/gix/internal/releases"
 	"github.com/temirov/gix/internal/repos/history"
 	"github.com/temirov/gix/internal/repos/shared"
@@ -37,6 +40,9 @@ var taskActionHandlers = map[string]taskActionHandlerFunc{
 	taskActionAuditReport:        handleAuditReportAction,
 	taskActionHistoryPurge:       handleHistoryPurgeAction,
 	taskActionFileReplace:        handleFileReplaceAction,
+	taskActionEditRepository:     handleEditRepositoryAction,
+	taskActionCreatePullRequest:  handleCreatePullRequestAction,
+	taskActionCommitStaged:       handleCommitStagedAction,
 }
 
 type taskActionHandlerFunc func(ctx context.Context, environment *Environment, repository *RepositoryState, parameters map[string]any) error
@@ -88,7 +94,10 @@ func handleCanonicalRemoteAction(ctx context.Context, environment *Environment,
 		return ownerError
 	}
 
-	operation := &CanonicalRemoteOperation{OwnerConstraint: strings.TrimSpace(ownerConstraint)}
+	operation, operationError := canonicalRemoteOperationFromOptions(strings.TrimSpace(ownerConstraint), reader)
+	if operationError != nil {
+		return operationError
+	}
 	state := &State{Repositories: []*RepositoryState{repository}}
 	return operation.Execute(ctx, environment, state)
 }
@@ -146,11 +155,21 @@ func handleRenameDirectoriesAction(ctx context.Context, environment *Environment
 		includeOwner = value
 	}
 
+	planFilePath, _, planFileError := reader.stringValue(optionPlanFileKeyConstant)
+	if planFileError != nil {
+		return planFileError
+	}
+
+	namingTemplate, namingTemplateError := readNamingTemplateOption(reader)
+	if namingTemplateError != nil {
+		return namingTemplateError
+	}
+
 	if requireClean && repository != nil && repository.HasNestedRepositories && repository.InitialCleanWorktree {
 		requireClean = false
 	}
 
-	operation := &RenameOperation{RequireCleanWorktree: requireClean, IncludeOwner: includeOwner, requireCleanExplicit: requireCleanExplicit}
+	operation := &RenameOperation{RequireCleanWorktree: requireClean, IncludeOwner: includeOwner, NamingTemplate: namingTemplate, requireCleanExplicit: requireCleanExplicit, PlanFilePath: planFilePath}
 	state := &State{Repositories: []*RepositoryState{repository}}
 	return operation.Execute(ctx, environment, state)
 }
@@ -191,12 +210,56 @@ func handleBranchDefaultAction(ctx context.Context, environment *Environment, re
 		deleteSource = value
 	}
 
+	retainSourceValue, _, retainSourceError := reader.stringValue(optionRetainSourceKeyConstant)
+	if retainSourceError != nil {
+		return retainSourceError
+	}
+
+	leaveTombstoneValue, _, leaveTombstoneError := reader.boolValue(optionLeaveTombstoneKeyConstant)
+	if leaveTombstoneError != nil {
+		return leaveTombstoneError
+	}
+
+	instructionsDirectoryValue, _, instructionsDirectoryError := reader.stringValue(optionWriteInstructionsKeyConstant)
+	if instructionsDirectoryError != nil {
+		return instructionsDirectoryError
+	}
+
+	updateDocsValue, _, updateDocsError := reader.boolValue(optionUpdateDocsKeyConstant)
+	if updateDocsError != nil {
+		return updateDocsError
+	}
+
+	docsPatternsValue, _, docsPatternsError := reader.stringSliceValue(optionDocsPatternsKeyConstant)
+	if docsPatternsError != nil {
+		return docsPatternsError
+	}
+	phase, gracePeriod, phaseError := readBranchMigrationPhase(reader)
+	if phaseError != nil {
+		return phaseError
+	}
+
 	target := BranchMigrationTarget{
-		RemoteName:         remoteName,
-		SourceBranch:       sourceBranchValue,
-		TargetBranch:       targetBranchValue,
-		PushToRemote:       pushToRemote,
-		DeleteSourceBranch: deleteSource,
+		RemoteName:            remoteName,
+		SourceBranch:          sourceBranchValue,
+		TargetBranch:          targetBranchValue,
+		PushToRemote:          pushToRemote,
+		DeleteSourceBranch:    deleteSource,
+		RetainSource:          strings.ToLower(retainSourceValue),
+		LeaveTombstone:        leaveTombstoneValue,
+		InstructionsDirectory: instructionsDirectoryValue,
+		UpdateDocumentation:   updateDocsValue,
+		DocumentationPatterns: docsPatternsValue,
+		Phase:                 phase,
+		GracePeriod:           gracePeriod,
+	}
+
+	if overrides, overridesProvided := parameters[optionOverridesKeyConstant].(*migrate.RepositoryOverrides); overridesProvided && overrides != nil && environment != nil {
+		overriddenTarget, proceed := applyBranchMigrationOverride(environment, repository, overrides, target)
+		if !proceed {
+			return nil
+		}
+		target = overriddenTarget
 	}
 
 	operation := &BranchMigrationOperation{Targets: []BranchMigrationTarget{target}}
@@ -271,6 +334,105 @@ func handleAuditReportAction(ctx context.Context, environment *Environment, repo
 		return debugError
 	}
 
+	failOnNested, _, failOnNestedError := reader.boolValue("fail_on_nested")
+	if failOnNestedError != nil {
+		return failOnNestedError
+	}
+
+	duplicatesOnly, _, duplicatesOnlyError := reader.boolValue("duplicates_only")
+	if duplicatesOnlyError != nil {
+		return duplicatesOnlyError
+	}
+
+	githubHost, _, githubHostError := reader.stringValue("github_host")
+	if githubHostError != nil {
+		return githubHostError
+	}
+
+	includeBare, includeBareExists, includeBareError := reader.boolValue("include_bare")
+	if includeBareError != nil {
+		return includeBareError
+	}
+	if !includeBareExists {
+		includeBare = true
+	}
+
+	sortValue, _, sortError := reader.stringValue(optionSortKeyConstant)
+	if sortError != nil {
+		return sortError
+	}
+	var sortOrder audit.ReportSortOrder
+	if len(strings.TrimSpace(sortValue)) > 0 {
+		parsedSortOrder, parseSortError := audit.ParseReportSortOrder(sortValue)
+		if parseSortError != nil {
+			return parseSortError
+		}
+		sortOrder = parsedSortOrder
+	}
+
+	formatValue, _, formatError := reader.stringValue(optionReportFormatKeyConstant)
+	if formatError != nil {
+		return formatError
+	}
+	reportFormat, parseFormatError := audit.ParseReportFormat(formatValue)
+	if parseFormatError != nil {
+		return parseFormatError
+	}
+
+	minimumScore, minimumScoreError := readIntOption(parameters, optionMinimumScoreKeyConstant)
+	if minimumScoreError != nil {
+		return minimumScoreError
+	}
+
+	configuredWeights, weightsExist, weightsError := reader.mapValue(optionScoreWeightsKeyConstant)
+	if weightsError != nil {
+		return weightsError
+	}
+	if weightsExist {
+		scoreWeights, parseWeightsError := audit.ParseScoreWeights(configuredWeights)
+		if parseWeightsError != nil {
+			return parseWeightsError
+		}
+		environment.AuditService.SetScoreWeights(scoreWeights)
+	}
+
+	identityRuleEntries, identityRulesExist, identityRulesError := reader.mapSlice(optionIdentityRulesKeyConstant)
+	if identityRulesError != nil {
+		return identityRulesError
+	}
+	if identityRulesExist {
+		identityRules, parseRulesError := audit.ParseIdentityRules(identityRuleEntries)
+		if parseRulesError != nil {
+			return parseRulesError
+		}
+		if rulesError := environment.AuditService.SetIdentityRules(identityRules); rulesError != nil {
+			return rulesError
+		}
+	}
+
+	fix, _, fixError := reader.boolValue("fix")
+	if fixError != nil {
+		return fixError
+	}
+
+	fixProtocolValue, _, fixProtocolError := reader.stringValue("fix_protocol")
+	if fixProtocolError != nil {
+		return fixProtocolError
+	}
+	var fixProtocol audit.RemoteProtocolType
+	if len(strings.TrimSpace(fixProtocolValue)) > 0 {
+		parsedProtocol, parseProtocolError := shared.ParseRemoteProtocol(fixProtocolValue)
+		if parseProtocolError != nil {
+			return parseProtocolError
+		}
+		fixProtocol = parsedProtocol
+	}
+
+	unshallow, _, unshallowError := reader.boolValue("unshallow")
+	if unshallowError != nil {
+		return unshallowError
+	}
+
 	depthValue, _, depthError := reader.stringValue("depth")
 	if depthError != nil {
 		return depthError
@@ -293,7 +455,7 @@ func handleAuditReportAction(ctx context.Context, environment *Environment, repo
 	sanitizedOutput := strings.TrimSpace(outputValue)
 	writeToFile := outputExists && len(sanitizedOutput) > 0
 
-	if environment.DryRun {
+	if environment.DryRun && (!fix || writeToFile) {
 		target := auditReportDestinationStdoutConstant
 		if writeToFile {
 			target = sanitizedOutput
@@ -305,14 +467,39 @@ func handleAuditReportAction(ctx context.Context, environment *Environment, repo
 		return nil
 	}
 
+	if environment.TokenAccess == githubcli.TokenAccessReadOnly && environment.Errors != nil {
+		fmt.Fprint(environment.Errors, auditReadOnlyTokenMessageConstant)
+	}
+
 	if writeToFile {
+		if len(strings.TrimSpace(githubHost)) > 0 {
+			environment.AuditService.SetGitHubHost(githubHost)
+		}
+		environment.AuditService.SetIncludeBareRepositories(includeBare)
 		inspections, discoveryError := environment.AuditService.DiscoverInspections(ctx, roots, includeAll, debugOutput, depth)
-		if discoveryError != nil {
+		if discoveryError != nil && (ctx.Err() == nil || len(inspections) == 0) {
 			environment.auditReportExecuted = true
 			return discoveryError
 		}
 
-		if writeError := writeAuditReportFile(sanitizedOutput, inspections); writeError != nil {
+		audit.SortInspections(inspections, sortOrder)
+		inspections = audit.FilterInspectionsByScore(inspections, minimumScore)
+		writeReport := writeAuditReportFile
+		switch reportFormat {
+		case audit.ReportFormatMarkdown:
+			writeReport = func(destination string, inspections []audit.RepositoryInspection) error {
+				return writeAuditDocumentFile(destination, func(writer io.Writer) error {
+					return audit.WriteMarkdownReport(writer, environment.AuditService.MarkdownReport(inspections))
+				})
+			}
+		case audit.ReportFormatJSON:
+			writeReport = func(destination string, inspections []audit.RepositoryInspection) error {
+				return writeAuditDocumentFile(destination, func(writer io.Writer) error {
+					return audit.WriteJSONReport(writer, inspections, environment.AuditService.ScoreWeights())
+				})
+			}
+		}
+		if writeError := writeReport(sanitizedOutput, inspections); writeError != nil {
 			environment.auditReportExecuted = true
 			return writeError
 		}
@@ -321,7 +508,28 @@ func handleAuditReportAction(ctx context.Context, environment *Environment, repo
 			fmt.Fprintf(environment.Output, auditWriteMessageTemplateConstant, sanitizedOutput)
 		}
 		environment.auditReportExecuted = true
-		return nil
+		if discoveryError != nil {
+			return discoveryError
+		}
+		if !reportFormat.Structured() {
+			environment.AuditService.ReportHostMismatches()
+			environment.AuditService.ReportStaleRemoteHeads()
+			environment.AuditService.ReportPushURLMismatches()
+			environment.AuditService.ReportInProgressOperations()
+			environment.AuditService.ReportShallowClones()
+			environment.AuditService.ReportIdentityViolations()
+			environment.AuditService.ReportDuplicateClones()
+		}
+		if fix {
+			reconcileOptions := audit.ReconciliationOptions{TargetProtocol: fixProtocol, DryRun: environment.DryRun, Unshallow: unshallow}
+			if reconcileError := environment.AuditService.Reconcile(ctx, inspections, reconcileOptions); reconcileError != nil {
+				return reconcileError
+			}
+		}
+		if reportFormat.Structured() {
+			return environment.AuditService.NestedRepositoriesError(failOnNested)
+		}
+		return environment.AuditService.ReportContainment(failOnNested)
 	}
 
 	commandOptions := audit.CommandOptions{
@@ -329,6 +537,17 @@ func handleAuditReportAction(ctx context.Context, environment *Environment, repo
 		DebugOutput:       debugOutput,
 		IncludeAllFolders: includeAll,
 		InspectionDepth:   depth,
+		FailOnNested:      failOnNested,
+		GitHubHost:        githubHost,
+		DuplicatesOnly:    duplicatesOnly,
+		SortOrder:         sortOrder,
+		ReportFormat:      reportFormat,
+		MinimumScore:      minimumScore,
+		IncludeBare:       includeBare,
+		Fix:               fix,
+		FixProtocol:       fixProtocol,
+		Unshallow:         unshallow,
+		DryRun:            environment.DryRun,
 	}
 
 	if runError := environment.AuditService.Run(ctx, commandOptions); runError != nil {
@@ -507,6 +726,32 @@ func readHistoryPaths(raw any) ([]string, error) {
 	}
 }
 
+// writeAuditDocumentFile creates the destination file and its directory and renders a markdown or json report into it.
+func writeAuditDocumentFile(destination string, render func(io.Writer) error) (writeError error) {
+	if len(strings.TrimSpace(destination)) == 0 {
+		return errors.New("audit report destination missing")
+	}
+
+	targetDirectory := filepath.Dir(destination)
+	if targetDirectory != auditCurrentDirectorySentinelConstant {
+		if mkdirError := os.MkdirAll(targetDirectory, auditDirectoryPermissionsConstant); mkdirError != nil {
+			return mkdirError
+		}
+	}
+
+	fileHandle, createError := os.Create(destination)
+	if createError != nil {
+		return createError
+	}
+	defer func() {
+		if closeError := fileHandle.Close(); closeError != nil && writeError == nil {
+			writeError = closeError
+		}
+	}()
+
+	return render(fileHandle)
+}
+
 func writeAuditReportFile(destination string, inspections []audit.RepositoryInspection) error {
 	if len(strings.TrimSpace(destination)) == 0 {
 		return errors.New("audit report destination missing")
@@ -535,6 +780,10 @@ func writeAuditReportFile(destination string, inspections []audit.RepositoryInsp
 		auditCSVHeaderInSyncConstant,
 		auditCSVHeaderRemoteProtocolConstant,
 		auditCSVHeaderOriginCanonicalConstant,
+		auditCSVHeaderLastActivityConstant,
+		auditCSVHeaderDeleteOnMergeConstant,
+		auditCSVHeaderMergeQueueConstant,
+		auditCSVHeaderHealthScoreConstant,
 	}
 
 	if writeError := writer.Write(header); writeError != nil {
@@ -135,3 +135,40 @@ func TestHandleAuditReportActionFallsBackToStateRoots(testInstance *testing.T) {
 	require.Len(testInstance, discoverer.recordedRoots, 1)
 	require.Equal(testInstance, []string{stateRoot}, discoverer.recordedRoots[0])
 }
+
+func TestHandleAuditReportActionStatesReadOnlyToken(testInstance *testing.T) {
+	testCases := []struct {
+		name           string
+		tokenAccess    githubcli.TokenAccess
+		expectedErrors string
+	}{
+		{name: "read_only_token_is_stated", tokenAccess: githubcli.TokenAccessReadOnly, expectedErrors: auditReadOnlyTokenMessageConstant},
+		{name: "read_write_token_is_silent", tokenAccess: githubcli.TokenAccessReadWrite},
+		{name: "unknown_token_is_silent", tokenAccess: githubcli.TokenAccessUnknown},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			temporaryDirectory := subtest.TempDir()
+			repositoryPath := filepath.Join(temporaryDirectory, "repository")
+			discoverer := &stubRepositoryDiscoverer{repositories: []string{repositoryPath}}
+			gitRepositoryManager := &stubGitRepositoryManager{remoteURL: "https://github.com/example/repo.git"}
+			metadataResolver := &stubGitHubMetadataResolver{metadata: githubcli.RepositoryMetadata{NameWithOwner: "example/repo", DefaultBranch: "main"}}
+			errorsOutput := &bytes.Buffer{}
+			environment := &Environment{
+				AuditService: audit.NewService(discoverer, gitRepositoryManager, &stubGitExecutor{}, metadataResolver, &bytes.Buffer{}, &bytes.Buffer{}),
+				Output:       &bytes.Buffer{},
+				Errors:       errorsOutput,
+				TokenAccess:  testCase.tokenAccess,
+				State:        &State{Roots: []string{repositoryPath}, Repositories: []*RepositoryState{{Path: repositoryPath}}},
+			}
+			parameters := map[string]any{
+				"output": filepath.Join(temporaryDirectory, "audit.csv"),
+				"depth":  string(audit.InspectionDepthMinimal),
+			}
+
+			require.NoError(subtest, handleAuditReportAction(context.Background(), environment, &RepositoryState{Path: repositoryPath}, parameters))
+			require.Equal(subtest, testCase.expectedErrors, errorsOutput.String())
+		})
+	}
+}
@@ -19,6 +19,8 @@ const (
 	fileReplaceFindOptionKey       = "find"
 	fileReplaceReplaceOptionKey    = "replace"
 	fileReplaceCommandOptionKey    = "command"
+	fileReplaceShellOptionKey      = "shell"
+	fileReplaceShellProgramKey     = "shell_program"
 	fileReplaceSafeguardsOptionKey = "safeguards"
 
 	fileReplaceRequireCleanKey  = "require_clean"
@@ -30,6 +32,8 @@ const (
 	fileReplaceSkipMessageTemplate    = "REPLACE-SKIP: %s reason=%s\n"
 	fileReplaceNoopMessageTemplate    = "REPLACE-NOOP: %s reason=%s\n"
 	fileReplaceCommandPlanTemplate    = "REPLACE-COMMAND-PLAN: %s command=%s\n"
+	fileReplaceShellCommandTemplate   = "%s (via %s -lc)"
+	fileReplaceDefaultShellProgram    = "sh"
 	fileReplaceCommandApplyTemplate   = "REPLACE-COMMAND: %s command=%s\n"
 	fileReplaceCommandSupportMessage  = "replacement command execution requires shell support"
 	fileReplaceMissingFindMessage     = "replacement action requires non-empty 'find'"
@@ -78,6 +82,16 @@ func handleFileReplaceAction(ctx context.Context, environment *Environment, repo
 		return commandError
 	}
 
+	shellWrapped, _, shellError := reader.boolValue(fileReplaceShellOptionKey)
+	if shellError != nil {
+		return shellError
+	}
+	shellProgram, _, shellProgramError := reader.stringValue(fileReplaceShellProgramKey)
+	if shellProgramError != nil {
+		return shellProgramError
+	}
+	shellProgram = strings.TrimSpace(shellProgram)
+
 	safeguardMap, _, safeguardsError := reader.mapValue(fileReplaceSafeguardsOptionKey)
 	if safeguardsError != nil {
 		return safeguardsError
@@ -105,7 +119,7 @@ func handleFileReplaceAction(ctx context.Context, environment *Environment, repo
 	if environment.DryRun {
 		describeReplacementPlan(environment, repository.Path, plans)
 		if len(commandArguments) > 0 && len(plans) > 0 {
-			writeReplacementMessage(environment, fileReplaceCommandPlanTemplate, repository.Path, strings.Join(commandArguments, " "))
+			writeReplacementMessage(environment, fileReplaceCommandPlanTemplate, repository.Path, describeReplacementCommand(commandArguments, shellWrapped, shellProgram))
 		}
 		return nil
 	}
@@ -135,6 +149,9 @@ func handleFileReplaceAction(ctx context.Context, environment *Environment, repo
 		Details: execshell.CommandDetails{
 			Arguments:        commandArguments[1:],
 			WorkingDirectory: repository.Path,
+			Idempotent:       false,
+			ShellWrapped:     shellWrapped,
+			Shell:            shellProgram,
 		},
 	}
 
@@ -142,10 +159,23 @@ func handleFileReplaceAction(ctx context.Context, environment *Environment, repo
 		return executionError
 	}
 
-	writeReplacementMessage(environment, fileReplaceCommandApplyTemplate, repository.Path, strings.Join(commandArguments, " "))
+	writeReplacementMessage(environment, fileReplaceCommandApplyTemplate, repository.Path, describeReplacementCommand(commandArguments, shellWrapped, shellProgram))
 	return nil
 }
 
+// describeReplacementCommand renders the follow-up command for plan and apply messages, naming the login shell when
+// the command runs through one.
+func describeReplacementCommand(commandArguments []string, shellWrapped bool, shellProgram string) string {
+	commandLine := strings.Join(commandArguments, " ")
+	if !shellWrapped {
+		return commandLine
+	}
+	if len(shellProgram) == 0 {
+		shellProgram = fileReplaceDefaultShellProgram
+	}
+	return fmt.Sprintf(fileReplaceShellCommandTemplate, commandLine, shellProgram)
+}
+
 type replacementPlan struct {
 	absolutePath string
 	relativePath string
@@ -173,3 +173,40 @@ func TestHandleFileReplaceActionSafeguardSkips(t *testing.T) {
 	require.Contains(t, output.String(), "REPLACE-SKIP")
 	require.Empty(t, executor.commands)
 }
+
+func TestHandleFileReplaceActionShellWrappedCommand(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	targetPath := filepath.Join(tempDir, "example.txt")
+	require.NoError(t, filesystem.OSFileSystem{}.WriteFile(targetPath, []byte("alpha BETA"), 0o644))
+
+	executor := &recordingShellExecutor{clean: true, branch: "master"}
+	manager, managerError := gitrepo.NewRepositoryManager(executor)
+	require.NoError(t, managerError)
+
+	output := &bytes.Buffer{}
+	environment := &Environment{
+		FileSystem:        filesystem.OSFileSystem{},
+		RepositoryManager: manager,
+		GitExecutor:       executor,
+		Output:            output,
+	}
+
+	parameters := map[string]any{
+		"pattern":       "*.txt",
+		"find":          "BETA",
+		"replace":       "DELTA",
+		"command":       "npm run build",
+		"shell":         true,
+		"shell_program": "bash",
+	}
+
+	require.NoError(t, handleFileReplaceAction(context.Background(), environment, &RepositoryState{Path: tempDir}, parameters))
+
+	require.Contains(t, output.String(), "REPLACE-COMMAND: "+tempDir+" command=npm run build (via bash -lc)")
+	require.Len(t, executor.commands, 1)
+	require.Equal(t, execshell.CommandName("npm"), executor.commands[0].Name)
+	require.True(t, executor.commands[0].Details.ShellWrapped)
+	require.Equal(t, "bash", executor.commands[0].Details.Shell)
+}
@@ -0,0 +1,99 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	tokenAccessPreflightFailedLogMessageConstant  = "GitHub token access preflight failed; mutations will be attempted"
+	tokenAccessPreflightSkippedLogMessageConstant = "GitHub CLI not found; skipping the token access preflight"
+	readOnlyDegradationWarningConstant            = "WARNING: the GitHub token is read-only; running as a dry run (--degrade-readonly)\n"
+)
+
+// gitHubMutatingTaskActions lists the task actions that change GitHub state through the GitHub client.
+var gitHubMutatingTaskActions = map[string]struct{}{
+	taskActionEditRepository:    {},
+	taskActionCreatePullRequest: {},
+	taskActionBranchDefault:     {},
+}
+
+type wrappingOperation interface {
+	Unwrap() Operation
+}
+
+// resolveTokenAccess runs the auth preflight on the GitHub client, which then refuses mutations under a read-only
+// token. Runs started with --degrade-readonly turn into dry runs with a warning instead of failing on the first
+// mutation. Offline runs, runs without a GitHub client, and workflows whose steps never change GitHub state skip the
+// preflight.
+func (executor *Executor) resolveTokenAccess(executionContext context.Context, runtimeOptions *RuntimeOptions) githubcli.TokenAccess {
+	client := executor.dependencies.GitHubClient
+	if client == nil || runtimeOptions.Offline || !operationsMutateGitHub(executor.operations) {
+		return githubcli.TokenAccessUnknown
+	}
+
+	tokenAccess, detectionError := client.DetectTokenAccess(executionContext)
+	if detectionError != nil {
+		if executor.dependencies.Logger != nil {
+			logMessage := tokenAccessPreflightFailedLogMessageConstant
+			if execshell.IsExecutableNotFound(detectionError) {
+				logMessage = tokenAccessPreflightSkippedLogMessageConstant
+			}
+			executor.dependencies.Logger.Debug(logMessage, zap.Error(detectionError))
+		}
+		return githubcli.TokenAccessUnknown
+	}
+
+	if tokenAccess == githubcli.TokenAccessReadOnly && !runtimeOptions.DryRun && utils.NewCommandContextAccessor().ReadOnlyDegradation(executionContext) {
+		if executor.dependencies.Errors != nil {
+			fmt.Fprint(executor.dependencies.Errors, readOnlyDegradationWarningConstant)
+		}
+		runtimeOptions.DryRun = true
+	}
+	return tokenAccess
+}
+
+// operationsMutateGitHub reports whether any operation changes GitHub state through the GitHub client, which is what
+// the token preflight guards.
+func operationsMutateGitHub(operations []Operation) bool {
+	for _, operation := range operations {
+		if operationMutatesGitHub(operation) {
+			return true
+		}
+	}
+	return false
+}
+
+func operationMutatesGitHub(operation Operation) bool {
+	for {
+		wrapper, wraps := operation.(wrappingOperation)
+		if !wraps {
+			break
+		}
+		operation = wrapper.Unwrap()
+	}
+
+	switch typedOperation := operation.(type) {
+	case *EditRepositoryOperation, *CreatePullRequestOperation, *BranchMigrationOperation:
+		return true
+	case *TaskOperation:
+		for _, task := range typedOperation.tasks {
+			if task.PullRequest != nil {
+				return true
+			}
+			for _, action := range task.Actions {
+				if _, mutates := gitHubMutatingTaskActions[strings.ToLower(strings.TrimSpace(action.Type))]; mutates {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
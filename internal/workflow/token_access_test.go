@@ -0,0 +1,77 @@
+package workflow
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/githubcli"
+	"github.com/temirov/gix/internal/utils"
+)
+
+const (
+	readOnlyPreflightOutputConstant  = "HTTP/2.0 200 OK\nX-Oauth-Scopes: read:org\n\n{}"
+	readWritePreflightOutputConstant = "HTTP/2.0 200 OK\nX-Oauth-Scopes: repo\n\n{}"
+)
+
+type tokenPreflightExecutor struct {
+	noopGitExecutor
+	output string
+}
+
+func (executor tokenPreflightExecutor) ExecuteGitHubCLI(context.Context, execshell.CommandDetails) (execshell.ExecutionResult, error) {
+	return execshell.ExecutionResult{StandardOutput: executor.output}, nil
+}
+
+func TestExecutorResolveTokenAccess(testInstance *testing.T) {
+	testCases := []struct {
+		name            string
+		output          string
+		degrade         bool
+		dryRun          bool
+		offline         bool
+		operations      []Operation
+		expectedAccess  githubcli.TokenAccess
+		expectedDryRun  bool
+		expectedWarning bool
+	}{
+		{name: "read_write_keeps_run", output: readWritePreflightOutputConstant, degrade: true, expectedAccess: githubcli.TokenAccessReadWrite},
+		{name: "read_only_without_degrade_keeps_run", output: readOnlyPreflightOutputConstant, expectedAccess: githubcli.TokenAccessReadOnly},
+		{name: "read_only_with_degrade_becomes_dry_run", output: readOnlyPreflightOutputConstant, degrade: true, expectedAccess: githubcli.TokenAccessReadOnly, expectedDryRun: true, expectedWarning: true},
+		{name: "dry_run_is_not_warned", output: readOnlyPreflightOutputConstant, degrade: true, dryRun: true, expectedAccess: githubcli.TokenAccessReadOnly, expectedDryRun: true},
+		{name: "offline_skips_preflight", output: readOnlyPreflightOutputConstant, degrade: true, offline: true, expectedAccess: githubcli.TokenAccessUnknown},
+		{name: "pull_request_task_runs_preflight", output: readOnlyPreflightOutputConstant, operations: []Operation{&TaskOperation{tasks: []TaskDefinition{{Actions: []TaskActionDefinition{{Type: taskActionCreatePullRequest}}}}}}, expectedAccess: githubcli.TokenAccessReadOnly},
+		{name: "local_steps_skip_preflight", output: readOnlyPreflightOutputConstant, degrade: true, operations: []Operation{&ProtocolConversionOperation{}, &TaskOperation{tasks: []TaskDefinition{{Actions: []TaskActionDefinition{{Type: taskActionFileReplace}}}}}}, expectedAccess: githubcli.TokenAccessUnknown},
+	}
+
+	for _, testCase := range testCases {
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			client, clientError := githubcli.NewClient(tokenPreflightExecutor{output: testCase.output})
+			require.NoError(subtest, clientError)
+			errorsOutput := &strings.Builder{}
+			operations := testCase.operations
+			if operations == nil {
+				operations = []Operation{&TimedOperation{operation: &EditRepositoryOperation{}}}
+			}
+			executor := NewExecutor(operations, Dependencies{GitHubClient: client, Errors: errorsOutput})
+
+			executionContext := context.Background()
+			if testCase.degrade {
+				executionContext = utils.NewCommandContextAccessor().WithReadOnlyDegradation(executionContext, true)
+			}
+			runtimeOptions := RuntimeOptions{DryRun: testCase.dryRun, Offline: testCase.offline}
+
+			access := executor.resolveTokenAccess(executionContext, &runtimeOptions)
+			require.Equal(subtest, testCase.expectedAccess, access)
+			require.Equal(subtest, testCase.expectedDryRun, runtimeOptions.DryRun)
+			if testCase.expectedWarning {
+				require.Equal(subtest, readOnlyDegradationWarningConstant, errorsOutput.String())
+			} else {
+				require.Empty(subtest, errorsOutput.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,416 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/repos/shared"
+)
+
+const (
+	workingBranchDefaultRemoteConstant           = "origin"
+	workingBranchDateLayoutConstant              = "2006-01-02"
+	workingBranchDetachedHeadConstant            = "HEAD"
+	workingBranchLogPrefixApply                  = "WORKFLOW-BRANCH"
+	workingBranchLogPrefixPlan                   = "WORKFLOW-BRANCH-PLAN"
+	workingBranchLogPrefixSkip                   = "WORKFLOW-BRANCH-SKIP"
+	workingBranchLogPrefixPushed                 = "WORKFLOW-BRANCH-PUSHED"
+	workingBranchEnteredTemplateConstant         = "%s: %s branch=%s from=%s\n"
+	workingBranchPlanTemplateConstant            = "%s: %s branch=%s from=%s push=%s\n"
+	workingBranchSkipTemplateConstant            = "%s: %s branch=%s reason=%s\n"
+	workingBranchPushedTemplateConstant          = "%s: %s branch=%s remote=%s\n"
+	workingBranchNoPushPlanConstant              = "no"
+	workingBranchDirtyReasonConstant             = "worktree has uncommitted changes; commit or stash them before the workflow switches branches"
+	workingBranchNoCommitsReasonConstant         = "no changes committed"
+	workingBranchNameRequiredMessageConstant     = "workflow branch requires a name"
+	workingBranchTitleRequiredMessageConstant    = "workflow branch pull_request requires a title"
+	workingBranchTemplateErrorTemplateConstant   = "workflow branch %s template is invalid: %w"
+	workingBranchRenderErrorTemplateConstant     = "failed to render workflow branch %s for %s: %w"
+	workingBranchEmptyNameTemplateConstant       = "workflow branch name rendered empty for %s"
+	workingBranchSwitchErrorTemplateConstant     = "failed to switch %s to workflow branch %s: %w"
+	workingBranchPublishErrorTemplateConstant    = "failed to publish workflow branch %s of %s: %w"
+	workingBranchRestoreErrorTemplateConstant    = "failed to restore %s to %s after the workflow branch: %w"
+	workingBranchTaskPullRequestTemplateConstant = "task %q opens its own pull request without its own branch; with a workflow branch, declare branch.pull_request instead"
+	workingBranchNameFieldConstant               = "name"
+	workingBranchTitleFieldConstant              = "pull_request.title"
+	workingBranchBodyFieldConstant               = "pull_request.body"
+)
+
+// WorkingBranchConfiguration isolates the file changes of a workflow run on a dedicated branch in every repository.
+// Tasks without a branch of their own commit on it instead of on the checked-out branch, which is restored afterwards.
+type WorkingBranchConfiguration struct {
+	// NameTemplate renders the branch name from repository facts and .Date, for example "gix/workflow-{{ .Date }}".
+	NameTemplate string
+	// Remote receives the branch when it is pushed.
+	Remote string
+	// Push publishes the branch once the repository's steps committed to it.
+	Push bool
+	// PullRequest, when set, opens a pull request from the pushed branch; it implies Push.
+	PullRequest *WorkingBranchPullRequest
+}
+
+// WorkingBranchPullRequest describes the pull request opened from the workflow branch. Title and Body are templates
+// over the same values as the branch name; an empty Base selects the remote default branch.
+type WorkingBranchPullRequest struct {
+	Title string
+	Body  string
+	Base  string
+	Draft bool
+}
+
+// WorkingBranchTemplateData exposes templating values for the workflow branch name and pull request text. Date is the
+// run date formatted as YYYY-MM-DD.
+type WorkingBranchTemplateData struct {
+	TaskTemplateData
+	Date string
+}
+
+type workflowBranchSettings struct {
+	Name        string                             `yaml:"name" json:"name"`
+	Remote      string                             `yaml:"remote" json:"remote"`
+	Push        bool                               `yaml:"push" json:"push"`
+	PullRequest *workflowBranchPullRequestSettings `yaml:"pull_request" json:"pull_request"`
+}
+
+type workflowBranchPullRequestSettings struct {
+	Title string `yaml:"title" json:"title"`
+	Body  string `yaml:"body" json:"body"`
+	Base  string `yaml:"base" json:"base"`
+	Draft bool   `yaml:"draft" json:"draft"`
+}
+
+// UnmarshalYAML accepts either a branch name or a mapping with name, remote, push, and pull_request.
+func (settings *workflowBranchSettings) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&settings.Name)
+	}
+	type plainSettings workflowBranchSettings
+	return node.Decode((*plainSettings)(settings))
+}
+
+func buildWorkingBranchConfiguration(settings *workflowBranchSettings) (*WorkingBranchConfiguration, error) {
+	if settings == nil {
+		return nil, nil
+	}
+
+	nameTemplate := strings.TrimSpace(settings.Name)
+	if len(nameTemplate) == 0 {
+		return nil, errors.New(workingBranchNameRequiredMessageConstant)
+	}
+	if templateError := validateWorkingBranchTemplate(workingBranchNameFieldConstant, nameTemplate); templateError != nil {
+		return nil, templateError
+	}
+
+	remote := strings.TrimSpace(settings.Remote)
+	if len(remote) == 0 {
+		remote = workingBranchDefaultRemoteConstant
+	}
+
+	configuration := &WorkingBranchConfiguration{NameTemplate: nameTemplate, Remote: remote, Push: settings.Push}
+	if settings.PullRequest == nil {
+		return configuration, nil
+	}
+
+	title := strings.TrimSpace(settings.PullRequest.Title)
+	if len(title) == 0 {
+		return nil, errors.New(workingBranchTitleRequiredMessageConstant)
+	}
+	if templateError := validateWorkingBranchTemplate(workingBranchTitleFieldConstant, title); templateError != nil {
+		return nil, templateError
+	}
+	if templateError := validateWorkingBranchTemplate(workingBranchBodyFieldConstant, settings.PullRequest.Body); templateError != nil {
+		return nil, templateError
+	}
+
+	configuration.Push = true
+	configuration.PullRequest = &WorkingBranchPullRequest{
+		Title: title,
+		Body:  settings.PullRequest.Body,
+		Base:  strings.TrimSpace(settings.PullRequest.Base),
+		Draft: settings.PullRequest.Draft,
+	}
+	return configuration, nil
+}
+
+func validateWorkingBranchTemplate(field string, value string) error {
+	if _, parseError := parseWorkflowTemplate(value); parseError != nil {
+		return fmt.Errorf(workingBranchTemplateErrorTemplateConstant, field, parseError)
+	}
+	return nil
+}
+
+// validateWorkingBranchTasks rejects tasks whose pull request would be opened from the workflow branch mid-run.
+func validateWorkingBranchTasks(operation Operation, workingBranch *WorkingBranchConfiguration) error {
+	taskOperation, isTaskOperation := operation.(*TaskOperation)
+	if workingBranch == nil || !isTaskOperation {
+		return nil
+	}
+	for _, task := range taskOperation.tasks {
+		if task.PullRequest != nil && !taskDeclaresOwnBranch(task) {
+			return fmt.Errorf(workingBranchTaskPullRequestTemplateConstant, task.Name)
+		}
+	}
+	return nil
+}
+
+func taskDeclaresOwnBranch(task TaskDefinition) bool {
+	return len(strings.TrimSpace(task.Branch.NameTemplate)) > 0
+}
+
+// requiresWorkingBranch reports whether any task that applies to the repository writes files on the workflow branch.
+func requiresWorkingBranch(tasks []TaskDefinition, repository *RepositoryState) bool {
+	for _, task := range tasks {
+		if len(task.Files) > 0 && !taskDeclaresOwnBranch(task) && repositoryMatchesFilter(task.RepositoryFilter, repository) {
+			return true
+		}
+	}
+	return false
+}
+
+// workingBranchSession tracks the workflow branch of one repository between entering and leaving it.
+type workingBranchSession struct {
+	environment       *Environment
+	repository        *RepositoryState
+	configuration     *WorkingBranchConfiguration
+	templateData      WorkingBranchTemplateData
+	branchName        string
+	originalReference string
+	created           bool
+}
+
+// activeWorkingBranch returns the workflow branch checked out in the repository, if any.
+func (environment *Environment) activeWorkingBranch(repositoryPath string) (string, bool) {
+	branchName, active := environment.workingBranches[repositoryPath]
+	return branchName, active
+}
+
+func (environment *Environment) now() time.Time {
+	if environment.clock == nil {
+		return shared.SystemClock{}.Now()
+	}
+	return environment.clock.Now()
+}
+
+// enterWorkingBranch creates or checks out the workflow branch from the current head before the repository's tasks
+// run. It reports false when a dirty worktree blocks the switch, in which case the repository's tasks are skipped.
+func (environment *Environment) enterWorkingBranch(executionContext context.Context, repository *RepositoryState, tasks []TaskDefinition) (*workingBranchSession, bool, error) {
+	if environment.WorkingBranch == nil || !requiresWorkingBranch(tasks, repository) {
+		return nil, true, nil
+	}
+
+	session := &workingBranchSession{
+		environment:   environment,
+		repository:    repository,
+		configuration: environment.WorkingBranch,
+		templateData: WorkingBranchTemplateData{
+			TaskTemplateData: buildTaskTemplateData(repository, TaskDefinition{}),
+			Date:             environment.now().Format(workingBranchDateLayoutConstant),
+		},
+	}
+	branchName, renderError := session.render(workingBranchNameFieldConstant, environment.WorkingBranch.NameTemplate)
+	if renderError != nil {
+		return nil, false, renderError
+	}
+	session.branchName = strings.Join(strings.Fields(branchName), "-")
+	if len(session.branchName) == 0 {
+		return nil, false, fmt.Errorf(workingBranchEmptyNameTemplateConstant, repository.Path)
+	}
+
+	clean, cleanError := environment.RepositoryManager.CheckCleanWorktree(executionContext, repository.Path)
+	if cleanError != nil {
+		return nil, false, fmt.Errorf(workingBranchSwitchErrorTemplateConstant, repository.Path, session.branchName, cleanError)
+	}
+	if !clean {
+		environment.printWorkingBranchLine(workingBranchSkipTemplateConstant, workingBranchLogPrefixSkip, repository.Path, session.branchName, workingBranchDirtyReasonConstant)
+		return nil, false, nil
+	}
+
+	originalReference, referenceError := session.currentReference(executionContext)
+	if referenceError != nil {
+		return nil, false, fmt.Errorf(workingBranchSwitchErrorTemplateConstant, repository.Path, session.branchName, referenceError)
+	}
+	session.originalReference = originalReference
+
+	if environment.DryRun {
+		pushPlan := workingBranchNoPushPlanConstant
+		if session.configuration.Push {
+			pushPlan = session.configuration.Remote
+		}
+		environment.printWorkingBranchLine(workingBranchPlanTemplateConstant, workingBranchLogPrefixPlan, repository.Path, session.branchName, originalReference, pushPlan)
+		environment.recordWorkingBranch(repository.Path, session.branchName)
+		return session, true, nil
+	}
+
+	if originalReference != session.branchName {
+		if switchError := session.checkoutOrCreate(executionContext); switchError != nil {
+			return nil, false, fmt.Errorf(workingBranchSwitchErrorTemplateConstant, repository.Path, session.branchName, switchError)
+		}
+	}
+	environment.printWorkingBranchLine(workingBranchEnteredTemplateConstant, workingBranchLogPrefixApply, repository.Path, session.branchName, originalReference)
+	environment.recordWorkingBranch(repository.Path, session.branchName)
+	return session, true, nil
+}
+
+// leave publishes the branch when the tasks succeeded and committed to it, then restores the original checkout. The
+// restore runs even when the tasks failed or the repository timed out.
+func (session *workingBranchSession) leave(executionContext context.Context, tasksError error) error {
+	if session == nil {
+		return tasksError
+	}
+	environment := session.environment
+	delete(environment.workingBranches, session.repository.Path)
+	restoreContext := context.WithoutCancel(executionContext)
+
+	var publishError error
+	hasCommits := true
+	if tasksError == nil {
+		if !environment.DryRun {
+			var countError error
+			hasCommits, countError = session.hasCommits(restoreContext)
+			if countError != nil {
+				publishError = countError
+			}
+		}
+		if publishError == nil && !hasCommits {
+			environment.printWorkingBranchLine(workingBranchSkipTemplateConstant, workingBranchLogPrefixSkip, session.repository.Path, session.branchName, workingBranchNoCommitsReasonConstant)
+		}
+		if publishError == nil && hasCommits {
+			publishError = session.publish(executionContext)
+		}
+		if publishError != nil {
+			publishError = fmt.Errorf(workingBranchPublishErrorTemplateConstant, session.branchName, session.repository.Path, publishError)
+		}
+	}
+
+	if environment.DryRun || session.originalReference == session.branchName {
+		return errors.Join(tasksError, publishError)
+	}
+
+	var restoreError error
+	if checkoutError := environment.RepositoryManager.CheckoutBranch(restoreContext, session.repository.Path, session.originalReference); checkoutError != nil {
+		restoreError = fmt.Errorf(workingBranchRestoreErrorTemplateConstant, session.repository.Path, session.originalReference, checkoutError)
+	} else if session.created && tasksError == nil && publishError == nil && !hasCommits {
+		_ = environment.RepositoryManager.DeleteBranch(restoreContext, session.repository.Path, session.branchName, false)
+	}
+	return errors.Join(tasksError, publishError, restoreError)
+}
+
+func (session *workingBranchSession) publish(executionContext context.Context) error {
+	environment := session.environment
+	if !session.configuration.Push {
+		return nil
+	}
+
+	if !environment.DryRun {
+		pushArguments := []string{"push", "--set-upstream", session.configuration.Remote, session.branchName}
+		if _, pushError := environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: pushArguments, WorkingDirectory: session.repository.Path, Idempotent: false}); pushError != nil {
+			return pushError
+		}
+		environment.printWorkingBranchLine(workingBranchPushedTemplateConstant, workingBranchLogPrefixPushed, session.repository.Path, session.branchName, session.configuration.Remote)
+	}
+
+	pullRequest := session.configuration.PullRequest
+	if pullRequest == nil {
+		return nil
+	}
+	title, titleError := session.render(workingBranchTitleFieldConstant, pullRequest.Title)
+	if titleError != nil {
+		return titleError
+	}
+	body, bodyError := session.render(workingBranchBodyFieldConstant, pullRequest.Body)
+	if bodyError != nil {
+		return bodyError
+	}
+	parameters := map[string]any{
+		optionTaskPRTitleKeyConstant:     title,
+		optionTaskPRBodyKeyConstant:      body,
+		optionTaskPRBaseKeyConstant:      pullRequest.Base,
+		optionPullRequestHeadKeyConstant: session.branchName,
+		optionTaskPRDraftKeyConstant:     pullRequest.Draft,
+	}
+	return handleCreatePullRequestAction(executionContext, environment, session.repository, parameters)
+}
+
+func (session *workingBranchSession) render(field string, value string) (string, error) {
+	parsedTemplate, parseError := parseWorkflowTemplate(value)
+	if parseError != nil {
+		return "", fmt.Errorf(workingBranchRenderErrorTemplateConstant, field, session.repository.Path, parseError)
+	}
+	var buffer bytes.Buffer
+	if executeError := parsedTemplate.Execute(&buffer, session.templateData); executeError != nil {
+		return "", fmt.Errorf(workingBranchRenderErrorTemplateConstant, field, session.repository.Path, executeError)
+	}
+	return strings.TrimSpace(buffer.String()), nil
+}
+
+// currentReference returns the checked-out branch, or the commit when HEAD is detached, so it can be restored later.
+func (session *workingBranchSession) currentReference(executionContext context.Context) (string, error) {
+	environment := session.environment
+	currentBranch, branchError := environment.RepositoryManager.GetCurrentBranch(executionContext, session.repository.Path)
+	if branchError != nil {
+		return "", branchError
+	}
+	if currentBranch != workingBranchDetachedHeadConstant {
+		return currentBranch, nil
+	}
+	result, revisionError := environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: []string{"rev-parse", workingBranchDetachedHeadConstant}, WorkingDirectory: session.repository.Path, Idempotent: true})
+	if revisionError != nil {
+		return "", revisionError
+	}
+	return strings.TrimSpace(result.StandardOutput), nil
+}
+
+func (session *workingBranchSession) checkoutOrCreate(executionContext context.Context) error {
+	environment := session.environment
+	_, verifyError := environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: []string{"rev-parse", "--verify", "--quiet", "refs/heads/" + session.branchName}, WorkingDirectory: session.repository.Path, Idempotent: true})
+	if verifyError != nil {
+		var commandError execshell.CommandFailedError
+		if !errors.As(verifyError, &commandError) {
+			return verifyError
+		}
+		if createError := environment.RepositoryManager.CreateBranch(executionContext, session.repository.Path, session.branchName, ""); createError != nil {
+			return createError
+		}
+		session.created = true
+	}
+	return environment.RepositoryManager.CheckoutBranch(executionContext, session.repository.Path, session.branchName)
+}
+
+// hasCommits reports whether the workflow branch moved past the original checkout.
+func (session *workingBranchSession) hasCommits(executionContext context.Context) (bool, error) {
+	if session.originalReference == session.branchName {
+		return true, nil
+	}
+	revisionRange := session.originalReference + ".." + session.branchName
+	result, countError := session.environment.GitExecutor.ExecuteGit(executionContext, execshell.CommandDetails{Arguments: []string{"rev-list", "--count", revisionRange}, WorkingDirectory: session.repository.Path, Idempotent: true})
+	if countError != nil {
+		return false, countError
+	}
+	commitCount, parseError := strconv.Atoi(strings.TrimSpace(result.StandardOutput))
+	if parseError != nil {
+		return false, parseError
+	}
+	return commitCount > 0, nil
+}
+
+func (environment *Environment) recordWorkingBranch(repositoryPath string, branchName string) {
+	if environment.workingBranches == nil {
+		environment.workingBranches = map[string]string{}
+	}
+	environment.workingBranches[repositoryPath] = branchName
+}
+
+func (environment *Environment) printWorkingBranchLine(format string, arguments ...any) {
+	if environment.Output == nil {
+		return
+	}
+	fmt.Fprintf(environment.Output, format, arguments...)
+}
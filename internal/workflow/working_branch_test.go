@@ -0,0 +1,223 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temirov/gix/internal/audit"
+	"github.com/temirov/gix/internal/execshell"
+	"github.com/temirov/gix/internal/execshell/execshelltest"
+	"github.com/temirov/gix/internal/gitrepo"
+)
+
+const (
+	workingBranchTestRepositoryPathConstant = "/repositories/sample"
+	workingBranchTestBranchConstant         = "gix/workflow-2025-03-04"
+)
+
+type fixedWorkingBranchClock struct {
+	now time.Time
+}
+
+func (clock fixedWorkingBranchClock) Now() time.Time {
+	return clock.now
+}
+
+func workingBranchTaskStep(taskName string, filePath string) StepConfiguration {
+	return StepConfiguration{
+		Operation: OperationTypeApplyTasks,
+		Options: map[string]any{
+			optionTasksKeyConstant: []any{
+				map[string]any{
+					optionTaskNameKeyConstant: taskName,
+					optionTaskFilesKeyConstant: []any{
+						map[string]any{optionTaskFilePathKeyConstant: filePath, optionTaskFileContentKeyConstant: taskName},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestWorkingBranchIsolatesTaskCommits(testInstance *testing.T) {
+	testCases := []struct {
+		name              string
+		dryRun            bool
+		dirty             bool
+		existingLicense   bool
+		pullRequest       bool
+		commitCount       string
+		expectedOutput    string
+		expectedArguments [][]string
+		forbiddenCommands []string
+	}{
+		{
+			name:        "commits_on_branch_pushes_and_restores",
+			commitCount: "1\n",
+			expectedOutput: "WORKFLOW-BRANCH: /repositories/sample branch=gix/workflow-2025-03-04 from=main\n" +
+				"TASK-APPLY: Add license /repositories/sample applied branch=gix/workflow-2025-03-04\n" +
+				"WORKFLOW-BRANCH-PUSHED: /repositories/sample branch=gix/workflow-2025-03-04 remote=origin\n",
+			expectedArguments: [][]string{
+				{"branch", workingBranchTestBranchConstant},
+				{"checkout", workingBranchTestBranchConstant},
+				{"add", "LICENSE"},
+				{"push", "--set-upstream", "origin", workingBranchTestBranchConstant},
+				{"checkout", "main"},
+			},
+		},
+		{
+			name:              "dirty_worktree_blocks_switch",
+			dirty:             true,
+			expectedOutput:    "WORKFLOW-BRANCH-SKIP: /repositories/sample branch=gix/workflow-2025-03-04 reason=worktree has uncommitted changes; commit or stash them before the workflow switches branches\n",
+			forbiddenCommands: []string{"checkout", "branch", "commit", "push"},
+		},
+		{
+			name:            "branch_without_commits_is_removed",
+			existingLicense: true,
+			commitCount:     "0\n",
+			expectedOutput: "WORKFLOW-BRANCH: /repositories/sample branch=gix/workflow-2025-03-04 from=main\n" +
+				"TASK-NOOP: Add license /repositories/sample branch=gix/workflow-2025-03-04 base=\n" +
+				"TASK-NOOP: Add license file=LICENSE action=skip (unchanged)\n" +
+				"WORKFLOW-BRANCH-SKIP: /repositories/sample branch=gix/workflow-2025-03-04 reason=no changes committed\n",
+			expectedArguments: [][]string{
+				{"branch", workingBranchTestBranchConstant},
+				{"checkout", workingBranchTestBranchConstant},
+				{"checkout", "main"},
+				{"branch", "--delete", workingBranchTestBranchConstant},
+			},
+			forbiddenCommands: []string{"commit", "push"},
+		},
+		{
+			name:        "dry_run_plans_branch_and_pull_request",
+			dryRun:      true,
+			pullRequest: true,
+			expectedOutput: "WORKFLOW-BRANCH-PLAN: /repositories/sample branch=gix/workflow-2025-03-04 from=main push=origin\n" +
+				"TASK-PLAN: Add license /repositories/sample branch=gix/workflow-2025-03-04 base=\n" +
+				"TASK-PLAN: Add license file=LICENSE action=write\n" +
+				"PULL-REQUEST-PLAN: /repositories/sample (octocat/sample) gix/workflow-2025-03-04 -> main title=\"Workflow sample\"\n",
+			forbiddenCommands: []string{"checkout", "branch", "commit", "push"},
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			workingBranch := &WorkingBranchConfiguration{NameTemplate: "gix/workflow-{{ .Date }}", Remote: "origin", Push: true}
+			if testCase.pullRequest {
+				workingBranch.PullRequest = &WorkingBranchPullRequest{Title: "Workflow {{ .Repository.Name }}"}
+			}
+			operations, buildError := BuildOperations(Configuration{Steps: []StepConfiguration{workingBranchTaskStep("Add license", "LICENSE")}, WorkingBranch: workingBranch})
+			require.NoError(subtest, buildError)
+
+			gitExecutor := execshelltest.NewPermissiveExecutor()
+			if testCase.dirty {
+				gitExecutor.OnGit("status", "--porcelain").ReturnOutput(" M README.md\n")
+			}
+			gitExecutor.OnGit("rev-parse", "--abbrev-ref", "HEAD").ReturnOutput("main\n")
+			gitExecutor.OnGit("rev-parse", "--verify", "--quiet", "refs/heads/"+workingBranchTestBranchConstant).FailWith(1, "")
+			gitExecutor.OnGit("rev-list", "--count", "main.."+workingBranchTestBranchConstant).ReturnOutput(testCase.commitCount)
+			repositoryManager, managerError := gitrepo.NewRepositoryManager(gitExecutor)
+			require.NoError(subtest, managerError)
+
+			initialFiles := map[string][]byte{}
+			if testCase.existingLicense {
+				initialFiles[filepath.Join(workingBranchTestRepositoryPathConstant, "LICENSE")] = []byte("Add license")
+			}
+			outputBuffer := &bytes.Buffer{}
+			environment := &Environment{
+				GitExecutor:       gitExecutor,
+				RepositoryManager: repositoryManager,
+				FileSystem:        newFakeFileSystem(initialFiles),
+				Output:            outputBuffer,
+				DryRun:            testCase.dryRun,
+				WorkingBranch:     workingBranch,
+				clock:             fixedWorkingBranchClock{now: time.Date(2025, time.March, 4, 10, 0, 0, 0, time.UTC)},
+			}
+			repository := NewRepositoryState(audit.RepositoryInspection{Path: workingBranchTestRepositoryPathConstant, FinalOwnerRepo: "octocat/sample", RemoteDefaultBranch: "main"})
+			state := &State{Repositories: []*RepositoryState{repository}}
+
+			require.NoError(subtest, operations[0].Execute(context.Background(), environment, state))
+			require.Equal(subtest, testCase.expectedOutput, outputBuffer.String())
+			require.Empty(subtest, environment.workingBranches)
+
+			executedArguments := gitExecutor.ExecutedArguments(execshell.CommandGit)
+			for _, arguments := range executedArguments {
+				require.NotContains(subtest, testCase.forbiddenCommands, arguments[0], arguments)
+			}
+			mutatingArguments := make([][]string, 0, len(executedArguments))
+			for _, arguments := range executedArguments {
+				switch arguments[0] {
+				case "branch", "checkout", "add", "push":
+					mutatingArguments = append(mutatingArguments, arguments)
+				}
+			}
+			if len(testCase.expectedArguments) > 0 {
+				require.Equal(subtest, testCase.expectedArguments, mutatingArguments)
+			}
+		})
+	}
+}
+
+func TestLoadConfigurationWorkingBranch(testInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		content       string
+		expected      *WorkingBranchConfiguration
+		expectedError string
+	}{
+		{
+			name:     "scalar_name",
+			content:  "branch: gix/workflow-{{ .Date }}\nworkflow:\n  - step:\n      operation: audit-report\n",
+			expected: &WorkingBranchConfiguration{NameTemplate: "gix/workflow-{{ .Date }}", Remote: "origin"},
+		},
+		{
+			name:     "pull_request_implies_push",
+			content:  "branch:\n  name: gix/cleanup\n  remote: upstream\n  pull_request:\n    title: Cleanup\n    draft: true\nworkflow:\n  - step:\n      operation: audit-report\n",
+			expected: &WorkingBranchConfiguration{NameTemplate: "gix/cleanup", Remote: "upstream", Push: true, PullRequest: &WorkingBranchPullRequest{Title: "Cleanup", Draft: true}},
+		},
+		{
+			name:          "pull_request_requires_title",
+			content:       "branch:\n  name: gix/cleanup\n  pull_request:\n    body: Cleanup\nworkflow:\n  - step:\n      operation: audit-report\n",
+			expectedError: workingBranchTitleRequiredMessageConstant,
+		},
+		{
+			name:          "invalid_name_template",
+			content:       "branch: gix/{{ .Date\nworkflow:\n  - step:\n      operation: audit-report\n",
+			expectedError: "workflow branch name template is invalid",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			configurationPath := filepath.Join(subtest.TempDir(), "workflow.yaml")
+			require.NoError(subtest, os.WriteFile(configurationPath, []byte(testCase.content), 0o600))
+
+			configuration, loadError := LoadConfiguration(configurationPath)
+			if len(testCase.expectedError) > 0 {
+				require.ErrorContains(subtest, loadError, testCase.expectedError)
+				return
+			}
+			require.NoError(subtest, loadError)
+			require.Equal(subtest, testCase.expected, configuration.WorkingBranch)
+		})
+	}
+}
+
+func TestBuildOperationsRejectsTaskPullRequestOnWorkingBranch(testInstance *testing.T) {
+	step := workingBranchTaskStep("Add license", "LICENSE")
+	taskEntry := step.Options[optionTasksKeyConstant].([]any)[0].(map[string]any)
+	taskEntry[optionTaskPullRequestKeyConstant] = map[string]any{optionTaskPRTitleKeyConstant: "Add license"}
+
+	_, buildError := BuildOperations(Configuration{Steps: []StepConfiguration{step}, WorkingBranch: &WorkingBranchConfiguration{NameTemplate: "gix/cleanup"}})
+	require.EqualError(testInstance, buildError, `task "Add license" opens its own pull request without its own branch; with a workflow branch, declare branch.pull_request instead`)
+
+	_, buildError = BuildOperations(Configuration{Steps: []StepConfiguration{step}})
+	require.NoError(testInstance, buildError)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -9,12 +10,22 @@ import (
 
 const (
 	exitErrorTemplateConstant = "%v\n"
+	defaultExitCodeConstant   = 1
 )
 
+type processExitCoder interface {
+	ProcessExitCode() int
+}
+
 // main executes the gix command-line application.
 func main() {
 	if executionError := cli.Execute(); executionError != nil {
 		fmt.Fprintf(os.Stderr, exitErrorTemplateConstant, executionError)
-		os.Exit(1)
+		exitCode := defaultExitCodeConstant
+		var exitCoder processExitCoder
+		if errors.As(executionError, &exitCoder) {
+			exitCode = exitCoder.ProcessExitCode()
+		}
+		os.Exit(exitCode)
 	}
 }
@@ -15,6 +15,7 @@ const (
 	integrationUnexpectedSuccessMessageConstant = "command succeeded unexpectedly"
 	integrationUnexpectedSuccessFormatConstant  = "%s\n%s"
 	integrationCommandFailureFormatConstant     = "command failed: %v\n%s"
+	integrationTimingSummaryPrefixConstant      = "done in "
 	pathEnvironmentVariableNameConstant         = "PATH"
 	gitConfigSystemEnvironmentNameConstant      = "GIT_CONFIG_SYSTEM"
 	gitConfigGlobalEnvironmentNameConstant      = "GIT_CONFIG_GLOBAL"
@@ -124,6 +125,9 @@ func filterStructuredOutput(rawOutput string) string {
 		if strings.HasPrefix(trimmed, "{") {
 			continue
 		}
+		if strings.HasPrefix(trimmed, integrationTimingSummaryPrefixConstant) {
+			continue
+		}
 		filtered = append(filtered, line)
 	}
 	if len(filtered) == 0 {
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -29,6 +30,9 @@ const (
 	migrationRetainBranchCaseNameConstant     = "retain_source_branch_with_open_pr"
 	migrationDeleteBranchCaseNameConstant     = "delete_source_branch_when_safe"
 	migrationSkipDeletionCaseNameConstant     = "skip_deletion_when_not_safe"
+	migrationArchiveBranchCaseNameConstant    = "archive_source_branch_when_safe"
+	migrationSkipArchiveCaseNameConstant      = "skip_archive_when_not_safe"
+	migrationArchivedBranchNameConstant       = "archive/main-2024-05-01"
 	migrationSubtestNameTemplateConstant      = "%d_%s"
 	gitExecutableNameConstant                 = "git"
 	gitDirOptionConstant                      = "--git-dir"
@@ -49,6 +53,15 @@ type recordingGitHubOperations struct {
 	branchProtectionEnabled bool
 	remoteGitDirectoryPath  string
 	currentDefaultBranch    string
+	lockedBranches          []string
+}
+
+type fixedMigrationClock struct {
+	instant time.Time
+}
+
+func (clock fixedMigrationClock) Now() time.Time {
+	return clock.instant
 }
 
 func (operations *recordingGitHubOperations) GetPagesConfig(_ context.Context, repository string) (githubcli.PagesStatus, error) {
@@ -113,10 +126,45 @@ func (operations *recordingGitHubOperations) ResolveRepoMetadata(_ context.Conte
 	return githubcli.RepositoryMetadata{NameWithOwner: repository, DefaultBranch: defaultBranch}, nil
 }
 
-func (operations *recordingGitHubOperations) CheckBranchProtection(_ context.Context, repository string, branchName string) (bool, error) {
+func (operations *recordingGitHubOperations) GetBranchProtection(_ context.Context, repository string, branchName string) (githubcli.BranchProtection, bool, error) {
 	_ = repository
 	_ = branchName
-	return operations.branchProtectionEnabled, nil
+	return githubcli.BranchProtection{}, operations.branchProtectionEnabled, nil
+}
+
+func (operations *recordingGitHubOperations) ListBranchProtectionRules(_ context.Context, repository string) (githubcli.BranchProtectionRules, error) {
+	_ = repository
+	return githubcli.BranchProtectionRules{}, nil
+}
+
+func (operations *recordingGitHubOperations) LockBranch(_ context.Context, repository string, branchName string) error {
+	_ = repository
+	operations.lockedBranches = append(operations.lockedBranches, branchName)
+	return nil
+}
+
+func (operations *recordingGitHubOperations) GetPagesConfigResponse(context.Context, string) ([]byte, error) {
+	return nil, nil
+}
+
+func (operations *recordingGitHubOperations) GetBranchProtectionResponse(context.Context, string, string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (operations *recordingGitHubOperations) DescribeUpdatePagesConfig(repository string, configuration githubcli.PagesConfiguration) (githubcli.IntendedRequest, error) {
+	return (&githubcli.Client{}).DescribeUpdatePagesConfig(repository, configuration)
+}
+
+func (operations *recordingGitHubOperations) DescribeSetDefaultBranch(repository string, branchName string) (githubcli.IntendedRequest, error) {
+	return (&githubcli.Client{}).DescribeSetDefaultBranch(repository, branchName)
+}
+
+func (operations *recordingGitHubOperations) DescribeUpdatePullRequestBase(repository string, pullRequestNumber int, baseBranch string) (githubcli.IntendedRequest, error) {
+	return (&githubcli.Client{}).DescribeUpdatePullRequestBase(repository, pullRequestNumber, baseBranch)
+}
+
+func (operations *recordingGitHubOperations) DescribeLockBranch(repository string, branchName string) (githubcli.IntendedRequest, error) {
+	return (&githubcli.Client{}).DescribeLockBranch(repository, branchName)
 }
 
 func TestMigrationIntegration(testInstance *testing.T) {
@@ -125,11 +173,13 @@ func TestMigrationIntegration(testInstance *testing.T) {
 		pullRequests            []githubcli.PullRequest
 		branchProtectionEnabled bool
 		deleteSourceBranch      bool
+		retainSource            migrate.SourceRetentionMode
 		expectSafe              bool
 		expectedBlockingReasons []string
 		expectedRetargeted      []int
 		expectLocalBranch       bool
 		expectRemoteBranch      bool
+		expectedArchivedBranch  string
 	}{
 		{
 			name:                    migrationRetainBranchCaseNameConstant,
@@ -164,6 +214,29 @@ func TestMigrationIntegration(testInstance *testing.T) {
 			expectLocalBranch:       true,
 			expectRemoteBranch:      true,
 		},
+		{
+			name:                    migrationArchiveBranchCaseNameConstant,
+			pullRequests:            nil,
+			branchProtectionEnabled: false,
+			retainSource:            migrate.SourceRetentionArchive,
+			expectSafe:              true,
+			expectedBlockingReasons: nil,
+			expectedRetargeted:      nil,
+			expectLocalBranch:       true,
+			expectRemoteBranch:      false,
+			expectedArchivedBranch:  migrationArchivedBranchNameConstant,
+		},
+		{
+			name:                    migrationSkipArchiveCaseNameConstant,
+			pullRequests:            []githubcli.PullRequest{{Number: 56}},
+			branchProtectionEnabled: false,
+			retainSource:            migrate.SourceRetentionArchive,
+			expectSafe:              false,
+			expectedBlockingReasons: []string{"open pull requests still target source branch"},
+			expectedRetargeted:      []int{56},
+			expectLocalBranch:       true,
+			expectRemoteBranch:      true,
+		},
 	}
 
 	for testCaseIndex := range testCases {
@@ -226,6 +299,7 @@ func TestMigrationIntegration(testInstance *testing.T) {
 				RepositoryManager: repositoryManager,
 				GitHubClient:      githubOperations,
 				GitExecutor:       executor,
+				Clock:             fixedMigrationClock{instant: time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)},
 			})
 			require.NoError(subtest, serviceError)
 
@@ -238,6 +312,7 @@ func TestMigrationIntegration(testInstance *testing.T) {
 				TargetBranch:         migrate.BranchMaster,
 				PushUpdates:          false,
 				DeleteSourceBranch:   testCase.deleteSourceBranch,
+				RetainSource:         testCase.retainSource,
 			}
 
 			result, migrationError := service.Execute(context.Background(), options)
@@ -274,6 +349,14 @@ func TestMigrationIntegration(testInstance *testing.T) {
 
 			require.Equal(subtest, testCase.expectLocalBranch, branchExists(subtest, repositoryDirectory, "main"))
 			require.Equal(subtest, testCase.expectRemoteBranch, remoteBranchExists(subtest, remotePath, "main"))
+			require.Equal(subtest, testCase.expectedArchivedBranch, result.ArchivedSourceBranch)
+			require.Equal(subtest, testCase.deleteSourceBranch && testCase.expectSafe, result.SourceBranchDeleted)
+			if len(testCase.expectedArchivedBranch) > 0 {
+				require.True(subtest, remoteBranchExists(subtest, remotePath, testCase.expectedArchivedBranch))
+				require.Equal(subtest, []string{testCase.expectedArchivedBranch}, githubOperations.lockedBranches)
+			} else {
+				require.Empty(subtest, githubOperations.lockedBranches)
+			}
 		})
 	}
 }
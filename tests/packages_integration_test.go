@@ -34,6 +34,8 @@ const (
 	packagesIntegrationDeleteActionCommand              = "delete"
 	packagesIntegrationCommandTimeout                   = 10 * time.Second
 	packagesIntegrationExpectedPageSizeConstant         = 100
+	packagesIntegrationPermissionProbePageSizeConstant  = 1
+	packagesIntegrationPermissionSentinelIDConstant     = 0
 	packagesIntegrationTaggedVersionIDConstant          = 101
 	packagesIntegrationFirstUntaggedVersionIDConstant   = 202
 	packagesIntegrationSecondUntaggedVersionIDConstant  = 303
@@ -68,6 +70,7 @@ type packagesIntegrationServer struct {
 	pageOnePayload       string
 	listRequests         []packagesIntegrationListRequest
 	deleteRequests       []packagesIntegrationDeleteRequest
+	permissionProbes     []string
 	authorizationHeaders []string
 }
 
@@ -84,6 +87,9 @@ func (server *packagesIntegrationServer) ServeHTTP(responseWriter http.ResponseW
 	case http.MethodGet:
 		pageValue := request.URL.Query().Get("page")
 		perPageValue := request.URL.Query().Get("per_page")
+		if len(pageValue) == 0 {
+			pageValue = "1"
+		}
 		pageNumber, pageParseError := strconv.Atoi(pageValue)
 		if pageParseError != nil {
 			responseWriter.WriteHeader(http.StatusBadRequest)
@@ -98,6 +104,15 @@ func (server *packagesIntegrationServer) ServeHTTP(responseWriter http.ResponseW
 			return
 		}
 
+		if perPageNumber == packagesIntegrationPermissionProbePageSizeConstant {
+			server.mutex.Lock()
+			server.permissionProbes = append(server.permissionProbes, request.Method)
+			server.mutex.Unlock()
+			responseWriter.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(responseWriter, "[]")
+			return
+		}
+
 		listRequest := packagesIntegrationListRequest{
 			path:    request.URL.Path,
 			page:    pageNumber,
@@ -110,6 +125,7 @@ func (server *packagesIntegrationServer) ServeHTTP(responseWriter http.ResponseW
 
 		responseWriter.Header().Set("Content-Type", "application/json")
 		if pageNumber == 1 {
+			responseWriter.Header().Set("Link", fmt.Sprintf(`<%s?per_page=%d&page=2>; rel="next"`, request.URL.Path, perPageNumber))
 			_, _ = fmt.Fprint(responseWriter, server.pageOnePayload)
 			return
 		}
@@ -131,6 +147,14 @@ func (server *packagesIntegrationServer) ServeHTTP(responseWriter http.ResponseW
 			return
 		}
 
+		if versionID == packagesIntegrationPermissionSentinelIDConstant {
+			server.mutex.Lock()
+			server.permissionProbes = append(server.permissionProbes, request.Method)
+			server.mutex.Unlock()
+			responseWriter.WriteHeader(http.StatusNotFound)
+			return
+		}
+
 		deleteRequest := packagesIntegrationDeleteRequest{
 			path:      request.URL.Path,
 			versionID: versionID,
@@ -154,6 +178,12 @@ func (server *packagesIntegrationServer) snapshotListRequests() []packagesIntegr
 	return requests
 }
 
+func (server *packagesIntegrationServer) snapshotPermissionProbes() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]string(nil), server.permissionProbes...)
+}
+
 func (server *packagesIntegrationServer) snapshotDeleteRequests() []packagesIntegrationDeleteRequest {
 	server.mutex.Lock()
 	defer server.mutex.Unlock()
@@ -270,9 +300,12 @@ func TestPackagesCommandIntegration(testInstance *testing.T) {
 			require.Equal(subtest, packagesIntegrationExpectedPageSizeConstant, listRequests[1].perPage)
 
 			deleteRequests := serverState.snapshotDeleteRequests()
+			permissionProbes := serverState.snapshotPermissionProbes()
 			if len(testCase.expectedDeleteIDs) == 0 {
 				require.Empty(subtest, deleteRequests)
+				require.Empty(subtest, permissionProbes)
 			} else {
+				require.Equal(subtest, []string{http.MethodGet, http.MethodDelete}, permissionProbes)
 				require.GreaterOrEqual(subtest, len(deleteRequests), len(testCase.expectedDeleteIDs))
 				for deleteIndex, expectedIdentifier := range testCase.expectedDeleteIDs {
 					deleteRequest := deleteRequests[deleteIndex]
@@ -289,7 +322,7 @@ func TestPackagesCommandIntegration(testInstance *testing.T) {
 
 			authorizationHeaders := serverState.snapshotAuthorizationHeaders()
 			expectedAuthorization := fmt.Sprintf(packagesIntegrationAuthorizationTemplateConstant, packagesIntegrationTokenValueConstant)
-			expectedHeaderCount := len(listRequests) + len(deleteRequests)
+			expectedHeaderCount := len(listRequests) + len(deleteRequests) + len(permissionProbes)
 			require.Len(subtest, authorizationHeaders, expectedHeaderCount)
 			for _, headerValue := range authorizationHeaders {
 				require.Equal(subtest, expectedAuthorization, headerValue)
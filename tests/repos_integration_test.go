@@ -63,7 +63,7 @@ const (
 	reposIntegrationProtocolConfigDryRunCase    = "convert_protocol_config_dry_run_literal"
 	reposIntegrationHistoryRemoveCaseName       = "history_remove_dry_run"
 	reposIntegrationProtocolHelpCaseName        = "protocol_help_missing_flags"
-	reposIntegrationProtocolUsageSnippet        = "gix repo remote update-protocol [flags]"
+	reposIntegrationProtocolUsageSnippet        = "gix repo remote update-protocol [root ...] [flags]"
 	reposIntegrationProtocolMissingFlagsMessage = "specify both --from and --to"
 	reposIntegrationConfigFlagName              = "--config"
 	reposIntegrationConfigFileName              = "config.yaml"
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rootsStdinListCommand = "list"
+	rootsStdinFormatFlag  = "--format"
+	rootsStdinPathsFormat = "paths"
+	rootsStdinValue       = "-"
+	rootsStdinCommentLine = "# repositories piped from gix repo list\n\n"
+	rootsStdinSkipSnippet = "UPDATE-REMOTE-SKIP: "
+)
+
+func TestRootsReadFromStandardInputIntegration(testInstance *testing.T) {
+	workingDirectory, workingDirectoryError := os.Getwd()
+	require.NoError(testInstance, workingDirectoryError)
+	binaryPath := buildIntegrationBinary(testInstance, filepath.Dir(workingDirectory))
+
+	testCases := []struct {
+		name              string
+		updateArguments   []string
+		expectedOutput    func(repositoryPath string) string
+		expectedOriginURL string
+	}{
+		{
+			name:            "piped_paths_drive_remote_update",
+			updateArguments: []string{reposIntegrationYesFlag},
+			expectedOutput: func(repositoryPath string) string {
+				return "UPDATE-REMOTE-DONE: " + repositoryPath + " origin now https://github.com/canonical/example.git\n"
+			},
+			expectedOriginURL: "https://github.com/canonical/example.git\n",
+		},
+		{
+			name: "confirmations_cannot_read_consumed_stdin",
+			expectedOutput: func(repositoryPath string) string {
+				return rootsStdinSkipSnippet + repositoryPath
+			},
+			expectedOriginURL: reposIntegrationOriginURL + "\n",
+		},
+	}
+
+	for testCaseIndex := range testCases {
+		testCase := testCases[testCaseIndex]
+		testInstance.Run(testCase.name, func(subtest *testing.T) {
+			repositoryPath, extendedPath := initializeRepositoryWithStub(subtest)
+			resolvedRepositoryPath, resolveError := filepath.EvalSymlinks(repositoryPath)
+			require.NoError(subtest, resolveError)
+			commandEnvironment := buildCommandEnvironment(integrationCommandOptions{PathVariable: extendedPath})
+
+			listContext, cancelList := context.WithTimeout(context.Background(), reposIntegrationTimeout)
+			defer cancelList()
+			listCommand := exec.CommandContext(listContext, binaryPath,
+				reposIntegrationLogLevelFlag, reposIntegrationErrorLevel,
+				reposIntegrationRepoNamespaceCommand, rootsStdinListCommand,
+				rootsStdinFormatFlag, rootsStdinPathsFormat,
+				reposIntegrationRootFlag, filepath.Dir(resolvedRepositoryPath),
+			)
+			listCommand.Env = commandEnvironment
+			listOutput, listError := listCommand.Output()
+			require.NoError(subtest, listError)
+			require.Equal(subtest, resolvedRepositoryPath+"\n", string(listOutput))
+
+			updateContext, cancelUpdate := context.WithTimeout(context.Background(), reposIntegrationTimeout)
+			defer cancelUpdate()
+			updateArguments := append([]string{
+				reposIntegrationLogLevelFlag, reposIntegrationErrorLevel,
+				reposIntegrationRepoNamespaceCommand, reposIntegrationRemoteNamespaceCommand, reposIntegrationUpdateCanonicalAction,
+				reposIntegrationRootFlag, rootsStdinValue,
+			}, testCase.updateArguments...)
+			updateCommand := exec.CommandContext(updateContext, binaryPath, updateArguments...)
+			updateCommand.Env = commandEnvironment
+			updateCommand.Stdin = strings.NewReader(rootsStdinCommentLine + string(listOutput))
+			var updateOutput bytes.Buffer
+			updateCommand.Stdout = &updateOutput
+			updateCommand.Stderr = &updateOutput
+
+			require.NoError(subtest, updateCommand.Run(), updateOutput.String())
+			require.Contains(subtest, updateOutput.String(), testCase.expectedOutput(resolvedRepositoryPath))
+
+			remoteCommand := exec.Command(reposIntegrationGitExecutable, "-C", resolvedRepositoryPath, reposIntegrationRemoteSubcommand, reposIntegrationGetURLSubcommand, reposIntegrationOriginRemoteName)
+			remoteCommand.Env = buildGitCommandEnvironment(nil)
+			remoteOutput, remoteError := remoteCommand.CombinedOutput()
+			require.NoError(subtest, remoteError, string(remoteOutput))
+			require.Equal(subtest, testCase.expectedOriginURL, string(remoteOutput))
+		})
+	}
+}
@@ -54,7 +54,7 @@ const (
 	workflowIntegrationRemoteSkipExpectedTemplate = "UPDATE-REMOTE-SKIP: %s (already canonical)\n"
 	workflowIntegrationDefaultExpectedTemplate    = "WORKFLOW-DEFAULT: %s (main → master) safe_to_delete=true\n"
 	workflowIntegrationAuditExpectedTemplate      = "WORKFLOW-AUDIT: wrote report to %s\n"
-	workflowIntegrationCSVHeader                  = "folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical\n"
+	workflowIntegrationCSVHeader                  = "folder_name,final_github_repo,name_matches,remote_default_branch,local_branch,in_sync,remote_protocol,origin_matches_canonical,last_activity,delete_branch_on_merge,merge_queue,health_score\n"
 	workflowIntegrationSubtestNameTemplate        = "%d_%s"
 	workflowIntegrationDefaultCaseName            = "protocol_default_audit"
 	workflowIntegrationConfigFlagCaseName         = "config_flag_without_positional"
@@ -62,6 +62,26 @@ const (
 	workflowIntegrationHelpCaseName               = "workflow_help_missing_configuration"
 	workflowIntegrationUsageSnippet               = "workflow <configuration>"
 	workflowIntegrationMissingConfigMessage       = "workflow configuration path required; provide a positional argument or --config flag"
+	workflowIntegrationStagedCommitConfig         = `workflow:
+  - step:
+      operation: apply-tasks
+      commit: stage
+      with:
+        tasks:
+          - name: Add license
+            files: [{path: LICENSE, content: "license"}]
+  - step:
+      operation: apply-tasks
+      commit: stage
+      with:
+        tasks:
+          - name: Add notice
+            files: [{path: NOTICE, content: "notice"}]
+  - step:
+      operation: commit
+      with:
+        message: "chore: maintenance for {{ .Repository.Name }}\n\n{{ range .Steps }}- {{ . }}\n{{ end }}"
+`
 )
 
 func TestWorkflowRunIntegration(testInstance *testing.T) {
@@ -398,6 +418,10 @@ if [ "$1" = "api" ] && [ "$2" = "repos/canonical/example/branches/main/protectio
   echo 'gh: Not Found (HTTP 404)' >&2
   exit 1
 fi
+if [ "$1" = "api" ] && [ "$2" = "graphql" ]; then
+  echo '{"data":{"repository":{"branchProtectionRules":{"nodes":[]}}}}'
+  exit 0
+fi
 exit 0
 `
 	return fmt.Sprintf(template, stateFilePath, workflowIntegrationRepoViewJSONTemplate)
@@ -438,3 +462,50 @@ func verifyWorkflowRepositoryState(testInstance *testing.T, repositoryPath strin
 	require.Equal(testInstance, "ssh", records[1][6])
 	require.Equal(testInstance, "yes", records[1][7])
 }
+
+func TestWorkflowRunCommitsStagedTasksTogether(testInstance *testing.T) {
+	workingDirectory, workingDirectoryError := os.Getwd()
+	require.NoError(testInstance, workingDirectoryError)
+	repositoryRoot := filepath.Dir(workingDirectory)
+
+	tempDirectory := testInstance.TempDir()
+	repositoryPath := filepath.Join(tempDirectory, "legacy")
+	initializeWorkflowRepository(testInstance, repositoryPath)
+
+	stateFilePath := filepath.Join(tempDirectory, workflowIntegrationStateFileName)
+	require.NoError(testInstance, os.WriteFile(stateFilePath, []byte("master\n"), 0o644))
+	stubDirectory := filepath.Join(tempDirectory, "bin")
+	require.NoError(testInstance, os.Mkdir(stubDirectory, 0o755))
+	stubPath := filepath.Join(stubDirectory, workflowIntegrationStubExecutable)
+	require.NoError(testInstance, os.WriteFile(stubPath, []byte(buildWorkflowStubScript(stateFilePath)), 0o755))
+
+	configPath := filepath.Join(tempDirectory, workflowIntegrationConfigFileName)
+	require.NoError(testInstance, os.WriteFile(configPath, []byte(workflowIntegrationStagedCommitConfig), 0o644))
+
+	commandArguments := []string{
+		workflowIntegrationRunSubcommand,
+		workflowIntegrationModulePathConstant,
+		workflowIntegrationLogLevelFlag,
+		workflowIntegrationErrorLevel,
+		workflowIntegrationCommand,
+		configPath,
+		workflowIntegrationRootsFlag,
+		tempDirectory,
+		workflowIntegrationYesFlag,
+	}
+	commandOptions := integrationCommandOptions{PathVariable: stubDirectory + string(os.PathListSeparator) + os.Getenv("PATH")}
+	rawOutput := runIntegrationCommand(testInstance, repositoryRoot, commandOptions, workflowIntegrationTimeout, commandArguments)
+	require.Contains(testInstance, filterStructuredOutput(rawOutput), "COMMIT: "+repositoryPath+" steps=Add license,Add notice\n")
+
+	logCommand := exec.Command(workflowIntegrationGitExecutable, "-C", repositoryPath, "log", "-1", "--pretty=%B", "--name-only")
+	logCommand.Env = buildGitCommandEnvironment(nil)
+	logOutput, logError := logCommand.CombinedOutput()
+	require.NoError(testInstance, logError, string(logOutput))
+	require.Equal(testInstance, "chore: maintenance for example\n\n- Add license\n- Add notice\n\n\nLICENSE\nNOTICE\n", string(logOutput))
+
+	countCommand := exec.Command(workflowIntegrationGitExecutable, "-C", repositoryPath, "rev-list", "--count", "HEAD")
+	countCommand.Env = buildGitCommandEnvironment(nil)
+	countOutput, countError := countCommand.CombinedOutput()
+	require.NoError(testInstance, countError, string(countOutput))
+	require.Equal(testInstance, "3\n", string(countOutput))
+}